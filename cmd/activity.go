@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	activityLimit  int
+	activityTypes  []string
+	activityBead   string
+	activityAgent  string
+	activitySince  time.Duration
+	activityJSON   bool
+	activityFollow bool
+)
+
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Show the mob-wide activity feed",
+	Long: `Show the timeline of everything happening across the mob: beads created,
+status changes, assignments and comments, agents spawned, stopped or stuck,
+merges, reports and daemon errors.
+
+Every mob process appends to the same feed, so this is the one place to see
+what happened and in what order.
+
+Example:
+  mob activity
+  mob activity -n 100 --type merge_landed --type merge_failed
+  mob activity --bead bd-a1b2
+  mob activity --since 1h --json
+  mob activity -f`,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		feed, err := storage.NewActivityStore(storage.ActivityDir(mobDir))
+		if err != nil {
+			fail(err)
+		}
+
+		filter := storage.ActivityFilter{
+			BeadID: activityBead,
+			Agent:  activityAgent,
+			Limit:  activityLimit,
+		}
+		for _, t := range activityTypes {
+			filter.Types = append(filter.Types, models.ActivityType(t))
+		}
+		if activitySince > 0 {
+			filter.Since = time.Now().Add(-activitySince)
+		}
+
+		entries, err := feed.List(filter)
+		if err != nil {
+			fail(err)
+		}
+		printActivity(entries)
+		if !activityFollow {
+			return
+		}
+
+		// Follow: poll for entries newer than the last one shown
+		filter.Limit = 0
+		for {
+			if len(entries) > 0 {
+				filter.Since = entries[len(entries)-1].Timestamp
+			} else if filter.Since.IsZero() {
+				filter.Since = time.Now()
+			}
+			time.Sleep(2 * time.Second)
+			if entries, err = feed.List(filter); err != nil {
+				fail(err)
+			}
+			printActivity(entries)
+		}
+	},
+}
+
+func printActivity(entries []*models.Activity) {
+	if activityJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, a := range entries {
+			enc.Encode(a)
+		}
+		return
+	}
+	if len(entries) == 0 && !activityFollow {
+		fmt.Println(mutedStyle.Render("No activity recorded yet."))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, a := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\n",
+			mutedStyle.Render(a.Timestamp.Format("Jan 2 15:04:05")),
+			formatActivityType(a.Type),
+			valueStyle.Render(a.Message))
+	}
+	w.Flush()
+}
+
+func formatActivityType(t models.ActivityType) string {
+	label := strings.ReplaceAll(string(t), "_", " ")
+	switch t {
+	case models.ActivityError, models.ActivityMergeFailed, models.ActivityAgentStuck, models.ActivitySpawnExpired, models.ActivityModelDegraded:
+		return errorStyle.Render(label)
+	case models.ActivityMergeLanded, models.ActivityAgentSpawned, models.ActivityModelRecovered:
+		return successStyle.Render(label)
+	default:
+		return labelStyle.Render(label)
+	}
+}
+
+// trackActivity mirrors a bead store's changes to the activity feed. The
+// feed is best effort, so a store that cannot be opened is skipped.
+func trackActivity(store *storage.BeadStore) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return
+	}
+	if feed, err := storage.NewActivityStore(storage.ActivityDir(mobDir)); err == nil {
+		store.SetActivity(feed)
+	}
+}
+
+func init() {
+	activityCmd.Flags().IntVarP(&activityLimit, "limit", "n", 20, "Number of most recent entries to show (0 = all)")
+	activityCmd.Flags().StringArrayVar(&activityTypes, "type", nil, "Only show entries of this type (repeatable)")
+	activityCmd.Flags().StringVar(&activityBead, "bead", "", "Only show entries about this bead")
+	activityCmd.Flags().StringVar(&activityAgent, "agent", "", "Only show entries by or about this agent")
+	activityCmd.Flags().DurationVar(&activitySince, "since", 0, "Only show entries from this long ago (e.g. 1h)")
+	activityCmd.Flags().BoolVar(&activityJSON, "json", false, "Output one JSON object per line")
+	activityCmd.Flags().BoolVarP(&activityFollow, "follow", "f", false, "Keep printing new entries as they arrive")
+
+	rootCmd.AddCommand(activityCmd)
+}
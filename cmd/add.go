@@ -2,66 +2,106 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/gabe/mob/internal/errkind"
 	"github.com/gabe/mob/internal/models"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 var addCmd = &cobra.Command{
-	Use:     "add <description>",
-	Short:   "Create a new bead (task)",
-	Long:    `Create a new bead with the given description. The bead will be added to the open queue.`,
-	Aliases: []string{"a"},
-	Args:    cobra.MinimumNArgs(1),
+	Use:   "add <description>",
+	Short: "Create a new bead (task)",
+	Long: `Create a new bead with the given description. The bead will be added to the open queue.
+
+With --template, the bead is built from ~/mob/templates/<name>.toml instead:
+a parent bead plus the children the template lists. --var fills in the
+template's {{.name}} placeholders; the description, when given, is the
+title for templates without a title pattern.
+
+Example:
+  mob create --template release-checklist --var version=1.4 --turf api`,
+	Aliases: []string{"a", "create"},
 	Run: func(cmd *cobra.Command, args []string) {
 		description := strings.Join(args, " ")
+		templateName, _ := cmd.Flags().GetString("template")
+		if templateName != "" {
+			runAddTemplate(cmd, templateName, description)
+			return
+		}
+		if description == "" {
+			fail(errkind.New(errkind.Invalid, "give the bead a description, or use --template"))
+		}
 
 		priority, _ := cmd.Flags().GetInt("priority")
 		beadType, _ := cmd.Flags().GetString("type")
 		turfName, _ := cmd.Flags().GetString("turf")
 		labels, _ := cmd.Flags().GetString("labels")
+		checklist, _ := cmd.Flags().GetStringArray("check")
+		due, sla := dueFlags(cmd)
+		estimate, _ := cmd.Flags().GetString("estimate")
+		if _, err := models.ParseTimeEstimate(estimate); err != nil {
+			fail(errkind.New(errkind.Invalid, err.Error()))
+		}
 
 		beadsPath, err := getBeadsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 		store, err := storage.NewBeadStore(beadsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
+		trackActivity(store)
 
 		bead := &models.Bead{
-			Title:       description,
-			Description: description,
-			Status:      models.BeadStatusOpen,
-			Priority:    priority,
-			Type:        models.BeadType(beadType),
-			Turf:        turfName,
-			Labels:      labels,
+			Title:        description,
+			Description:  description,
+			Status:       models.BeadStatusOpen,
+			Priority:     priority,
+			Type:         models.BeadType(beadType),
+			Turf:         turfName,
+			Labels:       models.ParseLabels(labels),
+			DueAt:        due,
+			SLA:          sla,
+			TimeEstimate: estimate,
 		}
+		bead.AddChecklistItems(checklist...)
 
 		created, err := store.Create(bead)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		fmt.Printf("Created bead %s: %s\n", created.ID, created.Title)
 	},
 }
 
+// dueFlags parses --due and --sla, failing on values that do not parse
+func dueFlags(cmd *cobra.Command) (*time.Time, string) {
+	var due *time.Time
+	if s, _ := cmd.Flags().GetString("due"); s != "" {
+		t, err := models.ParseDue(s, time.Now())
+		if err != nil {
+			fail(errkind.New(errkind.Invalid, err.Error()))
+		}
+		due = &t
+	}
+	sla, _ := cmd.Flags().GetString("sla")
+	if _, err := models.ParseSLA(sla); err != nil {
+		fail(errkind.New(errkind.Invalid, err.Error()))
+	}
+	return due, sla
+}
+
 func getBeadsPath() (string, error) {
-	home, err := os.UserHomeDir()
+	mobDir, err := getMobDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, "mob", ".mob", "beads"), nil
+	return storage.BeadsDir(mobDir), nil
 }
 
 func init() {
@@ -69,6 +109,12 @@ func init() {
 	addCmd.Flags().StringP("type", "t", "task", "Type (bug, feature, task, chore)")
 	addCmd.Flags().String("turf", "", "Target turf")
 	addCmd.Flags().StringP("labels", "l", "", "Comma-separated labels")
+	addCmd.Flags().StringArray("check", nil, "Acceptance criterion to add to the checklist (repeatable)")
+	addCmd.Flags().String("due", "", "Due date: 2006-01-02, RFC 3339, or a period from now like 2d")
+	addCmd.Flags().String("sla", "", "How long after creation it must be closed, e.g. 4h or 3d")
+	addCmd.Flags().String("estimate", "", "How long the work should take, e.g. 90m, 4h or 2d")
+	addCmd.Flags().String("template", "", "Create the bead and its children from ~/mob/templates/<name>.toml")
+	addCmd.Flags().StringArray("var", nil, "Template variable as name=value (repeatable)")
 
 	rootCmd.AddCommand(addCmd)
 }
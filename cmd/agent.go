@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/soldati"
+	"github.com/spf13/cobra"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Control running agent sessions",
+}
+
+var agentReloadCmd = &cobra.Command{
+	Use:   "reload <name>",
+	Short: "Restart a soldati's session with its current definition",
+	Long: `Restart a soldati's session so edits to soldati/<name>.toml (role, model,
+turfs) take effect. A soldati busy with work finishes it first.
+
+The daemon also notices edited definitions on patrol and restarts idle
+soldati by itself; use this to apply a change right away.
+
+Example:
+  mob agent reload vinnie`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		dir, err := getSoldatiDir()
+		if err != nil {
+			fail(err)
+		}
+		mgr, err := soldati.NewManager(dir)
+		if err != nil {
+			fail(err)
+		}
+		if _, err := mgr.Get(name); err != nil {
+			fail(err)
+		}
+
+		hookMgr, err := hook.NewManager(getHookDir(), name)
+		if err != nil {
+			fail(err)
+		}
+		if err := hookMgr.Write(&hook.Hook{Type: hook.HookTypeReload, Timestamp: time.Now()}); err != nil {
+			fail(err)
+		}
+
+		fmt.Println(successStyle.Render(fmt.Sprintf("✓ Reload requested for %s", name)))
+		fmt.Println(mutedStyle.Render("The daemon restarts its session once any current work finishes."))
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(agentReloadCmd)
+	rootCmd.AddCommand(agentCmd)
+}
@@ -2,17 +2,22 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/mcp"
 	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
 	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
 	"github.com/spf13/cobra"
 )
 
 var approveCmd = &cobra.Command{
-	Use:     "approve <bead-id>",
-	Short:   "Approve a pending bead",
-	Long:    `Approve a bead that is in pending_approval status, allowing work to proceed.`,
+	Use:   "approve <bead-id>",
+	Short: "Approve a pending bead or a bead in review",
+	Long: `Approve a bead that is in pending_approval status, allowing work to proceed.
+
+For a bead in review, approval closes the bead and merges its branch.`,
 	Aliases: []string{"app"},
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -20,40 +25,67 @@ var approveCmd = &cobra.Command{
 
 		beadsPath, err := getBeadsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 		store, err := storage.NewBeadStore(beadsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
+		trackActivity(store)
 
 		// Get the bead
 		bead, err := store.Get(beadID)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
-		// Check if it's in pending_approval status
-		if bead.Status != models.BeadStatusPendingApproval {
-			fmt.Fprintf(os.Stderr, "Error: Bead %s is not pending approval (current status: %s)\n", beadID, bead.Status)
-			os.Exit(1)
+		inReview := bead.Status == models.BeadStatusInReview
+		result, err := approveBead(store, bead)
+		if err != nil {
+			fail(err)
+		}
+		if inReview {
+			fmt.Printf("✓ Approved review of bead %s: %s\n", bead.ID, bead.Title)
+		} else {
+			fmt.Printf("✓ Approved bead %s: %s\n", bead.ID, bead.Title)
 		}
+		fmt.Printf("  %s\n", result)
+	},
+}
 
-		// Update status to open so it can be picked up
-		bead.Status = models.BeadStatusOpen
+// approveBead lets a pending bead proceed, or approves a bead in review
+// through the review gate, which merges and closes it. It describes what
+// happened.
+func approveBead(store *storage.BeadStore, bead *models.Bead) (string, error) {
+	if bead.Status == models.BeadStatusInReview {
+		return mcp.ResolveReview(reviewToolContext(store), bead.ID, true, "human", "")
+	}
+	if bead.Status != models.BeadStatusPendingApproval {
+		return "", errkind.New(errkind.Conflict, fmt.Sprintf("Bead %s is not pending approval (current status: %s)", bead.ID, bead.Status))
+	}
 
-		_, err = store.Update(bead)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error updating bead: %v\n", err)
-			os.Exit(1)
-		}
+	// Open it so it can be picked up
+	bead.Status = models.BeadStatusOpen
+	if _, err := store.Update(bead); err != nil {
+		return "", fmt.Errorf("updating bead: %w", err)
+	}
+	return "Status changed from pending_approval → open", nil
+}
 
-		fmt.Printf("✓ Approved bead %s: %s\n", bead.ID, bead.Title)
-		fmt.Printf("  Status changed from pending_approval → open\n")
-	},
+// reviewToolContext builds the tool context used to resolve reviews from the CLI
+func reviewToolContext(store *storage.BeadStore) *mcp.ToolContext {
+	mobDir, _ := getMobDir()
+	ctx := &mcp.ToolContext{
+		Registry:  registry.New(getRegistryPath()),
+		BeadStore: store,
+		MobDir:    mobDir,
+	}
+	if turfsPath, err := getTurfsPath(); err == nil {
+		if mgr, err := turf.NewManager(turfsPath); err == nil {
+			ctx.TurfManager = mgr
+		}
+	}
+	return ctx
 }
 
 func init() {
@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gabe/mob/internal/abort"
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/router"
+	"github.com/gabe/mob/internal/soldati"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+	"github.com/spf13/cobra"
+)
+
+var beadCmd = &cobra.Command{
+	Use:   "bead",
+	Short: "Work with individual beads",
+	Long:  `Commands that operate on a single bead and the agent working on it.`,
+}
+
+var beadChatMessage string
+
+var beadChatCmd = &cobra.Command{
+	Use:   "chat <bead-id>",
+	Short: "Talk to the agent assigned to a bead",
+	Long: `Open a focused conversation with the agent assigned to a bead, resuming its session.
+
+Use this to answer a question the agent asked or to redirect it mid-task.
+Every exchange is appended to the bead's history. Chat is refused while the
+agent's call is still running; wait for it to finish or abort the bead first.
+
+Example:
+  mob bead chat bd-a1b2
+  mob bead chat bd-a1b2 -m "Use the existing retry helper instead"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		trackActivity(store)
+
+		bead, err := store.Get(args[0])
+		if err != nil {
+			fail(err)
+		}
+
+		if bead.Assignee == "" {
+			fmt.Fprintf(os.Stderr, "Error: bead %s has no assigned agent\n", bead.ID)
+			os.Exit(1)
+		}
+
+		reg := registry.New(getRegistryPath())
+		record, err := reg.GetByName(bead.Assignee)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: agent '%s' is not currently running\n", bead.Assignee)
+			os.Exit(1)
+		}
+		if err := checkBeadChatIdle(bead, record); err != nil {
+			fail(err)
+		}
+
+		a, err := resumeBeadAgent(bead, record)
+		if err != nil {
+			fail(err)
+		}
+
+		if record.SessionID == "" {
+			fmt.Println(mutedStyle.Render("No session recorded for this agent; starting a new conversation."))
+		}
+
+		if beadChatMessage != "" {
+			if err := beadChatExchange(store, bead, a, beadChatMessage); err != nil {
+				fail(err)
+			}
+			saveBeadChatSession(reg, record, a)
+			return
+		}
+
+		fmt.Printf("%s %s with %s\n", headerStyle.Render("Bead"), valueStyle.Render(bead.ID), labelStyle.Render(a.Name))
+		fmt.Println(mutedStyle.Render("Type /exit or press Ctrl+D to leave."))
+
+		scanner := bufio.NewScanner(os.Stdin)
+		for {
+			fmt.Print("you> ")
+			if !scanner.Scan() {
+				fmt.Println()
+				break
+			}
+
+			message := strings.TrimSpace(scanner.Text())
+			if message == "" {
+				continue
+			}
+			if message == "/exit" || message == "/quit" {
+				break
+			}
+
+			if err := beadChatExchange(store, bead, a, message); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+
+		saveBeadChatSession(reg, record, a)
+	},
+}
+
+var beadChecklistCmd = &cobra.Command{
+	Use:   "checklist <bead-id> [add <item>... | check <n>... | uncheck <n>... | remove <n>...]",
+	Short: "View or edit a bead's acceptance criteria",
+	Long: `View or edit the checklist of acceptance criteria on a bead.
+
+Items are numbered from 1. complete_bead refuses to close a bead until every
+item is checked, unless the agent gives an explicit override reason.
+
+Example:
+  mob bead checklist bd-a1b2
+  mob bead checklist bd-a1b2 add "Tests cover the retry path" "README updated"
+  mob bead checklist bd-a1b2 check 1 2
+  mob bead checklist bd-a1b2 remove 3`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		trackActivity(store)
+
+		bead, err := store.Get(args[0])
+		if err != nil {
+			fail(err)
+		}
+
+		if len(args) > 1 {
+			if err := editChecklist(bead, args[1], args[2:]); err != nil {
+				fail(err)
+			}
+			if _, err := store.Update(bead); err != nil {
+				fail(err)
+			}
+		}
+
+		done := len(bead.Checklist) - len(bead.UncheckedItems())
+		fmt.Printf("%s %s (%d/%d done)\n", headerStyle.Render("Checklist"), valueStyle.Render(bead.ID), done, len(bead.Checklist))
+		fmt.Println(bead.FormatChecklist())
+	},
+}
+
+var (
+	beadAbortReason string
+	beadAbortBlock  bool
+)
+
+var beadAbortCmd = &cobra.Command{
+	Use:   "abort <bead-id>",
+	Short: "Cancel the in-flight work on a bead",
+	Long: `Stop the agent working on a bead and reset the bead.
+
+A soldati's current call is cancelled through its hook (the daemon kills the
+claude process and drops any pending assignment). An associate's claude
+process is killed directly. The bead returns to open, or to blocked with
+--block, and the reason is recorded in its history.
+
+Example:
+  mob bead abort bd-a1b2
+  mob bead abort bd-a1b2 --block -r "Waiting on the API redesign"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		trackActivity(store)
+
+		result, err := abort.Bead(store, registry.New(getRegistryPath()), getHookDir(), args[0], abort.Options{
+			Reason: beadAbortReason,
+			Block:  beadAbortBlock,
+			Actor:  "human",
+		})
+		if err != nil {
+			fail(err)
+		}
+
+		switch {
+		case result.Signalled:
+			fmt.Printf("Sent abort to soldati '%s'\n", result.Agent)
+			fmt.Println(mutedStyle.Render("(The daemon cancels the work when it picks up the hook)"))
+		case result.Killed:
+			fmt.Printf("Killed in-flight work of %s\n", result.Agent)
+		case result.Agent == "":
+			fmt.Println(mutedStyle.Render("No agent was working on this bead."))
+		default:
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("%s had no work in flight.", result.Agent)))
+		}
+		fmt.Printf("Bead %s is now %s\n", valueStyle.Render(args[0]), formatBeadStatus(result.Status))
+	},
+}
+
+// editChecklist applies a checklist subcommand to a bead
+func editChecklist(bead *models.Bead, action string, params []string) error {
+	if len(params) == 0 {
+		return fmt.Errorf("%s needs at least one argument", action)
+	}
+
+	if action == "add" {
+		bead.AddChecklistItems(params...)
+		return nil
+	}
+
+	positions := make([]int, 0, len(params))
+	for _, p := range params {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return fmt.Errorf("invalid item number %q", p)
+		}
+		positions = append(positions, n)
+	}
+
+	switch action {
+	case "check":
+		return bead.SetChecklistDone(positions, true)
+	case "uncheck":
+		return bead.SetChecklistDone(positions, false)
+	case "remove":
+		return bead.RemoveChecklistItems(positions)
+	default:
+		return fmt.Errorf("unknown action %q (expected add, check, uncheck, or remove)", action)
+	}
+}
+
+// resumeBeadAgent creates a local handle on the agent assigned to a bead,
+// pointed at the bead's worktree and resuming the agent's recorded session
+func resumeBeadAgent(bead *models.Bead, record *registry.AgentRecord) (*agent.Agent, error) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return nil, err
+	}
+
+	workDir := bead.WorktreePath
+	if workDir == "" {
+		workDir = beadTurfPath(bead.Turf, mobDir)
+	}
+
+	mcpConfigPath, err := mcp.GenerateTurfMCPConfig(mobDir, record.Turf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to generate MCP config: %v\n", err)
+	}
+
+	agentType := agent.AgentType(record.Type)
+	promptName := agent.PromptSoldati
+	if agentType == agent.AgentTypeAssociate {
+		promptName = agent.PromptAssociate
+	}
+	vars := agent.PromptVars{Name: record.Name, Turf: bead.Turf, BeadID: bead.ID}
+	if bead.Turf != "" {
+		vars.Conventions, _ = turf.LoadConventions(beadTurfPath(bead.Turf, mobDir))
+	}
+	systemPrompt, err := agent.SystemPrompt(mobDir, promptName, vars)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	a, err := newSpawner(mobDir).SpawnWithOptions(agent.SpawnOptions{
+		Type:         agentType,
+		Name:         record.Name,
+		Turf:         record.Turf,
+		WorkDir:      workDir,
+		SystemPrompt: systemPrompt,
+		MCPConfig:    mcpConfigPath,
+		Model:        beadChatModel(mobDir, bead, record),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent: %w", err)
+	}
+	a.SessionID = record.SessionID
+
+	return a, nil
+}
+
+// checkBeadChatIdle refuses to chat with an agent while its call on the bead
+// is in flight: resuming its session then would fork the conversation
+func checkBeadChatIdle(bead *models.Bead, record *registry.AgentRecord) error {
+	switch record.Status {
+	case registry.StatusActive, registry.StatusWorking, registry.StatusNudged, registry.StatusStuck:
+		return errkind.New(errkind.Conflict, fmt.Sprintf("%s is working on bead %s right now; wait for it to finish, or stop it with `mob bead abort %s`", record.Name, bead.ID, bead.ID))
+	}
+	return nil
+}
+
+// beadChatModel returns the model the agent works on the bead with: its
+// soldati definition's model, else the one the router gave the bead, else
+// the configured default model
+func beadChatModel(mobDir string, bead *models.Bead, record *registry.AgentRecord) string {
+	if record.Type == string(agent.AgentTypeSoldati) {
+		if mgr, err := soldati.NewManager(filepath.Join(mobDir, "soldati")); err == nil {
+			if def, err := mgr.Get(record.Name); err == nil && def.Model != "" {
+				return def.Model
+			}
+		}
+	}
+	if bead.Model != "" {
+		return bead.Model
+	}
+	if cfg, err := config.Load(filepath.Join(mobDir, "config.toml")); err == nil && cfg.Routing.DefaultModel != "" {
+		return cfg.Routing.DefaultModel
+	}
+	return router.FallbackDefaultModel
+}
+
+// beadTurfPath resolves a bead's turf to a directory, falling back to the mob directory
+func beadTurfPath(turfName, mobDir string) string {
+	if turfName == "" {
+		return mobDir
+	}
+
+	turfsPath, err := getTurfsPath()
+	if err != nil {
+		return mobDir
+	}
+	mgr, err := turf.NewManager(turfsPath)
+	if err != nil {
+		return mobDir
+	}
+	t, err := mgr.Get(turfName)
+	if err != nil {
+		return mobDir
+	}
+	return t.Path
+}
+
+// beadChatExchange sends one human message to the agent and records both
+// sides of the exchange in the bead's history
+func beadChatExchange(store *storage.BeadStore, bead *models.Bead, a *agent.Agent, message string) error {
+	if err := store.AddComment(bead.ID, "human", message); err != nil {
+		return fmt.Errorf("failed to record message: %w", err)
+	}
+
+	resp, err := a.Chat(formatBeadChatMessage(bead, message))
+	if err != nil {
+		return fmt.Errorf("agent did not respond: %w", err)
+	}
+
+	reply := loadRedactor().String(strings.TrimSpace(resp.GetText()))
+	fmt.Printf("%s> %s\n", a.Name, reply)
+
+	if reply != "" {
+		if err := store.AddComment(bead.ID, a.Name, reply); err != nil {
+			return fmt.Errorf("failed to record reply: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// formatBeadChatMessage wraps a human message with the bead it concerns
+func formatBeadChatMessage(bead *models.Bead, message string) string {
+	return fmt.Sprintf("[Message from the human about bead %s: %s]\n\n%s", bead.ID, bead.Title, message)
+}
+
+// saveBeadChatSession persists a newly started session so later chats and
+// the daemon continue the same conversation
+func saveBeadChatSession(reg *registry.Registry, record *registry.AgentRecord, a *agent.Agent) {
+	if a.SessionID == "" || a.SessionID == record.SessionID {
+		return
+	}
+	if err := reg.UpdateSession(record.ID, a.SessionID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save session: %v\n", err)
+	}
+}
+
+func init() {
+	beadChatCmd.Flags().StringVarP(&beadChatMessage, "message", "m", "", "Send a single message instead of starting an interactive session")
+
+	beadAbortCmd.Flags().StringVarP(&beadAbortReason, "reason", "r", "", "Why the work is being called off")
+	beadAbortCmd.Flags().BoolVar(&beadAbortBlock, "block", false, "Mark the bead blocked instead of returning it to open")
+
+	beadCmd.AddCommand(beadChatCmd)
+	beadCmd.AddCommand(beadAbortCmd)
+	beadCmd.AddCommand(beadChecklistCmd)
+	rootCmd.AddCommand(beadCmd)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var beadDueCmd = &cobra.Command{
+	Use:   "due <bead-id> [when|none]",
+	Short: "Set or clear a bead's due date and SLA",
+	Long: `Set when a bead has to be closed by. The due date is a date (due by the
+end of that day), an RFC 3339 time, or a period from now such as 36h or 2d;
+"none" clears it. --sla sets how long after creation the bead must be closed
+instead, and --sla none clears that.
+
+A bead still open past the earlier of the two is overdue: on its next patrol
+the daemon raises its priority one level, comments on it and notifies. A new
+due date or SLA lets a bead that was already escalated be escalated again.
+With no date and no --sla, shows the bead's deadline.
+
+Example:
+  mob bead due bd-a1b2 2026-11-01
+  mob bead due bd-a1b2 --sla 4h`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		bead, err := store.Get(args[0])
+		if err != nil {
+			fail(err)
+		}
+
+		changed := false
+		if len(args) == 2 {
+			bead.DueAt = nil
+			if args[1] != "none" {
+				due, err := models.ParseDue(args[1], time.Now())
+				if err != nil {
+					fail(errkind.New(errkind.Invalid, err.Error()))
+				}
+				bead.DueAt = &due
+			}
+			changed = true
+		}
+		if cmd.Flags().Changed("sla") {
+			sla, _ := cmd.Flags().GetString("sla")
+			if sla == "none" {
+				sla = ""
+			}
+			if _, err := models.ParseSLA(sla); err != nil {
+				fail(errkind.New(errkind.Invalid, err.Error()))
+			}
+			bead.SLA = sla
+			changed = true
+		}
+
+		if changed {
+			bead.SLABreachedAt = nil
+			if bead, err = store.Update(bead); err != nil {
+				fail(err)
+			}
+		}
+
+		deadline, ok := bead.Deadline()
+		switch {
+		case !ok:
+			fmt.Printf("%s %s has no due date\n", successStyle.Render("✓"), bead.ID)
+		case bead.Overdue(time.Now()):
+			fmt.Printf("%s %s was due %s\n", errorStyle.Render("!"), bead.ID, formatDeadline(deadline))
+		default:
+			fmt.Printf("%s %s is due %s\n", successStyle.Render("✓"), bead.ID, formatDeadline(deadline))
+		}
+	},
+}
+
+// formatDeadline shows a deadline with how far off it is, or how late
+func formatDeadline(t time.Time) string {
+	left := time.Until(t)
+	rel := "in " + roughDuration(left)
+	if left < 0 {
+		rel = roughDuration(-left) + " late"
+	}
+	return fmt.Sprintf("%s %s", t.Format("Mon Jan 2 15:04"), mutedStyle.Render("("+rel+")"))
+}
+
+// roughDuration renders d in its largest sensible unit: minutes, hours or days
+func roughDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+func init() {
+	beadDueCmd.Flags().String("sla", "", "How long after creation it must be closed, e.g. 4h or 3d; none clears it")
+	beadCmd.AddCommand(beadDueCmd)
+}
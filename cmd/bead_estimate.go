@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var beadEstimateCmd = &cobra.Command{
+	Use:   "estimate <bead-id> [time|none]",
+	Short: "Set or clear how long a bead's work should take",
+	Long: `Set a bead's time estimate, such as 90m, 4h or 2d; "none" clears it. With
+no time, shows the estimate next to how long the work has taken so far.
+
+The time an agent actually spends is recorded on its own: from the first
+time the bead goes in progress to the last time its work is handed in.
+mob report velocity compares the two.
+
+Example:
+  mob bead estimate bd-a1b2 4h`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		bead, err := store.Get(args[0])
+		if err != nil {
+			fail(err)
+		}
+
+		if len(args) == 2 {
+			estimate := args[1]
+			if estimate == "none" {
+				estimate = ""
+			}
+			if _, err := models.ParseTimeEstimate(estimate); err != nil {
+				fail(errkind.New(errkind.Invalid, err.Error()))
+			}
+			bead.TimeEstimate = estimate
+			if bead, err = store.Update(bead); err != nil {
+				fail(err)
+			}
+		}
+
+		estimate := "no estimate"
+		if bead.TimeEstimate != "" {
+			estimate = "estimated at " + bead.TimeEstimate
+		}
+		fmt.Printf("%s %s %s%s\n", successStyle.Render("✓"), bead.ID, estimate, mutedStyle.Render(workedSummary(bead)))
+	},
+}
+
+// workedSummary describes how long the work on a bead has taken, with a
+// leading separator, or nothing if it has not started
+func workedSummary(b *models.Bead) string {
+	if took, ok := b.ActualTime(); ok {
+		return "; took " + roughDuration(took)
+	}
+	if b.StartedAt != nil {
+		return fmt.Sprintf("; started %s", formatRelativeTime(*b.StartedAt))
+	}
+	return ""
+}
+
+func init() {
+	beadCmd.AddCommand(beadEstimateCmd)
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	beadSplitChildren []string
+	beadSplitAgent    bool
+	beadSplitYes      bool
+)
+
+var beadSplitCmd = &cobra.Command{
+	Use:   "split <bead-id>",
+	Short: "Break an oversized bead into smaller child beads",
+	Long: `Turn one bead into a parent epic with several child beads.
+
+Children inherit the parent's turf, priority and labels, and block the
+parent, so it only comes back up once they are all closed. Give the child
+titles with --child, type them in when prompted, or let an agent read the
+bead and propose a split with --agent.
+
+Example:
+  mob bead split bd-a1b2
+  mob bead split bd-a1b2 -c "Add the schema" -c "Backfill old rows" -c "Switch reads over"
+  mob bead split bd-a1b2 --agent`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		trackActivity(store)
+
+		bead, err := store.Get(args[0])
+		if err != nil {
+			fail(err)
+		}
+		if bead.Status == models.BeadStatusClosed {
+			fail(fmt.Errorf("%w: %s", storage.ErrBeadClosed, bead.ID))
+		}
+
+		reader := bufio.NewReader(os.Stdin)
+		var children []*models.Bead
+		switch {
+		case len(beadSplitChildren) > 0:
+			for _, title := range beadSplitChildren {
+				children = append(children, &models.Bead{Title: strings.TrimSpace(title)})
+			}
+		case beadSplitAgent:
+			fmt.Println(mutedStyle.Render("Asking an agent to propose a split..."))
+			children, err = proposeSplit(bead)
+			if err != nil {
+				fail(err)
+			}
+		default:
+			children = promptSplitChildren(reader, bead)
+		}
+
+		if len(children) < 2 {
+			fmt.Fprintln(os.Stderr, "Error: a split needs at least two children")
+			os.Exit(exitInvalid)
+		}
+
+		fmt.Printf("%s %s %s\n", headerStyle.Render("Split"), valueStyle.Render(bead.ID), bead.Title)
+		for i, child := range children {
+			fmt.Printf("  %d. %s\n", i+1, child.Title)
+			if child.Description != "" {
+				fmt.Printf("     %s\n", mutedStyle.Render(truncate(child.Description, 70)))
+			}
+		}
+
+		if !beadSplitYes && !confirm(reader, fmt.Sprintf("Create these %d beads?", len(children))) {
+			fmt.Println("Cancelled")
+			return
+		}
+
+		created, err := store.Split(bead.ID, children, "human")
+		if err != nil {
+			fail(err)
+		}
+
+		fmt.Printf("\n%s Split %s into %d beads\n", successStyle.Render("✓"), bead.ID, len(created))
+		for _, child := range created {
+			fmt.Printf("  %s  %s\n", labelStyle.Render(child.ID), child.Title)
+		}
+	},
+}
+
+var (
+	beadCloneTitle string
+	beadCloneTurf  string
+	beadCloneCount int
+)
+
+var beadCloneCmd = &cobra.Command{
+	Use:   "clone <bead-id>",
+	Short: "Create a fresh copy of a bead for recurring work",
+	Long: `Create a new open bead from an existing one.
+
+The copy keeps the description, type, priority, labels, turf and checklist
+(with every item unchecked) but starts with no assignee, dependencies or
+history. Useful for work that comes around again, like release prep or
+dependency bumps.
+
+Example:
+  mob bead clone bd-a1b2
+  mob bead clone bd-a1b2 --title "Bump dependencies (March)"
+  mob bead clone bd-a1b2 --turf api -n 3`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		trackActivity(store)
+
+		if beadCloneCount < 1 {
+			fmt.Fprintln(os.Stderr, "Error: --count must be at least 1")
+			os.Exit(exitInvalid)
+		}
+
+		for i := 0; i < beadCloneCount; i++ {
+			clone, err := store.Clone(args[0], storage.CloneOptions{
+				Title: beadCloneTitle,
+				Turf:  beadCloneTurf,
+				Actor: "human",
+			})
+			if err != nil {
+				fail(err)
+			}
+			fmt.Printf("%s Cloned %s as %s  %s\n", successStyle.Render("✓"), args[0], labelStyle.Render(clone.ID), clone.Title)
+		}
+	},
+}
+
+// promptSplitChildren reads child titles from the terminal until a blank line
+func promptSplitChildren(reader *bufio.Reader, bead *models.Bead) []*models.Bead {
+	fmt.Printf("Splitting %s: %s\n", valueStyle.Render(bead.ID), bead.Title)
+	fmt.Println(mutedStyle.Render("Enter one child title per line; leave a line blank to finish."))
+
+	var children []*models.Bead
+	for {
+		fmt.Printf("  %d. ", len(children)+1)
+		line, err := reader.ReadString('\n')
+		title := strings.TrimSpace(line)
+		if title == "" {
+			if err != nil {
+				fmt.Println()
+			}
+			return children
+		}
+		children = append(children, &models.Bead{Title: title})
+		if err != nil {
+			fmt.Println()
+			return children
+		}
+	}
+}
+
+// confirm asks a yes/no question, defaulting to no
+func confirm(reader *bufio.Reader, question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// splitPrompt asks an agent to break a bead into smaller pieces
+const splitPrompt = `Break the following task into 2-6 smaller tasks that can each be done and
+merged on their own, in the order they should be done. Look at the code in
+the working directory if it helps. Reply with only a JSON array, no prose:
+[{"title": "...", "description": "..."}]
+
+Task %s: %s
+
+%s`
+
+// proposeSplit asks a one-off agent in the bead's turf for child beads
+func proposeSplit(bead *models.Bead) ([]*models.Bead, error) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return nil, err
+	}
+
+	systemPrompt, err := agent.SystemPrompt(mobDir, agent.PromptAssociate, agent.PromptVars{Turf: bead.Turf, BeadID: bead.ID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	a, err := newSpawner(mobDir).SpawnWithOptions(agent.SpawnOptions{
+		Type:         agent.AgentTypeAssociate,
+		Turf:         bead.Turf,
+		WorkDir:      beadTurfPath(bead.Turf, mobDir),
+		SystemPrompt: systemPrompt,
+		Model:        "sonnet",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent: %w", err)
+	}
+
+	resp, err := a.Chat(fmt.Sprintf(splitPrompt, bead.ID, bead.Title, bead.Description))
+	if err != nil {
+		return nil, fmt.Errorf("agent did not respond: %w", err)
+	}
+	return parseSplitProposal(resp.GetText())
+}
+
+// parseSplitProposal reads the JSON array of child beads out of an agent reply
+func parseSplitProposal(text string) ([]*models.Bead, error) {
+	start := strings.Index(text, "[")
+	end := strings.LastIndex(text, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("agent reply had no JSON array: %s", truncate(text, 200))
+	}
+
+	var proposed []struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &proposed); err != nil {
+		return nil, fmt.Errorf("agent reply was not a valid split: %w", err)
+	}
+
+	var children []*models.Bead
+	for _, p := range proposed {
+		if title := strings.TrimSpace(p.Title); title != "" {
+			children = append(children, &models.Bead{Title: title, Description: strings.TrimSpace(p.Description)})
+		}
+	}
+	return children, nil
+}
+
+func init() {
+	beadSplitCmd.Flags().StringArrayVarP(&beadSplitChildren, "child", "c", nil, "Title of a child bead (repeatable)")
+	beadSplitCmd.Flags().BoolVar(&beadSplitAgent, "agent", false, "Have an agent read the bead and propose the children")
+	beadSplitCmd.Flags().BoolVarP(&beadSplitYes, "yes", "y", false, "Create the children without asking for confirmation")
+
+	beadCloneCmd.Flags().StringVarP(&beadCloneTitle, "title", "t", "", "Title for the copy (default: the source title)")
+	beadCloneCmd.Flags().StringVar(&beadCloneTurf, "turf", "", "Turf for the copy (default: the source turf)")
+	beadCloneCmd.Flags().IntVarP(&beadCloneCount, "count", "n", 1, "Number of copies to create")
+
+	beadCmd.AddCommand(beadSplitCmd)
+	beadCmd.AddCommand(beadCloneCmd)
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import "testing"
+
+func TestParseSplitProposal(t *testing.T) {
+	reply := "Here's a split:\n```json\n" +
+		`[{"title": "Add the schema", "description": "New table"}, {"title": "  "}, {"title": "Switch reads"}]` +
+		"\n```"
+
+	children, err := parseSplitProposal(reply)
+	if err != nil {
+		t.Fatalf("parseSplitProposal failed: %v", err)
+	}
+	if len(children) != 2 {
+		t.Fatalf("got %d children, want 2 (blank titles dropped)", len(children))
+	}
+	if children[0].Title != "Add the schema" || children[0].Description != "New table" || children[1].Title != "Switch reads" {
+		t.Errorf("children = %+v, %+v", children[0], children[1])
+	}
+
+	if _, err := parseSplitProposal("I can't split this."); err == nil {
+		t.Error("expected an error for a reply without JSON")
+	}
+}
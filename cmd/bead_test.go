@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/mockclaude"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/router"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+func TestCheckBeadChatIdle(t *testing.T) {
+	bead := &models.Bead{ID: "bd-a1b2"}
+	for _, status := range []registry.Status{registry.StatusActive, registry.StatusWorking, registry.StatusNudged, registry.StatusStuck} {
+		err := checkBeadChatIdle(bead, &registry.AgentRecord{Name: "vinnie", Status: status})
+		if !errors.Is(err, errkind.Conflict) {
+			t.Errorf("%s: err = %v, want chat refused mid-call", status, err)
+		}
+	}
+	for _, status := range []registry.Status{registry.StatusIdle, registry.StatusPaused, registry.StatusError, registry.StatusDead} {
+		if err := checkBeadChatIdle(bead, &registry.AgentRecord{Name: "vinnie", Status: status}); err != nil {
+			t.Errorf("%s: %v, want chat allowed", status, err)
+		}
+	}
+}
+
+func TestBeadChatModel(t *testing.T) {
+	mobDir := t.TempDir()
+	record := &registry.AgentRecord{Name: "vinnie", Type: "soldati"}
+	bead := &models.Bead{ID: "bd-a1b2"}
+
+	if got := beadChatModel(mobDir, bead, record); got != router.FallbackDefaultModel {
+		t.Errorf("unconfigured model = %q, want %q", got, router.FallbackDefaultModel)
+	}
+
+	config := "[routing]\ndefault_model = \"haiku\"\n"
+	if err := os.WriteFile(filepath.Join(mobDir, "config.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := beadChatModel(mobDir, bead, record); got != "haiku" {
+		t.Errorf("model = %q, want the configured default haiku", got)
+	}
+
+	bead.Model = "opus"
+	if got := beadChatModel(mobDir, bead, record); got != "opus" {
+		t.Errorf("model = %q, want opus, the model the bead was routed to", got)
+	}
+
+	mgr, err := soldati.NewManager(filepath.Join(mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := mgr.Create("vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	def.Model = "sonnet"
+	if err := mgr.Update(def); err != nil {
+		t.Fatal(err)
+	}
+	if got := beadChatModel(mobDir, bead, record); got != "sonnet" {
+		t.Errorf("model = %q, want sonnet from vinnie's definition", got)
+	}
+}
+
+func TestBeadChatResumesSessionThroughMockClaude(t *testing.T) {
+	_, store := e2eMob(t, mockclaude.Turn{
+		Match: `about bead (bd-\w+)`,
+		Text:  "Switching to the retry helper for $1",
+	})
+	bead, err := store.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusInProgress, Assignee: "vinnie", Model: "opus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := &registry.AgentRecord{ID: "a1", Name: "vinnie", Type: "soldati", Status: registry.StatusIdle, SessionID: "sess-vinnie"}
+
+	a, err := resumeBeadAgent(bead, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.SessionID != "sess-vinnie" || a.Model != "opus" {
+		t.Errorf("agent session %q on %q, want sess-vinnie on the bead's opus", a.SessionID, a.Model)
+	}
+	if err := beadChatExchange(store, bead, a, "Use the existing retry helper instead"); err != nil {
+		t.Fatal(err)
+	}
+	if a.SessionID != "sess-vinnie" {
+		t.Errorf("session = %q, want the conversation continued in sess-vinnie", a.SessionID)
+	}
+
+	got, err := store.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasE2EComment(got, "Use the existing retry helper instead") {
+		t.Errorf("expected the human's message recorded, got %+v", got.History)
+	}
+	if !hasE2EComment(got, "Switching to the retry helper for "+bead.ID) {
+		t.Errorf("expected the agent's reply recorded, got %+v", got.History)
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var beadWatchCmd = &cobra.Command{
+	Use:   "watch <bead-id> [name...]",
+	Short: "Get notified when a bead changes status or gets comments",
+	Long: `Add watchers to a bead. The daemon notifies each watcher, through the
+channels configured for them under [[notifications.humans]], whenever the
+bead changes status or someone comments on it. Anyone @-mentioned in a
+comment starts watching automatically.
+
+With no names, watches as the first configured human (or $USER).
+
+Example:
+  mob bead watch bd-a1b2
+  mob bead watch bd-a1b2 alice bob`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		store, names := watchArgs(args)
+		bead, err := store.Watch(args[0], names...)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %s watched by %s\n", successStyle.Render("✓"), bead.ID, listOrDash(bead.Watchers))
+	},
+}
+
+var beadUnwatchCmd = &cobra.Command{
+	Use:   "unwatch <bead-id> [name...]",
+	Short: "Stop watching a bead",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		store, names := watchArgs(args)
+		bead, err := store.Unwatch(args[0], names...)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s %s watched by %s\n", successStyle.Render("✓"), bead.ID, listOrDash(bead.Watchers))
+	},
+}
+
+// watchArgs opens the bead store and returns the names to (un)watch as
+func watchArgs(args []string) (*storage.BeadStore, []string) {
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		fail(err)
+	}
+	store, err := storage.NewBeadStore(beadsPath)
+	if err != nil {
+		fail(err)
+	}
+
+	names := args[1:]
+	if len(names) == 0 {
+		names = []string{defaultWatcher()}
+	}
+	return store, names
+}
+
+// defaultWatcher is the human running the command: the first one configured
+// for notifications, else the login name
+func defaultWatcher() string {
+	if mobDir, err := getMobDir(); err == nil {
+		if cfg, err := config.Load(filepath.Join(mobDir, "config.toml")); err == nil && len(cfg.Notifications.Humans) > 0 {
+			return cfg.Notifications.Humans[0].Name
+		}
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "human"
+}
+
+func init() {
+	beadCmd.AddCommand(beadWatchCmd)
+	beadCmd.AddCommand(beadUnwatchCmd)
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabe/mob/internal/backlog"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var beadsCmd = &cobra.Command{
+	Use:   "beads",
+	Short: "Check, export and import the bead store as a whole",
+}
+
+var beadsValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Find dependency cycles and links to missing beads",
+	Long: `Check every bead's blocks links. A cycle (A blocks B, B blocks A) leaves
+each bead in it waiting on another, so none of them ever becomes ready;
+those are errors. Links to beads that do not exist block nothing and are
+reported as warnings.
+
+Exits non-zero when a cycle is found, so it can run in scripts.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		beads, err := store.List(storage.BeadFilter{})
+		if err != nil {
+			fail(err)
+		}
+
+		cycles := storage.FindCycles(beads)
+		for _, cycle := range cycles {
+			fmt.Printf("%s cycle: %s blocks %s\n", errorStyle.Render("✗"), strings.Join(cycle, " blocks "), cycle[0])
+		}
+		for _, d := range storage.FindDanglingLinks(beads) {
+			fmt.Printf("%s %s blocks %s, which does not exist\n", warningStyle.Render("!"), d.BeadID, d.Missing)
+		}
+
+		if len(cycles) > 0 {
+			fail(errkind.New(errkind.Invalid, fmt.Sprintf("%d dependency cycle(s); remove one blocks link from each", len(cycles))))
+		}
+		fmt.Printf("%s %d bead(s), no dependency cycles\n", successStyle.Render("✓"), len(beads))
+	},
+}
+
+var beadsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write beads out as JSON or CSV",
+	Long: `Write beads to stdout, or to a file with -o, for moving a backlog to another
+mob instance or into a spreadsheet. JSON keeps every field; CSV keeps the
+ones that make sense in a spreadsheet, one bead per row.
+
+Filters are key=value and may repeat; values for one key may be comma-separated.
+Keys: status, type, turf, assignee, label, priority.
+
+Examples:
+  mob beads export > backlog.json
+  mob beads export --format csv --filter status=open,blocked --filter turf=api -o api.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		format, err := beadsFormat(cmd, output)
+		if err != nil {
+			fail(err)
+		}
+		filters, _ := cmd.Flags().GetStringArray("filter")
+		filter, err := backlog.ParseFilter(filters)
+		if err != nil {
+			fail(err)
+		}
+
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		beads, err := store.List(storage.BeadFilter{})
+		if err != nil {
+			fail(err)
+		}
+		beads = filter.Apply(beads)
+
+		if output == "" || output == "-" {
+			if err := backlog.Write(os.Stdout, beads, format); err != nil {
+				fail(err)
+			}
+			return
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			fail(err)
+		}
+		if err := backlog.Write(f, beads, format); err != nil {
+			f.Close()
+			fail(err)
+		}
+		if err := f.Close(); err != nil {
+			fail(err)
+		}
+		fmt.Fprintf(os.Stderr, "%s Exported %d bead(s) to %s\n", successStyle.Render("✓"), len(beads), output)
+	},
+}
+
+var beadsImportCmd = &cobra.Command{
+	Use:   "import <file|->",
+	Short: "Add beads from a JSON or CSV export",
+	Long: `Add beads written by "mob beads export" on another instance, or rows from a
+spreadsheet saved as CSV (only a title column is required).
+
+Every bead gets a new ID here, and parent, blocks and related links between
+imported beads are rewritten to match; links to beads that are not in the
+file are dropped. A bead whose title and turf match one already here, or one
+earlier in the file, is reported as a duplicate and skipped. Assignments do
+not carry over, so in-progress beads arrive open.
+
+The format follows the file extension unless --format is given; use - to read
+stdin. Try --dry-run first to see what would happen.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+		format, err := beadsFormat(cmd, path)
+		if err != nil {
+			fail(err)
+		}
+		turfName, _ := cmd.Flags().GetString("turf")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		var in io.Reader = os.Stdin
+		source := "stdin"
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				fail(err)
+			}
+			defer f.Close()
+			in = f
+			source = filepath.Base(path)
+		}
+		incoming, err := backlog.Read(in, format)
+		if err != nil {
+			fail(err)
+		}
+
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		trackActivity(store)
+
+		result, err := store.Import(incoming, storage.ImportOptions{
+			Source: source,
+			Turf:   turfName,
+			Actor:  "user",
+			DryRun: dryRun,
+		})
+		if err != nil {
+			fail(err)
+		}
+
+		verb := "Imported"
+		if dryRun {
+			verb = "Would import"
+		}
+		for _, b := range result.Created {
+			was := ""
+			if from := b.History[0].From; from != "" {
+				was = mutedStyle.Render(" (was " + from + ")")
+			}
+			fmt.Printf("  %s %s%s\n", valueStyle.Render(b.ID), truncate(b.Title, 60), was)
+		}
+		for _, d := range result.Duplicates {
+			fmt.Printf("  %s %s %s\n", warningStyle.Render("="), truncate(d.Title, 60), mutedStyle.Render("matches "+d.Existing))
+		}
+		fmt.Printf("%s %s %d bead(s)", successStyle.Render("✓"), verb, len(result.Created))
+		if n := len(result.Duplicates); n > 0 {
+			fmt.Printf(", skipped %d duplicate(s)", n)
+		}
+		if result.DroppedLinks > 0 {
+			fmt.Printf(", dropped %d link(s) to beads not in the file", result.DroppedLinks)
+		}
+		fmt.Println()
+	},
+}
+
+// beadsFormat reads --format, falling back to the file's extension
+func beadsFormat(cmd *cobra.Command, path string) (string, error) {
+	if format, _ := cmd.Flags().GetString("format"); format != "" {
+		return backlog.ParseFormat(format)
+	}
+	return backlog.FormatOf(path), nil
+}
+
+func init() {
+	beadsExportCmd.Flags().String("format", "", "json or csv (default: from -o's extension, else json)")
+	beadsExportCmd.Flags().StringArray("filter", nil, "only export beads matching key=value (repeatable)")
+	beadsExportCmd.Flags().StringP("output", "o", "", "file to write instead of stdout")
+	beadsImportCmd.Flags().String("format", "", "json or csv (default: from the file's extension)")
+	beadsImportCmd.Flags().String("turf", "", "put every imported bead in this turf")
+	beadsImportCmd.Flags().Bool("dry-run", false, "show what would be imported without writing")
+
+	beadsCmd.AddCommand(beadsValidateCmd)
+	beadsCmd.AddCommand(beadsExportCmd)
+	beadsCmd.AddCommand(beadsImportCmd)
+	rootCmd.AddCommand(beadsCmd)
+}
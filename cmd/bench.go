@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/bench"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchAgents   int
+	benchBeads    int
+	benchDuration time.Duration
+	benchCycles   int
+	benchPatrol   time.Duration
+	benchDir      string
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load test the bead store and registry with simulated agents",
+	Long: `Simulate agents claiming, commenting on and closing beads while a patrol
+loop scans the registry and bead store, then report throughput and latency
+percentiles for each operation.
+
+Runs against a temporary mob directory unless --dir is given; it never
+touches ~/mob. Use it to check storage and daemon changes for regressions
+at scale.
+
+Example:
+  mob bench --agents 20 --beads 500 --duration 30s`,
+	Hidden: true, // Developer tool, not part of the everyday workflow
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Running %d agents against %d beads...\n", benchAgents, benchBeads)
+		report, err := bench.Run(ctx, bench.Options{
+			Agents:         benchAgents,
+			Beads:          benchBeads,
+			Duration:       benchDuration,
+			Cycles:         benchCycles,
+			PatrolInterval: benchPatrol,
+			Dir:            benchDir,
+		})
+		if err != nil {
+			fail(err)
+		}
+
+		fmt.Println()
+		fmt.Printf("%s %s\n", labelStyle.Render("Elapsed:"), valueStyle.Render(report.Elapsed.Round(time.Millisecond).String()))
+		fmt.Printf("%s %s\n", labelStyle.Render("Cycles:"), valueStyle.Render(fmt.Sprintf("%d (%.1f/s)", report.Cycles, report.Throughput())))
+		if report.Errors > 0 {
+			fmt.Printf("%s %s\n", labelStyle.Render("Errors:"), errorStyle.Render(fmt.Sprintf("%d", report.Errors)))
+		}
+		if report.Conflicts > 0 {
+			fmt.Printf("%s %s\n", labelStyle.Render("Conflicts:"), valueStyle.Render(fmt.Sprintf("%d claims retried", report.Conflicts)))
+		}
+		fmt.Println()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "OPERATION\tCOUNT\tOPS/S\tMEAN\tP50\tP95\tP99\tMAX")
+		for _, name := range report.OpNames() {
+			s := report.Ops[name]
+			fmt.Fprintf(w, "%s\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\n",
+				name,
+				s.Count(),
+				float64(s.Count())/report.Elapsed.Seconds(),
+				formatLatency(s.Mean()),
+				formatLatency(s.Percentile(50)),
+				formatLatency(s.Percentile(95)),
+				formatLatency(s.Percentile(99)),
+				formatLatency(s.Percentile(100)),
+			)
+		}
+		w.Flush()
+	},
+}
+
+// formatLatency rounds a latency to a readable precision
+func formatLatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+func init() {
+	benchCmd.Flags().IntVarP(&benchAgents, "agents", "a", 10, "Number of simulated agents")
+	benchCmd.Flags().IntVarP(&benchBeads, "beads", "b", 200, "Number of open beads to seed")
+	benchCmd.Flags().DurationVarP(&benchDuration, "duration", "d", 10*time.Second, "How long agents keep working")
+	benchCmd.Flags().IntVar(&benchCycles, "cycles", 0, "Claim-work-close loops per agent (overrides --duration)")
+	benchCmd.Flags().DurationVar(&benchPatrol, "patrol-interval", 100*time.Millisecond, "How often the patrol loop scans (0 disables it)")
+	benchCmd.Flags().StringVar(&benchDir, "dir", "", "Mob directory to run against (default: a temporary directory)")
+	rootCmd.AddCommand(benchCmd)
+}
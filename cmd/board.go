@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/snapshot"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	boardDiffFrom string
+	boardDiffTo   string
+	boardDiffTurf string
+	boardDiffJSON bool
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Inspect the board's history",
+}
+
+var boardDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show beads created, closed and reprioritized over an interval",
+	Long: `Compare the board between two days using the snapshots the daemon records
+once a day. Lists beads created, closed, reopened, reprioritized and removed.
+
+--from and --to take a date (2006-01-02), today, yesterday or an age (7d).
+--from compares against the board as that day began; --to includes that
+day's changes, and today means the board as it is now.
+
+Example:
+  mob board diff --from 2024-05-01 --to today`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		now := time.Now()
+		from := parseBoardDate("--from", boardDiffFrom, now)
+		to := parseBoardDate("--to", boardDiffTo, now)
+		if to.Before(from) {
+			fail(errkind.New(errkind.Invalid, "--to is before --from"))
+		}
+
+		dir := snapshot.Dir(mobDir)
+		before, err := snapshot.At(dir, from.Format(snapshot.DateLayout))
+		if err != nil {
+			fail(err)
+		}
+
+		var after *snapshot.Snapshot
+		end := to.AddDate(0, 0, 1)
+		if end.After(now) {
+			after = liveBoard(now)
+		} else if after, err = snapshot.At(dir, end.Format(snapshot.DateLayout)); err != nil {
+			fail(err)
+		}
+
+		diff := snapshot.Compare(before, after, boardDiffTurf)
+		if boardDiffJSON {
+			data, _ := json.MarshalIndent(diff, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+		printBoardDiff(diff)
+	},
+}
+
+var boardSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Record today's board snapshot now",
+	Long: `Record the board as it is now as today's snapshot, replacing the one the
+daemon took earlier today.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		snap := liveBoard(time.Now())
+		if err := snapshot.Save(snapshot.Dir(mobDir), snap); err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s Recorded %d bead(s) for %s\n", successStyle.Render("✓"), len(snap.Beads), snap.Date())
+	},
+}
+
+// liveBoard captures the board as it is now
+func liveBoard(now time.Time) *snapshot.Snapshot {
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		fail(err)
+	}
+	store, err := storage.NewBeadStore(beadsPath)
+	if err != nil {
+		fail(err)
+	}
+	beads, err := store.List(storage.BeadFilter{})
+	if err != nil {
+		fail(err)
+	}
+	return snapshot.Capture(beads, now)
+}
+
+// parseBoardDate resolves a --from or --to value to the start of its day
+func parseBoardDate(flag, value string, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	switch value {
+	case "today":
+		return today
+	case "yesterday":
+		return today.AddDate(0, 0, -1)
+	}
+	if t, err := time.ParseInLocation(snapshot.DateLayout, value, time.Local); err == nil {
+		return t
+	}
+	if d, err := config.ParseRetention(value); err == nil && d > 0 {
+		t := now.Add(-d)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	}
+	fail(errkind.New(errkind.Invalid, fmt.Sprintf("%s %q is not a date (2006-01-02), today, yesterday or an age (7d)", flag, value)))
+	return time.Time{}
+}
+
+func printBoardDiff(diff *snapshot.Diff) {
+	fmt.Printf("%s %s → %s\n", sectionStyle.Render("Board changes"),
+		diff.From.Local().Format("2006-01-02 15:04"), diff.To.Local().Format("2006-01-02 15:04"))
+	if diff.Empty() {
+		fmt.Println(mutedStyle.Render("  No changes"))
+		return
+	}
+
+	printBoardBeads("Created", diff.Created)
+	printBoardBeads("Closed", diff.Closed)
+	printBoardBeads("Reopened", diff.Reopened)
+
+	if len(diff.Reprioritized) > 0 {
+		fmt.Printf("\n%s (%d)\n", sectionStyle.Render("Reprioritized"), len(diff.Reprioritized))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, r := range diff.Reprioritized {
+			change := fmt.Sprintf("P%d → P%d", r.From, r.Priority)
+			style := mutedStyle
+			if r.Priority < r.From {
+				style = warningStyle
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", valueStyle.Render(r.ID), style.Render(change), truncate(r.Title, 60), mutedStyle.Render(r.Turf))
+		}
+		w.Flush()
+	}
+
+	printBoardBeads("Removed", diff.Removed)
+}
+
+func printBoardBeads(title string, beads []snapshot.Bead) {
+	if len(beads) == 0 {
+		return
+	}
+	fmt.Printf("\n%s (%d)\n", sectionStyle.Render(title), len(beads))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, b := range beads {
+		fmt.Fprintf(w, "  %s\tP%d\t%s\t%s\n", valueStyle.Render(b.ID), b.Priority, truncate(b.Title, 60), mutedStyle.Render(b.Turf))
+	}
+	w.Flush()
+}
+
+func init() {
+	boardDiffCmd.Flags().StringVar(&boardDiffFrom, "from", "7d", "First day: date (2006-01-02), today, yesterday or age (7d)")
+	boardDiffCmd.Flags().StringVar(&boardDiffTo, "to", "today", "Last day: date (2006-01-02), today, yesterday or age (7d)")
+	boardDiffCmd.Flags().StringVar(&boardDiffTurf, "turf", "", "Only compare this turf's beads")
+	boardDiffCmd.Flags().BoolVar(&boardDiffJSON, "json", false, "Output in JSON format")
+	boardCmd.AddCommand(boardDiffCmd)
+	boardCmd.AddCommand(boardSnapshotCmd)
+	rootCmd.AddCommand(boardCmd)
+}
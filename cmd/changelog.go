@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gabe/mob/internal/changelog"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/git"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogSince string
+	changelogTurf  string
+	changelogTitle string
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Write a markdown changelog from closed beads",
+	Long: `Print a markdown changelog section built from the beads closed since a
+release. Beads are grouped into Features, Fixes, Chores and Other changes by
+type, and each line carries the bead ID and the commit that merged it.
+
+--since takes a git tag or other revision of the turf's repository, a date
+(2006-01-02) or an age (30d, 72h). Without it every closed bead is listed.
+
+Example:
+  mob changelog --since v1.2.0 --turf api --title v1.3.0 >> CHANGELOG.md`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		beads, err := store.List(storage.BeadFilter{Status: models.BeadStatusClosed})
+		if err != nil {
+			fail(err)
+		}
+
+		repos := changelogRepos(changelogTurf)
+		since, sinceRef := resolveChangelogSince(changelogSince, repos)
+
+		commits := make(map[string]string)
+		for _, t := range repos {
+			from := sinceRef
+			if from != "" {
+				if _, err := git.CommitTime(t.Path, from); err != nil {
+					from = "" // the release tag belongs to another turf's repo
+				}
+			}
+			merges, err := git.MergeCommits(t.Path, from)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", t.Name, err)
+				continue
+			}
+			for branch, hash := range merges {
+				commits[branch] = hash
+			}
+		}
+
+		sections := changelog.Build(beads, changelog.Options{Since: since, Turf: changelogTurf, Commits: commits})
+		heading := fmt.Sprintf("%s (%s)", changelogTitle, time.Now().Format("2006-01-02"))
+		if err := changelog.WriteMarkdown(os.Stdout, heading, sections); err != nil {
+			fail(err)
+		}
+	},
+}
+
+// changelogRepos returns the turf named by --turf, or every turf
+func changelogRepos(name string) []models.Turf {
+	turfsPath, err := getTurfsPath()
+	if err != nil {
+		fail(err)
+	}
+	mgr, err := turf.NewManager(turfsPath)
+	if err != nil {
+		fail(err)
+	}
+	if name == "" {
+		return mgr.List()
+	}
+	t, err := mgr.Get(name)
+	if err != nil {
+		fail(err)
+	}
+	return []models.Turf{*t}
+}
+
+// resolveChangelogSince turns --since into the time beads must have closed
+// after and, when it names a revision, the revision merges are listed from.
+// A revision is looked up in each repo in turn.
+func resolveChangelogSince(since string, repos []models.Turf) (time.Time, string) {
+	if since == "" {
+		return time.Time{}, ""
+	}
+	if d, err := config.ParseRetention(since); err == nil && d > 0 {
+		return time.Now().Add(-d), ""
+	}
+	if t, err := time.ParseInLocation("2006-01-02", since, time.Local); err == nil {
+		return t, ""
+	}
+	for _, t := range repos {
+		if at, err := git.CommitTime(t.Path, since); err == nil {
+			return at, since
+		}
+	}
+	fail(errkind.New(errkind.Invalid, fmt.Sprintf("--since %q is not a revision of any turf, a date (2006-01-02) or an age (30d)", since)))
+	return time.Time{}, ""
+}
+
+func init() {
+	changelogCmd.Flags().StringVar(&changelogSince, "since", "", "Release tag or revision, date (2006-01-02) or age (30d) to start from")
+	changelogCmd.Flags().StringVar(&changelogTurf, "turf", "", "Only include this turf's beads")
+	changelogCmd.Flags().StringVar(&changelogTitle, "title", "Unreleased", "Heading for the changelog section, e.g. the new version")
+	rootCmd.AddCommand(changelogCmd)
+}
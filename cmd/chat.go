@@ -2,12 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/gabe/mob/internal/agent"
 	"github.com/gabe/mob/internal/underboss"
 	"github.com/spf13/cobra"
 )
@@ -25,7 +25,7 @@ var chatCmd = &cobra.Command{
 		}
 
 		// 2. Create spawner
-		spawner := agent.NewSpawner()
+		spawner := newSpawner(mobDir)
 
 		// 3. Create and start Underboss
 		ub := underboss.New(mobDir, spawner)
@@ -60,7 +60,7 @@ var chatCmd = &cobra.Command{
 		session := underboss.NewSession(ub, os.Stdin, os.Stdout)
 
 		// 5. Run session
-		if err := session.Run(ctx); err != nil && err != context.Canceled {
+		if err := session.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
 			fmt.Fprintf(os.Stderr, "Error during session: %v\n", err)
 			os.Exit(1)
 		}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/turf"
+	"github.com/spf13/cobra"
+)
+
+var conventionsCmd = &cobra.Command{
+	Use:   "conventions",
+	Short: "Manage a turf's conventions document",
+	Long: `Each turf can keep a conventions document (.mob/conventions.md in the
+project) describing its established patterns. It is injected into the prompt
+of every agent working on that turf, and agents can add to it with the
+update_conventions tool.`,
+}
+
+var conventionsShowCmd = &cobra.Command{
+	Use:   "show <turf>",
+	Short: "Print a turf's conventions",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		turfPath, err := resolveConventionsTurf(args[0])
+		if err != nil {
+			fail(err)
+		}
+
+		conventions, err := turf.LoadConventions(turfPath)
+		if err != nil {
+			fail(err)
+		}
+		if conventions == "" {
+			fmt.Printf("No conventions for turf '%s'. Create them with 'mob conventions edit %s'.\n", args[0], args[0])
+			return
+		}
+		fmt.Println(conventions)
+	},
+}
+
+var conventionsEditCmd = &cobra.Command{
+	Use:   "edit <turf>",
+	Short: "Edit a turf's conventions in $EDITOR",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		turfPath, err := resolveConventionsTurf(args[0])
+		if err != nil {
+			fail(err)
+		}
+
+		path := turf.ConventionsPath(turfPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			fail(err)
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			template := fmt.Sprintf("# %s conventions\n\n<!-- Patterns every agent on this turf should follow. -->\n", args[0])
+			if err := os.WriteFile(path, []byte(template), 0644); err != nil {
+				fail(err)
+			}
+		}
+
+		editor := os.Getenv("EDITOR")
+		if editor == "" {
+			editor = "vi"
+		}
+		edit := exec.Command(editor, path)
+		edit.Stdin = os.Stdin
+		edit.Stdout = os.Stdout
+		edit.Stderr = os.Stderr
+		if err := edit.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: editor failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Saved conventions for turf '%s' (%s)\n", args[0], path)
+	},
+}
+
+var conventionsPathCmd = &cobra.Command{
+	Use:   "path <turf>",
+	Short: "Print the location of a turf's conventions document",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		turfPath, err := resolveConventionsTurf(args[0])
+		if err != nil {
+			fail(err)
+		}
+		fmt.Println(turf.ConventionsPath(turfPath))
+	},
+}
+
+// resolveConventionsTurf looks up a registered turf's project path
+func resolveConventionsTurf(name string) (string, error) {
+	turfsPath, err := getTurfsPath()
+	if err != nil {
+		return "", err
+	}
+	mgr, err := turf.NewManager(turfsPath)
+	if err != nil {
+		return "", err
+	}
+	t, err := mgr.Get(name)
+	if err != nil {
+		return "", err
+	}
+	return t.Path, nil
+}
+
+func init() {
+	conventionsCmd.AddCommand(conventionsShowCmd)
+	conventionsCmd.AddCommand(conventionsEditCmd)
+	conventionsCmd.AddCommand(conventionsPathCmd)
+	rootCmd.AddCommand(conventionsCmd)
+}
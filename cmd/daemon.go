@@ -1,33 +1,70 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/gabe/mob/internal/daemon"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/logfile"
+	"github.com/gabe/mob/internal/service"
 	"github.com/spf13/cobra"
 )
 
-var debug bool
+var (
+	debug           bool
+	daemonSupervise []string
+	daemonDryRun    bool
+	daemonPlanJSON  bool
+)
 
 var daemonCmd = &cobra.Command{
 	Use:   "daemon",
 	Short: "Manage the mob daemon",
-	Long:  `Start, stop, and check the status of the mob daemon process.`,
+	Long:  `Start, stop, reload, restart, upgrade, install, and check the status, logs and next patrol plan of the mob daemon process.`,
 }
 
 var daemonStartCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the mob daemon",
+	Long: `Start the mob daemon for the mob directory (--mob-dir, $MOB_HOME or ~/mob).
+
+With --supervise, one process looks after the daemons of several mob
+directories, say work and personal. Each directory's daemon runs in its own
+process with its own registry, beads and log, and is started again if it
+crashes. Stop one with 'mob --mob-dir <dir> daemon stop'; stopping the
+supervisor stops them all, and SIGHUP reloads them all.
+
+With --dry-run, nothing is started: the first patrol is worked out and
+printed as 'mob daemon plan' would.
+
+Example:
+  mob daemon start
+  mob daemon start --dry-run
+  mob --mob-dir ~/work-mob daemon start --supervise ~/personal-mob`,
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
+		}
+		if daemonDryRun {
+			runDaemonPlan(mobDir)
+			return
+		}
+		if len(daemonSupervise) > 0 {
+			superviseDaemons(mobDir, daemonSupervise)
+			return
 		}
 
 		// Always log to daemon.log file for TUI viewing
@@ -36,7 +73,10 @@ var daemonStartCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error creating log directory: %v\n", err)
 			os.Exit(1)
 		}
-		logFile, err := os.OpenFile(filepath.Join(logDir, "daemon.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err := service.RotateLog(service.LogPath(mobDir), service.MaxLogBytes, service.LogsKept); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to rotate service log: %v\n", err)
+		}
+		logFile, err := logfile.Open(filepath.Join(logDir, "daemon.log"), logfile.PolicyFromConfig(loadJobsConfig(mobDir).Logging))
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error opening log file: %v\n", err)
 			os.Exit(1)
@@ -51,22 +91,79 @@ var daemonStartCmd = &cobra.Command{
 		logger := log.New(out, "", log.LstdFlags)
 
 		d := daemon.New(mobDir, logger)
+		d.SetLogFile(logFile)
 
 		if err := d.Start(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 	},
 }
 
+var daemonPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show what the next patrol would do, without doing it",
+	Long: `Work out what the next patrol would do and print it: the soldati it would
+spawn, the beads it would assign and to whom, the work it would park for
+urgent beads, and the associates it would nudge or kill. Nothing is changed,
+so this is safe to run next to a running daemon and useful for debugging
+assignment policies such as WIP limits, skills and preemption.
+
+Agents a running daemon has registered are taken to be alive. Merges,
+worktree quotas and the search index are not part of the plan.
+
+Example:
+  mob daemon plan
+  mob daemon plan --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		runDaemonPlan(mobDir)
+	},
+}
+
+// runDaemonPlan prints what the next patrol in mobDir would do
+func runDaemonPlan(mobDir string) {
+	plan, err := daemon.PlanPatrol(mobDir)
+	if err != nil {
+		fail(err)
+	}
+	if daemonPlanJSON {
+		data, _ := json.MarshalIndent(plan, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if !plan.Working {
+		fmt.Println(warningStyle.Render("Outside working hours: no spawns or assignments"))
+	}
+	if len(plan.Steps) == 0 {
+		fmt.Println(mutedStyle.Render("Nothing to do"))
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, step := range plan.Steps {
+		agent, bead := step.Agent, step.BeadID
+		if agent == "" {
+			agent = "-"
+		}
+		if bead == "" {
+			bead = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", headerStyle.Render(step.Action), agent, valueStyle.Render(bead), mutedStyle.Render(step.Detail))
+	}
+	w.Flush()
+}
+
 var daemonStopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop the mob daemon",
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 		pidFile := filepath.Join(mobDir, ".mob", "daemon.pid")
 
@@ -78,8 +175,7 @@ var daemonStopCmd = &cobra.Command{
 			return
 		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		process, err := os.FindProcess(pid)
@@ -105,8 +201,7 @@ var daemonStatusCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		var out io.Writer = io.Discard
@@ -119,8 +214,7 @@ var daemonStatusCmd = &cobra.Command{
 
 		state, pid, err := d.Status()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if state == daemon.StateIdle {
@@ -131,18 +225,395 @@ var daemonStatusCmd = &cobra.Command{
 	},
 }
 
-func getMobDir() (string, error) {
-	home, err := os.UserHomeDir()
+var daemonLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Print the end of the daemon log",
+	Long: `Print the last lines of .mob/daemon.log, reaching back into rotated logs
+when the current one is shorter. With --all the whole log is printed, oldest
+rotated log first.
+
+The daemon rotates its log once it passes max_size_mb or is rotate_every
+old, gzips rotated logs when compress is set, and removes those older than
+retention or beyond max_files:
+
+  [logging]
+  max_size_mb = 10
+  rotate_every = "24h"
+  compress = true
+  retention = "7d"
+  max_files = 0
+
+Example:
+  mob daemon logs -n 50
+  mob daemon logs --all | grep Failures`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		path := filepath.Join(mobDir, ".mob", "daemon.log")
+
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			r, err := logfile.NewReader(path)
+			if err != nil {
+				fail(err)
+			}
+			defer r.Close()
+			if _, err := io.Copy(os.Stdout, r); err != nil {
+				fail(err)
+			}
+			return
+		}
+
+		n, _ := cmd.Flags().GetInt("lines")
+		lines, err := logfile.Tail(path, n)
+		if err != nil {
+			fail(err)
+		}
+		if len(lines) == 0 {
+			fmt.Println(mutedStyle.Render("The daemon log is empty."))
+			return
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	},
+}
+
+var daemonReloadCmd = &cobra.Command{
+	Use:   "reload",
+	Short: "Re-read config.toml without restarting the daemon",
+	Long: `Tell the running daemon to re-read config.toml and apply it in place:
+patrol and nudge intervals, working hours, redaction, notification backends,
+jobs, model failover, log rotation and the webhook endpoint. Running agents and their work
+are left alone. A config that fails to load is reported and the daemon keeps
+the one it has.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		r, err := signalDaemon(mobDir, syscall.SIGHUP, 10*time.Second)
+		if err != nil {
+			fail(err)
+		}
+		if r.Error != "" {
+			fail(errkind.New(errkind.Invalid, "reload failed: "+r.Error))
+		}
+		if len(r.Changes) == 0 {
+			fmt.Println(mutedStyle.Render("Config reloaded; nothing changed"))
+			return
+		}
+		fmt.Printf("%s Config reloaded: %s\n", successStyle.Render("✓"), strings.Join(r.Changes, ", "))
+	},
+}
+
+var daemonUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Replace the running daemon with a new binary",
+	Long: `Switch the running daemon over to a new mob binary without a restart.
+
+The daemon checks the binary runs, stops handing out new work, and waits for
+in-flight assignments, merges, jobs and heresy fixes to finish. It then execs
+the new binary in its own process, passing on its schedule state and the
+webhook socket, so the PID, registered agents and webhook endpoint carry on.
+If the work does not finish within --wait, the upgrade is abandoned and the
+daemon carries on as before.
+
+The binary defaults to the one running this command, so after installing a
+new mob, "mob daemon upgrade" is enough.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		binary, err := daemonBinary(cmd)
+		if err != nil {
+			fail(err)
+		}
+		wait, _ := cmd.Flags().GetDuration("wait")
+
+		if err := daemon.WriteUpgradeRequest(mobDir, daemon.UpgradeRequest{Binary: binary, Wait: wait}); err != nil {
+			fail(err)
+		}
+		fmt.Println(mutedStyle.Render("Waiting for in-flight work to finish..."))
+		r, err := signalDaemon(mobDir, syscall.SIGUSR2, wait+time.Minute)
+		if err != nil {
+			fail(err)
+		}
+		if r.Error != "" {
+			fail(errkind.New(errkind.Conflict, "upgrade failed: "+r.Error))
+		}
+		fmt.Printf("%s Daemon now running %s (PID %d)\n", successStyle.Render("✓"), r.Version, r.PID)
+	},
+}
+
+var daemonRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart the daemon without losing agent sessions",
+	Long: `Restart the running daemon in place, keeping its soldati working.
+
+Unlike stopping and starting, which ends every session, a restart hands each
+soldati's Claude session, hook watcher and nudge state to the new process,
+which resumes the sessions with --resume. Assignments in progress are cut
+short and picked up again in the same session once the daemon is back.
+Queued merges, running jobs and heresy fixes are waited for, up to --wait;
+if they do not finish in time the restart is abandoned and the interrupted
+assignments carry on in the running daemon.
+
+The binary defaults to the one running this command.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		binary, err := daemonBinary(cmd)
+		if err != nil {
+			fail(err)
+		}
+		wait, _ := cmd.Flags().GetDuration("wait")
+
+		req := daemon.UpgradeRequest{Binary: binary, Wait: wait, Restart: true}
+		if err := daemon.WriteUpgradeRequest(mobDir, req); err != nil {
+			fail(err)
+		}
+		fmt.Println(mutedStyle.Render("Restarting..."))
+		r, err := signalDaemon(mobDir, syscall.SIGUSR2, wait+time.Minute)
+		if err != nil {
+			fail(err)
+		}
+		if r.Error != "" {
+			fail(errkind.New(errkind.Conflict, "restart failed: "+r.Error))
+		}
+		fmt.Printf("%s Daemon restarted, running %s (PID %d)\n", successStyle.Render("✓"), r.Version, r.PID)
+	},
+}
+
+var daemonInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Run the daemon as a service that starts at login",
+	Long: `Install the daemon as a user service, so it starts when you log in and
+comes back if it crashes: a systemd user unit on Linux, a launchd agent on
+macOS. The service is started right away.
+
+A clean stop with 'mob daemon stop' stays stopped; a crash restarts the
+daemon after a few seconds. On Linux the service's output goes to the
+journal (journalctl --user -u mob), which rotates it. On macOS it goes to
+.mob/daemon.service.log, which the daemon rotates as it starts. Either way
+the daemon's own log stays in .mob/daemon.log.
+
+The service runs the binary given by --binary, or this one, for this mob
+directory and any given with --supervise (see 'mob daemon start'), with the
+current PATH so agents can find claude and git. Install again after moving
+the binary; --print shows the service definition without installing it.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		binary, err := daemonBinary(cmd)
+		if err != nil {
+			fail(err)
+		}
+		svc, err := service.New()
+		if err != nil {
+			fail(err)
+		}
+		supervise, _ := cmd.Flags().GetStringArray("supervise")
+		dirs, err := superviseDirs(mobDir, supervise)
+		if err != nil {
+			fail(err)
+		}
+		spec := service.Spec{Binary: binary, MobDir: mobDir, Supervise: dirs[1:], Path: os.Getenv("PATH")}
+
+		if print, _ := cmd.Flags().GetBool("print"); print {
+			def, err := svc.Render(spec)
+			if err != nil {
+				fail(err)
+			}
+			fmt.Print(def)
+			return
+		}
+
+		// A daemon the service already runs keeps running; the new
+		// definition applies from its next start
+		reinstall := svc.Installed()
+		running, pid, err := daemon.CheckExistingDaemon(filepath.Join(mobDir, ".mob", "daemon.pid"))
+		if err != nil {
+			fail(err)
+		}
+		if running && !reinstall {
+			fail(errkind.New(errkind.Conflict, fmt.Sprintf("daemon is already running (PID %d); stop it with 'mob daemon stop' so the service can start it", pid)))
+		}
+
+		path, err := svc.Install(spec)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s Installed %s\n", successStyle.Render("✓"), path)
+		if running {
+			fmt.Println(mutedStyle.Render("The running daemon keeps its old service settings until the service next starts it."))
+			return
+		}
+		fmt.Println(mutedStyle.Render("The daemon is running and will start at login. Remove it with 'mob daemon uninstall'."))
+		if svc.OS == "linux" {
+			fmt.Println(mutedStyle.Render("To start it at boot before you log in, run 'loginctl enable-linger'."))
+		}
+	},
+}
+
+var daemonUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Stop the daemon service and remove it",
+	Long: `Stop the daemon service installed with 'mob daemon install' and remove
+its systemd unit or launchd agent. Agents are stopped with the daemon.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		svc, err := service.New()
+		if err != nil {
+			fail(err)
+		}
+		path, err := svc.Uninstall()
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("%s Removed %s\n", successStyle.Render("✓"), path)
+	},
+}
+
+// superviseDaemons runs a daemon process for mobDir and each of the other
+// directories until they have all stopped
+func superviseDaemons(mobDir string, others []string) {
+	binary, err := os.Executable()
+	if err != nil {
+		fail(err)
+	}
+	dirs, err := superviseDirs(mobDir, others)
+	if err != nil {
+		fail(err)
+	}
+
+	sup := &daemon.Supervisor{
+		Dirs:   dirs,
+		Logger: log.New(os.Stderr, "", log.LstdFlags),
+		Command: func(dir string) *exec.Cmd {
+			args := []string{"--mob-dir", dir, "daemon", "start"}
+			if debug {
+				args = append(args, "--debug")
+			}
+			c := exec.Command(binary, args...)
+			c.Stdout, c.Stderr = os.Stdout, os.Stderr
+			return c
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		for sig := range sigChan {
+			if sig == syscall.SIGHUP {
+				sup.Signal(sig)
+				continue
+			}
+			cancel()
+		}
+	}()
+	sup.Run(ctx)
+}
+
+// superviseDirs returns mobDir and the other directories, absolute and
+// without repeats
+func superviseDirs(mobDir string, others []string) ([]string, error) {
+	dirs := []string{mobDir}
+	seen := map[string]bool{mobDir: true}
+	for _, dir := range others {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(filepath.Join(abs, ".mob")); err != nil || !info.IsDir() {
+			return nil, errkind.New(errkind.NotFound, fmt.Sprintf("%s is not a mob directory; run 'mob --mob-dir %s init' first", dir, dir))
+		}
+		if !seen[abs] {
+			seen[abs] = true
+			dirs = append(dirs, abs)
+		}
+	}
+	return dirs, nil
+}
+
+// daemonBinary returns the absolute path of the binary given by --binary,
+// or of this one
+func daemonBinary(cmd *cobra.Command) (string, error) {
+	binary, _ := cmd.Flags().GetString("binary")
+	if binary == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return "", err
+		}
+		binary = exe
+	}
+	return filepath.Abs(binary)
+}
+
+// signalDaemon sends sig to the running daemon and waits for it to report
+// the outcome
+func signalDaemon(mobDir string, sig syscall.Signal, timeout time.Duration) (*daemon.ControlResult, error) {
+	pid, err := daemon.ReadPID(filepath.Join(mobDir, ".mob", "daemon.pid"))
+	if os.IsNotExist(err) {
+		return nil, errkind.New(errkind.NotFound, "daemon is not running")
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
+	}
+
+	sent := time.Now()
+	if err := syscall.Kill(pid, sig); err != nil {
+		return nil, fmt.Errorf("failed to signal daemon (PID %d): %w", pid, err)
+	}
+	for deadline := sent.Add(timeout); time.Now().Before(deadline); time.Sleep(250 * time.Millisecond) {
+		r, err := daemon.ReadControlResult(mobDir)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil && r.At.After(sent) {
+			return r, nil
+		}
 	}
-	return filepath.Join(home, "mob"), nil
+	return nil, errkind.New(errkind.Transient, fmt.Sprintf("daemon did not answer within %s; see .mob/daemon.log", timeout))
 }
 
 func init() {
 	daemonCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug output")
+	daemonStartCmd.Flags().StringArrayVar(&daemonSupervise, "supervise", nil, "also run the daemon for this mob directory (repeatable)")
+	daemonStartCmd.Flags().BoolVarP(&daemonDryRun, "dry-run", "n", false, "print what the first patrol would do and exit")
+	daemonStartCmd.Flags().BoolVar(&daemonPlanJSON, "json", false, "with --dry-run, output the plan as JSON")
+	daemonPlanCmd.Flags().BoolVar(&daemonPlanJSON, "json", false, "Output in JSON format")
 	daemonCmd.AddCommand(daemonStartCmd)
+	daemonCmd.AddCommand(daemonPlanCmd)
 	daemonCmd.AddCommand(daemonStopCmd)
 	daemonCmd.AddCommand(daemonStatusCmd)
+	daemonLogsCmd.Flags().IntP("lines", "n", 100, "number of lines to print")
+	daemonLogsCmd.Flags().Bool("all", false, "print the whole log, rotated logs included")
+	daemonCmd.AddCommand(daemonLogsCmd)
+	daemonUpgradeCmd.Flags().String("binary", "", "mob binary to switch to (default: this one)")
+	daemonUpgradeCmd.Flags().Duration("wait", daemon.DefaultUpgradeWait, "how long to wait for in-flight work")
+	daemonRestartCmd.Flags().String("binary", "", "mob binary to restart into (default: this one)")
+	daemonRestartCmd.Flags().Duration("wait", daemon.DefaultUpgradeWait, "how long to wait for merges, jobs and fixes")
+	daemonCmd.AddCommand(daemonReloadCmd)
+	daemonCmd.AddCommand(daemonRestartCmd)
+	daemonCmd.AddCommand(daemonUpgradeCmd)
+	daemonInstallCmd.Flags().String("binary", "", "mob binary the service runs (default: this one)")
+	daemonInstallCmd.Flags().StringArray("supervise", nil, "also run the daemon for this mob directory (repeatable)")
+	daemonInstallCmd.Flags().Bool("print", false, "print the service definition instead of installing it")
+	daemonCmd.AddCommand(daemonInstallCmd)
+	daemonCmd.AddCommand(daemonUninstallCmd)
 	rootCmd.AddCommand(daemonCmd)
 }
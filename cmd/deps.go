@@ -23,14 +23,12 @@ var depsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		beadsPath, err := getBeadsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		store, err := storage.NewBeadStore(beadsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if len(args) == 0 {
@@ -52,8 +50,7 @@ var depsCmd = &cobra.Command{
 func showSimpleDeps(store *storage.BeadStore, beadID string) {
 	bead, err := store.Get(beadID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	blockedBy, err := store.GetBlockedBy(beadID)
@@ -76,8 +73,7 @@ func showSimpleDeps(store *storage.BeadStore, beadID string) {
 func showDependencyTree(store *storage.BeadStore, beadID string) {
 	tree, err := store.GetDependencyTree(beadID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	opts := display.DefaultTreeOpts()
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/gabe/mob/internal/daemon"
+	"github.com/gabe/mob/internal/reconcile"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+	"github.com/spf13/cobra"
+)
+
+var doctorDryRun bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Repair state left behind by crashed agents and daemons",
+	Long: `Bring the registry, beads and worktrees back in line with what is running:
+
+  dead-agent        agent records whose process is gone: soldati lose the
+                    PID (or are marked dead when the daemon is not running),
+                    associates are marked failed
+  stranded-bead     beads in progress that no agent is working on are
+                    reopened so they can be assigned again
+  orphan-worktree   worktrees of closed or deleted beads are removed, keeping
+                    their branches; ones with uncommitted changes are left
+
+The daemon runs the same checks each time it starts. When it is running,
+doctor also asks it for /healthz and fails if its patrols have stalled.
+
+Example:
+  mob doctor --dry-run`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+
+		running, _, err := daemon.CheckExistingDaemon(filepath.Join(mobDir, ".mob", "daemon.pid"))
+		if err != nil {
+			fail(err)
+		}
+		r := &reconcile.Reconciler{
+			Registry: registry.New(getRegistryPath()),
+			Beads:    store,
+			Actor:    "doctor",
+			Fresh:    !running,
+		}
+		if turfsPath, err := getTurfsPath(); err == nil {
+			if mgr, err := turf.NewManager(turfsPath); err == nil {
+				r.Turfs = mgr.List()
+			}
+		}
+		if dir, err := getSoldatiDir(); err == nil {
+			if mgr, err := soldati.NewManager(dir); err == nil {
+				if all, err := mgr.List(); err == nil {
+					for _, s := range all {
+						r.Soldati = append(r.Soldati, s.Name)
+					}
+				}
+			}
+		}
+
+		healthy := true
+		if running {
+			healthy = printDaemonHealth(mobDir)
+		}
+
+		report := r.Run(doctorDryRun)
+		printDoctorReport(report)
+
+		if len(report.Errors) > 0 {
+			for _, err := range report.Errors {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		if !healthy {
+			os.Exit(1)
+		}
+	},
+}
+
+// printDaemonHealth shows the running daemon's health check, reporting
+// whether it passed
+func printDaemonHealth(mobDir string) bool {
+	h, err := daemon.CheckHealth(mobDir)
+	if err != nil {
+		fmt.Printf("%s Daemon health: %v\n\n", warningStyle.Render("✗"), err)
+		return false
+	}
+
+	mark, ok := successStyle.Render("✓"), h.Status != daemon.HealthStalled
+	if !ok {
+		mark = warningStyle.Render("✗")
+	}
+	detail := "no patrol yet"
+	if !h.LastPatrol.IsZero() {
+		detail = "last patrol " + formatRelativeTime(h.LastPatrol)
+	}
+	fmt.Printf("%s Daemon %s %s (%s, PID %d)\n", mark, h.Status, mutedStyle.Render(detail), h.Version, h.PID)
+	for _, p := range h.Problems {
+		fmt.Printf("  %s\n", warningStyle.Render(p))
+	}
+	fmt.Println()
+	return ok
+}
+
+func printDoctorReport(report *reconcile.Report) {
+	if len(report.Fixes) == 0 && len(report.Skipped) == 0 {
+		fmt.Printf("%s Nothing to repair\n", successStyle.Render("✓"))
+		return
+	}
+
+	title := "Repaired"
+	if report.DryRun {
+		title = "Needs repair (dry run)"
+	}
+	fmt.Println(sectionStyle.Render(title))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, kind := range reconcile.AllKinds {
+		for _, f := range report.Fixes {
+			if f.Kind == kind {
+				printDoctorFix(w, f)
+			}
+		}
+	}
+	w.Flush()
+
+	if len(report.Skipped) > 0 {
+		fmt.Println()
+		fmt.Println(warningStyle.Render("Left alone"))
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, f := range report.Skipped {
+			printDoctorFix(w, f)
+		}
+		w.Flush()
+	}
+
+	if report.DryRun && len(report.Fixes) > 0 {
+		fmt.Println(mutedStyle.Render("\nRun without --dry-run to repair them."))
+	}
+}
+
+func printDoctorFix(w *tabwriter.Writer, f reconcile.Fix) {
+	subject := f.ID
+	if f.Kind == reconcile.KindDeadAgent {
+		subject = f.Name
+	}
+	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n",
+		labelStyle.Render(string(f.Kind)),
+		valueStyle.Render(subject),
+		truncate(f.Turf, 20),
+		mutedStyle.Render(f.Detail))
+}
+
+func init() {
+	doctorCmd.Flags().BoolVarP(&doctorDryRun, "dry-run", "n", false, "Report what would be repaired without changing anything")
+	rootCmd.AddCommand(doctorCmd)
+}
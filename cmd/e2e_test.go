@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/daemon"
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/mockclaude"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/soldati"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+)
+
+// The end-to-end tests run agents with MOB_CLAUDE=mock, so the test binary
+// is started as `mock-claude` in place of claude and as `mcp-server` for the
+// mock's tool calls. Both run through the real commands.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && (os.Args[1] == "mock-claude" || os.Args[1] == "mcp-server") {
+		if err := Execute(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// e2eMob sets up a mob directory with a soldati, a fast patrol and the mock
+// claude replaying turns, returning the directory and its bead store
+func e2eMob(t *testing.T, turns ...mockclaude.Turn) (string, *storage.BeadStore) {
+	t.Helper()
+	mobDir := t.TempDir()
+	t.Setenv(MobHomeEnv, mobDir)
+	t.Setenv(agent.ClaudeEnv, "mock")
+
+	script := filepath.Join(mobDir, "script.json")
+	data, err := json.Marshal(mockclaude.Script{Turns: turns})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(mockclaude.ScriptEnv, script)
+
+	config := "[daemon]\nheartbeat_interval = \"100ms\"\n"
+	if err := os.WriteFile(filepath.Join(mobDir, "config.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mgr, err := soldati.NewManager(filepath.Join(mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mgr.Create("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	store, err := storage.NewBeadStore(storage.BeadsDir(mobDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mobDir, store
+}
+
+// startE2EDaemon runs the daemon until the test ends, once vinnie is up and
+// watching its hook
+func startE2EDaemon(t *testing.T, mobDir string) *daemon.Daemon {
+	t.Helper()
+	d := daemon.New(mobDir, log.New(io.Discard, "", 0))
+	spawned := make(chan struct{}, 1)
+	d.Events().Subscribe(func(events.Event) {
+		select {
+		case spawned <- struct{}{}:
+		default:
+		}
+	}, events.TypeAgentSpawned)
+	done := make(chan error, 1)
+	go func() { done <- d.Start() }()
+	t.Cleanup(func() {
+		d.Stop()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Error("daemon did not stop")
+		}
+	})
+
+	select {
+	case <-spawned:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for vinnie to spawn")
+	}
+	return d
+}
+
+// waitUntil polls cond until it holds, failing the test after ten seconds
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// assignE2E hands a bead to vinnie the way auto-assignment does
+func assignE2E(t *testing.T, d *daemon.Daemon, store *storage.BeadStore, bead *models.Bead) {
+	t.Helper()
+	if err := d.AssignWork("vinnie", bead.ID, bead.Title); err != nil {
+		t.Fatal(err)
+	}
+	bead.Status, bead.Assignee = models.BeadStatusInProgress, "vinnie"
+	if _, err := store.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// completeTurn is a mock reply that closes the bead it was assigned
+var completeTurn = mockclaude.Turn{
+	Match: `\[Bead (bd-\w+)\]`,
+	ToolCalls: []mockclaude.ToolCall{
+		{Name: "complete_bead", Input: map[string]interface{}{"id": "$1", "close_reason": "shipped by the mock"}},
+	},
+	Text: "Finished $1",
+}
+
+func TestDaemonRunsAssignmentThroughMockClaude(t *testing.T) {
+	mobDir, store := e2eMob(t, completeTurn)
+	bead, err := store.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := startE2EDaemon(t, mobDir)
+
+	assignE2E(t, d, store, bead)
+	waitUntil(t, "the bead to close", func() bool {
+		got, err := store.Get(bead.ID)
+		return err == nil && got.Status == models.BeadStatusClosed
+	})
+	got, _ := store.Get(bead.ID)
+	if got.CloseReason != "shipped by the mock" {
+		t.Errorf("close reason = %q, want the one the mock's complete_bead gave", got.CloseReason)
+	}
+	waitUntil(t, "vinnie's hook to clear", func() bool {
+		mgr, err := d.GetHookManager("vinnie")
+		if err != nil {
+			return false
+		}
+		h, _ := mgr.Read()
+		return h == nil
+	})
+}
+
+// gitE2E runs git in dir
+func gitE2E(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestDaemonMergesWorkCompletedThroughMockClaude(t *testing.T) {
+	for _, v := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(v, "mob")
+	}
+	for _, v := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(v, "mob@example.com")
+	}
+	mobDir, store := e2eMob(t, completeTurn)
+
+	// A turf with the bead's work committed on its worktree branch
+	turfDir := t.TempDir()
+	gitE2E(t, turfDir, "init", "-q", "-b", "main")
+	gitE2E(t, turfDir, "commit", "-q", "--allow-empty", "-m", "initial")
+	turfs, err := turf.NewManager(filepath.Join(mobDir, "turfs.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := turfs.Add(turfDir, "backend", "main"); err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Add endpoint", Status: models.BeadStatusOpen, Turf: "backend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree := filepath.Join(t.TempDir(), bead.ID)
+	bead.Branch, bead.WorktreePath = "mob/"+bead.ID, worktree
+	gitE2E(t, turfDir, "worktree", "add", "-q", "-b", bead.Branch, worktree)
+	if err := os.WriteFile(filepath.Join(worktree, "endpoint.go"), []byte("package api\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitE2E(t, worktree, "add", "endpoint.go")
+	gitE2E(t, worktree, "commit", "-q", "-m", "Add endpoint")
+
+	d := startE2EDaemon(t, mobDir)
+	assignE2E(t, d, store, bead)
+
+	// complete_bead queues the merge; the daemon's patrol merges and closes it
+	waitUntil(t, "the bead to merge", func() bool {
+		got, err := store.Get(bead.ID)
+		return err == nil && got.Status != models.BeadStatusInProgress
+	})
+	got, _ := store.Get(bead.ID)
+	if got.Status != models.BeadStatusClosed {
+		t.Fatalf("bead = %s (%q), want closed once merged", got.Status, got.CloseReason)
+	}
+	if !hasE2EComment(got, "queued to merge") {
+		t.Errorf("expected complete_bead to queue the merge with the daemon running, got %+v", got.History)
+	}
+	if files := gitE2E(t, turfDir, "ls-tree", "--name-only", "main"); !strings.Contains(files, "endpoint.go") {
+		t.Errorf("main has %q, want the worktree's endpoint.go merged", files)
+	}
+}
+
+// hasE2EComment reports whether a comment on the bead contains text
+func hasE2EComment(b *models.Bead, text string) bool {
+	for _, e := range b.History {
+		if e.Type == models.BeadEventTypeComment && strings.Contains(e.Comment, text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var epicCmd = &cobra.Command{
+	Use:   "epic [epic-id]",
+	Short: "Show how far along epics are",
+	Long: `Roll an epic's child beads (those whose parent it is) up into its
+progress: how many are in each status, the share closed, how many are
+overdue, and the nearest deadline among the epic and its open children.
+
+With no ID, lists every epic not yet closed with its progress.
+
+Example:
+  mob epic
+  mob epic bd-a1b2`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		now := time.Now()
+
+		if len(args) == 1 {
+			p, err := store.EpicProgress(args[0], now)
+			if err != nil {
+				fail(err)
+			}
+			printEpicProgress(p, now)
+			return
+		}
+
+		beads, err := store.List(storage.BeadFilter{})
+		if err != nil {
+			fail(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		shown := 0
+		for _, b := range beads {
+			if b.Type != models.BeadTypeEpic || b.Status == models.BeadStatusClosed {
+				continue
+			}
+			p := models.RollUp(b, beads, now)
+			due := ""
+			if p.EarliestDue != nil {
+				due = formatDeadline(*p.EarliestDue)
+			}
+			fmt.Fprintf(w, "%s\t%s %3d%%\t%d/%d\t%s\t%s\n", valueStyle.Render(b.ID), progressBar(p.Percent(), 10), p.Percent(), p.Closed(), p.Total(), truncate(b.Title, 50), due)
+			shown++
+		}
+		if shown == 0 {
+			fmt.Println(mutedStyle.Render("No open epics"))
+			return
+		}
+		w.Flush()
+	},
+}
+
+// printEpicProgress shows one epic's roll-up and its children
+func printEpicProgress(p *models.EpicProgress, now time.Time) {
+	fmt.Printf("%s %s %s\n", headerStyle.Render("Epic"), valueStyle.Render(p.Epic.ID), p.Epic.Title)
+	fmt.Printf("  %s %d%%  %d of %d children closed\n", progressBar(p.Percent(), 20), p.Percent(), p.Closed(), p.Total())
+	if p.Total() == 0 {
+		fmt.Println(mutedStyle.Render("  No child beads yet; mob bead split adds some"))
+		return
+	}
+	fmt.Printf("  %s\n", epicStatusSummary(p))
+	if p.EarliestDue != nil {
+		fmt.Printf("  Next due:   %s %s\n", formatDeadline(*p.EarliestDue), mutedStyle.Render(p.EarliestDueID))
+	}
+	if p.Overdue > 0 {
+		fmt.Printf("  %s\n", errorStyle.Render(fmt.Sprintf("%d overdue", p.Overdue)))
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, b := range p.Children {
+		assignee := b.Assignee
+		if assignee == "" {
+			assignee = "-"
+		}
+		status := string(b.Status)
+		if b.Overdue(now) {
+			status = errorStyle.Render("overdue") + " " + status
+		}
+		fmt.Fprintf(w, "  %s\tP%d\t%s\t%s\t%s\n", valueStyle.Render(b.ID), b.Priority, status, assignee, truncate(b.Title, 50))
+	}
+	w.Flush()
+}
+
+// epicStatusSummary lists the child counts per status, e.g. "2 open · 3 closed"
+func epicStatusSummary(p *models.EpicProgress) string {
+	var parts []string
+	for _, status := range models.StatusOrder {
+		if n := p.ByStatus[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, strings.ReplaceAll(string(status), "_", " ")))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// progressBar draws percent as a bar width cells wide
+func progressBar(percent, width int) string {
+	filled := percent * width / 100
+	return successStyle.Render(strings.Repeat("█", filled)) + mutedStyle.Render(strings.Repeat("░", width-filled))
+}
+
+func init() {
+	rootCmd.AddCommand(epicCmd)
+}
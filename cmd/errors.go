@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// Exit codes by error kind, so scripts can tell a missing bead from a busy store
+const (
+	exitError     = 1  // anything unclassified
+	exitInvalid   = 2  // bad input
+	exitNotFound  = 3  // bead, agent, turf or soldati does not exist
+	exitConflict  = 4  // clashes with existing state
+	exitTransient = 75 // retry later (EX_TEMPFAIL)
+)
+
+// exitCode maps an error to the process exit code for its kind
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errkind.NotFound):
+		return exitNotFound
+	case errors.Is(err, errkind.Conflict):
+		return exitConflict
+	case errors.Is(err, errkind.Transient):
+		return exitTransient
+	case errors.Is(err, errkind.Invalid):
+		return exitInvalid
+	default:
+		return exitError
+	}
+}
+
+// fail prints err and exits with the code for its kind
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(exitCode(err))
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gabe/mob/internal/merge"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"bead not found", fmt.Errorf("%w: bd-1234", storage.ErrBeadNotFound), exitNotFound},
+		{"agent not found", registry.ErrAgentNotFound, exitNotFound},
+		{"queue conflict", merge.ErrItemExists, exitConflict},
+		{"store locked", fmt.Errorf("failed to close: %w", storage.ErrStoreLocked), exitTransient},
+		{"invalid message", storage.ErrInvalidMessage, exitInvalid},
+		{"unclassified", errors.New("boom"), exitError},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("%s: exitCode() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
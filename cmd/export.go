@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/metrics"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/transcript"
+	"github.com/gabe/mob/internal/turf"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportSince string
+	exportCSV   bool
+
+	calibrationSince string
+	calibrationBy    string
+	calibrationCSV   bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export mob data for analysis elsewhere",
+}
+
+var exportMetricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Export per-day throughput, cost, tokens, agents and merges",
+	Long: `Print one row per day covering:
+
+  beads_created, beads_closed     bead throughput
+  cost_usd                        agent spend recorded by the daemon
+  input_tokens, output_tokens     from transcripts of sessions run in turfs
+  sessions                        agent sessions active that day
+  agents_spawned, agents_active   from the activity feed
+  merges_landed, merges_failed    merge queue outcomes
+
+The daemon keeps 90 days of spend and the activity feed keeps its most recent
+entries, so older days may show only bead and token figures.
+
+Example:
+  mob export metrics --since 90d --csv > mob-metrics.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		since, err := config.ParseRetention(exportSince)
+		if err != nil || since <= 0 {
+			fail(errkind.New(errkind.Invalid, fmt.Sprintf("invalid --since %q (e.g. 90d or 72h)", exportSince)))
+		}
+		now := time.Now()
+		from := now.Add(-since)
+
+		src, err := loadMetricsSources(from)
+		if err != nil {
+			fail(err)
+		}
+		days := metrics.Daily(src, from, now)
+
+		if exportCSV {
+			if err := metrics.WriteCSV(os.Stdout, days); err != nil {
+				fail(err)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tCREATED\tCLOSED\tCOST\tTOKENS IN/OUT\tSESSIONS\tSPAWNED\tACTIVE\tMERGED\tFAILED")
+		for _, d := range days {
+			fmt.Fprintf(w, "%s\t%d\t%d\t$%.2f\t%d/%d\t%d\t%d\t%d\t%d\t%d\n",
+				d.Date, d.BeadsCreated, d.BeadsClosed, d.CostUSD, d.InputTokens, d.OutputTokens,
+				d.Sessions, d.AgentsSpawned, d.AgentsActive, d.MergesLanded, d.MergesFailed)
+		}
+		w.Flush()
+	},
+}
+
+var exportCalibrationCmd = &cobra.Command{
+	Use:   "calibration",
+	Short: "Compare bead estimates with what the work actually cost",
+	Long: `Compare the preflight estimates recorded by estimate_task with what each
+closed bead went on to cost, grouped by bead type and by agent (or by the
+estimate's scope). For each group:
+
+  ratio       actual over estimated cost; above 1 the work ran over
+  mean miss   average size of a bead's miss, as a share of its estimate
+  on target   beads that landed within 50% of their estimate
+  avg cost    average actual cost of one bead
+  avg time    average time from work starting to the bead closing
+
+Groups that run well over their estimates, or cost more than they are worth,
+are candidates for doing by hand rather than delegating.
+
+Example:
+  mob export calibration --since 90d
+  mob export calibration --by scope --csv > calibration.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		since, err := config.ParseRetention(calibrationSince)
+		if err != nil || since <= 0 {
+			fail(errkind.New(errkind.Invalid, fmt.Sprintf("invalid --since %q (e.g. 90d or 72h)", calibrationSince)))
+		}
+		groupings := []metrics.Grouping{metrics.ByType, metrics.ByAgent}
+		switch by := metrics.Grouping(calibrationBy); by {
+		case "":
+		case metrics.ByType, metrics.ByAgent, metrics.ByScope:
+			groupings = []metrics.Grouping{by}
+		default:
+			fail(errkind.New(errkind.Invalid, fmt.Sprintf("invalid --by %q (expected type, agent or scope)", calibrationBy)))
+		}
+
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		beads, err := store.List(storage.BeadFilter{Status: models.BeadStatusClosed})
+		if err != nil {
+			fail(err)
+		}
+
+		from := time.Now().Add(-since)
+		var rows []*metrics.Calibration
+		for _, by := range groupings {
+			rows = append(rows, metrics.Calibrate(beads, by, from)...)
+		}
+
+		if calibrationCSV {
+			if err := metrics.WriteCalibrationCSV(os.Stdout, rows); err != nil {
+				fail(err)
+			}
+			return
+		}
+		if len(rows) == 0 {
+			fmt.Println(mutedStyle.Render("No closed beads with estimates in that period."))
+			return
+		}
+
+		preflight := 0.0
+		for i, by := range groupings {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(sectionStyle.Render("By " + string(by)))
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "  GROUP\tBEADS\tESTIMATED\tACTUAL\tRATIO\tMEAN MISS\tON TARGET\tAVG COST\tAVG TIME")
+			for _, c := range rows {
+				if c.By != by {
+					continue
+				}
+				if i == 0 {
+					preflight += c.PreflightUSD
+				}
+				avgTime := "-"
+				if d := c.AvgDuration(); d > 0 {
+					avgTime = d.Round(time.Minute).String()
+				}
+				fmt.Fprintf(w, "  %s\t%d\t$%.2f\t$%.2f\t%.2fx\t%.0f%%\t%d/%d\t$%.2f\t%s\n",
+					c.Group, c.Beads, c.EstimatedUSD, c.ActualUSD, c.Ratio(),
+					c.MeanError()*100, c.OnTarget, c.Beads, c.AvgCost(), avgTime)
+			}
+			w.Flush()
+		}
+		fmt.Println()
+		fmt.Println(mutedStyle.Render(fmt.Sprintf("Producing these estimates cost $%.2f.", preflight)))
+	},
+}
+
+// loadMetricsSources reads the history metrics are computed from
+func loadMetricsSources(since time.Time) (metrics.Sources, error) {
+	var src metrics.Sources
+
+	mobDir, err := getMobDir()
+	if err != nil {
+		return src, err
+	}
+
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		return src, err
+	}
+	beadStore, err := storage.NewBeadStore(beadsPath)
+	if err != nil {
+		return src, err
+	}
+	if src.Beads, err = beadStore.List(storage.BeadFilter{}); err != nil {
+		return src, err
+	}
+
+	if src.Spend, err = storage.SpendByDay(storage.SpendPath(mobDir)); err != nil {
+		return src, err
+	}
+
+	activity, err := storage.NewActivityStore(storage.ActivityDir(mobDir))
+	if err != nil {
+		return src, err
+	}
+	if src.Activity, err = activity.List(storage.ActivityFilter{Since: since}); err != nil {
+		return src, err
+	}
+
+	workDirs := []string{mobDir}
+	if turfsPath, err := getTurfsPath(); err == nil {
+		if mgr, err := turf.NewManager(turfsPath); err == nil {
+			for _, t := range mgr.List() {
+				workDirs = append(workDirs, t.Path)
+			}
+		}
+	}
+	if projects, err := transcript.ProjectsDir(); err == nil {
+		paths, err := transcript.Sessions(projects, workDirs, since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		for _, path := range paths {
+			t, err := transcript.Load(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				continue
+			}
+			src.Transcripts = append(src.Transcripts, t)
+		}
+	}
+
+	return src, nil
+}
+
+func init() {
+	exportMetricsCmd.Flags().StringVar(&exportSince, "since", "30d", "How far back to export (e.g. 90d, 72h)")
+	exportMetricsCmd.Flags().BoolVar(&exportCSV, "csv", false, "Write CSV with a header row instead of a table")
+	exportCalibrationCmd.Flags().StringVar(&calibrationSince, "since", "90d", "Only beads closed within this long (e.g. 90d, 72h)")
+	exportCalibrationCmd.Flags().StringVar(&calibrationBy, "by", "", "Group by type, agent or scope (default: type and agent)")
+	exportCalibrationCmd.Flags().BoolVar(&calibrationCSV, "csv", false, "Write CSV with a header row instead of tables")
+	exportCmd.AddCommand(exportMetricsCmd)
+	exportCmd.AddCommand(exportCalibrationCmd)
+	rootCmd.AddCommand(exportCmd)
+}
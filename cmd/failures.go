@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/failures"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	failuresDeadOnly bool
+	failuresRetryAll bool
+)
+
+var failuresCmd = &cobra.Command{
+	Use:   "failures",
+	Short: "List and retry assignments that failed",
+	Long: `When a soldati's call errors or an associate fails, its bead is blocked and
+waits in the failure queue. The daemon reopens it after a backoff that
+doubles with each failure, handing it to a different agent once it has
+failed reassign_after times. After max_attempts it is left dead until you
+retry it. The policy is set under [failures] in config.toml:
+
+  [failures]
+  max_attempts = 3
+  backoff = "5m"
+  max_backoff = "1h"
+  reassign_after = 2
+
+Example:
+  mob failures list
+  mob failures retry bd-a1b2`,
+}
+
+var failuresListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show failed beads, their attempts and next retry",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		queue := failures.Open(mobDir, loadJobsConfig(mobDir).Failures)
+		entries, err := queue.List()
+		if err != nil {
+			fail(err)
+		}
+		if failuresDeadOnly {
+			var dead []*failures.Entry
+			for _, e := range entries {
+				if e.Status == failures.Dead {
+					dead = append(dead, e)
+				}
+			}
+			entries = dead
+		}
+		if len(entries) == 0 {
+			fmt.Println(mutedStyle.Render("No failed assignments."))
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "BEAD\tTURF\tSTATUS\tATTEMPTS\tAGENTS\tFAILED\tNEXT RETRY\tERROR")
+		for _, e := range entries {
+			status := string(e.Status)
+			next := "-"
+			switch e.Status {
+			case failures.Dead:
+				status = errorStyle.Render(status)
+			case failures.Waiting:
+				next = formatRetryTime(e.RetryAt)
+			}
+			turf := e.Turf
+			if turf == "" {
+				turf = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+				e.BeadID, turf, status, e.Attempts, strings.Join(e.Agents, ", "),
+				formatRelativeTime(e.FailedAt), next, truncate(e.LastError, 60))
+		}
+		w.Flush()
+	},
+}
+
+var failuresRetryCmd = &cobra.Command{
+	Use:   "retry [bead-id...]",
+	Short: "Reopen failed beads for another attempt now",
+	Long: `Reopen failed beads so the daemon assigns them again, without waiting for
+their backoff. A dead bead gets one more attempt. Agents that already failed
+a bead past reassign_after are still passed over.
+
+Example:
+  mob failures retry bd-a1b2
+  mob failures retry --all`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if failuresRetryAll == (len(args) > 0) {
+			fail(fmt.Errorf("give bead IDs or --all"))
+		}
+
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		trackActivity(store)
+
+		queue := failures.Open(mobDir, loadJobsConfig(mobDir).Failures)
+		ids := args
+		if failuresRetryAll {
+			entries, err := queue.List()
+			if err != nil {
+				fail(err)
+			}
+			for _, e := range entries {
+				if e.Status != failures.Requeued {
+					ids = append(ids, e.BeadID)
+				}
+			}
+			if len(ids) == 0 {
+				fmt.Println(mutedStyle.Render("No failed assignments to retry."))
+				return
+			}
+		}
+
+		var firstErr error
+		for _, id := range ids {
+			if _, err := queue.Get(id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				firstErr = orErr(firstErr, err)
+				continue
+			}
+			if err := queue.Retry(store, id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				firstErr = orErr(firstErr, err)
+				continue
+			}
+			fmt.Println(successStyle.Render("✓ Reopened " + id + " for another attempt"))
+		}
+		if firstErr != nil {
+			os.Exit(exitCode(firstErr))
+		}
+	},
+}
+
+// formatRetryTime describes when a retry is due
+func formatRetryTime(t time.Time) string {
+	if d := time.Until(t); d > 0 {
+		return "in " + d.Round(time.Second).String()
+	}
+	return "due"
+}
+
+// orErr keeps the first error seen
+func orErr(first, err error) error {
+	if first != nil {
+		return first
+	}
+	return err
+}
+
+func init() {
+	failuresListCmd.Flags().BoolVar(&failuresDeadOnly, "dead", false, "Only show beads out of attempts")
+	failuresRetryCmd.Flags().BoolVar(&failuresRetryAll, "all", false, "Retry every waiting and dead bead")
+	failuresCmd.AddCommand(failuresListCmd)
+	failuresCmd.AddCommand(failuresRetryCmd)
+	rootCmd.AddCommand(failuresCmd)
+}
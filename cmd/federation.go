@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/federation"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var federationCmd = &cobra.Command{
+	Use:   "federation",
+	Short: "Share beads with other mob instances",
+	Long: `Federation keeps a shared backlog across separate mob instances, such as
+one per machine or team. Peers are other mob directories reachable from
+here, declared in config.toml:
+
+  [federation]
+  instance = "laptop"             # defaults to the hostname
+
+  [[federation.peers]]
+  name = "team"
+  path = "/mnt/team/mob"          # the peer's mob directory
+  turf = "api"                    # turf pulled beads land in (optional)
+
+Only shared beads cross over. Each instance gives its copy a local ID and
+tracks where the bead started, so IDs never clash; when both sides changed a
+bead, the most recent update wins. Assignment, worktrees and dependencies
+stay local.
+
+Add a [jobs.<name>] table with kind = "federate" to sync on a schedule.
+
+Example:
+  mob federation share bd-a1b2
+  mob federation sync
+  mob federation pull team`,
+}
+
+var federationShareCmd = &cobra.Command{
+	Use:   "share <bead-id>...",
+	Short: "Mark beads to be pushed to peers",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		store, cfg := openFederation()
+
+		shared, err := store.Share(args, cfg.Federation.InstanceName())
+		if err != nil {
+			fail(err)
+		}
+		for _, b := range shared {
+			fmt.Println(successStyle.Render(fmt.Sprintf("✓ %s shared (origin %s/%s)", b.ID, b.Origin.Instance, b.Origin.ID)))
+		}
+	},
+}
+
+var federationPushCmd = &cobra.Command{
+	Use:   "push [peer]",
+	Short: "Copy shared beads to every peer, or one",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runFederation(args, federation.DirectionPush)
+	},
+}
+
+var federationPullCmd = &cobra.Command{
+	Use:   "pull [peer]",
+	Short: "Copy beads shared by every peer, or one, to this instance",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runFederation(args, federation.DirectionPull)
+	},
+}
+
+var federationSyncCmd = &cobra.Command{
+	Use:   "sync [peer]",
+	Short: "Push and then pull shared beads",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runFederation(args, federation.DirectionSync)
+	},
+}
+
+// openFederation opens the local bead store and the config naming the peers
+func openFederation() (*storage.BeadStore, *config.Config) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		fail(err)
+	}
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		fail(err)
+	}
+	store, err := storage.NewBeadStore(beadsPath)
+	if err != nil {
+		fail(err)
+	}
+	trackActivity(store)
+	return store, loadJobsConfig(mobDir)
+}
+
+// runFederation exchanges beads with the peer in args, or all of them
+func runFederation(args []string, dir federation.Direction) {
+	store, cfg := openFederation()
+
+	peer := ""
+	if len(args) == 1 {
+		peer = args[0]
+	}
+	results, err := federation.Sync(store, &cfg.Federation, peer, dir)
+	for _, res := range results {
+		fmt.Println(successStyle.Render("✓ " + res.String()))
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func init() {
+	federationCmd.AddCommand(federationShareCmd)
+	federationCmd.AddCommand(federationPushCmd)
+	federationCmd.AddCommand(federationPullCmd)
+	federationCmd.AddCommand(federationSyncCmd)
+	rootCmd.AddCommand(federationCmd)
+}
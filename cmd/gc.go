@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/gc"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gcDryRun bool
+	gcKinds  []string
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove stale sessions, transcripts and scratch files",
+	Long: `Remove artifacts that accumulate as agents run:
+
+  sessions      records of agents that finished
+  transcripts   Claude transcripts of sessions mob's agents ran (never your own)
+  mcp-configs   MCP config files other than the live one
+  tmp           scratch files under .mob/tmp
+
+How long each kind is kept comes from the [gc] section of config.toml.
+The daemon runs the same collection as its "gc" job (see mob jobs).
+
+Example:
+  mob gc --dry-run
+  mob gc --kind transcripts`,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		cfg, err := config.Load(filepath.Join(mobDir, "config.toml"))
+		if err != nil {
+			cfg = config.DefaultConfig()
+		}
+
+		policy, err := gc.PolicyFromConfig(cfg.GC)
+		if err != nil {
+			fail(err)
+		}
+		if len(gcKinds) > 0 {
+			policy, err = restrictPolicy(policy, gcKinds)
+			if err != nil {
+				fail(err)
+			}
+		}
+
+		report := gc.NewCollector(mobDir, registry.New(getRegistryPath())).Run(policy, gcDryRun)
+		printGCReport(report, policy)
+
+		if len(report.Errors) > 0 {
+			for _, err := range report.Errors {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			os.Exit(1)
+		}
+	},
+}
+
+// restrictPolicy keeps only the named artifact kinds
+func restrictPolicy(policy gc.Policy, kinds []string) (gc.Policy, error) {
+	restricted := gc.Policy{}
+	for _, name := range kinds {
+		kind := gc.Kind(name)
+		retention, ok := policy[kind]
+		if !ok {
+			return nil, fmt.Errorf("unknown kind %q (expected sessions, transcripts, mcp-configs, or tmp)", name)
+		}
+		restricted[kind] = retention
+	}
+	return restricted, nil
+}
+
+func printGCReport(report *gc.Report, policy gc.Policy) {
+	title := "Collected"
+	if report.DryRun {
+		title = "Reclaimable (dry run)"
+	}
+	fmt.Println(sectionStyle.Render(title))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, kind := range gc.AllKinds {
+		retention, ok := policy[kind]
+		if !ok {
+			continue
+		}
+		if retention == 0 {
+			fmt.Fprintf(w, "  %s\t%s\n", labelStyle.Render(string(kind)), mutedStyle.Render("kept forever"))
+			continue
+		}
+		fmt.Fprintf(w, "  %s\t%d\t%s\t%s\n",
+			labelStyle.Render(string(kind)),
+			report.Count(kind),
+			valueStyle.Render(gc.FormatBytes(report.Bytes(kind))),
+			mutedStyle.Render("older than "+formatRetention(retention)))
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s %s\n", headerStyle.Render("Total:"), valueStyle.Render(gc.FormatBytes(report.TotalBytes())))
+	if report.DryRun && len(report.Items) > 0 {
+		fmt.Println(mutedStyle.Render("Run without --dry-run to remove them."))
+	}
+}
+
+// formatRetention renders whole days as "7d" and anything else as a duration
+func formatRetention(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
+
+func init() {
+	gcCmd.Flags().BoolVarP(&gcDryRun, "dry-run", "n", false, "Report what would be removed without removing it")
+	gcCmd.Flags().StringSliceVarP(&gcKinds, "kind", "k", nil, "Only collect these kinds (sessions, transcripts, mcp-configs, tmp)")
+	rootCmd.AddCommand(gcCmd)
+}
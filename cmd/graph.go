@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gabe/mob/internal/display"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphTurf   string
+	graphFormat string
+	graphAll    bool
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the bead relationship graph",
+	Long: `Show how beads relate to each other: subtasks (ParentID), blocking
+dependencies (Blocks), and related beads (Related).
+
+By default the graph is drawn as an ASCII tree in the terminal. Use --format
+dot or --format mermaid to emit it for Graphviz or Mermaid rendering.
+Closed beads are hidden unless --all is given.
+
+Example:
+  mob graph
+  mob graph --turf backend --format mermaid
+  mob graph --all --format dot | dot -Tsvg > beads.svg`,
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+
+		beads, err := store.List(storage.BeadFilter{Turf: graphTurf})
+		if err != nil {
+			fail(err)
+		}
+
+		if !graphAll {
+			open := beads[:0]
+			for _, b := range beads {
+				if b.Status != models.BeadStatusClosed {
+					open = append(open, b)
+				}
+			}
+			beads = open
+		}
+
+		if len(beads) == 0 {
+			fmt.Fprintln(os.Stderr, "No beads found.")
+			return
+		}
+
+		g := display.BuildBeadGraph(beads)
+
+		switch graphFormat {
+		case "tree":
+			fmt.Print(display.RenderGraphTree(g, display.DefaultTreeOpts()))
+		case "dot":
+			fmt.Print(display.RenderDOT(g))
+		case "mermaid":
+			fmt.Print(display.RenderMermaid(g))
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unknown format %q (expected tree, dot, or mermaid)\n", graphFormat)
+			os.Exit(exitInvalid)
+		}
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphTurf, "turf", "", "Only include beads in this turf")
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "tree", "Output format: tree, dot, or mermaid")
+	graphCmd.Flags().BoolVarP(&graphAll, "all", "a", false, "Include closed beads")
+
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/search"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/transcript"
+	"github.com/spf13/cobra"
+)
+
+var (
+	grepTypes   []string
+	grepLimit   int
+	grepRebuild bool
+)
+
+var grepCmd = &cobra.Command{
+	Use:   "grep <query>",
+	Short: "Search beads, comments, transcripts, agent output, and daemon logs",
+	Long: `Search across all mob data in one command.
+
+Results are typed (bead, comment, transcript, output, log) and link back to
+their source: the bead ID, the agent, and when it happened. The daemon keeps
+the search index fresh on every patrol; without a running daemon the index is
+rebuilt from disk (agent output is only available while the daemon runs).
+
+Example:
+  mob grep "retry helper"
+  mob grep timeout --type log --type output
+  mob grep auth --rebuild`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query := strings.Join(args, " ")
+
+		var kinds []search.Kind
+		for _, t := range grepTypes {
+			kind, err := search.ParseKind(t)
+			if err != nil {
+				fail(err)
+			}
+			kinds = append(kinds, kind)
+		}
+
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		idx, err := loadSearchIndex(mobDir, grepRebuild)
+		if err != nil {
+			fail(err)
+		}
+
+		results := idx.Search(search.Query{Text: query, Kinds: kinds, Limit: grepLimit})
+		if len(results) == 0 {
+			fmt.Println(mutedStyle.Render("No matches."))
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				labelStyle.Render(string(r.Kind)),
+				valueStyle.Render(grepSourceLink(r)),
+				orDash(r.Agent),
+				mutedStyle.Render(grepTimestamp(r.Timestamp)),
+				r.Snippet)
+		}
+		w.Flush()
+	},
+}
+
+// loadSearchIndex reads the daemon-maintained index, building one from disk
+// when it's missing or a rebuild is requested
+func loadSearchIndex(mobDir string, rebuild bool) (*search.Index, error) {
+	path := search.DefaultPath(mobDir)
+	if !rebuild {
+		if idx, err := search.Load(path); err == nil {
+			return idx, nil
+		}
+	}
+
+	src := search.Sources{
+		Transcripts: make(map[string]string),
+		LogPath:     filepath.Join(mobDir, ".mob", "daemon.log"),
+	}
+
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		return nil, err
+	}
+	store, err := storage.NewBeadStore(beadsPath)
+	if err != nil {
+		return nil, err
+	}
+	if src.Beads, err = store.List(storage.BeadFilter{}); err != nil {
+		return nil, err
+	}
+
+	reg := registry.New(getRegistryPath())
+	if records, err := reg.List(); err == nil {
+		for _, record := range records {
+			if record.SessionID == "" {
+				continue
+			}
+			if p, err := transcript.Find(record.SessionID); err == nil {
+				src.Transcripts[record.Name] = p
+			}
+		}
+	}
+
+	idx := search.Build(src)
+	if err := idx.Save(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save search index: %v\n", err)
+	}
+	return idx, nil
+}
+
+// grepSourceLink returns the most specific pointer back to a result's source
+func grepSourceLink(r search.Result) string {
+	switch {
+	case r.BeadID != "":
+		return r.BeadID
+	case r.Kind == search.KindDaemonLog:
+		return "daemon.log"
+	case r.Source != "":
+		return r.Source
+	default:
+		return "-"
+	}
+}
+
+func grepTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("Jan 2 15:04")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func init() {
+	grepCmd.Flags().StringSliceVarP(&grepTypes, "type", "t", nil, "Only show results of these types (bead, comment, transcript, output, log)")
+	grepCmd.Flags().IntVarP(&grepLimit, "limit", "n", 50, "Maximum number of results (0 for all)")
+	grepCmd.Flags().BoolVar(&grepRebuild, "rebuild", false, "Rebuild the index from disk before searching")
+
+	rootCmd.AddCommand(grepCmd)
+}
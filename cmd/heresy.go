@@ -6,8 +6,12 @@ import (
 	"os"
 	"path/filepath"
 	"text/tabwriter"
+	"time"
 
+	"github.com/gabe/mob/internal/config"
 	"github.com/gabe/mob/internal/heresy"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/plugin"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/gabe/mob/internal/turf"
 	"github.com/spf13/cobra"
@@ -40,6 +44,11 @@ This command detects various types of heresies:
   - Import alias inconsistencies
 
 Each detected heresy can be converted to a bead for tracking and remediation.
+With --create-beads, [heresy] in config.toml decides how by severity:
+critical heresies are filed pending approval and notified at once (with
+prespawn_fix, a fix bead is lined up for an associate to start on approval),
+low ones are gathered into one chore bead per turf per week, and the rest
+are filed as open beads.
 
 If no turf is specified, uses the current directory.`,
 	Args: cobra.MaximumNArgs(1),
@@ -90,14 +99,12 @@ func init() {
 func runHeresyScan(cmd *cobra.Command, args []string) {
 	turfPath, err := resolveHeresyTurfPath(args)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	detector, err := createDetector(turfPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	fmt.Printf("Scanning for heresies in %s...\n\n", turfPath)
@@ -121,15 +128,27 @@ func runHeresyScan(cmd *cobra.Command, args []string) {
 	// Create beads if requested
 	if heresyCreateBeads {
 		fmt.Println("\nCreating beads for heresies...")
-		beadIDs, err := detector.CreateBeads(heresies)
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		cfg := loadJobsConfig(mobDir)
+		policy, err := heresyPolicy(cfg.Heresy)
+		if err != nil {
+			fail(err)
+		}
+		var notifier heresy.Notifier
+		if mgr := heresyNotifier(mobDir, cfg); mgr != nil {
+			defer mgr.Close()
+			notifier = mgr
+		}
+
+		filed, err := detector.File(heresies, policy, notifier, time.Now())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating beads: %v\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("Created %d heresy beads:\n", len(beadIDs))
-		for _, id := range beadIDs {
-			fmt.Printf("  %s\n", id)
-		}
+		printFiled(filed)
 	} else {
 		fmt.Println("\nUse --create-beads to create beads for these heresies.")
 	}
@@ -141,16 +160,14 @@ func runHeresyList(cmd *cobra.Command, args []string) {
 		var err error
 		turfPath, err = resolveHeresyTurfPath(args)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 	}
 
 	// Get bead store
 	beadDir, err := getBeadStorePath()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	beadStore, err := storage.NewBeadStore(beadDir)
@@ -158,6 +175,7 @@ func runHeresyList(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(os.Stderr, "Error creating bead store: %v\n", err)
 		os.Exit(1)
 	}
+	trackActivity(beadStore)
 
 	detector := heresy.New(turfPath, beadStore)
 
@@ -189,8 +207,7 @@ func runHeresyPurge(cmd *cobra.Command, args []string) {
 
 	detector, err := createDetector(cwd)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	fmt.Printf("Purging heresy %s...\n\n", beadID)
@@ -262,10 +279,79 @@ func createDetector(turfPath string) (*heresy.Detector, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bead store: %w", err)
 	}
+	trackActivity(beadStore)
 
 	return heresy.New(turfPath, beadStore), nil
 }
 
+// heresyPolicy reads the [heresy] thresholds
+func heresyPolicy(cfg config.HeresyConfig) (heresy.Policy, error) {
+	policy := heresy.DefaultPolicy()
+	policy.PrespawnFix = cfg.PrespawnFix
+	if cfg.Escalate != "" {
+		sev, err := heresy.ParseSeverity(cfg.Escalate)
+		if err != nil {
+			return policy, fmt.Errorf("[heresy] escalate: %w", err)
+		}
+		policy.Escalate = sev
+	}
+	if cfg.Batch != "" {
+		sev, err := heresy.ParseSeverity(cfg.Batch)
+		if err != nil {
+			return policy, fmt.Errorf("[heresy] batch: %w", err)
+		}
+		policy.Batch = sev
+	}
+	return policy, nil
+}
+
+// heresyNotifier gathers the notification backends escalations are sent
+// through, or returns nil when there are none
+func heresyNotifier(mobDir string, cfg *config.Config) *notify.Manager {
+	var notifiers []notify.Notifier
+	if cfg.Notifications.Terminal {
+		if n, err := notify.NewTerminalNotifier(); err == nil {
+			notifiers = append(notifiers, n)
+		}
+	}
+	plugins, errs := plugin.Discover(plugin.Dir(mobDir), mobDir)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	notifiers = append(notifiers, plugin.Notifiers(plugins)...)
+	if len(notifiers) == 0 {
+		return nil
+	}
+	mgr := notify.NewManager(notifiers...)
+	mgr.SetRedactor(loadRedactor())
+	return mgr
+}
+
+// printFiled lists the beads a scan filed, by how they were filed
+func printFiled(filed *heresy.Filed) {
+	printFiledIDs("Escalated for approval", filed.Escalated)
+	printFiledIDs("Fixes waiting on approval", filed.Fixes)
+	printFiledIDs("Created", filed.Created)
+	printFiledIDs("Added to this week's chore", filed.Batched)
+	if len(filed.Escalated) > 0 {
+		fmt.Printf("\nApprove escalated heresies with 'mob approve <bead-id>'")
+		if filed.Notified > 0 {
+			fmt.Printf(" (%d notification(s) sent)", filed.Notified)
+		}
+		fmt.Println(".")
+	}
+}
+
+func printFiledIDs(title string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	fmt.Printf("%s (%d):\n", title, len(ids))
+	for _, id := range ids {
+		fmt.Printf("  %s\n", id)
+	}
+}
+
 // printHeresies prints heresies in a formatted table
 func printHeresies(heresies []*heresy.Heresy) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -1,9 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/gabe/mob/internal/setup"
 	"github.com/spf13/cobra"
 )
@@ -14,9 +11,11 @@ var initCmd = &cobra.Command{
 	Long:  `Run the first-time setup wizard to configure mob directories and settings.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		wizard := setup.NewWizard()
+		if mobDir, err := getMobDir(); err == nil {
+			wizard.MobDir = mobDir
+		}
 		if err := wizard.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 	},
 }
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/jobs"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/plugin"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+	"github.com/spf13/cobra"
+)
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "List and run the daemon's recurring jobs",
+	Long: `Recurring daemon work is declared under [jobs] in config.toml, one table
+per job:
+
+  [jobs.nightly-sweep]
+  kind = "sweep"            # gc, sweep, summary or federate (defaults to the job name)
+  schedule = "0 2 * * *"    # cron, @hourly/@daily/@weekly/@monthly, or "@every 6h"
+  sweep = "review"          # review, bugs or all
+  turf = "api"              # empty sweeps every turf
+  enabled = true
+
+Without a [jobs.gc] table the daemon collects garbage every gc.interval.
+
+Example:
+  mob jobs list
+  mob jobs run-now nightly-sweep`,
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show each job's schedule, last run and next run",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		resolved, err := jobs.FromConfig(loadJobsConfig(mobDir))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if len(resolved) == 0 {
+			fmt.Println(mutedStyle.Render("No jobs configured."))
+			return
+		}
+
+		state, err := jobs.LoadState(jobs.StatePath(mobDir))
+		if err != nil {
+			fail(err)
+		}
+
+		now := time.Now()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tKIND\tSCHEDULE\tLAST RUN\tNEXT RUN\tRESULT")
+		for _, j := range resolved {
+			last := state[j.Name]
+
+			lastRun := "never"
+			if !last.At.IsZero() {
+				lastRun = formatRelativeTime(last.At)
+			}
+
+			nextRun := "disabled"
+			if j.Enabled {
+				nextRun = "-"
+				if next := j.NextRun(last, now); !next.IsZero() {
+					nextRun = next.Format("Jan 02 15:04")
+				}
+			}
+
+			result := last.Result
+			if last.Error != "" {
+				result = errorStyle.Render("failed: " + last.Error)
+			}
+			if result == "" {
+				result = "-"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", j.Name, j.Kind, j.Spec, lastRun, nextRun, truncate(result, 60))
+		}
+		w.Flush()
+	},
+}
+
+var jobsRunNowCmd = &cobra.Command{
+	Use:   "run-now <name>",
+	Short: "Run a job immediately, whatever its schedule",
+	Long: `Run a job once in the foreground. The run is recorded as the job's last
+run, so the daemon schedules its next run from now. Disabled jobs can be run
+this way too.
+
+Example:
+  mob jobs run-now gc`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		cfg := loadJobsConfig(mobDir)
+		resolved, err := jobs.FromConfig(cfg)
+		job, findErr := jobs.Find(resolved, args[0])
+		if findErr != nil {
+			if err != nil {
+				fail(err)
+			}
+			fail(findErr)
+		}
+
+		runner, err := newJobRunner(mobDir, cfg)
+		if err != nil {
+			fail(err)
+		}
+
+		statePath := jobs.StatePath(mobDir)
+		state, err := jobs.LoadState(statePath)
+		if err != nil {
+			fail(err)
+		}
+
+		fmt.Printf("Running %s (%s)...\n", valueStyle.Render(job.Name), job.Kind)
+		start := time.Now()
+		result, runErr := runner.Run(context.Background(), job, state[job.Name])
+		run := jobs.Run{At: start, Duration: time.Since(start), Result: result, Manual: true}
+		if runErr != nil {
+			run.Error = runErr.Error()
+		}
+		if err := jobs.RecordRun(statePath, job.Name, run); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		if runErr != nil {
+			fail(runErr)
+		}
+		fmt.Println(successStyle.Render("✓ " + result))
+	},
+}
+
+// loadJobsConfig reads config.toml, falling back to defaults
+func loadJobsConfig(mobDir string) *config.Config {
+	cfg, err := config.Load(filepath.Join(mobDir, "config.toml"))
+	if err != nil {
+		return config.DefaultConfig()
+	}
+	return cfg
+}
+
+// newJobRunner gathers the stores a job may need
+func newJobRunner(mobDir string, cfg *config.Config) (*jobs.Runner, error) {
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		return nil, err
+	}
+	beadStore, err := storage.NewBeadStore(beadsPath)
+	if err != nil {
+		return nil, err
+	}
+	trackActivity(beadStore)
+
+	activity, err := storage.NewActivityStore(storage.ActivityDir(mobDir))
+	if err != nil {
+		return nil, err
+	}
+
+	var turfs []models.Turf
+	if turfsPath, err := getTurfsPath(); err == nil {
+		if mgr, err := turf.NewManager(turfsPath); err == nil {
+			turfs = mgr.List()
+		}
+	}
+
+	plugins, errs := plugin.Discover(plugin.Dir(mobDir), mobDir)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	return &jobs.Runner{
+		MobDir:    mobDir,
+		Config:    cfg,
+		Beads:     beadStore,
+		Registry:  registry.New(getRegistryPath()),
+		Activity:  activity,
+		Turfs:     turfs,
+		Detectors: plugin.Detectors(plugins),
+	}, nil
+}
+
+func init() {
+	jobsCmd.AddCommand(jobsListCmd)
+	jobsCmd.AddCommand(jobsRunNowCmd)
+	rootCmd.AddCommand(jobsCmd)
+}
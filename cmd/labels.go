@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "List the labels in use, with how many beads carry each",
+	Long: `List every label on a bead with the number of open beads carrying it and
+the total including closed ones, busiest first.
+
+Use mob list --label <name> to see the beads behind a count.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		filter := storage.BeadFilter{}
+		filter.Turf, _ = cmd.Flags().GetString("turf")
+		beads, err := store.List(filter)
+		if err != nil {
+			fail(err)
+		}
+
+		counts := storage.CountLabels(beads)
+		if len(counts) == 0 {
+			fmt.Println(mutedStyle.Render("No labels"))
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, c := range counts {
+			fmt.Fprintf(w, "%s\t%d open\t%d total\n", valueStyle.Render(c.Label), c.Open, c.Total)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	labelsCmd.Flags().String("turf", "", "Only count beads in this turf")
+	rootCmd.AddCommand(labelsCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List beads",
+	Long: `List beads, optionally filtered by status, turf, assignee or label. Closed
+beads are left out unless --status closed asks for them.
+
+--overdue lists only beads still open past their due date or SLA, most
+overdue first.
+
+Example:
+  mob list --turf api
+  mob list --overdue`,
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		filter := storage.BeadFilter{Status: models.BeadStatus(status)}
+		filter.Turf, _ = cmd.Flags().GetString("turf")
+		filter.Assignee, _ = cmd.Flags().GetString("assignee")
+		filter.Label, _ = cmd.Flags().GetString("label")
+
+		var beads []*models.Bead
+		if overdue, _ := cmd.Flags().GetBool("overdue"); overdue {
+			all, err := store.ListOverdue(time.Now())
+			if err != nil {
+				fail(err)
+			}
+			for _, b := range all {
+				if filter.Match(b) {
+					beads = append(beads, b)
+				}
+			}
+		} else {
+			all, err := store.List(filter)
+			if err != nil {
+				fail(err)
+			}
+			for _, b := range all {
+				if status != "" || b.Status != models.BeadStatusClosed {
+					beads = append(beads, b)
+				}
+			}
+		}
+
+		if len(beads) == 0 {
+			fmt.Println(mutedStyle.Render("No beads"))
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, b := range beads {
+			due := ""
+			if deadline, ok := b.Deadline(); ok {
+				due = formatDeadline(deadline)
+				if b.Overdue(time.Now()) {
+					due = errorStyle.Render("overdue") + " " + due
+				}
+			}
+			assignee := b.Assignee
+			if assignee == "" {
+				assignee = "-"
+			}
+			fmt.Fprintf(w, "%s\tP%d\t%s\t%s\t%s\t%s\n", valueStyle.Render(b.ID), b.Priority, b.Status, assignee, truncate(b.Title, 50), due)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	listCmd.Flags().String("status", "", "Only beads with this status (open, in_progress, blocked, closed, ...)")
+	listCmd.Flags().String("turf", "", "Only beads in this turf")
+	listCmd.Flags().String("assignee", "", "Only beads assigned to this agent")
+	listCmd.Flags().String("label", "", "Only beads carrying this label")
+	listCmd.Flags().Bool("overdue", false, "Only beads still open past their due date or SLA")
+	rootCmd.AddCommand(listCmd)
+}
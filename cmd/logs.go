@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/gabe/mob/internal/models"
@@ -19,14 +20,12 @@ var logsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		beadsPath, err := getBeadsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		store, err := storage.NewBeadStore(beadsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if len(args) > 0 {
@@ -43,8 +42,7 @@ var logsCmd = &cobra.Command{
 func showBeadLogs(store *storage.BeadStore, beadID string) {
 	bead, err := store.Get(beadID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	fmt.Printf("%s: %s\n", headerStyle.Render("Bead"), valueStyle.Render(bead.ID))
@@ -70,6 +68,9 @@ func showBeadLogs(store *storage.BeadStore, beadID string) {
 		switch event.Type {
 		case models.BeadEventTypeCreated:
 			description = fmt.Sprintf("Created by %s", actor)
+			if event.Comment != "" {
+				description += fmt.Sprintf(" (%s)", event.Comment)
+			}
 		case models.BeadEventTypeStatusChange:
 			description = fmt.Sprintf("Status changed: %s → %s", event.From, event.To)
 		case models.BeadEventTypeAssigned:
@@ -86,6 +87,12 @@ func showBeadLogs(store *storage.BeadStore, beadID string) {
 			description = fmt.Sprintf("%s completed work", actor)
 		case models.BeadEventTypeWorktreeCreate:
 			description = fmt.Sprintf("Worktree created: %s", event.Comment)
+		case models.BeadEventTypeSplit:
+			description = fmt.Sprintf("Split by %s into %s", actor, strings.ReplaceAll(event.To, ",", ", "))
+		case models.BeadEventTypeCloned:
+			description = fmt.Sprintf("Cloned by %s as %s", actor, event.To)
+		case models.BeadEventTypePriorityAged:
+			description = fmt.Sprintf("Priority aged: P%s → P%s", event.From, event.To)
 		default:
 			description = fmt.Sprintf("%s: %s", event.Type, event.Comment)
 		}
@@ -102,8 +109,7 @@ func showRecentLogs(store *storage.BeadStore) {
 	// Get all beads
 	allBeads, err := store.List(storage.BeadFilter{})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	if len(allBeads) == 0 {
@@ -179,6 +185,12 @@ func showRecentLogs(store *storage.BeadStore) {
 			description = fmt.Sprintf("%s completed by %s", truncate(item.bead.Title, 25), actor)
 		case models.BeadEventTypeWorktreeCreate:
 			description = fmt.Sprintf("%s worktree created", truncate(item.bead.Title, 30))
+		case models.BeadEventTypeSplit:
+			description = fmt.Sprintf("%s split into %d beads", truncate(item.bead.Title, 25), len(strings.Split(item.event.To, ",")))
+		case models.BeadEventTypeCloned:
+			description = fmt.Sprintf("%s cloned as %s", truncate(item.bead.Title, 25), item.event.To)
+		case models.BeadEventTypePriorityAged:
+			description = fmt.Sprintf("%s aged to P%s", truncate(item.bead.Title, 30), item.event.To)
 		default:
 			description = truncate(item.bead.Title, 40)
 		}
@@ -198,7 +210,7 @@ func formatBeadStatus(status models.BeadStatus) string {
 		return successStyle.Render(string(status))
 	case models.BeadStatusBlocked:
 		return errorStyle.Render(string(status))
-	case models.BeadStatusPendingApproval:
+	case models.BeadStatusPendingApproval, models.BeadStatusInReview:
 		return warningStyle.Render(string(status))
 	case models.BeadStatusClosed:
 		return mutedStyle.Render(string(status))
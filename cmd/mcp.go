@@ -5,8 +5,12 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/approval"
+	"github.com/gabe/mob/internal/config"
 	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/plugin"
+	"github.com/gabe/mob/internal/redact"
 	"github.com/gabe/mob/internal/registry"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/gabe/mob/internal/turf"
@@ -15,7 +19,8 @@ import (
 
 var (
 	mcpRegistryPath string
-	mcpMobDir       string
+	mcpTurf         string
+	mcpConfirm      bool
 )
 
 var mcpServerCmd = &cobra.Command{
@@ -25,14 +30,10 @@ var mcpServerCmd = &cobra.Command{
 	Hidden: true, // Hidden because it's invoked by Claude, not humans
 	Run: func(cmd *cobra.Command, args []string) {
 		// Determine mob directory
-		mobDir := mcpMobDir
-		if mobDir == "" {
-			var err error
-			mobDir, err = getMobDir()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting mob directory: %v\n", err)
-				os.Exit(1)
-			}
+		mobDir, err := getMobDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting mob directory: %v\n", err)
+			os.Exit(1)
 		}
 
 		// Determine registry path
@@ -43,15 +44,15 @@ var mcpServerCmd = &cobra.Command{
 
 		// Create registry and spawner
 		reg := registry.New(registryPath)
-		spawner := agent.NewSpawner()
+		spawner := newSpawner(mobDir)
 
 		// Create bead store
-		beadDir := filepath.Join(mobDir, ".mob", "beads")
-		beadStore, err := storage.NewBeadStore(beadDir)
+		beadStore, err := storage.NewBeadStore(storage.BeadsDir(mobDir))
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating bead store: %v\n", err)
 			os.Exit(1)
 		}
+		trackActivity(beadStore)
 
 		// Create turf manager
 		turfsFile := filepath.Join(mobDir, "turfs.toml")
@@ -63,6 +64,29 @@ var mcpServerCmd = &cobra.Command{
 
 		// Create and run MCP server
 		server := mcp.NewServer(reg, spawner, beadStore, turfMgr, mobDir)
+		if cfg, err := config.Load(filepath.Join(mobDir, "config.toml")); err == nil {
+			server.SetLimits(cfg.MCP)
+			redactor, err := redact.FromConfig(cfg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v; using the built-in redaction rules\n", err)
+			}
+			server.SetRedactor(redactor)
+		}
+		if mcpTurf != "" {
+			server.SetTurfScope(mcpTurf)
+		}
+		if mcpConfirm {
+			server.SetConfirm(approval.NewStore(approval.Path(mobDir)))
+		}
+
+		// Record notifications for the dashboard, and add tools and
+		// notification backends from ~/mob/plugins
+		server.AddNotifier(notify.NewFeed(notify.FeedPath(mobDir)))
+		plugins, errs := plugin.Discover(plugin.Dir(mobDir), mobDir)
+		errs = append(errs, server.AddPlugins(plugins)...)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 		if err := server.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
 			os.Exit(1)
@@ -72,6 +96,7 @@ var mcpServerCmd = &cobra.Command{
 
 func init() {
 	mcpServerCmd.Flags().StringVar(&mcpRegistryPath, "registry", "", "Path to agent registry file")
-	mcpServerCmd.Flags().StringVar(&mcpMobDir, "mob-dir", "", "Mob directory path")
+	mcpServerCmd.Flags().StringVar(&mcpTurf, "turf", "", "Only expose beads, agents and worktrees of this turf")
+	mcpServerCmd.Flags().BoolVar(&mcpConfirm, "confirm", false, "Hold mutating tool calls until the user approves them")
 	rootCmd.AddCommand(mcpServerCmd)
 }
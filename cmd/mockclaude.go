@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gabe/mob/internal/mockclaude"
+	"github.com/spf13/cobra"
+)
+
+var mockClaudeCmd = &cobra.Command{
+	Use:   "mock-claude",
+	Short: "Stand in for the claude binary with scripted replies",
+	Long: `Acts as a single claude -p call, replaying the script named by
+MOB_MOCK_SCRIPT. Agents use it instead of claude when MOB_CLAUDE=mock.`,
+	Hidden:             true, // Hidden because it's invoked by the spawner, not humans
+	DisableFlagParsing: true, // Accepts claude's flags as is
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := mockclaude.Run(args, os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "mock-claude: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mockClaudeCmd)
+}
@@ -42,7 +42,7 @@ func runNudge(cmd *cobra.Command, args []string) {
 
 	if level < 0 || level > 2 {
 		fmt.Fprintf(os.Stderr, "Error: invalid nudge level %d (must be 0, 1, or 2)\n", level)
-		os.Exit(1)
+		os.Exit(exitInvalid)
 	}
 
 	nudgeLevel := nudge.NudgeLevel(level)
@@ -50,22 +50,19 @@ func runNudge(cmd *cobra.Command, args []string) {
 	// Get the soldati directory
 	soldatiDir, err := getSoldatiDir()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	// Get the hook base directory
 	hookBase, err := getHookBaseDir()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	// Create soldati manager to get list of soldati
 	soldatiMgr, err := soldati.NewManager(soldatiDir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	// Create spawner and nudger
@@ -98,7 +95,7 @@ func runNudge(cmd *cobra.Command, args []string) {
 		// Verify the soldati exists
 		if _, err := soldatiMgr.Get(args[0]); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: soldati %q not found\n", args[0])
-			os.Exit(1)
+			os.Exit(exitNotFound)
 		}
 	}
 
@@ -157,9 +154,9 @@ func runNudge(cmd *cobra.Command, args []string) {
 
 // getHookBaseDir returns the base directory for hook files
 func getHookBaseDir() (string, error) {
-	home, err := os.UserHomeDir()
+	mobDir, err := getMobDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, "mob", ".mob", "soldati"), nil
+	return filepath.Join(mobDir, ".mob", "soldati"), nil
 }
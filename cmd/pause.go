@@ -26,8 +26,7 @@ Use --hard for immediate pause without waiting for task completion.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		// Check if daemon is running
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gabe/mob/internal/plugin"
+	"github.com/spf13/cobra"
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage plugins",
+	Long: `Plugins add MCP tools, sweep detectors and notification backends
+without forking mob. Each plugin is a directory under ~/mob/plugins/ with a
+plugin.toml manifest and an executable that speaks JSON over stdin/stdout.`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List discovered plugins and what they provide",
+	Aliases: []string{"ls"},
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		dir := plugin.Dir(mobDir)
+		plugins, errs := plugin.Discover(dir, mobDir)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		if len(plugins) == 0 {
+			fmt.Printf("No plugins found in %s\n", dir)
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tTOOLS\tDETECTORS\tNOTIFIER")
+		for _, p := range plugins {
+			var tools, detectors []string
+			for _, t := range p.Tools {
+				tools = append(tools, t.Name)
+			}
+			for _, d := range p.Detectors {
+				detectors = append(detectors, d.Name+" ("+d.Sweep+")")
+			}
+			notifier := "no"
+			if p.Notifier {
+				notifier = "yes"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, listOrDash(tools), listOrDash(detectors), notifier)
+		}
+		w.Flush()
+	},
+}
+
+// listOrDash joins names for a table cell, showing "-" when there are none
+func listOrDash(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/spf13/cobra"
+)
+
+var promptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "Customise agent system prompts",
+	Long: `Agent system prompts can be overridden with template files in
+~/mob/prompts (soldati.md, associate.md, reviewer.md). Templates use Go
+text/template syntax and can refer to:
+
+  {{.Name}}         agent name (empty when not yet known)
+  {{.Turf}}         turf the agent works on
+  {{.BeadID}}       bead the agent was spawned for, if any
+  {{.Conventions}}  the turf's conventions section (appended automatically
+                    when the template does not use it)
+
+Templates are read on every spawn, so edits apply to the next agent.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		for _, name := range agent.PromptNames {
+			source := mutedStyle.Render("built-in")
+			if _, custom, err := agent.LoadPromptTemplate(mobDir, name); err != nil {
+				source = errorStyle.Render(err.Error())
+			} else if custom {
+				source = agent.PromptPath(mobDir, name)
+			}
+			fmt.Printf("%-10s %s\n", name, source)
+		}
+	},
+}
+
+var promptsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print a prompt template",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		text, _, err := agent.LoadPromptTemplate(mobDir, args[0])
+		if err != nil {
+			fail(errkind.Wrap(errkind.Invalid, err))
+		}
+		fmt.Print(text)
+	},
+}
+
+var promptsEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edit a prompt template in $EDITOR",
+	Long: `Edit a prompt template in $EDITOR, starting from the built-in prompt the
+first time. The template is checked after saving; a template that does not
+render is kept, but agents fall back to the built-in prompt until it is fixed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		name := args[0]
+		text, custom, err := agent.LoadPromptTemplate(mobDir, name)
+		if err != nil {
+			fail(errkind.Wrap(errkind.Invalid, err))
+		}
+
+		path := agent.PromptPath(mobDir, name)
+		if !custom {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				fail(err)
+			}
+			if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+				fail(err)
+			}
+		}
+
+		editor := os.Getenv("EDITOR")
+		if editor == "" {
+			editor = "vi"
+		}
+		edit := exec.Command(editor, path)
+		edit.Stdin = os.Stdin
+		edit.Stdout = os.Stdout
+		edit.Stderr = os.Stderr
+		if err := edit.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: editor failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		edited, _, err := agent.LoadPromptTemplate(mobDir, name)
+		if err == nil {
+			_, err = agent.RenderPrompt(edited, agent.PromptVars{Name: "example", Turf: "example", BeadID: "bd-0000"})
+		}
+		if err != nil {
+			fail(errkind.Wrap(errkind.Invalid, fmt.Errorf("saved %s, but it does not render (agents will use the built-in prompt): %w", path, err)))
+		}
+
+		fmt.Printf("Saved %s prompt (%s); it applies to the next agent spawned\n", name, path)
+	},
+}
+
+var promptsResetCmd = &cobra.Command{
+	Use:   "reset <name>",
+	Short: "Go back to the built-in prompt",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		name := args[0]
+		if _, err := agent.DefaultPrompt(name); err != nil {
+			fail(errkind.Wrap(errkind.Invalid, err))
+		}
+		path := agent.PromptPath(mobDir, name)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			fail(err)
+		}
+		fmt.Printf("Reset %s prompt to the built-in version\n", name)
+	},
+}
+
+func init() {
+	promptsCmd.AddCommand(promptsShowCmd)
+	promptsCmd.AddCommand(promptsEditCmd)
+	promptsCmd.AddCommand(promptsResetCmd)
+	rootCmd.AddCommand(promptsCmd)
+}
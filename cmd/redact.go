@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/redact"
+)
+
+// loadRedactor builds the [redaction] rules used before writing exports and
+// agent replies, falling back to the defaults without a readable config
+func loadRedactor() *redact.Redactor {
+	cfg := config.DefaultConfig()
+	if mobDir, err := getMobDir(); err == nil {
+		if loaded, err := config.Load(filepath.Join(mobDir, "config.toml")); err == nil {
+			cfg = loaded
+		}
+	}
+
+	redactor, err := redact.FromConfig(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using the built-in redaction rules\n", err)
+	}
+	return redactor
+}
@@ -2,19 +2,22 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/mcp"
 	"github.com/gabe/mob/internal/models"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 var rejectCmd = &cobra.Command{
-	Use:     "reject <bead-id> [reason]",
-	Short:   "Reject a pending bead",
-	Long:    `Reject a bead that is in pending_approval status, closing it with a reason.`,
+	Use:   "reject <bead-id> [reason]",
+	Short: "Reject a pending bead or request changes on a bead in review",
+	Long: `Reject a bead that is in pending_approval status, closing it with a reason.
+
+For a bead in review, the reason is sent back to the assigned soldati as feedback.`,
 	Aliases: []string{"rej"},
 	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -26,47 +29,59 @@ var rejectCmd = &cobra.Command{
 
 		beadsPath, err := getBeadsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 		store, err := storage.NewBeadStore(beadsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
+		trackActivity(store)
 
 		// Get the bead
 		bead, err := store.Get(beadID)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
-		// Check if it's in pending_approval status
-		if bead.Status != models.BeadStatusPendingApproval {
-			fmt.Fprintf(os.Stderr, "Error: Bead %s is not pending approval (current status: %s)\n", beadID, bead.Status)
-			os.Exit(1)
+		inReview := bead.Status == models.BeadStatusInReview
+		result, err := rejectBead(store, bead, reason)
+		if err != nil {
+			fail(err)
 		}
-
-		// Update status to closed with rejection reason
-		bead.Status = models.BeadStatusClosed
-		now := time.Now()
-		bead.ClosedAt = &now
-		if reason == "" {
-			reason = "Rejected by user"
+		if inReview {
+			fmt.Printf("✗ Requested changes on bead %s: %s\n", bead.ID, bead.Title)
+		} else {
+			fmt.Printf("✗ Rejected bead %s: %s\n", bead.ID, bead.Title)
 		}
-		bead.CloseReason = reason
+		fmt.Printf("  %s\n", result)
+	},
+}
 
-		_, err = store.Update(bead)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error updating bead: %v\n", err)
-			os.Exit(1)
+// rejectBead closes a pending bead with the reason, or sends a bead in review
+// back to its soldati with the reason as feedback. It describes what happened.
+func rejectBead(store *storage.BeadStore, bead *models.Bead, reason string) (string, error) {
+	if bead.Status == models.BeadStatusInReview {
+		if reason == "" {
+			return "", errkind.New(errkind.Invalid, "feedback is required when requesting changes on a bead in review")
 		}
+		return mcp.ResolveReview(reviewToolContext(store), bead.ID, false, "human", reason)
+	}
+	if bead.Status != models.BeadStatusPendingApproval {
+		return "", errkind.New(errkind.Conflict, fmt.Sprintf("Bead %s is not pending approval (current status: %s)", bead.ID, bead.Status))
+	}
 
-		fmt.Printf("✗ Rejected bead %s: %s\n", bead.ID, bead.Title)
-		fmt.Printf("  Status changed from pending_approval → closed\n")
-		fmt.Printf("  Reason: %s\n", reason)
-	},
+	// Close it with the rejection reason
+	if reason == "" {
+		reason = "Rejected by user"
+	}
+	now := time.Now()
+	bead.Status = models.BeadStatusClosed
+	bead.ClosedAt = &now
+	bead.CloseReason = reason
+	if _, err := store.Update(bead); err != nil {
+		return "", fmt.Errorf("updating bead: %w", err)
+	}
+	return fmt.Sprintf("Status changed from pending_approval → closed (reason: %s)", reason), nil
 }
 
 func init() {
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/metrics"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize how the mob's work is going",
+}
+
+var reportVelocityCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Compare estimated and actual working time per turf and soldati",
+	Long: `Summarize the work finished over a period, grouped by turf and by the
+soldati who did it. For each group:
+
+  finished    beads whose work was handed in during the period
+  per week    finished beads per week
+  avg time    average time from picking a bead up to handing it in
+  estimated   total time estimate of the finished beads that had one
+  actual      what those same beads took
+  ratio       actual over estimated: above 1 the work ran long
+
+Working time runs from the first time a bead goes in progress to the last
+time its work is handed in for review, approval or closure. Set estimates
+with mob add --estimate, mob bead estimate, or the time_estimate argument
+of create_bead and update_bead.
+
+Example:
+  mob report velocity --since 30d
+  mob report velocity --by soldati`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		period, err := config.ParseRetention(sinceFlag)
+		if err != nil || period <= 0 {
+			fail(errkind.New(errkind.Invalid, fmt.Sprintf("invalid --since %q (e.g. 30d or 72h)", sinceFlag)))
+		}
+		groupings := []metrics.Grouping{metrics.ByTurf, metrics.ByAgent}
+		switch by, _ := cmd.Flags().GetString("by"); by {
+		case "":
+		case "turf":
+			groupings = []metrics.Grouping{metrics.ByTurf}
+		case "soldati", "agent":
+			groupings = []metrics.Grouping{metrics.ByAgent}
+		default:
+			fail(errkind.New(errkind.Invalid, fmt.Sprintf("invalid --by %q (expected turf or soldati)", by)))
+		}
+
+		beadsPath, err := getBeadsPath()
+		if err != nil {
+			fail(err)
+		}
+		store, err := storage.NewBeadStore(beadsPath)
+		if err != nil {
+			fail(err)
+		}
+		beads, err := store.List(storage.BeadFilter{})
+		if err != nil {
+			fail(err)
+		}
+
+		from := time.Now().Add(-period)
+		for i, by := range groupings {
+			rows := metrics.MeasureVelocity(beads, by, from)
+			if i == 0 && len(rows) == 0 {
+				fmt.Println(mutedStyle.Render("No work finished in that period."))
+				return
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			title := "By turf"
+			if by == metrics.ByAgent {
+				title = "By soldati"
+			}
+			fmt.Println(sectionStyle.Render(title))
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "  GROUP\tFINISHED\tPER WEEK\tAVG TIME\tESTIMATED\tACTUAL\tRATIO")
+			for _, v := range rows {
+				avg, estimated, actual, ratio := "-", "-", "-", "-"
+				if d := v.AvgTime(); d > 0 {
+					avg = roughDuration(d)
+				}
+				if v.Estimated > 0 {
+					estimated = fmt.Sprintf("%s (%d)", roughDuration(v.EstimatedTime), v.Estimated)
+					actual = roughDuration(v.ActualTime)
+					ratio = fmt.Sprintf("%.2fx", v.Ratio())
+				}
+				fmt.Fprintf(w, "  %s\t%d\t%.1f\t%s\t%s\t%s\t%s\n", v.Group, v.Finished, v.PerWeek(period), avg, estimated, actual, ratio)
+			}
+			w.Flush()
+		}
+	},
+}
+
+func init() {
+	reportVelocityCmd.Flags().String("since", "30d", "Only work finished within this long (e.g. 30d, 72h)")
+	reportVelocityCmd.Flags().String("by", "", "Group by turf or soldati (default: both)")
+	reportCmd.AddCommand(reportVelocityCmd)
+	rootCmd.AddCommand(reportCmd)
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/gabe/mob/internal/errkind"
 	"github.com/gabe/mob/internal/models"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/spf13/cobra"
@@ -20,14 +22,12 @@ var reportsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		reportStore, err := storage.NewReportStore(filepath.Join(mobDir, ".mob", "reports"))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		// Parse filters
@@ -56,8 +56,7 @@ var reportsCmd = &cobra.Command{
 
 		reports, err := reportStore.List(filter)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if len(reports) == 0 {
@@ -110,21 +109,18 @@ var reportHandleCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		reportStore, err := storage.NewReportStore(filepath.Join(mobDir, ".mob", "reports"))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		reportID := args[0]
 		report, err := reportStore.MarkHandled(reportID)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		fmt.Printf("Report %s marked as handled.\n", report.ID)
@@ -138,27 +134,92 @@ var reportShowCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		reportStore, err := storage.NewReportStore(filepath.Join(mobDir, ".mob", "reports"))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		reportID := args[0]
 		report, err := reportStore.Get(reportID)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		printReportDetail(report)
 	},
 }
 
+var reportAnswerCmd = &cobra.Command{
+	Use:   "answer <report-id> <answer...>",
+	Short: "Answer an agent's request for human input",
+	Long: `Answer a question an agent asked with request_human_input.
+
+The answer is returned to the agent the next time it checks with
+get_human_input, posted as a comment on the bead, and dropped in the
+agent's inbox.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+
+		reportStore, err := storage.NewReportStore(filepath.Join(mobDir, ".mob", "reports"))
+		if err != nil {
+			fail(err)
+		}
+
+		answer := strings.TrimSpace(strings.Join(args[1:], " "))
+		if answer == "" {
+			fail(errkind.New(errkind.Invalid, "answer must not be empty"))
+		}
+
+		by, _ := cmd.Flags().GetString("as")
+		if by == "" {
+			by = defaultWatcher()
+		}
+
+		report, err := reportStore.Answer(args[0], answer, by)
+		if err != nil {
+			fail(err)
+		}
+
+		if report.BeadID != "" {
+			beadsPath, err := getBeadsPath()
+			if err == nil {
+				var beadStore *storage.BeadStore
+				if beadStore, err = storage.NewBeadStore(beadsPath); err == nil {
+					trackActivity(beadStore)
+					err = beadStore.AddComment(report.BeadID, by, fmt.Sprintf("Answered %s: %s", report.ID, answer))
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to comment on %s: %v\n", report.BeadID, err)
+			}
+		}
+
+		if report.AgentName != "" {
+			messageStore, err := storage.NewMessageStore(filepath.Join(mobDir, ".mob", "inbox"))
+			if err == nil {
+				_, err = messageStore.Send(&models.AgentMessage{
+					From:   by,
+					To:     report.AgentName,
+					BeadID: report.BeadID,
+					Body:   fmt.Sprintf("Answer to your question %s (%q): %s", report.ID, truncate(report.Message, 80), answer),
+				})
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to message %s: %v\n", report.AgentName, err)
+			}
+		}
+
+		fmt.Printf("Answered %s.\n", report.ID)
+	},
+}
+
 func printReportDetail(r *models.AgentReport) {
 	fmt.Printf("Report: %s\n", r.ID)
 	fmt.Printf("  Type:      %s\n", r.Type)
@@ -177,10 +238,19 @@ func printReportDetail(r *models.AgentReport) {
 	fmt.Printf("  Status:    %s\n", status)
 	fmt.Printf("  Timestamp: %s\n", r.Timestamp.Format(time.RFC3339))
 	fmt.Printf("\nMessage:\n%s\n", r.Message)
+	if len(r.Options) > 0 {
+		fmt.Printf("\nOptions:\n")
+		for _, o := range r.Options {
+			fmt.Printf("  - %s\n", o)
+		}
+	}
+	if r.Answered() {
+		fmt.Printf("\nAnswer (%s, %s):\n%s\n", r.AnsweredBy, r.AnsweredAt.Format(time.RFC3339), r.Answer)
+	}
 }
 
 func init() {
-	reportsCmd.Flags().String("type", "", "Filter by type (blocked, question, escalation, progress)")
+	reportsCmd.Flags().String("type", "", "Filter by type (blocked, question, escalation, progress, human_input)")
 	reportsCmd.Flags().String("agent", "", "Filter by agent name")
 	reportsCmd.Flags().String("bead", "", "Filter by bead ID")
 	reportsCmd.Flags().Bool("handled", false, "Show only handled reports")
@@ -188,6 +258,9 @@ func init() {
 
 	reportsCmd.AddCommand(reportHandleCmd)
 	reportsCmd.AddCommand(reportShowCmd)
+	reportsCmd.AddCommand(reportAnswerCmd)
+
+	reportAnswerCmd.Flags().String("as", "", "Name to answer as (default: first configured human, then $USER)")
 
 	rootCmd.AddCommand(reportsCmd)
 }
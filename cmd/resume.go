@@ -19,8 +19,7 @@ var resumeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		// Check if daemon is running
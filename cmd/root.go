@@ -6,24 +6,40 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gabe/mob/internal/daemon"
-	"github.com/gabe/mob/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+// MobHomeEnv names the environment variable that picks the mob directory
+// when --mob-dir is not given
+const MobHomeEnv = "MOB_HOME"
+
+// mobDirFlag is the --mob-dir flag every command accepts
+var mobDirFlag string
+
 var rootCmd = &cobra.Command{
 	Use:   "mob",
 	Short: "Mob - Claude Code Agent Orchestrator",
-	Long:  `A mafia-themed agent orchestrator for managing multiple Claude Code instances.`,
+	Long: `A mafia-themed agent orchestrator for managing multiple Claude Code instances.
+
+Mob keeps its config, beads, agents and logs in one directory: --mob-dir,
+else $MOB_HOME, else ~/mob. Separate directories (work and personal, say)
+are fully isolated from each other.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Agents, plugins and the daemon's children inherit the choice
+		if mobDir, err := getMobDir(); err == nil {
+			os.Setenv(MobHomeEnv, mobDir)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get mob directory
 		mobDir, err := getMobDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		d := daemon.New(mobDir, log.New(io.Discard, "", 0))
@@ -57,7 +73,7 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Run the TUI - this blocks until user exits
-		tuiErr := tui.Run()
+		tuiErr := runTUI()
 
 		// Clean up daemon if we started it
 		if daemonStartedByUs {
@@ -95,6 +111,33 @@ func stopDaemon(mobDir string) {
 	}
 }
 
+// getMobDir returns the mob directory: --mob-dir, else $MOB_HOME, else ~/mob
+func getMobDir() (string, error) {
+	dir := mobDirFlag
+	if dir == "" {
+		dir = os.Getenv(MobHomeEnv)
+	}
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		return filepath.Join(home, "mob"), nil
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	return filepath.Abs(dir)
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&mobDirFlag, "mob-dir", "", "mob directory (default: $MOB_HOME, else ~/mob)")
+}
+
 func Execute() error {
 	return rootCmd.Execute()
 }
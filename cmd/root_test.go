@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetMobDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(MobHomeEnv, "")
+	defer func() { mobDirFlag = "" }()
+
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{"default", "", "", filepath.Join(home, "mob")},
+		{"env", "", "/srv/work-mob", "/srv/work-mob"},
+		{"flag beats env", "/srv/personal-mob", "/srv/work-mob", "/srv/personal-mob"},
+		{"tilde", "~/side-mob", "", filepath.Join(home, "side-mob")},
+	}
+	for _, tt := range tests {
+		mobDirFlag = tt.flag
+		t.Setenv(MobHomeEnv, tt.env)
+		got, err := getMobDir()
+		if err != nil || got != tt.want {
+			t.Errorf("%s: getMobDir() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/workhours"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleOverride time.Duration
+	scheduleClear    bool
+	scheduleTurf     string
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Show working hours, or work outside them for a while",
+	Long: `Show when the daemon assigns and nudges work in each turf, and whether
+each is inside its window now.
+
+Working hours come from [schedule] in config.toml. A turf can have its own
+window, which replaces the mob-wide one for that turf:
+
+  [schedule]
+  working_hours = "08:00-20:00"
+
+  [schedule.turfs.batch]
+  working_hours = "22:00-06:00"   # off-peak only
+
+Outside a turf's window the daemon holds auto-assignment and nudges there,
+and picks them back up when the window opens. --override lifts the window
+for a while, for every turf or just --turf; --clear ends overrides early.
+
+Example:
+  mob schedule
+  mob schedule --override 2h --turf batch
+  mob schedule --clear`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		path := workhours.Path(mobDir)
+		overrides, err := workhours.Load(path)
+		if err != nil {
+			fail(err)
+		}
+		now := time.Now()
+		overrides.Prune(now)
+
+		key := scheduleTurf
+		if key == "" {
+			key = workhours.AllTurfs
+		}
+		switch {
+		case scheduleClear:
+			if scheduleTurf == "" {
+				overrides = workhours.Overrides{}
+			} else {
+				delete(overrides, key)
+			}
+			if err := workhours.Save(path, overrides); err != nil {
+				fail(err)
+			}
+			fmt.Printf("%s Overrides cleared; working hours apply again\n", successStyle.Render("✓"))
+			return
+		case scheduleOverride < 0:
+			fail(errkind.New(errkind.Invalid, "--override must be positive"))
+		case scheduleOverride > 0:
+			until := now.Add(scheduleOverride)
+			overrides[key] = until
+			if err := workhours.Save(path, overrides); err != nil {
+				fail(err)
+			}
+			where := "every turf"
+			if scheduleTurf != "" {
+				where = "turf " + scheduleTurf
+			}
+			fmt.Printf("%s Working outside hours in %s until %s\n", successStyle.Render("✓"), where, until.Format("15:04"))
+			fmt.Println(mutedStyle.Render("The daemon picks this up on its next patrol."))
+			return
+		}
+
+		cfg, err := config.Load(filepath.Join(mobDir, "config.toml"))
+		if err != nil {
+			cfg = config.DefaultConfig()
+		}
+		if err := cfg.Schedule.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		printSchedule(cfg.Schedule, overrides, now)
+	},
+}
+
+func printSchedule(s config.ScheduleConfig, overrides workhours.Overrides, now time.Time) {
+	fmt.Println(sectionStyle.Render("Working hours"))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printWindow(w, "all turfs", workhours.AllTurfs, s, overrides, now)
+	turfs := make([]string, 0, len(s.Turfs))
+	for name := range s.Turfs {
+		turfs = append(turfs, name)
+	}
+	sort.Strings(turfs)
+	for _, name := range turfs {
+		printWindow(w, name, name, s.ForTurf(name), overrides, now)
+	}
+	w.Flush()
+}
+
+func printWindow(w *tabwriter.Writer, label, turf string, s config.ScheduleConfig, overrides workhours.Overrides, now time.Time) {
+	window := s.WorkingHours
+	if window == "" {
+		window = "all day"
+	}
+	if len(s.WorkingDays) > 0 {
+		window += " " + strings.Join(s.WorkingDays, ",")
+	}
+	if s.Timezone != "" {
+		window += " " + s.Timezone
+	}
+
+	state := mutedStyle.Render("closed")
+	switch {
+	case overrides.Active(turf, now):
+		until := overrides[turf]
+		if overrides[workhours.AllTurfs].After(until) {
+			until = overrides[workhours.AllTurfs]
+		}
+		state = warningStyle.Render("override until " + until.Format("15:04"))
+	case s.IsWorkingTime(now):
+		state = successStyle.Render("open")
+	}
+	fmt.Fprintf(w, "  %s\t%s\t%s\n", labelStyle.Render(label), valueStyle.Render(window), state)
+}
+
+func init() {
+	scheduleCmd.Flags().DurationVar(&scheduleOverride, "override", 0, "Assign and nudge work outside working hours for this long, e.g. 2h")
+	scheduleCmd.Flags().BoolVar(&scheduleClear, "clear", false, "End overrides early")
+	scheduleCmd.Flags().StringVar(&scheduleTurf, "turf", "", "Apply --override or --clear to one turf only")
+	rootCmd.AddCommand(scheduleCmd)
+}
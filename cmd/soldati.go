@@ -27,20 +27,17 @@ var soldatiListCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		dir, err := getSoldatiDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		mgr, err := soldati.NewManager(dir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		list, err := mgr.List()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if len(list) == 0 {
@@ -57,7 +54,7 @@ var soldatiListCmd = &cobra.Command{
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NAME\tSTATUS\tTASK\tTASKS\tSUCCESS\tLAST ACTIVE")
+		fmt.Fprintln(w, "NAME\tSTATUS\tTASK\tTASKS\tSUCCESS\tLAST ACTIVE\tSKILLS")
 		for _, s := range list {
 			tasks := s.Stats.TasksCompleted + s.Stats.TasksFailed
 			successStr := "-"
@@ -70,7 +67,7 @@ var soldatiListCmd = &cobra.Command{
 			status := "idle"
 			task := "-"
 			if agent, ok := agentStatus[s.Name]; ok {
-				status = agent.Status
+				status = string(agent.Status)
 				if agent.Task != "" {
 					task = truncateStr(agent.Task, 30)
 				}
@@ -80,7 +77,12 @@ var soldatiListCmd = &cobra.Command{
 				}
 			}
 
-			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", s.Name, status, task, tasks, successStr, lastActive)
+			skills := "-"
+			if len(s.Skills) > 0 {
+				skills = strings.Join(s.Skills, ",")
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n", s.Name, status, task, tasks, successStr, lastActive, skills)
 		}
 		w.Flush()
 	},
@@ -93,14 +95,12 @@ var soldatiNewCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		dir, err := getSoldatiDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		mgr, err := soldati.NewManager(dir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		name := ""
@@ -110,14 +110,58 @@ var soldatiNewCmd = &cobra.Command{
 
 		s, err := mgr.Create(name)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
+		}
+		if skills, _ := cmd.Flags().GetStringSlice("skills"); len(skills) > 0 {
+			if err := mgr.SetSkills(s.Name, skills); err != nil {
+				fail(err)
+			}
 		}
 
 		fmt.Printf("Created soldati '%s'\n", s.Name)
 	},
 }
 
+var soldatiSkillsCmd = &cobra.Command{
+	Use:   "skills <name> [skill...]",
+	Short: "Show or set a soldati's skills",
+	Long: `Show a soldati's skills, or replace them with the ones given.
+
+Skills are tags like "frontend" or "rust". When auto-assigning, the daemon
+gives a bead to the idle soldati whose skills match most of its labels.
+Use --clear to remove them all.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := getSoldatiDir()
+		if err != nil {
+			fail(err)
+		}
+
+		mgr, err := soldati.NewManager(dir)
+		if err != nil {
+			fail(err)
+		}
+
+		name := args[0]
+		clearSkills, _ := cmd.Flags().GetBool("clear")
+		if len(args) > 1 || clearSkills {
+			if err := mgr.SetSkills(name, args[1:]); err != nil {
+				fail(err)
+			}
+		}
+
+		s, err := mgr.Get(name)
+		if err != nil {
+			fail(err)
+		}
+		if len(s.Skills) == 0 {
+			fmt.Println(mutedStyle.Render(fmt.Sprintf("'%s' has no skills", s.Name)))
+			return
+		}
+		fmt.Printf("'%s' skills: %s\n", s.Name, strings.Join(s.Skills, ", "))
+	},
+}
+
 var soldatiKillCmd = &cobra.Command{
 	Use:   "kill <name>",
 	Short: "Delete a soldati",
@@ -127,19 +171,16 @@ var soldatiKillCmd = &cobra.Command{
 
 		dir, err := getSoldatiDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		mgr, err := soldati.NewManager(dir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if err := mgr.Delete(name); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		// Also remove from registry if present
@@ -171,19 +212,17 @@ Example:
 		// Verify soldati exists
 		dir, err := getSoldatiDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		mgr, err := soldati.NewManager(dir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if _, err := mgr.Get(name); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: soldati '%s' not found\n", name)
-			os.Exit(1)
+			os.Exit(exitNotFound)
 		}
 
 		// Create hook manager and write assignment
@@ -233,19 +272,17 @@ Note: The soldati must be actively running for attachment to work.`,
 		// Verify soldati exists
 		dir, err := getSoldatiDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		mgr, err := soldati.NewManager(dir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if _, err := mgr.Get(name); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: soldati '%s' not found\n", name)
-			os.Exit(1)
+			os.Exit(exitNotFound)
 		}
 
 		// Check if soldati is running
@@ -266,26 +303,29 @@ Note: The soldati must be actively running for attachment to work.`,
 		if agent.Task != "" {
 			fmt.Printf("Current task: %s\n", agent.Task)
 		}
+		if agent.WorkDir != "" {
+			fmt.Printf("Working in: %s\n", agent.WorkDir)
+		}
 		fmt.Printf("Last active: %s\n", time.Since(agent.LastPing).Round(time.Second))
 	},
 }
 
 func getSoldatiDir() (string, error) {
-	home, err := os.UserHomeDir()
+	mobDir, err := getMobDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, "mob", "soldati"), nil
+	return filepath.Join(mobDir, "soldati"), nil
 }
 
 func getHookDir() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, "mob", ".mob", "soldati")
+	mobDir, _ := getMobDir()
+	return filepath.Join(mobDir, ".mob", "soldati")
 }
 
 func getRegistryPath() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, "mob", ".mob", "agents.json")
+	mobDir, _ := getMobDir()
+	return registry.DefaultPath(mobDir)
 }
 
 func truncateStr(s string, maxLen int) string {
@@ -297,11 +337,14 @@ func truncateStr(s string, maxLen int) string {
 
 func init() {
 	soldatiAssignCmd.Flags().StringVar(&soldatiAssignBeadID, "bead", "", "Bead ID to associate with the task")
+	soldatiNewCmd.Flags().StringSlice("skills", nil, "Skills to route matching beads by (comma-separated)")
+	soldatiSkillsCmd.Flags().Bool("clear", false, "Remove all skills")
 
 	soldatiCmd.AddCommand(soldatiListCmd)
 	soldatiCmd.AddCommand(soldatiNewCmd)
 	soldatiCmd.AddCommand(soldatiKillCmd)
 	soldatiCmd.AddCommand(soldatiAssignCmd)
 	soldatiCmd.AddCommand(soldatiAttachCmd)
+	soldatiCmd.AddCommand(soldatiSkillsCmd)
 	rootCmd.AddCommand(soldatiCmd)
 }
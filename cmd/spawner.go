@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/failover"
+)
+
+// newSpawner returns a spawner whose calls go through the mob's model
+// circuit breaker, so CLI and MCP calls fail over during an outage and count
+// towards it the same way the daemon's do
+func newSpawner(mobDir string) *agent.Spawner {
+	cfg, err := config.Load(filepath.Join(mobDir, "config.toml"))
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	spawner := agent.NewSpawner()
+	spawner.SetModelBreaker(failover.New(failover.Path(mobDir), cfg.Failover))
+	return spawner
+}
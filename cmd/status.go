@@ -13,7 +13,10 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gabe/mob/internal/daemon"
+	"github.com/gabe/mob/internal/failover"
+	"github.com/gabe/mob/internal/merge"
 	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/pool"
 	"github.com/gabe/mob/internal/registry"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/gabe/mob/internal/turf"
@@ -45,6 +48,9 @@ type statusOutput struct {
 	Beads    beadSummary  `json:"beads"`
 	Turfs    []turfInfo   `json:"turfs"`
 	Activity []activityEntry `json:"recent_activity,omitempty"`
+	SpawnQueue []spawnInfo `json:"spawn_queue,omitempty"`
+	Pool *poolInfo `json:"agent_pool,omitempty"`
+	Models []modelInfo `json:"models,omitempty"`
 }
 
 type daemonInfo struct {
@@ -65,18 +71,58 @@ type beadSummary struct {
 	InProgress      int `json:"in_progress"`
 	Open            int `json:"open"`
 	PendingApproval int `json:"pending_approval"`
+	InReview        int `json:"in_review"`
 	Blocked         int `json:"blocked"`
 	Closed          int `json:"closed"`
 }
 
 type turfInfo struct {
-	Name   string `json:"name"`
-	Path   string `json:"path"`
-	Agents int    `json:"agents"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Agents     int    `json:"agents"`
+	MergeQueue int    `json:"merge_queue"`
+}
+
+// spawnInfo is an associate spawn waiting for capacity
+type spawnInfo struct {
+	ID          string `json:"id"`
+	Turf        string `json:"turf"`
+	Task        string `json:"task"`
+	RequestedBy string `json:"requested_by,omitempty"`
+	Waiting     string `json:"waiting"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// poolInfo is the soldati working against the concurrency limits, and the
+// assignments waiting for room
+type poolInfo struct {
+	Working int          `json:"working"`
+	Limit   int          `json:"limit,omitempty"` // 0 = unlimited
+	Queue   []queuedWork `json:"queue,omitempty"`
+}
+
+// queuedWork is an assignment waiting for the agent pool
+type queuedWork struct {
+	Agent   string `json:"agent"`
+	Turf    string `json:"turf,omitempty"`
+	BeadID  string `json:"bead_id,omitempty"`
+	Waiting string `json:"waiting"`
+}
+
+// modelInfo is a model whose provider has been failing
+type modelInfo struct {
+	Model     string `json:"model"`
+	Circuit   string `json:"circuit"` // closed, open or half_open
+	Failures  int    `json:"failures"`
+	FailOver  string `json:"fail_over,omitempty"` // model calls go to while open
+	Since     string `json:"since,omitempty"`     // when the circuit opened
+	Retry     string `json:"retry,omitempty"`     // when the model is probed again
+	LastError string `json:"last_error,omitempty"`
 }
 
 type activityEntry struct {
 	Time    string `json:"time"`
+	Type    string `json:"type"`
 	Message string `json:"message"`
 }
 
@@ -92,6 +138,11 @@ var statusCmd = &cobra.Command{
 			return
 		}
 
+		if flagOneline || flagProm || flagWaybar {
+			showStatusBar()
+			return
+		}
+
 		if flagWatch {
 			// Watch mode - refresh every 2 seconds
 			for {
@@ -108,21 +159,50 @@ var statusCmd = &cobra.Command{
 func showBeadDetail(beadID string) {
 	beadsPath, err := getBeadsPath()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 	store, err := storage.NewBeadStore(beadsPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	bead, err := store.Get(beadID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 	printBeadDetail(bead)
+	printDiscoveryLinks(store, bead)
+}
+
+// printDiscoveryLinks shows where a bead was discovered and the follow-ups filed from it
+func printDiscoveryLinks(store *storage.BeadStore, b *models.Bead) {
+	if b.DiscoveredFrom != "" {
+		chain, err := store.GetDiscoveredChain(b.ID)
+		if err == nil {
+			links := []string{b.ID}
+			for _, parent := range chain {
+				links = append(links, fmt.Sprintf("%s (%s)", parent.ID, truncate(parent.Title, 30)))
+			}
+			// Non-bead origins like "sweep" end the chain
+			if len(chain) == 0 || chain[len(chain)-1].DiscoveredFrom != "" {
+				origin := b.DiscoveredFrom
+				if len(chain) > 0 {
+					origin = chain[len(chain)-1].DiscoveredFrom
+				}
+				links = append(links, origin)
+			}
+			fmt.Printf("\nDiscovered from:\n  %s\n", strings.Join(links, " ← "))
+		}
+	}
+
+	followups, err := store.GetDiscovered(b.ID)
+	if err != nil || len(followups) == 0 {
+		return
+	}
+	fmt.Println("\nFollow-ups:")
+	for _, f := range followups {
+		fmt.Printf("  %s  %-12s %s\n", f.ID, f.Status, f.Title)
+	}
 }
 
 func showStatus() {
@@ -154,6 +234,21 @@ func showStatus() {
 		fmt.Println()
 	}
 
+	if output.Pool != nil {
+		printPool(output.Pool)
+		fmt.Println()
+	}
+
+	if len(output.SpawnQueue) > 0 {
+		printSpawnQueue(output.SpawnQueue)
+		fmt.Println()
+	}
+
+	if len(output.Models) > 0 {
+		printModels(output.Models)
+		fmt.Println()
+	}
+
 	printBeadsSummary(output.Beads)
 	fmt.Println()
 
@@ -194,16 +289,65 @@ func collectStatusData(mobDir string) statusOutput {
 			output.Agents = append(output.Agents, agentInfo{
 				Name:     name,
 				Type:     a.Type,
-				Status:   a.Status,
+				Status:   string(a.Status),
 				Task:     truncate(a.Task, 40),
 				LastPing: formatRelativeTime(a.LastPing),
 			})
 		}
 	}
 
+	// Associate spawns waiting for capacity
+	if pending, err := storage.NewSpawnQueue(storage.SpawnQueuePath(mobDir)).List(time.Now()); err == nil {
+		for _, r := range pending {
+			output.SpawnQueue = append(output.SpawnQueue, spawnInfo{
+				ID:          r.ID,
+				Turf:        r.Turf,
+				Task:        truncate(r.Task, 40),
+				RequestedBy: r.RequestedBy,
+				Waiting:     formatRelativeTime(r.RequestedAt),
+				ExpiresAt:   r.ExpiresAt.Format(time.RFC3339),
+			})
+		}
+	}
+
+	// Soldati working and assignments waiting for room, as the daemon last published
+	if output.Daemon.Running {
+		if snap, err := pool.Load(pool.StatePath(mobDir)); err == nil && (len(snap.Running) > 0 || len(snap.Queue) > 0 || snap.Limits.Max > 0) {
+			info := &poolInfo{Working: len(snap.Running), Limit: snap.Limits.Max}
+			for _, w := range snap.Queue {
+				info.Queue = append(info.Queue, queuedWork{
+					Agent:   w.Agent,
+					Turf:    w.Turf,
+					BeadID:  w.BeadID,
+					Waiting: formatRelativeTime(w.QueuedAt),
+				})
+			}
+			output.Pool = info
+		}
+	}
+
+	// Models whose provider has been failing
+	breaker := failover.New(failover.Path(mobDir), loadJobsConfig(mobDir).Failover)
+	if circuits, err := breaker.Circuits(); err == nil {
+		now := time.Now()
+		for _, c := range circuits {
+			info := modelInfo{
+				Model:     c.Model,
+				Circuit:   string(c.State(now)),
+				Failures:  c.Failures,
+				LastError: c.LastError,
+			}
+			if !c.OpenedAt.IsZero() {
+				info.FailOver = breaker.Secondary()
+				info.Since = formatRelativeTime(c.OpenedAt)
+				info.Retry = c.OpenUntil.Format(time.Kitchen)
+			}
+			output.Models = append(output.Models, info)
+		}
+	}
+
 	// Bead summary
-	beadsPath := filepath.Join(mobDir, "beads")
-	store, err := storage.NewBeadStore(beadsPath)
+	store, err := storage.NewBeadStore(storage.BeadsDir(mobDir))
 	if err == nil {
 		allBeads, err := store.List(storage.BeadFilter{})
 		if err == nil {
@@ -215,6 +359,8 @@ func collectStatusData(mobDir string) statusOutput {
 					output.Beads.InProgress++
 				case models.BeadStatusPendingApproval:
 					output.Beads.PendingApproval++
+				case models.BeadStatusInReview:
+					output.Beads.InReview++
 				case models.BeadStatusBlocked:
 					output.Beads.Blocked++
 				case models.BeadStatusClosed:
@@ -225,6 +371,12 @@ func collectStatusData(mobDir string) statusOutput {
 	}
 
 	// Turf information
+	mergeDepths := map[string]int{}
+	if output.Daemon.Running {
+		if depths, err := merge.LoadDepths(merge.DepthsPath(mobDir)); err == nil {
+			mergeDepths = depths
+		}
+	}
 	turfMgr, err := turf.NewManager(filepath.Join(mobDir, "turfs.json"))
 	if err == nil {
 		turfs := turfMgr.List()
@@ -232,16 +384,16 @@ func collectStatusData(mobDir string) statusOutput {
 			// Count agents in this turf (simplified - count all agents for now)
 			agentCount := len(output.Agents)
 			output.Turfs = append(output.Turfs, turfInfo{
-				Name:   t.Name,
-				Path:   t.Path,
-				Agents: agentCount,
+				Name:       t.Name,
+				Path:       t.Path,
+				Agents:     agentCount,
+				MergeQueue: mergeDepths[t.Name],
 			})
 		}
 	}
 
-	// Recent activity from daemon log
-	logPath := filepath.Join(mobDir, ".mob", "daemon.log")
-	if entries := parseRecentActivity(logPath, 5); len(entries) > 0 {
+	// Recent activity from the feed
+	if entries := recentActivity(mobDir, 5); len(entries) > 0 {
 		output.Activity = entries
 	}
 
@@ -285,6 +437,76 @@ func printAgents(agents []agentInfo) {
 	w.Flush()
 }
 
+func printPool(p *poolInfo) {
+	limit := "no limit"
+	if p.Limit > 0 {
+		limit = fmt.Sprintf("limit %d", p.Limit)
+	}
+	fmt.Printf("%s %s\n", sectionStyle.Render("Agent pool"),
+		mutedStyle.Render(fmt.Sprintf("%d working, %s", p.Working, limit)))
+	if len(p.Queue) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, q := range p.Queue {
+		bead := q.BeadID
+		if bead == "" {
+			bead = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+			warningStyle.Render(fmt.Sprintf("#%d", i+1)),
+			valueStyle.Render(q.Agent),
+			bead,
+			q.Turf,
+			mutedStyle.Render("queued "+q.Waiting))
+	}
+	w.Flush()
+}
+
+func printSpawnQueue(queue []spawnInfo) {
+	fmt.Printf("%s (%d)\n", sectionStyle.Render("Spawn queue"), len(queue))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, q := range queue {
+		by := q.RequestedBy
+		if by == "" {
+			by = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n",
+			warningStyle.Render(fmt.Sprintf("#%d", i+1)),
+			valueStyle.Render(q.ID),
+			q.Turf,
+			mutedStyle.Render(q.Task),
+			mutedStyle.Render("by "+by+", queued "+q.Waiting))
+	}
+	w.Flush()
+}
+
+// printModels shows each failing model's circuit breaker
+func printModels(models []modelInfo) {
+	fmt.Println(sectionStyle.Render("Models"))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, m := range models {
+		var state string
+		switch failover.State(m.Circuit) {
+		case failover.Open:
+			state = errorStyle.Render("degraded")
+			if m.FailOver != "" {
+				state += mutedStyle.Render(" → " + m.FailOver)
+			}
+			state += mutedStyle.Render(fmt.Sprintf(" since %s, retry at %s", m.Since, m.Retry))
+		case failover.HalfOpen:
+			state = warningStyle.Render("recovering") + mutedStyle.Render(" (next call probes it)")
+		default:
+			state = warningStyle.Render(fmt.Sprintf("%d failure(s)", m.Failures))
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", valueStyle.Render(m.Model), state, mutedStyle.Render(truncate(m.LastError, 60)))
+	}
+	w.Flush()
+}
+
 func printBeadsSummary(summary beadSummary) {
 	fmt.Println(sectionStyle.Render("Beads"))
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -298,6 +520,9 @@ func printBeadsSummary(summary beadSummary) {
 	if summary.PendingApproval > 0 {
 		fmt.Fprintf(w, "  Pending Approval:\t%s\n", warningStyle.Render(fmt.Sprintf("%d", summary.PendingApproval)))
 	}
+	if summary.InReview > 0 {
+		fmt.Fprintf(w, "  In Review:\t%s\n", warningStyle.Render(fmt.Sprintf("%d", summary.InReview)))
+	}
 	if summary.Blocked > 0 {
 		fmt.Fprintf(w, "  Blocked:\t%s\n", errorStyle.Render(fmt.Sprintf("%d", summary.Blocked)))
 	}
@@ -305,7 +530,7 @@ func printBeadsSummary(summary beadSummary) {
 		fmt.Fprintf(w, "  Closed:\t%s\n", mutedStyle.Render(fmt.Sprintf("%d", summary.Closed)))
 	}
 
-	total := summary.InProgress + summary.Open + summary.PendingApproval + summary.Blocked + summary.Closed
+	total := summary.InProgress + summary.Open + summary.PendingApproval + summary.InReview + summary.Blocked + summary.Closed
 	if total == 0 {
 		fmt.Fprintln(w, mutedStyle.Render("  No beads"))
 	}
@@ -330,9 +555,14 @@ func printTurfs(turfs []turfInfo) {
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	for _, t := range turfs {
-		fmt.Fprintf(w, "  %s\t%s\n",
+		queue := mutedStyle.Render("merge queue empty")
+		if t.MergeQueue > 0 {
+			queue = warningStyle.Render(fmt.Sprintf("%d queued to merge", t.MergeQueue))
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n",
 			valueStyle.Render(t.Name),
-			mutedStyle.Render(t.Path))
+			mutedStyle.Render(t.Path),
+			queue)
 	}
 	w.Flush()
 }
@@ -350,81 +580,26 @@ func formatAgentStatus(status string) string {
 	}
 }
 
-func parseRecentActivity(logPath string, limit int) []activityEntry {
-	content, err := os.ReadFile(logPath)
+// recentActivity returns the last limit entries of the activity feed
+func recentActivity(mobDir string, limit int) []activityEntry {
+	feed, err := storage.NewActivityStore(storage.ActivityDir(mobDir))
 	if err != nil {
 		return nil
 	}
-
-	lines := strings.Split(string(content), "\n")
-	entries := []activityEntry{}
-
-	// Process from end to get most recent
-	for i := len(lines) - 1; i >= 0 && len(entries) < limit; i-- {
-		line := strings.TrimSpace(lines[i])
-		if line == "" {
-			continue
-		}
-
-		// Filter for important events
-		if strings.Contains(line, "started") ||
-			strings.Contains(line, "completed") ||
-			strings.Contains(line, "spawning") ||
-			strings.Contains(line, "error") ||
-			strings.Contains(line, "created") {
-
-			// Try to extract timestamp and message
-			// Format: "2024/01/16 12:34:56 Message"
-			if len(line) >= 20 && line[4] == '/' && line[7] == '/' {
-				timestamp := line[:19]
-				message := strings.TrimSpace(line[20:])
-
-				// Make message more readable
-				message = simplifyLogMessage(message)
-
-				entries = append(entries, activityEntry{
-					Time:    formatLogTime(timestamp),
-					Message: message,
-				})
-			}
-		}
-	}
-
-	// Reverse to get chronological order
-	for i := 0; i < len(entries)/2; i++ {
-		entries[i], entries[len(entries)-1-i] = entries[len(entries)-1-i], entries[i]
-	}
-
-	return entries
-}
-
-func simplifyLogMessage(msg string) string {
-	// Remove common prefixes
-	msg = strings.TrimPrefix(msg, "daemon: ")
-	msg = strings.TrimPrefix(msg, "patrol: ")
-	msg = strings.TrimPrefix(msg, "hook: ")
-	return msg
-}
-
-func formatLogTime(timestamp string) string {
-	// Parse "2024/01/16 12:34:56"
-	t, err := time.Parse("2006/01/02 15:04:05", timestamp)
+	recent, err := feed.List(storage.ActivityFilter{Limit: limit})
 	if err != nil {
-		return timestamp
+		return nil
 	}
 
-	// Format as relative time
-	d := time.Since(t)
-	if d < time.Minute {
-		return fmt.Sprintf("%ds ago", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		return fmt.Sprintf("%dm ago", int(d.Minutes()))
-	}
-	if d < 24*time.Hour {
-		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	entries := make([]activityEntry, 0, len(recent))
+	for _, a := range recent {
+		entries = append(entries, activityEntry{
+			Time:    formatRelativeTime(a.Timestamp),
+			Type:    string(a.Type),
+			Message: a.Message,
+		})
 	}
-	return t.Format("Jan 2 15:04")
+	return entries
 }
 
 func formatRelativeTime(t time.Time) string {
@@ -464,17 +639,61 @@ func printBeadDetail(b *models.Bead) {
 	if b.Assignee != "" {
 		fmt.Printf("  Assignee:    %s\n", b.Assignee)
 	}
-	if b.Labels != "" {
-		fmt.Printf("  Labels:      %s\n", b.Labels)
+	if len(b.Labels) > 0 {
+		fmt.Printf("  Labels:      %s\n", strings.Join(b.Labels, ", "))
+	}
+	if deadline, ok := b.Deadline(); ok {
+		due := formatDeadline(deadline)
+		if b.Overdue(time.Now()) {
+			due = errorStyle.Render("overdue") + " " + due
+		}
+		if b.SLA != "" {
+			due += mutedStyle.Render(" SLA " + b.SLA)
+		}
+		fmt.Printf("  Due:         %s\n", due)
+	}
+	if b.TimeEstimate != "" || b.StartedAt != nil {
+		estimate := "-"
+		if b.TimeEstimate != "" {
+			estimate = b.TimeEstimate
+		}
+		fmt.Printf("  Estimate:    %s%s\n", estimate, mutedStyle.Render(workedSummary(b)))
+	}
+	if len(b.Watchers) > 0 {
+		fmt.Printf("  Watchers:    %s\n", strings.Join(b.Watchers, ", "))
+	}
+	if run := b.LastTestRun; run != nil {
+		result := successStyle.Render("passed")
+		if run.TimedOut {
+			result = errorStyle.Render("timed out")
+		} else if !run.Passed {
+			result = errorStyle.Render(fmt.Sprintf("failed (exit %d)", run.ExitCode))
+		}
+		fmt.Printf("  Tests:       %s %s\n", result, mutedStyle.Render(formatRelativeTime(run.At)))
+	}
+	for _, a := range b.Attachments {
+		fmt.Printf("  Attachment:  %s %s\n", a.Path, mutedStyle.Render(formatRelativeTime(a.CreatedAt)))
 	}
 	if b.Branch != "" {
 		fmt.Printf("  Branch:      %s\n", b.Branch)
 	}
+	if b.Model != "" {
+		fmt.Printf("  Model:       %s\n", b.Model)
+	}
+	if b.CostUSD > 0 {
+		fmt.Printf("  Cost:        $%.2f\n", b.CostUSD)
+	}
+	if e := b.Estimate; e != nil {
+		fmt.Printf("  Estimate:    %s, $%.2f on %s %s\n", e.Scope, e.CostUSD, e.Model, mutedStyle.Render(formatRelativeTime(e.At)))
+	}
 	fmt.Printf("  Created:     %s\n", b.CreatedAt.Format(time.RFC3339))
 	fmt.Printf("  Updated:     %s\n", b.UpdatedAt.Format(time.RFC3339))
 	if b.Description != b.Title {
 		fmt.Printf("\nDescription:\n%s\n", b.Description)
 	}
+	if len(b.Checklist) > 0 {
+		fmt.Printf("\nChecklist:\n%s\n", b.FormatChecklist())
+	}
 }
 
 func init() {
@@ -482,6 +701,9 @@ func init() {
 	statusCmd.Flags().BoolVar(&flagBeads, "beads", false, "Show only bead summary")
 	statusCmd.Flags().BoolVar(&flagAgents, "agents", false, "Show only agent list")
 	statusCmd.Flags().BoolVar(&flagWatch, "watch", false, "Refresh every 2 seconds")
+	statusCmd.Flags().BoolVar(&flagOneline, "oneline", false, "One-line summary for tmux and other status bars")
+	statusCmd.Flags().BoolVar(&flagProm, "prom", false, "Summary in Prometheus text format")
+	statusCmd.Flags().BoolVar(&flagWaybar, "waybar", false, "Summary as Waybar custom module JSON")
 
 	// Legacy flags for backward compatibility
 	statusCmd.Flags().String("status", "", "Filter by status (deprecated, use 'mob list' instead)")
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/gabe/mob/internal/daemon"
+	"github.com/gabe/mob/internal/statusbar"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// statusBarMaxAge is how old the daemon's snapshot may be before status bar
+// output falls back to reading the stores directly
+const statusBarMaxAge = time.Minute
+
+var (
+	flagOneline bool
+	flagProm    bool
+	flagWaybar  bool
+)
+
+// showStatusBar prints the compact summary for tmux, Prometheus or Waybar
+func showStatusBar() {
+	mobDir, err := getMobDir()
+	if err != nil {
+		fail(err)
+	}
+
+	s := loadStatusBar(mobDir)
+	switch {
+	case flagProm:
+		fmt.Print(statusbar.Prometheus(s))
+	case flagWaybar:
+		fmt.Println(statusbar.Waybar(s))
+	default:
+		fmt.Println(statusbar.Oneline(s))
+	}
+}
+
+// loadStatusBar returns the daemon's snapshot while it is fresh, and builds
+// one from the stores when the daemon is down or has stopped refreshing it
+func loadStatusBar(mobDir string) statusbar.Summary {
+	state, _, _ := daemon.New(mobDir, log.New(io.Discard, "", 0)).Status()
+	running := state == daemon.StateRunning
+
+	if running {
+		if s, err := statusbar.Load(statusbar.Path(mobDir)); err == nil && time.Since(s.UpdatedAt) < statusBarMaxAge {
+			return s
+		}
+	}
+
+	var beads *storage.BeadStore
+	if beadsPath, err := getBeadsPath(); err == nil {
+		beads, _ = storage.NewBeadStore(beadsPath)
+	}
+	s := statusbar.Collect(mobDir, beads)
+	s.DaemonRunning = running
+	return s
+}
@@ -8,6 +8,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/gabe/mob/internal/plugin"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/gabe/mob/internal/sweep"
 	"github.com/gabe/mob/internal/turf"
@@ -84,14 +85,12 @@ func init() {
 func runSweepReview(cmd *cobra.Command, args []string) {
 	turfPath, err := resolveTurfPath(args)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	sweeper, err := createSweeper(turfPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	fmt.Printf("Running code review sweep on %s...\n\n", turfPath)
@@ -109,14 +108,12 @@ func runSweepReview(cmd *cobra.Command, args []string) {
 func runSweepBugs(cmd *cobra.Command, args []string) {
 	turfPath, err := resolveTurfPath(args)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	sweeper, err := createSweeper(turfPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	fmt.Printf("Running bug sweep on %s...\n\n", turfPath)
@@ -134,14 +131,12 @@ func runSweepBugs(cmd *cobra.Command, args []string) {
 func runSweepAll(cmd *cobra.Command, args []string) {
 	turfPath, err := resolveTurfPath(args)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	sweeper, err := createSweeper(turfPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		fail(err)
 	}
 
 	fmt.Printf("Running all sweeps on %s...\n\n", turfPath)
@@ -223,17 +218,25 @@ func createSweeper(turfPath string) (*sweep.Sweeper, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bead store: %w", err)
 	}
+	trackActivity(beadStore)
+
+	sweeper := sweep.New(turfPath, beadStore)
+
+	// Run plugin detectors alongside the built-in checks
+	if mobDir, err := getMobDir(); err == nil {
+		plugins, errs := plugin.Discover(plugin.Dir(mobDir), mobDir)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		sweeper.AddDetectors(plugin.Detectors(plugins)...)
+	}
 
-	return sweep.New(turfPath, beadStore), nil
+	return sweeper, nil
 }
 
 // getBeadStorePath returns the path to the bead store
 func getBeadStorePath() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
-	}
-	return filepath.Join(home, "mob", ".mob", "beads"), nil
+	return getBeadsPath()
 }
 
 // printSweepResult prints a sweep result to stdout
@@ -253,5 +256,9 @@ func printSweepResult(result *sweep.SweepResult) {
 		w.Flush()
 	}
 
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
 	fmt.Printf("\n%s\n", result.Summary)
 }
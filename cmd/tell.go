@@ -7,7 +7,6 @@ import (
 	"os/signal"
 	"syscall"
 
-	"github.com/gabe/mob/internal/agent"
 	"github.com/gabe/mob/internal/underboss"
 	"github.com/spf13/cobra"
 )
@@ -28,7 +27,7 @@ var tellCmd = &cobra.Command{
 		}
 
 		// 2. Create spawner
-		spawner := agent.NewSpawner()
+		spawner := newSpawner(mobDir)
 
 		// 3. Create Underboss
 		ub := underboss.New(mobDir, spawner)
@@ -58,8 +57,7 @@ var tellCmd = &cobra.Command{
 		// 4. Call Tell(ctx, instruction)
 		acknowledgment, err := ub.Tell(ctx, instruction)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		// 5. Print acknowledgment
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List the bead templates in ~/mob/templates",
+	Long: `List the bead templates mob create --template can expand, with how many
+child beads each creates.
+
+A template is a TOML file in ~/mob/templates:
+
+  about = "Everything a release needs"
+  title = "Release {{.version}}"
+  labels = "release"
+  checklist = ["Tagged", "Announced"]
+
+  [[children]]
+  title = "Write the {{.version}} changelog"
+  checklist = ["Breaking changes called out"]`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mobDir, err := getMobDir()
+		if err != nil {
+			fail(err)
+		}
+		dir := templates.Dir(mobDir)
+		list, errs := templates.List(dir)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "%s %v\n", warningStyle.Render("!"), err)
+		}
+		if len(list) == 0 {
+			fmt.Println(mutedStyle.Render("No templates in " + dir))
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, t := range list {
+			fmt.Fprintf(w, "%s\t%d children\t%s\n", valueStyle.Render(t.Name), len(t.Children), mutedStyle.Render(t.About))
+		}
+		w.Flush()
+	},
+}
+
+// runAddTemplate creates a bead and its children from the named template,
+// with any add flags the user set overriding the template's parent
+func runAddTemplate(cmd *cobra.Command, name, title string) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		fail(err)
+	}
+	t, err := templates.Load(templates.Dir(mobDir), name)
+	if err != nil {
+		fail(err)
+	}
+
+	pairs, _ := cmd.Flags().GetStringArray("var")
+	vars, err := parseTemplateVars(pairs)
+	if err != nil {
+		fail(err)
+	}
+	if title != "" {
+		vars[templates.TitleVar] = title
+	}
+
+	if cmd.Flags().Changed("priority") {
+		priority, _ := cmd.Flags().GetInt("priority")
+		t.Priority = &priority
+	}
+	if cmd.Flags().Changed("type") {
+		t.Type, _ = cmd.Flags().GetString("type")
+	}
+	if cmd.Flags().Changed("labels") {
+		t.Labels, _ = cmd.Flags().GetString("labels")
+	}
+	checklist, _ := cmd.Flags().GetStringArray("check")
+	t.Checklist = append(t.Checklist, checklist...)
+	turfName, _ := cmd.Flags().GetString("turf")
+	due, sla := dueFlags(cmd)
+
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		fail(err)
+	}
+	store, err := storage.NewBeadStore(beadsPath)
+	if err != nil {
+		fail(err)
+	}
+	trackActivity(store)
+
+	parent, children, err := templates.Create(store, t, vars, turfName, "user")
+	if err != nil {
+		fail(err)
+	}
+	if due != nil || sla != "" {
+		parent.DueAt, parent.SLA = due, sla
+		if parent, err = store.Update(parent); err != nil {
+			fail(err)
+		}
+	}
+
+	fmt.Printf("Created bead %s: %s\n", parent.ID, parent.Title)
+	for _, c := range children {
+		fmt.Printf("  %s %s\n", valueStyle.Render(c.ID), c.Title)
+	}
+}
+
+// parseTemplateVars turns name=value pairs into template variables
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, errkind.New(errkind.Invalid, fmt.Sprintf("--var %q is not name=value", pair))
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+}
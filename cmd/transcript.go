@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/transcript"
+	"github.com/spf13/cobra"
+)
+
+var transcriptCmd = &cobra.Command{
+	Use:   "transcript",
+	Short: "Work with agent session transcripts",
+	Long:  `Commands for reading and exporting the Claude session transcripts behind agent conversations.`,
+}
+
+var (
+	transcriptExportFormat string
+	transcriptExportOutput string
+)
+
+var transcriptExportCmd = &cobra.Command{
+	Use:   "export <session>",
+	Short: "Export a session transcript as markdown",
+	Long: `Export a session transcript as clean markdown for pasting into PRs, docs, or incident reviews.
+
+The session can be a session ID, a path to a session .jsonl file, or the name
+of a running agent. Tool calls are collapsed and token usage and cost are
+summarised at the end. Secrets matching the [redaction] rules are masked.
+--format picks the output; markdown, the default, is the only one so far.
+
+Example:
+  mob transcript export 4f1c9e2a-...
+  mob transcript export vinnie -o vinnie.md`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if transcriptExportFormat != "markdown" && transcriptExportFormat != "md" {
+			fmt.Fprintf(os.Stderr, "Error: unknown format %q (expected markdown)\n", transcriptExportFormat)
+			os.Exit(exitInvalid)
+		}
+
+		path, err := transcript.Find(resolveTranscriptSession(args[0]))
+		if err != nil {
+			fail(err)
+		}
+
+		t, err := transcript.Load(path)
+		if err != nil {
+			fail(err)
+		}
+
+		md := loadRedactor().String(transcript.RenderMarkdown(t))
+
+		if transcriptExportOutput == "" {
+			fmt.Print(md)
+			return
+		}
+
+		if err := os.WriteFile(transcriptExportOutput, []byte(md), 0644); err != nil {
+			fail(err)
+		}
+		fmt.Printf("Exported %s to %s\n", t.SessionID, transcriptExportOutput)
+	},
+}
+
+// resolveTranscriptSession maps a running agent's name to its recorded
+// session ID, leaving session IDs and paths untouched
+func resolveTranscriptSession(session string) string {
+	reg := registry.New(getRegistryPath())
+	if record, err := reg.GetByName(session); err == nil && record.SessionID != "" {
+		return record.SessionID
+	}
+	return session
+}
+
+func init() {
+	transcriptExportCmd.Flags().StringVarP(&transcriptExportFormat, "format", "f", "markdown", "Output format: markdown")
+	transcriptExportCmd.Flags().StringVarP(&transcriptExportOutput, "output", "o", "", "Write to a file instead of stdout")
+
+	transcriptCmd.AddCommand(transcriptExportCmd)
+	rootCmd.AddCommand(transcriptCmd)
+}
@@ -3,17 +3,101 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/gabe/mob/internal/approval"
+	"github.com/gabe/mob/internal/chatsession"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
 	"github.com/gabe/mob/internal/tui"
+	"github.com/gabe/mob/internal/underboss"
 	"github.com/spf13/cobra"
 )
 
+var tuiObserve bool
+
+// runTUI starts the dashboard; replaced in tests
+var runTUI = func() error {
+	cfg := loadTUIConfig()
+	opts := tui.Options{Observe: tuiObserve, Refresh: loadTUIStatus, Redactor: loadRedactor(), LoadBead: loadTUIBead, LoadEpic: loadTUIEpic, Beads: tuiBeads{}, Agents: tuiAgents{}, Approvals: tuiApprovals{}, Commands: tuiCommands{}}
+	opts.UnderbossModel, opts.AgentModel = cfg.Underboss.Model, cfg.Routing.DefaultModel
+	if mobDir, err := getMobDir(); err == nil {
+		opts.ThemeDir = tui.ThemeDir(mobDir)
+		opts.MarkRead = func(read bool, ids ...string) error {
+			return notify.MarkRead(notify.FeedPath(mobDir), read, ids...)
+		}
+	}
+	if !tuiObserve {
+		loadTUIChat(cfg, &opts)
+	}
+	return tui.RunWithConfig(cfg.TUI, opts)
+}
+
 var tuiCmd = &cobra.Command{
 	Use:   "tui",
 	Short: "Launch the TUI dashboard",
-	Long:  `Launch the interactive TUI dashboard for monitoring and managing mob agents.`,
+	Long: `Launch the interactive TUI dashboard for monitoring and managing mob agents.
+
+Tab and shift+tab move between tabs. On the Agents tab x kills the
+selected agent, n nudges it, t shows its session transcript, m changes a
+soldati's model and r hands its current bead to another soldati. The Beads
+tab lists the open beads beside a detail pane for the selected one
+(description, history, comments, blockers, worktree branch and diff
+summary); a approves, r reassigns, c comments on and x closes the selected
+bead. b switches to a board with a column per status (open, in progress,
+pending approval, blocked and closed in the last week) and its count; the
+arrow keys move around it, < and > move the selected bead a column left or
+right, updating its status, and f shows one turf at a time.
+
+The Approvals tab lists the beads waiting on you with a preview of their
+diff; a approves and r rejects, as mob approve and mob reject do.
+
+The Notifications tab lists what the daemon and agents notified about, with
+unread ones marked; enter jumps to the bead or agent, m toggles read and M
+marks all read. New ones ring the terminal bell ([tui] bell).
+
+In the chat, lines starting with / are commands (/help lists them); typing
+/ opens a popup that completes them. /turf <name> scopes the Beads and
+Agents tabs and the board to one turf, and /turf all shows every turf
+again. esc or ctrl+c cancels a reply while it streams, stopping the
+underboss's claude process. ctrl+v selects lines of the chat, daemon log or
+agent output to copy with y; /copy-last copies the last reply.
+ctrl+f searches them (/ also does on the daemon and agent output tabs); n
+and N move between matches as in less.
+
+The Agent Output tab shows the session transcript of the agent selected on
+the Agents tab.
+
+The Costs tab breaks down spend from the usage ledger (.mob/usage.jsonl) by
+agent, turf and day, with sparklines of daily spend and hourly token burn
+and the month's projected spend; w switches between today, 7 and 30 days.
+
+The sidebar beside the chat shows the session's tokens and cost, the mob's
+spend today and the models in use with their price per million tokens. ctrl+o opens a switcher
+there (up/down picks the underboss or agents, left/right the model, enter
+applies); /model opus switches the underboss and /model agents haiku the
+default for agents' work. Choices are saved to config.toml and the daemon
+reloads them. /theme lists the color themes and /theme <name> switches to
+one: dark and light are built in, and .mob/themes/<name>.toml adds more.
+
+Chats are saved under .mob/sessions as they go. On startup a picker offers
+the recent ones; /sessions lists them and /resume <id> continues one with
+the underboss's Claude session.
+
+With --observe the dashboard is read-only: it shows the daemon log, agent
+output, agent status and beads but has no chat and refuses slash commands
+and agent and bead actions, so it never starts the underboss, sends tokens
+to an LLM or changes mob state. Useful for a second screen or a demo.
+
+Example:
+  mob tui
+  mob tui --observe`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := tui.Run(); err != nil {
+		if err := runTUI(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return err
 		}
@@ -21,6 +105,91 @@ var tuiCmd = &cobra.Command{
 	},
 }
 
+// loadTUIConfig reads the config the dashboard runs with, falling back to
+// defaults
+func loadTUIConfig() *config.Config {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return config.DefaultConfig()
+	}
+	cfg, err := config.Load(filepath.Join(mobDir, "config.toml"))
+	if err != nil {
+		return config.DefaultConfig()
+	}
+	return cfg
+}
+
+// loadTUIChat connects the chat to the underboss, on the model from
+// [underboss] model, streams its token usage to the sidebar and saves it
+// under .mob/sessions. With
+// confirm_mutations on, its state-changing tool calls wait for y/n in the chat.
+func loadTUIChat(cfg *config.Config, opts *tui.Options) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return
+	}
+	boss := underboss.New(mobDir, newSpawner(mobDir))
+	opts.Ask = boss.AskStream
+	opts.Sessions = chatsession.NewStore(chatsession.Dir(mobDir))
+	opts.Resume = boss.ResumeSession
+	opts.Models = tuiModels{boss: boss}
+	opts.WatchUsage = boss.SetOnUsage
+	boss.SetModel(cfg.Underboss.Model)
+	if cfg.TUI.ConfirmMutations {
+		boss.SetConfirmMutations(true)
+		opts.Approver = approval.NewStore(approval.Path(mobDir))
+	}
+}
+
+// loadTUIStatus reads the activity feed, agent registry, beads,
+// notifications and usage ledger for the dashboard's tabs. It only reads, so observers can use it.
+func loadTUIStatus() tui.RefreshMsg {
+	var msg tui.RefreshMsg
+
+	if mobDir, err := getMobDir(); err == nil {
+		// Opening the store creates its directory, so only read an existing feed
+		dir := storage.ActivityDir(mobDir)
+		if _, err := os.Stat(dir); err == nil {
+			if store, err := storage.NewActivityStore(dir); err == nil {
+				msg.Activity, _ = store.List(storage.ActivityFilter{Limit: 50})
+			}
+		}
+		msg.Notifications, _ = notify.ReadFeed(notify.FeedPath(mobDir), 100)
+		msg.Usage, _ = storage.ReadUsage(storage.UsagePath(mobDir), time.Now().AddDate(0, 0, -tui.CostLedgerDays))
+	}
+	msg.Agents, _ = registry.New(getRegistryPath()).List()
+	msg.Beads, msg.Closed = loadTUIBeads()
+
+	return msg
+}
+
+// openTUIBeadStore opens the bead store for the dashboard
+func openTUIBeadStore() (*storage.BeadStore, error) {
+	beadsPath, err := getBeadsPath()
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewBeadStore(beadsPath)
+}
+
+// loadTUIBead reads a bead for the dashboard's detail view
+func loadTUIBead(id string) (*models.Bead, error) {
+	store, err := openTUIBeadStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Get(id)
+}
+
+func loadTUIEpic(id string) (*models.EpicProgress, error) {
+	store, err := openTUIBeadStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.EpicProgress(id, time.Now())
+}
+
 func init() {
+	tuiCmd.Flags().BoolVar(&tuiObserve, "observe", false, "Read-only mode: display status, logs and agent output without chat or commands")
 	rootCmd.AddCommand(tuiCmd)
 }
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/abort"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+// tuiAgents acts on agents from the dashboard's agents tab through the
+// registry and the underboss's tools
+type tuiAgents struct{}
+
+func (tuiAgents) record(id string) (*registry.Registry, *registry.AgentRecord, error) {
+	reg := registry.New(getRegistryPath())
+	rec, err := reg.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reg, rec, nil
+}
+
+// Kill stops the bead the agent is working on, then sends it home as the
+// kill_agent tool does
+func (t tuiAgents) Kill(id string) (string, error) {
+	reg, rec, err := t.record(id)
+	if err != nil {
+		return "", err
+	}
+	if beadID := agentBead(rec); beadID != "" {
+		store, err := tuiBeads{}.store()
+		if err != nil {
+			return "", err
+		}
+		if bead, err := store.Get(beadID); err == nil && bead.Status == models.BeadStatusInProgress {
+			opts := abort.Options{Reason: fmt.Sprintf("%s was killed", rec.Label()), Actor: "human"}
+			if _, err := abort.Bead(store, reg, getHookDir(), beadID, opts); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	mobDir, err := getMobDir()
+	if err != nil {
+		return "", err
+	}
+	ctx := reviewToolContext(nil)
+	ctx.Spawner = newSpawner(mobDir)
+	return callTool(ctx, "kill_agent", map[string]interface{}{"id": id})
+}
+
+func (tuiAgents) Nudge(id string) (string, error) {
+	return callTool(reviewToolContext(nil), "nudge_agent", map[string]interface{}{"id": id})
+}
+
+// SetModel changes the soldati's definition; the daemon restarts its session
+// with the new model once it is idle
+func (t tuiAgents) SetModel(id, model string) error {
+	_, rec, err := t.record(id)
+	if err != nil {
+		return err
+	}
+	if rec.Type != "soldati" {
+		return errkind.New(errkind.Invalid, fmt.Sprintf("%s is a %s; only soldati have a model of their own", rec.Label(), rec.Type))
+	}
+	dir, err := getSoldatiDir()
+	if err != nil {
+		return err
+	}
+	mgr, err := soldati.NewManager(dir)
+	if err != nil {
+		return err
+	}
+	def, err := mgr.Get(rec.Name)
+	if err != nil {
+		return err
+	}
+	def.Model = model
+	return mgr.Update(def)
+}
+
+// Reassign hands the agent's current bead to another soldati
+func (t tuiAgents) Reassign(id, to string) (string, error) {
+	_, rec, err := t.record(id)
+	if err != nil {
+		return "", err
+	}
+	beadID := agentBead(rec)
+	if beadID == "" {
+		return "", errkind.New(errkind.NotFound, fmt.Sprintf("%s has no bead to reassign", rec.Label()))
+	}
+	return beadID, tuiBeads{}.Reassign(beadID, to)
+}
+
+// agentBead returns the bead an agent is on: an associate's linked bead, or
+// the bead on a soldati's hook
+func agentBead(rec *registry.AgentRecord) string {
+	if rec.BeadID != "" {
+		return rec.BeadID
+	}
+	if rec.Type != "soldati" || rec.Name == "" {
+		return ""
+	}
+	mgr, err := hook.NewManager(getHookDir(), rec.Name)
+	if err != nil {
+		return ""
+	}
+	if h, err := mgr.Read(); err == nil && h != nil {
+		return h.BeadID
+	}
+	return ""
+}
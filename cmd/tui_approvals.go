@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/git"
+)
+
+// tuiApprovals decides the beads waiting on the user from the dashboard's
+// approvals tab, the way mob approve and mob reject do
+type tuiApprovals struct{}
+
+// Preview returns the patch in the bead's worktree against the turf's main
+// branch
+func (tuiApprovals) Preview(id string) (string, error) {
+	return diffBead(id, git.Diff)
+}
+
+func (tuiApprovals) Approve(id string) (string, error) {
+	return tuiBeads{}.Approve(id)
+}
+
+func (tuiApprovals) Reject(id, reason string) (string, error) {
+	store, err := tuiBeads{}.store()
+	if err != nil {
+		return "", err
+	}
+	bead, err := store.Get(id)
+	if err != nil {
+		return "", err
+	}
+	result, err := rejectBead(store, bead, reason)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Rejected %s: %s", id, result), nil
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gabe/mob/internal/abort"
+	"github.com/gabe/mob/internal/git"
+	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// tuiBeads acts on beads from the dashboard's beads tab the way the matching
+// mob commands do
+type tuiBeads struct{}
+
+// store opens the bead store, recording changes in the activity feed
+func (tuiBeads) store() (*storage.BeadStore, error) {
+	store, err := openTUIBeadStore()
+	if err != nil {
+		return nil, err
+	}
+	trackActivity(store)
+	return store, nil
+}
+
+// Diff summarizes the bead's worktree against the turf's main branch
+func (tuiBeads) Diff(id string) (string, error) {
+	return diffBead(id, git.DiffSummary)
+}
+
+// diffBead runs diff on the bead's worktree against the turf's main branch.
+// A bead without a worktree has no diff.
+func diffBead(id string, diff func(dir, base string) (string, error)) (string, error) {
+	store, err := openTUIBeadStore()
+	if err != nil {
+		return "", err
+	}
+	bead, err := store.Get(id)
+	if err != nil || bead.WorktreePath == "" {
+		return "", err
+	}
+	wt, err := git.NewWorktreeManager(bead.WorktreePath)
+	if err != nil {
+		return "", err
+	}
+	mainBranch, err := wt.GetMainBranch()
+	if err != nil {
+		return "", err
+	}
+	return diff(bead.WorktreePath, mainBranch)
+}
+
+func (t tuiBeads) Approve(id string) (string, error) {
+	store, err := t.store()
+	if err != nil {
+		return "", err
+	}
+	bead, err := store.Get(id)
+	if err != nil {
+		return "", err
+	}
+	result, err := approveBead(store, bead)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Approved %s: %s", id, result), nil
+}
+
+// Reassign stops whoever is working on the bead and assigns it to soldati
+// through the assign_bead tool
+func (t tuiBeads) Reassign(id, soldati string) error {
+	store, err := t.store()
+	if err != nil {
+		return err
+	}
+	bead, err := store.Get(id)
+	if err != nil {
+		return err
+	}
+	if bead.Status == models.BeadStatusInProgress && bead.Assignee != soldati {
+		opts := abort.Options{Reason: "reassigned to " + soldati, Actor: "human"}
+		if _, err := abort.Bead(store, registry.New(getRegistryPath()), getHookDir(), id, opts); err != nil {
+			return err
+		}
+	}
+	_, err = callTool(reviewToolContext(store), "assign_bead", map[string]interface{}{"agent_name": soldati, "bead_id": id})
+	return err
+}
+
+// callTool runs one of the underboss's MCP tools directly
+func callTool(ctx *mcp.ToolContext, name string, args map[string]interface{}) (string, error) {
+	for _, tool := range mcp.GetTools() {
+		if tool.Name == name {
+			return tool.Handler(ctx, args)
+		}
+	}
+	return "", fmt.Errorf("%s tool unavailable", name)
+}
+
+func (t tuiBeads) Comment(id, text string) error {
+	store, err := t.store()
+	if err != nil {
+		return err
+	}
+	return store.AddComment(id, "human", text)
+}
+
+// Close stops any work in flight on the bead and closes it
+func (t tuiBeads) Close(id, reason string) error {
+	store, err := t.store()
+	if err != nil {
+		return err
+	}
+	bead, err := store.Get(id)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		reason = "Closed by user"
+	}
+	if bead.Status == models.BeadStatusInProgress {
+		opts := abort.Options{Reason: reason, Actor: "human"}
+		if _, err := abort.Bead(store, registry.New(getRegistryPath()), getHookDir(), id, opts); err != nil {
+			return err
+		}
+		if bead, err = store.Get(id); err != nil {
+			return err
+		}
+	}
+
+	bead.Status = models.BeadStatusClosed
+	now := time.Now()
+	bead.ClosedAt = &now
+	bead.CloseReason = reason
+	_, err = store.Update(bead)
+	return err
+}
+
+// SetStatus moves a bead to another status from the board. Closing goes
+// through Close; a bead leaving in_progress has its work stopped first, and
+// a reopened bead loses its close reason.
+func (t tuiBeads) SetStatus(id string, status models.BeadStatus) error {
+	if status == models.BeadStatusClosed {
+		return t.Close(id, "")
+	}
+	store, err := t.store()
+	if err != nil {
+		return err
+	}
+	bead, err := store.Get(id)
+	if err != nil || bead.Status == status {
+		return err
+	}
+	if bead.Status == models.BeadStatusInProgress {
+		opts := abort.Options{Reason: "moved to " + string(status), Block: status == models.BeadStatusBlocked, Actor: "human"}
+		if _, err := abort.Bead(store, registry.New(getRegistryPath()), getHookDir(), id, opts); err != nil {
+			return err
+		}
+		if bead, err = store.Get(id); err != nil || bead.Status == status {
+			return err
+		}
+	}
+
+	bead.Status = status
+	bead.ClosedAt = nil
+	bead.CloseReason = ""
+	_, err = store.Update(bead)
+	return err
+}
+
+// tuiClosedWindow is how long closed beads stay on the board
+const tuiClosedWindow = 7 * 24 * time.Hour
+
+// loadTUIBeads lists the beads not yet closed for the beads tab, most urgent
+// first, and those closed in the last week, most recent first. It only reads,
+// so observers can use it.
+func loadTUIBeads() (open, closed []*models.Bead) {
+	store, err := openTUIBeadStore()
+	if err != nil {
+		return nil, nil
+	}
+	all, err := store.List(storage.BeadFilter{})
+	if err != nil {
+		return nil, nil
+	}
+	since := time.Now().Add(-tuiClosedWindow)
+	for _, b := range all {
+		switch {
+		case b.Status != models.BeadStatusClosed:
+			open = append(open, b)
+		case b.ClosedAt != nil && b.ClosedAt.After(since):
+			closed = append(closed, b)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		if open[i].Priority != open[j].Priority {
+			return open[i].Priority < open[j].Priority
+		}
+		return open[i].CreatedAt.Before(open[j].CreatedAt)
+	})
+	sort.SliceStable(closed, func(i, j int) bool {
+		return closed[i].ClosedAt.After(*closed[j].ClosedAt)
+	})
+	return open, closed
+}
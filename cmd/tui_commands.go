@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/turf"
+)
+
+// tuiCommands runs the dashboard chat's slash commands through the
+// underboss's tools
+type tuiCommands struct{}
+
+// Spawn hires a soldati as the spawn_soldati tool does, working in the turf's
+// directory
+func (tuiCommands) Spawn(turfName, name string) (string, error) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return "", err
+	}
+	ctx := reviewToolContext(nil)
+	ctx.Spawner = newSpawner(mobDir)
+	args := map[string]interface{}{"turf": turfName, "name": name}
+	if ctx.TurfManager != nil {
+		t, err := ctx.TurfManager.Get(turfName)
+		if err != nil {
+			return "", err
+		}
+		args["work_dir"] = t.Path
+	}
+	return callTool(ctx, "spawn_soldati", args)
+}
+
+func (tuiCommands) Turfs() ([]models.Turf, error) {
+	path, err := getTurfsPath()
+	if err != nil {
+		return nil, err
+	}
+	mgr, err := turf.NewManager(path)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.List(), nil
+}
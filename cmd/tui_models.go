@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/underboss"
+)
+
+// tuiModels switches the dashboard chat's model and the daemon's default
+// model for bead work, saving both to config.toml
+type tuiModels struct {
+	boss *underboss.Underboss
+}
+
+// SetUnderbossModel saves [underboss] model and switches the chat to it
+func (t tuiModels) SetUnderbossModel(model string) (string, error) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return "", err
+	}
+	if err := config.SetString(filepath.Join(mobDir, "config.toml"), "underboss", "model", model); err != nil {
+		return "", err
+	}
+	t.boss.SetModel(model)
+	return fmt.Sprintf("Underboss now chats with %s", model), nil
+}
+
+// SetAgentModel saves [routing] default_model and has the daemon reload its
+// config, as mob daemon reload does
+func (tuiModels) SetAgentModel(model string) (string, error) {
+	mobDir, err := getMobDir()
+	if err != nil {
+		return "", err
+	}
+	if err := config.SetString(filepath.Join(mobDir, "config.toml"), "routing", "default_model", model); err != nil {
+		return "", err
+	}
+	r, err := signalDaemon(mobDir, syscall.SIGHUP, 10*time.Second)
+	switch {
+	case errors.Is(err, errkind.NotFound):
+		return fmt.Sprintf("Agents will work with %s once the daemon starts", model), nil
+	case err != nil:
+		return "", err
+	case r.Error != "":
+		return "", errkind.New(errkind.Invalid, "reload failed: "+r.Error)
+	}
+	return fmt.Sprintf("Agents now work with %s", model), nil
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
-	"errors"
+	"context"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/config"
 	"github.com/gabe/mob/internal/tui"
 )
 
@@ -15,8 +18,31 @@ func TestTuiCommand(t *testing.T) {
 	if cmd.RunE == nil {
 		t.Fatal("expected RunE")
 	}
-	err := cmd.RunE(cmd, []string{})
-	if !errors.Is(err, tui.ErrNotImplemented) {
-		t.Fatal("expected not implemented error")
+}
+
+func TestTuiChatStreamsUsageToSidebar(t *testing.T) {
+	t.Setenv(MobHomeEnv, t.TempDir())
+
+	var opts tui.Options
+	loadTUIChat(config.DefaultConfig(), &opts)
+	if opts.WatchUsage == nil {
+		t.Fatal("expected the chat to stream usage")
+	}
+	var sent []tea.Msg
+	opts.WatchUsage(tui.ForwardUsage(func(msg tea.Msg) { sent = append(sent, msg) }))
+
+	// The underboss reports usage partway through a response
+	boss := opts.Models.(tuiModels).boss
+	if err := boss.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	boss.Agent().OnUsage(agent.UsageInfo{InputTokens: 120, OutputTokens: 30})
+
+	var model tea.Model = tui.NewModel()
+	for _, msg := range sent {
+		model, _ = model.Update(msg)
+	}
+	if live := model.(tui.Model).Sidebar.Live; live.InputTokens != 120 || live.OutputTokens != 30 {
+		t.Errorf("sidebar live usage = %+v, want the streamed 120 in / 30 out", live)
 	}
 }
@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"text/tabwriter"
 
+	"github.com/gabe/mob/internal/errkind"
 	"github.com/gabe/mob/internal/turf"
+	"github.com/gabe/mob/internal/vcs"
 	"github.com/spf13/cobra"
 )
 
@@ -31,21 +33,32 @@ var turfAddCmd = &cobra.Command{
 		}
 
 		mainBranch, _ := cmd.Flags().GetString("branch")
+		vcsKind, _ := cmd.Flags().GetString("vcs")
+		if !vcs.ValidKind(vcsKind) {
+			fail(errkind.New(errkind.Invalid, fmt.Sprintf("unsupported vcs %q (expected git or jj)", vcsKind)))
+		}
 
 		turfsPath, err := getTurfsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 		mgr, err := turf.NewManager(turfsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if err := mgr.Add(path, name, mainBranch); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
+		}
+		if vcsKind != "" {
+			if err := mgr.SetVCS(name, vcsKind); err != nil {
+				fail(err)
+			}
+		}
+		if testCommand, _ := cmd.Flags().GetString("test"); testCommand != "" {
+			if err := mgr.SetTestCommand(name, testCommand); err != nil {
+				fail(err)
+			}
 		}
 
 		fmt.Printf("Registered turf '%s' at %s\n", name, path)
@@ -59,13 +72,11 @@ var turfListCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		turfsPath, err := getTurfsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 		mgr, err := turf.NewManager(turfsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		turfs := mgr.List()
@@ -75,9 +86,13 @@ var turfListCmd = &cobra.Command{
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NAME\tPATH\tBRANCH")
+		fmt.Fprintln(w, "NAME\tPATH\tBRANCH\tVCS\tTESTS")
 		for _, t := range turfs {
-			fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, t.Path, t.MainBranch)
+			tests := t.TestCommand
+			if tests == "" {
+				tests = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", t.Name, t.Path, t.MainBranch, vcs.Resolve(t.Path, t.VCS), tests)
 		}
 		w.Flush()
 	},
@@ -93,37 +108,71 @@ var turfRemoveCmd = &cobra.Command{
 
 		turfsPath, err := getTurfsPath()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 		mgr, err := turf.NewManager(turfsPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		if err := mgr.Remove(name); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fail(err)
 		}
 
 		fmt.Printf("Removed turf '%s'\n", name)
 	},
 }
 
+var turfSetTestCmd = &cobra.Command{
+	Use:   "set-test <name> [command]",
+	Short: "Set the test command agents run with run_tests",
+	Long: `Set the shell command the run_tests tool runs in a bead's worktree, for
+example "go test ./...". With tests.require_pass on (the default), beads on
+the turf cannot be completed without a passing run. Omit the command to
+clear it.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		turfsPath, err := getTurfsPath()
+		if err != nil {
+			fail(err)
+		}
+		mgr, err := turf.NewManager(turfsPath)
+		if err != nil {
+			fail(err)
+		}
+
+		command := ""
+		if len(args) > 1 {
+			command = args[1]
+		}
+		if err := mgr.SetTestCommand(args[0], command); err != nil {
+			fail(err)
+		}
+
+		if command == "" {
+			fmt.Printf("Cleared test command for turf '%s'\n", args[0])
+			return
+		}
+		fmt.Printf("Turf '%s' tests with: %s\n", args[0], command)
+	},
+}
+
 func getTurfsPath() (string, error) {
-	home, err := os.UserHomeDir()
+	mobDir, err := getMobDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, "mob", "turfs.toml"), nil
+	return filepath.Join(mobDir, "turfs.toml"), nil
 }
 
 func init() {
 	turfAddCmd.Flags().StringP("branch", "b", "main", "Main branch name")
+	turfAddCmd.Flags().String("test", "", "Test command run_tests runs in bead worktrees")
+	turfAddCmd.Flags().String("vcs", "", "Version control system: git or jj (default: detect)")
 
 	turfCmd.AddCommand(turfAddCmd)
 	turfCmd.AddCommand(turfListCmd)
 	turfCmd.AddCommand(turfRemoveCmd)
+	turfCmd.AddCommand(turfSetTestCmd)
 	rootCmd.AddCommand(turfCmd)
 }
@@ -0,0 +1,149 @@
+// Package abort cancels in-progress bead work: it stops the assigned agent's
+// in-flight call, cleans up its hook and resets the bead.
+package abort
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// Options controls how a bead is reset after its work is aborted
+type Options struct {
+	Reason string // Why the work was aborted, recorded on the bead
+	Block  bool   // Mark the bead blocked instead of returning it to open
+	Actor  string // Who asked for the abort
+}
+
+// Result describes what an abort did
+type Result struct {
+	Agent     string            // Agent that was working on the bead, "" if none
+	Signalled bool              // An abort hook was sent to the daemon for a soldati
+	Killed    bool              // An associate's claude process was killed
+	Status    models.BeadStatus // Status the bead was reset to
+}
+
+// Bead aborts the work on a bead. Soldati are stopped through their hook,
+// which makes the daemon cancel the call and clears any pending assignment.
+// Associates run outside the daemon, so their claude process is killed directly.
+func Bead(store *storage.BeadStore, reg *registry.Registry, hookDir, beadID string, opts Options) (*Result, error) {
+	bead, err := store.Get(beadID)
+	if err != nil {
+		return nil, err
+	}
+	if bead.Status == models.BeadStatusClosed {
+		return nil, fmt.Errorf("bead %s is already closed", bead.ID)
+	}
+
+	if opts.Actor == "" {
+		opts.Actor = "human"
+	}
+	if opts.Reason == "" {
+		opts.Reason = "aborted"
+	}
+
+	result := &Result{Status: models.BeadStatusOpen}
+	if opts.Block {
+		result.Status = models.BeadStatusBlocked
+	}
+
+	if reg != nil {
+		if record := assignedAgent(reg, bead); record != nil {
+			result.Agent = agentLabel(record)
+			if err := stopAgent(reg, hookDir, bead, record, result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	comment := fmt.Sprintf("Work aborted: %s", opts.Reason)
+	if result.Agent != "" {
+		comment = fmt.Sprintf("Work by %s aborted: %s", result.Agent, opts.Reason)
+	}
+	if err := store.AddComment(bead.ID, opts.Actor, comment); err != nil {
+		return nil, fmt.Errorf("failed to record abort: %w", err)
+	}
+
+	// Reload so the update keeps the comment just added
+	bead, err = store.Get(bead.ID)
+	if err != nil {
+		return nil, err
+	}
+	bead.Status = result.Status
+	bead.Assignee = ""
+	if opts.Block {
+		bead.CloseReason = opts.Reason
+	}
+	if _, err := store.Update(bead); err != nil {
+		return nil, fmt.Errorf("failed to reset bead: %w", err)
+	}
+
+	return result, nil
+}
+
+// assignedAgent finds the registry record of the agent working on a bead
+func assignedAgent(reg *registry.Registry, bead *models.Bead) *registry.AgentRecord {
+	agents, err := reg.List()
+	if err != nil {
+		return nil
+	}
+	for _, a := range agents {
+		if bead.Assignee != "" && a.Name == bead.Assignee {
+			return a
+		}
+		if a.BeadID == bead.ID && !a.Status.Terminal() {
+			return a
+		}
+	}
+	return nil
+}
+
+// stopAgent cancels an agent's in-flight work on a bead
+func stopAgent(reg *registry.Registry, hookDir string, bead *models.Bead, record *registry.AgentRecord, result *Result) error {
+	if record.Type == "soldati" && record.Name != "" {
+		mgr, err := hook.NewManager(hookDir, record.Name)
+		if err != nil {
+			return fmt.Errorf("failed to open hook for %s: %w", record.Name, err)
+		}
+		// A soldati whose hook holds another bead has moved on; aborting
+		// would stop the wrong work
+		if current, _ := mgr.Read(); current != nil && current.BeadID != "" && current.BeadID != bead.ID {
+			return nil
+		}
+		// The abort replaces any assignment still waiting in the hook
+		if err := mgr.Write(&hook.Hook{
+			Type:      hook.HookTypeAbort,
+			BeadID:    bead.ID,
+			Timestamp: time.Now(),
+		}); err != nil {
+			return fmt.Errorf("failed to signal %s: %w", record.Name, err)
+		}
+		result.Signalled = true
+		return nil
+	}
+
+	// Mark the associate aborted first so its runner treats the kill as
+	// cancellation rather than a failure
+	reg.UpdateStatus(record.ID, registry.StatusAborted)
+	if record.PID > 0 {
+		if process, err := os.FindProcess(record.PID); err == nil && process.Signal(syscall.SIGTERM) == nil {
+			result.Killed = true
+		}
+		reg.UpdatePID(record.ID, 0)
+	}
+	return nil
+}
+
+// agentLabel names an agent for comments, falling back to its ID for associates
+func agentLabel(record *registry.AgentRecord) string {
+	if record.Name != "" {
+		return record.Name
+	}
+	return "associate " + record.ID
+}
@@ -0,0 +1,148 @@
+package abort
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func newAbortTest(t *testing.T) (*storage.BeadStore, *registry.Registry, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, registry.New(filepath.Join(dir, "agents.json")), filepath.Join(dir, "hooks")
+}
+
+func TestBead_SoldatiGetsAbortHook(t *testing.T) {
+	store, reg, hookDir := newAbortTest(t)
+
+	bead, err := store.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg.Register(&registry.AgentRecord{ID: "s-1", Type: "soldati", Name: "vinnie", Status: "active"})
+
+	result, err := Bead(store, reg, hookDir, bead.ID, Options{Reason: "wrong approach"})
+	if err != nil {
+		t.Fatalf("Bead() failed: %v", err)
+	}
+	if result.Agent != "vinnie" || !result.Signalled || result.Status != models.BeadStatusOpen {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	mgr, _ := hook.NewManager(hookDir, "vinnie")
+	h, err := mgr.Read()
+	if err != nil || h == nil || h.Type != hook.HookTypeAbort || h.BeadID != bead.ID {
+		t.Errorf("hook = %+v, %v; want abort for %s", h, err, bead.ID)
+	}
+
+	updated, _ := store.Get(bead.ID)
+	if updated.Status != models.BeadStatusOpen || updated.Assignee != "" {
+		t.Errorf("bead status=%s assignee=%q, want open and unassigned", updated.Status, updated.Assignee)
+	}
+	found := false
+	for _, e := range updated.History {
+		if e.Type == models.BeadEventTypeComment && strings.Contains(e.Comment, "wrong approach") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected abort comment in history, got %+v", updated.History)
+	}
+}
+
+func TestBead_AssociateProcessKilledAndBeadBlocked(t *testing.T) {
+	store, reg, hookDir := newAbortTest(t)
+
+	bead, err := store.Create(&models.Bead{Title: "Refactor", Status: models.BeadStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proc := exec.Command("sleep", "10")
+	if err := proc.Start(); err != nil {
+		t.Skipf("sleep unavailable: %v", err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		proc.Wait()
+		close(exited)
+	}()
+
+	reg.Register(&registry.AgentRecord{ID: "a-1", Type: "associate", BeadID: bead.ID, Status: "working", PID: proc.Process.Pid})
+
+	result, err := Bead(store, reg, hookDir, bead.ID, Options{Reason: "needs design", Block: true, Actor: "underboss"})
+	if err != nil {
+		t.Fatalf("Bead() failed: %v", err)
+	}
+	if !result.Killed || result.Agent != "associate a-1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		proc.Process.Kill()
+		t.Fatal("associate process was not killed")
+	}
+
+	record, _ := reg.Get("a-1")
+	if record.Status != "aborted" || record.PID != 0 {
+		t.Errorf("record status=%s pid=%d, want aborted and 0", record.Status, record.PID)
+	}
+
+	updated, _ := store.Get(bead.ID)
+	if updated.Status != models.BeadStatusBlocked || updated.CloseReason != "needs design" {
+		t.Errorf("bead status=%s reason=%q, want blocked with reason", updated.Status, updated.CloseReason)
+	}
+}
+
+func TestBead_ClosedBeadRefused(t *testing.T) {
+	store, reg, hookDir := newAbortTest(t)
+
+	bead, err := store.Create(&models.Bead{Title: "Done", Status: models.BeadStatusClosed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Bead(store, reg, hookDir, bead.ID, Options{}); err == nil {
+		t.Error("expected an error aborting a closed bead")
+	}
+}
+
+func TestBead_SoldatiOnOtherWorkLeftAlone(t *testing.T) {
+	store, reg, hookDir := newAbortTest(t)
+
+	bead, err := store.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg.Register(&registry.AgentRecord{ID: "s-1", Type: "soldati", Name: "vinnie", Status: "active"})
+
+	// vinnie has already moved on to another bead
+	mgr, _ := hook.NewManager(hookDir, "vinnie")
+	if err := mgr.Write(&hook.Hook{Type: hook.HookTypeAssign, BeadID: "bd-next", Message: "next"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Bead(store, reg, hookDir, bead.ID, Options{})
+	if err != nil {
+		t.Fatalf("Bead() failed: %v", err)
+	}
+	if result.Signalled {
+		t.Error("expected no abort sent to a soldati on other work")
+	}
+	if h, _ := mgr.Read(); h == nil || h.Type != hook.HookTypeAssign || h.BeadID != "bd-next" {
+		t.Errorf("hook = %+v, want vinnie's next assignment kept", h)
+	}
+}
@@ -3,11 +3,16 @@ package agent
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/exec"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
@@ -29,14 +34,26 @@ type Agent struct {
 	Turf         string // project this agent works on
 	WorkDir      string // working directory for Claude
 	StartedAt    time.Time
-	SessionID    string // Claude session ID for --resume
-	SystemPrompt string // System prompt injected on first call
-	MCPConfig    string // Path to MCP config JSON file
-	Model        string // Model to use (e.g., "sonnet", "opus") - passed as --model flag
+	SessionID    string        // Claude session ID for --resume
+	SystemPrompt string        // System prompt injected on first call
+	MCPConfig    string        // Path to MCP config JSON file
+	Model        string        // Model to use (e.g., "sonnet", "opus") - passed as --model flag
+	OnUsage      UsageCallback // Optional live usage updates while a response streams
 	spawner      *Spawner
 	mu           sync.Mutex
+	outputTail   []string  // most recent raw output lines, for post-mortems
+	lastOutput   time.Time // when the agent last printed anything, for stuck detection
+	outputMu     sync.Mutex
+	proc         *exec.Cmd // claude process serving the in-flight call
+	procStarted  time.Time // when proc started
+	aborted      bool      // set when Abort killed proc
+	onProcess    func(pid int)
+	procMu       sync.Mutex
 }
 
+// outputTailSize is how many recent output lines an agent retains
+const outputTailSize = 50
+
 // ContentBlockType represents the type of content in a response
 type ContentBlockType string
 
@@ -103,8 +120,19 @@ type StreamEvent struct {
 	Index        int           `json:"index,omitempty"`
 	ContentBlock *ContentBlock `json:"content_block,omitempty"`
 	Delta        *ContentDelta `json:"delta,omitempty"`
+	Message      *EventMessage `json:"message,omitempty"` // message_start
+	Usage        *UsageInfo    `json:"usage,omitempty"`   // message_delta (cumulative for the message)
+}
+
+// EventMessage is the message envelope carried by message_start events
+type EventMessage struct {
+	Model string     `json:"model,omitempty"`
+	Usage *UsageInfo `json:"usage,omitempty"`
 }
 
+// UsageCallback is called with cumulative token usage as a response streams
+type UsageCallback func(usage UsageInfo)
+
 // ContentDelta represents incremental content updates
 type ContentDelta struct {
 	Type    string `json:"type"`
@@ -145,9 +173,53 @@ func (a *Agent) Chat(message string) (*ChatResponse, error) {
 
 // ChatStream sends a message and calls the callback for each content update
 func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatResponse, error) {
+	return a.ChatStreamContext(context.Background(), message, callback)
+}
+
+// ChatStreamContext is ChatStream with cancellation. Cancelling ctx kills the
+// claude process and the call returns ErrAborted.
+//
+// With a model breaker set on the spawner, the call goes to the model the
+// breaker routes it to, and a provider failure that opens the model's
+// circuit is retried once on the model it fails over to.
+func (a *Agent) ChatStreamContext(ctx context.Context, message string, callback StreamCallback) (*ChatResponse, error) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	var breaker ModelBreaker
+	if a.spawner != nil {
+		breaker = a.spawner.modelBreaker()
+	}
+	if breaker == nil {
+		return a.chat(ctx, a.Model, message, callback)
+	}
+
+	model := breaker.Route(a.Model)
+	resp, err := a.chat(ctx, model, message, callback)
+	if err == nil {
+		breaker.Success(model)
+		return resp, nil
+	}
+	if errors.Is(err, ErrAborted) {
+		return nil, err
+	}
+	breaker.Failure(model, err)
+
+	next := breaker.Route(a.Model)
+	if next == model {
+		return nil, err
+	}
+	resp, err = a.chat(ctx, next, message, callback)
+	if err == nil {
+		breaker.Success(next)
+	} else if !errors.Is(err, ErrAborted) {
+		breaker.Failure(next, err)
+	}
+	return resp, err
+}
+
+// chat runs one claude call with the given model (must hold a.mu)
+func (a *Agent) chat(ctx context.Context, model, message string, callback StreamCallback) (*ChatResponse, error) {
 	// Build command args
 	args := []string{
 		"--dangerously-skip-permissions",
@@ -173,8 +245,8 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 	}
 
 	// Add model flag if specified
-	if a.Model != "" {
-		args = append(args, "--model", a.Model)
+	if model != "" {
+		args = append(args, "--model", model)
 	}
 
 	// Add --resume if we have a session ID
@@ -183,9 +255,17 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 	}
 
 	// Create the command
-	cmd := a.spawner.commandCreator(a.spawner.claudePath, args...)
+	cmd := a.spawner.commandCreator(a.spawner.claudePath, append(append([]string{}, a.spawner.claudeArgs...), args...)...)
 	cmd.Dir = a.WorkDir
 
+	// Expose agent identity to MCP tools spawned by claude (reports, inbox)
+	if a.Name != "" {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, "MOB_AGENT_ID="+a.ID, "MOB_AGENT_NAME="+a.Name)
+	}
+
 	// Set up stdin with the message
 	inputMsg := map[string]interface{}{
 		"type": "user",
@@ -200,6 +280,11 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 	}
 	cmd.Stdin = bytes.NewReader(append(inputBytes, '\n'))
 
+	tracker := a.spawner.processTracker()
+	if tracker != nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	}
+
 	// Set up stdout pipe for streaming
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -216,6 +301,23 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start claude: %w", err)
 	}
+	a.setProcess(cmd)
+	defer a.setProcess(nil)
+	if tracker != nil {
+		tracker.Track(a.ID, cmd.Process.Pid)
+		defer tracker.Untrack(a.ID)
+	}
+
+	// Kill the process if the caller gives up on the call
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			a.Abort()
+		case <-done:
+		}
+	}()
 
 	// Start goroutine to capture stderr
 	var stderrBuf bytes.Buffer
@@ -228,6 +330,7 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 			if a.spawner != nil {
 				a.spawner.emitOutput(a.ID, a.Name, line, "stderr")
 			}
+			a.recordOutput(line)
 		}
 	}()
 
@@ -235,6 +338,7 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 	response := &ChatResponse{}
 	var streamLines []string
 	currentBlocks := map[int]*ChatContentBlock{}
+	usage := &usageTracker{}
 
 	scanner := bufio.NewScanner(stdout)
 	// Increase buffer size for large responses
@@ -246,6 +350,7 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 		if a.spawner != nil {
 			a.spawner.emitOutput(a.ID, a.Name, line, "stdout")
 		}
+		a.recordOutput(line)
 		if line == "" {
 			continue
 		}
@@ -263,6 +368,12 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 			}
 		}
 
+		if a.OnUsage != nil {
+			if total, ok := usage.update(msg); ok {
+				a.OnUsage(total)
+			}
+		}
+
 		// Capture session ID
 		if msg.SessionID != "" && a.SessionID == "" {
 			a.SessionID = msg.SessionID
@@ -298,8 +409,12 @@ func (a *Agent) ChatStream(message string, callback StreamCallback) (*ChatRespon
 	}
 
 	// Wait for command to finish
-	if err := cmd.Wait(); err != nil {
-		return nil, fmt.Errorf("claude command failed: %w (stderr: %s)", err, stderrBuf.String())
+	waitErr := cmd.Wait()
+	if a.wasAborted() {
+		return nil, ErrAborted
+	}
+	if waitErr != nil {
+		return nil, fmt.Errorf("claude command failed: %w (stderr: %s)", waitErr, stderrBuf.String())
 	}
 
 	if len(response.Blocks) == 0 {
@@ -400,11 +515,108 @@ func (a *Agent) Send(method string, params interface{}) error {
 	return fmt.Errorf("invalid params for Send")
 }
 
+// recordOutput appends a line to the agent's bounded output tail
+func (a *Agent) recordOutput(line string) {
+	if line == "" {
+		return
+	}
+	a.outputMu.Lock()
+	defer a.outputMu.Unlock()
+	a.lastOutput = time.Now()
+	a.outputTail = append(a.outputTail, line)
+	if len(a.outputTail) > outputTailSize {
+		a.outputTail = a.outputTail[len(a.outputTail)-outputTailSize:]
+	}
+}
+
+// RecentOutput returns up to n of the most recent raw output lines
+func (a *Agent) RecentOutput(n int) []string {
+	a.outputMu.Lock()
+	defer a.outputMu.Unlock()
+	if n <= 0 || n > len(a.outputTail) {
+		n = len(a.outputTail)
+	}
+	lines := make([]string, n)
+	copy(lines, a.outputTail[len(a.outputTail)-n:])
+	return lines
+}
+
+// LastOutputAt returns when the agent last printed a line of output, zero
+// if it never has
+func (a *Agent) LastOutputAt() time.Time {
+	a.outputMu.Lock()
+	defer a.outputMu.Unlock()
+	return a.lastOutput
+}
+
+// CallStartedAt returns when the in-flight call's claude process started,
+// zero when no call is running
+func (a *Agent) CallStartedAt() time.Time {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+	return a.procStarted
+}
+
 // IsRunning returns true if the agent is available for messages
 func (a *Agent) IsRunning() bool {
 	return a.spawner != nil
 }
 
+// Abort kills the claude process serving an in-flight call, which then
+// returns ErrAborted. It reports whether a call was running.
+func (a *Agent) Abort() bool {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+
+	if a.proc == nil || a.proc.Process == nil {
+		return false
+	}
+	a.aborted = true
+	if attr := a.proc.SysProcAttr; attr != nil && attr.Setpgid {
+		// Take down the tools and servers the call started along with it
+		syscall.Kill(-a.proc.Process.Pid, syscall.SIGKILL)
+	}
+	a.proc.Process.Kill()
+	return true
+}
+
+// SetOnProcess sets a callback for the claude PID when a call starts and 0
+// when it ends. It is safe to call while another call is running.
+func (a *Agent) SetOnProcess(fn func(pid int)) {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+	a.onProcess = fn
+}
+
+// setProcess records the process serving the current call and reports it to
+// the SetOnProcess callback
+func (a *Agent) setProcess(cmd *exec.Cmd) {
+	a.procMu.Lock()
+	a.proc = cmd
+	a.procStarted = time.Time{}
+	if cmd != nil {
+		a.aborted = false
+		a.procStarted = time.Now()
+	}
+	onProcess := a.onProcess
+	a.procMu.Unlock()
+
+	if onProcess != nil {
+		pid := 0
+		if cmd != nil && cmd.Process != nil {
+			pid = cmd.Process.Pid
+		}
+		onProcess(pid)
+	}
+}
+
+// wasAborted reports whether Abort killed the current call
+func (a *Agent) wasAborted() bool {
+	a.procMu.Lock()
+	defer a.procMu.Unlock()
+	return a.aborted
+}
+
 // Kill clears the session (no persistent process to kill)
 func (a *Agent) Kill() error {
 	a.mu.Lock()
@@ -0,0 +1,27 @@
+package agent
+
+import "fmt"
+
+// ConventionsSection formats a turf's conventions document for inclusion in
+// an agent prompt. It returns "" when there are no conventions.
+func ConventionsSection(turfName, conventions string) string {
+	if conventions == "" {
+		return ""
+	}
+	return fmt.Sprintf(`## Project Conventions (turf: %s)
+
+These are the established patterns for this project. Follow them, and call
+update_conventions when you learn something future agents should know.
+
+%s
+`, turfName, conventions)
+}
+
+// WithConventions appends a turf's conventions to a system prompt
+func WithConventions(prompt, turfName, conventions string) string {
+	section := ConventionsSection(turfName, conventions)
+	if section == "" {
+		return prompt
+	}
+	return prompt + "\n" + section
+}
@@ -1,14 +1,21 @@
 package agent
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/gabe/mob/internal/errkind"
+)
 
 var (
 	// ErrAgentNotConnected is returned when the agent's IPC client is nil
-	ErrAgentNotConnected = errors.New("agent not connected")
+	ErrAgentNotConnected = errkind.New(errkind.Transient, "agent not connected")
 
 	// ErrAgentNotStarted is returned when the agent's process hasn't been started
-	ErrAgentNotStarted = errors.New("agent not started")
+	ErrAgentNotStarted = errkind.New(errkind.Transient, "agent not started")
 
 	// ErrAgentNotFound is returned when an agent cannot be found by ID
-	ErrAgentNotFound = errors.New("agent not found")
+	ErrAgentNotFound = errkind.New(errkind.NotFound, "agent not found")
+
+	// ErrAborted is returned when a call is cancelled before the agent finishes
+	ErrAborted = errors.New("agent call aborted")
 )
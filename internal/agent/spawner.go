@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"io"
+	"os"
 	"os/exec"
 	"sync"
 	"time"
@@ -28,21 +29,89 @@ func defaultCommandCreator(name string, args ...string) *exec.Cmd {
 	return exec.Command(name, args...)
 }
 
+// ClaudeEnv names the environment variable that overrides the claude binary.
+// Set it to a path to use a different build, or to "mock" to run the built-in
+// mock runner (mob mock-claude) for end-to-end tests.
+const ClaudeEnv = "MOB_CLAUDE"
+
+// claudeCommand resolves the binary and leading arguments used to run claude
+func claudeCommand() (string, []string) {
+	switch v := os.Getenv(ClaudeEnv); v {
+	case "":
+		return "claude", nil
+	case "mock":
+		exe, err := os.Executable()
+		if err != nil {
+			exe = "mob" // Fall back to PATH lookup
+		}
+		return exe, []string{"mock-claude"}
+	default:
+		return v, nil
+	}
+}
+
 // Spawner manages spawning and tracking Claude Code instances
 type Spawner struct {
 	claudePath     string              // path to claude binary (default: "claude")
+	claudeArgs     []string            // arguments placed before claude's flags, for the mock runner
 	agents         map[string]*Agent
 	mu             sync.RWMutex
 	commandCreator CommandCreator      // for dependency injection in tests
 	outputChan     chan AgentOutput    // broadcast channel for agent output
 	outputSubs     []chan AgentOutput  // subscribers to agent output
 	outputSubsMu   sync.RWMutex        // protects outputSubs
+	tracker        ProcessTracker      // records process groups of in-flight calls; nil = not tracked
+	breaker        ModelBreaker        // moves calls off failing models; nil = calls always use the agent's model
+}
+
+// ProcessTracker records the process groups of in-flight claude calls so a
+// restarted daemon can reap calls orphaned when it was killed
+type ProcessTracker interface {
+	Track(agentID string, pgid int)
+	Untrack(agentID string)
+}
+
+// SetProcessTracker runs each claude call in its own process group, reported
+// to t, so the call and everything it started can be killed together
+func (s *Spawner) SetProcessTracker(t ProcessTracker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tracker = t
+}
+
+func (s *Spawner) processTracker() ProcessTracker {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tracker
+}
+
+// ModelBreaker steers calls away from a model whose provider keeps failing
+type ModelBreaker interface {
+	Route(model string) string // the model a call meant for model should use
+	Failure(model string, err error)
+	Success(model string)
+}
+
+// SetModelBreaker routes every agent's calls through b, which records their
+// outcomes and can move them to another model during an outage
+func (s *Spawner) SetModelBreaker(b ModelBreaker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.breaker = b
+}
+
+func (s *Spawner) modelBreaker() ModelBreaker {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.breaker
 }
 
 // NewSpawner creates a new spawner
 func NewSpawner() *Spawner {
+	claudePath, claudeArgs := claudeCommand()
 	s := &Spawner{
-		claudePath:     "claude",
+		claudePath:     claudePath,
+		claudeArgs:     claudeArgs,
 		agents:         make(map[string]*Agent),
 		commandCreator: defaultCommandCreator,
 		outputChan:     make(chan AgentOutput, 1000),
@@ -93,6 +162,8 @@ type SpawnOptions struct {
 	SystemPrompt string // Injected on first call via --system-prompt
 	MCPConfig    string // Path to MCP config JSON file
 	Model        string // Model to use (e.g., "sonnet", "opus") - passed as --model flag
+	ID           string // Reuse an agent's ID, e.g. across a daemon restart; generated when empty
+	SessionID    string // Claude session to resume instead of starting a new one
 }
 
 // Spawn creates a new Claude Code agent that can send messages
@@ -112,7 +183,10 @@ func (s *Spawner) SpawnWithOptions(opts SpawnOptions) (*Agent, error) {
 	defer s.mu.Unlock()
 
 	// Create agent (no process yet - spawns per-call)
-	id := generateID()
+	id := opts.ID
+	if id == "" {
+		id = generateID()
+	}
 	agent := &Agent{
 		ID:           id,
 		Type:         opts.Type,
@@ -122,6 +196,7 @@ func (s *Spawner) SpawnWithOptions(opts SpawnOptions) (*Agent, error) {
 		SystemPrompt: opts.SystemPrompt,
 		MCPConfig:    opts.MCPConfig,
 		Model:        opts.Model,
+		SessionID:    opts.SessionID,
 		StartedAt:    time.Now(),
 		spawner:      s,
 	}
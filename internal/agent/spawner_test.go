@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"context"
 	"os/exec"
 	"testing"
 	"time"
@@ -40,6 +41,22 @@ func TestSpawner_SpawnGeneratesID(t *testing.T) {
 	}
 }
 
+func TestSpawner_SpawnResumesSession(t *testing.T) {
+	spawner := NewSpawner()
+	defer spawner.KillAll()
+
+	a, err := spawner.SpawnWithOptions(SpawnOptions{Type: AgentTypeSoldati, Name: "vinnie", ID: "0123456789abcdef", SessionID: "sess-1"})
+	if err != nil {
+		t.Fatalf("SpawnWithOptions failed: %v", err)
+	}
+	if a.ID != "0123456789abcdef" || a.SessionID != "sess-1" {
+		t.Errorf("got ID %q session %q, want the ones given", a.ID, a.SessionID)
+	}
+	if got, ok := spawner.Get("0123456789abcdef"); !ok || got != a {
+		t.Error("agent should be tracked under the reused ID")
+	}
+}
+
 func TestAgent_IsRunning(t *testing.T) {
 	// Test with nil spawner
 	agent := &Agent{}
@@ -395,3 +412,108 @@ func TestSpawner_CommandCreator(t *testing.T) {
 		// This is expected behavior in the new architecture
 	}
 }
+
+func TestAgent_ChatStreamContextAbort(t *testing.T) {
+	spawner := NewSpawner()
+	spawner.SetCommandCreator(func(name string, args ...string) *exec.Cmd {
+		return exec.Command("sleep", "10")
+	})
+
+	var pids []int
+	a := &Agent{
+		ID:      "agent-1",
+		WorkDir: t.TempDir(),
+		spawner: spawner,
+	}
+	a.SetOnProcess(func(pid int) { pids = append(pids, pid) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := a.ChatStreamContext(ctx, "hi", nil)
+	if err != ErrAborted {
+		t.Fatalf("ChatStreamContext error = %v, want ErrAborted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("abort took %v, want the process killed promptly", elapsed)
+	}
+	if len(pids) != 2 || pids[0] == 0 || pids[1] != 0 {
+		t.Errorf("process callbacks = %v, want [pid 0]", pids)
+	}
+	if a.Abort() {
+		t.Error("Abort() with no call in flight = true, want false")
+	}
+}
+
+func TestClaudeCommand(t *testing.T) {
+	t.Setenv(ClaudeEnv, "")
+	if path, args := claudeCommand(); path != "claude" || args != nil {
+		t.Errorf("default = %q %v, want claude", path, args)
+	}
+
+	t.Setenv(ClaudeEnv, "/opt/claude-dev")
+	if path, args := claudeCommand(); path != "/opt/claude-dev" || args != nil {
+		t.Errorf("override = %q %v, want /opt/claude-dev", path, args)
+	}
+
+	t.Setenv(ClaudeEnv, "mock")
+	if path, args := claudeCommand(); path == "claude" || len(args) != 1 || args[0] != "mock-claude" {
+		t.Errorf("mock = %q %v, want this binary with mock-claude", path, args)
+	}
+}
+
+// fakeBreaker opens on the first failure and routes to sonnet while open
+type fakeBreaker struct {
+	open      bool
+	failures  []string
+	successes []string
+}
+
+func (b *fakeBreaker) Route(model string) string {
+	if b.open {
+		return "sonnet"
+	}
+	return model
+}
+
+func (b *fakeBreaker) Failure(model string, err error) {
+	b.failures = append(b.failures, model)
+	b.open = true
+}
+
+func (b *fakeBreaker) Success(model string) {
+	b.successes = append(b.successes, model)
+}
+
+func TestAgent_ChatFailsOverToSecondaryModel(t *testing.T) {
+	spawner := NewSpawner()
+	spawner.SetCommandCreator(func(name string, args ...string) *exec.Cmd {
+		for i, arg := range args {
+			if arg == "--model" && args[i+1] == "opus" {
+				return exec.Command("echo", `{"type":"result","is_error":true,"result":"API Error: 529 overloaded"}`)
+			}
+		}
+		return exec.Command("echo", `{"type":"assistant","message":{"model":"sonnet","content":[{"type":"text","text":"ok"}]}}`)
+	})
+	breaker := &fakeBreaker{}
+	spawner.SetModelBreaker(breaker)
+
+	a := &Agent{ID: "agent-1", WorkDir: t.TempDir(), Model: "opus", spawner: spawner}
+	resp, err := a.ChatStreamContext(context.Background(), "hi", nil)
+	if err != nil {
+		t.Fatalf("expected the call to fail over, got %v", err)
+	}
+	if resp.GetText() != "ok" {
+		t.Errorf("response = %q, want ok", resp.GetText())
+	}
+	if len(breaker.failures) != 1 || breaker.failures[0] != "opus" {
+		t.Errorf("failures = %v, want [opus]", breaker.failures)
+	}
+	if len(breaker.successes) != 1 || breaker.successes[0] != "sonnet" {
+		t.Errorf("successes = %v, want [sonnet]", breaker.successes)
+	}
+	if a.Model != "opus" {
+		t.Errorf("agent model changed to %q; failover must not rewrite it", a.Model)
+	}
+}
@@ -78,3 +78,43 @@ func parseStreamBlocks(lines []string) []ChatContentBlock {
 
 	return blocks
 }
+
+// usageTracker accumulates token usage across the assistant messages of one
+// streamed response. Output tokens in message_delta events are cumulative per
+// message, so each message's latest counts replace the previous ones.
+type usageTracker struct {
+	done    UsageInfo
+	current UsageInfo
+}
+
+// update applies a stream message and returns the running total if it changed
+func (t *usageTracker) update(msg StreamMessage) (UsageInfo, bool) {
+	if msg.Type != "stream_event" || msg.Event == nil {
+		return UsageInfo{}, false
+	}
+
+	switch msg.Event.Type {
+	case "message_start":
+		if msg.Event.Message == nil || msg.Event.Message.Usage == nil {
+			return UsageInfo{}, false
+		}
+		t.done.InputTokens += t.current.InputTokens
+		t.done.OutputTokens += t.current.OutputTokens
+		t.current = *msg.Event.Message.Usage
+	case "message_delta":
+		if msg.Event.Usage == nil {
+			return UsageInfo{}, false
+		}
+		if msg.Event.Usage.InputTokens > 0 {
+			t.current.InputTokens = msg.Event.Usage.InputTokens
+		}
+		t.current.OutputTokens = msg.Event.Usage.OutputTokens
+	default:
+		return UsageInfo{}, false
+	}
+
+	return UsageInfo{
+		InputTokens:  t.done.InputTokens + t.current.InputTokens,
+		OutputTokens: t.done.OutputTokens + t.current.OutputTokens,
+	}, true
+}
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -124,7 +125,7 @@ func TestAssistantMessageToolResult(t *testing.T) {
 	blocks := blocksFromAssistantMessage(ClaudeMessage{Content: []ContentBlock{{
 		Type:      "tool_result",
 		ToolUseID: "call-1",
-		Content:   "ok",
+		Content:   json.RawMessage(`"ok"`),
 	}}})
 
 	if len(blocks) != 1 {
@@ -135,6 +136,38 @@ func TestAssistantMessageToolResult(t *testing.T) {
 	}
 }
 
+func TestUsageTrackerAccumulatesAcrossMessages(t *testing.T) {
+	lines := []string{
+		`{"type":"stream_event","event":{"type":"message_start","message":{"usage":{"input_tokens":100,"output_tokens":1}}}}`,
+		`{"type":"stream_event","event":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"hi"}}}`,
+		`{"type":"stream_event","event":{"type":"message_delta","usage":{"output_tokens":40}}}`,
+		`{"type":"stream_event","event":{"type":"message_start","message":{"usage":{"input_tokens":150,"output_tokens":1}}}}`,
+		`{"type":"stream_event","event":{"type":"message_delta","usage":{"output_tokens":25}}}`,
+	}
+
+	tracker := &usageTracker{}
+	var updates []UsageInfo
+	for _, line := range lines {
+		var msg StreamMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatal(err)
+		}
+		if total, ok := tracker.update(msg); ok {
+			updates = append(updates, total)
+		}
+	}
+
+	if len(updates) != 4 {
+		t.Fatalf("expected 4 usage updates, got %d", len(updates))
+	}
+	if updates[1] != (UsageInfo{InputTokens: 100, OutputTokens: 40}) {
+		t.Errorf("unexpected usage after first message: %+v", updates[1])
+	}
+	if last := updates[3]; last != (UsageInfo{InputTokens: 250, OutputTokens: 65}) {
+		t.Errorf("unexpected final usage: %+v", last)
+	}
+}
+
 func newTempDir() string {
 	dir, _ := os.MkdirTemp("", "mob-agent-test")
 	return dir
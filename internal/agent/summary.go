@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSummaryChanges caps how many characters of the agent's closing message are kept
+const maxSummaryChanges = 1500
+
+// editTools are tool names whose input names a file the agent modified
+var editTools = map[string]bool{
+	"Edit":         true,
+	"MultiEdit":    true,
+	"Write":        true,
+	"NotebookEdit": true,
+}
+
+// testCommands are command fragments that mark a shell call as a test run
+var testCommands = []string{
+	"go test", "npm test", "npm run test", "yarn test", "pnpm test", "bun test",
+	"pytest", "cargo test", "make test", "jest", "vitest", "mvn test", "gradle test",
+	"rspec", "mix test", "dotnet test",
+}
+
+// concernMarkers flag lines of the closing message worth surfacing as open
+// concerns. A bare "didn't" also matches work deliberately left alone ("didn't
+// need to touch the schema"), so only phrases about unfinished work count.
+var concernMarkers = []string{
+	"todo", "concern", "caveat", "warning", "follow-up", "followup", "not yet",
+	"unable to", "couldn't", "could not", "didn't get to", "did not get to",
+	"didn't finish", "did not finish", "remaining", "known issue",
+}
+
+// WorkSummary is a structured account of what an agent did, extracted from
+// the blocks of its response
+type WorkSummary struct {
+	Changes      string   // The agent's closing description of its work
+	FilesTouched []string // Files edited or written, sorted
+	TestsRun     []string // Shell commands that ran tests, in order
+	Concerns     []string // Lines of the closing message that flag open issues
+}
+
+// Summarize builds a WorkSummary from a completed response
+func Summarize(resp *ChatResponse) WorkSummary {
+	var summary WorkSummary
+	if resp == nil {
+		return summary
+	}
+
+	files := make(map[string]bool)
+	seenTests := make(map[string]bool)
+	lastText := ""
+
+	for _, b := range resp.Blocks {
+		switch b.Type {
+		case ContentTypeText:
+			if strings.TrimSpace(b.Text) != "" {
+				lastText = b.Text
+			}
+		case ContentTypeToolUse:
+			var input map[string]interface{}
+			if err := json.Unmarshal([]byte(b.Input), &input); err != nil {
+				continue
+			}
+			if editTools[b.Name] {
+				for _, key := range []string{"file_path", "notebook_path"} {
+					if path, ok := input[key].(string); ok && path != "" {
+						files[path] = true
+					}
+				}
+			}
+			if b.Name == "Bash" {
+				if cmd, ok := input["command"].(string); ok && isTestCommand(cmd) && !seenTests[cmd] {
+					seenTests[cmd] = true
+					summary.TestsRun = append(summary.TestsRun, cmd)
+				}
+			}
+		}
+	}
+
+	for path := range files {
+		summary.FilesTouched = append(summary.FilesTouched, path)
+	}
+	sort.Strings(summary.FilesTouched)
+
+	summary.Changes = strings.TrimSpace(lastText)
+	if r := []rune(summary.Changes); len(r) > maxSummaryChanges {
+		summary.Changes = string(r[:maxSummaryChanges]) + "..."
+	}
+	summary.Concerns = findConcerns(lastText)
+
+	return summary
+}
+
+// isTestCommand reports whether a shell command runs a test suite
+func isTestCommand(cmd string) bool {
+	lower := strings.ToLower(cmd)
+	for _, marker := range testCommands {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// findConcerns returns the lines of text that mention unfinished work or risks
+func findConcerns(text string) []string {
+	var concerns []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*•"))
+		if trimmed == "" {
+			continue
+		}
+		lower := strings.ToLower(trimmed)
+		for _, marker := range concernMarkers {
+			if strings.Contains(lower, marker) {
+				concerns = append(concerns, trimmed)
+				break
+			}
+		}
+	}
+	return concerns
+}
+
+// Format renders the summary as a bead comment
+func (s WorkSummary) Format(agentName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Work summary from %s\n", agentName)
+
+	b.WriteString("\nWhat changed:\n")
+	if s.Changes != "" {
+		b.WriteString(s.Changes + "\n")
+	} else {
+		b.WriteString("(no description given)\n")
+	}
+
+	b.WriteString("\nFiles touched:\n")
+	writeList(&b, s.FilesTouched, "none")
+
+	b.WriteString("\nTests run:\n")
+	writeList(&b, s.TestsRun, "none")
+
+	b.WriteString("\nRemaining concerns:\n")
+	writeList(&b, s.Concerns, "none noted")
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeList writes items as a bulleted list, or a placeholder when empty
+func writeList(b *strings.Builder, items []string, empty string) {
+	if len(items) == 0 {
+		fmt.Fprintf(b, "- %s\n", empty)
+		return
+	}
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSummarize(t *testing.T) {
+	resp := &ChatResponse{Blocks: []ChatContentBlock{
+		{Type: ContentTypeText, Text: "Looking at the handler first."},
+		{Type: ContentTypeToolUse, Name: "Read", Input: `{"file_path":"/repo/auth.go"}`},
+		{Type: ContentTypeToolUse, Name: "Edit", Input: `{"file_path":"/repo/auth.go","old_string":"a","new_string":"b"}`},
+		{Type: ContentTypeToolUse, Name: "Write", Input: `{"file_path":"/repo/auth_test.go","content":"..."}`},
+		{Type: ContentTypeToolUse, Name: "Edit", Input: `{"file_path":"/repo/auth.go","old_string":"c","new_string":"d"}`},
+		{Type: ContentTypeToolUse, Name: "Bash", Input: `{"command":"go build ./..."}`},
+		{Type: ContentTypeToolUse, Name: "Bash", Input: `{"command":"go test ./internal/auth/..."}`},
+		{Type: ContentTypeToolUse, Name: "Bash", Input: `{"command":"go test ./internal/auth/..."}`},
+		{Type: ContentTypeText, Text: "Added token refresh to the auth handler.\n\n- Refresh runs before expiry\n- TODO: the retry backoff is not yet configurable\n"},
+	}}
+
+	s := Summarize(resp)
+
+	if !strings.HasPrefix(s.Changes, "Added token refresh") {
+		t.Errorf("Changes = %q, want the closing message", s.Changes)
+	}
+	if len(s.FilesTouched) != 2 || s.FilesTouched[0] != "/repo/auth.go" || s.FilesTouched[1] != "/repo/auth_test.go" {
+		t.Errorf("FilesTouched = %v", s.FilesTouched)
+	}
+	if len(s.TestsRun) != 1 || s.TestsRun[0] != "go test ./internal/auth/..." {
+		t.Errorf("TestsRun = %v", s.TestsRun)
+	}
+	if len(s.Concerns) != 1 || !strings.HasPrefix(s.Concerns[0], "TODO: the retry backoff") {
+		t.Errorf("Concerns = %v", s.Concerns)
+	}
+}
+
+func TestSummarizeConcernsAndLongChanges(t *testing.T) {
+	text := "Moved the retry into the client.\n" +
+		"I didn't need to touch the schema.\n" +
+		"Didn't get to the metrics endpoint.\n"
+	s := Summarize(&ChatResponse{Blocks: []ChatContentBlock{{Type: ContentTypeText, Text: text}}})
+	if len(s.Concerns) != 1 || s.Concerns[0] != "Didn't get to the metrics endpoint." {
+		t.Errorf("Concerns = %q, want only the unfinished work", s.Concerns)
+	}
+
+	// A cut through multi-byte characters keeps whole characters
+	long := strings.Repeat("é", maxSummaryChanges+10)
+	s = Summarize(&ChatResponse{Blocks: []ChatContentBlock{{Type: ContentTypeText, Text: long}}})
+	if !utf8.ValidString(s.Changes) || s.Changes != strings.Repeat("é", maxSummaryChanges)+"..." {
+		t.Errorf("Changes cut to %d bytes, want %d whole characters and an ellipsis", len(s.Changes), maxSummaryChanges)
+	}
+}
+
+func TestWorkSummaryFormat(t *testing.T) {
+	out := WorkSummary{
+		Changes:      "Fixed the login bug.",
+		FilesTouched: []string{"auth.go"},
+	}.Format("associate-1")
+
+	for _, want := range []string{
+		"Work summary from associate-1",
+		"What changed:\nFixed the login bug.",
+		"Files touched:\n- auth.go",
+		"Tests run:\n- none",
+		"Remaining concerns:\n- none noted",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Format() missing %q in:\n%s", want, out)
+		}
+	}
+
+	if empty := Summarize(nil); empty.Changes != "" || len(empty.FilesTouched) != 0 {
+		t.Errorf("Summarize(nil) = %+v, want zero value", empty)
+	}
+}
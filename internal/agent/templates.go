@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Names of the system prompts that can be overridden with a template file
+const (
+	PromptSoldati   = "soldati"
+	PromptAssociate = "associate"
+	PromptReviewer  = "reviewer"
+	PromptEstimator = "estimator"
+)
+
+// defaultPrompts are the compiled-in prompts used when no template file exists
+var defaultPrompts = map[string]string{
+	PromptSoldati:   SoldatiSystemPrompt,
+	PromptAssociate: AssociateSystemPrompt,
+	PromptReviewer:  ReviewerSystemPrompt,
+	PromptEstimator: EstimatorSystemPrompt,
+}
+
+// PromptNames lists the prompts that can be customised, sorted
+var PromptNames = slices.Sorted(maps.Keys(defaultPrompts))
+
+// PromptVars are the values available to a prompt template. Conventions is
+// the turf's raw conventions document; templates see it already formatted as
+// a prompt section (empty when the turf has none).
+type PromptVars struct {
+	Name        string // agent name, empty when not yet known
+	Turf        string
+	BeadID      string
+	Conventions string
+}
+
+// PromptsDir returns the directory holding prompt templates (~/mob/prompts)
+func PromptsDir(mobDir string) string {
+	return filepath.Join(mobDir, "prompts")
+}
+
+// PromptPath returns the template file overriding the named prompt
+func PromptPath(mobDir, name string) string {
+	return filepath.Join(PromptsDir(mobDir), name+".md")
+}
+
+// DefaultPrompt returns the compiled-in text of the named prompt
+func DefaultPrompt(name string) (string, error) {
+	prompt, ok := defaultPrompts[name]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt %q (want one of %s)", name, strings.Join(PromptNames, ", "))
+	}
+	return prompt, nil
+}
+
+// LoadPromptTemplate returns the template text for the named prompt: the
+// file under PromptsDir when there is one, else the compiled-in default
+func LoadPromptTemplate(mobDir, name string) (text string, custom bool, err error) {
+	def, err := DefaultPrompt(name)
+	if err != nil {
+		return "", false, err
+	}
+	if mobDir == "" {
+		return def, false, nil
+	}
+	data, err := os.ReadFile(PromptPath(mobDir, name))
+	if os.IsNotExist(err) {
+		return def, false, nil
+	}
+	if err != nil {
+		return def, false, err
+	}
+	return string(data), true, nil
+}
+
+// RenderPrompt expands a prompt template. Templates that never mention
+// {{.Conventions}} get the turf's conventions appended, as the compiled-in
+// prompts always have.
+func RenderPrompt(text string, vars PromptVars) (string, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	section := ConventionsSection(vars.Turf, vars.Conventions)
+	data := vars
+	data.Conventions = section
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	out := buf.String()
+	if section != "" && !strings.Contains(text, ".Conventions") {
+		out += "\n" + section
+	}
+	return out, nil
+}
+
+// SystemPrompt builds the named system prompt for a spawn. Template files
+// are read on every call, so edits apply to the next agent spawned. A
+// template that fails to load or render falls back to the compiled-in
+// prompt, returned together with the error so the caller can log it.
+func SystemPrompt(mobDir, name string, vars PromptVars) (string, error) {
+	text, custom, loadErr := LoadPromptTemplate(mobDir, name)
+	if text == "" && loadErr != nil {
+		return "", loadErr
+	}
+	prompt, err := RenderPrompt(text, vars)
+	if err == nil {
+		return prompt, loadErr
+	}
+	if !custom {
+		return "", err
+	}
+
+	def, _ := DefaultPrompt(name)
+	fallback, _ := RenderPrompt(def, vars)
+	return fallback, fmt.Errorf("prompt template %s: %w", PromptPath(mobDir, name), err)
+}
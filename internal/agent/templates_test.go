@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSystemPromptDefault(t *testing.T) {
+	got, err := SystemPrompt(t.TempDir(), PromptSoldati, PromptVars{Turf: "api", Conventions: "Use tabs."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := WithConventions(SoldatiSystemPrompt, "api", "Use tabs.")
+	if got != want {
+		t.Errorf("default prompt should match the compiled-in prompt plus conventions")
+	}
+
+	if _, err := SystemPrompt(t.TempDir(), "capo", PromptVars{}); err == nil {
+		t.Error("expected an error for an unknown prompt")
+	}
+}
+
+func TestSystemPromptTemplate(t *testing.T) {
+	mobDir := t.TempDir()
+	write := func(text string) {
+		t.Helper()
+		if err := os.MkdirAll(PromptsDir(mobDir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(PromptPath(mobDir, PromptAssociate), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vars := PromptVars{Name: "sal", Turf: "api", BeadID: "bd-1234", Conventions: "Use tabs."}
+
+	write("You are {{.Name}} on {{.Turf}}, working {{.BeadID}}.\n")
+	got, err := SystemPrompt(mobDir, PromptAssociate, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "You are sal on api, working bd-1234.\n") {
+		t.Errorf("variables not expanded: %q", got)
+	}
+	if !strings.Contains(got, "Use tabs.") {
+		t.Error("conventions should be appended when the template does not place them")
+	}
+
+	// Edits are picked up on the next call
+	write("Header\n{{.Conventions}}Footer\n")
+	got, err = SystemPrompt(mobDir, PromptAssociate, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(got, "Use tabs.\nFooter\n") || strings.Count(got, "Use tabs.") != 1 {
+		t.Errorf("conventions should be placed once where the template puts them: %q", got)
+	}
+
+	write("Broken {{.Nmae}}\n")
+	got, err = SystemPrompt(mobDir, PromptAssociate, vars)
+	if err == nil || !strings.Contains(err.Error(), filepath.Base(PromptPath(mobDir, PromptAssociate))) {
+		t.Errorf("expected an error naming the template, got %v", err)
+	}
+	if !strings.HasPrefix(got, AssociateSystemPrompt) {
+		t.Error("a broken template should fall back to the compiled-in prompt")
+	}
+}
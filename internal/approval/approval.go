@@ -0,0 +1,243 @@
+// Package approval holds mutating tool calls until a human approves them.
+// The underboss's MCP server files each call it wants to run and waits; the
+// dashboard lists what is waiting, grouped (create 5 beads, spawn 2
+// associates), and answers y or n for the lot.
+package approval
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+var (
+	// ErrDeclined is returned when the user turns a call down
+	ErrDeclined = errkind.New(errkind.Conflict, "declined by the user")
+
+	// ErrNoAnswer is returned when nobody answered before the wait ran out
+	ErrNoAnswer = errkind.New(errkind.Transient, "no answer from the user")
+)
+
+// Decision is the user's answer to a request
+type Decision string
+
+const (
+	Pending  Decision = ""
+	Approved Decision = "approved"
+	Declined Decision = "declined"
+)
+
+// Request is one tool call waiting for approval
+type Request struct {
+	ID        string    `json:"id"`
+	Tool      string    `json:"tool"`
+	Verb      string    `json:"verb"`             // "create"
+	Noun      string    `json:"noun"`             // "bead"
+	Nouns     string    `json:"nouns"`            // "beads"
+	Detail    string    `json:"detail,omitempty"` // what the call would do, e.g. the bead's title
+	CreatedAt time.Time `json:"created_at"`
+	Decision  Decision  `json:"decision,omitempty"`
+}
+
+// PollInterval is how often a waiting call rechecks for an answer
+var PollInterval = 250 * time.Millisecond
+
+// Store keeps requests in a file shared by the MCP server and the dashboard
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Path returns the request file for a mob directory
+func Path(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "approvals.json")
+}
+
+// NewStore returns a store keeping requests at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Ask files req and waits up to wait for the user's answer. It returns nil
+// once the call is approved, ErrDeclined when it is turned down and
+// ErrNoAnswer when the wait runs out. The request is withdrawn either way.
+func (s *Store) Ask(ctx context.Context, req *Request, wait time.Duration) error {
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
+	req.ID = id
+	req.CreatedAt = time.Now()
+	req.Decision = Pending
+	if err := s.update(func(reqs map[string]*Request) { reqs[id] = req }); err != nil {
+		return err
+	}
+	defer s.update(func(reqs map[string]*Request) { delete(reqs, id) })
+
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+	for {
+		reqs, err := s.load()
+		if err != nil {
+			return err
+		}
+		switch r := reqs[id]; {
+		case r == nil:
+			return errkind.New(errkind.Conflict, "the request was withdrawn")
+		case r.Decision == Approved:
+			return nil
+		case r.Decision == Declined:
+			return ErrDeclined
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("%w within %s", ErrNoAnswer, wait)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Pending returns the requests still waiting for an answer, oldest first
+func (s *Store) Pending() ([]*Request, error) {
+	reqs, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []*Request
+	for _, r := range reqs {
+		if r.Decision == Pending {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// Decide answers the requests with the given IDs. Requests already answered
+// or withdrawn are skipped.
+func (s *Store) Decide(ids []string, decision Decision) error {
+	return s.update(func(reqs map[string]*Request) {
+		for _, id := range ids {
+			if r := reqs[id]; r != nil && r.Decision == Pending {
+				r.Decision = decision
+			}
+		}
+	})
+}
+
+// Summarize groups requests into one line, e.g. "create 5 beads, spawn 2
+// associates", in the order each kind first appears
+func Summarize(reqs []*Request) string {
+	type group struct {
+		req   *Request
+		count int
+	}
+	var order []string
+	groups := make(map[string]*group)
+	for _, r := range reqs {
+		key := r.Verb + " " + r.Noun
+		if g := groups[key]; g != nil {
+			g.count++
+			continue
+		}
+		groups[key] = &group{req: r, count: 1}
+		order = append(order, key)
+	}
+
+	parts := make([]string, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		if g.count == 1 {
+			parts = append(parts, fmt.Sprintf("%s 1 %s", g.req.Verb, g.req.Noun))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %d %s", g.req.Verb, g.count, g.req.Nouns))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// generateID creates a short random ID for requests
+func generateID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random ID: %w", err)
+	}
+	return "cf-" + hex.EncodeToString(b), nil
+}
+
+// load reads the requests without holding the file lock; writes replace the
+// file atomically
+func (s *Store) load() (map[string]*Request, error) {
+	reqs := make(map[string]*Request)
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return reqs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return reqs, nil
+	}
+	if err := json.Unmarshal(content, &reqs); err != nil {
+		return nil, fmt.Errorf("approval requests: %w", err)
+	}
+	return reqs, nil
+}
+
+// update applies fn to the requests under the file lock and saves them
+func (s *Store) update(fn func(map[string]*Request)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	reqs, err := s.load()
+	if err != nil {
+		return err
+	}
+	fn(reqs)
+
+	content, err := json.MarshalIndent(reqs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
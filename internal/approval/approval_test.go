@@ -0,0 +1,73 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func init() {
+	PollInterval = 5 * time.Millisecond
+}
+
+// answer decides every request once n are pending
+func answer(t *testing.T, store *Store, n int, decision Decision) {
+	t.Helper()
+	go func() {
+		for {
+			reqs, err := store.Pending()
+			if err == nil && len(reqs) >= n {
+				ids := make([]string, 0, len(reqs))
+				for _, r := range reqs {
+					ids = append(ids, r.ID)
+				}
+				store.Decide(ids, decision)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+}
+
+func TestAsk(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "approvals.json"))
+
+	answer(t, store, 2, Approved)
+	errs := make(chan error, 2)
+	for _, title := range []string{"Fix login", "Add SSO"} {
+		go func() {
+			errs <- store.Ask(context.Background(), &Request{Tool: "create_bead", Verb: "create", Noun: "bead", Nouns: "beads", Detail: title}, time.Second)
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("expected approval, got %v", err)
+		}
+	}
+	if reqs, _ := store.Pending(); len(reqs) != 0 {
+		t.Errorf("expected answered requests withdrawn, got %d", len(reqs))
+	}
+
+	answer(t, store, 1, Declined)
+	if err := store.Ask(context.Background(), &Request{Tool: "kill_agent"}, time.Second); !errors.Is(err, ErrDeclined) {
+		t.Errorf("expected declined, got %v", err)
+	}
+
+	if err := store.Ask(context.Background(), &Request{Tool: "kill_agent"}, 20*time.Millisecond); !errors.Is(err, ErrNoAnswer) {
+		t.Errorf("expected no answer, got %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	bead := &Request{Verb: "create", Noun: "bead", Nouns: "beads"}
+	associate := &Request{Verb: "spawn", Noun: "associate", Nouns: "associates"}
+	got := Summarize([]*Request{bead, associate, bead, bead, associate, bead, bead})
+	if want := "create 5 beads, spawn 2 associates"; got != want {
+		t.Errorf("Summarize = %q, want %q", got, want)
+	}
+	if got := Summarize([]*Request{associate}); got != "spawn 1 associate" {
+		t.Errorf("Summarize = %q", got)
+	}
+}
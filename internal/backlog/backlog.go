@@ -0,0 +1,284 @@
+// Package backlog reads and writes beads as JSON or CSV, so a backlog can be
+// moved between mob instances or seeded from a spreadsheet.
+//
+// JSON is an array of beads in the store's own format (JSON Lines is read
+// too). CSV has one bead per row under a header naming the columns; only
+// title is required, and columns mob does not know are ignored. Lists such
+// as blocks are comma-separated within their cell, and checklist items go
+// one per line, with "[x] " marking the done ones.
+package backlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+// Formats beads can be read and written in
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// ErrInvalid is returned for input that cannot be read as beads
+var ErrInvalid = errkind.New(errkind.Invalid, "invalid bead import")
+
+// Columns are the CSV columns written, in order
+var Columns = []string{
+	"id", "title", "description", "status", "priority", "type", "assignee", "labels", "turf",
+	"parent_id", "blocks", "related", "discovered_from", "checklist", "due_at", "sla",
+	"time_estimate", "created_at", "closed_at", "close_reason",
+}
+
+// ParseFormat checks a format name
+func ParseFormat(s string) (string, error) {
+	switch f := strings.ToLower(strings.TrimSpace(s)); f {
+	case FormatJSON, FormatCSV:
+		return f, nil
+	case "jsonl":
+		return FormatJSON, nil
+	}
+	return "", errkind.New(errkind.Invalid, fmt.Sprintf("unknown format %q (expected json or csv)", s))
+}
+
+// FormatOf guesses a file's format from its extension, defaulting to JSON
+func FormatOf(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return FormatCSV
+	}
+	return FormatJSON
+}
+
+// Write writes beads in the given format
+func Write(w io.Writer, beads []*models.Bead, format string) error {
+	if format == FormatCSV {
+		return writeCSV(w, beads)
+	}
+	if beads == nil {
+		beads = []*models.Bead{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(beads)
+}
+
+// Read reads beads in the given format
+func Read(r io.Reader, format string) ([]*models.Bead, error) {
+	if format == FormatCSV {
+		return readCSV(r)
+	}
+	return readJSON(r)
+}
+
+func readJSON(r io.Reader) ([]*models.Bead, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var beads []*models.Bead
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &beads); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		return beads, nil
+	}
+
+	// One bead per line
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var b models.Bead
+		if err := json.Unmarshal(text, &b); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalid, line, err)
+		}
+		beads = append(beads, &b)
+	}
+	return beads, scanner.Err()
+}
+
+func writeCSV(w io.Writer, beads []*models.Bead) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Columns); err != nil {
+		return err
+	}
+	for _, b := range beads {
+		row := []string{
+			b.ID, b.Title, b.Description, string(b.Status), strconv.Itoa(b.Priority), string(b.Type),
+			b.Assignee, strings.Join(b.Labels, ","), b.Turf, b.ParentID, strings.Join(b.Blocks, ","), strings.Join(b.Related, ","),
+			b.DiscoveredFrom, formatChecklist(b.Checklist), formatTime(b.DueAt), b.SLA, b.TimeEstimate,
+			formatTime(&b.CreatedAt), formatTime(b.ClosedAt), b.CloseReason,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readCSV(r io.Reader) ([]*models.Bead, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		col[strings.ReplaceAll(name, " ", "_")] = i
+	}
+	if _, ok := col["title"]; !ok {
+		return nil, fmt.Errorf("%w: the header has no title column", ErrInvalid)
+	}
+
+	var beads []*models.Bead
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return beads, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		if get("title") == "" && strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue // blank row
+		}
+
+		b := &models.Bead{
+			ID:             get("id"),
+			Title:          get("title"),
+			Description:    get("description"),
+			Status:         models.BeadStatus(get("status")),
+			Type:           models.BeadType(get("type")),
+			Assignee:       get("assignee"),
+			Labels:         models.ParseLabels(get("labels")),
+			Turf:           get("turf"),
+			ParentID:       get("parent_id"),
+			Blocks:         splitIDs(get("blocks")),
+			Related:        splitIDs(get("related")),
+			DiscoveredFrom: get("discovered_from"),
+			Checklist:      parseChecklist(get("checklist")),
+			SLA:            get("sla"),
+			TimeEstimate:   get("time_estimate"),
+			CloseReason:    get("close_reason"),
+			Priority:       2,
+		}
+		if p := get("priority"); p != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(p), "P"))
+			if err != nil || n < 0 || n > 4 {
+				return nil, fmt.Errorf("%w: line %d: priority %q is not 0-4", ErrInvalid, line, p)
+			}
+			b.Priority = n
+		}
+		if _, err := models.ParseSLA(b.SLA); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalid, line, err)
+		}
+		if _, err := models.ParseTimeEstimate(b.TimeEstimate); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalid, line, err)
+		}
+		if b.DueAt, err = parseTime(get("due_at"), true); err != nil {
+			return nil, fmt.Errorf("%w: line %d: due_at: %v", ErrInvalid, line, err)
+		}
+		if b.ClosedAt, err = parseTime(get("closed_at"), false); err != nil {
+			return nil, fmt.Errorf("%w: line %d: closed_at: %v", ErrInvalid, line, err)
+		}
+		created, err := parseTime(get("created_at"), false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: created_at: %v", ErrInvalid, line, err)
+		}
+		if created != nil {
+			b.CreatedAt = *created
+		}
+		beads = append(beads, b)
+	}
+}
+
+// splitIDs reads a cell of bead IDs separated by commas or spaces
+func splitIDs(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == ';' })
+}
+
+// formatChecklist puts one item per line, marking the done ones
+func formatChecklist(items []models.ChecklistItem) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = item.Text
+		if item.Done {
+			lines[i] = "[x] " + item.Text
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseChecklist(s string) []models.ChecklistItem {
+	var items []models.ChecklistItem
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		item := models.ChecklistItem{Text: line}
+		if rest, ok := strings.CutPrefix(line, "[x] "); ok {
+			item = models.ChecklistItem{Text: rest, Done: true}
+		} else if rest, ok := strings.CutPrefix(line, "[ ] "); ok {
+			item.Text = rest
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// parseTime reads an RFC 3339 time or a date. A due date given as a date
+// means the end of that day.
+func parseTime(s string, endOfDay bool) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a date (2006-01-02) or RFC 3339 time", s)
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return &day, nil
+}
@@ -0,0 +1,126 @@
+package backlog
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	in := []*models.Bead{{
+		ID:          "bd-1",
+		Title:       "Ship it, finally",
+		Description: "Two\nlines",
+		Status:      models.BeadStatusBlocked,
+		Priority:    1,
+		Type:        models.BeadTypeFeature,
+		Labels:      []string{"api", "auth"},
+		Turf:        "api",
+		Blocks:      []string{"bd-2", "bd-3"},
+		Checklist:   []models.ChecklistItem{{Text: "write"}, {Text: "test", Done: true}},
+		DueAt:       &due,
+		SLA:         "3d",
+		CreatedAt:   due.Add(-48 * time.Hour),
+	}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, in, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	out, err := Read(&buf, FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("read %d beads, want 1", len(out))
+	}
+	b := out[0]
+	if b.ID != "bd-1" || b.Title != in[0].Title || b.Description != in[0].Description || b.Priority != 1 ||
+		b.Status != models.BeadStatusBlocked || strings.Join(b.Labels, ",") != "api,auth" || b.SLA != "3d" {
+		t.Errorf("read %+v", b)
+	}
+	if strings.Join(b.Blocks, " ") != "bd-2 bd-3" {
+		t.Errorf("blocks = %v", b.Blocks)
+	}
+	if len(b.Checklist) != 2 || b.Checklist[0].Done || !b.Checklist[1].Done || b.Checklist[1].Text != "test" {
+		t.Errorf("checklist = %+v", b.Checklist)
+	}
+	if b.DueAt == nil || !b.DueAt.Equal(due) || !b.CreatedAt.Equal(in[0].CreatedAt) {
+		t.Errorf("due %v created %v", b.DueAt, b.CreatedAt)
+	}
+}
+
+func TestReadSpreadsheetCSV(t *testing.T) {
+	sheet := "\ufeffTitle,Priority,Due At,Notes\nFirst,P0,2026-03-01,ignored\n,,,\nSecond,,,\n"
+	beads, err := Read(strings.NewReader(sheet), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beads) != 2 {
+		t.Fatalf("read %d beads, want 2", len(beads))
+	}
+	if beads[0].Title != "First" || beads[0].Priority != 0 || beads[0].DueAt == nil || beads[0].DueAt.Day() != 1 || beads[0].DueAt.Hour() != 23 {
+		t.Errorf("first = %+v due %v", beads[0], beads[0].DueAt)
+	}
+	if beads[1].Priority != 2 {
+		t.Errorf("second priority = %d, want the default 2", beads[1].Priority)
+	}
+
+	for _, bad := range []string{"name\nx\n", "title,priority\nx,9\n", "title,sla\nx,soon\n"} {
+		if _, err := Read(strings.NewReader(bad), FormatCSV); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Read(%q) = %v, want ErrInvalid", bad, err)
+		}
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	for name, data := range map[string]string{
+		"array": `[{"id":"bd-1","title":"a"},{"id":"bd-2","title":"b"}]`,
+		"lines": "{\"id\":\"bd-1\",\"title\":\"a\"}\n\n{\"id\":\"bd-2\",\"title\":\"b\"}\n",
+	} {
+		beads, err := Read(strings.NewReader(data), FormatJSON)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(beads) != 2 || beads[1].Title != "b" {
+			t.Errorf("%s: read %+v", name, beads)
+		}
+	}
+	if _, err := Read(strings.NewReader("{oops"), FormatJSON); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	f, err := ParseFilter([]string{"status=open,blocked", "label=backend", "priority=P0,1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		bead *models.Bead
+		want bool
+	}{
+		{&models.Bead{Status: models.BeadStatusOpen, Labels: []string{"ui", "backend"}, Priority: 1}, true},
+		{&models.Bead{Status: models.BeadStatusBlocked, Labels: []string{"backend"}, Priority: 0}, true},
+		{&models.Bead{Status: models.BeadStatusClosed, Labels: []string{"backend"}, Priority: 0}, false},
+		{&models.Bead{Status: models.BeadStatusOpen, Labels: []string{"backend-ish"}, Priority: 0}, false},
+		{&models.Bead{Status: models.BeadStatusOpen, Labels: []string{"backend"}, Priority: 2}, false},
+	}
+	for i, c := range cases {
+		if got := f.Match(c.bead); got != c.want {
+			t.Errorf("case %d: Match = %v, want %v", i, got, c.want)
+		}
+	}
+
+	for _, bad := range []string{"status", "colour=red", "priority=high"} {
+		if _, err := ParseFilter([]string{bad}); !errors.Is(err, errkind.Invalid) {
+			t.Errorf("ParseFilter(%q) = %v, want an Invalid error", bad, err)
+		}
+	}
+}
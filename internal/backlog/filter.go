@@ -0,0 +1,117 @@
+package backlog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+// Filter picks the beads to export. Each field lists accepted values; an
+// empty field accepts anything.
+type Filter struct {
+	Status   []string
+	Type     []string
+	Turf     []string
+	Assignee []string
+	Label    []string // beads carrying any of these labels
+	Priority []int
+}
+
+// ParseFilter reads key=value filters such as status=open or label=backend.
+// Values for one key may be comma-separated, and repeating a key adds values.
+func ParseFilter(pairs []string) (Filter, error) {
+	var f Filter
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || key == "" || strings.TrimSpace(value) == "" {
+			return f, errkind.New(errkind.Invalid, fmt.Sprintf("filter %q is not key=value", pair))
+		}
+		values := splitValues(value)
+		switch key {
+		case "status":
+			f.Status = append(f.Status, values...)
+		case "type":
+			f.Type = append(f.Type, values...)
+		case "turf":
+			f.Turf = append(f.Turf, values...)
+		case "assignee":
+			f.Assignee = append(f.Assignee, values...)
+		case "label", "labels":
+			f.Label = append(f.Label, values...)
+		case "priority":
+			for _, v := range values {
+				n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(v), "P"))
+				if err != nil || n < 0 || n > 4 {
+					return f, errkind.New(errkind.Invalid, fmt.Sprintf("filter priority %q is not 0-4", v))
+				}
+				f.Priority = append(f.Priority, n)
+			}
+		default:
+			return f, errkind.New(errkind.Invalid, fmt.Sprintf("unknown filter %q (expected status, type, turf, assignee, label or priority)", key))
+		}
+	}
+	return f, nil
+}
+
+// Match reports whether a bead passes the filter
+func (f Filter) Match(b *models.Bead) bool {
+	if !anyOf(f.Status, string(b.Status)) || !anyOf(f.Type, string(b.Type)) ||
+		!anyOf(f.Turf, b.Turf) || !anyOf(f.Assignee, b.Assignee) {
+		return false
+	}
+	if len(f.Priority) > 0 {
+		found := false
+		for _, p := range f.Priority {
+			found = found || p == b.Priority
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Label) > 0 {
+		for _, label := range f.Label {
+			if b.HasLabel(label) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Apply returns the beads that pass the filter
+func (f Filter) Apply(beads []*models.Bead) []*models.Bead {
+	var out []*models.Bead
+	for _, b := range beads {
+		if f.Match(b) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func anyOf(accepted []string, v string) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+	for _, a := range accepted {
+		if strings.EqualFold(a, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitValues(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,361 @@
+// Package bench load tests the orchestration core: simulated agents claim,
+// work and close beads against a throwaway mob directory while a patrol loop
+// scans the registry and bead store, and every operation's latency is
+// recorded. It exercises the same storage and registry code the daemon and
+// MCP servers use, without spawning Claude.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// Operation names reported by Run
+const (
+	OpCreate    = "bead.create"
+	OpListReady = "bead.list_ready"
+	OpClaim     = "bead.claim"
+	OpComment   = "bead.comment"
+	OpClose     = "bead.close"
+	OpPing      = "registry.ping"
+	OpPatrol    = "patrol.scan"
+	OpCycle     = "agent.cycle" // one claim-work-close loop end to end
+)
+
+// Options configures a benchmark run
+type Options struct {
+	Agents         int           // simulated agents working concurrently
+	Beads          int           // open beads seeded before the run
+	Duration       time.Duration // how long agents keep working; ignored when Cycles is set
+	Cycles         int           // claim-work-close loops per agent; 0 runs for Duration
+	PatrolInterval time.Duration // how often the patrol loop scans; 0 disables it
+	Dir            string        // mob directory to use; a temp dir is created and removed when empty
+}
+
+// Report holds the results of a run
+type Report struct {
+	Options   Options
+	Elapsed   time.Duration
+	Cycles    int
+	Errors    int
+	Conflicts int // claims lost to another agent and retried, not errors
+	Ops       map[string]*OpStats
+}
+
+// Throughput returns completed agent cycles per second
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Cycles) / r.Elapsed.Seconds()
+}
+
+// OpNames returns the recorded operations in a stable order
+func (r *Report) OpNames() []string {
+	names := make([]string, 0, len(r.Ops))
+	for name := range r.Ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpStats holds the latencies observed for one operation
+type OpStats struct {
+	latencies []time.Duration
+	sorted    bool
+}
+
+// Count returns how many times the operation ran
+func (s *OpStats) Count() int { return len(s.latencies) }
+
+// Percentile returns the latency at or below which p percent of calls finished
+func (s *OpStats) Percentile(p float64) time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	if !s.sorted {
+		sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+		s.sorted = true
+	}
+	i := int(float64(len(s.latencies)-1) * p / 100)
+	return s.latencies[i]
+}
+
+// Mean returns the average latency
+func (s *OpStats) Mean() time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, l := range s.latencies {
+		total += l
+	}
+	return total / time.Duration(len(s.latencies))
+}
+
+// recorder collects latencies from concurrent workers
+type recorder struct {
+	mu        sync.Mutex
+	ops       map[string]*OpStats
+	errors    int
+	conflicts int
+}
+
+// time runs fn and records its latency under op. A bead conflict is
+// contention between agents rather than a failure, so it is counted apart
+// from errors.
+func (r *recorder) time(op string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if errors.Is(err, storage.ErrBeadConflict) {
+		r.conflicts++
+		return err
+	}
+	if err != nil {
+		r.errors++
+		return err
+	}
+	stats, ok := r.ops[op]
+	if !ok {
+		stats = &OpStats{}
+		r.ops[op] = stats
+	}
+	stats.latencies = append(stats.latencies, elapsed)
+	return nil
+}
+
+// Run seeds a mob directory and drives simulated agents against it
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Agents < 1 {
+		opts.Agents = 1
+	}
+	if opts.Cycles <= 0 && opts.Duration <= 0 {
+		return nil, fmt.Errorf("either cycles or duration is required")
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "mob-bench-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp mob dir: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+		dir = tmp
+	}
+
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		return nil, err
+	}
+	reg := registry.New(registry.DefaultPath(dir))
+	rec := &recorder{ops: make(map[string]*OpStats)}
+
+	for i := 0; i < opts.Beads; i++ {
+		if err := rec.time(OpCreate, func() error {
+			_, err := store.Create(benchBead(i))
+			return err
+		}); err != nil {
+			return nil, fmt.Errorf("failed to seed beads: %w", err)
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if opts.Cycles <= 0 {
+		runCtx, cancel = context.WithTimeout(runCtx, opts.Duration)
+		defer cancel()
+	}
+
+	// Patrol scans until the agents are done
+	patrolDone := make(chan struct{})
+	if opts.PatrolInterval > 0 {
+		go func() {
+			defer close(patrolDone)
+			ticker := time.NewTicker(opts.PatrolInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-runCtx.Done():
+					return
+				case <-ticker.C:
+					rec.time(OpPatrol, func() error { return patrolScan(store, reg) })
+				}
+			}
+		}()
+	} else {
+		close(patrolDone)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	var cyclesMu sync.Mutex
+	cycles := 0
+	for i := 0; i < opts.Agents; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a := &simAgent{id: fmt.Sprintf("bench-%d", i), index: i, store: store, reg: reg, rec: rec}
+			if err := reg.Register(&registry.AgentRecord{ID: a.id, Type: "soldati", Name: a.id, Status: registry.StatusIdle, StartedAt: time.Now(), LastPing: time.Now()}); err != nil {
+				rec.time(OpPing, func() error { return err })
+				return
+			}
+			for n := 0; opts.Cycles <= 0 || n < opts.Cycles; n++ {
+				if runCtx.Err() != nil {
+					return
+				}
+				if rec.time(OpCycle, a.cycle) == nil {
+					cyclesMu.Lock()
+					cycles++
+					cyclesMu.Unlock()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	cancel()
+	<-patrolDone
+
+	return &Report{
+		Options:   opts,
+		Elapsed:   elapsed,
+		Cycles:    cycles,
+		Errors:    rec.errors,
+		Conflicts: rec.conflicts,
+		Ops:       rec.ops,
+	}, nil
+}
+
+// simAgent mimics a soldati's storage traffic for one bead at a time
+type simAgent struct {
+	id    string
+	index int
+	store *storage.BeadStore
+	reg   *registry.Registry
+	rec   *recorder
+	seq   int
+}
+
+// claim takes a ready bead, returning nil when the backlog is empty. When
+// another agent claims the same bead first it re-lists and tries again.
+func (a *simAgent) claim() (*models.Bead, error) {
+	for {
+		var ready []*models.Bead
+		if err := a.rec.time(OpListReady, func() error {
+			var err error
+			ready, err = a.store.ListReady("")
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		if len(ready) == 0 {
+			return nil, nil
+		}
+
+		// Spread agents across the backlog so they rarely pick the same bead
+		bead := ready[a.index%len(ready)]
+		bead.Status = models.BeadStatusInProgress
+		bead.Assignee = a.id
+		err := a.rec.time(OpClaim, func() error {
+			_, err := a.store.Update(bead)
+			return err
+		})
+		if errors.Is(err, storage.ErrBeadConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return bead, nil
+	}
+}
+
+// cycle claims a ready bead, comments on it, closes it and files a
+// replacement so the open backlog stays the same size
+func (a *simAgent) cycle() error {
+	bead, err := a.claim()
+	if err != nil {
+		return err
+	}
+	if bead == nil {
+		a.seq++
+		return a.rec.time(OpCreate, func() error {
+			_, err := a.store.Create(benchBead(a.index*1000000 + a.seq))
+			return err
+		})
+	}
+
+	if err := a.rec.time(OpPing, func() error {
+		if err := a.reg.UpdateTask(a.id, bead.ID); err != nil {
+			return err
+		}
+		return a.reg.Ping(a.id)
+	}); err != nil {
+		return err
+	}
+
+	if err := a.rec.time(OpComment, func() error {
+		return a.store.AddComment(bead.ID, a.id, "Made the change and ran the tests.")
+	}); err != nil {
+		return err
+	}
+
+	if err := a.rec.time(OpClose, func() error {
+		current, err := a.store.Get(bead.ID)
+		if err != nil {
+			return err
+		}
+		current.Status = models.BeadStatusClosed
+		now := time.Now()
+		current.ClosedAt = &now
+		_, err = a.store.Update(current)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	a.seq++
+	return a.rec.time(OpCreate, func() error {
+		_, err := a.store.Create(benchBead(a.index*1000000 + a.seq))
+		return err
+	})
+}
+
+// patrolScan does the reads a daemon patrol makes each tick
+func patrolScan(store *storage.BeadStore, reg *registry.Registry) error {
+	if _, err := reg.List(); err != nil {
+		return err
+	}
+	if _, err := store.List(storage.BeadFilter{Status: models.BeadStatusInProgress}); err != nil {
+		return err
+	}
+	_, err := store.ListReady("")
+	return err
+}
+
+// benchBead builds the nth synthetic bead
+func benchBead(n int) *models.Bead {
+	return &models.Bead{
+		Title:       fmt.Sprintf("Synthetic task %d", n),
+		Description: "Generated by mob bench.",
+		Status:      models.BeadStatusOpen,
+		Priority:    n % 5,
+		Type:        models.BeadTypeTask,
+		Turf:        "bench",
+	}
+}
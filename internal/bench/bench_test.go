@@ -0,0 +1,185 @@
+package bench
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	report, err := Run(context.Background(), Options{
+		Agents:         4,
+		Beads:          20,
+		Cycles:         5,
+		PatrolInterval: time.Millisecond,
+		Dir:            dir,
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if report.Errors != 0 {
+		t.Errorf("Errors = %d, want 0", report.Errors)
+	}
+	if report.Cycles != 20 {
+		t.Errorf("Cycles = %d, want 20", report.Cycles)
+	}
+	for _, op := range []string{OpCreate, OpListReady, OpClaim, OpComment, OpClose, OpPing, OpCycle} {
+		stats, ok := report.Ops[op]
+		if !ok || stats.Count() == 0 {
+			t.Errorf("no latencies recorded for %s", op)
+			continue
+		}
+		if stats.Percentile(50) > stats.Percentile(99) {
+			t.Errorf("%s p50 %v > p99 %v", op, stats.Percentile(50), stats.Percentile(99))
+		}
+	}
+
+	// Every closed bead was replaced, so the open backlog keeps its size
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	open, err := store.List(storage.BeadFilter{Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) < 20 {
+		t.Errorf("open beads = %d, want at least 20", len(open))
+	}
+
+	agents, err := registry.New(registry.DefaultPath(dir)).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 4 {
+		t.Errorf("registered agents = %d, want 4", len(agents))
+	}
+}
+
+func TestRun_RequiresCyclesOrDuration(t *testing.T) {
+	if _, err := Run(context.Background(), Options{Agents: 1}); err == nil {
+		t.Error("expected an error without cycles or duration")
+	}
+}
+
+func TestOpStats_Percentile(t *testing.T) {
+	s := &OpStats{}
+	for i := 10; i >= 1; i-- {
+		s.latencies = append(s.latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, time.Millisecond},
+		{50, 5 * time.Millisecond},
+		{100, 10 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if got := s.Percentile(tt.p); got != tt.want {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := s.Mean(); got != 5500*time.Microsecond {
+		t.Errorf("Mean() = %v, want 5.5ms", got)
+	}
+}
+
+func BenchmarkBeadStoreCreate(b *testing.B) {
+	store, err := storage.NewBeadStore(filepath.Join(b.TempDir(), "beads"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.Create(benchBead(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBeadStoreUpdate measures an update against a store already
+// holding 1000 beads, the size a busy turf reaches within a few weeks
+func BenchmarkBeadStoreUpdate(b *testing.B) {
+	store, err := storage.NewBeadStore(filepath.Join(b.TempDir(), "beads"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var beads []*models.Bead
+	for i := 0; i < 1000; i++ {
+		bead, err := store.Create(benchBead(i))
+		if err != nil {
+			b.Fatal(err)
+		}
+		beads = append(beads, bead)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bead := beads[i%len(beads)]
+		bead.Priority = i % 5
+		if _, err := store.Update(bead); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBeadStoreListReady(b *testing.B) {
+	store, err := storage.NewBeadStore(filepath.Join(b.TempDir(), "beads"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		if _, err := store.Create(benchBead(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.ListReady(""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRegistryPing(b *testing.B) {
+	reg := registry.New(registry.DefaultPath(b.TempDir()))
+	for i := 0; i < 20; i++ {
+		id := "bench-" + string(rune('a'+i))
+		if err := reg.Register(&registry.AgentRecord{ID: id, Type: "soldati", Name: id, Status: "idle"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := reg.Ping("bench-" + string(rune('a'+i%20))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSimulation runs whole agent cycles with 8 agents and a patrol
+func BenchmarkSimulation(b *testing.B) {
+	const agents = 8
+	cycles := b.N/agents + 1
+	b.ResetTimer()
+	report, err := Run(context.Background(), Options{
+		Agents:         agents,
+		Beads:          100,
+		Cycles:         cycles,
+		PatrolInterval: 10 * time.Millisecond,
+		Dir:            b.TempDir(),
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportMetric(report.Throughput(), "cycles/s")
+	b.ReportMetric(float64(report.Ops[OpCycle].Percentile(99).Microseconds()), "p99-us")
+}
@@ -0,0 +1,227 @@
+// Package briefing builds the onboarding context given to an agent on its
+// first message in a turf, so it doesn't start cold in an unfamiliar repo.
+package briefing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/turf"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+const (
+	readmeMaxLines  = 40
+	readmeMaxBytes  = 3000
+	layoutMaxDirs   = 30
+	layoutMaxFiles  = 8
+	recentCommits   = 10
+	openBeadsToShow = 10
+)
+
+// readmeNames are checked in order when looking for a project README
+var readmeNames = []string{"README.md", "README", "README.txt", "readme.md", "Readme.md"}
+
+// skipDirs are never shown in the directory layout
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	"__pycache__":  true,
+}
+
+// Options tune what goes into a briefing
+type Options struct {
+	// SkipConventions leaves out the conventions document, for agents whose
+	// system prompt already carries it
+	SkipConventions bool
+}
+
+// Build assembles a markdown briefing for a turf. Beads not in the turf or
+// already closed are ignored. Sections that can't be built are omitted.
+func Build(turfName, turfPath string, beads []*models.Bead, opts Options) string {
+	var sections []string
+
+	if s := readmeSection(turfPath); s != "" {
+		sections = append(sections, s)
+	}
+	if s := layoutSection(turfPath); s != "" {
+		sections = append(sections, s)
+	}
+	if s := commitsSection(turfPath); s != "" {
+		sections = append(sections, s)
+	}
+	if !opts.SkipConventions {
+		if conventions, err := turf.LoadConventions(turfPath); err == nil && conventions != "" {
+			sections = append(sections, "### Conventions\n\n"+conventions)
+		}
+	}
+	if s := beadsSection(turfName, beads); s != "" {
+		sections = append(sections, s)
+	}
+
+	if len(sections) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("## Turf Briefing: %s\n\nYou're new to this project. Here's what you need to know before starting.\n\n%s\n",
+		turfName, strings.Join(sections, "\n\n"))
+}
+
+// Prepend puts a briefing in front of a task message
+func Prepend(briefing, message string) string {
+	if briefing == "" {
+		return message
+	}
+	return briefing + "\n---\n\n" + message
+}
+
+func readmeSection(turfPath string) string {
+	for _, name := range readmeNames {
+		data, err := os.ReadFile(filepath.Join(turfPath, name))
+		if err != nil {
+			continue
+		}
+
+		text := string(data)
+		truncated := false
+		if lines := strings.Split(text, "\n"); len(lines) > readmeMaxLines {
+			text = strings.Join(lines[:readmeMaxLines], "\n")
+			truncated = true
+		}
+		if len(text) > readmeMaxBytes {
+			text = text[:readmeMaxBytes]
+			truncated = true
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return ""
+		}
+		if truncated {
+			text += fmt.Sprintf("\n\n(truncated - read %s for the rest)", name)
+		}
+		return fmt.Sprintf("### README (%s)\n\n%s", name, text)
+	}
+	return ""
+}
+
+func layoutSection(turfPath string) string {
+	entries, err := os.ReadDir(turfPath)
+	if err != nil {
+		return ""
+	}
+
+	var dirs, files []string
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if e.IsDir() {
+			if !skipDirs[name] {
+				dirs = append(dirs, name)
+			}
+		} else {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(dirs)
+	sort.Strings(files)
+
+	var lines []string
+	for i, dir := range dirs {
+		if i == layoutMaxDirs {
+			lines = append(lines, fmt.Sprintf("... and %d more directories", len(dirs)-layoutMaxDirs))
+			break
+		}
+		line := dir + "/"
+		if children := childSummary(filepath.Join(turfPath, dir)); children != "" {
+			line += "  " + children
+		}
+		lines = append(lines, line)
+	}
+	for i, file := range files {
+		if i == layoutMaxFiles {
+			lines = append(lines, fmt.Sprintf("... and %d more files", len(files)-layoutMaxFiles))
+			break
+		}
+		lines = append(lines, file)
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return "### Layout\n\n```\n" + strings.Join(lines, "\n") + "\n```"
+}
+
+// childSummary lists a directory's visible subdirectories on one line
+func childSummary(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") && !skipDirs[e.Name()] {
+			names = append(names, e.Name()+"/")
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	if len(names) > 6 {
+		names = append(names[:6], "...")
+	}
+	return "(" + strings.Join(names, " ") + ")"
+}
+
+func commitsSection(turfPath string) string {
+	repo, err := vcs.Open(turfPath, "")
+	if err != nil {
+		return ""
+	}
+	subjects, err := repo.RecentSubjects(recentCommits)
+	if err != nil || len(subjects) == 0 {
+		return ""
+	}
+	return "### Recent Commits\n\n- " + strings.Join(subjects, "\n- ")
+}
+
+func beadsSection(turfName string, beads []*models.Bead) string {
+	var open []*models.Bead
+	for _, b := range beads {
+		if b.Turf == turfName && b.Status != models.BeadStatusClosed {
+			open = append(open, b)
+		}
+	}
+	if len(open) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(open, func(i, j int) bool {
+		if open[i].Priority != open[j].Priority {
+			return open[i].Priority < open[j].Priority
+		}
+		return open[i].CreatedAt.Before(open[j].CreatedAt)
+	})
+
+	var lines []string
+	for i, b := range open {
+		if i == openBeadsToShow {
+			lines = append(lines, fmt.Sprintf("- ... and %d more", len(open)-openBeadsToShow))
+			break
+		}
+		line := fmt.Sprintf("- %s [P%d %s] %s", b.ID, b.Priority, b.Status, b.Title)
+		if b.Assignee != "" {
+			line += fmt.Sprintf(" (%s)", b.Assignee)
+		}
+		lines = append(lines, line)
+	}
+	return "### Open Beads in This Turf\n\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,77 @@
+package briefing
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/turf"
+)
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"cmd/mob", "internal/api", "node_modules/x", ".hidden"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Widget\n\nA widget service.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := turf.SaveConventions(dir, "- Errors are wrapped"); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git unavailable: %v %s", err, out)
+		}
+	}
+	git("init")
+	git("-c", "user.email=t@example.com", "-c", "user.name=T", "commit", "--allow-empty", "-m", "feat: add widgets")
+
+	beads := []*models.Bead{
+		{ID: "bd-0002", Title: "Low priority", Turf: "widget", Status: models.BeadStatusOpen, Priority: 3},
+		{ID: "bd-0001", Title: "Urgent fix", Turf: "widget", Status: models.BeadStatusInProgress, Priority: 0, Assignee: "vinnie"},
+		{ID: "bd-0003", Title: "Done", Turf: "widget", Status: models.BeadStatusClosed},
+		{ID: "bd-0004", Title: "Elsewhere", Turf: "other", Status: models.BeadStatusOpen},
+	}
+
+	got := Build("widget", dir, beads, Options{})
+
+	for _, want := range []string{
+		"## Turf Briefing: widget",
+		"A widget service.",
+		"cmd/  (mob/)",
+		"internal/  (api/)",
+		"- feat: add widgets",
+		"- Errors are wrapped",
+		"- bd-0001 [P0 in_progress] Urgent fix (vinnie)\n- bd-0002",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("briefing missing %q:\n%s", want, got)
+		}
+	}
+	if skipped := Build("widget", dir, beads, Options{SkipConventions: true}); strings.Contains(skipped, "Errors are wrapped") {
+		t.Errorf("expected conventions skipped:\n%s", skipped)
+	}
+	for _, unwanted := range []string{"node_modules", ".hidden", "bd-0003", "bd-0004"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("briefing should not contain %q:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestBuildEmptyTurf(t *testing.T) {
+	if got := Build("empty", t.TempDir(), nil, Options{}); got != "" {
+		t.Errorf("expected no briefing for an empty turf, got %q", got)
+	}
+	if got := Prepend("", "task"); got != "task" {
+		t.Errorf("expected task unchanged, got %q", got)
+	}
+}
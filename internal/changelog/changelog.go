@@ -0,0 +1,123 @@
+// Package changelog turns closed beads into a markdown release section,
+// grouped by the kind of change and linked to the commits that merged them.
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// Section is one group of changes, such as the features in a release
+type Section struct {
+	Title   string
+	Entries []Entry
+}
+
+// Entry is one closed bead in the changelog
+type Entry struct {
+	BeadID   string
+	Title    string
+	Commit   string // merge commit, empty when none was found
+	ClosedAt time.Time
+}
+
+// sections orders the groups and maps bead types onto them. Review beads
+// are process, not change, and are left out.
+var sections = []struct {
+	title string
+	types []models.BeadType
+}{
+	{"Features", []models.BeadType{models.BeadTypeFeature}},
+	{"Fixes", []models.BeadType{models.BeadTypeBug}},
+	{"Chores", []models.BeadType{models.BeadTypeChore, models.BeadTypeHeresy}},
+	{"Other changes", []models.BeadType{models.BeadTypeTask, models.BeadTypeEpic, ""}},
+}
+
+// Options selects which beads make the changelog
+type Options struct {
+	Since   time.Time         // beads closed after this
+	Until   time.Time         // and not after this; zero = now
+	Turf    string            // only this turf's beads; empty = every turf
+	Commits map[string]string // merge commit by branch, from git.MergeCommits
+}
+
+// Build groups the beads closed in the window into sections, oldest first
+// within each. Empty sections are dropped.
+func Build(beads []*models.Bead, opts Options) []Section {
+	until := opts.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	byType := make(map[models.BeadType][]Entry)
+	for _, b := range beads {
+		if b.Status != models.BeadStatusClosed || b.ClosedAt == nil {
+			continue
+		}
+		if !b.ClosedAt.After(opts.Since) || b.ClosedAt.After(until) {
+			continue
+		}
+		if opts.Turf != "" && b.Turf != opts.Turf {
+			continue
+		}
+		byType[b.Type] = append(byType[b.Type], Entry{
+			BeadID:   b.ID,
+			Title:    b.Title,
+			Commit:   opts.Commits[b.Branch],
+			ClosedAt: *b.ClosedAt,
+		})
+	}
+
+	var out []Section
+	for _, s := range sections {
+		var entries []Entry
+		for _, t := range s.types {
+			entries = append(entries, byType[t]...)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].ClosedAt.Before(entries[j].ClosedAt) })
+		out = append(out, Section{Title: s.title, Entries: entries})
+	}
+	return out
+}
+
+// WriteMarkdown writes the changelog under a "## heading" line
+func WriteMarkdown(w io.Writer, heading string, sections []Section) error {
+	if _, err := fmt.Fprintf(w, "## %s\n", heading); err != nil {
+		return err
+	}
+	if len(sections) == 0 {
+		_, err := fmt.Fprint(w, "\nNo changes.\n")
+		return err
+	}
+
+	for _, s := range sections {
+		if _, err := fmt.Fprintf(w, "\n### %s\n\n", s.Title); err != nil {
+			return err
+		}
+		for _, e := range s.Entries {
+			ref := e.BeadID
+			if e.Commit != "" {
+				ref += ", " + shortHash(e.Commit)
+			}
+			if _, err := fmt.Fprintf(w, "- %s (%s)\n", e.Title, ref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// shortHash abbreviates a commit hash the way git log --oneline does
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
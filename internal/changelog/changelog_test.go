@@ -0,0 +1,64 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestBuild(t *testing.T) {
+	release := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	closed := func(days int) *time.Time {
+		at := release.AddDate(0, 0, days)
+		return &at
+	}
+	beads := []*models.Bead{
+		{ID: "bd-0001", Title: "Add SSO", Type: models.BeadTypeFeature, Status: models.BeadStatusClosed, Turf: "api", Branch: "mob/bd-0001", ClosedAt: closed(3)},
+		{ID: "bd-0002", Title: "Fix login redirect", Type: models.BeadTypeBug, Status: models.BeadStatusClosed, Turf: "api", Branch: "mob/bd-0002", ClosedAt: closed(2)},
+		{ID: "bd-0003", Title: "Add audit log", Type: models.BeadTypeFeature, Status: models.BeadStatusClosed, Turf: "api", ClosedAt: closed(1)},
+		{ID: "bd-0004", Title: "Bump deps", Type: models.BeadTypeChore, Status: models.BeadStatusClosed, Turf: "api", ClosedAt: closed(1)},
+		{ID: "bd-0005", Title: "Before the release", Type: models.BeadTypeFeature, Status: models.BeadStatusClosed, Turf: "api", ClosedAt: closed(-1)},
+		{ID: "bd-0006", Title: "Other turf", Type: models.BeadTypeFeature, Status: models.BeadStatusClosed, Turf: "web", ClosedAt: closed(1)},
+		{ID: "bd-0007", Title: "Still open", Type: models.BeadTypeBug, Status: models.BeadStatusOpen, Turf: "api"},
+		{ID: "bd-0008", Title: "Review notes", Type: models.BeadTypeReview, Status: models.BeadStatusClosed, Turf: "api", ClosedAt: closed(1)},
+	}
+
+	sections := Build(beads, Options{
+		Since:   release,
+		Until:   release.AddDate(0, 0, 10),
+		Turf:    "api",
+		Commits: map[string]string{"mob/bd-0001": "0123456789abcdef"},
+	})
+	if len(sections) != 3 {
+		t.Fatalf("expected features, fixes and chores, got %+v", sections)
+	}
+	if sections[0].Title != "Features" || len(sections[0].Entries) != 2 || sections[0].Entries[0].BeadID != "bd-0003" {
+		t.Errorf("expected both features oldest first, got %+v", sections[0])
+	}
+	if sections[1].Title != "Fixes" || sections[2].Title != "Chores" {
+		t.Errorf("unexpected section order: %s, %s", sections[1].Title, sections[2].Title)
+	}
+
+	var sb strings.Builder
+	if err := WriteMarkdown(&sb, "v1.3.0", sections); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{"## v1.3.0\n", "### Features\n\n- Add audit log (bd-0003)\n- Add SSO (bd-0001, 0123456)\n", "### Fixes\n\n- Fix login redirect (bd-0002)\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteMarkdown_Empty(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteMarkdown(&sb, "Unreleased", nil); err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != "## Unreleased\n\nNo changes.\n" {
+		t.Errorf("unexpected output %q", sb.String())
+	}
+}
@@ -0,0 +1,175 @@
+// Package chatsession keeps the dashboard's chat sessions on disk so a
+// conversation with the underboss can be picked up again after the TUI
+// exits. Each session is one JSON file holding its messages and the Claude
+// session the underboss was in.
+package chatsession
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// Message roles
+const (
+	RoleUser      = "user"
+	RoleUnderboss = "underboss"
+)
+
+// titleLength is how many characters of the first message a session's
+// title keeps
+const titleLength = 60
+
+// Block is one content block of an underboss response
+type Block struct {
+	Type  string `json:"type"` // text, thinking, tool_use or tool_result
+	Text  string `json:"text,omitempty"`
+	Name  string `json:"name,omitempty"`  // tool_use: the tool called
+	Input string `json:"input,omitempty"` // tool_use: its input
+	ID    string `json:"id,omitempty"`    // tool_use: the call's ID
+}
+
+// Message is one chat entry: something the user sent, or the underboss's
+// response as shown
+type Message struct {
+	Role   string    `json:"role"`
+	Text   string    `json:"text"`
+	Blocks []Block   `json:"blocks,omitempty"` // the response's blocks, tool calls included
+	At     time.Time `json:"at"`
+}
+
+// Session is a saved chat
+type Session struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`                       // the first message sent, shortened
+	Underboss string    `json:"underboss_session,omitempty"` // Claude session to resume
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Messages  []Message `json:"messages"`
+}
+
+// New starts an empty session
+func New(now time.Time) (*Session, error) {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return &Session{ID: "chat-" + hex.EncodeToString(b), CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// Add appends a message, taking the title from the first one the user sends
+func (s *Session) Add(msg Message) {
+	if s.Title == "" && msg.Role == RoleUser {
+		s.Title = shorten(msg.Text)
+	}
+	s.Messages = append(s.Messages, msg)
+	s.UpdatedAt = msg.At
+}
+
+func shorten(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > titleLength {
+		return string(r[:titleLength-1]) + "…"
+	}
+	return text
+}
+
+// Store keeps sessions as files in a directory
+type Store struct {
+	dir string
+}
+
+// Dir returns the session directory for a mob directory
+func Dir(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "sessions")
+}
+
+// NewStore returns a store keeping sessions in dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// Save writes a session, replacing any earlier copy
+func (s *Store) Save(sess *Session) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(sess, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path(sess.ID) + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(sess.ID))
+}
+
+// Load reads a session by its ID or a prefix of it that names only one
+func (s *Store) Load(id string) (*Session, error) {
+	if sess, err := s.read(s.path(id)); err == nil || !os.IsNotExist(err) {
+		return sess, err
+	}
+
+	all, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	var found *Session
+	for _, sess := range all {
+		if strings.HasPrefix(sess.ID, id) {
+			if found != nil {
+				return nil, errkind.New(errkind.Invalid, fmt.Sprintf("%q matches more than one session", id))
+			}
+			found = sess
+		}
+	}
+	if found == nil {
+		return nil, errkind.New(errkind.NotFound, fmt.Sprintf("no chat session %q", id))
+	}
+	return found, nil
+}
+
+// List returns every saved session, most recently active first
+func (s *Store) List() ([]*Session, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*Session, 0, len(paths))
+	for _, path := range paths {
+		sess, err := s.read(path)
+		if err != nil {
+			continue // skip a file being written or damaged
+		}
+		sessions = append(sessions, sess)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+	return sessions, nil
+}
+
+func (s *Store) read(path string) (*Session, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sess Session
+	if err := json.Unmarshal(content, &sess); err != nil {
+		return nil, fmt.Errorf("chat session %s: %w", filepath.Base(path), err)
+	}
+	return &sess, nil
+}
@@ -0,0 +1,64 @@
+package chatsession
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+func TestStoreSavesAndLoads(t *testing.T) {
+	store := NewStore(t.TempDir())
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	older, err := New(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	older.Add(Message{Role: RoleUser, Text: "What is vinnie   working on?", At: start})
+	older.Add(Message{Role: RoleUnderboss, Text: "bd-a1b2", Blocks: []Block{{Type: "tool_use", Name: "list_agents", ID: "tu-1"}}, At: start.Add(time.Minute)})
+	older.Underboss = "sess-1"
+
+	newer, _ := New(start.Add(time.Hour))
+	newer.Add(Message{Role: RoleUser, Text: strings.Repeat("long ", 20), At: start.Add(time.Hour)})
+
+	for _, sess := range []*Session{older, newer} {
+		if err := store.Save(sess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].ID != newer.ID {
+		t.Fatalf("expected the newer session first, got %+v", all)
+	}
+	if n := len([]rune(all[0].Title)); n != titleLength {
+		t.Errorf("expected the title shortened to %d characters, got %d", titleLength, n)
+	}
+
+	got, err := store.Load(older.ID[:len(older.ID)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "What is vinnie working on?" || got.Underboss != "sess-1" || len(got.Messages) != 2 {
+		t.Fatalf("unexpected session %+v", got)
+	}
+	if b := got.Messages[1].Blocks; len(b) != 1 || b[0].Name != "list_agents" {
+		t.Errorf("expected the tool call kept, got %+v", b)
+	}
+	if !got.UpdatedAt.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected the last message's time, got %v", got.UpdatedAt)
+	}
+
+	if _, err := store.Load("chat-"); !errors.Is(err, errkind.Invalid) {
+		t.Errorf("expected an ambiguous prefix refused, got %v", err)
+	}
+	if _, err := store.Load("nope"); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
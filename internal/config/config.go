@@ -1,6 +1,12 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // DefaultAssociateTimeout is the default timeout for associates (10 minutes)
 const DefaultAssociateTimeout = 10 * time.Minute
@@ -10,26 +16,51 @@ const DefaultAssociateGracePeriod = 1 * time.Minute
 
 // Config holds the main mob configuration
 type Config struct {
-	Daemon        DaemonConfig        `toml:"daemon"`
-	Underboss     UnderbossConfig     `toml:"underboss"`
-	Soldati       SoldatiConfig       `toml:"soldati"`
-	Associates    AssociatesConfig    `toml:"associates"`
-	Notifications NotificationsConfig `toml:"notifications"`
-	Safety        SafetyConfig        `toml:"safety"`
-	Logging       LoggingConfig       `toml:"logging"`
+	Daemon        DaemonConfig         `toml:"daemon"`
+	Underboss     UnderbossConfig      `toml:"underboss"`
+	Soldati       SoldatiConfig        `toml:"soldati"`
+	Associates    AssociatesConfig     `toml:"associates"`
+	Notifications NotificationsConfig  `toml:"notifications"`
+	Safety        SafetyConfig         `toml:"safety"`
+	Logging       LoggingConfig        `toml:"logging"`
+	Flow          FlowConfig           `toml:"flow"`
+	Schedule      ScheduleConfig       `toml:"schedule"`
+	Routing       RoutingConfig        `toml:"routing"`
+	TUI           TUIConfig            `toml:"tui"`
+	Worktrees     WorktreeConfig       `toml:"worktrees"`
+	Scaling       ScalingConfig        `toml:"scaling"`
+	GC            GCConfig             `toml:"gc"`
+	MCP           MCPServerConfig      `toml:"mcp"`
+	Tests         TestsConfig          `toml:"tests"`
+	Jobs          map[string]JobConfig `toml:"jobs"` // recurring daemon jobs keyed by name
+	Redaction     RedactionConfig      `toml:"redaction"`
+	Federation    FederationConfig     `toml:"federation"`
+	Webhooks      WebhooksConfig       `toml:"webhooks"`
+	Failover      FailoverConfig       `toml:"failover"`
+	Failures      FailuresConfig       `toml:"failures"`
+	Preemption    PreemptionConfig     `toml:"preemption"`
+	Heresy        HeresyConfig         `toml:"heresy"`
 }
 
 type DaemonConfig struct {
-	HeartbeatInterval   string `toml:"heartbeat_interval"`
-	BootCheckInterval   string `toml:"boot_check_interval"`
-	StuckTimeout        string `toml:"stuck_timeout"`
-	MaxConcurrentAgents int    `toml:"max_concurrent_agents"`
+	HeartbeatInterval   string         `toml:"heartbeat_interval"`    // how often the patrol loop runs
+	BootCheckInterval   string         `toml:"boot_check_interval"`   // how often agents with work are nudged
+	StuckTimeout        string         `toml:"stuck_timeout"`         // a soldati call with no output for this long is stuck; "0" disables
+	MaxConcurrentAgents int            `toml:"max_concurrent_agents"` // soldati working at once across every turf; further assignments queue. 0 = unlimited
+	MaxAgentsPerTurf    int            `toml:"max_agents_per_turf"`   // soldati working at once in any one turf; 0 = unlimited
+	TurfAgentLimits     map[string]int `toml:"turf_agent_limits"`     // per-turf overrides keyed by turf name
+	PriorityAging       string         `toml:"priority_aging"`        // open beads rise one priority level per period this long, e.g. "14d"; empty = never
+	NudgeMaxBackoff     string         `toml:"nudge_max_backoff"`     // longest wait between nudges that do not move an agent along; each doubles the wait. "0" = no backoff
+	NudgesPerDay        int            `toml:"nudges_per_day"`        // nudges a day across all soldati; 0 = unlimited
+	NudgeBudgetUSD      float64        `toml:"nudge_budget_usd"`      // what nudges may cost a day; 0 = unlimited
+	MetricsListen       string         `toml:"metrics_listen"`        // also serve /healthz and /metrics on this address, e.g. "127.0.0.1:9464"; the .mob/daemon.sock socket always serves them
 }
 
 type UnderbossConfig struct {
 	Personality      string `toml:"personality"`
 	ApprovalRequired bool   `toml:"approval_required"`
 	HistoryMode      string `toml:"history_mode"`
+	Model            string `toml:"model"` // model the underboss chats with; empty = Claude's default
 }
 
 type SoldatiConfig struct {
@@ -40,23 +71,709 @@ type SoldatiConfig struct {
 type AssociatesConfig struct {
 	Timeout       string `toml:"timeout"`
 	MaxPerSoldati int    `toml:"max_per_soldati"`
+	NamePrefix    string `toml:"name_prefix"`    // generated names look like "<prefix>-crimson-fox"
+	MaxConcurrent int    `toml:"max_concurrent"` // associates running at once; further spawns queue. 0 = unlimited
+	QueueTimeout  string `toml:"queue_timeout"`  // how long a queued spawn waits for capacity before it is dropped
 }
 
 type NotificationsConfig struct {
-	Terminal        bool   `toml:"terminal"`
-	SummaryInterval string `toml:"summary_interval"`
+	Terminal        bool          `toml:"terminal"`
+	SummaryInterval string        `toml:"summary_interval"`
+	Humans          []HumanConfig `toml:"humans"`
+}
+
+// HumanConfig is a person who can watch beads and be @-mentioned
+type HumanConfig struct {
+	Name     string   `toml:"name"`
+	Channels []string `toml:"channels"` // "terminal" or "plugin:<name>"
 }
 
 type SafetyConfig struct {
 	BranchPrefix     string   `toml:"branch_prefix"`
 	CommandBlacklist []string `toml:"command_blacklist"`
 	RequireReview    bool     `toml:"require_review"`
+	ReviewGate       string   `toml:"review_gate"`       // review soldati work before close: "", "associate", or "human"
+	RepoHealthCheck  bool     `toml:"repo_health_check"` // hold assignments while a turf checkout is dirty, off its main branch or can't fetch origin
+	FetchMaxAge      string   `toml:"fetch_max_age"`     // refetch origin before assigning once the last fetch is older than this; "0" skips
 }
 
 type LoggingConfig struct {
-	Level     string `toml:"level"`
-	Format    string `toml:"format"`
-	Retention string `toml:"retention"`
+	Level       string `toml:"level"`
+	Format      string `toml:"format"`
+	Retention   string `toml:"retention"`    // rotated daemon logs last written longer ago are removed; "0" keeps them forever
+	MaxSizeMB   int    `toml:"max_size_mb"`  // the daemon log is rotated once it would grow past this
+	RotateEvery string `toml:"rotate_every"` // the daemon log is also rotated once it is this old; "0" rotates on size alone
+	Compress    bool   `toml:"compress"`     // gzip rotated daemon logs
+	MaxFiles    int    `toml:"max_files"`    // rotated daemon logs kept at most; 0 = limited by retention alone
+}
+
+// Defaults for daemon log rotation
+const (
+	DefaultLogMaxSizeMB   = 10
+	DefaultLogRotateEvery = 24 * time.Hour
+	DefaultLogRetention   = 7 * 24 * time.Hour
+)
+
+// GetMaxSize returns the size in bytes at which the daemon log is rotated,
+// falling back to DefaultLogMaxSizeMB when it is unset
+func (c *LoggingConfig) GetMaxSize() int64 {
+	mb := c.MaxSizeMB
+	if mb <= 0 {
+		mb = DefaultLogMaxSizeMB
+	}
+	return int64(mb) << 20
+}
+
+// GetRotateEvery parses how old the daemon log gets before it is rotated,
+// falling back to DefaultLogRotateEvery when it is empty or invalid.
+// "0" returns 0, rotating on size alone.
+func (c *LoggingConfig) GetRotateEvery() time.Duration {
+	if c.RotateEvery == "" {
+		return DefaultLogRotateEvery
+	}
+	d, err := ParseRetention(c.RotateEvery)
+	if err != nil {
+		return DefaultLogRotateEvery
+	}
+	return d
+}
+
+// GetRetention parses how long rotated daemon logs are kept, falling back to
+// DefaultLogRetention when it is empty or invalid. "0" returns 0, keeping
+// them forever.
+func (c *LoggingConfig) GetRetention() time.Duration {
+	if c.Retention == "" {
+		return DefaultLogRetention
+	}
+	d, err := ParseRetention(c.Retention)
+	if err != nil {
+		return DefaultLogRetention
+	}
+	return d
+}
+
+// FlowConfig controls kanban-style flow through the bead queue
+type FlowConfig struct {
+	DefaultWIPLimit  int            `toml:"default_wip_limit"` // max in_progress beads per turf, 0 = unlimited
+	WIPLimits        map[string]int `toml:"wip_limits"`        // per-turf overrides keyed by turf name
+	ExpeditePriority int            `toml:"expedite_priority"` // beads at or above this priority skip WIP limits, -1 = no expedite lane
+}
+
+// WIPLimit returns the WIP limit for a turf, falling back to the default.
+// A return value of 0 means the turf is unlimited.
+func (c *FlowConfig) WIPLimit(turf string) int {
+	if limit, ok := c.WIPLimits[turf]; ok {
+		return limit
+	}
+	return c.DefaultWIPLimit
+}
+
+// ExpediteDisabled turns the expedite lane off when set as ExpeditePriority.
+// Zero can't mean off because P0 is the highest priority.
+const ExpediteDisabled = -1
+
+// IsExpedite returns true if a bead with the given priority belongs in the expedite lane
+func (c *FlowConfig) IsExpedite(priority int) bool {
+	if c.ExpeditePriority < 0 {
+		return false
+	}
+	return priority <= c.ExpeditePriority
+}
+
+// WorktreeConfig bounds the disk used by per-bead git worktrees
+type WorktreeConfig struct {
+	MaxCount  int                       `toml:"max_count"`   // worktrees per turf; 0 = unlimited
+	MaxSizeMB int                       `toml:"max_size_mb"` // total worktree size per turf; 0 = unlimited
+	Sparse    []string                  `toml:"sparse"`      // check out only these directories; empty = everything
+	Shallow   bool                      `toml:"shallow"`     // check out only top-level files; agents add directories on demand
+	Turfs     map[string]WorktreePolicy `toml:"turfs"`       // per-turf overrides keyed by turf name
+}
+
+// WorktreePolicy is the effective worktree quota and checkout mode for a turf
+type WorktreePolicy struct {
+	MaxCount  int      `toml:"max_count"`
+	MaxSizeMB int      `toml:"max_size_mb"`
+	Sparse    []string `toml:"sparse"`
+	Shallow   bool     `toml:"shallow"`
+}
+
+// PolicyFor returns the worktree policy for a turf, falling back to the defaults
+func (c *WorktreeConfig) PolicyFor(turf string) WorktreePolicy {
+	if policy, ok := c.Turfs[turf]; ok {
+		return policy
+	}
+	return WorktreePolicy{
+		MaxCount:  c.MaxCount,
+		MaxSizeMB: c.MaxSizeMB,
+		Sparse:    c.Sparse,
+		Shallow:   c.Shallow,
+	}
+}
+
+// ScalingConfig lets the daemon add soldati to a turf whose ready backlog
+// outgrows the soldati it has, and retire them once the backlog drains
+type ScalingConfig struct {
+	BacklogThreshold int                      `toml:"backlog_threshold"` // ready beads each soldati added to a turf covers; another is added once the backlog passes the next multiple
+	MaxPerTurf       int                      `toml:"max_per_turf"`      // soldati the daemon may add to one turf; 0 = no scaling
+	RetireAfter      string                   `toml:"retire_after"`      // how long a turf's queue stays empty before its added soldati are retired; "0" = at once
+	Turfs            map[string]ScalingPolicy `toml:"turfs"`             // per-turf overrides keyed by turf name
+}
+
+// ScalingPolicy is the effective scaling for a turf
+type ScalingPolicy struct {
+	BacklogThreshold int `toml:"backlog_threshold"`
+	MaxPerTurf       int `toml:"max_per_turf"`
+}
+
+// DefaultBacklogThreshold is how many ready beads each added soldati covers
+// when backlog_threshold is unset
+const DefaultBacklogThreshold = 5
+
+// DefaultRetireAfter is how long a turf's queue stays empty before the
+// soldati added for it are retired
+const DefaultRetireAfter = 10 * time.Minute
+
+// PolicyFor returns the scaling policy for a turf, falling back to the
+// defaults. A policy without a threshold uses DefaultBacklogThreshold.
+func (c *ScalingConfig) PolicyFor(turf string) ScalingPolicy {
+	policy, ok := c.Turfs[turf]
+	if !ok {
+		policy = ScalingPolicy{BacklogThreshold: c.BacklogThreshold, MaxPerTurf: c.MaxPerTurf}
+	}
+	if policy.BacklogThreshold <= 0 {
+		policy.BacklogThreshold = DefaultBacklogThreshold
+	}
+	return policy
+}
+
+// Wanted returns how many soldati the policy adds for a ready backlog: one
+// for each full threshold past the first, up to MaxPerTurf
+func (p ScalingPolicy) Wanted(backlog int) int {
+	if p.MaxPerTurf <= 0 || backlog <= p.BacklogThreshold {
+		return 0
+	}
+	return min((backlog-1)/p.BacklogThreshold, p.MaxPerTurf)
+}
+
+// GetRetireAfter parses how long a drained queue waits before its added
+// soldati are retired, falling back to DefaultRetireAfter when it is empty or
+// invalid; "0" retires them as soon as the queue drains
+func (c *ScalingConfig) GetRetireAfter() time.Duration {
+	if c.RetireAfter == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.RetireAfter)
+	if err != nil || d <= 0 {
+		return DefaultRetireAfter
+	}
+	return d
+}
+
+// GCConfig sets how long each kind of stale artifact is kept before the
+// daemon or `mob gc` removes it. Retentions accept Go durations or whole
+// days like "7d"; empty or "0" keeps that artifact forever.
+type GCConfig struct {
+	Interval    string `toml:"interval"`    // how often the daemon collects unless a [jobs.gc] entry sets a schedule; empty or "0" disables daemon GC
+	Sessions    string `toml:"sessions"`    // records of agents that finished
+	Transcripts string `toml:"transcripts"` // Claude transcripts of the sessions mob's agents ran
+	MCPConfigs  string `toml:"mcp_configs"` // MCP config files other than the live one
+	Tmp         string `toml:"tmp"`         // scratch files under .mob/tmp
+}
+
+// ParseRetention parses a retention period such as "36h" or "7d".
+// An empty string or "0" means keep forever and returns 0.
+func ParseRetention(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid retention %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid retention %q", s)
+	}
+	return d, nil
+}
+
+// MCPServerConfig limits each agent's connection to `mob mcp-server`
+type MCPServerConfig struct {
+	RateLimit     float64 `toml:"rate_limit"`     // sustained tool calls per second; 0 = unlimited
+	Burst         int     `toml:"burst"`          // calls allowed back to back before the rate applies
+	MaxConcurrent int     `toml:"max_concurrent"` // tool calls run at once; further calls wait their turn
+
+	ListFormat        string `toml:"list_format"`        // list tool output: "compact" (one line per item) or "full"
+	ListLimit         int    `toml:"list_limit"`         // items a list tool returns unless the caller asks for more; 0 = all
+	DescriptionLength int    `toml:"description_length"` // characters of description kept in compact listings
+
+	// Truncate overrides, per tool, how many characters of descriptions, tasks
+	// and messages a tool's output keeps; 0 keeps everything
+	Truncate map[string]int `toml:"truncate"`
+}
+
+// FederationConfig shares selected beads with other mob instances
+type FederationConfig struct {
+	Instance string           `toml:"instance"` // this instance's name in bead origins; defaults to the hostname
+	Peers    []FederationPeer `toml:"peers"`
+}
+
+// FederationPeer is another mob instance whose mob directory is reachable
+// from this machine, such as a mounted or synced folder
+type FederationPeer struct {
+	Name string `toml:"name"`
+	Path string `toml:"path"` // the peer's mob directory
+	Turf string `toml:"turf"` // turf beads pulled from this peer land in; empty = keep the peer's turf name
+}
+
+// InstanceName returns the name this instance federates under
+func (c *FederationConfig) InstanceName() string {
+	if c.Instance != "" {
+		return c.Instance
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return host
+	}
+	return "mob"
+}
+
+// Peer returns the configured peer with the given name
+func (c *FederationConfig) Peer(name string) (FederationPeer, bool) {
+	for _, p := range c.Peers {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return FederationPeer{}, false
+}
+
+// WebhooksConfig serves an HTTP endpoint from the daemon that turns git
+// hosting webhooks into beads and bead comments
+type WebhooksConfig struct {
+	Listen        string        `toml:"listen"`         // address to serve on, e.g. "127.0.0.1:7070"; empty = disabled
+	GitHubSecret  string        `toml:"github_secret"`  // verifies X-Hub-Signature-256; empty = GitHub deliveries are rejected
+	GitLabToken   string        `toml:"gitlab_token"`   // must match X-Gitlab-Token; empty = GitLab deliveries are rejected
+	AllowUnsigned bool          `toml:"allow_unsigned"` // accept deliveries from a provider with no secret set instead of rejecting them
+	Rules         []WebhookRule `toml:"rules"`
+}
+
+// WebhookRule maps one kind of webhook event onto the board
+type WebhookRule struct {
+	Event    string `toml:"event"`    // issue_opened, review_comment or ci_failed
+	Repo     string `toml:"repo"`     // "owner/name"; empty = any repository
+	Turf     string `toml:"turf"`     // turf new beads are filed in
+	Type     string `toml:"type"`     // type of new beads; defaults to bug for ci_failed, task otherwise
+	Priority *int   `toml:"priority"` // priority of new beads (0 = highest); nil = 2
+}
+
+// FailoverConfig trips a per-model circuit breaker when a model's provider
+// keeps failing (rate limits, overload, outages) and moves calls to a
+// secondary model until it recovers
+type FailoverConfig struct {
+	Secondary        string `toml:"secondary"`         // model calls move to while a circuit is open; empty = circuits are tracked but calls stay put
+	FailureThreshold int    `toml:"failure_threshold"` // consecutive provider errors that open a model's circuit
+	Cooldown         string `toml:"cooldown"`          // how long a circuit first stays open; doubles each time the recovery probe fails
+	MaxCooldown      string `toml:"max_cooldown"`      // cap on the doubled cooldown
+}
+
+// FailuresConfig is the retry policy for failed assignments
+type FailuresConfig struct {
+	MaxAttempts   int    `toml:"max_attempts"`   // failed attempts before a bead is left for `mob failures retry`
+	Backoff       string `toml:"backoff"`        // wait before the first retry; doubles with each further failure
+	MaxBackoff    string `toml:"max_backoff"`    // cap on the doubled wait
+	ReassignAfter int    `toml:"reassign_after"` // failures after which retries go to an agent that has not failed the bead
+}
+
+// PreemptionConfig lets an urgent bead take a busy soldati off low-priority
+// work, which is parked and handed back to the soldati once it is free
+type PreemptionConfig struct {
+	Enabled             bool `toml:"enabled"`
+	UrgentPriority      int  `toml:"urgent_priority"`      // ready beads at or above this priority (0 = highest) may preempt
+	PreemptiblePriority int  `toml:"preemptible_priority"` // running work at or below this priority may be parked
+}
+
+// DefaultPreemptiblePriority is the highest priority of work an urgent bead
+// may park by default
+const DefaultPreemptiblePriority = 3
+
+// GetPreemptiblePriority returns the priority at or below which running work
+// may be parked, falling back to DefaultPreemptiblePriority when it is unset
+func (c *PreemptionConfig) GetPreemptiblePriority() int {
+	if c.PreemptiblePriority <= 0 {
+		return DefaultPreemptiblePriority
+	}
+	return c.PreemptiblePriority
+}
+
+// HeresyConfig decides how `mob heresy scan --create-beads` files findings
+// by severity
+type HeresyConfig struct {
+	Escalate    string `toml:"escalate"`     // this severity and above are filed pending approval and notified at once; "none" = off
+	Batch       string `toml:"batch"`        // this severity and below collect in a weekly chore bead per turf; "none" = off
+	PrespawnFix bool   `toml:"prespawn_fix"` // escalated heresies also get a fix bead an associate starts on once approved
+}
+
+// TestsConfig controls the run_tests tool and the test gate on complete_bead
+type TestsConfig struct {
+	Timeout     string `toml:"timeout"`      // how long one run may take before it is killed
+	RequirePass bool   `toml:"require_pass"` // complete_bead needs a passing run at the worktree's HEAD, on a clean tree, on turfs with a test command
+	OutputLimit int    `toml:"output_limit"` // characters of output (the tail) returned to the agent; the full log is kept on disk
+}
+
+// RedactionConfig masks secrets in agent output before mob writes it to logs,
+// notifications, exports or its stores
+type RedactionConfig struct {
+	Enabled     bool     `toml:"enabled"`
+	Builtin     bool     `toml:"builtin"`     // API keys, tokens, private keys and key=value secrets
+	Emails      bool     `toml:"emails"`      // email addresses
+	Hostnames   []string `toml:"hostnames"`   // internal domains; the domain and its subdomains are masked
+	Patterns    []string `toml:"patterns"`    // extra regular expressions; each whole match is masked
+	Replacement string   `toml:"replacement"` // text left in place of a match
+}
+
+// JobConfig schedules one recurring daemon job
+type JobConfig struct {
+	Kind     string `toml:"kind"`     // "gc", "sweep", "heresy", "summary" or "federate"; defaults to the job's name
+	Schedule string `toml:"schedule"` // cron expression, @hourly/@daily/@weekly/@monthly, or "@every <duration>"
+	Enabled  *bool  `toml:"enabled"`  // nil = enabled
+	Turf     string `toml:"turf"`     // sweep and heresy jobs: turf to scan; empty = every turf
+	Sweep    string `toml:"sweep"`    // sweep jobs: "review", "bugs" or "all" (default)
+}
+
+// IsEnabled reports whether the job should run on its schedule
+func (j JobConfig) IsEnabled() bool {
+	return j.Enabled == nil || *j.Enabled
+}
+
+// TUIConfig holds dashboard display preferences
+type TUIConfig struct {
+	TokenWarnThreshold int    `toml:"token_warn_threshold"` // warn when one response's output exceeds this; 0 = never
+	ConfirmMutations   bool   `toml:"confirm_mutations"`    // hold the underboss's state-changing tool calls for y/n in chat
+	Theme              string `toml:"theme"`                // dark, light or a file in .mob/themes
+	Bell               bool   `toml:"bell"`                 // ring the terminal bell when a notification arrives
+}
+
+// RoutingConfig controls which model works on each bead
+type RoutingConfig struct {
+	DefaultModel   string       `toml:"default_model"`
+	PreflightModel string       `toml:"preflight_model"` // cheap model estimate_task uses to size beads
+	ReviewModel    string       `toml:"review_model"`    // model the review gate's reviewer associate uses; empty = default_model
+	Routes         []ModelRoute `toml:"routes"`          // first matching route wins
+}
+
+// ModelRoute picks a model for beads matching a type and priority ceiling
+type ModelRoute struct {
+	Type          string  `toml:"type"`           // bead type to match; empty = any
+	MaxPriority   *int    `toml:"max_priority"`   // match priorities <= this (0 = highest); nil = any
+	Model         string  `toml:"model"`          // model to use when matched
+	MaxAvgCost    float64 `toml:"max_avg_cost"`   // historical average cost (USD) above which to fall back; 0 = no limit
+	FallbackModel string  `toml:"fallback_model"` // cheaper model used when MaxAvgCost is exceeded
+}
+
+// ScheduleConfig restricts when the daemon spawns agents and assigns work
+type ScheduleConfig struct {
+	WorkingHours string                    `toml:"working_hours"` // "HH:MM-HH:MM", may wrap past midnight; empty = all day
+	WorkingDays  []string                  `toml:"working_days"`  // e.g. ["mon", "tue"]; empty = every day
+	Timezone     string                    `toml:"timezone"`      // IANA name like "America/New_York"; empty = system local time
+	Turfs        map[string]ScheduleConfig `toml:"turfs"`         // per-turf windows keyed by turf name, replacing the one above for that turf
+}
+
+// ForTurf returns the schedule work in a turf follows: its own window if it
+// has one, otherwise the mob-wide one. A turf window without a timezone uses
+// the mob-wide timezone.
+func (c *ScheduleConfig) ForTurf(turf string) ScheduleConfig {
+	s, ok := c.Turfs[turf]
+	if !ok {
+		s = *c
+	} else if s.Timezone == "" {
+		s.Timezone = c.Timezone
+	}
+	s.Turfs = nil
+	return s
+}
+
+// weekdays maps accepted day names to time.Weekday
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// parseClock parses "HH:MM" into minutes since midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// window returns the working window bounds in minutes since midnight
+func (c *ScheduleConfig) window() (start, end int, err error) {
+	parts := strings.SplitN(c.WorkingHours, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid working_hours %q (want HH:MM-HH:MM)", c.WorkingHours)
+	}
+	if start, err = parseClock(parts[0]); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(parts[1]); err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// location returns the configured timezone, defaulting to local time
+func (c *ScheduleConfig) location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+// Validate reports malformed schedule settings, including turf windows
+func (c *ScheduleConfig) Validate() error {
+	if err := c.validateWindow(); err != nil {
+		return err
+	}
+	for name := range c.Turfs {
+		s := c.ForTurf(name)
+		if err := s.validateWindow(); err != nil {
+			return fmt.Errorf("schedule.turfs.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateWindow reports malformed settings of this window alone
+func (c *ScheduleConfig) validateWindow() error {
+	if c.WorkingHours != "" {
+		if _, _, err := c.window(); err != nil {
+			return err
+		}
+	}
+	for _, day := range c.WorkingDays {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("invalid working day %q", day)
+		}
+	}
+	if _, err := c.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	return nil
+}
+
+// IsWorkingTime returns true if agents may be spawned and assigned work at t.
+// An empty or invalid schedule never blocks work.
+func (c *ScheduleConfig) IsWorkingTime(t time.Time) bool {
+	if c.validateWindow() != nil {
+		return true
+	}
+
+	loc, _ := c.location()
+	t = t.In(loc)
+
+	if len(c.WorkingDays) > 0 {
+		allowed := false
+		for _, day := range c.WorkingDays {
+			if weekdays[strings.ToLower(day)] == t.Weekday() {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+
+	if c.WorkingHours == "" {
+		return true
+	}
+
+	start, end, _ := c.window()
+	now := t.Hour()*60 + t.Minute()
+	if start <= end {
+		return now >= start && now < end
+	}
+	// Window wraps past midnight (e.g. 22:00-06:00)
+	return now >= start || now < end
+}
+
+// Daemon loop defaults
+const (
+	DefaultHeartbeatInterval = 2 * time.Minute
+	DefaultBootCheckInterval = 5 * time.Minute
+)
+
+// GetHeartbeatInterval parses the patrol interval, falling back to
+// DefaultHeartbeatInterval when it is empty or invalid
+func (c *DaemonConfig) GetHeartbeatInterval() time.Duration {
+	d, err := time.ParseDuration(c.HeartbeatInterval)
+	if err != nil || d <= 0 {
+		return DefaultHeartbeatInterval
+	}
+	return d
+}
+
+// GetBootCheckInterval parses the nudge interval, falling back to
+// DefaultBootCheckInterval when it is empty or invalid
+func (c *DaemonConfig) GetBootCheckInterval() time.Duration {
+	d, err := time.ParseDuration(c.BootCheckInterval)
+	if err != nil || d <= 0 {
+		return DefaultBootCheckInterval
+	}
+	return d
+}
+
+// DefaultStuckTimeout is how long a soldati's call may go without output
+// before it is considered stuck
+const DefaultStuckTimeout = 10 * time.Minute
+
+// GetStuckTimeout parses the stuck timeout, falling back to
+// DefaultStuckTimeout when it is empty or invalid. "0" returns 0, turning
+// stuck detection off.
+func (c *DaemonConfig) GetStuckTimeout() time.Duration {
+	if c.StuckTimeout == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.StuckTimeout)
+	if err != nil || d <= 0 {
+		return DefaultStuckTimeout
+	}
+	return d
+}
+
+// DefaultNudgeMaxBackoff caps how long an agent that nudges are not moving
+// along waits between them
+const DefaultNudgeMaxBackoff = 2 * time.Hour
+
+// GetNudgeMaxBackoff parses the nudge backoff cap, falling back to
+// DefaultNudgeMaxBackoff when it is empty or invalid; "0" turns backoff off
+func (c *DaemonConfig) GetNudgeMaxBackoff() time.Duration {
+	if c.NudgeMaxBackoff == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.NudgeMaxBackoff)
+	if err != nil || d <= 0 {
+		return DefaultNudgeMaxBackoff
+	}
+	return d
+}
+
+// GetPriorityAging parses how long an open bead waits before its priority is
+// raised a level; 0 when aging is off or the value is invalid
+func (c *DaemonConfig) GetPriorityAging() time.Duration {
+	d, err := ParseRetention(c.PriorityAging)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
+// DefaultSpawnQueueTimeout is how long a queued associate spawn waits by default
+const DefaultSpawnQueueTimeout = 30 * time.Minute
+
+// GetQueueTimeout parses the spawn queue timeout, falling back to
+// DefaultSpawnQueueTimeout when it is empty or invalid
+func (c *AssociatesConfig) GetQueueTimeout() time.Duration {
+	if c.QueueTimeout == "" {
+		return DefaultSpawnQueueTimeout
+	}
+	d, err := time.ParseDuration(c.QueueTimeout)
+	if err != nil || d <= 0 {
+		return DefaultSpawnQueueTimeout
+	}
+	return d
+}
+
+// Failover defaults
+const (
+	DefaultFailoverThreshold   = 3
+	DefaultFailoverCooldown    = time.Minute
+	DefaultFailoverMaxCooldown = 30 * time.Minute
+)
+
+// GetFailureThreshold returns the failure threshold, falling back to
+// DefaultFailoverThreshold when it is unset
+func (c *FailoverConfig) GetFailureThreshold() int {
+	if c.FailureThreshold <= 0 {
+		return DefaultFailoverThreshold
+	}
+	return c.FailureThreshold
+}
+
+// GetCooldown parses the cooldown, falling back to DefaultFailoverCooldown
+// when it is empty or invalid
+func (c *FailoverConfig) GetCooldown() time.Duration {
+	d, err := time.ParseDuration(c.Cooldown)
+	if err != nil || d <= 0 {
+		return DefaultFailoverCooldown
+	}
+	return d
+}
+
+// GetMaxCooldown parses the cooldown cap, falling back to
+// DefaultFailoverMaxCooldown when it is empty or invalid
+func (c *FailoverConfig) GetMaxCooldown() time.Duration {
+	d, err := time.ParseDuration(c.MaxCooldown)
+	if err != nil || d <= 0 {
+		return DefaultFailoverMaxCooldown
+	}
+	return d
+}
+
+// Failure retry defaults
+const (
+	DefaultFailureMaxAttempts   = 3
+	DefaultFailureBackoff       = 5 * time.Minute
+	DefaultFailureMaxBackoff    = time.Hour
+	DefaultFailureReassignAfter = 2
+)
+
+// GetMaxAttempts returns the attempts a bead gets, falling back to
+// DefaultFailureMaxAttempts when it is unset
+func (c *FailuresConfig) GetMaxAttempts() int {
+	if c.MaxAttempts <= 0 {
+		return DefaultFailureMaxAttempts
+	}
+	return c.MaxAttempts
+}
+
+// GetBackoff parses the first retry's wait, falling back to
+// DefaultFailureBackoff when it is empty or invalid
+func (c *FailuresConfig) GetBackoff() time.Duration {
+	d, err := time.ParseDuration(c.Backoff)
+	if err != nil || d <= 0 {
+		return DefaultFailureBackoff
+	}
+	return d
+}
+
+// GetMaxBackoff parses the retry wait cap, falling back to
+// DefaultFailureMaxBackoff when it is empty or invalid
+func (c *FailuresConfig) GetMaxBackoff() time.Duration {
+	d, err := time.ParseDuration(c.MaxBackoff)
+	if err != nil || d <= 0 {
+		return DefaultFailureMaxBackoff
+	}
+	return d
+}
+
+// GetReassignAfter returns the failures after which a bead moves to another
+// agent, falling back to DefaultFailureReassignAfter when it is unset
+func (c *FailuresConfig) GetReassignAfter() int {
+	if c.ReassignAfter <= 0 {
+		return DefaultFailureReassignAfter
+	}
+	return c.ReassignAfter
 }
 
 // GetAssociateTimeout parses the associate timeout string and returns a duration.
@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -20,6 +22,8 @@ func TestLoadConfig(t *testing.T) {
 [daemon]
 heartbeat_interval = "3m"
 max_concurrent_agents = 10
+max_agents_per_turf = 2
+turf_agent_limits = { frontend = 1 }
 
 [safety]
 branch_prefix = "mob/"
@@ -42,6 +46,9 @@ command_blacklist = ["sudo", "rm -rf"]
 	if cfg.Daemon.MaxConcurrentAgents != 10 {
 		t.Errorf("expected max_concurrent_agents 10, got %d", cfg.Daemon.MaxConcurrentAgents)
 	}
+	if cfg.Daemon.MaxAgentsPerTurf != 2 || cfg.Daemon.TurfAgentLimits["frontend"] != 1 {
+		t.Errorf("expected per-turf limits 2 and frontend 1, got %d and %v", cfg.Daemon.MaxAgentsPerTurf, cfg.Daemon.TurfAgentLimits)
+	}
 	if cfg.Safety.BranchPrefix != "mob/" {
 		t.Errorf("expected branch_prefix 'mob/', got '%s'", cfg.Safety.BranchPrefix)
 	}
@@ -73,3 +80,323 @@ func TestLoadConfigWithDefaults(t *testing.T) {
 		t.Errorf("expected default max_concurrent_agents 5, got %d", cfg.Daemon.MaxConcurrentAgents)
 	}
 }
+
+func TestFlowWIPLimit(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mob-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "config.toml")
+	configContent := `
+[flow]
+default_wip_limit = 3
+
+[flow.wip_limits]
+frontend = 1
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if got := cfg.Flow.WIPLimit("frontend"); got != 1 {
+		t.Errorf("expected frontend WIP limit 1, got %d", got)
+	}
+	if got := cfg.Flow.WIPLimit("backend"); got != 3 {
+		t.Errorf("expected default WIP limit 3, got %d", got)
+	}
+	if !cfg.Flow.IsExpedite(0) {
+		t.Error("expected priority 0 to be expedited")
+	}
+	if cfg.Flow.IsExpedite(1) {
+		t.Error("expected priority 1 not to be expedited")
+	}
+
+	if err := os.WriteFile(configPath, []byte("[flow]\nexpedite_priority = -1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Flow.IsExpedite(0) {
+		t.Error("expected no expedite lane with expedite_priority = -1")
+	}
+}
+
+func TestScheduleIsWorkingTime(t *testing.T) {
+	// 2026-01-14 is a Wednesday
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 14, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		schedule ScheduleConfig
+		t        time.Time
+		want     bool
+	}{
+		{"empty schedule", ScheduleConfig{}, at(3, 0), true},
+		{"inside window", ScheduleConfig{WorkingHours: "08:00-20:00", Timezone: "UTC"}, at(9, 30), true},
+		{"before window", ScheduleConfig{WorkingHours: "08:00-20:00", Timezone: "UTC"}, at(7, 59), false},
+		{"end is exclusive", ScheduleConfig{WorkingHours: "08:00-20:00", Timezone: "UTC"}, at(20, 0), false},
+		{"overnight late", ScheduleConfig{WorkingHours: "22:00-06:00", Timezone: "UTC"}, at(23, 0), true},
+		{"overnight early", ScheduleConfig{WorkingHours: "22:00-06:00", Timezone: "UTC"}, at(5, 0), true},
+		{"overnight midday", ScheduleConfig{WorkingHours: "22:00-06:00", Timezone: "UTC"}, at(12, 0), false},
+		{"weekday allowed", ScheduleConfig{WorkingDays: []string{"mon", "wed"}, Timezone: "UTC"}, at(12, 0), true},
+		{"weekday blocked", ScheduleConfig{WorkingDays: []string{"sat", "sun"}, Timezone: "UTC"}, at(12, 0), false},
+		{"timezone shifts window", ScheduleConfig{WorkingHours: "08:00-20:00", Timezone: "Asia/Tokyo"}, at(2, 0), true},
+		{"invalid schedule fails open", ScheduleConfig{WorkingHours: "late"}, at(3, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.IsWorkingTime(tt.t); got != tt.want {
+				t.Errorf("IsWorkingTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	valid := ScheduleConfig{WorkingHours: "08:00-20:00", WorkingDays: []string{"Mon", "friday"}, Timezone: "Europe/London"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid schedule, got %v", err)
+	}
+
+	for _, bad := range []ScheduleConfig{
+		{WorkingHours: "8-20"},
+		{WorkingDays: []string{"funday"}},
+		{Timezone: "Mars/Olympus"},
+		{Turfs: map[string]ScheduleConfig{"api": {WorkingHours: "night"}}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestScheduleForTurf(t *testing.T) {
+	s := ScheduleConfig{
+		WorkingHours: "08:00-20:00",
+		Timezone:     "UTC",
+		Turfs:        map[string]ScheduleConfig{"batch": {WorkingHours: "22:00-06:00"}},
+	}
+	night := time.Date(2026, 1, 14, 23, 0, 0, 0, time.UTC)
+
+	batch := s.ForTurf("batch")
+	if batch.Timezone != "UTC" || !batch.IsWorkingTime(night) {
+		t.Errorf("batch = %+v, want its own overnight window in the mob-wide timezone", batch)
+	}
+	if web := s.ForTurf("web"); web.WorkingHours != "08:00-20:00" || web.IsWorkingTime(night) {
+		t.Errorf("web = %+v, want the mob-wide window", web)
+	}
+}
+
+func TestWorktreePolicyFor(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mob-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "config.toml")
+	configContent := `
+[worktrees]
+max_count = 10
+sparse = ["libs"]
+
+[worktrees.turfs.monorepo]
+max_count = 2
+shallow = true
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if p := cfg.Worktrees.PolicyFor("monorepo"); p.MaxCount != 2 || !p.Shallow || len(p.Sparse) != 0 {
+		t.Errorf("expected monorepo override, got %+v", p)
+	}
+	if p := cfg.Worktrees.PolicyFor("web"); p.MaxCount != 10 || p.Shallow || len(p.Sparse) != 1 {
+		t.Errorf("expected default policy, got %+v", p)
+	}
+}
+
+func TestScalingPolicy(t *testing.T) {
+	c := ScalingConfig{
+		MaxPerTurf: 2,
+		Turfs:      map[string]ScalingPolicy{"monorepo": {BacklogThreshold: 3, MaxPerTurf: 4}},
+	}
+
+	web := c.PolicyFor("web")
+	if web.BacklogThreshold != DefaultBacklogThreshold || web.MaxPerTurf != 2 {
+		t.Errorf("expected default policy, got %+v", web)
+	}
+	for backlog, want := range map[int]int{0: 0, 5: 0, 6: 1, 10: 1, 11: 2, 50: 2} {
+		if got := web.Wanted(backlog); got != want {
+			t.Errorf("Wanted(%d) = %d, want %d", backlog, got, want)
+		}
+	}
+	if got := c.PolicyFor("monorepo").Wanted(10); got != 3 {
+		t.Errorf("monorepo Wanted(10) = %d, want 3", got)
+	}
+	if got := (ScalingPolicy{BacklogThreshold: 1}).Wanted(100); got != 0 {
+		t.Errorf("expected no scaling without max_per_turf, got %d", got)
+	}
+
+	if got := c.GetRetireAfter(); got != DefaultRetireAfter {
+		t.Errorf("GetRetireAfter() = %v, want the default when unset", got)
+	}
+	c.RetireAfter = "0"
+	if got := c.GetRetireAfter(); got != 0 {
+		t.Errorf("GetRetireAfter() = %v, want 0", got)
+	}
+}
+
+func TestFailuresDefaults(t *testing.T) {
+	var c FailuresConfig
+	if c.GetMaxAttempts() != DefaultFailureMaxAttempts || c.GetBackoff() != DefaultFailureBackoff ||
+		c.GetMaxBackoff() != DefaultFailureMaxBackoff || c.GetReassignAfter() != DefaultFailureReassignAfter {
+		t.Errorf("expected defaults for an empty [failures], got %d %v %v %d",
+			c.GetMaxAttempts(), c.GetBackoff(), c.GetMaxBackoff(), c.GetReassignAfter())
+	}
+	c = FailuresConfig{MaxAttempts: 5, Backoff: "30s", MaxBackoff: "bogus", ReassignAfter: 1}
+	if c.GetMaxAttempts() != 5 || c.GetBackoff() != 30*time.Second || c.GetMaxBackoff() != DefaultFailureMaxBackoff || c.GetReassignAfter() != 1 {
+		t.Errorf("unexpected policy from %+v", c)
+	}
+}
+
+func TestLoggingRotationDefaults(t *testing.T) {
+	var c LoggingConfig
+	if c.GetMaxSize() != DefaultLogMaxSizeMB<<20 || c.GetRotateEvery() != DefaultLogRotateEvery || c.GetRetention() != DefaultLogRetention {
+		t.Errorf("expected defaults for an empty [logging], got %d %v %v", c.GetMaxSize(), c.GetRotateEvery(), c.GetRetention())
+	}
+	c = LoggingConfig{MaxSizeMB: 2, RotateEvery: "0", Retention: "30d"}
+	if c.GetMaxSize() != 2<<20 || c.GetRotateEvery() != 0 || c.GetRetention() != 30*24*time.Hour {
+		t.Errorf("unexpected rotation from %+v", c)
+	}
+	c = LoggingConfig{RotateEvery: "bogus", Retention: "0"}
+	if c.GetRotateEvery() != DefaultLogRotateEvery || c.GetRetention() != 0 {
+		t.Errorf("unexpected rotation from %+v", c)
+	}
+}
+
+func TestParseRetention(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"xd", 0, true},
+		{"-1d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRetention(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRetention(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRetention(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDaemonIntervals(t *testing.T) {
+	c := DaemonConfig{HeartbeatInterval: "30s", BootCheckInterval: "soon"}
+	if got := c.GetHeartbeatInterval(); got != 30*time.Second {
+		t.Errorf("GetHeartbeatInterval() = %v, want 30s", got)
+	}
+	if got := c.GetBootCheckInterval(); got != DefaultBootCheckInterval {
+		t.Errorf("GetBootCheckInterval() = %v, want the default for an invalid value", got)
+	}
+	if got := c.GetPriorityAging(); got != 0 {
+		t.Errorf("GetPriorityAging() = %v, want 0 (off) when unset", got)
+	}
+	c.PriorityAging = "14d"
+	if got := c.GetPriorityAging(); got != 14*24*time.Hour {
+		t.Errorf("GetPriorityAging() = %v, want 14 days", got)
+	}
+	if got := c.GetNudgeMaxBackoff(); got != DefaultNudgeMaxBackoff {
+		t.Errorf("GetNudgeMaxBackoff() = %v, want the default when unset", got)
+	}
+	c.NudgeMaxBackoff = "0"
+	if got := c.GetNudgeMaxBackoff(); got != 0 {
+		t.Errorf("GetNudgeMaxBackoff() = %v, want 0 (no backoff)", got)
+	}
+	if got := c.GetStuckTimeout(); got != DefaultStuckTimeout {
+		t.Errorf("GetStuckTimeout() = %v, want the default when unset", got)
+	}
+	c.StuckTimeout = "0"
+	if got := c.GetStuckTimeout(); got != 0 {
+		t.Errorf("GetStuckTimeout() = %v, want 0 (off)", got)
+	}
+}
+
+func TestSetString(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := `# my mob
+[underboss]
+personality = "gruff" # keep it short
+
+[routing]
+default_model = "sonnet"
+
+[[routing.routes]]
+type = "chore"
+model = "haiku"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetString(path, "routing", "default_model", "opus"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetString(path, "underboss", "model", "haiku"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetString(path, "tui", "theme", "light"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"# my mob", `personality = "gruff" # keep it short`, "[tui]\ntheme = \"light\""} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q kept in:\n%s", want, data)
+		}
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Routing.DefaultModel != "opus" || cfg.Underboss.Model != "haiku" || len(cfg.Routing.Routes) != 1 {
+		t.Errorf("unexpected config %+v %+v", cfg.Routing, cfg.Underboss)
+	}
+
+	missing := filepath.Join(t.TempDir(), "config.toml")
+	if err := SetString(missing, "underboss", "model", "opus"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err := Load(missing); err != nil || cfg.Underboss.Model != "opus" {
+		t.Errorf("expected a new file with the model, got %+v (%v)", cfg, err)
+	}
+}
@@ -21,6 +21,9 @@ func DefaultConfig() *Config {
 		Associates: AssociatesConfig{
 			Timeout:       "10m",
 			MaxPerSoldati: 3,
+			NamePrefix:    "assoc",
+			MaxConcurrent: 5,
+			QueueTimeout:  "30m",
 		},
 		Notifications: NotificationsConfig{
 			Terminal:        true,
@@ -30,11 +33,78 @@ func DefaultConfig() *Config {
 			BranchPrefix:     "mob/",
 			CommandBlacklist: []string{"sudo", "rm -rf"},
 			RequireReview:    true,
+			RepoHealthCheck:  true,
+			FetchMaxAge:      "1h",
 		},
 		Logging: LoggingConfig{
-			Level:     "info",
-			Format:    "dual",
-			Retention: "7d",
+			Level:       "info",
+			Format:      "dual",
+			Retention:   "7d",
+			MaxSizeMB:   DefaultLogMaxSizeMB,
+			RotateEvery: "24h",
+			Compress:    true,
+		},
+		GC: GCConfig{
+			Interval:    "6h",
+			Sessions:    "7d",
+			Transcripts: "30d",
+			MCPConfigs:  "1d",
+			Tmp:         "1d",
+		},
+		MCP: MCPServerConfig{
+			RateLimit:     10,
+			Burst:         20,
+			MaxConcurrent: 4,
+
+			ListFormat:        "compact",
+			ListLimit:         50,
+			DescriptionLength: 80,
+		},
+		Tests: TestsConfig{
+			Timeout:     "10m",
+			RequirePass: true,
+			OutputLimit: 4000,
+		},
+		Redaction: RedactionConfig{
+			Enabled:     true,
+			Builtin:     true,
+			Replacement: "[REDACTED]",
+		},
+		Failover: FailoverConfig{
+			FailureThreshold: 3,
+			Cooldown:         "1m",
+			MaxCooldown:      "30m",
+		},
+		Preemption: PreemptionConfig{
+			Enabled:             true,
+			UrgentPriority:      0,
+			PreemptiblePriority: DefaultPreemptiblePriority,
+		},
+		Heresy: HeresyConfig{
+			Escalate: "critical",
+			Batch:    "low",
+		},
+		TUI: TUIConfig{
+			TokenWarnThreshold: 20000,
+			ConfirmMutations:   true,
+			Theme:              "dark",
+			Bell:               true,
+		},
+		Routing: RoutingConfig{
+			DefaultModel:   "sonnet",
+			PreflightModel: "haiku",
+			Routes: []ModelRoute{
+				{Type: "chore", Model: "haiku"},
+				{Type: "epic", MaxPriority: intPtr(0), Model: "opus"},
+			},
+		},
+		Flow: FlowConfig{
+			DefaultWIPLimit:  0,
+			ExpeditePriority: 0,
 		},
 	}
 }
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 )
@@ -42,3 +45,65 @@ func Save(path string, cfg *Config) error {
 	encoder := toml.NewEncoder(f)
 	return encoder.Encode(cfg)
 }
+
+// SetString sets one string key in a section of the config file, leaving the
+// rest of the file, comments included, as it was. The section is added when
+// the file lacks it, and the file is created when missing.
+func SetString(path, section, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	line := fmt.Sprintf("%s = %s", key, strconv.Quote(value))
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	header, end := -1, len(lines)
+	for i, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if !strings.HasPrefix(trimmed, "[") {
+			continue
+		}
+		if header >= 0 {
+			end = i
+			break
+		}
+		if trimmed == "["+section+"]" {
+			header = i
+		}
+	}
+
+	switch {
+	case header < 0:
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+section+"]", line)
+	default:
+		at := -1
+		for i := header + 1; i < end; i++ {
+			name, _, ok := strings.Cut(lines[i], "=")
+			if ok && strings.TrimSpace(name) == key {
+				at = i
+				break
+			}
+		}
+		if at >= 0 {
+			lines[at] = line
+		} else {
+			lines = append(lines[:header+1], append([]string{line}, lines[header+1:]...)...)
+		}
+	}
+
+	content := strings.Join(lines, "\n") + "\n"
+	if _, err := toml.Decode(content, DefaultConfig()); err != nil {
+		return fmt.Errorf("setting %s.%s would break %s: %w", section, key, path, err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
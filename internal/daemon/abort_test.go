@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/hook"
+)
+
+func TestAbortHookOnlyStopsItsBead(t *testing.T) {
+	d := restartTestDaemon(t, t.TempDir())
+	a := &agent.Agent{ID: "s-1", Name: "vinnie"}
+	mgr, err := hook.NewManager(filepath.Join(d.mobDir, ".mob", "soldati"), "vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d.work["vinnie"] = &assignmentWork{cancel: cancel, beadID: "bd-2"}
+
+	abortOf := func(beadID string) {
+		hooks := make(chan *hook.Hook, 1)
+		hooks <- &hook.Hook{Type: hook.HookTypeAbort, BeadID: beadID}
+		close(hooks)
+		d.processHooks("vinnie", a, hooks, mgr)
+	}
+
+	// A late abort of the bead vinnie finished leaves the next one running
+	abortOf("bd-1")
+	if workCtx.Err() != nil || d.work["vinnie"] == nil {
+		t.Fatal("abort of another bead cancelled vinnie's work")
+	}
+
+	abortOf("bd-2")
+	if workCtx.Err() == nil || d.work["vinnie"] != nil {
+		t.Error("abort of vinnie's bead left its work running")
+	}
+}
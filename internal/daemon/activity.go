@@ -0,0 +1,19 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/models"
+)
+
+// recordActivity appends an entry to the activity feed, logging rather than
+// failing when it cannot be written
+func (d *Daemon) recordActivity(a models.Activity) {
+	if d.activity == nil {
+		return
+	}
+	if a.Actor == "" {
+		a.Actor = "daemon"
+	}
+	if err := d.activity.Record(&a); err != nil {
+		d.logger.Printf("Activity: failed to record %s: %v\n", a.Type, err)
+	}
+}
@@ -0,0 +1,86 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// agePriorities raises open beads one priority level for each aging period
+// they have waited, so old low-priority work eventually reaches the front of
+// the ready queue. The period runs from creation, then from the last raise,
+// and each raise is recorded in the bead's history. Off unless
+// daemon.priority_aging is set.
+func (d *Daemon) agePriorities(now time.Time) {
+	if d.beadStore == nil || d.cfg == nil {
+		return
+	}
+	period := d.cfg.Daemon.GetPriorityAging()
+	if period <= 0 {
+		return
+	}
+	open, err := d.beadStore.List(storage.BeadFilter{Status: models.BeadStatusOpen})
+	if err != nil {
+		d.logger.Printf("Patrol: failed to list beads for priority aging: %v\n", err)
+		return
+	}
+
+	for _, b := range open {
+		if b.Priority <= 0 || now.Sub(lastAged(b)) < period {
+			continue
+		}
+		from := b.Priority
+		if d.planned(PlanStep{Action: PlanAge, BeadID: b.ID, Detail: fmt.Sprintf("waited %s; P%d → P%d", roundAge(period), from, from-1)}) {
+			continue
+		}
+		aged, err := d.updateBead(b.ID, func(b *models.Bead) error {
+			if b.Status != models.BeadStatusOpen || b.Priority != from {
+				return errBeadMoved
+			}
+			b.Priority--
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, errBeadMoved) {
+				d.logger.Printf("Patrol: failed to age bead %s: %v\n", b.ID, err)
+			}
+			continue
+		}
+		b = aged
+		event := models.BeadEvent{
+			Type:      models.BeadEventTypePriorityAged,
+			Actor:     "daemon",
+			From:      strconv.Itoa(from),
+			To:        strconv.Itoa(b.Priority),
+			Comment:   fmt.Sprintf("Open for over %s; priority raised P%d → P%d", roundAge(period), from, b.Priority),
+			Timestamp: now,
+		}
+		if err := d.beadStore.AddEvent(b.ID, event); err != nil {
+			d.logger.Printf("Patrol: failed to record priority aging on %s: %v\n", b.ID, err)
+		}
+		d.logger.Printf("Patrol: bead %s waited %s, aged to P%d\n", b.ID, roundAge(period), b.Priority)
+	}
+}
+
+// lastAged is when a bead's aging period started: its last priority raise,
+// or its creation
+func lastAged(b *models.Bead) time.Time {
+	for i := len(b.History) - 1; i >= 0; i-- {
+		if b.History[i].Type == models.BeadEventTypePriorityAged {
+			return b.History[i].Timestamp
+		}
+	}
+	return b.CreatedAt
+}
+
+// roundAge renders an aging period in days when it is a whole number of them
+func roundAge(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestAgePriorities(t *testing.T) {
+	d := New(t.TempDir(), log.New(io.Discard, "", 0))
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.beadStore = store
+	d.cfg = &config.Config{}
+
+	old, _ := store.Create(&models.Bead{Title: "Someday", Status: models.BeadStatusOpen, Priority: 4})
+	top, _ := store.Create(&models.Bead{Title: "Already urgent", Status: models.BeadStatusOpen, Priority: 0})
+	busy, _ := store.Create(&models.Bead{Title: "Being worked", Status: models.BeadStatusInProgress, Priority: 3})
+	start := old.CreatedAt
+
+	// Off by default
+	d.agePriorities(start.Add(30 * 24 * time.Hour))
+	if got, _ := store.Get(old.ID); got.Priority != 4 {
+		t.Fatalf("expected no aging without priority_aging, got P%d", got.Priority)
+	}
+
+	d.cfg.Daemon.PriorityAging = "7d"
+	d.agePriorities(start.Add(6 * 24 * time.Hour))
+	if got, _ := store.Get(old.ID); got.Priority != 4 {
+		t.Fatalf("expected no aging before the period, got P%d", got.Priority)
+	}
+
+	d.agePriorities(start.Add(8 * 24 * time.Hour))
+	got, _ := store.Get(old.ID)
+	if got.Priority != 3 {
+		t.Fatalf("expected P3 after a week, got P%d", got.Priority)
+	}
+	if last := got.History[len(got.History)-1]; last.Type != models.BeadEventTypePriorityAged || last.From != "4" || last.To != "3" {
+		t.Errorf("expected a priority_aged event, got %+v", last)
+	}
+
+	// The next period counts from the last raise
+	d.agePriorities(start.Add(10 * 24 * time.Hour))
+	if got, _ := store.Get(old.ID); got.Priority != 3 {
+		t.Errorf("expected one raise per period, got P%d", got.Priority)
+	}
+	d.agePriorities(start.Add(15 * 24 * time.Hour))
+	if got, _ := store.Get(old.ID); got.Priority != 2 {
+		t.Errorf("expected P2 after a second week, got P%d", got.Priority)
+	}
+
+	if got, _ := store.Get(top.ID); got.Priority != 0 {
+		t.Errorf("expected P0 left alone, got P%d", got.Priority)
+	}
+	if got, _ := store.Get(busy.ID); got.Priority != 3 {
+		t.Errorf("expected beads in progress left alone, got P%d", got.Priority)
+	}
+}
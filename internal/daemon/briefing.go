@@ -0,0 +1,37 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/briefing"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// withOnboarding prepares a soldati's task message. The first message of a
+// session, or the first after moving to a different turf, carries a full turf
+// briefing; later messages only carry the turf's conventions.
+func (d *Daemon) withOnboarding(name string, a *agent.Agent, beadID, taskMsg string) string {
+	if d.beadStore == nil || beadID == "" {
+		return taskMsg
+	}
+	bead, err := d.beadStore.Get(beadID)
+	if err != nil || bead.Turf == "" {
+		return taskMsg
+	}
+
+	d.mu.Lock()
+	needsBriefing := a.SessionID == "" || d.briefedTurf[name] != bead.Turf
+	if needsBriefing {
+		d.briefedTurf[name] = bead.Turf
+	}
+	d.mu.Unlock()
+
+	turfPath := d.resolveTurfPath(bead.Turf)
+	if !needsBriefing || turfPath == d.mobDir {
+		return d.withBeadConventions(beadID, taskMsg)
+	}
+
+	beads, _ := d.beadStore.List(storage.BeadFilter{Turf: bead.Turf})
+	brief := briefing.Build(bead.Turf, turfPath, beads, briefing.Options{})
+	d.logger.Printf("Briefing soldati '%s' on turf %s\n", name, bead.Turf)
+	return briefing.Prepend(brief, taskMsg)
+}
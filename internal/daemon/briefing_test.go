@@ -0,0 +1,28 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+)
+
+func TestWithOnboardingBriefsOncePerTurf(t *testing.T) {
+	d, turfDir, bead := newTurfTestDaemon(t)
+	if err := os.WriteFile(filepath.Join(turfDir, "README.md"), []byte("# Backend\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &agent.Agent{}
+	first := d.withOnboarding("vinnie", a, bead.ID, "task")
+	if !strings.Contains(first, "Turf Briefing: backend") || !strings.HasSuffix(first, "task") {
+		t.Fatalf("expected briefing on first message, got %q", first)
+	}
+
+	a.SessionID = "sess-1"
+	if second := d.withOnboarding("vinnie", a, bead.ID, "task"); second != "task" {
+		t.Errorf("expected no briefing once onboarded, got %q", second)
+	}
+}
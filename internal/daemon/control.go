@@ -0,0 +1,143 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/redact"
+)
+
+// Control actions reported in ControlResult
+const (
+	ActionReload  = "reload"
+	ActionUpgrade = "upgrade"
+	ActionRestart = "restart"
+)
+
+// ControlResult is the daemon's answer to the last reload or upgrade it was
+// asked for, so the command that signalled it can report the outcome
+type ControlResult struct {
+	Action  string    `json:"action"`
+	At      time.Time `json:"at"`
+	PID     int       `json:"pid"`
+	Version string    `json:"version,omitempty"` // binary now running
+	Changes []string  `json:"changes,omitempty"` // config sections that changed on reload
+	Error   string    `json:"error,omitempty"`
+}
+
+// ControlResultPath returns where the daemon reports reload and upgrade outcomes
+func ControlResultPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "daemon.control.json")
+}
+
+// ReadControlResult returns the last reported outcome, or nil when there is none
+func ReadControlResult(mobDir string) (*ControlResult, error) {
+	data, err := os.ReadFile(ControlResultPath(mobDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r ControlResult
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("daemon control result: %w", err)
+	}
+	return &r, nil
+}
+
+// reportControl records the outcome of a reload or upgrade
+func (d *Daemon) reportControl(r ControlResult) {
+	r.At = time.Now()
+	r.PID = os.Getpid()
+	data, err := json.Marshal(r)
+	if err == nil {
+		path := ControlResultPath(d.mobDir)
+		tmp := path + ".tmp"
+		if err = os.WriteFile(tmp, data, 0644); err == nil {
+			err = os.Rename(tmp, path)
+		}
+	}
+	if err != nil {
+		d.logger.Printf("Warning: failed to report %s result: %v\n", r.Action, err)
+	}
+}
+
+// reloadConfig re-reads config.toml and applies it without touching running
+// agents: redaction, notification backends and watchers, jobs, failover and
+// the webhook endpoint are rebuilt. The caller resets the loop tickers. A
+// config that fails to load leaves everything as it was.
+func (d *Daemon) reloadConfig() {
+	cfg, err := config.Load(filepath.Join(d.mobDir, "config.toml"))
+	if err != nil {
+		d.logger.Printf("Reload: keeping the current config: %v\n", err)
+		d.reportControl(ControlResult{Action: ActionReload, Error: err.Error()})
+		return
+	}
+	if err := cfg.Schedule.Validate(); err != nil {
+		d.logger.Printf("Warning: ignoring invalid schedule: %v\n", err)
+	}
+
+	changes := changedSections(d.cfg, cfg)
+	old := d.cfg
+	d.cfg = cfg
+
+	redactor, err := redact.FromConfig(cfg)
+	if err != nil {
+		d.logger.Printf("Warning: %v; using the built-in redaction rules\n", err)
+	}
+	d.redactor = redactor
+	d.logger.SetOutput(redactor.Writer(d.logOut))
+
+	// Jobs that never ran keep the schedule they had
+	since := d.jobsSince
+	d.notifier, d.watch = nil, nil
+	d.loadPlugins()
+	d.loadJobs()
+	d.jobsSince = since
+	d.setupFailover()
+	d.setupPool()
+	d.setupNudges()
+	d.setupFailures()
+	d.setupLogFile()
+
+	if !reflect.DeepEqual(old.Webhooks, cfg.Webhooks) {
+		d.stopWebhooks()
+		d.startWebhooks()
+	}
+	if old.Daemon.MetricsListen != cfg.Daemon.MetricsListen {
+		d.stopHealth()
+		d.startHealth()
+	}
+
+	if len(changes) == 0 {
+		d.logger.Println("Reload: config unchanged")
+	} else {
+		d.logger.Printf("Reload: applied changes to %s\n", strings.Join(changes, ", "))
+	}
+	d.reportControl(ControlResult{Action: ActionReload, Changes: changes})
+}
+
+// changedSections names the top-level config sections that differ
+func changedSections(old, cur *config.Config) []string {
+	var changed []string
+	a, b := reflect.ValueOf(*old), reflect.ValueOf(*cur)
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("toml"), ",")
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		changed = append(changed, name)
+	}
+	return changed
+}
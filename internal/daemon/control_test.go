@@ -0,0 +1,101 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+)
+
+func TestReloadConfig(t *testing.T) {
+	mobDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(mobDir, ".mob"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	d := New(mobDir, log.New(&out, "", 0))
+	d.logOut = &out
+	d.spawner = agent.NewSpawner()
+
+	config := `[daemon]
+heartbeat_interval = "30s"
+`
+	if err := os.WriteFile(filepath.Join(mobDir, "config.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d.reloadConfig()
+
+	if got := d.cfg.Daemon.GetHeartbeatInterval(); got != 30*time.Second {
+		t.Errorf("heartbeat interval = %v after reload, want 30s", got)
+	}
+	r, err := ReadControlResult(mobDir)
+	if err != nil || r == nil {
+		t.Fatalf("ReadControlResult() = %v, %v", r, err)
+	}
+	if r.Action != ActionReload || r.Error != "" || r.PID != os.Getpid() {
+		t.Errorf("unexpected result %+v", r)
+	}
+	if strings.Join(r.Changes, ",") != "daemon" {
+		t.Errorf("changes = %v, want [daemon]", r.Changes)
+	}
+
+	// A broken config is reported and the current one kept
+	if err := os.WriteFile(filepath.Join(mobDir, "config.toml"), []byte("[daemon"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d.reloadConfig()
+	if got := d.cfg.Daemon.GetHeartbeatInterval(); got != 30*time.Second {
+		t.Errorf("heartbeat interval = %v after a failed reload, want 30s kept", got)
+	}
+	if r, _ := ReadControlResult(mobDir); r == nil || r.Error == "" {
+		t.Errorf("expected the failed reload to be reported, got %+v", r)
+	}
+}
+
+func TestTakeHandoff(t *testing.T) {
+	mobDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(mobDir, ".mob"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	d := New(mobDir, log.New(&bytes.Buffer{}, "", 0))
+
+	if h := d.takeHandoff(); h != nil {
+		t.Fatalf("expected no handoff, got %+v", h)
+	}
+
+	write := func(h handoff) {
+		data, _ := json.Marshal(h)
+		if err := os.WriteFile(handoffPath(mobDir), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A handoff meant for another process is ignored and removed
+	write(handoff{PID: os.Getpid() + 1, Version: "0.0.9"})
+	if h := d.takeHandoff(); h != nil {
+		t.Errorf("expected another process's handoff to be ignored, got %+v", h)
+	}
+	if _, err := os.Stat(handoffPath(mobDir)); !os.IsNotExist(err) {
+		t.Errorf("expected the handoff to be removed, stat err = %v", err)
+	}
+
+	since := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write(handoff{PID: os.Getpid(), Version: "0.0.9", JobsSince: since, Reloads: map[string]bool{"vinnie": true}})
+	h := d.takeHandoff()
+	if h == nil {
+		t.Fatal("expected the handoff to be taken")
+	}
+	d.applyHandoff(h)
+	if !d.jobsSince.Equal(since) || !d.reloads["vinnie"] {
+		t.Errorf("handoff not applied: jobsSince=%v reloads=%v", d.jobsSince, d.reloads)
+	}
+	if r, _ := ReadControlResult(mobDir); r == nil || r.Action != ActionUpgrade || r.Error != "" {
+		t.Errorf("expected a successful upgrade to be reported, got %+v", r)
+	}
+}
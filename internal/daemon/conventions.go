@@ -0,0 +1,37 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/turf"
+)
+
+// turfConventions returns the conventions prompt section for a turf, or ""
+func (d *Daemon) turfConventions(turfName string) string {
+	if turfName == "" {
+		return ""
+	}
+	conventions, err := turf.LoadConventions(d.resolveTurfPath(turfName))
+	if err != nil {
+		d.logger.Printf("Warning: failed to load conventions for turf %s: %v\n", turfName, err)
+		return ""
+	}
+	return agent.ConventionsSection(turfName, conventions)
+}
+
+// withBeadConventions prefixes a task message with the conventions of the
+// bead's turf. Soldati move between turfs, so conventions travel with each
+// assignment rather than living in the system prompt.
+func (d *Daemon) withBeadConventions(beadID, taskMsg string) string {
+	if d.beadStore == nil || beadID == "" {
+		return taskMsg
+	}
+	bead, err := d.beadStore.Get(beadID)
+	if err != nil {
+		return taskMsg
+	}
+	section := d.turfConventions(bead.Turf)
+	if section == "" {
+		return taskMsg
+	}
+	return section + "\n" + taskMsg
+}
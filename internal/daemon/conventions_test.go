@@ -0,0 +1,58 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+)
+
+// newTurfTestDaemon returns a daemon with a "backend" turf and a bead on it
+func newTurfTestDaemon(t *testing.T) (d *Daemon, turfDir string, bead *models.Bead) {
+	t.Helper()
+	mobDir := t.TempDir()
+	turfDir = t.TempDir()
+	d = New(mobDir, log.New(io.Discard, "", 0))
+
+	turfMgr, err := turf.NewManager(filepath.Join(mobDir, "turfs.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := turfMgr.Add(turfDir, "backend", "main"); err != nil {
+		t.Fatal(err)
+	}
+	d.turfMgr = turfMgr
+
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.beadStore = store
+
+	bead, err = store.Create(&models.Bead{Title: "Add endpoint", Status: models.BeadStatusOpen, Turf: "backend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, turfDir, bead
+}
+
+func TestWithBeadConventions(t *testing.T) {
+	d, turfDir, bead := newTurfTestDaemon(t)
+
+	if got := d.withBeadConventions(bead.ID, "do it"); got != "do it" {
+		t.Errorf("expected message unchanged without conventions, got %q", got)
+	}
+
+	if err := turf.SaveConventions(turfDir, "- Handlers return typed errors"); err != nil {
+		t.Fatal(err)
+	}
+	got := d.withBeadConventions(bead.ID, "do it")
+	if !strings.Contains(got, "Project Conventions (turf: backend)") || !strings.HasSuffix(got, "do it") {
+		t.Errorf("expected conventions before task, got %q", got)
+	}
+}
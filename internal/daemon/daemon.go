@@ -2,8 +2,12 @@ package daemon
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -13,13 +17,31 @@ import (
 
 	"github.com/gabe/mob/internal/agent"
 	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/failover"
+	"github.com/gabe/mob/internal/failures"
 	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/jobs"
+	"github.com/gabe/mob/internal/logfile"
 	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/merge"
 	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/nudge"
+	"github.com/gabe/mob/internal/pool"
+	"github.com/gabe/mob/internal/postmortem"
+	"github.com/gabe/mob/internal/preempt"
+	"github.com/gabe/mob/internal/redact"
 	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/search"
 	"github.com/gabe/mob/internal/soldati"
 	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/sweep"
 	"github.com/gabe/mob/internal/turf"
+	"github.com/gabe/mob/internal/version"
+	"github.com/gabe/mob/internal/watch"
+	"github.com/gabe/mob/internal/workhours"
 )
 
 // State represents the daemon's operational state
@@ -37,6 +59,9 @@ type Daemon struct {
 	stateFile    string
 	mobDir       string
 	logger       *log.Logger
+	logOut       io.Writer       // where the logger wrote before redaction, kept for reloads
+	logFile      *logfile.Writer // the rotating daemon.log under logOut, nil when logging elsewhere
+	cfg          *config.Config
 	state        State
 	ctx          context.Context
 	cancel       context.CancelFunc
@@ -45,26 +70,85 @@ type Daemon struct {
 	soldatiMgr   *soldati.Manager
 	turfMgr      *turf.Manager
 	beadStore    *storage.BeadStore
+	activity     *storage.ActivityStore        // mob-wide activity feed
+	events       *events.Bus                   // what happens in the daemon, for the log, feed and notifications
+	pool         *pool.Pool                    // caps soldati working at once; assignments over the limits queue
 	activeAgents map[string]*agent.Agent       // keyed by soldati name
 	hookManagers map[string]*hook.Manager      // keyed by soldati name
 	hookCancels  map[string]context.CancelFunc // keyed by soldati name
+	work         map[string]*assignmentWork    // keyed by soldati name, the in-flight assignment
 	nudgedAt     map[string]time.Time          // keyed by associate ID, tracks when nudge was sent
-	mu           sync.RWMutex                  // protects activeAgents, hookManagers, hookCancels, nudgedAt
+	briefedTurf  map[string]string             // keyed by soldati name, turf last briefed on
+	definitions  map[string]string             // keyed by soldati name, fingerprint of the TOML its session started from
+	reloads      map[string]bool               // keyed by soldati name, reload requested and not yet applied
+	offHours     bool                          // true while outside configured working hours
+	closedTurfs  map[string]bool               // keyed by turf name, turfs with their own window that is closed
+	overrides    workhours.Overrides           // set with `mob schedule --override`, refreshed each patrol
+	drainedAt    map[string]time.Time          // keyed by turf, when the queue of a turf with added soldati last emptied; patrol only
+	stuck        map[string]*stuckAgent        // keyed by soldati name, calls that have gone silent; patrol only
+	searchIndex  *search.Builder               // builds the `mob grep` index from what changed; patrol only
+	merges       *merge.Scheduler              // shared merge loop across turf queues
+	mergePending map[string]merge.Request      // keyed by bead ID, claimed merges not yet finished
+	jobs         []*jobs.Job                   // recurring jobs from [jobs] config
+	jobsSince    time.Time                     // jobs that never ran are scheduled from here
+	jobsRunning  map[string]bool               // keyed by job name, jobs currently executing
+	detectors    []sweep.Detector              // plugin detectors for scheduled sweeps
+	redactor     *redact.Redactor              // masks secrets in logs and notifications, nil when disabled
+	notifier     *notify.Manager               // the notification feed and plugin backends; nil until plugins load
+	watch        *watch.Dispatcher             // bead watch notifications, nil when no humans are configured
+	webhooks     *http.Server                  // git hosting webhook endpoint, nil when [webhooks] listen is unset
+	webhookLn    net.Listener                  // socket webhooks is serving from
+	inheritedLn  net.Listener                  // webhook socket handed over by the daemon this one upgraded, until served
+	inheritedAt  string                        // [webhooks] listen the inherited socket was opened for
+	nudges       *nudge.Pacer                  // backs off unproductive nudges and caps their daily count and cost
+	nudgesHeld   string                        // why the daily limits hold nudges back, empty when they do not
+	failures     *failures.Queue               // failed assignments waiting to be retried
+	parked       *preempt.Store                // work set aside for urgent beads, resumed when its soldati is free
+	plan         *Plan                         // set while planning a patrol instead of running it
+	stats        *selfMetrics                  // what the daemon measures about itself, for /healthz and /metrics
+	healthSock   *http.Server                  // /healthz and /metrics on .mob/daemon.sock
+	healthTCP    *http.Server                  // the same on [daemon] metrics_listen, nil when unset
+	upgrade      *pendingUpgrade               // accepted upgrade waiting on in-flight work, nil when none
+	failover     *failover.Breaker             // per-model circuit breaker shared with every mob process
+	degraded     map[string]bool               // keyed by model, outages already reported
+	fixes        sync.WaitGroup                // associates started on approved heresy fixes
+	mu           sync.RWMutex                  // protects activeAgents, hookManagers, hookCancels, work, nudgedAt, briefedTurf, definitions, reloads, mergePending, jobsRunning, offHours, closedTurfs, overrides, nudgesHeld
 }
 
 // New creates a new daemon instance
 func New(mobDir string, logger *log.Logger) *Daemon {
-	return &Daemon{
+	d := &Daemon{
 		pidFile:      filepath.Join(mobDir, ".mob", "daemon.pid"),
 		stateFile:    filepath.Join(mobDir, ".mob", "daemon.state"),
 		mobDir:       mobDir,
 		logger:       logger,
+		cfg:          config.DefaultConfig(),
 		state:        StateIdle,
 		activeAgents: make(map[string]*agent.Agent),
 		hookManagers: make(map[string]*hook.Manager),
 		hookCancels:  make(map[string]context.CancelFunc),
+		work:         make(map[string]*assignmentWork),
 		nudgedAt:     make(map[string]time.Time),
-	}
+		briefedTurf:  make(map[string]string),
+		definitions:  make(map[string]string),
+		reloads:      make(map[string]bool),
+		merges:       merge.NewScheduler(0),
+		mergePending: make(map[string]merge.Request),
+		jobsRunning:  make(map[string]bool),
+		closedTurfs:  make(map[string]bool),
+		drainedAt:    make(map[string]time.Time),
+		stuck:        make(map[string]*stuckAgent),
+		searchIndex:  search.NewBuilder(),
+		events:       events.NewBus(),
+		pool:         pool.New(pool.Limits{}),
+		stats:        newSelfMetrics(time.Now()),
+		nudges:       nudge.NewPacer(nudge.PacerPath(mobDir), nudge.Limits{Interval: config.DefaultBootCheckInterval}),
+		failures:     failures.Open(mobDir, config.FailuresConfig{}),
+		parked:       preempt.New(preempt.Path(mobDir)),
+	}
+	d.merges.SetResultHandler(d.onMergeResult)
+	d.subscribe()
+	return d
 }
 
 // Start begins daemon operation
@@ -75,48 +159,58 @@ func (d *Daemon) Start() error {
 		return fmt.Errorf("failed to create .mob directory: %w", err)
 	}
 
-	// Check for existing daemon
+	// Check for existing daemon. A PID file left by a dead daemon means it
+	// did not shut down cleanly.
+	_, statErr := os.Stat(d.pidFile)
 	running, pid, err := CheckExistingDaemon(d.pidFile)
 	if err != nil {
 		return err
 	}
-	if running {
+	// After an upgrade the PID file already names this process
+	if running && pid != os.Getpid() {
 		return fmt.Errorf("daemon already running (PID %d)", pid)
 	}
+	handoff := d.takeHandoff()
+	stalePID := statErr == nil && handoff == nil
 
 	// Write our PID
 	if err := WritePID(d.pidFile, os.Getpid()); err != nil {
 		return fmt.Errorf("failed to write PID file: %w", err)
 	}
 
-	// Initialize spawner, registry, soldati manager, and turf manager
-	d.spawner = agent.NewSpawner()
-	d.registry = registry.New(registry.DefaultPath(d.mobDir))
-	soldatiDir := filepath.Join(d.mobDir, "soldati")
-	if err := os.MkdirAll(soldatiDir, 0755); err != nil {
-		return fmt.Errorf("failed to create soldati directory: %w", err)
+	if err := d.open(); err != nil {
+		return err
 	}
-	soldatiMgr, err := soldati.NewManager(soldatiDir)
+
+	// Mask secrets agents surface before they reach the log or notifications
+	redactor, err := redact.FromConfig(d.cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create soldati manager: %w", err)
+		d.logger.Printf("Warning: %v; using the built-in redaction rules\n", err)
 	}
-	d.soldatiMgr = soldatiMgr
+	d.redactor = redactor
+	d.logOut = d.logger.Writer()
+	d.logger.SetOutput(redactor.Writer(d.logOut))
 
-	// Initialize turf manager for resolving turf names to paths
-	turfsPath := filepath.Join(d.mobDir, "turfs.toml")
-	turfMgr, err := turf.NewManager(turfsPath)
-	if err != nil {
-		return fmt.Errorf("failed to create turf manager: %w", err)
+	// Mirror bead changes and daemon events to the activity feed
+	if activity, err := storage.NewActivityStore(storage.ActivityDir(d.mobDir)); err != nil {
+		d.logger.Printf("Warning: activity feed disabled: %v\n", err)
+	} else {
+		d.activity = activity
+		d.beadStore.SetActivity(activity)
 	}
-	d.turfMgr = turfMgr
 
-	// Initialize bead store for auto-assignment
-	beadsDir := filepath.Join(d.mobDir, "beads")
-	beadStore, err := storage.NewBeadStore(beadsDir)
-	if err != nil {
-		return fmt.Errorf("failed to create bead store: %w", err)
+	d.loadPlugins()
+	d.loadJobs()
+	if handoff != nil {
+		d.applyHandoff(handoff)
 	}
-	d.beadStore = beadStore
+
+	// Clean up after a predecessor that was killed, then track our own calls
+	d.recoverFromCrash(stalePID)
+	d.reconcile(handoff == nil)
+	d.restoreMerges()
+	d.trackAgentProcesses()
+	d.setupFailover()
 
 	// Set up context for graceful shutdown
 	d.ctx, d.cancel = context.WithCancel(context.Background())
@@ -124,34 +218,116 @@ func (d *Daemon) Start() error {
 
 	// Handle signals
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR2)
 
-	d.logger.Println("Mob daemon started")
+	started := events.DaemonStarted{Version: version.Version}
+	if handoff != nil {
+		started.UpgradedFrom, started.Restarted = handoff.Version, handoff.Restart
+	}
+	d.publish(started)
+	d.startWebhooks()
+	d.startHealth()
+	if handoff != nil {
+		d.resumeSessions(handoff.Sessions)
+	}
 
 	// Run initial patrol immediately
 	d.patrol()
 
-	// Main loop with two tickers:
-	// - patrol every 2 minutes (health checks, spawning, cleanup)
-	// - nudge all agents every 5 minutes (keep them working)
-	patrolTicker := time.NewTicker(2 * time.Minute)
-	nudgeTicker := time.NewTicker(5 * time.Minute)
+	// Main loop with three tickers:
+	// - patrol every heartbeat_interval (health checks, spawning, cleanup)
+	// - nudge all agents every boot_check_interval (keep them working)
+	// - send bead watch notifications and start due jobs every 15 seconds
+	patrolTicker := time.NewTicker(d.cfg.Daemon.GetHeartbeatInterval())
+	nudgeTicker := time.NewTicker(d.cfg.Daemon.GetBootCheckInterval())
+	watchTicker := time.NewTicker(15 * time.Second)
 	defer patrolTicker.Stop()
 	defer nudgeTicker.Stop()
+	defer watchTicker.Stop()
 
 	for {
 		select {
 		case <-d.ctx.Done():
 			return d.shutdown()
 		case sig := <-sigChan:
-			d.logger.Printf("\nReceived signal %v, shutting down...\n", sig)
-			return d.shutdown()
+			switch sig {
+			case syscall.SIGHUP:
+				d.logger.Println("Received SIGHUP, reloading config")
+				d.reloadConfig()
+				patrolTicker.Reset(d.cfg.Daemon.GetHeartbeatInterval())
+				nudgeTicker.Reset(d.cfg.Daemon.GetBootCheckInterval())
+			case syscall.SIGUSR2:
+				d.logger.Println("Received SIGUSR2, upgrading")
+				d.beginUpgrade()
+			default:
+				d.logger.Printf("\nReceived signal %v, shutting down...\n", sig)
+				return d.shutdown()
+			}
 		case <-patrolTicker.C:
 			d.patrol()
 		case <-nudgeTicker.C:
 			d.nudgeAllAgents()
+		case <-watchTicker.C:
+			d.dispatchWatchNotifications()
+			d.notifyHumanInputRequests()
+			d.notifyFailover()
+			d.snapshotBoard()
+			d.startHeresyFixes()
+			d.writeStatusBar()
+			d.runDueJobs()
+			d.continueUpgrade()
+		}
+	}
+}
+
+// open loads the config and the stores a patrol works from: registry,
+// soldati, turfs and beads
+func (d *Daemon) open() error {
+	// Load config, falling back to defaults if missing or invalid
+	cfg, err := config.Load(filepath.Join(d.mobDir, "config.toml"))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			d.logger.Printf("Warning: failed to load config, using defaults: %v\n", err)
 		}
+		cfg = config.DefaultConfig()
+	}
+	if err := cfg.Schedule.Validate(); err != nil {
+		d.logger.Printf("Warning: ignoring invalid schedule: %v\n", err)
+	}
+	d.cfg = cfg
+	d.setupPool()
+	d.setupNudges()
+	d.setupFailures()
+	d.setupLogFile()
+
+	// Initialize spawner, registry, soldati manager, and turf manager
+	d.spawner = agent.NewSpawner()
+	d.registry = registry.New(registry.DefaultPath(d.mobDir))
+	soldatiDir := filepath.Join(d.mobDir, "soldati")
+	if err := os.MkdirAll(soldatiDir, 0755); err != nil {
+		return fmt.Errorf("failed to create soldati directory: %w", err)
+	}
+	soldatiMgr, err := soldati.NewManager(soldatiDir)
+	if err != nil {
+		return fmt.Errorf("failed to create soldati manager: %w", err)
 	}
+	d.soldatiMgr = soldatiMgr
+
+	// Initialize turf manager for resolving turf names to paths
+	turfsPath := filepath.Join(d.mobDir, "turfs.toml")
+	turfMgr, err := turf.NewManager(turfsPath)
+	if err != nil {
+		return fmt.Errorf("failed to create turf manager: %w", err)
+	}
+	d.turfMgr = turfMgr
+
+	// Initialize bead store for auto-assignment
+	beadStore, err := storage.NewBeadStore(storage.BeadsDir(d.mobDir))
+	if err != nil {
+		return fmt.Errorf("failed to create bead store: %w", err)
+	}
+	d.beadStore = beadStore
+	return nil
 }
 
 // Stop gracefully stops the daemon
@@ -176,6 +352,8 @@ func (d *Daemon) Status() (State, int, error) {
 
 func (d *Daemon) shutdown() error {
 	d.state = StateIdle
+	d.stopWebhooks()
+	d.stopHealth()
 
 	d.mu.Lock()
 	// Cancel all hook watchers
@@ -202,12 +380,19 @@ func (d *Daemon) shutdown() error {
 		}
 	}
 
+	// Let in-flight merges finish so no turf is left mid-merge
+	d.merges.Wait()
+
 	RemovePID(d.pidFile)
-	d.logger.Println("Mob daemon stopped")
+	os.Remove(pool.StatePath(d.mobDir))
+	d.publish(events.DaemonStopped{Version: version.Version})
 	return nil
 }
 
 func (d *Daemon) patrol() {
+	d.stats.patrolStarted(time.Now(), d.cfg.Daemon.GetHeartbeatInterval())
+	defer func() { d.stats.patrolFinished(time.Now()) }()
+
 	if d.soldatiMgr == nil || d.spawner == nil || d.registry == nil {
 		return
 	}
@@ -216,6 +401,22 @@ func (d *Daemon) patrol() {
 	d.patrolAssociates()
 	d.cleanupStaleAssociates()
 
+	// Escalate beads that blew their due date or SLA, and age long-waiting ones
+	d.escalateOverdue(time.Now())
+	d.agePriorities(time.Now())
+
+	// Outside working hours, keep monitoring but don't start new work
+	working := d.inWorkingHours(time.Now())
+	if d.plan != nil {
+		d.plan.Working = working
+	}
+
+	// Notice soldati whose calls have gone silent and escalate until they recover
+	d.detectStuck(time.Now())
+
+	// Add soldati to turfs whose backlog has grown and retire them once it drains
+	d.scaleSoldati(time.Now(), working)
+
 	// Get all registered soldati from TOML files
 	registeredSoldati, err := d.soldatiMgr.List()
 	if err != nil {
@@ -243,8 +444,13 @@ func (d *Daemon) patrol() {
 	// Spawn Claude instances for soldati that don't have active agents
 	for _, s := range registeredSoldati {
 		if _, active := activeNames[s.Name]; active {
-			// Already has an active agent, check health
+			// A plan takes agents the running daemon registered to be alive
+			if d.plan != nil {
+				continue
+			}
+			// Already has an active agent, check health and pick up edits to its TOML
 			d.checkAgentHealth(s.Name, activeNames[s.Name])
+			d.checkDefinition(s.Name)
 			continue
 		}
 
@@ -253,7 +459,14 @@ func (d *Daemon) patrol() {
 			continue
 		}
 
+		if !working {
+			continue
+		}
+
 		// Spawn a new Claude instance for this soldati
+		if d.planned(PlanStep{Action: PlanSpawn, Agent: s.Name}) {
+			continue
+		}
 		d.logger.Printf("Patrol: spawning Claude instance for soldati '%s'\n", s.Name)
 		if err := d.spawnSoldatiAgent(s.Name); err != nil {
 			d.logger.Printf("Patrol: failed to spawn agent for '%s': %v\n", s.Name, err)
@@ -270,22 +483,44 @@ func (d *Daemon) patrol() {
 			}
 		}
 		if !found {
+			if d.planned(PlanStep{Action: PlanUnregister, Agent: name, Detail: "no soldati definition"}) {
+				continue
+			}
 			d.logger.Printf("Patrol: removing stale registry entry for '%s'\n", name)
 			d.registry.Unregister(record.ID)
 			delete(d.activeAgents, name)
 		}
 	}
 
-	// Auto-assign work to idle agents
-	d.assignWorkToIdleAgents()
+	// Reopen failed beads whose retry is due, then auto-assign work to idle agents
+	if working {
+		d.retryFailures(time.Now())
+		d.assignWorkToIdleAgents()
+	}
+	// Merges, disk quotas and the search index are not part of a plan
+	if d.plan != nil {
+		return
+	}
+
+	// Merge finished work, taking turns across turfs
+	d.processMerges()
+
+	// Reclaim disk from idle worktrees over quota
+	d.enforceWorktreeQuotas()
+
+	// Keep the search index fresh for `mob grep`
+	d.refreshSearchIndex()
 }
 
 // assignWorkToIdleAgents checks for idle soldati and assigns them the next ready bead
 func (d *Daemon) assignWorkToIdleAgents() {
-	if d.beadStore == nil {
+	// An upgrade waits for work to finish, so none is handed out meanwhile
+	if d.beadStore == nil || d.upgrade != nil {
 		return
 	}
 
+	now := time.Now()
+
 	// Get all active soldati from registry
 	agents, err := d.registry.ListByType("soldati")
 	if err != nil {
@@ -293,9 +528,34 @@ func (d *Daemon) assignWorkToIdleAgents() {
 		return
 	}
 
+	// Count in-progress beads per turf for WIP limit enforcement
+	allBeads, err := d.beadStore.List(storage.BeadFilter{Status: models.BeadStatusInProgress})
+	if err != nil {
+		d.logger.Printf("Patrol: failed to count in-progress beads: %v\n", err)
+		return
+	}
+	inProgress := countInProgress(allBeads)
+
+	// Parked work whose soldati is gone goes back to the queue
+	d.releaseParked(agents)
+
+	// Beads that failed enough times go to agents that have not failed them
+	avoid, err := d.failures.Avoid()
+	if err != nil {
+		d.logger.Printf("Patrol: failed to read the failure queue: %v\n", err)
+	}
+
+	// Repo health per turf, checked at most once per patrol
+	turfHealth := make(map[string]error)
+	healthCtx := &mcp.ToolContext{BeadStore: d.beadStore, TurfManager: d.turfMgr, MobDir: d.mobDir}
+	if d.plan != nil {
+		healthCtx.BeadStore = nil // no held-assignment comments from a plan
+	}
+
+	var idle []*registry.AgentRecord
 	for _, agentRecord := range agents {
 		// Only assign to idle agents
-		if agentRecord.Status != "idle" {
+		if agentRecord.Status != registry.StatusIdle || d.planRemoved(agentRecord.Name) {
 			continue
 		}
 
@@ -312,33 +572,133 @@ func (d *Daemon) assignWorkToIdleAgents() {
 			}
 		}
 
-		// Find next ready bead for this agent's turf
+		// Work parked for an urgent bead comes back before anything new
+		if d.resumeParked(agentRecord.Name, now) {
+			continue
+		}
+		idle = append(idle, agentRecord)
+	}
+
+	// Beads go to the idle soldati whose skills match their labels best
+	router := d.newSkillRouter(idle)
+
+	for _, agentRecord := range idle {
+		// Find next ready bead for this agent's turf, in turfs inside their working hours
 		readyBeads, err := d.beadStore.ListReady(agentRecord.Turf)
-		if err != nil || len(readyBeads) == 0 {
+		if err != nil {
+			continue
+		}
+		readyBeads = avoidedBy(d.inHoursBeads(d.unhanded(readyBeads), now), avoid, agentRecord.Name)
+		readyBeads = router.route(agentRecord, readyBeads, avoid)
+		if len(readyBeads) == 0 {
 			continue
 		}
 
-		// Pick first (highest priority) ready bead
-		nextBead := readyBeads[0]
+		// Pick the next bead, honoring the turf's WIP limit and expedite lane
+		nextBead := selectNextBead(readyBeads, inProgress[agentRecord.Turf], d.cfg.Flow, agentRecord.Turf)
+		if nextBead == nil {
+			d.planned(PlanStep{Action: PlanHold, Agent: agentRecord.Name,
+				Detail: fmt.Sprintf("turf '%s' at WIP limit (%d in progress)", agentRecord.Turf, inProgress[agentRecord.Turf])})
+			d.logger.Printf("Patrol: turf '%s' at WIP limit (%d in progress), holding assignment for '%s'\n",
+				agentRecord.Turf, inProgress[agentRecord.Turf], agentRecord.Name)
+			continue
+		}
+
+		// Hold work while the turf checkout is dirty, off main or can't
+		// fetch. Idle agents on one turf pick the same bead, so one check
+		// (and one bead comment) per turf per patrol is enough.
+		healthErr, checked := turfHealth[nextBead.Turf]
+		if !checked {
+			healthErr = mcp.CheckTurfHealth(healthCtx, nextBead)
+			turfHealth[nextBead.Turf] = healthErr
+		}
+		if healthErr != nil {
+			d.planned(PlanStep{Action: PlanHold, Agent: agentRecord.Name, BeadID: nextBead.ID, Detail: healthErr.Error()})
+			d.logger.Printf("Patrol: holding bead %s for '%s': %v\n", nextBead.ID, agentRecord.Name, healthErr)
+			continue
+		}
 
+		if d.planned(PlanStep{Action: PlanAssign, Agent: agentRecord.Name, BeadID: nextBead.ID,
+			Detail: fmt.Sprintf("P%d %s", nextBead.Priority, nextBead.Title)}) {
+			inProgress[nextBead.Turf]++
+			continue
+		}
 		d.logger.Printf("Patrol: auto-assigning bead %s to idle agent '%s'\n",
 			nextBead.ID, agentRecord.Name)
-
-		// Assign via hook (same as assign_bead MCP tool)
-		if err := d.AssignWork(agentRecord.Name, nextBead.ID, nextBead.Title); err != nil {
+		if err := d.assignBead(agentRecord.Name, nextBead.ID); err != nil {
 			d.logger.Printf("Patrol: failed to auto-assign: %v\n", err)
 			continue
 		}
+		inProgress[nextBead.Turf]++
+	}
+
+	// Urgent beads nobody was free for take a soldati off less urgent work
+	d.preemptForUrgent(now)
+}
 
-		// Update bead status and assignee
-		nextBead.Status = models.BeadStatusInProgress
-		nextBead.Assignee = agentRecord.Name
-		if _, err := d.beadStore.Update(nextBead); err != nil {
-			d.logger.Printf("Patrol: failed to update bead status: %v\n", err)
+// assignBead claims a bead for a soldati, hands it over through the
+// soldati's hook and nudges the soldati to pick it up. The claim is made on a
+// fresh read, so a bead taken or closed since it was listed is refused.
+func (d *Daemon) assignBead(name, beadID string) error {
+	claimed, err := d.updateBead(beadID, func(b *models.Bead) error {
+		if b.Status != models.BeadStatusOpen {
+			return fmt.Errorf("%w: %s is %s", errBeadMoved, b.ID, b.Status)
+		}
+		b.Status = models.BeadStatusInProgress
+		b.Assignee = name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Assign via hook (same as assign_bead MCP tool)
+	if err := d.AssignWork(name, claimed.ID, claimed.Title); err != nil {
+		// Give the claim back so another soldati can take the bead
+		if _, rerr := d.updateBead(claimed.ID, func(b *models.Bead) error {
+			if b.Status != models.BeadStatusInProgress || b.Assignee != name {
+				return errBeadMoved
+			}
+			b.Status = models.BeadStatusOpen
+			b.Assignee = ""
+			return nil
+		}); rerr != nil {
+			d.logger.Printf("Patrol: failed to reopen bead %s: %v\n", claimed.ID, rerr)
 		}
+		return err
+	}
+	d.publish(events.BeadAssigned{BeadID: claimed.ID, Agent: name, Turf: claimed.Turf})
+
+	// Nudge the agent to check their hook
+	d.nudgeAgent(name)
+	return nil
+}
+
+// errBeadMoved is returned by updateBead changes when the fresh copy of a
+// bead no longer calls for them
+var errBeadMoved = errkind.New(errkind.Conflict, "bead moved on since it was read")
 
-		// Nudge the agent to check their hook
-		d.nudgeAgent(agentRecord.Name)
+// beadUpdateAttempts bounds how often updateBead re-reads a bead that keeps
+// changing under it
+const beadUpdateAttempts = 5
+
+// updateBead reads a bead, applies change and saves it, reading it again
+// when another write lands in between. An error from change abandons the
+// update and is returned as is.
+func (d *Daemon) updateBead(id string, change func(*models.Bead) error) (*models.Bead, error) {
+	for attempt := 1; ; attempt++ {
+		bead, err := d.beadStore.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if err := change(bead); err != nil {
+			return nil, err
+		}
+		updated, err := d.beadStore.Update(bead)
+		if errors.Is(err, storage.ErrBeadConflict) && attempt < beadUpdateAttempts {
+			continue
+		}
+		return updated, err
 	}
 }
 
@@ -352,21 +712,27 @@ func (d *Daemon) nudgeAgent(name string) {
 		return
 	}
 
-	go func() {
-		d.logger.Printf("Patrol: nudging agent '%s' to check hook\n", name)
-		_, err := a.Chat("Check your hook. If there's work, do it.")
-		if err != nil {
-			d.logger.Printf("Patrol: failed to nudge agent '%s': %v\n", name, err)
-		}
-	}()
+	// New work resets the agent's backoff; only the daily budget applies
+	d.nudges.Forget(name)
+	if !d.allowNudge(name, "", time.Now()) {
+		return
+	}
+	d.logger.Printf("Patrol: nudging agent '%s' to check hook\n", name)
+	d.sendNudge(name, a, "Check your hook. If there's work, do it.", "")
 }
 
 // nudgeAllAgents sends a nudge to agents that have tasks assigned.
 // This is called every 5 minutes to prevent agents from getting stuck.
 // Only nudges agents that have work (hook with assignment or non-idle status).
 func (d *Daemon) nudgeAllAgents() {
+	// Don't wake agents up outside working hours or while an upgrade drains
+	if !d.inWorkingHours(time.Now()) || d.upgrade != nil {
+		return
+	}
+
 	// First, try to assign work to any idle agents
 	d.assignWorkToIdleAgents()
+	now := time.Now()
 
 	d.mu.RLock()
 	agents := make(map[string]*agent.Agent)
@@ -390,12 +756,12 @@ func (d *Daemon) nudgeAllAgents() {
 		return
 	}
 
-	statusMap := make(map[string]string)
+	recordMap := make(map[string]*registry.AgentRecord)
 	for _, rec := range agentRecords {
-		statusMap[rec.Name] = rec.Status
+		recordMap[rec.Name] = rec
 	}
 
-	nudgeCount := 0
+	nudgeCount, heldCount := 0, 0
 	for name, a := range agents {
 		if !a.IsRunning() {
 			continue
@@ -403,16 +769,20 @@ func (d *Daemon) nudgeAllAgents() {
 
 		// Check if agent has work: either has a hook or is not idle
 		hasWork := false
+		beadID := ""
 
 		// Check hook
+		var h *hook.Hook
 		if mgr, ok := hookMgrs[name]; ok {
-			if h, _ := mgr.Read(); h != nil {
+			if h, _ = mgr.Read(); h != nil {
 				hasWork = true
+				beadID = h.BeadID
 			}
 		}
 
 		// Check status - if active/working, they have work
-		if status, ok := statusMap[name]; ok && status != "idle" {
+		rec := recordMap[name]
+		if rec != nil && rec.Status != registry.StatusIdle {
 			hasWork = true
 		}
 
@@ -420,19 +790,32 @@ func (d *Daemon) nudgeAllAgents() {
 			continue
 		}
 
+		// Stuck detection escalates silent calls on its own schedule
+		if d.stuck[name] != nil {
+			continue
+		}
+
+		// Leave agents be while their turf is outside its working hours
+		if !d.turfInHours(d.assignmentTurf(a, beadID), now) {
+			continue
+		}
+
+		// Back off agents that earlier nudges did not move, and stay
+		// within the day's nudge budget
+		progress := d.nudgeProgress(h, rec)
+		if !d.allowNudge(name, progress, now) {
+			heldCount++
+			continue
+		}
+
 		nudgeCount++
 		// Send a message to the agent via Chat() - this uses --resume to continue the session
-		go func(name string, a *agent.Agent) {
-			d.logger.Printf("Nudge: nudging soldati '%s'\n", name)
-			_, err := a.Chat("Do your job.")
-			if err != nil {
-				d.logger.Printf("Nudge: failed to nudge soldati '%s': %v\n", name, err)
-			}
-		}(name, a)
+		d.logger.Printf("Nudge: nudging soldati '%s'\n", name)
+		d.sendNudge(name, a, "Do your job.", progress)
 	}
 
-	if nudgeCount > 0 {
-		d.logger.Printf("Nudge: sent nudge to %d agents with active work\n", nudgeCount)
+	if nudgeCount > 0 || heldCount > 0 {
+		d.logger.Printf("Nudge: sent nudge to %d agents with active work, held back %d\n", nudgeCount, heldCount)
 	}
 }
 
@@ -460,7 +843,7 @@ func (d *Daemon) patrolAssociates() {
 
 	for _, assoc := range associates {
 		// Skip completed or failed associates (they should be cleaned up by cleanupStaleAssociates)
-		if assoc.Status == "completed" || assoc.Status == "failed" || assoc.Status == "timed_out" {
+		if assoc.Status.Terminal() {
 			continue
 		}
 
@@ -477,6 +860,9 @@ func (d *Daemon) patrolAssociates() {
 			timeSinceNudge := now.Sub(nudgeTime)
 			if timeSinceNudge > gracePeriod {
 				// Grace period expired - force kill
+				if d.planned(PlanStep{Action: PlanKill, Agent: assoc.Label(), BeadID: assoc.BeadID, Detail: "timeout after nudge grace period"}) {
+					continue
+				}
 				d.forceKillAssociate(assoc, "timeout after nudge grace period")
 			}
 			// Still in grace period, skip
@@ -485,6 +871,16 @@ func (d *Daemon) patrolAssociates() {
 
 		// Check if timeout exceeded
 		if runningTime > timeout {
+			// A plan does not know which associates the running daemon
+			// nudged, so those past the grace period as well are killed
+			if runningTime > timeout+gracePeriod && d.planned(PlanStep{Action: PlanKill, Agent: assoc.Label(), BeadID: assoc.BeadID,
+				Detail: fmt.Sprintf("running for %s", runningTime.Round(time.Second))}) {
+				continue
+			}
+			if d.planned(PlanStep{Action: PlanNudge, Agent: assoc.Label(), BeadID: assoc.BeadID,
+				Detail: fmt.Sprintf("running for %s", runningTime.Round(time.Second))}) {
+				continue
+			}
 			// First nudge
 			d.nudgeAssociate(assoc)
 		}
@@ -494,7 +890,8 @@ func (d *Daemon) patrolAssociates() {
 // nudgeAssociate sends a nudge signal to a timed-out associate and records the nudge time
 func (d *Daemon) nudgeAssociate(assoc *registry.AgentRecord) {
 	d.logger.Printf("Patrol: associate '%s' exceeded timeout (running since %s), sending nudge\n",
-		assoc.ID, assoc.StartedAt.Format(time.RFC3339))
+		assoc.Label(), assoc.StartedAt.Format(time.RFC3339))
+	d.publish(events.AgentStuck{Agent: assoc.Label(), AgentID: assoc.ID, BeadID: assoc.BeadID, Turf: assoc.Turf, Task: assoc.Task})
 
 	// Record nudge time
 	d.mu.Lock()
@@ -502,34 +899,63 @@ func (d *Daemon) nudgeAssociate(assoc *registry.AgentRecord) {
 	d.mu.Unlock()
 
 	// Update status to indicate nudged
-	d.registry.UpdateStatus(assoc.ID, "nudged")
+	d.registry.UpdateStatus(assoc.ID, registry.StatusNudged)
 
 	// The actual nudge - update the ping time which should trigger activity check
 	d.registry.Ping(assoc.ID)
 
 	d.logger.Printf("Patrol: nudged associate '%s', will force kill in %v if no response\n",
-		assoc.ID, config.DefaultAssociateGracePeriod)
+		assoc.Label(), config.DefaultAssociateGracePeriod)
 }
 
 // forceKillAssociate terminates an associate that has exceeded its timeout and grace period
 func (d *Daemon) forceKillAssociate(assoc *registry.AgentRecord, reason string) {
-	d.logger.Printf("Patrol: force killing associate '%s' - %s\n", assoc.ID, reason)
+	d.logger.Printf("Patrol: force killing associate '%s' - %s\n", assoc.Label(), reason)
+
+	// Keep a handle on the in-memory agent for the post-mortem before it is killed
+	a, hasAgent := d.spawner.Get(assoc.ID)
 
 	// Kill in spawner (if it has a process)
 	if err := d.spawner.Kill(assoc.ID); err != nil {
 		// Ignore errors - process might already be dead
-		d.logger.Printf("Patrol: warning - spawner.Kill failed for '%s': %v\n", assoc.ID, err)
+		d.logger.Printf("Patrol: warning - spawner.Kill failed for '%s': %v\n", assoc.Label(), err)
 	}
 
 	// Update registry status to timed_out
-	d.registry.UpdateStatus(assoc.ID, "timed_out")
+	d.registry.UpdateStatus(assoc.ID, registry.StatusTimedOut)
+
+	// File a post-mortem so the timeout becomes trackable work
+	if d.beadStore != nil {
+		failure := postmortem.Failure{
+			AgentID:   assoc.ID,
+			AgentName: assoc.Name,
+			Turf:      assoc.Turf,
+			BeadID:    assoc.BeadID,
+			Task:      assoc.Task,
+			Reason:    "timed out",
+			Error:     reason,
+			SessionID: assoc.SessionID,
+		}
+		if hasAgent {
+			failure.Output = a.RecentOutput(postmortem.DefaultOutputLines)
+			failure.WorkDir = a.WorkDir
+			if failure.SessionID == "" {
+				failure.SessionID = a.SessionID
+			}
+		}
+		if pm, err := postmortem.CreateBead(d.beadStore, failure, "daemon"); err != nil {
+			d.logger.Printf("Patrol: failed to file post-mortem for associate '%s': %v\n", assoc.Label(), err)
+		} else {
+			d.logger.Printf("Patrol: filed post-mortem %s for associate '%s'\n", pm.ID, assoc.Label())
+		}
+	}
 
 	// Clean up nudge tracking
 	d.mu.Lock()
 	delete(d.nudgedAt, assoc.ID)
 	d.mu.Unlock()
 
-	d.logger.Printf("Patrol: associate '%s' terminated due to timeout\n", assoc.ID)
+	d.publish(events.AgentStopped{Agent: assoc.Label(), BeadID: assoc.BeadID, Turf: assoc.Turf, Reason: reason})
 }
 
 // AssociateCleanupTTL is how long after completion before an associate is removed from registry
@@ -553,7 +979,7 @@ func (d *Daemon) cleanupStaleAssociates() {
 
 	for _, assoc := range associates {
 		// Only clean up terminal states
-		if assoc.Status != "completed" && assoc.Status != "failed" && assoc.Status != "timed_out" {
+		if !assoc.Status.Terminal() {
 			continue
 		}
 
@@ -568,11 +994,15 @@ func (d *Daemon) cleanupStaleAssociates() {
 
 		timeSinceCompletion := now.Sub(completedTime)
 		if timeSinceCompletion > AssociateCleanupTTL {
+			if d.planned(PlanStep{Action: PlanCleanup, Agent: assoc.Label(), BeadID: assoc.BeadID,
+				Detail: fmt.Sprintf("%s %s ago", assoc.Status, timeSinceCompletion.Round(time.Second))}) {
+				continue
+			}
 			d.logger.Printf("Patrol: cleaning up stale associate '%s' (completed %v ago)\n",
-				assoc.ID, timeSinceCompletion.Round(time.Second))
+				assoc.Label(), timeSinceCompletion.Round(time.Second))
 
 			if err := d.registry.Unregister(assoc.ID); err != nil {
-				d.logger.Printf("Patrol: failed to unregister stale associate '%s': %v\n", assoc.ID, err)
+				d.logger.Printf("Patrol: failed to unregister stale associate '%s': %v\n", assoc.Label(), err)
 			}
 		}
 	}
@@ -580,28 +1010,15 @@ func (d *Daemon) cleanupStaleAssociates() {
 
 // spawnSoldatiAgent creates a Claude instance for a soldati
 func (d *Daemon) spawnSoldatiAgent(name string) error {
-	// Use current working directory as default work dir
-	workDir, err := os.Getwd()
-	if err != nil {
-		workDir = d.mobDir
-	}
+	// Run in the soldati's own turf, never wherever the daemon was started
+	workDir := d.soldatiHomeDir(name)
 
-	// Generate MCP config for tool access
-	mcpConfigPath, err := mcp.GenerateMCPConfig(d.mobDir)
-	if err != nil {
-		d.logger.Printf("Warning: failed to generate MCP config: %v", err)
-	}
-
-	// Spawn the agent with system prompt
-	a, err := d.spawner.SpawnWithOptions(agent.SpawnOptions{
-		Type:         agent.AgentTypeSoldati,
-		Name:         name,
-		Turf:         "", // Will be assigned when work is given
-		WorkDir:      workDir,
-		SystemPrompt: agent.SoldatiSystemPrompt,
-		MCPConfig:    mcpConfigPath,
-		Model:        "sonnet", // Default to sonnet for cost efficiency
-	})
+	// Soldati added for a turf's backlog start out on that turf; the rest
+	// are given their turf with their work
+	turf := d.scaledTurf(name)
+
+	// Spawn the agent with system prompt and tools scoped to that turf
+	a, err := d.spawner.SpawnWithOptions(d.soldatiSpawnOptions(name, turf, workDir, d.mcpConfig(d.toolTurf(name, ""))))
 	if err != nil {
 		return fmt.Errorf("failed to spawn agent: %w", err)
 	}
@@ -612,10 +1029,14 @@ func (d *Daemon) spawnSoldatiAgent(name string) error {
 		Type:      "soldati",
 		Name:      name,
 		Turf:      d.mobDir, // Default turf to mob directory, updated when work is assigned
-		Status:    "idle",
+		WorkDir:   workDir,
+		Status:    registry.StatusIdle,
 		StartedAt: a.StartedAt,
 		LastPing:  time.Now(),
 	}
+	if turf != "" {
+		record.Turf = turf
+	}
 	if err := d.registry.Register(record); err != nil {
 		return fmt.Errorf("failed to register agent: %w", err)
 	}
@@ -631,6 +1052,7 @@ func (d *Daemon) spawnSoldatiAgent(name string) error {
 	}
 
 	d.logger.Printf("Patrol: soldati '%s' is now active (ID: %s)\n", name, a.ID)
+	d.publish(events.AgentSpawned{Agent: name, Turf: a.Turf})
 	return nil
 }
 
@@ -671,62 +1093,245 @@ func (d *Daemon) processHooks(name string, a *agent.Agent, hookChan <-chan *hook
 	for h := range hookChan {
 		switch h.Type {
 		case hook.HookTypeAssign:
-			d.handleAssignment(name, a, h, mgr)
+			d.handleAssignment(name, a, h, mgr, "")
 		case hook.HookTypeNudge:
 			d.logger.Printf("Hook: nudge received for soldati '%s'\n", name)
 			// Nudge just wakes up the agent - no action needed with per-call model
 		case hook.HookTypeAbort:
 			d.logger.Printf("Hook: abort received for soldati '%s'\n", name)
-			// With per-call model, we can't abort mid-execution
-			// Just clear the hook and mark idle
+			// An abort of a bead the soldati has since moved on from leaves
+			// its current work alone
+			if !d.abortApplies(name, h.BeadID) {
+				d.logger.Printf("Hook: ignoring abort of bead %s, soldati '%s' is on other work\n", h.BeadID, name)
+				continue
+			}
+			// Kill the in-flight call, then clear the hook and mark idle
+			if d.cancelWork(name) {
+				d.logger.Printf("Hook: cancelled in-flight work for soldati '%s'\n", name)
+			}
+			if d.pool.Cancel(name) {
+				d.logger.Printf("Hook: dropped queued work for soldati '%s'\n", name)
+			}
 			mgr.Clear()
-			d.registry.UpdateStatus(a.ID, "idle")
+			d.registry.UpdateStatus(a.ID, registry.StatusIdle)
+			d.registry.UpdateTask(a.ID, "")
 		case hook.HookTypePause:
 			d.logger.Printf("Hook: pause received for soldati '%s'\n", name)
-			d.registry.UpdateStatus(a.ID, "paused")
+			d.registry.UpdateStatus(a.ID, registry.StatusPaused)
 		case hook.HookTypeResume:
 			d.logger.Printf("Hook: resume received for soldati '%s'\n", name)
-			d.registry.UpdateStatus(a.ID, "idle")
+			d.registry.UpdateStatus(a.ID, registry.StatusIdle)
+		case hook.HookTypeReload:
+			d.logger.Printf("Hook: reload received for soldati '%s'\n", name)
+			d.requestReload(name)
 		}
 	}
 }
 
-// handleAssignment processes a work assignment for a soldati
-func (d *Daemon) handleAssignment(name string, a *agent.Agent, h *hook.Hook, mgr *hook.Manager) {
-	d.logger.Printf("Hook: work assignment for soldati '%s': bead=%s\n", name, h.BeadID)
-
+// handleAssignment processes a work assignment for a soldati, starting it
+// once the agent pool has room. resume is set when the assignment was cut
+// short, by a restart or a stalled call, and the soldati's session is picking
+// it back up; it says why.
+func (d *Daemon) handleAssignment(name string, a *agent.Agent, h *hook.Hook, mgr *hook.Manager, resume string) {
 	// Update status to working
-	d.registry.UpdateStatus(a.ID, "active")
+	d.registry.UpdateStatus(a.ID, registry.StatusActive)
 	d.registry.UpdateTask(a.ID, h.Message)
 
-	// Execute the work via Chat
-	go func() {
-		// Build the task message
-		taskMsg := h.Message
-		if h.BeadID != "" {
-			taskMsg = fmt.Sprintf("[Bead %s] %s", h.BeadID, h.Message)
+	// Give the assignment its own context so an abort hook can cancel it,
+	// even while it waits in the pool's queue
+	workCtx, cancel := context.WithCancel(d.ctx)
+	work := &assignmentWork{cancel: cancel, beadID: h.BeadID, resume: resume}
+	d.mu.Lock()
+	d.work[name] = work
+	d.mu.Unlock()
+
+	turf := d.assignmentTurf(a, h.BeadID)
+	start := func() { d.runAssignment(workCtx, name, a, h, mgr, work) }
+	if !d.pool.Submit(name, turf, h.BeadID, start) {
+		d.logger.Printf("Pool: at capacity, queued bead %s for soldati '%s' (turf %s)\n", h.BeadID, name, turf)
+	}
+}
+
+// runAssignment does a soldati's assigned work once the agent pool starts it
+func (d *Daemon) runAssignment(workCtx context.Context, name string, a *agent.Agent, h *hook.Hook, mgr *hook.Manager, work *assignmentWork) {
+	// Runs last, once the pool slot and work entry are released
+	var then func()
+	defer func() {
+		if then != nil {
+			then()
 		}
+	}()
+	defer d.pool.Release(name)
+	defer d.finishWork(name, work)
+	if workCtx.Err() != nil {
+		return // aborted or shutting down while it waited
+	}
+	d.publish(events.WorkStarted{Agent: name, BeadID: h.BeadID})
+
+	// Run in the bead's worktree or turf, before onboarding checks the session
+	d.pinWorkDir(name, a, h.BeadID)
+	d.pinMCPConfig(name, a, h.BeadID)
+
+	// Build the task message
+	taskMsg := h.Message
+	if h.BeadID != "" {
+		taskMsg = d.withOnboarding(name, a, h.BeadID, fmt.Sprintf("[Bead %s] %s", h.BeadID, h.Message))
+		d.routeModel(a, h.BeadID)
+	}
+	resume := work.resume
+	if p, _ := d.parked.Take(h.BeadID); p != nil {
+		// Parked work picks up in the session it was parked in
+		if p.SessionID != "" {
+			a.SessionID = p.SessionID
+		}
+		if resume == "" {
+			resume = fmt.Sprintf(parkedNote, p.For)
+		}
+	}
+	if resume != "" && a.SessionID != "" {
+		taskMsg = resume + "\n\n" + taskMsg
+	}
 
-		d.logger.Printf("Soldati '%s' starting work: %s\n", name, truncateMessage(taskMsg, 80))
+	d.logger.Printf("Soldati '%s' starting work: %s\n", name, truncateMessage(taskMsg, 80))
 
-		// Call the agent
-		resp, err := a.Chat(taskMsg)
-		if err != nil {
-			d.logger.Printf("Soldati '%s' error: %v\n", name, err)
-			d.registry.UpdateStatus(a.ID, "error")
+	// Call the agent, recording its session as soon as it is known so
+	// `mob bead chat` can resume the conversation mid-task
+	sessionRecorded := false
+	a.SetOnProcess(func(pid int) { d.registry.UpdatePID(a.ID, pid) })
+	resp, err := a.ChatStreamContext(workCtx, taskMsg, func(block agent.ChatContentBlock) {
+		if !sessionRecorded && a.SessionID != "" {
+			d.registry.UpdateSession(a.ID, a.SessionID)
+			sessionRecorded = true
+		}
+	})
+	if errors.Is(err, agent.ErrAborted) {
+		switch d.abortOf(work) {
+		case abortResume:
+			d.logger.Printf("Stuck: resuming soldati '%s' after its call went silent\n", name)
+			then = func() { d.handleAssignment(name, a, h, mgr, stalledNote) }
 			return
+		case abortPark:
+			urgent := d.parkAssignment(name, a, h, mgr, work)
+			then = func() { d.assignUrgent(name, a, urgent) }
+			return
+		case abortFail:
+			err = errStalled // handled as a failed attempt below
+		default:
+			d.logger.Printf("Soldati '%s' work aborted\n", name)
+			return
+		}
+	}
+	if err != nil {
+		d.publish(events.AgentFailed{Agent: name, AgentID: a.ID, BeadID: h.BeadID, Err: err})
+		d.registry.UpdateStatus(a.ID, registry.StatusError)
+		if h.BeadID != "" {
+			// The bead waits in the failure queue instead of on this hook
+			d.failAssignment(name, h.BeadID, err)
+			mgr.Clear()
+			d.registry.UpdateTask(a.ID, "")
 		}
+		return
+	}
+	if !sessionRecorded && a.SessionID != "" {
+		d.registry.UpdateSession(a.ID, a.SessionID)
+	}
+
+	d.recordUsage(name, a, h.BeadID, resp)
+	if h.BeadID != "" {
+		d.recordBeadCost(h.BeadID, resp.TotalCost)
+		if err := d.failures.Resolve(h.BeadID); err != nil {
+			d.logger.Printf("Warning: failed to clear bead %s from the failure queue: %v\n", h.BeadID, err)
+		}
+	}
+	d.stats.workCompleted(time.Now(), resp.TotalCost)
 
-		// Log completion
-		responseText := resp.GetText()
-		d.logger.Printf("Soldati '%s' completed work. Response: %s\n", name, truncateMessage(responseText, 200))
+	// Log completion
+	responseText := resp.GetText()
+	d.logger.Printf("Soldati '%s' completed work. Response: %s\n", name, truncateMessage(responseText, 200))
 
-		// Clear the hook and mark idle
-		mgr.Clear()
-		d.registry.UpdateStatus(a.ID, "idle")
-		d.registry.UpdateTask(a.ID, "")
-		d.registry.Ping(a.ID)
-	}()
+	// Clear the hook and mark idle
+	mgr.Clear()
+	d.registry.UpdateStatus(a.ID, registry.StatusIdle)
+	d.registry.UpdateTask(a.ID, "")
+	d.registry.Ping(a.ID)
+}
+
+// assignmentWork tracks a soldati's in-flight assignment so it can be aborted
+type assignmentWork struct {
+	cancel  context.CancelFunc
+	beadID  string      // the bead assigned, empty for a message without one
+	resume  string      // why an interrupted assignment is being picked back up, empty for a fresh one
+	abort   abortAction // what to do once an aborted call returns; guarded by d.mu
+	parkFor string      // the urgent bead an assignment is parked for; guarded by d.mu
+}
+
+// abortAction is what the daemon wants done with an assignment whose call it
+// aborts
+type abortAction int
+
+const (
+	abortNone   abortAction = iota // aborted by hand; just stop
+	abortResume                    // resume the assignment in the same session
+	abortFail                      // give the bead to the failure queue
+	abortPark                      // set the bead aside for more urgent work
+)
+
+// abortWork kills a soldati's in-flight call, telling runAssignment what to
+// do once it returns. It reports whether there was a call to abort.
+func (d *Daemon) abortWork(name string, a *agent.Agent, action abortAction) bool {
+	d.mu.Lock()
+	work := d.work[name]
+	if work != nil {
+		work.abort = action
+	}
+	d.mu.Unlock()
+	if work == nil {
+		return false
+	}
+	return a.Abort()
+}
+
+// abortOf returns what the daemon wanted done with an aborted call
+func (d *Daemon) abortOf(work *assignmentWork) abortAction {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return work.abort
+}
+
+// cancelWork cancels a soldati's in-flight assignment, reporting whether one was running
+func (d *Daemon) cancelWork(name string) bool {
+	d.mu.Lock()
+	work, ok := d.work[name]
+	delete(d.work, name)
+	d.mu.Unlock()
+
+	if ok {
+		work.cancel()
+	}
+	return ok
+}
+
+// abortApplies reports whether an abort of beadID applies to a soldati: it has
+// no assignment, or its assignment is that bead. An abort naming no bead
+// applies to whatever it is doing.
+func (d *Daemon) abortApplies(name, beadID string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	work := d.work[name]
+	return beadID == "" || work == nil || work.beadID == beadID
+}
+
+// finishWork releases an assignment's context once its goroutine is done
+func (d *Daemon) finishWork(name string, work *assignmentWork) {
+	work.cancel()
+	d.mu.Lock()
+	if d.work[name] == work {
+		delete(d.work, name)
+	}
+	d.mu.Unlock()
+
+	// Apply definition edits and reloads that waited for the work
+	d.checkDefinition(name)
 }
 
 // truncateMessage truncates a message for logging
@@ -802,24 +1407,18 @@ func (d *Daemon) resolveTurfPath(turfName string) string {
 
 // respawnSoldati recreates an agent process for an existing registry entry
 func (d *Daemon) respawnSoldati(name string, record *registry.AgentRecord) error {
-	workDir := d.resolveTurfPath(record.Turf)
-
-	// Generate MCP config for tool access
-	mcpConfigPath, err := mcp.GenerateMCPConfig(d.mobDir)
-	if err != nil {
-		d.logger.Printf("Warning: failed to generate MCP config: %v", err)
+	// Pick up where it was working if that directory is still around
+	workDir := record.WorkDir
+	if workDir == "" || !isDir(workDir) {
+		workDir = d.soldatiHomeDir(name)
 	}
 
+	// Scope its tools to the bead it was working on, not the registry's
+	// turf, which for configured soldati is the mob directory
+	mcpConfigPath := d.mcpConfig(d.hookedToolTurf(name))
+
 	// Spawn a new agent process
-	a, err := d.spawner.SpawnWithOptions(agent.SpawnOptions{
-		Type:         agent.AgentTypeSoldati,
-		Name:         name,
-		Turf:         record.Turf,
-		WorkDir:      workDir,
-		SystemPrompt: agent.SoldatiSystemPrompt,
-		MCPConfig:    mcpConfigPath,
-		Model:        "sonnet", // Default to sonnet for cost efficiency
-	})
+	a, err := d.spawner.SpawnWithOptions(d.soldatiSpawnOptions(name, record.Turf, workDir, mcpConfigPath))
 	if err != nil {
 		return fmt.Errorf("failed to spawn agent: %w", err)
 	}
@@ -827,7 +1426,8 @@ func (d *Daemon) respawnSoldati(name string, record *registry.AgentRecord) error
 	// Update registry with new process info (keep existing ID for continuity)
 	record.StartedAt = a.StartedAt
 	record.LastPing = time.Now()
-	record.Status = "idle"
+	record.Status = registry.StatusIdle
+	record.WorkDir = workDir
 	if err := d.registry.Register(record); err != nil {
 		return fmt.Errorf("failed to update registry: %w", err)
 	}
@@ -843,6 +1443,7 @@ func (d *Daemon) respawnSoldati(name string, record *registry.AgentRecord) error
 	}
 
 	d.logger.Printf("Patrol: respawned soldati '%s' (ID: %s)\n", name, record.ID)
+	d.publish(events.AgentSpawned{Agent: name, Turf: record.Turf, Respawned: true})
 	return nil
 }
 
@@ -902,3 +1503,13 @@ func (d *Daemon) GetHookManager(name string) (*hook.Manager, error) {
 	hookDir := filepath.Join(d.mobDir, ".mob", "soldati")
 	return hook.NewManager(hookDir, name)
 }
+
+// systemPrompt renders the named agent prompt from ~/mob/prompts, falling
+// back to the compiled-in one if the template is broken
+func (d *Daemon) systemPrompt(name string, vars agent.PromptVars) string {
+	prompt, err := agent.SystemPrompt(d.mobDir, name, vars)
+	if err != nil {
+		d.logger.Printf("Warning: %v\n", err)
+	}
+	return prompt
+}
@@ -1,11 +1,15 @@
 package daemon
 
 import (
+	"errors"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
 )
 
 func TestPIDFile(t *testing.T) {
@@ -153,3 +157,43 @@ func TestDaemonStatus(t *testing.T) {
 		t.Errorf("expected PID 0, got %d", pid)
 	}
 }
+
+func TestAssignBeadClaimsAFreshCopy(t *testing.T) {
+	d, _, bead := newTurfTestDaemon(t)
+	hookDir := filepath.Join(d.mobDir, ".mob", "soldati")
+
+	// A comment lands after patrol listed the bead
+	if err := d.beadStore.AddComment(bead.ID, "human", "Keep the old route working"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.assignBead("vinnie", bead.ID); err != nil {
+		t.Fatalf("assignBead: %v", err)
+	}
+	got, _ := d.beadStore.Get(bead.ID)
+	if got.Status != models.BeadStatusInProgress || got.Assignee != "vinnie" {
+		t.Fatalf("bead = %s/%s, want in progress on vinnie", got.Status, got.Assignee)
+	}
+	vinnie, err := hook.NewManager(hookDir, "vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, _ := vinnie.Read(); h == nil || h.BeadID != bead.ID {
+		t.Fatalf("vinnie's hook = %+v, want %s", h, bead.ID)
+	}
+
+	// A second soldati can't be handed the bead vinnie already took
+	err = d.assignBead("sal", bead.ID)
+	if !errors.Is(err, errBeadMoved) {
+		t.Fatalf("err = %v, want the claimed bead refused", err)
+	}
+	sal, err := hook.NewManager(hookDir, "sal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, _ := sal.Read(); h != nil {
+		t.Errorf("sal's hook = %+v, want nothing written for a refused bead", h)
+	}
+	if got, _ := d.beadStore.Get(bead.ID); got.Assignee != "vinnie" {
+		t.Errorf("assignee = %q, want the bead left on vinnie", got.Assignee)
+	}
+}
@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/events"
+)
+
+// Events returns the daemon's event bus, for in-process subscribers
+func (d *Daemon) Events() *events.Bus {
+	return d.events
+}
+
+// publish hands an event to the daemon's subscribers
+func (d *Daemon) publish(e events.Event) {
+	d.events.Publish(e)
+}
+
+// subscribe wires up the daemon's own subscribers: the log, the activity
+// feed, the self-metrics and the notification backends
+func (d *Daemon) subscribe() {
+	d.events.Subscribe(d.logEvent)
+	d.events.Subscribe(d.recordEvent)
+	d.events.Subscribe(d.stats.countEvent)
+	d.events.Subscribe(d.notifyEvent,
+		events.TypeAgentStuck, events.TypeAgentFailed, events.TypeModelDegraded, events.TypeModelRecovered,
+		events.TypeRetriesExhausted)
+}
+
+func (d *Daemon) logEvent(e events.Event) {
+	d.logger.Printf("Event %s: %s\n", e.Type(), e.Message())
+}
+
+func (d *Daemon) recordEvent(e events.Event) {
+	if a, ok := events.Activity(e); ok {
+		d.recordActivity(a)
+	}
+}
+
+// notifyEvent sends the events people should hear about to the
+// notification backends
+func (d *Daemon) notifyEvent(e events.Event) {
+	if d.notifier == nil {
+		return
+	}
+
+	var err error
+	switch e := e.(type) {
+	case events.AgentStuck:
+		err = d.notifier.NotifyAgentStuck(e.Agent, e.AgentID, e.Task)
+	case events.AgentFailed:
+		err = d.notifier.NotifyAgentError(e.Agent, e.AgentID, e.Err.Error())
+	case events.ModelDegraded:
+		err = d.notifier.NotifyModelDegraded(e.Model, e.Fallback, e.LastError)
+	case events.ModelRecovered:
+		err = d.notifier.NotifyModelRecovered(e.Model)
+	case events.RetriesExhausted:
+		err = d.notifier.NotifyInfo("Bead Out of Retries", e.Message())
+	}
+	if err != nil {
+		d.logger.Printf("Events: notify %s: %v\n", e.Type(), err)
+	}
+}
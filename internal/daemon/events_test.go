@@ -0,0 +1,45 @@
+package daemon
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestPublishLogsAndRecordsActivity(t *testing.T) {
+	mobDir := t.TempDir()
+	var buf bytes.Buffer
+	d := New(mobDir, log.New(&buf, "", 0))
+	feed, err := storage.NewActivityStore(storage.ActivityDir(mobDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.activity = feed
+
+	var seen []events.Type
+	d.Events().Subscribe(func(e events.Event) { seen = append(seen, e.Type()) })
+
+	d.publish(events.AgentSpawned{Agent: "vinnie", Turf: "api"})
+	d.publish(events.BeadAssigned{BeadID: "bd-a1b2", Agent: "vinnie"})
+
+	if !strings.Contains(buf.String(), "Event agent_spawned: Soldati vinnie spawned") {
+		t.Errorf("spawn not logged: %q", buf.String())
+	}
+	if len(seen) != 2 {
+		t.Errorf("outside subscriber saw %v, want both events", seen)
+	}
+
+	// Assignments reach the feed through the bead store, not the bus
+	entries, err := feed.List(storage.ActivityFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Type != models.ActivityAgentSpawned || entries[0].Agent != "vinnie" || entries[0].Turf != "api" {
+		t.Errorf("feed = %+v, want just the spawn", entries)
+	}
+}
@@ -0,0 +1,67 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/failover"
+)
+
+// setupFailover routes soldati calls through the shared model circuit
+// breaker. Outages reported before a restart are not reported again.
+func (d *Daemon) setupFailover() {
+	d.failover = failover.New(failover.Path(d.mobDir), d.cfg.Failover)
+	d.spawner.SetModelBreaker(d.failover)
+
+	d.degraded = make(map[string]bool)
+	defer func() { d.stats.setDegraded(d.degraded) }()
+	circuits, err := d.failover.Circuits()
+	if err != nil {
+		d.logger.Printf("Failover: %v\n", err)
+		return
+	}
+	for _, c := range circuits {
+		if c.NotifiedAt != nil {
+			d.degraded[c.Model] = true
+		}
+	}
+}
+
+// notifyFailover reports models whose circuit has opened, from any mob
+// process, and models that have since recovered
+func (d *Daemon) notifyFailover() {
+	if d.failover == nil {
+		return
+	}
+	circuits, err := d.failover.Circuits()
+	if err != nil {
+		d.logger.Printf("Failover: %v\n", err)
+		return
+	}
+
+	now := time.Now()
+	open := make(map[string]bool)
+	for _, c := range circuits {
+		if c.State(now) == failover.Closed {
+			continue
+		}
+		open[c.Model] = true
+		if d.degraded[c.Model] {
+			continue
+		}
+		d.degraded[c.Model] = true
+
+		d.publish(events.ModelDegraded{Model: c.Model, Fallback: d.failover.Secondary(), Failures: c.Failures, LastError: c.LastError})
+		if err := d.failover.MarkNotified(c.Model, now); err != nil {
+			d.logger.Printf("Failover: %v\n", err)
+		}
+	}
+
+	for model := range d.degraded {
+		if open[model] {
+			continue
+		}
+		delete(d.degraded, model)
+		d.publish(events.ModelRecovered{Model: model})
+	}
+}
@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/failures"
+	"github.com/gabe/mob/internal/models"
+)
+
+// setupFailures applies the config's retry policy to the failure queue
+func (d *Daemon) setupFailures() {
+	d.failures.SetPolicy(failures.PolicyFromConfig(d.cfg.Failures))
+}
+
+// failAssignment puts a bead whose assignment failed in the failure queue,
+// blocking it until its retry is due or, once it is out of attempts, until
+// someone runs `mob failures retry`
+func (d *Daemon) failAssignment(name, beadID string, cause error) {
+	if d.beadStore == nil {
+		return
+	}
+	entry, err := d.failures.Fail(d.beadStore, failures.Failure{BeadID: beadID, Agent: name, Err: cause}, time.Now())
+	if entry == nil {
+		d.logger.Printf("Failures: failed to record failure of bead %s: %v\n", beadID, err)
+		return
+	}
+	if err != nil {
+		d.logger.Printf("Warning: bead %s: %v\n", beadID, err)
+	}
+	d.logger.Printf("Failures: bead %s failed on '%s' (attempt %d, %s)\n", beadID, name, entry.Attempts, entry.Status)
+
+	if entry.Status == failures.Dead {
+		d.publish(events.RetriesExhausted{BeadID: beadID, Turf: entry.Turf, Attempts: entry.Attempts, LastError: entry.LastError})
+	}
+}
+
+// retryFailures reopens failed beads whose retry is due so auto-assignment
+// picks them up again
+func (d *Daemon) retryFailures(now time.Time) {
+	if d.beadStore == nil {
+		return
+	}
+	due, err := d.failures.Due(now)
+	if err != nil {
+		d.logger.Printf("Failures: failed to read the failure queue: %v\n", err)
+		return
+	}
+	for _, e := range due {
+		if d.planned(PlanStep{Action: PlanRetry, BeadID: e.BeadID, Detail: fmt.Sprintf("after %d failed attempt(s)", e.Attempts)}) {
+			continue
+		}
+		if err := d.failures.Retry(d.beadStore, e.BeadID); err != nil {
+			if errors.Is(err, failures.ErrClosed) || errors.Is(err, errkind.NotFound) {
+				d.failures.Resolve(e.BeadID) // finished or removed some other way
+				continue
+			}
+			d.logger.Printf("Failures: failed to retry bead %s: %v\n", e.BeadID, err)
+			continue
+		}
+		d.logger.Printf("Failures: retrying bead %s after %d failed attempt(s)\n", e.BeadID, e.Attempts)
+	}
+}
+
+// avoidedBy drops the beads agent has already failed once they are due to go
+// to someone else
+func avoidedBy(beads []*models.Bead, avoid map[string][]string, agent string) []*models.Bead {
+	if len(avoid) == 0 {
+		return beads
+	}
+	var kept []*models.Bead
+	for _, b := range beads {
+		if !slices.Contains(avoid[b.ID], agent) {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,53 @@
+package daemon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/failures"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestRetryFailuresReassigns(t *testing.T) {
+	d, _, bead := newTurfTestDaemon(t)
+	d.cfg.Failures = config.FailuresConfig{MaxAttempts: 5, Backoff: "1m", ReassignAfter: 2}
+	d.setupFailures()
+
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		d.failAssignment("vinnie", bead.ID, errors.New("boom"))
+		if got, _ := d.beadStore.Get(bead.ID); got.Status != models.BeadStatusBlocked {
+			t.Fatalf("expected the failed bead blocked, got %s", got.Status)
+		}
+		d.retryFailures(now.Add(time.Duration(i+1) * time.Hour))
+		if got, _ := d.beadStore.Get(bead.ID); got.Status != models.BeadStatusOpen {
+			t.Fatalf("expected the bead reopened once its retry was due, got %s", got.Status)
+		}
+	}
+
+	avoid, err := d.failures.Avoid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready, _ := d.beadStore.ListReady("backend")
+	if got := avoidedBy(ready, avoid, "vinnie"); len(got) != 0 {
+		t.Errorf("expected vinnie passed over after failing twice, got %v", got)
+	}
+	if got := avoidedBy(ready, avoid, "sal"); len(got) != 1 {
+		t.Errorf("expected sal offered the bead, got %v", got)
+	}
+
+	// Finishing the bead some other way drops it from the queue
+	d.failAssignment("sal", bead.ID, errors.New("boom"))
+	got, _ := d.beadStore.Get(bead.ID)
+	got.Status = models.BeadStatusClosed
+	if _, err := d.beadStore.Update(got); err != nil {
+		t.Fatal(err)
+	}
+	d.retryFailures(now.Add(24 * time.Hour))
+	if _, err := d.failures.Get(bead.ID); !errors.Is(err, failures.ErrNotFound) {
+		t.Errorf("expected the closed bead resolved, got %v", err)
+	}
+}
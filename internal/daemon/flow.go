@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+)
+
+// selectNextBead picks the bead an idle agent should take from a turf's ready queue.
+// Ready beads are expected in priority order (as returned by ListReady). Expedite
+// beads always win and ignore the WIP limit; standard beads are only handed out
+// while the turf is under its limit.
+func selectNextBead(ready []*models.Bead, inProgress int, flow config.FlowConfig, turf string) *models.Bead {
+	if len(ready) == 0 {
+		return nil
+	}
+
+	// Expedite lane first - these preempt normal assignment order
+	for _, b := range ready {
+		if flow.IsExpedite(b.Priority) {
+			return b
+		}
+	}
+
+	limit := flow.WIPLimit(turf)
+	if limit > 0 && inProgress >= limit {
+		return nil
+	}
+
+	return ready[0]
+}
+
+// countInProgress returns the number of in_progress beads per turf
+func countInProgress(beads []*models.Bead) map[string]int {
+	counts := make(map[string]int)
+	for _, b := range beads {
+		if b.Status == models.BeadStatusInProgress {
+			counts[b.Turf]++
+		}
+	}
+	return counts
+}
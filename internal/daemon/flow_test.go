@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestSelectNextBead_RespectsWIPLimit(t *testing.T) {
+	flow := config.FlowConfig{DefaultWIPLimit: 2, ExpeditePriority: 0}
+	ready := []*models.Bead{
+		{ID: "bd-0002", Priority: 2},
+		{ID: "bd-0003", Priority: 3},
+	}
+
+	if got := selectNextBead(ready, 1, flow, "api"); got == nil || got.ID != "bd-0002" {
+		t.Fatalf("expected bd-0002 under WIP limit, got %v", got)
+	}
+	if got := selectNextBead(ready, 2, flow, "api"); got != nil {
+		t.Fatalf("expected nothing at WIP limit, got %s", got.ID)
+	}
+}
+
+func TestSelectNextBead_ExpediteBypassesLimit(t *testing.T) {
+	flow := config.FlowConfig{DefaultWIPLimit: 1, ExpeditePriority: 0}
+	ready := []*models.Bead{
+		{ID: "bd-0001", Priority: 1},
+		{ID: "bd-0000", Priority: 0},
+	}
+
+	got := selectNextBead(ready, 5, flow, "api")
+	if got == nil || got.ID != "bd-0000" {
+		t.Fatalf("expected expedite bead bd-0000, got %v", got)
+	}
+}
+
+func TestSelectNextBead_ExpediteDisabled(t *testing.T) {
+	flow := config.FlowConfig{DefaultWIPLimit: 1, ExpeditePriority: config.ExpediteDisabled}
+	ready := []*models.Bead{{ID: "bd-0000", Priority: 0}}
+
+	if got := selectNextBead(ready, 1, flow, "api"); got != nil {
+		t.Fatalf("expected P0 held at the WIP limit with no expedite lane, got %s", got.ID)
+	}
+}
+
+func TestSelectNextBead_PerTurfOverride(t *testing.T) {
+	flow := config.FlowConfig{
+		DefaultWIPLimit:  1,
+		WIPLimits:        map[string]int{"web": 0},
+		ExpeditePriority: 0,
+	}
+	ready := []*models.Bead{{ID: "bd-0002", Priority: 2}}
+
+	if got := selectNextBead(ready, 10, flow, "web"); got == nil {
+		t.Fatal("expected unlimited turf to assign")
+	}
+	if got := selectNextBead(ready, 1, flow, "api"); got != nil {
+		t.Fatal("expected default limit to block")
+	}
+}
+
+func TestCountInProgress(t *testing.T) {
+	counts := countInProgress([]*models.Bead{
+		{Turf: "api", Status: models.BeadStatusInProgress},
+		{Turf: "api", Status: models.BeadStatusInProgress},
+		{Turf: "web", Status: models.BeadStatusOpen},
+	})
+	if counts["api"] != 2 {
+		t.Errorf("expected 2 in progress for api, got %d", counts["api"])
+	}
+	if counts["web"] != 0 {
+		t.Errorf("expected 0 in progress for web, got %d", counts["web"])
+	}
+}
@@ -0,0 +1,209 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/version"
+)
+
+// Health states
+const (
+	HealthOK       = "ok"       // patrolling on schedule
+	HealthStarting = "starting" // no patrol has finished yet
+	HealthStalled  = "stalled"  // patrols stopped finishing
+)
+
+// stalledAfter is how many patrol intervals may pass without one finishing
+// before the daemon reports itself stalled
+const stalledAfter = 3
+
+// Health is what /healthz reports
+type Health struct {
+	Status         string    `json:"status"`
+	Version        string    `json:"version"`
+	PID            int       `json:"pid"`
+	StartedAt      time.Time `json:"started_at"`
+	LastPatrol     time.Time `json:"last_patrol,omitempty"`
+	PatrolInterval string    `json:"patrol_interval"`
+	Problems       []string  `json:"problems,omitempty"`
+}
+
+// SocketPath returns the unix socket the daemon serves /healthz and
+// /metrics on
+func SocketPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "daemon.sock")
+}
+
+// health checks that patrols are still finishing on schedule
+func (d *Daemon) health(now time.Time) Health {
+	m := d.stats
+	m.mu.Lock()
+	h := Health{
+		Status:         HealthOK,
+		Version:        version.Version,
+		PID:            os.Getpid(),
+		StartedAt:      m.started,
+		LastPatrol:     m.lastPatrol,
+		PatrolInterval: m.interval.String(),
+	}
+	limit := stalledAfter * m.interval
+	patrolling := m.patrolling
+	degraded := len(m.degraded)
+	m.mu.Unlock()
+
+	switch {
+	case !patrolling.IsZero() && now.Sub(patrolling) > limit:
+		h.Status = HealthStalled
+		h.Problems = append(h.Problems, fmt.Sprintf("patrol running for %s", now.Sub(patrolling).Round(time.Second)))
+	case h.LastPatrol.IsZero() && now.Sub(h.StartedAt) > limit:
+		h.Status = HealthStalled
+		h.Problems = append(h.Problems, "no patrol has finished since the daemon started")
+	case h.LastPatrol.IsZero():
+		h.Status = HealthStarting
+	case now.Sub(h.LastPatrol) > limit:
+		h.Status = HealthStalled
+		h.Problems = append(h.Problems, fmt.Sprintf("last patrol finished %s ago", now.Sub(h.LastPatrol).Round(time.Second)))
+	}
+	if degraded > 0 {
+		h.Problems = append(h.Problems, fmt.Sprintf("%d model(s) degraded", degraded))
+	}
+	return h
+}
+
+// gauges reads the figures /metrics reports from the daemon's state
+func (d *Daemon) gauges(healthy bool) gauges {
+	g := gauges{healthy: healthy}
+
+	snap := d.pool.Snapshot()
+	g.poolRunning, g.poolQueued = len(snap.Running), len(snap.Queue)
+	running := make(map[string]bool, len(snap.Running))
+	for _, r := range snap.Running {
+		running[r.Agent] = true
+	}
+
+	d.mu.RLock()
+	g.agents = len(d.activeAgents)
+	managers := make(map[string]*hook.Manager, len(d.hookManagers))
+	for name, mgr := range d.hookManagers {
+		managers[name] = mgr
+	}
+	d.mu.RUnlock()
+
+	for name, mgr := range managers {
+		if running[name] {
+			continue
+		}
+		if h, err := mgr.Read(); err == nil && h != nil {
+			g.hookBacklog++
+		}
+	}
+	return g
+}
+
+// healthHandler serves /healthz and /metrics
+func (d *Daemon) healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		h := d.health(time.Now())
+		w.Header().Set("Content-Type", "application/json")
+		if h.Status == HealthStalled {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(h)
+	})
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now()
+		g := d.gauges(d.health(now).Status != HealthStalled)
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		d.stats.writeMetrics(w, g, now)
+	})
+	return mux
+}
+
+// startHealth serves /healthz and /metrics on the daemon's unix socket, and
+// on [daemon] metrics_listen when it is set. An endpoint that cannot be
+// opened is logged and the daemon runs without it.
+func (d *Daemon) startHealth() {
+	handler := d.healthHandler()
+
+	path := SocketPath(d.mobDir)
+	os.Remove(path) // left behind by a crash or the process this one replaced
+	if ln, err := net.Listen("unix", path); err != nil {
+		d.logger.Printf("Warning: health socket disabled: %v\n", err)
+	} else {
+		os.Chmod(path, 0600)
+		d.healthSock = d.serveHealth(ln, handler)
+	}
+
+	if listen := d.cfg.Daemon.MetricsListen; listen != "" {
+		ln, err := net.Listen("tcp", listen)
+		if err != nil {
+			d.logger.Printf("Warning: metrics endpoint disabled: %v\n", err)
+			return
+		}
+		d.logger.Printf("Health: serving /healthz and /metrics on %s\n", ln.Addr())
+		d.healthTCP = d.serveHealth(ln, handler)
+	}
+}
+
+func (d *Daemon) serveHealth(ln net.Listener, handler http.Handler) *http.Server {
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			d.logger.Printf("Health: server on %s stopped: %v\n", ln.Addr(), err)
+		}
+	}()
+	return srv
+}
+
+// stopHealth closes the health endpoints
+func (d *Daemon) stopHealth() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for _, srv := range []*http.Server{d.healthSock, d.healthTCP} {
+		if srv != nil {
+			srv.Shutdown(ctx)
+		}
+	}
+	if d.healthSock != nil {
+		os.Remove(SocketPath(d.mobDir))
+	}
+	d.healthSock, d.healthTCP = nil, nil
+}
+
+// CheckHealth asks the running daemon for /healthz over its socket
+func CheckHealth(mobDir string) (*Health, error) {
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", SocketPath(mobDir))
+			},
+		},
+	}
+	resp, err := client.Get("http://mob/healthz")
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errkind.New(errkind.NotFound, "daemon is not serving health checks")
+		}
+		return nil, errkind.Wrap(errkind.Transient, fmt.Errorf("health check failed: %w", err))
+	}
+	defer resp.Body.Close()
+
+	var h Health
+	if err := json.NewDecoder(resp.Body).Decode(&h); err != nil {
+		return nil, fmt.Errorf("failed to parse health check: %w", err)
+	}
+	return &h, nil
+}
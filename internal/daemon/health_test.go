@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/events"
+)
+
+func TestHealth(t *testing.T) {
+	start := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	d := New(t.TempDir(), log.New(io.Discard, "", 0))
+	d.stats = newSelfMetrics(start)
+
+	if h := d.health(start.Add(time.Minute)); h.Status != HealthStarting {
+		t.Errorf("before the first patrol = %s, want starting", h.Status)
+	}
+
+	d.stats.patrolStarted(start, time.Minute)
+	d.stats.patrolFinished(start.Add(2 * time.Second))
+	if h := d.health(start.Add(2 * time.Minute)); h.Status != HealthOK || len(h.Problems) != 0 {
+		t.Errorf("after a patrol = %+v, want ok", h)
+	}
+	if h := d.health(start.Add(5 * time.Minute)); h.Status != HealthStalled {
+		t.Errorf("no patrol for 5 intervals = %s, want stalled", h.Status)
+	}
+
+	// A patrol that never finishes
+	d.stats.patrolStarted(start.Add(time.Minute), time.Minute)
+	if h := d.health(start.Add(10 * time.Minute)); h.Status != HealthStalled || !strings.Contains(h.Problems[0], "patrol running") {
+		t.Errorf("hung patrol = %+v", h)
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	now := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	m := newSelfMetrics(now.Add(-2 * time.Hour))
+	m.patrolStarted(now.Add(-time.Minute), time.Minute)
+	m.patrolFinished(now.Add(-time.Minute + 500*time.Millisecond))
+	m.countEvent(events.BeadAssigned{BeadID: "bd-1", Agent: "vinnie"})
+	m.countEvent(events.AgentFailed{Agent: "vinnie", Err: errors.New("boom")})
+	m.countEvent(events.MergeCompleted{BeadID: "bd-1", Success: true})
+	m.countEvent(events.ModelDegraded{Model: "opus"})
+	m.workCompleted(now.Add(-90*time.Minute), 2) // outside the hour
+	m.workCompleted(now.Add(-10*time.Minute), 0.25)
+
+	var out bytes.Buffer
+	m.writeMetrics(&out, gauges{healthy: true, agents: 2, poolQueued: 1, hookBacklog: 3}, now)
+	for _, want := range []string{
+		"mob_up 1\n",
+		"mob_patrol_duration_seconds_count 1\n",
+		"mob_patrol_last_duration_seconds 0.5\n",
+		"mob_assignments_total 1\n",
+		"mob_agent_failures_total 1\n",
+		"mob_work_completed_total 2\n",
+		`mob_merges_total{result="landed"} 1` + "\n",
+		"mob_api_cost_usd_total 2.25\n",
+		"mob_api_cost_usd_per_hour 0.25\n",
+		"mob_pool_queued 1\n",
+		"mob_hook_backlog 3\n",
+		"mob_models_degraded 1\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("metrics missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	mobDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(mobDir, ".mob"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CheckHealth(mobDir); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("no daemon = %v, want NotFound", err)
+	}
+
+	d := New(mobDir, log.New(io.Discard, "", 0))
+	d.startHealth()
+	defer d.stopHealth()
+	d.patrol()
+
+	h, err := CheckHealth(mobDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Status != HealthOK || h.PID != os.Getpid() {
+		t.Errorf("health = %+v", h)
+	}
+
+	d.stopHealth()
+	if _, err := os.Stat(SocketPath(mobDir)); !os.IsNotExist(err) {
+		t.Error("socket should be removed when the daemon stops")
+	}
+}
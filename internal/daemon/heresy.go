@@ -0,0 +1,132 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/heresy"
+	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// heresyFixTag tags the associates started on approved heresy fixes
+const heresyFixTag = "heresy-fix"
+
+// startHeresyFixes starts the associate lined up for an escalated heresy
+// once the heresy is approved, and closes the fix when it is rejected
+func (d *Daemon) startHeresyFixes() {
+	if d.beadStore == nil || d.spawner == nil || d.registry == nil || d.upgrade != nil {
+		return
+	}
+	pending, err := d.beadStore.List(storage.BeadFilter{Status: models.BeadStatusPendingApproval})
+	if err != nil {
+		d.logger.Printf("Heresy: failed to list pending beads: %v\n", err)
+		return
+	}
+
+	for _, fix := range pending {
+		if fix.DiscoveredFrom != heresy.FixSource || fix.ParentID == "" {
+			continue
+		}
+		parent, err := d.beadStore.Get(fix.ParentID)
+		if err != nil {
+			d.logger.Printf("Heresy: fix %s: %v\n", fix.ID, err)
+			continue
+		}
+
+		switch parent.Status {
+		case models.BeadStatusPendingApproval:
+			continue
+		case models.BeadStatusClosed:
+			if _, err := d.updateBead(fix.ID, func(b *models.Bead) error {
+				if b.Status != models.BeadStatusPendingApproval {
+					return errBeadMoved
+				}
+				b.Status = models.BeadStatusClosed
+				b.CloseReason = fmt.Sprintf("heresy %s was closed before the fix started", parent.ID)
+				return nil
+			}); err != nil && !errors.Is(err, errBeadMoved) {
+				d.logger.Printf("Heresy: failed to close fix %s: %v\n", fix.ID, err)
+			}
+			continue
+		}
+
+		// Open it first so a later tick does not submit it again
+		opened, err := d.updateBead(fix.ID, func(b *models.Bead) error {
+			if b.Status != models.BeadStatusPendingApproval {
+				return errBeadMoved
+			}
+			b.Status = models.BeadStatusOpen
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, errBeadMoved) {
+				d.logger.Printf("Heresy: failed to open fix %s: %v\n", fix.ID, err)
+			}
+			continue
+		}
+		fix = opened
+
+		turfName := d.turfName(fix.Turf)
+		ctx := &mcp.ToolContext{
+			Context:     d.ctx,
+			Registry:    d.registry,
+			Spawner:     d.spawner,
+			BeadStore:   d.beadStore,
+			TurfManager: d.turfMgr,
+			MobDir:      d.mobDir,
+			TaskWg:      &d.fixes,
+			Redactor:    d.redactor,
+		}
+		record, err := mcp.QueueAssociate(ctx, &models.SpawnRequest{
+			Turf:        turfName,
+			Task:        fmt.Sprintf("Work on bead %s: %s\n\n%s", fix.ID, fix.Title, fix.Description),
+			WorkDir:     d.resolveTurfPath(fix.Turf),
+			BeadID:      fix.ID,
+			Tag:         heresyFixTag,
+			RequestedBy: "daemon",
+		})
+		switch {
+		case err != nil:
+			d.logger.Printf("Heresy: failed to start fix %s for approved heresy %s: %v\n", fix.ID, parent.ID, err)
+		case record == nil:
+			d.logger.Printf("Heresy: fix %s for approved heresy %s queued for associate capacity\n", fix.ID, parent.ID)
+		default:
+			d.publish(events.HeresyFixStarted{BeadID: fix.ID, HeresyID: parent.ID, Agent: record.Label()})
+		}
+	}
+}
+
+// turfName maps the turf path heresy scans record back to its registered
+// name, falling back to the value as given
+func (d *Daemon) turfName(turf string) string {
+	if d.turfMgr == nil {
+		return turf
+	}
+	for _, t := range d.turfMgr.List() {
+		if t.Path == turf {
+			return t.Name
+		}
+	}
+	return turf
+}
+
+// runningHeresyFixes counts the heresy fix associates still working
+func (d *Daemon) runningHeresyFixes() int {
+	if d.registry == nil {
+		return 0
+	}
+	associates, err := d.registry.ListByType("associate")
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, a := range associates {
+		if a.Tag == heresyFixTag && a.CompletedAt == nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// notifyHumanInputRequests tells the operator about agent questions filed
+// with request_human_input that nobody has been told about yet
+func (d *Daemon) notifyHumanInputRequests() {
+	store, err := storage.NewReportStore(filepath.Join(d.mobDir, ".mob", "reports"))
+	if err != nil {
+		d.logger.Printf("Human input: %v\n", err)
+		return
+	}
+
+	unhandled := false
+	pending, err := store.List(storage.ReportFilter{Type: models.ReportTypeHumanInput, Handled: &unhandled})
+	if err != nil {
+		d.logger.Printf("Human input: %v\n", err)
+		return
+	}
+
+	for _, r := range pending {
+		if r.NotifiedAt != nil {
+			continue
+		}
+		d.logger.Printf("Human input: %s asks (%s): %s\n", r.AgentName, r.ID, r.Message)
+		if d.notifier != nil {
+			if err := d.notifier.NotifyHumanInputNeeded(r.ID, r.AgentName, r.BeadID, r.Message); err != nil {
+				d.logger.Printf("Human input: notify %s: %v\n", r.ID, err)
+			}
+		}
+		if _, err := store.MarkNotified(r.ID); err != nil {
+			d.logger.Printf("Human input: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/gabe/mob/internal/jobs"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+)
+
+// loadJobs resolves the [jobs] schedules. Invalid entries are logged and
+// skipped; the rest still run.
+func (d *Daemon) loadJobs() {
+	resolved, err := jobs.FromConfig(d.cfg)
+	if err != nil {
+		d.logger.Printf("Jobs: %v\n", err)
+	}
+	d.jobs = resolved
+	d.jobsSince = time.Now()
+
+	for _, j := range resolved {
+		if j.Enabled {
+			d.logger.Printf("Jobs: %s (%s) scheduled %q\n", j.Name, j.Kind, j.Spec)
+		}
+	}
+}
+
+// runDueJobs starts every enabled job whose next run has come, skipping jobs
+// still running from an earlier start
+func (d *Daemon) runDueJobs() {
+	if len(d.jobs) == 0 || d.upgrade != nil {
+		return
+	}
+
+	statePath := jobs.StatePath(d.mobDir)
+	state, err := jobs.LoadState(statePath)
+	if err != nil {
+		d.logger.Printf("Jobs: %v\n", err)
+		return
+	}
+
+	now := time.Now()
+	for _, j := range d.jobs {
+		if !j.Due(state[j.Name], d.jobsSince, now) {
+			continue
+		}
+
+		d.mu.Lock()
+		running := d.jobsRunning[j.Name]
+		d.jobsRunning[j.Name] = true
+		d.mu.Unlock()
+		if running {
+			continue
+		}
+
+		go d.runJob(j, state[j.Name])
+	}
+}
+
+// runJob executes one job, records the run and reports summaries to the
+// notification backends
+func (d *Daemon) runJob(j *jobs.Job, last jobs.Run) {
+	defer func() {
+		d.mu.Lock()
+		delete(d.jobsRunning, j.Name)
+		d.mu.Unlock()
+	}()
+
+	var turfs []models.Turf
+	if d.turfMgr != nil {
+		turfs = d.turfMgr.List()
+	}
+	runner := &jobs.Runner{
+		MobDir:    d.mobDir,
+		Config:    d.cfg,
+		Beads:     d.beadStore,
+		Registry:  d.registry,
+		Activity:  d.activity,
+		Turfs:     turfs,
+		Detectors: d.detectors,
+	}
+
+	start := time.Now()
+	result, err := runner.Run(d.ctx, j, last)
+	run := jobs.Run{At: start, Duration: time.Since(start), Result: result}
+	if err != nil {
+		run.Error = err.Error()
+		d.logger.Printf("Jobs: %s failed: %v\n", j.Name, err)
+	} else {
+		d.logger.Printf("Jobs: %s: %s\n", j.Name, result)
+	}
+
+	if err := jobs.RecordRun(jobs.StatePath(d.mobDir), j.Name, run); err != nil {
+		d.logger.Printf("Jobs: %v\n", err)
+	}
+
+	if j.Kind == jobs.KindSummary && err == nil && d.notifier != nil {
+		if err := d.notifier.Notify(notify.Notification{Type: notify.NotificationTypeInfo, Title: "Mob activity summary", Message: result}); err != nil {
+			d.logger.Printf("Jobs: notify summary: %v\n", err)
+		}
+	}
+}
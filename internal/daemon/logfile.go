@@ -0,0 +1,16 @@
+package daemon
+
+import "github.com/gabe/mob/internal/logfile"
+
+// SetLogFile tells the daemon which rotating log its logger writes to, so
+// config reloads can change how it is rotated
+func (d *Daemon) SetLogFile(w *logfile.Writer) {
+	d.logFile = w
+}
+
+// setupLogFile applies the config's rotation and retention to the daemon log
+func (d *Daemon) setupLogFile() {
+	if d.logFile != nil {
+		d.logFile.SetPolicy(logfile.PolicyFromConfig(d.cfg.Logging))
+	}
+}
@@ -0,0 +1,151 @@
+package daemon
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/merge"
+)
+
+// processMerges feeds merge requests filed by agents into the scheduler,
+// starts the next merge for each idle turf and publishes queue depths
+func (d *Daemon) processMerges() {
+	requests, err := merge.DrainRequests(merge.RequestsPath(d.mobDir), d.keepMerges)
+	if err != nil {
+		d.logger.Printf("Merges: failed to read merge requests: %v\n", err)
+	}
+
+	for _, req := range requests {
+		d.enqueueMerge(req)
+	}
+
+	if started := d.merges.Dispatch(); started > 0 {
+		d.logger.Printf("Merges: started %d merge(s)\n", started)
+	}
+	d.publishMergeDepths()
+}
+
+// restoreMerges queues again the merges a previous daemon claimed but did
+// not finish
+func (d *Daemon) restoreMerges() {
+	requests, err := merge.LoadPending(merge.PendingPath(d.mobDir))
+	if err != nil {
+		d.logger.Printf("Merges: failed to read unfinished merges: %v\n", err)
+		return
+	}
+	if len(requests) == 0 {
+		return
+	}
+
+	d.mu.Lock()
+	for _, req := range requests {
+		d.mergePending[req.BeadID] = req
+	}
+	d.mu.Unlock()
+
+	d.logger.Printf("Merges: restoring %d unfinished merge(s)\n", len(requests))
+	for _, req := range requests {
+		d.enqueueMerge(req)
+	}
+}
+
+// enqueueMerge adds a kept request to its turf's queue. A request the queue
+// refuses fails its bead rather than leaving it waiting on a merge.
+func (d *Daemon) enqueueMerge(req merge.Request) {
+	d.merges.Queue(req.Turf, req.RepoPath).SetVCS(req.VCS)
+	if err := d.merges.Enqueue(req.Turf, req.RepoPath, req.BeadID, req.Branch, req.BlockedBy); err != nil {
+		if errors.Is(err, merge.ErrItemExists) {
+			return
+		}
+		d.logger.Printf("Merges: failed to queue bead %s: %v\n", req.BeadID, err)
+		d.onMergeResult(req.Turf, &merge.MergeResult{BeadID: req.BeadID, Message: err.Error()}, err)
+		return
+	}
+	d.logger.Printf("Merges: queued bead %s on turf %s\n", req.BeadID, req.Turf)
+}
+
+// keepMerges records claimed requests on disk until their merges finish
+func (d *Daemon) keepMerges(requests []merge.Request) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, req := range requests {
+		d.mergePending[req.BeadID] = req
+	}
+	return d.savePendingMergesLocked()
+}
+
+// finishPendingMerge forgets a bead's merge once its result is handled,
+// returning the close reason it was requested with. Callers must not hold d.mu.
+func (d *Daemon) finishPendingMerge(beadID string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	req, ok := d.mergePending[beadID]
+	if !ok {
+		return ""
+	}
+	delete(d.mergePending, beadID)
+	if err := d.savePendingMergesLocked(); err != nil {
+		d.logger.Printf("Merges: failed to record finished merge of bead %s: %v\n", beadID, err)
+	}
+	return req.CloseReason
+}
+
+// savePendingMergesLocked writes the unfinished merges. Callers must hold d.mu.
+func (d *Daemon) savePendingMergesLocked() error {
+	requests := make([]merge.Request, 0, len(d.mergePending))
+	for _, req := range d.mergePending {
+		requests = append(requests, req)
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].RequestedAt.Before(requests[j].RequestedAt) })
+	return merge.SavePending(merge.PendingPath(d.mobDir), requests)
+}
+
+// onMergeResult closes or blocks a bead once the scheduler has merged it.
+// A merge that could not run blocks its bead like a failed one.
+func (d *Daemon) onMergeResult(turfName string, result *merge.MergeResult, err error) {
+	defer d.publishMergeDepths()
+
+	if result == nil {
+		// Without a bead the merge stays recorded and is retried on restart
+		d.logger.Printf("Merges: processing error on turf %s: %v\n", turfName, err)
+		return
+	}
+	if err != nil {
+		d.logger.Printf("Merges: processing error for bead %s on turf %s: %v\n", result.BeadID, turfName, err)
+		result.Success = false
+	}
+	reason := d.finishPendingMerge(result.BeadID)
+	if d.beadStore == nil {
+		return
+	}
+
+	bead, err := d.beadStore.Get(result.BeadID)
+	if err != nil {
+		d.logger.Printf("Merges: bead %s vanished before its merge finished: %v\n", result.BeadID, err)
+		return
+	}
+
+	ctx := &mcp.ToolContext{
+		BeadStore:   d.beadStore,
+		TurfManager: d.turfMgr,
+		MobDir:      d.mobDir,
+	}
+	if d.notifier != nil {
+		ctx.NotifyManager = d.notifier
+	}
+	msg, err := mcp.FinishMerge(ctx, bead, reason, result)
+	if err != nil {
+		d.logger.Printf("Merges: failed to finish bead %s: %v\n", result.BeadID, err)
+		return
+	}
+	d.publish(events.MergeCompleted{BeadID: bead.ID, Turf: turfName, Success: result.Success, Summary: msg})
+}
+
+// publishMergeDepths writes per-turf queue depths for `mob status`
+func (d *Daemon) publishMergeDepths() {
+	if err := merge.SaveDepths(merge.DepthsPath(d.mobDir), d.merges.Depths()); err != nil {
+		d.logger.Printf("Merges: failed to publish queue depths: %v\n", err)
+	}
+}
@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/gabe/mob/internal/merge"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestMergesSurviveRestart(t *testing.T) {
+	old, turfDir, bead := newTurfTestDaemon(t)
+	path := merge.RequestsPath(old.mobDir)
+	req := merge.Request{BeadID: bead.ID, Branch: "mob/" + bead.ID, Turf: "backend", RepoPath: turfDir, CloseReason: "done"}
+	if err := merge.AppendRequest(path, req); err != nil {
+		t.Fatal(err)
+	}
+
+	// The old daemon claims the request and dies before merging it
+	if _, err := merge.DrainRequests(path, old.keepMerges); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _ := merge.LoadPending(merge.PendingPath(old.mobDir)); len(pending) != 1 || pending[0].BeadID != bead.ID {
+		t.Fatalf("pending merges = %+v, want the claimed request", pending)
+	}
+
+	d := New(old.mobDir, log.New(io.Discard, "", 0))
+	d.turfMgr, d.beadStore = old.turfMgr, old.beadStore
+	d.restoreMerges()
+	if depth := d.merges.Depths()["backend"]; depth != 1 {
+		t.Fatalf("restored queue depth = %d, want 1", depth)
+	}
+
+	// The turf is no repository, so the merge fails and blocks the bead
+	d.processMerges()
+	d.merges.Wait()
+	got, err := d.beadStore.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.BeadStatusBlocked || got.CloseReason == "" {
+		t.Errorf("bead = %s (%q), want blocked with the merge failure", got.Status, got.CloseReason)
+	}
+	if pending, _ := merge.LoadPending(merge.PendingPath(d.mobDir)); len(pending) != 0 {
+		t.Errorf("pending merges = %+v, want none once the merge finished", pending)
+	}
+}
+
+func TestMergeErrorBlocksBead(t *testing.T) {
+	d, _, bead := newTurfTestDaemon(t)
+	if err := d.keepMerges([]merge.Request{{BeadID: bead.ID, Turf: "backend", CloseReason: "done"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	d.onMergeResult("backend", &merge.MergeResult{BeadID: bead.ID, Message: "checkout failed"}, merge.ErrMergeFailed)
+	got, _ := d.beadStore.Get(bead.ID)
+	if got.Status != models.BeadStatusBlocked || got.ClosedAt != nil {
+		t.Errorf("bead = %s, want blocked rather than left open", got.Status)
+	}
+	if _, ok := d.mergePending[bead.ID]; ok {
+		t.Error("expected the finished merge forgotten")
+	}
+}
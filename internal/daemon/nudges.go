@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/nudge"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// setupNudges applies the config's nudge backoff and daily limits
+func (d *Daemon) setupNudges() {
+	d.nudges.SetLimits(d.nudgeLimits())
+}
+
+func (d *Daemon) nudgeLimits() nudge.Limits {
+	return nudge.Limits{
+		Interval:   d.cfg.Daemon.GetBootCheckInterval(),
+		MaxBackoff: d.cfg.Daemon.GetNudgeMaxBackoff(),
+		PerDay:     d.cfg.Daemon.NudgesPerDay,
+		BudgetUSD:  d.cfg.Daemon.NudgeBudgetUSD,
+	}
+}
+
+// nudgeProgress is a marker of how far along a soldati is: what is on its
+// hook, its status and task, and when its bead last changed. A nudge after
+// which none of these move did not help.
+func (d *Daemon) nudgeProgress(h *hook.Hook, rec *registry.AgentRecord) string {
+	var beadID, message string
+	if h != nil {
+		beadID, message = h.BeadID, h.Message
+	}
+	var status registry.Status
+	var task string
+	if rec != nil {
+		status, task = rec.Status, rec.Task
+	}
+	var updated time.Time
+	if beadID != "" && d.beadStore != nil {
+		if b, err := d.beadStore.Get(beadID); err == nil {
+			updated = b.UpdatedAt
+		}
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%d", beadID, message, status, task, updated.UnixNano())
+}
+
+// allowNudge reports whether a soldati may be nudged now, logging when the
+// daily limit or budget first holds nudges back and when they resume
+func (d *Daemon) allowNudge(name, progress string, now time.Time) bool {
+	ok, held := d.nudges.Allow(name, progress, now)
+	daily := held == nudge.HeldLimit || held == nudge.HeldBudget
+
+	// Urgent assignments nudge from worker goroutines as well as the patrol
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch {
+	case daily && d.nudgesHeld == "":
+		d.logger.Printf("Nudge: %s reached (%s), holding nudges until tomorrow\n",
+			held, d.nudges.Today(now).Describe(d.nudgeLimits()))
+	case !daily && d.nudgesHeld != "":
+		d.logger.Printf("Nudge: %s lifted, nudging again\n", d.nudgesHeld)
+	}
+	if daily {
+		d.nudgesHeld = held
+	} else {
+		d.nudgesHeld = ""
+	}
+	return ok
+}
+
+// sendNudge sends a soldati a nudge in its session, recording it and what it
+// cost against the day's nudge budget and the usage ledger
+func (d *Daemon) sendNudge(name string, a *agent.Agent, message, progress string) {
+	now := time.Now()
+	d.nudges.Nudged(name, progress, now)
+
+	go func() {
+		resp, err := a.Chat(message)
+		if err != nil {
+			d.logger.Printf("Nudge: failed to nudge soldati '%s': %v\n", name, err)
+			return
+		}
+		d.nudges.Spent(resp.TotalCost, time.Now())
+		d.recordUsage(name, a, "", resp)
+	}()
+}
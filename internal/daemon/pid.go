@@ -3,23 +3,20 @@ package daemon
 import (
 	"fmt"
 	"os"
-	"strconv"
-	"strings"
 	"syscall"
+
+	"github.com/gabe/mob/internal/reaper"
 )
 
-// WritePID writes the process ID to a file
+// WritePID writes the process ID, and the identity of that process, to a file
 func WritePID(path string, pid int) error {
-	return os.WriteFile(path, []byte(strconv.Itoa(pid)), 0644)
+	return reaper.WritePIDFile(path, pid)
 }
 
 // ReadPID reads the process ID from a file
 func ReadPID(path string) (int, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return 0, err
-	}
-	return strconv.Atoi(strings.TrimSpace(string(data)))
+	pid, _, err := reaper.ReadPIDFile(path)
+	return pid, err
 }
 
 // RemovePID removes the PID file
@@ -38,9 +35,11 @@ func IsProcessRunning(pid int) bool {
 	return err == nil
 }
 
-// CheckExistingDaemon checks if a daemon is already running
+// CheckExistingDaemon checks if a daemon is already running. A PID file whose
+// process has exited, or whose PID now belongs to a different process, is
+// stale and removed.
 func CheckExistingDaemon(pidFile string) (bool, int, error) {
-	pid, err := ReadPID(pidFile)
+	pid, identity, err := reaper.ReadPIDFile(pidFile)
 	if os.IsNotExist(err) {
 		return false, 0, nil
 	}
@@ -48,7 +47,7 @@ func CheckExistingDaemon(pidFile string) (bool, int, error) {
 		return false, 0, fmt.Errorf("failed to read PID file: %w", err)
 	}
 
-	if IsProcessRunning(pid) {
+	if reaper.Alive(pid, identity) {
 		return true, pid, nil
 	}
 
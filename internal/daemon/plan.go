@@ -0,0 +1,98 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// Plan step actions
+const (
+	PlanSpawn      = "spawn"      // start an agent for a soldati
+	PlanUnregister = "unregister" // drop a registry entry with no soldati behind it
+	PlanNudge      = "nudge"      // nudge an associate past its timeout
+	PlanKill       = "kill"       // kill an associate past its grace period
+	PlanCleanup    = "cleanup"    // forget a finished associate
+	PlanEscalate   = "escalate"   // raise an overdue bead's priority
+	PlanAge        = "age"        // raise a long-waiting bead's priority
+	PlanScaleUp    = "scale-up"   // add a soldati for a turf's backlog
+	PlanRetire     = "retire"     // remove a soldati added for a drained turf
+	PlanRetry      = "retry"      // reopen a failed bead whose retry is due
+	PlanAssign     = "assign"     // hand a ready bead to an idle soldati
+	PlanResume     = "resume"     // hand parked work back to its soldati
+	PlanReopen     = "reopen"     // reopen parked work whose soldati is gone
+	PlanHold       = "hold"       // leave a soldati idle although work is ready
+	PlanPark       = "park"       // park running work for an urgent bead
+)
+
+// PlanStep is one thing a patrol would do
+type PlanStep struct {
+	Action string `json:"action"`
+	Agent  string `json:"agent,omitempty"`
+	BeadID string `json:"bead_id,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Plan is what one patrol would do, worked out without doing any of it
+type Plan struct {
+	At      time.Time  `json:"at"`
+	Working bool       `json:"working"` // inside working hours, so spawns and assignments happen
+	Steps   []PlanStep `json:"steps"`
+
+	handed  map[string]bool // beads the plan already gives out
+	removed map[string]bool // soldati the plan already takes away
+}
+
+// PlanPatrol loads mobDir and runs one patrol in planning mode: every
+// decision is made as the daemon would make it, but instead of spawning,
+// assigning or killing anything the step is recorded. Beads, hooks and the
+// registry are left as they are. Agents a running daemon has registered are
+// taken to be alive.
+func PlanPatrol(mobDir string) (*Plan, error) {
+	d := New(mobDir, log.New(io.Discard, "", 0))
+	if err := d.open(); err != nil {
+		return nil, err
+	}
+	d.plan = &Plan{At: time.Now(), handed: make(map[string]bool), removed: make(map[string]bool)}
+	d.patrol()
+	return d.plan, nil
+}
+
+// planned records step and reports true when the daemon is only planning;
+// callers skip the action it describes
+func (d *Daemon) planned(step PlanStep) bool {
+	if d.plan == nil {
+		return false
+	}
+	d.plan.Steps = append(d.plan.Steps, step)
+	switch step.Action {
+	case PlanAssign, PlanResume:
+		d.plan.handed[step.BeadID] = true
+	case PlanUnregister, PlanRetire:
+		d.plan.removed[step.Agent] = true
+	}
+	return true
+}
+
+// planRemoved reports whether the plan already takes a soldati away, since
+// planning leaves it registered
+func (d *Daemon) planRemoved(name string) bool {
+	return d.plan != nil && d.plan.removed[name]
+}
+
+// unhanded drops the beads the plan already gave to another soldati, since
+// planning leaves them ready on disk
+func (d *Daemon) unhanded(beads []*models.Bead) []*models.Bead {
+	if d.plan == nil || len(d.plan.handed) == 0 {
+		return beads
+	}
+	var kept []*models.Bead
+	for _, b := range beads {
+		if !d.plan.handed[b.ID] {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+)
+
+func TestPlanPatrolChangesNothing(t *testing.T) {
+	mobDir := t.TempDir()
+	turfs, err := turf.NewManager(filepath.Join(mobDir, "turfs.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := turfs.Add(t.TempDir(), "backend", "main"); err != nil {
+		t.Fatal(err)
+	}
+	mgr, err := soldati.NewManager(filepath.Join(mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"vinnie", "sal"} {
+		if _, err := mgr.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// sal is running under a daemon; ghost is left over from a removed soldati
+	reg := registry.New(registry.DefaultPath(mobDir))
+	reg.Register(&registry.AgentRecord{ID: "sal-id", Type: "soldati", Name: "sal", Turf: "backend", Status: registry.StatusIdle})
+	reg.Register(&registry.AgentRecord{ID: "ghost-id", Type: "soldati", Name: "ghost", Status: registry.StatusIdle})
+
+	store, err := storage.NewBeadStore(storage.BeadsDir(mobDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := store.Create(&models.Bead{Title: "Add endpoint", Status: models.BeadStatusOpen, Turf: "backend", Priority: 1})
+	store.Create(&models.Bead{Title: "Write docs", Status: models.BeadStatusOpen, Turf: "backend", Priority: 2})
+
+	plan, err := PlanPatrol(mobDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Working {
+		t.Error("expected the default schedule to be working hours")
+	}
+	want := []PlanStep{
+		{Action: PlanSpawn, Agent: "vinnie"},
+		{Action: PlanUnregister, Agent: "ghost"},
+		{Action: PlanAssign, Agent: "sal", BeadID: first.ID},
+	}
+	if len(plan.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got %+v", len(want), plan.Steps)
+	}
+	for i, w := range want {
+		got := plan.Steps[i]
+		if got.Action != w.Action || got.Agent != w.Agent || got.BeadID != w.BeadID {
+			t.Errorf("step %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	// Nothing it planned was done
+	if got, _ := store.Get(first.ID); got.Status != models.BeadStatusOpen || got.Assignee != "" {
+		t.Errorf("expected the bead left open, got %s/%s", got.Status, got.Assignee)
+	}
+	if agents, _ := reg.ListByType("soldati"); len(agents) != 2 {
+		t.Errorf("expected the registry untouched, got %d soldati", len(agents))
+	}
+	hooks, _ := hook.NewManager(filepath.Join(mobDir, ".mob", "soldati"), "sal")
+	if h, _ := hooks.Read(); h != nil {
+		t.Errorf("expected sal's hook left empty, got %+v", h)
+	}
+}
@@ -0,0 +1,57 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/plugin"
+	"github.com/gabe/mob/internal/watch"
+)
+
+// loadPlugins sets up the notification feed, notification backends and sweep
+// detectors from ~/mob/plugins, and the per-human channels used for bead
+// watch notifications
+func (d *Daemon) loadPlugins() {
+	plugins, errs := plugin.Discover(plugin.Dir(d.mobDir), d.mobDir)
+	for _, err := range errs {
+		d.logger.Printf("Plugins: %v\n", err)
+	}
+
+	d.detectors = plugin.Detectors(plugins)
+
+	// The feed backs the dashboard's notifications tab; plugins add the rest
+	d.notifier = notify.NewManager(notify.NewFeed(notify.FeedPath(d.mobDir)))
+	if notifiers := plugin.Notifiers(plugins); len(notifiers) > 0 {
+		for _, n := range notifiers {
+			d.notifier.Add(n)
+		}
+		d.logger.Printf("Plugins: %d notification backend(s) loaded\n", len(notifiers))
+	}
+	d.notifier.SetRedactor(d.redactor)
+
+	if d.cfg != nil && len(d.cfg.Notifications.Humans) > 0 && d.beadStore != nil {
+		channels, errs := watch.Channels(d.cfg.Notifications.Humans, plugins)
+		for _, err := range errs {
+			d.logger.Printf("Watchers: %v\n", err)
+		}
+		for human, notifiers := range channels {
+			for i, n := range notifiers {
+				channels[human][i] = notify.Redacted(n, d.redactor)
+			}
+		}
+		d.watch = watch.NewDispatcher(d.beadStore, watch.CursorPath(d.mobDir), channels)
+	}
+}
+
+// dispatchWatchNotifications tells watching and mentioned humans about new
+// bead activity
+func (d *Daemon) dispatchWatchNotifications() {
+	if d.watch == nil {
+		return
+	}
+	sent, err := d.watch.Dispatch()
+	if err != nil {
+		d.logger.Printf("Watchers: %v\n", err)
+	}
+	if sent > 0 {
+		d.logger.Printf("Watchers: sent %d notification(s)\n", sent)
+	}
+}
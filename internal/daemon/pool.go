@@ -0,0 +1,35 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/pool"
+)
+
+// setupPool applies the config's concurrency limits to the agent pool and
+// publishes its state for `mob status`
+func (d *Daemon) setupPool() {
+	d.pool.OnChange(d.publishPool)
+	d.pool.SetLimits(pool.Limits{
+		Max:     d.cfg.Daemon.MaxConcurrentAgents,
+		PerTurf: d.cfg.Daemon.MaxAgentsPerTurf,
+		Turfs:   d.cfg.Daemon.TurfAgentLimits,
+	})
+}
+
+// publishPool writes the pool's running work and queue for `mob status`
+func (d *Daemon) publishPool(s pool.Snapshot) {
+	if err := pool.Save(pool.StatePath(d.mobDir), s); err != nil {
+		d.logger.Printf("Pool: failed to publish state: %v\n", err)
+	}
+}
+
+// assignmentTurf names the turf an assignment counts against: the bead's,
+// or the soldati's own when there is no bead
+func (d *Daemon) assignmentTurf(a *agent.Agent, beadID string) string {
+	if beadID != "" && d.beadStore != nil {
+		if b, err := d.beadStore.Get(beadID); err == nil && b.Turf != "" {
+			return d.turfName(b.Turf)
+		}
+	}
+	return d.turfName(a.Turf)
+}
@@ -0,0 +1,294 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/preempt"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// parkedNote tells a soldati handed its parked work back why it stopped
+const parkedNote = "You were pulled off this bead for urgent bead %s, which is now done or taken. Pick up where you left off."
+
+// preemptForUrgent parks less urgent work for urgent ready beads that no
+// soldati was free to take. Each urgent bead takes at most one soldati per
+// patrol, from its own turf; the soldati moves to it once its call stops.
+func (d *Daemon) preemptForUrgent(now time.Time) {
+	policy := preempt.PolicyFromConfig(d.cfg.Preemption)
+	if !policy.Enabled {
+		return
+	}
+	ready, err := d.beadStore.ListReady("")
+	if err != nil {
+		d.logger.Printf("Preempt: failed to list ready beads: %v\n", err)
+		return
+	}
+	var urgent []*models.Bead
+	for _, b := range d.inHoursBeads(ready, now) {
+		if policy.Urgent(b.Priority) && b.Turf != "" {
+			urgent = append(urgent, b)
+		}
+	}
+	if len(urgent) == 0 {
+		return
+	}
+
+	agents, err := d.registry.ListByType("soldati")
+	if err != nil {
+		d.logger.Printf("Preempt: failed to list soldati: %v\n", err)
+		return
+	}
+	running, free := d.runningByTurf(agents)
+	healthCtx := &mcp.ToolContext{BeadStore: d.beadStore, TurfManager: d.turfMgr, MobDir: d.mobDir}
+	if d.plan != nil {
+		healthCtx.BeadStore = nil // no held-assignment comments from a plan
+	}
+
+	for _, b := range urgent {
+		// A free soldati will get it on its own once whatever held it clears
+		if free[b.Turf] {
+			continue
+		}
+		victim := policy.Victim(b.Priority, running[b.Turf])
+		if victim == nil {
+			continue
+		}
+		if err := mcp.CheckTurfHealth(healthCtx, b); err != nil {
+			continue // held for everyone; parking work would not get it started
+		}
+		if d.planned(PlanStep{Action: PlanPark, Agent: victim.Agent, BeadID: victim.BeadID,
+			Detail: fmt.Sprintf("P%d, for urgent bead %s (P%d)", victim.Priority, b.ID, b.Priority)}) {
+			running[b.Turf] = dropRunning(running[b.Turf], victim.Agent)
+			continue
+		}
+		if !d.parkWork(victim.Agent, b.ID) {
+			continue
+		}
+		d.logger.Printf("Preempt: parking bead %s (P%d) on '%s' for urgent bead %s (P%d)\n",
+			victim.BeadID, victim.Priority, victim.Agent, b.ID, b.Priority)
+		running[b.Turf] = dropRunning(running[b.Turf], victim.Agent)
+	}
+}
+
+// runningByTurf returns each turf's soldati that are working on a bead, keyed
+// by the turf of the bead on their hook, and the turfs that have a soldati
+// with nothing to do
+func (d *Daemon) runningByTurf(agents []*registry.AgentRecord) (map[string][]preempt.Running, map[string]bool) {
+	running := make(map[string][]preempt.Running)
+	free := make(map[string]bool)
+	for _, rec := range agents {
+		d.mu.RLock()
+		work := d.work[rec.Name]
+		parking := work != nil && work.abort != abortNone
+		d.mu.RUnlock()
+
+		h := d.readHook(rec.Name)
+		if h == nil {
+			if rec.Status == registry.StatusIdle {
+				free[rec.Turf] = true
+			}
+			continue
+		}
+		if rec.Status != registry.StatusActive || parking || h.BeadID == "" {
+			continue
+		}
+		bead, err := d.beadStore.Get(h.BeadID)
+		if err != nil {
+			continue
+		}
+		// A configured soldati is registered on the mob directory and takes
+		// beads from any turf, so its work counts toward the bead's turf
+		running[bead.Turf] = append(running[bead.Turf], preempt.Running{
+			Agent: rec.Name, BeadID: h.BeadID, Priority: bead.Priority, Since: h.Timestamp,
+		})
+	}
+	return running, free
+}
+
+// readHook returns what is on a soldati's hook, or nil when it is empty
+func (d *Daemon) readHook(name string) *hook.Hook {
+	d.mu.RLock()
+	mgr, ok := d.hookManagers[name]
+	d.mu.RUnlock()
+	if !ok {
+		var err error
+		if mgr, err = hook.NewManager(filepath.Join(d.mobDir, ".mob", "soldati"), name); err != nil {
+			return nil
+		}
+	}
+	h, _ := mgr.Read()
+	return h
+}
+
+// dropRunning removes an agent's entry from a turf's running work
+func dropRunning(running []preempt.Running, agent string) []preempt.Running {
+	var kept []preempt.Running
+	for _, r := range running {
+		if r.Agent != agent {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// parkWork aborts a soldati's call so its assignment is parked for an urgent
+// bead, reporting whether there was a call to abort
+func (d *Daemon) parkWork(name, urgentID string) bool {
+	d.mu.Lock()
+	a := d.activeAgents[name]
+	work := d.work[name]
+	// Only a call that has started can be parked; queued work just waits
+	if a != nil && work != nil && work.abort == abortNone && !a.CallStartedAt().IsZero() {
+		work.parkFor = urgentID
+	} else {
+		work = nil
+	}
+	d.mu.Unlock()
+	if a == nil || work == nil {
+		return false
+	}
+	return d.abortWork(name, a, abortPark)
+}
+
+// parkAssignment sets an aborted assignment aside so the soldati can take the
+// urgent bead it was parked for, returning that bead. The parked bead stays
+// in progress on the soldati, which resumes it in the same session once free.
+func (d *Daemon) parkAssignment(name string, a *agent.Agent, h *hook.Hook, mgr *hook.Manager, work *assignmentWork) string {
+	d.mu.RLock()
+	urgentID := work.parkFor
+	d.mu.RUnlock()
+
+	entry := preempt.Entry{BeadID: h.BeadID, Agent: name, SessionID: a.SessionID, For: urgentID, ParkedAt: time.Now()}
+	bead, err := d.beadStore.Get(h.BeadID)
+	if err == nil {
+		entry.Turf, entry.Priority = bead.Turf, bead.Priority
+	}
+	if err := d.parked.Park(entry); err != nil {
+		d.logger.Printf("Preempt: failed to park bead %s: %v\n", h.BeadID, err)
+	}
+	if a.SessionID != "" {
+		d.registry.UpdateSession(a.ID, a.SessionID)
+	}
+
+	// The agent stays active until the urgent bead is on its hook, so patrol
+	// does not hand the parked work straight back
+	mgr.Clear()
+	d.registry.UpdateTask(a.ID, "")
+
+	note := fmt.Sprintf("Parked so %s can take urgent bead %s; it resumes here once %s is free", name, urgentID, name)
+	if err := d.beadStore.AddComment(h.BeadID, "daemon", note); err != nil {
+		d.logger.Printf("Preempt: failed to comment on parked bead %s: %v\n", h.BeadID, err)
+	}
+
+	parked := events.WorkParked{Agent: name, BeadID: h.BeadID, Priority: entry.Priority, Turf: entry.Turf, For: urgentID}
+	if urgent, err := d.beadStore.Get(urgentID); err == nil {
+		parked.ForPriority = urgent.Priority
+	}
+	d.publish(parked)
+	return urgentID
+}
+
+// assignUrgent gives a soldati whose work was just parked the urgent bead it
+// was parked for. If someone else took the bead meanwhile the soldati goes
+// idle and patrol hands its parked work back.
+func (d *Daemon) assignUrgent(name string, a *agent.Agent, urgentID string) {
+	err := d.assignBead(name, urgentID)
+	if err == nil {
+		d.logger.Printf("Preempt: assigned urgent bead %s to '%s'\n", urgentID, name)
+		return
+	}
+	if !errors.Is(err, errBeadMoved) {
+		d.logger.Printf("Preempt: failed to assign urgent bead %s to '%s': %v\n", urgentID, name, err)
+	}
+	d.registry.UpdateStatus(a.ID, registry.StatusIdle)
+}
+
+// resumeParked hands a free soldati back the oldest work parked on it,
+// reporting whether it did. Parked work the soldati no longer holds, because
+// the bead was closed or reassigned meanwhile, is dropped.
+func (d *Daemon) resumeParked(name string, now time.Time) bool {
+	for {
+		entry, err := d.parked.Next(name)
+		if err != nil {
+			d.logger.Printf("Preempt: failed to read parked work: %v\n", err)
+			return false
+		}
+		if entry == nil {
+			return false
+		}
+		bead, err := d.beadStore.Get(entry.BeadID)
+		if err != nil || bead.Status != models.BeadStatusInProgress || bead.Assignee != name {
+			if d.plan != nil {
+				return false
+			}
+			d.parked.Take(entry.BeadID)
+			continue
+		}
+		if !d.turfInHours(bead.Turf, now) {
+			d.planned(PlanStep{Action: PlanHold, Agent: name, BeadID: bead.ID, Detail: "parked work waits for its turf's working hours"})
+			return true // it waits for the turf's hours rather than giving way to new work
+		}
+		if d.planned(PlanStep{Action: PlanResume, Agent: name, BeadID: bead.ID, Detail: fmt.Sprintf("parked for %s", entry.For)}) {
+			return true
+		}
+		if err := d.AssignWork(name, bead.ID, bead.Title); err != nil {
+			d.logger.Printf("Preempt: failed to resume parked bead %s on '%s': %v\n", bead.ID, name, err)
+			return false
+		}
+		d.logger.Printf("Preempt: handing parked bead %s back to '%s'\n", bead.ID, name)
+		d.nudgeAgent(name)
+		return true
+	}
+}
+
+// releaseParked reopens parked work whose soldati is gone so any soldati can
+// pick it up
+func (d *Daemon) releaseParked(agents []*registry.AgentRecord) {
+	entries, err := d.parked.List()
+	if err != nil || len(entries) == 0 {
+		return
+	}
+	present := make(map[string]bool, len(agents))
+	for _, rec := range agents {
+		present[rec.Name] = true
+	}
+	for _, e := range entries {
+		if present[e.Agent] {
+			continue
+		}
+		bead, err := d.beadStore.Get(e.BeadID)
+		stale := err != nil || bead.Status != models.BeadStatusInProgress || bead.Assignee != e.Agent
+		if !stale && d.planned(PlanStep{Action: PlanReopen, Agent: e.Agent, BeadID: e.BeadID, Detail: "parked on a soldati that is gone"}) {
+			continue
+		}
+		if d.plan != nil {
+			continue
+		}
+		d.parked.Take(e.BeadID)
+		if stale {
+			continue
+		}
+		if _, err := d.updateBead(e.BeadID, func(b *models.Bead) error {
+			if b.Status != models.BeadStatusInProgress || b.Assignee != e.Agent {
+				return errBeadMoved
+			}
+			b.Status = models.BeadStatusOpen
+			b.Assignee = ""
+			return nil
+		}); err != nil {
+			if !errors.Is(err, errBeadMoved) {
+				d.logger.Printf("Preempt: failed to reopen parked bead %s: %v\n", e.BeadID, err)
+			}
+			continue
+		}
+		d.logger.Printf("Preempt: reopened bead %s, parked on '%s' which is gone\n", e.BeadID, e.Agent)
+	}
+}
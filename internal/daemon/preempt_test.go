@@ -0,0 +1,199 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/preempt"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+func TestUrgentBeadParksLowPriorityWork(t *testing.T) {
+	d, turfDir, low := newTurfTestDaemon(t)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	defer d.cancel()
+	d.registry = registry.New(registry.DefaultPath(d.mobDir))
+	d.setupPool()
+
+	// Use up the day's nudges so none start a call of their own
+	d.cfg.Daemon.NudgesPerDay = 1
+	d.setupNudges()
+	d.nudges.Nudged("sal", "", time.Now())
+
+	low.Priority = 3
+	low.Status = models.BeadStatusInProgress
+	low.Assignee = "vinnie"
+	if _, err := d.beadStore.Update(low); err != nil {
+		t.Fatal(err)
+	}
+
+	// The call runs until it is aborted
+	d.spawner = agent.NewSpawner()
+	d.spawner.SetCommandCreator(func(name string, args ...string) *exec.Cmd {
+		return exec.Command("sleep", "30")
+	})
+	a, err := d.spawner.Spawn(agent.AgentTypeSoldati, "vinnie", "backend", turfDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.SessionID = "sess-low"
+	if err := d.registry.Register(&registry.AgentRecord{ID: a.ID, Type: "soldati", Name: "vinnie", Turf: "backend", Status: registry.StatusIdle}); err != nil {
+		t.Fatal(err)
+	}
+	mgr, err := hook.NewManager(filepath.Join(d.mobDir, ".mob", "soldati"), "vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &hook.Hook{Type: hook.HookTypeAssign, BeadID: low.ID, Message: low.Title, Timestamp: time.Now()}
+	if err := mgr.Write(h); err != nil {
+		t.Fatal(err)
+	}
+	d.activeAgents["vinnie"], d.hookManagers["vinnie"] = a, mgr
+
+	var parked []events.WorkParked
+	d.events.Subscribe(func(e events.Event) {
+		if p, ok := e.(events.WorkParked); ok {
+			parked = append(parked, p)
+		}
+	})
+
+	d.handleAssignment("vinnie", a, h, mgr, "")
+	waitFor(t, "the call to start", func() bool { return !a.CallStartedAt().IsZero() })
+
+	// Nothing urgent: the work is left alone
+	d.preemptForUrgent(time.Now())
+	if len(parked) != 0 {
+		t.Fatal("expected no preemption without an urgent bead")
+	}
+
+	urgent, err := d.beadStore.Create(&models.Bead{Title: "Prod is down", Status: models.BeadStatusOpen, Turf: "backend", Priority: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.preemptForUrgent(time.Now())
+	waitFor(t, "the urgent bead to be assigned", func() bool {
+		got, _ := d.beadStore.Get(urgent.ID)
+		return got.Status == models.BeadStatusInProgress && got.Assignee == "vinnie"
+	})
+	if hk, _ := mgr.Read(); hk == nil || hk.BeadID != urgent.ID {
+		t.Fatalf("expected the urgent bead on vinnie's hook, got %+v", hk)
+	}
+	if len(parked) != 1 || parked[0].BeadID != low.ID || parked[0].For != urgent.ID || parked[0].ForPriority != 0 {
+		t.Fatalf("expected one WorkParked for the low-priority bead, got %+v", parked)
+	}
+	entry, _ := d.parked.Next("vinnie")
+	if entry == nil || entry.BeadID != low.ID || entry.SessionID != "sess-low" {
+		t.Fatalf("expected the low-priority bead parked with its session, got %+v", entry)
+	}
+	got, _ := d.beadStore.Get(low.ID)
+	if got.Status != models.BeadStatusInProgress || got.Assignee != "vinnie" {
+		t.Errorf("expected the parked bead kept on vinnie, got %s/%s", got.Status, got.Assignee)
+	}
+
+	// Once vinnie is free again the parked bead comes back before new work
+	waitFor(t, "the parked call to end", func() bool { return a.CallStartedAt().IsZero() })
+	mgr.Clear()
+	a.SessionID = "sess-urgent"
+	urgent, _ = d.beadStore.Get(urgent.ID)
+	urgent.Status = models.BeadStatusClosed
+	if _, err := d.beadStore.Update(urgent); err != nil {
+		t.Fatal(err)
+	}
+	d.registry.UpdateStatus(a.ID, registry.StatusIdle)
+	d.assignWorkToIdleAgents()
+	hk, _ := mgr.Read()
+	if hk == nil || hk.BeadID != low.ID {
+		t.Fatalf("expected the parked bead handed back, got %+v", hk)
+	}
+
+	d.handleAssignment("vinnie", a, hk, mgr, "")
+	waitFor(t, "the resumed call to start", func() bool { return !a.CallStartedAt().IsZero() })
+	if a.SessionID != "sess-low" {
+		t.Errorf("expected the parked session restored, got %q", a.SessionID)
+	}
+	if all, _ := d.parked.List(); len(all) != 0 {
+		t.Errorf("expected the parked entry taken once resumed, got %+v", all)
+	}
+	a.Abort()
+	waitFor(t, "the resumed call to end", func() bool {
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+		return d.work["vinnie"] == nil
+	})
+}
+
+func TestReleaseParkedReopensWorkOfMissingSoldati(t *testing.T) {
+	d, _, bead := newTurfTestDaemon(t)
+	bead.Status = models.BeadStatusInProgress
+	bead.Assignee = "vinnie"
+	if _, err := d.beadStore.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+	d.parked.Park(preempt.Entry{BeadID: bead.ID, Agent: "vinnie", For: "bd-urgent", ParkedAt: time.Now()})
+
+	d.releaseParked(nil)
+	got, _ := d.beadStore.Get(bead.ID)
+	if got.Status != models.BeadStatusOpen || got.Assignee != "" {
+		t.Errorf("expected the bead reopened, got %s/%s", got.Status, got.Assignee)
+	}
+	if all, _ := d.parked.List(); len(all) != 0 {
+		t.Errorf("expected the entry dropped, got %+v", all)
+	}
+}
+
+func TestRunningByTurfKeysWorkByHookedBead(t *testing.T) {
+	d, _, bead := newTurfTestDaemon(t)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	defer d.cancel()
+	d.spawner = agent.NewSpawner()
+	d.registry = registry.New(registry.DefaultPath(d.mobDir))
+	mgr, err := soldati.NewManager(filepath.Join(d.mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.soldatiMgr = mgr
+	if _, err := mgr.Create("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.spawnSoldatiAgent("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	record, err := d.registry.GetByName("vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.registry.UpdateStatus(record.ID, registry.StatusActive)
+
+	bead.Priority, bead.Status, bead.Assignee = 3, models.BeadStatusInProgress, "vinnie"
+	if _, err := d.beadStore.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+	hooks, err := hook.NewManager(filepath.Join(d.mobDir, ".mob", "soldati"), "vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hooks.Write(&hook.Hook{Type: hook.HookTypeAssign, BeadID: bead.ID, Message: bead.Title}); err != nil {
+		t.Fatal(err)
+	}
+
+	agents, err := d.registry.ListByType("soldati")
+	if err != nil {
+		t.Fatal(err)
+	}
+	running, _ := d.runningByTurf(agents)
+	got := running["backend"]
+	if len(got) != 1 || got[0].Agent != "vinnie" || got[0].BeadID != bead.ID || got[0].Priority != 3 {
+		t.Fatalf("running on backend = %+v, want vinnie's bead; all running = %+v", got, running)
+	}
+	if victim := preempt.PolicyFromConfig(d.cfg.Preemption).Victim(0, got); victim == nil || victim.Agent != "vinnie" {
+		t.Errorf("expected vinnie's work preemptible for an urgent backend bead, got %+v", victim)
+	}
+}
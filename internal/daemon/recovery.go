@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/reaper"
+	"github.com/gabe/mob/internal/reconcile"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+// Stale-state recovery timings
+const (
+	reapGrace    = 5 * time.Second // how long orphaned calls get to exit before SIGKILL
+	staleLockAge = time.Minute     // git holds locks for moments; older ones were abandoned
+)
+
+// trackAgentProcesses runs every claude call the daemon makes in its own
+// process group and records it, so a restart after a crash can reap them
+func (d *Daemon) trackAgentProcesses() {
+	tracker := reaper.NewTracker(reaper.ProcsPath(d.mobDir))
+	tracker.OnError = func(err error) {
+		d.logger.Printf("Warning: failed to track agent process: %v\n", err)
+	}
+	d.spawner.SetProcessTracker(tracker)
+}
+
+// recoverFromCrash cleans up after a previous daemon that was killed without
+// shutting down. It kills the agent calls that daemon left running, which
+// would otherwise keep working on beads the new daemon reassigns, and clears
+// the worktree locks killed git processes left behind.
+func (d *Daemon) recoverFromCrash(stalePID bool) {
+	reaped, err := reaper.Reap(reaper.ProcsPath(d.mobDir), reapGrace)
+	if err != nil {
+		d.logger.Printf("Recovery: %v\n", err)
+	}
+	for _, p := range reaped {
+		d.logger.Printf("Recovery: killed orphaned process group %d of agent %s (started %s)\n", p.PGID, p.Agent, p.StartedAt.Format(time.RFC3339))
+		if d.registry != nil {
+			d.registry.UpdatePID(p.Agent, 0)
+		}
+	}
+
+	if !stalePID && len(reaped) == 0 {
+		return
+	}
+
+	var cleared []string
+	if d.turfMgr != nil {
+		for _, t := range d.turfMgr.List() {
+			repo, err := vcs.Open(t.Path, t.VCS)
+			if err != nil {
+				continue
+			}
+			locks, err := repo.ClearStaleLocks(staleLockAge)
+			if err != nil {
+				d.logger.Printf("Recovery: failed to clear locks in turf %s: %v\n", t.Name, err)
+			}
+			cleared = append(cleared, locks...)
+		}
+	}
+	for _, lock := range cleared {
+		d.logger.Printf("Recovery: removed stale lock %s\n", lock)
+	}
+
+	d.publish(events.DaemonRecovered{Reaped: len(reaped), Cleared: len(cleared)})
+}
+
+// reconcile repairs the registry, beads and worktrees against what is
+// actually running. fresh is set when this process starts without sessions
+// handed over, so no soldati is working yet whatever the registry says.
+func (d *Daemon) reconcile(fresh bool) {
+	r := &reconcile.Reconciler{
+		Registry: d.registry,
+		Beads:    d.beadStore,
+		Actor:    "daemon",
+		Fresh:    fresh,
+	}
+	if d.turfMgr != nil {
+		r.Turfs = d.turfMgr.List()
+	}
+	if d.soldatiMgr != nil {
+		if soldati, err := d.soldatiMgr.List(); err == nil {
+			for _, s := range soldati {
+				r.Soldati = append(r.Soldati, s.Name)
+			}
+		}
+	}
+
+	report := r.Run(false)
+	for _, f := range report.Fixes {
+		d.logger.Printf("Reconcile: %s %s: %s\n", f.Kind, reconcileSubject(f), f.Detail)
+	}
+	for _, f := range report.Skipped {
+		d.logger.Printf("Reconcile: %s %s: %s\n", f.Kind, reconcileSubject(f), f.Detail)
+	}
+	for _, err := range report.Errors {
+		d.logger.Printf("Reconcile: %v\n", err)
+	}
+}
+
+// reconcileSubject names what a fix was about in the log
+func reconcileSubject(f reconcile.Fix) string {
+	if f.Kind == reconcile.KindDeadAgent {
+		return f.Name
+	}
+	return f.ID
+}
@@ -0,0 +1,127 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/models"
+)
+
+// defaultSoldatiModel is used by soldati whose definition names no model
+const defaultSoldatiModel = "sonnet"
+
+// soldatiDefinition reads a soldati's TOML and its fingerprint. Both are
+// empty when the definition cannot be read.
+func (d *Daemon) soldatiDefinition(name string) (*models.Soldati, string) {
+	if d.soldatiMgr == nil {
+		return nil, ""
+	}
+	def, err := d.soldatiMgr.Get(name)
+	if err != nil {
+		return nil, ""
+	}
+	fingerprint, err := d.soldatiMgr.Fingerprint(name)
+	if err != nil {
+		return def, ""
+	}
+	return def, fingerprint
+}
+
+// soldatiSpawnOptions builds a soldati's session from its definition and
+// remembers which definition the session started from
+func (d *Daemon) soldatiSpawnOptions(name, turf, workDir, mcpConfig string) agent.SpawnOptions {
+	def, fingerprint := d.soldatiDefinition(name)
+
+	prompt := d.systemPrompt(agent.PromptSoldati, agent.PromptVars{Name: name, Turf: turf})
+	model := defaultSoldatiModel
+	if def != nil {
+		if def.Role != "" {
+			prompt += "\n\n## Your Role\n\n" + def.Role + "\n"
+		}
+		if len(def.Skills) > 0 {
+			prompt += "\n\n## Your Skills\n\n" + strings.Join(def.Skills, ", ") + ". Beads labelled with these come to you first.\n"
+		}
+		if def.Model != "" {
+			model = def.Model
+		}
+	}
+
+	d.mu.Lock()
+	d.definitions[name] = fingerprint
+	d.mu.Unlock()
+
+	return agent.SpawnOptions{
+		Type:         agent.AgentTypeSoldati,
+		Name:         name,
+		Turf:         turf,
+		WorkDir:      workDir,
+		SystemPrompt: prompt,
+		MCPConfig:    mcpConfig,
+		Model:        model,
+	}
+}
+
+// requestReload restarts a soldati's session with its current definition,
+// waiting for in-flight work to finish first
+func (d *Daemon) requestReload(name string) {
+	d.mu.Lock()
+	d.reloads[name] = true
+	d.mu.Unlock()
+	d.checkDefinition(name)
+}
+
+// checkDefinition restarts an idle soldati's session when its TOML changed
+// since the session started or a reload was requested. Busy soldati are left
+// alone; this runs again when their work finishes and on every patrol.
+func (d *Daemon) checkDefinition(name string) {
+	d.mu.RLock()
+	_, running := d.activeAgents[name]
+	_, busy := d.work[name]
+	started := d.definitions[name]
+	requested := d.reloads[name]
+	d.mu.RUnlock()
+
+	if !running || busy || d.registry == nil {
+		return
+	}
+	if !requested {
+		_, current := d.soldatiDefinition(name)
+		if current == "" || started == "" || current == started {
+			return
+		}
+		d.logger.Printf("Patrol: soldati '%s' definition changed, restarting its session\n", name)
+	}
+
+	if err := d.reloadSoldati(name); err != nil {
+		d.logger.Printf("Patrol: failed to reload soldati '%s': %v\n", name, err)
+	}
+}
+
+// reloadSoldati ends a soldati's session and starts a fresh one from its
+// current definition, keeping its registry entry
+func (d *Daemon) reloadSoldati(name string) error {
+	record, err := d.registry.GetByName(name)
+	if err != nil {
+		return err
+	}
+
+	d.stopHookWatcher(name)
+	d.mu.Lock()
+	old := d.activeAgents[name]
+	delete(d.activeAgents, name)
+	delete(d.reloads, name)
+	delete(d.briefedTurf, name) // the new session has not been briefed
+	d.mu.Unlock()
+	if old != nil {
+		d.spawner.Kill(old.ID)
+	}
+
+	record.SessionID = ""
+	record.PID = 0
+	if err := d.respawnSoldati(name, record); err != nil {
+		return fmt.Errorf("failed to restart session: %w", err)
+	}
+	d.logger.Printf("Patrol: soldati '%s' reloaded\n", name)
+	return nil
+}
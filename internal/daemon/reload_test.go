@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+func TestSoldatiReloadsEditedDefinition(t *testing.T) {
+	mobDir := t.TempDir()
+	d := New(mobDir, log.New(io.Discard, "", 0))
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	defer d.cancel()
+	d.spawner = agent.NewSpawner()
+	d.registry = registry.New(registry.DefaultPath(mobDir))
+	mgr, err := soldati.NewManager(filepath.Join(mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.soldatiMgr = mgr
+
+	def, err := mgr.Create("vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	def.Role = "Frontend specialist: React and CSS."
+	def.Model = "opus"
+	if err := mgr.Update(def); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.spawnSoldatiAgent("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	first := d.activeAgents["vinnie"]
+	if first.Model != "opus" || !strings.Contains(first.SystemPrompt, "Frontend specialist") {
+		t.Fatalf("expected the definition's model and role, got %q / %q", first.Model, first.SystemPrompt)
+	}
+	record, err := d.registry.GetByName("vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.checkDefinition("vinnie")
+	if d.activeAgents["vinnie"] != first {
+		t.Fatal("expected an unchanged definition to keep the session")
+	}
+
+	// Edits wait for in-flight work
+	work := &assignmentWork{cancel: func() {}}
+	d.work["vinnie"] = work
+	def.Model = "haiku"
+	if err := mgr.Update(def); err != nil {
+		t.Fatal(err)
+	}
+	d.checkDefinition("vinnie")
+	if d.activeAgents["vinnie"] != first {
+		t.Fatal("expected a busy soldati to keep its session")
+	}
+
+	d.finishWork("vinnie", work)
+	second := d.activeAgents["vinnie"]
+	if second == first || second.Model != "haiku" {
+		t.Fatalf("expected a new session on haiku once work finished, got %+v", second)
+	}
+	if _, ok := d.spawner.Get(first.ID); ok {
+		t.Error("expected the old session to be dropped")
+	}
+	if updated, err := d.registry.GetByName("vinnie"); err != nil || updated.ID != record.ID {
+		t.Errorf("expected the registry entry kept, got %+v (%v)", updated, err)
+	}
+
+	// An explicit reload restarts even without edits
+	d.requestReload("vinnie")
+	if third := d.activeAgents["vinnie"]; third == second || third.Model != "haiku" {
+		t.Fatalf("expected a fresh session, got %+v", third)
+	}
+	if d.reloads["vinnie"] {
+		t.Error("expected the reload request cleared")
+	}
+}
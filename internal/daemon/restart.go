@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// sessionHandoff is a soldati's session passed across a restart or upgrade,
+// so the new process resumes the conversation rather than starting over
+type sessionHandoff struct {
+	Name        string    `json:"name"`
+	ID          string    `json:"id"`
+	SessionID   string    `json:"session_id,omitempty"`
+	Turf        string    `json:"turf,omitempty"`
+	WorkDir     string    `json:"work_dir,omitempty"`
+	Model       string    `json:"model,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	BriefedTurf string    `json:"briefed_turf,omitempty"`
+	Definition  string    `json:"definition,omitempty"`  // fingerprint of the TOML the session started from
+	Interrupted bool      `json:"interrupted,omitempty"` // its assignment was cut short by the restart and is picked up again
+}
+
+// restartNote is put before an assignment a restart interrupted when the
+// soldati's session picks it back up
+const restartNote = "The mob daemon restarted while you were working on this. Pick up where you left off."
+
+// interruptAssignments cancels running and queued assignments ahead of a
+// restart and returns the soldati they belonged to. Their hooks are left in
+// place for the new process to pick up.
+func (d *Daemon) interruptAssignments() map[string]bool {
+	d.mu.RLock()
+	names := make([]string, 0, len(d.work))
+	for name := range d.work {
+		names = append(names, name)
+	}
+	d.mu.RUnlock()
+
+	interrupted := make(map[string]bool, len(names))
+	for _, name := range names {
+		d.pool.Cancel(name)
+		if d.cancelWork(name) {
+			interrupted[name] = true
+			d.logger.Printf("Restart: interrupted work for soldati '%s'\n", name)
+		}
+	}
+	return interrupted
+}
+
+// handOffSessions lists the soldati sessions to resume after the exec
+func (d *Daemon) handOffSessions(interrupted map[string]bool) []sessionHandoff {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	sessions := make([]sessionHandoff, 0, len(d.activeAgents))
+	for name, a := range d.activeAgents {
+		sessions = append(sessions, sessionHandoff{
+			Name:        name,
+			ID:          a.ID,
+			SessionID:   a.SessionID,
+			Turf:        a.Turf,
+			WorkDir:     a.WorkDir,
+			Model:       a.Model,
+			StartedAt:   a.StartedAt,
+			BriefedTurf: d.briefedTurf[name],
+			Definition:  d.definitions[name],
+			Interrupted: interrupted[name],
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Name < sessions[j].Name })
+	return sessions
+}
+
+// resumeSessions rebuilds the soldati sessions the previous process handed
+// over, restarts their hook watchers and picks interrupted assignments back
+// up. A soldati whose definition or registry entry has gone is left for
+// patrol to sort out.
+func (d *Daemon) resumeSessions(sessions []sessionHandoff) {
+	resumed := 0
+	for _, s := range sessions {
+		if _, err := os.Stat(filepath.Join(d.mobDir, "soldati", s.Name+".toml")); err != nil {
+			continue
+		}
+		record, err := d.registry.Get(s.ID)
+		if err != nil || record == nil {
+			continue
+		}
+
+		opts := d.soldatiSpawnOptions(s.Name, s.Turf, s.WorkDir, d.mcpConfig(d.hookedToolTurf(s.Name)))
+		opts.ID, opts.SessionID = s.ID, s.SessionID
+		if s.Model != "" {
+			opts.Model = s.Model
+		}
+		a, err := d.spawner.SpawnWithOptions(opts)
+		if err != nil {
+			d.logger.Printf("Restart: failed to resume soldati '%s': %v\n", s.Name, err)
+			continue
+		}
+		a.StartedAt = s.StartedAt
+
+		d.mu.Lock()
+		d.activeAgents[s.Name] = a
+		d.definitions[s.Name] = s.Definition
+		if s.BriefedTurf != "" {
+			d.briefedTurf[s.Name] = s.BriefedTurf
+		}
+		d.mu.Unlock()
+
+		if err := d.startHookWatcher(s.Name, a); err != nil {
+			d.logger.Printf("Restart: warning - failed to start hook watcher for '%s': %v\n", s.Name, err)
+		}
+		if s.Interrupted {
+			d.resumeAssignment(s.Name)
+		}
+		resumed++
+		d.publish(events.AgentSpawned{Agent: s.Name, Turf: s.Turf, Respawned: true})
+	}
+	if resumed > 0 {
+		d.logger.Printf("Restart: resumed %d soldati session(s)\n", resumed)
+	}
+}
+
+// resumeAssignment runs the assignment still on a soldati's hook again, in
+// the session it was interrupted in
+func (d *Daemon) resumeAssignment(name string) {
+	d.mu.RLock()
+	a, mgr := d.activeAgents[name], d.hookManagers[name]
+	d.mu.RUnlock()
+	if a == nil || mgr == nil {
+		return
+	}
+
+	h, err := mgr.Read()
+	if err != nil || h == nil || h.Type != hook.HookTypeAssign {
+		d.registry.UpdateStatus(a.ID, registry.StatusIdle)
+		d.registry.UpdateTask(a.ID, "")
+		return
+	}
+	d.logger.Printf("Restart: resuming bead %s for soldati '%s'\n", h.BeadID, name)
+	d.handleAssignment(name, a, h, mgr, restartNote)
+}
@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+// restartTestDaemon is a daemon over mobDir with the pieces sessions need
+func restartTestDaemon(t *testing.T, mobDir string) *Daemon {
+	t.Helper()
+	d := New(mobDir, log.New(io.Discard, "", 0))
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	t.Cleanup(d.cancel)
+	d.spawner = agent.NewSpawner()
+	d.registry = registry.New(registry.DefaultPath(mobDir))
+	mgr, err := soldati.NewManager(filepath.Join(mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.soldatiMgr = mgr
+	return d
+}
+
+func TestRestartResumesSessions(t *testing.T) {
+	mobDir := t.TempDir()
+	old := restartTestDaemon(t, mobDir)
+	if _, err := old.soldatiMgr.Create("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	if err := old.spawnSoldatiAgent("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	a := old.activeAgents["vinnie"]
+	a.SessionID, a.Model = "sess-vinnie", "opus"
+	old.briefedTurf["vinnie"] = "api"
+
+	sessions := old.handOffSessions(map[string]bool{})
+	if len(sessions) != 1 || sessions[0].SessionID != "sess-vinnie" || sessions[0].Interrupted {
+		t.Fatalf("handoff = %+v", sessions)
+	}
+	old.cancel() // the exec ends the old process's watchers
+
+	d := restartTestDaemon(t, mobDir)
+	d.resumeSessions(sessions)
+
+	got, ok := d.activeAgents["vinnie"]
+	if !ok {
+		t.Fatal("vinnie was not resumed")
+	}
+	if got.ID != a.ID || got.SessionID != "sess-vinnie" || got.Model != "opus" || !got.StartedAt.Equal(a.StartedAt) {
+		t.Errorf("resumed %+v, want the handed-over session", got)
+	}
+	if d.briefedTurf["vinnie"] != "api" {
+		t.Error("briefing was not carried over")
+	}
+	if _, ok := d.hookManagers["vinnie"]; !ok {
+		t.Error("hook watcher was not restarted")
+	}
+}
+
+func TestRestartSkipsRemovedSoldati(t *testing.T) {
+	mobDir := t.TempDir()
+	d := restartTestDaemon(t, mobDir)
+
+	d.resumeSessions([]sessionHandoff{{Name: "ghost", ID: "0123456789abcdef", SessionID: "sess-ghost"}})
+	if _, ok := d.activeAgents["ghost"]; ok {
+		t.Error("a soldati with no definition should be left for patrol")
+	}
+}
@@ -0,0 +1,81 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/router"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// routeModel picks the model for a bead (the soldati's own model when its
+// definition sets one), switches the agent to it, and records the choice on
+// the bead
+func (d *Daemon) routeModel(a *agent.Agent, beadID string) {
+	if d.beadStore == nil {
+		return
+	}
+
+	bead, err := d.beadStore.Get(beadID)
+	if err != nil {
+		return
+	}
+
+	// A soldati whose definition names a model keeps it for all of its work
+	model := ""
+	if def, _ := d.soldatiDefinition(a.Name); def != nil {
+		model = def.Model
+	}
+	if model == "" {
+		history, err := d.beadStore.List(storage.BeadFilter{Status: models.BeadStatusClosed})
+		if err != nil {
+			history = nil
+		}
+		model = router.New(d.cfg.Routing).Route(bead, history)
+	}
+	a.Model = model
+
+	if bead.Model != model {
+		if _, err := d.updateBead(beadID, func(b *models.Bead) error {
+			b.Model = model
+			return nil
+		}); err != nil {
+			d.logger.Printf("Router: failed to record model on bead %s: %v\n", beadID, err)
+		}
+	}
+	d.logger.Printf("Router: bead %s (%s, P%d) routed to %s\n", beadID, bead.Type, bead.Priority, model)
+}
+
+// recordBeadCost adds the cost of an agent call to the bead's running total
+func (d *Daemon) recordBeadCost(beadID string, cost float64) {
+	if d.beadStore == nil || cost <= 0 {
+		return
+	}
+
+	if _, err := d.updateBead(beadID, func(b *models.Bead) error {
+		b.CostUSD += cost
+		return nil
+	}); err != nil {
+		d.logger.Printf("Router: failed to record cost on bead %s: %v\n", beadID, err)
+	}
+}
+
+// recordUsage records a soldati's call, and the turf and bead it was for, in
+// the usage and spend ledgers
+func (d *Daemon) recordUsage(name string, a *agent.Agent, beadID string, resp *agent.ChatResponse) {
+	model := resp.Model
+	if model == "" {
+		model = a.Model
+	}
+	entry := models.UsageEntry{
+		Agent:        name,
+		Turf:         d.assignmentTurf(a, beadID),
+		BeadID:       beadID,
+		Model:        model,
+		InputTokens:  resp.InputTokens,
+		OutputTokens: resp.OutputTokens,
+		CostUSD:      resp.TotalCost,
+	}
+	if err := storage.RecordCall(d.mobDir, entry); err != nil {
+		d.logger.Printf("Warning: failed to record usage: %v\n", err)
+	}
+}
@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestRouteModelRecordsChoice(t *testing.T) {
+	d := New(t.TempDir(), log.New(io.Discard, "", 0))
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.beadStore = store
+
+	bead, err := store.Create(&models.Bead{Title: "Bump deps", Status: models.BeadStatusOpen, Type: models.BeadTypeChore, Priority: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &agent.Agent{Model: "sonnet"}
+	d.routeModel(a, bead.ID)
+
+	if a.Model != "haiku" {
+		t.Errorf("expected chore routed to haiku, got %q", a.Model)
+	}
+	updated, err := store.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Model != "haiku" {
+		t.Errorf("expected model recorded on bead, got %q", updated.Model)
+	}
+
+	d.recordBeadCost(bead.ID, 0.25)
+	d.recordBeadCost(bead.ID, 0.5)
+	updated, _ = store.Get(bead.ID)
+	if updated.CostUSD != 0.75 {
+		t.Errorf("expected accumulated cost 0.75, got %v", updated.CostUSD)
+	}
+}
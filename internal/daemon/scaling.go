@@ -0,0 +1,233 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// scaleSoldati adds soldati to turfs whose ready backlog outgrows the ones
+// already added for them, up to [scaling] max_per_turf, and retires those it
+// added once their turf's queue has stayed empty for retire_after. Soldati
+// created by hand are never touched. Outside working hours none are added.
+func (d *Daemon) scaleSoldati(now time.Time, working bool) {
+	if d.beadStore == nil || d.soldatiMgr == nil {
+		return
+	}
+
+	all, err := d.soldatiMgr.List()
+	if err != nil {
+		d.logger.Printf("Scaling: failed to list soldati: %v\n", err)
+		return
+	}
+	scaled := make(map[string][]string) // keyed by turf, soldati added for it
+	for _, s := range all {
+		if s.AutoScaled && s.PrimaryTurf != "" {
+			scaled[s.PrimaryTurf] = append(scaled[s.PrimaryTurf], s.Name)
+		}
+	}
+	if len(scaled) == 0 && d.cfg.Scaling.MaxPerTurf <= 0 && len(d.cfg.Scaling.Turfs) == 0 {
+		return
+	}
+
+	ready, err := d.beadStore.ListReady("")
+	if err != nil {
+		d.logger.Printf("Scaling: failed to list ready beads: %v\n", err)
+		return
+	}
+	backlog := turfBacklogs(d.inHoursBeads(ready, now))
+
+	// An upgrade waits for work to finish, so it is no time to add more
+	if working && d.upgrade == nil {
+		d.scaleUp(backlog, scaled)
+	}
+
+	retireAfter := d.cfg.Scaling.GetRetireAfter()
+	for turf := range d.drainedAt {
+		if len(scaled[turf]) == 0 {
+			delete(d.drainedAt, turf)
+		}
+	}
+	for _, turf := range sortedTurfs(scaled) {
+		if backlog[turf] > 0 {
+			delete(d.drainedAt, turf)
+			continue
+		}
+		since, ok := d.drainedAt[turf]
+		if !ok {
+			since = now
+			d.drainedAt[turf] = now
+		}
+		if now.Sub(since) < retireAfter {
+			continue
+		}
+		for _, name := range scaled[turf] {
+			if d.soldatiBusy(name) {
+				continue
+			}
+			if d.planned(PlanStep{Action: PlanRetire, Agent: name, Detail: fmt.Sprintf("turf '%s' drained", turf)}) {
+				continue
+			}
+			if err := d.retireSoldati(name, turf); err != nil {
+				d.logger.Printf("Scaling: failed to retire soldati '%s': %v\n", name, err)
+			}
+		}
+	}
+}
+
+// scaleUp adds the soldati each turf's backlog calls for, skipping turfs at
+// their WIP limit since more hands could not start anything there
+func (d *Daemon) scaleUp(backlog map[string]int, scaled map[string][]string) {
+	var inProgress map[string]int
+	for _, turf := range sortedTurfs(backlog) {
+		policy := d.cfg.Scaling.PolicyFor(turf)
+		wanted := policy.Wanted(backlog[turf])
+		if len(scaled[turf]) >= wanted {
+			continue
+		}
+		if d.turfMgr == nil {
+			return
+		}
+		if _, err := d.turfMgr.Get(turf); err != nil {
+			continue // beads filed against a turf that is not registered
+		}
+
+		if inProgress == nil {
+			beads, err := d.beadStore.List(storage.BeadFilter{Status: models.BeadStatusInProgress})
+			if err != nil {
+				d.logger.Printf("Scaling: failed to count in-progress beads: %v\n", err)
+				return
+			}
+			inProgress = countInProgress(beads)
+		}
+		if limit := d.cfg.Flow.WIPLimit(turf); limit > 0 && inProgress[turf] >= limit {
+			continue
+		}
+
+		for len(scaled[turf]) < wanted {
+			if d.planned(PlanStep{Action: PlanScaleUp, Detail: fmt.Sprintf("turf '%s' has %d ready bead(s) (%d/%d added)",
+				turf, backlog[turf], len(scaled[turf])+1, policy.MaxPerTurf)}) {
+				scaled[turf] = append(scaled[turf], "")
+				continue
+			}
+			name, err := d.addSoldati(turf)
+			if err != nil {
+				d.logger.Printf("Scaling: failed to add soldati to turf '%s': %v\n", turf, err)
+				break
+			}
+			scaled[turf] = append(scaled[turf], name)
+			d.logger.Printf("Scaling: added soldati '%s' to turf '%s' for %d ready bead(s) (%d/%d added)\n",
+				name, turf, backlog[turf], len(scaled[turf]), policy.MaxPerTurf)
+		}
+		delete(d.drainedAt, turf)
+	}
+}
+
+// addSoldati creates a soldati bound to turf, marked as added by the daemon,
+// and starts its agent
+func (d *Daemon) addSoldati(turf string) (string, error) {
+	s, err := d.soldatiMgr.Create("")
+	if err != nil {
+		return "", err
+	}
+	s.Turfs = []string{turf}
+	s.PrimaryTurf = turf
+	s.AutoScaled = true
+	if err := d.soldatiMgr.Update(s); err != nil {
+		d.soldatiMgr.Delete(s.Name)
+		return "", err
+	}
+	if err := d.spawnSoldatiAgent(s.Name); err != nil {
+		d.soldatiMgr.Delete(s.Name)
+		return "", err
+	}
+	return s.Name, nil
+}
+
+// soldatiBusy reports whether a soldati has work in flight, on its hook or
+// is anything but idle
+func (d *Daemon) soldatiBusy(name string) bool {
+	d.mu.RLock()
+	_, working := d.work[name]
+	mgr, hasHook := d.hookManagers[name]
+	d.mu.RUnlock()
+	if working {
+		return true
+	}
+	if hasHook {
+		if h, err := mgr.Read(); err == nil && h != nil {
+			return true
+		}
+	}
+	if d.registry != nil {
+		if rec, err := d.registry.GetByName(name); err == nil && rec.Status != registry.StatusIdle {
+			return true
+		}
+	}
+	return false
+}
+
+// retireSoldati stops a soldati the daemon added and removes it for good
+func (d *Daemon) retireSoldati(name, turf string) error {
+	d.stopHookWatcher(name)
+	d.mu.Lock()
+	if a, ok := d.activeAgents[name]; ok {
+		a.Kill()
+		delete(d.activeAgents, name)
+	}
+	delete(d.briefedTurf, name)
+	delete(d.definitions, name)
+	delete(d.reloads, name)
+	d.mu.Unlock()
+	d.nudges.Forget(name)
+
+	if d.registry != nil {
+		if rec, err := d.registry.GetByName(name); err == nil {
+			d.registry.Unregister(rec.ID)
+		}
+	}
+	if err := d.soldatiMgr.Delete(name); err != nil {
+		return fmt.Errorf("failed to remove soldati: %w", err)
+	}
+	d.publish(events.AgentRetired{Agent: name, Turf: turf})
+	return nil
+}
+
+// turfBacklogs counts ready beads per turf, leaving out beads with no turf
+func turfBacklogs(ready []*models.Bead) map[string]int {
+	backlog := make(map[string]int)
+	for _, b := range ready {
+		if b.Turf != "" {
+			backlog[b.Turf]++
+		}
+	}
+	return backlog
+}
+
+// sortedTurfs returns a map's turf names in order, so scaling decisions are
+// made the same way every patrol
+func sortedTurfs[V any](m map[string]V) []string {
+	turfs := make([]string, 0, len(m))
+	for turf := range m {
+		turfs = append(turfs, turf)
+	}
+	sort.Strings(turfs)
+	return turfs
+}
+
+// scaledTurf returns the turf a soldati was added for, empty for soldati
+// created by hand
+func (d *Daemon) scaledTurf(name string) string {
+	if d.soldatiMgr == nil {
+		return ""
+	}
+	if s, err := d.soldatiMgr.Get(name); err == nil && s.AutoScaled {
+		return s.PrimaryTurf
+	}
+	return ""
+}
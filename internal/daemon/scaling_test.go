@@ -0,0 +1,107 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+func TestScaleSoldati(t *testing.T) {
+	d, _, _ := newTurfTestDaemon(t)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	defer d.cancel()
+	d.spawner = agent.NewSpawner()
+	d.registry = registry.New(registry.DefaultPath(d.mobDir))
+	mgr, err := soldati.NewManager(filepath.Join(d.mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.soldatiMgr = mgr
+	if _, err := mgr.Create("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	d.cfg.Scaling = config.ScalingConfig{BacklogThreshold: 2, MaxPerTurf: 3, RetireAfter: "1m"}
+
+	var beads []*models.Bead
+	for i := 0; i < 4; i++ {
+		b, err := d.beadStore.Create(&models.Bead{Title: "More work", Status: models.BeadStatusOpen, Turf: "backend"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		beads = append(beads, b)
+	}
+
+	// Five ready beads at two per added soldati calls for two
+	now := time.Now()
+	d.scaleSoldati(now, false)
+	if added := scaledSoldati(t, mgr); len(added) != 0 {
+		t.Fatalf("expected none added outside working hours, got %v", added)
+	}
+	d.scaleSoldati(now, true)
+	added := scaledSoldati(t, mgr)
+	if len(added) != 2 {
+		t.Fatalf("expected 2 soldati added, got %v", added)
+	}
+	for _, name := range added {
+		rec, err := d.registry.GetByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rec.Turf != "backend" || d.activeAgents[name] == nil {
+			t.Errorf("expected %s running on backend, got turf %q", name, rec.Turf)
+		}
+	}
+	d.scaleSoldati(now, true)
+	if again := scaledSoldati(t, mgr); len(again) != 2 {
+		t.Fatalf("expected the same backlog to add no more, got %v", again)
+	}
+
+	// Drain the queue; one added soldati is still working
+	all, _ := d.beadStore.ListReady("backend")
+	for _, b := range all {
+		b.Status = models.BeadStatusClosed
+		if _, err := d.beadStore.Update(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d.work[added[0]] = &assignmentWork{cancel: func() {}}
+
+	d.scaleSoldati(now, true)
+	if left := scaledSoldati(t, mgr); len(left) != 2 {
+		t.Fatalf("expected added soldati kept until retire_after passes, got %v", left)
+	}
+	d.scaleSoldati(now.Add(2*time.Minute), true)
+	left := scaledSoldati(t, mgr)
+	if len(left) != 1 || left[0] != added[0] {
+		t.Fatalf("expected only the working soldati left, got %v", left)
+	}
+	if _, err := d.registry.GetByName(added[1]); err == nil {
+		t.Error("expected the retired soldati unregistered")
+	}
+	if _, err := mgr.Get("vinnie"); err != nil {
+		t.Errorf("expected soldati created by hand kept: %v", err)
+	}
+}
+
+// scaledSoldati returns the names of soldati the daemon added
+func scaledSoldati(t *testing.T, mgr *soldati.Manager) []string {
+	t.Helper()
+	all, err := mgr.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range all {
+		if s.AutoScaled {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,97 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/workhours"
+)
+
+// inWorkingHours reports whether the configured schedule allows spawning agents
+// and assigning work at now: the mob-wide window or a turf's own window is
+// open, or `mob schedule --override` is running. It picks up overrides and
+// logs when the daemon enters or leaves off-hours.
+func (d *Daemon) inWorkingHours(now time.Time) bool {
+	overrides := d.loadOverrides(now)
+	working := d.cfg.Schedule.IsWorkingTime(now) || overrides.Any(now)
+	for turf := range d.cfg.Schedule.Turfs {
+		if working {
+			break
+		}
+		s := d.cfg.Schedule.ForTurf(turf)
+		working = s.IsWorkingTime(now)
+	}
+
+	d.mu.Lock()
+	changed := working == d.offHours
+	d.offHours = !working
+	d.mu.Unlock()
+
+	if changed {
+		if working {
+			d.logger.Printf("Schedule: working hours started, resuming spawns and assignments\n")
+		} else {
+			d.logger.Printf("Schedule: outside working hours, holding spawns and assignments\n")
+		}
+	}
+
+	return working
+}
+
+// turfInHours reports whether work in a turf may be assigned and nudged at
+// now, following the turf's own window when it has one, and logs when that
+// window opens or closes
+func (d *Daemon) turfInHours(turf string, now time.Time) bool {
+	d.mu.RLock()
+	overrides := d.overrides
+	d.mu.RUnlock()
+
+	s := d.cfg.Schedule.ForTurf(turf)
+	open := s.IsWorkingTime(now) || overrides.Active(turf, now)
+	if _, own := d.cfg.Schedule.Turfs[turf]; !own {
+		return open
+	}
+
+	d.mu.Lock()
+	changed := open == d.closedTurfs[turf]
+	d.closedTurfs[turf] = !open
+	d.mu.Unlock()
+
+	if changed {
+		if open {
+			d.logger.Printf("Schedule: turf %s working hours started, resuming assignments and nudges\n", turf)
+		} else {
+			d.logger.Printf("Schedule: turf %s outside its working hours (%s), holding assignments and nudges\n", turf, s.WorkingHours)
+		}
+	}
+	return open
+}
+
+// loadOverrides refreshes the overrides set with `mob schedule`, keeping the
+// last ones read if the file cannot be
+func (d *Daemon) loadOverrides(now time.Time) workhours.Overrides {
+	overrides, err := workhours.Load(workhours.Path(d.mobDir))
+	if err != nil {
+		d.logger.Printf("Schedule: %v\n", err)
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+		return d.overrides
+	}
+	overrides.Prune(now)
+
+	d.mu.Lock()
+	d.overrides = overrides
+	d.mu.Unlock()
+	return overrides
+}
+
+// inHoursBeads keeps the beads whose turf is inside its working hours
+func (d *Daemon) inHoursBeads(beads []*models.Bead, now time.Time) []*models.Bead {
+	var kept []*models.Bead
+	for _, b := range beads {
+		if d.turfInHours(d.turfName(b.Turf), now) {
+			kept = append(kept, b)
+		}
+	}
+	return kept
+}
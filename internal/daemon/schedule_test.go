@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/workhours"
+)
+
+func TestInWorkingHours(t *testing.T) {
+	var buf bytes.Buffer
+	d := New(t.TempDir(), log.New(&buf, "", 0))
+	d.cfg.Schedule = config.ScheduleConfig{WorkingHours: "08:00-20:00", Timezone: "UTC"}
+
+	day := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 1, 14, 23, 0, 0, 0, time.UTC)
+
+	if !d.inWorkingHours(day) {
+		t.Error("expected midday to be working hours")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no log while already working, got %q", buf.String())
+	}
+
+	if d.inWorkingHours(night) {
+		t.Error("expected 23:00 to be outside working hours")
+	}
+	d.inWorkingHours(night)
+	if n := strings.Count(buf.String(), "outside working hours"); n != 1 {
+		t.Errorf("expected off-hours transition logged once, got %d", n)
+	}
+
+	if !d.inWorkingHours(day) {
+		t.Error("expected working hours to resume")
+	}
+	if !strings.Contains(buf.String(), "working hours started") {
+		t.Errorf("expected resume to be logged, got %q", buf.String())
+	}
+}
+
+func TestTurfInHours(t *testing.T) {
+	var buf bytes.Buffer
+	mobDir := t.TempDir()
+	d := New(mobDir, log.New(&buf, "", 0))
+	d.cfg.Schedule = config.ScheduleConfig{
+		WorkingHours: "08:00-20:00",
+		Timezone:     "UTC",
+		Turfs:        map[string]config.ScheduleConfig{"batch": {WorkingHours: "22:00-06:00"}},
+	}
+	day := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	night := time.Date(2026, 1, 14, 23, 0, 0, 0, time.UTC)
+
+	// The batch turf's own window keeps the daemon working overnight
+	if !d.inWorkingHours(night) {
+		t.Error("expected the night to count as working hours for batch")
+	}
+	if !d.turfInHours("batch", night) || d.turfInHours("web", night) {
+		t.Error("expected only batch to work at night")
+	}
+	if d.turfInHours("batch", day) || !d.turfInHours("web", day) {
+		t.Error("expected only web to work by day")
+	}
+	if !strings.Contains(buf.String(), "turf batch outside its working hours") {
+		t.Errorf("expected batch closing to be logged, got %q", buf.String())
+	}
+
+	// An override opens web overnight until it runs out
+	if err := workhours.Save(workhours.Path(mobDir), workhours.Overrides{"web": night.Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	d.inWorkingHours(night)
+	if !d.turfInHours("web", night) {
+		t.Error("expected the override to open web")
+	}
+	if d.turfInHours("web", night.Add(2*time.Hour)) {
+		t.Error("expected the override to run out")
+	}
+}
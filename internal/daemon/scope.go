@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/mcp"
+)
+
+// registeredTurf returns the registered name of a turf given by name or
+// path, or "" when it names no registered turf
+func (d *Daemon) registeredTurf(turf string) string {
+	if turf == "" || d.turfMgr == nil {
+		return ""
+	}
+	name := d.turfName(turf)
+	if _, err := d.turfMgr.Get(name); err != nil {
+		return ""
+	}
+	return name
+}
+
+// toolTurf names the turf a soldati's MCP tools are scoped to for a bead:
+// the bead's turf, or the turf the soldati was added for when there is no
+// bead. Empty leaves its tools unscoped.
+func (d *Daemon) toolTurf(name, beadID string) string {
+	if beadID == "" {
+		return d.registeredTurf(d.scaledTurf(name))
+	}
+	if d.beadStore == nil {
+		return ""
+	}
+	bead, err := d.beadStore.Get(beadID)
+	if err != nil {
+		return ""
+	}
+	return d.registeredTurf(bead.Turf)
+}
+
+// hookedToolTurf is toolTurf for the bead on a soldati's hook
+func (d *Daemon) hookedToolTurf(name string) string {
+	beadID := ""
+	if h := d.readHook(name); h != nil {
+		beadID = h.BeadID
+	}
+	return d.toolTurf(name, beadID)
+}
+
+// mcpConfig writes the MCP config for tools scoped to turf, unscoped when
+// turf is empty, returning its path
+func (d *Daemon) mcpConfig(turf string) string {
+	path, err := mcp.GenerateTurfMCPConfig(d.mobDir, turf)
+	if err != nil {
+		d.logger.Printf("Warning: failed to generate MCP config: %v", err)
+	}
+	return path
+}
+
+// pinMCPConfig scopes a soldati's tools to the turf of the bead its next
+// call works on, so its MCP server never holds it to another turf
+func (d *Daemon) pinMCPConfig(name string, a *agent.Agent, beadID string) {
+	a.MCPConfig = d.mcpConfig(d.toolTurf(name, beadID))
+}
@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+// mcpServerArgs reads the mob-tools server arguments from an MCP config
+func mcpServerArgs(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		MCPServers map[string]struct {
+			Args []string `json:"args"`
+		} `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	return config.MCPServers["mob-tools"].Args
+}
+
+// turfArg returns the --turf an MCP server is started with, "" for none
+func turfArg(args []string) string {
+	if i := slices.Index(args, "--turf"); i >= 0 && i+1 < len(args) {
+		return args[i+1]
+	}
+	return ""
+}
+
+func TestRespawnScopesToolsToHookedBead(t *testing.T) {
+	d, _, bead := newTurfTestDaemon(t)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	t.Cleanup(d.cancel)
+	d.spawner = agent.NewSpawner()
+	d.registry = registry.New(registry.DefaultPath(d.mobDir))
+	mgr, err := soldati.NewManager(filepath.Join(d.mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.soldatiMgr = mgr
+	if _, err := mgr.Create("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+
+	// vinnie was working on the backend bead when its process died
+	bead.Status, bead.Assignee = models.BeadStatusInProgress, "vinnie"
+	if _, err := d.beadStore.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+	hooks, err := hook.NewManager(filepath.Join(d.mobDir, ".mob", "soldati"), "vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hooks.Write(&hook.Hook{Type: hook.HookTypeAssign, BeadID: bead.ID, Message: bead.Title}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.spawnSoldatiAgent("vinnie"); err != nil {
+		t.Fatal(err)
+	}
+	if turf := turfArg(mcpServerArgs(t, d.activeAgents["vinnie"].MCPConfig)); turf != "" {
+		t.Errorf("a configured soldati should start unscoped, got --turf %s", turf)
+	}
+	record, err := d.registry.GetByName("vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.stopHookWatcher("vinnie")
+
+	if err := d.respawnSoldati("vinnie", record); err != nil {
+		t.Fatal(err)
+	}
+	turf := turfArg(mcpServerArgs(t, d.activeAgents["vinnie"].MCPConfig))
+	if turf != "backend" {
+		t.Fatalf("respawned tools scoped to %q, want the hooked bead's turf backend", turf)
+	}
+
+	// The respawned soldati can still finish its bead
+	server := mcp.NewServer(d.registry, nil, d.beadStore, d.turfMgr, d.mobDir)
+	server.SetTurfScope(turf)
+	call := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"complete_bead","arguments":{"id":%q,"close_reason":"done"}}}`, bead.ID)
+	var out strings.Builder
+	if err := server.Serve(strings.NewReader(call+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), `"isError":true`) {
+		t.Fatalf("complete_bead failed after respawn: %s", out.String())
+	}
+	if got, _ := d.beadStore.Get(bead.ID); got.Status != models.BeadStatusClosed {
+		t.Errorf("bead status = %s, want closed", got.Status)
+	}
+}
+
+func TestToolTurfIgnoresUnregisteredTurfs(t *testing.T) {
+	d, turfDir, bead := newTurfTestDaemon(t)
+
+	if got := d.toolTurf("vinnie", bead.ID); got != "backend" {
+		t.Errorf("toolTurf = %q, want backend", got)
+	}
+	if got := d.registeredTurf(turfDir); got != "backend" {
+		t.Errorf("a turf's path should map to its name, got %q", got)
+	}
+	for _, turf := range []string{d.mobDir, "nowhere", ""} {
+		if got := d.registeredTurf(turf); got != "" {
+			t.Errorf("registeredTurf(%q) = %q, want unscoped", turf, got)
+		}
+	}
+}
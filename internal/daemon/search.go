@@ -0,0 +1,52 @@
+package daemon
+
+import (
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/search"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+// searchOutputLines is how much recent agent output is kept in the index
+const searchOutputLines = 50
+
+// refreshSearchIndex rebuilds the index backing `mob grep` from beads,
+// agent transcripts, live agent output, and the daemon log, rereading only
+// the transcripts and log lines that changed since the last patrol
+func (d *Daemon) refreshSearchIndex() {
+	src := search.Sources{
+		Transcripts: make(map[string]string),
+		AgentOutput: make(map[string][]string),
+		LogPath:     filepath.Join(d.mobDir, ".mob", "daemon.log"),
+	}
+
+	if d.beadStore != nil {
+		if beads, err := d.beadStore.List(storage.BeadFilter{}); err == nil {
+			src.Beads = beads
+		}
+	}
+
+	if d.registry != nil {
+		if records, err := d.registry.List(); err == nil {
+			for _, record := range records {
+				if record.SessionID == "" {
+					continue
+				}
+				if path, err := transcript.Find(record.SessionID); err == nil {
+					src.Transcripts[record.Name] = path
+				}
+			}
+		}
+	}
+
+	d.mu.RLock()
+	for name, a := range d.activeAgents {
+		src.AgentOutput[name] = a.RecentOutput(searchOutputLines)
+	}
+	d.mu.RUnlock()
+
+	if err := d.searchIndex.Build(src).Save(search.DefaultPath(d.mobDir)); err != nil {
+		d.logger.Printf("Search: failed to save index: %v\n", err)
+	}
+}
@@ -0,0 +1,187 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/events"
+)
+
+// costWindow is how far back the API cost rate looks
+const costWindow = time.Hour
+
+// selfMetrics is what the daemon measures about its own work, served on
+// /metrics and behind /healthz
+type selfMetrics struct {
+	mu          sync.Mutex
+	started     time.Time
+	patrols     int
+	patrolTotal time.Duration
+	patrolLast  time.Duration
+	interval    time.Duration // how often patrols are meant to run
+	lastPatrol  time.Time     // when the last patrol finished
+	patrolling  time.Time     // when the running patrol started, zero between patrols
+	assigned    int
+	workStarted int
+	completed   int
+	failures    int
+	respawns    int
+	merged      int
+	mergeFailed int
+	costTotal   float64
+	costs       []costSample    // agent calls within costWindow, oldest first
+	degraded    map[string]bool // models whose provider keeps failing
+}
+
+type costSample struct {
+	at  time.Time
+	usd float64
+}
+
+func newSelfMetrics(now time.Time) *selfMetrics {
+	return &selfMetrics{started: now, interval: config.DefaultHeartbeatInterval, degraded: make(map[string]bool)}
+}
+
+// patrolStarted marks a patrol as running, due again after interval
+func (m *selfMetrics) patrolStarted(now time.Time, interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.patrolling = now
+	m.interval = interval
+}
+
+// patrolFinished records how long the running patrol took
+func (m *selfMetrics) patrolFinished(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.patrolling.IsZero() {
+		return
+	}
+	took := now.Sub(m.patrolling)
+	m.patrols++
+	m.patrolTotal += took
+	m.patrolLast = took
+	m.lastPatrol = now
+	m.patrolling = time.Time{}
+}
+
+// workCompleted counts a finished assignment and what its agent call cost
+func (m *selfMetrics) workCompleted(now time.Time, usd float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed++
+	m.costTotal += usd
+	m.costs = append(m.costs, costSample{at: now, usd: usd})
+	m.pruneCosts(now)
+}
+
+// costPerHour sums what agent calls cost over the last costWindow
+func (m *selfMetrics) costPerHour(now time.Time) float64 {
+	m.pruneCosts(now)
+	var usd float64
+	for _, c := range m.costs {
+		usd += c.usd
+	}
+	return usd
+}
+
+func (m *selfMetrics) pruneCosts(now time.Time) {
+	i := 0
+	for i < len(m.costs) && now.Sub(m.costs[i].at) > costWindow {
+		i++
+	}
+	m.costs = m.costs[i:]
+}
+
+// setDegraded replaces the degraded models, as read at startup or reload
+func (m *selfMetrics) setDegraded(models map[string]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.degraded = make(map[string]bool, len(models))
+	for model := range models {
+		m.degraded[model] = true
+	}
+}
+
+// countEvent tallies the events the metrics count; it subscribes to the bus
+func (m *selfMetrics) countEvent(e events.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch e := e.(type) {
+	case events.BeadAssigned:
+		m.assigned++
+	case events.WorkStarted:
+		m.workStarted++
+	case events.AgentFailed:
+		m.failures++
+	case events.AgentSpawned:
+		if e.Respawned {
+			m.respawns++
+		}
+	case events.MergeCompleted:
+		if e.Success {
+			m.merged++
+		} else {
+			m.mergeFailed++
+		}
+	case events.ModelDegraded:
+		m.degraded[e.Model] = true
+	case events.ModelRecovered:
+		delete(m.degraded, e.Model)
+	}
+}
+
+// gauges are the figures read from the daemon's state at scrape time
+type gauges struct {
+	healthy     bool
+	agents      int // soldati sessions the daemon runs
+	poolRunning int
+	poolQueued  int
+	hookBacklog int // hooks set on soldati whose work has not started
+}
+
+// writeMetrics writes the metrics in the Prometheus text format
+func (m *selfMetrics) writeMetrics(w io.Writer, g gauges, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	up := 0
+	if g.healthy {
+		up = 1
+	}
+	metric(w, "mob_up", "gauge", "Whether the daemon is patrolling on schedule.", up)
+	metric(w, "mob_uptime_seconds", "gauge", "Seconds since the daemon started.", now.Sub(m.started).Seconds())
+
+	fmt.Fprintf(w, "# HELP mob_patrol_duration_seconds How long patrols take.\n# TYPE mob_patrol_duration_seconds summary\n")
+	fmt.Fprintf(w, "mob_patrol_duration_seconds_sum %g\nmob_patrol_duration_seconds_count %d\n", m.patrolTotal.Seconds(), m.patrols)
+	metric(w, "mob_patrol_last_duration_seconds", "gauge", "How long the last patrol took.", m.patrolLast.Seconds())
+	var last float64
+	if !m.lastPatrol.IsZero() {
+		last = float64(m.lastPatrol.Unix())
+	}
+	metric(w, "mob_patrol_last_timestamp_seconds", "gauge", "When the last patrol finished, as a Unix time; 0 before the first.", last)
+
+	metric(w, "mob_assignments_total", "counter", "Beads the daemon assigned to idle soldati.", m.assigned)
+	metric(w, "mob_work_started_total", "counter", "Assignments soldati started on.", m.workStarted)
+	metric(w, "mob_work_completed_total", "counter", "Assignments soldati finished.", m.completed)
+	metric(w, "mob_agent_failures_total", "counter", "Agent calls that ended in an error.", m.failures)
+	metric(w, "mob_agent_respawns_total", "counter", "Soldati sessions restarted after dying.", m.respawns)
+	fmt.Fprintf(w, "# HELP mob_merges_total Queued merges by outcome.\n# TYPE mob_merges_total counter\n")
+	fmt.Fprintf(w, "mob_merges_total{result=\"landed\"} %d\nmob_merges_total{result=\"failed\"} %d\n", m.merged, m.mergeFailed)
+
+	metric(w, "mob_api_cost_usd_total", "counter", "What soldati agent calls cost since the daemon started.", m.costTotal)
+	metric(w, "mob_api_cost_usd_per_hour", "gauge", "What soldati agent calls cost over the last hour.", m.costPerHour(now))
+
+	metric(w, "mob_agents", "gauge", "Soldati sessions the daemon runs.", g.agents)
+	metric(w, "mob_pool_running", "gauge", "Assignments working in the agent pool.", g.poolRunning)
+	metric(w, "mob_pool_queued", "gauge", "Assignments waiting for room in the agent pool.", g.poolQueued)
+	metric(w, "mob_hook_backlog", "gauge", "Hooks set on soldati whose work has not started.", g.hookBacklog)
+	metric(w, "mob_models_degraded", "gauge", "Models whose provider keeps failing.", len(m.degraded))
+}
+
+func metric(w io.Writer, name, kind, help string, value any) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+}
@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// skillRouter steers ready beads toward idle soldati whose skills match their
+// labels during one auto-assignment pass. A soldati that matches a bead worse
+// than an idle peer on its turf leaves that bead for the peer, but each peer
+// holds at most one bead, so nobody sits idle waiting on a backlog of
+// specialist work.
+type skillRouter struct {
+	defs    map[string]*models.Soldati      // soldati definitions by name
+	waiting map[string]*registry.AgentRecord // idle soldati not yet served this pass
+	held    map[string]string                // bead → the soldati it is left for
+}
+
+// newSkillRouter starts a pass over the idle soldati in waiting
+func (d *Daemon) newSkillRouter(waiting []*registry.AgentRecord) *skillRouter {
+	r := &skillRouter{
+		defs:    make(map[string]*models.Soldati),
+		waiting: make(map[string]*registry.AgentRecord, len(waiting)),
+		held:    make(map[string]string),
+	}
+	for _, rec := range waiting {
+		r.waiting[rec.Name] = rec
+	}
+	if d.soldatiMgr != nil {
+		if defs, err := d.soldatiMgr.List(); err == nil {
+			for _, def := range defs {
+				r.defs[def.Name] = def
+			}
+		}
+	}
+	return r
+}
+
+// match scores how well a soldati's skills fit a bead
+func (r *skillRouter) match(name string, b *models.Bead) int {
+	def := r.defs[name]
+	if def == nil {
+		return 0
+	}
+	return def.SkillMatch(b)
+}
+
+// route returns the beads a soldati may take, best skill match first within
+// each priority. Beads an idle peer is better suited for are left out and
+// held for that peer.
+func (r *skillRouter) route(rec *registry.AgentRecord, ready []*models.Bead, avoid map[string][]string) []*models.Bead {
+	delete(r.waiting, rec.Name)
+	for id, holder := range r.held {
+		if holder == rec.Name {
+			delete(r.held, id)
+		}
+	}
+
+	holding := make(map[string]bool, len(r.held))
+	for _, holder := range r.held {
+		holding[holder] = true
+	}
+
+	var kept []*models.Bead
+	for _, b := range ready {
+		if _, ok := r.held[b.ID]; ok {
+			continue
+		}
+		if peer := r.betterPeer(rec, b, holding, avoid); peer != "" {
+			r.held[b.ID] = peer
+			holding[peer] = true
+			continue
+		}
+		kept = append(kept, b)
+	}
+
+	// ListReady orders by priority; only reorder within a priority
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].Priority != kept[j].Priority {
+			return kept[i].Priority < kept[j].Priority
+		}
+		return r.match(rec.Name, kept[i]) > r.match(rec.Name, kept[j])
+	})
+	return kept
+}
+
+// betterPeer returns the idle peer on rec's turf that matches b best, if it
+// matches better than rec and is not already holding a bead
+func (r *skillRouter) betterPeer(rec *registry.AgentRecord, b *models.Bead, holding map[string]bool, avoid map[string][]string) string {
+	best, bestMatch := "", r.match(rec.Name, b)
+	for name, peer := range r.waiting {
+		if peer.Turf != rec.Turf || holding[name] || slices.Contains(avoid[b.ID], name) {
+			continue
+		}
+		if m := r.match(name, b); m > bestMatch || (m == bestMatch && best != "" && name < best) {
+			best, bestMatch = name, m
+		}
+	}
+	return best
+}
@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+func TestSkillRouterPrefersMatchWithinPriority(t *testing.T) {
+	r := &skillRouter{
+		defs:    map[string]*models.Soldati{"sal": {Name: "sal", Skills: []string{"rust"}}},
+		waiting: map[string]*registry.AgentRecord{},
+		held:    map[string]string{},
+	}
+	ready := []*models.Bead{
+		{ID: "bd-1", Priority: 1},
+		{ID: "bd-2", Priority: 2},
+		{ID: "bd-3", Priority: 2, Labels: []string{"rust"}},
+	}
+	got := r.route(&registry.AgentRecord{Name: "sal", Turf: "backend"}, ready, nil)
+	if len(got) != 3 || got[0].ID != "bd-1" || got[1].ID != "bd-3" {
+		t.Errorf("expected priority kept and the rust bead first among P2, got %v", beadIDs(got))
+	}
+}
+
+func TestAutoAssignRoutesBySkill(t *testing.T) {
+	d, _, rustA := newTurfTestDaemon(t)
+	d.registry = registry.New(registry.DefaultPath(d.mobDir))
+	mgr, err := soldati.NewManager(filepath.Join(d.mobDir, "soldati"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.soldatiMgr = mgr
+
+	for _, name := range []string{"vinnie", "sal"} {
+		if _, err := mgr.Create(name); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.registry.Register(&registry.AgentRecord{ID: name + "-id", Type: "soldati", Name: name, Turf: "backend", Status: registry.StatusIdle}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mgr.SetSkills("sal", []string{"rust"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rustA.Priority, rustA.Labels = 1, []string{"rust"}
+	if _, err := d.beadStore.Update(rustA); err != nil {
+		t.Fatal(err)
+	}
+	rustB, _ := d.beadStore.Create(&models.Bead{Title: "Port parser", Status: models.BeadStatusOpen, Turf: "backend", Priority: 1, Labels: []string{"rust"}})
+	css, _ := d.beadStore.Create(&models.Bead{Title: "Fix layout", Status: models.BeadStatusOpen, Turf: "backend", Priority: 2, Labels: []string{"css"}})
+
+	d.assignWorkToIdleAgents()
+
+	// sal takes a rust bead; vinnie is not left idle for want of a skill
+	hooked := make(map[string]string)
+	for _, name := range []string{"vinnie", "sal"} {
+		m, err := hook.NewManager(filepath.Join(d.mobDir, ".mob", "soldati"), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h, _ := m.Read(); h != nil {
+			hooked[name] = h.BeadID
+		}
+	}
+	if hooked["sal"] != rustA.ID || hooked["vinnie"] != rustB.ID {
+		t.Errorf("expected sal on %s and vinnie on %s, got %v", rustA.ID, rustB.ID, hooked)
+	}
+	if got, _ := d.beadStore.Get(css.ID); got.Status != models.BeadStatusOpen {
+		t.Errorf("expected the css bead still waiting, got %s", got.Status)
+	}
+}
+
+func beadIDs(beads []*models.Bead) []string {
+	ids := make([]string, len(beads))
+	for i, b := range beads {
+		ids[i] = b.ID
+	}
+	return ids
+}
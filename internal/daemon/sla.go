@@ -0,0 +1,67 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// escalateOverdue raises the priority of beads that blew their due date or
+// SLA by one level and notifies about them. Each bead is escalated once; a
+// new due date clears the breach so it can be escalated again.
+func (d *Daemon) escalateOverdue(now time.Time) {
+	if d.beadStore == nil {
+		return
+	}
+	overdue, err := d.beadStore.ListOverdue(now)
+	if err != nil {
+		d.logger.Printf("Patrol: failed to list overdue beads: %v\n", err)
+		return
+	}
+
+	for _, b := range overdue {
+		if b.SLABreachedAt != nil {
+			continue
+		}
+		deadline, _ := b.Deadline()
+		from := b.Priority
+		if d.planned(PlanStep{Action: PlanEscalate, BeadID: b.ID, Detail: fmt.Sprintf("missed its deadline (%s); P%d → P%d", deadline.Format(time.RFC3339), from, max(from-1, 0))}) {
+			continue
+		}
+		escalated, err := d.updateBead(b.ID, func(b *models.Bead) error {
+			if b.SLABreachedAt != nil || !b.Overdue(now) {
+				return errBeadMoved
+			}
+			from = b.Priority
+			if b.Priority > 0 {
+				b.Priority--
+			}
+			b.SLABreachedAt = &now
+			return nil
+		})
+		if err != nil {
+			if !errors.Is(err, errBeadMoved) {
+				d.logger.Printf("Patrol: failed to escalate overdue bead %s: %v\n", b.ID, err)
+			}
+			continue
+		}
+		b = escalated
+
+		note := fmt.Sprintf("Missed its deadline (%s); priority raised P%d → P%d", deadline.Format(time.RFC3339), from, b.Priority)
+		if from == b.Priority {
+			note = fmt.Sprintf("Missed its deadline (%s); already P0", deadline.Format(time.RFC3339))
+		}
+		if err := d.beadStore.AddComment(b.ID, "daemon", note); err != nil {
+			d.logger.Printf("Patrol: failed to comment on overdue bead %s: %v\n", b.ID, err)
+		}
+		d.logger.Printf("Patrol: bead %s is overdue (due %s), escalated to P%d\n", b.ID, deadline.Format(time.RFC3339), b.Priority)
+
+		if d.notifier != nil {
+			if err := d.notifier.NotifyBeadOverdue(b.ID, b.Title, b.Assignee, deadline, b.Priority); err != nil {
+				d.logger.Printf("Warning: failed to send overdue notification: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package daemon
+
+import (
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestEscalateOverdue(t *testing.T) {
+	mobDir := t.TempDir()
+	d := New(mobDir, log.New(io.Discard, "", 0))
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.beadStore = store
+
+	now := time.Now()
+	due := now.Add(-time.Hour)
+	late, _ := store.Create(&models.Bead{Title: "Late", Status: models.BeadStatusOpen, Priority: 2, DueAt: &due})
+	urgent, _ := store.Create(&models.Bead{Title: "Late and urgent", Status: models.BeadStatusOpen, Priority: 0, DueAt: &due})
+
+	d.escalateOverdue(now)
+	got, _ := store.Get(late.ID)
+	if got.Priority != 1 || got.SLABreachedAt == nil {
+		t.Errorf("expected P1 and a recorded breach, got P%d breached=%v", got.Priority, got.SLABreachedAt)
+	}
+	if last := got.History[len(got.History)-1]; last.Type != models.BeadEventTypeComment || last.Actor != "daemon" {
+		t.Errorf("expected a daemon comment, got %+v", last)
+	}
+	if got, _ := store.Get(urgent.ID); got.Priority != 0 || got.SLABreachedAt == nil {
+		t.Errorf("expected P0 kept and a recorded breach, got P%d", got.Priority)
+	}
+
+	// Once escalated, a bead is left alone on later patrols
+	d.escalateOverdue(now.Add(time.Hour))
+	if got, _ := store.Get(late.ID); got.Priority != 1 {
+		t.Errorf("expected one escalation, got P%d", got.Priority)
+	}
+}
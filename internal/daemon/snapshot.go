@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/gabe/mob/internal/snapshot"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// snapshotBoard records the board once a day, on the first tick of each day,
+// for `mob board diff`, and drops snapshots past their retention
+func (d *Daemon) snapshotBoard() {
+	if d.beadStore == nil {
+		return
+	}
+	now := time.Now()
+	dir := snapshot.Dir(d.mobDir)
+	if snapshot.Exists(dir, now.Format(snapshot.DateLayout)) {
+		return
+	}
+
+	beads, err := d.beadStore.List(storage.BeadFilter{})
+	if err != nil {
+		d.logger.Printf("Snapshot: %v\n", err)
+		return
+	}
+	if err := snapshot.Save(dir, snapshot.Capture(beads, now)); err != nil {
+		d.logger.Printf("Snapshot: %v\n", err)
+		return
+	}
+	d.logger.Printf("Snapshot: recorded %d bead(s)\n", len(beads))
+
+	if _, err := snapshot.Prune(dir, snapshot.DefaultRetention, now); err != nil {
+		d.logger.Printf("Snapshot: %v\n", err)
+	}
+}
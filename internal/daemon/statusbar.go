@@ -0,0 +1,15 @@
+package daemon
+
+import (
+	"github.com/gabe/mob/internal/statusbar"
+)
+
+// writeStatusBar refreshes the snapshot `mob status --oneline` and friends
+// read, so status bars polling every few seconds stay cheap
+func (d *Daemon) writeStatusBar() {
+	s := statusbar.Collect(d.mobDir, d.beadStore)
+	s.DaemonRunning = true
+	if err := statusbar.Save(statusbar.Path(d.mobDir), s); err != nil {
+		d.logger.Printf("Status bar: %v\n", err)
+	}
+}
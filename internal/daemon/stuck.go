@@ -0,0 +1,117 @@
+package daemon
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// stalledNote is put before an assignment whose call went silent and was
+// restarted, when the soldati's session picks it back up
+const stalledNote = "Your last call on this produced no output for a long time and was restarted. Pick up where you left off."
+
+// errStalled fails an assignment whose call went silent again after it was
+// restarted
+var errStalled = errors.New("call produced no output, even after a restart, and was aborted")
+
+// stuckAgent is a soldati whose in-flight call has gone silent
+type stuckAgent struct {
+	since     time.Time // when it was marked stuck
+	restarted time.Time // when the call aborted to resume it had started, zero if not yet
+}
+
+// detectStuck watches soldati calls that are running but printing nothing.
+// A call silent for stuck_timeout marks its soldati stuck and notifies; one
+// still silent after another stuck_timeout is aborted and resumed in the same
+// session; and if the resumed call goes silent too, the assignment fails and
+// its bead goes to the failure queue. Any output in between clears the mark.
+func (d *Daemon) detectStuck(now time.Time) {
+	timeout := d.cfg.Daemon.GetStuckTimeout()
+
+	d.mu.RLock()
+	agents := make(map[string]*agent.Agent, len(d.activeAgents))
+	for name, a := range d.activeAgents {
+		agents[name] = a
+	}
+	d.mu.RUnlock()
+
+	for name := range d.stuck {
+		if agents[name] == nil || timeout <= 0 {
+			delete(d.stuck, name)
+		}
+	}
+	if timeout <= 0 {
+		return
+	}
+
+	for name, a := range agents {
+		s := d.stuck[name]
+		started := a.CallStartedAt()
+		if started.IsZero() {
+			if s != nil && !d.hasWork(name) {
+				delete(d.stuck, name) // finished or given up on
+			}
+			continue
+		}
+
+		last := a.LastOutputAt()
+		if s != nil && last.After(s.since) && last.After(started) {
+			d.logger.Printf("Stuck: soldati '%s' is producing output again\n", name)
+			d.registry.UpdateStatus(a.ID, registry.StatusActive)
+			delete(d.stuck, name)
+			continue
+		}
+
+		silent := now.Sub(started)
+		if last.After(started) {
+			silent = now.Sub(last)
+		}
+		switch {
+		case s == nil && silent >= timeout:
+			d.markStuck(name, a, silent)
+			d.stuck[name] = &stuckAgent{since: now}
+		case s != nil && s.restarted.IsZero() && silent >= 2*timeout:
+			d.logger.Printf("Stuck: soldati '%s' still silent after %s, restarting its call\n", name, silent.Round(time.Second))
+			if d.abortWork(name, a, abortResume) {
+				s.restarted = started
+			}
+		case s != nil && !s.restarted.IsZero() && started.After(s.restarted) && silent >= timeout:
+			d.logger.Printf("Stuck: soldati '%s' went silent again after a restart, failing its assignment\n", name)
+			if d.abortWork(name, a, abortFail) {
+				delete(d.stuck, name)
+			}
+		}
+	}
+}
+
+// markStuck records a silent soldati as stuck and tells whoever is listening
+func (d *Daemon) markStuck(name string, a *agent.Agent, silent time.Duration) {
+	d.logger.Printf("Stuck: soldati '%s' has produced no output for %s\n", name, silent.Round(time.Second))
+	d.registry.UpdateStatus(a.ID, registry.StatusStuck)
+
+	task := ""
+	if rec, err := d.registry.Get(a.ID); err == nil {
+		task = rec.Task
+	}
+	beadID := ""
+	d.mu.RLock()
+	mgr := d.hookManagers[name]
+	d.mu.RUnlock()
+	if mgr != nil {
+		if h, _ := mgr.Read(); h != nil {
+			beadID = h.BeadID
+		}
+	}
+	d.publish(events.AgentStuck{Agent: name, AgentID: a.ID, BeadID: beadID, Turf: d.assignmentTurf(a, beadID), Task: task, Silent: silent})
+}
+
+// hasWork reports whether a soldati has an assignment running or queued
+func (d *Daemon) hasWork(name string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.work[name]
+	return ok
+}
@@ -0,0 +1,114 @@
+package daemon
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// waitFor polls cond until it holds or a few seconds pass
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSilentCallEscalates(t *testing.T) {
+	d, turfDir, bead := newTurfTestDaemon(t)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	defer d.cancel()
+	d.registry = registry.New(registry.DefaultPath(d.mobDir))
+	d.cfg.Daemon.StuckTimeout = "1m"
+	d.setupPool()
+	d.setupFailures()
+
+	// Every call hangs without printing anything
+	d.spawner = agent.NewSpawner()
+	d.spawner.SetCommandCreator(func(name string, args ...string) *exec.Cmd {
+		return exec.Command("sleep", "30")
+	})
+	a, err := d.spawner.Spawn(agent.AgentTypeSoldati, "vinnie", "backend", turfDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.registry.Register(&registry.AgentRecord{ID: a.ID, Type: "soldati", Name: "vinnie", Turf: "backend", Status: registry.StatusIdle}); err != nil {
+		t.Fatal(err)
+	}
+	mgr, err := hook.NewManager(filepath.Join(d.mobDir, ".mob", "soldati"), "vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &hook.Hook{Type: hook.HookTypeAssign, BeadID: bead.ID, Message: bead.Title, Timestamp: time.Now()}
+	if err := mgr.Write(h); err != nil {
+		t.Fatal(err)
+	}
+	d.activeAgents["vinnie"], d.hookManagers["vinnie"] = a, mgr
+
+	var stuck []events.AgentStuck
+	d.events.Subscribe(func(e events.Event) {
+		if s, ok := e.(events.AgentStuck); ok {
+			stuck = append(stuck, s)
+		}
+	})
+
+	d.handleAssignment("vinnie", a, h, mgr, "")
+	waitFor(t, "the call to start", func() bool { return !a.CallStartedAt().IsZero() })
+	first := a.CallStartedAt()
+
+	// Quiet for less than the timeout: nothing happens
+	d.detectStuck(first.Add(30 * time.Second))
+	if len(d.stuck) != 0 || len(stuck) != 0 {
+		t.Fatal("expected a call silent for under stuck_timeout left alone")
+	}
+
+	// Silent past the timeout: marked stuck and reported
+	d.detectStuck(first.Add(61 * time.Second))
+	if rec, _ := d.registry.Get(a.ID); rec.Status != registry.StatusStuck {
+		t.Errorf("expected vinnie marked stuck, got %s", rec.Status)
+	}
+	if len(stuck) != 1 || stuck[0].BeadID != bead.ID || stuck[0].Silent < time.Minute {
+		t.Fatalf("expected one AgentStuck for the bead, got %+v", stuck)
+	}
+
+	// Still silent: the call is aborted and resumed in the same session
+	d.detectStuck(first.Add(121 * time.Second))
+	waitFor(t, "the call to be resumed", func() bool { return a.CallStartedAt().After(first) })
+	if d.stuck["vinnie"] == nil {
+		t.Fatal("expected vinnie still tracked as stuck across the restart")
+	}
+	if got, _ := d.beadStore.Get(bead.ID); got.Status == models.BeadStatusBlocked {
+		t.Fatal("expected the bead kept on vinnie after one restart")
+	}
+
+	// Silent again after the restart: the assignment fails into the queue
+	d.detectStuck(a.CallStartedAt().Add(61 * time.Second))
+	waitFor(t, "the bead to fail", func() bool {
+		got, _ := d.beadStore.Get(bead.ID)
+		return got.Status == models.BeadStatusBlocked
+	})
+	entry, err := d.failures.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Attempts != 1 || entry.LastError != errStalled.Error() {
+		t.Errorf("failure entry = %+v", entry)
+	}
+	waitFor(t, "the call to end", func() bool { return a.CallStartedAt().IsZero() })
+	d.detectStuck(time.Now())
+	if len(d.stuck) != 0 {
+		t.Errorf("expected vinnie no longer tracked once its work ended, got %v", d.stuck)
+	}
+}
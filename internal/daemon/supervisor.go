@@ -0,0 +1,133 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Supervisor backoff defaults
+const (
+	DefaultSupervisorMinBackoff = 5 * time.Second
+	DefaultSupervisorMaxBackoff = time.Minute
+)
+
+// Supervisor runs a daemon for each of several mob directories, each in its
+// own process so their registries, stores, upgrades and restarts stay
+// apart. A daemon that exits with an error is started again after a
+// backoff that doubles while it keeps failing; one stopped cleanly, with
+// `mob daemon stop`, stays down. Run returns once every daemon has stopped.
+type Supervisor struct {
+	Dirs   []string
+	Logger *log.Logger
+
+	// Command returns the command that runs one directory's daemon
+	Command func(mobDir string) *exec.Cmd
+
+	MinBackoff time.Duration // 0 = DefaultSupervisorMinBackoff
+	MaxBackoff time.Duration // 0 = DefaultSupervisorMaxBackoff
+
+	mu    sync.Mutex
+	procs map[string]*os.Process // keyed by mob directory, daemons running now
+}
+
+// Run supervises every directory until each daemon has stopped or ctx is
+// cancelled, when the daemons are stopped with SIGTERM
+func (s *Supervisor) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.procs = make(map[string]*os.Process)
+	s.mu.Unlock()
+
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Signal(syscall.SIGTERM)
+		case <-stop:
+		}
+	}()
+	defer close(stop)
+
+	var wg sync.WaitGroup
+	for _, dir := range s.Dirs {
+		wg.Add(1)
+		go func(dir string) {
+			defer wg.Done()
+			s.supervise(ctx, dir)
+		}(dir)
+	}
+	wg.Wait()
+}
+
+// Signal sends sig to every running daemon
+func (s *Supervisor) Signal(sig os.Signal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for dir, p := range s.procs {
+		if err := p.Signal(sig); err != nil {
+			s.Logger.Printf("Supervisor: failed to signal daemon for %s: %v\n", dir, err)
+		}
+	}
+}
+
+// supervise keeps one directory's daemon running
+func (s *Supervisor) supervise(ctx context.Context, dir string) {
+	minBackoff, maxBackoff := s.MinBackoff, s.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultSupervisorMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultSupervisorMaxBackoff
+	}
+	backoff := minBackoff
+
+	for ctx.Err() == nil {
+		started := time.Now()
+		cmd := s.Command(dir)
+		err := cmd.Start()
+		if err == nil {
+			s.track(dir, cmd.Process)
+			if ctx.Err() != nil {
+				cmd.Process.Signal(syscall.SIGTERM) // started as the rest were stopped
+			}
+			s.Logger.Printf("Supervisor: daemon for %s running (PID %d)\n", dir, cmd.Process.Pid)
+			err = cmd.Wait()
+			s.track(dir, nil)
+			if err == nil {
+				s.Logger.Printf("Supervisor: daemon for %s stopped\n", dir)
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A daemon that ran a good while before failing starts over
+		if time.Since(started) > maxBackoff {
+			backoff = minBackoff
+		}
+		s.Logger.Printf("Supervisor: daemon for %s failed: %v; restarting in %s\n", dir, err, backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (s *Supervisor) track(dir string, p *os.Process) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p == nil {
+		delete(s.procs, dir)
+	} else {
+		s.procs[dir] = p
+	}
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSupervisorRestartsFailedDaemons(t *testing.T) {
+	tmp := t.TempDir()
+	runs := filepath.Join(tmp, "runs")
+
+	// "flaky" fails twice before stopping cleanly; "steady" stops at once
+	sup := &Supervisor{
+		Dirs:       []string{"flaky", "steady"},
+		Logger:     log.New(io.Discard, "", 0),
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 4 * time.Millisecond,
+		Command: func(dir string) *exec.Cmd {
+			script := "echo " + dir + " >> " + runs + "; exit 0"
+			if dir == "flaky" {
+				script = "echo flaky >> " + runs + `; [ "$(grep -c flaky ` + runs + `)" -ge 3 ]`
+			}
+			return exec.Command("sh", "-c", script)
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		sup.Run(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run did not return once every daemon stopped")
+	}
+
+	data, _ := os.ReadFile(runs)
+	if got := strings.Count(string(data), "flaky"); got != 3 {
+		t.Errorf("flaky ran %d times, want 3", got)
+	}
+	if got := strings.Count(string(data), "steady"); got != 1 {
+		t.Errorf("a daemon that stopped cleanly ran %d times, want 1", got)
+	}
+}
+
+func TestSupervisorStopsDaemons(t *testing.T) {
+	sup := &Supervisor{
+		Dirs:   []string{"a", "b"},
+		Logger: log.New(io.Discard, "", 0),
+		Command: func(dir string) *exec.Cmd {
+			return exec.Command("sleep", "30")
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sup.Run(ctx)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("daemons were not stopped")
+	}
+}
@@ -0,0 +1,323 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/gabe/mob/internal/events"
+	"github.com/gabe/mob/internal/version"
+)
+
+// DefaultUpgradeWait is how long an upgrade waits for in-flight work by default
+const DefaultUpgradeWait = 10 * time.Minute
+
+// UpgradeRequest asks the running daemon to replace itself with binary
+type UpgradeRequest struct {
+	Binary  string        `json:"binary"`
+	Wait    time.Duration `json:"wait"`              // how long to wait for in-flight work before giving up
+	Restart bool          `json:"restart,omitempty"` // interrupt assignments and resume them in the new process instead of waiting for them
+}
+
+// UpgradeRequestPath returns where `mob daemon upgrade` leaves its request
+func UpgradeRequestPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "daemon.upgrade.json")
+}
+
+// WriteUpgradeRequest leaves a request for the daemon to pick up on SIGUSR2
+func WriteUpgradeRequest(mobDir string, req UpgradeRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(UpgradeRequestPath(mobDir), data, 0644)
+}
+
+// handoff is the state an upgrading or restarting daemon passes to the
+// binary it execs. The process keeps its PID, so the PID file stays valid.
+type handoff struct {
+	PID         int                  `json:"pid"`
+	Version     string               `json:"version"` // binary that wrote the handoff
+	Restart     bool                 `json:"restart,omitempty"`
+	Sessions    []sessionHandoff     `json:"sessions,omitempty"`
+	JobsSince   time.Time            `json:"jobs_since"`
+	NudgedAt    map[string]time.Time `json:"nudged_at,omitempty"`
+	Reloads     map[string]bool      `json:"reloads,omitempty"`
+	OffHours    bool                 `json:"off_hours"`
+	WebhookFD   int                  `json:"webhook_fd,omitempty"` // inherited listening socket; 0 = none
+	WebhookAddr string               `json:"webhook_addr,omitempty"`
+}
+
+// handoffPath returns where the handoff is written across the exec
+func handoffPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "daemon.handoff.json")
+}
+
+// pendingUpgrade is an accepted upgrade waiting for in-flight work to finish
+type pendingUpgrade struct {
+	binary      string
+	deadline    time.Time
+	restart     bool
+	interrupted map[string]bool // soldati whose assignments the restart cut short
+}
+
+// beginUpgrade reads the upgrade request, checks the new binary runs, and
+// stops taking on new work until the upgrade can go ahead
+func (d *Daemon) beginUpgrade() {
+	data, err := os.ReadFile(UpgradeRequestPath(d.mobDir))
+	if err == nil {
+		os.Remove(UpgradeRequestPath(d.mobDir))
+	}
+	var req UpgradeRequest
+	if err == nil {
+		err = json.Unmarshal(data, &req)
+	}
+	if err == nil && req.Binary == "" {
+		err = errors.New("no binary given")
+	}
+	if err == nil {
+		err = checkBinary(req.Binary)
+	}
+	if err != nil {
+		d.failUpgrade(req.Restart, fmt.Errorf("%s request: %w", controlAction(req.Restart), err))
+		return
+	}
+
+	wait := req.Wait
+	if wait <= 0 {
+		wait = DefaultUpgradeWait
+	}
+	d.upgrade = &pendingUpgrade{binary: req.Binary, deadline: time.Now().Add(wait), restart: req.Restart}
+	if req.Restart {
+		// Sessions outlive the process, so assignments can be cut short and
+		// resumed; merges, jobs and heresy fixes still have to finish
+		d.upgrade.interrupted = d.interruptAssignments()
+		d.logger.Printf("Restart: holding new work until merges, jobs and fixes finish, then restarting %s\n", req.Binary)
+	} else {
+		d.logger.Printf("Upgrade: holding new work until in-flight work finishes, then switching to %s\n", req.Binary)
+	}
+	d.continueUpgrade()
+}
+
+// controlAction names what an upgrade request asked for
+func controlAction(restart bool) string {
+	if restart {
+		return ActionRestart
+	}
+	return ActionUpgrade
+}
+
+// checkBinary makes sure the new binary runs before the daemon gives itself up
+func checkBinary(path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s does not run: %v: %s", path, err, out)
+	}
+	return nil
+}
+
+// continueUpgrade execs the new binary once nothing is in flight, or gives
+// the upgrade up when the wait runs out
+func (d *Daemon) continueUpgrade() {
+	if d.upgrade == nil {
+		return
+	}
+	busy := d.inFlight()
+	if busy == "" {
+		d.execUpgrade()
+		return
+	}
+	if time.Now().After(d.upgrade.deadline) {
+		u := d.upgrade
+		d.upgrade = nil
+		d.resumeInterrupted(u.interrupted)
+		d.failUpgrade(u.restart, fmt.Errorf("gave up waiting for %s; try again with a longer --wait", busy))
+	}
+}
+
+// resumeInterrupted picks assignments a restart cut short back up in this
+// process when the restart does not happen
+func (d *Daemon) resumeInterrupted(interrupted map[string]bool) {
+	for name := range interrupted {
+		d.resumeAssignment(name)
+	}
+}
+
+// inFlight describes the work an upgrade would cut short, or is empty when
+// there is none
+func (d *Daemon) inFlight() string {
+	d.mu.RLock()
+	assignments, jobs := len(d.work), len(d.jobsRunning)
+	d.mu.RUnlock()
+	// Interrupted work leaves d.work at once but runs until its call returns
+	if running := len(d.pool.Snapshot().Running); running > assignments {
+		assignments = running
+	}
+
+	merges := 0
+	for _, depth := range d.merges.Depths() {
+		merges += depth
+	}
+	fixes := d.runningHeresyFixes()
+
+	switch {
+	case assignments > 0:
+		return fmt.Sprintf("%d assignment(s)", assignments)
+	case merges > 0:
+		return fmt.Sprintf("%d queued merge(s)", merges)
+	case jobs > 0:
+		return fmt.Sprintf("%d running job(s)", jobs)
+	case fixes > 0:
+		return fmt.Sprintf("%d heresy fix associate(s)", fixes)
+	}
+	return ""
+}
+
+// execUpgrade hands the daemon's state and webhook socket to the new binary
+// and replaces this process with it. If the exec fails the daemon carries on.
+func (d *Daemon) execUpgrade() {
+	u := d.upgrade
+	binary := u.binary
+	d.upgrade = nil
+
+	h := handoff{
+		PID:       os.Getpid(),
+		Version:   version.Version,
+		Restart:   u.restart,
+		Sessions:  d.handOffSessions(u.interrupted),
+		JobsSince: d.jobsSince,
+		OffHours:  d.offHours,
+	}
+	d.mu.RLock()
+	h.NudgedAt = d.nudgedAt
+	h.Reloads = d.reloads
+	d.mu.RUnlock()
+
+	webhookFile, addr, err := d.handOffWebhooks()
+	if err != nil {
+		d.logger.Printf("Upgrade: webhook endpoint will be reopened: %v\n", err)
+	}
+	if webhookFile != nil {
+		h.WebhookFD, h.WebhookAddr = int(webhookFile.Fd()), addr
+	}
+
+	data, err := json.Marshal(h)
+	if err == nil {
+		err = os.WriteFile(handoffPath(d.mobDir), data, 0644)
+	}
+	if err == nil {
+		d.merges.Wait()
+		if u.restart {
+			d.logger.Printf("Restart: handing %d soldati session(s) to %s\n", len(h.Sessions), binary)
+		} else {
+			d.logger.Printf("Upgrade: switching to %s\n", binary)
+		}
+		d.publish(events.DaemonStopped{Version: version.Version, Upgrading: !u.restart, Restarting: u.restart})
+		argv := append([]string{binary}, os.Args[1:]...)
+		err = syscall.Exec(binary, argv, os.Environ())
+	}
+
+	// Still here: the exec failed, so pick up where we left off
+	os.Remove(handoffPath(d.mobDir))
+	if webhookFile != nil {
+		webhookFile.Close()
+	}
+	if d.webhooks == nil {
+		d.startWebhooks()
+	}
+	d.resumeInterrupted(u.interrupted)
+	d.failUpgrade(u.restart, fmt.Errorf("exec %s: %w", binary, err))
+}
+
+// handOffWebhooks stops serving webhooks and returns the listening socket,
+// left open across exec for the new binary to serve from
+func (d *Daemon) handOffWebhooks() (*os.File, string, error) {
+	if d.webhooks == nil || d.webhookLn == nil {
+		return nil, "", nil
+	}
+	tcp, ok := d.webhookLn.(*net.TCPListener)
+	if !ok {
+		d.stopWebhooks()
+		return nil, "", errors.New("listener cannot be handed over")
+	}
+	f, err := tcp.File()
+	addr := d.cfg.Webhooks.Listen
+	d.stopWebhooks()
+	if err != nil {
+		return nil, "", err
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_FCNTL, f.Fd(), syscall.F_SETFD, 0); errno != 0 {
+		f.Close()
+		return nil, "", errno
+	}
+	return f, addr, nil
+}
+
+// failUpgrade reports an upgrade or restart that did not happen
+func (d *Daemon) failUpgrade(restart bool, err error) {
+	if restart {
+		d.logger.Printf("Restart: %v\n", err)
+	} else {
+		d.logger.Printf("Upgrade: %v\n", err)
+	}
+	d.reportControl(ControlResult{Action: controlAction(restart), Version: version.Version, Error: err.Error()})
+}
+
+// takeHandoff reads the state left by the daemon this process was exec'd
+// from, or returns nil when the process was started afresh
+func (d *Daemon) takeHandoff() *handoff {
+	path := handoffPath(d.mobDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	os.Remove(path)
+
+	var h handoff
+	if err := json.Unmarshal(data, &h); err != nil || h.PID != os.Getpid() {
+		return nil
+	}
+	return &h
+}
+
+// applyHandoff restores the state an upgrading or restarting daemon passed
+// on. Its soldati sessions are resumed once the daemon is running.
+func (d *Daemon) applyHandoff(h *handoff) {
+	d.jobsSince = h.JobsSince
+	d.offHours = h.OffHours
+	d.mu.Lock()
+	for k, v := range h.NudgedAt {
+		d.nudgedAt[k] = v
+	}
+	for k, v := range h.Reloads {
+		d.reloads[k] = v
+	}
+	d.mu.Unlock()
+
+	if h.WebhookFD > 0 {
+		f := os.NewFile(uintptr(h.WebhookFD), "webhooks")
+		ln, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			d.logger.Printf("Upgrade: could not take over the webhook socket: %v\n", err)
+		} else {
+			d.inheritedLn, d.inheritedAt = ln, h.WebhookAddr
+		}
+	}
+
+	if h.Restart {
+		d.logger.Printf("Restart: now running %s\n", version.Version)
+	} else {
+		d.logger.Printf("Upgrade: now running %s (was %s)\n", version.Version, h.Version)
+	}
+	d.reportControl(ControlResult{Action: controlAction(h.Restart), Version: version.Version})
+}
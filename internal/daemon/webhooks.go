@@ -0,0 +1,87 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gabe/mob/internal/webhook"
+)
+
+// startWebhooks serves the git hosting webhook endpoint when [webhooks]
+// listen is set. A port that cannot be bound is logged and the daemon runs
+// without it.
+func (d *Daemon) startWebhooks() {
+	cfg := d.cfg.Webhooks
+	if cfg.Listen == "" || d.beadStore == nil {
+		return
+	}
+
+	ln, inherited := d.takeInheritedListener(cfg.Listen)
+	if !inherited {
+		var err error
+		if ln, err = net.Listen("tcp", cfg.Listen); err != nil {
+			d.logger.Printf("Warning: webhooks disabled: %v\n", err)
+			return
+		}
+	}
+	d.warnUnsigned(ln.Addr(), "GitHub", "github_secret", cfg.GitHubSecret)
+	d.warnUnsigned(ln.Addr(), "GitLab", "gitlab_token", cfg.GitLabToken)
+
+	handler := webhook.NewHandler(d.beadStore, cfg, func(format string, args ...any) {
+		d.logger.Printf(format+"\n", args...)
+	})
+	d.webhooks = &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	d.webhookLn = ln
+	d.logger.Printf("Webhooks: listening on %s\n", ln.Addr())
+
+	go func(srv *http.Server) {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			d.logger.Printf("Webhooks: server stopped: %v\n", err)
+		}
+	}(d.webhooks)
+}
+
+// warnUnsigned logs what happens to a provider's deliveries when its secret
+// is not set: they are refused, or taken unchecked under allow_unsigned
+func (d *Daemon) warnUnsigned(addr net.Addr, provider, key, secret string) {
+	if secret != "" {
+		return
+	}
+	if d.cfg.Webhooks.AllowUnsigned {
+		d.logger.Printf("Warning: webhooks on %s accept unsigned %s deliveries; set %s\n", addr, provider, key)
+		return
+	}
+	d.logger.Printf("Warning: webhooks on %s reject %s deliveries until %s is set\n", addr, provider, key)
+}
+
+// stopWebhooks lets in-flight deliveries finish, then closes the endpoint
+func (d *Daemon) stopWebhooks() {
+	if d.webhooks == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.webhooks.Shutdown(ctx); err != nil {
+		d.logger.Printf("Webhooks: %v\n", err)
+	}
+	d.webhooks, d.webhookLn = nil, nil
+}
+
+// takeInheritedListener returns the webhook socket handed over by an
+// upgrade when it was opened for the same address. One opened for another
+// address is closed.
+func (d *Daemon) takeInheritedListener(listen string) (net.Listener, bool) {
+	ln := d.inheritedLn
+	if ln == nil {
+		return nil, false
+	}
+	d.inheritedLn = nil
+	if d.inheritedAt != listen {
+		ln.Close()
+		return nil, false
+	}
+	return ln, true
+}
@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"os"
+
+	"github.com/gabe/mob/internal/agent"
+)
+
+// soldatiHomeDir returns where a soldati runs between assignments: its
+// primary turf when it has one, otherwise the mob directory
+func (d *Daemon) soldatiHomeDir(name string) string {
+	if d.soldatiMgr != nil {
+		if s, err := d.soldatiMgr.Get(name); err == nil && s.PrimaryTurf != "" {
+			return d.resolveTurfPath(s.PrimaryTurf)
+		}
+	}
+	return d.mobDir
+}
+
+// beadWorkDir returns the directory a bead's work should happen in: its
+// worktree when one exists on disk, otherwise its turf. Empty when the bead
+// is unknown or has no turf.
+func (d *Daemon) beadWorkDir(beadID string) string {
+	if d.beadStore == nil || beadID == "" {
+		return ""
+	}
+	bead, err := d.beadStore.Get(beadID)
+	if err != nil {
+		return ""
+	}
+	if bead.WorktreePath != "" && isDir(bead.WorktreePath) {
+		return bead.WorktreePath
+	}
+	if bead.Turf != "" {
+		return d.resolveTurfPath(bead.Turf)
+	}
+	return ""
+}
+
+// pinWorkDir points a soldati at the directory its next call should run in
+// and records it in the registry. Claude keys sessions by directory, so a
+// move starts a fresh session rather than resuming one it cannot find.
+func (d *Daemon) pinWorkDir(name string, a *agent.Agent, beadID string) {
+	workDir := d.beadWorkDir(beadID)
+	if workDir == "" {
+		workDir = d.soldatiHomeDir(name)
+	}
+	if workDir == a.WorkDir {
+		return
+	}
+
+	if a.SessionID != "" {
+		d.logger.Printf("Soldati '%s' moving from %s to %s, starting a new session\n", name, a.WorkDir, workDir)
+		a.SessionID = ""
+	}
+	a.WorkDir = workDir
+	if d.registry != nil {
+		d.registry.UpdateWorkDir(a.ID, workDir)
+	}
+}
+
+// isDir reports whether path exists and is a directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/registry"
+)
+
+func TestPinWorkDir(t *testing.T) {
+	d, turfDir, bead := newTurfTestDaemon(t)
+
+	d.registry = registry.New(filepath.Join(d.mobDir, ".mob", "agents.json"))
+	a := &agent.Agent{ID: "s-1", WorkDir: d.mobDir, SessionID: "sess-1"}
+	d.registry.Register(&registry.AgentRecord{ID: a.ID, Type: "soldati", Name: "vinnie", Status: "idle", StartedAt: time.Now()})
+
+	// A bead without a worktree runs in its turf, in a fresh session
+	d.pinWorkDir("vinnie", a, bead.ID)
+	if a.WorkDir != turfDir || a.SessionID != "" {
+		t.Errorf("workdir=%s session=%q, want %s and a fresh session", a.WorkDir, a.SessionID, turfDir)
+	}
+	if record, _ := d.registry.Get(a.ID); record.WorkDir != turfDir {
+		t.Errorf("registry workdir = %q, want %s", record.WorkDir, turfDir)
+	}
+
+	// Staying put keeps the session
+	a.SessionID = "sess-2"
+	d.pinWorkDir("vinnie", a, bead.ID)
+	if a.SessionID != "sess-2" {
+		t.Error("expected session kept when the work dir is unchanged")
+	}
+
+	// A worktree on disk wins over the turf
+	worktree := filepath.Join(turfDir, ".worktrees", bead.ID)
+	if err := os.MkdirAll(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	bead.WorktreePath = worktree
+	if _, err := d.beadStore.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+	d.pinWorkDir("vinnie", a, bead.ID)
+	if a.WorkDir != worktree {
+		t.Errorf("workdir = %s, want worktree %s", a.WorkDir, worktree)
+	}
+
+	// Unknown beads fall back to the soldati's home
+	d.pinWorkDir("vinnie", a, "bd-missing")
+	if a.WorkDir != d.mobDir {
+		t.Errorf("workdir = %s, want mob dir %s", a.WorkDir, d.mobDir)
+	}
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"errors"
+
+	"github.com/gabe/mob/internal/quota"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+// enforceWorktreeQuotas evicts least recently used idle worktrees in every
+// turf that is over its configured count or size quota
+func (d *Daemon) enforceWorktreeQuotas() {
+	if d.turfMgr == nil {
+		return
+	}
+
+	for _, t := range d.turfMgr.List() {
+		policy := d.cfg.Worktrees.PolicyFor(t.Name)
+		if policy.MaxCount == 0 && policy.MaxSizeMB == 0 {
+			continue
+		}
+
+		repo, err := vcs.Open(t.Path, t.VCS)
+		if err != nil {
+			continue
+		}
+
+		result, err := quota.Enforce(repo, policy, d.beadStore, 0)
+		if err != nil && !errors.Is(err, quota.ErrQuotaExceeded) {
+			d.logger.Printf("Worktrees: quota check failed for turf %s: %v\n", t.Name, err)
+			continue
+		}
+		if len(result.Evicted) > 0 {
+			d.logger.Printf("Worktrees: evicted %d idle worktree(s) in turf %s: %v\n", len(result.Evicted), t.Name, result.Evicted)
+		}
+		if errors.Is(err, quota.ErrQuotaExceeded) {
+			d.logger.Printf("Worktrees: turf %s is over quota but every worktree is in use or dirty\n", t.Name)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// EdgeKind describes how two beads are related
+type EdgeKind string
+
+const (
+	EdgeParent  EdgeKind = "parent"  // From is the parent of To
+	EdgeBlocks  EdgeKind = "blocks"  // From blocks To
+	EdgeRelated EdgeKind = "related" // undirected
+)
+
+// GraphEdge is a relationship between two beads
+type GraphEdge struct {
+	From string
+	To   string
+	Kind EdgeKind
+}
+
+// BeadGraph is the relationship graph between a set of beads
+type BeadGraph struct {
+	Beads []*models.Bead
+	Edges []GraphEdge
+
+	byID map[string]*models.Bead
+}
+
+// BuildBeadGraph collects parent, blocks, and related edges between the given
+// beads. Edges pointing outside the set are dropped.
+func BuildBeadGraph(beads []*models.Bead) *BeadGraph {
+	g := &BeadGraph{byID: make(map[string]*models.Bead, len(beads))}
+
+	sorted := append([]*models.Bead(nil), beads...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	g.Beads = sorted
+	for _, b := range sorted {
+		g.byID[b.ID] = b
+	}
+
+	seenRelated := make(map[string]bool)
+	for _, b := range sorted {
+		if b.ParentID != "" && g.byID[b.ParentID] != nil {
+			g.Edges = append(g.Edges, GraphEdge{From: b.ParentID, To: b.ID, Kind: EdgeParent})
+		}
+		for _, id := range b.Blocks {
+			if g.byID[id] != nil {
+				g.Edges = append(g.Edges, GraphEdge{From: b.ID, To: id, Kind: EdgeBlocks})
+			}
+		}
+		for _, id := range b.Related {
+			if g.byID[id] == nil {
+				continue
+			}
+			// Related links are symmetric; emit each pair once
+			key := b.ID + "|" + id
+			if id < b.ID {
+				key = id + "|" + b.ID
+			}
+			if seenRelated[key] {
+				continue
+			}
+			seenRelated[key] = true
+			g.Edges = append(g.Edges, GraphEdge{From: b.ID, To: id, Kind: EdgeRelated})
+		}
+	}
+
+	return g
+}
+
+// RenderDOT renders the graph in Graphviz DOT format
+func RenderDOT(g *BeadGraph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph beads {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, b := range g.Beads {
+		sb.WriteString(fmt.Sprintf("  %q [label=%q];\n", b.ID, graphLabel(b)))
+	}
+
+	for _, e := range g.Edges {
+		var attrs string
+		switch e.Kind {
+		case EdgeParent:
+			attrs = ` [style=dotted, label="subtask"]`
+		case EdgeBlocks:
+			attrs = ` [label="blocks"]`
+		case EdgeRelated:
+			attrs = ` [style=dashed, dir=none, label="related"]`
+		}
+		sb.WriteString(fmt.Sprintf("  %q -> %q%s;\n", e.From, e.To, attrs))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart
+func RenderMermaid(g *BeadGraph) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	for _, b := range g.Beads {
+		label := strings.ReplaceAll(graphLabel(b), `"`, "#quot;")
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", mermaidID(b.ID), label))
+	}
+
+	for _, e := range g.Edges {
+		var arrow string
+		switch e.Kind {
+		case EdgeParent:
+			arrow = "-.->|subtask|"
+		case EdgeBlocks:
+			arrow = "-->|blocks|"
+		case EdgeRelated:
+			arrow = "---|related|"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s %s\n", mermaidID(e.From), arrow, mermaidID(e.To)))
+	}
+
+	return sb.String()
+}
+
+// RenderGraphTree renders the graph as an ASCII forest. Subtasks and blocked
+// beads nest under their parent or blocker; related beads are listed inline.
+// Beads reachable from more than one place are expanded once.
+func RenderGraphTree(g *BeadGraph, opts TreeOpts) string {
+	children := make(map[string][]string)
+	related := make(map[string][]string)
+	hasIncoming := make(map[string]bool)
+
+	for _, e := range g.Edges {
+		switch e.Kind {
+		case EdgeParent, EdgeBlocks:
+			children[e.From] = append(children[e.From], e.To)
+			hasIncoming[e.To] = true
+		case EdgeRelated:
+			related[e.From] = append(related[e.From], e.To)
+			related[e.To] = append(related[e.To], e.From)
+		}
+	}
+
+	var sb strings.Builder
+	expanded := make(map[string]bool)
+
+	var walk func(id, indent string, isLast, isRoot bool, depth int)
+	walk = func(id, indent string, isLast, isRoot bool, depth int) {
+		bead := g.byID[id]
+
+		line := renderBead(bead, "", isRoot, opts)
+		if len(related[id]) > 0 {
+			line += fmt.Sprintf(" ~ related: %s", strings.Join(related[id], ", "))
+		}
+
+		childIndent := ""
+		if !isRoot {
+			sb.WriteString(indent)
+			if isLast {
+				sb.WriteString(treeLastBranch)
+				childIndent = indent + treeEmpty
+			} else {
+				sb.WriteString(treeBranch)
+				childIndent = indent + treeVertical
+			}
+			sb.WriteString(" ")
+		}
+
+		if expanded[id] {
+			sb.WriteString(line + " (see above)\n")
+			return
+		}
+		expanded[id] = true
+		sb.WriteString(line + "\n")
+
+		if depth >= opts.MaxDepth {
+			return
+		}
+		kids := children[id]
+		for i, child := range kids {
+			walk(child, childIndent, i == len(kids)-1, false, depth+1)
+		}
+	}
+
+	for _, b := range g.Beads {
+		if !hasIncoming[b.ID] {
+			walk(b.ID, "", true, true, 0)
+		}
+	}
+
+	// Anything left unvisited sits on a cycle with no entry point
+	for _, b := range g.Beads {
+		if !expanded[b.ID] {
+			walk(b.ID, "", true, true, 0)
+		}
+	}
+
+	return sb.String()
+}
+
+// graphLabel is the node text used in DOT and Mermaid output
+func graphLabel(b *models.Bead) string {
+	title := b.Title
+	if r := []rune(title); len(r) > 40 {
+		title = string(r[:37]) + "..."
+	}
+	return fmt.Sprintf("%s: %s (%s)", b.ID, title, b.Status)
+}
+
+// mermaidID makes a bead ID safe to use as a Mermaid node identifier
+func mermaidID(id string) string {
+	return strings.ReplaceAll(id, "-", "_")
+}
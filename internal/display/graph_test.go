@@ -0,0 +1,87 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func testGraph() *BeadGraph {
+	return BuildBeadGraph([]*models.Bead{
+		{ID: "bd-0001", Title: "Epic", Status: models.BeadStatusOpen},
+		{ID: "bd-0002", Title: "Schema", Status: models.BeadStatusClosed, ParentID: "bd-0001", Blocks: []string{"bd-0003"}},
+		{ID: "bd-0003", Title: "API", Status: models.BeadStatusOpen, ParentID: "bd-0001", Related: []string{"bd-0004"}},
+		{ID: "bd-0004", Title: "Docs", Status: models.BeadStatusOpen, Related: []string{"bd-0003", "bd-9999"}},
+	})
+}
+
+func TestBuildBeadGraph(t *testing.T) {
+	g := testGraph()
+
+	counts := make(map[EdgeKind]int)
+	for _, e := range g.Edges {
+		counts[e.Kind]++
+	}
+	if counts[EdgeParent] != 2 || counts[EdgeBlocks] != 1 || counts[EdgeRelated] != 1 {
+		t.Fatalf("unexpected edges: %+v", g.Edges)
+	}
+}
+
+func TestRenderDOTAndMermaid(t *testing.T) {
+	g := testGraph()
+
+	dot := RenderDOT(g)
+	if !strings.Contains(dot, `"bd-0002" -> "bd-0003" [label="blocks"]`) {
+		t.Errorf("missing blocks edge in DOT:\n%s", dot)
+	}
+
+	mermaid := RenderMermaid(g)
+	if !strings.Contains(mermaid, "bd_0001 -.->|subtask| bd_0002") {
+		t.Errorf("missing parent edge in Mermaid:\n%s", mermaid)
+	}
+}
+
+func TestRenderGraphTree(t *testing.T) {
+	opts := DefaultTreeOpts()
+	opts.ColorEnabled = false
+	out := RenderGraphTree(testGraph(), opts)
+
+	if !strings.HasPrefix(out, "bd-0001 Epic (open)\n") {
+		t.Errorf("expected epic as first root:\n%s", out)
+	}
+	if !strings.Contains(out, "(see above)") {
+		t.Errorf("expected bd-0003 reached twice to be collapsed:\n%s", out)
+	}
+	if !strings.Contains(out, "bd-0004 Docs (open) ~ related: bd-0003") {
+		t.Errorf("expected related annotation:\n%s", out)
+	}
+}
+
+func TestRenderGraphTreeCycle(t *testing.T) {
+	g := BuildBeadGraph([]*models.Bead{
+		{ID: "bd-a", Title: "A", Blocks: []string{"bd-b"}},
+		{ID: "bd-b", Title: "B", Blocks: []string{"bd-a"}},
+	})
+	opts := DefaultTreeOpts()
+	opts.ColorEnabled = false
+
+	out := RenderGraphTree(g, opts)
+	if !strings.Contains(out, "bd-a") || !strings.Contains(out, "bd-b") {
+		t.Fatalf("expected cycle members rendered:\n%s", out)
+	}
+}
+
+func TestGraphLabelTruncatesOnRunes(t *testing.T) {
+	title := strings.Repeat("é", 45)
+	got := graphLabel(&models.Bead{ID: "bd-0001", Title: title, Status: models.BeadStatusOpen})
+	want := "bd-0001: " + strings.Repeat("é", 37) + "... (open)"
+	if got != want {
+		t.Errorf("label = %q, want %q", got, want)
+	}
+
+	short := strings.Repeat("é", 30)
+	if got := graphLabel(&models.Bead{ID: "bd-0001", Title: short, Status: models.BeadStatusOpen}); !strings.Contains(got, short+" (open)") {
+		t.Errorf("label = %q, want a 30-rune title left whole", got)
+	}
+}
@@ -0,0 +1,66 @@
+// Package errkind classifies errors so the daemon, MCP server and CLI can
+// react to what went wrong ("not found", "conflict", "transient") with
+// errors.Is instead of matching on message text.
+package errkind
+
+import "errors"
+
+// Error kinds. Package sentinels built with New match their kind under
+// errors.Is, so callers can check either the specific error or its kind.
+var (
+	// NotFound means the bead, agent, turf or other record does not exist
+	NotFound = errors.New("not found")
+	// Conflict means the operation clashes with existing state, such as a
+	// duplicate queue entry or a merge conflict
+	Conflict = errors.New("conflict")
+	// Transient means the operation may succeed if retried, such as a store
+	// held by another process
+	Transient = errors.New("temporarily unavailable")
+	// Invalid means the input was malformed or missing
+	Invalid = errors.New("invalid")
+)
+
+// kinds lists every kind in the order Of checks them
+var kinds = []error{NotFound, Conflict, Transient, Invalid}
+
+// kindError is an error that belongs to a kind
+type kindError struct {
+	msg  string
+	kind error
+	err  error // wrapped cause, nil for sentinels
+}
+
+func (e *kindError) Error() string {
+	if e.msg == "" && e.err != nil {
+		return e.err.Error()
+	}
+	return e.msg
+}
+
+func (e *kindError) Is(target error) bool { return target == e.kind }
+
+func (e *kindError) Unwrap() error { return e.err }
+
+// New returns a sentinel error with msg that matches kind under errors.Is
+func New(kind error, msg string) error {
+	return &kindError{msg: msg, kind: kind}
+}
+
+// Wrap marks err as belonging to kind, keeping its message and chain.
+// A nil err returns nil.
+func Wrap(kind error, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// Of returns the kind of err, or nil when it has none
+func Of(err error) error {
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package errkind
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSentinelMatchesItselfAndKind(t *testing.T) {
+	errMissing := New(NotFound, "bead not found")
+	wrapped := fmt.Errorf("%w: bd-1234", errMissing)
+
+	if wrapped.Error() != "bead not found: bd-1234" {
+		t.Errorf("Error() = %q", wrapped.Error())
+	}
+	if !errors.Is(wrapped, errMissing) || !errors.Is(wrapped, NotFound) {
+		t.Error("expected wrapped sentinel to match itself and its kind")
+	}
+	if errors.Is(wrapped, Conflict) {
+		t.Error("expected no match for another kind")
+	}
+	if Of(wrapped) != NotFound {
+		t.Errorf("Of() = %v, want NotFound", Of(wrapped))
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("resource busy")
+	err := Wrap(Transient, cause)
+
+	if err.Error() != "resource busy" {
+		t.Errorf("Error() = %q, want cause message", err.Error())
+	}
+	if !errors.Is(err, cause) || !errors.Is(err, Transient) {
+		t.Error("expected wrapped error to match cause and kind")
+	}
+	if Wrap(Transient, nil) != nil {
+		t.Error("expected Wrap(nil) to be nil")
+	}
+	if Of(errors.New("plain")) != nil {
+		t.Error("expected plain errors to have no kind")
+	}
+}
@@ -0,0 +1,46 @@
+package events
+
+import "github.com/gabe/mob/internal/models"
+
+// Activity turns an event into its activity feed entry. Events the feed
+// does not show report false: bead assignments are mirrored to the feed by
+// the bead store itself, and heresy fixes show up as the fix bead's changes.
+func Activity(e Event) (models.Activity, bool) {
+	a := models.Activity{Message: e.Message()}
+	switch e := e.(type) {
+	case DaemonStarted:
+		a.Type = models.ActivityDaemonStarted
+	case DaemonStopped:
+		a.Type = models.ActivityDaemonStopped
+	case DaemonRecovered:
+		a.Type = models.ActivityDaemonRecovered
+	case AgentSpawned:
+		a.Type, a.Agent, a.Turf = models.ActivityAgentSpawned, e.Agent, e.Turf
+	case AgentStuck:
+		a.Type, a.Agent, a.BeadID, a.Turf = models.ActivityAgentStuck, e.Agent, e.BeadID, e.Turf
+	case AgentStopped:
+		a.Type, a.Agent, a.BeadID, a.Turf = models.ActivityAgentStopped, e.Agent, e.BeadID, e.Turf
+	case AgentRetired:
+		a.Type, a.Agent, a.Turf = models.ActivityAgentStopped, e.Agent, e.Turf
+	case AgentFailed:
+		a.Type, a.Agent, a.BeadID = models.ActivityError, e.Agent, e.BeadID
+	case RetriesExhausted:
+		a.Type, a.BeadID, a.Turf = models.ActivityError, e.BeadID, e.Turf
+	case WorkParked:
+		a.Type, a.Agent, a.BeadID, a.Turf = models.ActivityWorkParked, e.Agent, e.BeadID, e.Turf
+	case WorkStarted:
+		a.Type, a.Agent, a.BeadID = models.ActivityWorkAssigned, e.Agent, e.BeadID
+	case MergeCompleted:
+		a.Type, a.BeadID, a.Turf = models.ActivityMergeLanded, e.BeadID, e.Turf
+		if !e.Success {
+			a.Type = models.ActivityMergeFailed
+		}
+	case ModelDegraded:
+		a.Type = models.ActivityModelDegraded
+	case ModelRecovered:
+		a.Type = models.ActivityModelRecovered
+	default:
+		return models.Activity{}, false
+	}
+	return a, true
+}
@@ -0,0 +1,332 @@
+// Package events is the daemon's in-process event bus. Subsystems publish
+// typed events as things happen (an agent spawned, a bead assigned, a merge
+// finished) and subscribers react to the ones they care about: the daemon
+// log, the activity feed that the TUI and mob status read, and the
+// notification backends. Publishers do not need to know who is listening.
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Type names a kind of event
+type Type string
+
+// Event types
+const (
+	TypeDaemonStarted    Type = "daemon_started"
+	TypeDaemonStopped    Type = "daemon_stopped"
+	TypeDaemonRecovered  Type = "daemon_recovered"
+	TypeAgentSpawned     Type = "agent_spawned"
+	TypeAgentStuck       Type = "agent_stuck"
+	TypeAgentStopped     Type = "agent_stopped"
+	TypeAgentFailed      Type = "agent_failed"
+	TypeAgentRetired     Type = "agent_retired"
+	TypeRetriesExhausted Type = "retries_exhausted"
+	TypeWorkParked       Type = "work_parked"
+	TypeBeadAssigned     Type = "bead_assigned"
+	TypeWorkStarted      Type = "work_started"
+	TypeMergeCompleted   Type = "merge_completed"
+	TypeModelDegraded    Type = "model_degraded"
+	TypeModelRecovered   Type = "model_recovered"
+	TypeHeresyFixStarted Type = "heresy_fix_started"
+)
+
+// Event is something that happened in the daemon
+type Event interface {
+	Type() Type
+	Message() string // one line for people: logs, the feed, notifications
+}
+
+// DaemonStarted is published once the daemon is up
+type DaemonStarted struct {
+	Version      string
+	UpgradedFrom string // version the daemon was upgraded from in place, if it was
+	Restarted    bool   // by `mob daemon restart`, resuming the previous process's sessions
+}
+
+// DaemonStopped is published as the daemon shuts down or execs a new binary
+type DaemonStopped struct {
+	Version    string
+	Upgrading  bool
+	Restarting bool
+}
+
+// DaemonRecovered is published after cleaning up from an unclean shutdown
+type DaemonRecovered struct {
+	Reaped  int // orphaned agent calls killed
+	Cleared int // stale VCS locks removed
+}
+
+// AgentSpawned is published when a soldati starts or is restarted
+type AgentSpawned struct {
+	Agent     string
+	Turf      string
+	Respawned bool
+}
+
+// AgentStuck is published when an associate runs past its timeout and is
+// nudged, or when a soldati's call goes silent for stuck_timeout
+type AgentStuck struct {
+	Agent   string
+	AgentID string
+	BeadID  string
+	Turf    string
+	Task    string
+	Silent  time.Duration // how long the soldati's call has printed nothing; 0 for associates
+}
+
+// AgentStopped is published when the daemon kills an agent
+type AgentStopped struct {
+	Agent  string
+	BeadID string
+	Turf   string
+	Reason string
+}
+
+// AgentRetired is published when the daemon retires a soldati it added for
+// a turf's backlog, once that backlog drains
+type AgentRetired struct {
+	Agent string
+	Turf  string
+}
+
+// AgentFailed is published when an agent's work ends in an error
+type AgentFailed struct {
+	Agent   string
+	AgentID string
+	BeadID  string
+	Err     error
+}
+
+// RetriesExhausted is published when a bead has failed as many times as the
+// [failures] policy allows and is left for `mob failures retry`
+type RetriesExhausted struct {
+	BeadID    string
+	Turf      string
+	Attempts  int
+	LastError string
+}
+
+// WorkParked is published when a soldati's work is set aside for an urgent bead
+type WorkParked struct {
+	Agent       string
+	BeadID      string
+	Priority    int
+	Turf        string
+	For         string // the urgent bead
+	ForPriority int
+}
+
+// BeadAssigned is published when the daemon hands a bead to an idle agent
+type BeadAssigned struct {
+	BeadID string
+	Agent  string
+	Turf   string
+}
+
+// WorkStarted is published when an agent picks up the work on its hook
+type WorkStarted struct {
+	Agent  string
+	BeadID string
+}
+
+// MergeCompleted is published when a queued merge lands or fails
+type MergeCompleted struct {
+	BeadID  string
+	Turf    string
+	Success bool
+	Summary string // what became of the bead
+}
+
+// ModelDegraded is published when a model's provider keeps failing and
+// calls start failing over
+type ModelDegraded struct {
+	Model     string
+	Fallback  string // model calls fail over to, empty when there is none
+	Failures  int
+	LastError string
+}
+
+// ModelRecovered is published when a degraded model answers again
+type ModelRecovered struct {
+	Model string
+}
+
+// HeresyFixStarted is published when an associate starts on the fix for an
+// approved heresy
+type HeresyFixStarted struct {
+	BeadID   string // the fix
+	HeresyID string
+	Agent    string
+}
+
+func (DaemonStarted) Type() Type    { return TypeDaemonStarted }
+func (DaemonStopped) Type() Type    { return TypeDaemonStopped }
+func (DaemonRecovered) Type() Type  { return TypeDaemonRecovered }
+func (AgentSpawned) Type() Type     { return TypeAgentSpawned }
+func (AgentStuck) Type() Type       { return TypeAgentStuck }
+func (AgentStopped) Type() Type     { return TypeAgentStopped }
+func (AgentFailed) Type() Type      { return TypeAgentFailed }
+func (AgentRetired) Type() Type     { return TypeAgentRetired }
+func (RetriesExhausted) Type() Type { return TypeRetriesExhausted }
+func (WorkParked) Type() Type       { return TypeWorkParked }
+func (BeadAssigned) Type() Type     { return TypeBeadAssigned }
+func (WorkStarted) Type() Type      { return TypeWorkStarted }
+func (MergeCompleted) Type() Type   { return TypeMergeCompleted }
+func (ModelDegraded) Type() Type    { return TypeModelDegraded }
+func (ModelRecovered) Type() Type   { return TypeModelRecovered }
+func (HeresyFixStarted) Type() Type { return TypeHeresyFixStarted }
+
+func (e DaemonStarted) Message() string {
+	if e.Restarted {
+		return fmt.Sprintf("Daemon restarted (%s)", e.Version)
+	}
+	if e.UpgradedFrom != "" {
+		return fmt.Sprintf("Daemon upgraded from %s to %s", e.UpgradedFrom, e.Version)
+	}
+	return "Daemon started"
+}
+
+func (e DaemonStopped) Message() string {
+	if e.Restarting {
+		return "Daemon restarting"
+	}
+	if e.Upgrading {
+		return fmt.Sprintf("Daemon upgrading from %s", e.Version)
+	}
+	return "Daemon stopped"
+}
+
+func (e DaemonRecovered) Message() string {
+	return fmt.Sprintf("Recovered from an unclean shutdown: killed %d orphaned agent call(s), cleared %d stale lock(s)", e.Reaped, e.Cleared)
+}
+
+func (e AgentSpawned) Message() string {
+	if e.Respawned {
+		return fmt.Sprintf("Soldati %s respawned", e.Agent)
+	}
+	return fmt.Sprintf("Soldati %s spawned", e.Agent)
+}
+
+func (e AgentStuck) Message() string {
+	if e.Silent > 0 {
+		return fmt.Sprintf("Soldati %s has produced no output for %s", e.Agent, e.Silent.Round(time.Second))
+	}
+	return fmt.Sprintf("Associate %s exceeded its timeout, nudged", e.Agent)
+}
+
+func (e AgentStopped) Message() string {
+	return fmt.Sprintf("Associate %s killed: %s", e.Agent, e.Reason)
+}
+
+func (e AgentRetired) Message() string {
+	return fmt.Sprintf("Soldati %s retired, %s's queue has drained", e.Agent, e.Turf)
+}
+
+func (e AgentFailed) Message() string {
+	return fmt.Sprintf("Soldati %s failed: %v", e.Agent, e.Err)
+}
+
+func (e WorkParked) Message() string {
+	return fmt.Sprintf("Soldati %s parked %s (P%d) for urgent %s (P%d)", e.Agent, e.BeadID, e.Priority, e.For, e.ForPriority)
+}
+
+func (e RetriesExhausted) Message() string {
+	return fmt.Sprintf("Bead %s failed %d time(s) and will not be retried automatically: %s", e.BeadID, e.Attempts, e.LastError)
+}
+
+func (e BeadAssigned) Message() string {
+	return fmt.Sprintf("Bead %s assigned to %s", e.BeadID, e.Agent)
+}
+
+func (e WorkStarted) Message() string {
+	return fmt.Sprintf("Soldati %s started work on %s", e.Agent, e.BeadID)
+}
+
+func (e MergeCompleted) Message() string {
+	return e.Summary
+}
+
+func (e ModelDegraded) Message() string {
+	msg := fmt.Sprintf("Model %s degraded after %d provider errors: %s", e.Model, e.Failures, e.LastError)
+	if e.Fallback != "" {
+		msg += "; failing over to " + e.Fallback
+	}
+	return msg
+}
+
+func (e ModelRecovered) Message() string {
+	return fmt.Sprintf("Model %s recovered", e.Model)
+}
+
+func (e HeresyFixStarted) Message() string {
+	return fmt.Sprintf("Associate %s started on fix %s for approved heresy %s", e.Agent, e.BeadID, e.HeresyID)
+}
+
+// Handler reacts to an event
+type Handler func(Event)
+
+type subscription struct {
+	handler Handler
+	types   map[Type]bool // nil = every type
+}
+
+// Bus delivers published events to its subscribers
+type Bus struct {
+	mu   sync.RWMutex
+	subs []*subscription
+}
+
+// NewBus creates a bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers h for events of the given types, or for every event
+// when none are given. Call the returned func to unsubscribe.
+func (b *Bus) Subscribe(h Handler, types ...Type) func() {
+	sub := &subscription{handler: h}
+	if len(types) > 0 {
+		sub.types = make(map[Type]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.subs = append(b.subs, sub)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, s := range b.subs {
+			if s == sub {
+				b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish hands e to each subscriber that wants it, in the order they
+// subscribed, on the caller's goroutine. Handlers should return quickly;
+// one with slow work to do should start it in the background. Publishing
+// on a nil bus does nothing.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		if s.types == nil || s.types[e.Type()] {
+			s.handler(e)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestBus_DeliversInSubscribeOrder(t *testing.T) {
+	bus := NewBus()
+	var got []string
+	bus.Subscribe(func(e Event) { got = append(got, "first:"+string(e.Type())) })
+	bus.Subscribe(func(e Event) { got = append(got, "second:"+string(e.Type())) })
+
+	bus.Publish(AgentSpawned{Agent: "vinnie"})
+
+	want := []string{"first:agent_spawned", "second:agent_spawned"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("delivered %v, want %v", got, want)
+	}
+}
+
+func TestBus_SubscribeToTypes(t *testing.T) {
+	bus := NewBus()
+	var got []Type
+	bus.Subscribe(func(e Event) { got = append(got, e.Type()) }, TypeModelDegraded, TypeModelRecovered)
+
+	bus.Publish(AgentSpawned{Agent: "vinnie"})
+	bus.Publish(ModelDegraded{Model: "opus"})
+	bus.Publish(ModelRecovered{Model: "opus"})
+
+	if len(got) != 2 || got[0] != TypeModelDegraded || got[1] != TypeModelRecovered {
+		t.Errorf("delivered %v, want only the model events", got)
+	}
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	bus := NewBus()
+	calls := 0
+	var unsubscribe func()
+	unsubscribe = bus.Subscribe(func(Event) {
+		calls++
+		unsubscribe() // from inside a handler, mid-publish
+	})
+	other := 0
+	bus.Subscribe(func(Event) { other++ })
+
+	bus.Publish(DaemonStarted{})
+	bus.Publish(DaemonStarted{})
+
+	if calls != 1 || other != 2 {
+		t.Errorf("unsubscribed handler ran %d times, other %d; want 1 and 2", calls, other)
+	}
+}
+
+func TestBus_NilDropsEvents(t *testing.T) {
+	var bus *Bus
+	bus.Publish(DaemonStarted{}) // must not panic
+}
+
+func TestActivity(t *testing.T) {
+	tests := []struct {
+		event   Event
+		typ     models.ActivityType
+		message string
+	}{
+		{DaemonStarted{Version: "1.2.0", UpgradedFrom: "1.1.0"}, models.ActivityDaemonStarted, "Daemon upgraded from 1.1.0 to 1.2.0"},
+		{DaemonStarted{Version: "1.2.0", UpgradedFrom: "1.2.0", Restarted: true}, models.ActivityDaemonStarted, "Daemon restarted (1.2.0)"},
+		{DaemonStopped{Version: "1.1.0", Upgrading: true}, models.ActivityDaemonStopped, "Daemon upgrading from 1.1.0"},
+		{AgentSpawned{Agent: "vinnie", Respawned: true}, models.ActivityAgentSpawned, "Soldati vinnie respawned"},
+		{AgentStuck{Agent: "vinnie", Silent: 10*time.Minute + 300*time.Millisecond}, models.ActivityAgentStuck, "Soldati vinnie has produced no output for 10m0s"},
+		{WorkParked{Agent: "vinnie", BeadID: "bd-a1b2", Priority: 3, For: "bd-c3d4"}, models.ActivityWorkParked, "Soldati vinnie parked bd-a1b2 (P3) for urgent bd-c3d4 (P0)"},
+		{AgentRetired{Agent: "vinnie", Turf: "backend"}, models.ActivityAgentStopped, "Soldati vinnie retired, backend's queue has drained"},
+		{RetriesExhausted{BeadID: "bd-a1b2", Attempts: 3, LastError: "boom"}, models.ActivityError, "Bead bd-a1b2 failed 3 time(s) and will not be retried automatically: boom"},
+		{AgentFailed{Agent: "vinnie", BeadID: "bd-a1b2", Err: errors.New("boom")}, models.ActivityError, "Soldati vinnie failed: boom"},
+		{MergeCompleted{BeadID: "bd-a1b2", Summary: "conflict"}, models.ActivityMergeFailed, "conflict"},
+		{MergeCompleted{BeadID: "bd-a1b2", Success: true, Summary: "merged"}, models.ActivityMergeLanded, "merged"},
+		{ModelDegraded{Model: "opus", Failures: 5, LastError: "529", Fallback: "sonnet"}, models.ActivityModelDegraded, "Model opus degraded after 5 provider errors: 529; failing over to sonnet"},
+	}
+	for _, tt := range tests {
+		a, ok := Activity(tt.event)
+		if !ok || a.Type != tt.typ || a.Message != tt.message {
+			t.Errorf("Activity(%T) = %v %q (%v), want %v %q", tt.event, a.Type, a.Message, ok, tt.typ, tt.message)
+		}
+	}
+
+	a, _ := Activity(WorkStarted{Agent: "vinnie", BeadID: "bd-a1b2"})
+	if a.Agent != "vinnie" || a.BeadID != "bd-a1b2" {
+		t.Errorf("WorkStarted entry = %+v", a)
+	}
+	if _, ok := Activity(BeadAssigned{BeadID: "bd-a1b2", Agent: "vinnie"}); ok {
+		t.Error("bead assignments are mirrored by the bead store; the feed should skip them")
+	}
+}
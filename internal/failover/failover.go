@@ -0,0 +1,282 @@
+// Package failover keeps a circuit breaker per model. Repeated provider
+// errors (rate limits, overload, outages) open a model's circuit and calls
+// move to the configured secondary model; after a cooldown one call probes
+// the primary again, and each failed probe doubles the cooldown. State lives
+// in a file so the daemon, MCP servers and CLI share it.
+package failover
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// ErrStateLocked is returned when the state file lock could not be acquired
+var ErrStateLocked = errkind.New(errkind.Transient, "failover state is locked")
+
+// State is where a model's circuit is
+type State string
+
+const (
+	Closed   State = "closed"    // calls go to the model
+	Open     State = "open"      // calls go to the secondary model
+	HalfOpen State = "half_open" // the cooldown passed; the next call probes the model
+)
+
+// defaultModel names the circuit for calls that do not pick a model
+const defaultModel = "default"
+
+// Circuit is one model's failure record
+type Circuit struct {
+	Model      string     `json:"model"`
+	Failures   int        `json:"failures"`              // consecutive provider errors
+	Trips      int        `json:"trips,omitempty"`       // times opened since the model last succeeded
+	OpenedAt   time.Time  `json:"opened_at,omitempty"`   // when the circuit first opened; zero while closed
+	OpenUntil  time.Time  `json:"open_until,omitempty"`  // end of the current cooldown
+	LastError  string     `json:"last_error,omitempty"`  // the most recent provider error
+	NotifiedAt *time.Time `json:"notified_at,omitempty"` // when the daemon reported the outage
+}
+
+// State returns the circuit's state at now
+func (c *Circuit) State(now time.Time) State {
+	switch {
+	case c.OpenedAt.IsZero():
+		return Closed
+	case now.Before(c.OpenUntil):
+		return Open
+	default:
+		return HalfOpen
+	}
+}
+
+// Breaker routes calls around models whose provider keeps failing
+type Breaker struct {
+	path        string
+	secondary   string
+	threshold   int
+	cooldown    time.Duration
+	maxCooldown time.Duration
+	now         func() time.Time
+	mu          sync.Mutex
+}
+
+// Path returns the failover state file for a mob directory
+func Path(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "failover.json")
+}
+
+// New returns a breaker keeping its state at path
+func New(path string, cfg config.FailoverConfig) *Breaker {
+	return &Breaker{
+		path:        path,
+		secondary:   cfg.Secondary,
+		threshold:   cfg.GetFailureThreshold(),
+		cooldown:    cfg.GetCooldown(),
+		maxCooldown: cfg.GetMaxCooldown(),
+		now:         time.Now,
+	}
+}
+
+// Route returns the model a call meant for model should use: the secondary
+// while model's circuit is open, otherwise model itself
+func (b *Breaker) Route(model string) string {
+	if b.secondary == "" || b.secondary == model {
+		return model
+	}
+	circuits, err := b.load()
+	if err != nil {
+		return model
+	}
+	if c := circuits[key(model)]; c != nil && c.State(b.now()) == Open {
+		return b.secondary
+	}
+	return model
+}
+
+// Failure records a failed call to model. Only provider errors count. The
+// circuit opens at the failure threshold, and a failed probe reopens it for
+// twice as long as before.
+func (b *Breaker) Failure(model string, err error) {
+	if !IsProviderError(err) {
+		return
+	}
+	b.update(func(circuits map[string]*Circuit) {
+		now := b.now()
+		c := circuits[key(model)]
+		if c == nil {
+			c = &Circuit{Model: key(model)}
+			circuits[c.Model] = c
+		}
+		c.Failures++
+		c.LastError = firstLine(err.Error())
+
+		switch c.State(now) {
+		case Closed:
+			if c.Failures >= b.threshold {
+				c.OpenedAt = now
+				c.Trips = 1
+				c.OpenUntil = now.Add(b.cooldown)
+			}
+		case HalfOpen:
+			c.Trips++
+			c.OpenUntil = now.Add(b.backoff(c.Trips))
+		}
+	})
+}
+
+// Success records a successful call to model, closing its circuit
+func (b *Breaker) Success(model string) {
+	if circuits, err := b.load(); err == nil && circuits[key(model)] == nil {
+		return // nothing to reset; skip the write
+	}
+	b.update(func(circuits map[string]*Circuit) {
+		delete(circuits, key(model))
+	})
+}
+
+// Circuits returns every model with failures on record, by model name
+func (b *Breaker) Circuits() ([]*Circuit, error) {
+	circuits, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Circuit, 0, len(circuits))
+	for _, c := range circuits {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Model < out[j].Model })
+	return out, nil
+}
+
+// Secondary returns the model calls fail over to, empty when none is set
+func (b *Breaker) Secondary() string {
+	return b.secondary
+}
+
+// MarkNotified records that the outage on model's circuit was reported
+func (b *Breaker) MarkNotified(model string, at time.Time) error {
+	return b.update(func(circuits map[string]*Circuit) {
+		if c := circuits[key(model)]; c != nil {
+			c.NotifiedAt = &at
+		}
+	})
+}
+
+// backoff is the cooldown after the circuit opened for the trips-th time
+func (b *Breaker) backoff(trips int) time.Duration {
+	d := b.cooldown
+	for i := 1; i < trips && d < b.maxCooldown; i++ {
+		d *= 2
+	}
+	if d > b.maxCooldown {
+		d = b.maxCooldown
+	}
+	return d
+}
+
+// providerErrors are fragments of claude's error output that mean the
+// provider, not the request, failed
+var providerErrors = []string{
+	"api error: 429", "api error: 5", "rate limit", "rate_limit", "usage limit",
+	"overloaded", "api_error", "internal server error", "service unavailable",
+	"bad gateway", "gateway timeout", "connection refused", "econnreset", "etimedout",
+}
+
+// IsProviderError reports whether err looks like the model provider failing
+// (rate limits, overload, outages) rather than the call itself
+func IsProviderError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range providerErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// key names a model's circuit
+func key(model string) string {
+	if model == "" {
+		return defaultModel
+	}
+	return model
+}
+
+// firstLine trims an error to its first line for display
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if len(s) > 200 {
+		s = s[:200]
+	}
+	return s
+}
+
+// load reads the circuits without holding the file lock; writes replace the
+// file atomically, so a reader sees either the old or the new state
+func (b *Breaker) load() (map[string]*Circuit, error) {
+	circuits := make(map[string]*Circuit)
+	content, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return circuits, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return circuits, nil
+	}
+	if err := json.Unmarshal(content, &circuits); err != nil {
+		return nil, fmt.Errorf("failover state: %w", err)
+	}
+	return circuits, nil
+}
+
+// update applies fn to the circuits under the file lock and saves them
+func (b *Breaker) update(fn func(map[string]*Circuit)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(b.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("%w: %w", ErrStateLocked, err)
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	circuits, err := b.load()
+	if err != nil {
+		// A corrupt state file only loses failure counts; start over
+		circuits = make(map[string]*Circuit)
+	}
+	fn(circuits)
+
+	content, err := json.MarshalIndent(circuits, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, b.path)
+}
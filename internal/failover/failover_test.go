@@ -0,0 +1,112 @@
+package failover
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+)
+
+var errOverloaded = errors.New(`claude error: API Error: 529 {"type":"overloaded_error"}`)
+
+func newBreaker(t *testing.T) (*Breaker, *time.Time) {
+	t.Helper()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := New(filepath.Join(t.TempDir(), "failover.json"), config.FailoverConfig{
+		Secondary:        "sonnet",
+		FailureThreshold: 2,
+		Cooldown:         "1m",
+		MaxCooldown:      "3m",
+	})
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker_OpensAndFailsOver(t *testing.T) {
+	b, now := newBreaker(t)
+
+	b.Failure("opus", errOverloaded)
+	if got := b.Route("opus"); got != "opus" {
+		t.Fatalf("one failure should not open the circuit, routed to %s", got)
+	}
+	b.Failure("opus", errOverloaded)
+	if got := b.Route("opus"); got != "sonnet" {
+		t.Fatalf("expected failover to sonnet, routed to %s", got)
+	}
+	if got := b.Route("haiku"); got != "haiku" {
+		t.Errorf("other models should be unaffected, routed to %s", got)
+	}
+
+	// After the cooldown one call probes the primary; a failed probe
+	// reopens the circuit for twice as long
+	*now = now.Add(time.Minute)
+	if got := b.Route("opus"); got != "opus" {
+		t.Fatalf("expected a probe of opus after the cooldown, routed to %s", got)
+	}
+	b.Failure("opus", errOverloaded)
+	circuits, _ := b.Circuits()
+	if len(circuits) != 1 || circuits[0].Trips != 2 || !circuits[0].OpenUntil.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("expected the cooldown doubled, got %+v", circuits[0])
+	}
+
+	// The cooldown is capped
+	*now = now.Add(2 * time.Minute)
+	b.Failure("opus", errOverloaded)
+	circuits, _ = b.Circuits()
+	if !circuits[0].OpenUntil.Equal(now.Add(3 * time.Minute)) {
+		t.Errorf("expected the cooldown capped at 3m, open until %v", circuits[0].OpenUntil)
+	}
+
+	// A successful probe closes the circuit
+	*now = now.Add(3 * time.Minute)
+	b.Success("opus")
+	if circuits, _ := b.Circuits(); len(circuits) != 0 {
+		t.Errorf("expected the circuit closed, got %+v", circuits)
+	}
+	if got := b.Route("opus"); got != "opus" {
+		t.Errorf("expected opus again, routed to %s", got)
+	}
+}
+
+func TestBreaker_IgnoresOtherErrors(t *testing.T) {
+	b, _ := newBreaker(t)
+	for i := 0; i < 5; i++ {
+		b.Failure("opus", errors.New("claude command failed: exit status 1 (stderr: bad flag)"))
+	}
+	if circuits, _ := b.Circuits(); len(circuits) != 0 {
+		t.Errorf("expected errors that are not the provider's ignored, got %+v", circuits)
+	}
+}
+
+func TestBreaker_SharedThroughFile(t *testing.T) {
+	b, _ := newBreaker(t)
+	b.Failure("", errOverloaded)
+	b.Failure("", errOverloaded)
+
+	// A second process reading the same file sees the open circuit
+	other := New(b.path, config.FailoverConfig{Secondary: "haiku"})
+	other.now = b.now
+	if got := other.Route(""); got != "haiku" {
+		t.Errorf("expected the default model's circuit shared, routed to %q", got)
+	}
+}
+
+func TestIsProviderError(t *testing.T) {
+	for _, msg := range []string{
+		"claude error: API Error: 429 rate_limit_error",
+		"claude error: Claude AI usage limit reached|1760000000",
+		"claude error: API Error: 500 Internal server error",
+		"claude error: Overloaded",
+	} {
+		if !IsProviderError(errors.New(msg)) {
+			t.Errorf("IsProviderError(%q) = false", msg)
+		}
+	}
+	for _, msg := range []string{"no response from claude (stderr: )", "took 1500ms"} {
+		if IsProviderError(errors.New(msg)) {
+			t.Errorf("IsProviderError(%q) = true", msg)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+// Package failures is the queue of assignments that failed. When a soldati's
+// call errors or an associate fails, its bead waits here and is handed out
+// again after a backoff that doubles with each failure. Once a bead has
+// failed ReassignAfter times its retries go to agents that have not failed
+// it, and after MaxAttempts it stays put as dead until someone runs
+// `mob failures retry`. State lives in a file so the daemon, MCP servers and
+// CLI share it.
+package failures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+var (
+	// ErrNotFound is returned when a bead has no failures on record
+	ErrNotFound = errkind.New(errkind.NotFound, "no failures on record for bead")
+	// ErrClosed is returned when retrying a bead that was closed meanwhile
+	ErrClosed = errkind.New(errkind.Conflict, "bead is already closed")
+	// ErrStateLocked is returned when the queue file lock could not be acquired
+	ErrStateLocked = errkind.New(errkind.Transient, "failure queue is locked")
+)
+
+// Status is where a failed bead is in the queue
+type Status string
+
+const (
+	Waiting  Status = "waiting"  // blocked until its retry is due
+	Requeued Status = "requeued" // reopened for another attempt
+	Dead     Status = "dead"     // out of attempts; waits for `mob failures retry`
+)
+
+// Entry is one bead's failure record
+type Entry struct {
+	BeadID    string    `json:"bead_id"`
+	Turf      string    `json:"turf,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Status    Status    `json:"status"`
+	Attempts  int       `json:"attempts"`           // failed attempts so far
+	Agents    []string  `json:"agents"`             // agents that failed it, first failure first
+	LastError string    `json:"last_error"`         // the most recent failure
+	FailedAt  time.Time `json:"failed_at"`          // when it last failed
+	RetryAt   time.Time `json:"retry_at,omitempty"` // when a waiting bead is reopened
+}
+
+// Failure is a failed attempt at a bead
+type Failure struct {
+	BeadID string
+	Turf   string
+	Title  string
+	Agent  string
+	Err    error
+}
+
+// Policy decides when failed beads are retried and by whom
+type Policy struct {
+	MaxAttempts   int
+	Backoff       time.Duration
+	MaxBackoff    time.Duration
+	ReassignAfter int
+}
+
+// PolicyFromConfig returns the policy set in [failures]
+func PolicyFromConfig(cfg config.FailuresConfig) Policy {
+	return Policy{
+		MaxAttempts:   cfg.GetMaxAttempts(),
+		Backoff:       cfg.GetBackoff(),
+		MaxBackoff:    cfg.GetMaxBackoff(),
+		ReassignAfter: cfg.GetReassignAfter(),
+	}
+}
+
+// backoff is the wait after the attempts-th failure
+func (p Policy) backoff(attempts int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempts && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// Queue holds the beads whose assignments failed
+type Queue struct {
+	path   string
+	policy Policy
+	mu     sync.Mutex
+}
+
+// Path returns the failure queue file for a mob directory
+func Path(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "failures.json")
+}
+
+// New returns a queue keeping its state at path
+func New(path string, policy Policy) *Queue {
+	return &Queue{path: path, policy: policy}
+}
+
+// Open returns the queue for a mob directory with the policy in cfg
+func Open(mobDir string, cfg config.FailuresConfig) *Queue {
+	return New(Path(mobDir), PolicyFromConfig(cfg))
+}
+
+// SetPolicy replaces the retry policy, for config reloads
+func (q *Queue) SetPolicy(policy Policy) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.policy = policy
+}
+
+// Record adds a failed attempt, scheduling the bead's retry or, once it is
+// out of attempts, marking it dead
+func (q *Queue) Record(f Failure, now time.Time) (*Entry, error) {
+	var entry Entry
+	err := q.update(func(entries map[string]*Entry) {
+		e := entries[f.BeadID]
+		if e == nil {
+			e = &Entry{BeadID: f.BeadID}
+			entries[f.BeadID] = e
+		}
+		if f.Turf != "" {
+			e.Turf = f.Turf
+		}
+		if f.Title != "" {
+			e.Title = f.Title
+		}
+		e.Attempts++
+		if f.Agent != "" && !slices.Contains(e.Agents, f.Agent) {
+			e.Agents = append(e.Agents, f.Agent)
+		}
+		if f.Err != nil {
+			e.LastError = firstLine(f.Err.Error())
+		}
+		e.FailedAt = now
+		if e.Attempts >= q.policy.MaxAttempts {
+			e.Status, e.RetryAt = Dead, time.Time{}
+		} else {
+			e.Status, e.RetryAt = Waiting, now.Add(q.policy.backoff(e.Attempts))
+		}
+		entry = *e
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Fail records a failed attempt at a bead and blocks the bead until its
+// retry, noting on it what failed and what happens next. The bead's turf and
+// title are filled in from the store.
+func (q *Queue) Fail(store *storage.BeadStore, f Failure, now time.Time) (*Entry, error) {
+	bead, err := store.Get(f.BeadID)
+	if err != nil {
+		return nil, err
+	}
+	f.Turf, f.Title = bead.Turf, bead.Title
+	entry, err := q.Record(f, now)
+	if err != nil {
+		return nil, err
+	}
+
+	bead.Status = models.BeadStatusBlocked
+	bead.Assignee = ""
+	bead.CloseReason = fmt.Sprintf("attempt %d by %s failed: %s", entry.Attempts, f.Agent, entry.LastError)
+	if _, err := store.Update(bead); err != nil {
+		return entry, fmt.Errorf("failed to block bead: %w", err)
+	}
+
+	note := fmt.Sprintf("Attempt %d by %s failed: %s. Retrying after %s.",
+		entry.Attempts, f.Agent, entry.LastError, entry.RetryAt.Format(time.RFC3339))
+	if entry.Status == Dead {
+		note = fmt.Sprintf("Attempt %d by %s failed: %s. Out of attempts; run `mob failures retry %s` to try again.",
+			entry.Attempts, f.Agent, entry.LastError, f.BeadID)
+	}
+	if err := store.AddComment(f.BeadID, "failure-queue", note); err != nil {
+		return entry, fmt.Errorf("failed to comment on bead: %w", err)
+	}
+	return entry, nil
+}
+
+// Due returns the waiting beads whose retry is due at now
+func (q *Queue) Due(now time.Time) ([]*Entry, error) {
+	entries, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(entries, func(e *Entry) bool {
+		return e.Status != Waiting || now.Before(e.RetryAt)
+	}), nil
+}
+
+// Requeue marks a bead as reopened for another attempt. A dead bead gets
+// one more attempt before it is dead again.
+func (q *Queue) Requeue(beadID string) error {
+	found := false
+	err := q.update(func(entries map[string]*Entry) {
+		if e := entries[beadID]; e != nil {
+			found = true
+			e.Status, e.RetryAt = Requeued, time.Time{}
+			if e.Attempts >= q.policy.MaxAttempts {
+				e.Attempts = q.policy.MaxAttempts - 1
+			}
+		}
+	})
+	if err == nil && !found {
+		return fmt.Errorf("%w: %s", ErrNotFound, beadID)
+	}
+	return err
+}
+
+// Retry reopens a failed bead for another attempt. A bead closed since it
+// failed is dropped from the queue instead, with ErrClosed.
+func (q *Queue) Retry(store *storage.BeadStore, beadID string) error {
+	bead, err := store.Get(beadID)
+	if err != nil {
+		return err
+	}
+	if bead.Status == models.BeadStatusClosed {
+		q.Resolve(beadID)
+		return fmt.Errorf("%w: %s", ErrClosed, beadID)
+	}
+	if err := q.Requeue(beadID); err != nil {
+		return err
+	}
+	if bead.Status != models.BeadStatusBlocked {
+		return nil // someone already moved it along
+	}
+	bead.Status = models.BeadStatusOpen
+	bead.Assignee = ""
+	bead.CloseReason = ""
+	if _, err := store.Update(bead); err != nil {
+		return fmt.Errorf("failed to reopen bead: %w", err)
+	}
+	return nil
+}
+
+// Resolve drops a bead from the queue once an attempt at it succeeds
+func (q *Queue) Resolve(beadID string) error {
+	if entries, err := q.load(); err == nil && entries[beadID] == nil {
+		return nil // nothing on record; skip the write
+	}
+	return q.update(func(entries map[string]*Entry) {
+		delete(entries, beadID)
+	})
+}
+
+// Get returns a bead's failure record
+func (q *Queue) Get(beadID string) (*Entry, error) {
+	entries, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	e := entries[beadID]
+	if e == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, beadID)
+	}
+	return e, nil
+}
+
+// List returns every bead in the queue, most recent failure first
+func (q *Queue) List() ([]*Entry, error) {
+	entries, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Entry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].FailedAt.Equal(out[j].FailedAt) {
+			return out[i].FailedAt.After(out[j].FailedAt)
+		}
+		return out[i].BeadID < out[j].BeadID
+	})
+	return out, nil
+}
+
+// Avoid returns, keyed by bead ID, the agents that should not be handed each
+// requeued bead again: those that failed it, once it has failed
+// ReassignAfter times
+func (q *Queue) Avoid() (map[string][]string, error) {
+	q.mu.Lock()
+	reassignAfter := q.policy.ReassignAfter
+	q.mu.Unlock()
+
+	entries, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	avoid := make(map[string][]string)
+	for id, e := range entries {
+		if e.Status == Requeued && e.Attempts >= reassignAfter {
+			avoid[id] = e.Agents
+		}
+	}
+	return avoid, nil
+}
+
+// firstLine trims an error to its first line for display
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if len(s) > 200 {
+		s = s[:200]
+	}
+	return s
+}
+
+// load reads the queue without holding the file lock; writes replace the
+// file atomically, so a reader sees either the old or the new state
+func (q *Queue) load() (map[string]*Entry, error) {
+	entries := make(map[string]*Entry)
+	content, err := os.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return entries, nil
+	}
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failure queue: %w", err)
+	}
+	return entries, nil
+}
+
+// update applies fn to the queue under the file lock and saves it
+func (q *Queue) update(fn func(map[string]*Entry)) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(q.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(q.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("%w: %w", ErrStateLocked, err)
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	entries, err := q.load()
+	if err != nil {
+		return err
+	}
+	fn(entries)
+
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, q.path)
+}
@@ -0,0 +1,132 @@
+package failures
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+var testPolicy = Policy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 90 * time.Second, ReassignAfter: 2}
+
+func TestRecordBacksOffAndGoesDead(t *testing.T) {
+	q := New(filepath.Join(t.TempDir(), "failures.json"), testPolicy)
+	now := time.Now()
+	boom := errors.New("boom\nstack trace")
+
+	e, err := q.Record(Failure{BeadID: "bd-1", Agent: "vinnie", Err: boom}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Status != Waiting || e.Attempts != 1 || !e.RetryAt.Equal(now.Add(time.Minute)) || e.LastError != "boom" {
+		t.Fatalf("first failure = %+v", e)
+	}
+
+	e, _ = q.Record(Failure{BeadID: "bd-1", Agent: "sal", Err: boom}, now)
+	if !e.RetryAt.Equal(now.Add(90*time.Second)) {
+		t.Errorf("expected the doubled backoff capped at 90s, got retry at %v", e.RetryAt.Sub(now))
+	}
+	if len(e.Agents) != 2 {
+		t.Errorf("expected both agents recorded, got %v", e.Agents)
+	}
+
+	if due, _ := q.Due(now); len(due) != 0 {
+		t.Errorf("expected nothing due before the backoff, got %v", due)
+	}
+	if due, _ := q.Due(now.Add(2 * time.Minute)); len(due) != 1 {
+		t.Errorf("expected bd-1 due, got %v", due)
+	}
+
+	e, _ = q.Record(Failure{BeadID: "bd-1", Agent: "sal", Err: boom}, now)
+	if e.Status != Dead || !e.RetryAt.IsZero() || len(e.Agents) != 2 {
+		t.Fatalf("expected dead after 3 attempts, got %+v", e)
+	}
+	if due, _ := q.Due(now.Add(time.Hour)); len(due) != 0 {
+		t.Errorf("dead beads are never due, got %v", due)
+	}
+}
+
+func TestAvoidAfterReassignThreshold(t *testing.T) {
+	q := New(filepath.Join(t.TempDir(), "failures.json"), testPolicy)
+	now := time.Now()
+
+	q.Record(Failure{BeadID: "bd-1", Agent: "vinnie"}, now)
+	q.Requeue("bd-1")
+	if avoid, _ := q.Avoid(); len(avoid) != 0 {
+		t.Errorf("expected the same agent allowed after one failure, got %v", avoid)
+	}
+
+	q.Record(Failure{BeadID: "bd-1", Agent: "vinnie"}, now)
+	q.Requeue("bd-1")
+	avoid, err := q.Avoid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := avoid["bd-1"]; len(got) != 1 || got[0] != "vinnie" {
+		t.Errorf("expected vinnie avoided, got %v", avoid)
+	}
+
+	if err := q.Requeue("bd-2"); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("expected NotFound requeueing an unknown bead, got %v", err)
+	}
+}
+
+func TestFailAndRetry(t *testing.T) {
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Flaky", Status: models.BeadStatusInProgress, Turf: "api", Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := New(filepath.Join(t.TempDir(), "failures.json"), Policy{MaxAttempts: 1, Backoff: time.Minute, MaxBackoff: time.Minute, ReassignAfter: 1})
+
+	e, err := q.Fail(store, Failure{BeadID: bead.ID, Agent: "vinnie", Err: errors.New("boom")}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Status != Dead || e.Turf != "api" || e.Title != "Flaky" {
+		t.Errorf("entry = %+v", e)
+	}
+	got, _ := store.Get(bead.ID)
+	if got.Status != models.BeadStatusBlocked || got.Assignee != "" {
+		t.Errorf("expected the bead blocked and unassigned, got %+v", got)
+	}
+	noted := false
+	for _, ev := range got.History {
+		noted = noted || (ev.Type == models.BeadEventTypeComment && strings.Contains(ev.Comment, "mob failures retry"))
+	}
+	if !noted {
+		t.Error("expected a comment saying how to retry the bead")
+	}
+
+	// A dead bead retried by hand gets one more attempt
+	if err := q.Retry(store, bead.ID); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = store.Get(bead.ID)
+	if got.Status != models.BeadStatusOpen || got.CloseReason != "" {
+		t.Errorf("expected the bead reopened, got %+v", got)
+	}
+	if e, _ := q.Get(bead.ID); e.Status != Requeued {
+		t.Errorf("expected requeued, got %s", e.Status)
+	}
+	if e, _ := q.Record(Failure{BeadID: bead.ID, Agent: "sal"}, time.Now()); e.Status != Dead {
+		t.Errorf("expected dead again after the extra attempt, got %s", e.Status)
+	}
+
+	got.Status = models.BeadStatusClosed
+	store.Update(got)
+	if err := q.Retry(store, bead.ID); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+	if _, err := q.Get(bead.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a closed bead dropped from the queue, got %v", err)
+	}
+}
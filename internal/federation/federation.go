@@ -0,0 +1,115 @@
+// Package federation shares selected beads between separate mob instances.
+// Peers are other mob directories reachable from this machine; pushing and
+// pulling copy every federated bead across, matching copies by origin so each
+// instance keeps its own conflict-free IDs.
+package federation
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// Direction is which way beads are copied
+type Direction string
+
+const (
+	DirectionPush Direction = "push" // local beads to peers
+	DirectionPull Direction = "pull" // peer beads to here
+	DirectionSync Direction = "sync" // both, pushing first
+)
+
+// Result is what one push, pull or sync with a peer changed
+type Result struct {
+	Peer   string
+	Pushed storage.ReceiveResult // changes made on the peer
+	Pulled storage.ReceiveResult // changes made here
+}
+
+// String summarizes the result on one line
+func (r *Result) String() string {
+	return fmt.Sprintf("%s: pushed %d new, %d updated; pulled %d new, %d updated",
+		r.Peer, r.Pushed.Created, r.Pushed.Updated, r.Pulled.Created, r.Pulled.Updated)
+}
+
+// OpenPeer opens a peer's bead store. The peer's path must be an initialized
+// mob directory.
+func OpenPeer(peer config.FederationPeer) (*storage.BeadStore, error) {
+	if peer.Path == "" {
+		return nil, errkind.New(errkind.Invalid, fmt.Sprintf("peer %q has no path", peer.Name))
+	}
+	stateDir := filepath.Join(peer.Path, ".mob")
+	if info, err := os.Stat(stateDir); err != nil || !info.IsDir() {
+		return nil, errkind.New(errkind.NotFound, fmt.Sprintf("peer %q: %s is not a mob directory", peer.Name, peer.Path))
+	}
+	return storage.NewBeadStore(storage.BeadsDir(peer.Path))
+}
+
+// Push copies this instance's federated beads to a peer
+func Push(local, remote *storage.BeadStore, self string, res *Result) error {
+	beads, err := local.Federated()
+	if err != nil {
+		return err
+	}
+	got, err := remote.Receive(beads, storage.ReceiveOptions{From: self})
+	if err != nil {
+		return fmt.Errorf("push to %s: %w", res.Peer, err)
+	}
+	res.Pushed = *got
+	return nil
+}
+
+// Pull copies a peer's federated beads here, into the peer's configured turf
+func Pull(local, remote *storage.BeadStore, peer config.FederationPeer, res *Result) error {
+	beads, err := remote.Federated()
+	if err != nil {
+		return fmt.Errorf("pull from %s: %w", peer.Name, err)
+	}
+	got, err := local.Receive(beads, storage.ReceiveOptions{From: peer.Name, Turf: peer.Turf})
+	if err != nil {
+		return err
+	}
+	res.Pulled = *got
+	return nil
+}
+
+// Sync exchanges beads with every peer, or only the named one, in the given
+// direction. A peer that fails is reported without stopping the others.
+func Sync(local *storage.BeadStore, cfg *config.FederationConfig, only string, dir Direction) ([]*Result, error) {
+	peers := cfg.Peers
+	if only != "" {
+		peer, ok := cfg.Peer(only)
+		if !ok {
+			return nil, errkind.New(errkind.NotFound, fmt.Sprintf("no federation peer named %q", only))
+		}
+		peers = []config.FederationPeer{peer}
+	}
+	if len(peers) == 0 {
+		return nil, errkind.New(errkind.Invalid, "no federation peers configured")
+	}
+
+	self := cfg.InstanceName()
+	var results []*Result
+	var errs []error
+	for _, peer := range peers {
+		res := &Result{Peer: peer.Name}
+		remote, err := OpenPeer(peer)
+		if err == nil && dir != DirectionPull {
+			err = Push(local, remote, self, res)
+		}
+		if err == nil && dir != DirectionPush {
+			err = Pull(local, remote, peer, res)
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, res)
+	}
+	return results, errors.Join(errs...)
+}
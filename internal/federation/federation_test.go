@@ -0,0 +1,144 @@
+package federation
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// newInstance creates a mob directory and opens its bead store
+func newInstance(t *testing.T) (string, *storage.BeadStore) {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, store
+}
+
+func TestSync(t *testing.T) {
+	laptopDir, laptop := newInstance(t)
+	teamDir, team := newInstance(t)
+	laptopCfg := &config.FederationConfig{Instance: "laptop", Peers: []config.FederationPeer{{Name: "team", Path: teamDir}}}
+	teamCfg := &config.FederationConfig{Instance: "team", Peers: []config.FederationPeer{{Name: "laptop", Path: laptopDir, Turf: "shared"}}}
+
+	shared, err := laptop.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusOpen, Turf: "api", Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := laptop.Create(&models.Bead{Title: "Private", Status: models.BeadStatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := laptop.Share([]string{shared.ID}, laptopCfg.InstanceName()); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := Sync(laptop, laptopCfg, "", DirectionPush)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Pushed.Created != 1 {
+		t.Fatalf("expected one bead pushed, got %+v", results)
+	}
+
+	beads, err := team.List(storage.BeadFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beads) != 1 {
+		t.Fatalf("expected only the shared bead on the peer, got %d", len(beads))
+	}
+	copied := beads[0]
+	if copied.Title != "Fix login" || copied.Assignee != "" || copied.Turf != "api" {
+		t.Errorf("unexpected copy: %+v", copied)
+	}
+	if copied.Origin == nil || *copied.Origin != (models.Origin{Instance: "laptop", ID: shared.ID}) {
+		t.Errorf("expected origin laptop/%s, got %+v", shared.ID, copied.Origin)
+	}
+
+	// The peer closes its copy; pulling brings the change back
+	copied.Status = models.BeadStatusClosed
+	copied.CloseReason = "done"
+	if _, err := team.Update(copied); err != nil {
+		t.Fatal(err)
+	}
+	results, err = Sync(laptop, laptopCfg, "team", DirectionSync)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Pushed.Updated != 0 || results[0].Pulled.Updated != 1 || results[0].Pulled.Created != 0 {
+		t.Fatalf("expected only the peer's change pulled, got %s", results[0])
+	}
+	got, err := laptop.Get(shared.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.BeadStatusClosed || got.CloseReason != "done" || got.Assignee != "vinnie" {
+		t.Errorf("expected the close pulled and the assignment kept, got %+v", got)
+	}
+
+	// Once both sides agree, syncing again changes nothing
+	for _, cfg := range []struct {
+		store *storage.BeadStore
+		cfg   *config.FederationConfig
+	}{{laptop, laptopCfg}, {team, teamCfg}} {
+		results, err := Sync(cfg.store, cfg.cfg, "", DirectionSync)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := results[0]
+		if r.Pushed != (storage.ReceiveResult{}) || r.Pulled != (storage.ReceiveResult{}) {
+			t.Errorf("expected a no-op sync from %s, got %s", cfg.cfg.Instance, r)
+		}
+	}
+}
+
+func TestSync_PeerTurfAndIDs(t *testing.T) {
+	_, local := newInstance(t)
+	peerDir, peer := newInstance(t)
+	cfg := &config.FederationConfig{Instance: "local", Peers: []config.FederationPeer{{Name: "peer", Path: peerDir, Turf: "shared"}}}
+
+	remote, err := peer.Create(&models.Bead{Title: "Remote", Status: models.BeadStatusOpen, Turf: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peer.Share([]string{remote.ID}, "peer"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Sync(local, cfg, "peer", DirectionPull); err != nil {
+		t.Fatal(err)
+	}
+	beads, err := local.Federated()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(beads) != 1 || beads[0].Turf != "shared" || beads[0].Branch != "mob/"+beads[0].ID {
+		t.Fatalf("expected the bead in the peer's turf with a local branch, got %+v", beads)
+	}
+	if beads[0].Origin.ID != remote.ID {
+		t.Errorf("expected the peer's ID kept as origin, got %+v", beads[0].Origin)
+	}
+}
+
+func TestSync_Errors(t *testing.T) {
+	_, local := newInstance(t)
+
+	if _, err := Sync(local, &config.FederationConfig{}, "", DirectionSync); !errors.Is(err, errkind.Invalid) {
+		t.Errorf("expected invalid without peers, got %v", err)
+	}
+
+	cfg := &config.FederationConfig{Peers: []config.FederationPeer{{Name: "gone", Path: t.TempDir()}}}
+	if _, err := Sync(local, cfg, "other", DirectionSync); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("expected an unknown peer to be not found, got %v", err)
+	}
+	if _, err := Sync(local, cfg, "", DirectionSync); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("expected a directory without .mob to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,328 @@
+// Package gc removes stale mob artifacts: finished agent records, Claude
+// transcripts of old sessions, leftover MCP config files and scratch files.
+package gc
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+// Kind identifies a type of collectable artifact
+type Kind string
+
+const (
+	KindSessions    Kind = "sessions"
+	KindTranscripts Kind = "transcripts"
+	KindMCPConfigs  Kind = "mcp-configs"
+	KindTmp         Kind = "tmp"
+)
+
+// AllKinds lists every artifact kind in reporting order
+var AllKinds = []Kind{KindSessions, KindTranscripts, KindMCPConfigs, KindTmp}
+
+// liveMCPConfig is the MCP config file agents are currently started with
+const liveMCPConfig = "mcp-config.json"
+
+// Policy holds the retention for each artifact kind. Zero keeps that kind forever.
+type Policy map[Kind]time.Duration
+
+// PolicyFromConfig parses the [gc] retentions from config
+func PolicyFromConfig(cfg config.GCConfig) (Policy, error) {
+	policy := Policy{}
+	for kind, value := range map[Kind]string{
+		KindSessions:    cfg.Sessions,
+		KindTranscripts: cfg.Transcripts,
+		KindMCPConfigs:  cfg.MCPConfigs,
+		KindTmp:         cfg.Tmp,
+	} {
+		d, err := config.ParseRetention(value)
+		if err != nil {
+			return nil, fmt.Errorf("gc.%s: %w", kind, err)
+		}
+		policy[kind] = d
+	}
+	return policy, nil
+}
+
+// Item is one artifact that is (or would be, in a dry run) removed
+type Item struct {
+	Kind      Kind
+	Path      string // file or directory; empty for registry records
+	AgentID   string // registry record for sessions
+	SessionID string // Claude session a transcript belongs to
+	Size      int64
+	ModTime   time.Time
+}
+
+// Report summarizes a collection run
+type Report struct {
+	DryRun bool
+	Items  []Item
+	Errors []error
+}
+
+// Bytes returns the space reclaimed (or reclaimable) by kind
+func (r *Report) Bytes(kind Kind) int64 {
+	var total int64
+	for _, item := range r.Items {
+		if item.Kind == kind {
+			total += item.Size
+		}
+	}
+	return total
+}
+
+// Count returns the number of artifacts of a kind in the report
+func (r *Report) Count(kind Kind) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// TotalBytes returns the space reclaimed across every kind
+func (r *Report) TotalBytes() int64 {
+	var total int64
+	for _, item := range r.Items {
+		total += item.Size
+	}
+	return total
+}
+
+// Collector finds and removes stale artifacts
+type Collector struct {
+	MobDir      string
+	Registry    *registry.Registry
+	ProjectsDir string // Claude's transcript directory (~/.claude/projects)
+	Now         func() time.Time
+}
+
+// NewCollector creates a collector for a mob directory and its agent registry
+func NewCollector(mobDir string, reg *registry.Registry) *Collector {
+	projects, _ := transcript.ProjectsDir()
+	return &Collector{
+		MobDir:      mobDir,
+		Registry:    reg,
+		ProjectsDir: projects,
+	}
+}
+
+// Run collects every kind with a non-zero retention in policy. With dryRun
+// nothing is removed and the report lists what would be.
+func (c *Collector) Run(policy Policy, dryRun bool) *Report {
+	report := &Report{DryRun: dryRun}
+	now := time.Now()
+	if c.Now != nil {
+		now = c.Now()
+	}
+
+	for _, kind := range AllKinds {
+		retention := policy[kind]
+		if retention <= 0 {
+			continue
+		}
+		cutoff := now.Add(-retention)
+
+		var items []Item
+		var err error
+		switch kind {
+		case KindSessions:
+			items, err = c.staleSessions(cutoff)
+		case KindTranscripts:
+			items, err = c.staleTranscripts(cutoff)
+		case KindMCPConfigs:
+			items, err = c.staleMCPConfigs(cutoff)
+		case KindTmp:
+			items, err = staleEntries(filepath.Join(c.MobDir, ".mob", "tmp"), KindTmp, cutoff, nil)
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("%s: %w", kind, err))
+		}
+
+		for _, item := range items {
+			if !dryRun {
+				if err := c.remove(item); err != nil {
+					report.Errors = append(report.Errors, err)
+					continue
+				}
+			}
+			report.Items = append(report.Items, item)
+		}
+	}
+
+	return report
+}
+
+// remove deletes one artifact
+func (c *Collector) remove(item Item) error {
+	if item.AgentID != "" {
+		if err := c.Registry.Unregister(item.AgentID); err != nil {
+			return fmt.Errorf("failed to remove agent record %s: %w", item.AgentID, err)
+		}
+		return nil
+	}
+	if err := os.RemoveAll(item.Path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", item.Path, err)
+	}
+	if item.SessionID != "" {
+		if err := c.Registry.ForgetSessions(item.SessionID); err != nil {
+			return fmt.Errorf("failed to forget session %s: %w", item.SessionID, err)
+		}
+	}
+	return nil
+}
+
+// staleSessions returns the records of agents that finished before cutoff
+// and have not been heard from since
+func (c *Collector) staleSessions(cutoff time.Time) ([]Item, error) {
+	if c.Registry == nil {
+		return nil, nil
+	}
+	agents, err := c.Registry.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, a := range agents {
+		if !a.Status.Terminal() || a.CompletedAt == nil || !a.CompletedAt.Before(cutoff) || !a.LastPing.Before(cutoff) {
+			continue
+		}
+		items = append(items, Item{Kind: KindSessions, AgentID: a.ID, ModTime: *a.CompletedAt})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ModTime.Before(items[j].ModTime) })
+	return items, nil
+}
+
+// staleTranscripts returns transcripts last written before cutoff of the
+// sessions mob's agents ran, skipping sessions a registered agent still
+// holds. Only session IDs the registry recorded are touched: the user's own
+// Claude sessions in the same directories are never removed.
+func (c *Collector) staleTranscripts(cutoff time.Time) ([]Item, error) {
+	if c.ProjectsDir == "" || c.Registry == nil {
+		return nil, nil
+	}
+	sessions, err := c.Registry.Sessions()
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	agents, err := c.Registry.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range agents {
+		if a.CompletedAt == nil {
+			delete(sessions, a.SessionID)
+		}
+	}
+
+	entries, err := os.ReadDir(c.ProjectsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		found, err := staleEntries(filepath.Join(c.ProjectsDir, e.Name()), KindTranscripts, cutoff, func(name string) bool {
+			id, ok := strings.CutSuffix(name, ".jsonl")
+			_, ours := sessions[id]
+			return ok && ours
+		})
+		if err != nil {
+			return items, err
+		}
+		for _, item := range found {
+			item.SessionID = strings.TrimSuffix(filepath.Base(item.Path), ".jsonl")
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// staleMCPConfigs returns MCP config files other than the live one
+func (c *Collector) staleMCPConfigs(cutoff time.Time) ([]Item, error) {
+	return staleEntries(filepath.Join(c.MobDir, ".mob"), KindMCPConfigs, cutoff, func(name string) bool {
+		return name != liveMCPConfig && strings.HasPrefix(name, "mcp-config") && strings.HasSuffix(name, ".json")
+	})
+}
+
+// staleEntries returns the entries of dir last modified before cutoff that
+// satisfy match (all entries when match is nil). A missing dir yields nothing.
+func staleEntries(dir string, kind Kind, cutoff time.Time, match func(name string) bool) ([]Item, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, e := range entries {
+		if match != nil && !match(e.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		size, modTime := usage(path)
+		if !modTime.Before(cutoff) {
+			continue
+		}
+		items = append(items, Item{Kind: kind, Path: path, Size: size, ModTime: modTime})
+	}
+	return items, nil
+}
+
+// usage returns the total size of a file or directory and its newest modification time
+func usage(root string) (int64, time.Time) {
+	var size int64
+	var newest time.Time
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, newest
+}
+
+// FormatBytes renders a byte count for reports
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
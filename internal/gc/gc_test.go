@@ -0,0 +1,179 @@
+package gc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+// writeAged creates a file with the given content and modification time
+func writeAged(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestCollectorRun(t *testing.T) {
+	root := t.TempDir()
+	mobDir := filepath.Join(root, "mob")
+	turfDir := filepath.Join(root, "repos", "api")
+	projects := filepath.Join(root, "claude-projects")
+
+	// The collector runs eight days from now, so the associate finishing
+	// below is past the seven-day session retention
+	now := time.Now()
+	clock := now.Add(8 * 24 * time.Hour)
+	old := now.Add(-10 * 24 * time.Hour)
+	recent := clock.Add(-time.Hour)
+
+	reg := registry.New(filepath.Join(mobDir, ".mob", "agents.json"))
+	reg.Register(&registry.AgentRecord{ID: "done", Type: "associate", Status: "active", SessionID: "old-session"})
+	reg.UpdateStatus("done", "completed")
+	reg.Register(&registry.AgentRecord{ID: "vinnie", Type: "soldati", Name: "vinnie", Status: "active", SessionID: "wt-session"})
+	reg.UpdateSession("vinnie", "live-session")
+	reg.Register(&registry.AgentRecord{ID: "just-done", Type: "associate", Status: "completed", CompletedAt: &recent})
+	reg.Register(&registry.AgentRecord{ID: "fresh", Type: "associate", Status: "active", SessionID: "new-session"})
+
+	turfProject := filepath.Join(projects, transcript.ProjectKey(turfDir))
+	worktreeProject := filepath.Join(projects, transcript.ProjectKey(filepath.Join(turfDir, ".mob-worktrees", "bd-1")))
+	otherProject := filepath.Join(projects, transcript.ProjectKey(filepath.Join(root, "elsewhere")))
+
+	writeAged(t, filepath.Join(turfProject, "old-session.jsonl"), "0123456789", old)
+	writeAged(t, filepath.Join(turfProject, "live-session.jsonl"), "keep", old)
+	writeAged(t, filepath.Join(turfProject, "new-session.jsonl"), "keep", recent)
+	writeAged(t, filepath.Join(worktreeProject, "wt-session.jsonl"), "01234", old)
+	writeAged(t, filepath.Join(otherProject, "not-ours.jsonl"), "keep", old)
+	// The user's own sessions in a turf are not mob's to remove
+	writeAged(t, filepath.Join(turfProject, "user-session.jsonl"), "keep", old)
+
+	writeAged(t, filepath.Join(mobDir, ".mob", "mcp-config.json"), "{}", old)
+	writeAged(t, filepath.Join(mobDir, ".mob", "mcp-config-abc123.json"), "{}", old)
+	writeAged(t, filepath.Join(mobDir, ".mob", "tmp", "scratch.txt"), "xyz", old)
+	writeAged(t, filepath.Join(mobDir, ".mob", "tmp", "fresh.txt"), "xyz", recent)
+
+	c := &Collector{
+		MobDir:      mobDir,
+		Registry:    reg,
+		ProjectsDir: projects,
+		Now:         func() time.Time { return clock },
+	}
+	policy := Policy{
+		KindSessions:    7 * 24 * time.Hour,
+		KindTranscripts: 7 * 24 * time.Hour,
+		KindMCPConfigs:  24 * time.Hour,
+		KindTmp:         24 * time.Hour,
+	}
+
+	// A dry run reports without removing anything
+	dry := c.Run(policy, true)
+	if len(dry.Errors) > 0 {
+		t.Fatalf("dry run errors: %v", dry.Errors)
+	}
+	if dry.Count(KindSessions) != 1 {
+		t.Errorf("sessions = %d, want 1", dry.Count(KindSessions))
+	}
+	if dry.Count(KindTranscripts) != 2 || dry.Bytes(KindTranscripts) != 15 {
+		t.Errorf("transcripts = %d (%d bytes), want 2 (15 bytes)", dry.Count(KindTranscripts), dry.Bytes(KindTranscripts))
+	}
+	if dry.Count(KindMCPConfigs) != 1 {
+		t.Errorf("mcp configs = %d, want 1", dry.Count(KindMCPConfigs))
+	}
+	if dry.Count(KindTmp) != 1 {
+		t.Errorf("tmp = %d, want 1", dry.Count(KindTmp))
+	}
+	if !exists(filepath.Join(turfProject, "old-session.jsonl")) {
+		t.Fatal("dry run removed a transcript")
+	}
+
+	report := c.Run(policy, false)
+	if len(report.Errors) > 0 {
+		t.Fatalf("run errors: %v", report.Errors)
+	}
+
+	for _, gone := range []string{
+		filepath.Join(turfProject, "old-session.jsonl"),
+		filepath.Join(worktreeProject, "wt-session.jsonl"),
+		filepath.Join(mobDir, ".mob", "mcp-config-abc123.json"),
+		filepath.Join(mobDir, ".mob", "tmp", "scratch.txt"),
+	} {
+		if exists(gone) {
+			t.Errorf("expected %s to be removed", gone)
+		}
+	}
+	for _, kept := range []string{
+		filepath.Join(turfProject, "live-session.jsonl"),
+		filepath.Join(turfProject, "new-session.jsonl"),
+		filepath.Join(mobDir, ".mob", "tmp", "fresh.txt"),
+		filepath.Join(otherProject, "not-ours.jsonl"),
+		filepath.Join(turfProject, "user-session.jsonl"),
+		filepath.Join(mobDir, ".mob", "mcp-config.json"),
+	} {
+		if !exists(kept) {
+			t.Errorf("expected %s to be kept", kept)
+		}
+	}
+
+	if _, err := reg.Get("done"); err == nil {
+		t.Error("expected finished agent record to be removed")
+	}
+	if _, err := reg.Get("vinnie"); err != nil {
+		t.Error("expected active agent record to be kept")
+	}
+	if _, err := reg.Get("just-done"); err != nil {
+		t.Error("expected a record that finished within the retention to be kept")
+	}
+	sessions, err := reg.Sessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sessions["old-session"]; ok {
+		t.Error("expected a removed transcript's session forgotten")
+	}
+	if _, ok := sessions["live-session"]; !ok {
+		t.Error("expected a live session kept in the ledger")
+	}
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	policy, err := PolicyFromConfig(config.GCConfig{Sessions: "7d", Transcripts: "", Tmp: "12h"})
+	if err != nil {
+		t.Fatalf("PolicyFromConfig failed: %v", err)
+	}
+	if policy[KindSessions] != 7*24*time.Hour || policy[KindTranscripts] != 0 || policy[KindTmp] != 12*time.Hour {
+		t.Errorf("unexpected policy: %v", policy)
+	}
+
+	if _, err := PolicyFromConfig(config.GCConfig{Sessions: "forever"}); err == nil {
+		t.Error("expected an error for an invalid retention")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for n, want := range tests {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HealthOptions controls which repository checks CheckHealth runs
+type HealthOptions struct {
+	MainBranch  string        // branch the checkout must be on; detected when empty
+	FetchMaxAge time.Duration // fetch origin when the last fetch is older than this; 0 skips the fetch check
+}
+
+// RepoHealth is the state of a turf's main checkout before work is assigned
+type RepoHealth struct {
+	Branch     string   // branch currently checked out
+	MainBranch string   // branch the checkout should be on
+	Dirty      []string // tracked files with uncommitted changes
+	Problems   []string // human-readable reasons the repo is not ready
+}
+
+// OK reports whether the repo passed every check
+func (h *RepoHealth) OK() bool {
+	return len(h.Problems) == 0
+}
+
+// String summarises the problems on one line
+func (h *RepoHealth) String() string {
+	if h.OK() {
+		return "healthy"
+	}
+	return strings.Join(h.Problems, "; ")
+}
+
+// CheckHealth verifies a repository's main checkout is safe to branch
+// worktrees from and merge into: no uncommitted changes to tracked files,
+// the main branch checked out, and origin (when there is one) fetched
+// recently. Untracked files are ignored since they don't affect worktrees
+// or merges.
+func CheckHealth(repoPath string, opts HealthOptions) (*RepoHealth, error) {
+	mgr, err := NewWorktreeManager(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &RepoHealth{MainBranch: opts.MainBranch}
+	if h.MainBranch == "" {
+		if h.MainBranch, err = mgr.GetMainBranch(); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := gitOutput(repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read current branch: %w", err)
+	}
+	h.Branch = out
+	if h.Branch != h.MainBranch {
+		h.Problems = append(h.Problems, fmt.Sprintf("checkout is on %s, not %s", h.Branch, h.MainBranch))
+	}
+
+	out, err = gitOutput(repoPath, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git status: %w", err)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) > 3 {
+			h.Dirty = append(h.Dirty, line[3:])
+		}
+	}
+	if len(h.Dirty) > 0 {
+		h.Problems = append(h.Problems, fmt.Sprintf("%d uncommitted change(s) (%s)", len(h.Dirty), summarizeFiles(h.Dirty, 3)))
+	}
+
+	if opts.FetchMaxAge > 0 {
+		if problem := ensureFetched(repoPath, h.MainBranch, opts.FetchMaxAge); problem != "" {
+			h.Problems = append(h.Problems, problem)
+		}
+	}
+
+	return h, nil
+}
+
+// ensureFetched fetches origin when the last fetch is older than maxAge and
+// returns a problem when origin can't be reached. Repos without an origin
+// remote pass.
+func ensureFetched(repoPath, mainBranch string, maxAge time.Duration) string {
+	if _, err := gitOutput(repoPath, "remote", "get-url", "origin"); err != nil {
+		return ""
+	}
+
+	gitDir, err := gitOutput(repoPath, "rev-parse", "--absolute-git-dir")
+	if err == nil {
+		if info, err := os.Stat(filepath.Join(gitDir, "FETCH_HEAD")); err == nil && time.Since(info.ModTime()) < maxAge {
+			return ""
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "fetch", "--quiet", "origin", mainBranch)
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Sprintf("could not fetch origin: %s", strings.TrimSpace(firstLine(string(out), err)))
+	}
+	return ""
+}
+
+func gitOutput(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	return strings.TrimRight(string(out), "\n"), err
+}
+
+func summarizeFiles(files []string, n int) string {
+	if len(files) <= n {
+		return strings.Join(files, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(files[:n], ", "), len(files)-n)
+}
+
+func firstLine(out string, err error) string {
+	if line, _, _ := strings.Cut(strings.TrimSpace(out), "\n"); line != "" {
+		return line
+	}
+	return err.Error()
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckHealth(t *testing.T) {
+	repo := setupTestRepo(t)
+	defer os.RemoveAll(repo)
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	// Untracked files don't count against a clean checkout
+	if err := os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("scratch"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	h, err := CheckHealth(repo, HealthOptions{})
+	if err != nil {
+		t.Fatalf("CheckHealth failed: %v", err)
+	}
+	if !h.OK() {
+		t.Fatalf("expected healthy repo, got %s", h)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("# Edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("checkout", "-q", "-b", "feature")
+	h, err = CheckHealth(repo, HealthOptions{MainBranch: h.MainBranch})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Problems) != 2 || !strings.Contains(h.String(), "not "+h.MainBranch) || !strings.Contains(h.String(), "README.md") {
+		t.Errorf("expected wrong-branch and dirty problems, got %q", h)
+	}
+
+	run("checkout", "-q", "-")
+	run("checkout", "-q", "--", "README.md")
+	run("remote", "add", "origin", filepath.Join(repo, "does-not-exist"))
+	h, err = CheckHealth(repo, HealthOptions{FetchMaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Problems) != 1 || !strings.Contains(h.Problems[0], "could not fetch origin") {
+		t.Errorf("expected fetch problem, got %q", h)
+	}
+}
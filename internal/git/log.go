@@ -0,0 +1,107 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecentCommitSubjects returns the subject lines of the last n commits on HEAD
+func RecentCommitSubjects(repoPath string, n int) ([]string, error) {
+	cmd := exec.Command("git", "log", fmt.Sprintf("-n%d", n), "--format=%s")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, ErrNotGitRepo
+	}
+
+	var subjects []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
+}
+
+// HeadCommit returns the full hash of HEAD
+func HeadCommit(repoPath string) (string, error) {
+	out, err := gitOutput(repoPath, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// mergeSubjectPattern matches the subjects git and jj give merge commits:
+// "Merge branch 'mob/bd-1a2b'" and "Merge mob/bd-1a2b into main"
+var mergeSubjectPattern = regexp.MustCompile(`^Merge (?:branch )?'?([^' ]+)'?`)
+
+// MergeCommits maps each branch merged into HEAD after since to the hash of
+// its merge commit. An empty since covers the whole history.
+func MergeCommits(repoPath, since string) (map[string]string, error) {
+	revs := "HEAD"
+	if since != "" {
+		revs = since + "..HEAD"
+	}
+	out, err := gitOutput(repoPath, "log", "--merges", "--format=%H%x09%s", revs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merges in %s: %w", repoPath, err)
+	}
+
+	merges := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		hash, subject, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		if m := mergeSubjectPattern.FindStringSubmatch(subject); m != nil {
+			if _, seen := merges[m[1]]; !seen {
+				merges[m[1]] = hash // newest first, so a re-merge keeps its latest commit
+			}
+		}
+	}
+	return merges, nil
+}
+
+// CommitTime returns when ref was committed
+func CommitTime(repoPath, ref string) (time.Time, error) {
+	out, err := gitOutput(repoPath, "log", "-1", "--format=%ct", ref, "--")
+	if err != nil || out == "" {
+		return time.Time{}, fmt.Errorf("unknown revision %q in %s", ref, repoPath)
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected commit time %q for %s", out, ref)
+	}
+	return time.Unix(secs, 0), nil
+}
+
+// DiffSummary returns git's one-line summary of how dir differs from where it
+// branched off base, uncommitted edits included, such as "2 files changed,
+// 10 insertions(+)". It is empty when nothing changed.
+func DiffSummary(dir, base string) (string, error) {
+	out, err := diffFromFork(dir, base, "--shortstat")
+	return strings.TrimSpace(out), err
+}
+
+// Diff returns the patch of how dir differs from where it branched off base,
+// uncommitted edits included. It is empty when nothing changed.
+func Diff(dir, base string) (string, error) {
+	return diffFromFork(dir, base)
+}
+
+func diffFromFork(dir, base string, args ...string) (string, error) {
+	fork, err := gitOutput(dir, "merge-base", base, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("no common history with %s in %s", base, dir)
+	}
+	out, err := gitOutput(dir, append(append([]string{"diff"}, args...), fork)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s: %w", dir, err)
+	}
+	return out, nil
+}
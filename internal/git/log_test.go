@@ -0,0 +1,98 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeCommits(t *testing.T) {
+	repo := setupTestRepo(t)
+	defer os.RemoveAll(repo)
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	mergeBranch := func(branch string) {
+		t.Helper()
+		main, err := gitOutput(repo, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		run("checkout", "-q", "-b", branch)
+		run("commit", "-q", "--allow-empty", "-m", "work on "+branch)
+		run("checkout", "-q", main)
+		run("merge", "-q", "--no-ff", "--no-edit", branch)
+	}
+
+	mergeBranch("mob/bd-0001")
+	run("tag", "v1.2.0")
+	mergeBranch("mob/bd-0002")
+
+	all, err := MergeCommits(repo, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all["mob/bd-0001"] == "" || all["mob/bd-0002"] == "" {
+		t.Fatalf("expected both merges, got %v", all)
+	}
+
+	since, err := MergeCommits(repo, "v1.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(since) != 1 || since["mob/bd-0002"] != all["mob/bd-0002"] {
+		t.Errorf("expected only the merge after the tag, got %v", since)
+	}
+
+	if at, err := CommitTime(repo, "v1.2.0"); err != nil || at.IsZero() {
+		t.Errorf("expected the tag's commit time, got %v (%v)", at, err)
+	}
+	if _, err := CommitTime(repo, "v9.9.9"); err == nil {
+		t.Error("expected an unknown revision to fail")
+	}
+}
+
+func TestDiffSummary(t *testing.T) {
+	repo := setupTestRepo(t)
+	defer os.RemoveAll(repo)
+
+	main, err := gitOutput(repo, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := DiffSummary(repo, main); err != nil || got != "" {
+		t.Fatalf("expected no changes, got %q (%v)", got, err)
+	}
+
+	if err := exec.Command("git", "-C", repo, "checkout", "-q", "-b", "mob/bd-0001").Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "new.txt"), []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := exec.Command("git", "-C", repo, "add", "new.txt").Run(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DiffSummary(repo, main)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "1 file changed, 2 insertions(+)" {
+		t.Errorf("expected the staged file counted, got %q", got)
+	}
+	if patch, err := Diff(repo, main); err != nil || !strings.Contains(patch, "+++ b/new.txt\n@@ -0,0 +1,2 @@\n+one") {
+		t.Errorf("expected the staged file's patch, got %q (%v)", patch, err)
+	}
+
+	if _, err := DiffSummary(repo, "no-such-branch"); err == nil {
+		t.Error("expected an unknown base to fail")
+	}
+}
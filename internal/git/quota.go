@@ -0,0 +1,148 @@
+package git
+
+import (
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WorktreeUsage is a worktree with its disk footprint and last activity
+type WorktreeUsage struct {
+	*Worktree
+	SizeBytes int64
+	LastUsed  time.Time // newest file modification in the worktree
+}
+
+// Quota bounds the worktrees kept for one repository
+type Quota struct {
+	MaxCount int   // 0 = unlimited
+	MaxBytes int64 // 0 = unlimited
+}
+
+// checkoutSparse populates a --no-checkout worktree with only the requested
+// directories. Shallow checkouts get top-level files only.
+func checkoutSparse(worktreePath string, opts CreateOptions) error {
+	args := []string{"sparse-checkout", "set", "--cone"}
+	if !opts.Shallow {
+		args = append(args, opts.Sparse...)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = worktreePath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to configure sparse checkout: %s: %w", string(output), err)
+	}
+
+	cmd = exec.Command("git", "checkout")
+	cmd.Dir = worktreePath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to check out worktree: %s: %w", string(output), err)
+	}
+	return nil
+}
+
+// Usage returns every mob worktree with its size and last activity
+func (m *WorktreeManager) Usage() ([]*WorktreeUsage, error) {
+	worktrees, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	return MeasureUsage(worktrees), nil
+}
+
+// MeasureUsage sizes each worktree, skipping any whose directory is gone
+func MeasureUsage(worktrees []*Worktree) []*WorktreeUsage {
+	usages := make([]*WorktreeUsage, 0, len(worktrees))
+	for _, wt := range worktrees {
+		size, lastUsed, err := dirUsage(wt.Path)
+		if err != nil {
+			continue // Worktree directory vanished; the VCS will prune it
+		}
+		usages = append(usages, &WorktreeUsage{Worktree: wt, SizeBytes: size, LastUsed: lastUsed})
+	}
+	return usages
+}
+
+// IsClean reports whether a worktree has no uncommitted or untracked changes
+func IsClean(worktreePath string) (bool, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = worktreePath
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to check worktree status: %w", err)
+	}
+	return strings.TrimSpace(string(output)) == "", nil
+}
+
+// PlanEviction picks worktrees to remove so that, after adding reserve new
+// ones, the quota holds. Only worktrees accepted by eligible are considered,
+// least recently used first. The quota may still be exceeded if too few
+// worktrees are eligible.
+func PlanEviction(usages []*WorktreeUsage, quota Quota, reserve int, eligible func(*WorktreeUsage) bool) []*WorktreeUsage {
+	count := len(usages) + reserve
+	var total int64
+	for _, u := range usages {
+		total += u.SizeBytes
+	}
+
+	over := func() bool {
+		return (quota.MaxCount > 0 && count > quota.MaxCount) || (quota.MaxBytes > 0 && total > quota.MaxBytes)
+	}
+	if !over() {
+		return nil
+	}
+
+	var candidates []*WorktreeUsage
+	for _, u := range usages {
+		if eligible(u) {
+			candidates = append(candidates, u)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastUsed.Before(candidates[j].LastUsed)
+	})
+
+	var evict []*WorktreeUsage
+	for _, u := range candidates {
+		if !over() {
+			break
+		}
+		evict = append(evict, u)
+		count--
+		total -= u.SizeBytes
+	}
+	return evict
+}
+
+// dirUsage sums file sizes under a directory and finds the newest modification
+func dirUsage(root string) (int64, time.Time, error) {
+	var size int64
+	var newest time.Time
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // Skip unreadable entries
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return size, newest, nil
+}
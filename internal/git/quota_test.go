@@ -0,0 +1,81 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCreateWithOptionsSparse(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	mgr, err := NewWorktreeManager(repoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := mgr.CreateWithOptions("bd-sparse", CreateOptions{Shallow: true})
+	if err != nil {
+		t.Fatalf("failed to create shallow worktree: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, "README.md")); err != nil {
+		t.Errorf("expected top-level files checked out: %v", err)
+	}
+
+	clean, err := IsClean(wt.Path)
+	if err != nil || !clean {
+		t.Errorf("expected fresh worktree to be clean (%v)", err)
+	}
+	if err := os.WriteFile(filepath.Join(wt.Path, "scratch.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if clean, _ := IsClean(wt.Path); clean {
+		t.Error("expected untracked file to make worktree dirty")
+	}
+
+	usages, err := mgr.Usage()
+	if err != nil {
+		t.Fatalf("failed to get usage: %v", err)
+	}
+	if len(usages) != 1 || usages[0].SizeBytes == 0 || usages[0].LastUsed.IsZero() {
+		t.Fatalf("unexpected usage: %+v", usages)
+	}
+}
+
+func TestPlanEviction(t *testing.T) {
+	now := time.Now()
+	usage := func(id string, size int64, age time.Duration) *WorktreeUsage {
+		return &WorktreeUsage{Worktree: &Worktree{BeadID: id}, SizeBytes: size, LastUsed: now.Add(-age)}
+	}
+	usages := []*WorktreeUsage{
+		usage("bd-new", 100, time.Minute),
+		usage("bd-old", 100, 3*time.Hour),
+		usage("bd-busy", 100, 5*time.Hour),
+		usage("bd-mid", 100, time.Hour),
+	}
+	idle := func(u *WorktreeUsage) bool { return u.BeadID != "bd-busy" }
+
+	evict := PlanEviction(usages, Quota{MaxCount: 3}, 1, idle)
+	if len(evict) != 2 || evict[0].BeadID != "bd-old" || evict[1].BeadID != "bd-mid" {
+		t.Fatalf("expected LRU idle worktrees evicted, got %v", ids(evict))
+	}
+
+	evict = PlanEviction(usages, Quota{MaxBytes: 350}, 0, idle)
+	if len(evict) != 1 || evict[0].BeadID != "bd-old" {
+		t.Fatalf("expected one eviction for size quota, got %v", ids(evict))
+	}
+
+	if evict := PlanEviction(usages, Quota{}, 5, idle); evict != nil {
+		t.Fatalf("expected no eviction without a quota, got %v", ids(evict))
+	}
+}
+
+func ids(usages []*WorktreeUsage) []string {
+	var out []string
+	for _, u := range usages {
+		out = append(out, u.BeadID)
+	}
+	return out
+}
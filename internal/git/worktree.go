@@ -1,13 +1,14 @@
 package git
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/gabe/mob/internal/errkind"
 )
 
 // BranchPrefix is the prefix for all mob-managed branches
@@ -18,11 +19,11 @@ const WorktreesDir = ".mob-worktrees"
 
 var (
 	// ErrNotGitRepo indicates the path is not a git repository
-	ErrNotGitRepo = errors.New("not a git repository")
+	ErrNotGitRepo = errkind.New(errkind.Invalid, "not a git repository")
 	// ErrWorktreeExists indicates a worktree already exists for the bead
-	ErrWorktreeExists = errors.New("worktree already exists")
+	ErrWorktreeExists = errkind.New(errkind.Conflict, "worktree already exists")
 	// ErrWorktreeNotFound indicates the worktree does not exist
-	ErrWorktreeNotFound = errors.New("worktree not found")
+	ErrWorktreeNotFound = errkind.New(errkind.NotFound, "worktree not found")
 )
 
 // WorktreeManager manages git worktrees for beads
@@ -57,8 +58,19 @@ func NewWorktreeManager(repoPath string) (*WorktreeManager, error) {
 	}, nil
 }
 
+// CreateOptions control how much of the repository a worktree checks out
+type CreateOptions struct {
+	Sparse  []string // directories to check out (cone mode); empty = everything
+	Shallow bool     // check out top-level files only
+}
+
 // Create creates a new worktree for a bead
 func (m *WorktreeManager) Create(beadID string) (*Worktree, error) {
+	return m.CreateWithOptions(beadID, CreateOptions{})
+}
+
+// CreateWithOptions creates a new worktree for a bead, optionally as a sparse checkout
+func (m *WorktreeManager) CreateWithOptions(beadID string, opts CreateOptions) (*Worktree, error) {
 	branch := BranchPrefix + beadID
 	worktreePath := filepath.Join(m.repoPath, WorktreesDir, beadID)
 
@@ -79,12 +91,25 @@ func (m *WorktreeManager) Create(beadID string) (*Worktree, error) {
 	}
 
 	// Create the worktree with a new branch
-	cmd := exec.Command("git", "worktree", "add", "-b", branch, worktreePath, mainBranch)
+	sparse := opts.Shallow || len(opts.Sparse) > 0
+	args := []string{"worktree", "add"}
+	if sparse {
+		args = append(args, "--no-checkout")
+	}
+	args = append(args, "-b", branch, worktreePath, mainBranch)
+	cmd := exec.Command("git", args...)
 	cmd.Dir = m.repoPath
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return nil, fmt.Errorf("failed to create worktree: %s: %w", string(output), err)
 	}
 
+	if sparse {
+		if err := checkoutSparse(worktreePath, opts); err != nil {
+			m.Remove(beadID, true)
+			return nil, err
+		}
+	}
+
 	return &Worktree{
 		Path:      worktreePath,
 		Branch:    branch,
@@ -215,6 +240,47 @@ func (m *WorktreeManager) Remove(beadID string, deleteBranch bool) error {
 	return nil
 }
 
+// ClearStaleLocks removes index locks and worktree locks older than olderThan
+// left in mob worktrees by git processes that were killed, and prunes records
+// of worktrees whose directories are gone. git holds its locks for moments,
+// so an old lock has no live owner. It returns the lock files removed.
+func (m *WorktreeManager) ClearStaleLocks(olderThan time.Duration) ([]string, error) {
+	cmd := exec.Command("git", "worktree", "prune")
+	cmd.Dir = m.repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to prune worktrees: %s: %w", string(output), err)
+	}
+
+	worktrees, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, wt := range worktrees {
+		cmd := exec.Command("git", "rev-parse", "--absolute-git-dir")
+		cmd.Dir = wt.Path
+		output, err := cmd.Output()
+		if err != nil {
+			continue // Not checked out far enough to have locks
+		}
+		gitDir := strings.TrimSpace(string(output))
+		for _, name := range []string{"index.lock", "locked"} {
+			lock := filepath.Join(gitDir, name)
+			info, err := os.Stat(lock)
+			if err != nil || time.Since(info.ModTime()) < olderThan {
+				continue
+			}
+			if err := os.Remove(lock); err == nil {
+				removed = append(removed, lock)
+			} else if !os.IsNotExist(err) {
+				return removed, fmt.Errorf("failed to remove %s: %w", lock, err)
+			}
+		}
+	}
+	return removed, nil
+}
+
 // GetMainBranch returns the main branch name (main or master)
 func (m *WorktreeManager) GetMainBranch() (string, error) {
 	// Try "main" first
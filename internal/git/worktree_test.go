@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 // setupTestRepo creates a temporary git repository for testing
@@ -331,6 +332,60 @@ func TestWorktreeManager_Remove(t *testing.T) {
 	})
 }
 
+func TestWorktreeManager_ClearStaleLocks(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	manager, err := NewWorktreeManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	stale, err := manager.Create("bd-stale")
+	if err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	fresh, err := manager.Create("bd-fresh")
+	if err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	lockIn := func(wt *Worktree) string {
+		cmd := exec.Command("git", "rev-parse", "--absolute-git-dir")
+		cmd.Dir = wt.Path
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("failed to find git dir: %v", err)
+		}
+		lock := filepath.Join(strings.TrimSpace(string(output)), "index.lock")
+		if err := os.WriteFile(lock, nil, 0644); err != nil {
+			t.Fatalf("failed to write lock: %v", err)
+		}
+		return lock
+	}
+
+	staleLock := lockIn(stale)
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(staleLock, old, old); err != nil {
+		t.Fatalf("failed to age lock: %v", err)
+	}
+	freshLock := lockIn(fresh)
+
+	removed, err := manager.ClearStaleLocks(time.Minute)
+	if err != nil {
+		t.Fatalf("failed to clear locks: %v", err)
+	}
+	if len(removed) != 1 || removed[0] != staleLock {
+		t.Errorf("expected only %s removed, got %v", staleLock, removed)
+	}
+	if _, err := os.Stat(staleLock); !os.IsNotExist(err) {
+		t.Error("expected stale lock to be removed")
+	}
+	if _, err := os.Stat(freshLock); err != nil {
+		t.Error("expected fresh lock to be kept")
+	}
+}
+
 func TestWorktreeManager_GetMainBranch(t *testing.T) {
 	tmpDir := setupTestRepo(t)
 	defer os.RemoveAll(tmpDir)
@@ -377,3 +432,20 @@ func TestValidateBranch(t *testing.T) {
 		})
 	}
 }
+
+func TestRecentCommitSubjects(t *testing.T) {
+	repoPath := setupTestRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	subjects, err := RecentCommitSubjects(repoPath, 5)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	if len(subjects) != 1 || subjects[0] != "Initial commit" {
+		t.Errorf("expected [Initial commit], got %v", subjects)
+	}
+
+	if _, err := RecentCommitSubjects(t.TempDir(), 5); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
@@ -0,0 +1,249 @@
+package heresy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// Sources recorded in DiscoveredFrom on beads filed by policy
+const (
+	// FixSource marks a fix bead lined up for an escalated heresy. Its
+	// associate starts once the heresy is approved.
+	FixSource = "heresy-fix"
+	// BatchSource marks the weekly chore bead low-severity heresies collect in
+	BatchSource = "heresy-batch"
+)
+
+// SeverityNone turns an escalation or batching threshold off
+const SeverityNone Severity = "none"
+
+// severityRank orders severities from least to most severe
+var severityRank = map[Severity]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// ParseSeverity checks a severity named in config. "none" is accepted and
+// matches nothing.
+func ParseSeverity(s string) (Severity, error) {
+	sev := Severity(strings.ToLower(strings.TrimSpace(s)))
+	if sev == SeverityNone {
+		return sev, nil
+	}
+	if _, ok := severityRank[sev]; !ok {
+		return "", errkind.New(errkind.Invalid, fmt.Sprintf("unknown heresy severity %q (want low, medium, high, critical or none)", s))
+	}
+	return sev, nil
+}
+
+// Policy decides how scanned heresies are filed by severity
+type Policy struct {
+	Escalate    Severity // this severity and above are filed pending approval and notified at once
+	Batch       Severity // this severity and below collect in one chore bead per turf per week
+	PrespawnFix bool     // escalated heresies also get a fix bead whose associate starts on approval
+}
+
+// DefaultPolicy escalates critical heresies and batches low ones
+func DefaultPolicy() Policy {
+	return Policy{Escalate: SeverityCritical, Batch: SeverityLow}
+}
+
+// escalates reports whether a heresy of severity s is escalated
+func (p Policy) escalates(s Severity) bool {
+	min, ok := severityRank[p.Escalate]
+	return ok && severityRank[s] >= min
+}
+
+// batches reports whether a heresy of severity s goes in the weekly chore
+func (p Policy) batches(s Severity) bool {
+	max, ok := severityRank[p.Batch]
+	return ok && severityRank[s] <= max
+}
+
+// Notifier is told when an escalated heresy needs approval. notify.Manager fits.
+type Notifier interface {
+	NotifyApprovalNeeded(beadID, title string) error
+}
+
+// Filed lists the beads File created or added to
+type Filed struct {
+	Escalated []string // pending_approval heresy beads
+	Fixes     []string // fix beads waiting on an escalated heresy's approval
+	Created   []string // open heresy beads for severities in between
+	Batched   []string // weekly chore beads low-severity heresies were added to
+	Notified  int      // escalations the notifier was told about
+}
+
+// File files heresies according to policy. Escalated heresies become
+// pending_approval beads and are notified straight away; with PrespawnFix
+// each also gets a pending fix bead. Batched heresies are appended to the
+// turf's chore bead for the week of now, created on first use. The rest are
+// filed as open beads, as CreateBeads does. notifier may be nil.
+func (d *Detector) File(heresies []*Heresy, policy Policy, notifier Notifier, now time.Time) (*Filed, error) {
+	filed := &Filed{}
+	var batch []*Heresy
+
+	for _, h := range heresies {
+		switch {
+		case policy.escalates(h.Severity):
+			if err := d.escalate(h, policy, notifier, filed); err != nil {
+				return filed, err
+			}
+		case policy.batches(h.Severity):
+			batch = append(batch, h)
+		default:
+			created, err := d.beadStore.Create(d.heresyToBead(h))
+			if err != nil {
+				return filed, fmt.Errorf("failed to create bead for heresy %s: %w", h.ID, err)
+			}
+			filed.Created = append(filed.Created, created.ID)
+		}
+	}
+
+	if len(batch) > 0 {
+		id, err := d.addToBatch(batch, policy, now)
+		if err != nil {
+			return filed, err
+		}
+		filed.Batched = append(filed.Batched, id)
+	}
+	return filed, nil
+}
+
+// escalate files h pending approval, lines up its fix and sends the notification
+func (d *Detector) escalate(h *Heresy, policy Policy, notifier Notifier, filed *Filed) error {
+	bead := d.heresyToBead(h)
+	bead.Status = models.BeadStatusPendingApproval
+	created, err := d.beadStore.Create(bead)
+	if err != nil {
+		return fmt.Errorf("failed to create bead for heresy %s: %w", h.ID, err)
+	}
+	filed.Escalated = append(filed.Escalated, created.ID)
+
+	if policy.PrespawnFix {
+		fix, err := d.beadStore.Create(d.fixBead(h, created))
+		if err != nil {
+			return fmt.Errorf("failed to create fix bead for heresy %s: %w", h.ID, err)
+		}
+		filed.Fixes = append(filed.Fixes, fix.ID)
+	}
+
+	if notifier != nil {
+		if err := notifier.NotifyApprovalNeeded(created.ID, created.Title); err == nil {
+			filed.Notified++
+		}
+	}
+	return nil
+}
+
+// fixBead is the work an associate picks up once the heresy is approved
+func (d *Detector) fixBead(h *Heresy, parent *models.Bead) *models.Bead {
+	description := fmt.Sprintf("Eradicate the heresy in %s: %s", parent.ID, h.Description)
+	if h.Correct != "" {
+		description += fmt.Sprintf("\n\nReplace it with: %s", h.Correct)
+	}
+	if len(h.Locations) > 0 {
+		description += fmt.Sprintf("\n\nLocations:\n- %s", strings.Join(h.Locations, "\n- "))
+	}
+	description += fmt.Sprintf("\n\nAn associate starts on this as soon as %s is approved; rejecting %s closes it.", parent.ID, parent.ID)
+
+	return &models.Bead{
+		Title:          fmt.Sprintf("Fix heresy: %s", h.Pattern),
+		Description:    description,
+		Status:         models.BeadStatusPendingApproval,
+		Type:           models.BeadTypeBug,
+		Turf:           d.turfPath,
+		Priority:       parent.Priority,
+		ParentID:       parent.ID,
+		DiscoveredFrom: FixSource,
+	}
+}
+
+// BatchTitle is the title of the chore bead collecting low-severity heresies
+// for the ISO week containing t
+func BatchTitle(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("[HERESY] Low-severity findings %d-W%02d", year, week)
+}
+
+// addToBatch appends heresies to this week's chore bead, skipping patterns
+// it already lists, and returns the bead's ID
+func (d *Detector) addToBatch(heresies []*Heresy, policy Policy, now time.Time) (string, error) {
+	title := BatchTitle(now)
+	existing, err := d.beadStore.List(storage.BeadFilter{Turf: d.turfPath, Type: models.BeadTypeChore})
+	if err != nil {
+		return "", fmt.Errorf("failed to list chore beads: %w", err)
+	}
+
+	var chore *models.Bead
+	for _, b := range existing {
+		if b.Title == title && b.DiscoveredFrom == BatchSource && b.Status != models.BeadStatusClosed {
+			chore = b
+			break
+		}
+	}
+
+	if chore == nil {
+		chore = &models.Bead{
+			Title:          title,
+			Description:    "Low-severity heresies found this week. Fix them together when there is slack.",
+			Status:         models.BeadStatusOpen,
+			Type:           models.BeadTypeChore,
+			Turf:           d.turfPath,
+			Priority:       d.severityToPriority(policy.Batch),
+			DiscoveredFrom: BatchSource,
+		}
+		for _, h := range heresies {
+			chore.Description += batchEntry(h)
+		}
+		created, err := d.beadStore.Create(chore)
+		if err != nil {
+			return "", fmt.Errorf("failed to create weekly heresy chore: %w", err)
+		}
+		return created.ID, nil
+	}
+
+	var added []string
+	for _, h := range heresies {
+		if strings.Contains(chore.Description, batchHeading(h)) {
+			continue
+		}
+		chore.Description += batchEntry(h)
+		added = append(added, h.Pattern)
+	}
+	if len(added) == 0 {
+		return chore.ID, nil
+	}
+	if _, err := d.beadStore.Update(chore); err != nil {
+		return "", fmt.Errorf("failed to update weekly heresy chore: %w", err)
+	}
+	comment := fmt.Sprintf("Added %d heresy finding(s): %s", len(added), strings.Join(added, ", "))
+	if err := d.beadStore.AddComment(chore.ID, "heresy-scan", comment); err != nil {
+		return "", fmt.Errorf("failed to comment on weekly heresy chore: %w", err)
+	}
+	return chore.ID, nil
+}
+
+// batchHeading starts a heresy's entry in the weekly chore
+func batchHeading(h *Heresy) string {
+	return fmt.Sprintf("\n\n### %s", h.Pattern)
+}
+
+// batchEntry describes one heresy in the weekly chore
+func batchEntry(h *Heresy) string {
+	entry := batchHeading(h) + "\n" + h.Description
+	if h.Correct != "" {
+		entry += fmt.Sprintf("\nCorrect pattern: %s", h.Correct)
+	}
+	if len(h.Locations) > 0 {
+		entry += fmt.Sprintf("\n- %s", strings.Join(h.Locations, "\n- "))
+	}
+	return entry
+}
@@ -564,19 +564,15 @@ func (d *Detector) heresyToBead(h *Heresy) *models.Bead {
 		Type:           models.BeadTypeHeresy,
 		Turf:           d.turfPath,
 		Priority:       d.severityToPriority(h.Severity),
-		Labels:         strings.Join(h.Locations, ","),
+		Locations:      append([]string(nil), h.Locations...),
 		DiscoveredFrom: "heresy-scan",
 	}
 }
 
-// extractLocations extracts locations from a bead's labels field
+// extractLocations extracts locations from a heresy bead
 func (d *Detector) extractLocations(bead *models.Bead) []string {
-	if bead.Labels == "" {
-		return nil
-	}
-	locations := strings.Split(bead.Labels, ",")
 	var cleaned []string
-	for _, loc := range locations {
+	for _, loc := range bead.Locations {
 		loc = strings.TrimSpace(loc)
 		if loc != "" {
 			cleaned = append(cleaned, loc)
@@ -2,12 +2,15 @@ package heresy
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gabe/mob/internal/errkind"
 	"github.com/gabe/mob/internal/models"
 	"github.com/gabe/mob/internal/storage"
 )
@@ -411,7 +414,7 @@ func TestDetector_Purge(t *testing.T) {
 		Type:        models.BeadTypeHeresy,
 		Turf:        turfPath,
 		Priority:    2,
-		Labels:      "main.go:10,util.go:20,handler.go:30", // Store locations in labels for purge
+		Locations:   []string{"main.go:10", "util.go:20", "handler.go:30"},
 	}
 	parentBead, err := beadStore.Create(heresyBead)
 	if err != nil {
@@ -542,3 +545,148 @@ func TestDetector_List_Empty(t *testing.T) {
 
 // Helper to run git command (for test compatibility)
 var newExecCommand = exec.Command
+
+type recordingNotifier struct {
+	beads []string
+}
+
+func (n *recordingNotifier) NotifyApprovalNeeded(beadID, title string) error {
+	n.beads = append(n.beads, beadID)
+	return nil
+}
+
+func newFileTestDetector(t *testing.T) (*Detector, *storage.BeadStore) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	beadStore, err := storage.NewBeadStore(filepath.Join(tmpDir, "beads"))
+	if err != nil {
+		t.Fatalf("failed to create bead store: %v", err)
+	}
+	return New(filepath.Join(tmpDir, "turf"), beadStore), beadStore
+}
+
+func TestDetector_File_BySeverity(t *testing.T) {
+	detector, beadStore := newFileTestDetector(t)
+	notifier := &recordingNotifier{}
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	heresies := []*Heresy{
+		{ID: "h1", Description: "SQL built by concatenation", Pattern: "db.Query(\"...\" + id)", Severity: SeverityCritical, Locations: []string{"store.go:10"}},
+		{ID: "h2", Description: "Deprecated helper", Pattern: "oldHelper()", Severity: SeverityHigh},
+		{ID: "h3", Description: "Mixed naming", Pattern: "snake_case", Severity: SeverityLow},
+		{ID: "h4", Description: "Alias drift", Pattern: "import alias", Severity: SeverityLow},
+	}
+
+	policy := DefaultPolicy()
+	policy.PrespawnFix = true
+	filed, err := detector.File(heresies, policy, notifier, now)
+	if err != nil {
+		t.Fatalf("File() returned error: %v", err)
+	}
+
+	if len(filed.Escalated) != 1 || len(filed.Fixes) != 1 || len(filed.Created) != 1 || len(filed.Batched) != 1 {
+		t.Fatalf("expected 1 escalated, 1 fix, 1 created, 1 batched, got %+v", filed)
+	}
+	if len(notifier.beads) != 1 || notifier.beads[0] != filed.Escalated[0] || filed.Notified != 1 {
+		t.Errorf("expected one notification for %s, got %v", filed.Escalated[0], notifier.beads)
+	}
+
+	escalated, _ := beadStore.Get(filed.Escalated[0])
+	if escalated.Status != models.BeadStatusPendingApproval || escalated.Priority != 0 {
+		t.Errorf("expected escalated bead pending approval at P0, got %s P%d", escalated.Status, escalated.Priority)
+	}
+
+	fix, _ := beadStore.Get(filed.Fixes[0])
+	if fix.Status != models.BeadStatusPendingApproval || fix.ParentID != escalated.ID || fix.DiscoveredFrom != FixSource {
+		t.Errorf("expected fix pending approval under %s, got %s under %s from %q", escalated.ID, fix.Status, fix.ParentID, fix.DiscoveredFrom)
+	}
+
+	created, _ := beadStore.Get(filed.Created[0])
+	if created.Status != models.BeadStatusOpen || created.Type != models.BeadTypeHeresy {
+		t.Errorf("expected high heresy filed as an open heresy bead, got %s %s", created.Status, created.Type)
+	}
+
+	chore, _ := beadStore.Get(filed.Batched[0])
+	if chore.Type != models.BeadTypeChore || chore.Title != "[HERESY] Low-severity findings 2026-W42" {
+		t.Errorf("unexpected weekly chore %s %q", chore.Type, chore.Title)
+	}
+	for _, pattern := range []string{"snake_case", "import alias"} {
+		if !strings.Contains(chore.Description, "### "+pattern) {
+			t.Errorf("expected weekly chore to list %q, got:\n%s", pattern, chore.Description)
+		}
+	}
+}
+
+func TestDetector_File_AppendsToWeeklyChore(t *testing.T) {
+	detector, beadStore := newFileTestDetector(t)
+	monday := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	first := []*Heresy{{ID: "h1", Description: "Mixed naming", Pattern: "snake_case", Severity: SeverityLow}}
+	filed, err := detector.File(first, DefaultPolicy(), nil, monday)
+	if err != nil {
+		t.Fatalf("File() returned error: %v", err)
+	}
+	choreID := filed.Batched[0]
+
+	// Later the same week: the known finding is skipped, the new one appended
+	again := []*Heresy{
+		{ID: "h2", Description: "Mixed naming", Pattern: "snake_case", Severity: SeverityLow},
+		{ID: "h3", Description: "Alias drift", Pattern: "import alias", Severity: SeverityLow},
+	}
+	filed, err = detector.File(again, DefaultPolicy(), nil, monday.AddDate(0, 0, 4))
+	if err != nil {
+		t.Fatalf("File() returned error: %v", err)
+	}
+	if filed.Batched[0] != choreID {
+		t.Fatalf("expected findings added to %s, got %s", choreID, filed.Batched[0])
+	}
+	chore, _ := beadStore.Get(choreID)
+	if strings.Count(chore.Description, "### snake_case") != 1 || !strings.Contains(chore.Description, "### import alias") {
+		t.Errorf("unexpected weekly chore description:\n%s", chore.Description)
+	}
+	last := chore.History[len(chore.History)-1]
+	if last.Type != models.BeadEventTypeComment || !strings.Contains(last.Comment, "import alias") {
+		t.Errorf("expected a comment naming the new finding, got %+v", last)
+	}
+
+	// The next week starts a new chore
+	filed, err = detector.File(first, DefaultPolicy(), nil, monday.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("File() returned error: %v", err)
+	}
+	if filed.Batched[0] == choreID {
+		t.Error("expected a new chore for the next week")
+	}
+}
+
+func TestDetector_File_ThresholdsOff(t *testing.T) {
+	detector, beadStore := newFileTestDetector(t)
+	heresies := []*Heresy{
+		{ID: "h1", Description: "Critical", Pattern: "a", Severity: SeverityCritical},
+		{ID: "h2", Description: "Low", Pattern: "b", Severity: SeverityLow},
+	}
+
+	filed, err := detector.File(heresies, Policy{Escalate: SeverityNone, Batch: SeverityNone}, nil, time.Now())
+	if err != nil {
+		t.Fatalf("File() returned error: %v", err)
+	}
+	if len(filed.Created) != 2 || len(filed.Escalated)+len(filed.Batched) != 0 {
+		t.Fatalf("expected both filed as open beads, got %+v", filed)
+	}
+	for _, id := range filed.Created {
+		if b, _ := beadStore.Get(id); b.Status != models.BeadStatusOpen {
+			t.Errorf("expected %s open, got %s", id, b.Status)
+		}
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, s := range []string{"low", "Medium", " high ", "critical", "none"} {
+		if _, err := ParseSeverity(s); err != nil {
+			t.Errorf("ParseSeverity(%q) returned error: %v", s, err)
+		}
+	}
+	if _, err := ParseSeverity("urgent"); !errors.Is(err, errkind.Invalid) {
+		t.Errorf("expected Invalid for an unknown severity, got %v", err)
+	}
+}
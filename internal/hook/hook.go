@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/gabe/mob/internal/errkind"
 )
 
 // HookType represents the type of hook message
@@ -21,8 +22,12 @@ const (
 	HookTypeAbort  HookType = "abort"  // Cancel current work
 	HookTypePause  HookType = "pause"  // Pause execution
 	HookTypeResume HookType = "resume" // Resume execution
+	HookTypeReload HookType = "reload" // Restart the session with the soldati's current definition
 )
 
+// ErrInvalidHook is returned when a hook file cannot be parsed
+var ErrInvalidHook = errkind.New(errkind.Invalid, "invalid hook file")
+
 // hookFileName is the standard name for hook files
 const hookFileName = "hook.json"
 
@@ -109,7 +114,7 @@ func (m *Manager) Read() (*Hook, error) {
 
 	var hook Hook
 	if err := json.Unmarshal(data, &hook); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal hook: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHook, err)
 	}
 
 	return &hook, nil
@@ -306,6 +306,7 @@ func TestHookTypes(t *testing.T) {
 		{HookTypeAbort, "abort"},
 		{HookTypePause, "pause"},
 		{HookTypeResume, "resume"},
+		{HookTypeReload, "reload"},
 	}
 
 	for _, tt := range tests {
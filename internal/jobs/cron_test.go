@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	// Wednesday
+	base := time.Date(2026, 3, 4, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 4, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)},
+		{"30 9-17/2 * * *", time.Date(2026, 3, 4, 11, 30, 0, 0, time.UTC)},
+		{"0 2 * * 1-5", time.Date(2026, 3, 5, 2, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0,45 10 * * *", time.Date(2026, 3, 4, 10, 45, 0, 0, time.UTC)},
+		{"0 0 15 * 1", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)}, // Monday comes before the 15th
+		{"@hourly", time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"@every 6h", base.Add(6 * time.Hour)},
+		{"@every 1d", base.Add(24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		sched, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := sched.Next(base); !got.Equal(tt.want) {
+			t.Errorf("%q: Next = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseSchedule_NextInHalfHourZone(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	sched, err := ParseSchedule("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 3, 4, 7, 10, 0, 0, ist)
+	if got, want := sched.Next(base), time.Date(2026, 3, 4, 9, 0, 0, 0, ist); !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+}
+
+func TestParseSchedule_NeverFires(t *testing.T) {
+	sched, err := ParseSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sched.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Feb 30 should never fire, got %v", got)
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every",
+		"@every 10s",
+		"@yearly",
+	} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) should fail", spec)
+		}
+	}
+}
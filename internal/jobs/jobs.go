@@ -0,0 +1,200 @@
+// Package jobs runs the daemon's recurring work — garbage collection, sweeps,
+// activity summaries and federation syncs — on the cron schedules declared under [jobs] in
+// config, and remembers when each job last ran.
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// Kind is the work a job does
+type Kind string
+
+const (
+	KindGC       Kind = "gc"       // remove stale sessions, transcripts, MCP configs and scratch files
+	KindSweep    Kind = "sweep"    // file beads for review findings and bug markers
+	KindSummary  Kind = "summary"  // summarize the activity feed since the last run
+	KindFederate Kind = "federate" // push and pull federated beads with every peer
+)
+
+// Kinds lists every job kind
+var Kinds = []Kind{KindGC, KindSweep, KindSummary, KindFederate}
+
+// Job is one scheduled job resolved from config
+type Job struct {
+	Name     string
+	Kind     Kind
+	Spec     string // schedule as written in config
+	Schedule Schedule
+	Enabled  bool
+	Turf     string // sweep scope; empty = every turf
+	Sweep    string // sweep type: "review", "bugs" or "all"
+}
+
+// FromConfig resolves the [jobs] entries, sorted by name. When no "gc" job is
+// configured, the [gc] interval becomes one so older configs keep collecting.
+func FromConfig(cfg *config.Config) ([]*Job, error) {
+	entries := make(map[string]config.JobConfig, len(cfg.Jobs)+1)
+	for name, jc := range cfg.Jobs {
+		entries[name] = jc
+	}
+	if _, ok := entries[string(KindGC)]; !ok {
+		interval, err := config.ParseRetention(cfg.GC.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("gc.interval: %w", err)
+		}
+		if interval > 0 {
+			entries[string(KindGC)] = config.JobConfig{Kind: string(KindGC), Schedule: "@every " + cfg.GC.Interval}
+		}
+	}
+
+	var jobs []*Job
+	var errs []error
+	for name, jc := range entries {
+		job, err := newJob(name, jc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, errors.Join(errs...)
+}
+
+// newJob validates one [jobs.<name>] entry
+func newJob(name string, jc config.JobConfig) (*Job, error) {
+	kind := Kind(jc.Kind)
+	if kind == "" {
+		kind = Kind(name)
+	}
+	switch kind {
+	case KindGC, KindSweep, KindSummary, KindFederate:
+	default:
+		return nil, errkind.New(errkind.Invalid, fmt.Sprintf("jobs.%s: unknown kind %q (expected gc, sweep, summary or federate)", name, kind))
+	}
+
+	sweepType := jc.Sweep
+	if kind == KindSweep {
+		switch sweepType {
+		case "":
+			sweepType = "all"
+		case "review", "bugs", "all":
+		default:
+			return nil, errkind.New(errkind.Invalid, fmt.Sprintf("jobs.%s: unknown sweep %q (expected review, bugs or all)", name, sweepType))
+		}
+	}
+
+	sched, err := ParseSchedule(jc.Schedule)
+	if err != nil {
+		return nil, errkind.Wrap(errkind.Invalid, fmt.Errorf("jobs.%s: %w", name, err))
+	}
+
+	return &Job{
+		Name:     name,
+		Kind:     kind,
+		Spec:     jc.Schedule,
+		Schedule: sched,
+		Enabled:  jc.IsEnabled(),
+		Turf:     jc.Turf,
+		Sweep:    sweepType,
+	}, nil
+}
+
+// Find returns the job with the given name
+func Find(jobs []*Job, name string) (*Job, error) {
+	for _, j := range jobs {
+		if j.Name == name {
+			return j, nil
+		}
+	}
+	return nil, errkind.New(errkind.NotFound, fmt.Sprintf("no job named %q", name))
+}
+
+// NextRun is when a job is next due. A job that has never run is scheduled
+// from since, normally when the daemon started.
+func (j *Job) NextRun(last Run, since time.Time) time.Time {
+	from := since
+	if !last.At.IsZero() {
+		from = last.At
+	}
+	return j.Schedule.Next(from)
+}
+
+// Due reports whether a job should run at now
+func (j *Job) Due(last Run, since, now time.Time) bool {
+	if !j.Enabled {
+		return false
+	}
+	next := j.NextRun(last, since)
+	return !next.IsZero() && !next.After(now)
+}
+
+// Run records the outcome of one job run
+type Run struct {
+	At       time.Time     `json:"at"`
+	Duration time.Duration `json:"duration"`
+	Result   string        `json:"result,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Manual   bool          `json:"manual,omitempty"` // started with `mob jobs run-now`
+}
+
+// stateMu serializes state updates from jobs finishing at the same time
+var stateMu sync.Mutex
+
+// StatePath returns where last-run times are kept
+func StatePath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "jobs.json")
+}
+
+// LoadState reads the last run of every job. A missing file is an empty state.
+func LoadState(path string) (map[string]Run, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Run{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job state: %w", err)
+	}
+
+	state := map[string]Run{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse job state: %w", err)
+	}
+	return state, nil
+}
+
+// RecordRun stores a job's latest run
+func RecordRun(path, name string, run Run) error {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	state, err := LoadState(path)
+	if err != nil {
+		return err
+	}
+	state[name] = run
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode job state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create job state directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write job state: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
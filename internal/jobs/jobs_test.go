@@ -0,0 +1,166 @@
+package jobs
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestFromConfig(t *testing.T) {
+	off := false
+	cfg := config.DefaultConfig()
+	cfg.Jobs = map[string]config.JobConfig{
+		"nightly": {Kind: "sweep", Schedule: "0 2 * * *", Sweep: "review", Turf: "api"},
+		"summary": {Schedule: "@daily", Enabled: &off},
+	}
+
+	resolved, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, j := range resolved {
+		names = append(names, j.Name)
+	}
+	if got := strings.Join(names, ","); got != "gc,nightly,summary" {
+		t.Fatalf("jobs = %s, want gc,nightly,summary", got)
+	}
+
+	gc := resolved[0]
+	if gc.Kind != KindGC || gc.Spec != "@every 6h" || !gc.Enabled {
+		t.Errorf("gc job from gc.interval = %+v", gc)
+	}
+	nightly := resolved[1]
+	if nightly.Kind != KindSweep || nightly.Sweep != "review" || nightly.Turf != "api" {
+		t.Errorf("nightly = %+v", nightly)
+	}
+	summary := resolved[2]
+	if summary.Kind != KindSummary || summary.Enabled {
+		t.Errorf("summary should take its kind from its name and be disabled: %+v", summary)
+	}
+}
+
+func TestFromConfig_GCOverrideAndDisable(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.GC.Interval = "0"
+	resolved, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved) != 0 {
+		t.Errorf("gc.interval 0 should leave no jobs, got %d", len(resolved))
+	}
+
+	cfg.Jobs = map[string]config.JobConfig{"gc": {Schedule: "0 3 * * *"}}
+	resolved, err = FromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved) != 1 || resolved[0].Spec != "0 3 * * *" {
+		t.Errorf("[jobs.gc] should replace gc.interval: %+v", resolved)
+	}
+}
+
+func TestFromConfig_Invalid(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Jobs = map[string]config.JobConfig{
+		"weird":  {Schedule: "@daily"},
+		"broken": {Kind: "gc", Schedule: "every day"},
+		"sweepy": {Kind: "sweep", Schedule: "@daily", Sweep: "lint"},
+		"fine":   {Kind: "summary", Schedule: "@daily"},
+	}
+
+	resolved, err := FromConfig(cfg)
+	if !errors.Is(err, errkind.Invalid) {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+	for _, name := range []string{"weird", "broken", "sweepy"} {
+		if !strings.Contains(err.Error(), "jobs."+name) {
+			t.Errorf("error should name jobs.%s: %v", name, err)
+		}
+	}
+	if _, err := Find(resolved, "fine"); err != nil {
+		t.Errorf("valid jobs should still resolve: %v", err)
+	}
+	if _, err := Find(resolved, "weird"); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("Find(weird) = %v, want not found", err)
+	}
+}
+
+func TestJob_Due(t *testing.T) {
+	sched, _ := ParseSchedule("@every 1h")
+	job := &Job{Name: "gc", Kind: KindGC, Schedule: sched, Enabled: true}
+	since := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	if job.Due(Run{}, since, since.Add(30*time.Minute)) {
+		t.Error("never-run job is not due before one interval from since")
+	}
+	if !job.Due(Run{}, since, since.Add(time.Hour)) {
+		t.Error("never-run job should be due one interval after since")
+	}
+
+	last := Run{At: since.Add(50 * time.Minute)}
+	if job.Due(last, since, since.Add(time.Hour)) {
+		t.Error("job should be scheduled from its last run")
+	}
+
+	job.Enabled = false
+	if job.Due(Run{}, since, since.Add(48*time.Hour)) {
+		t.Error("disabled job is never due")
+	}
+}
+
+func TestRecordRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mob", "jobs.json")
+
+	state, err := LoadState(path)
+	if err != nil || len(state) != 0 {
+		t.Fatalf("missing state = %v, %v", state, err)
+	}
+
+	at := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	if err := RecordRun(path, "gc", Run{At: at, Result: "removed 2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordRun(path, "summary", Run{At: at, Error: "boom", Manual: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state["gc"].At.Equal(at) || state["gc"].Result != "removed 2" {
+		t.Errorf("gc run = %+v", state["gc"])
+	}
+	if state["summary"].Error != "boom" || !state["summary"].Manual {
+		t.Errorf("summary run = %+v", state["summary"])
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	since := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	entries := []*models.Activity{
+		{Timestamp: since.Add(time.Minute), Type: models.ActivityBeadCreated, Message: "Created bd-1"},
+		{Timestamp: since.Add(2 * time.Minute), Type: models.ActivityMergeLanded, Message: "Merged bd-1"},
+		{Timestamp: since.Add(3 * time.Minute), Type: models.ActivityBeadCreated, Message: "Created bd-2"},
+	}
+
+	var buf bytes.Buffer
+	headline := WriteSummary(&buf, entries, since, since.Add(time.Hour))
+
+	if want := "3 event(s) since 2026-03-04 09:00: bead_created 2, merge_landed 1"; headline != want {
+		t.Errorf("headline = %q, want %q", headline, want)
+	}
+	if !strings.Contains(buf.String(), "Merged bd-1") {
+		t.Errorf("summary should list recent entries:\n%s", buf.String())
+	}
+}
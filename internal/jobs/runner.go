@@ -0,0 +1,207 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/federation"
+	"github.com/gabe/mob/internal/gc"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/sweep"
+)
+
+// Runner carries what jobs need to do their work. The daemon and
+// `mob jobs run-now` build one from their own stores.
+type Runner struct {
+	MobDir    string
+	Config    *config.Config
+	Beads     *storage.BeadStore
+	Registry  *registry.Registry
+	Activity  *storage.ActivityStore // nil skips feed pruning and summaries
+	Turfs     []models.Turf
+	Detectors []sweep.Detector // plugin detectors run alongside sweeps
+}
+
+// Run executes a job once and returns a one-line result. last is the job's
+// previous run; summaries cover the activity since then.
+func (r *Runner) Run(ctx context.Context, job *Job, last Run) (string, error) {
+	switch job.Kind {
+	case KindGC:
+		return r.runGC()
+	case KindSweep:
+		return r.runSweep(ctx, job)
+	case KindSummary:
+		return r.runSummary(last.At)
+	case KindFederate:
+		return r.runFederate()
+	default:
+		return "", fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+}
+
+// runGC prunes the activity feed and removes artifacts past their [gc] retention
+func (r *Runner) runGC() (string, error) {
+	if r.Activity != nil {
+		if _, err := r.Activity.Prune(storage.DefaultActivityRetention); err != nil {
+			return "", fmt.Errorf("failed to prune activity feed: %w", err)
+		}
+	}
+
+	policy, err := gc.PolicyFromConfig(r.Config.GC)
+	if err != nil {
+		return "", err
+	}
+
+	report := gc.NewCollector(r.MobDir, r.Registry).Run(policy, false)
+	result := fmt.Sprintf("removed %d stale artifact(s), reclaimed %s", len(report.Items), gc.FormatBytes(report.TotalBytes()))
+	if len(report.Errors) > 0 {
+		return result, fmt.Errorf("%d artifact(s) could not be removed: %w", len(report.Errors), report.Errors[0])
+	}
+	return result, nil
+}
+
+// runSweep sweeps the job's turf, or every turf, filing beads for what it finds
+func (r *Runner) runSweep(ctx context.Context, job *Job) (string, error) {
+	if r.Beads == nil {
+		return "", fmt.Errorf("sweeps need the bead store")
+	}
+
+	turfs := r.Turfs
+	if job.Turf != "" {
+		turfs = nil
+		for _, t := range r.Turfs {
+			if t.Name == job.Turf {
+				turfs = append(turfs, t)
+			}
+		}
+		if len(turfs) == 0 {
+			return "", fmt.Errorf("turf %q is not registered", job.Turf)
+		}
+	}
+
+	found, filed := 0, 0
+	for _, t := range turfs {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		sweeper := sweep.New(t.Path, r.Beads)
+		sweeper.AddDetectors(r.Detectors...)
+
+		var results []*sweep.SweepResult
+		var err error
+		switch sweep.SweepType(job.Sweep) {
+		case sweep.SweepTypeReview:
+			var res *sweep.SweepResult
+			res, err = sweeper.Review(ctx)
+			results = append(results, res)
+		case sweep.SweepTypeBugs:
+			var res *sweep.SweepResult
+			res, err = sweeper.Bugs(ctx)
+			results = append(results, res)
+		default:
+			results, err = sweeper.All(ctx)
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", t.Name, err)
+		}
+
+		for _, res := range results {
+			found += res.ItemsFound
+			filed += len(res.Beads)
+		}
+	}
+
+	return fmt.Sprintf("swept %d turf(s): %d item(s) found, %d new bead(s)", len(turfs), found, filed), nil
+}
+
+// runFederate syncs federated beads with every configured peer
+func (r *Runner) runFederate() (string, error) {
+	if r.Beads == nil {
+		return "", fmt.Errorf("federation needs the bead store")
+	}
+
+	results, err := federation.Sync(r.Beads, &r.Config.Federation, "", federation.DirectionSync)
+	lines := make([]string, 0, len(results))
+	for _, res := range results {
+		lines = append(lines, res.String())
+	}
+	return strings.Join(lines, "; "), err
+}
+
+// SummaryPath returns the file activity summaries are appended to
+func SummaryPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "summaries.log")
+}
+
+// runSummary appends a digest of the activity since the previous run to the
+// summaries log. The first run covers the last day.
+func (r *Runner) runSummary(since time.Time) (string, error) {
+	if r.Activity == nil {
+		return "", fmt.Errorf("summaries need the activity feed")
+	}
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour)
+	}
+
+	entries, err := r.Activity.List(storage.ActivityFilter{Since: since})
+	if err != nil {
+		return "", err
+	}
+
+	path := SummaryPath(r.MobDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create summary directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to open summary file: %w", err)
+	}
+	defer f.Close()
+
+	line := WriteSummary(f, entries, since, time.Now())
+	return line, nil
+}
+
+// WriteSummary writes a digest of activity entries to w and returns its
+// one-line headline
+func WriteSummary(w io.Writer, entries []*models.Activity, since, now time.Time) string {
+	counts := make(map[models.ActivityType]int)
+	for _, e := range entries {
+		counts[e.Type]++
+	}
+
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%s %d", t, counts[models.ActivityType(t)]))
+	}
+
+	headline := fmt.Sprintf("%d event(s) since %s", len(entries), since.Format("2006-01-02 15:04"))
+	if len(parts) > 0 {
+		headline += ": " + strings.Join(parts, ", ")
+	}
+
+	fmt.Fprintf(w, "=== Activity summary (%s) ===\n%s\n", now.Format(time.RFC3339), headline)
+	start := max(len(entries)-10, 0)
+	for _, e := range entries[start:] {
+		fmt.Fprintf(w, "  [%s] %s: %s\n", e.Timestamp.Format("01-02 15:04"), e.Type, e.Message)
+	}
+	fmt.Fprintln(w)
+
+	return headline
+}
@@ -0,0 +1,267 @@
+// Package logfile writes the daemon log with rotation and retention, and
+// reads it back across rotations. The active log keeps its name; once it
+// outgrows MaxBytes or is older than MaxAge it is renamed with a timestamp
+// suffix (daemon.log.20260102-150405), gzipped when Compress is set, and
+// rotated logs past Retention or beyond MaxFiles are removed.
+package logfile
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+)
+
+// stampLayout is the timestamp suffix of rotated logs; it sorts by time
+const stampLayout = "20060102-150405"
+
+// Policy decides when the log is rotated and how long rotated logs are kept.
+// Zero values disable the corresponding limit.
+type Policy struct {
+	MaxBytes  int64         // rotate once the active log would grow past this
+	MaxAge    time.Duration // rotate once the active log has been written for this long
+	Compress  bool          // gzip rotated logs
+	Retention time.Duration // remove rotated logs last written longer ago than this
+	MaxFiles  int           // keep at most this many rotated logs
+}
+
+// PolicyFromConfig returns the policy set in [logging]
+func PolicyFromConfig(cfg config.LoggingConfig) Policy {
+	return Policy{
+		MaxBytes:  cfg.GetMaxSize(),
+		MaxAge:    cfg.GetRotateEvery(),
+		Compress:  cfg.Compress,
+		Retention: cfg.GetRetention(),
+		MaxFiles:  cfg.MaxFiles,
+	}
+}
+
+// Writer appends to a log file, rotating it as its policy says. It is safe
+// for concurrent use.
+type Writer struct {
+	path    string
+	policy  Policy
+	file    *os.File
+	size    int64
+	started time.Time        // when the active log was opened or last rotated
+	now     func() time.Time // replaced in tests
+	pending sync.WaitGroup   // rotated logs being compressed and pruned
+	mu      sync.Mutex
+}
+
+// Open opens the log at path for appending. A log left over from an earlier
+// run that is already due for rotation is rotated first.
+func Open(path string, policy Policy) (*Writer, error) {
+	w := &Writer{path: path, policy: policy, now: time.Now}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	var rotated string
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		due := policy.MaxBytes > 0 && info.Size() >= policy.MaxBytes
+		due = due || (policy.MaxAge > 0 && w.now().Sub(info.ModTime()) >= policy.MaxAge)
+		if due {
+			if rotated, err = w.rename(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := w.openFile(); err != nil {
+		return nil, err
+	}
+	w.cleanup(rotated)
+	return w, nil
+}
+
+// Path returns the active log's path
+func (w *Writer) Path() string {
+	return w.path
+}
+
+// SetPolicy replaces the rotation policy, for config reloads, and prunes
+// rotated logs the new policy no longer keeps
+func (w *Writer) SetPolicy(policy Policy) {
+	w.mu.Lock()
+	w.policy = policy
+	w.mu.Unlock()
+	w.cleanup("")
+}
+
+// Write appends p to the active log, rotating it first when p would take it
+// over MaxBytes or it has reached MaxAge
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	if w.due(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Rotate moves the active log aside now and starts a new one
+func (w *Writer) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return os.ErrClosed
+	}
+	return w.rotate()
+}
+
+// Close closes the active log, waiting for rotated logs to finish compressing
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+	w.pending.Wait()
+	return err
+}
+
+// due reports whether writing n more bytes should rotate the log first. An
+// empty log is never rotated, so a single oversized write still lands.
+func (w *Writer) due(n int) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.policy.MaxBytes > 0 && w.size+int64(n) > w.policy.MaxBytes {
+		return true
+	}
+	return w.policy.MaxAge > 0 && w.now().Sub(w.started) >= w.policy.MaxAge
+}
+
+// rotate renames the active log, opens a fresh one and compresses and prunes
+// in the background so logging is not held up
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+	rotated, err := w.rename()
+	if err != nil {
+		// Keep logging to the old file rather than losing lines
+		if openErr := w.openFile(); openErr != nil {
+			return openErr
+		}
+		return err
+	}
+	if err := w.openFile(); err != nil {
+		return err
+	}
+	w.pending.Add(1)
+	go func() {
+		defer w.pending.Done()
+		w.cleanup(rotated)
+	}()
+	return nil
+}
+
+// openFile opens the active log for appending
+func (w *Writer) openFile() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.file, w.size, w.started = f, info.Size(), w.now()
+	return nil
+}
+
+// rename moves the active log to a timestamped name and returns it
+func (w *Writer) rename() (string, error) {
+	stamp := w.now().Format(stampLayout)
+	name := w.path + "." + stamp
+	for i := 1; exists(name) || exists(name+".gz"); i++ {
+		name = fmt.Sprintf("%s.%s-%d", w.path, stamp, i)
+	}
+	return name, os.Rename(w.path, name)
+}
+
+// cleanup compresses a just-rotated log when the policy says to, then removes
+// rotated logs the policy no longer keeps
+func (w *Writer) cleanup(rotated string) {
+	w.mu.Lock()
+	policy := w.policy
+	w.mu.Unlock()
+
+	if rotated != "" && policy.Compress {
+		compress(rotated) // left uncompressed on failure; it is still read back
+	}
+	prune(w.path, policy, w.now())
+}
+
+// compress gzips a rotated log, replacing it once the gzipped copy is complete
+func compress(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+".gz")
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// prune removes rotated logs last written before the retention period and
+// the oldest beyond MaxFiles
+func prune(path string, policy Policy, now time.Time) {
+	rotated, err := Rotated(path)
+	if err != nil {
+		return
+	}
+	for i, name := range rotated {
+		remove := policy.MaxFiles > 0 && len(rotated)-i > policy.MaxFiles
+		if !remove && policy.Retention > 0 {
+			info, err := os.Stat(name)
+			remove = err == nil && now.Sub(info.ModTime()) > policy.Retention
+		}
+		if remove {
+			os.Remove(name)
+		}
+	}
+}
+
+// exists reports whether a file is at path
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,168 @@
+package logfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock steps a writer's time forward by hand
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func openWithClock(t *testing.T, path string, policy Policy, clock *fakeClock) *Writer {
+	t.Helper()
+	w, err := Open(path, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.now = clock.now
+	w.started = clock.t
+	t.Cleanup(func() { w.Close() })
+	return w
+}
+
+func TestRotatesOnSizeAndReadsBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	clock := &fakeClock{t: time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)}
+	w := openWithClock(t, path, Policy{MaxBytes: 20, Compress: true}, clock)
+
+	for i := 1; i <= 6; i++ {
+		fmt.Fprintf(w, "line %d\n", i) // 7 bytes each, so two fit before a rotation
+		clock.t = clock.t.Add(time.Second)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := Rotated(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rotated) != 2 {
+		t.Fatalf("expected 2 rotated logs, got %v", rotated)
+	}
+	for _, name := range rotated {
+		if !strings.HasSuffix(name, ".gz") {
+			t.Errorf("expected %s compressed", name)
+		}
+	}
+
+	r, err := NewReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	all, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "line 1\nline 2\nline 3\nline 4\nline 5\nline 6\n"
+	if string(all) != want {
+		t.Errorf("reader = %q, want %q", all, want)
+	}
+
+	lines, err := Tail(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(lines, ",") != "line 4,line 5,line 6" {
+		t.Errorf("tail = %v", lines)
+	}
+	if lines, _ := Tail(path, 100); len(lines) != 6 {
+		t.Errorf("expected every line when asking for more than there are, got %v", lines)
+	}
+}
+
+func TestRotatesOnAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	clock := &fakeClock{t: time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)}
+	w := openWithClock(t, path, Policy{MaxAge: time.Hour}, clock)
+
+	fmt.Fprintln(w, "before")
+	clock.t = clock.t.Add(30 * time.Minute)
+	fmt.Fprintln(w, "still today")
+	clock.t = clock.t.Add(30 * time.Minute)
+	fmt.Fprintln(w, "after")
+	w.Close()
+
+	rotated, _ := Rotated(path)
+	if len(rotated) != 1 || rotated[0] != path+".20260102-160405" {
+		t.Fatalf("expected one uncompressed log named for the rotation time, got %v", rotated)
+	}
+	if active, _ := os.ReadFile(path); string(active) != "after\n" {
+		t.Errorf("active log = %q", active)
+	}
+}
+
+func TestPrunesByCountAndRetention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "daemon.log")
+	now := time.Now()
+	for i, age := range []time.Duration{72 * time.Hour, 48 * time.Hour, 2 * time.Hour, time.Hour} {
+		stamp := now.Add(-age).Format(stampLayout)
+		name := path + "." + stamp
+		if i == 0 {
+			name += ".gz"
+		}
+		os.WriteFile(name, []byte("old\n"), 0644)
+		os.Chtimes(name, now.Add(-age), now.Add(-age))
+	}
+	// Neither other files nor temporary compression output count as rotated logs
+	os.WriteFile(path+".lock", nil, 0644)
+	os.WriteFile(path+"."+now.Format(stampLayout)+".gz.tmp", nil, 0644)
+	os.WriteFile(filepath.Join(dir, "daemon.service.log"), nil, 0644)
+
+	w, err := Open(path, Policy{Retention: 50 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if rotated, _ := Rotated(path); len(rotated) != 3 {
+		t.Errorf("expected the log past retention removed, got %v", rotated)
+	}
+
+	w.SetPolicy(Policy{MaxFiles: 1})
+	rotated, _ := Rotated(path)
+	if len(rotated) != 1 || rotated[0] != path+"."+now.Add(-time.Hour).Format(stampLayout) {
+		t.Errorf("expected only the newest rotated log kept, got %v", rotated)
+	}
+	if _, err := os.Stat(path + ".lock"); err != nil {
+		t.Errorf("expected unrelated files left alone: %v", err)
+	}
+}
+
+func TestOpenRotatesOversizedLeftover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	os.WriteFile(path, []byte(strings.Repeat("x", 100)+"\n"), 0644)
+
+	w, err := Open(path, Policy{MaxBytes: 50, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(w, "fresh")
+	w.Close()
+
+	rotated, _ := Rotated(path)
+	if len(rotated) != 1 || !strings.HasSuffix(rotated[0], ".gz") {
+		t.Fatalf("expected the leftover log rotated and compressed, got %v", rotated)
+	}
+	if lines, _ := Tail(path, 2); len(lines) != 2 || lines[1] != "fresh" {
+		t.Errorf("tail = %v", lines)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	ts, ok := Timestamp("2026/01/02 15:04:05 Patrol: all quiet")
+	if !ok || !ts.Equal(time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)) {
+		t.Errorf("Timestamp = %v, %v", ts, ok)
+	}
+	if _, ok := Timestamp("panic: boom"); ok {
+		t.Error("expected no timestamp on an unprefixed line")
+	}
+}
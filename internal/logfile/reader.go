@@ -0,0 +1,235 @@
+package logfile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rotated returns the rotated logs of the log at path, oldest first. A log
+// caught mid-compression is listed once, uncompressed.
+func Rotated(path string) ([]string, error) {
+	matches, err := filepath.Glob(globEscape(path) + ".*")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var stems []string
+	for _, m := range matches {
+		stem := strings.TrimSuffix(m, ".gz")
+		if _, _, ok := rotation(path, stem); !ok || seen[stem] {
+			continue
+		}
+		seen[stem] = true
+		stems = append(stems, stem)
+	}
+	sort.Slice(stems, func(i, j int) bool {
+		stampI, nI, _ := rotation(path, stems[i])
+		stampJ, nJ, _ := rotation(path, stems[j])
+		if stampI != stampJ {
+			return stampI < stampJ
+		}
+		return nI < nJ
+	})
+
+	names := make([]string, 0, len(stems))
+	for _, stem := range stems {
+		if exists(stem) {
+			names = append(names, stem)
+		} else {
+			names = append(names, stem+".gz")
+		}
+	}
+	return names, nil
+}
+
+// NewReader streams the log at path from its oldest rotated log through the
+// active one, decompressing as it goes. Files that disappear while reading,
+// such as a rotated log pruned meanwhile, are skipped.
+func NewReader(path string) (io.ReadCloser, error) {
+	names, err := Rotated(path)
+	if err != nil {
+		return nil, err
+	}
+	return &reader{names: append(names, path)}, nil
+}
+
+// Tail returns the last n lines of the log at path, reaching back into
+// rotated logs when the active one has fewer
+func Tail(path string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	names, err := Rotated(path)
+	if err != nil {
+		return nil, err
+	}
+	names = append(names, path)
+
+	var lines []string
+	for i := len(names) - 1; i >= 0 && len(lines) < n; i-- {
+		rc, err := openLog(names[i])
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fileLines, err := lastLines(rc, n-len(lines))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		lines = append(fileLines, lines...)
+	}
+	return lines, nil
+}
+
+// Timestamp parses the standard log prefix of a daemon log line
+func Timestamp(line string) (time.Time, bool) {
+	if len(line) < 19 {
+		return time.Time{}, false
+	}
+	ts, err := time.ParseInLocation("2006/01/02 15:04:05", line[:19], time.Local)
+	return ts, err == nil
+}
+
+// reader reads a sequence of logs one after another
+type reader struct {
+	names []string
+	cur   io.ReadCloser
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for {
+		if r.cur == nil {
+			if len(r.names) == 0 {
+				return 0, io.EOF
+			}
+			rc, err := openLog(r.names[0])
+			r.names = r.names[1:]
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			r.cur = rc
+		}
+		n, err := r.cur.Read(p)
+		if err == io.EOF {
+			r.cur.Close()
+			r.cur = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (r *reader) Close() error {
+	r.names = nil
+	if r.cur != nil {
+		err := r.cur.Close()
+		r.cur = nil
+		return err
+	}
+	return nil
+}
+
+// OpenFile opens one log, active or rotated, decompressing it when it is
+// gzipped
+func OpenFile(name string) (io.ReadCloser, error) {
+	return openLog(name)
+}
+
+// openLog opens a log, decompressing it when it is gzipped
+func openLog(name string) (io.ReadCloser, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(name, ".gz") {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return gzipFile{zr, f}, nil
+}
+
+// gzipFile closes both the decompressor and the file under it
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// lastLines returns the last n lines read from r
+func lastLines(r io.Reader, n int) ([]string, error) {
+	ring := make([]string, 0, n)
+	start := 0
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(ring) < n {
+			ring = append(ring, scanner.Text())
+			continue
+		}
+		ring[start] = scanner.Text()
+		start = (start + 1) % n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return append(ring[start:], ring[:start]...), nil
+}
+
+// rotation splits a rotated copy of the log at path into its timestamp and
+// the -N counter added for same-second rotations, reporting false for any
+// other file
+func rotation(path, name string) (string, int, bool) {
+	suffix, ok := strings.CutPrefix(name, path+".")
+	if !ok || len(suffix) < len(stampLayout) {
+		return "", 0, false
+	}
+	stamp, rest := suffix[:len(stampLayout)], suffix[len(stampLayout):]
+	if _, err := time.Parse(stampLayout, stamp); err != nil {
+		return "", 0, false
+	}
+	if rest == "" {
+		return stamp, 0, true
+	}
+	digits, ok := strings.CutPrefix(rest, "-")
+	n, err := strconv.Atoi(digits)
+	if !ok || err != nil || n < 1 {
+		return "", 0, false
+	}
+	return stamp, n, true
+}
+
+// globEscape escapes glob metacharacters in a path
+func globEscape(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/gabe/mob/internal/abort"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/registry"
+)
+
+func handleAbortBead(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	beadID, _ := args["id"].(string)
+	reason, _ := args["reason"].(string)
+	block, _ := args["block"].(bool)
+
+	if beadID == "" {
+		return "", fmt.Errorf("id is required")
+	}
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+
+	// Only the underboss, or the agent on the bead calling off its own
+	// work, can stop it
+	caller := callerAgentName("")
+	bead, err := ctx.BeadStore.Get(beadID)
+	if err != nil {
+		return "", err
+	}
+	if caller != "underboss" && caller != bead.Assignee {
+		return "", errkind.New(errkind.Invalid, fmt.Sprintf("bead %s is assigned to %q; only its assignee or the underboss can abort it", beadID, bead.Assignee))
+	}
+
+	result, err := abort.Bead(ctx.BeadStore, ctx.Registry, filepath.Join(ctx.MobDir, ".mob", "soldati"), beadID, abort.Options{
+		Reason: reason,
+		Block:  block,
+		Actor:  caller,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var stopped string
+	switch {
+	case result.Signalled:
+		stopped = fmt.Sprintf("Told the daemon to stop %s.", result.Agent)
+	case result.Killed:
+		stopped = fmt.Sprintf("Killed %s's in-flight work.", result.Agent)
+	case result.Agent != "":
+		stopped = fmt.Sprintf("%s had nothing in flight.", result.Agent)
+	default:
+		stopped = "Nobody was working on it."
+	}
+
+	return fmt.Sprintf("Bead %s called off. %s Bead is now %s.", beadID, stopped, result.Status), nil
+}
+
+// wasAborted reports whether abort_bead stopped an agent on purpose
+func wasAborted(reg *registry.Registry, agentID string) bool {
+	record, err := reg.Get(agentID)
+	return err == nil && record.Status == registry.StatusAborted
+}
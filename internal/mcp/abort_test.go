@@ -0,0 +1,41 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestAbortBeadRestrictedToAssigneeAndUnderboss(t *testing.T) {
+	ctx := checklistContext(t)
+	bead, err := ctx.BeadStore.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("MOB_AGENT_NAME", "sal")
+	if _, err := handleAbortBead(ctx, map[string]interface{}{"id": bead.ID}); err == nil || !strings.Contains(err.Error(), "only its assignee or the underboss") {
+		t.Fatalf("abort by another soldati = %v, want refused", err)
+	}
+	if got, _ := ctx.BeadStore.Get(bead.ID); got.Status != models.BeadStatusInProgress || got.Assignee != "vinnie" {
+		t.Fatalf("refused abort changed the bead to %s/%s", got.Status, got.Assignee)
+	}
+
+	t.Setenv("MOB_AGENT_NAME", "vinnie")
+	if _, err := handleAbortBead(ctx, map[string]interface{}{"id": bead.ID, "reason": "wrong approach"}); err != nil {
+		t.Fatalf("abort by the assignee: %v", err)
+	}
+	if got, _ := ctx.BeadStore.Get(bead.ID); got.Status != models.BeadStatusOpen {
+		t.Errorf("bead status = %s, want open after its assignee aborted it", got.Status)
+	}
+
+	other, err := ctx.BeadStore.Create(&models.Bead{Title: "Add search", Status: models.BeadStatusInProgress, Assignee: "sal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MOB_AGENT_NAME", "")
+	if _, err := handleAbortBead(ctx, map[string]interface{}{"id": other.ID}); err != nil {
+		t.Fatalf("abort by the underboss: %v", err)
+	}
+}
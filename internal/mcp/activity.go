@@ -0,0 +1,38 @@
+package mcp
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// recordActivity appends an entry to the mob activity feed. The feed is best
+// effort, so failures are only logged.
+func recordActivity(ctx *ToolContext, a models.Activity) {
+	if ctx.MobDir == "" {
+		return
+	}
+	feed, err := storage.NewActivityStore(storage.ActivityDir(ctx.MobDir))
+	if err == nil {
+		err = feed.Record(&a)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to record activity: %v", err)
+	}
+}
+
+// recordReport mirrors an agent report to the activity feed
+func recordReport(ctx *ToolContext, r *models.AgentReport) {
+	who := r.AgentName
+	if who == "" {
+		who = "agent"
+	}
+	recordActivity(ctx, models.Activity{
+		Type:    models.ActivityReport,
+		Actor:   who,
+		BeadID:  r.BeadID,
+		Message: fmt.Sprintf("%s reported %s: %s", who, r.Type, r.Message),
+	})
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/mockclaude"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// The spawner runs `<test binary> mock-claude` when MOB_CLAUDE=mock, so the
+// test binary answers as claude for associates spawned in tests
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == "mock-claude" {
+		if err := mockclaude.Run(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "mock-claude: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestSpawnAssociatePostsWorkSummary(t *testing.T) {
+	ctx := checklistContext(t)
+	script := filepath.Join(ctx.MobDir, "script.json")
+	data, err := json.Marshal(mockclaude.Script{Turns: []mockclaude.Turn{{
+		Text: "Added the login form.\n\n- Didn't get to rate limiting",
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(agent.ClaudeEnv, "mock")
+	t.Setenv(mockclaude.ScriptEnv, script)
+
+	var wg sync.WaitGroup
+	ctx.Context = context.Background()
+	ctx.Registry = registry.New(registry.DefaultPath(ctx.MobDir))
+	ctx.Spawner = agent.NewSpawner()
+	ctx.TaskWg = &wg
+	bead, err := ctx.BeadStore.Create(&models.Bead{Title: "Add login", Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := handleSpawnAssociate(ctx, map[string]interface{}{"turf": "api", "task": "Add the login form", "work_dir": ctx.MobDir, "bead_id": bead.ID}); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	got, err := ctx.BeadStore.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary *models.BeadEvent
+	for i, e := range got.History {
+		if e.Type == models.BeadEventTypeComment && strings.HasPrefix(e.Comment, "Work summary from associate ") {
+			summary = &got.History[i]
+		}
+	}
+	if summary == nil {
+		t.Fatalf("expected a work summary comment, got %+v", got.History)
+	}
+	for _, want := range []string{
+		"What changed:\nAdded the login form.",
+		"Tests run:\n- none",
+		"Remaining concerns:\n- Didn't get to rate limiting",
+	} {
+		if !strings.Contains(summary.Comment, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary.Comment)
+		}
+	}
+	if !strings.HasPrefix(summary.Comment, "Work summary from "+summary.Actor) {
+		t.Errorf("summary by %q should name its author: %s", summary.Actor, summary.Comment)
+	}
+	if got.Status != models.BeadStatusClosed {
+		t.Errorf("bead status = %s, want closed by the associate", got.Status)
+	}
+}
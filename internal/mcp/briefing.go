@@ -0,0 +1,26 @@
+package mcp
+
+import (
+	"github.com/gabe/mob/internal/briefing"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// turfBriefing builds the onboarding briefing for a new agent on a turf.
+// Conventions are skipped because spawned agents get them in their system prompt.
+func turfBriefing(ctx *ToolContext, turfName string) string {
+	if ctx.TurfManager == nil || turfName == "" {
+		return ""
+	}
+	t, err := ctx.TurfManager.Get(turfName)
+	if err != nil {
+		return ""
+	}
+
+	var beads []*models.Bead
+	if ctx.BeadStore != nil {
+		beads, _ = ctx.BeadStore.List(storage.BeadFilter{Turf: turfName})
+	}
+
+	return briefing.Build(turfName, t.Path, beads, briefing.Options{SkipConventions: true})
+}
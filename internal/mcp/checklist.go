@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// stringArgs extracts a list of strings from a JSON array argument
+func stringArgs(args map[string]interface{}, key string) []string {
+	raw, ok := args[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// intArgs extracts a list of integers from a JSON array argument
+func intArgs(args map[string]interface{}, key string) []int {
+	raw, ok := args[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]int, 0, len(raw))
+	for _, v := range raw {
+		if n, ok := v.(float64); ok {
+			values = append(values, int(n))
+		}
+	}
+	return values
+}
+
+// checkChecklist refuses completion while acceptance criteria are open,
+// unless an override reason is given, which is recorded on the bead. It
+// returns the bead as it should be used for the rest of completion.
+func checkChecklist(ctx *ToolContext, bead *models.Bead, override string) (*models.Bead, error) {
+	unchecked := bead.UncheckedItems()
+	if len(unchecked) == 0 {
+		return bead, nil
+	}
+
+	if strings.TrimSpace(override) == "" {
+		var items []string
+		for _, item := range unchecked {
+			items = append(items, "- "+item.Text)
+		}
+		return nil, fmt.Errorf("bead %s has %d unchecked checklist item(s):\n%s\nCheck them off with update_checklist, or pass override_checklist with a reason",
+			bead.ID, len(unchecked), strings.Join(items, "\n"))
+	}
+
+	comment := fmt.Sprintf("Completed with %d unchecked checklist item(s). Override reason: %s", len(unchecked), override)
+	if err := ctx.BeadStore.AddComment(bead.ID, callerAgentName(""), comment); err != nil {
+		return nil, fmt.Errorf("failed to record checklist override: %w", err)
+	}
+
+	// Reload so later updates keep the override comment in history
+	return ctx.BeadStore.Get(bead.ID)
+}
+
+func handleUpdateChecklist(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	id, _ := args["id"].(string)
+	action, _ := args["action"].(string)
+
+	if id == "" {
+		return "", fmt.Errorf("id is required")
+	}
+
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+
+	bead, err := ctx.BeadStore.Get(id)
+	if err != nil {
+		return "", fmt.Errorf("bead not found: %w", err)
+	}
+
+	switch action {
+	case "add":
+		items := stringArgs(args, "items")
+		if len(items) == 0 {
+			return "", fmt.Errorf("items is required for add")
+		}
+		bead.AddChecklistItems(items...)
+	case "check", "uncheck":
+		if err := bead.SetChecklistDone(intArgs(args, "positions"), action == "check"); err != nil {
+			return "", err
+		}
+	case "remove":
+		if err := bead.RemoveChecklistItems(intArgs(args, "positions")); err != nil {
+			return "", err
+		}
+	case "":
+		// No change requested; just show the checklist
+	default:
+		return "", fmt.Errorf("unknown action %q (expected add, check, uncheck, or remove)", action)
+	}
+
+	if action != "" {
+		if _, err := ctx.BeadStore.Update(bead); err != nil {
+			return "", fmt.Errorf("failed to update checklist: %w", err)
+		}
+	}
+
+	done := len(bead.Checklist) - len(bead.UncheckedItems())
+	return fmt.Sprintf("Checklist for %s (%d/%d done):\n%s", bead.ID, done, len(bead.Checklist), bead.FormatChecklist()), nil
+}
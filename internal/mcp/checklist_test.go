@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// checklistContext is a tool context over a fresh bead store
+func checklistContext(t *testing.T) *ToolContext {
+	t.Helper()
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ToolContext{Context: context.Background(), BeadStore: store, MobDir: t.TempDir()}
+}
+
+// checklistBead creates an in-progress bead with one open and one done item
+func checklistBead(t *testing.T, ctx *ToolContext) *models.Bead {
+	t.Helper()
+	bead, err := ctx.BeadStore.Create(&models.Bead{Title: "Add login", Status: models.BeadStatusInProgress, Checklist: []models.ChecklistItem{
+		{Text: "Handles bad passwords", Done: true},
+		{Text: "Rate limits attempts"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bead
+}
+
+func TestCheckChecklist(t *testing.T) {
+	ctx := checklistContext(t)
+	bead := checklistBead(t, ctx)
+
+	_, err := handleCompleteBead(ctx, map[string]interface{}{"id": bead.ID})
+	if err == nil || !strings.Contains(err.Error(), "Rate limits attempts") || strings.Contains(err.Error(), "Handles bad passwords") {
+		t.Fatalf("expected completion refused over the open item, got %v", err)
+	}
+	if got, _ := ctx.BeadStore.Get(bead.ID); got.Status != models.BeadStatusInProgress {
+		t.Fatalf("refused bead status = %s, want in_progress", got.Status)
+	}
+
+	// A blank override is no override
+	if _, err := checkChecklist(ctx, bead, "  "); err == nil {
+		t.Fatal("expected a blank override refused")
+	}
+
+	if _, err := handleCompleteBead(ctx, map[string]interface{}{"id": bead.ID, "override_checklist": "rate limiting is bd-later"}); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	got, _ := ctx.BeadStore.Get(bead.ID)
+	if got.Status != models.BeadStatusClosed {
+		t.Errorf("overridden bead status = %s, want closed", got.Status)
+	}
+	if !hasComment(got, "Override reason: rate limiting is bd-later") {
+		t.Errorf("expected the override recorded, got %+v", got.History)
+	}
+
+	// Every item checked needs no override
+	done, err := ctx.BeadStore.Create(&models.Bead{Title: "Done", Status: models.BeadStatusInProgress, Checklist: []models.ChecklistItem{{Text: "Works", Done: true}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checked, err := checkChecklist(ctx, done, ""); err != nil || checked != done {
+		t.Errorf("checked list: %v", err)
+	}
+}
+
+func TestFinishAssociateBeadAppliesGates(t *testing.T) {
+	ctx := checklistContext(t)
+	resp := &agent.ChatResponse{TotalCost: 0.25, Blocks: []agent.ChatContentBlock{{Type: agent.ContentTypeText, Text: "Added the login form."}}}
+
+	// Open acceptance criteria hold the bead back, blocked with the reason
+	held := checklistBead(t, ctx)
+	finishAssociateBead(ctx, held.ID, "quick-fox", "quick-fox (associate)", resp)
+	got, _ := ctx.BeadStore.Get(held.ID)
+	if got.Status != models.BeadStatusBlocked || got.ClosedAt != nil {
+		t.Fatalf("held bead status = %s, want blocked and not closed", got.Status)
+	}
+	if !hasComment(got, "Not completed: bead "+held.ID+" has 1 unchecked checklist item") {
+		t.Errorf("expected the gate's reason on the bead, got %+v", got.History)
+	}
+	if got.CostUSD != 0.25 {
+		t.Errorf("cost = %v, want the associate's 0.25 recorded", got.CostUSD)
+	}
+
+	// A bead that passes the gates closes
+	clean, err := ctx.BeadStore.Create(&models.Bead{Title: "Fix typo", Status: models.BeadStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	finishAssociateBead(ctx, clean.ID, "quick-fox", "quick-fox (associate)", resp)
+	got, _ = ctx.BeadStore.Get(clean.ID)
+	if got.Status != models.BeadStatusClosed || got.CloseReason != "completed by associate quick-fox" {
+		t.Errorf("clean bead = %s (%q), want closed by the associate", got.Status, got.CloseReason)
+	}
+	if !hasComment(got, "Added the login form.") {
+		t.Errorf("expected the work summary posted, got %+v", got.History)
+	}
+}
+
+// hasComment reports whether a comment on the bead contains text
+func hasComment(b *models.Bead, text string) bool {
+	for _, e := range b.History {
+		if e.Type == models.BeadEventTypeComment && strings.Contains(e.Comment, text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/soldati"
+)
+
+// List formats accepted by the list tools
+const (
+	ListFormatCompact = "compact" // a header naming the fields, then one pipe-separated line per item
+	ListFormatFull    = "full"    // the original multi-line prose
+)
+
+// Default columns for compact listings, chosen to be what agents need to
+// pick their next step without a follow-up get_bead
+var (
+	defaultBeadFields  = []string{"id", "priority", "type", "status", "assignee", "turf", "title"}
+	defaultAgentFields = []string{"name", "type", "status", "turf", "task"}
+)
+
+// Every selectable field, sorted, for schemas and error messages
+var (
+	beadFieldNames  = slices.Sorted(maps.Keys(beadFields(textLimit{})))
+	agentFieldNames = slices.Sorted(maps.Keys(agentFields(nil, textLimit{})))
+)
+
+// beadFields renders each selectable bead field; desc caps descriptions
+func beadFields(desc textLimit) map[string]func(*models.Bead) string {
+	return map[string]func(*models.Bead) string{
+		"id":          func(b *models.Bead) string { return b.ID },
+		"title":       func(b *models.Bead) string { return b.Title },
+		"status":      func(b *models.Bead) string { return string(b.Status) },
+		"priority":    func(b *models.Bead) string { return fmt.Sprintf("P%d", b.Priority) },
+		"type":        func(b *models.Bead) string { return string(b.Type) },
+		"turf":        func(b *models.Bead) string { return b.Turf },
+		"assignee":    func(b *models.Bead) string { return b.Assignee },
+		"labels":      func(b *models.Bead) string { return strings.Join(b.Labels, ",") },
+		"description": func(b *models.Bead) string { return desc.cutFor(b.ID, oneLine(b.Description)) },
+		"parent":      func(b *models.Bead) string { return b.ParentID },
+		"blocks":      func(b *models.Bead) string { return strings.Join(b.Blocks, ",") },
+		"branch":      func(b *models.Bead) string { return b.Branch },
+		"created":     func(b *models.Bead) string { return b.CreatedAt.Format(time.RFC3339) },
+		"updated":     func(b *models.Bead) string { return b.UpdatedAt.Format(time.RFC3339) },
+	}
+}
+
+// agentFields renders each selectable agent field; task caps tasks and
+// soldatiMgr may be nil
+func agentFields(soldatiMgr *soldati.Manager, task textLimit) map[string]func(*registry.AgentRecord) string {
+	return map[string]func(*registry.AgentRecord) string{
+		"id":        func(a *registry.AgentRecord) string { return a.ID },
+		"name":      func(a *registry.AgentRecord) string { return a.Label() },
+		"type":      func(a *registry.AgentRecord) string { return a.Type },
+		"status":    func(a *registry.AgentRecord) string { return string(a.Status) },
+		"turf":      func(a *registry.AgentRecord) string { return a.Turf },
+		"task":      func(a *registry.AgentRecord) string { return task.cutFor(a.BeadID, oneLine(a.Task)) },
+		"tag":       func(a *registry.AgentRecord) string { return a.Tag },
+		"bead":      func(a *registry.AgentRecord) string { return a.BeadID },
+		"last_seen": func(a *registry.AgentRecord) string { return a.LastPing.Format(time.RFC3339) },
+		"turfs": func(a *registry.AgentRecord) string {
+			if a.Type != "soldati" || soldatiMgr == nil || a.Name == "" {
+				return ""
+			}
+			if s, err := soldatiMgr.Get(a.Name); err == nil {
+				return strings.Join(s.Turfs, ",")
+			}
+			return ""
+		},
+	}
+}
+
+// Schema properties shared by the list tools
+var (
+	listFormatProperty = map[string]interface{}{
+		"type":        "string",
+		"description": "compact (default): a field header then one line per item; full: multi-line descriptions",
+		"enum":        []string{ListFormatCompact, ListFormatFull},
+	}
+	listLimitProperty = map[string]interface{}{
+		"type":        "integer",
+		"description": "Maximum number of items to return (0 = all)",
+	}
+)
+
+// listFieldsProperty describes the fields parameter of a list tool
+func listFieldsProperty(defaults, available []string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"items":       map[string]interface{}{"type": "string"},
+		"description": fmt.Sprintf("Fields to include in compact output, in order. Default: %s. Available: %s", strings.Join(defaults, ", "), strings.Join(available, ", ")),
+	}
+}
+
+// listOptions are the output controls shared by the list tools
+type listOptions struct {
+	format string
+	fields []string
+	limit  int       // 0 = no limit
+	text   textLimit // caps descriptions and tasks
+}
+
+// parseListOptions reads format, fields and limit from tool args, falling
+// back to the [mcp] config defaults. valid names the selectable fields. The
+// caller sets the text limit, which differs between tools.
+func parseListOptions(args map[string]interface{}, cfg config.MCPServerConfig, defaultFields []string, valid []string) (listOptions, error) {
+	opts := listOptions{format: cfg.ListFormat, limit: cfg.ListLimit}
+	if opts.format == "" {
+		opts.format = ListFormatCompact
+	}
+
+	if format, ok := args["format"].(string); ok && format != "" {
+		opts.format = format
+	}
+	if opts.format != ListFormatCompact && opts.format != ListFormatFull {
+		return opts, fmt.Errorf("format must be %q or %q", ListFormatCompact, ListFormatFull)
+	}
+	if limit, ok := args["limit"].(float64); ok {
+		opts.limit = int(limit)
+	}
+
+	switch fields := args["fields"].(type) {
+	case []interface{}:
+		for _, f := range fields {
+			if s, ok := f.(string); ok {
+				opts.fields = append(opts.fields, strings.TrimSpace(s))
+			}
+		}
+	case string:
+		for _, f := range strings.Split(fields, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				opts.fields = append(opts.fields, f)
+			}
+		}
+	}
+	if len(opts.fields) == 0 {
+		opts.fields = defaultFields
+	}
+	for _, f := range opts.fields {
+		if !slices.Contains(valid, f) {
+			return opts, fmt.Errorf("unknown field %q (available: %s)", f, strings.Join(valid, ", "))
+		}
+	}
+	return opts, nil
+}
+
+// compactTable renders rows as a field header and one pipe-separated line
+// per row. Empty values become "-" and pipes inside values are escaped so
+// every line splits cleanly.
+func compactTable(noun string, total int, fields []string, rows [][]string) string {
+	var sb strings.Builder
+	if len(rows) < total {
+		fmt.Fprintf(&sb, "%s: %d of %d (raise limit for more)\n", noun, len(rows), total)
+	} else {
+		fmt.Fprintf(&sb, "%s: %d\n", noun, total)
+	}
+	sb.WriteString(strings.Join(fields, "|"))
+	for _, row := range rows {
+		sb.WriteString("\n")
+		for i, v := range row {
+			if i > 0 {
+				sb.WriteString("|")
+			}
+			if v == "" {
+				v = "-"
+			}
+			sb.WriteString(strings.ReplaceAll(v, "|", "/"))
+		}
+	}
+	return sb.String()
+}
+
+// compactBeads renders beads with the selected fields
+func compactBeads(noun string, beads []*models.Bead, opts listOptions) string {
+	total := len(beads)
+	if opts.limit > 0 && len(beads) > opts.limit {
+		beads = beads[:opts.limit]
+	}
+	render := beadFields(opts.text)
+	rows := make([][]string, 0, len(beads))
+	for _, b := range beads {
+		row := make([]string, len(opts.fields))
+		for i, f := range opts.fields {
+			row[i] = render[f](b)
+		}
+		rows = append(rows, row)
+	}
+	return compactTable(noun, total, opts.fields, rows)
+}
+
+// compactAgents renders agents with the selected fields
+func compactAgents(agents []*registry.AgentRecord, opts listOptions, soldatiMgr *soldati.Manager) string {
+	total := len(agents)
+	if opts.limit > 0 && len(agents) > opts.limit {
+		agents = agents[:opts.limit]
+	}
+	render := agentFields(soldatiMgr, opts.text)
+	rows := make([][]string, 0, len(agents))
+	for _, a := range agents {
+		row := make([]string, len(opts.fields))
+		for i, f := range opts.fields {
+			row[i] = render[f](a)
+		}
+		rows = append(rows, row)
+	}
+	return compactTable("agents", total, opts.fields, rows)
+}
+
+// descriptionLength is how much of a description list tools keep by default
+func descriptionLength(cfg config.MCPServerConfig) int {
+	if cfg.DescriptionLength <= 0 {
+		return 80
+	}
+	return cfg.DescriptionLength
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestListBeads_Compact(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []*models.Bead{
+		{Title: "Fix login | SSO", Status: models.BeadStatusOpen, Priority: 1, Type: models.BeadTypeBug, Turf: "api",
+			Description: "Users on SSO are bounced\nback to the login page after the token refresh."},
+		{Title: "Write docs", Status: models.BeadStatusOpen, Priority: 3, Type: models.BeadTypeTask},
+		{Title: "Old work", Status: models.BeadStatusClosed, Priority: 2, Type: models.BeadTypeTask},
+	} {
+		if _, err := store.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := &ToolContext{BeadStore: store, MobDir: dir}
+
+	out, err := handleListBeads(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(out, "\n")
+	if lines[0] != "beads: 2" || lines[1] != "id|priority|type|status|assignee|turf|title" {
+		t.Fatalf("unexpected header:\n%s", out)
+	}
+	if len(lines) != 4 || !strings.HasSuffix(lines[2], "|P1|bug|open|-|api|Fix login / SSO") {
+		t.Errorf("expected closed bead skipped and one line per bead:\n%s", out)
+	}
+
+	out, err = handleListBeads(ctx, map[string]interface{}{
+		"fields": []interface{}{"title", "description"},
+		"limit":  float64(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "beads: 1 of 2 (raise limit for more)\ntitle|description\nFix login / SSO|Users on SSO are bounced back to the login page after the token refresh."
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+
+	if _, err := handleListBeads(ctx, map[string]interface{}{"fields": "id,nope"}); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+
+	out, err = handleListBeads(ctx, map[string]interface{}{"format": "full", "include_closed": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "The job board (3 items)") {
+		t.Errorf("expected full format with closed beads:\n%s", out)
+	}
+}
+
+func TestListAgents_Compact(t *testing.T) {
+	dir := t.TempDir()
+	reg := registry.New(registry.DefaultPath(dir))
+	if err := reg.Register(&registry.AgentRecord{ID: "a1", Type: "associate", Name: "assoc-crimson-fox", Tag: "lint", Status: "working", Turf: "api", Task: "Fix the\nlinter"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ToolContext{Registry: reg, MobDir: dir}
+
+	out, err := handleListAgents(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "agents: 1\nname|type|status|turf|task\nassoc-crimson-fox [lint]|associate|working|api|Fix the linter"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/approval"
+)
+
+// confirmWait caps how long a mutating call waits for the user's answer
+const confirmWait = 10 * time.Minute
+
+// mutation describes a tool that changes mob state, for the confirmation
+// prompt: its verb and noun, and the arguments that say what it would touch
+type mutation struct {
+	verb, noun, nouns string
+	detail            []string
+}
+
+// mutations lists the built-in tools held for approval in confirm mode.
+// Reads, reports and messages to agents run straight away. Plugin tools are
+// always held, since mob cannot tell what they do.
+var mutations = map[string]mutation{
+	"spawn_soldati":             {"spawn", "soldato", "soldati", []string{"name", "turf"}},
+	"spawn_associate":           {"spawn", "associate", "associates", []string{"turf", "task"}},
+	"kill_agent":                {"kill", "agent", "agents", []string{"name", "id"}},
+	"assign_bead":               {"assign", "bead", "beads", []string{"bead_id", "agent_name", "agent_id", "description"}},
+	"create_bead":               {"create", "bead", "beads", []string{"title"}},
+	"create_bead_from_template": {"create", "bead from a template", "beads from templates", []string{"template", "title"}},
+	"file_followup":             {"file", "follow-up", "follow-ups", []string{"title"}},
+	"update_bead":               {"update", "bead", "beads", []string{"id", "status", "title"}},
+	"complete_bead":             {"complete", "bead", "beads", []string{"id"}},
+	"abort_bead":                {"abort", "bead", "beads", []string{"id"}},
+	"review_bead":               {"review", "bead", "beads", []string{"id", "decision"}},
+	"update_conventions":        {"update", "conventions file", "conventions files", []string{"turf"}},
+}
+
+// SetConfirm holds mutating tool calls until the user approves them through
+// store, for the underboss serving an interactive chat. Call it before Run.
+func (s *Server) SetConfirm(store *approval.Store) {
+	s.approvals = store
+}
+
+// confirmCall asks the user to approve a mutating call and reports why it
+// may not run. Calls that change nothing pass straight through.
+func (s *Server) confirmCall(req *jsonRPCRequest) error {
+	if s.approvals == nil {
+		return nil
+	}
+	var params toolCallParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil // handleToolsCall reports it
+	}
+	tool, ok := s.tools[params.Name]
+	if !ok {
+		return nil
+	}
+	r := describeMutation(tool, params.Arguments)
+	if r == nil {
+		return nil
+	}
+
+	err := s.approvals.Ask(s.ctx, r, confirmWait)
+	if err != nil {
+		what := r.Verb + " " + r.Noun
+		if r.Detail != "" {
+			what += " (" + r.Detail + ")"
+		}
+		return fmt.Errorf("%s: %w; do not retry it, ask the user how to proceed", what, err)
+	}
+	return nil
+}
+
+// describeMutation returns the confirmation request for a call, or nil when
+// the tool changes nothing
+func describeMutation(tool *Tool, args map[string]interface{}) *approval.Request {
+	m, ok := mutations[tool.Name]
+	if !ok {
+		if _, builtin := builtinTools[tool.Name]; builtin {
+			return nil
+		}
+		m = mutation{verb: "run", noun: tool.Name + " call", nouns: tool.Name + " calls"}
+	}
+
+	var parts []string
+	for _, key := range m.detail {
+		if v, ok := args[key].(string); ok && strings.TrimSpace(v) != "" {
+			parts = append(parts, truncate(strings.TrimSpace(v), 80))
+		}
+	}
+	return &approval.Request{
+		Tool:   tool.Name,
+		Verb:   m.verb,
+		Noun:   m.noun,
+		Nouns:  m.nouns,
+		Detail: strings.Join(parts, ", "),
+	}
+}
+
+// builtinTools names the tools mob itself provides
+var builtinTools = func() map[string]bool {
+	names := make(map[string]bool)
+	for _, t := range GetTools() {
+		names[t.Name] = true
+	}
+	return names
+}()
@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/merge"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestFinishMergeAttachesConflict(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Rework config", Type: models.BeadTypeTask, Status: models.BeadStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &merge.MergeResult{
+		BeadID:        bead.ID,
+		Message:       "merge conflict detected",
+		ConflictFiles: []string{"config.go"},
+		Conflict: &merge.ConflictReport{
+			Into:   "main",
+			Branch: "mob/" + bead.ID,
+			Base:   "0123456789abcdef",
+			Files:  []merge.ConflictFile{{Path: "config.go", Hunks: []string{"<<<<<<< HEAD\na\n||||||| base\n=======\nb\n>>>>>>> mob/x"}}},
+		},
+	}
+	ctx := &ToolContext{BeadStore: store, MobDir: dir}
+	msg, err := FinishMerge(ctx, bead, "", result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := store.Get(bead.ID)
+	if got.Status != models.BeadStatusBlocked {
+		t.Errorf("status = %s, want blocked", got.Status)
+	}
+	if len(got.Attachments) != 1 {
+		t.Fatalf("expected one attachment, got %+v", got.Attachments)
+	}
+	path := got.Attachments[0].Path
+	if !strings.Contains(msg, path) || !strings.Contains(got.CloseReason, "config.go (1 hunk)") || !strings.Contains(got.CloseReason, path) {
+		t.Errorf("block reason and reply should summarise and point at the attachment:\n%s\n%s", got.CloseReason, msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "### Hunk 1") {
+		t.Errorf("attachment not written: %v\n%s", err, data)
+	}
+}
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/turf"
+)
+
+// systemPrompt renders the named agent prompt for a spawn, filling in the
+// turf's conventions
+func systemPrompt(ctx *ToolContext, name string, vars agent.PromptVars) string {
+	vars.Conventions = turfConventions(ctx, vars.Turf)
+	prompt, err := agent.SystemPrompt(ctx.MobDir, name, vars)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return prompt
+}
+
+// turfConventions returns a turf's conventions document, "" when it has none
+func turfConventions(ctx *ToolContext, turfName string) string {
+	if ctx.TurfManager == nil || turfName == "" {
+		return ""
+	}
+	t, err := ctx.TurfManager.Get(turfName)
+	if err != nil {
+		return ""
+	}
+	conventions, err := turf.LoadConventions(t.Path)
+	if err != nil {
+		log.Printf("Warning: failed to load conventions for turf %s: %v", turfName, err)
+		return ""
+	}
+	return conventions
+}
+
+func handleUpdateConventions(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	turfName, _ := args["turf"].(string)
+	content, _ := args["content"].(string)
+	mode, _ := args["mode"].(string)
+
+	if turfName == "" {
+		return "", fmt.Errorf("turf is required")
+	}
+
+	if ctx.TurfManager == nil {
+		return "", fmt.Errorf("turf manager not available")
+	}
+
+	t, err := ctx.TurfManager.Get(turfName)
+	if err != nil {
+		return "", fmt.Errorf("turf not found: %w", err)
+	}
+
+	// Without content, just show what's there
+	if content == "" {
+		conventions, err := turf.LoadConventions(t.Path)
+		if err != nil {
+			return "", err
+		}
+		if conventions == "" {
+			return fmt.Sprintf("No conventions recorded for turf %s yet.", turfName), nil
+		}
+		return conventions, nil
+	}
+
+	switch mode {
+	case "", "append":
+		err = turf.AppendConventions(t.Path, content)
+	case "replace":
+		err = turf.SaveConventions(t.Path, content)
+	default:
+		return "", fmt.Errorf("unknown mode %q (expected append or replace)", mode)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Conventions for turf %s updated (%s). New agents on this turf will see them.", turfName, turf.ConventionsPath(t.Path)), nil
+}
@@ -0,0 +1,51 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+// dueAtProperty and slaProperty set when a bead has to be closed by
+var (
+	dueAtProperty = map[string]interface{}{
+		"type":        "string",
+		"description": "When the bead must be closed by: a date (2006-01-02), an RFC 3339 time, or a period from now like 2d. \"none\" clears it",
+	}
+	slaProperty = map[string]interface{}{
+		"type":        "string",
+		"description": "How long after creation the bead must be closed by, e.g. 4h or 3d. \"none\" clears it",
+	}
+)
+
+// applyDueArgs sets a bead's due date and SLA from tool arguments. Changing
+// either lets the daemon escalate the bead again if it misses the new one.
+func applyDueArgs(bead *models.Bead, args map[string]interface{}) error {
+	changed := false
+	if s, ok := args["due_at"].(string); ok && s != "" {
+		bead.DueAt = nil
+		if s != "none" {
+			due, err := models.ParseDue(s, time.Now())
+			if err != nil {
+				return errkind.New(errkind.Invalid, err.Error())
+			}
+			bead.DueAt = &due
+		}
+		changed = true
+	}
+	if s, ok := args["sla"].(string); ok && s != "" {
+		if s == "none" {
+			s = ""
+		}
+		if _, err := models.ParseSLA(s); err != nil {
+			return errkind.New(errkind.Invalid, err.Error())
+		}
+		bead.SLA = s
+		changed = true
+	}
+	if changed {
+		bead.SLABreachedAt = nil
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// handleGetEpicProgress rolls an epic's children up so the underboss can see
+// what is left, what is stuck and what is due next
+func handleGetEpicProgress(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	id, _ := args["id"].(string)
+	if id == "" {
+		return "", fmt.Errorf("id is required")
+	}
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+
+	p, err := ctx.BeadStore.EpicProgress(id, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return formatEpicProgress(p), nil
+}
+
+// formatEpicProgress describes an epic's roll-up and lists its children
+func formatEpicProgress(p *models.EpicProgress) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Epic %s '%s': %d of %d children closed (%d%%).\n", p.Epic.ID, p.Epic.Title, p.Closed(), p.Total(), p.Percent())
+	if p.Total() == 0 {
+		b.WriteString("It has no child beads yet; split it to plan the work.")
+		return b.String()
+	}
+
+	var counts []string
+	for _, status := range models.StatusOrder {
+		if n := p.ByStatus[status]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	fmt.Fprintf(&b, "By status: %s\n", strings.Join(counts, ", "))
+	if p.EarliestDue != nil {
+		fmt.Fprintf(&b, "Next due: %s (%s)\n", p.EarliestDue.Format(time.RFC3339), p.EarliestDueID)
+	}
+	if p.Overdue > 0 {
+		fmt.Fprintf(&b, "Overdue: %d\n", p.Overdue)
+	}
+
+	b.WriteString("Children:\n")
+	for _, c := range p.Children {
+		fmt.Fprintf(&b, "- %s [%s] P%d %s", c.ID, c.Status, c.Priority, c.Title)
+		if c.Assignee != "" {
+			fmt.Fprintf(&b, " (%s)", c.Assignee)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
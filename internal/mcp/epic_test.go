@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestHandleGetEpicProgress(t *testing.T) {
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	epic, err := store.Create(&models.Bead{Title: "Billing", Type: models.BeadTypeEpic, Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, child := range []*models.Bead{
+		{Title: "Invoices", Status: models.BeadStatusClosed, ParentID: epic.ID},
+		{Title: "Refunds", Status: models.BeadStatusInProgress, Assignee: "vinnie", ParentID: epic.ID},
+		{Title: "Taxes", Status: models.BeadStatusOpen, ParentID: epic.ID},
+	} {
+		if _, err := store.Create(child); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := &ToolContext{Context: context.Background(), BeadStore: store}
+
+	out, err := handleGetEpicProgress(ctx, map[string]interface{}{"id": epic.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"1 of 3 children closed (33%)", "1 open, 1 in_progress, 1 closed", "Refunds (vinnie)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	task, _ := store.Create(&models.Bead{Title: "Just a task", Type: models.BeadTypeTask, Status: models.BeadStatusOpen})
+	if _, err := handleGetEpicProgress(ctx, map[string]interface{}{"id": task.ID}); !errors.Is(err, storage.ErrNotEpic) {
+		t.Errorf("expected ErrNotEpic for a task, got %v", err)
+	}
+}
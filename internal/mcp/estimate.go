@@ -0,0 +1,191 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/router"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// handleEstimateTask sizes a bead with a cheap model before costlier work is
+// committed to it, and records the estimate on the bead
+func handleEstimateTask(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	id, _ := args["id"].(string)
+	if id == "" {
+		return "", fmt.Errorf("id is required")
+	}
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+	if ctx.Spawner == nil {
+		return "", fmt.Errorf("spawner not available")
+	}
+
+	bead, err := ctx.BeadStore.Get(id)
+	if err != nil {
+		return "", fmt.Errorf("bead not found: %w", err)
+	}
+
+	cfg := loadConfig(ctx.MobDir).Routing
+	history, _ := ctx.BeadStore.List(storage.BeadFilter{Status: models.BeadStatusClosed})
+	model := router.New(cfg).Route(bead, history)
+
+	preflightModel, _ := args["model"].(string)
+	if preflightModel == "" {
+		preflightModel = cfg.PreflightModel
+	}
+	if preflightModel == "" {
+		preflightModel = "haiku"
+	}
+
+	workDir := ctx.MobDir
+	if ctx.TurfManager != nil && bead.Turf != "" {
+		if t, err := ctx.TurfManager.Get(bead.Turf); err == nil {
+			workDir = t.Path
+		}
+	}
+
+	estimator, err := ctx.Spawner.SpawnWithOptions(agent.SpawnOptions{
+		Type:         agent.AgentTypeAssociate,
+		Name:         "estimator-" + bead.ID,
+		Turf:         bead.Turf,
+		WorkDir:      workDir,
+		SystemPrompt: systemPrompt(ctx, agent.PromptEstimator, agent.PromptVars{Name: "estimator-" + bead.ID, Turf: bead.Turf, BeadID: bead.ID}),
+		Model:        preflightModel,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to spawn estimator: %w", err)
+	}
+	defer ctx.Spawner.Kill(estimator.ID)
+
+	callCtx := ctx.Context
+	if callCtx == nil {
+		callCtx = context.Background()
+	}
+	resp, err := estimator.ChatStreamContext(callCtx, estimatePrompt(bead, model), nil)
+	if err != nil {
+		return "", fmt.Errorf("estimator failed: %w", err)
+	}
+
+	estimate, err := parseEstimate(resp.GetText())
+	if err != nil {
+		return "", err
+	}
+	estimate.At = time.Now()
+	estimate.Model = model
+	estimate.EstimatedBy = preflightModel
+	estimate.PreflightUSD = resp.TotalCost
+
+	// Reload so the estimate lands on the latest version of the bead
+	bead, err = ctx.BeadStore.Get(id)
+	if err != nil {
+		return "", err
+	}
+	bead.Estimate = estimate
+	if _, err := ctx.BeadStore.Update(bead); err != nil {
+		return "", fmt.Errorf("failed to record estimate: %w", err)
+	}
+	log.Printf("Estimated bead %s: %s, $%.2f on %s", bead.ID, estimate.Scope, estimate.CostUSD, model)
+
+	return formatEstimate(bead, estimate, history), nil
+}
+
+// estimatePrompt is the task given to the estimator
+func estimatePrompt(bead *models.Bead, model string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Estimate this task. It will be worked on by the %s model.\n\n", model)
+	fmt.Fprintf(&b, "Title: %s\nType: %s\nPriority: P%d\n", bead.Title, bead.Type, bead.Priority)
+	if bead.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", bead.Description)
+	}
+	if len(bead.Checklist) > 0 {
+		b.WriteString("\nAcceptance criteria:\n")
+		for _, item := range bead.Checklist {
+			fmt.Fprintf(&b, "- %s\n", item.Text)
+		}
+	}
+	return b.String()
+}
+
+// estimateAnswer is the JSON object the estimator ends its answer with
+type estimateAnswer struct {
+	Scope   string   `json:"scope"`
+	Files   []string `json:"files"`
+	CostUSD float64  `json:"estimated_cost_usd"`
+	Notes   string   `json:"notes"`
+}
+
+// parseEstimate reads the last JSON object in the estimator's answer
+func parseEstimate(text string) (*models.Estimate, error) {
+	for end := strings.LastIndex(text, "}"); end >= 0; end = strings.LastIndex(text[:end], "}") {
+		for start := strings.LastIndex(text[:end], "{"); start >= 0; start = strings.LastIndex(text[:start], "{") {
+			var answer estimateAnswer
+			if err := json.Unmarshal([]byte(text[start:end+1]), &answer); err != nil || answer.Scope == "" {
+				continue
+			}
+			scope := strings.ToLower(answer.Scope)
+			switch scope {
+			case models.EstimateScopeSmall, models.EstimateScopeMedium, models.EstimateScopeLarge:
+			default:
+				return nil, fmt.Errorf("estimator gave an unknown scope %q", answer.Scope)
+			}
+			if answer.CostUSD < 0 {
+				answer.CostUSD = 0
+			}
+			return &models.Estimate{
+				Scope:   scope,
+				Files:   answer.Files,
+				CostUSD: answer.CostUSD,
+				Notes:   strings.TrimSpace(answer.Notes),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("estimator did not return an estimate")
+}
+
+// formatEstimate describes an estimate, alongside what similar past beads cost
+func formatEstimate(bead *models.Bead, e *models.Estimate, history []*models.Bead) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Estimate for '%s' (%s): %s scope, about $%.2f on %s.\n", bead.Title, bead.ID, e.Scope, e.CostUSD, e.Model)
+	if avg, n := router.AverageCost(history, bead.Type, e.Model); n > 0 {
+		fmt.Fprintf(&b, "Past %s beads on %s averaged $%.2f (%d bead(s)).\n", bead.Type, e.Model, avg, n)
+	}
+	if len(e.Files) > 0 {
+		fmt.Fprintf(&b, "Likely files: %s\n", strings.Join(e.Files, ", "))
+	}
+	if e.Notes != "" {
+		fmt.Fprintf(&b, "Notes: %s\n", e.Notes)
+	}
+	fmt.Fprintf(&b, "Preflight by %s cost $%.4f. The estimate is recorded on the bead.", e.EstimatedBy, e.PreflightUSD)
+	return b.String()
+}
+
+// timeEstimateProperty sets how long a bead's work should take
+var timeEstimateProperty = map[string]interface{}{
+	"type":        "string",
+	"description": "How long the work should take, e.g. 90m, 4h or 2d. \"none\" clears it",
+}
+
+// applyTimeEstimateArg sets a bead's time estimate from tool arguments
+func applyTimeEstimateArg(bead *models.Bead, args map[string]interface{}) error {
+	s, ok := args["time_estimate"].(string)
+	if !ok || s == "" {
+		return nil
+	}
+	if s == "none" {
+		s = ""
+	}
+	if _, err := models.ParseTimeEstimate(s); err != nil {
+		return errkind.New(errkind.Invalid, err.Error())
+	}
+	bead.TimeEstimate = s
+	return nil
+}
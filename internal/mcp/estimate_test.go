@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestParseEstimate(t *testing.T) {
+	text := "I looked at the handlers. Example: {\"scope\": \"huge\"} is not valid.\n\n" +
+		`{"scope": "Medium", "files": ["internal/api/user.go", "internal/api/user_test.go"], "estimated_cost_usd": 0.75, "notes": "Touches the auth middleware."}`
+	e, err := parseEstimate(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Scope != models.EstimateScopeMedium || len(e.Files) != 2 || e.CostUSD != 0.75 || e.Notes != "Touches the auth middleware." {
+		t.Errorf("unexpected estimate: %+v", e)
+	}
+
+	if _, err := parseEstimate("Looks small, maybe fifty cents."); err == nil {
+		t.Error("expected an error for an answer without JSON")
+	}
+	if _, err := parseEstimate(`{"scope": "huge", "estimated_cost_usd": 1}`); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+}
+
+func TestHandleEstimateTask(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Add endpoint", Type: models.BeadTypeTask})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answer := `{"type":"assistant","message":{"content":[{"type":"text","text":"{\"scope\": \"small\", \"files\": [\"api.go\"], \"estimated_cost_usd\": 0.4}"}]}}
+{"type":"result","total_cost_usd":0.002}`
+	var gotArgs []string
+	spawner := agent.NewSpawner()
+	spawner.SetCommandCreator(func(name string, args ...string) *exec.Cmd {
+		gotArgs = args
+		return exec.Command("sh", "-c", "cat >/dev/null; printf '%s\\n' \"$0\"", answer)
+	})
+	defer spawner.KillAll()
+
+	ctx := &ToolContext{Context: context.Background(), BeadStore: store, Spawner: spawner, MobDir: dir}
+	out, err := handleEstimateTask(ctx, map[string]interface{}{"id": bead.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "small scope") || !strings.Contains(out, "$0.40") {
+		t.Errorf("unexpected result: %s", out)
+	}
+	if !strings.Contains(strings.Join(gotArgs, " "), "--model haiku") {
+		t.Errorf("expected the preflight model, got args %v", gotArgs)
+	}
+	if spawner.Count() != 0 {
+		t.Error("expected the estimator to be released")
+	}
+
+	updated, err := store.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := updated.Estimate
+	if e == nil || e.Scope != models.EstimateScopeSmall || e.CostUSD != 0.4 || e.Model != "sonnet" || e.EstimatedBy != "haiku" || e.PreflightUSD != 0.002 {
+		t.Fatalf("unexpected recorded estimate: %+v", e)
+	}
+}
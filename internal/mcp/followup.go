@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// followupLabel marks beads filed by agents as out-of-scope discoveries
+const followupLabel = "followup"
+
+func handleFileFollowup(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	beadID, _ := args["bead_id"].(string)
+	title, _ := args["title"].(string)
+	description, _ := args["description"].(string)
+
+	if beadID == "" {
+		return "", fmt.Errorf("bead_id is required")
+	}
+	if title == "" {
+		return "", fmt.Errorf("title is required")
+	}
+
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+
+	parent, err := ctx.BeadStore.Get(beadID)
+	if err != nil {
+		return "", fmt.Errorf("bead not found: %w", err)
+	}
+
+	agentName := callerAgentName("")
+
+	followup := &models.Bead{
+		Title:          title,
+		Description:    description,
+		Status:         models.BeadStatusOpen,
+		Type:           models.BeadTypeTask,
+		Priority:       3, // Follow-ups default to low priority until triaged
+		Turf:           parent.Turf,
+		Labels:         []string{followupLabel},
+		CreatedBy:      agentName,
+		DiscoveredFrom: parent.ID,
+		Related:        []string{parent.ID},
+	}
+	if beadType, ok := args["type"].(string); ok && beadType != "" {
+		followup.Type = models.BeadType(beadType)
+	}
+	if priority, ok := args["priority"].(float64); ok {
+		followup.Priority = int(priority)
+	}
+	if labels, ok := labelsArg(args); ok {
+		followup.AddLabels(labels...)
+	}
+	if followup.Description == "" {
+		followup.Description = title
+	}
+
+	created, err := ctx.BeadStore.Create(followup)
+	if err != nil {
+		return "", fmt.Errorf("failed to create follow-up: %w", err)
+	}
+
+	comment := fmt.Sprintf("Filed follow-up %s: %s", created.ID, created.Title)
+	if err := ctx.BeadStore.AddComment(parent.ID, agentName, comment); err != nil {
+		return "", fmt.Errorf("follow-up %s created but failed to link it on %s: %w", created.ID, parent.ID, err)
+	}
+
+	return fmt.Sprintf("Filed follow-up %s (discovered from %s, P%d %s): %s\nKeep working on %s - this one goes on the board for later.",
+		created.ID, parent.ID, created.Priority, created.Type, created.Title, parent.ID), nil
+}
@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/git"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+// ErrTurfNotReady is returned when a bead's turf checkout fails the
+// pre-assignment repo health check
+var ErrTurfNotReady = errkind.New(errkind.Transient, "turf checkout is not ready for new work")
+
+// healthActor signs the bead comments left by the health check
+const healthActor = "mob"
+
+// CheckTurfHealth runs the pre-assignment repo health check for a bead's
+// turf: its main checkout must be clean, on the main branch and recently
+// fetched. When it isn't, the bead gets a comment saying what to fix (once
+// per distinct problem) and ErrTurfNotReady is returned. Beads without a
+// turf, turfs that aren't git repos, and configs with the check disabled
+// all pass.
+func CheckTurfHealth(ctx *ToolContext, bead *models.Bead) error {
+	if bead.Turf == "" || ctx.TurfManager == nil {
+		return nil
+	}
+	cfg := loadConfig(ctx.MobDir)
+	if !cfg.Safety.RepoHealthCheck {
+		return nil
+	}
+	turfInfo, err := ctx.TurfManager.Get(bead.Turf)
+	if err != nil {
+		return nil
+	}
+	// The checks are git-specific; jj keeps no dirty or detached state to check
+	if vcs.Resolve(turfInfo.Path, turfInfo.VCS) != vcs.KindGit {
+		return nil
+	}
+
+	maxAge, err := config.ParseRetention(cfg.Safety.FetchMaxAge)
+	if err != nil {
+		log.Printf("Warning: invalid safety.fetch_max_age %q: %v", cfg.Safety.FetchMaxAge, err)
+		maxAge = 0
+	}
+	health, err := git.CheckHealth(turfInfo.Path, git.HealthOptions{
+		MainBranch:  turfInfo.MainBranch,
+		FetchMaxAge: maxAge,
+	})
+	if err != nil {
+		log.Printf("Warning: repo health check skipped for turf %s: %v", bead.Turf, err)
+		return nil
+	}
+	if health.OK() {
+		return nil
+	}
+
+	comment := fmt.Sprintf("Assignment held: the %s checkout at %s is not ready: %s. "+
+		"Commit or stash local changes, check out %s and make sure origin is reachable; "+
+		"the bead is picked up again once the checkout is healthy.",
+		bead.Turf, turfInfo.Path, health, health.MainBranch)
+	if ctx.BeadStore != nil && lastHealthComment(bead) != comment {
+		if err := ctx.BeadStore.AddComment(bead.ID, healthActor, comment); err != nil {
+			log.Printf("Warning: failed to comment on bead %s: %v", bead.ID, err)
+		}
+	}
+	return fmt.Errorf("%w: %s: %s", ErrTurfNotReady, bead.Turf, health)
+}
+
+// lastHealthComment returns the most recent health check comment on a bead
+func lastHealthComment(bead *models.Bead) string {
+	for i := len(bead.History) - 1; i >= 0; i-- {
+		e := bead.History[i]
+		if e.Type == models.BeadEventTypeComment && e.Actor == healthActor && strings.HasPrefix(e.Comment, "Assignment held:") {
+			return e.Comment
+		}
+	}
+	return ""
+}
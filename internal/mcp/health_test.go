@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+)
+
+func TestCheckTurfHealth(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("git", "add", "main.go")
+	cmd.Dir = repo
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	turfMgr, err := turf.NewManager(filepath.Join(dir, "turfs.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := turfMgr.Add(repo, "api", "main"); err != nil {
+		t.Fatal(err)
+	}
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Add endpoint", Status: models.BeadStatusOpen, Type: models.BeadTypeTask, Turf: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ToolContext{BeadStore: store, TurfManager: turfMgr, MobDir: dir}
+
+	// A staged, uncommitted file holds the bead, with one comment however often it's checked
+	for i := 0; i < 2; i++ {
+		if bead, err = store.Get(bead.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := CheckTurfHealth(ctx, bead); !errors.Is(err, ErrTurfNotReady) {
+			t.Fatalf("check %d: expected ErrTurfNotReady, got %v", i+1, err)
+		}
+	}
+	bead, _ = store.Get(bead.ID)
+	comments := 0
+	for _, e := range bead.History {
+		if e.Type == models.BeadEventTypeComment {
+			comments++
+		}
+	}
+	if comments != 1 {
+		t.Errorf("expected one hold comment, got %d", comments)
+	}
+
+	cmd = exec.Command("git", "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "add main")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("commit: %v\n%s", err, out)
+	}
+	if err := CheckTurfHealth(ctx, bead); err != nil {
+		t.Errorf("expected clean checkout to pass, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// maxHumanInputWait caps how long one call may block waiting for an answer
+const maxHumanInputWait = 5 * time.Minute
+
+// humanInputPollInterval is how often a waiting call rechecks for an answer
+var humanInputPollInterval = 2 * time.Second
+
+func handleRequestHumanInput(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	question, _ := args["question"].(string)
+	beadID, _ := args["bead_id"].(string)
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return "", fmt.Errorf("question is required")
+	}
+
+	var options []string
+	if raw, ok := args["options"].([]interface{}); ok {
+		for _, o := range raw {
+			if s, ok := o.(string); ok && strings.TrimSpace(s) != "" {
+				options = append(options, strings.TrimSpace(s))
+			}
+		}
+	}
+
+	if beadID != "" && ctx.BeadStore != nil {
+		if _, err := ctx.BeadStore.Get(beadID); err != nil {
+			return "", err
+		}
+	}
+
+	reportStore, err := storage.NewReportStore(filepath.Join(ctx.MobDir, ".mob", "reports"))
+	if err != nil {
+		return "", fmt.Errorf("failed to create report store: %w", err)
+	}
+
+	agentName := os.Getenv("MOB_AGENT_NAME")
+	report, err := reportStore.Create(&models.AgentReport{
+		AgentID:   os.Getenv("MOB_AGENT_ID"),
+		AgentName: agentName,
+		BeadID:    beadID,
+		Type:      models.ReportTypeHumanInput,
+		Message:   question,
+		Options:   options,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to file request: %w", err)
+	}
+	recordReport(ctx, report)
+
+	if beadID != "" && ctx.BeadStore != nil {
+		actor := agentName
+		if actor == "" {
+			actor = "agent"
+		}
+		comment := fmt.Sprintf("Asked a human (%s): %s", report.ID, question)
+		if len(options) > 0 {
+			comment += fmt.Sprintf(" [options: %s]", strings.Join(options, " / "))
+		}
+		if err := ctx.BeadStore.AddComment(beadID, actor, comment); err != nil {
+			log.Printf("Warning: failed to comment on bead %s: %v", beadID, err)
+		}
+	}
+
+	report, err = awaitHumanInput(ctx, reportStore, report.ID, waitArg(args))
+	if err != nil {
+		return "", err
+	}
+	return formatHumanInput(report), nil
+}
+
+func handleGetHumanInput(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	id, _ := args["id"].(string)
+	if id == "" {
+		return "", fmt.Errorf("id is required")
+	}
+
+	reportStore, err := storage.NewReportStore(filepath.Join(ctx.MobDir, ".mob", "reports"))
+	if err != nil {
+		return "", fmt.Errorf("failed to create report store: %w", err)
+	}
+
+	report, err := awaitHumanInput(ctx, reportStore, id, waitArg(args))
+	if err != nil {
+		return "", err
+	}
+	if report.Type != models.ReportTypeHumanInput {
+		return "", fmt.Errorf("%s is a %s report, not a human input request", id, report.Type)
+	}
+	return formatHumanInput(report), nil
+}
+
+// waitArg reads wait_seconds, clamped to maxHumanInputWait
+func waitArg(args map[string]interface{}) time.Duration {
+	secs, _ := args["wait_seconds"].(float64)
+	wait := time.Duration(secs) * time.Second
+	if wait < 0 {
+		return 0
+	}
+	if wait > maxHumanInputWait {
+		return maxHumanInputWait
+	}
+	return wait
+}
+
+// awaitHumanInput returns the request once it is answered, the wait runs
+// out or the call is cancelled, whichever comes first
+func awaitHumanInput(ctx *ToolContext, store *storage.ReportStore, id string, wait time.Duration) (*models.AgentReport, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		report, err := store.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if report.Answered() || !time.Now().Before(deadline) {
+			return report, nil
+		}
+
+		var done <-chan struct{}
+		if ctx.Context != nil {
+			done = ctx.Context.Done()
+		}
+		select {
+		case <-done:
+			return report, nil
+		case <-time.After(min(humanInputPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+func formatHumanInput(r *models.AgentReport) string {
+	if r.Answered() {
+		by := r.AnsweredBy
+		if by == "" {
+			by = "the operator"
+		}
+		return fmt.Sprintf("Answer from %s to %s: %s", by, r.ID, r.Answer)
+	}
+	return fmt.Sprintf("Question %s is waiting on the operator. Carry on with anything that doesn't depend on it, "+
+		"then call get_human_input with id %q (and wait_seconds) to collect the answer.", r.ID, r.ID)
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestHumanInputRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("MOB_AGENT_NAME", "vinnie")
+	t.Setenv("MOB_AGENT_ID", "")
+
+	beadStore, err := storage.NewBeadStore(filepath.Join(dir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := beadStore.Create(&models.Bead{Title: "Pick a schema", Type: models.BeadTypeTask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ToolContext{Context: context.Background(), BeadStore: beadStore, MobDir: dir}
+
+	out, err := handleRequestHumanInput(ctx, map[string]interface{}{
+		"question": "Postgres or SQLite?",
+		"bead_id":  bead.ID,
+		"options":  []interface{}{"postgres", "sqlite"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "get_human_input") {
+		t.Fatalf("unanswered request should point at get_human_input, got %q", out)
+	}
+
+	reportStore, err := storage.NewReportStore(filepath.Join(dir, ".mob", "reports"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports, err := reportStore.List(storage.ReportFilter{Type: models.ReportTypeHumanInput})
+	if err != nil || len(reports) != 1 {
+		t.Fatalf("expected one human input report, got %d (%v)", len(reports), err)
+	}
+	r := reports[0]
+	if r.AgentName != "vinnie" || r.BeadID != bead.ID || len(r.Options) != 2 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+
+	got, _ := beadStore.Get(bead.ID)
+	commented := false
+	for _, e := range got.History {
+		if e.Type == models.BeadEventTypeComment && strings.Contains(e.Comment, r.ID) {
+			commented = true
+		}
+	}
+	if !commented {
+		t.Fatalf("expected a bead comment referencing %s, got %+v", r.ID, got.History)
+	}
+
+	// An answer arriving mid-wait is returned without waiting out the timeout
+	oldPoll := humanInputPollInterval
+	humanInputPollInterval = 10 * time.Millisecond
+	defer func() { humanInputPollInterval = oldPoll }()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		reportStore.Answer(r.ID, "sqlite", "gabe")
+	}()
+	start := time.Now()
+	out, err = handleGetHumanInput(ctx, map[string]interface{}{"id": r.ID, "wait_seconds": float64(10)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "sqlite") || !strings.Contains(out, "gabe") {
+		t.Fatalf("expected the answer, got %q", out)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("get_human_input waited past the answer")
+	}
+
+	answered, _ := reportStore.Get(r.ID)
+	if !answered.Handled {
+		t.Error("answering should mark the report handled")
+	}
+	if _, err := reportStore.Answer(r.ID, "postgres", "gabe"); !errors.Is(err, storage.ErrReportAnswered) {
+		t.Errorf("second answer: got %v, want ErrReportAnswered", err)
+	}
+}
+
+func TestHumanInputValidation(t *testing.T) {
+	ctx := &ToolContext{Context: context.Background(), MobDir: t.TempDir()}
+	if _, err := handleRequestHumanInput(ctx, map[string]interface{}{"question": "  "}); err == nil {
+		t.Error("expected an error for an empty question")
+	}
+	if _, err := handleGetHumanInput(ctx, map[string]interface{}{"id": "rp-missing"}); !errors.Is(err, storage.ErrReportNotFound) {
+		t.Errorf("got %v, want ErrReportNotFound", err)
+	}
+	if got := waitArg(map[string]interface{}{"wait_seconds": float64(9999)}); got != maxHumanInputWait {
+		t.Errorf("waitArg should clamp to %v, got %v", maxHumanInputWait, got)
+	}
+}
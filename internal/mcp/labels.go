@@ -0,0 +1,34 @@
+package mcp
+
+import (
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// labelsProperty describes a labels argument
+func labelsProperty(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"items":       map[string]interface{}{"type": "string"},
+		"description": description + " (a comma-separated string works too)",
+	}
+}
+
+// labelsArg reads a labels argument given as a list or a comma-separated
+// string, normalised as models.ParseLabels does
+func labelsArg(args map[string]interface{}) ([]string, bool) {
+	switch v := args["labels"].(type) {
+	case string:
+		return models.ParseLabels(v), true
+	case []interface{}:
+		var b models.Bead
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				b.AddLabels(strings.Split(s, ",")...)
+			}
+		}
+		return b.Labels, true
+	}
+	return nil, false
+}
@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/gabe/mob/internal/merge"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/reaper"
+)
+
+// queueMerge hands a finished bead to the daemon's merge scheduler. The bead
+// stays open until the daemon merges its branch and closes it.
+func queueMerge(ctx *ToolContext, bead *models.Bead, turfInfo *models.Turf, closeReason string) (string, error) {
+	req := merge.Request{
+		BeadID:      bead.ID,
+		Branch:      bead.Branch,
+		Turf:        bead.Turf,
+		RepoPath:    turfInfo.Path,
+		VCS:         turfInfo.VCS,
+		BlockedBy:   bead.Blocks,
+		CloseReason: closeReason,
+		RequestedAt: time.Now(),
+	}
+	if err := merge.AppendRequest(merge.RequestsPath(ctx.MobDir), req); err != nil {
+		return "", fmt.Errorf("failed to queue merge: %w", err)
+	}
+
+	if err := ctx.BeadStore.AddComment(bead.ID, "system", fmt.Sprintf("Work complete; queued to merge %s into %s", bead.Branch, bead.Turf)); err != nil {
+		return "", fmt.Errorf("failed to record merge request: %w", err)
+	}
+
+	ahead := 0
+	if depths, err := merge.LoadDepths(merge.DepthsPath(ctx.MobDir)); err == nil {
+		ahead = depths[bead.Turf]
+	}
+
+	return fmt.Sprintf("Job '%s' is queued for merge into turf '%s' (%d ahead of it). The bead closes once the branch merges.", bead.Title, bead.Turf, ahead), nil
+}
+
+// daemonRunning reports whether the mob daemon is alive to drain merge requests
+func daemonRunning(mobDir string) bool {
+	pid, identity, err := reaper.ReadPIDFile(filepath.Join(mobDir, ".mob", "daemon.pid"))
+	if err != nil {
+		return false
+	}
+	return reaper.Alive(pid, identity)
+}
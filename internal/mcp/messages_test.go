@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadMessagesReadsOnlyTheCallersInbox(t *testing.T) {
+	ctx := checklistContext(t)
+	t.Setenv("MOB_AGENT_NAME", "underboss")
+	if _, err := handleSendMessageToAgent(ctx, map[string]interface{}{"to": "sal", "message": "Rebase onto main"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// vinnie asking for sal's inbox still reads its own
+	t.Setenv("MOB_AGENT_NAME", "vinnie")
+	out, err := handleReadMessages(ctx, map[string]interface{}{"agent": "sal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "Rebase onto main") || !strings.Contains(out, "No new messages for vinnie") {
+		t.Errorf("vinnie read %q, want only its own empty inbox", out)
+	}
+
+	t.Setenv("MOB_AGENT_NAME", "sal")
+	out, err = handleReadMessages(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Rebase onto main") {
+		t.Errorf("sal read %q, want its unread message", out)
+	}
+}
+
+func TestSendMessageComesFromTheCaller(t *testing.T) {
+	ctx := checklistContext(t)
+	t.Setenv("MOB_AGENT_NAME", "vinnie")
+	if _, err := handleSendMessageToAgent(ctx, map[string]interface{}{"to": "sal", "message": "Ship it", "from": "underboss"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("MOB_AGENT_NAME", "sal")
+	out, err := handleReadMessages(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "vinnie") || strings.Contains(out, "underboss") {
+		t.Errorf("sal read %q, want the message from vinnie, not the underboss it claimed", out)
+	}
+}
@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/plugin"
+)
+
+// AddPlugins registers the tools and notification backends plugins provide.
+// A tool whose name is already taken, by a built-in or an earlier plugin, is
+// skipped and reported.
+func (s *Server) AddPlugins(plugins []*plugin.Plugin) []error {
+	var errs []error
+	for _, p := range plugins {
+		for _, spec := range p.Tools {
+			if _, taken := s.tools[spec.Name]; taken {
+				errs = append(errs, fmt.Errorf("plugin %s: tool %q is already registered, skipping", p.Name, spec.Name))
+				continue
+			}
+			s.tools[spec.Name] = pluginTool(p, spec)
+		}
+	}
+
+	for _, n := range plugin.Notifiers(plugins) {
+		s.AddNotifier(n)
+	}
+	return errs
+}
+
+// AddNotifier registers a notification backend for the tools that notify,
+// such as the dashboard's notification feed. Call it before Run.
+func (s *Server) AddNotifier(n notify.Notifier) {
+	if s.notifier == nil {
+		s.notifier = notify.NewManager()
+	}
+	s.notifier.Add(notify.Redacted(n, s.redactor))
+}
+
+// pluginTool wraps a tool declared in a plugin manifest
+func pluginTool(p *plugin.Plugin, spec plugin.ToolSpec) *Tool {
+	schema := spec.InputSchema
+	if schema == nil {
+		schema = map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		}
+	}
+	description := spec.Description
+	if description == "" {
+		description = fmt.Sprintf("Provided by the %s plugin.", p.Name)
+	}
+
+	return &Tool{
+		Name:        spec.Name,
+		Description: description,
+		InputSchema: schema,
+		Handler: func(ctx *ToolContext, args map[string]interface{}) (string, error) {
+			resp, err := p.Call(ctx.Context, plugin.Request{Type: plugin.CallTool, Tool: spec.Name, Arguments: args})
+			if err != nil {
+				return "", err
+			}
+			return resp.Result, nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket limiting how fast one connection can call tools
+type rateLimiter struct {
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+	mu     sync.Mutex
+}
+
+// newRateLimiter returns a limiter allowing rate calls per second with bursts
+// of up to burst calls. A non-positive rate returns nil, which never limits.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Allow takes a token if one is available. When none is, it reports how long
+// until the next one.
+func (l *rateLimiter) Allow() (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
@@ -0,0 +1,276 @@
+package mcp
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/router"
+)
+
+// Review gate modes
+const (
+	ReviewGateAssociate = "associate" // a reviewer associate inspects the diff
+	ReviewGateHuman     = "human"     // a human approves via the CLI
+)
+
+// reviewGateMode returns the configured review gate for soldati work, or "" when disabled
+func reviewGateMode(mobDir string) string {
+	switch mode := strings.ToLower(loadConfig(mobDir).Safety.ReviewGate); mode {
+	case ReviewGateAssociate, ReviewGateHuman:
+		return mode
+	default:
+		return ""
+	}
+}
+
+// isSoldatiOwned returns true if the bead is assigned to a soldati
+func isSoldatiOwned(ctx *ToolContext, bead *models.Bead) bool {
+	if bead.Assignee == "" {
+		return false
+	}
+	if ctx.Registry != nil {
+		if record, err := ctx.Registry.GetByName(bead.Assignee); err == nil {
+			return record.Type == "soldati"
+		}
+	}
+	// Fall back to the soldati roster for agents not currently running
+	_, err := os.Stat(filepath.Join(ctx.MobDir, "soldati", bead.Assignee+".toml"))
+	return err == nil
+}
+
+// requestReview moves a bead into review instead of closing it and starts the reviewer
+func requestReview(ctx *ToolContext, bead *models.Bead, mode, closeReason string) (string, error) {
+	bead.Status = models.BeadStatusInReview
+	if _, err := ctx.BeadStore.Update(bead); err != nil {
+		return "", fmt.Errorf("failed to move bead to review: %w", err)
+	}
+
+	note := "Ready for review"
+	if closeReason != "" {
+		note += ": " + closeReason
+	}
+	if err := ctx.BeadStore.AddComment(bead.ID, bead.Assignee, note); err != nil {
+		log.Printf("Warning: failed to record review request on bead %s: %v", bead.ID, err)
+	}
+
+	if mode == ReviewGateHuman {
+		if ctx.NotifyManager != nil {
+			if err := ctx.NotifyManager.NotifyApprovalNeeded(bead.ID, bead.Title); err != nil {
+				log.Printf("Warning: failed to send review notification: %v", err)
+			}
+		}
+		return fmt.Sprintf("Job '%s' is in review. A human will approve it with 'mob approve %s' or send it back with 'mob reject %s <feedback>'.", bead.Title, bead.ID, bead.ID), nil
+	}
+
+	reviewerID, err := spawnReviewer(ctx, bead)
+	if err != nil {
+		// Leave the bead in review so a human can pick it up
+		log.Printf("Warning: failed to spawn reviewer for bead %s: %v", bead.ID, err)
+		return fmt.Sprintf("Job '%s' is in review, but no reviewer could be spawned (%v). A human must approve it with 'mob approve %s'.", bead.Title, err, bead.ID), nil
+	}
+
+	return fmt.Sprintf("Job '%s' is in review. Reviewer associate %s is inspecting the diff; you'll hear back if changes are needed.", bead.Title, reviewerID), nil
+}
+
+// spawnReviewer starts a reviewer associate on the bead's worktree in the background
+func spawnReviewer(ctx *ToolContext, bead *models.Bead) (string, error) {
+	if ctx.Spawner == nil || ctx.Registry == nil {
+		return "", fmt.Errorf("spawner not available")
+	}
+
+	workDir := bead.WorktreePath
+	mainBranch := "main"
+	if ctx.TurfManager != nil && bead.Turf != "" {
+		if t, err := ctx.TurfManager.Get(bead.Turf); err == nil {
+			if workDir == "" {
+				workDir = t.Path
+			}
+			if t.MainBranch != "" {
+				mainBranch = t.MainBranch
+			}
+		}
+	}
+	if workDir == "" {
+		workDir = ctx.MobDir
+	}
+
+	mcpConfigPath, err := GenerateTurfMCPConfig(ctx.MobDir, bead.Turf)
+	if err != nil {
+		log.Printf("Warning: failed to generate MCP config: %v", err)
+	}
+
+	reviewer, err := ctx.Spawner.SpawnWithOptions(agent.SpawnOptions{
+		Type:         agent.AgentTypeAssociate,
+		Name:         "reviewer-" + bead.ID,
+		Turf:         bead.Turf,
+		WorkDir:      workDir,
+		SystemPrompt: systemPrompt(ctx, agent.PromptReviewer, agent.PromptVars{Name: "reviewer-" + bead.ID, Turf: bead.Turf, BeadID: bead.ID}),
+		MCPConfig:    mcpConfigPath,
+		Model:        reviewerModel(ctx.MobDir),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to spawn reviewer: %w", err)
+	}
+
+	task := fmt.Sprintf("[Bead %s] Review the work on branch %s against %s, then call review_bead with id %q and reviewer %q.",
+		bead.ID, bead.Branch, mainBranch, bead.ID, reviewer.Name)
+
+	record := &registry.AgentRecord{
+		ID:        reviewer.ID,
+		Type:      "associate",
+		Name:      reviewer.Name,
+		Turf:      bead.Turf,
+		Task:      task,
+		Tag:       "review",
+		BeadID:    bead.ID,
+		Status:    registry.StatusActive,
+		StartedAt: reviewer.StartedAt,
+	}
+	if err := ctx.Registry.Register(record); err != nil {
+		return "", fmt.Errorf("failed to register reviewer: %w", err)
+	}
+
+	if ctx.TaskWg != nil {
+		ctx.TaskWg.Add(1)
+	}
+	go func(reg *registry.Registry) {
+		if ctx.TaskWg != nil {
+			defer ctx.TaskWg.Done()
+		}
+
+		reg.UpdateStatus(reviewer.ID, registry.StatusWorking)
+		if _, err := reviewer.Chat(task); err != nil {
+			log.Printf("Reviewer %s failed: %v", reviewer.ID, err)
+			reg.UpdateStatus(reviewer.ID, registry.StatusFailed)
+			return
+		}
+		reg.UpdateStatus(reviewer.ID, registry.StatusCompleted)
+	}(ctx.Registry)
+
+	return reviewer.ID, nil
+}
+
+// reviewerModel returns the model reviewer associates run on: the configured
+// review model, else the default model
+func reviewerModel(mobDir string) string {
+	cfg := loadConfig(mobDir).Routing
+	if cfg.ReviewModel != "" {
+		return cfg.ReviewModel
+	}
+	if cfg.DefaultModel != "" {
+		return cfg.DefaultModel
+	}
+	return router.FallbackDefaultModel
+}
+
+// ResolveReview records a review verdict on a bead in review. Approval merges and
+// closes the bead; requesting changes hands it back to the assigned soldati.
+func ResolveReview(ctx *ToolContext, beadID string, approve bool, reviewer, comments string) (string, error) {
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+
+	bead, err := ctx.BeadStore.Get(beadID)
+	if err != nil {
+		return "", fmt.Errorf("bead not found: %w", err)
+	}
+	if bead.Status != models.BeadStatusInReview {
+		return "", fmt.Errorf("bead %s is not in review (current status: %s)", bead.ID, bead.Status)
+	}
+	if bead.Assignee != "" && reviewer == bead.Assignee {
+		return "", errkind.New(errkind.Invalid, fmt.Sprintf("%s cannot review its own work on bead %s", reviewer, bead.ID))
+	}
+
+	verdict := "Review approved"
+	if !approve {
+		verdict = "Changes requested"
+	}
+	if comments != "" {
+		verdict += ": " + comments
+	}
+	if err := ctx.BeadStore.AddComment(bead.ID, reviewer, verdict); err != nil {
+		return "", fmt.Errorf("failed to record review: %w", err)
+	}
+
+	// Re-read so the review comment is preserved by the following update
+	bead, err = ctx.BeadStore.Get(beadID)
+	if err != nil {
+		return "", fmt.Errorf("bead not found: %w", err)
+	}
+
+	if approve {
+		return mergeAndCloseBead(ctx, bead, fmt.Sprintf("completed (reviewed by %s)", reviewer))
+	}
+
+	bead.Status = models.BeadStatusInProgress
+	if _, err := ctx.BeadStore.Update(bead); err != nil {
+		return "", fmt.Errorf("failed to update bead: %w", err)
+	}
+
+	if bead.Assignee != "" {
+		hookMgr, err := hook.NewManager(filepath.Join(ctx.MobDir, ".mob", "soldati"), bead.Assignee)
+		if err != nil {
+			return "", fmt.Errorf("failed to create hook manager: %w", err)
+		}
+		h := &hook.Hook{
+			Type:      hook.HookTypeAssign,
+			BeadID:    bead.ID,
+			Message:   fmt.Sprintf("Review of '%s' requested changes from %s: %s\nAddress the feedback, commit, and call complete_bead again.", bead.Title, reviewer, comments),
+			Timestamp: time.Now(),
+		}
+		if err := hookMgr.Write(h); err != nil {
+			return "", fmt.Errorf("failed to write hook: %w", err)
+		}
+	}
+
+	return fmt.Sprintf("Changes requested on '%s'. Sent back to %s.", bead.Title, bead.Assignee), nil
+}
+
+func handleReviewBead(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	id, _ := args["id"].(string)
+	decision, _ := args["decision"].(string)
+	comments, _ := args["comments"].(string)
+	reviewer, _ := args["reviewer"].(string)
+
+	if id == "" {
+		return "", fmt.Errorf("id is required")
+	}
+
+	var approve bool
+	switch decision {
+	case "approve":
+		approve = true
+	case "request_changes":
+		if comments == "" {
+			return "", fmt.Errorf("comments are required when requesting changes")
+		}
+	default:
+		return "", fmt.Errorf("decision must be 'approve' or 'request_changes'")
+	}
+
+	// The gate decides who may review: a human through the CLI, or the
+	// reviewer associate spawned for the bead
+	switch reviewGateMode(ctx.MobDir) {
+	case ReviewGateHuman:
+		return "", errkind.New(errkind.Invalid, fmt.Sprintf("bead %s waits on a human review; approve it with 'mob approve %s' or send it back with 'mob reject %s <feedback>'", id, id, id))
+	case ReviewGateAssociate:
+		if want := "reviewer-" + id; os.Getenv("MOB_AGENT_NAME") != want {
+			return "", errkind.New(errkind.Invalid, fmt.Sprintf("only the reviewer associate %s can review bead %s", want, id))
+		}
+	}
+
+	// An agent reviews as itself; only the underboss names the reviewer
+	if self := os.Getenv("MOB_AGENT_NAME"); self != "" {
+		reviewer = self
+	}
+	return ResolveReview(ctx, id, approve, callerAgentName(reviewer), comments)
+}
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/hook"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestReviewGateRequestRejectApprove(t *testing.T) {
+	ctx := checklistContext(t)
+	bead, err := ctx.BeadStore.Create(&models.Bead{Title: "Add login", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := requestReview(ctx, bead, ReviewGateHuman, "login works"); err != nil {
+		t.Fatalf("requestReview: %v", err)
+	}
+	got, _ := ctx.BeadStore.Get(bead.ID)
+	if got.Status != models.BeadStatusInReview || !hasComment(got, "Ready for review: login works") {
+		t.Fatalf("requested bead = %s, want in review with the request noted", got.Status)
+	}
+
+	// Changes requested go back to the soldati on its hook
+	if _, err := ResolveReview(ctx, bead.ID, false, "reviewer-1", "add rate limiting"); err != nil {
+		t.Fatalf("request changes: %v", err)
+	}
+	got, _ = ctx.BeadStore.Get(bead.ID)
+	if got.Status != models.BeadStatusInProgress || !hasComment(got, "Changes requested: add rate limiting") {
+		t.Errorf("rejected bead = %s, want back in progress with the feedback", got.Status)
+	}
+	mgr, err := hook.NewManager(filepath.Join(ctx.MobDir, ".mob", "soldati"), "vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, _ := mgr.Read(); h == nil || h.BeadID != bead.ID || !strings.Contains(h.Message, "add rate limiting") {
+		t.Errorf("vinnie's hook = %+v, want the feedback assigned", h)
+	}
+
+	if _, err := requestReview(ctx, got, ReviewGateHuman, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResolveReview(ctx, bead.ID, true, "human", ""); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	got, _ = ctx.BeadStore.Get(bead.ID)
+	if got.Status != models.BeadStatusClosed || got.CloseReason != "completed (reviewed by human)" {
+		t.Errorf("approved bead = %s (%q), want closed by the review", got.Status, got.CloseReason)
+	}
+}
+
+func TestReviewGateRefusesSelfReview(t *testing.T) {
+	ctx := checklistContext(t)
+	bead, err := ctx.BeadStore.Create(&models.Bead{Title: "Add login", Status: models.BeadStatusInReview, Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ResolveReview(ctx, bead.ID, true, "vinnie", ""); err == nil {
+		t.Fatal("expected vinnie refused as reviewer of its own bead")
+	}
+
+	// Naming another reviewer does not get the assignee past the check
+	t.Setenv("MOB_AGENT_NAME", "vinnie")
+	args := map[string]interface{}{"id": bead.ID, "decision": "approve", "reviewer": "sal"}
+	if _, err := handleReviewBead(ctx, args); err == nil || !strings.Contains(err.Error(), "own work") {
+		t.Fatalf("review_bead by the assignee = %v, want refused", err)
+	}
+	if got, _ := ctx.BeadStore.Get(bead.ID); got.Status != models.BeadStatusInReview {
+		t.Errorf("self-reviewed bead = %s, want still in review", got.Status)
+	}
+
+	t.Setenv("MOB_AGENT_NAME", "reviewer-"+bead.ID)
+	if _, err := handleReviewBead(ctx, args); err != nil {
+		t.Fatalf("review_bead by the reviewer: %v", err)
+	}
+}
+
+func TestReviewBeadFollowsTheGate(t *testing.T) {
+	ctx := checklistContext(t)
+	bead, err := ctx.BeadStore.Create(&models.Bead{Title: "Add login", Status: models.BeadStatusInReview, Assignee: "vinnie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gate := func(mode string) {
+		config := "[safety]\nreview_gate = \"" + mode + "\"\n"
+		if err := os.WriteFile(filepath.Join(ctx.MobDir, "config.toml"), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := map[string]interface{}{"id": bead.ID, "decision": "approve"}
+
+	// A human gate leaves approval to mob approve and mob reject
+	gate(ReviewGateHuman)
+	t.Setenv("MOB_AGENT_NAME", "")
+	if _, err := handleReviewBead(ctx, args); err == nil || !strings.Contains(err.Error(), "mob approve") {
+		t.Fatalf("review_bead from the underboss = %v, want refused under the human gate", err)
+	}
+	t.Setenv("MOB_AGENT_NAME", "reviewer-"+bead.ID)
+	if _, err := handleReviewBead(ctx, args); err == nil {
+		t.Fatal("expected even the reviewer associate refused under the human gate")
+	}
+
+	// An associate gate takes only the bead's reviewer, whatever name is passed
+	gate(ReviewGateAssociate)
+	for _, caller := range []string{"", "sal"} {
+		t.Setenv("MOB_AGENT_NAME", caller)
+		named := map[string]interface{}{"id": bead.ID, "decision": "approve", "reviewer": "reviewer-" + bead.ID}
+		if _, err := handleReviewBead(ctx, named); err == nil {
+			t.Fatalf("review_bead from %q = nil, want only the reviewer associate accepted", caller)
+		}
+	}
+	if got, _ := ctx.BeadStore.Get(bead.ID); got.Status != models.BeadStatusInReview {
+		t.Fatalf("bead = %s, want still in review after the refused calls", got.Status)
+	}
+	t.Setenv("MOB_AGENT_NAME", "reviewer-"+bead.ID)
+	if _, err := handleReviewBead(ctx, args); err != nil {
+		t.Fatalf("review_bead by the reviewer associate: %v", err)
+	}
+	if got, _ := ctx.BeadStore.Get(bead.ID); got.Status != models.BeadStatusClosed {
+		t.Errorf("bead = %s, want closed by the reviewer's approval", got.Status)
+	}
+}
+
+func TestReviewerModel(t *testing.T) {
+	dir := t.TempDir()
+	if got := reviewerModel(dir); got != "sonnet" {
+		t.Errorf("default reviewer model = %q, want the default model", got)
+	}
+
+	write := func(config string) {
+		if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("[routing]\ndefault_model = \"haiku\"\n")
+	if got := reviewerModel(dir); got != "haiku" {
+		t.Errorf("reviewer model = %q, want default_model haiku", got)
+	}
+	write("[routing]\ndefault_model = \"haiku\"\nreview_model = \"opus\"\n")
+	if got := reviewerModel(dir); got != "opus" {
+		t.Errorf("reviewer model = %q, want review_model opus", got)
+	}
+}
@@ -0,0 +1,173 @@
+package mcp
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// ErrOutOfScope is returned when a turf-scoped agent reaches for a bead,
+// agent or directory belonging to another turf
+var ErrOutOfScope = errkind.New(errkind.Invalid, "outside this agent's turf")
+
+// unscopedTools are supervisory tools that turf-scoped agents do not get
+var unscopedTools = map[string]bool{
+	"list_reports":        true,
+	"mark_report_handled": true,
+}
+
+// beadIDTools take the bead they act on as "id" rather than "bead_id"
+var beadIDTools = map[string]bool{
+	"get_bead":          true,
+	"get_epic_progress": true,
+	"update_bead":       true,
+	"complete_bead":     true,
+	"abort_bead":        true,
+	"update_checklist":  true,
+	"review_bead":       true,
+	"run_tests":         true,
+	"estimate_task":     true,
+}
+
+// agentIDTools take the agent they act on as "id"/"name"
+var agentIDTools = map[string]bool{
+	"get_agent_status": true,
+	"kill_agent":       true,
+	"nudge_agent":      true,
+}
+
+// agentNameTools take the agent they act on by name alone, under the key
+var agentNameTools = map[string]string{
+	"send_message_to_agent": "to",
+}
+
+// toolInScope reports whether a connection scoped to turfName offers the tool
+func toolInScope(turfName, tool string) bool {
+	return turfName == "" || !unscopedTools[tool]
+}
+
+// checkTurfClaim holds a tool call to the connection's turf claim. Turf
+// arguments must name the claimed turf (and default to it), and any beads,
+// agents or work directories the call refers to must belong to it. It is a
+// no-op for unscoped connections.
+func checkTurfClaim(ctx *ToolContext, tool *Tool, args map[string]interface{}) error {
+	if ctx.Turf == "" {
+		return nil
+	}
+	if !toolInScope(ctx.Turf, tool.Name) {
+		return fmt.Errorf("%w: %s is not available to turf-scoped agents", ErrOutOfScope, tool.Name)
+	}
+
+	if t, _ := args["turf"].(string); t != "" && t != ctx.Turf {
+		return fmt.Errorf("%w: turf %s (this agent works on %s)", ErrOutOfScope, t, ctx.Turf)
+	}
+	if hasProperty(tool, "turf") {
+		args["turf"] = ctx.Turf
+	}
+
+	for _, id := range beadRefs(ctx, tool.Name, args) {
+		if ctx.BeadStore == nil {
+			break
+		}
+		bead, err := ctx.BeadStore.Get(id)
+		if err != nil {
+			continue // let the handler report the missing bead
+		}
+		if bead.Turf != ctx.Turf {
+			return fmt.Errorf("%w: bead %s is on turf %q (this agent works on %s)", ErrOutOfScope, id, bead.Turf, ctx.Turf)
+		}
+	}
+
+	if record := agentRef(ctx, tool.Name, args); record != nil && record.Turf != ctx.Turf {
+		return fmt.Errorf("%w: agent %s is on turf %q (this agent works on %s)", ErrOutOfScope, record.Name, record.Turf, ctx.Turf)
+	}
+
+	if workDir, _ := args["work_dir"].(string); workDir != "" && ctx.TurfManager != nil {
+		t, err := ctx.TurfManager.Get(ctx.Turf)
+		if err != nil {
+			return err
+		}
+		if !withinDir(t.Path, workDir) {
+			return fmt.Errorf("%w: work_dir %s is not inside %s", ErrOutOfScope, workDir, t.Path)
+		}
+	}
+	return nil
+}
+
+// hasProperty reports whether a tool's input schema declares the argument
+func hasProperty(tool *Tool, name string) bool {
+	props, _ := tool.InputSchema["properties"].(map[string]interface{})
+	_, ok := props[name]
+	return ok
+}
+
+// beadRefs collects the bead IDs a call refers to, including the bead a
+// human input request was asked about
+func beadRefs(ctx *ToolContext, tool string, args map[string]interface{}) []string {
+	var ids []string
+	keys := []string{"bead_id", "parent_id"}
+	if beadIDTools[tool] {
+		keys = append(keys, "id")
+	}
+	for _, key := range keys {
+		if id, _ := args[key].(string); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if id, _ := args["id"].(string); tool == "get_human_input" && id != "" {
+		if store, err := storage.NewReportStore(filepath.Join(ctx.MobDir, ".mob", "reports")); err == nil {
+			if report, err := store.Get(id); err == nil && report.BeadID != "" {
+				ids = append(ids, report.BeadID)
+			}
+		}
+	}
+	for _, key := range []string{"blocks", "related"} {
+		list, _ := args[key].([]interface{})
+		for _, v := range list {
+			if id, _ := v.(string); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// agentRef looks up the agent a call acts on, nil when there is none or it
+// does not exist (the handler reports that)
+func agentRef(ctx *ToolContext, tool string, args map[string]interface{}) *registry.AgentRecord {
+	if ctx.Registry == nil {
+		return nil
+	}
+	idKey, nameKey := "agent_id", "agent_name"
+	if agentIDTools[tool] {
+		idKey, nameKey = "id", "name"
+	} else if key, ok := agentNameTools[tool]; ok {
+		idKey, nameKey = "", key
+	} else if tool != "assign_bead" {
+		return nil
+	}
+
+	var record *registry.AgentRecord
+	var err error
+	if id, _ := args[idKey].(string); id != "" {
+		record, err = ctx.Registry.Get(id)
+	} else if name, _ := args[nameKey].(string); name != "" {
+		record, err = ctx.Registry.GetByName(name)
+	} else {
+		return nil
+	}
+	if err != nil {
+		return nil
+	}
+	return record
+}
+
+// withinDir reports whether path is dir or somewhere beneath it
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestTurfScope(t *testing.T) {
+	dir := t.TempDir()
+	s := newTestServer(t, dir, config.MCPServerConfig{MaxConcurrent: 1})
+	s.SetTurfScope("frontend")
+
+	mine, err := s.beadStore.Create(&models.Bead{Title: "Fix navbar", Turf: "frontend", Type: models.BeadTypeTask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	theirs, err := s.beadStore.Create(&models.Bead{Title: "Fix API", Turf: "backend", Type: models.BeadTypeTask})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.registry.Register(&registry.AgentRecord{ID: "a1", Name: "sal", Type: "soldati", Turf: "backend", Status: "active"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := storage.NewReportStore(filepath.Join(dir, ".mob", "reports"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	question, err := reports.Create(&models.AgentReport{Type: models.ReportTypeHumanInput, BeadID: theirs.ID, Message: "Which API version?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &ToolContext{BeadStore: s.beadStore, Registry: s.registry, MobDir: dir, Turf: "frontend"}
+	check := func(tool string, args map[string]interface{}) error {
+		t.Helper()
+		return checkTurfClaim(ctx, s.tools[tool], args)
+	}
+
+	if err := check("get_bead", map[string]interface{}{"id": mine.ID}); err != nil {
+		t.Errorf("own bead: %v", err)
+	}
+	for tool, args := range map[string]map[string]interface{}{
+		"get_bead":              {"id": theirs.ID},
+		"comment_on_bead":       {"bead_id": theirs.ID, "comment": "hi"},
+		"update_bead":           {"id": mine.ID, "blocks": []interface{}{theirs.ID}},
+		"create_bead":           {"title": "x", "turf": "backend"},
+		"kill_agent":            {"name": "sal"},
+		"list_reports":          {},
+		"get_human_input":       {"id": question.ID},
+		"send_message_to_agent": {"to": "sal", "message": "hi"},
+		"request_human_input":   {"question": "Which API version?", "bead_id": theirs.ID},
+	} {
+		if err := check(tool, args); !errors.Is(err, ErrOutOfScope) {
+			t.Errorf("%s %v: got %v, want ErrOutOfScope", tool, args, err)
+		}
+	}
+
+	if err := check("send_message_to_agent", map[string]interface{}{"to": "underboss", "message": "hi"}); err != nil {
+		t.Errorf("messaging the underboss: %v", err)
+	}
+
+	args := map[string]interface{}{"title": "New thing"}
+	if err := check("create_bead", args); err != nil || args["turf"] != "frontend" {
+		t.Errorf("create_bead should default to the claimed turf, got %v (%v)", args["turf"], err)
+	}
+
+	// tools/list hides supervisory tools, and listings only show the turf
+	resp := s.handleToolsList(&jsonRPCRequest{ID: 1})
+	for _, tool := range resp.Result.(toolsListResult).Tools {
+		if tool.Name == "list_reports" {
+			t.Error("list_reports should be hidden from a scoped connection")
+		}
+	}
+	out, err := handleListBeads(ctx, map[string]interface{}{"turf": "frontend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, mine.ID) || strings.Contains(out, theirs.ID) {
+		t.Errorf("list_beads leaked another turf: %s", out)
+	}
+	out, err = handleListAgents(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "sal") {
+		t.Errorf("list_agents leaked another turf: %s", out)
+	}
+
+	// An unscoped connection is unrestricted
+	ctx.Turf = ""
+	if err := check("get_bead", map[string]interface{}{"id": theirs.ID}); err != nil {
+		t.Errorf("unscoped: %v", err)
+	}
+}
+
+func TestWithinDir(t *testing.T) {
+	root := filepath.Join("/", "src", "app")
+	for path, want := range map[string]bool{
+		root: true,
+		filepath.Join(root, ".mob-worktrees", "bd-1"): true,
+		filepath.Join("/", "src", "app2"):             false,
+		filepath.Join(root, "..", "other"):            false,
+	} {
+		if got := withinDir(root, path); got != want {
+			t.Errorf("withinDir(%s, %s) = %v, want %v", root, path, got, want)
+		}
+	}
+}
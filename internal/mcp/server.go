@@ -2,13 +2,21 @@ package mcp
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/approval"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/redact"
 	"github.com/gabe/mob/internal/registry"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/gabe/mob/internal/turf"
@@ -21,8 +29,21 @@ type Server struct {
 	beadStore   *storage.BeadStore
 	turfManager *turf.Manager
 	mobDir      string
+	turf        string // turf claim; empty for an unscoped connection
 	tools       map[string]*Tool
-	taskWg      sync.WaitGroup // Track background tasks
+	taskWg      sync.WaitGroup   // Track background tasks
+	notifier    *notify.Manager  // Notification backends, nil when there are none
+	redactor    *redact.Redactor // Masks secrets in tool arguments before they are stored; nil when disabled
+	approvals   *approval.Store  // Holds mutating calls for the user's approval; nil runs them straight away
+
+	// Each server is one agent's connection. Tool calls run concurrently up
+	// to the slots limit, and responses share the writer.
+	limiter *rateLimiter
+	slots   chan struct{}
+	calls   sync.WaitGroup
+	writeMu sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
 }
 
 // NewServer creates a new MCP server
@@ -35,6 +56,9 @@ func NewServer(reg *registry.Registry, spawner *agent.Spawner, beadStore *storag
 		mobDir:      mobDir,
 		tools:       make(map[string]*Tool),
 	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.SetLimits(config.DefaultConfig().MCP)
+	s.redactor, _ = redact.New(config.DefaultConfig().Redaction)
 
 	// Register all tools
 	for _, tool := range GetTools() {
@@ -44,6 +68,32 @@ func NewServer(reg *registry.Registry, spawner *agent.Spawner, beadStore *storag
 	return s
 }
 
+// SetLimits applies the connection's rate limit and tool call concurrency.
+// Call it before Run.
+func (s *Server) SetLimits(cfg config.MCPServerConfig) {
+	s.limiter = newRateLimiter(cfg.RateLimit, cfg.Burst)
+	maxConcurrent := cfg.MaxConcurrent
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	s.slots = make(chan struct{}, maxConcurrent)
+}
+
+// SetRedactor replaces the redaction applied to tool arguments, test logs and
+// notifications. A nil redactor turns redaction off. Call it before Run.
+func (s *Server) SetRedactor(r *redact.Redactor) {
+	s.redactor = r
+	if s.notifier != nil {
+		s.notifier.SetRedactor(r)
+	}
+}
+
+// SetTurfScope limits the connection to one turf: it only sees and changes
+// that turf's beads, agents and worktrees. Call it before Run.
+func (s *Server) SetTurfScope(turfName string) {
+	s.turf = turfName
+}
+
 // JSON-RPC 2.0 structures
 type jsonRPCRequest struct {
 	JSONRPC string          `json:"jsonrpc"`
@@ -121,12 +171,21 @@ type contentBlock struct {
 
 // Run starts the MCP server, reading from stdin and writing to stdout
 func (s *Server) Run() error {
-	reader := bufio.NewReader(os.Stdin)
-	writer := os.Stdout
+	return s.Serve(os.Stdin, os.Stdout)
+}
+
+// Serve handles requests from r until EOF, writing responses to w. Tool calls
+// run in their own goroutines so a slow call does not hold up the rest;
+// their responses may arrive out of order, matched by ID.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	defer s.cancel()
+	reader := bufio.NewReader(r)
 
 	for {
 		line, err := reader.ReadString('\n')
 		if err != nil {
+			// Let in-flight calls answer before returning
+			s.calls.Wait()
 			if err == io.EOF {
 				// Wait for any background tasks to complete before exiting
 				s.taskWg.Wait()
@@ -137,17 +196,63 @@ func (s *Server) Run() error {
 
 		var req jsonRPCRequest
 		if err := json.Unmarshal([]byte(line), &req); err != nil {
-			s.writeError(writer, nil, -32700, "Parse error")
+			s.writeError(w, nil, -32700, "Parse error")
+			continue
+		}
+
+		if req.Method == "tools/call" {
+			s.dispatchToolsCall(w, &req)
 			continue
 		}
 
 		response := s.handleRequest(&req)
 		if response != nil {
-			s.writeResponse(writer, response)
+			s.writeResponse(w, response)
 		}
 	}
 }
 
+// dispatchToolsCall rate limits a tool call, then runs it once a slot is free
+func (s *Server) dispatchToolsCall(w io.Writer, req *jsonRPCRequest) {
+	if ok, wait := s.limiter.Allow(); !ok {
+		s.writeResponse(w, &jsonRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result: toolCallResult{
+				Content: []contentBlock{
+					{Type: "text", Text: fmt.Sprintf("Error: rate limit exceeded, retry in %s", wait.Round(time.Millisecond))},
+				},
+				IsError: true,
+			},
+		})
+		return
+	}
+
+	s.calls.Add(1)
+	go func() {
+		defer s.calls.Done()
+		// Wait for approval before taking a slot, so a batch of mutating
+		// calls is put to the user together
+		if err := s.confirmCall(req); err != nil {
+			s.writeResponse(w, &jsonRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result: toolCallResult{
+					Content: []contentBlock{
+						{Type: "text", Text: fmt.Sprintf("Error: %s", err.Error())},
+					},
+					IsError: true,
+				},
+			})
+			return
+		}
+		s.slots <- struct{}{}
+		defer func() { <-s.slots }()
+
+		s.writeResponse(w, s.handleToolsCall(req))
+	}()
+}
+
 func (s *Server) handleRequest(req *jsonRPCRequest) *jsonRPCResponse {
 	switch req.Method {
 	case "initialize":
@@ -191,6 +296,9 @@ func (s *Server) handleInitialize(req *jsonRPCRequest) *jsonRPCResponse {
 func (s *Server) handleToolsList(req *jsonRPCRequest) *jsonRPCResponse {
 	tools := make([]toolDefinition, 0, len(s.tools))
 	for _, tool := range s.tools {
+		if !toolInScope(s.turf, tool.Name) {
+			continue
+		}
 		schemaBytes, _ := json.Marshal(tool.InputSchema)
 		tools = append(tools, toolDefinition{
 			Name:        tool.Name,
@@ -233,24 +341,63 @@ func (s *Server) handleToolsCall(req *jsonRPCRequest) *jsonRPCResponse {
 		}
 	}
 
-	// Execute the tool
+	// Each call gets a context of its own; shared stores serialize their writes
+	callCtx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	if callCtx.Err() != nil {
+		return &jsonRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &rpcError{
+				Code:    -32603,
+				Message: "Server shutting down",
+			},
+		}
+	}
+
 	ctx := &ToolContext{
+		Context:     callCtx,
 		Registry:    s.registry,
 		Spawner:     s.spawner,
 		BeadStore:   s.beadStore,
 		TurfManager: s.turfManager,
 		MobDir:      s.mobDir,
+		Turf:        s.turf,
 		TaskWg:      &s.taskWg,
+		Redactor:    s.redactor,
+	}
+	if s.notifier != nil {
+		ctx.NotifyManager = s.notifier
 	}
 
-	result, err := tool.Handler(ctx, params.Arguments)
+	if params.Arguments == nil {
+		params.Arguments = make(map[string]interface{})
+	}
+	// Agents pass along whatever they read; keep secrets out of beads, reports and comments
+	s.redactor.Value(params.Arguments)
+	err := checkTurfClaim(ctx, tool, params.Arguments)
+	var result string
+	if err == nil {
+		result, err = tool.Handler(ctx, params.Arguments)
+	}
 	if err != nil {
+		text := fmt.Sprintf("Error: %s", err.Error())
+		if errors.Is(err, errkind.Transient) {
+			text += " (temporary, retry shortly)"
+		}
+		if errors.Is(err, storage.ErrBeadConflict) {
+			text += " (someone else changed it first; get_bead again, re-apply your change and retry with the new revision)"
+		}
+		var cycle *storage.CycleError
+		if errors.As(err, &cycle) {
+			text += " (drop one of these blocks links; nothing in a cycle can ever start)"
+		}
 		return &jsonRPCResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
 			Result: toolCallResult{
 				Content: []contentBlock{
-					{Type: "text", Text: fmt.Sprintf("Error: %s", err.Error())},
+					{Type: "text", Text: text},
 				},
 				IsError: true,
 			},
@@ -270,6 +417,8 @@ func (s *Server) handleToolsCall(req *jsonRPCRequest) *jsonRPCResponse {
 
 func (s *Server) writeResponse(w io.Writer, resp *jsonRPCResponse) {
 	data, _ := json.Marshal(resp)
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	fmt.Fprintln(w, string(data))
 }
 
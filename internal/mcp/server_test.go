@@ -0,0 +1,283 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/approval"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// newTestServer opens its own bead store on dir, as each agent's mcp-server process does
+func newTestServer(t *testing.T, dir string, limits config.MCPServerConfig) *Server {
+	t.Helper()
+	store, err := storage.NewBeadStore(filepath.Join(dir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(registry.New(registry.DefaultPath(dir)), nil, store, nil, dir)
+	s.SetLimits(limits)
+	return s
+}
+
+// commentCalls builds n comment_on_bead requests as newline-delimited JSON-RPC
+func commentCalls(beadID, actor string, n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		req, _ := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      fmt.Sprintf("%s-%d", actor, i),
+			"method":  "tools/call",
+			"params": map[string]interface{}{
+				"name":      "comment_on_bead",
+				"arguments": map[string]interface{}{"bead_id": beadID, "comment": fmt.Sprintf("note %d", i), "actor": actor},
+			},
+		})
+		b.Write(req)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+type testResponse struct {
+	ID     string `json:"id"`
+	Result struct {
+		Content []contentBlock `json:"content"`
+		IsError bool           `json:"isError"`
+	} `json:"result"`
+}
+
+func readResponses(t *testing.T, out *bytes.Buffer) []testResponse {
+	t.Helper()
+	var responses []testResponse
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		var resp testResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("interleaved or malformed response %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServer_ConcurrentSessionsShareBeadStore(t *testing.T) {
+	dir := t.TempDir()
+	limits := config.MCPServerConfig{MaxConcurrent: 8}
+
+	seed, err := storage.NewBeadStore(filepath.Join(dir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := seed.Create(&models.Bead{Title: "Busy bead", Status: models.BeadStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const sessions, perSession = 4, 30
+	outputs := make([]*bytes.Buffer, sessions)
+	var wg sync.WaitGroup
+	for i := 0; i < sessions; i++ {
+		outputs[i] = &bytes.Buffer{}
+		server := newTestServer(t, dir, limits)
+		input := commentCalls(bead.ID, fmt.Sprintf("agent%d", i), perSession)
+
+		wg.Add(1)
+		go func(out *bytes.Buffer) {
+			defer wg.Done()
+			if err := server.Serve(strings.NewReader(input), out); err != nil {
+				t.Errorf("Serve failed: %v", err)
+			}
+		}(outputs[i])
+	}
+	wg.Wait()
+
+	for i, out := range outputs {
+		responses := readResponses(t, out)
+		if len(responses) != perSession {
+			t.Fatalf("session %d got %d responses, want %d", i, len(responses), perSession)
+		}
+		seen := make(map[string]bool)
+		for _, resp := range responses {
+			if resp.Result.IsError {
+				t.Errorf("session %d call %s failed: %+v", i, resp.ID, resp.Result.Content)
+			}
+			seen[resp.ID] = true
+		}
+		if len(seen) != perSession {
+			t.Errorf("session %d answered %d distinct IDs, want %d", i, len(seen), perSession)
+		}
+	}
+
+	got, err := seed.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments := 0
+	for _, e := range got.History {
+		if e.Type == models.BeadEventTypeComment {
+			comments++
+		}
+	}
+	if want := sessions * perSession; comments != want {
+		t.Errorf("comments = %d, want %d (writes were lost)", comments, want)
+	}
+}
+
+func TestServer_RateLimitPerConnection(t *testing.T) {
+	dir := t.TempDir()
+	server := newTestServer(t, dir, config.MCPServerConfig{RateLimit: 0.001, Burst: 3, MaxConcurrent: 2})
+
+	var out bytes.Buffer
+	if err := server.Serve(strings.NewReader(commentCalls("bd-none", "agent", 5)), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	limited := 0
+	for _, resp := range readResponses(t, &out) {
+		if len(resp.Result.Content) > 0 && strings.Contains(resp.Result.Content[0].Text, "rate limit exceeded") {
+			limited++
+		}
+	}
+	if limited != 2 {
+		t.Errorf("rate limited %d calls, want 2 past the burst of 3", limited)
+	}
+
+	// A second connection has its own budget
+	other := newTestServer(t, dir, config.MCPServerConfig{RateLimit: 0.001, Burst: 3, MaxConcurrent: 2})
+	out.Reset()
+	if err := other.Serve(strings.NewReader(commentCalls("bd-none", "other", 1)), &out); err != nil {
+		t.Fatal(err)
+	}
+	if resp := readResponses(t, &out); len(resp) != 1 || strings.Contains(resp[0].Result.Content[0].Text, "rate limit") {
+		t.Errorf("expected the second connection to be unaffected, got %+v", resp)
+	}
+}
+
+func TestRateLimiter_Refills(t *testing.T) {
+	now := time.Now()
+	l := newRateLimiter(2, 1)
+	l.now = func() time.Time { return now }
+
+	if ok, _ := l.Allow(); !ok {
+		t.Fatal("expected the first call to be allowed")
+	}
+	ok, wait := l.Allow()
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("Allow() = %v, %v; want refused with 500ms wait", ok, wait)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.Allow(); !ok {
+		t.Error("expected a token after refilling")
+	}
+
+	if ok, _ := newRateLimiter(0, 0).Allow(); !ok {
+		t.Error("expected a zero rate to never limit")
+	}
+}
+
+func TestServer_RedactsToolArguments(t *testing.T) {
+	dir := t.TempDir()
+	server := newTestServer(t, dir, config.MCPServerConfig{MaxConcurrent: 1})
+
+	bead, err := server.beadStore.Create(&models.Bead{Title: "Leaky bead", Status: models.BeadStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      "1",
+		"method":  "tools/call",
+		"params": map[string]interface{}{
+			"name":      "comment_on_bead",
+			"arguments": map[string]interface{}{"bead_id": bead.ID, "comment": "Found AWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI/K7MDENG in .env", "actor": "vinnie"},
+		},
+	})
+	var out bytes.Buffer
+	if err := server.Serve(strings.NewReader(string(req)+"\n"), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := server.beadStore.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := got.History[len(got.History)-1]
+	if strings.Contains(last.Comment, "wJalrXUtnFEMI") || !strings.Contains(last.Comment, "AWS_SECRET_ACCESS_KEY=[REDACTED]") {
+		t.Errorf("stored comment not redacted: %q", last.Comment)
+	}
+}
+
+func TestServer_ConfirmHoldsMutations(t *testing.T) {
+	approval.PollInterval = 5 * time.Millisecond
+	dir := t.TempDir()
+	s := newTestServer(t, dir, config.MCPServerConfig{MaxConcurrent: 1})
+	approvals := approval.NewStore(approval.Path(dir))
+	s.SetConfirm(approvals)
+
+	var in strings.Builder
+	for i, call := range []map[string]interface{}{
+		{"name": "create_bead", "arguments": map[string]interface{}{"title": "Fix login", "turf": "api"}},
+		{"name": "create_bead", "arguments": map[string]interface{}{"title": "Add SSO", "turf": "api"}},
+		{"name": "list_beads", "arguments": map[string]interface{}{}},
+	} {
+		req, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": fmt.Sprint(i), "method": "tools/call", "params": call})
+		in.Write(req)
+		in.WriteByte('\n')
+	}
+
+	// Both creates wait together, even with one call slot; the read does not
+	go func() {
+		for {
+			reqs, _ := approvals.Pending()
+			if len(reqs) == 2 {
+				if got := approval.Summarize(reqs); got != "create 2 beads" {
+					t.Errorf("pending = %q", got)
+				}
+				approvals.Decide([]string{reqs[0].ID}, approval.Approved)
+				approvals.Decide([]string{reqs[1].ID}, approval.Declined)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(in.String()), &out); err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[string]testResponse)
+	for _, resp := range readResponses(t, &out) {
+		byID[resp.ID] = resp
+	}
+	if resp := byID["2"]; resp.Result.IsError {
+		t.Errorf("list_beads should run without approval: %+v", resp)
+	}
+	declined := 0
+	for _, id := range []string{"0", "1"} {
+		if byID[id].Result.IsError {
+			declined++
+			if text := byID[id].Result.Content[0].Text; !strings.Contains(text, "declined by the user") {
+				t.Errorf("expected the decline reported, got %q", text)
+			}
+		}
+	}
+	if declined != 1 {
+		t.Errorf("expected one create declined, got %d", declined)
+	}
+	if beads, _ := s.beadStore.List(storage.BeadFilter{}); len(beads) != 1 {
+		t.Errorf("expected only the approved bead created, got %d", len(beads))
+	}
+}
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// runningAssociates counts associates that are still working
+func runningAssociates(ctx *ToolContext) (int, error) {
+	associates, err := ctx.Registry.ListByType("associate")
+	if err != nil {
+		return 0, err
+	}
+	running := 0
+	for _, a := range associates {
+		if a.Status == registry.StatusActive || a.Status == registry.StatusWorking {
+			running++
+		}
+	}
+	return running, nil
+}
+
+// associateCapacity returns how many more associates may start now. An
+// unreadable registry counts as full so requests wait rather than overrun.
+func associateCapacity(ctx *ToolContext, max int) int {
+	if max <= 0 {
+		return 1
+	}
+	running, err := runningAssociates(ctx)
+	if err != nil {
+		return 0
+	}
+	return max - running
+}
+
+// runSpawnQueue drains the spawn queue, submitting req first when set, and
+// returns the associates it started and the requests that failed to start,
+// keyed by request ID
+func runSpawnQueue(ctx *ToolContext, req *models.SpawnRequest) (map[string]*registry.AgentRecord, map[string]error, error) {
+	max := loadConfig(ctx.MobDir).Associates.MaxConcurrent
+	queue := storage.NewSpawnQueue(storage.SpawnQueuePath(ctx.MobDir))
+
+	started := make(map[string]*registry.AgentRecord)
+	failed := make(map[string]error)
+	free := func() int { return associateCapacity(ctx, max) }
+	start := func(r *models.SpawnRequest) error {
+		record, err := startAssociate(ctx, r)
+		if err != nil {
+			failed[r.ID] = err
+			return err
+		}
+		started[r.ID] = record
+		return nil
+	}
+
+	var result *storage.DrainResult
+	var err error
+	if req != nil {
+		result, err = queue.Submit(req, time.Now(), free, start)
+	} else {
+		result, err = queue.Drain(time.Now(), free, start)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("spawn queue: %w", err)
+	}
+
+	for _, r := range result.Expired {
+		log.Printf("Queued spawn %s expired after waiting since %s", r.ID, r.RequestedAt.Format(time.Kitchen))
+		recordActivity(ctx, models.Activity{
+			Type:    models.ActivitySpawnExpired,
+			Agent:   r.RequestedBy,
+			BeadID:  r.BeadID,
+			Turf:    r.Turf,
+			Message: fmt.Sprintf("Queued associate spawn %s expired: %s", r.ID, truncate(r.Task, 80)),
+		})
+	}
+	for _, r := range result.Started {
+		if r != req {
+			log.Printf("Queued spawn %s started as associate %s", r.ID, started[r.ID].Label())
+		}
+	}
+	for _, r := range result.Failed {
+		if r != req {
+			log.Printf("Queued spawn %s failed to start: %v", r.ID, failed[r.ID])
+		}
+	}
+	return started, failed, nil
+}
+
+// QueueAssociate submits an associate spawn from outside a tool call, such as
+// the daemon starting work that was waiting on approval. It returns the
+// associate when one started, or nil when the request is queued.
+func QueueAssociate(ctx *ToolContext, req *models.SpawnRequest) (*registry.AgentRecord, error) {
+	if req.ExpiresAt.IsZero() {
+		req.ExpiresAt = time.Now().Add(loadConfig(ctx.MobDir).Associates.GetQueueTimeout())
+	}
+	started, failed, err := runSpawnQueue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := failed[req.ID]; err != nil {
+		return nil, err
+	}
+	return started[req.ID], nil
+}
+
+// drainSpawnQueue starts waiting spawns once capacity frees up
+func drainSpawnQueue(ctx *ToolContext) {
+	if _, _, err := runSpawnQueue(ctx, nil); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+// queuedSpawnResult tells the caller where its request waits
+func queuedSpawnResult(ctx *ToolContext, req *models.SpawnRequest, max int) string {
+	position, total := 0, 0
+	queue := storage.NewSpawnQueue(storage.SpawnQueuePath(ctx.MobDir))
+	if pending, err := queue.List(time.Now()); err == nil {
+		total = len(pending)
+		for i, r := range pending {
+			if r.ID == req.ID {
+				position = i + 1
+			}
+		}
+	}
+	running, _ := runningAssociates(ctx)
+
+	result := fmt.Sprintf("Associate capacity is full (%d of %d running). Spawn request %s queued at position %d of %d; "+
+		"it starts automatically when an associate finishes, and is dropped if still waiting at %s.",
+		running, max, req.ID, position, total, req.ExpiresAt.Format("15:04"))
+	if req.BeadID != "" {
+		result += fmt.Sprintf(" Bead %s stays open until then.", req.BeadID)
+	}
+	return result
+}
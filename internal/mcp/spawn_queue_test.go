@@ -0,0 +1,46 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestSpawnAssociate_QueuesAtCapacity(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("[associates]\nmax_concurrent = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reg := registry.New(registry.DefaultPath(dir))
+	if err := reg.Register(&registry.AgentRecord{ID: "a1", Name: "assoc-busy", Type: "associate", Status: "working"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ToolContext{Registry: reg, MobDir: dir}
+
+	for i, task := range []string{"lint the api", "fix the flaky test"} {
+		out, err := handleSpawnAssociate(ctx, map[string]interface{}{"turf": "api", "task": task, "work_dir": dir})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"1 of 1 running", fmt.Sprintf("queued at position %d of %d", i+1, i+1)}
+		for _, w := range want {
+			if !strings.Contains(out, w) {
+				t.Errorf("expected %q in %q", w, out)
+			}
+		}
+	}
+
+	pending, err := storage.NewSpawnQueue(storage.SpawnQueuePath(dir)).List(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].Task != "lint the api" || pending[0].Turf != "api" {
+		t.Fatalf("expected both requests waiting in order, got %+v", pending)
+	}
+}
@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gabe/mob/internal/templates"
+)
+
+func handleCreateBeadFromTemplate(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	name, _ := args["template"].(string)
+	if name == "" {
+		return "", fmt.Errorf("template is required")
+	}
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+
+	t, err := templates.Load(templates.Dir(ctx.MobDir), name)
+	if err != nil {
+		return "", err
+	}
+
+	vars := make(map[string]string)
+	if raw, ok := args["vars"].(map[string]interface{}); ok {
+		for k, v := range raw {
+			vars[k] = fmt.Sprint(v)
+		}
+	}
+	if title, ok := args["title"].(string); ok && strings.TrimSpace(title) != "" {
+		vars[templates.TitleVar] = title
+	}
+	turf, _ := args["turf"].(string)
+
+	parent, children, err := templates.Create(ctx.BeadStore, t, vars, turf, callerAgentName(""))
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("New job on the board from template %s: %s\n\n", t.Name, parent.ID))
+	sb.WriteString(fmt.Sprintf("Title: %s\n", parent.Title))
+	sb.WriteString(fmt.Sprintf("Type: %s\n", parent.Type))
+	sb.WriteString(fmt.Sprintf("Priority: %d\n", parent.Priority))
+	if parent.Turf != "" {
+		sb.WriteString(fmt.Sprintf("Turf: %s\n", parent.Turf))
+	}
+	if len(parent.Checklist) > 0 {
+		sb.WriteString(fmt.Sprintf("Checklist:\n%s\n", parent.FormatChecklist()))
+	}
+	if len(children) > 0 {
+		sb.WriteString(fmt.Sprintf("Children (%d, each blocks %s):\n", len(children), parent.ID))
+		for _, c := range children {
+			sb.WriteString(fmt.Sprintf("- %s: %s", c.ID, c.Title))
+			if len(c.Checklist) > 0 {
+				sb.WriteString(fmt.Sprintf(" (%d checklist items)", len(c.Checklist)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,239 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+// TestLogDir holds the full output of run_tests runs, one log per bead
+func TestLogDir(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "tests")
+}
+
+func handleRunTests(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	id, _ := args["id"].(string)
+	if id == "" {
+		return "", fmt.Errorf("id is required")
+	}
+	if ctx.BeadStore == nil {
+		return "", fmt.Errorf("bead store not available")
+	}
+
+	bead, err := ctx.BeadStore.Get(id)
+	if err != nil {
+		return "", fmt.Errorf("bead not found: %w", err)
+	}
+	t, err := beadTestTurf(ctx, bead)
+	if err != nil {
+		return "", err
+	}
+	if t.TestCommand == "" {
+		return "", fmt.Errorf("turf %s has no test command; set one with 'mob turf set-test %s <command>'", t.Name, t.Name)
+	}
+
+	workDir := bead.WorktreePath
+	if workDir == "" {
+		workDir = t.Path
+	}
+
+	cfg := loadConfig(ctx.MobDir).Tests
+	timeout, err := config.ParseRetention(cfg.Timeout)
+	if err != nil {
+		return "", err
+	}
+
+	run, output := runTestCommand(ctx.Context, t.TestCommand, workDir, timeout)
+	output = ctx.Redactor.Bytes(output)
+	run.Commit, _ = headCommit(t, workDir)
+	if clean, err := treeClean(t, workDir); err == nil {
+		run.Dirty = !clean
+	}
+
+	logPath := filepath.Join(TestLogDir(ctx.MobDir), bead.ID+".log")
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err == nil {
+		if err := os.WriteFile(logPath, output, 0644); err == nil {
+			run.LogPath = logPath
+		}
+	}
+
+	// Reload so the run lands on the latest version of the bead
+	bead, err = ctx.BeadStore.Get(id)
+	if err != nil {
+		return "", err
+	}
+	bead.LastTestRun = run
+	if _, err := ctx.BeadStore.Update(bead); err != nil {
+		return "", fmt.Errorf("failed to record test run: %w", err)
+	}
+	if err := ctx.BeadStore.AddComment(bead.ID, callerAgentName(""), formatTestRun(run)); err != nil {
+		log.Printf("Warning: failed to comment test run on bead %s: %v", bead.ID, err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(formatTestRun(run))
+	sb.WriteString("\n\n")
+	sb.WriteString(tailOutput(string(output), cfg.OutputLimit))
+	return sb.String(), nil
+}
+
+// beadTestTurf returns the turf whose test command covers a bead
+func beadTestTurf(ctx *ToolContext, bead *models.Bead) (*models.Turf, error) {
+	if bead.Turf == "" {
+		return nil, fmt.Errorf("bead %s has no turf, so there is no test command to run", bead.ID)
+	}
+	if ctx.TurfManager == nil {
+		return nil, fmt.Errorf("turf manager not available")
+	}
+	return ctx.TurfManager.Get(bead.Turf)
+}
+
+// runTestCommand runs command through the shell in dir, capturing combined
+// output. A timeout of 0 means no limit beyond the call's own context.
+func runTestCommand(parent context.Context, command, dir string, timeout time.Duration) (*models.TestRun, []byte) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	runCtx, cancel := parent, context.CancelFunc(func() {})
+	if timeout > 0 {
+		runCtx, cancel = context.WithTimeout(parent, timeout)
+	}
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(runCtx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Kill the whole process group on timeout, so test binaries the shell
+	// started don't outlive it
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error { return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) }
+	cmd.WaitDelay = 5 * time.Second
+
+	run := &models.TestRun{At: time.Now(), Command: command}
+	err := cmd.Run()
+	run.Duration = time.Since(run.At).Round(time.Millisecond)
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		run.Passed = true
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		run.TimedOut = true
+		run.ExitCode = -1
+		fmt.Fprintf(&out, "\n[mob] killed after %s\n", timeout)
+	case errors.As(err, &exitErr):
+		run.ExitCode = exitErr.ExitCode()
+	default:
+		run.ExitCode = -1
+		fmt.Fprintf(&out, "\n[mob] %v\n", err)
+	}
+	return run, out.Bytes()
+}
+
+// checkTestGate refuses to complete a bead on a turf with a test command
+// unless its last run passed against the worktree as it is now: at the
+// current HEAD, with nothing uncommitted then or since. It is a no-op when
+// tests.require_pass is off or the turf has no test command.
+func checkTestGate(ctx *ToolContext, bead *models.Bead) error {
+	if !loadConfig(ctx.MobDir).Tests.RequirePass || bead.Turf == "" || ctx.TurfManager == nil {
+		return nil
+	}
+	t, err := ctx.TurfManager.Get(bead.Turf)
+	if err != nil || t.TestCommand == "" {
+		return nil
+	}
+
+	run := bead.LastTestRun
+	switch {
+	case run == nil:
+		return fmt.Errorf("bead %s needs a passing test run before it can be completed; call run_tests", bead.ID)
+	case !run.Passed:
+		return fmt.Errorf("bead %s's last test run failed (%s); fix the failures and call run_tests again", bead.ID, testRunResult(run))
+	}
+
+	workDir := bead.WorktreePath
+	if workDir == "" {
+		workDir = t.Path
+	}
+	// Uncommitted changes were not tested at any commit, so the run only
+	// counts for a clean tree
+	if clean, err := treeClean(t, workDir); err == nil && !clean {
+		return fmt.Errorf("bead %s has uncommitted changes; commit them and call run_tests again", bead.ID)
+	}
+	if run.Dirty {
+		return fmt.Errorf("bead %s's last test run included uncommitted changes; call run_tests again on the committed work", bead.ID)
+	}
+	if head, err := headCommit(t, workDir); err == nil && run.Commit != "" && head != run.Commit {
+		return fmt.Errorf("bead %s has commits since its last test run; call run_tests again", bead.ID)
+	}
+	return nil
+}
+
+// headCommit identifies the state checked out in a turf's workDir, so a
+// test run can be matched to the commit it ran against
+func headCommit(t *models.Turf, workDir string) (string, error) {
+	repo, err := vcs.Open(t.Path, t.VCS)
+	if err != nil {
+		return "", err
+	}
+	return repo.Head(workDir)
+}
+
+// treeClean reports whether a turf's workDir has no uncommitted changes
+func treeClean(t *models.Turf, workDir string) (bool, error) {
+	repo, err := vcs.Open(t.Path, t.VCS)
+	if err != nil {
+		return false, err
+	}
+	return repo.IsClean(workDir)
+}
+
+func testRunResult(run *models.TestRun) string {
+	switch {
+	case run.Passed:
+		return "passed"
+	case run.TimedOut:
+		return "timed out"
+	default:
+		return fmt.Sprintf("exit %d", run.ExitCode)
+	}
+}
+
+func formatTestRun(run *models.TestRun) string {
+	verdict := "Tests passed"
+	if !run.Passed {
+		verdict = "Tests failed (" + testRunResult(run) + ")"
+	}
+	commit := ""
+	if len(run.Commit) >= 8 {
+		commit = " at " + run.Commit[:8]
+	}
+	return fmt.Sprintf("%s in %s%s: %s", verdict, run.Duration, commit, run.Command)
+}
+
+// tailOutput keeps the last limit characters of output, where failures
+// usually are. A limit of 0 keeps everything.
+func tailOutput(output string, limit int) string {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return "(no output)"
+	}
+	if limit <= 0 || len(output) <= limit {
+		return output
+	}
+	return fmt.Sprintf("... (%d earlier characters omitted)\n%s", len(output)-limit, output[len(output)-limit:])
+}
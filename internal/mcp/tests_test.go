@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/turf"
+)
+
+func TestRunTestsGate(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	gitRun("init", "-q", "-b", "main")
+	gitRun("commit", "-q", "--allow-empty", "-m", "init")
+
+	turfMgr, err := turf.NewManager(filepath.Join(dir, "turfs.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := turfMgr.Add(repo, "api", "main"); err != nil {
+		t.Fatal(err)
+	}
+	// Passes once the marker file exists
+	if err := turfMgr.SetTestCommand("api", "echo running; test -f ok"); err != nil {
+		t.Fatal(err)
+	}
+	store, err := storage.NewBeadStore(filepath.Join(dir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Add endpoint", Type: models.BeadTypeTask, Turf: "api", WorktreePath: repo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ToolContext{Context: context.Background(), BeadStore: store, TurfManager: turfMgr, MobDir: dir}
+	gate := func() error {
+		t.Helper()
+		b, err := store.Get(bead.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return checkTestGate(ctx, b)
+	}
+
+	if err := gate(); err == nil || !strings.Contains(err.Error(), "run_tests") {
+		t.Fatalf("completing without a run should be refused, got %v", err)
+	}
+	// An associate finishing the bead goes through the same gate
+	finishAssociateBead(ctx, bead.ID, "quick-fox", "quick-fox (associate)", &agent.ChatResponse{})
+	if got, _ := store.Get(bead.ID); got.Status != models.BeadStatusBlocked || !hasComment(got, "needs a passing test run") {
+		t.Fatalf("associate completion without a run = %s, want blocked on the test gate", got.Status)
+	}
+
+	out, err := handleRunTests(ctx, map[string]interface{}{"id": bead.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Tests failed (exit 1)") || !strings.Contains(out, "running") {
+		t.Errorf("unexpected failing output: %q", out)
+	}
+	if err := gate(); err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Errorf("a failed run should hold completion, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "ok"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A pass on uncommitted work does not count, even once the tree is clean
+	if out, err = handleRunTests(ctx, map[string]interface{}{"id": bead.ID}); err != nil || !strings.Contains(out, "Tests passed") {
+		t.Fatalf("expected a pass, got %q (%v)", out, err)
+	}
+	if err := gate(); err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("a dirty tree should hold completion, got %v", err)
+	}
+	gitRun("add", "ok")
+	gitRun("commit", "-q", "-m", "add ok")
+	if err := gate(); err == nil || !strings.Contains(err.Error(), "included uncommitted changes") {
+		t.Errorf("a run on uncommitted work should need a fresh run, got %v", err)
+	}
+
+	if out, err = handleRunTests(ctx, map[string]interface{}{"id": bead.ID}); err != nil || !strings.Contains(out, "Tests passed") {
+		t.Fatalf("expected a pass, got %q (%v)", out, err)
+	}
+	got, _ := store.Get(bead.ID)
+	if got.LastTestRun == nil || !got.LastTestRun.Passed || got.LastTestRun.LogPath == "" {
+		t.Fatalf("run not recorded: %+v", got.LastTestRun)
+	}
+	if err := gate(); err != nil {
+		t.Errorf("a passing run at HEAD should allow completion: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "ok"), []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := gate(); err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("edits after a passing run should hold completion, got %v", err)
+	}
+	gitRun("checkout", "--", "ok")
+
+	gitRun("commit", "-q", "--allow-empty", "-m", "more work")
+	if err := gate(); err == nil || !strings.Contains(err.Error(), "commits since") {
+		t.Errorf("new commits should require a fresh run, got %v", err)
+	}
+}
+
+func TestRunTestCommandTimeout(t *testing.T) {
+	run, out := runTestCommand(context.Background(), "sleep 5", t.TempDir(), 100*time.Millisecond)
+	if run.Passed || !run.TimedOut || !strings.Contains(string(out), "killed after") {
+		t.Errorf("expected a timed out run, got %+v %q", run, out)
+	}
+	if got := tailOutput("abcdef", 3); !strings.HasSuffix(got, "\ndef") {
+		t.Errorf("tailOutput = %q", got)
+	}
+}
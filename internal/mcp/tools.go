@@ -1,33 +1,46 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gabe/mob/internal/agent"
-	"github.com/gabe/mob/internal/git"
+	"github.com/gabe/mob/internal/briefing"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/failures"
 	"github.com/gabe/mob/internal/hook"
 	"github.com/gabe/mob/internal/merge"
 	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/postmortem"
+	"github.com/gabe/mob/internal/quota"
+	"github.com/gabe/mob/internal/redact"
 	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/router"
 	"github.com/gabe/mob/internal/soldati"
 	"github.com/gabe/mob/internal/storage"
 	"github.com/gabe/mob/internal/turf"
+	"github.com/gabe/mob/internal/vcs"
 )
 
 // ToolContext provides access to mob systems for tool handlers
 type ToolContext struct {
+	Context        context.Context // Scoped to one call: cancelled when it returns or the server shuts down
 	Registry       *registry.Registry
 	Spawner        *agent.Spawner
 	BeadStore      *storage.BeadStore
 	TurfManager    *turf.Manager
 	MobDir         string
+	Turf           string          // Turf claim of a scoped connection; empty when unscoped
 	TaskWg         *sync.WaitGroup // Track background tasks for graceful shutdown
 	NotifyManager  interface {
 		NotifyTaskComplete(beadID, title, assignee string) error
@@ -35,6 +48,7 @@ type ToolContext struct {
 		NotifyAgentStuck(agentName, agentID, task string) error
 		NotifyAgentError(agentName, agentID, errorMsg string) error
 	} // Optional notification manager
+	Redactor *redact.Redactor // Masks secrets in output mob writes to disk; nil when disabled
 }
 
 // ToolHandler is a function that executes a tool
@@ -76,7 +90,7 @@ func GetTools() []*Tool {
 		},
 		{
 			Name:        "spawn_associate",
-			Description: "Get a temp worker for a quick job. No names, no history - just work.",
+			Description: "Get a temp worker for a quick job. No names, no history - just work. When associate capacity is full the request is queued and starts automatically once a slot frees up; the result gives its queue position.",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -96,6 +110,11 @@ func GetTools() []*Tool {
 						"type":        "string",
 						"description": "Optional bead ID to link - auto-completes when associate finishes successfully, marks blocked on failure",
 					},
+					"tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional short label for the job (e.g. \"lint-fix\"), shown next to the associate's name in logs, notifications and the TUI",
+					},
+					"include_full": includeFullProperty,
 				},
 				"required": []string{"turf", "task"},
 			},
@@ -112,6 +131,10 @@ func GetTools() []*Tool {
 						"description": "Filter by agent type: 'soldati', 'associate', or empty for all",
 						"enum":        []string{"soldati", "associate", ""},
 					},
+					"format":       listFormatProperty,
+					"fields":       listFieldsProperty(defaultAgentFields, agentFieldNames),
+					"limit":        listLimitProperty,
+					"include_full": includeFullProperty,
 				},
 			},
 			Handler: handleListAgents,
@@ -192,6 +215,7 @@ func GetTools() []*Tool {
 						"type":        "string",
 						"description": "Task description if no bead ID",
 					},
+					"include_full": includeFullProperty,
 				},
 			},
 			Handler: handleAssignBead,
@@ -225,10 +249,7 @@ func GetTools() []*Tool {
 						"type":        "string",
 						"description": "Which project/territory this belongs to",
 					},
-					"labels": map[string]interface{}{
-						"type":        "string",
-						"description": "Comma-separated tags for the job",
-					},
+					"labels": labelsProperty("Tags for the job"),
 					"parent_id": map[string]interface{}{
 						"type":        "string",
 						"description": "Parent bead ID if this is a sub-task",
@@ -247,21 +268,58 @@ func GetTools() []*Tool {
 						"type":        "boolean",
 						"description": "If true, creates bead with pending_approval status requiring approval via 'mob approve <bead-id>' before work can start",
 					},
+					"checklist": map[string]interface{}{
+						"type":        "array",
+						"description": "Acceptance criteria that must all be checked before the bead can be completed",
+						"items":       map[string]interface{}{"type": "string"},
+					},
+					"due_at":        dueAtProperty,
+					"sla":           slaProperty,
+					"time_estimate": timeEstimateProperty,
+					"include_full":  includeFullProperty,
 				},
 				"required": []string{"title"},
 			},
 			Handler: handleCreateBead,
 		},
+		{
+			Name:        "create_bead_from_template",
+			Description: "Drop a standard job on the board from a template in ~/mob/templates (e.g. release-checklist). Creates the parent bead plus the child beads the template lists, each with its checklist; the children block the parent.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"template": map[string]interface{}{
+						"type":        "string",
+						"description": "Template name: the file name in ~/mob/templates without .toml",
+					},
+					"vars": map[string]interface{}{
+						"type":                 "object",
+						"description":          "Values for the template's {{.name}} placeholders, e.g. {\"version\": \"1.4\"}",
+						"additionalProperties": map[string]interface{}{"type": "string"},
+					},
+					"title": map[string]interface{}{
+						"type":        "string",
+						"description": "Title for the parent when the template has no title pattern",
+					},
+					"turf": map[string]interface{}{
+						"type":        "string",
+						"description": "Which project/territory the beads belong to",
+					},
+				},
+				"required": []string{"template"},
+			},
+			Handler: handleCreateBeadFromTemplate,
+		},
 		{
 			Name:        "list_beads",
-			Description: "Check the job board. See what work is pending for the crew.",
+			Description: "Check the job board. See what work is pending for the crew. Closed beads are left out unless you filter by status or set include_closed.",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
 					"status": map[string]interface{}{
 						"type":        "string",
 						"description": "Filter by status: open, in_progress, blocked, closed, pending_approval",
-						"enum":        []string{"open", "in_progress", "blocked", "closed", "pending_approval"},
+						"enum":        []string{"open", "in_progress", "blocked", "closed", "pending_approval", "in_review"},
 					},
 					"turf": map[string]interface{}{
 						"type":        "string",
@@ -276,6 +334,18 @@ func GetTools() []*Tool {
 						"description": "Filter by work type: bug, feature, task, epic, chore, review, heresy",
 						"enum":        []string{"bug", "feature", "task", "epic", "chore", "review", "heresy"},
 					},
+					"label": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter by label",
+					},
+					"include_closed": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include closed beads when no status filter is given (default false)",
+					},
+					"format":       listFormatProperty,
+					"fields":       listFieldsProperty(defaultBeadFields, beadFieldNames),
+					"limit":        listLimitProperty,
+					"include_full": includeFullProperty,
 				},
 			},
 			Handler: handleListBeads,
@@ -294,6 +364,9 @@ func GetTools() []*Tool {
 						"type":        "integer",
 						"description": "Maximum number of beads to return (default 10)",
 					},
+					"format":       listFormatProperty,
+					"fields":       listFieldsProperty(defaultBeadFields, beadFieldNames),
+					"include_full": includeFullProperty,
 				},
 			},
 			Handler: handleListReadyBeads,
@@ -313,9 +386,24 @@ func GetTools() []*Tool {
 			},
 			Handler: handleGetBead,
 		},
+		{
+			Name:        "get_epic_progress",
+			Description: "See how far along an epic is: its child beads counted per status, the share closed, how many are overdue and the nearest deadline, with each child listed. Use it to plan what to assign next.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "Epic bead ID",
+					},
+				},
+				"required": []string{"id"},
+			},
+			Handler: handleGetEpicProgress,
+		},
 		{
 			Name:        "update_bead",
-			Description: "Make changes to a piece of work. Update any details on a bead.",
+			Description: "Make changes to a piece of work. Update any details on a bead. Pass the revision you read so a change someone made since is not overwritten.",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -323,6 +411,10 @@ func GetTools() []*Tool {
 						"type":        "string",
 						"description": "Bead ID to update",
 					},
+					"revision": map[string]interface{}{
+						"type":        "integer",
+						"description": "Revision of the bead your change is based on, from get_bead. The update is refused if the bead has changed since; re-read it and retry",
+					},
 					"title": map[string]interface{}{
 						"type":        "string",
 						"description": "New title for the bead",
@@ -333,8 +425,8 @@ func GetTools() []*Tool {
 					},
 					"status": map[string]interface{}{
 						"type":        "string",
-						"description": "New status: open, in_progress, blocked, closed, pending_approval",
-						"enum":        []string{"open", "in_progress", "blocked", "closed", "pending_approval"},
+						"description": "New status: open, in_progress, blocked, pending_approval. Close a bead with complete_bead; a bead in review leaves it through review_bead",
+						"enum":        []string{"open", "in_progress", "blocked", "pending_approval"},
 					},
 					"priority": map[string]interface{}{
 						"type":        "integer",
@@ -344,10 +436,7 @@ func GetTools() []*Tool {
 						"type":        "string",
 						"description": "Who's working this job",
 					},
-					"labels": map[string]interface{}{
-						"type":        "string",
-						"description": "Labels/tags for the bead",
-					},
+					"labels": labelsProperty("Labels/tags for the bead; replaces the current ones"),
 					"blocks": map[string]interface{}{
 						"type":        "array",
 						"items":       map[string]interface{}{"type": "string"},
@@ -358,6 +447,9 @@ func GetTools() []*Tool {
 						"items":       map[string]interface{}{"type": "string"},
 						"description": "Related bead IDs",
 					},
+					"due_at":        dueAtProperty,
+					"sla":           slaProperty,
+					"time_estimate": timeEstimateProperty,
 				},
 				"required": []string{"id"},
 			},
@@ -377,11 +469,161 @@ func GetTools() []*Tool {
 						"type":        "string",
 						"description": "Why the job's done (completed, won't fix, duplicate, etc.)",
 					},
+					"override_checklist": map[string]interface{}{
+						"type":        "string",
+						"description": "Reason for completing despite unchecked checklist items (recorded on the bead)",
+					},
 				},
 				"required": []string{"id"},
 			},
 			Handler: handleCompleteBead,
 		},
+		{
+			Name:        "abort_bead",
+			Description: "Call off the work on a bead. Stops the assigned agent's in-flight work and resets the bead to open (or blocked) with a reason. Only the bead's assignee or the underboss can abort it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "Bead ID to abort",
+					},
+					"reason": map[string]interface{}{
+						"type":        "string",
+						"description": "Why the work is being called off (recorded on the bead)",
+					},
+					"block": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Mark the bead blocked instead of returning it to open",
+					},
+				},
+				"required": []string{"id"},
+			},
+			Handler: handleAbortBead,
+		},
+		{
+			Name:        "file_followup",
+			Description: "File something you noticed but didn't fix as a new bead linked to the bead you're working on. Use this instead of expanding scope.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"bead_id": map[string]interface{}{
+						"type":        "string",
+						"description": "The bead you were working on when you found this",
+					},
+					"title": map[string]interface{}{
+						"type":        "string",
+						"description": "Short summary of the follow-up work",
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "What you noticed, where, and why it matters",
+					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Kind of work (defaults to task)",
+						"enum":        []string{"bug", "feature", "task", "chore"},
+					},
+					"priority": map[string]interface{}{
+						"type":        "integer",
+						"description": "0-4, 0 = highest (defaults to 3)",
+						"minimum":     0,
+						"maximum":     4,
+					},
+					"labels": labelsProperty("Extra labels (followup is always added)"),
+				},
+				"required": []string{"bead_id", "title"},
+			},
+			Handler: handleFileFollowup,
+		},
+		{
+			Name:        "update_conventions",
+			Description: "Read or add to a turf's conventions document - the project's established patterns, injected into every new agent's prompt for that turf.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"turf": map[string]interface{}{
+						"type":        "string",
+						"description": "Turf name",
+					},
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "Markdown to add (omit to read the current conventions)",
+					},
+					"mode": map[string]interface{}{
+						"type":        "string",
+						"description": "append (default) adds to the end; replace overwrites the whole document",
+						"enum":        []string{"append", "replace"},
+					},
+				},
+				"required": []string{"turf"},
+			},
+			Handler: handleUpdateConventions,
+		},
+		{
+			Name:        "run_tests",
+			Description: "Run the turf's test command in the bead's worktree, record the result on the bead and return the tail of the output. On turfs with a test command, complete_bead requires a passing run at the current commit.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "The bead whose worktree to test",
+					},
+				},
+				"required": []string{"id"},
+			},
+			Handler: handleRunTests,
+		},
+		{
+			Name:        "estimate_task",
+			Description: "Preflight a bead before committing budget to it: a cheap model skims the turf and estimates the scope, the files likely touched and what the work will cost on the model it routes to. The estimate is recorded on the bead for later comparison with the actual cost.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "The bead to estimate",
+					},
+					"model": map[string]interface{}{
+						"type":        "string",
+						"description": "Model that produces the estimate (default: routing.preflight_model)",
+					},
+				},
+				"required": []string{"id"},
+			},
+			Handler: handleEstimateTask,
+		},
+		{
+			Name:        "update_checklist",
+			Description: "View or edit a bead's acceptance criteria checklist. complete_bead fails until every item is checked.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "Bead ID",
+					},
+					"action": map[string]interface{}{
+						"type":        "string",
+						"description": "What to do (omit to just view the checklist)",
+						"enum":        []string{"add", "check", "uncheck", "remove"},
+					},
+					"items": map[string]interface{}{
+						"type":        "array",
+						"description": "Item text to add (for add)",
+						"items":       map[string]interface{}{"type": "string"},
+					},
+					"positions": map[string]interface{}{
+						"type":        "array",
+						"description": "1-based item numbers (for check, uncheck, remove)",
+						"items":       map[string]interface{}{"type": "integer"},
+					},
+				},
+				"required": []string{"id"},
+			},
+			Handler: handleUpdateChecklist,
+		},
 		{
 			Name:        "comment_on_bead",
 			Description: "Leave a comment on a bead. Agents can report what they did, blockers found, questions, or progress updates.",
@@ -405,6 +647,34 @@ func GetTools() []*Tool {
 			},
 			Handler: handleCommentOnBead,
 		},
+		{
+			Name:        "review_bead",
+			Description: "Approve or request changes on a bead that is in review. Approving closes the bead and merges its branch; requesting changes sends it back to the assigned soldati with your feedback. Under review_gate = \"human\" only a human reviews, with mob approve or mob reject; under \"associate\" only the bead's reviewer associate can call this.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "The bead ID under review",
+					},
+					"decision": map[string]interface{}{
+						"type":        "string",
+						"description": "Review outcome",
+						"enum":        []string{"approve", "request_changes"},
+					},
+					"comments": map[string]interface{}{
+						"type":        "string",
+						"description": "Review feedback (required when requesting changes)",
+					},
+					"reviewer": map[string]interface{}{
+						"type":        "string",
+						"description": "Who performed the review when the underboss reviews (defaults to 'underboss'); an agent always reviews as itself and cannot review its own bead",
+					},
+				},
+				"required": []string{"id", "decision"},
+			},
+			Handler: handleReviewBead,
+		},
 		{
 			Name:        "list_turfs",
 			Description: "Get the turf mappings. Returns all registered turfs with their paths so you know where projects are located.",
@@ -490,6 +760,53 @@ func GetTools() []*Tool {
 			},
 			Handler: handleReportProgress,
 		},
+		{
+			Name:        "request_human_input",
+			Description: "Ask the human operator a question only they can answer (a product decision, a credential, an ambiguous requirement) instead of guessing or stalling. The operator is notified; set wait_seconds to wait for the reply, or poll later with get_human_input.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"question": map[string]interface{}{
+						"type":        "string",
+						"description": "The question, with enough context to answer without opening the bead",
+					},
+					"bead_id": map[string]interface{}{
+						"type":        "string",
+						"description": "The bead you need the answer for",
+					},
+					"options": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Suggested answers, if the choice is between known options (optional)",
+					},
+					"wait_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("How long to wait for the answer before returning (0-%d, default 0)", int(maxHumanInputWait.Seconds())),
+					},
+				},
+				"required": []string{"question"},
+			},
+			Handler: handleRequestHumanInput,
+		},
+		{
+			Name:        "get_human_input",
+			Description: "Check whether the operator has answered a request_human_input question, optionally waiting for it.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "Request ID returned by request_human_input",
+					},
+					"wait_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("How long to wait for the answer before returning (0-%d, default 0)", int(maxHumanInputWait.Seconds())),
+					},
+				},
+				"required": []string{"id"},
+			},
+			Handler: handleGetHumanInput,
+		},
 		{
 			Name:        "list_reports",
 			Description: "List agent reports with optional filtering.",
@@ -517,6 +834,7 @@ func GetTools() []*Tool {
 						"type":        "boolean",
 						"description": "Filter by handled status (omit for all)",
 					},
+					"include_full": includeFullProperty,
 				},
 			},
 			Handler: handleListReports,
@@ -536,6 +854,43 @@ func GetTools() []*Tool {
 			},
 			Handler: handleMarkReportHandled,
 		},
+		{
+			Name:        "send_message_to_agent",
+			Description: "Send a message directly to another agent's inbox. Use to hand off context (e.g., 'the API changed, here's the new schema') without going through a human.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"to": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the receiving agent (e.g., a soldati name or 'underboss')",
+					},
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "The message to deliver",
+					},
+					"bead_id": map[string]interface{}{
+						"type":        "string",
+						"description": "The bead ID this message relates to (optional)",
+					},
+				},
+				"required": []string{"to", "message"},
+			},
+			Handler: handleSendMessageToAgent,
+		},
+		{
+			Name:        "read_messages",
+			Description: "Read messages sent to you by other agents. Returns unread messages and marks them as read.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"include_read": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also return messages that were already read (default: false)",
+					},
+				},
+			},
+			Handler: handleReadMessages,
+		},
 	}
 }
 
@@ -591,8 +946,8 @@ func handleSpawnSoldati(ctx *ToolContext, args map[string]interface{}) (string,
 		return "", fmt.Errorf("failed to assign turf: %w", err)
 	}
 
-	// Generate MCP config for tool access
-	mcpConfigPath, err := GenerateMCPConfig(ctx.MobDir)
+	// Generate MCP config for tool access, scoped to the agent's turf
+	mcpConfigPath, err := GenerateTurfMCPConfig(ctx.MobDir, turf)
 	if err != nil {
 		log.Printf("Warning: failed to generate MCP config: %v", err)
 	}
@@ -603,7 +958,7 @@ func handleSpawnSoldati(ctx *ToolContext, args map[string]interface{}) (string,
 		Name:         name,
 		Turf:         turf,
 		WorkDir:      workDir,
-		SystemPrompt: agent.SoldatiSystemPrompt,
+		SystemPrompt: systemPrompt(ctx, agent.PromptSoldati, agent.PromptVars{Name: name, Turf: turf}),
 		MCPConfig:    mcpConfigPath,
 		Model:        "sonnet", // Default to sonnet for cost efficiency
 	})
@@ -619,7 +974,7 @@ func handleSpawnSoldati(ctx *ToolContext, args map[string]interface{}) (string,
 		Type:      "soldati",
 		Name:      name,
 		Turf:      turf,
-		Status:    "active",
+		Status:    registry.StatusActive,
 		StartedAt: spawnedAgent.StartedAt,
 	}
 	if err := ctx.Registry.Register(record); err != nil {
@@ -627,6 +982,12 @@ func handleSpawnSoldati(ctx *ToolContext, args map[string]interface{}) (string,
 		mgr.Delete(name)
 		return "", fmt.Errorf("failed to register soldati: %w", err)
 	}
+	recordActivity(ctx, models.Activity{
+		Type:    models.ActivityAgentSpawned,
+		Agent:   name,
+		Turf:    turf,
+		Message: fmt.Sprintf("Soldati %s hired on turf %s", name, turf),
+	})
 
 	return fmt.Sprintf("Soldati '%s' is now on the payroll. ID: %s, Turf: %s", name, spawnedAgent.ID, turf), nil
 }
@@ -636,6 +997,7 @@ func handleSpawnAssociate(ctx *ToolContext, args map[string]interface{}) (string
 	task, _ := args["task"].(string)
 	workDir, _ := args["work_dir"].(string)
 	beadID, _ := args["bead_id"].(string)
+	tag, _ := args["tag"].(string)
 
 	if turf == "" {
 		return "", fmt.Errorf("turf is required")
@@ -643,122 +1005,260 @@ func handleSpawnAssociate(ctx *ToolContext, args map[string]interface{}) (string
 	if task == "" {
 		return "", fmt.Errorf("task is required")
 	}
+	tag = strings.TrimSpace(tag)
+	if len(tag) > 32 {
+		return "", fmt.Errorf("tag must be 32 characters or fewer")
+	}
 
 	// Default work directory
 	if workDir == "" {
 		workDir, _ = os.Getwd()
 	}
 
-	// If bead_id provided, update the bead to in_progress
+	// A bad bead should fail now, not when the request leaves the queue
+	if beadID != "" && ctx.BeadStore != nil {
+		if _, err := ctx.BeadStore.Get(beadID); err != nil {
+			return "", fmt.Errorf("bead not found: %w", err)
+		}
+	}
+
+	cfg := loadConfig(ctx.MobDir).Associates
+	req := &models.SpawnRequest{
+		Turf:        turf,
+		Task:        task,
+		WorkDir:     workDir,
+		BeadID:      beadID,
+		Tag:         tag,
+		RequestedBy: os.Getenv("MOB_AGENT_NAME"),
+		ExpiresAt:   time.Now().Add(cfg.GetQueueTimeout()),
+	}
+	started, failed, err := runSpawnQueue(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if err := failed[req.ID]; err != nil {
+		return "", err
+	}
+	record, ok := started[req.ID]
+	if !ok {
+		return queuedSpawnResult(ctx, req, cfg.MaxConcurrent), nil
+	}
+
+	limit := textLimitFor(ctx, "spawn_associate", args, loadConfig(ctx.MobDir).MCP, 50)
+	result := fmt.Sprintf("Associate '%s' spawned and working. ID: %s, Task: %s", record.Label(), record.ID, limit.cutFor(beadID, task))
+	if beadID != "" {
+		result += fmt.Sprintf(", Linked Bead: %s", beadID)
+	}
+	return result, nil
+}
+
+// startAssociate spawns and registers the associate a spawn request asks for
+// and sets it working on the task in the background
+func startAssociate(ctx *ToolContext, req *models.SpawnRequest) (*registry.AgentRecord, error) {
+	turf, task, workDir, beadID, tag := req.Turf, req.Task, req.WorkDir, req.BeadID, req.Tag
+
+	// If bead_id provided, route its model and update the bead to in_progress
+	model := loadConfig(ctx.MobDir).Routing.DefaultModel
 	if beadID != "" && ctx.BeadStore != nil {
 		bead, err := ctx.BeadStore.Get(beadID)
 		if err != nil {
-			return "", fmt.Errorf("bead not found: %w", err)
+			return nil, fmt.Errorf("bead not found: %w", err)
 		}
+		history, _ := ctx.BeadStore.List(storage.BeadFilter{Status: models.BeadStatusClosed})
+		model = router.New(loadConfig(ctx.MobDir).Routing).Route(bead, history)
+		bead.Model = model
 		bead.Status = models.BeadStatusInProgress
 		if _, err := ctx.BeadStore.Update(bead); err != nil {
-			return "", fmt.Errorf("failed to update bead status: %w", err)
+			return nil, fmt.Errorf("failed to update bead status: %w", err)
 		}
 	}
 
-	// Generate MCP config for tool access
-	mcpConfigPath, err := GenerateMCPConfig(ctx.MobDir)
+	// Generate MCP config for tool access, scoped to the agent's turf
+	mcpConfigPath, err := GenerateTurfMCPConfig(ctx.MobDir, turf)
 	if err != nil {
 		log.Printf("Warning: failed to generate MCP config: %v", err)
 	}
 
+	// Associates get a throwaway readable name so logs and notifications make sense
+	name := ctx.Registry.NewAssociateName(loadConfig(ctx.MobDir).Associates.NamePrefix)
+
 	// Spawn the agent with the Associate system prompt
 	spawnedAgent, err := ctx.Spawner.SpawnWithOptions(agent.SpawnOptions{
 		Type:         agent.AgentTypeAssociate,
-		Name:         "", // Associates don't get names
+		Name:         name,
 		Turf:         turf,
 		WorkDir:      workDir,
-		SystemPrompt: agent.AssociateSystemPrompt,
+		SystemPrompt: systemPrompt(ctx, agent.PromptAssociate, agent.PromptVars{Name: name, Turf: turf, BeadID: beadID}),
 		MCPConfig:    mcpConfigPath,
-		Model:        "sonnet", // Default to sonnet for cost efficiency
+		Model:        model,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to spawn associate: %w", err)
+		return nil, fmt.Errorf("failed to spawn associate: %w", err)
 	}
 
 	// Register in registry with linked bead
 	record := &registry.AgentRecord{
 		ID:        spawnedAgent.ID,
 		Type:      "associate",
+		Name:      name,
 		Turf:      turf,
 		Task:      task,
+		Tag:       tag,
 		BeadID:    beadID, // Link the bead for auto-completion
-		Status:    "active",
+		Status:    registry.StatusActive,
 		StartedAt: spawnedAgent.StartedAt,
 	}
 	if err := ctx.Registry.Register(record); err != nil {
-		return "", fmt.Errorf("failed to register associate: %w", err)
-	}
+		return nil, fmt.Errorf("failed to register associate: %w", err)
+	}
+	recordActivity(ctx, models.Activity{
+		Type:    models.ActivityAgentSpawned,
+		Agent:   record.Label(),
+		BeadID:  beadID,
+		Turf:    turf,
+		Message: fmt.Sprintf("Associate %s spawned: %s", record.Label(), truncate(task, 80)),
+	})
+
+	// Associates start cold, so their first message carries a turf briefing
+	turfBrief := turfBriefing(ctx, turf)
 
 	// Execute the task in a background goroutine
 	ctx.TaskWg.Add(1)
-	go func(a *agent.Agent, agentID string, taskDesc string, linkedBeadID string, reg *registry.Registry, beadStore *storage.BeadStore, notifyMgr interface {
+	go func(a *agent.Agent, agentID string, label string, taskDesc string, linkedBeadID string, reg *registry.Registry, beadStore *storage.BeadStore, notifyMgr interface {
 		NotifyTaskComplete(beadID, title, assignee string) error
 		NotifyAgentError(agentName, agentID, errorMsg string) error
 	}) {
 		defer ctx.TaskWg.Done()
+		// Runs before Done: the slot this associate held goes to the next queued spawn
+		defer drainSpawnQueue(ctx)
 
 		// Update status to working
-		reg.UpdateStatus(agentID, "working")
+		reg.UpdateStatus(agentID, registry.StatusWorking)
+
+		// Execute the task, recording the session for post-mortems and the
+		// process so abort_bead can stop it
+		sessionRecorded := false
+		a.SetOnProcess(func(pid int) { reg.UpdatePID(agentID, pid) })
+		resp, err := a.ChatStream(briefing.Prepend(turfBrief, taskDesc), func(block agent.ChatContentBlock) {
+			if !sessionRecorded && a.SessionID != "" {
+				reg.UpdateSession(agentID, a.SessionID)
+				sessionRecorded = true
+			}
+		})
 
-		// Execute the task
-		_, err := a.Chat(taskDesc)
+		// An aborted associate was stopped on purpose; abort_bead already reset its bead
+		if err != nil && wasAborted(reg, agentID) {
+			log.Printf("Associate %s aborted", label)
+			return
+		}
+
+		if resp != nil {
+			entry := models.UsageEntry{Agent: a.Name, Turf: turf, BeadID: linkedBeadID, Model: resp.Model,
+				InputTokens: resp.InputTokens, OutputTokens: resp.OutputTokens, CostUSD: resp.TotalCost}
+			if uerr := storage.RecordCall(ctx.MobDir, entry); uerr != nil {
+				log.Printf("Warning: failed to record usage for associate %s: %v", label, uerr)
+			}
+		}
 
 		// Update status based on result (CompletedAt is set automatically by UpdateStatus)
 		if err != nil {
-			log.Printf("Associate %s failed: %v", agentID, err)
-			reg.UpdateStatus(agentID, "failed")
+			log.Printf("Associate %s failed: %v", label, err)
+			reg.UpdateStatus(agentID, registry.StatusFailed)
 
 			// Send error notification
 			if notifyMgr != nil {
-				if notifyErr := notifyMgr.NotifyAgentError("Associate", agentID, err.Error()); notifyErr != nil {
+				if notifyErr := notifyMgr.NotifyAgentError(label, agentID, err.Error()); notifyErr != nil {
 					log.Printf("Warning: failed to send error notification: %v", notifyErr)
 				}
 			}
 
-			// If linked to a bead, mark it as blocked
+			// If linked to a bead, it waits blocked in the failure queue for a retry
 			if linkedBeadID != "" && beadStore != nil {
-				if bead, berr := beadStore.Get(linkedBeadID); berr == nil {
-					bead.Status = models.BeadStatusBlocked
-					bead.CloseReason = fmt.Sprintf("associate %s failed: %v", agentID, err)
-					beadStore.Update(bead)
-					log.Printf("Bead %s marked as blocked due to associate failure", linkedBeadID)
+				queue := failures.Open(ctx.MobDir, loadConfig(ctx.MobDir).Failures)
+				entry, ferr := queue.Fail(beadStore, failures.Failure{BeadID: linkedBeadID, Agent: label, Err: err}, time.Now())
+				if ferr != nil {
+					log.Printf("Warning: failed to queue bead %s for retry: %v", linkedBeadID, ferr)
+				}
+				if entry != nil {
+					log.Printf("Bead %s blocked after associate failure (attempt %d, %s)", linkedBeadID, entry.Attempts, entry.Status)
+				}
+			}
+
+			// File a post-mortem so the failure becomes trackable work
+			if beadStore != nil {
+				pm, pmErr := postmortem.CreateBead(beadStore, postmortem.Failure{
+					AgentID:   agentID,
+					AgentName: a.Name,
+					Turf:      a.Turf,
+					BeadID:    linkedBeadID,
+					Task:      taskDesc,
+					Reason:    "failed",
+					Error:     err.Error(),
+					Output:    a.RecentOutput(postmortem.DefaultOutputLines),
+					SessionID: a.SessionID,
+					WorkDir:   a.WorkDir,
+				}, "mcp")
+				if pmErr != nil {
+					log.Printf("Warning: failed to file post-mortem for associate %s: %v", label, pmErr)
+				} else {
+					log.Printf("Post-mortem %s filed for associate %s", pm.ID, label)
 				}
 			}
 		} else {
-			reg.UpdateStatus(agentID, "completed")
+			reg.UpdateStatus(agentID, registry.StatusCompleted)
 
-			// If linked to a bead, auto-complete it
+			// If linked to a bead, record what the associate did and auto-complete it
 			if linkedBeadID != "" && beadStore != nil {
-				if bead, berr := beadStore.Get(linkedBeadID); berr == nil {
-					bead.Status = models.BeadStatusClosed
-					now := time.Now()
-					bead.ClosedAt = &now
-					bead.CloseReason = fmt.Sprintf("completed by associate %s", agentID)
-					beadStore.Update(bead)
-					log.Printf("Bead %s auto-completed by associate %s", linkedBeadID, agentID)
-
-					// Send completion notification
-					if notifyMgr != nil {
-						if notifyErr := notifyMgr.NotifyTaskComplete(linkedBeadID, bead.Title, "Associate"); notifyErr != nil {
-							log.Printf("Warning: failed to send completion notification: %v", notifyErr)
-						}
-					}
-				}
+				finishAssociateBead(ctx, linkedBeadID, a.Name, label, resp)
 			}
 		}
-	}(spawnedAgent, spawnedAgent.ID, task, beadID, ctx.Registry, ctx.BeadStore, ctx.NotifyManager)
+	}(spawnedAgent, spawnedAgent.ID, record.Label(), task, beadID, ctx.Registry, ctx.BeadStore, ctx.NotifyManager)
 
-	result := fmt.Sprintf("Associate spawned and working. ID: %s, Task: %s", spawnedAgent.ID, truncate(task, 50))
-	if beadID != "" {
-		result += fmt.Sprintf(", Linked Bead: %s", beadID)
+	return record, nil
+}
+
+// finishAssociateBead posts what an associate did on its linked bead and
+// completes the bead the way complete_bead would, through the checklist,
+// test and review gates. A bead the gates hold back is blocked with the
+// reason, since the associate that could fix it has finished.
+func finishAssociateBead(ctx *ToolContext, beadID, name, label string, resp *agent.ChatResponse) {
+	author := "associate " + name
+	if err := ctx.BeadStore.AddComment(beadID, author, agent.Summarize(resp).Format(author)); err != nil {
+		log.Printf("Warning: failed to post work summary on bead %s: %v", beadID, err)
+	}
+
+	bead, err := ctx.BeadStore.Get(beadID)
+	if err != nil {
+		log.Printf("Warning: failed to load bead %s to complete it: %v", beadID, err)
+		return
+	}
+	if resp != nil {
+		bead.CostUSD += resp.TotalCost
+	}
+	if bead.Assignee == "" {
+		bead.Assignee = label
+	}
+	if bead, err = ctx.BeadStore.Update(bead); err != nil {
+		log.Printf("Warning: failed to record associate %s on bead %s: %v", label, beadID, err)
+		return
+	}
+
+	if _, err := completeBead(ctx, bead, fmt.Sprintf("completed by associate %s", name), ""); err != nil {
+		log.Printf("Bead %s not auto-completed after associate %s: %v", beadID, label, err)
+		if cerr := ctx.BeadStore.AddComment(beadID, author, "Not completed: "+err.Error()); cerr != nil {
+			log.Printf("Warning: failed to record why bead %s was held: %v", beadID, cerr)
+		}
+		if held, gerr := ctx.BeadStore.Get(beadID); gerr == nil {
+			held.Status = models.BeadStatusBlocked
+			ctx.BeadStore.Update(held)
+		}
+		return
+	}
+
+	log.Printf("Bead %s auto-completed by associate %s", beadID, label)
+	if err := failures.Open(ctx.MobDir, config.FailuresConfig{}).Resolve(beadID); err != nil {
+		log.Printf("Warning: failed to clear bead %s from the failure queue: %v", beadID, err)
 	}
-	return result, nil
 }
 
 func handleListAgents(ctx *ToolContext, args map[string]interface{}) (string, error) {
@@ -776,6 +1276,16 @@ func handleListAgents(ctx *ToolContext, args map[string]interface{}) (string, er
 	if err != nil {
 		return "", fmt.Errorf("failed to list agents: %w", err)
 	}
+	if ctx.Turf != "" {
+		agents = slices.DeleteFunc(agents, func(a *registry.AgentRecord) bool { return a.Turf != ctx.Turf })
+	}
+
+	cfg := loadConfig(ctx.MobDir).MCP
+	opts, err := parseListOptions(args, cfg, defaultAgentFields, agentFieldNames)
+	if err != nil {
+		return "", err
+	}
+	opts.text = textLimitFor(ctx, "list_agents", args, cfg, 60)
 
 	if len(agents) == 0 {
 		return "No agents on the payroll right now.", nil
@@ -788,6 +1298,13 @@ func handleListAgents(ctx *ToolContext, args map[string]interface{}) (string, er
 		log.Printf("Warning: failed to create soldati manager: %v", err)
 	}
 
+	if opts.format == ListFormatCompact {
+		return compactAgents(agents, opts, soldatiMgr), nil
+	}
+	if opts.limit > 0 && len(agents) > opts.limit {
+		agents = agents[:opts.limit]
+	}
+
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("The crew (%d members):\n\n", len(agents)))
 
@@ -798,6 +1315,9 @@ func handleListAgents(ctx *ToolContext, args map[string]interface{}) (string, er
 		}
 		sb.WriteString(fmt.Sprintf("- %s [%s] (ID: %s)\n", name, a.Type, a.ID))
 		sb.WriteString(fmt.Sprintf("  Turf: %s, Status: %s\n", a.Turf, a.Status))
+		if a.Tag != "" {
+			sb.WriteString(fmt.Sprintf("  Tag: %s\n", a.Tag))
+		}
 
 		// For soldati, show turf assignments
 		if a.Type == "soldati" && soldatiMgr != nil && a.Name != "" {
@@ -809,11 +1329,14 @@ func handleListAgents(ctx *ToolContext, args map[string]interface{}) (string, er
 					}
 					sb.WriteString("\n")
 				}
+				if s.AutoScaled {
+					sb.WriteString("  Added by the daemon for the turf's backlog; retired once it drains\n")
+				}
 			}
 		}
 
 		if a.Task != "" {
-			sb.WriteString(fmt.Sprintf("  Current job: %s\n", truncate(a.Task, 60)))
+			sb.WriteString(fmt.Sprintf("  Current job: %s\n", opts.text.cutFor(a.BeadID, a.Task)))
 		}
 		sb.WriteString(fmt.Sprintf("  Last seen: %s\n", a.LastPing.Format(time.RFC3339)))
 		sb.WriteString("\n")
@@ -887,11 +1410,7 @@ func handleKillAgent(ctx *ToolContext, args map[string]interface{}) (string, err
 		}
 	}
 
-	displayName := agent.Name
-	if displayName == "" {
-		displayName = agent.ID
-	}
-	return fmt.Sprintf("Agent '%s' has been sent home.", displayName), nil
+	return fmt.Sprintf("Agent '%s' has been sent home.", agent.Label()), nil
 }
 
 func handleNudgeAgent(ctx *ToolContext, args map[string]interface{}) (string, error) {
@@ -921,11 +1440,7 @@ func handleNudgeAgent(ctx *ToolContext, args map[string]interface{}) (string, er
 		return "", fmt.Errorf("failed to nudge agent: %w", err)
 	}
 
-	displayName := agent.Name
-	if displayName == "" {
-		displayName = agent.ID
-	}
-	return fmt.Sprintf("Nudged '%s'. They better be working.", displayName), nil
+	return fmt.Sprintf("Nudged '%s'. They better be working.", agent.Label()), nil
 }
 
 func handleAssignBead(ctx *ToolContext, args map[string]interface{}) (string, error) {
@@ -973,6 +1488,11 @@ func handleAssignBead(ctx *ToolContext, args map[string]interface{}) (string, er
 				return "", fmt.Errorf("bead %s is pending approval - use 'mob approve %s' to approve it before assigning", beadID, beadID)
 			}
 
+			// Don't branch new work off a turf checkout in a confusing state
+			if err := CheckTurfHealth(ctx, bead); err != nil {
+				return "", err
+			}
+
 			// Update assignee to the agent's name (or ID if no name)
 			assigneeName := agentRecord.Name
 			if assigneeName == "" {
@@ -985,16 +1505,29 @@ func handleAssignBead(ctx *ToolContext, args map[string]interface{}) (string, er
 			if bead.Turf != "" && ctx.TurfManager != nil {
 				turfInfo, err := ctx.TurfManager.Get(bead.Turf)
 				if err == nil {
-					// Create worktree manager for this turf's repo
-					wtMgr, err := git.NewWorktreeManager(turfInfo.Path)
+					// Open this turf's repo with its version control system
+					wtMgr, err := vcs.Open(turfInfo.Path, turfInfo.VCS)
 					if err == nil {
+						// Make room under the turf's worktree quota before adding another
+						policy := loadConfig(ctx.MobDir).Worktrees.PolicyFor(bead.Turf)
+						if _, getErr := wtMgr.Get(beadID); getErr != nil {
+							result, err := quota.Enforce(wtMgr, policy, ctx.BeadStore, 1)
+							if errors.Is(err, quota.ErrQuotaExceeded) {
+								return "", fmt.Errorf("turf %s is at its worktree quota (%d) and every worktree is in use or has uncommitted changes; finish or close a bead first", bead.Turf, policy.MaxCount)
+							} else if err != nil {
+								log.Printf("Warning: worktree quota check failed for turf %s: %v", bead.Turf, err)
+							} else if len(result.Evicted) > 0 {
+								log.Printf("Evicted idle worktrees in turf %s: %v", bead.Turf, result.Evicted)
+							}
+						}
+
 						// Try to create worktree (may already exist)
-						wt, err := wtMgr.Create(beadID)
+						wt, err := wtMgr.CreateWithOptions(beadID, quota.CreateOptions(policy))
 						if err == nil {
 							worktreePath = wt.Path
 							bead.WorktreePath = worktreePath
 							log.Printf("Created worktree for bead %s at %s", beadID, worktreePath)
-						} else if err == git.ErrWorktreeExists {
+						} else if errors.Is(err, vcs.ErrWorkspaceExists) {
 							// Worktree already exists, get its path
 							wt, _ := wtMgr.Get(beadID)
 							if wt != nil {
@@ -1022,7 +1555,7 @@ func handleAssignBead(ctx *ToolContext, args map[string]interface{}) (string, er
 	}
 
 	// Update status to active
-	if err := ctx.Registry.UpdateStatus(agentRecord.ID, "active"); err != nil {
+	if err := ctx.Registry.UpdateStatus(agentRecord.ID, registry.StatusActive); err != nil {
 		return "", fmt.Errorf("failed to update status: %w", err)
 	}
 
@@ -1043,11 +1576,8 @@ func handleAssignBead(ctx *ToolContext, args map[string]interface{}) (string, er
 		return "", fmt.Errorf("failed to write hook: %w", err)
 	}
 
-	displayName := agentRecord.Name
-	if displayName == "" {
-		displayName = agentRecord.ID
-	}
-	result := fmt.Sprintf("Assigned work to '%s': %s", displayName, truncate(taskDesc, 50))
+	limit := textLimitFor(ctx, "assign_bead", args, loadConfig(ctx.MobDir).MCP, 50)
+	result := fmt.Sprintf("Assigned work to '%s': %s", agentRecord.Label(), limit.cutFor(beadID, taskDesc))
 	if worktreePath != "" {
 		result += fmt.Sprintf("\nWorktree: %s", worktreePath)
 	}
@@ -1093,7 +1623,7 @@ func handleCreateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 	if turf, ok := args["turf"].(string); ok {
 		bead.Turf = turf
 	}
-	if labels, ok := args["labels"].(string); ok {
+	if labels, ok := labelsArg(args); ok {
 		bead.Labels = labels
 	}
 	if parentID, ok := args["parent_id"].(string); ok {
@@ -1115,6 +1645,13 @@ func handleCreateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 			}
 		}
 	}
+	bead.AddChecklistItems(stringArgs(args, "checklist")...)
+	if err := applyDueArgs(bead, args); err != nil {
+		return "", err
+	}
+	if err := applyTimeEstimateArg(bead, args); err != nil {
+		return "", err
+	}
 
 	// Create the bead
 	createdBead, err := ctx.BeadStore.Create(bead)
@@ -1136,6 +1673,9 @@ func handleCreateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 	sb.WriteString(fmt.Sprintf("Type: %s\n", createdBead.Type))
 	sb.WriteString(fmt.Sprintf("Priority: %d\n", createdBead.Priority))
 	sb.WriteString(fmt.Sprintf("Status: %s\n", createdBead.Status))
+	if len(createdBead.Checklist) > 0 {
+		sb.WriteString(fmt.Sprintf("Checklist:\n%s\n", createdBead.FormatChecklist()))
+	}
 	if createdBead.Status == models.BeadStatusPendingApproval {
 		sb.WriteString("\n⚠ This bead requires approval before work can start.\n")
 		sb.WriteString(fmt.Sprintf("Approve with: mob approve %s\n", createdBead.ID))
@@ -1144,7 +1684,8 @@ func handleCreateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 		sb.WriteString(fmt.Sprintf("Turf: %s\n", createdBead.Turf))
 	}
 	if createdBead.Description != "" {
-		sb.WriteString(fmt.Sprintf("Description: %s\n", truncate(createdBead.Description, 100)))
+		limit := textLimitFor(ctx, "create_bead", args, loadConfig(ctx.MobDir).MCP, 100)
+		sb.WriteString(fmt.Sprintf("Description: %s\n", limit.cut(createdBead.Description)))
 	}
 	sb.WriteString(fmt.Sprintf("Branch: %s\n", createdBead.Branch))
 
@@ -1171,15 +1712,42 @@ func handleListBeads(ctx *ToolContext, args map[string]interface{}) (string, err
 	if beadType, ok := args["type"].(string); ok && beadType != "" {
 		filter.Type = models.BeadType(beadType)
 	}
+	if label, ok := args["label"].(string); ok {
+		filter.Label = label
+	}
+
+	cfg := loadConfig(ctx.MobDir).MCP
+	opts, err := parseListOptions(args, cfg, defaultBeadFields, beadFieldNames)
+	if err != nil {
+		return "", err
+	}
+	opts.text = textLimitFor(ctx, "list_beads", args, cfg, descriptionLength(cfg))
 
 	beads, err := ctx.BeadStore.List(filter)
 	if err != nil {
 		return "", fmt.Errorf("failed to list beads: %w", err)
 	}
 
+	// Closed work is rarely what an agent is looking for
+	if includeClosed, _ := args["include_closed"].(bool); filter.Status == "" && !includeClosed {
+		open := beads[:0]
+		for _, b := range beads {
+			if b.Status != models.BeadStatusClosed {
+				open = append(open, b)
+			}
+		}
+		beads = open
+	}
+
 	if len(beads) == 0 {
 		return "No jobs on the board matching those filters.", nil
 	}
+	if opts.format == ListFormatCompact {
+		return compactBeads("beads", beads, opts), nil
+	}
+	if opts.limit > 0 && len(beads) > opts.limit {
+		beads = beads[:opts.limit]
+	}
 
 	// Priority labels for display
 	priorityLabels := []string{"🔴 Critical", "🟠 High", "🟡 Medium", "🔵 Low", "⚪ Lowest"}
@@ -1218,19 +1786,26 @@ func handleListReadyBeads(ctx *ToolContext, args map[string]interface{}) (string
 	}
 
 	turf, _ := args["turf"].(string)
-	limit := 10 // Default limit
-	if limitArg, ok := args["limit"].(float64); ok {
-		limit = int(limitArg)
+	cfg := loadConfig(ctx.MobDir).MCP
+	cfg.ListLimit = 10 // Default limit
+	opts, err := parseListOptions(args, cfg, defaultBeadFields, beadFieldNames)
+	if err != nil {
+		return "", err
 	}
+	opts.text = textLimitFor(ctx, "list_ready_beads", args, cfg, descriptionLength(cfg))
 
 	beads, err := ctx.BeadStore.ListReady(turf)
 	if err != nil {
 		return "", fmt.Errorf("failed to list ready beads: %w", err)
 	}
 
+	if opts.format == ListFormatCompact && len(beads) > 0 {
+		return compactBeads("ready beads", beads, opts), nil
+	}
+
 	// Apply limit
-	if len(beads) > limit {
-		beads = beads[:limit]
+	if opts.limit > 0 && len(beads) > opts.limit {
+		beads = beads[:opts.limit]
 	}
 
 	if len(beads) == 0 {
@@ -1263,7 +1838,7 @@ func handleListReadyBeads(ctx *ToolContext, args map[string]interface{}) (string
 			sb.WriteString(fmt.Sprintf("  Turf: %s\n", bead.Turf))
 		}
 		if bead.Description != "" {
-			sb.WriteString(fmt.Sprintf("  Description: %s\n", truncate(bead.Description, 80)))
+			sb.WriteString(fmt.Sprintf("  Description: %s\n", opts.text.cutFor(bead.ID, bead.Description)))
 		}
 		sb.WriteString("\n")
 	}
@@ -1312,6 +1887,12 @@ func handleUpdateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 		return "", fmt.Errorf("bead not found: %w", err)
 	}
 
+	// Base the write on the revision the caller read, so the store refuses
+	// it if the bead moved on in between
+	if revision, ok := args["revision"].(float64); ok {
+		bead.Revision = int(revision)
+	}
+
 	// Update only fields that are provided
 	if title, ok := args["title"].(string); ok && title != "" {
 		bead.Title = title
@@ -1320,6 +1901,9 @@ func handleUpdateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 		bead.Description = description
 	}
 	if status, ok := args["status"].(string); ok && status != "" {
+		if err := checkStatusUpdate(bead, models.BeadStatus(status)); err != nil {
+			return "", err
+		}
 		bead.Status = models.BeadStatus(status)
 	}
 	if priority, ok := args["priority"].(float64); ok {
@@ -1328,7 +1912,7 @@ func handleUpdateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 	if assignee, ok := args["assignee"].(string); ok {
 		bead.Assignee = assignee
 	}
-	if labels, ok := args["labels"].(string); ok {
+	if labels, ok := labelsArg(args); ok {
 		bead.Labels = labels
 	}
 	if blocks, ok := args["blocks"].([]interface{}); ok {
@@ -1347,6 +1931,12 @@ func handleUpdateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 			}
 		}
 	}
+	if err := applyDueArgs(bead, args); err != nil {
+		return "", err
+	}
+	if err := applyTimeEstimateArg(bead, args); err != nil {
+		return "", err
+	}
 
 	// Save the updated bead
 	updatedBead, err := ctx.BeadStore.Update(bead)
@@ -1362,6 +1952,21 @@ func handleUpdateBead(ctx *ToolContext, args map[string]interface{}) (string, er
 	return string(data), nil
 }
 
+// checkStatusUpdate refuses the status changes update_bead must not make:
+// closing a bead and taking one out of review. Both go through tools that
+// enforce the checklist, test and review gates.
+func checkStatusUpdate(bead *models.Bead, status models.BeadStatus) error {
+	switch {
+	case status == bead.Status:
+		return nil
+	case status == models.BeadStatusClosed:
+		return errkind.New(errkind.Invalid, fmt.Sprintf("update_bead cannot close bead %s; use complete_bead so its checklist, tests and review are checked", bead.ID))
+	case bead.Status == models.BeadStatusInReview:
+		return errkind.New(errkind.Invalid, fmt.Sprintf("bead %s is in review; it leaves review through review_bead, or mob approve and mob reject", bead.ID))
+	}
+	return nil
+}
+
 func handleCompleteBead(ctx *ToolContext, args map[string]interface{}) (string, error) {
 	id, _ := args["id"].(string)
 	closeReason, _ := args["close_reason"].(string)
@@ -1380,6 +1985,43 @@ func handleCompleteBead(ctx *ToolContext, args map[string]interface{}) (string,
 		return "", fmt.Errorf("bead not found: %w", err)
 	}
 
+	override, _ := args["override_checklist"].(string)
+	return completeBead(ctx, bead, closeReason, override)
+}
+
+// completeBead holds a bead to the completion gates (acceptance checklist,
+// test gate and review gate) and merges and closes it once they pass.
+// complete_bead and associates finishing their linked bead both come
+// through here.
+func completeBead(ctx *ToolContext, bead *models.Bead, closeReason, override string) (string, error) {
+	// Beads already under review can only be closed by the reviewer
+	if bead.Status == models.BeadStatusInReview {
+		return "", fmt.Errorf("bead %s is awaiting review; use review_bead to approve or request changes", bead.ID)
+	}
+
+	// Acceptance criteria must be met (or explicitly overridden)
+	bead, err := checkChecklist(ctx, bead, override)
+	if err != nil {
+		return "", err
+	}
+
+	// A configured test command must have passed at the current HEAD
+	if err := checkTestGate(ctx, bead); err != nil {
+		return "", err
+	}
+
+	// Soldati work goes through the review gate when enabled
+	if mode := reviewGateMode(ctx.MobDir); mode != "" && isSoldatiOwned(ctx, bead) {
+		return requestReview(ctx, bead, mode, closeReason)
+	}
+
+	return mergeAndCloseBead(ctx, bead, closeReason)
+}
+
+// mergeAndCloseBead merges a bead's worktree branch (if any) and closes the bead.
+// If the merge fails the bead is marked blocked instead. While the daemon is
+// running the merge is handed to its scheduler and the bead closes once merged.
+func mergeAndCloseBead(ctx *ToolContext, bead *models.Bead, closeReason string) (string, error) {
 	var mergeResult *merge.MergeResult
 	var mergeErr error
 
@@ -1387,11 +2029,16 @@ func handleCompleteBead(ctx *ToolContext, args map[string]interface{}) (string,
 	if bead.WorktreePath != "" && bead.Turf != "" && ctx.TurfManager != nil {
 		turfInfo, err := ctx.TurfManager.Get(bead.Turf)
 		if err == nil {
+			if daemonRunning(ctx.MobDir) {
+				return queueMerge(ctx, bead, turfInfo, closeReason)
+			}
+
 			// Create merge queue for this repo
 			mq := merge.New(turfInfo.Path)
+			mq.SetVCS(turfInfo.VCS)
 
 			// Add the bead to merge queue
-			if err := mq.Add(bead.ID, bead.Branch, bead.Turf, bead.Blocks); err != nil && err != merge.ErrItemExists {
+			if err := mq.Add(bead.ID, bead.Branch, bead.Turf, bead.Blocks); err != nil && !errors.Is(err, merge.ErrItemExists) {
 				log.Printf("Warning: failed to add bead %s to merge queue: %v", bead.ID, err)
 			}
 
@@ -1400,28 +2047,45 @@ func handleCompleteBead(ctx *ToolContext, args map[string]interface{}) (string,
 			if mergeErr != nil {
 				log.Printf("Warning: merge processing error for bead %s: %v", bead.ID, mergeErr)
 			}
+		}
+	}
 
-			// If merge succeeded, clean up the worktree
-			if mergeResult != nil && mergeResult.Success {
-				wtMgr, err := git.NewWorktreeManager(turfInfo.Path)
-				if err == nil {
-					if err := wtMgr.Remove(bead.ID, true); err != nil {
-						log.Printf("Warning: failed to remove worktree for bead %s: %v", bead.ID, err)
-					} else {
-						log.Printf("Removed worktree and branch for bead %s", bead.ID)
-						bead.WorktreePath = "" // Clear the path since worktree is gone
-					}
-				}
-			} else if mergeResult != nil && !mergeResult.Success {
-				// Merge failed - mark bead as blocked instead of closed
-				bead.Status = models.BeadStatusBlocked
-				bead.CloseReason = fmt.Sprintf("merge failed: %s", mergeResult.Message)
-				if _, err := ctx.BeadStore.Update(bead); err != nil {
-					return "", fmt.Errorf("failed to update bead: %w", err)
+	return FinishMerge(ctx, bead, closeReason, mergeResult)
+}
+
+// FinishMerge applies the outcome of a merge to a bead. A successful merge
+// removes the worktree and closes the bead, a failed one marks it blocked,
+// and a nil result closes the bead without merging anything.
+func FinishMerge(ctx *ToolContext, bead *models.Bead, closeReason string, mergeResult *merge.MergeResult) (string, error) {
+	// If merge succeeded, clean up the worktree
+	if mergeResult != nil && mergeResult.Success && ctx.TurfManager != nil {
+		if turfInfo, err := ctx.TurfManager.Get(bead.Turf); err == nil {
+			wtMgr, err := vcs.Open(turfInfo.Path, turfInfo.VCS)
+			if err == nil {
+				if err := wtMgr.Remove(bead.ID, true); err != nil {
+					log.Printf("Warning: failed to remove worktree for bead %s: %v", bead.ID, err)
+				} else {
+					log.Printf("Removed worktree and branch for bead %s", bead.ID)
+					bead.WorktreePath = "" // Clear the path since worktree is gone
 				}
-				return fmt.Sprintf("Job '%s' merge failed: %s. Bead marked as blocked.", bead.Title, mergeResult.Message), nil
 			}
 		}
+	} else if mergeErr := mergeResult.Err(); mergeErr != nil {
+		// Merge failed - mark bead as blocked instead of closed
+		bead.Status = models.BeadStatusBlocked
+		bead.CloseReason = mergeErr.Error()
+		attachment := attachConflict(ctx, bead, mergeResult.Conflict)
+		if _, err := ctx.BeadStore.Update(bead); err != nil {
+			return "", fmt.Errorf("failed to update bead: %w", err)
+		}
+		if errors.Is(mergeErr, merge.ErrMergeConflict) {
+			msg := fmt.Sprintf("Job '%s' hit a %s. Bead marked as blocked until the conflict is resolved.", bead.Title, mergeErr)
+			if attachment != "" {
+				msg += " Conflicting hunks and both sides' diffs: " + attachment
+			}
+			return msg, nil
+		}
+		return fmt.Sprintf("Job '%s' %s. Bead marked as blocked.", bead.Title, mergeErr), nil
 	}
 
 	// Mark as completed
@@ -1435,8 +2099,7 @@ func handleCompleteBead(ctx *ToolContext, args map[string]interface{}) (string,
 	}
 
 	// Save the updated bead
-	_, err = ctx.BeadStore.Update(bead)
-	if err != nil {
+	if _, err := ctx.BeadStore.Update(bead); err != nil {
 		return "", fmt.Errorf("failed to complete bead: %w", err)
 	}
 
@@ -1458,8 +2121,39 @@ func handleCompleteBead(ctx *ToolContext, args map[string]interface{}) (string,
 	return result, nil
 }
 
+// loadConfig reads the mob config, falling back to defaults if it is missing or invalid
+func loadConfig(mobDir string) *config.Config {
+	cfg, err := config.Load(filepath.Join(mobDir, "config.toml"))
+	if err != nil {
+		return config.DefaultConfig()
+	}
+	return cfg
+}
+
 // GenerateMCPConfig creates an MCP config file for Claude
 func GenerateMCPConfig(mobDir string) (string, error) {
+	return writeMCPConfig(mobDir, filepath.Join(mobDir, ".mob", "mcp-config.json"))
+}
+
+// GenerateTurfMCPConfig creates an MCP config for agents working on one
+// turf: their server only lets them see and change that turf's beads,
+// agents and worktrees. An empty turf gives the unscoped config.
+func GenerateTurfMCPConfig(mobDir, turfName string) (string, error) {
+	if turfName == "" {
+		return GenerateMCPConfig(mobDir)
+	}
+	path := filepath.Join(mobDir, ".mob", "mcp", "turf-"+filepath.Base(turfName)+".json")
+	return writeMCPConfig(mobDir, path, "--turf", turfName)
+}
+
+// GenerateConfirmMCPConfig creates the unscoped MCP config with mutating
+// tool calls held for the user's approval, for an underboss driven from an
+// interactive chat
+func GenerateConfirmMCPConfig(mobDir string) (string, error) {
+	return writeMCPConfig(mobDir, filepath.Join(mobDir, ".mob", "mcp", "underboss-confirm.json"), "--confirm")
+}
+
+func writeMCPConfig(mobDir, configPath string, extraArgs ...string) (string, error) {
 	// Find the mob binary path
 	mobPath, err := os.Executable()
 	if err != nil {
@@ -1467,23 +2161,22 @@ func GenerateMCPConfig(mobDir string) (string, error) {
 	}
 
 	registryPath := filepath.Join(mobDir, ".mob", "agents.json")
+	args := append([]string{"mcp-server", "--registry", registryPath, "--mob-dir", mobDir}, extraArgs...)
 
 	config := map[string]interface{}{
 		"mcpServers": map[string]interface{}{
 			"mob-tools": map[string]interface{}{
 				"command": mobPath,
-				"args":    []string{"mcp-server", "--registry", registryPath, "--mob-dir", mobDir},
+				"args":    args,
 			},
 		},
 	}
 
 	// Ensure config directory exists
-	configDir := filepath.Join(mobDir, ".mob")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
 		return "", err
 	}
 
-	configPath := filepath.Join(configDir, "mcp-config.json")
 	data, _ := json.MarshalIndent(config, "", "  ")
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return "", err
@@ -1492,6 +2185,25 @@ func GenerateMCPConfig(mobDir string) (string, error) {
 	return configPath, nil
 }
 
+// attachConflict saves a captured merge conflict as an attachment on the
+// bead and adds its summary to the block reason. It returns the attachment
+// path, or "" when there was nothing to save.
+func attachConflict(ctx *ToolContext, bead *models.Bead, report *merge.ConflictReport) string {
+	if report == nil {
+		return ""
+	}
+	bead.CloseReason += "\n" + report.Summary()
+
+	attachment, err := storage.WriteAttachment(ctx.MobDir, bead.ID, "merge-conflict.md", []byte(report.Markdown()))
+	if err != nil {
+		log.Printf("Warning: failed to save merge conflict for bead %s: %v", bead.ID, err)
+		return ""
+	}
+	bead.Attachments = append(bead.Attachments, attachment)
+	bead.CloseReason += "\nDetails: " + attachment.Path
+	return attachment.Path
+}
+
 func handleCommentOnBead(ctx *ToolContext, args map[string]interface{}) (string, error) {
 	beadID, _ := args["bead_id"].(string)
 	comment, _ := args["comment"].(string)
@@ -1527,6 +2239,9 @@ func handleListTurfs(ctx *ToolContext, args map[string]interface{}) (string, err
 	}
 
 	turfs := ctx.TurfManager.List()
+	if ctx.Turf != "" {
+		turfs = slices.DeleteFunc(turfs, func(t models.Turf) bool { return t.Name != ctx.Turf })
+	}
 
 	if len(turfs) == 0 {
 		return "No turfs registered. Use 'mob turf add' to register a project.", nil
@@ -1587,6 +2302,7 @@ func handleReportBlocked(ctx *ToolContext, args map[string]interface{}) (string,
 	if err != nil {
 		return "", fmt.Errorf("failed to create report: %w", err)
 	}
+	recordReport(ctx, createdReport)
 
 	// If associated with a bead, mark the bead as blocked
 	if beadID != "" && ctx.BeadStore != nil {
@@ -1629,6 +2345,7 @@ func handleReportQuestion(ctx *ToolContext, args map[string]interface{}) (string
 	if err != nil {
 		return "", fmt.Errorf("failed to create report: %w", err)
 	}
+	recordReport(ctx, createdReport)
 
 	data, _ := json.MarshalIndent(createdReport, "", "  ")
 	return fmt.Sprintf("Question filed (ID: %s):\n%s", createdReport.ID, string(data)), nil
@@ -1662,6 +2379,7 @@ func handleReportEscalation(ctx *ToolContext, args map[string]interface{}) (stri
 	if err != nil {
 		return "", fmt.Errorf("failed to create report: %w", err)
 	}
+	recordReport(ctx, createdReport)
 
 	data, _ := json.MarshalIndent(createdReport, "", "  ")
 	return fmt.Sprintf("Escalation filed (ID: %s):\n%s", createdReport.ID, string(data)), nil
@@ -1695,6 +2413,7 @@ func handleReportProgress(ctx *ToolContext, args map[string]interface{}) (string
 	if err != nil {
 		return "", fmt.Errorf("failed to create report: %w", err)
 	}
+	recordReport(ctx, createdReport)
 
 	data, _ := json.MarshalIndent(createdReport, "", "  ")
 	return fmt.Sprintf("Progress reported (ID: %s):\n%s", createdReport.ID, string(data)), nil
@@ -1733,6 +2452,7 @@ func handleListReports(ctx *ToolContext, args map[string]interface{}) (string, e
 	}
 
 	var sb strings.Builder
+	limit := textLimitFor(ctx, "list_reports", args, loadConfig(ctx.MobDir).MCP, 100)
 	sb.WriteString(fmt.Sprintf("Found %d report(s):\n\n", len(reports)))
 
 	for _, r := range reports {
@@ -1749,7 +2469,7 @@ func handleListReports(ctx *ToolContext, args map[string]interface{}) (string, e
 		if r.BeadID != "" {
 			sb.WriteString(fmt.Sprintf("  Bead: %s\n", r.BeadID))
 		}
-		sb.WriteString(fmt.Sprintf("  Message: %s\n", truncate(r.Message, 100)))
+		sb.WriteString(fmt.Sprintf("  Message: %s\n", limit.cutFor(r.BeadID, r.Message)))
 		sb.WriteString("\n")
 	}
 
@@ -1775,3 +2495,93 @@ func handleMarkReportHandled(ctx *ToolContext, args map[string]interface{}) (str
 
 	return fmt.Sprintf("Report %s marked as handled.", report.ID), nil
 }
+
+// Messaging handlers
+
+// callerAgentName resolves the calling agent's name from an explicit argument,
+// falling back to the environment and then to the underboss
+func callerAgentName(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if name := os.Getenv("MOB_AGENT_NAME"); name != "" {
+		return name
+	}
+	return "underboss"
+}
+
+// handleSendMessageToAgent delivers a message to another agent's inbox. The
+// sender is the agent identity the server was started with, so an agent
+// cannot send mail as the underboss or as another soldati.
+func handleSendMessageToAgent(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	to, _ := args["to"].(string)
+	message, _ := args["message"].(string)
+	beadID, _ := args["bead_id"].(string)
+
+	if to == "" {
+		return "", fmt.Errorf("to is required")
+	}
+	if message == "" {
+		return "", fmt.Errorf("message is required")
+	}
+
+	messageStore, err := storage.NewMessageStore(filepath.Join(ctx.MobDir, ".mob", "inbox"))
+	if err != nil {
+		return "", fmt.Errorf("failed to create message store: %w", err)
+	}
+
+	sent, err := messageStore.Send(&models.AgentMessage{
+		From:   callerAgentName(""),
+		To:     to,
+		BeadID: beadID,
+		Body:   message,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return fmt.Sprintf("Message %s delivered to %s's inbox.", sent.ID, sent.To), nil
+}
+
+// handleReadMessages reads the calling agent's own inbox. The reader comes
+// from the agent identity the server was started with, never an argument, so
+// one agent cannot read (and mark read) another's messages.
+func handleReadMessages(ctx *ToolContext, args map[string]interface{}) (string, error) {
+	includeRead, _ := args["include_read"].(bool)
+	agentName := callerAgentName("")
+
+	messageStore, err := storage.NewMessageStore(filepath.Join(ctx.MobDir, ".mob", "inbox"))
+	if err != nil {
+		return "", fmt.Errorf("failed to create message store: %w", err)
+	}
+
+	messages, err := messageStore.List(agentName, !includeRead)
+	if err != nil {
+		return "", fmt.Errorf("failed to read messages: %w", err)
+	}
+
+	if len(messages) == 0 {
+		return fmt.Sprintf("No new messages for %s.", agentName), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d message(s) for %s:\n\n", len(messages), agentName))
+
+	var unreadIDs []string
+	for _, m := range messages {
+		sb.WriteString(fmt.Sprintf("• [%s] from %s - %s\n", m.ID, m.From, m.Timestamp.Format(time.RFC3339)))
+		if m.BeadID != "" {
+			sb.WriteString(fmt.Sprintf("  Bead: %s\n", m.BeadID))
+		}
+		sb.WriteString(fmt.Sprintf("  %s\n\n", m.Body))
+		if !m.Read {
+			unreadIDs = append(unreadIDs, m.ID)
+		}
+	}
+
+	if err := messageStore.MarkRead(agentName, unreadIDs); err != nil {
+		return "", fmt.Errorf("failed to mark messages as read: %w", err)
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestHandleUpdateBeadRevision(t *testing.T) {
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Add endpoint", Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ToolContext{Context: context.Background(), BeadStore: store}
+
+	if _, err := handleUpdateBead(ctx, map[string]interface{}{"id": bead.ID, "revision": float64(bead.Revision), "priority": float64(1)}); err != nil {
+		t.Fatalf("update at the current revision failed: %v", err)
+	}
+
+	// A second agent still holding the first revision is turned away
+	_, err = handleUpdateBead(ctx, map[string]interface{}{"id": bead.ID, "revision": float64(bead.Revision), "title": "Stale"})
+	if !errors.Is(err, storage.ErrBeadConflict) {
+		t.Fatalf("expected ErrBeadConflict, got %v", err)
+	}
+	got, _ := store.Get(bead.ID)
+	if got.Title != "Add endpoint" || got.Priority != 1 {
+		t.Errorf("unexpected bead after conflict: %+v", got)
+	}
+
+	// Without a revision the update applies to the bead as it is now
+	if _, err := handleUpdateBead(ctx, map[string]interface{}{"id": bead.ID, "title": "Add users endpoint"}); err != nil {
+		t.Fatalf("update without a revision failed: %v", err)
+	}
+}
+
+func TestHandleUpdateBeadLeavesClosingAndReviewToTheirTools(t *testing.T) {
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ToolContext{Context: context.Background(), BeadStore: store}
+	working, err := store.Create(&models.Bead{Title: "Add endpoint", Status: models.BeadStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	review, err := store.Create(&models.Bead{Title: "Add login", Status: models.BeadStatusInReview})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		bead   *models.Bead
+		status string
+	}{
+		{working, "closed"},
+		{review, "closed"},
+		{review, "in_progress"},
+		{review, "open"},
+	} {
+		_, err := handleUpdateBead(ctx, map[string]interface{}{"id": tc.bead.ID, "status": tc.status})
+		if !errors.Is(err, errkind.Invalid) {
+			t.Errorf("%s -> %s: err = %v, want refused", tc.bead.Status, tc.status, err)
+		}
+		if got, _ := store.Get(tc.bead.ID); got.Status != tc.bead.Status {
+			t.Errorf("%s -> %s: bead is now %s, want unchanged", tc.bead.Status, tc.status, got.Status)
+		}
+	}
+
+	// Other moves, and edits that leave a bead in review, still go through
+	if _, err := handleUpdateBead(ctx, map[string]interface{}{"id": working.ID, "status": "blocked"}); err != nil {
+		t.Errorf("in_progress -> blocked: %v", err)
+	}
+	if _, err := handleUpdateBead(ctx, map[string]interface{}{"id": review.ID, "status": "in_review", "title": "Add login form"}); err != nil {
+		t.Errorf("editing a bead in review: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"os"
+
+	"github.com/gabe/mob/internal/config"
+)
+
+// includeFullProperty lets a caller lift a tool's truncation for one call
+var includeFullProperty = map[string]interface{}{
+	"type":        "boolean",
+	"description": "Return descriptions, tasks and messages in full instead of truncated",
+}
+
+// textLimit caps long text in a tool's output
+type textLimit struct {
+	max  int    // characters kept; 0 = everything
+	bead string // bead the calling agent is working on; its text is never cut
+}
+
+// cut truncates s to the limit
+func (l textLimit) cut(s string) string {
+	if l.max <= 0 {
+		return s
+	}
+	return truncate(s, l.max)
+}
+
+// cutFor truncates text belonging to beadID, unless the caller is working on
+// that bead and needs all of it
+func (l textLimit) cutFor(beadID, s string) string {
+	if beadID != "" && beadID == l.bead {
+		return s
+	}
+	return l.cut(s)
+}
+
+// textLimitFor returns how much long text a tool keeps: its [mcp.truncate]
+// entry if set, otherwise def. include_full in args lifts the limit.
+func textLimitFor(ctx *ToolContext, tool string, args map[string]interface{}, cfg config.MCPServerConfig, def int) textLimit {
+	limit := textLimit{max: def, bead: callerBead(ctx)}
+	if n, ok := cfg.Truncate[tool]; ok {
+		limit.max = n
+	}
+	if full, _ := args["include_full"].(bool); full {
+		limit.max = 0
+	}
+	return limit
+}
+
+// callerBead returns the bead the agent on this connection is working on, or
+// "" for the underboss and agents without one
+func callerBead(ctx *ToolContext) string {
+	if ctx.Registry == nil {
+		return ""
+	}
+	if id := os.Getenv("MOB_AGENT_ID"); id != "" {
+		if record, err := ctx.Registry.Get(id); err == nil {
+			return record.BeadID
+		}
+	}
+	if name := os.Getenv("MOB_AGENT_NAME"); name != "" {
+		if record, err := ctx.Registry.GetByName(name); err == nil {
+			return record.BeadID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestListBeads_Truncation(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	long := strings.Repeat("the whole spec matters ", 10)
+	mine, err := store.Create(&models.Bead{Title: "Mine", Status: models.BeadStatusOpen, Description: long})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := store.Create(&models.Bead{Title: "Other", Status: models.BeadStatusOpen, Description: long})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := registry.New(registry.DefaultPath(dir))
+	ctx := &ToolContext{BeadStore: store, Registry: reg, MobDir: dir}
+
+	descriptions := func(args map[string]interface{}) map[string]string {
+		t.Helper()
+		args["fields"] = "id,description"
+		out, err := handleListBeads(ctx, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string)
+		for _, line := range strings.Split(out, "\n")[2:] {
+			id, desc, _ := strings.Cut(line, "|")
+			got[id] = desc
+		}
+		return got
+	}
+	full := oneLine(long)
+
+	got := descriptions(map[string]interface{}{})
+	if len(got[mine.ID]) != 80 || !strings.HasSuffix(got[mine.ID], "...") {
+		t.Errorf("expected the default 80 characters, got %q", got[mine.ID])
+	}
+
+	got = descriptions(map[string]interface{}{"include_full": true})
+	if got[mine.ID] != full || got[other.ID] != full {
+		t.Errorf("expected include_full to keep whole descriptions, got %v", got)
+	}
+
+	// An agent working on a bead always sees all of it
+	if err := reg.Register(&registry.AgentRecord{ID: "a1", Name: "vinnie", Type: "soldati", BeadID: mine.ID}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MOB_AGENT_ID", "a1")
+	got = descriptions(map[string]interface{}{})
+	if got[mine.ID] != full {
+		t.Errorf("expected the caller's own bead in full, got %q", got[mine.ID])
+	}
+	if got[other.ID] == full {
+		t.Error("expected other beads still truncated")
+	}
+
+	// Per-tool limits come from [mcp.truncate]
+	config := "[mcp.truncate]\nlist_beads = 20\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got = descriptions(map[string]interface{}{})
+	if len(got[other.ID]) != 20 {
+		t.Errorf("expected the configured 20 characters, got %q", got[other.ID])
+	}
+}
+
+func TestTextLimit(t *testing.T) {
+	limit := textLimit{max: 10, bead: "bd-1"}
+	if got := limit.cut("0123456789abc"); got != "0123456..." {
+		t.Errorf("unexpected cut %q", got)
+	}
+	if got := limit.cutFor("bd-1", "0123456789abc"); got != "0123456789abc" {
+		t.Errorf("expected the caller's bead uncut, got %q", got)
+	}
+	if got := limit.cutFor("", "0123456789abc"); got != "0123456..." {
+		t.Errorf("expected text without a bead cut, got %q", got)
+	}
+	if got := (textLimit{}).cut("0123456789abc"); got != "0123456789abc" {
+		t.Errorf("expected no limit to keep everything, got %q", got)
+	}
+}
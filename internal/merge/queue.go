@@ -5,11 +5,19 @@ package merge
 import (
 	"errors"
 	"fmt"
-	"os/exec"
 	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+// Conflict reports are produced by the VCS backends
+type (
+	ConflictReport = vcs.ConflictReport
+	ConflictFile   = vcs.ConflictFile
 )
 
 // Status constants for queue items
@@ -23,9 +31,13 @@ const (
 
 var (
 	// ErrItemExists indicates an item with the same BeadID already exists in the queue
-	ErrItemExists = errors.New("item already exists in queue")
+	ErrItemExists = errkind.New(errkind.Conflict, "item already exists in queue")
 	// ErrItemNotFound indicates the item was not found in the queue
-	ErrItemNotFound = errors.New("item not found in queue")
+	ErrItemNotFound = errkind.New(errkind.NotFound, "item not found in queue")
+	// ErrMergeConflict indicates a branch could not merge cleanly
+	ErrMergeConflict = errkind.New(errkind.Conflict, "merge conflict")
+	// ErrMergeFailed indicates a merge failed for a reason other than conflicts
+	ErrMergeFailed = errors.New("merge failed")
 )
 
 // QueueItem represents a bead in the merge queue
@@ -40,10 +52,23 @@ type QueueItem struct {
 
 // MergeResult represents the result of a merge attempt
 type MergeResult struct {
-	Success       bool     // Whether the merge succeeded
-	BeadID        string   // ID of the bead that was processed
-	Message       string   // Descriptive message about the result
-	ConflictFiles []string // Files with conflicts (if any)
+	Success       bool            // Whether the merge succeeded
+	BeadID        string          // ID of the bead that was processed
+	Message       string          // Descriptive message about the result
+	ConflictFiles []string        // Files with conflicts (if any)
+	Conflict      *ConflictReport // Hunks and diffs of a conflicted merge, captured before it was aborted
+}
+
+// Err returns nil for a successful merge, ErrMergeConflict when files
+// conflicted and ErrMergeFailed for any other failure
+func (r *MergeResult) Err() error {
+	if r == nil || r.Success {
+		return nil
+	}
+	if len(r.ConflictFiles) > 0 {
+		return fmt.Errorf("%w in %s: %s", ErrMergeConflict, strings.Join(r.ConflictFiles, ", "), r.Message)
+	}
+	return fmt.Errorf("%w: %s", ErrMergeFailed, r.Message)
 }
 
 // Queue manages the merge queue for dependency-aware serial merging
@@ -53,14 +78,26 @@ type Queue struct {
 	mu         sync.RWMutex
 	onMerged   func(item *QueueItem)
 	onConflict func(item *QueueItem, result *MergeResult)
+	merge      func(item *QueueItem) *MergeResult
+	vcs        string // turf's configured VCS; empty = detect
 }
 
 // New creates a new merge queue for the given repository path
 func New(repoPath string) *Queue {
-	return &Queue{
+	q := &Queue{
 		items:    make([]*QueueItem, 0),
 		repoPath: repoPath,
 	}
+	q.merge = q.attemptMerge
+	return q
+}
+
+// SetVCS sets the version control system merges use ("git" or "jj"). By
+// default it is detected from the repository.
+func (q *Queue) SetVCS(kind string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.vcs = kind
 }
 
 // Add adds a bead to the merge queue
@@ -168,6 +205,20 @@ func (q *Queue) Next() *QueueItem {
 	return candidates[0]
 }
 
+// Depth returns the number of items waiting to merge or merging right now
+func (q *Queue) Depth() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	depth := 0
+	for _, item := range q.items {
+		if item.Status == StatusPending || item.Status == StatusMerging {
+			depth++
+		}
+	}
+	return depth
+}
+
 // List returns all items in the queue
 func (q *Queue) List() []*QueueItem {
 	q.mu.RLock()
@@ -198,7 +249,7 @@ func (q *Queue) Process() (*MergeResult, error) {
 	q.mu.Unlock()
 
 	// Attempt the merge
-	result := q.attemptMerge(next)
+	result := q.merge(next)
 
 	// Update status based on result
 	q.mu.Lock()
@@ -234,89 +285,26 @@ func (q *Queue) SetCallbacks(onMerged func(*QueueItem), onConflict func(*QueueIt
 	q.onConflict = onConflict
 }
 
-// attemptMerge performs the actual git merge operation
+// attemptMerge merges the item's branch with the repository's VCS
 func (q *Queue) attemptMerge(item *QueueItem) *MergeResult {
 	result := &MergeResult{
 		BeadID: item.BeadID,
 	}
 
-	// First, get the main branch name
-	mainBranch := q.getMainBranch()
-
-	// Make sure we're on the main branch
-	cmd := exec.Command("git", "checkout", mainBranch)
-	cmd.Dir = q.repoPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		result.Success = false
-		result.Message = fmt.Sprintf("failed to checkout %s: %s", mainBranch, string(output))
-		return result
-	}
-
-	// Attempt the merge
-	cmd = exec.Command("git", "merge", item.Branch, "--no-edit")
-	cmd.Dir = q.repoPath
-	output, err := cmd.CombinedOutput()
+	q.mu.RLock()
+	kind := q.vcs
+	q.mu.RUnlock()
 
+	repo, err := vcs.Open(q.repoPath, kind)
 	if err != nil {
-		// Check if it's a conflict
-		if strings.Contains(string(output), "CONFLICT") || strings.Contains(string(output), "Merge conflict") {
-			result.Success = false
-			result.Message = "merge conflict detected"
-			result.ConflictFiles = q.getConflictFiles()
-
-			// Abort the merge to clean up
-			abortCmd := exec.Command("git", "merge", "--abort")
-			abortCmd.Dir = q.repoPath
-			abortCmd.Run()
-
-			return result
-		}
-
-		result.Success = false
-		result.Message = fmt.Sprintf("merge failed: %s", string(output))
+		result.Message = fmt.Sprintf("failed to open repository: %v", err)
 		return result
 	}
 
-	result.Success = true
-	result.Message = fmt.Sprintf("successfully merged %s into %s", item.Branch, mainBranch)
+	merged := repo.Merge(item.Branch)
+	result.Success = merged.Success
+	result.Message = merged.Message
+	result.ConflictFiles = merged.ConflictFiles
+	result.Conflict = merged.Conflict
 	return result
 }
-
-// getMainBranch returns the main branch name (main or master)
-func (q *Queue) getMainBranch() string {
-	// Try "main" first
-	cmd := exec.Command("git", "rev-parse", "--verify", "main")
-	cmd.Dir = q.repoPath
-	if err := cmd.Run(); err == nil {
-		return "main"
-	}
-
-	// Try "master"
-	cmd = exec.Command("git", "rev-parse", "--verify", "master")
-	cmd.Dir = q.repoPath
-	if err := cmd.Run(); err == nil {
-		return "master"
-	}
-
-	// Fallback to main
-	return "main"
-}
-
-// getConflictFiles returns a list of files with merge conflicts
-func (q *Queue) getConflictFiles() []string {
-	cmd := exec.Command("git", "diff", "--name-only", "--diff-filter=U")
-	cmd.Dir = q.repoPath
-	output, err := cmd.Output()
-	if err != nil {
-		return nil
-	}
-
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var files []string
-	for _, line := range lines {
-		if line != "" {
-			files = append(files, line)
-		}
-	}
-	return files
-}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -414,6 +415,28 @@ func TestQueue_Process_Conflict(t *testing.T) {
 		t.Error("expected conflict files to be reported")
 	}
 
+	// The conflict is captured before the merge is aborted
+	report := result.Conflict
+	if report == nil || len(report.Files) != 1 || report.Files[0].Path != "shared.txt" {
+		t.Fatalf("expected a conflict report for shared.txt, got %+v", report)
+	}
+	if report.HunkCount() != 1 {
+		t.Errorf("expected 1 conflicting hunk, got %d", report.HunkCount())
+	}
+	hunk := report.Files[0].Hunks[0]
+	if !strings.Contains(hunk, "content from branch 1") || !strings.Contains(hunk, "different content from branch 2") {
+		t.Errorf("hunk should hold both sides:\n%s", hunk)
+	}
+	if report.Ours == "" || report.Theirs == "" {
+		t.Errorf("expected both commits in the report: %+v", report)
+	}
+	if md := report.Markdown(); !strings.Contains(md, "## shared.txt") || !strings.Contains(md, "mob/bd-002") {
+		t.Errorf("unexpected markdown:\n%s", md)
+	}
+	if !strings.Contains(report.Summary(), "shared.txt (1 hunk)") {
+		t.Errorf("unexpected summary: %s", report.Summary())
+	}
+
 	// Verify item status is now conflict
 	for _, item := range q.List() {
 		if item.BeadID == "bd-002" {
@@ -0,0 +1,184 @@
+package merge
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Request asks the daemon's scheduler to merge a finished bead's branch.
+// Requests are appended by MCP servers and drained by the daemon.
+type Request struct {
+	BeadID      string    `json:"bead_id"`
+	Branch      string    `json:"branch"`
+	Turf        string    `json:"turf"`
+	RepoPath    string    `json:"repo_path"`
+	VCS         string    `json:"vcs,omitempty"` // turf's configured VCS; empty = detect
+	BlockedBy   []string  `json:"blocked_by,omitempty"`
+	CloseReason string    `json:"close_reason,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// RequestsPath returns where pending merge requests are written
+func RequestsPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "merge-requests.jsonl")
+}
+
+// DepthsPath returns where the daemon publishes per-turf queue depths
+func DepthsPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "merge-queue.json")
+}
+
+// AppendRequest adds a merge request to the requests file
+func AppendRequest(path string, req Request) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open requests file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write request: %w", err)
+	}
+	return nil
+}
+
+// PendingPath returns where the daemon keeps merges it has claimed but not
+// yet finished, so a restart picks them up again
+func PendingPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "merge-pending.json")
+}
+
+// DrainRequests reads and removes every filed merge request.
+// The file is renamed before reading so requests appended meanwhile are kept for the next drain.
+// keep is called with the requests before they are removed; when it fails the
+// claimed file is left in place and its requests are returned again by the next drain.
+func DrainRequests(path string, keep func([]Request) error) ([]Request, error) {
+	draining := path + ".draining"
+	if _, err := os.Stat(draining); err != nil {
+		// No drain was interrupted, so claim the current file
+		if err := os.Rename(path, draining); err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to claim requests: %w", err)
+		}
+	}
+
+	requests, err := readRequests(draining)
+	if err != nil {
+		return nil, err
+	}
+	if keep != nil {
+		if err := keep(requests); err != nil {
+			return nil, fmt.Errorf("failed to keep requests: %w", err)
+		}
+	}
+	os.Remove(draining)
+	return requests, nil
+}
+
+// readRequests parses a file of JSON lines, skipping malformed ones
+func readRequests(path string) ([]Request, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open requests: %w", err)
+	}
+	defer f.Close()
+
+	var requests []Request
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var req Request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			continue // Skip malformed lines
+		}
+		requests = append(requests, req)
+	}
+	if err := scanner.Err(); err != nil {
+		return requests, fmt.Errorf("failed to read requests: %w", err)
+	}
+	return requests, nil
+}
+
+// SavePending replaces the daemon's record of unfinished merges
+func SavePending(path string, requests []Request) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	data, err := json.MarshalIndent(requests, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending merges: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write pending merges: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadPending reads the merges the daemon had not finished.
+// A missing file means there are none.
+func LoadPending(path string) ([]Request, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending merges: %w", err)
+	}
+
+	var requests []Request
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return nil, fmt.Errorf("failed to parse pending merges: %w", err)
+	}
+	return requests, nil
+}
+
+// SaveDepths publishes per-turf queue depths for status output
+func SaveDepths(path string, depths map[string]int) error {
+	data, err := json.MarshalIndent(depths, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal depths: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write depths: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadDepths reads the per-turf queue depths last published by the daemon.
+// A missing file means every queue is empty.
+func LoadDepths(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]int{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read depths: %w", err)
+	}
+
+	depths := make(map[string]int)
+	if err := json.Unmarshal(data, &depths); err != nil {
+		return nil, fmt.Errorf("failed to parse depths: %w", err)
+	}
+	return depths, nil
+}
@@ -0,0 +1,142 @@
+package merge
+
+import "sync"
+
+// ResultHandler is called after the scheduler processes an item for a turf.
+// The result names the processed bead even when err is set.
+type ResultHandler func(turf string, result *MergeResult, err error)
+
+// Scheduler shares one processing loop between the merge queues of several
+// turfs. Turfs take turns in round-robin order so a busy turf cannot starve
+// the others, and each turf has at most one merge in flight at a time since
+// merges check out the turf's main branch.
+type Scheduler struct {
+	queues        map[string]*Queue
+	order         []string
+	cursor        int
+	running       map[string]bool
+	inFlight      int
+	maxConcurrent int
+	onResult      ResultHandler
+	wg            sync.WaitGroup
+	mu            sync.Mutex
+}
+
+// NewScheduler creates a scheduler that runs at most maxConcurrent merges
+// across all turfs at once. Zero means one per turf with no global limit.
+func NewScheduler(maxConcurrent int) *Scheduler {
+	return &Scheduler{
+		queues:        make(map[string]*Queue),
+		running:       make(map[string]bool),
+		maxConcurrent: maxConcurrent,
+	}
+}
+
+// SetResultHandler sets the callback invoked after each processed item
+func (s *Scheduler) SetResultHandler(fn ResultHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onResult = fn
+}
+
+// Queue returns the queue for a turf, creating it on first use
+func (s *Scheduler) Queue(turf, repoPath string) *Queue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if q, ok := s.queues[turf]; ok {
+		return q
+	}
+	q := New(repoPath)
+	s.queues[turf] = q
+	s.order = append(s.order, turf)
+	return q
+}
+
+// Enqueue adds a bead's branch to its turf's queue. A bead whose earlier
+// attempt already finished (merged, conflicted or failed) is queued again;
+// one that is still pending or merging returns ErrItemExists.
+func (s *Scheduler) Enqueue(turf, repoPath, beadID, branch string, blockedBy []string) error {
+	q := s.Queue(turf, repoPath)
+	for _, item := range q.List() {
+		if item.BeadID != beadID {
+			continue
+		}
+		if item.Status == StatusPending || item.Status == StatusMerging {
+			return ErrItemExists
+		}
+		q.Remove(beadID)
+		break
+	}
+	return q.Add(beadID, branch, turf, blockedBy)
+}
+
+// Dispatch starts processing on every turf that has an item ready and no
+// merge in flight, beginning with the turf after the one served last.
+// It returns the number of merges started; results arrive via the result handler.
+func (s *Scheduler) Dispatch() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	started := 0
+	start := s.cursor
+	n := len(s.order)
+	for i := 0; i < n; i++ {
+		if s.maxConcurrent > 0 && s.inFlight >= s.maxConcurrent {
+			break
+		}
+
+		idx := (start + i) % n
+		turf := s.order[idx]
+		q := s.queues[turf]
+		if s.running[turf] || q.Next() == nil {
+			continue
+		}
+
+		s.running[turf] = true
+		s.inFlight++
+		s.cursor = (idx + 1) % n
+		started++
+
+		s.wg.Add(1)
+		go s.process(turf, q, s.onResult)
+	}
+	return started
+}
+
+// process runs one item from a turf's queue and releases the turf
+func (s *Scheduler) process(turf string, q *Queue, onResult ResultHandler) {
+	defer s.wg.Done()
+
+	next := q.Next()
+	result, err := q.Process()
+	if err != nil && result == nil && next != nil {
+		result = &MergeResult{BeadID: next.BeadID, Message: err.Error()}
+	}
+
+	s.mu.Lock()
+	s.running[turf] = false
+	s.inFlight--
+	s.mu.Unlock()
+
+	if onResult != nil && (result != nil || err != nil) {
+		onResult(turf, result, err)
+	}
+}
+
+// Wait blocks until every dispatched merge has finished
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Depths returns the number of queued or merging items per turf
+func (s *Scheduler) Depths() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	depths := make(map[string]int, len(s.queues))
+	for turf, q := range s.queues {
+		depths[turf] = q.Depth()
+	}
+	return depths
+}
@@ -0,0 +1,233 @@
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubMerges replaces a queue's git merge with one that records the order
+// beads were merged in
+func stubMerges(q *Queue, mu *sync.Mutex, order *[]string) {
+	q.merge = func(item *QueueItem) *MergeResult {
+		mu.Lock()
+		*order = append(*order, item.BeadID)
+		mu.Unlock()
+		return &MergeResult{Success: true, BeadID: item.BeadID}
+	}
+}
+
+func TestScheduler_RoundRobinAcrossTurfs(t *testing.T) {
+	s := NewScheduler(1)
+
+	var mu sync.Mutex
+	var order []string
+	stubMerges(s.Queue("busy", "/tmp/busy"), &mu, &order)
+	stubMerges(s.Queue("quiet", "/tmp/quiet"), &mu, &order)
+
+	for i := 1; i <= 4; i++ {
+		if err := s.Enqueue("busy", "/tmp/busy", fmt.Sprintf("busy-%d", i), "b", nil); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+		time.Sleep(time.Millisecond) // Keep AddedAt ordering stable
+	}
+	if err := s.Enqueue("quiet", "/tmp/quiet", "quiet-1", "b", nil); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	for s.Dispatch() > 0 {
+		s.Wait()
+	}
+
+	want := []string{"busy-1", "quiet-1", "busy-2", "busy-3", "busy-4"}
+	if len(order) != len(want) {
+		t.Fatalf("merged %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("merged %v, want %v", order, want)
+		}
+	}
+}
+
+func TestScheduler_OneMergePerTurf(t *testing.T) {
+	s := NewScheduler(0)
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	inFlight := map[string]int{}
+	maxSeen := map[string]int{}
+
+	for _, turf := range []string{"a", "b"} {
+		q := s.Queue(turf, "/tmp/"+turf)
+		q.merge = func(item *QueueItem) *MergeResult {
+			mu.Lock()
+			inFlight[item.Turf]++
+			if inFlight[item.Turf] > maxSeen[item.Turf] {
+				maxSeen[item.Turf] = inFlight[item.Turf]
+			}
+			mu.Unlock()
+
+			<-release
+
+			mu.Lock()
+			inFlight[item.Turf]--
+			mu.Unlock()
+			return &MergeResult{Success: true, BeadID: item.BeadID}
+		}
+		for i := 1; i <= 3; i++ {
+			s.Enqueue(turf, "/tmp/"+turf, fmt.Sprintf("%s-%d", turf, i), "b", nil)
+		}
+	}
+
+	if started := s.Dispatch(); started != 2 {
+		t.Errorf("first Dispatch started %d merges, want 2 (one per turf)", started)
+	}
+	if started := s.Dispatch(); started != 0 {
+		t.Errorf("Dispatch while busy started %d merges, want 0", started)
+	}
+
+	depths := s.Depths()
+	if depths["a"] != 3 || depths["b"] != 3 {
+		t.Errorf("Depths() = %v, want 3 for each turf", depths)
+	}
+
+	close(release)
+	s.Wait()
+	for s.Dispatch() > 0 {
+		s.Wait()
+	}
+
+	for turf, n := range maxSeen {
+		if n != 1 {
+			t.Errorf("turf %s had %d merges in flight, want 1", turf, n)
+		}
+	}
+	depths = s.Depths()
+	if depths["a"] != 0 || depths["b"] != 0 {
+		t.Errorf("Depths() after draining = %v, want 0", depths)
+	}
+}
+
+func TestScheduler_ResultHandlerAndRequeue(t *testing.T) {
+	s := NewScheduler(0)
+	q := s.Queue("turf", "/tmp/turf")
+	q.merge = func(item *QueueItem) *MergeResult {
+		return &MergeResult{Success: false, BeadID: item.BeadID, ConflictFiles: []string{"a.go"}}
+	}
+
+	var results []*MergeResult
+	s.SetResultHandler(func(turf string, result *MergeResult, err error) {
+		results = append(results, result)
+	})
+
+	s.Enqueue("turf", "/tmp/turf", "bd-1", "mob/bd-1", nil)
+	if err := s.Enqueue("turf", "/tmp/turf", "bd-1", "mob/bd-1", nil); err != ErrItemExists {
+		t.Errorf("Enqueue of pending bead = %v, want ErrItemExists", err)
+	}
+
+	s.Dispatch()
+	s.Wait()
+
+	if len(results) != 1 || results[0].Success {
+		t.Fatalf("results = %v, want one failed merge", results)
+	}
+
+	// A conflicted bead can be queued again once its branch is fixed
+	if err := s.Enqueue("turf", "/tmp/turf", "bd-1", "mob/bd-1", nil); err != nil {
+		t.Fatalf("re-Enqueue after conflict failed: %v", err)
+	}
+	if depth := s.Depths()["turf"]; depth != 1 {
+		t.Errorf("depth after requeue = %d, want 1", depth)
+	}
+}
+
+func TestRequests_AppendAndDrain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mob", "merge-requests.jsonl")
+
+	if reqs, err := DrainRequests(path, nil); err != nil || len(reqs) != 0 {
+		t.Fatalf("DrainRequests on missing file = %v, %v; want nothing", reqs, err)
+	}
+
+	for _, id := range []string{"bd-1", "bd-2"} {
+		if err := AppendRequest(path, Request{BeadID: id, Turf: "api", Branch: "mob/" + id}); err != nil {
+			t.Fatalf("AppendRequest failed: %v", err)
+		}
+	}
+
+	reqs, err := DrainRequests(path, nil)
+	if err != nil {
+		t.Fatalf("DrainRequests failed: %v", err)
+	}
+	if len(reqs) != 2 || reqs[0].BeadID != "bd-1" || reqs[1].Branch != "mob/bd-2" {
+		t.Errorf("DrainRequests = %+v", reqs)
+	}
+
+	if reqs, _ := DrainRequests(path, nil); len(reqs) != 0 {
+		t.Errorf("second drain returned %d requests, want 0", len(reqs))
+	}
+}
+
+func TestRequests_KeepFailureLeavesRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mob", "merge-requests.jsonl")
+	if err := AppendRequest(path, Request{BeadID: "bd-1", Turf: "api"}); err != nil {
+		t.Fatal(err)
+	}
+
+	failed := errors.New("disk full")
+	if _, err := DrainRequests(path, func([]Request) error { return failed }); !errors.Is(err, failed) {
+		t.Fatalf("DrainRequests = %v, want the keep error", err)
+	}
+
+	// The claimed requests come back once, with later ones left for the drain after
+	if err := AppendRequest(path, Request{BeadID: "bd-2", Turf: "api"}); err != nil {
+		t.Fatal(err)
+	}
+	var kept []Request
+	reqs, err := DrainRequests(path, func(r []Request) error { kept = r; return nil })
+	if err != nil || len(reqs) != 1 || reqs[0].BeadID != "bd-1" || len(kept) != 1 {
+		t.Fatalf("retried drain = %+v, %v; want bd-1 kept", reqs, err)
+	}
+	if reqs, _ := DrainRequests(path, nil); len(reqs) != 1 || reqs[0].BeadID != "bd-2" {
+		t.Errorf("next drain = %+v, want bd-2", reqs)
+	}
+}
+
+func TestPending_SaveAndLoad(t *testing.T) {
+	path := PendingPath(t.TempDir())
+
+	if reqs, err := LoadPending(path); err != nil || len(reqs) != 0 {
+		t.Fatalf("LoadPending on missing file = %v, %v; want nothing", reqs, err)
+	}
+	want := []Request{{BeadID: "bd-1", Turf: "api", Branch: "mob/bd-1", CloseReason: "done"}}
+	if err := SavePending(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPending(path)
+	if err != nil || len(got) != 1 || got[0].BeadID != "bd-1" || got[0].CloseReason != "done" {
+		t.Errorf("LoadPending = %+v, %v", got, err)
+	}
+}
+
+func TestDepths_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "merge-queue.json")
+
+	depths, err := LoadDepths(path)
+	if err != nil || len(depths) != 0 {
+		t.Fatalf("LoadDepths on missing file = %v, %v; want empty", depths, err)
+	}
+
+	if err := SaveDepths(path, map[string]int{"api": 2, "web": 0}); err != nil {
+		t.Fatalf("SaveDepths failed: %v", err)
+	}
+	depths, err = LoadDepths(path)
+	if err != nil {
+		t.Fatalf("LoadDepths failed: %v", err)
+	}
+	if depths["api"] != 2 || depths["web"] != 0 {
+		t.Errorf("LoadDepths = %v", depths)
+	}
+}
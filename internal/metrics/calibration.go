@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// Grouping is what calibration rows are keyed by
+type Grouping string
+
+const (
+	ByType  Grouping = "type"  // bead type
+	ByAgent Grouping = "agent" // who the bead was assigned to
+	ByScope Grouping = "scope" // the estimate's small/medium/large
+	ByTurf  Grouping = "turf"  // the bead's turf
+)
+
+// calibrationTolerance is how far actual cost may stray from the estimate,
+// as a fraction of it, and still count as on target
+const calibrationTolerance = 0.5
+
+// Calibration compares one group's estimates with what its beads cost
+type Calibration struct {
+	By           Grouping
+	Group        string
+	Beads        int // closed beads that had an estimate
+	EstimatedUSD float64
+	ActualUSD    float64
+	PreflightUSD float64 // what producing the estimates cost
+	OnTarget     int     // beads whose actual cost was within the tolerance of the estimate
+
+	worked   time.Duration // from work starting to the bead closing
+	timed    int           // beads with a known working time
+	absError float64       // sum of |actual - estimate| / estimate
+	errored  int           // beads with a non-zero estimate
+}
+
+// Ratio is actual over estimated cost: above 1 the work costs more than
+// estimated. 0 when nothing was estimated to cost anything.
+func (c *Calibration) Ratio() float64 {
+	if c.EstimatedUSD <= 0 {
+		return 0
+	}
+	return c.ActualUSD / c.EstimatedUSD
+}
+
+// MeanError is the average size of a bead's miss, as a fraction of its estimate
+func (c *Calibration) MeanError() float64 {
+	if c.errored == 0 {
+		return 0
+	}
+	return c.absError / float64(c.errored)
+}
+
+// AvgCost is the average actual cost of one bead
+func (c *Calibration) AvgCost() float64 {
+	if c.Beads == 0 {
+		return 0
+	}
+	return c.ActualUSD / float64(c.Beads)
+}
+
+// AvgDuration is the average time from work starting to the bead closing
+func (c *Calibration) AvgDuration() time.Duration {
+	if c.timed == 0 {
+		return 0
+	}
+	return c.worked / time.Duration(c.timed)
+}
+
+// Calibrate groups closed beads with estimates, closed after since, and
+// compares each group's estimated and actual cost. Groups are ordered by
+// actual spend, largest first.
+func Calibrate(beads []*models.Bead, by Grouping, since time.Time) []*Calibration {
+	groups := make(map[string]*Calibration)
+	for _, b := range beads {
+		e := b.Estimate
+		if e == nil || b.Status != models.BeadStatusClosed || b.ClosedAt == nil || b.ClosedAt.Before(since) {
+			continue
+		}
+
+		key := groupKey(b, by)
+		c := groups[key]
+		if c == nil {
+			c = &Calibration{By: by, Group: key}
+			groups[key] = c
+		}
+
+		c.Beads++
+		c.EstimatedUSD += e.CostUSD
+		c.ActualUSD += b.CostUSD
+		c.PreflightUSD += e.PreflightUSD
+		if e.CostUSD > 0 {
+			miss := math.Abs(b.CostUSD-e.CostUSD) / e.CostUSD
+			c.absError += miss
+			c.errored++
+			if miss <= calibrationTolerance {
+				c.OnTarget++
+			}
+		}
+		if started := workStarted(b); !started.IsZero() && b.ClosedAt.After(started) {
+			c.worked += b.ClosedAt.Sub(started)
+			c.timed++
+		}
+	}
+
+	out := make([]*Calibration, 0, len(groups))
+	for _, c := range groups {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ActualUSD != out[j].ActualUSD {
+			return out[i].ActualUSD > out[j].ActualUSD
+		}
+		return out[i].Group < out[j].Group
+	})
+	return out
+}
+
+// groupKey returns the group a bead is counted in
+func groupKey(b *models.Bead, by Grouping) string {
+	var key string
+	switch by {
+	case ByAgent:
+		key = b.Assignee
+	case ByScope:
+		key = b.Estimate.Scope
+	case ByTurf:
+		key = b.Turf
+	default:
+		key = string(b.Type)
+	}
+	if key == "" {
+		return "(none)"
+	}
+	return key
+}
+
+// workStarted is when an agent first started on a bead: its first
+// work_started event or move to in_progress
+func workStarted(b *models.Bead) time.Time {
+	for _, e := range b.History {
+		if e.Type == models.BeadEventTypeWorkStarted ||
+			(e.Type == models.BeadEventTypeStatusChange && e.To == string(models.BeadStatusInProgress)) {
+			return e.Timestamp
+		}
+	}
+	return time.Time{}
+}
+
+// CalibrationHeader names the CSV columns, in the order Record writes them
+var CalibrationHeader = []string{
+	"group_by", "group", "beads", "estimated_usd", "actual_usd", "ratio",
+	"mean_error", "on_target", "avg_cost_usd", "avg_minutes", "preflight_usd",
+}
+
+// Record returns a group's CSV row
+func (c *Calibration) Record() []string {
+	return []string{
+		string(c.By),
+		c.Group,
+		strconv.Itoa(c.Beads),
+		strconv.FormatFloat(c.EstimatedUSD, 'f', 4, 64),
+		strconv.FormatFloat(c.ActualUSD, 'f', 4, 64),
+		strconv.FormatFloat(c.Ratio(), 'f', 2, 64),
+		strconv.FormatFloat(c.MeanError(), 'f', 2, 64),
+		strconv.Itoa(c.OnTarget),
+		strconv.FormatFloat(c.AvgCost(), 'f', 4, 64),
+		strconv.FormatFloat(c.AvgDuration().Minutes(), 'f', 1, 64),
+		strconv.FormatFloat(c.PreflightUSD, 'f', 4, 64),
+	}
+}
+
+// WriteCalibrationCSV writes calibration rows as CSV with a header row
+func WriteCalibrationCSV(w io.Writer, rows []*Calibration) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CalibrationHeader); err != nil {
+		return err
+	}
+	for _, c := range rows {
+		if err := cw.Write(c.Record()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
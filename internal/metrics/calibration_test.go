@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestCalibrate(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	closedAfter := func(d time.Duration) *time.Time {
+		at := start.Add(d)
+		return &at
+	}
+	started := []models.BeadEvent{{Type: models.BeadEventTypeStatusChange, To: "in_progress", Timestamp: start}}
+	bead := func(typ models.BeadType, assignee string, est, actual float64, took time.Duration) *models.Bead {
+		return &models.Bead{
+			Type:     typ,
+			Status:   models.BeadStatusClosed,
+			Assignee: assignee,
+			CostUSD:  actual,
+			ClosedAt: closedAfter(took),
+			History:  started,
+			Estimate: &models.Estimate{Scope: models.EstimateScopeSmall, CostUSD: est, PreflightUSD: 0.01},
+		}
+	}
+
+	beads := []*models.Bead{
+		bead(models.BeadTypeBug, "vinnie", 1.0, 1.2, 30*time.Minute),                                              // within 50%
+		bead(models.BeadTypeBug, "vinnie", 1.0, 3.0, 90*time.Minute),                                              // ran over
+		bead(models.BeadTypeFeature, "sal", 2.0, 1.0, 60*time.Minute),                                             // on target at the edge
+		{Type: models.BeadTypeBug, Status: models.BeadStatusClosed, CostUSD: 5, ClosedAt: closedAfter(time.Hour)}, // no estimate
+		{Type: models.BeadTypeBug, Status: models.BeadStatusOpen, Estimate: &models.Estimate{CostUSD: 1}},         // still open
+	}
+
+	byType := Calibrate(beads, ByType, start.Add(-time.Hour))
+	if len(byType) != 2 || byType[0].Group != "bug" || byType[1].Group != "feature" {
+		t.Fatalf("expected bug then feature by spend, got %+v", byType)
+	}
+	bugs := byType[0]
+	if bugs.Beads != 2 || bugs.EstimatedUSD != 2 || bugs.ActualUSD != 4.2 || bugs.OnTarget != 1 {
+		t.Errorf("unexpected bug row: %+v", bugs)
+	}
+	if r := bugs.Ratio(); r < 2.09 || r > 2.11 {
+		t.Errorf("expected a 2.1x ratio, got %v", r)
+	}
+	if m := bugs.MeanError(); m < 1.09 || m > 1.11 {
+		t.Errorf("expected a 110%% mean miss, got %v", m)
+	}
+	if bugs.AvgDuration() != time.Hour {
+		t.Errorf("expected an hour on average, got %v", bugs.AvgDuration())
+	}
+	if byType[1].OnTarget != 1 {
+		t.Errorf("expected a miss of exactly 50%% to count as on target, got %+v", byType[1])
+	}
+
+	byAgent := Calibrate(beads, ByAgent, start.Add(-time.Hour))
+	if len(byAgent) != 2 || byAgent[0].Group != "vinnie" || byAgent[0].By != ByAgent {
+		t.Errorf("unexpected agent rows: %+v", byAgent)
+	}
+
+	if got := Calibrate(beads, ByType, start.Add(2*time.Hour)); len(got) != 0 {
+		t.Errorf("expected beads closed before since to be skipped, got %+v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCalibrationCSV(&buf, byType); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[1][0] != "type" || rows[1][1] != "bug" || rows[1][5] != "2.10" || rows[1][9] != "60.0" {
+		t.Errorf("unexpected CSV: %v", rows)
+	}
+}
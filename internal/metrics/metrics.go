@@ -0,0 +1,159 @@
+// Package metrics rolls mob's beads, spend, transcripts and activity feed up
+// into per-day figures for offline analysis, and compares preflight
+// estimates with what beads actually cost.
+package metrics
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+// dateLayout keys days by local date, as the spend ledger does
+const dateLayout = "2006-01-02"
+
+// Day is one day's figures
+type Day struct {
+	Date          string // local date, 2006-01-02
+	BeadsCreated  int
+	BeadsClosed   int
+	CostUSD       float64 // agent spend recorded by the daemon
+	InputTokens   int
+	OutputTokens  int
+	Sessions      int // agent sessions with at least one turn that day
+	AgentsSpawned int
+	AgentsActive  int // distinct agents named in that day's activity
+	MergesLanded  int
+	MergesFailed  int
+}
+
+// Sources is the history days are computed from
+type Sources struct {
+	Beads       []*models.Bead
+	Spend       map[string]float64 // storage.SpendByDay
+	Transcripts []*transcript.Transcript
+	Activity    []*models.Activity
+}
+
+// Daily returns one Day for every local date from since to until, inclusive,
+// oldest first. Days without activity are included as zero rows so the series
+// has no gaps.
+func Daily(src Sources, since, until time.Time) []*Day {
+	var days []*Day
+	byDate := make(map[string]*Day)
+	start := since.Local()
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	for d := start; !d.After(until); d = d.AddDate(0, 0, 1) {
+		day := &Day{Date: d.Format(dateLayout)}
+		days = append(days, day)
+		byDate[day.Date] = day
+	}
+	on := func(t time.Time) *Day {
+		return byDate[t.Local().Format(dateLayout)]
+	}
+
+	for _, b := range src.Beads {
+		if day := on(b.CreatedAt); day != nil {
+			day.BeadsCreated++
+		}
+		if b.ClosedAt != nil {
+			if day := on(*b.ClosedAt); day != nil {
+				day.BeadsClosed++
+			}
+		}
+	}
+
+	for date, usd := range src.Spend {
+		if day := byDate[date]; day != nil {
+			day.CostUSD += usd
+		}
+	}
+
+	for _, t := range src.Transcripts {
+		seen := make(map[*Day]bool)
+		for _, e := range t.Entries {
+			day := on(e.Timestamp)
+			if day == nil {
+				continue
+			}
+			day.InputTokens += e.InputTokens
+			day.OutputTokens += e.OutputTokens
+			if !seen[day] {
+				seen[day] = true
+				day.Sessions++
+			}
+		}
+	}
+
+	active := make(map[*Day]map[string]bool)
+	for _, a := range src.Activity {
+		day := on(a.Timestamp)
+		if day == nil {
+			continue
+		}
+		switch a.Type {
+		case models.ActivityAgentSpawned:
+			day.AgentsSpawned++
+		case models.ActivityMergeLanded:
+			day.MergesLanded++
+		case models.ActivityMergeFailed:
+			day.MergesFailed++
+		}
+		for _, name := range []string{a.Actor, a.Agent} {
+			if name == "" || name == "user" || name == "daemon" {
+				continue
+			}
+			if active[day] == nil {
+				active[day] = make(map[string]bool)
+			}
+			active[day][name] = true
+		}
+	}
+	for day, names := range active {
+		day.AgentsActive = len(names)
+	}
+
+	return days
+}
+
+// Header names the CSV columns, in the order Record writes them
+var Header = []string{
+	"date", "beads_created", "beads_closed", "cost_usd", "input_tokens", "output_tokens",
+	"sessions", "agents_spawned", "agents_active", "merges_landed", "merges_failed",
+}
+
+// Record returns a day's CSV row
+func (d *Day) Record() []string {
+	return []string{
+		d.Date,
+		strconv.Itoa(d.BeadsCreated),
+		strconv.Itoa(d.BeadsClosed),
+		strconv.FormatFloat(d.CostUSD, 'f', 4, 64),
+		strconv.Itoa(d.InputTokens),
+		strconv.Itoa(d.OutputTokens),
+		strconv.Itoa(d.Sessions),
+		strconv.Itoa(d.AgentsSpawned),
+		strconv.Itoa(d.AgentsActive),
+		strconv.Itoa(d.MergesLanded),
+		strconv.Itoa(d.MergesFailed),
+	}
+}
+
+// WriteCSV writes days as CSV with a header row
+func WriteCSV(w io.Writer, days []*Day) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Header); err != nil {
+		return err
+	}
+	for _, d := range days {
+		if err := cw.Write(d.Record()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+func TestDaily(t *testing.T) {
+	day1 := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	closed := day2.Add(time.Hour)
+	before := day1.AddDate(0, 0, -5)
+
+	src := Sources{
+		Beads: []*models.Bead{
+			{ID: "bd-1", CreatedAt: day1, ClosedAt: &closed},
+			{ID: "bd-2", CreatedAt: day1},
+			{ID: "bd-3", CreatedAt: before}, // outside the range
+		},
+		Spend: map[string]float64{
+			day1.Format("2006-01-02"):   1.5,
+			before.Format("2006-01-02"): 9,
+		},
+		Transcripts: []*transcript.Transcript{
+			{Entries: []*transcript.Entry{
+				{Timestamp: day1, InputTokens: 100, OutputTokens: 10},
+				{Timestamp: day1.Add(time.Minute), InputTokens: 50, OutputTokens: 5},
+				{Timestamp: day2, InputTokens: 10, OutputTokens: 1},
+			}},
+			{Entries: []*transcript.Entry{{Timestamp: day2, InputTokens: 7}}},
+		},
+		Activity: []*models.Activity{
+			{Timestamp: day1, Type: models.ActivityAgentSpawned, Actor: "daemon", Agent: "vinnie"},
+			{Timestamp: day1, Type: models.ActivityWorkAssigned, Actor: "underboss", Agent: "vinnie"},
+			{Timestamp: day2, Type: models.ActivityMergeLanded, Actor: "daemon", BeadID: "bd-1"},
+			{Timestamp: day2, Type: models.ActivityMergeFailed, Actor: "daemon"},
+			{Timestamp: day2, Type: models.ActivityBeadComment, Actor: "user"},
+		},
+	}
+
+	days := Daily(src, day1, day2.Add(2*time.Hour))
+	if len(days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(days))
+	}
+
+	d1, d2 := days[0], days[1]
+	if d1.Date != day1.Format("2006-01-02") || d2.Date != day2.Format("2006-01-02") {
+		t.Errorf("unexpected dates %s, %s", d1.Date, d2.Date)
+	}
+	if d1.BeadsCreated != 2 || d1.BeadsClosed != 0 || d2.BeadsClosed != 1 {
+		t.Errorf("unexpected throughput: %+v / %+v", d1, d2)
+	}
+	if d1.CostUSD != 1.5 || d2.CostUSD != 0 {
+		t.Errorf("unexpected cost: %v / %v", d1.CostUSD, d2.CostUSD)
+	}
+	if d1.InputTokens != 150 || d1.OutputTokens != 15 || d2.InputTokens != 17 || d2.OutputTokens != 1 {
+		t.Errorf("unexpected tokens: %+v / %+v", d1, d2)
+	}
+	if d1.Sessions != 1 || d2.Sessions != 2 {
+		t.Errorf("unexpected sessions: %d / %d", d1.Sessions, d2.Sessions)
+	}
+	if d1.AgentsSpawned != 1 || d1.AgentsActive != 2 || d2.AgentsActive != 0 {
+		t.Errorf("unexpected agents: %+v / %+v", d1, d2)
+	}
+	if d2.MergesLanded != 1 || d2.MergesFailed != 1 {
+		t.Errorf("unexpected merges: %+v", d2)
+	}
+}
+
+func TestDailyFillsEmptyDays(t *testing.T) {
+	start := time.Date(2026, 3, 1, 23, 0, 0, 0, time.Local)
+	days := Daily(Sources{}, start, start.AddDate(0, 0, 6))
+	if len(days) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(days))
+	}
+	if days[0].Date != "2026-03-01" || days[6].Date != "2026-03-07" {
+		t.Errorf("unexpected range %s..%s", days[0].Date, days[6].Date)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	days := []*Day{{Date: "2026-03-02", BeadsCreated: 2, CostUSD: 1.5, InputTokens: 150}}
+	if err := WriteCSV(&buf, days); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || len(rows[0]) != len(Header) || rows[0][0] != "date" {
+		t.Fatalf("unexpected CSV: %v", rows)
+	}
+	if rows[1][0] != "2026-03-02" || rows[1][1] != "2" || rows[1][3] != "1.5000" || rows[1][4] != "150" {
+		t.Errorf("unexpected row: %v", rows[1])
+	}
+}
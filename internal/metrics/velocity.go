@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// Velocity sums up one group's finished work and how it compared with the
+// time estimated for it
+type Velocity struct {
+	By       Grouping
+	Group    string
+	Finished int // beads whose work finished in the period
+
+	Estimated     int           // finished beads with a time estimate and a known working time
+	EstimatedTime time.Duration // what those beads were estimated to take
+	ActualTime    time.Duration // what those beads took
+
+	worked time.Duration // working time of every finished bead with a known start
+	timed  int
+}
+
+// Ratio is actual over estimated time for the beads that had an estimate:
+// above 1 the work took longer than estimated. 0 when none had one.
+func (v *Velocity) Ratio() float64 {
+	if v.EstimatedTime <= 0 {
+		return 0
+	}
+	return float64(v.ActualTime) / float64(v.EstimatedTime)
+}
+
+// AvgTime is the average time from an agent picking a bead up to finishing it
+func (v *Velocity) AvgTime() time.Duration {
+	if v.timed == 0 {
+		return 0
+	}
+	return v.worked / time.Duration(v.timed)
+}
+
+// PerWeek is how many beads the group finishes in a week, averaged over period
+func (v *Velocity) PerWeek(period time.Duration) float64 {
+	if period <= 0 {
+		return 0
+	}
+	return float64(v.Finished) / (period.Hours() / (24 * 7))
+}
+
+// MeasureVelocity groups beads whose work finished after since and totals
+// their estimated and actual working time. Beads from before start and
+// finish times were recorded fall back to their history and closing time.
+// Groups are ordered by beads finished, most first.
+func MeasureVelocity(beads []*models.Bead, by Grouping, since time.Time) []*Velocity {
+	groups := make(map[string]*Velocity)
+	for _, b := range beads {
+		started, finished := workSpan(b)
+		if finished.IsZero() || finished.Before(since) {
+			continue
+		}
+
+		key := groupKey(b, by)
+		v := groups[key]
+		if v == nil {
+			v = &Velocity{By: by, Group: key}
+			groups[key] = v
+		}
+		v.Finished++
+		if started.IsZero() || !finished.After(started) {
+			continue
+		}
+		took := finished.Sub(started)
+		v.worked += took
+		v.timed++
+		if est, err := models.ParseTimeEstimate(b.TimeEstimate); err == nil && est > 0 {
+			v.Estimated++
+			v.EstimatedTime += est
+			v.ActualTime += took
+		}
+	}
+
+	out := make([]*Velocity, 0, len(groups))
+	for _, v := range groups {
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Finished != out[j].Finished {
+			return out[i].Finished > out[j].Finished
+		}
+		return out[i].Group < out[j].Group
+	})
+	return out
+}
+
+// workSpan is when work on a bead started and finished; finished is zero
+// while the work is still going
+func workSpan(b *models.Bead) (started, finished time.Time) {
+	if b.StartedAt != nil {
+		started = *b.StartedAt
+	} else {
+		started = workStarted(b)
+	}
+	switch {
+	case b.FinishedAt != nil:
+		finished = *b.FinishedAt
+	case b.Status == models.BeadStatusClosed && b.ClosedAt != nil && !started.IsZero():
+		finished = *b.ClosedAt
+	}
+	return started, finished
+}
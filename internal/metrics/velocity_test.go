@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestMeasureVelocity(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+	bead := func(turf, assignee, estimate string, took time.Duration) *models.Bead {
+		return &models.Bead{
+			Turf:         turf,
+			Assignee:     assignee,
+			Status:       models.BeadStatusInReview,
+			TimeEstimate: estimate,
+			StartedAt:    at(0),
+			FinishedAt:   at(took),
+		}
+	}
+
+	beads := []*models.Bead{
+		bead("api", "vinnie", "2h", 3*time.Hour),
+		bead("api", "sal", "1h", time.Hour),
+		bead("api", "vinnie", "", 2*time.Hour),
+		bead("web", "vinnie", "", 30*time.Minute),
+		// Closed before start times were recorded: falls back to history
+		{
+			Turf: "web", Assignee: "sal", Status: models.BeadStatusClosed, ClosedAt: at(time.Hour),
+			History: []models.BeadEvent{{Type: models.BeadEventTypeStatusChange, To: "in_progress", Timestamp: start}},
+		},
+		{Turf: "web", Assignee: "sal", Status: models.BeadStatusInProgress, StartedAt: at(0)}, // still going
+		{Turf: "api", Assignee: "sal", Status: models.BeadStatusInReview, StartedAt: at(-48 * time.Hour), FinishedAt: at(-47 * time.Hour)},
+	}
+
+	byTurf := MeasureVelocity(beads, ByTurf, start.Add(-time.Hour))
+	if len(byTurf) != 2 || byTurf[0].Group != "api" || byTurf[1].Group != "web" {
+		t.Fatalf("expected api then web, got %+v", byTurf)
+	}
+	api := byTurf[0]
+	if api.Finished != 3 || api.Estimated != 2 || api.EstimatedTime != 3*time.Hour || api.ActualTime != 4*time.Hour {
+		t.Errorf("unexpected api row: %+v", api)
+	}
+	if r := api.Ratio(); r < 1.33 || r > 1.34 {
+		t.Errorf("expected a 1.33x ratio, got %v", r)
+	}
+	if api.AvgTime() != 2*time.Hour {
+		t.Errorf("expected 2h on average, got %v", api.AvgTime())
+	}
+	if web := byTurf[1]; web.Finished != 2 || web.Estimated != 0 || web.Ratio() != 0 || web.AvgTime() != 45*time.Minute {
+		t.Errorf("unexpected web row: %+v", web)
+	}
+	if pw := api.PerWeek(7 * 24 * time.Hour); pw != 3 {
+		t.Errorf("expected 3 per week, got %v", pw)
+	}
+
+	byAgent := MeasureVelocity(beads, ByAgent, start.Add(-time.Hour))
+	if len(byAgent) != 2 || byAgent[0].Group != "vinnie" || byAgent[0].Finished != 3 {
+		t.Errorf("expected vinnie first with 3 finished, got %+v", byAgent)
+	}
+}
@@ -0,0 +1,251 @@
+package mockclaude
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// options are the claude flags the mock understands
+type options struct {
+	partial   bool
+	mcpConfig string
+	model     string
+	resume    string
+}
+
+// parseArgs reads the flags mob passes to claude, ignoring the rest
+func parseArgs(args []string) options {
+	var o options
+	for i := 0; i < len(args); i++ {
+		value := func() string {
+			if i+1 < len(args) {
+				i++
+				return args[i]
+			}
+			return ""
+		}
+		switch args[i] {
+		case "--include-partial-messages":
+			o.partial = true
+		case "--mcp-config":
+			o.mcpConfig = value()
+		case "--model":
+			o.model = value()
+		case "--resume":
+			o.resume = value()
+		case "--system-prompt", "--output-format", "--input-format":
+			value()
+		}
+	}
+	return o
+}
+
+// Run acts as one claude -p call: it reads the prompt from stdin, plays the
+// matching turn of the script named by MOB_MOCK_SCRIPT and writes the reply
+// as stream-json to stdout
+func Run(args []string, stdin io.Reader, stdout io.Writer) error {
+	script := &Script{}
+	if path := os.Getenv(ScriptEnv); path != "" {
+		var err error
+		if script, err = LoadScript(path); err != nil {
+			return err
+		}
+	}
+	return run(script, parseArgs(args), stdin, stdout)
+}
+
+func run(script *Script, opts options, stdin io.Reader, stdout io.Writer) error {
+	prompt, err := readPrompt(stdin)
+	if err != nil {
+		return err
+	}
+
+	sessionID := opts.resume
+	if sessionID == "" {
+		sessionID = script.SessionID
+	}
+	if sessionID == "" {
+		sessionID = "mock-" + randomHex()
+	}
+	model := script.Model
+	if model == "" {
+		model = opts.model
+	}
+	if model == "" {
+		model = "mock"
+	}
+
+	start := time.Now()
+	w := &streamWriter{enc: json.NewEncoder(stdout), partial: opts.partial}
+	w.emit(map[string]interface{}{"type": "system", "subtype": "init", "session_id": sessionID, "model": model})
+
+	turn := script.Reply(prompt)
+	usage := map[string]int{
+		"input_tokens":  orDefault(turn.InputTokens, len(prompt)/4+1),
+		"output_tokens": orDefault(turn.OutputTokens, len(turn.Text)/4+1),
+	}
+
+	if turn.Error != "" {
+		w.emit(map[string]interface{}{
+			"type": "result", "subtype": "error_during_execution", "session_id": sessionID,
+			"is_error": true, "result": turn.Error, "duration_ms": time.Since(start).Milliseconds(),
+		})
+		return nil
+	}
+
+	var blocks []map[string]interface{}
+	if turn.Thinking != "" {
+		blocks = append(blocks, map[string]interface{}{"type": "thinking", "thinking": turn.Thinking, "text": turn.Thinking})
+	}
+
+	if len(turn.ToolCalls) > 0 {
+		tools, err := newToolRunner(opts.mcpConfig)
+		if err != nil {
+			return err
+		}
+		defer tools.close()
+
+		for i, call := range turn.ToolCalls {
+			server, tool, err := tools.resolve(call.Name)
+			if err != nil {
+				return err
+			}
+			id := fmt.Sprintf("toolu_mock_%d", i+1)
+			blocks = append(blocks, map[string]interface{}{
+				"type": "tool_use", "id": id, "name": "mcp__" + server + "__" + tool, "input": call.Input,
+			})
+			result, isError, err := tools.call(server, tool, call.Input)
+			if err != nil {
+				result, isError = err.Error(), true
+			}
+			blocks = append(blocks, map[string]interface{}{
+				"type": "tool_result", "tool_use_id": id, "content": result, "is_error": isError,
+			})
+		}
+	}
+
+	if turn.Text != "" {
+		blocks = append(blocks, map[string]interface{}{"type": "text", "text": turn.Text})
+	}
+
+	w.message(model, blocks, usage, turn.delay)
+	w.emit(map[string]interface{}{
+		"type": "result", "subtype": "success", "session_id": sessionID,
+		"is_error": false, "result": turn.Text, "duration_ms": time.Since(start).Milliseconds(),
+		"total_cost_usd": 0, "usage": usage,
+	})
+	return nil
+}
+
+// readPrompt returns the text of the first user message on stdin
+func readPrompt(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var msg struct {
+			Type    string `json:"type"`
+			Message struct {
+				Content json.RawMessage `json:"content"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil || msg.Type != "user" {
+			continue
+		}
+
+		var text string
+		if err := json.Unmarshal(msg.Message.Content, &text); err == nil {
+			return text, nil
+		}
+		var parts []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(msg.Message.Content, &parts); err == nil {
+			var b strings.Builder
+			for _, p := range parts {
+				b.WriteString(p.Text)
+			}
+			return b.String(), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read prompt: %w", err)
+	}
+	return "", fmt.Errorf("no user message on stdin")
+}
+
+// streamWriter writes stream-json lines the way claude does
+type streamWriter struct {
+	enc     *json.Encoder
+	partial bool
+}
+
+func (w *streamWriter) emit(v interface{}) {
+	w.enc.Encode(v)
+}
+
+func (w *streamWriter) event(e map[string]interface{}) {
+	w.emit(map[string]interface{}{"type": "stream_event", "event": e})
+}
+
+// message writes one assistant message, as stream events when partial
+// messages were requested and as a single assistant line otherwise
+func (w *streamWriter) message(model string, blocks []map[string]interface{}, usage map[string]int, delay time.Duration) {
+	if !w.partial {
+		time.Sleep(delay * time.Duration(len(blocks)))
+		w.emit(map[string]interface{}{
+			"type":    "assistant",
+			"message": map[string]interface{}{"model": model, "content": blocks},
+		})
+		return
+	}
+
+	w.event(map[string]interface{}{
+		"type":    "message_start",
+		"message": map[string]interface{}{"model": model, "usage": map[string]int{"input_tokens": usage["input_tokens"]}},
+	})
+	for i, block := range blocks {
+		time.Sleep(delay)
+		start := map[string]interface{}{"type": block["type"]}
+		var delta map[string]interface{}
+		switch block["type"] {
+		case "text":
+			delta = map[string]interface{}{"type": "text_delta", "text": block["text"]}
+		case "thinking":
+			delta = map[string]interface{}{"type": "thinking_delta", "text": block["thinking"]}
+		case "tool_use":
+			start["id"], start["name"] = block["id"], block["name"]
+			input, _ := json.Marshal(block["input"])
+			delta = map[string]interface{}{"type": "input_json_delta", "text": string(input)}
+		case "tool_result":
+			start["tool_use_id"], start["content"] = block["tool_use_id"], block["content"]
+		}
+		w.event(map[string]interface{}{"type": "content_block_start", "index": i, "content_block": start})
+		if delta != nil {
+			w.event(map[string]interface{}{"type": "content_block_delta", "index": i, "delta": delta})
+		}
+		w.event(map[string]interface{}{"type": "content_block_stop", "index": i})
+	}
+	w.event(map[string]interface{}{"type": "message_delta", "usage": usage})
+	w.event(map[string]interface{}{"type": "message_stop"})
+}
+
+func orDefault(v, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
+func randomHex() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
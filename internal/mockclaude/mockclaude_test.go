@@ -0,0 +1,212 @@
+package mockclaude
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// helperEnv makes the test binary act as claude or as the mob MCP server
+const helperEnv = "MOCKCLAUDE_HELPER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperEnv) {
+	case "claude":
+		if err := Run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+			os.Stderr.WriteString(err.Error() + "\n")
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "mcp":
+		dir := os.Args[1]
+		store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+		if err != nil {
+			os.Exit(1)
+		}
+		server := mcp.NewServer(registry.New(registry.DefaultPath(dir)), nil, store, nil, dir)
+		if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func writeJSON(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScript_Reply(t *testing.T) {
+	s := &Script{Turns: []Turn{
+		{Match: `review (?P<bead>bd-\w+)`, Text: "Reviewed ${bead}", ToolCalls: []ToolCall{
+			{Name: "review_bead", Input: map[string]interface{}{"bead_id": "${bead}", "tags": []interface{}{"$bead"}}},
+		}},
+		{Match: `fail`, Error: "overloaded"},
+		{Text: "fallback"},
+	}}
+	if err := s.compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		prompt   string
+		wantText string
+		wantErr  string
+	}{
+		{"please review bd-12ab now", "Reviewed bd-12ab", ""},
+		{"this will fail", "", "overloaded"},
+		{"anything else", "fallback", ""},
+	}
+	for _, tt := range tests {
+		got := s.Reply(tt.prompt)
+		if got.Text != tt.wantText || got.Error != tt.wantErr {
+			t.Errorf("Reply(%q) = text %q error %q, want %q %q", tt.prompt, got.Text, got.Error, tt.wantText, tt.wantErr)
+		}
+	}
+
+	got := s.Reply("review bd-9")
+	if id := got.ToolCalls[0].Input["bead_id"]; id != "bd-9" {
+		t.Errorf("tool input bead_id = %v, want bd-9", id)
+	}
+	if tags := got.ToolCalls[0].Input["tags"].([]interface{}); tags[0] != "bd-9" {
+		t.Errorf("nested input = %v, want bd-9", tags)
+	}
+	if s.Turns[0].ToolCalls[0].Input["bead_id"] != "${bead}" {
+		t.Error("Reply modified the script")
+	}
+
+	if got := (&Script{}).Reply("hello"); got.Text != "mock reply: hello" {
+		t.Errorf("empty script reply = %q", got.Text)
+	}
+}
+
+func TestRun_PlainReply(t *testing.T) {
+	var out bytes.Buffer
+	stdin := strings.NewReader(`{"type":"user","message":{"role":"user","content":"hi"}}` + "\n")
+	script := &Script{SessionID: "sess-1", Turns: []Turn{{Text: "hello there"}}}
+	if err := run(script, parseArgs([]string{"-p", "--model", "opus"}), stdin, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want init, assistant and result:\n%s", len(lines), out.String())
+	}
+	var result agent.StreamMessage
+	if err := json.Unmarshal([]byte(lines[2]), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Type != "result" || result.SessionID != "sess-1" || result.Result != "hello there" {
+		t.Errorf("result = %+v", result)
+	}
+	if !strings.Contains(lines[1], `"model":"opus"`) {
+		t.Errorf("assistant message should report --model: %s", lines[1])
+	}
+}
+
+// TestSpawner_EndToEnd drives a real agent through the mock, whose scripted
+// tool call lands in the bead store via the MCP server
+func TestSpawner_EndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mcpConfig := filepath.Join(dir, "mcp-config.json")
+	writeJSON(t, mcpConfig, map[string]interface{}{
+		"mcpServers": map[string]interface{}{
+			"mob-tools": map[string]interface{}{
+				"command": os.Args[0],
+				"args":    []string{dir},
+				"env":     map[string]string{helperEnv: "mcp"},
+			},
+		},
+	})
+	scriptPath := filepath.Join(dir, "script.json")
+	writeJSON(t, scriptPath, Script{Turns: []Turn{{
+		Match: `(bd-\w+)`,
+		ToolCalls: []ToolCall{
+			{Name: "comment_on_bead", Input: map[string]interface{}{"bead_id": "$1", "comment": "Fixed it", "actor": "vinnie"}},
+		},
+		Text: "Done with $1",
+	}}})
+
+	spawner := agent.NewSpawner()
+	spawner.SetCommandCreator(func(name string, args ...string) *exec.Cmd {
+		cmd := exec.Command(os.Args[0], args...)
+		cmd.Env = append(os.Environ(), helperEnv+"=claude", ScriptEnv+"="+scriptPath)
+		return cmd
+	})
+	a, err := spawner.SpawnWithOptions(agent.SpawnOptions{Type: agent.AgentTypeSoldati, Name: "vinnie", WorkDir: dir, MCPConfig: mcpConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var streamed []agent.ChatContentBlock
+	resp, err := a.ChatStream("Work on "+bead.ID, func(b agent.ChatContentBlock) { streamed = append(streamed, b) })
+	if err != nil {
+		t.Fatalf("ChatStream failed: %v", err)
+	}
+
+	if got, want := resp.GetText(), "Done with "+bead.ID; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+	if !strings.HasPrefix(a.SessionID, "mock-") {
+		t.Errorf("SessionID = %q, want a mock session", a.SessionID)
+	}
+	var types []string
+	for _, b := range resp.Blocks {
+		types = append(types, string(b.Type))
+	}
+	if got := strings.Join(types, ","); got != "tool_use,tool_result,text" {
+		t.Errorf("blocks = %s, want tool_use,tool_result,text", got)
+	}
+	if len(streamed) == 0 {
+		t.Error("expected streaming callbacks")
+	}
+
+	got, err := store.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, e := range got.History {
+		if e.Type == models.BeadEventTypeComment && strings.Contains(e.Comment, "Fixed it") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("scripted comment missing from bead history: %+v", got.History)
+	}
+
+	// The next call resumes the same session
+	first := a.SessionID
+	if _, err := a.Chat("Anything else on " + bead.ID + "?"); err != nil {
+		t.Fatal(err)
+	}
+	if a.SessionID != first {
+		t.Errorf("SessionID changed from %q to %q on resume", first, a.SessionID)
+	}
+}
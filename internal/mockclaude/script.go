@@ -0,0 +1,141 @@
+// Package mockclaude is a stand-in for the claude binary used in end-to-end
+// tests. It accepts the flags mob passes to claude, reads the stream-json
+// prompt from stdin and replays a scripted reply as stream-json on stdout,
+// so the daemon, MCP tools and TUI can be exercised deterministically
+// without a real Claude binary or API spend.
+//
+// Select it by setting MOB_CLAUDE=mock; point MOB_MOCK_SCRIPT at a script
+// file to control what it says and which tools it calls. Tool calls are
+// made for real against the MCP servers in the --mcp-config file, so a
+// scripted complete_bead closes the bead just as Claude would.
+package mockclaude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+)
+
+// ScriptEnv names the environment variable holding the script path
+const ScriptEnv = "MOB_MOCK_SCRIPT"
+
+// Script is the reply plan for every call made while it is active
+type Script struct {
+	SessionID string `json:"session_id,omitempty"` // reported for new sessions; random when empty
+	Model     string `json:"model,omitempty"`      // reported model; defaults to --model or "mock"
+	Turns     []Turn `json:"turns"`
+}
+
+// Turn is one scripted reply. The first turn whose Match matches the prompt
+// is used; a turn with no Match matches anything. Named and numbered groups
+// in Match can be referenced as ${name} or $1 in Text and tool inputs.
+type Turn struct {
+	Match        string     `json:"match,omitempty"`
+	Thinking     string     `json:"thinking,omitempty"`
+	ToolCalls    []ToolCall `json:"tool_calls,omitempty"`
+	Text         string     `json:"text,omitempty"`
+	Error        string     `json:"error,omitempty"` // reply with an error result instead
+	Delay        string     `json:"delay,omitempty"` // pause before each content block, e.g. "50ms"
+	InputTokens  int        `json:"input_tokens,omitempty"`
+	OutputTokens int        `json:"output_tokens,omitempty"`
+
+	re    *regexp.Regexp
+	delay time.Duration
+}
+
+// ToolCall is an MCP tool the reply invokes
+type ToolCall struct {
+	Name  string                 `json:"name"` // bare tool name, or mcp__<server>__<tool>
+	Input map[string]interface{} `json:"input,omitempty"`
+}
+
+// LoadScript reads and validates a script file
+func LoadScript(path string) (*Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock script: %w", err)
+	}
+	var s Script
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid mock script %s: %w", path, err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, fmt.Errorf("invalid mock script %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// compile parses the patterns and delays of every turn
+func (s *Script) compile() error {
+	for i := range s.Turns {
+		t := &s.Turns[i]
+		if t.Match != "" {
+			re, err := regexp.Compile(t.Match)
+			if err != nil {
+				return fmt.Errorf("turn %d: bad match: %w", i+1, err)
+			}
+			t.re = re
+		}
+		if t.Delay != "" {
+			d, err := time.ParseDuration(t.Delay)
+			if err != nil {
+				return fmt.Errorf("turn %d: bad delay %q", i+1, t.Delay)
+			}
+			t.delay = d
+		}
+	}
+	return nil
+}
+
+// Reply picks the turn for a prompt and fills in its captured groups. With no
+// matching turn it echoes the prompt back.
+func (s *Script) Reply(prompt string) Turn {
+	for _, t := range s.Turns {
+		if t.re == nil {
+			return t
+		}
+		match := t.re.FindStringSubmatchIndex(prompt)
+		if match == nil {
+			continue
+		}
+		expand := func(v string) string {
+			return string(t.re.ExpandString(nil, v, prompt, match))
+		}
+		t.Text = expand(t.Text)
+		t.Thinking = expand(t.Thinking)
+		t.Error = expand(t.Error)
+		calls := make([]ToolCall, len(t.ToolCalls))
+		for i, c := range t.ToolCalls {
+			calls[i] = ToolCall{Name: c.Name, Input: expandValue(c.Input, expand).(map[string]interface{})}
+		}
+		t.ToolCalls = calls
+		return t
+	}
+	return Turn{Text: "mock reply: " + prompt}
+}
+
+// expandValue applies expand to every string inside a decoded JSON value
+func expandValue(v interface{}, expand func(string) string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return expand(v)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[k] = expandValue(val, expand)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = expandValue(val, expand)
+		}
+		return out
+	case nil:
+		return map[string]interface{}{}
+	default:
+		return v
+	}
+}
@@ -0,0 +1,191 @@
+package mockclaude
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// serverConfig is one entry of an --mcp-config file
+type serverConfig struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+}
+
+// toolRunner starts the configured MCP servers on demand and calls tools on them
+type toolRunner struct {
+	servers map[string]serverConfig
+	clients map[string]*mcpClient
+}
+
+func newToolRunner(configPath string) (*toolRunner, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("script calls tools but no --mcp-config was given")
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCP config: %w", err)
+	}
+	var cfg struct {
+		MCPServers map[string]serverConfig `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid MCP config %s: %w", configPath, err)
+	}
+	return &toolRunner{servers: cfg.MCPServers, clients: make(map[string]*mcpClient)}, nil
+}
+
+// resolve maps a script tool name to a server and bare tool name. Bare names
+// need exactly one configured server.
+func (r *toolRunner) resolve(name string) (string, string, error) {
+	if strings.HasPrefix(name, "mcp__") {
+		parts := strings.SplitN(strings.TrimPrefix(name, "mcp__"), "__", 2)
+		if len(parts) != 2 {
+			return "", "", fmt.Errorf("malformed tool name %q", name)
+		}
+		if _, ok := r.servers[parts[0]]; !ok {
+			return "", "", fmt.Errorf("no MCP server %q for tool %s", parts[0], name)
+		}
+		return parts[0], parts[1], nil
+	}
+
+	if len(r.servers) != 1 {
+		names := make([]string, 0, len(r.servers))
+		for n := range r.servers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return "", "", fmt.Errorf("tool %q must be written mcp__<server>__%s (servers: %s)", name, name, strings.Join(names, ", "))
+	}
+	for server := range r.servers {
+		return server, name, nil
+	}
+	return "", "", nil
+}
+
+// call runs a tool and returns its text and whether the server flagged an error
+func (r *toolRunner) call(server, tool string, input map[string]interface{}) (string, bool, error) {
+	client, ok := r.clients[server]
+	if !ok {
+		var err error
+		client, err = startClient(r.servers[server])
+		if err != nil {
+			return "", false, fmt.Errorf("failed to start MCP server %s: %w", server, err)
+		}
+		r.clients[server] = client
+	}
+	return client.callTool(tool, input)
+}
+
+func (r *toolRunner) close() {
+	for _, c := range r.clients {
+		c.close()
+	}
+}
+
+// mcpClient talks JSON-RPC to one MCP server over its stdio
+type mcpClient struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	out    *bufio.Scanner
+	nextID int
+}
+
+func startClient(cfg serverConfig) (*mcpClient, error) {
+	cmd := exec.Command(cfg.Command, cfg.Args...)
+	cmd.Env = os.Environ()
+	for k, v := range cfg.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	out := bufio.NewScanner(stdout)
+	out.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	c := &mcpClient{cmd: cmd, stdin: stdin, out: out}
+	if _, err := c.request("initialize", map[string]interface{}{
+		"protocolVersion": "2024-11-05",
+		"clientInfo":      map[string]string{"name": "mob-mock-claude", "version": "1.0.0"},
+		"capabilities":    map[string]interface{}{},
+	}); err != nil {
+		c.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// request sends a call and waits for the response with the same ID
+func (c *mcpClient) request(method string, params interface{}) (json.RawMessage, error) {
+	c.nextID++
+	id := c.nextID
+	req, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	if _, err := c.stdin.Write(append(req, '\n')); err != nil {
+		return nil, err
+	}
+
+	for c.out.Scan() {
+		var resp struct {
+			ID     json.RawMessage `json:"id"`
+			Result json.RawMessage `json:"result"`
+			Error  *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(c.out.Bytes(), &resp); err != nil || string(resp.ID) != fmt.Sprint(id) {
+			continue
+		}
+		if resp.Error != nil {
+			return nil, fmt.Errorf("%s: %s", method, resp.Error.Message)
+		}
+		return resp.Result, nil
+	}
+	if err := c.out.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("MCP server exited before answering %s", method)
+}
+
+func (c *mcpClient) callTool(name string, input map[string]interface{}) (string, bool, error) {
+	raw, err := c.request("tools/call", map[string]interface{}{"name": name, "arguments": input})
+	if err != nil {
+		return "", false, err
+	}
+	var result struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return "", false, fmt.Errorf("invalid tools/call result: %w", err)
+	}
+	var b strings.Builder
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			b.WriteString(block.Text)
+		}
+	}
+	return b.String(), result.IsError, nil
+}
+
+func (c *mcpClient) close() {
+	c.stdin.Close()
+	c.cmd.Wait()
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// ActivityType classifies an entry in the mob-wide activity feed
+type ActivityType string
+
+const (
+	ActivityBeadCreated     ActivityType = "bead_created"
+	ActivityBeadStatus      ActivityType = "bead_status"
+	ActivityBeadAssigned    ActivityType = "bead_assigned"
+	ActivityBeadComment     ActivityType = "bead_comment"
+	ActivityAgentSpawned    ActivityType = "agent_spawned"
+	ActivityAgentStopped    ActivityType = "agent_stopped"
+	ActivityAgentStuck      ActivityType = "agent_stuck"
+	ActivitySpawnExpired    ActivityType = "spawn_expired" // a queued associate spawn waited too long
+	ActivityWorkAssigned    ActivityType = "work_assigned"
+	ActivityWorkParked      ActivityType = "work_parked" // set aside for an urgent bead
+	ActivityMergeLanded     ActivityType = "merge_landed"
+	ActivityMergeFailed     ActivityType = "merge_failed"
+	ActivityReport          ActivityType = "report"
+	ActivityDaemonStarted   ActivityType = "daemon_started"
+	ActivityDaemonStopped   ActivityType = "daemon_stopped"
+	ActivityDaemonRecovered ActivityType = "daemon_recovered" // cleaned up after an unclean shutdown
+	ActivityModelDegraded   ActivityType = "model_degraded"   // a model's provider kept failing; calls fail over
+	ActivityModelRecovered  ActivityType = "model_recovered"  // the model answered again
+	ActivityError           ActivityType = "error"
+)
+
+// Activity is one entry in the activity feed. Every subsystem appends to the
+// same feed so status views, the TUI and outbound integrations all see one
+// consistent timeline.
+type Activity struct {
+	ID        string       `json:"id"`
+	Timestamp time.Time    `json:"timestamp"`
+	Type      ActivityType `json:"type"`
+	Actor     string       `json:"actor,omitempty"` // who caused it: agent name, "user" or "daemon"
+	BeadID    string       `json:"bead_id,omitempty"`
+	Agent     string       `json:"agent,omitempty"` // agent the entry is about, when not the actor
+	Turf      string       `json:"turf,omitempty"`
+	Message   string       `json:"message"` // one-line human-readable summary
+}
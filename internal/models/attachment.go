@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Attachment is a file kept alongside a bead, such as a captured merge conflict
+type Attachment struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -11,6 +11,7 @@ const (
 	BeadStatusBlocked         BeadStatus = "blocked"
 	BeadStatusClosed          BeadStatus = "closed"
 	BeadStatusPendingApproval BeadStatus = "pending_approval"
+	BeadStatusInReview        BeadStatus = "in_review"
 )
 
 // BeadType represents the type of work
@@ -37,6 +38,9 @@ const (
 	BeadEventTypeWorkStarted    BeadEventType = "work_started"
 	BeadEventTypeWorkCompleted  BeadEventType = "work_completed"
 	BeadEventTypeWorktreeCreate BeadEventType = "worktree_created"
+	BeadEventTypeSplit          BeadEventType = "split"         // To lists the child bead IDs
+	BeadEventTypeCloned         BeadEventType = "cloned"        // To is the new bead's ID
+	BeadEventTypePriorityAged   BeadEventType = "priority_aged" // From and To are the old and new priority
 )
 
 // BeadEvent represents a historical event on a bead
@@ -52,25 +56,42 @@ type BeadEvent struct {
 
 // Bead represents an atomic unit of work
 type Bead struct {
-	ID             string       `json:"id"`
-	Title          string       `json:"title"`
-	Description    string       `json:"description"`
-	Status         BeadStatus   `json:"status"`
-	Priority       int          `json:"priority"` // 0-4, 0 = highest
-	Type           BeadType     `json:"type"`
-	Assignee       string       `json:"assignee,omitempty"`
-	Labels         string       `json:"labels,omitempty"`
-	Turf           string       `json:"turf"`
-	Branch         string       `json:"branch,omitempty"`
-	WorktreePath   string       `json:"worktree_path,omitempty"` // Path to git worktree for this bead
-	CreatedAt      time.Time    `json:"created_at"`
-	UpdatedAt      time.Time    `json:"updated_at"`
-	ClosedAt       *time.Time   `json:"closed_at,omitempty"`
-	CreatedBy      string       `json:"created_by,omitempty"`
-	CloseReason    string       `json:"close_reason,omitempty"`
-	ParentID       string       `json:"parent_id,omitempty"`
-	Blocks         []string     `json:"blocks,omitempty"`
-	Related        []string     `json:"related,omitempty"`
-	DiscoveredFrom string       `json:"discovered_from,omitempty"`
-	History        []BeadEvent  `json:"history,omitempty"`
+	ID             string          `json:"id"`
+	Revision       int             `json:"revision,omitempty"` // Bumped on every write; an update must be based on the current revision
+	Title          string          `json:"title"`
+	Description    string          `json:"description"`
+	Status         BeadStatus      `json:"status"`
+	Priority       int             `json:"priority"` // 0-4, 0 = highest
+	Type           BeadType        `json:"type"`
+	Assignee       string          `json:"assignee,omitempty"`
+	Labels         []string        `json:"labels,omitempty"` // Lowercase tags; see AddLabels
+	Turf           string          `json:"turf"`
+	Branch         string          `json:"branch,omitempty"`
+	WorktreePath   string          `json:"worktree_path,omitempty"` // Path to git worktree for this bead
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+	CreatedBy      string          `json:"created_by,omitempty"`
+	CloseReason    string          `json:"close_reason,omitempty"`
+	ParentID       string          `json:"parent_id,omitempty"`
+	Blocks         []string        `json:"blocks,omitempty"`
+	Related        []string        `json:"related,omitempty"`
+	DiscoveredFrom string          `json:"discovered_from,omitempty"`
+	Model          string          `json:"model,omitempty"`     // Model chosen by the router for this bead
+	CostUSD        float64         `json:"cost_usd,omitempty"`  // Accumulated agent cost spent on this bead
+	Checklist      []ChecklistItem `json:"checklist,omitempty"` // Acceptance criteria that must be checked before completion
+	Watchers       []string        `json:"watchers,omitempty"`  // Humans notified of status changes and comments
+	LastTestRun    *TestRun        `json:"last_test_run,omitempty"`
+	Estimate       *Estimate       `json:"estimate,omitempty"`        // Preflight estimate from estimate_task
+	TimeEstimate   string          `json:"time_estimate,omitempty"`   // How long the work should take, e.g. "90m" or "2d"
+	StartedAt      *time.Time      `json:"started_at,omitempty"`      // When an agent first picked it up
+	FinishedAt     *time.Time      `json:"finished_at,omitempty"`     // When the agent last handed in its work
+	DueAt          *time.Time      `json:"due_at,omitempty"`          // Must be closed by then
+	SLA            string          `json:"sla,omitempty"`             // How long after creation it must be closed by, e.g. "4h" or "3d"
+	SLABreachedAt  *time.Time      `json:"sla_breached_at,omitempty"` // When the daemon escalated the missed deadline
+	Attachments    []Attachment    `json:"attachments,omitempty"`
+	Origin         *Origin         `json:"origin,omitempty"`    // Set once the bead is shared with other instances
+	Source         string          `json:"source,omitempty"`    // URL of the external issue or CI run a webhook filed it from
+	Locations      []string        `json:"locations,omitempty"` // Heresy beads: the files the heresy was found in
+	History        []BeadEvent     `json:"history,omitempty"`
 }
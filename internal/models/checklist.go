@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChecklistItem is one acceptance criterion on a bead
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// AddChecklistItems appends unchecked items, ignoring blank entries
+func (b *Bead) AddChecklistItems(items ...string) {
+	for _, text := range items {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		b.Checklist = append(b.Checklist, ChecklistItem{Text: text})
+	}
+}
+
+// SetChecklistDone marks the items at the given 1-based positions done or not done
+func (b *Bead) SetChecklistDone(positions []int, done bool) error {
+	if err := b.validateChecklistPositions(positions); err != nil {
+		return err
+	}
+	for _, pos := range positions {
+		b.Checklist[pos-1].Done = done
+	}
+	return nil
+}
+
+// RemoveChecklistItems deletes the items at the given 1-based positions
+func (b *Bead) RemoveChecklistItems(positions []int) error {
+	if err := b.validateChecklistPositions(positions); err != nil {
+		return err
+	}
+
+	remove := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		remove[pos-1] = true
+	}
+	kept := b.Checklist[:0]
+	for i, item := range b.Checklist {
+		if !remove[i] {
+			kept = append(kept, item)
+		}
+	}
+	b.Checklist = kept
+	return nil
+}
+
+// UncheckedItems returns the checklist items not yet done
+func (b *Bead) UncheckedItems() []ChecklistItem {
+	var unchecked []ChecklistItem
+	for _, item := range b.Checklist {
+		if !item.Done {
+			unchecked = append(unchecked, item)
+		}
+	}
+	return unchecked
+}
+
+// FormatChecklist renders the checklist as numbered markdown-style checkboxes
+func (b *Bead) FormatChecklist() string {
+	if len(b.Checklist) == 0 {
+		return "(no checklist)"
+	}
+
+	var sb strings.Builder
+	for i, item := range b.Checklist {
+		mark := " "
+		if item.Done {
+			mark = "x"
+		}
+		sb.WriteString(fmt.Sprintf("%d. [%s] %s\n", i+1, mark, item.Text))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (b *Bead) validateChecklistPositions(positions []int) error {
+	if len(positions) == 0 {
+		return fmt.Errorf("no checklist items given")
+	}
+	sorted := append([]int(nil), positions...)
+	sort.Ints(sorted)
+	for _, pos := range sorted {
+		if pos < 1 || pos > len(b.Checklist) {
+			return fmt.Errorf("checklist item %d does not exist (bead has %d items)", pos, len(b.Checklist))
+		}
+	}
+	return nil
+}
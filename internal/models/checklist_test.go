@@ -0,0 +1,36 @@
+package models
+
+import "testing"
+
+func TestChecklist(t *testing.T) {
+	b := &Bead{}
+	b.AddChecklistItems("Tests pass", " ", "Docs updated", "Changelog entry")
+
+	if len(b.Checklist) != 3 {
+		t.Fatalf("expected blank item skipped, got %d items", len(b.Checklist))
+	}
+	if len(b.UncheckedItems()) != 3 {
+		t.Fatalf("expected all items unchecked")
+	}
+
+	if err := b.SetChecklistDone([]int{1, 3}, true); err != nil {
+		t.Fatalf("failed to check items: %v", err)
+	}
+	if unchecked := b.UncheckedItems(); len(unchecked) != 1 || unchecked[0].Text != "Docs updated" {
+		t.Fatalf("unexpected unchecked items: %+v", unchecked)
+	}
+
+	if err := b.SetChecklistDone([]int{4}, true); err == nil {
+		t.Error("expected error for out-of-range item")
+	}
+
+	if err := b.RemoveChecklistItems([]int{2}); err != nil {
+		t.Fatalf("failed to remove item: %v", err)
+	}
+	if got := b.FormatChecklist(); got != "1. [x] Tests pass\n2. [x] Changelog entry" {
+		t.Errorf("unexpected checklist:\n%s", got)
+	}
+	if len(b.UncheckedItems()) != 0 {
+		t.Error("expected checklist complete")
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSLA parses how long a bead has to be closed, such as "4h" or "3d".
+// An empty string means no SLA and returns 0.
+func ParseSLA(s string) (time.Duration, error) {
+	return parsePeriod(s, "SLA")
+}
+
+// parsePeriod parses a length of time in hours or days; what names it in errors
+func parsePeriod(s, what string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid %s %q (expected e.g. 4h or 3d)", what, s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q (expected e.g. 4h or 3d)", what, s)
+	}
+	return d, nil
+}
+
+// ParseDue parses a due date given as RFC 3339, a date (due by the end of
+// that day, local time), or a period from now such as "36h" or "2d"
+func ParseDue(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if day, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return day.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	if d, err := ParseSLA(s); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due date %q (expected 2006-01-02, RFC 3339, or a period like 2d)", s)
+}
+
+// Deadline returns when the bead has to be closed by: the earlier of its
+// due date and its SLA counted from creation. ok is false when it has neither.
+func (b *Bead) Deadline() (deadline time.Time, ok bool) {
+	if b.DueAt != nil {
+		deadline, ok = *b.DueAt, true
+	}
+	if sla, err := ParseSLA(b.SLA); err == nil && sla > 0 {
+		if byDate := b.CreatedAt.Add(sla); !ok || byDate.Before(deadline) {
+			deadline, ok = byDate, true
+		}
+	}
+	return deadline, ok
+}
+
+// Overdue reports whether the bead is still open past its deadline
+func (b *Bead) Overdue(now time.Time) bool {
+	if b.Status == BeadStatusClosed {
+		return false
+	}
+	deadline, ok := b.Deadline()
+	return ok && now.After(deadline)
+}
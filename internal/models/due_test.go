@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDue(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2026-10-20", time.Date(2026, 10, 20, 23, 59, 59, 0, time.UTC), false},
+		{"2026-10-20T12:00:00Z", time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC), false},
+		{"36h", now.Add(36 * time.Hour), false},
+		{"2d", now.Add(48 * time.Hour), false},
+		{"friday", time.Time{}, true},
+		{"-2d", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDue(tt.in, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDue(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseDue(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	due := created.Add(48 * time.Hour)
+
+	b := &Bead{Status: BeadStatusOpen, CreatedAt: created}
+	if _, ok := b.Deadline(); ok {
+		t.Error("expected no deadline without a due date or SLA")
+	}
+	if b.Overdue(created.Add(1000 * time.Hour)) {
+		t.Error("a bead without a deadline is never overdue")
+	}
+
+	b.DueAt = &due
+	b.SLA = "4h"
+	if got, _ := b.Deadline(); !got.Equal(created.Add(4 * time.Hour)) {
+		t.Errorf("deadline = %v, want the earlier SLA", got)
+	}
+	b.SLA = "3d"
+	if got, _ := b.Deadline(); !got.Equal(due) {
+		t.Errorf("deadline = %v, want the earlier due date", got)
+	}
+
+	if b.Overdue(due.Add(-time.Minute)) || !b.Overdue(due.Add(time.Minute)) {
+		t.Error("expected the bead overdue only after its deadline")
+	}
+	b.Status = BeadStatusClosed
+	if b.Overdue(due.Add(time.Minute)) {
+		t.Error("a closed bead is never overdue")
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// ParseTimeEstimate parses how long a bead's work should take, such as
+// "90m", "4h" or "2d". An empty string means no estimate and returns 0.
+func ParseTimeEstimate(s string) (time.Duration, error) {
+	return parsePeriod(s, "time estimate")
+}
+
+// TrackWork records when work on the bead starts and finishes, given the
+// status it is moving from. Work starts the first time the bead goes in
+// progress, and finishes each time it leaves in progress for review,
+// approval or closure; rework after a review moves the finish later.
+func (b *Bead) TrackWork(from BeadStatus, now time.Time) {
+	if b.Status == from {
+		return
+	}
+	switch b.Status {
+	case BeadStatusInProgress:
+		if b.StartedAt == nil {
+			b.StartedAt = &now
+		}
+	case BeadStatusInReview, BeadStatusPendingApproval, BeadStatusClosed:
+		if from == BeadStatusInProgress && b.StartedAt != nil {
+			b.FinishedAt = &now
+		}
+	}
+}
+
+// ActualTime is how long the work took, from an agent picking the bead up to
+// its last hand-in. ok is false until both have happened.
+func (b *Bead) ActualTime() (d time.Duration, ok bool) {
+	if b.StartedAt == nil || b.FinishedAt == nil || b.FinishedAt.Before(*b.StartedAt) {
+		return 0, false
+	}
+	return b.FinishedAt.Sub(*b.StartedAt), true
+}
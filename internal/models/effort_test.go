@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrackWork(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	b := &Bead{Status: BeadStatusOpen}
+	if _, ok := b.ActualTime(); ok {
+		t.Fatal("expected no actual time before work starts")
+	}
+
+	move := func(to BeadStatus, at time.Time) {
+		from := b.Status
+		b.Status = to
+		b.TrackWork(from, at)
+	}
+	move(BeadStatusInProgress, start)
+	move(BeadStatusInReview, start.Add(2*time.Hour))
+	if took, ok := b.ActualTime(); !ok || took != 2*time.Hour {
+		t.Errorf("ActualTime = %v, %v; want 2h", took, ok)
+	}
+
+	// Rework after review keeps the start and moves the finish
+	move(BeadStatusInProgress, start.Add(3*time.Hour))
+	move(BeadStatusClosed, start.Add(4*time.Hour))
+	if !b.StartedAt.Equal(start) {
+		t.Errorf("StartedAt = %v, want the first pickup %v", b.StartedAt, start)
+	}
+	if took, _ := b.ActualTime(); took != 4*time.Hour {
+		t.Errorf("ActualTime = %v, want 4h", took)
+	}
+
+	// Closing a bead nobody worked on records no finish
+	idle := &Bead{Status: BeadStatusClosed}
+	idle.TrackWork(BeadStatusOpen, start)
+	if idle.StartedAt != nil || idle.FinishedAt != nil {
+		t.Errorf("expected no work recorded, got %v %v", idle.StartedAt, idle.FinishedAt)
+	}
+}
+
+func TestParseTimeEstimate(t *testing.T) {
+	for s, want := range map[string]time.Duration{"": 0, "90m": 90 * time.Minute, "4h": 4 * time.Hour, "2d": 48 * time.Hour} {
+		if got, err := ParseTimeEstimate(s); err != nil || got != want {
+			t.Errorf("ParseTimeEstimate(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseTimeEstimate("soon"); err == nil {
+		t.Error("expected an invalid estimate rejected")
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// EpicProgress rolls an epic's child beads up into how far along it is
+type EpicProgress struct {
+	Epic     *Bead
+	Children []*Bead // beads whose ParentID is the epic, in store order
+	ByStatus map[BeadStatus]int
+	Overdue  int // open children past their deadline
+
+	// EarliestDue is the nearest deadline among the epic and its open
+	// children, and EarliestDueID the bead it belongs to; nil when none has one
+	EarliestDue   *time.Time
+	EarliestDueID string
+}
+
+// RollUp computes an epic's progress from the beads that name it as parent
+func RollUp(epic *Bead, beads []*Bead, now time.Time) *EpicProgress {
+	p := &EpicProgress{Epic: epic, ByStatus: make(map[BeadStatus]int)}
+	p.considerDeadline(epic)
+	for _, b := range beads {
+		if b.ParentID != epic.ID || b.ID == epic.ID {
+			continue
+		}
+		p.Children = append(p.Children, b)
+		p.ByStatus[b.Status]++
+		if b.Overdue(now) {
+			p.Overdue++
+		}
+		p.considerDeadline(b)
+	}
+	return p
+}
+
+// considerDeadline keeps b's deadline if it is open and due soonest so far
+func (p *EpicProgress) considerDeadline(b *Bead) {
+	if b.Status == BeadStatusClosed {
+		return
+	}
+	if deadline, ok := b.Deadline(); ok && (p.EarliestDue == nil || deadline.Before(*p.EarliestDue)) {
+		p.EarliestDue = &deadline
+		p.EarliestDueID = b.ID
+	}
+}
+
+// Total is the number of child beads
+func (p *EpicProgress) Total() int {
+	return len(p.Children)
+}
+
+// Closed is the number of child beads closed
+func (p *EpicProgress) Closed() int {
+	return p.ByStatus[BeadStatusClosed]
+}
+
+// Percent is the share of child beads closed, 0-100. An epic with no
+// children is 0% done.
+func (p *EpicProgress) Percent() int {
+	if len(p.Children) == 0 {
+		return 0
+	}
+	return p.Closed() * 100 / len(p.Children)
+}
+
+// StatusOrder lists bead statuses from not started to done, for showing
+// counts per status
+var StatusOrder = []BeadStatus{
+	BeadStatusOpen, BeadStatusBlocked, BeadStatusInProgress, BeadStatusInReview, BeadStatusPendingApproval, BeadStatusClosed,
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRollUp(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	epic := &Bead{ID: "bd-e", Type: BeadTypeEpic, Status: BeadStatusOpen, DueAt: at(72 * time.Hour)}
+	beads := []*Bead{
+		epic,
+		{ID: "bd-1", ParentID: "bd-e", Status: BeadStatusClosed, DueAt: at(-time.Hour)}, // closed: its deadline no longer counts
+		{ID: "bd-2", ParentID: "bd-e", Status: BeadStatusInProgress, DueAt: at(24 * time.Hour)},
+		{ID: "bd-3", ParentID: "bd-e", Status: BeadStatusBlocked, DueAt: at(-2 * time.Hour)},
+		{ID: "bd-4", ParentID: "bd-e", Status: BeadStatusClosed},
+		{ID: "bd-5", ParentID: "bd-other", Status: BeadStatusOpen},
+	}
+
+	p := RollUp(epic, beads, now)
+	if p.Total() != 4 || p.Closed() != 2 || p.Percent() != 50 {
+		t.Errorf("total %d closed %d percent %d, want 4, 2 and 50", p.Total(), p.Closed(), p.Percent())
+	}
+	if p.ByStatus[BeadStatusBlocked] != 1 || p.ByStatus[BeadStatusInProgress] != 1 {
+		t.Errorf("by status = %v", p.ByStatus)
+	}
+	if p.Overdue != 1 {
+		t.Errorf("overdue = %d, want 1", p.Overdue)
+	}
+	if p.EarliestDueID != "bd-3" || !p.EarliestDue.Equal(*at(-2 * time.Hour)) {
+		t.Errorf("earliest due %v on %s, want bd-3's", p.EarliestDue, p.EarliestDueID)
+	}
+
+	empty := RollUp(&Bead{ID: "bd-x", Type: BeadTypeEpic}, beads, now)
+	if empty.Total() != 0 || empty.Percent() != 0 || empty.EarliestDue != nil {
+		t.Errorf("expected an empty roll-up, got %+v", empty)
+	}
+}
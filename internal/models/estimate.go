@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Estimate sizes scopes
+const (
+	EstimateScopeSmall  = "small"
+	EstimateScopeMedium = "medium"
+	EstimateScopeLarge  = "large"
+)
+
+// Estimate is a preflight guess at a bead's size and cost, recorded before
+// an agent is spawned so it can later be compared with what the work took
+type Estimate struct {
+	At           time.Time `json:"at"`
+	Scope        string    `json:"scope"`                   // small, medium or large
+	Files        []string  `json:"files,omitempty"`         // files likely to be touched
+	CostUSD      float64   `json:"cost_usd"`                // expected cost of doing the work
+	Model        string    `json:"model,omitempty"`         // model the work is routed to, which CostUSD assumes
+	EstimatedBy  string    `json:"estimated_by,omitempty"`  // model that produced the estimate
+	Notes        string    `json:"notes,omitempty"`         // risks and reasoning
+	PreflightUSD float64   `json:"preflight_usd,omitempty"` // what producing the estimate cost
+}
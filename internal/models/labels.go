@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// ParseLabels splits a comma-separated list of labels, lowercasing them and
+// dropping blanks and repeats
+func ParseLabels(s string) []string {
+	var b Bead
+	b.AddLabels(strings.Split(s, ",")...)
+	return b.Labels
+}
+
+// HasLabel reports whether the bead carries the label
+func (b *Bead) HasLabel(label string) bool {
+	return slices.Contains(b.Labels, strings.ToLower(strings.TrimSpace(label)))
+}
+
+// AddLabels adds labels to the bead and reports whether any were new
+func (b *Bead) AddLabels(labels ...string) bool {
+	added := false
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" || b.HasLabel(label) {
+			continue
+		}
+		b.Labels = append(b.Labels, label)
+		added = true
+	}
+	return added
+}
+
+// UnmarshalJSON reads a bead, including ones written before labels were a
+// list. Those stored labels as one comma-separated string, and heresy beads
+// used it for the files the heresy was found in, which now live in Locations.
+func (b *Bead) UnmarshalJSON(data []byte) error {
+	type plain Bead
+	aux := struct {
+		*plain
+		Labels json.RawMessage `json:"labels,omitempty"`
+	}{plain: (*plain)(b)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	b.Labels = nil
+	if len(aux.Labels) == 0 || string(aux.Labels) == "null" {
+		return nil
+	}
+	if aux.Labels[0] == '[' {
+		var labels []string
+		if err := json.Unmarshal(aux.Labels, &labels); err != nil {
+			return err
+		}
+		b.AddLabels(labels...)
+		return nil
+	}
+
+	var legacy string
+	if err := json.Unmarshal(aux.Labels, &legacy); err != nil {
+		return err
+	}
+	if b.Type == BeadTypeHeresy && len(b.Locations) == 0 {
+		for _, loc := range strings.Split(legacy, ",") {
+			if loc = strings.TrimSpace(loc); loc != "" {
+				b.Locations = append(b.Locations, loc)
+			}
+		}
+		return nil
+	}
+	b.Labels = ParseLabels(legacy)
+	return nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	got := ParseLabels(" Backend, ui,,backend ,UI")
+	if want := []string{"backend", "ui"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLabels = %v, want %v", got, want)
+	}
+	if got := ParseLabels(""); got != nil {
+		t.Errorf("ParseLabels(\"\") = %v, want nil", got)
+	}
+}
+
+func TestBeadLabelsJSON(t *testing.T) {
+	cases := []struct {
+		name      string
+		data      string
+		labels    []string
+		locations []string
+	}{
+		{"list", `{"id":"bd-1","labels":["api","Auth"]}`, []string{"api", "auth"}, nil},
+		{"legacy string", `{"id":"bd-1","labels":"api, auth"}`, []string{"api", "auth"}, nil},
+		{"legacy heresy", `{"id":"bd-1","type":"heresy","labels":"main.go:10,util.go:20"}`, nil, []string{"main.go:10", "util.go:20"}},
+		{"heresy with labels", `{"id":"bd-1","type":"heresy","labels":["lint"],"locations":["a.go:1"]}`, []string{"lint"}, []string{"a.go:1"}},
+		{"none", `{"id":"bd-1"}`, nil, nil},
+	}
+	for _, c := range cases {
+		var b Bead
+		if err := json.Unmarshal([]byte(c.data), &b); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if b.ID != "bd-1" || !reflect.DeepEqual(b.Labels, c.labels) || !reflect.DeepEqual(b.Locations, c.locations) {
+			t.Errorf("%s: id %q labels %v locations %v, want %v %v", c.name, b.ID, b.Labels, b.Locations, c.labels, c.locations)
+		}
+	}
+
+	data, err := json.Marshal(&Bead{ID: "bd-1", Labels: []string{"api"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Bead
+	if err := json.Unmarshal(data, &back); err != nil || !back.HasLabel("API") {
+		t.Errorf("round trip lost labels: %s %v", data, err)
+	}
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// AgentMessage is a message passed directly from one agent to another
+type AgentMessage struct {
+	ID        string    `json:"id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	BeadID    string    `json:"bead_id,omitempty"`
+	Body      string    `json:"body"`
+	Timestamp time.Time `json:"timestamp"`
+	Read      bool      `json:"read"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// Origin identifies a federated bead across mob instances: the instance that
+// first shared it and the ID it has there. Copies on other instances get
+// their own local IDs and are matched by origin instead.
+type Origin struct {
+	Instance string `json:"instance"`
+	ID       string `json:"id"`
+}
@@ -11,6 +11,7 @@ const (
 	ReportTypeQuestion   ReportType = "question"
 	ReportTypeEscalation ReportType = "escalation"
 	ReportTypeProgress   ReportType = "progress"
+	ReportTypeHumanInput ReportType = "human_input" // a question only a human can answer; the agent waits on Answer
 )
 
 // AgentReport represents a report from an agent to the underboss
@@ -23,4 +24,16 @@ type AgentReport struct {
 	Message   string     `json:"message"`
 	Timestamp time.Time  `json:"timestamp"`
 	Handled   bool       `json:"handled"`
+
+	// Human input requests only
+	Options    []string   `json:"options,omitempty"`     // suggested answers, if the agent offered any
+	Answer     string     `json:"answer,omitempty"`      // the human's reply
+	AnsweredBy string     `json:"answered_by,omitempty"` // who replied
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	NotifiedAt *time.Time `json:"notified_at,omitempty"` // when the operator was notified
+}
+
+// Answered reports whether a human has replied to the request
+func (r *AgentReport) Answered() bool {
+	return r.AnsweredAt != nil
 }
@@ -17,4 +17,19 @@ type Soldati struct {
 	Stats       SoldatiStats `toml:"stats"`
 	Turfs       []string     `toml:"turfs,omitempty"`        // assigned turfs, empty = all turfs
 	PrimaryTurf string       `toml:"primary_turf,omitempty"` // preferred turf
+	Role        string       `toml:"role,omitempty"`         // specialty added to the system prompt
+	Model       string       `toml:"model,omitempty"`        // model for all of its work, overriding the router
+	AutoScaled  bool         `toml:"autoscaled,omitempty"`   // added by the daemon for PrimaryTurf's backlog, retired once it drains
+	Skills      []string     `toml:"skills,omitempty"`       // lowercase tags; beads labelled with them are routed here first
+}
+
+// SkillMatch counts the bead's labels that are among the soldati's skills
+func (s *Soldati) SkillMatch(b *Bead) int {
+	n := 0
+	for _, skill := range s.Skills {
+		if b.HasLabel(skill) {
+			n++
+		}
+	}
+	return n
 }
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// SpawnRequest is a spawn_associate call waiting for associate capacity
+type SpawnRequest struct {
+	ID          string    `json:"id"`
+	Turf        string    `json:"turf"`
+	Task        string    `json:"task"`
+	WorkDir     string    `json:"work_dir,omitempty"`
+	BeadID      string    `json:"bead_id,omitempty"`
+	Tag         string    `json:"tag,omitempty"`
+	RequestedBy string    `json:"requested_by,omitempty"` // agent that asked for the associate
+	RequestedAt time.Time `json:"requested_at"`
+	ExpiresAt   time.Time `json:"expires_at"` // dropped if still waiting by then
+}
+
+// Expired reports whether the request has waited too long to be started
+func (r *SpawnRequest) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
+}
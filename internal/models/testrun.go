@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TestRun is the outcome of running a turf's test command for a bead
+type TestRun struct {
+	At       time.Time     `json:"at"`
+	Command  string        `json:"command"`
+	Commit   string        `json:"commit,omitempty"` // HEAD of the worktree when the tests ran
+	Dirty    bool          `json:"dirty,omitempty"`  // the worktree had uncommitted changes when the tests ran
+	Passed   bool          `json:"passed"`
+	ExitCode int           `json:"exit_code"`
+	TimedOut bool          `json:"timed_out,omitempty"`
+	Duration time.Duration `json:"duration"`
+	LogPath  string        `json:"log_path,omitempty"` // full output
+}
@@ -2,9 +2,11 @@ package models
 
 // Turf represents a registered project
 type Turf struct {
-	Name       string `toml:"name"`
-	Path       string `toml:"path"`
-	MainBranch string `toml:"main_branch"`
+	Name        string `toml:"name"`
+	Path        string `toml:"path"`
+	MainBranch  string `toml:"main_branch"`
+	TestCommand string `toml:"test_command,omitempty"` // run by the run_tests tool in a bead's worktree
+	VCS         string `toml:"vcs,omitempty"`          // "git" or "jj"; empty = detect from the repository
 }
 
 // TurfsConfig holds all registered turfs
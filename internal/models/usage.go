@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// UsageEntry is one agent call in the usage ledger: who made it, what it was
+// for and the tokens and dollars it took
+type UsageEntry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Agent        string    `json:"agent"` // soldati or associate name, or "underboss"
+	Turf         string    `json:"turf,omitempty"`
+	BeadID       string    `json:"bead_id,omitempty"`
+	Model        string    `json:"model,omitempty"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	CostUSD      float64   `json:"cost_usd"`
+}
+
+// Tokens returns the entry's input and output tokens together
+func (e UsageEntry) Tokens() int {
+	return e.InputTokens + e.OutputTokens
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// mentionPattern matches @name where name starts with a letter; an @ inside
+// a word (such as an email address) is not a mention
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z][\w.-]*\w|[A-Za-z])`)
+
+// Mentions returns the distinct names @-mentioned in text, lowercased, in
+// the order they first appear
+func Mentions(text string) []string {
+	var names []string
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		name := strings.ToLower(m[1])
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// IsWatching reports whether name is watching the bead
+func (b *Bead) IsWatching(name string) bool {
+	return slices.Contains(b.Watchers, strings.ToLower(name))
+}
+
+// AddWatchers adds names to the bead's watchers and reports whether any were new
+func (b *Bead) AddWatchers(names ...string) bool {
+	added := false
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || b.IsWatching(name) {
+			continue
+		}
+		b.Watchers = append(b.Watchers, name)
+		added = true
+	}
+	return added
+}
+
+// RemoveWatchers drops names from the bead's watchers and reports whether any were removed
+func (b *Bead) RemoveWatchers(names ...string) bool {
+	before := len(b.Watchers)
+	b.Watchers = slices.DeleteFunc(b.Watchers, func(w string) bool {
+		return slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, w) })
+	})
+	return len(b.Watchers) != before
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"@Alice can you look?", []string{"alice"}},
+		{"cc @bob, @alice and @bob again", []string{"bob", "alice"}},
+		{"mail gabe@example.com about it", nil},
+		{"(@carol-d) thoughts?", []string{"carol-d"}},
+		{"no mentions here", nil},
+	}
+	for _, tt := range tests {
+		if got := Mentions(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("Mentions(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWatchers(t *testing.T) {
+	b := &Bead{}
+	if !b.AddWatchers("Alice", "bob", " ") {
+		t.Fatal("expected watchers added")
+	}
+	if b.AddWatchers("alice") {
+		t.Error("expected duplicate watcher ignored")
+	}
+	if !b.IsWatching("ALICE") {
+		t.Error("expected case-insensitive match")
+	}
+	if !b.RemoveWatchers("alice") || b.IsWatching("alice") {
+		t.Error("expected alice removed")
+	}
+	if b.RemoveWatchers("nobody") {
+		t.Error("expected no change removing a non-watcher")
+	}
+	if !slices.Equal(b.Watchers, []string{"bob"}) {
+		t.Errorf("unexpected watchers %v", b.Watchers)
+	}
+}
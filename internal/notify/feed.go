@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FeedEntry is a notification as recorded in the feed
+type FeedEntry struct {
+	ID        string           `json:"id"`
+	Type      NotificationType `json:"type"`
+	Title     string           `json:"title"`
+	Message   string           `json:"message"`
+	Timestamp time.Time        `json:"timestamp"`
+	BeadID    string           `json:"bead_id,omitempty"`  // bead the notification is about
+	AgentID   string           `json:"agent_id,omitempty"` // agent it is about
+	Agent     string           `json:"agent,omitempty"`    // that agent's name
+	Read      bool             `json:"-"`                  // set by ReadFeed from the read file
+}
+
+// Feed records notifications in a JSONL file, which the dashboard's
+// notifications tab reads. The daemon and MCP servers append to the same
+// feed; each write is one line, so appends from several processes do not
+// interleave.
+type Feed struct {
+	mu   sync.Mutex
+	path string
+}
+
+// FeedPath returns where the notification feed lives for a mob directory
+func FeedPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "notifications.jsonl")
+}
+
+// readPath is where the IDs of notifications marked read are kept
+func readPath(feedPath string) string {
+	return strings.TrimSuffix(feedPath, ".jsonl") + ".read"
+}
+
+// NewFeed creates a feed backend writing to path
+func NewFeed(path string) *Feed {
+	return &Feed{path: path}
+}
+
+// Notify appends the notification to the feed
+func (f *Feed) Notify(notification Notification) error {
+	if notification.Timestamp.IsZero() {
+		notification.Timestamp = time.Now()
+	}
+	entry := FeedEntry{
+		ID:        strconv.FormatInt(notification.Timestamp.UnixNano(), 36),
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		Timestamp: notification.Timestamp,
+		BeadID:    dataString(notification.Data, "bead_id"),
+		AgentID:   dataString(notification.Data, "agent_id"),
+		Agent:     dataString(notification.Data, "agent_name", "agent", "assignee"),
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return appendLine(f.path, line)
+}
+
+// Close is a no-op; the feed is opened for each notification
+func (f *Feed) Close() error {
+	return nil
+}
+
+// dataString returns the first of keys set to a non-empty string in data
+func dataString(data map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := data[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// appendLine writes one line to the end of a file, creating it and its
+// directory when missing
+func appendLine(path string, line []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create notification directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open notification feed: %w", err)
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// ReadFeed returns the latest limit notifications in the feed at path,
+// oldest first, marked with whether they have been read. A missing feed has
+// none; lines that do not parse are skipped.
+func ReadFeed(path string, limit int) ([]FeedEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []FeedEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry FeedEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) > 2*limit {
+			entries = append(entries[:0], entries[len(entries)-limit:]...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	read, err := readIDs(path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Read = read[entries[i].ID]
+	}
+	return entries, nil
+}
+
+// readIDs returns the IDs of the notifications marked read
+func readIDs(feedPath string) (map[string]bool, error) {
+	data, err := os.ReadFile(readPath(feedPath))
+	if os.IsNotExist(err) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	read := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		if id, unread := strings.CutPrefix(line, "-"); unread {
+			delete(read, id)
+		} else if line != "" {
+			read[line] = true
+		}
+	}
+	return read, nil
+}
+
+// MarkRead records notifications in the feed at path as read, or with read
+// false as unread again
+func MarkRead(path string, read bool, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	prefix := ""
+	if !read {
+		prefix = "-"
+	}
+	lines := make([]string, len(ids))
+	for i, id := range ids {
+		lines[i] = prefix + id
+	}
+	return appendLine(readPath(path), []byte(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFeedRecordsNotifications(t *testing.T) {
+	path := FeedPath(t.TempDir())
+	manager := NewManager(NewFeed(path))
+
+	if err := manager.NotifyApprovalNeeded("bd-a1b2", "Drop the users table"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.NotifyAgentStuck("vinnie", "agent-1", "bd-c3d4"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadFeed(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if e := entries[0]; e.Type != NotificationTypeApprovalNeeded || e.BeadID != "bd-a1b2" || e.Read {
+		t.Errorf("unexpected first entry %+v", e)
+	}
+	if e := entries[1]; e.AgentID != "agent-1" || e.Agent != "vinnie" || e.ID == entries[0].ID {
+		t.Errorf("unexpected second entry %+v", e)
+	}
+
+	if latest, _ := ReadFeed(path, 1); len(latest) != 1 || latest[0].ID != entries[1].ID {
+		t.Errorf("expected the limit to keep the newest entry, got %+v", latest)
+	}
+}
+
+func TestFeedReadState(t *testing.T) {
+	path := FeedPath(t.TempDir())
+	feed := NewFeed(path)
+	for _, title := range []string{"one", "two"} {
+		if err := feed.Notify(Notification{Type: NotificationTypeInfo, Title: title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, _ := ReadFeed(path, 0)
+
+	if err := MarkRead(path, true, entries[0].ID, entries[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := MarkRead(path, false, entries[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadFeed(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !entries[0].Read || entries[1].Read {
+		t.Errorf("expected only the first entry read, got %v and %v", entries[0].Read, entries[1].Read)
+	}
+}
+
+func TestReadFeedMissing(t *testing.T) {
+	entries, err := ReadFeed(filepath.Join(t.TempDir(), "none.jsonl"), 10)
+	if err != nil || entries != nil {
+		t.Fatalf("expected no entries from a missing feed, got %v, %v", entries, err)
+	}
+}
@@ -2,6 +2,7 @@ package notify
 
 import (
 	"fmt"
+	"time"
 )
 
 // NotifyTaskComplete sends a notification for task completion
@@ -29,6 +30,39 @@ func (m *Manager) NotifyApprovalNeeded(beadID, title string) error {
 	})
 }
 
+// NotifyHumanInputNeeded sends a notification when an agent asks a human a question
+func (m *Manager) NotifyHumanInputNeeded(reportID, agentName, beadID, question string) error {
+	return m.Notify(Notification{
+		Type:    NotificationTypeHumanInput,
+		Title:   "Input Needed",
+		Message: fmt.Sprintf("%s asks: %s (answer with: mob reports answer %s <answer>)", agentName, question, reportID),
+		Data: map[string]interface{}{
+			"report_id": reportID,
+			"agent":     agentName,
+			"bead_id":   beadID,
+		},
+	})
+}
+
+// NotifyBeadOverdue sends a notification when a bead misses its due date or SLA
+func (m *Manager) NotifyBeadOverdue(beadID, title, assignee string, deadline time.Time, priority int) error {
+	owner := assignee
+	if owner == "" {
+		owner = "unassigned"
+	}
+	return m.Notify(Notification{
+		Type:    NotificationTypeOverdue,
+		Title:   "Bead Overdue",
+		Message: fmt.Sprintf("Bead %s (%s) was due %s and is still open; now P%d: %s", beadID, owner, deadline.Format("Jan 2 15:04"), priority, title),
+		Data: map[string]interface{}{
+			"bead_id":  beadID,
+			"assignee": assignee,
+			"due_at":   deadline,
+			"priority": priority,
+		},
+	})
+}
+
 // NotifyAgentStuck sends a notification when an agent appears stuck
 func (m *Manager) NotifyAgentStuck(agentName, agentID, task string) error {
 	return m.Notify(Notification{
@@ -70,6 +104,37 @@ func (m *Manager) NotifyRateLimit(remainingTokens int, resetTime string) error {
 	})
 }
 
+// NotifyModelDegraded sends a notification when a model's provider keeps
+// failing. secondary is the model calls fail over to, empty when none is set.
+func (m *Manager) NotifyModelDegraded(model, secondary, lastError string) error {
+	message := fmt.Sprintf("Model %s keeps failing: %s.", model, lastError)
+	if secondary != "" {
+		message += fmt.Sprintf(" Calls fail over to %s until it recovers.", secondary)
+	}
+	return m.Notify(Notification{
+		Type:    NotificationTypeRateLimit,
+		Title:   "Model Degraded",
+		Message: message,
+		Data: map[string]interface{}{
+			"model":     model,
+			"secondary": secondary,
+			"error":     lastError,
+		},
+	})
+}
+
+// NotifyModelRecovered sends a notification when a degraded model answers again
+func (m *Manager) NotifyModelRecovered(model string) error {
+	return m.Notify(Notification{
+		Type:    NotificationTypeInfo,
+		Title:   "Model Recovered",
+		Message: fmt.Sprintf("Model %s is answering again; failover has ended.", model),
+		Data: map[string]interface{}{
+			"model": model,
+		},
+	})
+}
+
 // NotifyInfo sends a general informational notification
 func (m *Manager) NotifyInfo(title, message string) error {
 	return m.Notify(Notification{
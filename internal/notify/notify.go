@@ -13,6 +13,10 @@ const (
 	NotificationTypeError         NotificationType = "error"
 	NotificationTypeRateLimit     NotificationType = "rate_limit"
 	NotificationTypeInfo          NotificationType = "info"
+	NotificationTypeWatch         NotificationType = "watch"   // activity on a watched bead
+	NotificationTypeMention       NotificationType = "mention" // @-mentioned in a bead comment
+	NotificationTypeHumanInput    NotificationType = "human_input" // an agent is waiting on an answer
+	NotificationTypeOverdue       NotificationType = "overdue"     // a bead missed its due date or SLA
 )
 
 // Notification represents a notification to be sent
@@ -44,6 +48,11 @@ func NewManager(notifiers ...Notifier) *Manager {
 	}
 }
 
+// Add registers another backend
+func (m *Manager) Add(notifier Notifier) {
+	m.notifiers = append(m.notifiers, notifier)
+}
+
 // Notify sends a notification to all registered backends
 func (m *Manager) Notify(notification Notification) error {
 	// Set timestamp if not provided
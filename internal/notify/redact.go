@@ -0,0 +1,40 @@
+package notify
+
+import "github.com/gabe/mob/internal/redact"
+
+// redacting masks secrets in notifications before a backend sees them
+type redacting struct {
+	Notifier
+	r *redact.Redactor
+}
+
+// Redacted wraps a backend so every notification it sends is redacted first.
+// A nil redactor returns the backend unchanged.
+func Redacted(n Notifier, r *redact.Redactor) Notifier {
+	if r == nil {
+		return n
+	}
+	return &redacting{Notifier: n, r: r}
+}
+
+func (n *redacting) Notify(notification Notification) error {
+	notification.Title = n.r.String(notification.Title)
+	notification.Message = n.r.String(notification.Message)
+	if notification.Data != nil {
+		data := make(map[string]interface{}, len(notification.Data))
+		for k, v := range notification.Data {
+			data[k] = n.r.Value(v)
+		}
+		notification.Data = data
+	}
+	return n.Notifier.Notify(notification)
+}
+
+// SetRedactor redacts every notification the manager sends from now on
+func (m *Manager) SetRedactor(r *redact.Redactor) {
+	for i, n := range m.notifiers {
+		if _, done := n.(*redacting); !done {
+			m.notifiers[i] = Redacted(n, r)
+		}
+	}
+}
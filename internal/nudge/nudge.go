@@ -4,18 +4,18 @@ package nudge
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"sync"
 	"time"
 
 	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/errkind"
 	"github.com/gabe/mob/internal/hook"
 )
 
 // ErrAgentNotFound is returned when the specified agent cannot be found
-var ErrAgentNotFound = errors.New("agent not found")
+var ErrAgentNotFound = errkind.New(errkind.NotFound, "agent not found")
 
 // NudgeLevel represents the escalation level for nudging stuck agents
 type NudgeLevel int
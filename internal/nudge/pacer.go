@@ -0,0 +1,217 @@
+package nudge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Limits bound how often and how expensively agents are nudged
+type Limits struct {
+	Interval   time.Duration // wait after a nudge that led to progress
+	MaxBackoff time.Duration // longest wait after nudges that led nowhere; 0 = no backoff
+	PerDay     int           // nudges a day across all agents; 0 = unlimited
+	BudgetUSD  float64       // what nudges may cost a day; 0 = unlimited
+}
+
+// Day is a day's nudging, kept so restarts do not reset the budget
+type Day struct {
+	Date    string  `json:"date"` // local date, 2006-01-02
+	Nudges  int     `json:"nudges"`
+	CostUSD float64 `json:"cost_usd"`
+}
+
+// Reasons a nudge is held back
+const (
+	HeldBackoff = "backoff"
+	HeldLimit   = "daily nudge limit"
+	HeldBudget  = "daily nudge budget"
+)
+
+// pace is what the pacer remembers about one agent
+type pace struct {
+	last   time.Time // when it was last nudged
+	state  string    // its progress marker then
+	misses int       // nudges in a row that led to no progress
+}
+
+// Pacer decides when agents with work are nudged. An agent whose state has
+// not moved since its last nudge waits twice as long each time, up to
+// MaxBackoff, and nudging stops for the day once it uses up the daily limit
+// or budget.
+type Pacer struct {
+	path   string
+	mu     sync.Mutex
+	limits Limits
+	agents map[string]*pace
+	day    Day
+}
+
+// PacerPath returns where the day's nudging is kept
+func PacerPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "nudges.json")
+}
+
+// NewPacer returns a pacer that keeps the day's nudging at path, picking up
+// what was already spent today
+func NewPacer(path string, limits Limits) *Pacer {
+	p := &Pacer{path: path, limits: limits, agents: make(map[string]*pace)}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &p.day)
+	}
+	return p
+}
+
+// SetLimits replaces the limits, keeping each agent's backoff
+func (p *Pacer) SetLimits(limits Limits) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limits = limits
+}
+
+// Allow reports whether agent may be nudged at now given state, a marker of
+// its progress (what it is working on and how far along). A marker that
+// changed since the last nudge means that nudge worked, and the backoff
+// resets. When the nudge is held back, the reason says why.
+func (p *Pacer) Allow(agent, state string, now time.Time) (bool, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if held := p.overBudget(now); held != "" {
+		return false, held
+	}
+	a, ok := p.agents[agent]
+	if !ok || a.state != state {
+		return true, ""
+	}
+	if now.Sub(a.last) < p.wait(a.misses) {
+		return false, HeldBackoff
+	}
+	return true, ""
+}
+
+// Nudged records a nudge sent at now with the agent's progress marker
+func (p *Pacer) Nudged(agent, state string, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	a, ok := p.agents[agent]
+	switch {
+	case !ok:
+		a = &pace{}
+		p.agents[agent] = a
+	case a.state == state:
+		a.misses++
+	default:
+		a.misses = 0
+	}
+	a.last, a.state = now, state
+
+	p.rollover(now)
+	p.day.Nudges++
+	p.save()
+}
+
+// Spent records what a nudge cost
+func (p *Pacer) Spent(usd float64, now time.Time) {
+	if usd <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rollover(now)
+	p.day.CostUSD += usd
+	p.save()
+}
+
+// Forget drops an agent's backoff, for agents that stopped or were handed
+// new work
+func (p *Pacer) Forget(agent string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.agents, agent)
+}
+
+// Backoff returns how long agent now waits between nudges
+func (p *Pacer) Backoff(agent string) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if a, ok := p.agents[agent]; ok {
+		return p.wait(a.misses)
+	}
+	return p.limits.Interval
+}
+
+// Today returns the nudging done so far on now's day
+func (p *Pacer) Today(now time.Time) Day {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rollover(now)
+	return p.day
+}
+
+// wait returns the gap after misses unproductive nudges in a row
+func (p *Pacer) wait(misses int) time.Duration {
+	wait := p.limits.Interval
+	if p.limits.MaxBackoff <= 0 {
+		return wait
+	}
+	for i := 0; i < misses && wait < p.limits.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.limits.MaxBackoff {
+		wait = p.limits.MaxBackoff
+	}
+	return wait
+}
+
+// overBudget returns why nudging is held for the rest of the day, if it is
+func (p *Pacer) overBudget(now time.Time) string {
+	p.rollover(now)
+	if p.limits.PerDay > 0 && p.day.Nudges >= p.limits.PerDay {
+		return HeldLimit
+	}
+	if p.limits.BudgetUSD > 0 && p.day.CostUSD >= p.limits.BudgetUSD {
+		return HeldBudget
+	}
+	return ""
+}
+
+// rollover starts a new day's count once the local date changes
+func (p *Pacer) rollover(now time.Time) {
+	if date := now.Local().Format("2006-01-02"); p.day.Date != date {
+		p.day = Day{Date: date}
+	}
+}
+
+func (p *Pacer) save() {
+	if p.path == "" {
+		return
+	}
+	data, err := json.Marshal(p.day)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err == nil {
+		os.Rename(tmp, p.path)
+	}
+}
+
+// Describe summarises a day's nudging against the limits
+func (d Day) Describe(limits Limits) string {
+	s := fmt.Sprintf("%d nudge(s)", d.Nudges)
+	if limits.PerDay > 0 {
+		s = fmt.Sprintf("%d/%d nudges", d.Nudges, limits.PerDay)
+	}
+	if limits.BudgetUSD > 0 {
+		return s + fmt.Sprintf(", $%.2f/$%.2f", d.CostUSD, limits.BudgetUSD)
+	}
+	return s + fmt.Sprintf(", $%.2f", d.CostUSD)
+}
@@ -0,0 +1,69 @@
+package nudge
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPacer_Backoff(t *testing.T) {
+	p := NewPacer("", Limits{Interval: 5 * time.Minute, MaxBackoff: 20 * time.Minute})
+	now := time.Date(2026, 1, 14, 12, 0, 0, 0, time.Local)
+
+	if ok, _ := p.Allow("vinnie", "bd-1|working", now); !ok {
+		t.Fatal("first nudge should be allowed")
+	}
+	p.Nudged("vinnie", "bd-1|working", now)
+
+	// No progress: each nudge doubles the wait, up to the cap
+	for i, wait := range []time.Duration{5 * time.Minute, 10 * time.Minute, 20 * time.Minute, 20 * time.Minute} {
+		if got := p.Backoff("vinnie"); got != wait {
+			t.Fatalf("after %d unproductive nudges backoff = %v, want %v", i, got, wait)
+		}
+		if ok, held := p.Allow("vinnie", "bd-1|working", now.Add(wait-time.Second)); ok || held != HeldBackoff {
+			t.Fatalf("nudge %d allowed before its backoff (%v, %q)", i, ok, held)
+		}
+		now = now.Add(wait)
+		if ok, _ := p.Allow("vinnie", "bd-1|working", now); !ok {
+			t.Fatalf("nudge %d held after its backoff", i)
+		}
+		p.Nudged("vinnie", "bd-1|working", now)
+	}
+
+	// Progress resets the backoff
+	if ok, _ := p.Allow("vinnie", "bd-1|reviewing", now.Add(time.Second)); !ok {
+		t.Error("a nudge after progress should be allowed straight away")
+	}
+	p.Nudged("vinnie", "bd-1|reviewing", now)
+	if got := p.Backoff("vinnie"); got != 5*time.Minute {
+		t.Errorf("backoff after progress = %v, want 5m", got)
+	}
+}
+
+func TestPacer_DailyLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mob", "nudges.json")
+	limits := Limits{Interval: time.Minute, PerDay: 2, BudgetUSD: 1}
+	p := NewPacer(path, limits)
+	now := time.Date(2026, 1, 14, 12, 0, 0, 0, time.Local)
+
+	p.Nudged("vinnie", "a", now)
+	p.Spent(1.5, now)
+	if ok, held := p.Allow("sal", "b", now); ok || held != HeldBudget {
+		t.Errorf("over budget = %v, %q", ok, held)
+	}
+
+	// A restart picks up the day's spend
+	p = NewPacer(path, Limits{Interval: time.Minute, PerDay: 2})
+	p.Nudged("sal", "b", now)
+	if ok, held := p.Allow("tony", "c", now); ok || held != HeldLimit {
+		t.Errorf("at the daily limit = %v, %q", ok, held)
+	}
+	if got := p.Today(now).Describe(limits); got != "2/2 nudges, $1.50/$1.00" {
+		t.Errorf("Describe() = %q", got)
+	}
+
+	// The next day starts fresh
+	if ok, _ := p.Allow("tony", "c", now.Add(24*time.Hour)); !ok {
+		t.Error("limits should reset the next day")
+	}
+}
@@ -4,15 +4,15 @@ package patrol
 
 import (
 	"context"
-	"errors"
 	"sync"
 	"time"
 
 	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/errkind"
 )
 
 // ErrAgentNotFound is returned when an agent cannot be found
-var ErrAgentNotFound = errors.New("agent not found")
+var ErrAgentNotFound = errkind.New(errkind.NotFound, "agent not found")
 
 // AgentStatus represents the health status of an agent
 type AgentStatus struct {
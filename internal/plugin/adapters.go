@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/sweep"
+)
+
+// Detector runs one plugin detector as a sweep detector
+type Detector struct {
+	plugin *Plugin
+	spec   DetectorSpec
+}
+
+// Name returns the detector name qualified by its plugin
+func (d *Detector) Name() string { return d.plugin.Name + "/" + d.spec.Name }
+
+// Sweep returns the sweep type the detector runs in
+func (d *Detector) Sweep() sweep.SweepType { return sweep.SweepType(d.spec.Sweep) }
+
+// Detect asks the plugin for issues in a turf
+func (d *Detector) Detect(ctx context.Context, turfPath string) ([]sweep.Issue, error) {
+	resp, err := d.plugin.Call(ctx, Request{Type: CallDetect, Detector: d.spec.Name, TurfPath: turfPath})
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]sweep.Issue, 0, len(resp.Issues))
+	for _, i := range resp.Issues {
+		issueType := i.Type
+		if issueType == "" {
+			issueType = d.spec.Name
+		}
+		issues = append(issues, sweep.Issue{
+			File:        i.File,
+			Line:        i.Line,
+			Type:        issueType,
+			Description: i.Description,
+			Context:     i.Context,
+		})
+	}
+	return issues, nil
+}
+
+// Detectors returns the sweep detectors declared by plugins
+func Detectors(plugins []*Plugin) []sweep.Detector {
+	var detectors []sweep.Detector
+	for _, p := range plugins {
+		for _, spec := range p.Detectors {
+			detectors = append(detectors, &Detector{plugin: p, spec: spec})
+		}
+	}
+	return detectors
+}
+
+// Notifier forwards mob notifications to a plugin
+type Notifier struct {
+	plugin *Plugin
+}
+
+// NewNotifier returns a notification backend for one plugin, whether or not
+// it asked for every notification
+func NewNotifier(p *Plugin) *Notifier {
+	return &Notifier{plugin: p}
+}
+
+// Notify sends a notification to the plugin
+func (n *Notifier) Notify(notification notify.Notification) error {
+	_, err := n.plugin.Call(context.Background(), Request{
+		Type: CallNotify,
+		Notification: &Notification{
+			Type:      string(notification.Type),
+			Title:     notification.Title,
+			Message:   notification.Message,
+			Timestamp: notification.Timestamp,
+			Data:      notification.Data,
+		},
+	})
+	return err
+}
+
+// Close is a no-op; plugins run one process per call
+func (n *Notifier) Close() error { return nil }
+
+// Notifiers returns a notification backend for every plugin that asked for one
+func Notifiers(plugins []*Plugin) []notify.Notifier {
+	var notifiers []notify.Notifier
+	for _, p := range plugins {
+		if p.Notifier {
+			notifiers = append(notifiers, NewNotifier(p))
+		}
+	}
+	return notifiers
+}
@@ -0,0 +1,254 @@
+// Package plugin discovers and runs external plugins that add MCP tools,
+// sweep detectors and notification backends without forking mob.
+//
+// A plugin is a directory under ~/mob/plugins/ holding a plugin.toml
+// manifest and an executable. Each call runs the executable once: mob writes
+// a single JSON Request to its stdin and reads a single JSON Response from
+// its stdout. Anything written to stderr is included in errors.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// ManifestName is the file that marks a directory as a plugin
+const ManifestName = "plugin.toml"
+
+// DefaultTimeout bounds a single plugin call when the manifest sets none
+const DefaultTimeout = 30 * time.Second
+
+// ErrInvalidManifest is returned when a plugin.toml cannot be used
+var ErrInvalidManifest = errkind.New(errkind.Invalid, "invalid plugin manifest")
+
+// Call types sent in Request.Type
+const (
+	CallTool   = "tool"
+	CallDetect = "detect"
+	CallNotify = "notify"
+)
+
+// Manifest is the contents of a plugin.toml
+type Manifest struct {
+	Name        string         `toml:"name"`
+	Description string         `toml:"description"`
+	Command     string         `toml:"command"` // executable, relative to the plugin directory unless absolute
+	Args        []string       `toml:"args"`
+	Timeout     string         `toml:"timeout"` // per call, e.g. "10s"; default 30s
+	Tools       []ToolSpec     `toml:"tools"`
+	Detectors   []DetectorSpec `toml:"detectors"`
+	Notifier    bool           `toml:"notifier"` // receive every mob notification
+}
+
+// ToolSpec declares an MCP tool served by the plugin
+type ToolSpec struct {
+	Name        string                 `toml:"name"`
+	Description string                 `toml:"description"`
+	InputSchema map[string]interface{} `toml:"input_schema"` // JSON schema; defaults to an empty object
+}
+
+// DetectorSpec declares a sweep detector served by the plugin
+type DetectorSpec struct {
+	Name  string `toml:"name"`
+	Sweep string `toml:"sweep"` // "review" or "bugs"
+}
+
+// Request is the JSON written to a plugin's stdin
+type Request struct {
+	Type         string                 `json:"type"`
+	MobDir       string                 `json:"mob_dir"`
+	Tool         string                 `json:"tool,omitempty"`
+	Arguments    map[string]interface{} `json:"arguments,omitempty"`
+	Detector     string                 `json:"detector,omitempty"`
+	TurfPath     string                 `json:"turf_path,omitempty"`
+	Notification *Notification          `json:"notification,omitempty"`
+}
+
+// Notification is a mob notification as sent to notifier plugins
+type Notification struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Message   string                 `json:"message"`
+	Timestamp time.Time              `json:"timestamp"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// Response is the JSON a plugin writes to stdout
+type Response struct {
+	Result string  `json:"result,omitempty"` // tool output
+	Error  string  `json:"error,omitempty"`  // set when the call failed
+	Issues []Issue `json:"issues,omitempty"` // detector findings
+}
+
+// Issue is one finding reported by a detector
+type Issue struct {
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Type        string `json:"type"` // e.g. "TODO", "security"
+	Description string `json:"description"`
+	Context     string `json:"context,omitempty"`
+}
+
+// Plugin is a discovered plugin ready to call
+type Plugin struct {
+	Manifest
+	Dir     string
+	MobDir  string
+	timeout time.Duration
+}
+
+// Dir returns the plugins directory for a mob directory
+func Dir(mobDir string) string {
+	return filepath.Join(mobDir, "plugins")
+}
+
+// Discover loads every plugin under dir, sorted by name. A plugin whose
+// manifest is broken is skipped and reported in the returned errors so one
+// bad plugin does not take the rest down. A missing dir yields nothing.
+func Discover(dir, mobDir string) ([]*Plugin, []error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to read plugins directory: %w", err)}
+	}
+
+	var plugins []*Plugin
+	var errs []error
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		manifestPath := filepath.Join(dir, e.Name(), ManifestName)
+		if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
+			continue
+		}
+
+		p, err := Load(filepath.Join(dir, e.Name()), mobDir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if seen[p.Name] {
+			errs = append(errs, fmt.Errorf("%w: %s: duplicate plugin name %q", ErrInvalidManifest, manifestPath, p.Name))
+			continue
+		}
+		seen[p.Name] = true
+		plugins = append(plugins, p)
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, errs
+}
+
+// Load reads and validates the plugin in dir
+func Load(dir, mobDir string) (*Plugin, error) {
+	manifestPath := filepath.Join(dir, ManifestName)
+	var m Manifest
+	if _, err := toml.DecodeFile(manifestPath, &m); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidManifest, manifestPath, err)
+	}
+
+	if m.Name == "" {
+		m.Name = filepath.Base(dir)
+	}
+	if m.Command == "" {
+		return nil, fmt.Errorf("%w: %s: command is required", ErrInvalidManifest, manifestPath)
+	}
+	for _, d := range m.Detectors {
+		if d.Name == "" || (d.Sweep != "review" && d.Sweep != "bugs") {
+			return nil, fmt.Errorf("%w: %s: detector %q needs sweep = \"review\" or \"bugs\"", ErrInvalidManifest, manifestPath, d.Name)
+		}
+	}
+	for _, t := range m.Tools {
+		if t.Name == "" {
+			return nil, fmt.Errorf("%w: %s: every tool needs a name", ErrInvalidManifest, manifestPath)
+		}
+	}
+
+	timeout := DefaultTimeout
+	if m.Timeout != "" {
+		d, err := time.ParseDuration(m.Timeout)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w: %s: invalid timeout %q", ErrInvalidManifest, manifestPath, m.Timeout)
+		}
+		timeout = d
+	}
+
+	return &Plugin{Manifest: m, Dir: dir, MobDir: mobDir, timeout: timeout}, nil
+}
+
+// commandPath resolves the manifest command: a file in the plugin directory
+// wins, otherwise absolute paths and names on PATH are used as is
+func (p *Plugin) commandPath() string {
+	if filepath.IsAbs(p.Command) {
+		return p.Command
+	}
+	local := filepath.Join(p.Dir, p.Command)
+	if _, err := os.Stat(local); err == nil {
+		return local
+	}
+	return p.Command
+}
+
+// Call runs the plugin once with req and returns its response. A response
+// carrying an error message is returned as an error.
+func (p *Plugin) Call(ctx context.Context, req Request) (*Response, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req.MobDir = p.MobDir
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, p.commandPath(), p.Args...)
+	cmd.Dir = p.Dir
+	cmd.Env = append(os.Environ(), "MOB_DIR="+p.MobDir, "MOB_PLUGIN="+p.Name)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errkind.Wrap(errkind.Transient, fmt.Errorf("plugin %s timed out after %s", p.Name, p.timeout))
+		}
+		return nil, fmt.Errorf("plugin %s failed: %w%s", p.Name, err, stderrSuffix(stderr.String()))
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %s returned invalid JSON: %w%s", p.Name, err, stderrSuffix(stderr.String()))
+	}
+	if resp.Error != "" {
+		return &resp, fmt.Errorf("plugin %s: %s", p.Name, resp.Error)
+	}
+	return &resp, nil
+}
+
+// stderrSuffix formats captured stderr for an error message
+func stderrSuffix(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if stderr == "" {
+		return ""
+	}
+	return ": " + stderr
+}
@@ -0,0 +1,131 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/sweep"
+)
+
+const echoScript = `#!/bin/sh
+input=$(cat)
+case "$input" in
+  *'"type":"tool"'*) echo '{"result":"pong from '"$MOB_PLUGIN"'"}' ;;
+  *'"type":"detect"'*) echo '{"issues":[{"file":"main.go","line":3,"description":"missing license header"}]}' ;;
+  *'"type":"notify"'*) echo "$input" > notified.json; echo '{}' ;;
+  *) echo '{"error":"unknown call"}' ;;
+esac
+`
+
+const echoManifest = `
+name = "echo"
+command = "run.sh"
+notifier = true
+
+[[tools]]
+name = "ping"
+description = "Replies with pong"
+
+[[detectors]]
+name = "license"
+sweep = "review"
+`
+
+// writePlugin creates a plugin directory with a manifest and optional script
+func writePlugin(t *testing.T, pluginsDir, name, manifest, script string) string {
+	t.Helper()
+	dir := filepath.Join(pluginsDir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestName), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if script != "" {
+		if err := os.WriteFile(filepath.Join(dir, "run.sh"), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDiscover(t *testing.T) {
+	mobDir := t.TempDir()
+	pluginsDir := Dir(mobDir)
+	writePlugin(t, pluginsDir, "echo", echoManifest, echoScript)
+	writePlugin(t, pluginsDir, "broken", `name = "broken"`, "")
+	if err := os.MkdirAll(filepath.Join(pluginsDir, "not-a-plugin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	plugins, errs := Discover(pluginsDir, mobDir)
+	if len(plugins) != 1 || plugins[0].Name != "echo" {
+		t.Fatalf("plugins = %+v, want just echo", plugins)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrInvalidManifest) || !errors.Is(errs[0], errkind.Invalid) {
+		t.Errorf("errs = %v, want one invalid manifest error", errs)
+	}
+
+	if plugins, errs := Discover(filepath.Join(mobDir, "missing"), mobDir); plugins != nil || errs != nil {
+		t.Errorf("expected nothing from a missing directory, got %v, %v", plugins, errs)
+	}
+}
+
+func TestPluginCalls(t *testing.T) {
+	mobDir := t.TempDir()
+	dir := writePlugin(t, Dir(mobDir), "echo", echoManifest, echoScript)
+	p, err := Load(dir, mobDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	resp, err := p.Call(context.Background(), Request{Type: CallTool, Tool: "ping"})
+	if err != nil || resp.Result != "pong from echo" {
+		t.Errorf("tool call = %+v, %v; want pong from echo", resp, err)
+	}
+
+	if _, err := p.Call(context.Background(), Request{Type: "bogus"}); err == nil || !strings.Contains(err.Error(), "unknown call") {
+		t.Errorf("expected the plugin's error to surface, got %v", err)
+	}
+
+	detectors := Detectors([]*Plugin{p})
+	if len(detectors) != 1 || detectors[0].Name() != "echo/license" || detectors[0].Sweep() != sweep.SweepTypeReview {
+		t.Fatalf("unexpected detectors: %+v", detectors)
+	}
+	issues, err := detectors[0].Detect(context.Background(), "/tmp/turf")
+	if err != nil || len(issues) != 1 || issues[0].File != "main.go" || issues[0].Type != "license" {
+		t.Errorf("Detect() = %+v, %v", issues, err)
+	}
+
+	notifiers := Notifiers([]*Plugin{p})
+	if len(notifiers) != 1 {
+		t.Fatalf("expected one notifier, got %d", len(notifiers))
+	}
+	if err := notify.NewManager(notifiers...).Notify(notify.Notification{Type: notify.NotificationTypeInfo, Title: "Heads up"}); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	sent, err := os.ReadFile(filepath.Join(dir, "notified.json"))
+	if err != nil || !strings.Contains(string(sent), `"title":"Heads up"`) {
+		t.Errorf("notification not delivered: %s, %v", sent, err)
+	}
+}
+
+func TestPluginTimeoutIsTransient(t *testing.T) {
+	mobDir := t.TempDir()
+	dir := writePlugin(t, Dir(mobDir), "slow", "command = \"run.sh\"\ntimeout = \"100ms\"\n", "#!/bin/sh\nexec sleep 5\n")
+	p, err := Load(dir, mobDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.Call(context.Background(), Request{Type: CallTool, Tool: "anything"})
+	if !errors.Is(err, errkind.Transient) {
+		t.Errorf("expected a transient timeout error, got %v", err)
+	}
+}
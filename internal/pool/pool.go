@@ -0,0 +1,264 @@
+// Package pool caps how many agents work at once, across mob and per turf.
+// Work that would go over a limit waits in a queue and starts, oldest first,
+// as running work finishes. A queued item whose turf is still full does not
+// hold up items for other turfs.
+package pool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Limits caps concurrent work. Zero means no limit.
+type Limits struct {
+	Max     int            `json:"max,omitempty"`      // across every turf
+	PerTurf int            `json:"per_turf,omitempty"` // in any one turf, unless overridden
+	Turfs   map[string]int `json:"turfs,omitempty"`    // per-turf overrides keyed by turf name
+}
+
+// TurfLimit returns the limit for one turf
+func (l Limits) TurfLimit(turf string) int {
+	if n, ok := l.Turfs[turf]; ok {
+		return n
+	}
+	return l.PerTurf
+}
+
+// Waiting is queued work
+type Waiting struct {
+	Agent    string    `json:"agent"`
+	Turf     string    `json:"turf,omitempty"`
+	BeadID   string    `json:"bead_id,omitempty"`
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// Running is work in progress
+type Running struct {
+	Agent     string    `json:"agent"`
+	Turf      string    `json:"turf,omitempty"`
+	BeadID    string    `json:"bead_id,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Snapshot is the pool's state at one moment
+type Snapshot struct {
+	Limits  Limits    `json:"limits"`
+	Running []Running `json:"running"`
+	Queue   []Waiting `json:"queue"`
+}
+
+type item struct {
+	Waiting
+	start func()
+}
+
+// Pool admits work while it is under its limits and queues the rest
+type Pool struct {
+	mu       sync.Mutex
+	limits   Limits
+	running  map[string]Running // keyed by agent
+	queue    []*item
+	onChange func(Snapshot)
+	notifyMu sync.Mutex // keeps onChange calls one at a time and in order
+}
+
+// New creates a pool with the given limits
+func New(limits Limits) *Pool {
+	return &Pool{limits: limits, running: make(map[string]Running)}
+}
+
+// OnChange registers fn to be called with the new state whenever work
+// starts, finishes, queues or is dropped
+func (p *Pool) OnChange(fn func(Snapshot)) {
+	p.mu.Lock()
+	p.onChange = fn
+	p.mu.Unlock()
+}
+
+// Submit runs start on its own goroutine now if the agent's work fits under
+// the limits, or queues it until it does. It reports whether the work
+// started. An agent already running waits for its current work to finish.
+func (p *Pool) Submit(agent, turf, beadID string, start func()) bool {
+	p.mu.Lock()
+	it := &item{Waiting: Waiting{Agent: agent, Turf: turf, BeadID: beadID, QueuedAt: time.Now()}, start: start}
+	p.queue = append(p.queue, it)
+	p.admit()
+	started := true
+	for _, q := range p.queue {
+		if q == it {
+			started = false
+			break
+		}
+	}
+	p.mu.Unlock()
+
+	p.changed()
+	return started
+}
+
+// Release marks an agent's work finished and starts queued work that now fits
+func (p *Pool) Release(agent string) {
+	p.mu.Lock()
+	if _, ok := p.running[agent]; !ok {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.running, agent)
+	p.admit()
+	p.mu.Unlock()
+
+	p.changed()
+}
+
+// Cancel drops an agent's queued work, reporting whether there was any
+func (p *Pool) Cancel(agent string) bool {
+	p.mu.Lock()
+	found := false
+	for i, it := range p.queue {
+		if it.Agent == agent {
+			p.queue = append(p.queue[:i:i], p.queue[i+1:]...)
+			found = true
+			break
+		}
+	}
+	p.mu.Unlock()
+
+	if found {
+		p.changed()
+	}
+	return found
+}
+
+// SetLimits changes the limits, starting queued work a raise makes room
+// for. Lowering them never stops running work; it only holds new work back.
+func (p *Pool) SetLimits(limits Limits) {
+	p.mu.Lock()
+	p.limits = limits
+	p.admit()
+	p.mu.Unlock()
+
+	p.changed()
+}
+
+// Snapshot returns the running work, oldest first, and the queue in order
+func (p *Pool) Snapshot() Snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.snapshot()
+}
+
+func (p *Pool) snapshot() Snapshot {
+	s := Snapshot{Limits: p.limits, Running: make([]Running, 0, len(p.running)), Queue: make([]Waiting, 0, len(p.queue))}
+	for _, r := range p.running {
+		s.Running = append(s.Running, r)
+	}
+	sort.Slice(s.Running, func(i, j int) bool { return s.Running[i].StartedAt.Before(s.Running[j].StartedAt) })
+	for _, it := range p.queue {
+		s.Queue = append(s.Queue, it.Waiting)
+	}
+	return s
+}
+
+// fits reports whether an item can start under the current limits (caller
+// must hold the lock)
+func (p *Pool) fits(it *item) bool {
+	if _, busy := p.running[it.Agent]; busy {
+		return false
+	}
+	if p.limits.Max > 0 && len(p.running) >= p.limits.Max {
+		return false
+	}
+	if limit := p.limits.TurfLimit(it.Turf); limit > 0 {
+		n := 0
+		for _, r := range p.running {
+			if r.Turf == it.Turf {
+				n++
+			}
+		}
+		if n >= limit {
+			return false
+		}
+	}
+	return true
+}
+
+// admit starts queued items in order while they fit (caller must hold the lock)
+func (p *Pool) admit() {
+	kept := p.queue[:0]
+	for _, it := range p.queue {
+		if p.fits(it) {
+			p.begin(it)
+			continue
+		}
+		kept = append(kept, it)
+	}
+	for i := len(kept); i < len(p.queue); i++ {
+		p.queue[i] = nil
+	}
+	p.queue = kept
+}
+
+func (p *Pool) begin(it *item) {
+	p.running[it.Agent] = Running{Agent: it.Agent, Turf: it.Turf, BeadID: it.BeadID, StartedAt: time.Now()}
+	go it.start()
+}
+
+func (p *Pool) changed() {
+	p.notifyMu.Lock()
+	defer p.notifyMu.Unlock()
+
+	p.mu.Lock()
+	fn := p.onChange
+	var s Snapshot
+	if fn != nil {
+		s = p.snapshot()
+	}
+	p.mu.Unlock()
+
+	if fn != nil {
+		fn(s)
+	}
+}
+
+// StatePath returns where the daemon publishes the pool's state for
+// `mob status`
+func StatePath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "agent-pool.json")
+}
+
+// Save writes a snapshot for other processes to read
+func Save(path string, s Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal agent pool: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write agent pool: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the snapshot last saved by the daemon. A missing file means
+// nothing is running or queued.
+func Load(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Snapshot{}, nil
+	}
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to read agent pool: %w", err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to parse agent pool: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,168 @@
+package pool
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// starts records which agents' work started
+type starts struct {
+	mu  sync.Mutex
+	got []string
+	wg  sync.WaitGroup
+}
+
+func (s *starts) fn(agent string) func() {
+	s.wg.Add(1)
+	return func() {
+		s.mu.Lock()
+		s.got = append(s.got, agent)
+		s.mu.Unlock()
+		s.wg.Done()
+	}
+}
+
+func (s *starts) wait(t *testing.T, want ...string) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() { s.wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("started work never ran")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen := make(map[string]bool)
+	for _, a := range s.got {
+		seen[a] = true
+	}
+	for _, a := range want {
+		if !seen[a] {
+			t.Errorf("%s did not start; started %v", a, s.got)
+		}
+	}
+}
+
+func queueOf(s Snapshot) []string {
+	var agents []string
+	for _, w := range s.Queue {
+		agents = append(agents, w.Agent)
+	}
+	return agents
+}
+
+func TestPool_GlobalLimit(t *testing.T) {
+	p := New(Limits{Max: 2})
+	var s starts
+
+	for _, agent := range []string{"vinnie", "sal"} {
+		if !p.Submit(agent, "api", "", s.fn(agent)) {
+			t.Fatalf("%s should start under the limit", agent)
+		}
+	}
+	if p.Submit("tony", "web", "bd-a1b2", func() { t.Error("tony started over the limit") }) {
+		t.Fatal("tony should have been queued")
+	}
+	s.wait(t, "vinnie", "sal")
+
+	snap := p.Snapshot()
+	if len(snap.Running) != 2 || len(snap.Queue) != 1 || snap.Queue[0].BeadID != "bd-a1b2" {
+		t.Fatalf("snapshot = %+v", snap)
+	}
+
+	// Dropping tony's queued work means nothing starts when a slot frees
+	if !p.Cancel("tony") || p.Cancel("tony") {
+		t.Error("Cancel should drop tony's work exactly once")
+	}
+	p.Release("vinnie")
+	if snap := p.Snapshot(); len(snap.Running) != 1 || len(snap.Queue) != 0 {
+		t.Errorf("after release = %+v", snap)
+	}
+}
+
+func TestPool_ReleaseStartsQueuedWork(t *testing.T) {
+	p := New(Limits{Max: 1})
+	var s starts
+	p.Submit("vinnie", "api", "", s.fn("vinnie"))
+	s.wait(t, "vinnie")
+
+	p.Submit("sal", "api", "", s.fn("sal"))
+	p.Release("vinnie")
+	s.wait(t, "sal")
+	if snap := p.Snapshot(); len(snap.Running) != 1 || snap.Running[0].Agent != "sal" {
+		t.Errorf("running = %+v, want sal", snap.Running)
+	}
+}
+
+func TestPool_TurfLimitDoesNotHoldUpOtherTurfs(t *testing.T) {
+	p := New(Limits{PerTurf: 1, Turfs: map[string]int{"web": 2}})
+	var s starts
+
+	p.Submit("vinnie", "api", "", s.fn("vinnie"))
+	if p.Submit("sal", "api", "", func() { t.Error("sal started over api's limit") }) {
+		t.Fatal("sal should wait for api")
+	}
+	// web allows two, and sal waiting on api does not block it
+	if !p.Submit("tony", "web", "", s.fn("tony")) || !p.Submit("paulie", "web", "", s.fn("paulie")) {
+		t.Fatal("web work should start past sal")
+	}
+	s.wait(t, "vinnie", "tony", "paulie")
+	if got := queueOf(p.Snapshot()); len(got) != 1 || got[0] != "sal" {
+		t.Errorf("queue = %v, want [sal]", got)
+	}
+}
+
+func TestPool_OneRunPerAgent(t *testing.T) {
+	p := New(Limits{})
+	var s starts
+	p.Submit("vinnie", "api", "bd-1", s.fn("vinnie"))
+	if p.Submit("vinnie", "api", "bd-2", s.fn("vinnie")) {
+		t.Fatal("an agent's second assignment should wait for its first")
+	}
+	p.Release("vinnie")
+	s.wait(t)
+	if snap := p.Snapshot(); len(snap.Running) != 1 || snap.Running[0].BeadID != "bd-2" {
+		t.Errorf("running = %+v, want bd-2", snap.Running)
+	}
+}
+
+func TestPool_RaisingLimitsAdmits(t *testing.T) {
+	p := New(Limits{Max: 1})
+	var s starts
+	p.Submit("vinnie", "api", "", s.fn("vinnie"))
+	p.Submit("sal", "api", "", s.fn("sal"))
+
+	var changes []Snapshot
+	p.OnChange(func(snap Snapshot) { changes = append(changes, snap) })
+	p.SetLimits(Limits{Max: 2})
+	s.wait(t, "vinnie", "sal")
+
+	if len(changes) != 1 || len(changes[0].Running) != 2 || changes[0].Limits.Max != 2 {
+		t.Errorf("changes = %+v", changes)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent-pool.json")
+	if s, err := Load(path); err != nil || len(s.Queue) != 0 {
+		t.Fatalf("missing file = %+v, %v", s, err)
+	}
+
+	want := Snapshot{
+		Limits: Limits{Max: 3},
+		Queue:  []Waiting{{Agent: "sal", Turf: "api", BeadID: "bd-a1b2", QueuedAt: time.Now().Truncate(time.Second)}},
+	}
+	if err := Save(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Limits.Max != 3 || len(got.Queue) != 1 || got.Queue[0].Agent != "sal" || !got.Queue[0].QueuedAt.Equal(want.Queue[0].QueuedAt) {
+		t.Errorf("round trip = %+v", got)
+	}
+}
@@ -0,0 +1,140 @@
+// Package postmortem turns agent failures into trackable "investigate failure" beads.
+package postmortem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// DefaultOutputLines is how many trailing output lines are attached to a post-mortem
+const DefaultOutputLines = 20
+
+// maxLineLength caps each attached output line so raw stream-json stays readable
+const maxLineLength = 300
+
+// Failure describes an agent failure worth investigating
+type Failure struct {
+	AgentID   string
+	AgentName string
+	Turf      string
+	BeadID    string // bead the agent was working on, if any
+	Task      string
+	Reason    string // "failed" or "timed out"
+	Error     string
+	Output    []string // last output lines from the agent
+	SessionID string
+	WorkDir   string
+}
+
+// TranscriptPath returns where Claude stores the session transcript for a work
+// directory and session ID, or "" if the session is unknown
+func TranscriptPath(workDir, sessionID string) string {
+	if sessionID == "" {
+		return ""
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	project := strings.NewReplacer("/", "-", ".", "-").Replace(workDir)
+	return filepath.Join(home, ".claude", "projects", project, sessionID+".jsonl")
+}
+
+// Describe renders the failure as a bead description
+func Describe(f Failure) string {
+	var sb strings.Builder
+
+	agentLabel := f.AgentID
+	if f.AgentName != "" {
+		agentLabel = fmt.Sprintf("%s (%s)", f.AgentName, f.AgentID)
+	}
+	reason := f.Reason
+	if reason == "" {
+		reason = "failed"
+	}
+
+	sb.WriteString(fmt.Sprintf("Associate %s %s.\n", agentLabel, reason))
+	if f.BeadID != "" {
+		sb.WriteString(fmt.Sprintf("\nOriginal bead: %s\n", f.BeadID))
+	}
+	if f.Task != "" {
+		sb.WriteString(fmt.Sprintf("\nTask:\n%s\n", f.Task))
+	}
+	if f.Error != "" {
+		sb.WriteString(fmt.Sprintf("\nError:\n%s\n", f.Error))
+	}
+
+	if f.SessionID != "" {
+		sb.WriteString(fmt.Sprintf("\nSession: %s\n", f.SessionID))
+		if path := TranscriptPath(f.WorkDir, f.SessionID); path != "" {
+			sb.WriteString(fmt.Sprintf("Transcript: %s\n", path))
+		}
+	}
+
+	if len(f.Output) > 0 {
+		sb.WriteString(fmt.Sprintf("\nLast %d output lines:\n", len(f.Output)))
+		for _, line := range f.Output {
+			if r := []rune(line); len(r) > maxLineLength {
+				line = string(r[:maxLineLength-3]) + "..."
+			}
+			sb.WriteString("  " + line + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// CreateBead files an "investigate failure" bead linked to the original bead
+// and records a pointer to it on the original bead's history. The bead waits
+// for approval, so a run of failures does not set soldati investigating each
+// one ahead of real work.
+func CreateBead(store *storage.BeadStore, f Failure, createdBy string) (*models.Bead, error) {
+	if store == nil {
+		return nil, fmt.Errorf("bead store not available")
+	}
+
+	subject := f.AgentID
+	priority := 2
+	var original *models.Bead
+	if f.BeadID != "" {
+		if b, err := store.Get(f.BeadID); err == nil {
+			original = b
+			subject = b.Title
+			priority = b.Priority
+		}
+	}
+
+	bead := &models.Bead{
+		Title:          fmt.Sprintf("Investigate failure: %s", subject),
+		Description:    Describe(f),
+		Status:         models.BeadStatusPendingApproval,
+		Priority:       priority,
+		Type:           models.BeadTypeBug,
+		Turf:           f.Turf,
+		Labels:         []string{"postmortem"},
+		CreatedBy:      createdBy,
+		DiscoveredFrom: f.BeadID,
+	}
+	if original != nil {
+		bead.Related = []string{original.ID}
+		if bead.Turf == "" {
+			bead.Turf = original.Turf
+		}
+	}
+
+	created, err := store.Create(bead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create post-mortem bead: %w", err)
+	}
+
+	if original != nil {
+		store.AddComment(original.ID, createdBy, fmt.Sprintf("Post-mortem filed as %s", created.ID))
+	}
+
+	return created, nil
+}
@@ -0,0 +1,101 @@
+package postmortem
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestDescribe(t *testing.T) {
+	desc := Describe(Failure{
+		AgentID:   "agent-1",
+		BeadID:    "bd-1234",
+		Task:      "fix the thing",
+		Reason:    "timed out",
+		Error:     "context deadline exceeded",
+		Output:    []string{"line one", strings.Repeat("x", 500)},
+		SessionID: "sess-1",
+		WorkDir:   "/tmp/work",
+	})
+
+	for _, want := range []string{"agent-1 timed out", "bd-1234", "fix the thing", "context deadline exceeded", "Session: sess-1", "Last 2 output lines", "line one"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("description missing %q:\n%s", want, desc)
+		}
+	}
+	if strings.Contains(desc, strings.Repeat("x", 400)) {
+		t.Error("expected long output lines to be truncated")
+	}
+
+	// Truncation keeps multi-byte characters whole
+	desc = Describe(Failure{AgentID: "agent-1", Output: []string{strings.Repeat("é", 500)}})
+	if !utf8.ValidString(desc) || !strings.Contains(desc, strings.Repeat("é", maxLineLength-3)+"...") {
+		t.Errorf("expected the line cut to %d runes on a rune boundary:\n%s", maxLineLength-3, desc)
+	}
+}
+
+func TestTranscriptPath(t *testing.T) {
+	if got := TranscriptPath("/tmp/work", ""); got != "" {
+		t.Errorf("expected empty path without session, got %q", got)
+	}
+
+	got := TranscriptPath("/tmp/my.repo", "sess-1")
+	if !strings.HasSuffix(got, "/.claude/projects/-tmp-my-repo/sess-1.jsonl") {
+		t.Errorf("unexpected transcript path %q", got)
+	}
+}
+
+func TestCreateBead(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mob-postmortem-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := storage.NewBeadStore(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original, err := store.Create(&models.Bead{
+		Title:    "Add auth",
+		Status:   models.BeadStatusBlocked,
+		Priority: 1,
+		Type:     models.BeadTypeFeature,
+		Turf:     "api",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pm, err := CreateBead(store, Failure{AgentID: "agent-1", BeadID: original.ID, Error: "boom"}, "daemon")
+	if err != nil {
+		t.Fatalf("failed to create post-mortem: %v", err)
+	}
+
+	if pm.Title != "Investigate failure: Add auth" {
+		t.Errorf("unexpected title %q", pm.Title)
+	}
+	if pm.Type != models.BeadTypeBug || pm.Priority != 1 || pm.Turf != "api" {
+		t.Errorf("unexpected post-mortem fields: type=%s priority=%d turf=%s", pm.Type, pm.Priority, pm.Turf)
+	}
+	if pm.Status != models.BeadStatusPendingApproval {
+		t.Errorf("post-mortem status = %s, want pending_approval so auto-assignment skips it", pm.Status)
+	}
+	if pm.DiscoveredFrom != original.ID || len(pm.Related) != 1 || pm.Related[0] != original.ID {
+		t.Errorf("expected post-mortem linked to %s, got discovered_from=%s related=%v", original.ID, pm.DiscoveredFrom, pm.Related)
+	}
+
+	updated, err := store.Get(original.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := updated.History[len(updated.History)-1]
+	if last.Type != models.BeadEventTypeComment || !strings.Contains(last.Comment, pm.ID) {
+		t.Errorf("expected comment pointing at %s, got %+v", pm.ID, last)
+	}
+}
@@ -0,0 +1,197 @@
+// Package preempt decides when an urgent bead may take a busy soldati off
+// lower-priority work, and keeps the work it sets aside. A parked assignment
+// is handed back to the same soldati, in the same session, once it is free
+// again. Parked work lives in a file so it survives daemon restarts.
+package preempt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+)
+
+// Policy says which beads may preempt running work and which work may be
+// parked for them. Lower priority numbers are more urgent.
+type Policy struct {
+	Enabled        bool
+	UrgentPriority int // ready beads at or above this priority may preempt
+	Preemptible    int // running work at or below this priority may be parked
+}
+
+// PolicyFromConfig returns the policy set in [preemption]
+func PolicyFromConfig(cfg config.PreemptionConfig) Policy {
+	return Policy{
+		Enabled:        cfg.Enabled,
+		UrgentPriority: cfg.UrgentPriority,
+		Preemptible:    cfg.GetPreemptiblePriority(),
+	}
+}
+
+// Urgent reports whether a ready bead of this priority may preempt
+func (p Policy) Urgent(priority int) bool {
+	return p.Enabled && priority <= p.UrgentPriority
+}
+
+// Running is a soldati's in-flight assignment
+type Running struct {
+	Agent    string
+	BeadID   string
+	Priority int
+	Since    time.Time // when the assignment started
+}
+
+// Victim picks the running assignment to park for an urgent bead: the least
+// urgent preemptible one, ties going to the one started most recently since
+// it has the least work to lose. It returns nil when nothing may be parked.
+func (p Policy) Victim(urgent int, running []Running) *Running {
+	if !p.Urgent(urgent) {
+		return nil
+	}
+	var victim *Running
+	for i := range running {
+		r := &running[i]
+		if r.Priority < p.Preemptible || r.Priority <= urgent {
+			continue
+		}
+		if victim == nil || r.Priority > victim.Priority ||
+			(r.Priority == victim.Priority && r.Since.After(victim.Since)) {
+			victim = r
+		}
+	}
+	return victim
+}
+
+// Entry is an assignment parked for an urgent bead
+type Entry struct {
+	BeadID    string    `json:"bead_id"`
+	Agent     string    `json:"agent"`
+	Turf      string    `json:"turf,omitempty"`
+	Priority  int       `json:"priority"`
+	SessionID string    `json:"session_id,omitempty"` // the session the work was in
+	For       string    `json:"for"`                  // the urgent bead it made way for
+	ParkedAt  time.Time `json:"parked_at"`
+}
+
+// Store holds parked assignments
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Path returns the parked work file for a mob directory
+func Path(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "parked.json")
+}
+
+// New returns a store keeping parked work at path
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Park sets an assignment aside, replacing any earlier entry for the bead
+func (s *Store) Park(e Entry) error {
+	return s.update(func(entries map[string]*Entry) {
+		entries[e.BeadID] = &e
+	})
+}
+
+// Next returns the work parked for agent longest ago, or nil if there is none
+func (s *Store) Next(agent string) (*Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Agent == agent {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+// Take removes a bead from the parked work and returns its entry, or nil if
+// it was not parked
+func (s *Store) Take(beadID string) (*Entry, error) {
+	var taken *Entry
+	err := s.update(func(entries map[string]*Entry) {
+		taken = entries[beadID]
+		delete(entries, beadID)
+	})
+	return taken, err
+}
+
+// List returns every parked assignment, parked longest ago first
+func (s *Store) List() ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Entry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].ParkedAt.Equal(out[j].ParkedAt) {
+			return out[i].ParkedAt.Before(out[j].ParkedAt)
+		}
+		return out[i].BeadID < out[j].BeadID
+	})
+	return out, nil
+}
+
+// load reads the parked work; callers hold s.mu
+func (s *Store) load() (map[string]*Entry, error) {
+	entries := make(map[string]*Entry)
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return entries, nil
+	}
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("parked work: %w", err)
+	}
+	return entries, nil
+}
+
+// update applies fn to the parked work and saves it, skipping the write when
+// fn leaves it unchanged
+func (s *Store) update(fn func(map[string]*Entry)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	before := len(entries)
+	fn(entries)
+	if len(entries) == 0 && before == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
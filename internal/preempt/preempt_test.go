@@ -0,0 +1,74 @@
+package preempt
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+)
+
+func TestVictim(t *testing.T) {
+	policy := PolicyFromConfig(config.DefaultConfig().Preemption)
+	now := time.Now()
+	running := []Running{
+		{Agent: "vinnie", BeadID: "bd-1", Priority: 3, Since: now.Add(-time.Hour)},
+		{Agent: "sal", BeadID: "bd-2", Priority: 4, Since: now.Add(-2 * time.Hour)},
+		{Agent: "paulie", BeadID: "bd-3", Priority: 4, Since: now.Add(-time.Minute)},
+		{Agent: "tony", BeadID: "bd-4", Priority: 1, Since: now},
+	}
+
+	if v := policy.Victim(0, running); v == nil || v.Agent != "paulie" {
+		t.Errorf("expected the least urgent, most recently started work parked, got %+v", v)
+	}
+	if v := policy.Victim(1, running); v != nil {
+		t.Errorf("expected a P1 bead not urgent enough to preempt, got %+v", v)
+	}
+	if v := policy.Victim(0, running[3:]); v != nil {
+		t.Errorf("expected P1 work never parked, got %+v", v)
+	}
+
+	policy.Enabled = false
+	if v := policy.Victim(0, running); v != nil {
+		t.Errorf("expected nothing parked with preemption off, got %+v", v)
+	}
+}
+
+func TestStoreParksAndTakes(t *testing.T) {
+	s := New(Path(t.TempDir()))
+	now := time.Now()
+
+	if e, err := s.Next("vinnie"); err != nil || e != nil {
+		t.Fatalf("expected nothing parked in a new store, got %+v, %v", e, err)
+	}
+	s.Park(Entry{BeadID: "bd-2", Agent: "vinnie", Priority: 3, SessionID: "sess-2", For: "bd-9", ParkedAt: now})
+	s.Park(Entry{BeadID: "bd-1", Agent: "vinnie", Priority: 3, SessionID: "sess-1", For: "bd-8", ParkedAt: now.Add(-time.Minute)})
+	s.Park(Entry{BeadID: "bd-3", Agent: "sal", Priority: 4, For: "bd-9", ParkedAt: now})
+
+	// A fresh store reads what the first one saved
+	s = New(s.path)
+	e, err := s.Next("vinnie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e == nil || e.BeadID != "bd-1" || e.SessionID != "sess-1" {
+		t.Fatalf("expected the work parked longest ago first, got %+v", e)
+	}
+
+	taken, _ := s.Take("bd-1")
+	if taken == nil || taken.For != "bd-8" {
+		t.Errorf("Take = %+v", taken)
+	}
+	if taken, _ := s.Take("bd-1"); taken != nil {
+		t.Errorf("expected a bead taken once, got %+v", taken)
+	}
+	if e, _ := s.Next("vinnie"); e == nil || e.BeadID != "bd-2" {
+		t.Errorf("expected bd-2 next, got %+v", e)
+	}
+	if all, _ := s.List(); len(all) != 2 {
+		t.Errorf("expected 2 entries left, got %d", len(all))
+	}
+	if filepath.Base(s.path) != "parked.json" {
+		t.Errorf("unexpected store path %s", s.path)
+	}
+}
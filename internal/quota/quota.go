@@ -0,0 +1,108 @@
+// Package quota keeps per-turf bead worktrees within their configured count
+// and size limits by evicting the least recently used idle ones.
+package quota
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/git"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+// ErrQuotaExceeded means no idle worktree could be evicted to make room.
+// It clears once a bead finishes, so it is transient.
+var ErrQuotaExceeded = errkind.New(errkind.Transient, "worktree quota exceeded")
+
+// Result reports what an enforcement pass did
+type Result struct {
+	Evicted []string // bead IDs whose worktrees were removed
+	Skipped []string // eviction candidates left alone because they had uncommitted changes
+}
+
+// QuotaFor converts a worktree policy into a git quota
+func QuotaFor(policy config.WorktreePolicy) git.Quota {
+	return git.Quota{
+		MaxCount: policy.MaxCount,
+		MaxBytes: int64(policy.MaxSizeMB) * 1024 * 1024,
+	}
+}
+
+// CreateOptions converts a worktree policy into git checkout options
+func CreateOptions(policy config.WorktreePolicy) git.CreateOptions {
+	return git.CreateOptions{Sparse: policy.Sparse, Shallow: policy.Shallow}
+}
+
+// Idle reports whether a bead's worktree may be evicted: the bead is gone,
+// closed, or waiting without anyone assigned to it
+func Idle(bead *models.Bead) bool {
+	if bead == nil {
+		return true
+	}
+	switch bead.Status {
+	case models.BeadStatusClosed:
+		return true
+	case models.BeadStatusInProgress, models.BeadStatusInReview:
+		return false
+	default:
+		return bead.Assignee == ""
+	}
+}
+
+// Enforce evicts idle worktrees from a repository until the policy holds with
+// room for reserve new worktrees. Evicted worktrees keep their branch, so no
+// committed work is lost, and worktrees with uncommitted changes are never
+// removed. Returns ErrQuotaExceeded if the count limit still can't fit reserve.
+func Enforce(repo vcs.Repo, policy config.WorktreePolicy, store *storage.BeadStore, reserve int) (*Result, error) {
+	result := &Result{}
+	q := QuotaFor(policy)
+	if q.MaxCount == 0 && q.MaxBytes == 0 {
+		return result, nil
+	}
+
+	usages, err := repo.Usage()
+	if err != nil {
+		return nil, err
+	}
+
+	beads := make(map[string]*models.Bead)
+	if store != nil {
+		if all, err := store.List(storage.BeadFilter{}); err == nil {
+			for _, b := range all {
+				beads[b.ID] = b
+			}
+		}
+	}
+
+	eligible := func(u *git.WorktreeUsage) bool {
+		if !Idle(beads[u.BeadID]) {
+			return false
+		}
+		clean, err := repo.IsClean(u.Path)
+		if err != nil || !clean {
+			result.Skipped = append(result.Skipped, u.BeadID)
+			return false
+		}
+		return true
+	}
+
+	for _, u := range git.PlanEviction(usages, q, reserve, eligible) {
+		if err := repo.Remove(u.BeadID, false); err != nil {
+			return result, fmt.Errorf("failed to evict worktree for %s: %w", u.BeadID, err)
+		}
+		result.Evicted = append(result.Evicted, u.BeadID)
+
+		if bead := beads[u.BeadID]; bead != nil && bead.WorktreePath != "" && store != nil {
+			bead.WorktreePath = ""
+			store.Update(bead)
+		}
+	}
+
+	if q.MaxCount > 0 && len(usages)-len(result.Evicted)+reserve > q.MaxCount {
+		return result, ErrQuotaExceeded
+	}
+	return result, nil
+}
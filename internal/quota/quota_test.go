@@ -0,0 +1,92 @@
+package quota
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/git"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=T", "commit", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git unavailable: %v %s", err, out)
+		}
+	}
+	return dir
+}
+
+func TestIdle(t *testing.T) {
+	tests := []struct {
+		bead *models.Bead
+		want bool
+	}{
+		{nil, true},
+		{&models.Bead{Status: models.BeadStatusClosed, Assignee: "vinnie"}, true},
+		{&models.Bead{Status: models.BeadStatusInProgress}, false},
+		{&models.Bead{Status: models.BeadStatusInReview}, false},
+		{&models.Bead{Status: models.BeadStatusOpen}, true},
+		{&models.Bead{Status: models.BeadStatusBlocked, Assignee: "vinnie"}, false},
+	}
+	for _, tt := range tests {
+		if got := Idle(tt.bead); got != tt.want {
+			t.Errorf("Idle(%+v) = %v, want %v", tt.bead, got, tt.want)
+		}
+	}
+}
+
+func TestEnforce(t *testing.T) {
+	repo := setupRepo(t)
+	mgr, err := vcs.Open(repo, "git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closed, _ := store.Create(&models.Bead{Title: "done", Status: models.BeadStatusClosed})
+	dirty, _ := store.Create(&models.Bead{Title: "dirty", Status: models.BeadStatusClosed})
+	active, _ := store.Create(&models.Bead{Title: "active", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+	for _, b := range []*models.Bead{closed, dirty, active} {
+		wt, err := mgr.CreateWithOptions(b.ID, vcs.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.WorktreePath = wt.Path
+		store.Update(b)
+	}
+	if err := os.WriteFile(filepath.Join(repo, git.WorktreesDir, dirty.ID, "wip.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Enforce(mgr, config.WorktreePolicy{MaxCount: 3}, store, 1)
+	if err != nil {
+		t.Fatalf("expected room to be made, got %v", err)
+	}
+	if len(result.Evicted) != 1 || result.Evicted[0] != closed.ID {
+		t.Fatalf("expected closed bead's worktree evicted, got %+v", result)
+	}
+	if updated, _ := store.Get(closed.ID); updated.WorktreePath != "" {
+		t.Error("expected evicted bead's worktree path cleared")
+	}
+
+	// Only the dirty and active worktrees remain; neither may be evicted
+	if _, err := Enforce(mgr, config.WorktreePolicy{MaxCount: 2}, store, 1); err != ErrQuotaExceeded {
+		t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+// Package reaper cleans up after a daemon that was killed without shutting
+// down: it tells a stale PID file from a live daemon, kills the agent
+// processes the dead daemon had spawned and tracks the ones the current
+// daemon spawns so the next start can do the same.
+package reaper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Identity fingerprints a running process by its start time, so a recorded
+// PID can be told apart from an unrelated process that later reused it.
+// It returns "" when the process is not running.
+func Identity(pid int) string {
+	if pid <= 0 {
+		return ""
+	}
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(out)), " ")
+}
+
+// Alive reports whether pid is running and, when identity is set, is still
+// the process identity was taken from
+func Alive(pid int, identity string) bool {
+	if pid <= 0 || syscall.Kill(pid, 0) != nil {
+		return false
+	}
+	return identity == "" || Identity(pid) == identity
+}
+
+// Proc is an agent's in-flight claude call, run in its own process group
+type Proc struct {
+	Agent     string    `json:"agent"`
+	PGID      int       `json:"pgid"`
+	Identity  string    `json:"identity,omitempty"` // of the group leader, see Identity
+	StartedAt time.Time `json:"started_at"`
+}
+
+// ProcsPath returns where the daemon records the process groups it spawned
+func ProcsPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "agent-procs.json")
+}
+
+// Tracker keeps the process groups of in-flight calls on disk. It satisfies
+// agent.ProcessTracker; failures to write are reported through OnError.
+type Tracker struct {
+	path    string
+	mu      sync.Mutex
+	OnError func(error)
+}
+
+// NewTracker records process groups in the file at path
+func NewTracker(path string) *Tracker {
+	return &Tracker{path: path}
+}
+
+// Track records an agent's call running as process group pgid
+func (t *Tracker) Track(agentID string, pgid int) {
+	t.update(func(procs map[string]Proc) {
+		procs[agentID] = Proc{Agent: agentID, PGID: pgid, Identity: Identity(pgid), StartedAt: time.Now()}
+	})
+}
+
+// Untrack forgets an agent's finished call
+func (t *Tracker) Untrack(agentID string) {
+	t.update(func(procs map[string]Proc) {
+		delete(procs, agentID)
+	})
+}
+
+func (t *Tracker) update(fn func(map[string]Proc)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	procs, err := load(t.path)
+	if err == nil {
+		fn(procs)
+		err = save(t.path, procs)
+	}
+	if err != nil && t.OnError != nil {
+		t.OnError(err)
+	}
+}
+
+// Reap kills the process groups recorded at path that are still running,
+// then clears the record. It returns the groups it killed.
+func Reap(path string, grace time.Duration) ([]Proc, error) {
+	procs, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reaped []Proc
+	for _, p := range procs {
+		if !orphaned(p) {
+			continue
+		}
+		if err := killGroup(p.PGID, grace); err != nil {
+			return reaped, fmt.Errorf("failed to kill process group %d of agent %s: %w", p.PGID, p.Agent, err)
+		}
+		reaped = append(reaped, p)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return reaped, fmt.Errorf("failed to clear process record: %w", err)
+	}
+	return reaped, nil
+}
+
+// orphaned reports whether a recorded group is still running. Its leader
+// may have exited while the tools it started live on; the PGID cannot be
+// reused while any of them do. A leader PID now held by a different process
+// means the whole group is gone.
+func orphaned(p Proc) bool {
+	if p.PGID <= 0 || syscall.Kill(-p.PGID, 0) != nil {
+		return false
+	}
+	if syscall.Kill(p.PGID, 0) == nil {
+		return p.Identity == "" || Identity(p.PGID) == p.Identity
+	}
+	return true
+}
+
+// killGroup asks a process group to exit and kills it if its leader is still
+// running after grace
+func killGroup(pgid int, grace time.Duration) error {
+	if err := syscall.Kill(-pgid, syscall.SIGTERM); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return nil
+		}
+		return err
+	}
+	for deadline := time.Now().Add(grace); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		if syscall.Kill(-pgid, 0) != nil {
+			return nil
+		}
+	}
+	if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	return nil
+}
+
+func load(path string) (map[string]Proc, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Proc{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read process record: %w", err)
+	}
+	procs := map[string]Proc{}
+	if err := json.Unmarshal(data, &procs); err != nil {
+		return nil, fmt.Errorf("failed to parse process record: %w", err)
+	}
+	return procs, nil
+}
+
+func save(path string, procs map[string]Proc) error {
+	if len(procs) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(procs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write process record: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// WritePIDFile records pid, with its identity, in a PID file
+func WritePIDFile(path string, pid int) error {
+	content := strconv.Itoa(pid)
+	if id := Identity(pid); id != "" {
+		content += "\n" + id
+	}
+	return os.WriteFile(path, []byte(content+"\n"), 0644)
+}
+
+// ReadPIDFile reads a PID file written by WritePIDFile. identity is "" for
+// files from older versions that recorded only the PID.
+func ReadPIDFile(path string) (pid int, identity string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, "", err
+	}
+	first, rest, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+	pid, err = strconv.Atoi(strings.TrimSpace(first))
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid PID file %s: %w", path, err)
+	}
+	return pid, strings.TrimSpace(rest), nil
+}
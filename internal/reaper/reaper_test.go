@@ -0,0 +1,86 @@
+package reaper
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIdentityAndAlive(t *testing.T) {
+	pid := os.Getpid()
+	id := Identity(pid)
+	if id == "" {
+		t.Fatal("expected an identity for the running test process")
+	}
+	if !Alive(pid, id) || !Alive(pid, "") {
+		t.Error("expected the test process to be alive")
+	}
+	if Alive(pid, "Mon Jan  1 00:00:00 2001") {
+		t.Error("expected a different identity to mean a reused PID")
+	}
+	if Identity(999999999) != "" || Alive(999999999, "") {
+		t.Error("expected a missing process to have no identity")
+	}
+}
+
+func TestPIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.pid")
+	if err := WritePIDFile(path, os.Getpid()); err != nil {
+		t.Fatal(err)
+	}
+	pid, identity, err := ReadPIDFile(path)
+	if err != nil || pid != os.Getpid() || identity != Identity(os.Getpid()) {
+		t.Fatalf("got pid %d identity %q err %v", pid, identity, err)
+	}
+
+	// Files from before identities were recorded hold only the PID
+	if err := os.WriteFile(path, []byte("4242"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if pid, identity, err := ReadPIDFile(path); err != nil || pid != 4242 || identity != "" {
+		t.Fatalf("got pid %d identity %q err %v", pid, identity, err)
+	}
+}
+
+func TestTrackAndReap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent-procs.json")
+
+	// A call that started a child of its own, like claude starting an MCP server
+	cmd := exec.Command("sh", "-c", "sleep 60 & wait")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	exited := make(chan struct{})
+	go func() { cmd.Wait(); close(exited) }()
+
+	tracker := NewTracker(path)
+	tracker.OnError = func(err error) { t.Error(err) }
+	tracker.Track("agent-1", cmd.Process.Pid)
+	tracker.Track("agent-2", 999999999) // already gone
+	tracker.Untrack("agent-2")
+
+	reaped, err := Reap(path, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reaped) != 1 || reaped[0].Agent != "agent-1" {
+		t.Fatalf("expected agent-1's group reaped, got %+v", reaped)
+	}
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the process group to be killed")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected the record to be cleared")
+	}
+
+	// Nothing recorded is nothing to do
+	if reaped, err := Reap(path, time.Second); err != nil || len(reaped) != 0 {
+		t.Errorf("expected an empty reap, got %v, %v", reaped, err)
+	}
+}
@@ -0,0 +1,308 @@
+// Package reconcile brings mob's recorded state back in line with what is
+// actually running: agent records whose process is gone, beads left in
+// progress with nobody working on them, and bead worktrees that outlived
+// their bead. The daemon runs it as it starts, and `mob doctor` on demand.
+package reconcile
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/reaper"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+// Kind identifies a type of inconsistency
+type Kind string
+
+const (
+	KindDeadAgent      Kind = "dead-agent"      // registry record whose process is gone
+	KindStrandedBead   Kind = "stranded-bead"   // in progress with no agent working on it
+	KindOrphanWorktree Kind = "orphan-worktree" // worktree of a closed or deleted bead
+)
+
+// AllKinds lists every kind in the order they are reconciled and reported
+var AllKinds = []Kind{KindDeadAgent, KindStrandedBead, KindOrphanWorktree}
+
+// Fix is one inconsistency found, and what was (or would be) done about it
+type Fix struct {
+	Kind   Kind
+	ID     string // agent ID, or bead ID for beads and worktrees
+	Name   string // agent label, or bead title
+	Turf   string
+	Path   string // worktree directory
+	Detail string
+}
+
+// Report summarizes a reconciliation run
+type Report struct {
+	DryRun  bool
+	Fixes   []Fix
+	Skipped []Fix // found but left alone, e.g. worktrees with uncommitted changes
+	Errors  []error
+}
+
+// Count returns the number of fixes of a kind in the report
+func (r *Report) Count(kind Kind) int {
+	n := 0
+	for _, f := range r.Fixes {
+		if f.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// Reconciler finds and repairs state left behind by agents and daemons that
+// did not shut down cleanly
+type Reconciler struct {
+	Registry *registry.Registry
+	Beads    *storage.BeadStore
+	Turfs    []models.Turf
+	Soldati  []string // defined soldati, so a bead assigned to one that is not registered counts as stranded
+	Actor    string   // recorded on reopened beads, e.g. "daemon" or "doctor"
+
+	// Fresh means no soldati is running: the daemon is starting without
+	// sessions handed over, or is not running at all. Soldati records left
+	// over from an earlier process are then dead whatever their PID says.
+	Fresh bool
+
+	Alive func(pid int) bool // defaults to checking the process exists
+	Now   func() time.Time
+}
+
+// Run reconciles every kind. With dryRun nothing is changed and the report
+// lists what would be.
+func (r *Reconciler) Run(dryRun bool) *Report {
+	report := &Report{DryRun: dryRun}
+	agents := r.agents(report)
+	r.deadAgents(report, agents)
+	r.strandedBeads(report, agents)
+	r.orphanWorktrees(report)
+	return report
+}
+
+func (r *Reconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (r *Reconciler) alive(pid int) bool {
+	if r.Alive != nil {
+		return r.Alive(pid)
+	}
+	return reaper.Alive(pid, "")
+}
+
+// agents returns the registry's records sorted by name
+func (r *Reconciler) agents(report *Report) []*registry.AgentRecord {
+	if r.Registry == nil {
+		return nil
+	}
+	agents, err := r.Registry.List()
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Errorf("registry: %w", err))
+		return nil
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Label() < agents[j].Label() })
+	return agents
+}
+
+// deadAgents marks live records whose process has gone. Soldati become dead
+// for the daemon to respawn, associates fail, and anything else just loses
+// its PID. The records are updated in place so stranded beads see the result
+// even in a dry run.
+func (r *Reconciler) deadAgents(report *Report, agents []*registry.AgentRecord) {
+	for _, a := range agents {
+		if a.Status.Terminal() || a.Status == registry.StatusDead {
+			continue
+		}
+		pidGone := a.PID != 0 && !r.alive(a.PID)
+		leftover := r.Fresh && a.Type == "soldati"
+		if !pidGone && !leftover {
+			continue
+		}
+
+		fix := Fix{Kind: KindDeadAgent, ID: a.ID, Name: a.Label(), Turf: a.Turf}
+		to := a.Status
+		switch {
+		case a.Type == "soldati" && leftover:
+			to = registry.StatusDead
+			fix.Detail = "left over from an earlier daemon; marked dead to be respawned"
+		case a.Type == "associate":
+			to = registry.StatusFailed
+			fix.Detail = fmt.Sprintf("process %d is gone; marked failed", a.PID)
+		default:
+			fix.Detail = fmt.Sprintf("process %d is gone; cleared", a.PID)
+		}
+
+		if !report.DryRun {
+			if a.PID != 0 {
+				if err := r.Registry.UpdatePID(a.ID, 0); err != nil {
+					report.Errors = append(report.Errors, fmt.Errorf("agent %s: %w", a.Label(), err))
+					continue
+				}
+			}
+			if to != a.Status {
+				if err := r.Registry.UpdateStatus(a.ID, to); err != nil {
+					report.Errors = append(report.Errors, fmt.Errorf("agent %s: %w", a.Label(), err))
+					continue
+				}
+			}
+		}
+		a.PID, a.Status = 0, to
+		report.Fixes = append(report.Fixes, fix)
+	}
+}
+
+// working reports whether a record is an agent busy with work
+func working(a *registry.AgentRecord) bool {
+	switch {
+	case a.Status.Terminal():
+		return false
+	case a.Status == registry.StatusIdle, a.Status == registry.StatusDead:
+		return false
+	}
+	return true
+}
+
+// strandedBeads reopens beads in progress that no agent is working on, so
+// they can be assigned again. A bead assigned to someone mob does not know
+// of, such as a person, is left alone.
+func (r *Reconciler) strandedBeads(report *Report, agents []*registry.AgentRecord) {
+	if r.Beads == nil {
+		return
+	}
+	beads, err := r.Beads.List(storage.BeadFilter{Status: models.BeadStatusInProgress})
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Errorf("beads: %w", err))
+		return
+	}
+
+	known := make(map[string]bool)
+	for _, name := range r.Soldati {
+		known[name] = true
+	}
+	busyAgents := make(map[string]bool) // keyed by name
+	busyBeads := make(map[string]bool)  // keyed by the bead an associate is linked to
+	for _, a := range agents {
+		known[a.Name] = true
+		if working(a) {
+			busyAgents[a.Name] = true
+			if a.BeadID != "" {
+				busyBeads[a.BeadID] = true
+			}
+		}
+	}
+
+	for _, b := range beads {
+		if busyBeads[b.ID] || (b.Assignee != "" && (busyAgents[b.Assignee] || !known[b.Assignee])) {
+			continue
+		}
+		detail := "no assignee"
+		if b.Assignee != "" {
+			detail = b.Assignee + " is not working on it"
+		}
+		fix := Fix{Kind: KindStrandedBead, ID: b.ID, Name: b.Title, Turf: b.Turf, Detail: detail + "; reopened"}
+
+		if !report.DryRun {
+			reopened, err := r.reopen(b, detail)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Errorf("bead %s: %w", b.ID, err))
+				continue
+			}
+			if !reopened {
+				continue
+			}
+		}
+		report.Fixes = append(report.Fixes, fix)
+	}
+}
+
+// reopen puts a stranded bead back in the ready queue and notes why. It
+// reports false, without an error, when the bead changed since it was read.
+func (r *Reconciler) reopen(b *models.Bead, why string) (bool, error) {
+	b.Status = models.BeadStatusOpen
+	b.Assignee = ""
+	if _, err := r.Beads.Update(b); err != nil {
+		if errors.Is(err, storage.ErrBeadConflict) {
+			return false, nil // someone picked it up in the meantime
+		}
+		return false, err
+	}
+	return true, r.Beads.AddEvent(b.ID, models.BeadEvent{
+		Type:      models.BeadEventTypeComment,
+		Actor:     r.Actor,
+		Comment:   "Reopened: in progress but " + why,
+		Timestamp: r.now(),
+	})
+}
+
+// orphanWorktrees removes the worktrees of closed or deleted beads. Their
+// branches are kept so no committed work is lost, and worktrees with
+// uncommitted changes are left for a person to look at.
+func (r *Reconciler) orphanWorktrees(report *Report) {
+	if len(r.Turfs) == 0 {
+		return
+	}
+	beads := make(map[string]*models.Bead)
+	if r.Beads != nil {
+		all, err := r.Beads.List(storage.BeadFilter{})
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("beads: %w", err))
+			return // without the beads every worktree would look orphaned
+		}
+		for _, b := range all {
+			beads[b.ID] = b
+		}
+	}
+
+	for _, t := range r.Turfs {
+		repo, err := vcs.Open(t.Path, t.VCS)
+		if err != nil {
+			continue
+		}
+		worktrees, err := repo.List()
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("turf %s: %w", t.Name, err))
+			continue
+		}
+
+		for _, wt := range worktrees {
+			b := beads[wt.BeadID]
+			if b != nil && b.Status != models.BeadStatusClosed {
+				continue
+			}
+			fix := Fix{Kind: KindOrphanWorktree, ID: wt.BeadID, Turf: t.Name, Path: wt.Path, Detail: "bead no longer exists"}
+			if b != nil {
+				fix.Name, fix.Detail = b.Title, "bead is closed"
+			}
+
+			if clean, err := repo.IsClean(wt.Path); err != nil || !clean {
+				fix.Detail += "; has uncommitted changes, left in place"
+				report.Skipped = append(report.Skipped, fix)
+				continue
+			}
+			fix.Detail += "; removed, branch kept"
+
+			if !report.DryRun {
+				if err := repo.Remove(wt.BeadID, false); err != nil {
+					report.Errors = append(report.Errors, fmt.Errorf("worktree for %s: %w", wt.BeadID, err))
+					continue
+				}
+				if b != nil && b.WorktreePath != "" && r.Beads != nil {
+					b.WorktreePath = ""
+					r.Beads.Update(b)
+				}
+			}
+			report.Fixes = append(report.Fixes, fix)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package reconcile
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/git"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"-c", "user.email=t@example.com", "-c", "user.name=T", "commit", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git unavailable: %v %s", err, out)
+		}
+	}
+	return dir
+}
+
+func newStores(t *testing.T) (*registry.Registry, *storage.BeadStore) {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return registry.New(filepath.Join(dir, "agents.json")), store
+}
+
+func register(t *testing.T, reg *registry.Registry, rec *registry.AgentRecord) {
+	t.Helper()
+	rec.StartedAt, rec.LastPing = time.Now(), time.Now()
+	if err := reg.Register(rec); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeadAgents(t *testing.T) {
+	reg, _ := newStores(t)
+	register(t, reg, &registry.AgentRecord{ID: "s1", Type: "soldati", Name: "vinnie", Status: registry.StatusActive, PID: 111})
+	register(t, reg, &registry.AgentRecord{ID: "a1", Type: "associate", Name: "assoc-red-fox", Status: registry.StatusWorking, PID: 222})
+	register(t, reg, &registry.AgentRecord{ID: "a2", Type: "associate", Name: "assoc-blue-owl", Status: registry.StatusWorking, PID: 333})
+
+	r := &Reconciler{Registry: reg, Alive: func(pid int) bool { return pid == 333 }}
+	report := r.Run(false)
+	if report.Count(KindDeadAgent) != 2 || len(report.Errors) != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	if s, _ := reg.Get("s1"); s.PID != 0 || s.Status != registry.StatusActive {
+		t.Errorf("soldati with a dead call = %+v, want its PID cleared", s)
+	}
+	if a, _ := reg.Get("a1"); a.Status != registry.StatusFailed {
+		t.Errorf("associate with a dead process = %s, want failed", a.Status)
+	}
+	if a, _ := reg.Get("a2"); a.Status != registry.StatusWorking || a.PID != 333 {
+		t.Errorf("live associate was touched: %+v", a)
+	}
+}
+
+func TestStrandedBeads(t *testing.T) {
+	reg, store := newStores(t)
+	register(t, reg, &registry.AgentRecord{ID: "s1", Type: "soldati", Name: "vinnie", Status: registry.StatusActive})
+	register(t, reg, &registry.AgentRecord{ID: "s2", Type: "soldati", Name: "sal", Status: registry.StatusIdle})
+
+	working, _ := store.Create(&models.Bead{Title: "working", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+	idle, _ := store.Create(&models.Bead{Title: "idle", Status: models.BeadStatusInProgress, Assignee: "sal"})
+	gone, _ := store.Create(&models.Bead{Title: "gone", Status: models.BeadStatusInProgress, Assignee: "tony"})
+	person, _ := store.Create(&models.Bead{Title: "person", Status: models.BeadStatusInProgress, Assignee: "gabe"})
+
+	r := &Reconciler{Registry: reg, Beads: store, Soldati: []string{"vinnie", "sal", "tony"}, Actor: "doctor"}
+
+	// A dry run reports without touching anything
+	if report := r.Run(true); report.Count(KindStrandedBead) != 2 {
+		t.Fatalf("dry run = %+v", report)
+	}
+	if b, _ := store.Get(idle.ID); b.Status != models.BeadStatusInProgress {
+		t.Fatal("dry run changed a bead")
+	}
+
+	report := r.Run(false)
+	if report.Count(KindStrandedBead) != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	for _, id := range []string{idle.ID, gone.ID} {
+		b, _ := store.Get(id)
+		if b.Status != models.BeadStatusOpen || b.Assignee != "" {
+			t.Errorf("%s = %s/%q, want reopened", b.Title, b.Status, b.Assignee)
+		}
+		if last := b.History[len(b.History)-1]; last.Actor != "doctor" {
+			t.Errorf("%s history ends with %+v, want a note from doctor", b.Title, last)
+		}
+	}
+	for _, id := range []string{working.ID, person.ID} {
+		if b, _ := store.Get(id); b.Status != models.BeadStatusInProgress {
+			t.Errorf("%s was reopened", b.Title)
+		}
+	}
+}
+
+func TestFreshStartStrandsLeftoverSoldati(t *testing.T) {
+	reg, store := newStores(t)
+	register(t, reg, &registry.AgentRecord{ID: "s1", Type: "soldati", Name: "vinnie", Status: registry.StatusActive})
+	b, _ := store.Create(&models.Bead{Title: "crashed", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+
+	report := (&Reconciler{Registry: reg, Beads: store, Fresh: true}).Run(false)
+	if report.Count(KindDeadAgent) != 1 || report.Count(KindStrandedBead) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if s, _ := reg.Get("s1"); s.Status != registry.StatusDead {
+		t.Errorf("leftover soldati = %s, want dead", s.Status)
+	}
+	if got, _ := store.Get(b.ID); got.Status != models.BeadStatusOpen {
+		t.Errorf("bead = %s, want reopened", got.Status)
+	}
+}
+
+func TestOrphanWorktrees(t *testing.T) {
+	repo := setupRepo(t)
+	mgr, err := vcs.Open(repo, "git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, store := newStores(t)
+
+	closed, _ := store.Create(&models.Bead{Title: "done", Status: models.BeadStatusClosed})
+	dirty, _ := store.Create(&models.Bead{Title: "dirty", Status: models.BeadStatusClosed})
+	active, _ := store.Create(&models.Bead{Title: "active", Status: models.BeadStatusInProgress, Assignee: "vinnie"})
+	for _, b := range []*models.Bead{closed, dirty, active} {
+		wt, err := mgr.CreateWithOptions(b.ID, vcs.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.WorktreePath = wt.Path
+		store.Update(b)
+	}
+	if _, err := mgr.CreateWithOptions("bd-gone", vcs.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, git.WorktreesDir, dirty.ID, "wip.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Reconciler{Beads: store, Turfs: []models.Turf{{Name: "api", Path: repo, VCS: "git"}}}
+	report := r.Run(false)
+	if report.Count(KindOrphanWorktree) != 2 || len(report.Skipped) != 1 || report.Skipped[0].ID != dirty.ID {
+		t.Fatalf("report = %+v", report)
+	}
+
+	left, err := mgr.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining := make(map[string]bool)
+	for _, wt := range left {
+		remaining[wt.BeadID] = true
+	}
+	if remaining[closed.ID] || remaining["bd-gone"] || !remaining[dirty.ID] || !remaining[active.ID] {
+		t.Errorf("remaining worktrees = %v", remaining)
+	}
+	if b, _ := store.Get(closed.ID); b.WorktreePath != "" {
+		t.Error("removed worktree's path should be cleared from its bead")
+	}
+}
@@ -0,0 +1,167 @@
+// Package redact masks secrets and other sensitive text — API keys, tokens,
+// private keys, and optionally email addresses and internal hostnames —
+// before mob writes it to logs, notifications, exports or its stores.
+package redact
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/gabe/mob/internal/config"
+)
+
+// DefaultReplacement stands in for redacted text when config sets none
+const DefaultReplacement = "[REDACTED]"
+
+// rule is one pattern and what a match becomes. $R in template is the
+// replacement text; other $n refer to the pattern's groups.
+type rule struct {
+	re       *regexp.Regexp
+	template string
+}
+
+// builtinRules catch common credential formats
+var builtinRules = []rule{
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), "$R"},
+	{regexp.MustCompile(`\bsk-(?:ant-)?[A-Za-z0-9_-]{20,}`), "$R"},
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), "$R"},
+	{regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`), "$R"},
+	{regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}`), "$R"},
+	{regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`), "$R"},
+	{regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`), "$R"},
+	{regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), "$R"},
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), "$R"},
+	{regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/-]{16,}=*`), "${1}$R"},
+	{regexp.MustCompile(`(?i)(\b[a-z0-9_]*(?:api[_-]?key|secret|token|passw(?:or)?d)[a-z0-9_]*["']?\s*[:=]\s*["']?)[^\s"',;(){}<>=]{8,}`), "${1}$R"},
+}
+
+var emailRule = rule{regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`), "$R"}
+
+// Redactor applies a set of redaction rules. A nil Redactor leaves text unchanged.
+type Redactor struct {
+	rules []rule
+}
+
+// New builds a redactor from [redaction] config. It returns nil when
+// redaction is disabled.
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	replacement := cfg.Replacement
+	if replacement == "" {
+		replacement = DefaultReplacement
+	}
+	// Keep "$" in the replacement literal inside regexp templates
+	escaped := strings.ReplaceAll(replacement, "$", "$$")
+
+	var rules []rule
+	add := func(r rule) {
+		rules = append(rules, rule{re: r.re, template: strings.ReplaceAll(r.template, "$R", escaped)})
+	}
+
+	if cfg.Builtin {
+		for _, r := range builtinRules {
+			add(r)
+		}
+	}
+	if cfg.Emails {
+		add(emailRule)
+	}
+	for _, host := range cfg.Hostnames {
+		host = strings.Trim(strings.TrimSpace(host), ".")
+		if host == "" {
+			continue
+		}
+		add(rule{regexp.MustCompile(`(?i)\b(?:[a-z0-9-]+\.)*` + regexp.QuoteMeta(host) + `\b`), "$R"})
+	}
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction.patterns: %q: %w", pattern, err)
+		}
+		add(rule{re, "$R"})
+	}
+
+	return &Redactor{rules: rules}, nil
+}
+
+// FromConfig builds the redactor for a loaded config, falling back to the
+// built-in rules when the configured patterns don't compile
+func FromConfig(cfg *config.Config) (*Redactor, error) {
+	r, err := New(cfg.Redaction)
+	if err != nil {
+		fallback, _ := New(config.DefaultConfig().Redaction)
+		return fallback, err
+	}
+	return r, nil
+}
+
+// String returns s with every match redacted
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rule := range r.rules {
+		s = rule.re.ReplaceAllString(s, rule.template)
+	}
+	return s
+}
+
+// Bytes returns b with every match redacted
+func (r *Redactor) Bytes(b []byte) []byte {
+	if r == nil {
+		return b
+	}
+	return []byte(r.String(string(b)))
+}
+
+// Value redacts the strings inside a decoded JSON value (maps, slices and
+// strings), leaving numbers, booleans and nil alone
+func (r *Redactor) Value(v interface{}) interface{} {
+	if r == nil {
+		return v
+	}
+	switch v := v.(type) {
+	case string:
+		return r.String(v)
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = r.Value(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = r.Value(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// Writer returns a writer that redacts each write before passing it to w.
+// Matches split across writes are not caught, so wrap line-oriented writers
+// such as a log.Logger's output.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+	return &writer{r: r, w: w}
+}
+
+type writer struct {
+	r *Redactor
+	w io.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(w.r.Bytes(p)); err != nil {
+		return 0, err
+	}
+	// Report the caller's length; the redacted text may differ in size
+	return len(p), nil
+}
@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// DefaultAssociatePrefix starts every generated associate name
+const DefaultAssociatePrefix = "assoc"
+
+var associateAdjectives = []string{
+	"amber", "ashen", "azure", "brisk", "cobalt", "crimson", "dusky", "ember",
+	"frosty", "gilded", "hazel", "indigo", "ivory", "jade", "lucky", "misty",
+	"onyx", "quiet", "rusty", "scarlet", "silver", "swift", "tawny", "velvet",
+}
+
+var associateAnimals = []string{
+	"badger", "crane", "crow", "falcon", "ferret", "fox", "gecko", "hare",
+	"heron", "ibis", "jackal", "lynx", "marten", "mink", "moth", "newt",
+	"otter", "owl", "raven", "stoat", "swan", "viper", "weasel", "wren",
+}
+
+// AssociateName returns a readable ephemeral name such as
+// "assoc-crimson-fox" that is not in used. Once every combination is taken
+// a numeric suffix is added.
+func AssociateName(prefix string, used []string) string {
+	if prefix == "" {
+		prefix = DefaultAssociatePrefix
+	}
+
+	// Start at a random combination so concurrent spawns rarely collide
+	total := len(associateAdjectives) * len(associateAnimals)
+	start := rand.Intn(total)
+	for suffix := 1; ; suffix++ {
+		for i := 0; i < total; i++ {
+			n := (start + i) % total
+			candidate := fmt.Sprintf("%s-%s-%s", prefix, associateAdjectives[n/len(associateAnimals)], associateAnimals[n%len(associateAnimals)])
+			if suffix > 1 {
+				candidate = fmt.Sprintf("%s-%d", candidate, suffix)
+			}
+			if !slices.Contains(used, candidate) {
+				return candidate
+			}
+		}
+	}
+}
+
+// NewAssociateName picks an associate name no registered agent is using
+func (r *Registry) NewAssociateName(prefix string) string {
+	var used []string
+	if agents, err := r.List(); err == nil {
+		for _, a := range agents {
+			used = append(used, a.Name)
+		}
+	}
+	return AssociateName(prefix, used)
+}
+
+// Label returns how the agent is shown to humans: its name (or ID when it
+// has none) followed by its tag, e.g. "assoc-crimson-fox [lint-fix]"
+func (a *AgentRecord) Label() string {
+	label := a.Name
+	if label == "" {
+		label = a.ID
+	}
+	if a.Tag != "" {
+		label += " [" + a.Tag + "]"
+	}
+	return label
+}
@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestAssociateName(t *testing.T) {
+	name := AssociateName("", nil)
+	if !regexp.MustCompile(`^assoc-[a-z]+-[a-z]+$`).MatchString(name) {
+		t.Errorf("AssociateName() = %q, want assoc-<adjective>-<animal>", name)
+	}
+
+	if name := AssociateName("temp", nil); !regexp.MustCompile(`^temp-[a-z]+-[a-z]+$`).MatchString(name) {
+		t.Errorf("AssociateName(temp) = %q, want the custom prefix", name)
+	}
+
+	// Exhaust every combination; the next name gets a suffix
+	var used []string
+	for _, adj := range associateAdjectives {
+		for _, animal := range associateAnimals {
+			used = append(used, "assoc-"+adj+"-"+animal)
+		}
+	}
+	if name := AssociateName("assoc", used); !regexp.MustCompile(`^assoc-[a-z]+-[a-z]+-2$`).MatchString(name) {
+		t.Errorf("AssociateName with all names used = %q, want a -2 suffix", name)
+	}
+}
+
+func TestRegistry_NewAssociateNameAvoidsRegistered(t *testing.T) {
+	reg := New(DefaultPath(t.TempDir()))
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		name := reg.NewAssociateName("assoc")
+		if seen[name] {
+			t.Fatalf("NewAssociateName returned %q twice", name)
+		}
+		seen[name] = true
+		if err := reg.Register(&AgentRecord{ID: name + "-id", Type: "associate", Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAgentRecord_Label(t *testing.T) {
+	tests := []struct {
+		record AgentRecord
+		want   string
+	}{
+		{AgentRecord{ID: "abc", Name: "assoc-jade-otter", Tag: "docs"}, "assoc-jade-otter [docs]"},
+		{AgentRecord{ID: "abc", Name: "vinnie"}, "vinnie"},
+		{AgentRecord{ID: "abc"}, "abc"},
+	}
+	for _, tt := range tests {
+		if got := tt.record.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
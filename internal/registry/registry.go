@@ -2,17 +2,24 @@ package registry
 
 import (
 	"encoding/json"
-	"errors"
+	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/gabe/mob/internal/errkind"
 )
 
 var (
 	// ErrAgentNotFound is returned when an agent is not in the registry
-	ErrAgentNotFound = errors.New("agent not found in registry")
+	ErrAgentNotFound = errkind.New(errkind.NotFound, "agent not found in registry")
+
+	// ErrRegistryLocked is returned when the registry file lock could not be
+	// acquired; retrying usually succeeds
+	ErrRegistryLocked = errkind.New(errkind.Transient, "registry is locked")
 )
 
 // AgentRecord represents a tracked agent in the registry
@@ -21,10 +28,13 @@ type AgentRecord struct {
 	Type        string     `json:"type"` // underboss, soldati, associate
 	Name        string     `json:"name"`
 	Turf        string     `json:"turf"`
+	WorkDir     string     `json:"work_dir,omitempty"` // Directory the agent's claude calls run in
 	SessionID   string     `json:"session_id,omitempty"`
-	Status      string     `json:"status"` // active, idle, stuck, dead, completed, failed, timed_out
+	Status      Status     `json:"status"`
 	Task        string     `json:"task,omitempty"`
+	Tag         string     `json:"tag,omitempty"`     // Caller-supplied label for what an associate is doing, e.g. "lint-fix"
 	BeadID      string     `json:"bead_id,omitempty"` // Linked bead for auto-completion (associates)
+	PID         int        `json:"pid,omitempty"`     // Claude process serving the agent's in-flight call, 0 when idle
 	StartedAt   time.Time  `json:"started_at"`
 	LastPing    time.Time  `json:"last_ping"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"` // When associate finished (for cleanup TTL)
@@ -39,6 +49,22 @@ type Registry struct {
 // registryData is the on-disk format
 type registryData struct {
 	Agents map[string]*AgentRecord `json:"agents"`
+
+	// Sessions holds every Claude session ID an agent has run, with when it
+	// was last recorded. It outlives the agents' records so garbage
+	// collection only ever removes transcripts mob wrote.
+	Sessions map[string]time.Time `json:"sessions,omitempty"`
+}
+
+// recordSession notes a Claude session an agent is running
+func (d *registryData) recordSession(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+	if d.Sessions == nil {
+		d.Sessions = make(map[string]time.Time)
+	}
+	d.Sessions[sessionID] = time.Now()
 }
 
 // New creates a new registry at the specified file path
@@ -121,7 +147,7 @@ func (r *Registry) withFileLock(fn func() error) error {
 
 	// Acquire exclusive lock
 	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
-		return err
+		return fmt.Errorf("%w: %w", ErrRegistryLocked, err)
 	}
 	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
 
@@ -141,6 +167,7 @@ func (r *Registry) Register(agent *AgentRecord) error {
 
 		agent.LastPing = time.Now()
 		data.Agents[agent.ID] = agent
+		data.recordSession(agent.SessionID)
 
 		return r.save(data)
 	})
@@ -266,13 +293,9 @@ func (r *Registry) ListByType(agentType string) ([]*AgentRecord, error) {
 	return result, err
 }
 
-// isTerminalStatus returns true if the status indicates the agent has finished
-func isTerminalStatus(status string) bool {
-	return status == "completed" || status == "failed" || status == "timed_out"
-}
-
-// UpdateStatus updates an agent's status
-func (r *Registry) UpdateStatus(id, status string) error {
+// UpdateStatus updates an agent's status. A change the agent's current
+// status does not allow is logged and rejected with ErrInvalidTransition.
+func (r *Registry) UpdateStatus(id string, status Status) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -287,11 +310,17 @@ func (r *Registry) UpdateStatus(id, status string) error {
 			return ErrAgentNotFound
 		}
 
+		if !CanTransition(agent.Status, status) {
+			err := transitionError(agent.Label(), agent.Status, status)
+			log.Printf("Registry: %v", err)
+			return err
+		}
+
 		agent.Status = status
 		agent.LastPing = time.Now()
 
 		// Set CompletedAt when transitioning to a terminal status
-		if isTerminalStatus(status) && agent.CompletedAt == nil {
+		if status.Terminal() && agent.CompletedAt == nil {
 			now := time.Now()
 			agent.CompletedAt = &now
 		}
@@ -322,6 +351,110 @@ func (r *Registry) UpdateTask(id, task string) error {
 	})
 }
 
+// UpdateSession records the Claude session ID for an agent so other
+// processes can resume its conversation
+func (r *Registry) UpdateSession(id, sessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.withFileLock(func() error {
+		data, err := r.load()
+		if err != nil {
+			return err
+		}
+
+		agent, ok := data.Agents[id]
+		if !ok {
+			return ErrAgentNotFound
+		}
+
+		agent.SessionID = sessionID
+		data.recordSession(sessionID)
+		return r.save(data)
+	})
+}
+
+// Sessions returns the Claude session IDs mob's agents have run, including
+// those of agents no longer registered, with when each was last recorded
+func (r *Registry) Sessions() (map[string]time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result map[string]time.Time
+	err := r.withFileLock(func() error {
+		data, err := r.load()
+		if err != nil {
+			return err
+		}
+		result = make(map[string]time.Time, len(data.Sessions))
+		for id, at := range data.Sessions {
+			result[id] = at
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ForgetSessions drops session IDs whose transcripts have been removed
+func (r *Registry) ForgetSessions(ids ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.withFileLock(func() error {
+		data, err := r.load()
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			delete(data.Sessions, id)
+		}
+		return r.save(data)
+	})
+}
+
+// UpdatePID records the claude process currently working for an agent so
+// other processes can abort it. Pass 0 when the call finishes.
+func (r *Registry) UpdatePID(id string, pid int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.withFileLock(func() error {
+		data, err := r.load()
+		if err != nil {
+			return err
+		}
+
+		agent, ok := data.Agents[id]
+		if !ok {
+			return ErrAgentNotFound
+		}
+
+		agent.PID = pid
+		return r.save(data)
+	})
+}
+
+// UpdateWorkDir records the directory an agent's calls run in
+func (r *Registry) UpdateWorkDir(id, workDir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.withFileLock(func() error {
+		data, err := r.load()
+		if err != nil {
+			return err
+		}
+
+		agent, ok := data.Agents[id]
+		if !ok {
+			return ErrAgentNotFound
+		}
+
+		agent.WorkDir = workDir
+		return r.save(data)
+	})
+}
+
 // Ping updates an agent's last ping time
 func (r *Registry) Ping(id string) error {
 	r.mu.Lock()
@@ -343,15 +476,18 @@ func (r *Registry) Ping(id string) error {
 	})
 }
 
-// Clear removes all agents from the registry
+// Clear removes all agents from the registry, keeping the sessions they ran
 func (r *Registry) Clear() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	return r.withFileLock(func() error {
-		data := &registryData{
-			Agents: make(map[string]*AgentRecord),
+		data, err := r.load()
+		if err != nil {
+			data = &registryData{}
 		}
+		// The session ledger stays so their transcripts can still be collected
+		data.Agents = make(map[string]*AgentRecord)
 		return r.save(data)
 	})
 }
@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// Status is where an agent is in its lifecycle
+type Status string
+
+const (
+	StatusIdle    Status = "idle"    // soldati waiting for work
+	StatusActive  Status = "active"  // assigned work, or an associate just spawned
+	StatusWorking Status = "working" // an associate's claude call is running
+	StatusPaused  Status = "paused"  // soldati held by a pause hook
+	StatusNudged  Status = "nudged"  // associate past its timeout, in its grace period
+	StatusError   Status = "error"   // soldati whose last call failed
+	StatusStuck   Status = "stuck"   // no progress seen by patrol
+	StatusDead    Status = "dead"    // process gone, waiting to be respawned
+
+	// Terminal statuses: the agent has finished and will not run again
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusTimedOut  Status = "timed_out"
+	StatusAborted   Status = "aborted"
+)
+
+// ErrInvalidTransition is returned when a status change is not allowed
+// from the agent's current status
+var ErrInvalidTransition = errkind.New(errkind.Conflict, "invalid status transition")
+
+// terminal lists the statuses an agent can end in
+var terminal = []Status{StatusCompleted, StatusFailed, StatusTimedOut, StatusAborted}
+
+// transitions lists the statuses each live status may move to, on top of
+// the terminal ones. Terminal statuses have no way out: a late update from
+// an associate's goroutine must not revive one that was aborted or timed out.
+var transitions = map[Status][]Status{
+	StatusIdle:    {StatusActive, StatusWorking, StatusPaused, StatusError, StatusStuck, StatusDead},
+	StatusActive:  {StatusIdle, StatusWorking, StatusPaused, StatusNudged, StatusError, StatusStuck, StatusDead},
+	StatusWorking: {StatusIdle, StatusActive, StatusPaused, StatusNudged, StatusError, StatusStuck, StatusDead},
+	StatusPaused:  {StatusIdle, StatusActive, StatusError, StatusDead},
+	StatusNudged:  {StatusIdle, StatusActive, StatusWorking, StatusError, StatusStuck, StatusDead},
+	StatusError:   {StatusIdle, StatusActive, StatusPaused, StatusDead},
+	StatusStuck:   {StatusIdle, StatusActive, StatusWorking, StatusError, StatusDead},
+	StatusDead:    {StatusIdle},
+}
+
+// Valid reports whether s is a known status
+func (s Status) Valid() bool {
+	_, live := transitions[s]
+	return live || s.Terminal()
+}
+
+// Terminal reports whether the status means the agent has finished
+func (s Status) Terminal() bool {
+	for _, t := range terminal {
+		if s == t {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransition reports whether an agent may move from one status to
+// another. Staying in the same status is always allowed, and an agent with
+// no status yet may take any.
+func CanTransition(from, to Status) bool {
+	if !to.Valid() {
+		return false
+	}
+	if from == to || from == "" {
+		return true
+	}
+	if from.Terminal() {
+		return false
+	}
+	if to.Terminal() {
+		return true
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transitionError describes a rejected status change
+func transitionError(agent string, from, to Status) error {
+	return fmt.Errorf("%w: agent %s cannot go from %q to %q", ErrInvalidTransition, agent, from, to)
+}
@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusIdle, StatusActive, true},
+		{StatusActive, StatusIdle, true},
+		{StatusActive, StatusWorking, true},
+		{StatusWorking, StatusCompleted, true},
+		{StatusNudged, StatusTimedOut, true},
+		{StatusPaused, StatusIdle, true},
+		{StatusError, StatusIdle, true},
+		{StatusIdle, StatusIdle, true},
+		{"", StatusActive, true},
+		{StatusIdle, StatusNudged, false},
+		{StatusDead, StatusWorking, false},
+		{StatusAborted, StatusFailed, false},
+		{StatusTimedOut, StatusCompleted, false},
+		{StatusCompleted, StatusIdle, false},
+		{StatusIdle, "busy", false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRegistry_UpdateStatusRejectsIllegalTransition(t *testing.T) {
+	reg := New(DefaultPath(t.TempDir()))
+	if err := reg.Register(&AgentRecord{ID: "a1", Type: "associate", Name: "assoc-red-fox", Status: StatusWorking}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An abort lands first; the runner's late "failed" must not overwrite it
+	if err := reg.UpdateStatus("a1", StatusAborted); err != nil {
+		t.Fatal(err)
+	}
+	err := reg.UpdateStatus("a1", StatusFailed)
+	if !errors.Is(err, ErrInvalidTransition) || !errors.Is(err, errkind.Conflict) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+
+	record, err := reg.Get("a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Status != StatusAborted || record.CompletedAt == nil {
+		t.Errorf("expected the agent to stay aborted with CompletedAt set, got %q %v", record.Status, record.CompletedAt)
+	}
+}
@@ -0,0 +1,76 @@
+// Package router picks the model an agent should use for a bead.
+package router
+
+import (
+	"strings"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+)
+
+// FallbackDefaultModel is used when no default model is configured
+const FallbackDefaultModel = "sonnet"
+
+// minCostSamples is how many past beads a route needs before its historical cost is trusted
+const minCostSamples = 3
+
+// Router selects models for beads based on type, priority, and historical cost
+type Router struct {
+	cfg config.RoutingConfig
+}
+
+// New creates a router from routing config
+func New(cfg config.RoutingConfig) *Router {
+	return &Router{cfg: cfg}
+}
+
+// Route returns the model to use for a bead. History is the set of past beads
+// used to estimate what each route costs; it may be nil.
+func (r *Router) Route(bead *models.Bead, history []*models.Bead) string {
+	for _, route := range r.cfg.Routes {
+		if !matches(route, bead) || route.Model == "" {
+			continue
+		}
+
+		if route.MaxAvgCost > 0 && route.FallbackModel != "" {
+			avg, n := AverageCost(history, bead.Type, route.Model)
+			if n >= minCostSamples && avg > route.MaxAvgCost {
+				return route.FallbackModel
+			}
+		}
+		return route.Model
+	}
+
+	if r.cfg.DefaultModel != "" {
+		return r.cfg.DefaultModel
+	}
+	return FallbackDefaultModel
+}
+
+// matches returns true if a route applies to a bead
+func matches(route config.ModelRoute, bead *models.Bead) bool {
+	if route.Type != "" && !strings.EqualFold(route.Type, string(bead.Type)) {
+		return false
+	}
+	if route.MaxPriority != nil && bead.Priority > *route.MaxPriority {
+		return false
+	}
+	return true
+}
+
+// AverageCost returns the mean recorded cost of past beads of a type worked on by a model
+func AverageCost(history []*models.Bead, beadType models.BeadType, model string) (float64, int) {
+	var total float64
+	var n int
+	for _, b := range history {
+		if b.Type != beadType || b.Model != model || b.CostUSD <= 0 {
+			continue
+		}
+		total += b.CostUSD
+		n++
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return total / float64(n), n
+}
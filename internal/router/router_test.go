@@ -0,0 +1,70 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestRouteDefaults(t *testing.T) {
+	r := New(config.DefaultConfig().Routing)
+
+	tests := []struct {
+		name string
+		bead *models.Bead
+		want string
+	}{
+		{"chore goes to haiku", &models.Bead{Type: models.BeadTypeChore, Priority: 2}, "haiku"},
+		{"p0 epic goes to opus", &models.Bead{Type: models.BeadTypeEpic, Priority: 0}, "opus"},
+		{"p1 epic uses default", &models.Bead{Type: models.BeadTypeEpic, Priority: 1}, "sonnet"},
+		{"task uses default", &models.Bead{Type: models.BeadTypeTask, Priority: 0}, "sonnet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Route(tt.bead, nil); got != tt.want {
+				t.Errorf("Route() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteFallsBackOnHistoricalCost(t *testing.T) {
+	r := New(config.RoutingConfig{
+		DefaultModel: "sonnet",
+		Routes: []config.ModelRoute{
+			{Type: "feature", Model: "opus", MaxAvgCost: 1.0, FallbackModel: "sonnet"},
+		},
+	})
+	bead := &models.Bead{Type: models.BeadTypeFeature}
+
+	expensive := []*models.Bead{
+		{Type: models.BeadTypeFeature, Model: "opus", CostUSD: 2.0},
+		{Type: models.BeadTypeFeature, Model: "opus", CostUSD: 1.5},
+	}
+	if got := r.Route(bead, expensive); got != "opus" {
+		t.Errorf("expected opus with too few samples, got %q", got)
+	}
+
+	expensive = append(expensive, &models.Bead{Type: models.BeadTypeFeature, Model: "opus", CostUSD: 1.2})
+	if got := r.Route(bead, expensive); got != "sonnet" {
+		t.Errorf("expected fallback to sonnet when average cost exceeds limit, got %q", got)
+	}
+
+	cheap := []*models.Bead{
+		{Type: models.BeadTypeFeature, Model: "opus", CostUSD: 0.5},
+		{Type: models.BeadTypeFeature, Model: "opus", CostUSD: 0.4},
+		{Type: models.BeadTypeFeature, Model: "opus", CostUSD: 0.6},
+	}
+	if got := r.Route(bead, cheap); got != "opus" {
+		t.Errorf("expected opus when under cost limit, got %q", got)
+	}
+}
+
+func TestRouteEmptyConfig(t *testing.T) {
+	r := New(config.RoutingConfig{})
+	if got := r.Route(&models.Bead{Type: models.BeadTypeTask}, nil); got != FallbackDefaultModel {
+		t.Errorf("expected %q, got %q", FallbackDefaultModel, got)
+	}
+}
@@ -0,0 +1,480 @@
+// Package search maintains a lightweight full-text index over mob data:
+// beads, comments, session transcripts, agent output, and daemon logs.
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabe/mob/internal/logfile"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+// Kind identifies where a document came from
+type Kind string
+
+const (
+	KindBead        Kind = "bead"
+	KindComment     Kind = "comment"
+	KindTranscript  Kind = "transcript"
+	KindAgentOutput Kind = "output"
+	KindDaemonLog   Kind = "log"
+)
+
+// Kinds lists every document kind in display order
+var Kinds = []Kind{KindBead, KindComment, KindTranscript, KindAgentOutput, KindDaemonLog}
+
+// snippetRadius is how many characters of context surround a match
+const snippetRadius = 40
+
+// Document is one searchable piece of text with a link back to its source
+type Document struct {
+	Kind      Kind      `json:"kind"`
+	BeadID    string    `json:"bead_id,omitempty"`
+	Agent     string    `json:"agent,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Source    string    `json:"source,omitempty"` // file path or session ID
+	Text      string    `json:"text"`
+}
+
+// Result is a document that matched a query
+type Result struct {
+	Document
+	Snippet string
+}
+
+// Sources are the inputs an index is built from
+type Sources struct {
+	Beads       []*models.Bead
+	Transcripts map[string]string   // agent name -> transcript path
+	AgentOutput map[string][]string // agent name -> recent output lines
+	LogPath     string              // daemon log file
+}
+
+// Index is a snapshot of searchable documents
+type Index struct {
+	Documents []Document
+}
+
+// DefaultPath returns the index location within a mob directory
+func DefaultPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "search.jsonl")
+}
+
+// Build indexes all given sources. Sources that fail to load are skipped.
+func Build(src Sources) *Index {
+	return NewBuilder().Build(src)
+}
+
+// Builder builds an index again and again, reusing what it read last time
+// from transcripts and rotated logs that have not changed and reading only
+// what was appended to the active daemon log since. It is not safe for
+// concurrent use.
+type Builder struct {
+	files map[string]*fileDocs
+}
+
+// fileDocs are the documents read from one file and the state it was in
+type fileDocs struct {
+	info   os.FileInfo
+	offset int64 // bytes of an appended-to file already indexed
+	docs   []Document
+}
+
+// NewBuilder returns a builder with nothing read yet
+func NewBuilder() *Builder {
+	return &Builder{files: make(map[string]*fileDocs)}
+}
+
+// Build indexes all given sources. Sources that fail to load are skipped.
+func (b *Builder) Build(src Sources) *Index {
+	idx := &Index{}
+	seen := make(map[string]bool)
+
+	for _, bead := range src.Beads {
+		idx.addBead(bead)
+	}
+
+	agents := make([]string, 0, len(src.Transcripts))
+	for name := range src.Transcripts {
+		agents = append(agents, name)
+	}
+	sort.Strings(agents)
+	for _, name := range agents {
+		path := src.Transcripts[name]
+		key := string(KindTranscript) + ":" + name + ":" + path
+		seen[key] = true
+		idx.Documents = append(idx.Documents, b.transcriptDocs(key, name, path)...)
+	}
+
+	agents = agents[:0]
+	for name := range src.AgentOutput {
+		agents = append(agents, name)
+	}
+	sort.Strings(agents)
+	for _, name := range agents {
+		for _, line := range src.AgentOutput[name] {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			idx.Documents = append(idx.Documents, Document{Kind: KindAgentOutput, Agent: name, Text: line})
+		}
+	}
+
+	if src.LogPath != "" {
+		rotated, _ := logfile.Rotated(src.LogPath)
+		for _, name := range rotated {
+			key := string(KindDaemonLog) + ":" + name
+			seen[key] = true
+			idx.Documents = append(idx.Documents, b.rotatedLogDocs(key, name, src.LogPath)...)
+		}
+		key := string(KindDaemonLog) + ":" + src.LogPath
+		seen[key] = true
+		idx.Documents = append(idx.Documents, b.activeLogDocs(key, src.LogPath)...)
+	}
+
+	// Forget files no longer among the sources, such as pruned logs
+	for key := range b.files {
+		if !seen[key] {
+			delete(b.files, key)
+		}
+	}
+	return idx
+}
+
+// unchanged returns what was read from a file last time when it has not
+// changed since
+func (b *Builder) unchanged(key string, info os.FileInfo) *fileDocs {
+	cached := b.files[key]
+	if cached == nil || !os.SameFile(cached.info, info) || cached.info.Size() != info.Size() || !cached.info.ModTime().Equal(info.ModTime()) {
+		return nil
+	}
+	return cached
+}
+
+// transcriptDocs indexes an agent's transcript, parsing it again only when
+// it changed
+func (b *Builder) transcriptDocs(key, agent, path string) []Document {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if cached := b.unchanged(key, info); cached != nil {
+		return cached.docs
+	}
+	t, err := transcript.Load(path)
+	if err != nil {
+		return nil
+	}
+	docs := transcriptDocs(agent, t)
+	b.files[key] = &fileDocs{info: info, docs: docs}
+	return docs
+}
+
+// rotatedLogDocs indexes a rotated log, which is read once since it no
+// longer changes
+func (b *Builder) rotatedLogDocs(key, name, source string) []Document {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil
+	}
+	if cached := b.unchanged(key, info); cached != nil {
+		return cached.docs
+	}
+	rc, err := logfile.OpenFile(name)
+	if err != nil {
+		return nil
+	}
+	defer rc.Close()
+	docs, _ := logDocs(rc, source, false)
+	b.files[key] = &fileDocs{info: info, docs: docs}
+	return docs
+}
+
+// activeLogDocs indexes the active daemon log, reading on from where the
+// last build stopped unless the log was rotated or truncated meanwhile.
+// A last line still being written waits for the next build.
+func (b *Builder) activeLogDocs(key, path string) []Document {
+	info, err := os.Stat(path)
+	if err != nil {
+		delete(b.files, key)
+		return nil
+	}
+	cached := b.files[key]
+	if cached == nil || !os.SameFile(cached.info, info) || info.Size() < cached.offset {
+		cached = &fileDocs{}
+	}
+	if info.Size() > cached.offset {
+		f, err := os.Open(path)
+		if err != nil {
+			return cached.docs
+		}
+		defer f.Close()
+		if _, err := f.Seek(cached.offset, io.SeekStart); err != nil {
+			return cached.docs
+		}
+		docs, n := logDocs(f, path, true)
+		cached.docs = append(cached.docs, docs...)
+		cached.offset += n
+	}
+	cached.info = info
+	b.files[key] = cached
+	return cached.docs
+}
+
+func (idx *Index) addBead(bead *models.Bead) {
+	text := bead.Title
+	if bead.Description != "" {
+		text += "\n" + bead.Description
+	}
+	idx.Documents = append(idx.Documents, Document{
+		Kind:      KindBead,
+		BeadID:    bead.ID,
+		Agent:     bead.Assignee,
+		Timestamp: bead.UpdatedAt,
+		Text:      text,
+	})
+
+	for _, event := range bead.History {
+		if event.Type != models.BeadEventTypeComment || event.Comment == "" {
+			continue
+		}
+		idx.Documents = append(idx.Documents, Document{
+			Kind:      KindComment,
+			BeadID:    bead.ID,
+			Agent:     event.Actor,
+			Timestamp: event.Timestamp,
+			Text:      event.Comment,
+		})
+	}
+}
+
+// transcriptDocs turns a transcript's entries into documents
+func transcriptDocs(agent string, t *transcript.Transcript) []Document {
+	var docs []Document
+	for _, e := range t.Entries {
+		if e.Text == "" {
+			continue
+		}
+		author := string(e.Role)
+		if e.Role == transcript.RoleAssistant {
+			author = agent
+		}
+		docs = append(docs, Document{
+			Kind:      KindTranscript,
+			Agent:     author,
+			Timestamp: e.Timestamp,
+			Source:    t.SessionID,
+			Text:      e.Text,
+		})
+	}
+	return docs
+}
+
+// logDocs indexes each line of a daemon log, parsing the standard log
+// prefix, and returns how many bytes it consumed. Lines have no length
+// limit. With partial set, a last line without its newline is left unread.
+func logDocs(r io.Reader, source string, partial bool) ([]Document, int64) {
+	var docs []Document
+	var consumed int64
+	br := bufio.NewReader(r)
+	for {
+		raw, err := br.ReadString('\n')
+		if err != nil && (partial || raw == "") {
+			break
+		}
+		consumed += int64(len(raw))
+
+		line := strings.TrimSpace(raw)
+		if line != "" {
+			doc := Document{Kind: KindDaemonLog, Source: source, Text: line}
+			if ts, ok := logfile.Timestamp(line); ok && len(line) > 19 {
+				doc.Timestamp = ts
+				doc.Text = strings.TrimSpace(line[19:])
+			}
+			docs = append(docs, doc)
+		}
+		if err != nil {
+			break
+		}
+	}
+	return docs, consumed
+}
+
+// Save writes the index atomically as JSONL
+func (idx *Index) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, doc := range idx.Documents {
+		if err := enc.Encode(doc); err != nil {
+			f.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// Load reads an index previously written by Save
+func Load(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	idx := &Index{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 50*1024*1024)
+	for scanner.Scan() {
+		var doc Document
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			continue
+		}
+		idx.Documents = append(idx.Documents, doc)
+	}
+	return idx, scanner.Err()
+}
+
+// Query describes a search
+type Query struct {
+	Text  string
+	Kinds []Kind // empty = all kinds
+	Limit int    // 0 = unlimited
+}
+
+// Search returns documents containing the query text (case-insensitive),
+// newest first
+func (idx *Index) Search(q Query) []Result {
+	needle := strings.TrimSpace(q.Text)
+	if needle == "" {
+		return nil
+	}
+
+	allowed := make(map[Kind]bool, len(q.Kinds))
+	for _, k := range q.Kinds {
+		allowed[k] = true
+	}
+
+	var results []Result
+	for _, doc := range idx.Documents {
+		if len(allowed) > 0 && !allowed[doc.Kind] {
+			continue
+		}
+		pos, length := indexFold(doc.Text, needle)
+		if pos < 0 {
+			continue
+		}
+		results = append(results, Result{Document: doc, Snippet: snippet(doc.Text, pos, length)})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Timestamp.After(results[j].Timestamp)
+	})
+
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results
+}
+
+// ParseKind validates a kind name from user input
+func ParseKind(s string) (Kind, error) {
+	for _, k := range Kinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown result type %q (expected bead, comment, transcript, output, or log)", s)
+}
+
+// indexFold returns the byte offset and length in s of the first match of
+// substr under Unicode case folding, or -1. Both index s itself: lowercasing
+// s first would shift them wherever a rune's case has another width.
+func indexFold(s, substr string) (int, int) {
+	for i := 0; i < len(s); {
+		if n, ok := prefixFold(s[i:], substr); ok {
+			return i, n
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return -1, 0
+}
+
+// prefixFold reports whether s starts with prefix under case folding and
+// how many bytes of s the match spans
+func prefixFold(s, prefix string) (int, bool) {
+	n := 0
+	for _, want := range prefix {
+		if n >= len(s) {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if r != want && !strings.EqualFold(string(r), string(want)) {
+			return 0, false
+		}
+		n += size
+	}
+	return n, true
+}
+
+// snippet returns a single-line excerpt around a match
+func snippet(text string, pos, length int) string {
+	start := pos - snippetRadius
+	prefix := "…"
+	if start <= 0 {
+		start = 0
+		prefix = ""
+	}
+	end := pos + length + snippetRadius
+	suffix := "…"
+	if end >= len(text) {
+		end = len(text)
+		suffix = ""
+	}
+
+	// Avoid cutting through multi-byte runes
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+
+	excerpt := strings.Join(strings.Fields(text[start:end]), " ")
+	return prefix + excerpt + suffix
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,191 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func testSources(t *testing.T) Sources {
+	t.Helper()
+	dir := t.TempDir()
+
+	logPath := filepath.Join(dir, "daemon.log")
+	logData := "2026/01/14 10:00:00 Patrol: spawning Claude instance for soldati 'vinnie'\n"
+	if err := os.WriteFile(logPath, []byte(logData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sessionPath := filepath.Join(dir, "sess-1.jsonl")
+	sessionData := `{"type":"assistant","sessionId":"sess-1","timestamp":"2026-01-14T11:00:00Z","message":{"role":"assistant","content":"The retry loop in auth.go never backs off"}}` + "\n"
+	if err := os.WriteFile(sessionPath, []byte(sessionData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	return Sources{
+		Beads: []*models.Bead{{
+			ID:          "bd-a1b2",
+			Title:       "Fix login retry",
+			Description: "Users get locked out",
+			Assignee:    "vinnie",
+			UpdatedAt:   now,
+			History: []models.BeadEvent{
+				{Type: models.BeadEventTypeComment, Actor: "human", Comment: "Check the retry helper", Timestamp: now},
+				{Type: models.BeadEventTypeStatusChange, From: "open", To: "in_progress", Timestamp: now},
+			},
+		}},
+		Transcripts: map[string]string{"vinnie": sessionPath},
+		AgentOutput: map[string][]string{"vinnie": {"running go test ./auth", ""}},
+		LogPath:     logPath,
+	}
+}
+
+func TestBuildIndexesAllSources(t *testing.T) {
+	idx := Build(testSources(t))
+
+	counts := make(map[Kind]int)
+	for _, doc := range idx.Documents {
+		counts[doc.Kind]++
+	}
+	for _, k := range Kinds {
+		if counts[k] != 1 {
+			t.Errorf("expected 1 %s document, got %d", k, counts[k])
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx := Build(testSources(t))
+
+	results := idx.Search(Query{Text: "RETRY"})
+	if len(results) != 3 {
+		t.Fatalf("expected bead, comment and transcript matches, got %d", len(results))
+	}
+	if results[0].BeadID != "bd-a1b2" {
+		t.Errorf("expected newest result first, got %+v", results[0])
+	}
+
+	results = idx.Search(Query{Text: "retry", Kinds: []Kind{KindTranscript}})
+	if len(results) != 1 || results[0].Agent != "vinnie" || results[0].Source != "sess-1" {
+		t.Fatalf("expected transcript result linked to vinnie/sess-1, got %+v", results)
+	}
+
+	results = idx.Search(Query{Text: "spawning"})
+	if len(results) != 1 || results[0].Timestamp.IsZero() || strings.HasPrefix(results[0].Text, "2026") {
+		t.Fatalf("expected log line with parsed timestamp, got %+v", results)
+	}
+
+	if got := idx.Search(Query{Text: "retry", Limit: 1}); len(got) != 1 {
+		t.Errorf("expected limit to apply, got %d", len(got))
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	idx := Build(testSources(t))
+	path := filepath.Join(t.TempDir(), "search.jsonl")
+
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if len(loaded.Documents) != len(idx.Documents) {
+		t.Fatalf("expected %d documents, got %d", len(idx.Documents), len(loaded.Documents))
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	text := strings.Repeat("a", 100) + "needle" + strings.Repeat("b", 100)
+	got := snippet(text, 100, 6)
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "needle") {
+		t.Errorf("unexpected snippet %q", got)
+	}
+}
+
+func TestBuilderReadsOnlyAppendedLog(t *testing.T) {
+	src := testSources(t)
+	b := NewBuilder()
+	if got := len(b.Build(src).Search(Query{Text: "spawning", Kinds: []Kind{KindDaemonLog}})); got != 1 {
+		t.Fatalf("expected the first log line, got %d", got)
+	}
+
+	f, err := os.OpenFile(src.LogPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	// A line still being written is left for the next build
+	if _, err := f.WriteString("2026/01/14 10:05:00 Patrol: spawning Claude instance for soldati 'sal'\n2026/01/14 10:06:00 Patrol: spawn"); err != nil {
+		t.Fatal(err)
+	}
+	idx := b.Build(src)
+	if got := len(idx.Search(Query{Text: "spawning", Kinds: []Kind{KindDaemonLog}})); got != 2 {
+		t.Fatalf("expected the appended line indexed once, got %d matches", got)
+	}
+	if got := idx.Search(Query{Text: "10:06"}); len(got) != 0 {
+		t.Fatalf("expected the partial line held back, got %+v", got)
+	}
+
+	if _, err := f.WriteString("ing Claude instance for soldati 'paulie'\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.Build(src).Search(Query{Text: "paulie"}); len(got) != 1 || !strings.HasPrefix(got[0].Text, "Patrol: spawning") {
+		t.Fatalf("expected the finished line indexed whole, got %+v", got)
+	}
+
+	// A rotated log is indexed from the start of the new active log
+	if err := os.Rename(src.LogPath, src.LogPath+".20260114-100700"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src.LogPath, []byte("2026/01/14 10:08:00 Merges: queued bead bd-a1b2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	idx = b.Build(src)
+	if got := len(idx.Search(Query{Text: "spawning"})); got != 3 {
+		t.Errorf("expected rotated lines kept, got %d", got)
+	}
+	if got := len(idx.Search(Query{Text: "Merges"})); got != 1 {
+		t.Errorf("expected the new active log indexed, got %d", got)
+	}
+}
+
+func TestLogLinesLongerThanScannerBuffer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	long := strings.Repeat("x", 100*1024)
+	data := "2026/01/14 10:00:00 Agent said: " + long + " needle\n2026/01/14 10:00:01 After the long line\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	idx := Build(Sources{LogPath: path})
+	if got := idx.Search(Query{Text: "needle"}); len(got) != 1 {
+		t.Errorf("expected the long line indexed, got %d", len(got))
+	}
+	if got := idx.Search(Query{Text: "After the long line"}); len(got) != 1 {
+		t.Errorf("expected lines after the long one indexed, got %d", len(got))
+	}
+}
+
+func TestSearchFoldsCaseOnOriginalText(t *testing.T) {
+	// "İ" lowercases to a longer string, so offsets into a lowercased copy
+	// drift past the match
+	text := strings.Repeat("İ", 30) + " Straße needle " + strings.Repeat("z", 60)
+	idx := &Index{Documents: []Document{{Kind: KindComment, Text: text}}}
+	got := idx.Search(Query{Text: "NEEDLE"})
+	want := snippet(text, strings.Index(text, "needle"), len("needle"))
+	if len(got) != 1 || got[0].Snippet != want {
+		t.Fatalf("expected the snippet centered on the match %q, got %+v", want, got)
+	}
+	if pos, n := indexFold("ÀB ÉCOLE", "école"); pos != len("ÀB ") || n != len("ÉCOLE") {
+		t.Errorf("indexFold = %d, %d; want the match in the original text", pos, n)
+	}
+	if pos, _ := indexFold("abc", "abcd"); pos != -1 {
+		t.Errorf("indexFold past the end = %d, want -1", pos)
+	}
+}
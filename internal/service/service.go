@@ -0,0 +1,282 @@
+// Package service installs the daemon as a user service that starts with
+// the session and restarts when it fails: a systemd user unit on Linux, a
+// launchd agent on macOS.
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// Service names
+const (
+	systemdUnit  = "mob.service"
+	launchdLabel = "com.mob.daemon"
+)
+
+// Service log rotation: launchd writes the daemon's stdout and stderr to a
+// file, which the daemon rotates as it starts
+const (
+	MaxLogBytes = 10 * 1024 * 1024
+	LogsKept    = 3
+)
+
+// ErrUnsupported is returned on systems without systemd or launchd
+var ErrUnsupported = errkind.New(errkind.Invalid, "installing the daemon as a service needs systemd (Linux) or launchd (macOS)")
+
+// Spec describes the service to install
+type Spec struct {
+	Binary    string // absolute path of the mob binary
+	MobDir    string
+	Supervise []string // other mob directories the daemon looks after
+	Path      string   // PATH the daemon runs with, so agents find claude and git
+}
+
+// Args returns the arguments the service runs the binary with
+func (s Spec) Args() []string {
+	args := []string{"--mob-dir", s.MobDir, "daemon", "start"}
+	for _, dir := range s.Supervise {
+		args = append(args, "--supervise", dir)
+	}
+	return args
+}
+
+// Service is the daemon's service definition on one system
+type Service struct {
+	OS   string // runtime.GOOS value: "linux" or "darwin"
+	Home string
+
+	// Run runs a service manager command; replaced in tests
+	Run func(name string, args ...string) error
+}
+
+// New returns the service for this system and user
+func New() (*Service, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return &Service{OS: runtime.GOOS, Home: home, Run: run}, nil
+}
+
+func run(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %v: %s", name, strings.Join(args, " "), err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// LogPath returns where launchd sends the daemon's stdout and stderr
+func LogPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "daemon.service.log")
+}
+
+// Path returns where the service definition is installed
+func (s *Service) Path() (string, error) {
+	switch s.OS {
+	case "linux":
+		return filepath.Join(s.Home, ".config", "systemd", "user", systemdUnit), nil
+	case "darwin":
+		return filepath.Join(s.Home, "Library", "LaunchAgents", launchdLabel+".plist"), nil
+	}
+	return "", ErrUnsupported
+}
+
+// Installed reports whether the service definition is in place
+func (s *Service) Installed() bool {
+	path, err := s.Path()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Render returns the service definition for spec
+func (s *Service) Render(spec Spec) (string, error) {
+	switch s.OS {
+	case "linux":
+		return renderSystemd(spec), nil
+	case "darwin":
+		return renderLaunchd(spec), nil
+	}
+	return "", ErrUnsupported
+}
+
+// renderSystemd writes a user unit. Output goes to the journal, which
+// rotates it; the daemon keeps its own log in .mob/daemon.log.
+func renderSystemd(spec Spec) string {
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=mob daemon\n")
+	b.WriteString("After=network-online.target\n\n")
+	b.WriteString("[Service]\n")
+	b.WriteString("Type=simple\n")
+	argv := []string{systemdQuote(spec.Binary)}
+	for _, arg := range spec.Args() {
+		argv = append(argv, systemdQuote(arg))
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(argv, " "))
+	fmt.Fprintf(&b, "WorkingDirectory=%s\n", systemdQuote(spec.MobDir))
+	if spec.Path != "" {
+		fmt.Fprintf(&b, "Environment=%s\n", systemdQuote("PATH="+spec.Path))
+	}
+	b.WriteString("Restart=on-failure\n")
+	b.WriteString("RestartSec=5\n")
+	b.WriteString("StandardOutput=journal\n")
+	b.WriteString("StandardError=journal\n\n")
+	b.WriteString("[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+	return b.String()
+}
+
+// systemdQuote quotes a value that contains spaces or quotes
+func systemdQuote(s string) string {
+	if !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// renderLaunchd writes a launch agent that restarts the daemon whenever it
+// exits unsuccessfully
+func renderLaunchd(spec Spec) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	plistKey(&b, "Label", launchdLabel)
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range append([]string{spec.Binary}, spec.Args()...) {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	b.WriteString("\t</array>\n")
+	plistKey(&b, "WorkingDirectory", spec.MobDir)
+	if spec.Path != "" {
+		b.WriteString("\t<key>EnvironmentVariables</key>\n\t<dict>\n")
+		fmt.Fprintf(&b, "\t\t<key>PATH</key>\n\t\t<string>%s</string>\n", xmlEscape(spec.Path))
+		b.WriteString("\t</dict>\n")
+	}
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	b.WriteString("\t<key>ThrottleInterval</key>\n\t<integer>5</integer>\n")
+	plistKey(&b, "StandardOutPath", LogPath(spec.MobDir))
+	plistKey(&b, "StandardErrorPath", LogPath(spec.MobDir))
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+func plistKey(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "\t<key>%s</key>\n\t<string>%s</string>\n", key, xmlEscape(value))
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// Install writes the service definition and starts the service, returning
+// where it was written
+func (s *Service) Install(spec Spec) (string, error) {
+	path, err := s.Path()
+	if err != nil {
+		return "", err
+	}
+	def, err := s.Render(spec)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(def), 0644); err != nil {
+		return "", fmt.Errorf("failed to write service: %w", err)
+	}
+
+	switch s.OS {
+	case "linux":
+		if err := s.Run("systemctl", "--user", "daemon-reload"); err != nil {
+			return path, err
+		}
+		return path, s.Run("systemctl", "--user", "enable", "--now", systemdUnit)
+	default:
+		s.Run("launchctl", "unload", path) // a previous install may still be loaded
+		return path, s.Run("launchctl", "load", "-w", path)
+	}
+}
+
+// Uninstall stops the service and removes its definition, returning where
+// it was. A service that was never installed returns an error of kind
+// NotFound.
+func (s *Service) Uninstall() (string, error) {
+	path, err := s.Path()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path, errkind.New(errkind.NotFound, "the daemon is not installed as a service")
+	}
+
+	switch s.OS {
+	case "linux":
+		if err := s.Run("systemctl", "--user", "disable", "--now", systemdUnit); err != nil {
+			return path, err
+		}
+	default:
+		if err := s.Run("launchctl", "unload", "-w", path); err != nil {
+			return path, err
+		}
+	}
+	if err := os.Remove(path); err != nil {
+		return path, fmt.Errorf("failed to remove service: %w", err)
+	}
+	if s.OS == "linux" {
+		return path, s.Run("systemctl", "--user", "daemon-reload")
+	}
+	return path, nil
+}
+
+// RotateLog moves a log past maxBytes aside as path.1, shifting older ones
+// up to keep. The log is copied and truncated rather than renamed, because
+// launchd holds it open for the daemon.
+func RotateLog(path string, maxBytes int64, keep int) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) || (err == nil && info.Size() <= maxBytes) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := keep - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	src, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(path + ".1")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return src.Truncate(0)
+}
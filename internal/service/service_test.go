@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+func testService(t *testing.T, goos string) (*Service, *[]string) {
+	var calls []string
+	return &Service{
+		OS:   goos,
+		Home: t.TempDir(),
+		Run: func(name string, args ...string) error {
+			calls = append(calls, name+" "+strings.Join(args, " "))
+			return nil
+		},
+	}, &calls
+}
+
+func TestRenderSystemd(t *testing.T) {
+	s, _ := testService(t, "linux")
+	unit, err := s.Render(Spec{Binary: "/opt/my tools/mob", MobDir: "/home/me/mob", Supervise: []string{"/home/me/side mob"}, Path: "/usr/bin:/bin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`ExecStart="/opt/my tools/mob" --mob-dir /home/me/mob daemon start --supervise "/home/me/side mob"`,
+		"WorkingDirectory=/home/me/mob",
+		"Environment=PATH=/usr/bin:/bin",
+		"Restart=on-failure",
+		"StandardOutput=journal",
+		"WantedBy=default.target",
+	} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("unit missing %q:\n%s", want, unit)
+		}
+	}
+}
+
+func TestRenderLaunchd(t *testing.T) {
+	s, _ := testService(t, "darwin")
+	plist, err := s.Render(Spec{Binary: "/usr/local/bin/mob", MobDir: "/Users/me/R&D mob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<string>com.mob.daemon</string>",
+		"<string>/usr/local/bin/mob</string>\n\t\t<string>--mob-dir</string>\n\t\t<string>/Users/me/R&amp;D mob</string>\n\t\t<string>daemon</string>\n\t\t<string>start</string>",
+		"<key>SuccessfulExit</key>\n\t\t<false/>",
+		"<string>/Users/me/R&amp;D mob/.mob/daemon.service.log</string>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("plist missing %q:\n%s", want, plist)
+		}
+	}
+	if strings.Contains(plist, "EnvironmentVariables") {
+		t.Error("no PATH should mean no environment")
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	s, _ := testService(t, "windows")
+	if _, err := s.Install(Spec{}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Install = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestInstallUninstall(t *testing.T) {
+	s, calls := testService(t, "linux")
+	path, err := s.Install(Spec{Binary: "/usr/bin/mob", MobDir: "/home/me/mob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(s.Home, ".config", "systemd", "user", "mob.service") || !s.Installed() {
+		t.Fatalf("installed at %s", path)
+	}
+	if got := strings.Join(*calls, "; "); got != "systemctl --user daemon-reload; systemctl --user enable --now mob.service" {
+		t.Errorf("install ran %s", got)
+	}
+
+	*calls = nil
+	if _, err := s.Uninstall(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Installed() {
+		t.Error("unit should be removed")
+	}
+	if got := strings.Join(*calls, "; "); got != "systemctl --user disable --now mob.service; systemctl --user daemon-reload" {
+		t.Errorf("uninstall ran %s", got)
+	}
+
+	if _, err := s.Uninstall(); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("second uninstall = %v, want NotFound", err)
+	}
+}
+
+func TestRotateLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon.service.log")
+	if err := RotateLog(path, 10, 2); err != nil {
+		t.Fatalf("missing log: %v", err)
+	}
+
+	os.WriteFile(path, []byte("short"), 0644)
+	RotateLog(path, 10, 2)
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Fatal("a small log should not rotate")
+	}
+
+	for _, content := range []string{"first run output", "second run output", "third run output"} {
+		os.WriteFile(path, []byte(content), 0644)
+		if err := RotateLog(path, 10, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Errorf("log should be truncated, has %q", data)
+	}
+	if data, _ := os.ReadFile(path + ".1"); string(data) != "third run output" {
+		t.Errorf(".1 = %q", data)
+	}
+	if data, _ := os.ReadFile(path + ".2"); string(data) != "second run output" {
+		t.Errorf(".2 = %q", data)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("only 2 rotated logs should be kept")
+	}
+}
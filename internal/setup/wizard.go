@@ -13,6 +13,9 @@ import (
 // Wizard handles interactive first-run setup
 type Wizard struct {
 	reader *bufio.Reader
+
+	// MobDir is offered as where mob stores its data; empty = ~/mob
+	MobDir string
 }
 
 // NewWizard creates a setup wizard
@@ -34,7 +37,11 @@ func (w *Wizard) Run() error {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	defaultMobDir := filepath.Join(homeDir, "mob")
+	standardMobDir := filepath.Join(homeDir, "mob")
+	defaultMobDir := w.MobDir
+	if defaultMobDir == "" {
+		defaultMobDir = standardMobDir
+	}
 	mobDir, err := w.prompt("Where should mob store its data?", defaultMobDir)
 	if err != nil {
 		return err
@@ -48,8 +55,7 @@ func (w *Wizard) Run() error {
 		filepath.Join(mobDir, ".mob", "logs", "soldati"),
 		filepath.Join(mobDir, ".mob", "tmp"),
 		filepath.Join(mobDir, ".mob", "soldati"),
-		filepath.Join(mobDir, "beads"),
-		filepath.Join(mobDir, "beads", "archive"),
+		filepath.Join(mobDir, ".mob", "beads"),
 		filepath.Join(mobDir, "soldati"),
 		filepath.Join(mobDir, "history"),
 		filepath.Join(mobDir, "history", "summaries"),
@@ -78,6 +84,12 @@ func (w *Wizard) Run() error {
 	fmt.Println("Setup complete!")
 	fmt.Printf("  Mob home: %s\n", mobDir)
 	fmt.Printf("  Config:   %s\n", configPath)
+	if mobDir != standardMobDir {
+		fmt.Println()
+		fmt.Printf("Mob looks in %s by default. Point it here with:\n", standardMobDir)
+		fmt.Printf("  export MOB_HOME=%s\n", mobDir)
+		fmt.Println("or pass --mob-dir to each command.")
+	}
 	fmt.Println()
 	fmt.Println("Next steps:")
 	fmt.Println("  1. Register a project: mob turf add /path/to/project")
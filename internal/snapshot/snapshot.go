@@ -0,0 +1,243 @@
+// Package snapshot records the board as it stood each day and compares two
+// of those records, so retros can see what changed over an interval and
+// mass edits stand out after the fact.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+// DateLayout names snapshot files and dates passed to At
+const DateLayout = "2006-01-02"
+
+// DefaultRetention is how long Prune keeps snapshots by default
+const DefaultRetention = 180 * 24 * time.Hour
+
+// Bead is the part of a bead a snapshot keeps
+type Bead struct {
+	ID       string            `json:"id"`
+	Title    string            `json:"title"`
+	Status   models.BeadStatus `json:"status"`
+	Priority int               `json:"priority"`
+	Type     models.BeadType   `json:"type"`
+	Turf     string            `json:"turf"`
+	Assignee string            `json:"assignee,omitempty"`
+}
+
+// Snapshot is the board at one moment
+type Snapshot struct {
+	TakenAt time.Time `json:"taken_at"`
+	Beads   []Bead    `json:"beads"`
+}
+
+// Capture builds a snapshot of beads, sorted by ID
+func Capture(beads []*models.Bead, now time.Time) *Snapshot {
+	snap := &Snapshot{TakenAt: now, Beads: make([]Bead, 0, len(beads))}
+	for _, b := range beads {
+		snap.Beads = append(snap.Beads, Bead{
+			ID:       b.ID,
+			Title:    b.Title,
+			Status:   b.Status,
+			Priority: b.Priority,
+			Type:     b.Type,
+			Turf:     b.Turf,
+			Assignee: b.Assignee,
+		})
+	}
+	sort.Slice(snap.Beads, func(i, j int) bool { return snap.Beads[i].ID < snap.Beads[j].ID })
+	return snap
+}
+
+// Date returns the day the snapshot was taken on, in local time
+func (s *Snapshot) Date() string {
+	return s.TakenAt.Local().Format(DateLayout)
+}
+
+// Dir returns where snapshots are kept for a mob directory
+func Dir(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "snapshots")
+}
+
+// Exists reports whether dir already holds a snapshot for date
+func Exists(dir, date string) bool {
+	_, err := os.Stat(filepath.Join(dir, date+".json"))
+	return err == nil
+}
+
+// Save writes the snapshot as its day's file, replacing one taken earlier
+// that day
+func Save(dir string, snap *Snapshot) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	path := filepath.Join(dir, snap.Date()+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the snapshot taken on date
+func Load(dir, date string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(dir, date+".json"))
+	if os.IsNotExist(err) {
+		return nil, errkind.New(errkind.NotFound, fmt.Sprintf("no board snapshot for %s", date))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("snapshot %s: %w", date, err)
+	}
+	return &snap, nil
+}
+
+// Dates lists the days with a snapshot, oldest first
+func Dates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	var dates []string
+	for _, e := range entries {
+		date, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, date); err == nil {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+// At returns the latest snapshot taken on or before date, the board as it
+// stood when that day began
+func At(dir, date string) (*Snapshot, error) {
+	dates, err := Dates(dir)
+	if err != nil {
+		return nil, err
+	}
+	found := ""
+	for _, d := range dates {
+		if d > date {
+			break
+		}
+		found = d
+	}
+	if found == "" {
+		if len(dates) == 0 {
+			return nil, errkind.New(errkind.NotFound, "no board snapshots yet; the daemon takes one a day")
+		}
+		return nil, errkind.New(errkind.NotFound, fmt.Sprintf("no board snapshot on or before %s (the earliest is %s)", date, dates[0]))
+	}
+	return Load(dir, found)
+}
+
+// Prune removes snapshots older than retention and returns how many it removed
+func Prune(dir string, retention time.Duration, now time.Time) (int, error) {
+	dates, err := Dates(dir)
+	if err != nil {
+		return 0, err
+	}
+	cutoff := now.Add(-retention).Format(DateLayout)
+	removed := 0
+	for _, d := range dates {
+		if d >= cutoff {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, d+".json")); err != nil {
+			return removed, fmt.Errorf("failed to remove snapshot %s: %w", d, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Reprioritized is a bead whose priority changed between two snapshots
+type Reprioritized struct {
+	Bead
+	From int `json:"from"`
+}
+
+// Diff is what changed on the board between two snapshots
+type Diff struct {
+	From          time.Time       `json:"from"`
+	To            time.Time       `json:"to"`
+	Created       []Bead          `json:"created,omitempty"`
+	Closed        []Bead          `json:"closed,omitempty"`
+	Reopened      []Bead          `json:"reopened,omitempty"`
+	Reprioritized []Reprioritized `json:"reprioritized,omitempty"`
+	Removed       []Bead          `json:"removed,omitempty"`
+}
+
+// Empty reports whether nothing changed
+func (d *Diff) Empty() bool {
+	return len(d.Created)+len(d.Closed)+len(d.Reopened)+len(d.Reprioritized)+len(d.Removed) == 0
+}
+
+// Compare lists the beads created, closed, reopened, reprioritized and
+// removed between from and to. A bead created and closed in the interval is
+// listed under both. An empty turf compares every turf.
+func Compare(from, to *Snapshot, turf string) *Diff {
+	diff := &Diff{From: from.TakenAt, To: to.TakenAt}
+
+	before := make(map[string]Bead, len(from.Beads))
+	for _, b := range from.Beads {
+		if turf == "" || b.Turf == turf {
+			before[b.ID] = b
+		}
+	}
+
+	for _, b := range to.Beads {
+		if turf != "" && b.Turf != turf {
+			continue
+		}
+		old, existed := before[b.ID]
+		delete(before, b.ID)
+
+		closed := b.Status == models.BeadStatusClosed
+		if !existed {
+			diff.Created = append(diff.Created, b)
+			if closed {
+				diff.Closed = append(diff.Closed, b)
+			}
+			continue
+		}
+		wasClosed := old.Status == models.BeadStatusClosed
+		switch {
+		case closed && !wasClosed:
+			diff.Closed = append(diff.Closed, b)
+		case !closed && wasClosed:
+			diff.Reopened = append(diff.Reopened, b)
+		}
+		if b.Priority != old.Priority {
+			diff.Reprioritized = append(diff.Reprioritized, Reprioritized{Bead: b, From: old.Priority})
+		}
+	}
+
+	for _, b := range before {
+		diff.Removed = append(diff.Removed, b)
+	}
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	return diff
+}
@@ -0,0 +1,103 @@
+package snapshot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestCompare(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 5, 0, 0, time.Local)
+	from := Capture([]*models.Bead{
+		{ID: "bd-0001", Title: "Open", Status: models.BeadStatusOpen, Priority: 2, Turf: "api"},
+		{ID: "bd-0002", Title: "Finished", Status: models.BeadStatusInProgress, Priority: 1, Turf: "api"},
+		{ID: "bd-0003", Title: "Done", Status: models.BeadStatusClosed, Priority: 2, Turf: "api"},
+		{ID: "bd-0004", Title: "Gone", Status: models.BeadStatusOpen, Priority: 3, Turf: "api"},
+		{ID: "bd-0005", Title: "Web", Status: models.BeadStatusOpen, Priority: 2, Turf: "web"},
+	}, day)
+	to := Capture([]*models.Bead{
+		{ID: "bd-0006", Title: "Quick fix", Status: models.BeadStatusClosed, Priority: 1, Turf: "api"},
+		{ID: "bd-0001", Title: "Open", Status: models.BeadStatusOpen, Priority: 0, Turf: "api"},
+		{ID: "bd-0002", Title: "Finished", Status: models.BeadStatusClosed, Priority: 1, Turf: "api"},
+		{ID: "bd-0003", Title: "Done", Status: models.BeadStatusOpen, Priority: 2, Turf: "api"},
+		{ID: "bd-0005", Title: "Web", Status: models.BeadStatusOpen, Priority: 4, Turf: "web"},
+	}, day.AddDate(0, 0, 7))
+
+	diff := Compare(from, to, "api")
+	ids := func(beads []Bead) []string {
+		var out []string
+		for _, b := range beads {
+			out = append(out, b.ID)
+		}
+		return out
+	}
+	check := func(name string, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s = %v, want %v", name, got, want)
+				return
+			}
+		}
+	}
+	check("created", ids(diff.Created), "bd-0006")
+	check("closed", ids(diff.Closed), "bd-0002", "bd-0006")
+	check("reopened", ids(diff.Reopened), "bd-0003")
+	check("removed", ids(diff.Removed), "bd-0004")
+	if len(diff.Reprioritized) != 1 || diff.Reprioritized[0].ID != "bd-0001" || diff.Reprioritized[0].From != 2 || diff.Reprioritized[0].Priority != 0 {
+		t.Errorf("expected bd-0001 raised from P2 to P0, got %+v", diff.Reprioritized)
+	}
+
+	if all := Compare(from, to, ""); len(all.Reprioritized) != 2 {
+		t.Errorf("expected the web bead included without a turf, got %+v", all.Reprioritized)
+	}
+	if same := Compare(to, to, ""); !same.Empty() {
+		t.Errorf("expected no changes against itself, got %+v", same)
+	}
+}
+
+func TestAtAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := At(dir, "2026-05-01"); !errors.Is(err, errkind.NotFound) {
+		t.Fatalf("expected not found without snapshots, got %v", err)
+	}
+
+	for _, day := range []int{1, 3, 8} {
+		at := time.Date(2026, 5, day, 0, 5, 0, 0, time.Local)
+		beads := []*models.Bead{{ID: "bd-0001", Priority: day}}
+		if err := Save(dir, Capture(beads, at)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !Exists(dir, "2026-05-03") || Exists(dir, "2026-05-04") {
+		t.Error("Exists should report only saved days")
+	}
+
+	// A day without a snapshot uses the latest one before it
+	snap, err := At(dir, "2026-05-05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Date() != "2026-05-03" || snap.Beads[0].Priority != 3 {
+		t.Errorf("expected the May 3 snapshot, got %s", snap.Date())
+	}
+	if _, err := At(dir, "2026-04-30"); !errors.Is(err, errkind.NotFound) {
+		t.Errorf("expected not found before the first snapshot, got %v", err)
+	}
+
+	removed, err := Prune(dir, 7*24*time.Hour, time.Date(2026, 5, 9, 12, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dates, _ := Dates(dir)
+	if removed != 1 || len(dates) != 2 || dates[0] != "2026-05-03" {
+		t.Errorf("expected only May 1 pruned, removed %d, left %v", removed, dates)
+	}
+}
@@ -1,7 +1,8 @@
 package soldati
 
 import (
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,11 +11,18 @@ import (
 	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/gabe/mob/internal/errkind"
 	"github.com/gabe/mob/internal/models"
 )
 
-// ErrInvalidName is returned when a soldati name contains invalid characters
-var ErrInvalidName = errors.New("invalid soldati name")
+var (
+	// ErrInvalidName is returned when a soldati name contains invalid characters
+	ErrInvalidName = errkind.New(errkind.Invalid, "invalid soldati name")
+	// ErrNotFound is returned when no soldati has the requested name
+	ErrNotFound = errkind.New(errkind.NotFound, "soldati not found")
+	// ErrExists is returned when creating a soldati whose name is taken
+	ErrExists = errkind.New(errkind.Conflict, "soldati already exists")
+)
 
 // validNameRegex matches names that contain only alphanumeric characters, hyphens, and underscores
 var validNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
@@ -107,7 +115,7 @@ func (m *Manager) Get(name string) (*models.Soldati, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("soldati %q not found", name)
+			return nil, fmt.Errorf("%w: %q", ErrNotFound, name)
 		}
 		return nil, fmt.Errorf("failed to read soldati file: %w", err)
 	}
@@ -120,6 +128,20 @@ func (m *Manager) Get(name string) (*models.Soldati, error) {
 	return &soldati, nil
 }
 
+// Fingerprint returns a hash of a soldati's TOML file, which changes whenever
+// the definition is edited
+func (m *Manager) Fingerprint(name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(m.dir, name+".toml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %q", ErrNotFound, name)
+		}
+		return "", fmt.Errorf("failed to read soldati file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // List returns all soldati
 func (m *Manager) List() ([]*models.Soldati, error) {
 	names, err := m.listNames()
@@ -154,7 +176,7 @@ func (m *Manager) Delete(name string) error {
 
 	if err := os.Remove(filePath); err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("soldati %q not found", name)
+			return fmt.Errorf("%w: %q", ErrNotFound, name)
 		}
 		return fmt.Errorf("failed to delete soldati: %w", err)
 	}
@@ -171,7 +193,7 @@ func (m *Manager) createNew(soldati *models.Soldati) error {
 	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
 		if os.IsExist(err) {
-			return fmt.Errorf("soldati %q already exists", soldati.Name)
+			return fmt.Errorf("%w: %q", ErrExists, soldati.Name)
 		}
 		return fmt.Errorf("failed to create soldati file: %w", err)
 	}
@@ -282,6 +304,17 @@ func (m *Manager) SetPrimaryTurf(name, turf string) error {
 	return m.Update(soldati)
 }
 
+// SetSkills replaces a soldati's skills, lowercasing them and dropping blanks
+// and repeats
+func (m *Manager) SetSkills(name string, skills []string) error {
+	soldati, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+	soldati.Skills = models.ParseLabels(strings.Join(skills, ","))
+	return m.Update(soldati)
+}
+
 // ListByTurf returns all soldati assigned to a specific turf (or all turfs if empty)
 func (m *Manager) ListByTurf(turf string) ([]*models.Soldati, error) {
 	all, err := m.List()
@@ -1,9 +1,11 @@
 package soldati
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -354,3 +356,69 @@ func TestSoldatiManager_CreateDuplicate(t *testing.T) {
 		t.Error("expected error creating duplicate soldati, got nil")
 	}
 }
+
+func TestSoldatiManager_Fingerprint(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	mgr, err := NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	s, err := mgr.Create("vinnie")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	before, err := mgr.Fingerprint("vinnie")
+	if err != nil {
+		t.Fatalf("Fingerprint failed: %v", err)
+	}
+
+	s.Model = "opus"
+	if err := mgr.Update(s); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	after, err := mgr.Fingerprint("vinnie")
+	if err != nil {
+		t.Fatalf("Fingerprint failed: %v", err)
+	}
+	if before == after {
+		t.Error("expected fingerprint to change after editing the definition")
+	}
+
+	if _, err := mgr.Fingerprint("nobody"); err == nil {
+		t.Error("expected error fingerprinting a missing soldati")
+	}
+}
+
+func TestSoldatiManager_SetSkills(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if _, err := mgr.Create("vinnie"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := mgr.SetSkills("vinnie", []string{"Frontend", " rust", "frontend,css", ""}); err != nil {
+		t.Fatalf("SetSkills failed: %v", err)
+	}
+	s, err := mgr.Get("vinnie")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if strings.Join(s.Skills, ",") != "frontend,rust,css" {
+		t.Errorf("expected normalized skills, got %v", s.Skills)
+	}
+
+	if err := mgr.SetSkills("vinnie", nil); err != nil {
+		t.Fatalf("SetSkills failed: %v", err)
+	}
+	if s, _ := mgr.Get("vinnie"); len(s.Skills) != 0 {
+		t.Errorf("expected skills cleared, got %v", s.Skills)
+	}
+
+	if err := mgr.SetSkills("nobody", []string{"go"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing soldati, got %v", err)
+	}
+}
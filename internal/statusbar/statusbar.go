@@ -0,0 +1,157 @@
+// Package statusbar produces the tiny mob summary shown in tmux status
+// lines and desktop widgets. The daemon refreshes a snapshot every few
+// seconds so polling bars never have to scan the bead store themselves.
+package statusbar
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// Summary is what a status bar shows
+type Summary struct {
+	UpdatedAt        time.Time `json:"updated_at"`
+	DaemonRunning    bool      `json:"daemon_running"`
+	AgentsActive     int       `json:"agents_active"`
+	AgentsTotal      int       `json:"agents_total"`
+	BeadsInProgress  int       `json:"beads_in_progress"`
+	BeadsOpen        int       `json:"beads_open"`
+	ApprovalsPending int       `json:"approvals_pending"`
+	CostToday        float64   `json:"cost_today_usd"`
+}
+
+// Path returns the snapshot the daemon keeps (~/mob/.mob/statusbar.json)
+func Path(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "statusbar.json")
+}
+
+// Collect builds a summary from the registry, bead store and spend ledger
+func Collect(mobDir string, beads *storage.BeadStore) Summary {
+	s := Summary{UpdatedAt: time.Now()}
+
+	if agents, err := registry.New(registry.DefaultPath(mobDir)).List(); err == nil {
+		s.AgentsTotal = len(agents)
+		for _, a := range agents {
+			if a.Status == registry.StatusActive || a.Status == registry.StatusWorking {
+				s.AgentsActive++
+			}
+		}
+	}
+
+	if beads != nil {
+		if all, err := beads.List(storage.BeadFilter{}); err == nil {
+			for _, b := range all {
+				switch b.Status {
+				case models.BeadStatusInProgress:
+					s.BeadsInProgress++
+				case models.BeadStatusOpen:
+					s.BeadsOpen++
+				case models.BeadStatusPendingApproval:
+					s.ApprovalsPending++
+				}
+			}
+		}
+	}
+
+	s.CostToday, _ = storage.SpendOn(storage.SpendPath(mobDir), s.UpdatedAt)
+	return s
+}
+
+// Save writes a snapshot atomically
+func Save(path string, s Summary) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads a snapshot
+func Load(path string) (Summary, error) {
+	var s Summary
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+// Oneline renders the summary for a tmux status line
+func Oneline(s Summary) string {
+	parts := []string{
+		fmt.Sprintf("⚙ %d/%d", s.AgentsActive, s.AgentsTotal),
+		fmt.Sprintf("▶ %d", s.BeadsInProgress),
+	}
+	if s.ApprovalsPending > 0 {
+		parts = append(parts, fmt.Sprintf("✋ %d", s.ApprovalsPending))
+	}
+	parts = append(parts, fmt.Sprintf("$%.2f", s.CostToday))
+	if !s.DaemonRunning {
+		parts = append(parts, "daemon down")
+	}
+	return "mob " + strings.Join(parts, " ")
+}
+
+// Prometheus renders the summary in the Prometheus text exposition format,
+// for node_exporter's textfile collector and the like
+func Prometheus(s Summary) string {
+	var b strings.Builder
+	gauge := func(name, help string, v float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", name, help, name, name, v)
+	}
+	running := 0.0
+	if s.DaemonRunning {
+		running = 1
+	}
+	gauge("mob_daemon_up", "Whether the mob daemon is running.", running)
+	gauge("mob_agents_active", "Agents currently working.", float64(s.AgentsActive))
+	gauge("mob_agents_total", "Agents in the registry.", float64(s.AgentsTotal))
+	gauge("mob_beads_in_progress", "Beads in progress.", float64(s.BeadsInProgress))
+	gauge("mob_beads_open", "Open beads waiting for work.", float64(s.BeadsOpen))
+	gauge("mob_approvals_pending", "Beads waiting for plan approval.", float64(s.ApprovalsPending))
+	gauge("mob_cost_today_usd", "Agent spend since local midnight, in USD.", s.CostToday)
+	return b.String()
+}
+
+// waybarOutput is Waybar's custom module JSON
+type waybarOutput struct {
+	Text    string `json:"text"`
+	Tooltip string `json:"tooltip"`
+	Class   string `json:"class"`
+}
+
+// Waybar renders the summary as a Waybar custom module line
+func Waybar(s Summary) string {
+	class := "idle"
+	switch {
+	case !s.DaemonRunning:
+		class = "down"
+	case s.ApprovalsPending > 0:
+		class = "attention"
+	case s.AgentsActive > 0:
+		class = "busy"
+	}
+	data, _ := json.Marshal(waybarOutput{
+		Text: strings.TrimPrefix(Oneline(s), "mob "),
+		Tooltip: fmt.Sprintf("Agents: %d active of %d\nBeads: %d in progress, %d open\nApprovals pending: %d\nCost today: $%.2f",
+			s.AgentsActive, s.AgentsTotal, s.BeadsInProgress, s.BeadsOpen, s.ApprovalsPending, s.CostToday),
+		Class: class,
+	})
+	return string(data)
+}
@@ -0,0 +1,81 @@
+package statusbar
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func TestCollect(t *testing.T) {
+	mobDir := t.TempDir()
+	reg := registry.New(registry.DefaultPath(mobDir))
+	for i, status := range []registry.Status{registry.StatusActive, registry.StatusIdle, registry.StatusWorking} {
+		if err := reg.Register(&registry.AgentRecord{ID: string(rune('a' + i)), Status: status}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	beads, err := storage.NewBeadStore(filepath.Join(mobDir, ".mob", "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range []models.BeadStatus{models.BeadStatusInProgress, models.BeadStatusPendingApproval, models.BeadStatusOpen, models.BeadStatusOpen} {
+		if _, err := beads.Create(&models.Bead{Title: "x", Status: status}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storage.AddSpend(storage.SpendPath(mobDir), time.Now(), 1.25); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.AddSpend(storage.SpendPath(mobDir), time.Now().AddDate(0, 0, -1), 9); err != nil {
+		t.Fatal(err)
+	}
+
+	s := Collect(mobDir, beads)
+	if s.AgentsActive != 2 || s.AgentsTotal != 3 {
+		t.Errorf("agents = %d/%d, want 2/3", s.AgentsActive, s.AgentsTotal)
+	}
+	if s.BeadsInProgress != 1 || s.BeadsOpen != 2 || s.ApprovalsPending != 1 {
+		t.Errorf("beads = %+v", s)
+	}
+	if s.CostToday != 1.25 {
+		t.Errorf("cost today = %v, want 1.25 (yesterday excluded)", s.CostToday)
+	}
+
+	path := Path(mobDir)
+	if err := Save(path, s); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil || loaded.BeadsOpen != 2 {
+		t.Errorf("round trip: %+v, %v", loaded, err)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	s := Summary{DaemonRunning: true, AgentsActive: 2, AgentsTotal: 3, BeadsInProgress: 4, ApprovalsPending: 1, CostToday: 3.5}
+
+	if got := Oneline(s); got != "mob ⚙ 2/3 ▶ 4 ✋ 1 $3.50" {
+		t.Errorf("Oneline = %q", got)
+	}
+
+	prom := Prometheus(s)
+	for _, line := range []string{"mob_daemon_up 1", "mob_beads_in_progress 4", "mob_cost_today_usd 3.5", "# TYPE mob_agents_active gauge"} {
+		if !strings.Contains(prom, line+"\n") {
+			t.Errorf("Prometheus output missing %q:\n%s", line, prom)
+		}
+	}
+
+	var w waybarOutput
+	if err := json.Unmarshal([]byte(Waybar(s)), &w); err != nil {
+		t.Fatal(err)
+	}
+	if w.Class != "attention" || !strings.HasPrefix(w.Text, "⚙ 2/3") {
+		t.Errorf("Waybar = %+v", w)
+	}
+}
@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// DefaultActivityRetention is how many feed entries Prune keeps by default
+const DefaultActivityRetention = 5000
+
+// ActivityStore is the append-only JSONL activity feed shared by every mob
+// process
+type ActivityStore struct {
+	dir      string
+	openFile string
+	mu       sync.RWMutex
+}
+
+// ActivityFilter defines filtering options for reading the feed
+type ActivityFilter struct {
+	Since  time.Time             // only entries after this time
+	Types  []models.ActivityType // any of these types; empty = all
+	BeadID string
+	Agent  string // matches either the actor or the agent the entry is about
+	Turf   string
+	Limit  int // most recent N entries after filtering; 0 = all
+}
+
+// NewActivityStore creates an activity feed at the given directory
+func NewActivityStore(dir string) (*ActivityStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create activity directory: %w", err)
+	}
+
+	return &ActivityStore{
+		dir:      dir,
+		openFile: filepath.Join(dir, "activity.jsonl"),
+	}, nil
+}
+
+// ActivityDir returns where the activity feed lives in a mob directory
+func ActivityDir(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "activity")
+}
+
+// generateActivityID creates a random ID for feed entries
+func generateActivityID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random ID: %w", err)
+	}
+	return "act-" + hex.EncodeToString(b), nil
+}
+
+// Record appends an entry to the feed, stamping its ID and time
+func (s *ActivityStore) Record(a *models.Activity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.openFile + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	id, err := generateActivityID()
+	if err != nil {
+		return err
+	}
+	a.ID = id
+	if a.Timestamp.IsZero() {
+		a.Timestamp = time.Now()
+	}
+
+	f, err := os.OpenFile(s.openFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// List returns feed entries matching the filter, oldest first
+func (s *ActivityStore) List(filter ActivityFilter) ([]*models.Activity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*models.Activity
+	for _, a := range entries {
+		if !filter.Since.IsZero() && !a.Timestamp.After(filter.Since) {
+			continue
+		}
+		if len(filter.Types) > 0 && !containsActivityType(filter.Types, a.Type) {
+			continue
+		}
+		if filter.BeadID != "" && a.BeadID != filter.BeadID {
+			continue
+		}
+		if filter.Agent != "" && a.Agent != filter.Agent && a.Actor != filter.Agent {
+			continue
+		}
+		if filter.Turf != "" && a.Turf != filter.Turf {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+
+	if filter.Limit > 0 && len(filtered) > filter.Limit {
+		filtered = filtered[len(filtered)-filter.Limit:]
+	}
+	return filtered, nil
+}
+
+// Prune drops all but the newest keep entries and returns how many were removed
+func (s *ActivityStore) Prune(keep int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.openFile + ".lock")
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	entries, err := s.readAll()
+	if err != nil || len(entries) <= keep {
+		return 0, err
+	}
+	removed := len(entries) - keep
+	return removed, s.writeAll(entries[removed:])
+}
+
+func containsActivityType(types []models.ActivityType, t models.ActivityType) bool {
+	for _, want := range types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ActivityStore) readAll() ([]*models.Activity, error) {
+	f, err := os.Open(s.openFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*models.Activity
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var a models.Activity
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			continue // Skip malformed lines
+		}
+		entries = append(entries, &a)
+	}
+
+	return entries, scanner.Err()
+}
+
+func (s *ActivityStore) writeAll(entries []*models.Activity) error {
+	tmpFile := s.openFile + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for _, a := range entries {
+		data, err := json.Marshal(a)
+		if err != nil {
+			f.Close()
+			os.Remove(tmpFile)
+			return err
+		}
+		w.Write(append(data, '\n'))
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmpFile)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+
+	return os.Rename(tmpFile, s.openFile)
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestActivityStore_RecordAndList(t *testing.T) {
+	store, err := NewActivityStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Now().Add(-time.Hour)
+	entries := []models.Activity{
+		{Timestamp: base, Type: models.ActivityAgentSpawned, Agent: "vinnie", Message: "spawned"},
+		{Timestamp: base.Add(time.Minute), Type: models.ActivityBeadStatus, BeadID: "bd-a1b2", Actor: "vinnie", Message: "in progress"},
+		{Timestamp: base.Add(2 * time.Minute), Type: models.ActivityMergeLanded, BeadID: "bd-a1b2", Turf: "api", Message: "merged"},
+	}
+	for i := range entries {
+		if err := store.Record(&entries[i]); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+		if entries[i].ID == "" {
+			t.Error("expected ID to be assigned")
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter ActivityFilter
+		want   []string
+	}{
+		{"all", ActivityFilter{}, []string{"spawned", "in progress", "merged"}},
+		{"limit keeps newest", ActivityFilter{Limit: 2}, []string{"in progress", "merged"}},
+		{"bead", ActivityFilter{BeadID: "bd-a1b2"}, []string{"in progress", "merged"}},
+		{"agent matches actor or subject", ActivityFilter{Agent: "vinnie"}, []string{"spawned", "in progress"}},
+		{"types", ActivityFilter{Types: []models.ActivityType{models.ActivityMergeLanded}}, []string{"merged"}},
+		{"since", ActivityFilter{Since: base.Add(30 * time.Second)}, []string{"in progress", "merged"}},
+		{"turf", ActivityFilter{Turf: "api"}, []string{"merged"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.List(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d", len(got), len(tt.want))
+			}
+			for i, a := range got {
+				if a.Message != tt.want[i] {
+					t.Errorf("entry %d = %q, want %q", i, a.Message, tt.want[i])
+				}
+			}
+		})
+	}
+
+	removed, err := store.Prune(1)
+	if err != nil || removed != 2 {
+		t.Fatalf("Prune = %d, %v; want 2, nil", removed, err)
+	}
+	if got, _ := store.List(ActivityFilter{}); len(got) != 1 || got[0].Message != "merged" {
+		t.Errorf("expected only the newest entry kept, got %+v", got)
+	}
+}
+
+func TestBeadStore_MirrorsActivity(t *testing.T) {
+	dir := t.TempDir()
+	beads, err := NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed, err := NewActivityStore(filepath.Join(dir, "activity"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	beads.SetActivity(feed)
+
+	bead, err := beads.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusOpen, Type: models.BeadTypeBug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead.Status = models.BeadStatusInProgress
+	bead.Assignee = "vinnie"
+	if _, err := beads.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+	if err := beads.AddComment(bead.ID, "vinnie", "Found the bug"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := feed.List(ActivityFilter{BeadID: bead.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []models.ActivityType{models.ActivityBeadCreated, models.ActivityBeadStatus, models.ActivityBeadAssigned, models.ActivityBeadComment}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i, a := range got {
+		if a.Type != want[i] {
+			t.Errorf("entry %d type = %s, want %s", i, a.Type, want[i])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// AttachmentDir returns where a bead's attachments live
+// (~/mob/.mob/attachments/<bead-id>)
+func AttachmentDir(mobDir, beadID string) string {
+	return filepath.Join(mobDir, ".mob", "attachments", beadID)
+}
+
+// WriteAttachment saves data as a file for the bead and returns the
+// attachment to record on it. A timestamp keeps repeated captures apart.
+func WriteAttachment(mobDir, beadID, name string, data []byte) (models.Attachment, error) {
+	now := time.Now()
+	dir := AttachmentDir(mobDir, beadID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return models.Attachment{}, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+
+	ext := filepath.Ext(name)
+	file := fmt.Sprintf("%s-%s%s", name[:len(name)-len(ext)], now.Format("20060102-150405"), ext)
+	path := filepath.Join(dir, file)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return models.Attachment{}, fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return models.Attachment{Name: name, Path: path, CreatedAt: now}, nil
+}
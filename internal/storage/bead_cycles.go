@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// CycleError reports blocks links that loop back on themselves. Each bead in
+// Cycle blocks the next, and the last blocks the first.
+type CycleError struct {
+	Cycle []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%s: %s blocks %s", ErrDependencyCycle, strings.Join(e.Cycle, " blocks "), e.Cycle[0])
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrDependencyCycle
+}
+
+// checkCycle returns a CycleError when bead's blocks links, among beads,
+// lead back to bead. Cycles that do not pass through bead are left to
+// FindCycles, so existing bad links do not block unrelated edits.
+func checkCycle(beads []*models.Bead, bead *models.Bead) error {
+	if len(bead.Blocks) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.Bead, len(beads))
+	for _, b := range beads {
+		byID[b.ID] = b
+	}
+	byID[bead.ID] = bead
+
+	// Depth-first from bead, keeping the path so the loop can be reported
+	visited := make(map[string]bool)
+	var path []string
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		path = append(path, id)
+		defer func() { path = path[:len(path)-1] }()
+		b := byID[id]
+		if b == nil {
+			return nil
+		}
+		for _, next := range b.Blocks {
+			if next == bead.ID {
+				return append([]string(nil), path...)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if cycle := walk(next); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	if cycle := walk(bead.ID); cycle != nil {
+		return &CycleError{Cycle: cycle}
+	}
+	return nil
+}
+
+// FindCycles returns the dependency cycles among beads, at least one for
+// every tangle of looping links, each starting at its lowest bead ID
+func FindCycles(beads []*models.Bead) [][]string {
+	byID := make(map[string]*models.Bead, len(beads))
+	for _, b := range beads {
+		byID[b.ID] = b
+	}
+
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int, len(beads))
+	seen := make(map[string]bool)
+	var cycles [][]string
+	var path []string
+
+	var walk func(id string)
+	walk = func(id string) {
+		state[id] = onPath
+		path = append(path, id)
+		if b := byID[id]; b != nil {
+			for _, next := range b.Blocks {
+				switch state[next] {
+				case onPath:
+					// A link back into the path closes a loop
+					start := len(path) - 1
+					for path[start] != next {
+						start--
+					}
+					cycle := rotateToLowest(path[start:])
+					if key := strings.Join(cycle, ","); !seen[key] {
+						seen[key] = true
+						cycles = append(cycles, cycle)
+					}
+				case unvisited:
+					if byID[next] != nil {
+						walk(next)
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+	}
+
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			walk(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return strings.Join(cycles[i], ",") < strings.Join(cycles[j], ",")
+	})
+	return cycles
+}
+
+// rotateToLowest copies a cycle so it starts at its lowest ID
+func rotateToLowest(cycle []string) []string {
+	low := 0
+	for i, id := range cycle {
+		if id < cycle[low] {
+			low = i
+		}
+	}
+	return append(append([]string(nil), cycle[low:]...), cycle[:low]...)
+}
+
+// DanglingLink is a blocks link to a bead that does not exist
+type DanglingLink struct {
+	BeadID  string
+	Missing string
+}
+
+// FindDanglingLinks returns blocks links to beads that do not exist. They
+// block nothing, but usually mean a typo or a deleted bead.
+func FindDanglingLinks(beads []*models.Bead) []DanglingLink {
+	exists := make(map[string]bool, len(beads))
+	for _, b := range beads {
+		exists[b.ID] = true
+	}
+	var dangling []DanglingLink
+	for _, b := range beads {
+		for _, id := range b.Blocks {
+			if !exists[id] {
+				dangling = append(dangling, DanglingLink{BeadID: b.ID, Missing: id})
+			}
+		}
+	}
+	return dangling
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestUpdate_RejectsDependencyCycle(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	create := func(title string) *models.Bead {
+		b, err := store.Create(&models.Bead{Title: title, Status: models.BeadStatusOpen})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	a, b, c := create("a"), create("b"), create("c")
+
+	a.Blocks = []string{b.ID}
+	if _, err := store.Update(a); err != nil {
+		t.Fatal(err)
+	}
+	b.Blocks = []string{c.ID}
+	if _, err := store.Update(b); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Blocks = []string{a.ID}
+	_, err = store.Update(c)
+	var cycle *CycleError
+	if !errors.As(err, &cycle) || !errors.Is(err, ErrDependencyCycle) || !errors.Is(err, errkind.Invalid) {
+		t.Fatalf("expected a cycle error, got %v", err)
+	}
+	if want := []string{c.ID, a.ID, b.ID}; !reflect.DeepEqual(cycle.Cycle, want) {
+		t.Errorf("cycle = %v, want %v", cycle.Cycle, want)
+	}
+	if got, _ := store.Get(c.ID); len(got.Blocks) != 0 {
+		t.Errorf("expected the rejected link not saved, got %v", got.Blocks)
+	}
+
+	c.Blocks = []string{c.ID}
+	if _, err := store.Update(c); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expected a bead blocking itself rejected, got %v", err)
+	}
+
+	// Ready work is unaffected by the rejected links
+	ready, _ := store.ListReady("")
+	if len(ready) != 1 || ready[0].ID != a.ID {
+		t.Errorf("expected only %s ready, got %v", a.ID, ready)
+	}
+}
+
+func TestFindCycles(t *testing.T) {
+	beads := []*models.Bead{
+		{ID: "bd-0003", Blocks: []string{"bd-0001"}},
+		{ID: "bd-0001", Blocks: []string{"bd-0002"}},
+		{ID: "bd-0002", Blocks: []string{"bd-0003", "bd-0004"}},
+		{ID: "bd-0004", Blocks: []string{"bd-9999"}},
+		{ID: "bd-0005", Blocks: []string{"bd-0005"}},
+	}
+	want := [][]string{{"bd-0001", "bd-0002", "bd-0003"}, {"bd-0005"}}
+	if got := FindCycles(beads); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindCycles = %v, want %v", got, want)
+	}
+	if got := FindCycles(beads[3:4]); len(got) != 0 {
+		t.Errorf("expected no cycles, got %v", got)
+	}
+
+	dangling := FindDanglingLinks(beads)
+	if len(dangling) != 1 || dangling[0] != (DanglingLink{BeadID: "bd-0004", Missing: "bd-9999"}) {
+		t.Errorf("FindDanglingLinks = %v", dangling)
+	}
+}
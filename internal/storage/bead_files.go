@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// Beads are kept one file per turf, <dir>/<turf>/open.jsonl, so reading one
+// turf's beads never has to wade through another's, and a turf can be
+// backed up or moved by copying its directory. index.json maps every bead
+// ID to its turf, so a lookup by ID reads a single file.
+const (
+	beadsFile     = "open.jsonl"
+	beadIndexFile = "index.json"
+	noTurfDir     = "_unassigned" // beads with no turf
+)
+
+// maxBeadLine caps one bead's line in its turf file. A bead carries its
+// whole history, chat replies, work summaries and review notes included, so
+// lines grow far past bufio.Scanner's 64KB default.
+const maxBeadLine = 16 << 20
+
+// turfDir names the directory a turf's beads live in. Turfs that are not
+// safe as a single path element, such as turf paths, have the offending
+// characters replaced and a hash of the full name appended so they cannot
+// collide.
+func turfDir(turf string) string {
+	if turf == "" {
+		return noTurfDir
+	}
+	clean := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, turf), "-.")
+	if clean == turf && !strings.HasPrefix(clean, "_") {
+		return clean
+	}
+	if clean == "" {
+		clean = "turf"
+	}
+	h := fnv.New32a()
+	h.Write([]byte(turf))
+	return fmt.Sprintf("%s-%08x", clean, h.Sum32())
+}
+
+func (s *BeadStore) turfFile(turf string) string {
+	return filepath.Join(s.dir, turfDir(turf), beadsFile)
+}
+
+// readTurf reads one turf's beads
+func (s *BeadStore) readTurf(turf string) ([]*models.Bead, error) {
+	return readBeadFile(s.turfFile(turf))
+}
+
+// readAllBeads reads every turf's beads, oldest first
+func (s *BeadStore) readAllBeads() ([]*models.Bead, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var beads []*models.Bead
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		turfBeads, err := readBeadFile(filepath.Join(s.dir, e.Name(), beadsFile))
+		if err != nil {
+			return nil, err
+		}
+		beads = append(beads, turfBeads...)
+	}
+	sort.SliceStable(beads, func(i, j int) bool {
+		return beads[i].CreatedAt.Before(beads[j].CreatedAt)
+	})
+	return beads, nil
+}
+
+func readBeadFile(path string) ([]*models.Bead, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var beads []*models.Bead
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBeadLine)
+	for scanner.Scan() {
+		var bead models.Bead
+		if err := json.Unmarshal(scanner.Bytes(), &bead); err != nil {
+			continue // Skip malformed lines
+		}
+		beads = append(beads, &bead)
+	}
+
+	return beads, scanner.Err()
+}
+
+// appendBead adds a new bead to the end of its turf's file
+func (s *BeadStore) appendBead(bead *models.Bead) error {
+	path := s.turfFile(bead.Turf)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(bead)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	// A missing or unreadable index is rebuilt by the next full write;
+	// until then lookups fall back to reading every turf
+	index, _ := s.readIndex()
+	if index == nil {
+		index = make(map[string]string)
+	}
+	index[bead.ID] = bead.Turf
+	return s.writeIndex(index)
+}
+
+// writeAllBeads saves beads into their turfs' files. Only the files whose
+// beads changed are rewritten, and a turf left with no beads has its file
+// removed.
+func (s *BeadStore) writeAllBeads(beads []*models.Bead) error {
+	byDir := make(map[string][]*models.Bead)
+	index := make(map[string]string, len(beads))
+	for _, b := range beads {
+		dir := turfDir(b.Turf)
+		byDir[dir] = append(byDir[dir], b)
+		index[b.ID] = b.Turf
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, e := range entries {
+		if _, ok := byDir[e.Name()]; ok || !e.IsDir() {
+			continue
+		}
+		path := filepath.Join(s.dir, e.Name(), beadsFile)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		os.Remove(filepath.Dir(path)) // only succeeds if nothing else is in there
+	}
+
+	for dir, turfBeads := range byDir {
+		var buf bytes.Buffer
+		for _, bead := range turfBeads {
+			data, err := json.Marshal(bead)
+			if err != nil {
+				return err
+			}
+			buf.Write(append(data, '\n'))
+		}
+		if err := replaceFile(filepath.Join(s.dir, dir, beadsFile), buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return s.writeIndex(index)
+}
+
+// readIndex reads the bead ID → turf index, nil if there is none yet
+func (s *BeadStore) readIndex() (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, beadIndexFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var index map[string]string
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (s *BeadStore) writeIndex(index map[string]string) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return replaceFile(filepath.Join(s.dir, beadIndexFile), append(data, '\n'))
+}
+
+// replaceFile atomically replaces path with data, leaving it untouched if
+// it already holds exactly that
+func replaceFile(path string, data []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to temp file first
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	return os.Rename(tmpFile, path)
+}
+
+// migrateSingleFile moves a store from the old layout, every bead in one
+// open.jsonl, into per-turf files. The old file is kept alongside as
+// open.jsonl.migrated.
+func (s *BeadStore) migrateSingleFile() error {
+	legacy := filepath.Join(s.dir, beadsFile)
+	if _, err := os.Stat(legacy); os.IsNotExist(err) {
+		return nil
+	}
+
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Another process may have migrated it while we waited for the lock
+	if _, err := os.Stat(legacy); os.IsNotExist(err) {
+		return nil
+	}
+	old, err := readBeadFile(legacy)
+	if err != nil {
+		return err
+	}
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return err
+	}
+	if err := s.writeAllBeads(append(beads, old...)); err != nil {
+		return err
+	}
+	return os.Rename(legacy, legacy+".migrated")
+}
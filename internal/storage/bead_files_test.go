@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestTurfDir(t *testing.T) {
+	if got := turfDir(""); got != noTurfDir {
+		t.Errorf("turfDir(\"\") = %q, want %q", got, noTurfDir)
+	}
+	if got := turfDir("backend"); got != "backend" {
+		t.Errorf("turfDir(backend) = %q, want it unchanged", got)
+	}
+
+	path := turfDir("/home/gabe/src/api")
+	if strings.ContainsRune(path, filepath.Separator) || !strings.HasPrefix(path, "home-gabe-src-api-") {
+		t.Errorf("turfDir of a path = %q", path)
+	}
+	if turfDir("a/b") == turfDir("a-b") {
+		t.Error("turfs differing only in unsafe characters share a directory")
+	}
+	if turfDir(noTurfDir) == noTurfDir {
+		t.Error("a turf named like the no-turf directory shares it")
+	}
+}
+
+func TestBeadStore_PerTurfFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewBeadStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api, _ := store.Create(&models.Bead{Title: "API", Turf: "api", Status: models.BeadStatusOpen})
+	web, _ := store.Create(&models.Bead{Title: "Web", Turf: "web", Status: models.BeadStatusOpen})
+	loose, _ := store.Create(&models.Bead{Title: "Loose", Status: models.BeadStatusOpen})
+
+	for turf, want := range map[string]string{"api": api.ID, "web": web.ID, noTurfDir: loose.ID} {
+		beads, err := readBeadFile(filepath.Join(dir, turf, beadsFile))
+		if err != nil || len(beads) != 1 || beads[0].ID != want {
+			t.Errorf("%s/%s holds %v (err %v), want only %s", turf, beadsFile, beads, err, want)
+		}
+	}
+
+	var index map[string]string
+	data, err := os.ReadFile(filepath.Join(dir, beadIndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatal(err)
+	}
+	if index[api.ID] != "api" || index[web.ID] != "web" || index[loose.ID] != "" || len(index) != 3 {
+		t.Errorf("index = %v", index)
+	}
+
+	// Moving a bead to another turf moves it between files
+	api.Turf = "web"
+	if _, err := store.Update(api); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "api", beadsFile)); !os.IsNotExist(err) {
+		t.Errorf("emptied turf's file still there (err %v)", err)
+	}
+	webBeads, _ := store.List(BeadFilter{Turf: "web"})
+	if len(webBeads) != 2 {
+		t.Errorf("web has %d beads after the move, want 2", len(webBeads))
+	}
+	if got, err := store.Get(api.ID); err != nil || got.Turf != "web" {
+		t.Errorf("Get after the move = %v, %v", got, err)
+	}
+}
+
+func TestBeadStore_ListTurfReadsOnlyThatTurf(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewBeadStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, _ := store.Create(&models.Bead{Title: "API", Turf: "api", Status: models.BeadStatusOpen})
+	store.Create(&models.Bead{Title: "Web", Turf: "web", Status: models.BeadStatusOpen})
+
+	// Make web's file unreadable: anything that touches it fails
+	webFile := filepath.Join(dir, "web", beadsFile)
+	os.Remove(webFile)
+	if err := os.Mkdir(webFile, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.List(BeadFilter{}); err == nil {
+		t.Fatal("listing every turf should have hit web's file")
+	}
+
+	beads, err := store.List(BeadFilter{Turf: "api"})
+	if err != nil || len(beads) != 1 || beads[0].ID != api.ID {
+		t.Fatalf("List(api) = %v, %v", beads, err)
+	}
+	if got, err := store.Get(api.ID); err != nil || got.ID != api.ID {
+		t.Fatalf("Get(%s) = %v, %v; the index should point it at api only", api.ID, got, err)
+	}
+}
+
+func TestBeadStore_MigratesSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	var lines []string
+	for _, b := range []*models.Bead{
+		{ID: "bd-0001", Title: "One", Turf: "api", Status: models.BeadStatusOpen},
+		{ID: "bd-0002", Title: "Two", Turf: "/src/web", Status: models.BeadStatusClosed},
+		{ID: "bd-0003", Title: "Three", Status: models.BeadStatusOpen},
+	} {
+		data, _ := json.Marshal(b)
+		lines = append(lines, string(data))
+	}
+	legacy := filepath.Join(dir, beadsFile)
+	if err := os.WriteFile(legacy, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewBeadStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("old open.jsonl still in place (err %v)", err)
+	}
+	if _, err := os.Stat(legacy + ".migrated"); err != nil {
+		t.Errorf("old open.jsonl not kept: %v", err)
+	}
+
+	all, err := store.List(BeadFilter{})
+	if err != nil || len(all) != 3 {
+		t.Fatalf("List = %d beads, %v; want 3", len(all), err)
+	}
+	web, err := store.List(BeadFilter{Turf: "/src/web"})
+	if err != nil || len(web) != 1 || web[0].ID != "bd-0002" {
+		t.Errorf("List(/src/web) = %v, %v", web, err)
+	}
+	if b, err := store.Get("bd-0003"); err != nil || b.Title != "Three" {
+		t.Errorf("Get(bd-0003) = %v, %v", b, err)
+	}
+
+	// Opening it again leaves it alone
+	if _, err := NewBeadStore(dir); err != nil {
+		t.Fatal(err)
+	}
+	if all, _ := store.List(BeadFilter{}); len(all) != 3 {
+		t.Errorf("reopening changed the store: %d beads", len(all))
+	}
+}
+
+func TestBeadStore_ReadsBeadsOverTheScannerDefault(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Chatty", Turf: "api", Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := strings.Repeat("a long agent reply ", 10000) // ~190KB, past the 64KB default
+	if err := store.AddComment(bead.ID, "vinnie", reply); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Get(bead.ID)
+	if err != nil {
+		t.Fatalf("reading the bead back: %v", err)
+	}
+	if last := got.History[len(got.History)-1]; last.Comment != reply {
+		t.Errorf("comment read back with %d bytes, want %d", len(last.Comment), len(reply))
+	}
+	if _, err := store.List(BeadFilter{Turf: "api"}); err != nil {
+		t.Errorf("listing the turf: %v", err)
+	}
+}
@@ -1,14 +1,14 @@
 package storage
 
 import (
-	"bufio"
 	"crypto/rand"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,7 +18,8 @@ import (
 // BeadStore manages JSONL-based bead storage
 type BeadStore struct {
 	dir      string
-	openFile string
+	lock     string         // held by every process writing the store
+	activity *ActivityStore // feed that bead changes are mirrored to, if set
 	mu       sync.RWMutex
 }
 
@@ -28,18 +29,84 @@ type BeadFilter struct {
 	Turf     string
 	Assignee string
 	Type     models.BeadType
+	Label    string
 }
 
-// NewBeadStore creates a new bead store at the given directory
+// Match reports whether a bead passes the filter
+func (f BeadFilter) Match(b *models.Bead) bool {
+	return (f.Status == "" || b.Status == f.Status) &&
+		(f.Turf == "" || b.Turf == f.Turf) &&
+		(f.Assignee == "" || b.Assignee == f.Assignee) &&
+		(f.Type == "" || b.Type == f.Type) &&
+		(f.Label == "" || b.HasLabel(f.Label))
+}
+
+// BeadsDir returns where a mob directory keeps its beads
+func BeadsDir(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "beads")
+}
+
+// NewBeadStore creates a new bead store at the given directory. A store
+// still in the old single-file layout is split into per-turf files.
 func NewBeadStore(dir string) (*BeadStore, error) {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create bead directory: %w", err)
 	}
 
-	return &BeadStore{
-		dir:      dir,
-		openFile: filepath.Join(dir, "open.jsonl"),
-	}, nil
+	s := &BeadStore{
+		dir:  dir,
+		lock: filepath.Join(dir, "beads.lock"),
+	}
+	if err := s.migrateSingleFile(); err != nil {
+		return nil, fmt.Errorf("failed to split beads by turf: %w", err)
+	}
+	return s, nil
+}
+
+// SetActivity mirrors bead creation, status, assignment and comment events
+// to the given activity feed
+func (s *BeadStore) SetActivity(a *ActivityStore) {
+	s.activity = a
+}
+
+// recordActivity mirrors a bead event to the activity feed. The feed is
+// best effort: a failure to record never fails the bead write.
+func (s *BeadStore) recordActivity(b *models.Bead, e models.BeadEvent) {
+	if s.activity == nil {
+		return
+	}
+
+	a := &models.Activity{
+		Timestamp: e.Timestamp,
+		Actor:     e.Actor,
+		BeadID:    b.ID,
+		Turf:      b.Turf,
+	}
+	switch e.Type {
+	case models.BeadEventTypeCreated:
+		a.Type = models.ActivityBeadCreated
+		a.Message = fmt.Sprintf("%s created: %s", b.ID, b.Title)
+	case models.BeadEventTypeStatusChange:
+		a.Type = models.ActivityBeadStatus
+		a.Message = fmt.Sprintf("%s %s → %s: %s", b.ID, e.From, e.To, b.Title)
+	case models.BeadEventTypeAssigned:
+		a.Type = models.ActivityBeadAssigned
+		a.Agent = e.To
+		a.Message = fmt.Sprintf("%s assigned to %s", b.ID, e.To)
+		if e.To == "" {
+			a.Message = fmt.Sprintf("%s unassigned", b.ID)
+		}
+	case models.BeadEventTypeComment:
+		a.Type = models.ActivityBeadComment
+		comment := []rune(e.Comment)
+		if len(comment) > 120 {
+			comment = append(comment[:117], []rune("...")...)
+		}
+		a.Message = fmt.Sprintf("%s commented on %s: %s", e.Actor, b.ID, string(comment))
+	default:
+		return
+	}
+	s.activity.Record(a)
 }
 
 // generateID creates a short random ID for beads
@@ -51,66 +118,257 @@ func generateID() (string, error) {
 	return "bd-" + hex.EncodeToString(b)[:4], nil
 }
 
-// Create adds a new bead to the store
+// Create adds a new bead to the store. A new bead cannot close a dependency
+// cycle: no other bead can list its fresh ID yet.
 func (s *BeadStore) Create(bead *models.Bead) (*models.Bead, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	id, err := generateID()
+	unlock, err := lockFile(s.lock)
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
+
+	if err := initBead(bead, models.BeadEvent{}); err != nil {
+		return nil, err
+	}
+
+	if err := s.appendBead(bead); err != nil {
+		return nil, err
+	}
+	s.recordActivity(bead, bead.History[0])
+	return bead, nil
+}
+
+// initBead assigns a new bead its ID, timestamps, branch and creation event.
+// created may carry a From and Comment describing where the bead came from.
+func initBead(bead *models.Bead, created models.BeadEvent) error {
+	id, err := generateID()
+	if err != nil {
+		return err
+	}
 	bead.ID = id
+	bead.Revision = 1
 	bead.CreatedAt = time.Now()
 	bead.UpdatedAt = time.Now()
 	bead.Branch = "mob/" + bead.ID
 
 	// Add creation event to history
-	createdEvent := models.BeadEvent{
-		Type:      models.BeadEventTypeCreated,
-		Actor:     bead.CreatedBy,
-		Timestamp: bead.CreatedAt,
-	}
+	created.Type = models.BeadEventTypeCreated
+	created.Actor = bead.CreatedBy
+	created.Timestamp = bead.CreatedAt
 	eventID, err := generateID()
 	if err == nil {
-		createdEvent.ID = eventID
+		created.ID = eventID
+	}
+
+	if created.Actor == "" {
+		created.Actor = "user"
+	}
+
+	bead.History = []models.BeadEvent{created}
+	return nil
+}
+
+// Split turns a bead into a parent of the given children. Each child takes
+// the parent's turf, priority, labels and type unless it sets its own, is
+// linked by ParentID and blocks the parent, so the parent only becomes ready
+// again once every child is closed. The parent becomes an epic and records a
+// split event. Everything is written at once.
+func (s *BeadStore) Split(parentID string, children []*models.Bead, actor string) ([]*models.Bead, error) {
+	if len(children) == 0 {
+		return nil, fmt.Errorf("split needs at least one child")
 	}
 
-	if createdEvent.Actor == "" {
-		createdEvent.Actor = "user"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return nil, err
 	}
+	defer unlock()
 
-	bead.History = []models.BeadEvent{createdEvent}
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
 
-	return bead, s.appendBead(bead)
+	var parent *models.Bead
+	for _, b := range beads {
+		if b.ID == parentID {
+			parent = b
+			break
+		}
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, parentID)
+	}
+	if parent.Status == models.BeadStatusClosed {
+		return nil, fmt.Errorf("%w: %s", ErrBeadClosed, parentID)
+	}
+
+	childType := parent.Type
+	if childType == models.BeadTypeEpic || childType == "" {
+		childType = models.BeadTypeTask
+	}
+
+	ids := make([]string, 0, len(children))
+	for _, child := range children {
+		if child.Title == "" {
+			return nil, fmt.Errorf("every child needs a title")
+		}
+		if child.Turf == "" {
+			child.Turf = parent.Turf
+		}
+		if len(child.Labels) == 0 {
+			child.Labels = append([]string(nil), parent.Labels...)
+		}
+		if child.Type == "" {
+			child.Type = childType
+		}
+		if child.Status == "" {
+			child.Status = models.BeadStatusOpen
+		}
+		child.Priority = parent.Priority
+		child.ParentID = parent.ID
+		child.Blocks = append(child.Blocks, parent.ID)
+		child.CreatedBy = actor
+		if err := initBead(child, models.BeadEvent{From: parent.ID, Comment: "Split from " + parent.ID}); err != nil {
+			return nil, err
+		}
+		ids = append(ids, child.ID)
+		beads = append(beads, child)
+	}
+
+	parent.Type = models.BeadTypeEpic
+	parent.UpdatedAt = time.Now()
+	parent.Revision++
+	splitEvent := models.BeadEvent{
+		Type:      models.BeadEventTypeSplit,
+		Actor:     actor,
+		To:        strings.Join(ids, ","),
+		Comment:   fmt.Sprintf("Split into %d beads", len(ids)),
+		Timestamp: parent.UpdatedAt,
+	}
+	if eventID, err := generateID(); err == nil {
+		splitEvent.ID = eventID
+	}
+	parent.History = append(parent.History, splitEvent)
+
+	if err := s.writeAllBeads(beads); err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		s.recordActivity(child, child.History[0])
+	}
+	return children, nil
+}
+
+// CloneOptions overrides fields of a cloned bead
+type CloneOptions struct {
+	Title string // defaults to the source title
+	Turf  string // defaults to the source turf
+	Actor string
 }
 
-// List returns all beads matching the filter
+// Clone creates a fresh open copy of a bead for recurring work. The copy
+// keeps the source's description, type, priority, labels, turf, parent and
+// checklist (unchecked), but none of its assignment, dependencies or
+// history. The source records a cloned event pointing at the copy.
+func (s *BeadStore) Clone(sourceID string, opts CloneOptions) (*models.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+
+	var source *models.Bead
+	for _, b := range beads {
+		if b.ID == sourceID {
+			source = b
+			break
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, sourceID)
+	}
+
+	clone := &models.Bead{
+		Title:       source.Title,
+		Description: source.Description,
+		Status:      models.BeadStatusOpen,
+		Priority:    source.Priority,
+		Type:        source.Type,
+		Labels:      append([]string(nil), source.Labels...),
+		Turf:        source.Turf,
+		ParentID:    source.ParentID,
+		CreatedBy:   opts.Actor,
+	}
+	if opts.Title != "" {
+		clone.Title = opts.Title
+	}
+	if opts.Turf != "" {
+		clone.Turf = opts.Turf
+	}
+	for _, item := range source.Checklist {
+		clone.Checklist = append(clone.Checklist, models.ChecklistItem{Text: item.Text})
+	}
+	if err := initBead(clone, models.BeadEvent{From: source.ID, Comment: "Cloned from " + source.ID}); err != nil {
+		return nil, err
+	}
+
+	source.UpdatedAt = time.Now()
+	source.Revision++
+	clonedEvent := models.BeadEvent{
+		Type:      models.BeadEventTypeCloned,
+		Actor:     clone.History[0].Actor,
+		To:        clone.ID,
+		Timestamp: source.UpdatedAt,
+	}
+	if eventID, err := generateID(); err == nil {
+		clonedEvent.ID = eventID
+	}
+	source.History = append(source.History, clonedEvent)
+
+	if err := s.writeAllBeads(append(beads, clone)); err != nil {
+		return nil, err
+	}
+	s.recordActivity(clone, clone.History[0])
+	return clone, nil
+}
+
+// List returns all beads matching the filter. A turf filter reads only
+// that turf's beads.
 func (s *BeadStore) List(filter BeadFilter) ([]*models.Bead, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	beads, err := s.readAllBeads()
+	var beads []*models.Bead
+	var err error
+	if filter.Turf != "" {
+		beads, err = s.readTurf(filter.Turf)
+	} else {
+		beads, err = s.readAllBeads()
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Apply filters
 	var filtered []*models.Bead
 	for _, bead := range beads {
-		if filter.Status != "" && bead.Status != filter.Status {
-			continue
+		if filter.Match(bead) {
+			filtered = append(filtered, bead)
 		}
-		if filter.Turf != "" && bead.Turf != filter.Turf {
-			continue
-		}
-		if filter.Assignee != "" && bead.Assignee != filter.Assignee {
-			continue
-		}
-		if filter.Type != "" && bead.Type != filter.Type {
-			continue
-		}
-		filtered = append(filtered, bead)
 	}
 
 	return filtered, nil
@@ -160,31 +418,69 @@ func (s *BeadStore) ListReady(turf string) ([]*models.Bead, error) {
 		ready = append(ready, b)
 	}
 
-	// Sort by priority (0 = highest priority, should be first)
-	sort.Slice(ready, func(i, j int) bool {
-		return ready[i].Priority < ready[j].Priority
+	// Sort by priority (0 = highest priority, should be first), oldest first within a priority
+	sort.SliceStable(ready, func(i, j int) bool {
+		if ready[i].Priority != ready[j].Priority {
+			return ready[i].Priority < ready[j].Priority
+		}
+		return ready[i].CreatedAt.Before(ready[j].CreatedAt)
 	})
 
 	return ready, nil
 }
 
+// ListOverdue returns the beads still open past their due date or SLA,
+// most overdue first
+func (s *BeadStore) ListOverdue(now time.Time) ([]*models.Bead, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+
+	var overdue []*models.Bead
+	for _, b := range beads {
+		if b.Overdue(now) {
+			overdue = append(overdue, b)
+		}
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		a, _ := overdue[i].Deadline()
+		b, _ := overdue[j].Deadline()
+		return a.Before(b)
+	})
+	return overdue, nil
+}
+
 // Get retrieves a bead by ID
 func (s *BeadStore) Get(id string) (*models.Bead, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	return s.get(id)
+}
+
+// EpicProgress rolls up an epic's child beads as of now
+func (s *BeadStore) EpicProgress(id string, now time.Time) (*models.EpicProgress, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	beads, err := s.readAllBeads()
 	if err != nil {
 		return nil, err
 	}
-
 	for _, bead := range beads {
-		if bead.ID == id {
-			return bead, nil
+		if bead.ID != id {
+			continue
+		}
+		if bead.Type != models.BeadTypeEpic {
+			return nil, fmt.Errorf("%w: %s is a %s", ErrNotEpic, id, bead.Type)
 		}
+		return models.RollUp(bead, beads, now), nil
 	}
-
-	return nil, fmt.Errorf("bead not found: %s", id)
+	return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, id)
 }
 
 // AddEvent adds a historical event to a bead
@@ -192,12 +488,18 @@ func (s *BeadStore) AddEvent(beadID string, event models.BeadEvent) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	beads, err := s.readAllBeads()
 	if err != nil {
 		return err
 	}
 
-	found := false
+	var found *models.Bead
 	for i, b := range beads {
 		if b.ID == beadID {
 			// Generate event ID if not provided
@@ -221,18 +523,66 @@ func (s *BeadStore) AddEvent(beadID string, event models.BeadEvent) error {
 
 			// Add event to history
 			b.History = append(b.History, event)
+
+			// Anyone @-mentioned in a comment starts watching the bead
+			if event.Type == models.BeadEventTypeComment {
+				b.AddWatchers(models.Mentions(event.Comment)...)
+			}
 			b.UpdatedAt = time.Now()
+			b.Revision++
 			beads[i] = b
-			found = true
+			found = b
 			break
 		}
 	}
 
-	if !found {
-		return fmt.Errorf("bead not found: %s", beadID)
+	if found == nil {
+		return fmt.Errorf("%w: %s", ErrBeadNotFound, beadID)
+	}
+
+	if err := s.writeAllBeads(beads); err != nil {
+		return err
+	}
+	s.recordActivity(found, event)
+	return nil
+}
+
+// Watch adds names to a bead's watchers
+func (s *BeadStore) Watch(beadID string, names ...string) (*models.Bead, error) {
+	return s.modify(beadID, func(b *models.Bead) { b.AddWatchers(names...) })
+}
+
+// Unwatch removes names from a bead's watchers
+func (s *BeadStore) Unwatch(beadID string, names ...string) (*models.Bead, error) {
+	return s.modify(beadID, func(b *models.Bead) { b.RemoveWatchers(names...) })
+}
+
+// modify applies fn to a bead and writes it back under the store locks
+func (s *BeadStore) modify(beadID string, fn func(*models.Bead)) (*models.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range beads {
+		if b.ID == beadID {
+			fn(b)
+			b.UpdatedAt = time.Now()
+			b.Revision++
+			return b, s.writeAllBeads(beads)
+		}
 	}
 
-	return s.writeAllBeads(beads)
+	return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, beadID)
 }
 
 // AddComment adds a comment event to a bead's history
@@ -245,11 +595,20 @@ func (s *BeadStore) AddComment(beadID, actor, comment string) error {
 	return s.AddEvent(beadID, event)
 }
 
-// Update modifies an existing bead
+// Update modifies an existing bead. The bead must carry the revision it was
+// read at: if another write landed since, ErrBeadConflict is returned and
+// nothing is saved, so the caller can re-read the bead and apply its change
+// again rather than overwrite the other one.
 func (s *BeadStore) Update(bead *models.Bead) (*models.Bead, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	beads, err := s.readAllBeads()
 	if err != nil {
 		return nil, err
@@ -257,9 +616,13 @@ func (s *BeadStore) Update(bead *models.Bead) (*models.Bead, error) {
 
 	found := false
 	var oldBead *models.Bead
+	var changes []models.BeadEvent // mirrored to the activity feed once written
 	for i, b := range beads {
 		if b.ID == bead.ID {
 			oldBead = b
+			if bead.Revision != oldBead.Revision {
+				return nil, fmt.Errorf("%w: %s is at revision %d, the update was based on %d", ErrBeadConflict, bead.ID, oldBead.Revision, bead.Revision)
+			}
 			bead.UpdatedAt = time.Now()
 
 			// Auto-record status changes
@@ -288,6 +651,8 @@ func (s *BeadStore) Update(bead *models.Bead) (*models.Bead, error) {
 
 				// Add the status change event
 				bead.History = append(bead.History, event)
+				changes = append(changes, event)
+				bead.TrackWork(oldBead.Status, event.Timestamp)
 			} else {
 				// Preserve existing history if no status change
 				if bead.History == nil {
@@ -295,86 +660,40 @@ func (s *BeadStore) Update(bead *models.Bead) (*models.Bead, error) {
 				}
 			}
 
+			if oldBead.Assignee != bead.Assignee {
+				changes = append(changes, models.BeadEvent{
+					Type:      models.BeadEventTypeAssigned,
+					Actor:     "system",
+					From:      oldBead.Assignee,
+					To:        bead.Assignee,
+					Timestamp: bead.UpdatedAt,
+				})
+			}
+
 			beads[i] = bead
 			found = true
+			if !slices.Equal(oldBead.Blocks, bead.Blocks) {
+				if err := checkCycle(beads, bead); err != nil {
+					return nil, err
+				}
+			}
 			break
 		}
 	}
 
 	if !found {
-		return nil, fmt.Errorf("bead not found: %s", bead.ID)
+		return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, bead.ID)
 	}
 
-	return bead, s.writeAllBeads(beads)
-}
-
-func (s *BeadStore) appendBead(bead *models.Bead) error {
-	f, err := os.OpenFile(s.openFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	data, err := json.Marshal(bead)
-	if err != nil {
-		return err
-	}
-
-	_, err = f.Write(append(data, '\n'))
-	return err
-}
-
-func (s *BeadStore) readAllBeads() ([]*models.Bead, error) {
-	f, err := os.Open(s.openFile)
-	if os.IsNotExist(err) {
-		return nil, nil
-	}
-	if err != nil {
+	bead.Revision++
+	if err := s.writeAllBeads(beads); err != nil {
+		bead.Revision--
 		return nil, err
 	}
-	defer f.Close()
-
-	var beads []*models.Bead
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		var bead models.Bead
-		if err := json.Unmarshal(scanner.Bytes(), &bead); err != nil {
-			continue // Skip malformed lines
-		}
-		beads = append(beads, &bead)
+	for _, e := range changes {
+		s.recordActivity(bead, e)
 	}
-
-	return beads, scanner.Err()
-}
-
-func (s *BeadStore) writeAllBeads(beads []*models.Bead) error {
-	// Write to temp file first
-	tmpFile := s.openFile + ".tmp"
-	f, err := os.Create(tmpFile)
-	if err != nil {
-		return err
-	}
-
-	for _, bead := range beads {
-		data, err := json.Marshal(bead)
-		if err != nil {
-			f.Close()
-			os.Remove(tmpFile)
-			return err
-		}
-		if _, err := f.Write(append(data, '\n')); err != nil {
-			f.Close()
-			os.Remove(tmpFile)
-			return err
-		}
-	}
-
-	if err := f.Close(); err != nil {
-		os.Remove(tmpFile)
-		return err
-	}
-
-	return os.Rename(tmpFile, s.openFile)
+	return bead, nil
 }
 
 // DependencyTree represents a bead and its dependencies
@@ -503,8 +822,79 @@ func (s *BeadStore) buildDependencyTree(beadID string, visited map[string]bool)
 	return tree, nil
 }
 
-// get is an internal method that doesn't acquire locks (caller must hold lock)
+// GetDiscoveredChain returns the beads this bead was discovered from, nearest
+// first. The walk stops at sources that aren't beads (e.g. "sweep") and at cycles.
+func (s *BeadStore) GetDiscoveredChain(beadID string) ([]*models.Bead, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.Bead, len(beads))
+	for _, b := range beads {
+		byID[b.ID] = b
+	}
+
+	bead, ok := byID[beadID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, beadID)
+	}
+
+	chain := []*models.Bead{}
+	visited := map[string]bool{beadID: true}
+	for bead.DiscoveredFrom != "" && !visited[bead.DiscoveredFrom] {
+		parent, ok := byID[bead.DiscoveredFrom]
+		if !ok {
+			break
+		}
+		visited[parent.ID] = true
+		chain = append(chain, parent)
+		bead = parent
+	}
+
+	return chain, nil
+}
+
+// GetDiscovered returns the follow-up beads that were discovered from this bead
+func (s *BeadStore) GetDiscovered(beadID string) ([]*models.Bead, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := []*models.Bead{}
+	for _, b := range beads {
+		if b.DiscoveredFrom == beadID {
+			discovered = append(discovered, b)
+		}
+	}
+
+	return discovered, nil
+}
+
+// get is an internal method that doesn't acquire locks (caller must hold lock).
+// The index points it at the one turf file holding the bead; a bead the
+// index does not know is looked for everywhere.
 func (s *BeadStore) get(id string) (*models.Bead, error) {
+	if index, err := s.readIndex(); err == nil {
+		if turf, ok := index[id]; ok {
+			beads, err := s.readTurf(turf)
+			if err != nil {
+				return nil, err
+			}
+			for _, bead := range beads {
+				if bead.ID == id {
+					return bead, nil
+				}
+			}
+		}
+	}
+
 	beads, err := s.readAllBeads()
 	if err != nil {
 		return nil, err
@@ -516,5 +906,5 @@ func (s *BeadStore) get(id string) (*models.Bead, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("bead not found: %s", id)
+	return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, id)
 }
@@ -1,7 +1,11 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -306,6 +310,106 @@ func TestBeadStore_Update(t *testing.T) {
 	}
 }
 
+func TestBeadStore_UpdateTracksWork(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bead, err := store.Create(&models.Bead{Title: "Timed", Status: models.BeadStatusOpen, TimeEstimate: "2h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bead.Status = models.BeadStatusInProgress
+	if bead, err = store.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+	if bead.StartedAt == nil || bead.FinishedAt != nil {
+		t.Fatalf("expected work started, got %v %v", bead.StartedAt, bead.FinishedAt)
+	}
+
+	bead.Status = models.BeadStatusInReview
+	if _, err = store.Update(bead); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := store.Get(bead.ID)
+	if _, ok := got.ActualTime(); !ok || got.TimeEstimate != "2h" {
+		t.Errorf("expected the finish recorded alongside the estimate, got %+v", got)
+	}
+}
+
+func TestBeadStore_UpdateConflict(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := store.Create(&models.Bead{Title: "Shared", Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Revision != 1 {
+		t.Errorf("new bead revision = %d, want 1", created.Revision)
+	}
+
+	// Two agents read the same revision
+	first, _ := store.Get(created.ID)
+	second, _ := store.Get(created.ID)
+
+	first.Assignee = "vinnie"
+	if _, err := store.Update(first); err != nil {
+		t.Fatalf("first update failed: %v", err)
+	}
+	if first.Revision != 2 {
+		t.Errorf("revision after update = %d, want 2", first.Revision)
+	}
+
+	second.Title = "Renamed"
+	if _, err := store.Update(second); !errors.Is(err, ErrBeadConflict) {
+		t.Fatalf("expected ErrBeadConflict for a stale update, got %v", err)
+	}
+	got, _ := store.Get(created.ID)
+	if got.Title != "Shared" || got.Assignee != "vinnie" {
+		t.Errorf("stale update was applied: %+v", got)
+	}
+
+	// Other writes move the revision on too
+	if err := store.AddComment(created.ID, "gabe", "looks good"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Update(first); !errors.Is(err, ErrBeadConflict) {
+		t.Errorf("expected a comment to invalidate the copy read before it, got %v", err)
+	}
+
+	got, _ = store.Get(created.ID)
+	got.Title = "Renamed"
+	if _, err := store.Update(got); err != nil {
+		t.Errorf("retry on a fresh copy failed: %v", err)
+	}
+}
+
+func TestBeadStore_ListOverdue(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	yesterday, lastWeek, tomorrow := now.Add(-24*time.Hour), now.Add(-7*24*time.Hour), now.Add(24*time.Hour)
+
+	late, _ := store.Create(&models.Bead{Title: "Late", Status: models.BeadStatusOpen, DueAt: &yesterday})
+	later, _ := store.Create(&models.Bead{Title: "Very late", Status: models.BeadStatusInProgress, DueAt: &lastWeek})
+	store.Create(&models.Bead{Title: "On time", Status: models.BeadStatusOpen, DueAt: &tomorrow})
+	store.Create(&models.Bead{Title: "Done late", Status: models.BeadStatusClosed, DueAt: &lastWeek})
+	store.Create(&models.Bead{Title: "No deadline", Status: models.BeadStatusOpen})
+
+	overdue, err := store.ListOverdue(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overdue) != 2 || overdue[0].ID != later.ID || overdue[1].ID != late.ID {
+		t.Errorf("expected [%s %s], most overdue first; got %d beads", later.ID, late.ID, len(overdue))
+	}
+}
+
 func TestBeadStore_ListReady(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "mob-bead-test")
 	if err != nil {
@@ -506,3 +610,274 @@ func TestBeadStore_ListReady(t *testing.T) {
 		}
 	})
 }
+
+func TestBeadStore_DiscoveredChain(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mob-bead-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewBeadStore(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	root, _ := store.Create(&models.Bead{Title: "Root", Status: models.BeadStatusOpen, DiscoveredFrom: "sweep"})
+	child, _ := store.Create(&models.Bead{Title: "Child", Status: models.BeadStatusOpen, DiscoveredFrom: root.ID})
+	grandchild, _ := store.Create(&models.Bead{Title: "Grandchild", Status: models.BeadStatusOpen, DiscoveredFrom: child.ID})
+
+	chain, err := store.GetDiscoveredChain(grandchild.ID)
+	if err != nil {
+		t.Fatalf("failed to get chain: %v", err)
+	}
+	if len(chain) != 2 || chain[0].ID != child.ID || chain[1].ID != root.ID {
+		t.Fatalf("expected chain [child root], got %v", chain)
+	}
+
+	discovered, err := store.GetDiscovered(root.ID)
+	if err != nil {
+		t.Fatalf("failed to get discovered: %v", err)
+	}
+	if len(discovered) != 1 || discovered[0].ID != child.ID {
+		t.Fatalf("expected child discovered from root, got %v", discovered)
+	}
+}
+
+func TestBeadStore_ConcurrentWritersAcrossStores(t *testing.T) {
+	dir := t.TempDir()
+
+	// Separate stores on one directory stand in for the daemon, CLI and
+	// per-agent MCP servers, which share nothing but the files
+	stores := make([]*BeadStore, 4)
+	for i := range stores {
+		store, err := NewBeadStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stores[i] = store
+	}
+
+	bead, err := stores[0].Create(&models.Bead{Title: "Shared", Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const perStore = 25
+	var wg sync.WaitGroup
+	errs := make(chan error, len(stores)*perStore*2)
+	for i, store := range stores {
+		wg.Add(1)
+		go func(i int, store *BeadStore) {
+			defer wg.Done()
+			for j := 0; j < perStore; j++ {
+				if err := store.AddComment(bead.ID, fmt.Sprintf("agent-%d", i), fmt.Sprintf("note %d", j)); err != nil {
+					errs <- err
+				}
+				if _, err := store.Create(&models.Bead{Title: fmt.Sprintf("child %d-%d", i, j), Status: models.BeadStatusOpen}); err != nil {
+					errs <- err
+				}
+			}
+		}(i, store)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent write failed: %v", err)
+	}
+
+	got, err := stores[0].Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comments := 0
+	for _, e := range got.History {
+		if e.Type == models.BeadEventTypeComment {
+			comments++
+		}
+	}
+	if want := len(stores) * perStore; comments != want {
+		t.Errorf("comments = %d, want %d (writes were lost)", comments, want)
+	}
+
+	all, err := stores[0].List(BeadFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 1 + len(stores)*perStore; len(all) != want {
+		t.Errorf("beads = %d, want %d", len(all), want)
+	}
+}
+
+func TestBeadStore_Split(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent, err := store.Create(&models.Bead{
+		Title:    "Rewrite auth",
+		Status:   models.BeadStatusOpen,
+		Priority: 1,
+		Type:     models.BeadTypeFeature,
+		Labels:   []string{"auth", "security"},
+		Turf:     "api",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	children, err := store.Split(parent.ID, []*models.Bead{
+		{Title: "Add token table"},
+		{Title: "Migrate sessions", Labels: []string{"migration"}},
+	}, "human")
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if len(children) != 2 {
+		t.Fatalf("got %d children, want 2", len(children))
+	}
+
+	for _, child := range children {
+		got, err := store.Get(child.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ParentID != parent.ID || got.Turf != "api" || got.Priority != 1 || got.Type != models.BeadTypeFeature {
+			t.Errorf("child %s = parent %q turf %q priority %d type %q; want inherited from parent", got.ID, got.ParentID, got.Turf, got.Priority, got.Type)
+		}
+		if len(got.Blocks) != 1 || got.Blocks[0] != parent.ID {
+			t.Errorf("child %s blocks %v, want [%s]", got.ID, got.Blocks, parent.ID)
+		}
+		if got.History[0].From != parent.ID {
+			t.Errorf("child %s created event from %q, want %s", got.ID, got.History[0].From, parent.ID)
+		}
+	}
+	if strings.Join(children[0].Labels, ",") != "auth,security" || strings.Join(children[1].Labels, ",") != "migration" {
+		t.Errorf("labels = %v, %v; want inherited unless set", children[0].Labels, children[1].Labels)
+	}
+
+	got, err := store.Get(parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != models.BeadTypeEpic {
+		t.Errorf("parent type = %q, want epic", got.Type)
+	}
+	last := got.History[len(got.History)-1]
+	if last.Type != models.BeadEventTypeSplit || last.To != children[0].ID+","+children[1].ID {
+		t.Errorf("parent split event = %+v", last)
+	}
+
+	// The parent waits for its children
+	ready, err := store.ListReady("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range ready {
+		if b.ID == parent.ID {
+			t.Error("parent should not be ready while children are open")
+		}
+	}
+
+	closed, _ := store.Create(&models.Bead{Title: "Done", Status: models.BeadStatusClosed})
+	if _, err := store.Split(closed.ID, []*models.Bead{{Title: "x"}}, "human"); !errors.Is(err, ErrBeadClosed) {
+		t.Errorf("splitting a closed bead: err = %v, want ErrBeadClosed", err)
+	}
+	if _, err := store.Split("bd-none", []*models.Bead{{Title: "x"}}, "human"); !errors.Is(err, ErrBeadNotFound) {
+		t.Errorf("splitting a missing bead: err = %v, want ErrBeadNotFound", err)
+	}
+}
+
+func TestBeadStore_Clone(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := store.Create(&models.Bead{
+		Title:       "Bump dependencies",
+		Description: "Run the updater and fix breakage",
+		Status:      models.BeadStatusClosed,
+		Priority:    3,
+		Type:        models.BeadTypeChore,
+		Labels:      []string{"deps"},
+		Turf:        "web",
+		Assignee:    "vinnie",
+		Blocks:      []string{"bd-other"},
+		Checklist:   []models.ChecklistItem{{Text: "Tests pass", Done: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := store.Clone(source.ID, CloneOptions{Title: "Bump dependencies (March)", Actor: "human"})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+
+	if clone.ID == source.ID || clone.Title != "Bump dependencies (March)" || clone.Status != models.BeadStatusOpen {
+		t.Errorf("clone = %+v", clone)
+	}
+	if clone.Description != source.Description || clone.Priority != 3 || clone.Type != models.BeadTypeChore || !clone.HasLabel("deps") || clone.Turf != "web" {
+		t.Errorf("clone did not keep the source fields: %+v", clone)
+	}
+	if clone.Assignee != "" || len(clone.Blocks) != 0 {
+		t.Errorf("clone kept assignment or dependencies: assignee %q blocks %v", clone.Assignee, clone.Blocks)
+	}
+	if len(clone.Checklist) != 1 || clone.Checklist[0].Done {
+		t.Errorf("clone checklist = %+v, want one unchecked item", clone.Checklist)
+	}
+
+	got, err := store.Get(source.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := got.History[len(got.History)-1]
+	if last.Type != models.BeadEventTypeCloned || last.To != clone.ID || last.Actor != "human" {
+		t.Errorf("source cloned event = %+v", last)
+	}
+
+	if _, err := store.Clone("bd-none", CloneOptions{}); !errors.Is(err, ErrBeadNotFound) {
+		t.Errorf("cloning a missing bead: err = %v, want ErrBeadNotFound", err)
+	}
+}
+
+func TestBeadStore_Watchers(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bead, err := store.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusOpen, Type: models.BeadTypeBug})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Watch(bead.ID, "Alice"); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if err := store.AddComment(bead.ID, "soldati-1", "@bob does this look right?"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Get(bead.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsWatching("alice") || !got.IsWatching("bob") {
+		t.Errorf("expected alice and mentioned bob watching, got %v", got.Watchers)
+	}
+
+	got, err = store.Unwatch(bead.ID, "alice")
+	if err != nil {
+		t.Fatalf("Unwatch failed: %v", err)
+	}
+	if got.IsWatching("alice") {
+		t.Errorf("expected alice removed, got %v", got.Watchers)
+	}
+
+	if _, err := store.Watch("bd-missing", "alice"); !errors.Is(err, ErrBeadNotFound) {
+		t.Errorf("expected ErrBeadNotFound, got %v", err)
+	}
+}
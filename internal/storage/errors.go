@@ -0,0 +1,35 @@
+package storage
+
+import "github.com/gabe/mob/internal/errkind"
+
+var (
+	// ErrBeadNotFound is returned when no bead has the requested ID
+	ErrBeadNotFound = errkind.New(errkind.NotFound, "bead not found")
+
+	// ErrBeadConflict is returned when a bead changed after the copy being
+	// saved was read; re-read it and apply the change again
+	ErrBeadConflict = errkind.New(errkind.Conflict, "bead changed since it was read")
+
+	// ErrBeadClosed is returned when an operation needs a bead that is still open
+	ErrBeadClosed = errkind.New(errkind.Conflict, "bead is closed")
+
+	// ErrReportNotFound is returned when no report has the requested ID
+	ErrReportNotFound = errkind.New(errkind.NotFound, "report not found")
+
+	// ErrReportAnswered is returned when answering a human input request twice
+	ErrReportAnswered = errkind.New(errkind.Conflict, "report already answered")
+
+	// ErrNotEpic is returned when epic progress is asked of a bead of another type
+	ErrNotEpic = errkind.New(errkind.Invalid, "bead is not an epic")
+
+	// ErrDependencyCycle is returned when blocks links would loop back on
+	// themselves, leaving every bead in the loop waiting on another
+	ErrDependencyCycle = errkind.New(errkind.Invalid, "dependency cycle")
+
+	// ErrInvalidMessage is returned when a message is missing its recipient or body
+	ErrInvalidMessage = errkind.New(errkind.Invalid, "invalid message")
+
+	// ErrStoreLocked is returned when another process's lock on a store file
+	// could not be acquired; retrying usually succeeds
+	ErrStoreLocked = errkind.New(errkind.Transient, "store is locked")
+)
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// ReceiveOptions describes where federated beads came from
+type ReceiveOptions struct {
+	From string // peer the beads were read from, recorded in history
+	Turf string // turf new beads land in; empty = keep the incoming turf
+}
+
+// ReceiveResult counts what Receive changed
+type ReceiveResult struct {
+	Created int
+	Updated int
+}
+
+// Share marks beads as federated, recording this instance as their origin.
+// Beads that are already federated keep their origin.
+func (s *BeadStore) Share(ids []string, instance string) ([]*models.Bead, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.Bead, len(beads))
+	for _, b := range beads {
+		byID[b.ID] = b
+	}
+
+	shared := make([]*models.Bead, 0, len(ids))
+	for _, id := range ids {
+		b, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrBeadNotFound, id)
+		}
+		if b.Origin == nil {
+			b.Origin = &models.Origin{Instance: instance, ID: b.ID}
+			b.UpdatedAt = time.Now()
+			b.Revision++
+		}
+		shared = append(shared, b)
+	}
+
+	return shared, s.writeAllBeads(beads)
+}
+
+// Federated returns every bead shared with other instances
+func (s *BeadStore) Federated() ([]*models.Bead, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+
+	var federated []*models.Bead
+	for _, b := range beads {
+		if b.Origin != nil {
+			federated = append(federated, b)
+		}
+	}
+	return federated, nil
+}
+
+// Receive merges federated beads from another instance. A bead is matched to
+// a local copy by its origin; unknown beads are created with a fresh local ID
+// and branch, known ones take the incoming title, description, status,
+// priority, type, labels, checklist and closure when they were updated more
+// recently. Assignment, worktrees, dependencies and history stay local.
+func (s *BeadStore) Receive(incoming []*models.Bead, opts ReceiveOptions) (*ReceiveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(beads))
+	byOrigin := make(map[models.Origin]*models.Bead)
+	for _, b := range beads {
+		ids[b.ID] = true
+		if b.Origin != nil {
+			byOrigin[*b.Origin] = b
+		}
+	}
+
+	result := &ReceiveResult{}
+	var created []*models.Bead
+	type statusChange struct {
+		bead  *models.Bead
+		event models.BeadEvent
+	}
+	var changes []statusChange
+	for _, in := range incoming {
+		if in.Origin == nil {
+			continue
+		}
+
+		local, ok := byOrigin[*in.Origin]
+		if !ok {
+			b := &models.Bead{
+				Turf:      in.Turf,
+				CreatedBy: opts.From,
+				Origin:    &models.Origin{Instance: in.Origin.Instance, ID: in.Origin.ID},
+			}
+			if opts.Turf != "" {
+				b.Turf = opts.Turf
+			}
+			copyFederatedFields(b, in)
+			event := models.BeadEvent{
+				From:    in.Origin.Instance,
+				Comment: fmt.Sprintf("Received from %s (%s on %s)", opts.From, in.Origin.ID, in.Origin.Instance),
+			}
+			if err := initBead(b, event); err != nil {
+				return nil, err
+			}
+			// Short random IDs may clash with ones already in use here
+			for ids[b.ID] {
+				id, err := generateID()
+				if err != nil {
+					return nil, err
+				}
+				b.ID = id
+				b.Branch = "mob/" + id
+			}
+			b.UpdatedAt = in.UpdatedAt
+
+			ids[b.ID] = true
+			byOrigin[*b.Origin] = b
+			beads = append(beads, b)
+			created = append(created, b)
+			result.Created++
+			continue
+		}
+
+		if !in.UpdatedAt.After(local.UpdatedAt) {
+			continue
+		}
+		if local.Status != in.Status {
+			event := models.BeadEvent{
+				Type:      models.BeadEventTypeStatusChange,
+				Actor:     opts.From,
+				From:      string(local.Status),
+				To:        string(in.Status),
+				Timestamp: time.Now(),
+			}
+			if eventID, err := generateID(); err == nil {
+				event.ID = eventID
+			}
+			local.History = append(local.History, event)
+			changes = append(changes, statusChange{local, event})
+		}
+		copyFederatedFields(local, in)
+		// Keeping the incoming time means the next sync sees both copies as
+		// current instead of bouncing the change back
+		local.UpdatedAt = in.UpdatedAt
+		local.Revision++
+		result.Updated++
+	}
+
+	if result.Created == 0 && result.Updated == 0 {
+		return result, nil
+	}
+	if err := s.writeAllBeads(beads); err != nil {
+		return nil, err
+	}
+	for _, b := range created {
+		s.recordActivity(b, b.History[0])
+	}
+	for _, c := range changes {
+		s.recordActivity(c.bead, c.event)
+	}
+	return result, nil
+}
+
+// copyFederatedFields copies the fields instances share from src to dst
+func copyFederatedFields(dst, src *models.Bead) {
+	dst.Title = src.Title
+	dst.Description = src.Description
+	dst.Status = src.Status
+	dst.Priority = src.Priority
+	dst.Type = src.Type
+	dst.Labels = append([]string(nil), src.Labels...)
+	dst.Checklist = append([]models.ChecklistItem(nil), src.Checklist...)
+	dst.ClosedAt = src.ClosedAt
+	dst.CloseReason = src.CloseReason
+}
@@ -0,0 +1,216 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+// ImportOptions controls how Import brings beads in
+type ImportOptions struct {
+	Source string // where the beads came from, recorded in each new bead's history
+	Turf   string // turf every imported bead lands in; empty = keep each bead's turf
+	Actor  string
+	DryRun bool // work out the result without writing anything
+}
+
+// Duplicate is an imported bead that matched one already in the store
+type Duplicate struct {
+	ImportID string // the bead's ID in the import, if it had one
+	Title    string
+	Existing string // ID of the bead it matched
+}
+
+// ImportResult describes what Import did, or would do on a dry run
+type ImportResult struct {
+	Created      []*models.Bead
+	Duplicates   []Duplicate
+	IDs          map[string]string // import ID → local ID, for every bead in the import that had one
+	DroppedLinks int               // links to beads that were not in the import
+}
+
+// Import adds beads exported from another instance or read from a
+// spreadsheet. Every new bead gets a fresh ID, and parent, blocks, related
+// and discovered-from links between imported beads are rewritten to the new
+// IDs; links to beads outside the import are dropped, since their IDs mean
+// nothing here. A bead with the same title and turf as one already in the
+// store, or earlier in the import, is a duplicate: it is skipped and links to
+// it point at the bead it matched. Assignments and work in progress do not
+// carry over, so in-progress beads come in open. Everything is written at once.
+func (s *BeadStore) Import(incoming []*models.Bead, opts ImportOptions) (*ImportResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.lock)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	beads, err := s.readAllBeads()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(beads))
+	byKey := make(map[string]string, len(beads))
+	for _, b := range beads {
+		ids[b.ID] = true
+		byKey[importKey(b.Title, b.Turf)] = b.ID
+	}
+
+	result := &ImportResult{IDs: make(map[string]string)}
+	type pending struct {
+		bead *models.Bead
+		in   *models.Bead
+	}
+	var created []pending
+	for i, in := range incoming {
+		if strings.TrimSpace(in.Title) == "" {
+			return nil, errkind.New(errkind.Invalid, fmt.Sprintf("bead %d in the import has no title", i+1))
+		}
+		turf := in.Turf
+		if opts.Turf != "" {
+			turf = opts.Turf
+		}
+
+		key := importKey(in.Title, turf)
+		if existing, ok := byKey[key]; ok {
+			result.Duplicates = append(result.Duplicates, Duplicate{ImportID: in.ID, Title: in.Title, Existing: existing})
+			if in.ID != "" {
+				result.IDs[in.ID] = existing
+			}
+			continue
+		}
+
+		b := importedBead(in, turf, opts.Actor)
+		comment := "Imported"
+		if opts.Source != "" {
+			comment += " from " + opts.Source
+		}
+		if in.ID != "" {
+			comment += fmt.Sprintf(" (was %s)", in.ID)
+		}
+		if err := initBead(b, models.BeadEvent{From: in.ID, Comment: comment}); err != nil {
+			return nil, err
+		}
+		for ids[b.ID] {
+			id, err := generateID()
+			if err != nil {
+				return nil, err
+			}
+			b.ID = id
+			b.Branch = "mob/" + id
+		}
+		if !in.CreatedAt.IsZero() {
+			b.CreatedAt = in.CreatedAt
+		}
+
+		ids[b.ID] = true
+		byKey[key] = b.ID
+		if in.ID != "" {
+			result.IDs[in.ID] = b.ID
+		}
+		created = append(created, pending{b, in})
+	}
+
+	// Links can point forward in the import, so rewrite them once every bead has its ID
+	remap := func(id string) (string, bool) {
+		local, ok := result.IDs[id]
+		if !ok {
+			result.DroppedLinks++
+		}
+		return local, ok
+	}
+	createdIDs := make(map[string]bool, len(created))
+	for _, p := range created {
+		if p.in.ParentID != "" {
+			p.bead.ParentID, _ = remap(p.in.ParentID)
+		}
+		for _, id := range p.in.Blocks {
+			if local, ok := remap(id); ok {
+				p.bead.Blocks = append(p.bead.Blocks, local)
+			}
+		}
+		for _, id := range p.in.Related {
+			if local, ok := remap(id); ok {
+				p.bead.Related = append(p.bead.Related, local)
+			}
+		}
+		// Non-bead origins such as "sweep" carry over as they are
+		if from := p.in.DiscoveredFrom; from != "" {
+			if _, ok := result.IDs[from]; ok || strings.HasPrefix(from, "bd-") {
+				p.bead.DiscoveredFrom, _ = remap(from)
+			} else {
+				p.bead.DiscoveredFrom = from
+			}
+		}
+		beads = append(beads, p.bead)
+		result.Created = append(result.Created, p.bead)
+		createdIDs[p.bead.ID] = true
+	}
+
+	// Existing cycles are left to mob beads validate; only refuse new ones
+	for _, cycle := range FindCycles(beads) {
+		for _, id := range cycle {
+			if _, ok := createdIDs[id]; ok {
+				return nil, &CycleError{Cycle: cycle}
+			}
+		}
+	}
+
+	if opts.DryRun || len(result.Created) == 0 {
+		return result, nil
+	}
+	if err := s.writeAllBeads(beads); err != nil {
+		return nil, err
+	}
+	for _, b := range result.Created {
+		s.recordActivity(b, b.History[0])
+	}
+	return result, nil
+}
+
+// importedBead copies the fields that mean the same on any instance
+func importedBead(in *models.Bead, turf, actor string) *models.Bead {
+	b := &models.Bead{
+		Title:        strings.TrimSpace(in.Title),
+		Description:  in.Description,
+		Status:       in.Status,
+		Priority:     in.Priority,
+		Type:         in.Type,
+		Turf:         turf,
+		CreatedBy:    actor,
+		Checklist:    append([]models.ChecklistItem(nil), in.Checklist...),
+		DueAt:        in.DueAt,
+		SLA:          in.SLA,
+		TimeEstimate: in.TimeEstimate,
+	}
+	b.AddLabels(in.Labels...)
+	switch b.Status {
+	case "", models.BeadStatusInProgress, models.BeadStatusInReview:
+		b.Status = models.BeadStatusOpen
+	case models.BeadStatusClosed:
+		b.CloseReason = in.CloseReason
+		b.ClosedAt = in.ClosedAt
+		if b.ClosedAt == nil {
+			now := time.Now()
+			b.ClosedAt = &now
+		}
+	}
+	if b.Type == "" {
+		b.Type = models.BeadTypeTask
+	}
+	if b.Description == "" {
+		b.Description = b.Title
+	}
+	return b
+}
+
+// importKey identifies a bead for duplicate detection: its title and turf,
+// ignoring case and spacing
+func importKey(title, turf string) string {
+	return strings.ToLower(strings.TrimSpace(turf)) + "\x00" + strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
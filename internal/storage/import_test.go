@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestBeadStore_Import(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing, err := store.Create(&models.Bead{Title: "Fix login", Turf: "api", Status: models.BeadStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	incoming := []*models.Bead{
+		{ID: "bd-e1", Title: "Auth epic", Type: models.BeadTypeEpic, Turf: "api", Status: models.BeadStatusInProgress, Assignee: "vinnie"},
+		{ID: "bd-t1", Title: "Add tokens", Turf: "api", ParentID: "bd-e1", Blocks: []string{"bd-t2", "bd-gone"}},
+		{ID: "bd-t2", Title: "Rotate tokens", Turf: "api", ParentID: "bd-e1", Related: []string{"bd-dup"}},
+		{ID: "bd-dup", Title: "  fix LOGIN ", Turf: "api"},
+		{ID: "bd-t3", Title: "Add tokens", Turf: "api"},
+	}
+
+	dry, err := store.Import(incoming, ImportOptions{Source: "other.json", Actor: "user", DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dry.Created) != 3 {
+		t.Fatalf("dry run created %d beads, want 3", len(dry.Created))
+	}
+	if all, _ := store.List(BeadFilter{}); len(all) != 1 {
+		t.Fatalf("dry run wrote %d beads, want only the existing one", len(all))
+	}
+
+	result, err := store.Import(incoming, ImportOptions{Source: "other.json", Actor: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 3 || len(result.Duplicates) != 2 {
+		t.Fatalf("created %d, duplicates %d; want 3 and 2", len(result.Created), len(result.Duplicates))
+	}
+	if d := result.Duplicates[0]; d.ImportID != "bd-dup" || d.Existing != existing.ID {
+		t.Errorf("duplicate = %+v, want bd-dup matching %s", d, existing.ID)
+	}
+	if d := result.Duplicates[1]; d.ImportID != "bd-t3" || d.Existing != result.IDs["bd-t1"] {
+		t.Errorf("duplicate = %+v, want bd-t3 matching the imported bd-t1", d)
+	}
+	if result.DroppedLinks != 1 {
+		t.Errorf("dropped %d links, want 1", result.DroppedLinks)
+	}
+
+	epic, _ := store.Get(result.IDs["bd-e1"])
+	if epic.ID == "bd-e1" || epic.Status != models.BeadStatusOpen || epic.Assignee != "" {
+		t.Errorf("epic = %s %s %q, want a new open unassigned bead", epic.ID, epic.Status, epic.Assignee)
+	}
+	t1, _ := store.Get(result.IDs["bd-t1"])
+	if t1.ParentID != epic.ID || len(t1.Blocks) != 1 || t1.Blocks[0] != result.IDs["bd-t2"] {
+		t.Errorf("t1 parent %s blocks %v, want %s and [%s]", t1.ParentID, t1.Blocks, epic.ID, result.IDs["bd-t2"])
+	}
+	t2, _ := store.Get(result.IDs["bd-t2"])
+	if len(t2.Related) != 1 || t2.Related[0] != existing.ID {
+		t.Errorf("t2 related %v, want the existing duplicate %s", t2.Related, existing.ID)
+	}
+	if t2.Revision != 1 || t2.History[0].From != "bd-t2" {
+		t.Errorf("t2 revision %d from %q, want 1 and bd-t2", t2.Revision, t2.History[0].From)
+	}
+
+	again, err := store.Import(incoming, ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Created) != 0 {
+		t.Errorf("importing twice created %d beads, want 0", len(again.Created))
+	}
+}
+
+func TestBeadStore_ImportRejects(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Import([]*models.Bead{{Title: " "}}, ImportOptions{}); !errors.Is(err, errkind.Invalid) {
+		t.Error("expected an untitled bead rejected")
+	}
+
+	loop := []*models.Bead{
+		{ID: "a", Title: "a", Blocks: []string{"b"}},
+		{ID: "b", Title: "b", Blocks: []string{"a"}},
+	}
+	if _, err := store.Import(loop, ImportOptions{}); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expected a cycle error, got %v", err)
+	}
+	if all, _ := store.List(BeadFilter{}); len(all) != 0 {
+		t.Errorf("rejected import wrote %d beads", len(all))
+	}
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// LabelCount is how many beads carry a label
+type LabelCount struct {
+	Label string
+	Open  int // beads not yet closed
+	Total int
+}
+
+// CountLabels tallies the labels on beads, busiest first: by open beads,
+// then by all beads, then by name
+func CountLabels(beads []*models.Bead) []LabelCount {
+	byLabel := make(map[string]*LabelCount)
+	var counts []*LabelCount
+	for _, b := range beads {
+		for _, label := range b.Labels {
+			c, ok := byLabel[label]
+			if !ok {
+				c = &LabelCount{Label: label}
+				byLabel[label] = c
+				counts = append(counts, c)
+			}
+			c.Total++
+			if b.Status != models.BeadStatusClosed {
+				c.Open++
+			}
+		}
+	}
+
+	out := make([]LabelCount, len(counts))
+	for i, c := range counts {
+		out[i] = *c
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Open != out[j].Open {
+			return out[i].Open > out[j].Open
+		}
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Label < out[j].Label
+	})
+	return out
+}
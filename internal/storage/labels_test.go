@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestCountLabels(t *testing.T) {
+	store, err := NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []*models.Bead{
+		{Title: "a", Status: models.BeadStatusOpen, Labels: []string{"api", "auth"}},
+		{Title: "b", Status: models.BeadStatusOpen, Labels: []string{"auth"}},
+		{Title: "c", Status: models.BeadStatusClosed, Labels: []string{"api", "ui"}},
+		{Title: "d", Status: models.BeadStatusClosed, Labels: []string{"api"}},
+	} {
+		if _, err := store.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	beads, err := store.List(BeadFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []LabelCount{{"auth", 2, 2}, {"api", 1, 3}, {"ui", 0, 1}}
+	if got := CountLabels(beads); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountLabels = %v, want %v", got, want)
+	}
+
+	tagged, err := store.List(BeadFilter{Label: "API"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagged) != 3 {
+		t.Errorf("label filter matched %d beads, want 3", len(tagged))
+	}
+}
@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+)
+
+// MessageStore manages per-agent JSONL inboxes for agent-to-agent messages
+type MessageStore struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewMessageStore creates a new message store at the given directory
+func NewMessageStore(dir string) (*MessageStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create inbox directory: %w", err)
+	}
+
+	return &MessageStore{dir: dir}, nil
+}
+
+// generateMessageID creates a short random ID for messages
+func generateMessageID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random ID: %w", err)
+	}
+	return "msg-" + hex.EncodeToString(b)[:4], nil
+}
+
+// inboxPath returns the inbox file for an agent
+func (s *MessageStore) inboxPath(agentName string) (string, error) {
+	if agentName == "" || agentName != filepath.Base(agentName) || agentName == "." || agentName == ".." {
+		return "", errkind.Wrap(errkind.Invalid, fmt.Errorf("invalid agent name: %q", agentName))
+	}
+	return filepath.Join(s.dir, agentName+".jsonl"), nil
+}
+
+// Send appends a message to the recipient's inbox
+func (s *MessageStore) Send(msg *models.AgentMessage) (*models.AgentMessage, error) {
+	if msg.To == "" {
+		return nil, fmt.Errorf("%w: recipient is required", ErrInvalidMessage)
+	}
+	if msg.Body == "" {
+		return nil, fmt.Errorf("%w: body is required", ErrInvalidMessage)
+	}
+	path, err := s.inboxPath(msg.To)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	id, err := generateMessageID()
+	if err != nil {
+		return nil, err
+	}
+	msg.ID = id
+	msg.Timestamp = time.Now()
+	msg.Read = false
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// List returns the messages in an agent's inbox, oldest first
+func (s *MessageStore) List(agentName string, unreadOnly bool) ([]*models.AgentMessage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	messages, err := s.readInbox(agentName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !unreadOnly {
+		return messages, nil
+	}
+
+	var unread []*models.AgentMessage
+	for _, m := range messages {
+		if !m.Read {
+			unread = append(unread, m)
+		}
+	}
+	return unread, nil
+}
+
+// MarkRead marks the given messages in an agent's inbox as read
+func (s *MessageStore) MarkRead(agentName string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	path, err := s.inboxPath(agentName)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	messages, err := s.readInbox(agentName)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	for _, m := range messages {
+		if wanted[m.ID] {
+			m.Read = true
+		}
+	}
+
+	return s.writeInbox(agentName, messages)
+}
+
+func (s *MessageStore) readInbox(agentName string) ([]*models.AgentMessage, error) {
+	path, err := s.inboxPath(agentName)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var messages []*models.AgentMessage
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var msg models.AgentMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue // Skip malformed lines
+		}
+		messages = append(messages, &msg)
+	}
+
+	return messages, scanner.Err()
+}
+
+func (s *MessageStore) writeInbox(agentName string, messages []*models.AgentMessage) error {
+	path, err := s.inboxPath(agentName)
+	if err != nil {
+		return err
+	}
+
+	// Write to temp file first
+	tmpFile := path + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			f.Close()
+			os.Remove(tmpFile)
+			return err
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			f.Close()
+			os.Remove(tmpFile)
+			return err
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+
+	return os.Rename(tmpFile, path)
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestMessageStore_SendAndList(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mob-message-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewMessageStore(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	sent, err := store.Send(&models.AgentMessage{From: "underboss", To: "vinnie", Body: "the API changed"})
+	if err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	if sent.ID == "" {
+		t.Error("expected message to have ID")
+	}
+
+	if _, err := store.Send(&models.AgentMessage{From: "vinnie", To: "paulie", Body: "not for vinnie"}); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+
+	inbox, err := store.List("vinnie", false)
+	if err != nil {
+		t.Fatalf("failed to list inbox: %v", err)
+	}
+	if len(inbox) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(inbox))
+	}
+	if inbox[0].Body != "the API changed" || inbox[0].From != "underboss" {
+		t.Errorf("unexpected message: %+v", inbox[0])
+	}
+
+	empty, err := store.List("nobody", false)
+	if err != nil {
+		t.Fatalf("failed to list empty inbox: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected empty inbox, got %d messages", len(empty))
+	}
+}
+
+func TestMessageStore_MarkRead(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mob-message-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewMessageStore(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := store.Send(&models.AgentMessage{From: "a", To: "vinnie", Body: "one"})
+	store.Send(&models.AgentMessage{From: "a", To: "vinnie", Body: "two"})
+
+	if err := store.MarkRead("vinnie", []string{first.ID}); err != nil {
+		t.Fatalf("failed to mark read: %v", err)
+	}
+
+	unread, err := store.List("vinnie", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unread) != 1 || unread[0].Body != "two" {
+		t.Errorf("expected only 'two' unread, got %+v", unread)
+	}
+}
+
+func TestMessageStore_SendValidation(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "mob-message-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewMessageStore(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Send(&models.AgentMessage{Body: "hi"}); err == nil {
+		t.Error("expected error for missing recipient")
+	}
+	if _, err := store.Send(&models.AgentMessage{To: "vinnie"}); err == nil {
+		t.Error("expected error for empty body")
+	}
+	if _, err := store.Send(&models.AgentMessage{To: "../vinnie", Body: "hi"}); err == nil {
+		t.Error("expected error for path-like recipient")
+	}
+}
@@ -56,6 +56,12 @@ func (s *ReportStore) Create(report *models.AgentReport) (*models.AgentReport, e
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	unlock, err := lockFile(s.openFile + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	id, err := generateReportID()
 	if err != nil {
 		return nil, err
@@ -117,36 +123,65 @@ func (s *ReportStore) Get(id string) (*models.AgentReport, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("report not found: %s", id)
+	return nil, fmt.Errorf("%w: %s", ErrReportNotFound, id)
 }
 
 // MarkHandled marks a report as handled
 func (s *ReportStore) MarkHandled(id string) (*models.AgentReport, error) {
+	return s.modify(id, func(r *models.AgentReport) { r.Handled = true })
+}
+
+// Answer records a human's reply to a human input request and marks it handled
+func (s *ReportStore) Answer(id, answer, answeredBy string) (*models.AgentReport, error) {
+	var answered bool
+	report, err := s.modify(id, func(r *models.AgentReport) {
+		if answered = r.Answered(); answered {
+			return
+		}
+		now := time.Now()
+		r.Answer = answer
+		r.AnsweredBy = answeredBy
+		r.AnsweredAt = &now
+		r.Handled = true
+	})
+	if err == nil && answered {
+		return nil, fmt.Errorf("%w: %s", ErrReportAnswered, id)
+	}
+	return report, err
+}
+
+// MarkNotified records that the operator has been told about a report
+func (s *ReportStore) MarkNotified(id string) (*models.AgentReport, error) {
+	return s.modify(id, func(r *models.AgentReport) {
+		now := time.Now()
+		r.NotifiedAt = &now
+	})
+}
+
+// modify applies fn to a report and writes it back under the store locks
+func (s *ReportStore) modify(id string, fn func(*models.AgentReport)) (*models.AgentReport, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	unlock, err := lockFile(s.openFile + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	reports, err := s.readAllReports()
 	if err != nil {
 		return nil, err
 	}
 
-	found := false
-	var updatedReport *models.AgentReport
-	for i, report := range reports {
+	for _, report := range reports {
 		if report.ID == id {
-			report.Handled = true
-			reports[i] = report
-			updatedReport = report
-			found = true
-			break
+			fn(report)
+			return report, s.writeAllReports(reports)
 		}
 	}
 
-	if !found {
-		return nil, fmt.Errorf("report not found: %s", id)
-	}
-
-	return updatedReport, s.writeAllReports(reports)
+	return nil, fmt.Errorf("%w: %s", ErrReportNotFound, id)
 }
 
 func (s *ReportStore) appendReport(report *models.AgentReport) error {
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// SpawnQueue holds associate spawns waiting for capacity, oldest first. Every
+// MCP server shares the file, and capacity is checked and spent under its
+// lock so two servers cannot both take the last slot.
+type SpawnQueue struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DrainResult is what one pass over the spawn queue did
+type DrainResult struct {
+	Started []*models.SpawnRequest
+	Expired []*models.SpawnRequest
+	Failed  []*models.SpawnRequest // start returned an error; the request is dropped
+}
+
+// SpawnQueuePath returns where queued spawns are kept
+func SpawnQueuePath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "spawn_queue.jsonl")
+}
+
+// NewSpawnQueue opens the spawn queue at path
+func NewSpawnQueue(path string) *SpawnQueue {
+	return &SpawnQueue{path: path}
+}
+
+// generateSpawnID creates a short random ID for queued spawns
+func generateSpawnID() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random ID: %w", err)
+	}
+	return "sq-" + hex.EncodeToString(b)[:4], nil
+}
+
+// List returns the requests still waiting at now, oldest first
+func (q *SpawnQueue) List(now time.Time) ([]*models.SpawnRequest, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	requests, err := q.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*models.SpawnRequest
+	for _, r := range requests {
+		if !r.Expired(now) {
+			pending = append(pending, r)
+		}
+	}
+	return pending, nil
+}
+
+// Submit queues req behind any earlier requests and then drains the queue,
+// so req starts right away when nothing is waiting and there is capacity
+func (q *SpawnQueue) Submit(req *models.SpawnRequest, now time.Time, free func() int, start func(*models.SpawnRequest) error) (*DrainResult, error) {
+	id, err := generateSpawnID()
+	if err != nil {
+		return nil, err
+	}
+	req.ID = id
+	req.RequestedAt = now
+	return q.drain(req, now, free, start)
+}
+
+// Drain drops expired requests, then starts waiting ones in order while free
+// reports spare capacity. free is asked again after every start.
+func (q *SpawnQueue) Drain(now time.Time, free func() int, start func(*models.SpawnRequest) error) (*DrainResult, error) {
+	return q.drain(nil, now, free, start)
+}
+
+// drain runs one pass over the queue, with add appended first when set
+func (q *SpawnQueue) drain(add *models.SpawnRequest, now time.Time, free func() int, start func(*models.SpawnRequest) error) (*DrainResult, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	unlock, err := lockFile(q.path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	requests, err := q.read()
+	if err != nil {
+		return nil, err
+	}
+	if add != nil {
+		requests = append(requests, add)
+	}
+
+	result := &DrainResult{}
+	var waiting []*models.SpawnRequest
+	for _, r := range requests {
+		switch {
+		case r.Expired(now):
+			result.Expired = append(result.Expired, r)
+		case len(waiting) == 0 && free() > 0:
+			if err := start(r); err != nil {
+				result.Failed = append(result.Failed, r)
+			} else {
+				result.Started = append(result.Started, r)
+			}
+		default:
+			waiting = append(waiting, r)
+		}
+	}
+
+	if add == nil && len(waiting) == len(requests) {
+		return result, nil
+	}
+	return result, q.write(waiting)
+}
+
+func (q *SpawnQueue) read() ([]*models.SpawnRequest, error) {
+	f, err := os.Open(q.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var requests []*models.SpawnRequest
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r models.SpawnRequest
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue // Skip malformed lines
+		}
+		requests = append(requests, &r)
+	}
+	return requests, scanner.Err()
+}
+
+func (q *SpawnQueue) write(requests []*models.SpawnRequest) error {
+	if err := os.MkdirAll(filepath.Dir(q.path), 0755); err != nil {
+		return err
+	}
+
+	tmp := q.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	for _, r := range requests {
+		data, err := json.Marshal(r)
+		if err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return err
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, q.path)
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestSpawnQueue(t *testing.T) {
+	q := NewSpawnQueue(SpawnQueuePath(t.TempDir()))
+	now := time.Now()
+
+	capacity := 1
+	free := func() int { return capacity }
+	var started []string
+	start := func(r *models.SpawnRequest) error {
+		capacity--
+		started = append(started, r.Task)
+		return nil
+	}
+
+	// The first request takes the only slot; the next two wait in order
+	for _, task := range []string{"first", "second", "third"} {
+		req := &models.SpawnRequest{Task: task, ExpiresAt: now.Add(time.Hour)}
+		if _, err := q.Submit(req, now, free, start); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(started) != 1 || started[0] != "first" {
+		t.Fatalf("expected only the first request started, got %v", started)
+	}
+	pending, err := q.List(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].Task != "second" || pending[1].Task != "third" {
+		t.Fatalf("expected second and third waiting in order, got %+v", pending)
+	}
+	if pending[0].ID == "" || pending[0].RequestedAt.IsZero() {
+		t.Errorf("expected queued requests to get an ID and time, got %+v", pending[0])
+	}
+
+	// Capacity frees up: the oldest waiting request goes next
+	capacity = 1
+	res, err := q.Drain(now, free, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Started) != 1 || res.Started[0].Task != "second" {
+		t.Fatalf("expected the second request started, got %+v", res)
+	}
+
+	// A later arrival never jumps the queue
+	capacity = 0
+	late := &models.SpawnRequest{Task: "late", ExpiresAt: now.Add(time.Hour)}
+	if _, err := q.Submit(late, now, free, start); err != nil {
+		t.Fatal(err)
+	}
+	pending, _ = q.List(now)
+	if len(pending) != 2 || pending[1].ID != late.ID {
+		t.Fatalf("expected the late request behind third, got %+v", pending)
+	}
+}
+
+func TestSpawnQueue_ExpiryAndFailure(t *testing.T) {
+	q := NewSpawnQueue(filepath.Join(t.TempDir(), "queue.jsonl"))
+	now := time.Now()
+	none := func() int { return 0 }
+	start := func(r *models.SpawnRequest) error { return nil }
+
+	stale := &models.SpawnRequest{Task: "stale", ExpiresAt: now.Add(time.Minute)}
+	if _, err := q.Submit(stale, now, none, start); err != nil {
+		t.Fatal(err)
+	}
+	broken := &models.SpawnRequest{Task: "broken", ExpiresAt: now.Add(time.Hour)}
+	if _, err := q.Submit(broken, now, none, start); err != nil {
+		t.Fatal(err)
+	}
+
+	later := now.Add(2 * time.Minute)
+	if pending, _ := q.List(later); len(pending) != 1 || pending[0].Task != "broken" {
+		t.Fatalf("expected expired requests hidden, got %+v", pending)
+	}
+
+	res, err := q.Drain(later, func() int { return 1 }, func(r *models.SpawnRequest) error {
+		return errors.New("spawn failed")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Expired) != 1 || res.Expired[0].ID != stale.ID {
+		t.Errorf("expected the stale request expired, got %+v", res.Expired)
+	}
+	if len(res.Failed) != 1 || res.Failed[0].ID != broken.ID {
+		t.Errorf("expected the broken request failed, got %+v", res.Failed)
+	}
+	if pending, _ := q.List(later); len(pending) != 0 {
+		t.Errorf("expected expired and failed requests dropped, got %+v", pending)
+	}
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// spendDays is how many days of spend the ledger keeps
+const spendDays = 90
+
+// SpendPath returns the daily spend ledger (~/mob/.mob/spend.json)
+func SpendPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "spend.json")
+}
+
+// AddSpend adds an agent call's cost to the ledger under the day it was
+// spent (local time), dropping days older than spendDays
+func AddSpend(path string, at time.Time, usd float64) error {
+	if usd <= 0 {
+		return nil
+	}
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ledger, err := readSpend(path)
+	if err != nil {
+		return err
+	}
+	ledger[spendDay(at)] += usd
+
+	cutoff := spendDay(at.AddDate(0, 0, -spendDays))
+	for day := range ledger {
+		if day < cutoff {
+			delete(ledger, day)
+		}
+	}
+
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write spend ledger: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// SpendOn returns the recorded spend for the day containing t
+func SpendOn(path string, t time.Time) (float64, error) {
+	ledger, err := readSpend(path)
+	if err != nil {
+		return 0, err
+	}
+	return ledger[spendDay(t)], nil
+}
+
+// SpendByDay returns the whole ledger, keyed by local date (2006-01-02)
+func SpendByDay(path string) (map[string]float64, error) {
+	return readSpend(path)
+}
+
+func spendDay(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
+
+func readSpend(path string) (map[string]float64, error) {
+	ledger := make(map[string]float64)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spend ledger: %w", err)
+	}
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse spend ledger: %w", err)
+	}
+	return ledger, nil
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+// UsagePath returns the usage ledger (~/mob/.mob/usage.jsonl), which records
+// every agent call with who made it, so spend can be broken down by agent,
+// turf and day. It keeps spendDays of calls, like the spend ledger.
+func UsagePath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "usage.jsonl")
+}
+
+// RecordCall records an agent call in the usage ledger and adds its cost to
+// the daily spend ledger
+func RecordCall(mobDir string, entry models.UsageEntry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	if err := AddSpend(SpendPath(mobDir), entry.Timestamp, entry.CostUSD); err != nil {
+		return err
+	}
+	return RecordUsage(UsagePath(mobDir), entry)
+}
+
+// RecordUsage appends an agent call to the usage ledger. Calls that used
+// nothing are not recorded. Once the oldest call is older than spendDays
+// the ledger is rewritten without the expired ones.
+func RecordUsage(path string, entry models.UsageEntry) error {
+	if entry.Tokens() == 0 && entry.CostUSD <= 0 {
+		return nil
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create usage ledger directory: %w", err)
+	}
+	if err := pruneUsage(path, entry.Timestamp.AddDate(0, 0, -spendDays)); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// pruneUsage rewrites the ledger without calls before cutoff, when its first
+// call is that old. The caller holds the lock.
+func pruneUsage(path string, cutoff time.Time) error {
+	if first, err := firstUsage(path); err != nil || first == nil || !first.Timestamp.Before(cutoff) {
+		return err
+	}
+	entries, err := readUsage(path)
+	if err != nil {
+		return err
+	}
+	var kept strings.Builder
+	for _, e := range entries {
+		if e.Timestamp.Before(cutoff) {
+			continue
+		}
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		kept.Write(append(line, '\n'))
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(kept.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write usage ledger: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// firstUsage returns the oldest call in the ledger, or nil when it is empty
+func firstUsage(path string) (*models.UsageEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e models.UsageEntry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			return &e, nil
+		}
+	}
+	return nil, scanner.Err()
+}
+
+// ReadUsage returns the calls in the usage ledger made since the given time,
+// oldest first. A missing ledger has none; lines that do not parse are
+// skipped.
+func ReadUsage(path string, since time.Time) ([]models.UsageEntry, error) {
+	entries, err := readUsage(path)
+	if err != nil {
+		return nil, err
+	}
+	var recent []models.UsageEntry
+	for _, e := range entries {
+		if !e.Timestamp.Before(since) {
+			recent = append(recent, e)
+		}
+	}
+	return recent, nil
+}
+
+func readUsage(path string) ([]models.UsageEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+	defer file.Close()
+
+	var entries []models.UsageEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e models.UsageEntry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+	return entries, nil
+}
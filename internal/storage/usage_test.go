@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestUsageLedger(t *testing.T) {
+	mobDir := t.TempDir()
+	path := UsagePath(mobDir)
+	now := time.Now()
+
+	calls := []models.UsageEntry{
+		{Timestamp: now.AddDate(0, 0, -spendDays-1), Agent: "vinnie", CostUSD: 5},
+		{Timestamp: now.AddDate(0, 0, -2), Agent: "vinnie", Turf: "web", BeadID: "bd-a1b2", InputTokens: 1000, OutputTokens: 200, CostUSD: 0.5},
+		{Timestamp: now.Add(-time.Hour), Agent: "underboss"}, // used nothing
+		{Timestamp: now, Agent: "sal", Turf: "api", OutputTokens: 300, CostUSD: 0.25},
+	}
+	for _, c := range calls {
+		if err := RecordCall(mobDir, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := ReadUsage(path, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].BeadID != "bd-a1b2" || all[0].Tokens() != 1200 || all[1].Agent != "sal" {
+		t.Fatalf("expected the two recent calls that used something, oldest first, got %+v", all)
+	}
+
+	recent, err := ReadUsage(path, now.Add(-time.Minute))
+	if err != nil || len(recent) != 1 || recent[0].Agent != "sal" {
+		t.Fatalf("expected only the latest call, got %+v (%v)", recent, err)
+	}
+
+	spent, err := SpendOn(SpendPath(mobDir), now)
+	if err != nil || spent != 0.25 {
+		t.Fatalf("expected today's spend recorded too, got %v (%v)", spent, err)
+	}
+
+	none, err := ReadUsage(UsagePath(t.TempDir()), time.Time{})
+	if err != nil || len(none) != 0 {
+		t.Fatalf("expected a missing ledger to have no calls, got %+v (%v)", none, err)
+	}
+}
@@ -40,6 +40,7 @@ type SweepResult struct {
 	ItemsFound  int
 	Beads       []string // Bead IDs created
 	Summary     string
+	Warnings    []string // Detectors that failed; the sweep carries on without them
 }
 
 // Issue represents a found issue during a sweep
@@ -51,10 +52,21 @@ type Issue struct {
 	Context     string // surrounding code context
 }
 
+// Detector is an extra source of issues run alongside a sweep's built-in checks
+type Detector interface {
+	// Name identifies the detector in warnings
+	Name() string
+	// Sweep is the sweep type the detector belongs to (review or bugs)
+	Sweep() SweepType
+	// Detect returns the issues found in a turf
+	Detect(ctx context.Context, turfPath string) ([]Issue, error)
+}
+
 // Sweeper manages sweep operations for a turf
 type Sweeper struct {
 	turfPath  string
 	beadStore *storage.BeadStore
+	detectors []Detector
 }
 
 // New creates a new Sweeper for a turf
@@ -65,6 +77,29 @@ func New(turfPath string, beadStore *storage.BeadStore) *Sweeper {
 	}
 }
 
+// AddDetectors registers extra detectors, such as those provided by plugins
+func (s *Sweeper) AddDetectors(detectors ...Detector) {
+	s.detectors = append(s.detectors, detectors...)
+}
+
+// runDetectors collects issues from the registered detectors for a sweep
+// type, recording failures as warnings on the result
+func (s *Sweeper) runDetectors(ctx context.Context, sweepType SweepType, result *SweepResult) []Issue {
+	var issues []Issue
+	for _, d := range s.detectors {
+		if d.Sweep() != sweepType {
+			continue
+		}
+		found, err := d.Detect(ctx, s.turfPath)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("detector %s failed: %v", d.Name(), err))
+			continue
+		}
+		issues = append(issues, found...)
+	}
+	return issues
+}
+
 // Review runs a code review sweep.
 // It analyzes recent commits, looks for style issues, missing tests,
 // and security anti-patterns, creating beads for issues found.
@@ -92,6 +127,7 @@ func (s *Sweeper) Review(ctx context.Context) (*SweepResult, error) {
 	if err == nil {
 		issues = append(issues, codeIssues...)
 	}
+	issues = append(issues, s.runDetectors(ctx, SweepTypeReview, result)...)
 
 	// Create beads for found issues
 	for _, issue := range issues {
@@ -125,6 +161,7 @@ func (s *Sweeper) Bugs(ctx context.Context) (*SweepResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to find bug markers: %w", err)
 	}
+	issues = append(issues, s.runDetectors(ctx, SweepTypeBugs, result)...)
 
 	// Create beads for found issues
 	for _, issue := range issues {
@@ -343,12 +380,17 @@ func (s *Sweeper) findBugMarkers(ctx context.Context) ([]Issue, error) {
 	return issues, nil
 }
 
-// createBeadFromIssue creates a bead from a found issue
+// createBeadFromIssue creates a bead from a found issue. An issue that
+// already has an unclosed bead from an earlier sweep is skipped, so scheduled
+// sweeps don't file the same TODO every run.
 func (s *Sweeper) createBeadFromIssue(issue Issue, beadType models.BeadType) (*models.Bead, error) {
 	title := fmt.Sprintf("[%s] %s", issue.Type, issue.File)
 	if issue.Line > 0 {
 		title = fmt.Sprintf("[%s] %s:%d", issue.Type, issue.File, issue.Line)
 	}
+	if s.alreadyFiled(title) {
+		return nil, fmt.Errorf("already filed: %s", title)
+	}
 
 	description := issue.Description
 	if issue.Context != "" {
@@ -368,6 +410,20 @@ func (s *Sweeper) createBeadFromIssue(issue Issue, beadType models.BeadType) (*m
 	return s.beadStore.Create(bead)
 }
 
+// alreadyFiled reports whether an unclosed sweep bead with this title exists on the turf
+func (s *Sweeper) alreadyFiled(title string) bool {
+	beads, err := s.beadStore.List(storage.BeadFilter{Turf: s.turfPath})
+	if err != nil {
+		return false
+	}
+	for _, b := range beads {
+		if b.Title == title && b.DiscoveredFrom == "sweep" && b.Status != models.BeadStatusClosed {
+			return true
+		}
+	}
+	return false
+}
+
 // determineBeadType maps issue types to bead types
 func (s *Sweeper) determineBeadType(issueType string) models.BeadType {
 	switch strings.ToUpper(issueType) {
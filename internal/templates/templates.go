@@ -0,0 +1,254 @@
+// Package templates expands reusable bead structures kept as TOML files in
+// ~/mob/templates into a parent bead and its children.
+//
+// A template names the parent's title pattern, description, type, priority,
+// labels and checklist, and lists child beads with their own checklists.
+// Titles, descriptions and checklist items are text/template strings over
+// the variables given when the template is used:
+//
+//	about = "Everything a release needs"
+//	title = "Release {{.version}}"
+//	type = "epic"
+//	labels = "release"
+//
+//	[[children]]
+//	title = "Write the {{.version}} changelog"
+//	checklist = ["Breaking changes called out"]
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// ErrInvalidTemplate is returned when a template file cannot be used
+var ErrInvalidTemplate = errkind.New(errkind.Invalid, "invalid bead template")
+
+// TitleVar holds the title given when a template is used. A template without
+// a title pattern uses it as the parent's title.
+const TitleVar = "title"
+
+// Template is the contents of one ~/mob/templates/<name>.toml
+type Template struct {
+	Name        string   `toml:"-"`
+	About       string   `toml:"about"` // what the template is for, shown when listing
+	Title       string   `toml:"title"`
+	Description string   `toml:"description"`
+	Type        string   `toml:"type"`     // defaults to task; a parent with children becomes an epic
+	Priority    *int     `toml:"priority"` // defaults to 2
+	Labels      string   `toml:"labels"`
+	Checklist   []string `toml:"checklist"`
+	Children    []Child  `toml:"children"`
+}
+
+// Child is a bead created under the template's parent. Unset fields are
+// taken from the parent.
+type Child struct {
+	Title       string   `toml:"title"`
+	Description string   `toml:"description"`
+	Type        string   `toml:"type"`
+	Labels      string   `toml:"labels"`
+	Checklist   []string `toml:"checklist"`
+}
+
+// Dir returns where templates are kept for a mob directory
+func Dir(mobDir string) string {
+	return filepath.Join(mobDir, "templates")
+}
+
+// Load reads the named template from dir
+func Load(dir, name string) (*Template, error) {
+	path := filepath.Join(dir, name+".toml")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		msg := fmt.Sprintf("no bead template %q", name)
+		if names, _ := Names(dir); len(names) > 0 {
+			msg += fmt.Sprintf(" (have %s)", strings.Join(names, ", "))
+		} else {
+			msg += fmt.Sprintf(" (add one as %s)", path)
+		}
+		return nil, errkind.New(errkind.NotFound, msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	t := &Template{Name: name}
+	if _, err := toml.Decode(string(data), t); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, name, err)
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Names lists the templates in dir, sorted
+func Names(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".toml"); ok && !e.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// List loads every template in dir. Templates that fail to load are
+// returned as errors alongside the rest.
+func List(dir string) ([]*Template, []error) {
+	names, err := Names(dir)
+	if err != nil {
+		return nil, []error{err}
+	}
+	var list []*Template
+	var errs []error
+	for _, name := range names {
+		t, err := Load(dir, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		list = append(list, t)
+	}
+	return list, errs
+}
+
+// validate checks the fields that would otherwise fail only on use
+func (t *Template) validate() error {
+	if t.Priority != nil && (*t.Priority < 0 || *t.Priority > 4) {
+		return fmt.Errorf("%w: %s: priority must be 0-4", ErrInvalidTemplate, t.Name)
+	}
+	for i, c := range t.Children {
+		if strings.TrimSpace(c.Title) == "" {
+			return fmt.Errorf("%w: %s: child %d has no title", ErrInvalidTemplate, t.Name, i+1)
+		}
+	}
+	return nil
+}
+
+// Expand fills the template in with vars and returns the parent bead and
+// its children, not yet stored. Every variable a pattern uses must be set.
+func (t *Template) Expand(vars map[string]string) (*models.Bead, []*models.Bead, error) {
+	x := expander{name: t.Name, vars: vars}
+
+	title := x.text("title", t.Title)
+	if t.Title == "" {
+		title = vars[TitleVar]
+	}
+	parent := &models.Bead{
+		Title:       title,
+		Description: x.text("description", t.Description),
+		Status:      models.BeadStatusOpen,
+		Type:        models.BeadType(t.Type),
+		Priority:    2,
+		Labels:      models.ParseLabels(t.Labels),
+	}
+	if t.Priority != nil {
+		parent.Priority = *t.Priority
+	}
+	if parent.Type == "" {
+		parent.Type = models.BeadTypeTask
+	}
+	parent.AddChecklistItems(x.list("checklist", t.Checklist)...)
+
+	children := make([]*models.Bead, 0, len(t.Children))
+	for i, c := range t.Children {
+		field := fmt.Sprintf("children[%d]", i)
+		child := &models.Bead{
+			Title:       x.text(field+".title", c.Title),
+			Description: x.text(field+".description", c.Description),
+			Type:        models.BeadType(c.Type),
+			Labels:      models.ParseLabels(c.Labels),
+		}
+		child.AddChecklistItems(x.list(field+".checklist", c.Checklist)...)
+		children = append(children, child)
+	}
+
+	if x.err != nil {
+		return nil, nil, x.err
+	}
+	if strings.TrimSpace(parent.Title) == "" {
+		return nil, nil, errkind.New(errkind.Invalid, fmt.Sprintf("template %s has no title pattern; give the bead a title", t.Name))
+	}
+	return parent, children, nil
+}
+
+// Create expands the template and stores the parent and its children. The
+// parent is split into the children, as `mob bead split` does: they take its
+// turf and priority and block it, so it is ready only once they are closed.
+func Create(store *storage.BeadStore, t *Template, vars map[string]string, turf, actor string) (*models.Bead, []*models.Bead, error) {
+	parent, children, err := t.Expand(vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent.Turf = turf
+	parent.CreatedBy = actor
+	parent, err = store.Create(parent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create bead: %w", err)
+	}
+	if len(children) == 0 {
+		return parent, nil, nil
+	}
+
+	children, err = store.Split(parent.ID, children, actor)
+	if err != nil {
+		return parent, nil, fmt.Errorf("created %s but not its children: %w", parent.ID, err)
+	}
+	if parent, err = store.Get(parent.ID); err != nil {
+		return nil, nil, err
+	}
+	return parent, children, nil
+}
+
+// expander fills in patterns. After the first error it leaves the rest
+// alone and Expand reports that error.
+type expander struct {
+	name string
+	vars map[string]string
+	err  error
+}
+
+func (x *expander) text(field, pattern string) string {
+	if x.err != nil || !strings.Contains(pattern, "{{") {
+		return pattern
+	}
+	tmpl, err := template.New(field).Option("missingkey=error").Parse(pattern)
+	if err != nil {
+		x.err = fmt.Errorf("%w: %s: %s: %v", ErrInvalidTemplate, x.name, field, err)
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, x.vars); err != nil {
+		x.err = errkind.New(errkind.Invalid, fmt.Sprintf("template %s: %s uses a variable that was not given: %v", x.name, field, err))
+		return ""
+	}
+	return buf.String()
+}
+
+func (x *expander) list(field string, patterns []string) []string {
+	out := make([]string, 0, len(patterns))
+	for i, p := range patterns {
+		out = append(out, x.text(fmt.Sprintf("%s[%d]", field, i), p))
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+const releaseTemplate = `about = "Everything a release needs"
+title = "Release {{.version}}"
+description = "Ship {{.version}}"
+priority = 1
+labels = "release"
+checklist = ["Tagged {{.version}}", "Announced"]
+
+[[children]]
+title = "Write the {{.version}} changelog"
+type = "chore"
+checklist = ["Breaking changes called out"]
+
+[[children]]
+title = "Bump version to {{.version}}"
+labels = "build"
+`
+
+func writeTemplate(t *testing.T, dir, name, body string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".toml"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "release-checklist", releaseTemplate)
+
+	tmpl, err := Load(dir, "release-checklist")
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if tmpl.Name != "release-checklist" || tmpl.About != "Everything a release needs" || len(tmpl.Children) != 2 {
+		t.Errorf("unexpected template %+v", tmpl)
+	}
+
+	_, err = Load(dir, "missing")
+	if !errors.Is(err, errkind.NotFound) {
+		t.Errorf("expected NotFound for a missing template, got %v", err)
+	}
+
+	writeTemplate(t, dir, "broken", "priority = 9\n")
+	if _, err := Load(dir, "broken"); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("expected ErrInvalidTemplate for priority 9, got %v", err)
+	}
+
+	list, errs := List(dir)
+	if len(list) != 1 || len(errs) != 1 {
+		t.Errorf("expected one template and one error, got %d and %v", len(list), errs)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "release-checklist", releaseTemplate)
+	tmpl, err := Load(dir, "release-checklist")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent, children, err := tmpl.Expand(map[string]string{"version": "1.4"})
+	if err != nil {
+		t.Fatalf("Expand() returned error: %v", err)
+	}
+	if parent.Title != "Release 1.4" || parent.Description != "Ship 1.4" || parent.Priority != 1 {
+		t.Errorf("unexpected parent %q %q P%d", parent.Title, parent.Description, parent.Priority)
+	}
+	if len(parent.Checklist) != 2 || parent.Checklist[0].Text != "Tagged 1.4" {
+		t.Errorf("unexpected parent checklist %+v", parent.Checklist)
+	}
+	if len(children) != 2 || children[0].Title != "Write the 1.4 changelog" || children[0].Type != models.BeadTypeChore {
+		t.Fatalf("unexpected children %+v", children)
+	}
+	if len(children[0].Checklist) != 1 || !children[1].HasLabel("build") {
+		t.Errorf("unexpected child fields %+v %+v", children[0], children[1])
+	}
+
+	_, _, err = tmpl.Expand(nil)
+	if !errors.Is(err, errkind.Invalid) {
+		t.Errorf("expected Invalid when a variable is missing, got %v", err)
+	}
+}
+
+func TestExpand_TitleFromCaller(t *testing.T) {
+	tmpl := &Template{Name: "bugfix", Checklist: []string{"Regression test"}}
+
+	if _, _, err := tmpl.Expand(nil); !errors.Is(err, errkind.Invalid) {
+		t.Errorf("expected Invalid without a title, got %v", err)
+	}
+	parent, _, err := tmpl.Expand(map[string]string{TitleVar: "Login loops"})
+	if err != nil {
+		t.Fatalf("Expand() returned error: %v", err)
+	}
+	if parent.Title != "Login loops" || parent.Type != models.BeadTypeTask || parent.Priority != 2 {
+		t.Errorf("unexpected parent %q %s P%d", parent.Title, parent.Type, parent.Priority)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "release-checklist", releaseTemplate)
+	tmpl, err := Load(dir, "release-checklist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := storage.NewBeadStore(filepath.Join(t.TempDir(), "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent, children, err := Create(store, tmpl, map[string]string{"version": "1.4"}, "api", "user")
+	if err != nil {
+		t.Fatalf("Create() returned error: %v", err)
+	}
+	if parent.Type != models.BeadTypeEpic || parent.Turf != "api" {
+		t.Errorf("expected an epic on api, got %s on %q", parent.Type, parent.Turf)
+	}
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	for _, c := range children {
+		stored, err := store.Get(c.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.ParentID != parent.ID || stored.Turf != "api" || stored.Priority != 1 {
+			t.Errorf("child %s: parent %q turf %q P%d", c.ID, stored.ParentID, stored.Turf, stored.Priority)
+		}
+		if len(stored.Blocks) != 1 || stored.Blocks[0] != parent.ID {
+			t.Errorf("expected child %s to block %s, got %v", c.ID, parent.ID, stored.Blocks)
+		}
+	}
+
+	ready, err := store.ListReady("api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range ready {
+		if b.ID == parent.ID {
+			t.Error("expected the parent to wait on its children")
+		}
+	}
+}
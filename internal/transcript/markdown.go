@@ -0,0 +1,85 @@
+package transcript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxToolResultLength caps tool output embedded in exported markdown
+const maxToolResultLength = 2000
+
+// RenderMarkdown renders a transcript as clean markdown with tool calls
+// collapsed into <details> blocks and a usage summary at the end
+func RenderMarkdown(t *Transcript) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Session %s\n\n", t.SessionID))
+	if len(t.Entries) > 0 && !t.Entries[0].Timestamp.IsZero() {
+		sb.WriteString(fmt.Sprintf("_Started %s_\n\n", t.Entries[0].Timestamp.Format("2006-01-02 15:04 MST")))
+	}
+
+	for _, e := range t.Entries {
+		heading := "User"
+		if e.Role == RoleAssistant {
+			heading = "Assistant"
+			if e.Model != "" {
+				heading += fmt.Sprintf(" (%s)", e.Model)
+			}
+		}
+		sb.WriteString(fmt.Sprintf("## %s\n\n", heading))
+
+		if e.Text != "" {
+			sb.WriteString(strings.TrimSpace(e.Text))
+			sb.WriteString("\n\n")
+		}
+
+		for _, call := range e.ToolCalls {
+			writeToolCall(&sb, call)
+		}
+	}
+
+	in, out, cost := t.TotalUsage()
+	sb.WriteString("---\n\n")
+	sb.WriteString(fmt.Sprintf("**Usage:** %d input tokens, %d output tokens", in, out))
+	if cost > 0 {
+		sb.WriteString(fmt.Sprintf(", $%.4f", cost))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// writeToolCall renders a tool call as a collapsed details block
+func writeToolCall(sb *strings.Builder, call *ToolCall) {
+	summary := fmt.Sprintf("Tool: %s", call.Name)
+	if call.IsError {
+		summary += " (error)"
+	}
+	sb.WriteString(fmt.Sprintf("<details>\n<summary>%s</summary>\n\n", summary))
+
+	if call.Input != "" && call.Input != "null" && call.Input != "{}" {
+		sb.WriteString("```json\n")
+		sb.WriteString(call.Input)
+		sb.WriteString("\n```\n\n")
+	}
+
+	if call.Result != "" {
+		result := call.Result
+		if len(result) > maxToolResultLength {
+			result = result[:maxToolResultLength] + "\n… (truncated)"
+		}
+		sb.WriteString(fence(result))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("</details>\n\n")
+}
+
+// fence wraps text in a code fence long enough not to collide with backticks inside it
+func fence(text string) string {
+	marker := "```"
+	for strings.Contains(text, marker) {
+		marker += "`"
+	}
+	return marker + "\n" + text + "\n" + marker
+}
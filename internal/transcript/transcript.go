@@ -0,0 +1,304 @@
+// Package transcript reads Claude session transcripts and renders them for sharing.
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/git"
+)
+
+// EntryRole identifies who produced a transcript entry
+type EntryRole string
+
+const (
+	RoleUser      EntryRole = "user"
+	RoleAssistant EntryRole = "assistant"
+)
+
+// ToolCall is a tool invocation and, once available, its result
+type ToolCall struct {
+	ID      string
+	Name    string
+	Input   string // JSON-encoded input
+	Result  string
+	IsError bool
+}
+
+// Entry is one user or assistant turn in a transcript
+type Entry struct {
+	Role         EntryRole
+	Timestamp    time.Time
+	Text         string
+	ToolCalls    []*ToolCall
+	Model        string
+	InputTokens  int
+	OutputTokens int
+	CostUSD      float64
+}
+
+// Transcript is a parsed Claude session
+type Transcript struct {
+	SessionID string
+	Path      string
+	Entries   []*Entry
+}
+
+// TotalUsage sums tokens and cost across all entries
+func (t *Transcript) TotalUsage() (inputTokens, outputTokens int, costUSD float64) {
+	for _, e := range t.Entries {
+		inputTokens += e.InputTokens
+		outputTokens += e.OutputTokens
+		costUSD += e.CostUSD
+	}
+	return inputTokens, outputTokens, costUSD
+}
+
+// rawLine is the subset of a session JSONL line we care about
+type rawLine struct {
+	Type      string      `json:"type"`
+	SessionID string      `json:"sessionId"`
+	Timestamp time.Time   `json:"timestamp"`
+	CostUSD   float64     `json:"costUSD"`
+	Message   *rawMessage `json:"message"`
+}
+
+type rawMessage struct {
+	Role    string          `json:"role"`
+	Model   string          `json:"model"`
+	Content json.RawMessage `json:"content"`
+	Usage   *struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+type rawBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+// ProjectsDir returns ~/.claude/projects, where Claude keeps session transcripts
+func ProjectsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".claude", "projects"), nil
+}
+
+// ProjectKey returns the directory name under ProjectsDir that Claude uses
+// for sessions started in workDir
+func ProjectKey(workDir string) string {
+	return strings.NewReplacer("/", "-", ".", "-").Replace(workDir)
+}
+
+// OwnedBy reports whether the project directory name holds sessions started
+// in one of workDirs or in one of its bead worktrees. Other directories
+// whose keys share the prefix, such as a sibling /x/app-v2 of /x/app, are
+// not matched.
+func OwnedBy(project string, workDirs []string) bool {
+	for _, dir := range workDirs {
+		worktrees := ProjectKey(filepath.Join(dir, git.WorktreesDir))
+		if project == ProjectKey(dir) || strings.HasPrefix(project, worktrees+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// Sessions returns the transcripts under projectsDir started in workDirs and
+// written to since the given time. A missing projectsDir yields nothing.
+func Sessions(projectsDir string, workDirs []string, since time.Time) ([]string, error) {
+	projects, err := os.ReadDir(projectsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, p := range projects {
+		if !p.IsDir() || !OwnedBy(p.Name(), workDirs) {
+			continue
+		}
+		dir := filepath.Join(projectsDir, p.Name())
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return paths, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".jsonl") {
+				continue
+			}
+			if info, err := e.Info(); err == nil && !info.ModTime().Before(since) {
+				paths = append(paths, filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	return paths, nil
+}
+
+// Find locates the transcript file for a session ID under ~/.claude/projects.
+// A path to an existing file is returned unchanged.
+func Find(session string) (string, error) {
+	if info, err := os.Stat(session); err == nil && !info.IsDir() {
+		return session, nil
+	}
+
+	projects, err := ProjectsDir()
+	if err != nil {
+		return "", err
+	}
+
+	matches, err := filepath.Glob(filepath.Join(projects, "*", session+".jsonl"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("transcript not found for session: %s", session)
+	}
+	return matches[0], nil
+}
+
+// Load parses a Claude session JSONL file
+func Load(path string) (*Transcript, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t := &Transcript{
+		Path:      path,
+		SessionID: strings.TrimSuffix(filepath.Base(path), ".jsonl"),
+	}
+	calls := make(map[string]*ToolCall)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 50*1024*1024)
+	for scanner.Scan() {
+		var line rawLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue // Skip malformed lines
+		}
+		if line.Message == nil || (line.Type != "user" && line.Type != "assistant") {
+			continue
+		}
+		if line.SessionID != "" {
+			t.SessionID = line.SessionID
+		}
+		t.addLine(line, calls)
+	}
+
+	return t, scanner.Err()
+}
+
+// addLine folds a raw line into the transcript, attaching tool results to their calls
+func (t *Transcript) addLine(line rawLine, calls map[string]*ToolCall) {
+	entry := &Entry{
+		Role:      EntryRole(line.Type),
+		Timestamp: line.Timestamp,
+		Model:     line.Message.Model,
+		CostUSD:   line.CostUSD,
+	}
+	if line.Message.Usage != nil {
+		entry.InputTokens = line.Message.Usage.InputTokens
+		entry.OutputTokens = line.Message.Usage.OutputTokens
+	}
+
+	// Plain string content
+	var text string
+	if err := json.Unmarshal(line.Message.Content, &text); err == nil {
+		entry.Text = text
+		t.append(entry)
+		return
+	}
+
+	var blocks []rawBlock
+	if err := json.Unmarshal(line.Message.Content, &blocks); err != nil {
+		return
+	}
+
+	var texts []string
+	for _, b := range blocks {
+		switch b.Type {
+		case "text":
+			if strings.TrimSpace(b.Text) != "" {
+				texts = append(texts, b.Text)
+			}
+		case "tool_use":
+			call := &ToolCall{ID: b.ID, Name: b.Name, Input: string(b.Input)}
+			calls[b.ID] = call
+			entry.ToolCalls = append(entry.ToolCalls, call)
+		case "tool_result":
+			if call, ok := calls[b.ToolUseID]; ok {
+				call.Result = toolResultText(b.Content)
+				call.IsError = b.IsError
+			}
+		}
+	}
+	entry.Text = strings.Join(texts, "\n\n")
+
+	// Lines that only carried tool results don't become their own turn
+	if entry.Text == "" && len(entry.ToolCalls) == 0 {
+		return
+	}
+	t.append(entry)
+}
+
+// append adds an entry, merging consecutive assistant entries into one turn
+func (t *Transcript) append(entry *Entry) {
+	if n := len(t.Entries); n > 0 && entry.Role == RoleAssistant && t.Entries[n-1].Role == RoleAssistant {
+		prev := t.Entries[n-1]
+		if entry.Text != "" {
+			if prev.Text != "" {
+				prev.Text += "\n\n"
+			}
+			prev.Text += entry.Text
+		}
+		prev.ToolCalls = append(prev.ToolCalls, entry.ToolCalls...)
+		prev.InputTokens += entry.InputTokens
+		prev.OutputTokens += entry.OutputTokens
+		prev.CostUSD += entry.CostUSD
+		if prev.Model == "" {
+			prev.Model = entry.Model
+		}
+		return
+	}
+	t.Entries = append(t.Entries, entry)
+}
+
+// toolResultText flattens a tool_result content payload (string or text blocks)
+func toolResultText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var blocks []rawBlock
+	if err := json.Unmarshal(raw, &blocks); err == nil {
+		var parts []string
+		for _, b := range blocks {
+			if b.Type == "text" {
+				parts = append(parts, b.Text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+	return string(raw)
+}
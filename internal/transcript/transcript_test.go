@@ -0,0 +1,135 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/git"
+)
+
+const sampleSession = `{"type":"queue-operation","operation":"enqueue"}
+{"type":"user","sessionId":"sess-1","timestamp":"2026-01-14T10:00:00Z","message":{"role":"user","content":"Fix the login bug"}}
+{"type":"assistant","sessionId":"sess-1","timestamp":"2026-01-14T10:00:05Z","message":{"role":"assistant","model":"claude-sonnet","content":[{"type":"text","text":"Looking at auth.go."},{"type":"tool_use","id":"call-1","name":"Read","input":{"file_path":"auth.go"}}],"usage":{"input_tokens":100,"output_tokens":20}}}
+{"type":"user","sessionId":"sess-1","timestamp":"2026-01-14T10:00:06Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"call-1","content":"package auth"}]}}
+{"type":"assistant","sessionId":"sess-1","timestamp":"2026-01-14T10:00:09Z","costUSD":0.01,"message":{"role":"assistant","model":"claude-sonnet","content":[{"type":"text","text":"Fixed it."}],"usage":{"input_tokens":150,"output_tokens":10}}}
+not json
+`
+
+func writeSample(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sess-1.jsonl")
+	if err := os.WriteFile(path, []byte(sampleSession), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	tr, err := Load(writeSample(t))
+	if err != nil {
+		t.Fatalf("failed to load transcript: %v", err)
+	}
+
+	if tr.SessionID != "sess-1" {
+		t.Errorf("expected session sess-1, got %q", tr.SessionID)
+	}
+	if len(tr.Entries) != 2 {
+		t.Fatalf("expected user + merged assistant entries, got %d", len(tr.Entries))
+	}
+
+	assistant := tr.Entries[1]
+	if assistant.Text != "Looking at auth.go.\n\nFixed it." {
+		t.Errorf("unexpected assistant text %q", assistant.Text)
+	}
+	if len(assistant.ToolCalls) != 1 || assistant.ToolCalls[0].Result != "package auth" {
+		t.Fatalf("expected tool result attached to call, got %+v", assistant.ToolCalls)
+	}
+
+	in, out, cost := tr.TotalUsage()
+	if in != 250 || out != 30 || cost != 0.01 {
+		t.Errorf("unexpected usage: in=%d out=%d cost=%v", in, out, cost)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tr, err := Load(writeSample(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md := RenderMarkdown(tr)
+	for _, want := range []string{
+		"# Session sess-1",
+		"## User\n\nFix the login bug",
+		"## Assistant (claude-sonnet)",
+		"<summary>Tool: Read</summary>",
+		`"file_path":"auth.go"`,
+		"package auth",
+		"**Usage:** 250 input tokens, 30 output tokens, $0.0100",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestFenceAvoidsCollisions(t *testing.T) {
+	got := fence("has ``` inside")
+	if !strings.HasPrefix(got, "````\n") {
+		t.Errorf("expected longer fence, got %q", got)
+	}
+}
+
+func TestFindAcceptsPath(t *testing.T) {
+	path := writeSample(t)
+	found, err := Find(path)
+	if err != nil || found != path {
+		t.Errorf("expected path returned unchanged, got %q (%v)", found, err)
+	}
+	if _, err := Find("definitely-not-a-session"); err == nil {
+		t.Error("expected error for unknown session")
+	}
+}
+
+func TestSessions(t *testing.T) {
+	projects := t.TempDir()
+	turf := "/home/don/api"
+	write := func(project, name string, age time.Duration) string {
+		dir := filepath.Join(projects, project)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(sampleSession), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mod := time.Now().Add(-age)
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	recent := write(ProjectKey(turf), "a.jsonl", time.Hour)
+	worktree := write(ProjectKey(filepath.Join(turf, git.WorktreesDir, "bd-1")), "b.jsonl", time.Hour)
+	write(ProjectKey(turf+"-v2"), "sibling.jsonl", time.Hour)
+	write(ProjectKey(turf), "old.jsonl", 48*time.Hour)
+	write(ProjectKey(turf), "notes.txt", time.Hour)
+	write(ProjectKey("/home/don/other"), "c.jsonl", time.Hour)
+
+	paths, err := Sessions(projects, []string{turf}, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || !slices.Contains(paths, recent) || !slices.Contains(paths, worktree) {
+		t.Errorf("expected the turf's recent sessions, got %v", paths)
+	}
+
+	if paths, err := Sessions(filepath.Join(projects, "missing"), []string{turf}, time.Time{}); err != nil || len(paths) != 0 {
+		t.Errorf("expected nothing from a missing directory, got %v, %v", paths, err)
+	}
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/approval"
+)
+
+// Keys for answering the underboss's pending tool calls
+const (
+	KeyApprove = "y" // run every pending call
+	KeyDecline = "n" // turn every pending call down
+)
+
+// ApprovalPollInterval is how often the chat checks for tool calls waiting
+// on the user while a response is in flight
+const ApprovalPollInterval = 500 * time.Millisecond
+
+// Approver lists the tool calls waiting for the user and answers them.
+// approval.Store fits.
+type Approver interface {
+	Pending() ([]*approval.Request, error)
+	Decide(ids []string, decision approval.Decision) error
+}
+
+// ApprovalsMsg carries the tool calls waiting while a stream is in flight
+type ApprovalsMsg struct {
+	Stream   int
+	Requests []*approval.Request
+}
+
+// pollApprovals schedules the next check for calls waiting during stream id
+func (m Model) pollApprovals(id int) tea.Cmd {
+	if m.approver == nil {
+		return nil
+	}
+	approver := m.approver
+	return tea.Tick(ApprovalPollInterval, func(time.Time) tea.Msg {
+		reqs, _ := approver.Pending()
+		return ApprovalsMsg{Stream: id, Requests: reqs}
+	})
+}
+
+// handleApprovals shows the calls waiting on the user. Polling stops when
+// the stream it belongs to ends.
+func (m Model) handleApprovals(msg ApprovalsMsg) (tea.Model, tea.Cmd) {
+	if m.stream == nil || msg.Stream != m.stream.id {
+		return m, nil
+	}
+	m.Approvals = msg.Requests
+	return m, m.pollApprovals(msg.Stream)
+}
+
+// decideApprovals answers every call shown, reporting the outcome as a toast
+func (m Model) decideApprovals(decision approval.Decision) (tea.Model, tea.Cmd) {
+	ids := make([]string, 0, len(m.Approvals))
+	for _, r := range m.Approvals {
+		ids = append(ids, r.ID)
+	}
+	summary := approval.Summarize(m.Approvals)
+	m.Approvals = nil
+
+	if err := m.approver.Decide(ids, decision); err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Could not answer: %v", err)})
+		return m, nil
+	}
+	if decision == approval.Approved {
+		m.Toasts.Push(Toast{Message: "Approved: " + summary})
+	} else {
+		m.Toasts.Push(Toast{Message: "Declined: " + summary})
+	}
+	return m, nil
+}
+
+// declineAllApprovals turns down every waiting call, so a cancelled
+// response does not leave its tools blocked
+func (m *Model) declineAllApprovals() {
+	m.Approvals = nil
+	if m.approver == nil {
+		return
+	}
+	reqs, err := m.approver.Pending()
+	if err != nil || len(reqs) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(reqs))
+	for _, r := range reqs {
+		ids = append(ids, r.ID)
+	}
+	_ = m.approver.Decide(ids, approval.Declined)
+}
+
+// ApprovalView lists the calls waiting on the user, or is empty when none are
+func (m Model) ApprovalView() string {
+	if len(m.Approvals) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "The underboss wants to %s:", approval.Summarize(m.Approvals))
+	for _, r := range m.Approvals {
+		line := fmt.Sprintf("\n  %s %s", r.Verb, r.Noun)
+		if r.Detail != "" {
+			line += ": " + r.Detail
+		}
+		b.WriteString(line)
+	}
+	fmt.Fprintf(&b, "\n%s approve  %s decline", KeyApprove, KeyDecline)
+	return b.String()
+}
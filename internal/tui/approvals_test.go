@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/approval"
+)
+
+type fakeApprover struct {
+	pending []*approval.Request
+	decided map[string]approval.Decision
+}
+
+func (f *fakeApprover) Pending() ([]*approval.Request, error) {
+	return f.pending, nil
+}
+
+func (f *fakeApprover) Decide(ids []string, decision approval.Decision) error {
+	for _, id := range ids {
+		f.decided[id] = decision
+	}
+	return nil
+}
+
+func TestApprovalsPromptAndAnswer(t *testing.T) {
+	approver := &fakeApprover{decided: make(map[string]approval.Decision)}
+	m := NewModel()
+	m.approver = approver
+	m.stream = &stream{id: 1, live: map[int]agent.ChatContentBlock{}}
+
+	reqs := []*approval.Request{
+		{ID: "cf-1", Verb: "create", Noun: "bead", Nouns: "beads", Detail: "Fix login"},
+		{ID: "cf-2", Verb: "create", Noun: "bead", Nouns: "beads", Detail: "Add SSO"},
+		{ID: "cf-3", Verb: "spawn", Noun: "associate", Nouns: "associates", Detail: "api"},
+	}
+	model, cmd := m.Update(ApprovalsMsg{Stream: 1, Requests: reqs})
+	m = model.(Model)
+	if cmd == nil {
+		t.Error("expected polling to continue while the stream is in flight")
+	}
+	view := m.View()
+	for _, want := range []string{"wants to create 2 beads, spawn 1 associate", "create bead: Fix login", "y approve  n decline"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in:\n%s", want, view)
+		}
+	}
+
+	// Other keys wait for an answer
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+	if len(m.Approvals) != 3 {
+		t.Fatal("expected the prompt to stay until answered")
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = model.(Model)
+	if len(m.Approvals) != 0 || len(approver.decided) != 3 || approver.decided["cf-2"] != approval.Approved {
+		t.Fatalf("expected every call approved, got %v", approver.decided)
+	}
+	if toast, _ := m.Toasts.Pop(); toast.Message != "Approved: create 2 beads, spawn 1 associate" {
+		t.Errorf("unexpected toast %q", toast.Message)
+	}
+
+	// Polls for a finished stream are dropped
+	model, cmd = m.Update(ApprovalsMsg{Stream: 0, Requests: reqs})
+	if len(model.(Model).Approvals) != 0 || cmd != nil {
+		t.Error("expected a stale poll ignored")
+	}
+}
+
+func TestCancelStreamDeclinesWaitingCalls(t *testing.T) {
+	approver := &fakeApprover{
+		pending: []*approval.Request{{ID: "cf-1", Verb: "kill", Noun: "agent", Nouns: "agents"}},
+		decided: make(map[string]approval.Decision),
+	}
+	m := NewModel()
+	m.approver = approver
+	m.stream = &stream{id: 1, cancel: func() {}, live: map[int]agent.ChatContentBlock{}}
+
+	m = drive(t, m, CancelStreamMsg{})
+	if approver.decided["cf-1"] != approval.Declined {
+		t.Errorf("expected the waiting call declined, got %v", approver.decided)
+	}
+}
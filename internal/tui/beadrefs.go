@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gabe/mob/internal/approval"
+	"github.com/gabe/mob/internal/models"
+)
+
+// Keys for bead references in chat output
+const (
+	KeyPrevRef   = "ctrl+p" // select the previous bead reference
+	KeyNextRef   = "ctrl+n" // select the next bead reference
+	KeyOpenRef   = "enter"  // open the selected bead's detail view
+	KeyCopyRef   = "ctrl+y" // copy the selected bead's ID
+	KeyCloseView = "esc"    // close the detail view, then clear the selection
+)
+
+// beadRefPattern matches bead IDs such as bd-a1b2
+var beadRefPattern = regexp.MustCompile(`\bbd-[0-9a-f]{4,}\b`)
+
+// FindBeadRefs returns the distinct bead IDs mentioned in text, in order of
+// first mention
+func FindBeadRefs(text string) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	for _, id := range beadRefPattern.FindAllString(text, -1) {
+		if !seen[id] {
+			seen[id] = true
+			refs = append(refs, id)
+		}
+	}
+	return refs
+}
+
+// ChatMsg is a finished piece of chat output
+type ChatMsg struct {
+	Text string
+}
+
+// BeadDetailMsg carries a bead loaded for the detail view
+type BeadDetailMsg struct {
+	ID   string
+	Bead *models.Bead
+	Epic *models.EpicProgress // set when the bead is an epic and its progress loaded
+	Err  error
+}
+
+var (
+	beadRefStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color(NewStyles().Primary)).Underline(true)
+	beadRefFocusStyle = beadRefStyle.Reverse(true)
+)
+
+// copyToClipboard sets the terminal's clipboard with an OSC 52 sequence,
+// which also reaches the local clipboard over SSH; replaced in tests
+var copyToClipboard = func(text string) error {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	}
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
+
+// handleChat appends chat output and makes its bead references selectable
+func (m Model) handleChat(msg ChatMsg) (tea.Model, tea.Cmd) {
+	m.addChat(msg.Text)
+	return m, nil
+}
+
+// addChat appends finished output to the chat and records its bead references
+func (m *Model) addChat(text string) {
+	m.Chat = append(m.Chat, text)
+	for _, id := range FindBeadRefs(text) {
+		m.addBeadRef(id)
+	}
+}
+
+// addBeadRef records a reference, moving a repeated one to the end so the
+// most recent mentions are nearest the selection's starting point
+func (m *Model) addBeadRef(id string) {
+	for i, ref := range m.beadRefs {
+		if ref == id {
+			m.beadRefs = append(m.beadRefs[:i:i], m.beadRefs[i+1:]...)
+			if m.selectedRef == i {
+				m.selectedRef = len(m.beadRefs)
+			} else if m.selectedRef > i {
+				m.selectedRef--
+			}
+			break
+		}
+	}
+	m.beadRefs = append(m.beadRefs, id)
+}
+
+// SelectedRef returns the selected bead reference, if any
+func (m Model) SelectedRef() (string, bool) {
+	if m.selectedRef < 0 || m.selectedRef >= len(m.beadRefs) {
+		return "", false
+	}
+	return m.beadRefs[m.selectedRef], true
+}
+
+// handleKey switches tabs and handles bead reference keys on the chat tab
+// and the agents and beads tabs' keys
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Tool calls waiting on the user take the keyboard until answered
+	if m.stream != nil && msg.String() == KeyInterrupt {
+		return m.handleCancelStream()
+	}
+	if len(m.Approvals) > 0 && m.approver != nil {
+		switch msg.String() {
+		case KeyApprove:
+			return m.decideApprovals(approval.Approved)
+		case KeyDecline:
+			return m.decideApprovals(approval.Declined)
+		}
+		return m, nil
+	}
+	if m.selection.Active {
+		return m.handleSelectKey(msg)
+	}
+	if msg.String() == KeySelect {
+		return m.startSelection()
+	}
+	if m.search.Active {
+		return m.handleSearchKey(msg)
+	}
+	if key := msg.String(); key == KeySearchChat || key == KeySearch && (m.ActiveTab == TabDaemon || m.ActiveTab == TabAgentOutput) {
+		if lines, _ := m.selectableLines(); len(lines) > 0 {
+			return m.startSearch()
+		}
+	}
+	if m.ActiveTab == TabChat && len(m.Picker) > 0 && msg.String() != KeyNextTab && msg.String() != KeyPrevTab {
+		return m.handlePickerKey(msg)
+	}
+	if m.ActiveTab == TabApprovals && m.ApprovalsTab.Prompt.Active() {
+		return m.handleRejectPrompt(msg)
+	}
+	if m.ActiveTab == TabBeads && m.BeadsTab.Prompt.Active() {
+		return m.handleBeadPrompt(msg)
+	}
+	if m.ActiveTab == TabAgents && m.AgentsTab.Prompt.Active() {
+		return m.handleAgentPrompt(msg)
+	}
+	if m.ActiveTab == TabChat && !m.Observe && m.BeadDetail == nil {
+		if m.switcher.Open {
+			return m.handleSwitcherKey(msg)
+		}
+		if msg.String() == KeyModels {
+			return m.openSwitcher()
+		}
+		if m.stream != nil && msg.String() == KeyCancelStream && m.Input == "" {
+			return m.handleCancelStream()
+		}
+		if model, cmd, ok := m.handleInputKey(msg); ok {
+			return model, cmd
+		}
+	}
+	switch msg.String() {
+	case KeyNextTab:
+		return m.switchTab(1)
+	case KeyPrevTab:
+		return m.switchTab(-1)
+	}
+	switch m.ActiveTab {
+	case TabAgents:
+		return m.handleAgentsKey(msg)
+	case TabApprovals:
+		return m.handleApprovalsKey(msg)
+	case TabBeads:
+		return m.handleBeadsKey(msg)
+	case TabNotifications:
+		return m.handleNotificationsKey(msg)
+	case TabCosts:
+		return m.handleCostsKey(msg)
+	}
+	if m.Observe || m.ActiveTab != TabChat {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case KeyPrevRef:
+		switch {
+		case len(m.beadRefs) == 0:
+		case m.selectedRef < 0:
+			m.selectedRef = len(m.beadRefs) - 1
+		case m.selectedRef > 0:
+			m.selectedRef--
+		}
+	case KeyNextRef:
+		if m.selectedRef >= 0 && m.selectedRef < len(m.beadRefs)-1 {
+			m.selectedRef++
+		}
+	case KeyOpenRef:
+		if id, ok := m.SelectedRef(); ok {
+			return m, m.openBead(id)
+		}
+	case KeyCopyRef:
+		if id, ok := m.SelectedRef(); ok {
+			m.copyText(id, id)
+		}
+	case KeyCloseView:
+		if m.BeadDetail != nil {
+			m.BeadDetail, m.EpicDetail = nil, nil
+		} else {
+			m.selectedRef = -1
+		}
+	}
+	return m, nil
+}
+
+// openBead loads a bead for the detail view, with its progress if it is an epic
+func (m Model) openBead(id string) tea.Cmd {
+	load, loadEpic := m.loadBead, m.loadEpic
+	return func() tea.Msg {
+		if load == nil {
+			return BeadDetailMsg{ID: id, Err: fmt.Errorf("bead store unavailable")}
+		}
+		bead, err := load(id)
+		msg := BeadDetailMsg{ID: id, Bead: bead, Err: err}
+		if err == nil && bead.Type == models.BeadTypeEpic && loadEpic != nil {
+			msg.Epic, _ = loadEpic(id) // the detail view still shows without it
+		}
+		return msg
+	}
+}
+
+// handleBeadDetail shows a loaded bead, or reports why it could not be loaded
+func (m Model) handleBeadDetail(msg BeadDetailMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Cannot open %s: %v", msg.ID, msg.Err)})
+		return m, nil
+	}
+	m.BeadDetail = msg.Bead
+	m.EpicDetail = msg.Epic
+	return m, nil
+}
+
+// ChatView renders chat output with bead references highlighted and the
+// selected one marked, or the open bead's detail view
+func (m Model) ChatView() string {
+	if m.BeadDetail != nil {
+		if m.EpicDetail != nil {
+			return EpicPanel(m.EpicDetail) + "\n\n" + BeadDetailView(m.BeadDetail)
+		}
+		return BeadDetailView(m.BeadDetail)
+	}
+
+	selected, _ := m.SelectedRef()
+	var b strings.Builder
+	for i, text := range m.Chat {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(beadRefPattern.ReplaceAllStringFunc(text, func(id string) string {
+			if id == selected {
+				return beadRefFocusStyle.Render(id)
+			}
+			return beadRefStyle.Render(id)
+		}))
+	}
+	if m.stream != nil {
+		if text := m.stream.text(); text != "" {
+			b.WriteString("\n" + text)
+		}
+		b.WriteString("\n" + hintStyle.Render(fmt.Sprintf("%s or %s to cancel", KeyCancelStream, KeyInterrupt)))
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(m.InputView())
+	return b.String()
+}
+
+// BeadDetailView renders a bead's fields and description
+func BeadDetailView(bead *models.Bead) string {
+	return beadSummary(bead) + "\n\n" + KeyCloseView + " to return"
+}
+
+// beadSummary renders a bead's fields, description and links
+func beadSummary(bead *models.Bead) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s\n", bead.ID, bead.Title)
+	fmt.Fprintf(&b, "Status: %s  Priority: P%d  Type: %s", bead.Status, bead.Priority, bead.Type)
+	if bead.Assignee != "" {
+		fmt.Fprintf(&b, "  Assignee: %s", bead.Assignee)
+	}
+	if bead.Turf != "" {
+		fmt.Fprintf(&b, "  Turf: %s", bead.Turf)
+	}
+	if len(bead.Labels) > 0 {
+		b.WriteString("\n" + LabelChips(bead.Labels))
+	}
+	if bead.Description != "" {
+		b.WriteString("\n\n" + bead.Description)
+	}
+	writeBeadList(&b, "Blocks", bead.Blocks)
+	writeBeadList(&b, "Related", bead.Related)
+	return b.String()
+}
+
+func writeBeadList(w io.Writer, label string, ids []string) {
+	if len(ids) > 0 {
+		fmt.Fprintf(w, "\n%s: %s", label, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestFindBeadRefs(t *testing.T) {
+	got := FindBeadRefs("Filed bd-a1b2 and bd-c3d4; bd-a1b2 blocks bd-c3d4. Not bd-xyz or abd-1234.")
+	want := []string{"bd-a1b2", "bd-c3d4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func press(model tea.Model, key string) (tea.Model, tea.Cmd) {
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "ctrl+p":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlP}
+	case "ctrl+n":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlN}
+	case "ctrl+y":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlY}
+	}
+	return model.Update(msg)
+}
+
+func TestBeadRefSelection(t *testing.T) {
+	var model tea.Model = NewModel()
+	model, _ = model.Update(ChatMsg{Text: "Created bd-aaaa and bd-bbbb."})
+	model, _ = model.Update(ChatMsg{Text: "bd-aaaa is ready for review."})
+
+	if _, ok := model.(Model).SelectedRef(); ok {
+		t.Fatal("expected nothing selected before navigating")
+	}
+
+	// Selection starts at the most recent mention
+	model, _ = press(model, KeyPrevRef)
+	if id, _ := model.(Model).SelectedRef(); id != "bd-aaaa" {
+		t.Fatalf("expected bd-aaaa selected, got %q", id)
+	}
+	model, _ = press(model, KeyPrevRef)
+	if id, _ := model.(Model).SelectedRef(); id != "bd-bbbb" {
+		t.Fatalf("expected bd-bbbb selected, got %q", id)
+	}
+	model, _ = press(model, KeyPrevRef)
+	if id, _ := model.(Model).SelectedRef(); id != "bd-bbbb" {
+		t.Fatalf("expected selection to stop at the oldest reference, got %q", id)
+	}
+	model, _ = press(model, KeyNextRef)
+	if id, _ := model.(Model).SelectedRef(); id != "bd-aaaa" {
+		t.Fatalf("expected bd-aaaa selected, got %q", id)
+	}
+
+	model, _ = press(model, KeyCloseView)
+	if _, ok := model.(Model).SelectedRef(); ok {
+		t.Fatal("expected esc to clear the selection")
+	}
+}
+
+func TestOpenBeadRef(t *testing.T) {
+	m := NewModel()
+	m.loadBead = func(id string) (*models.Bead, error) {
+		if id != "bd-aaaa" {
+			return nil, errors.New("not found")
+		}
+		return &models.Bead{ID: id, Title: "Fix login", Status: models.BeadStatusOpen, Description: "Session cookie expires early"}, nil
+	}
+
+	var model tea.Model = m
+	model, _ = model.Update(ChatMsg{Text: "Working on bd-aaaa now."})
+
+	// Enter without a selection is left for the chat input
+	if _, cmd := press(model, KeyOpenRef); cmd != nil {
+		t.Fatal("expected enter to do nothing without a selection")
+	}
+
+	model, _ = press(model, KeyPrevRef)
+	model, cmd := press(model, KeyOpenRef)
+	if cmd == nil {
+		t.Fatal("expected enter to load the bead")
+	}
+	model, _ = model.Update(cmd())
+
+	view := model.View()
+	if !strings.Contains(view, "Fix login") || !strings.Contains(view, "Session cookie expires early") {
+		t.Fatalf("expected the detail view:\n%s", view)
+	}
+
+	model, _ = press(model, KeyCloseView)
+	if model.(Model).BeadDetail != nil || !strings.Contains(model.View(), "Working on") {
+		t.Fatalf("expected esc to return to the chat:\n%s", model.View())
+	}
+	if _, ok := model.(Model).SelectedRef(); !ok {
+		t.Fatal("expected the selection to survive closing the detail view")
+	}
+}
+
+func TestOpenMissingBeadRef(t *testing.T) {
+	m := NewModel()
+	m.loadBead = func(id string) (*models.Bead, error) { return nil, errors.New("not found") }
+
+	var model tea.Model = m
+	model, _ = model.Update(ChatMsg{Text: "See bd-dead."})
+	model, _ = press(model, KeyPrevRef)
+	model, cmd := press(model, KeyOpenRef)
+	model, _ = model.Update(cmd())
+
+	toast, ok := model.(Model).Toasts.Peek()
+	if !ok || !strings.Contains(toast.Message, "bd-dead") || model.(Model).BeadDetail != nil {
+		t.Fatalf("expected an error toast, got %+v", toast)
+	}
+}
+
+func TestCopyBeadRef(t *testing.T) {
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(text string) error { copied = text; return nil }
+	defer func() { copyToClipboard = orig }()
+
+	var model tea.Model = NewModel()
+	model, _ = model.Update(ChatMsg{Text: "Closed bd-beef."})
+	model, _ = press(model, KeyCopyRef)
+	if copied != "" {
+		t.Fatal("expected nothing copied without a selection")
+	}
+
+	model, _ = press(model, KeyPrevRef)
+	model, _ = press(model, KeyCopyRef)
+	if copied != "bd-beef" {
+		t.Fatalf("expected bd-beef copied, got %q", copied)
+	}
+	if toast, _ := model.(Model).Toasts.Peek(); !strings.Contains(toast.Message, "Copied bd-beef") {
+		t.Fatalf("expected copy toast, got %+v", toast)
+	}
+}
@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+// CommandMsg is a slash command entered in the chat input
+type CommandMsg struct {
+	Line string
+}
+
+// CommandDoneMsg carries the outcome of a slash command that ran in the
+// background
+type CommandDoneMsg struct {
+	Command string
+	Output  string // added to the chat
+	Err     error
+}
+
+// CommandActions runs the slash commands that reach past what the dashboard
+// polls
+type CommandActions interface {
+	// Spawn hires a soldati on a turf, generating a name when none is given
+	Spawn(turf, name string) (string, error)
+	// Turfs lists the registered turfs
+	Turfs() ([]models.Turf, error)
+}
+
+// slashCommand is a command run from the chat input
+type slashCommand struct {
+	Name string // including the slash
+	Args string // argument hint; optional arguments are bracketed
+	Help string
+	run  func(m Model, args []string) (tea.Model, tea.Cmd)
+}
+
+// slashCommands lists the chat's commands in the order /help shows them
+func slashCommands() []slashCommand {
+	return []slashCommand{
+		{Name: "/help", Help: "List the commands", run: Model.showHelp},
+		{Name: "/new", Help: "Start a new chat", run: Model.newChat},
+		{Name: "/beads", Args: "[status]", Help: "List the open beads", run: Model.listBeads},
+		{Name: "/assign", Args: "<bead> <soldati>", Help: "Hand a bead to a soldati", run: Model.assignBead},
+		{Name: "/spawn", Args: "<turf> [name]", Help: "Hire a soldati on a turf", run: Model.spawnSoldati},
+		{Name: "/turf", Args: "[name|all]", Help: "List the turfs, or show only one's beads and agents", run: Model.turfCommand},
+		{Name: "/model", Args: "[agents] [opus|sonnet|haiku]", Help: "Show or switch the underboss's or agents' model", run: Model.modelCommand},
+		{Name: "/theme", Args: "[name]", Help: "List the themes, or switch to one", run: Model.switchTheme},
+		{Name: "/cost", Help: "Show this session's token use and cost", run: Model.showCost},
+		{Name: "/sessions", Help: "List saved chats", run: Model.listSessions},
+		{Name: "/resume", Args: "<id>", Help: "Continue a saved chat", run: Model.resumeSession},
+		{Name: "/copy-last", Args: "[tool]", Help: "Copy the last reply, or the last tool output", run: Model.copyLast},
+		{Name: "/export", Args: "[path]", Help: "Write the transcript as markdown", run: Model.export},
+	}
+}
+
+// lookupCommand finds a command by its full name
+func lookupCommand(name string) (slashCommand, bool) {
+	for _, c := range slashCommands() {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return slashCommand{}, false
+}
+
+// runCommand dispatches a slash command, reporting the outcome as a toast
+func (m Model) runCommand(msg CommandMsg) (tea.Model, tea.Cmd) {
+	fields := strings.Fields(msg.Line)
+	if len(fields) == 0 {
+		return m, nil
+	}
+	c, ok := lookupCommand(fields[0])
+	if !ok {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Unknown command: %s (/help lists them)", fields[0])})
+		return m, nil
+	}
+	return c.run(m, fields[1:])
+}
+
+// runInBackground runs a command's work off the update loop
+func runInBackground(command string, work func() (string, error)) tea.Cmd {
+	return func() tea.Msg {
+		output, err := work()
+		return CommandDoneMsg{Command: command, Output: output, Err: err}
+	}
+}
+
+// handleCommandDone shows what a background command produced
+func (m Model) handleCommandDone(msg CommandDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("%s failed: %v", msg.Command, msg.Err)})
+		return m, nil
+	}
+	if msg.Output != "" {
+		m.addChat(msg.Output)
+	}
+	return m, nil
+}
+
+func (m Model) showHelp(args []string) (tea.Model, tea.Cmd) {
+	lines := []string{"Commands:"}
+	for _, c := range slashCommands() {
+		lines = append(lines, fmt.Sprintf("  %-28s %s", strings.TrimSpace(c.Name+" "+c.Args), c.Help))
+	}
+	m.Chat = append(m.Chat, strings.Join(lines, "\n"))
+	return m, nil
+}
+
+// newChat clears the chat and starts the next message in a fresh session
+func (m Model) newChat(args []string) (tea.Model, tea.Cmd) {
+	if m.stream != nil {
+		m.Toasts.Push(Toast{Message: "Wait for the response to finish, or cancel it, before starting a new chat"})
+		return m, nil
+	}
+	m.Chat, m.beadRefs, m.selectedRef = nil, nil, -1
+	m.BeadDetail, m.EpicDetail = nil, nil
+	m.Session, m.SessionID = nil, ""
+	m.lastReply, m.lastToolOutput = "", ""
+	if m.resume != nil {
+		m.resume("")
+	}
+	return m, nil
+}
+
+// listBeads adds the open beads the beads tab holds to the chat, so their
+// IDs can be selected and opened
+func (m Model) listBeads(args []string) (tea.Model, tea.Cmd) {
+	var lines []string
+	for _, bead := range m.BeadsTab.Beads {
+		if len(args) > 0 && string(bead.Status) != args[0] {
+			continue
+		}
+		line := fmt.Sprintf("%s  P%d  %-16s %s", bead.ID, bead.Priority, bead.Status, bead.Title)
+		if bead.Assignee != "" {
+			line += "  (" + bead.Assignee + ")"
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		m.Toasts.Push(Toast{Message: "No matching beads"})
+		return m, nil
+	}
+	m.addChat(strings.Join(lines, "\n"))
+	return m, nil
+}
+
+func (m Model) assignBead(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) != 2:
+		m.Toasts.Push(Toast{Message: "Usage: /assign <bead> <soldati>"})
+		return m, nil
+	case m.beadActions == nil:
+		m.Toasts.Push(Toast{Message: "Bead actions are unavailable"})
+		return m, nil
+	}
+	actions, id, to := m.beadActions, args[0], args[1]
+	return m, runInBackground("/assign", func() (string, error) {
+		if err := actions.Reassign(id, to); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Assigned %s to %s", id, to), nil
+	})
+}
+
+func (m Model) spawnSoldati(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) == 0 || len(args) > 2:
+		m.Toasts.Push(Toast{Message: "Usage: /spawn <turf> [name]"})
+		return m, nil
+	case m.commandActions == nil:
+		m.Toasts.Push(Toast{Message: "Spawning is unavailable"})
+		return m, nil
+	}
+	actions, turf, name := m.commandActions, args[0], ""
+	if len(args) == 2 {
+		name = args[1]
+	}
+	return m, runInBackground("/spawn", func() (string, error) {
+		return actions.Spawn(turf, name)
+	})
+}
+
+func (m Model) showCost(args []string) (tea.Model, tea.Cmd) {
+	total := m.Sidebar.Total()
+	m.Chat = append(m.Chat, fmt.Sprintf("This session: %s tokens in, %s out, $%.4f",
+		formatTokens(total.InputTokens), formatTokens(total.OutputTokens), total.CostUSD))
+	return m, nil
+}
+
+func (m Model) export(args []string) (tea.Model, tea.Cmd) {
+	path, err := m.exportTranscript(args)
+	if err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Export failed: %v", err)})
+	} else {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Transcript exported to %s", path)})
+	}
+	return m, nil
+}
+
+// exportTranscript writes the current session as markdown, to the given path
+// or <ExportDir>/<session>.md
+func (m Model) exportTranscript(args []string) (string, error) {
+	if m.SessionID == "" {
+		return "", fmt.Errorf("no session to export yet")
+	}
+
+	src, err := transcript.Find(m.SessionID)
+	if err != nil {
+		return "", err
+	}
+	t, err := transcript.Load(src)
+	if err != nil {
+		return "", err
+	}
+
+	dest := filepath.Join(m.ExportDir, m.SessionID+".md")
+	if len(args) > 0 {
+		dest = args[0]
+	}
+
+	if err := os.WriteFile(dest, []byte(m.redactor.String(transcript.RenderMarkdown(t))), 0644); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
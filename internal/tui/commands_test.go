@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExportWithoutSession(t *testing.T) {
+	var model tea.Model = NewModel()
+	model, _ = model.Update(CommandMsg{Line: "/export"})
+
+	toast, ok := model.(Model).Toasts.Peek()
+	if !ok || !strings.Contains(toast.Message, "no session") {
+		t.Fatalf("expected no-session toast, got %+v", toast)
+	}
+}
+
+func TestExportWritesMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	session := filepath.Join(dir, "sess-9.jsonl")
+	line := `{"type":"user","sessionId":"sess-9","message":{"role":"user","content":"hello"}}` + "\n"
+	if err := os.WriteFile(session, []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel()
+	m.SessionID = session
+	out := filepath.Join(dir, "out.md")
+
+	var model tea.Model = m
+	model, _ = model.Update(CommandMsg{Line: "/export " + out})
+
+	toast, _ := model.(Model).Toasts.Peek()
+	if !strings.Contains(toast.Message, out) {
+		t.Fatalf("expected export toast, got %q", toast.Message)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "## User\n\nhello") {
+		t.Fatalf("unexpected export:\n%s", data)
+	}
+}
+
+func TestCompletionsMatchFuzzily(t *testing.T) {
+	names := func(input string) []string {
+		var out []string
+		for _, c := range completions(input) {
+			out = append(out, c.Name)
+		}
+		return out
+	}
+	if got := names("/as"); len(got) == 0 || got[0] != "/assign" {
+		t.Errorf("expected /assign first for /as, got %v", got)
+	}
+	if got := names("/sns"); len(got) != 1 || got[0] != "/sessions" {
+		t.Errorf("expected /sns to match only /sessions, got %v", got)
+	}
+	if got := names("/"); len(got) != len(slashCommands()) {
+		t.Errorf("expected every command for a bare slash, got %v", got)
+	}
+	if got := names("/assign bd"); got != nil {
+		t.Errorf("expected no popup while typing arguments, got %v", got)
+	}
+	if hint := argHint("/assign bd-a1b2 "); hint != "<soldati>" {
+		t.Errorf("expected the remaining argument hinted, got %q", hint)
+	}
+}
+
+func TestChatInputRunsCommands(t *testing.T) {
+	actions := &fakeBeadActions{}
+	m := NewModel()
+	m.beadActions = actions
+
+	var model tea.Model = m
+	model, _ = typeKeys(model, runeKey("/as"))
+	if view := model.View(); !strings.Contains(view, "Hand a bead to a soldati") {
+		t.Fatalf("expected the command popup:\n%s", view)
+	}
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyTab})
+	if got := model.(Model); got.Input != "/assign " || got.ActiveTab != TabChat {
+		t.Fatalf("expected tab to complete the command, got %q on tab %d", got.Input, got.ActiveTab)
+	}
+	if view := model.View(); !strings.Contains(view, "<bead> <soldati>") {
+		t.Fatalf("expected argument hints:\n%s", view)
+	}
+
+	model, cmd := typeKeys(model, runeKey("bd-aaaa"), tea.KeyMsg{Type: tea.KeySpace}, runeKey("sal"), tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected /assign to run")
+	}
+	model, _ = model.Update(cmd())
+	if len(actions.calls) != 1 || actions.calls[0] != "reassign bd-aaaa sal" {
+		t.Fatalf("expected the bead reassigned, got %v", actions.calls)
+	}
+	if chat := model.(Model).Chat; len(chat) != 1 || chat[0] != "Assigned bd-aaaa to sal" {
+		t.Errorf("expected the outcome in the chat, got %q", chat)
+	}
+
+	// With nothing typed, tab still switches tabs
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyTab})
+	if model.(Model).ActiveTab != TabDaemon {
+		t.Errorf("expected tab to switch tabs with an empty input")
+	}
+}
+
+func TestBeadsAndHelpCommands(t *testing.T) {
+	var model tea.Model = NewModel()
+	model, _ = model.Update(RefreshMsg{Beads: testBeads()})
+	model, _ = model.Update(CommandMsg{Line: "/beads open"})
+	got := model.(Model)
+	if len(got.Chat) != 1 || strings.Contains(got.Chat[0], "bd-aaaa") || !strings.Contains(got.Chat[0], "bd-bbbb") {
+		t.Fatalf("expected only open beads listed, got %q", got.Chat)
+	}
+	if len(got.beadRefs) != 2 {
+		t.Errorf("expected the listed beads selectable, got %v", got.beadRefs)
+	}
+
+	model, _ = model.Update(CommandMsg{Line: "/help"})
+	if chat := model.(Model).Chat; !strings.Contains(chat[len(chat)-1], "/spawn <turf> [name]") {
+		t.Errorf("expected /help to list commands with their arguments, got %q", chat[len(chat)-1])
+	}
+
+	model, _ = model.Update(CommandMsg{Line: "/nope"})
+	if toast, _ := model.(Model).Toasts.Peek(); !strings.Contains(toast.Message, "Unknown command: /nope") {
+		t.Errorf("expected an unknown command toast, got %+v", toast)
+	}
+}
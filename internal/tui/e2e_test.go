@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/mockclaude"
+	"github.com/gabe/mob/internal/underboss"
+)
+
+// The spawner runs `<test binary> mock-claude` when MOB_CLAUDE=mock, so the
+// test binary answers as claude for the end-to-end tests
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == "mock-claude" {
+		if err := mockclaude.Run(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "mock-claude: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// TestChatStreamsFromMockClaude sends a chat message through the underboss
+// to the mock claude and checks its blocks and usage reach the model
+func TestChatStreamsFromMockClaude(t *testing.T) {
+	mobDir := t.TempDir()
+	script := filepath.Join(mobDir, "script.json")
+	data, err := json.Marshal(mockclaude.Script{SessionID: "sess-mock", Turns: []mockclaude.Turn{{
+		Match:        `status`,
+		Thinking:     "Checking the board",
+		Text:         "All quiet on the backend turf.",
+		Delay:        "10ms",
+		InputTokens:  120,
+		OutputTokens: 30,
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(agent.ClaudeEnv, "mock")
+	t.Setenv(mockclaude.ScriptEnv, script)
+
+	boss := underboss.New(mobDir, agent.NewSpawner())
+	usage := make(chan tea.Msg, 16)
+	boss.SetOnUsage(ForwardUsage(func(msg tea.Msg) { usage <- msg }))
+
+	m := NewModel()
+	m.ask = boss.AskStream
+
+	// Drive the model as the program would, applying the usage streamed in
+	// ahead of each message
+	var model tea.Model = m
+	var msg tea.Msg = SendMsg{Text: "status?"}
+	var live Usage
+	blocks := 0
+	for msg != nil {
+		if _, ok := msg.(StreamBlockMsg); ok {
+			blocks++
+		}
+		for drained := false; !drained; {
+			select {
+			case u := <-usage:
+				model, _ = model.Update(u)
+				if l := model.(Model).Sidebar.Live; l.OutputTokens > 0 {
+					live = l
+				}
+			default:
+				drained = true
+			}
+		}
+		var cmd tea.Cmd
+		model, cmd = model.Update(msg)
+		msg = nil
+		if cmd != nil {
+			msg = cmd()
+		}
+	}
+
+	m = model.(Model)
+	if m.Streaming() {
+		t.Fatal("expected the stream to finish")
+	}
+	if blocks < 2 {
+		t.Errorf("streamed %d blocks, want the thinking and text blocks", blocks)
+	}
+	if last := m.Chat[len(m.Chat)-1]; last != "All quiet on the backend turf." {
+		t.Errorf("chat = %q, want the mock's reply last", m.Chat)
+	}
+	if m.SessionID != "sess-mock" {
+		t.Errorf("session = %q, want the mock's sess-mock", m.SessionID)
+	}
+	if live.InputTokens != 120 || live.OutputTokens != 30 {
+		t.Errorf("live usage = %+v, want 120 in / 30 out streamed before the response finished", live)
+	}
+	if got := m.Sidebar.Session; got.InputTokens != 120 || got.OutputTokens != 30 {
+		t.Errorf("session usage = %+v, want the response's 120 in / 30 out", got)
+	}
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gabe/mob/internal/models"
+)
+
+// epicBarWidth is how many cells the epic progress bar spans
+const epicBarWidth = 24
+
+var (
+	epicPanelStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(NewStyles().Primary)).Padding(0, 1)
+	epicDoneStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(NewStyles().Success))
+	epicLeftStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(NewStyles().Faint))
+	epicLateStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(NewStyles().Error))
+)
+
+// EpicPanel renders an epic's progress: a bar of the share of children
+// closed, counts per status, the next deadline and any overdue children
+func EpicPanel(p *models.EpicProgress) string {
+	filled := p.Percent() * epicBarWidth / 100
+	bar := epicDoneStyle.Render(strings.Repeat("█", filled)) + epicLeftStyle.Render(strings.Repeat("░", epicBarWidth-filled))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Epic progress  %s %d%%  (%d/%d closed)", bar, p.Percent(), p.Closed(), p.Total())
+	var counts []string
+	for _, status := range models.StatusOrder {
+		if n := p.ByStatus[status]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%s %d", status, n))
+		}
+	}
+	if len(counts) > 0 {
+		b.WriteString("\n" + strings.Join(counts, "  "))
+	}
+	if p.EarliestDue != nil {
+		fmt.Fprintf(&b, "\nNext due: %s (%s)", p.EarliestDue.Format("Mon Jan 2 15:04"), p.EarliestDueID)
+	}
+	if p.Overdue > 0 {
+		b.WriteString("\n" + epicLateStyle.Render(fmt.Sprintf("%d overdue", p.Overdue)))
+	}
+	return epicPanelStyle.Render(b.String())
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestOpenEpicShowsProgress(t *testing.T) {
+	epic := &models.Bead{ID: "bd-e001", Title: "Auth revamp", Type: models.BeadTypeEpic}
+	m := NewModel()
+	m.loadBead = func(id string) (*models.Bead, error) { return epic, nil }
+	m.loadEpic = func(id string) (*models.EpicProgress, error) {
+		return models.RollUp(epic, []*models.Bead{
+			{ID: "bd-c001", ParentID: epic.ID, Status: models.BeadStatusClosed},
+			{ID: "bd-c002", ParentID: epic.ID, Status: models.BeadStatusClosed},
+			{ID: "bd-c003", ParentID: epic.ID, Status: models.BeadStatusInProgress},
+			{ID: "bd-c004", ParentID: epic.ID, Status: models.BeadStatusOpen},
+		}, epic.CreatedAt), nil
+	}
+
+	var model tea.Model = m
+	model, _ = model.Update(ChatMsg{Text: "Planning bd-e001."})
+	model, _ = press(model, KeyPrevRef)
+	model, cmd := press(model, KeyOpenRef)
+	model, _ = model.Update(cmd())
+
+	view := model.View()
+	if !strings.Contains(view, "50%") || !strings.Contains(view, "(2/4 closed)") || !strings.Contains(view, "Auth revamp") {
+		t.Fatalf("expected the epic progress panel with the detail view:\n%s", view)
+	}
+
+	model, _ = press(model, KeyCloseView)
+	if model.(Model).EpicDetail != nil {
+		t.Error("expected esc to close the progress panel too")
+	}
+}
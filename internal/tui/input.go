@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Keys for the chat input's command popup
+const (
+	KeyComplete = "tab" // fill in the selected command
+)
+
+// completionLimit is how many commands the popup shows at once
+const completionLimit = 6
+
+var hintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(NewStyles().Muted))
+
+// completions returns the commands matching a partly typed command name,
+// best match first. Nothing matches once arguments are being typed.
+func completions(input string) []slashCommand {
+	if !strings.HasPrefix(input, "/") || strings.Contains(input, " ") {
+		return nil
+	}
+	query := strings.TrimPrefix(input, "/")
+
+	type match struct {
+		cmd   slashCommand
+		score int
+	}
+	var matches []match
+	for _, c := range slashCommands() {
+		if score, ok := fuzzyScore(strings.TrimPrefix(c.Name, "/"), query); ok {
+			matches = append(matches, match{c, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	found := make([]slashCommand, 0, len(matches))
+	for _, match := range matches {
+		found = append(found, match.cmd)
+	}
+	return found
+}
+
+// fuzzyScore reports whether query's letters appear in name in order. Matches
+// at the start of the name, and letters next to each other, score higher.
+func fuzzyScore(name, query string) (int, bool) {
+	score, at := 0, 0
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(name[at:], r)
+		if i < 0 {
+			return 0, false
+		}
+		switch {
+		case at == 0 && i == 0:
+			score += 3
+		case i == 0:
+			score += 2
+		default:
+			score++
+		}
+		at += i + 1
+	}
+	return score, true
+}
+
+// argHint returns the hints for the arguments of a command still to be typed
+func argHint(input string) string {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || !strings.HasSuffix(input, " ") {
+		return ""
+	}
+	c, ok := lookupCommand(fields[0])
+	if !ok {
+		return ""
+	}
+	hints := strings.Fields(c.Args)
+	if typed := len(fields) - 1; typed < len(hints) {
+		return strings.Join(hints[typed:], " ")
+	}
+	return ""
+}
+
+// handleInputKey edits the chat input. Enter sends the line, as a slash
+// command when it starts with one; while the command popup is open, tab and
+// enter fill in the selected command and up/down move through it. It
+// reports false for keys the input leaves to the chat.
+func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	matches := completions(m.Input)
+	popup := len(matches) > 0
+
+	switch key := msg.String(); {
+	case popup && key == KeyUp:
+		if m.completion > 0 {
+			m.completion--
+		}
+	case popup && key == KeyDown:
+		if m.completion < min(len(matches), completionLimit)-1 {
+			m.completion++
+		}
+	case popup && (key == KeyComplete || key == KeyOpenRef && matches[m.completion].Name != m.Input):
+		m.Input, m.completion = matches[m.completion].Name+" ", 0
+	case key == KeyOpenRef && strings.TrimSpace(m.Input) != "":
+		line := strings.TrimSpace(m.Input)
+		m.Input, m.completion = "", 0
+		if strings.HasPrefix(line, "/") {
+			model, cmd := m.Update(CommandMsg{Line: line})
+			return model, cmd, true
+		}
+		model, cmd := m.handleSend(SendMsg{Text: line})
+		return model, cmd, true
+	case key == KeyCloseView && m.Input != "":
+		m.Input, m.completion = "", 0
+	case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace || msg.Type == tea.KeyBackspace && m.Input != "":
+		m.Input, m.completion = editLine(m.Input, msg), 0
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// InputView renders the chat input with the command popup or the hints for
+// the arguments still to type
+func (m Model) InputView() string {
+	view := "› " + m.Input + "█"
+	if hint := argHint(m.Input); hint != "" {
+		view += " " + hintStyle.Render(hint)
+	}
+
+	matches := completions(m.Input)
+	if len(matches) > completionLimit {
+		matches = matches[:completionLimit]
+	}
+	for i, c := range matches {
+		row := fmt.Sprintf("  %-28s %s", strings.TrimSpace(c.Name+" "+c.Args), c.Help)
+		if i == m.completion {
+			row = rowFocusStyle.Render(row)
+		}
+		view += "\n" + row
+	}
+	return view
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelColors are the chip backgrounds labels are spread across, from the theme
+var labelColors = NewStyles().Labels
+
+var labelChipStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(NewStyles().ChipText)).Padding(0, 1)
+
+// LabelColor picks a label's chip color. Within a theme it depends only on
+// the label, so a label looks the same wherever it appears.
+func LabelColor(label string) string {
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	return labelColors[h.Sum32()%uint32(len(labelColors))]
+}
+
+// LabelChips renders labels as colored chips separated by spaces
+func LabelChips(labels []string) string {
+	chips := make([]string, len(labels))
+	for i, label := range labels {
+		chips[i] = labelChipStyle.Background(lipgloss.Color(LabelColor(label))).Render(label)
+	}
+	return strings.Join(chips, " ")
+}
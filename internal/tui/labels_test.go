@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestLabelColorStable(t *testing.T) {
+	if LabelColor("backend") != LabelColor("backend") {
+		t.Fatal("a label should always get the same color")
+	}
+	seen := make(map[string]bool)
+	for _, label := range []string{"api", "auth", "ui", "backend", "docs", "infra", "release", "bug"} {
+		seen[LabelColor(label)] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("expected labels spread across colors, got %v", seen)
+	}
+}
+
+func TestBeadDetailViewShowsLabels(t *testing.T) {
+	view := BeadDetailView(&models.Bead{ID: "bd-a1b2", Title: "Fix it", Labels: []string{"api", "auth"}})
+	if !strings.Contains(view, "api") || !strings.Contains(view, "auth") {
+		t.Errorf("labels missing from detail view:\n%s", view)
+	}
+}
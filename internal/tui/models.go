@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Keys for the sidebar's model switcher
+const (
+	KeyModels      = "ctrl+o" // open the switcher from the chat tab
+	KeyModelPrev   = "left"   // the previous model for the selected row
+	KeyModelNext   = "right"  // the next model
+	KeyModelApply  = "enter"  // switch to the models picked
+	KeyModelCancel = "esc"    // close without switching
+)
+
+// switchableModels are the models the switcher cycles through, most capable
+// first
+var switchableModels = []string{"opus", "sonnet", "haiku"}
+
+// modelRate is a model's list price in USD per million tokens
+type modelRate struct {
+	Input  float64
+	Output float64
+}
+
+var modelRates = map[string]modelRate{
+	"opus":   {Input: 5, Output: 25},
+	"sonnet": {Input: 3, Output: 15},
+	"haiku":  {Input: 1, Output: 5},
+}
+
+// ModelActions switches models at runtime and keeps the choice in config.toml
+type ModelActions interface {
+	// SetUnderbossModel changes the model the chat uses from its next message
+	SetUnderbossModel(model string) (string, error)
+	// SetAgentModel changes the default model for agents' work
+	SetAgentModel(model string) (string, error)
+}
+
+// ModelSwitchedMsg reports a model change. Exactly one of Underboss and
+// Agent is set, naming the new model.
+type ModelSwitchedMsg struct {
+	Underboss string
+	Agent     string
+	Done      string // what happened, shown as a toast
+	Err       error
+}
+
+// Switcher rows
+const (
+	switchUnderboss = iota
+	switchAgents
+)
+
+// modelSwitcher picks the underboss's and agents' models in the sidebar
+type modelSwitcher struct {
+	Open  bool
+	Row   int       // switchUnderboss or switchAgents
+	Picks [2]string // model picked for each row
+}
+
+// rateLabel describes a model's list price, empty when it is not known
+func rateLabel(model string) string {
+	for name, rate := range modelRates {
+		if strings.Contains(model, name) {
+			return fmt.Sprintf("$%g/$%g per M tokens", rate.Input, rate.Output)
+		}
+	}
+	return ""
+}
+
+// modelLabel names a model with its price; empty means Claude's default
+func modelLabel(model string) string {
+	if model == "" {
+		return "default"
+	}
+	if rate := rateLabel(model); rate != "" {
+		return fmt.Sprintf("%s (%s)", model, rate)
+	}
+	return model
+}
+
+// validModel reports whether a model name can be passed to Claude: one of
+// the switcher's aliases or a full model ID
+func validModel(model string) bool {
+	for _, name := range switchableModels {
+		if model == name {
+			return true
+		}
+	}
+	return strings.HasPrefix(model, "claude-")
+}
+
+// sidebarView renders the sidebar with the models in use, or the switcher
+func (m Model) sidebarView() string {
+	if !m.switcher.Open {
+		view := m.Sidebar.View()
+		if m.modelActions != nil && !m.Observe {
+			view += fmt.Sprintf("\n%s switch models", KeyModels)
+		}
+		return view
+	}
+
+	view := m.Sidebar.usageView()
+	for row, label := range []string{"Model", "Agents"} {
+		line := fmt.Sprintf("%s: ‹ %s ›", label, modelLabel(m.switcher.Picks[row]))
+		if row == m.switcher.Row {
+			line = rowFocusStyle.Render(line)
+		}
+		view += "\n" + line
+	}
+	return view + fmt.Sprintf("\n%s apply  %s cancel", KeyModelApply, KeyModelCancel)
+}
+
+// openSwitcher starts picking from the models in use
+func (m Model) openSwitcher() (tea.Model, tea.Cmd) {
+	if m.modelActions == nil {
+		m.Toasts.Push(Toast{Message: "Switching models is unavailable"})
+		return m, nil
+	}
+	m.switcher = modelSwitcher{Open: true, Picks: [2]string{m.Sidebar.Model, m.Sidebar.AgentModel}}
+	return m, nil
+}
+
+// handleSwitcherKey moves through the switcher and applies what was picked
+func (m Model) handleSwitcherKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := &m.switcher
+	switch msg.String() {
+	case KeyUp:
+		s.Row = switchUnderboss
+	case KeyDown:
+		s.Row = switchAgents
+	case KeyModelPrev:
+		s.Picks[s.Row] = cycleModel(s.Picks[s.Row], -1)
+	case KeyModelNext:
+		s.Picks[s.Row] = cycleModel(s.Picks[s.Row], 1)
+	case KeyModelCancel:
+		m.switcher = modelSwitcher{}
+	case KeyModelApply:
+		picks := s.Picks
+		m.switcher = modelSwitcher{}
+		var cmds []tea.Cmd
+		if picks[switchUnderboss] != m.Sidebar.Model {
+			cmds = append(cmds, m.switchModel(switchUnderboss, picks[switchUnderboss]))
+		}
+		if picks[switchAgents] != m.Sidebar.AgentModel {
+			cmds = append(cmds, m.switchModel(switchAgents, picks[switchAgents]))
+		}
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+// cycleModel steps through the switchable models from the current one
+func cycleModel(current string, step int) string {
+	n := len(switchableModels)
+	for i, name := range switchableModels {
+		if name == current {
+			return switchableModels[((i+step)%n+n)%n]
+		}
+	}
+	return switchableModels[0]
+}
+
+// switchModel changes the underboss's or agents' model in the background
+func (m Model) switchModel(row int, model string) tea.Cmd {
+	actions := m.modelActions
+	return func() tea.Msg {
+		if row == switchUnderboss {
+			done, err := actions.SetUnderbossModel(model)
+			return ModelSwitchedMsg{Underboss: model, Done: done, Err: err}
+		}
+		done, err := actions.SetAgentModel(model)
+		return ModelSwitchedMsg{Agent: model, Done: done, Err: err}
+	}
+}
+
+// handleModelSwitched shows the model now in use
+func (m Model) handleModelSwitched(msg ModelSwitchedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Model switch failed: %v", msg.Err)})
+		return m, nil
+	}
+	if msg.Underboss != "" {
+		m.Sidebar.Model = msg.Underboss
+	}
+	if msg.Agent != "" {
+		m.Sidebar.AgentModel = msg.Agent
+	}
+	m.Toasts.Push(Toast{Message: msg.Done})
+	return m, nil
+}
+
+// modelCommand shows the models in use, or switches the underboss's model
+// (/model <name>) or the agents' (/model agents <name>)
+func (m Model) modelCommand(args []string) (tea.Model, tea.Cmd) {
+	row := switchUnderboss
+	if len(args) > 0 && args[0] == "agents" {
+		row, args = switchAgents, args[1:]
+	}
+	if len(args) == 0 {
+		if row == switchAgents {
+			m.Chat = append(m.Chat, fmt.Sprintf("Agents: %s", modelLabel(m.Sidebar.AgentModel)))
+			return m, nil
+		}
+		line := fmt.Sprintf("Underboss: %s", modelLabel(m.Sidebar.Model))
+		if m.UnderbossModel != "" {
+			line += fmt.Sprintf(", last answered with %s", m.UnderbossModel)
+		}
+		m.Chat = append(m.Chat, line+fmt.Sprintf("\nAgents: %s", modelLabel(m.Sidebar.AgentModel)))
+		return m, nil
+	}
+
+	switch model := args[0]; {
+	case len(args) > 1 || !validModel(model):
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Usage: /model [agents] [%s]", strings.Join(switchableModels, "|"))})
+	case m.modelActions == nil:
+		m.Toasts.Push(Toast{Message: "Switching models is unavailable"})
+	default:
+		return m, m.switchModel(row, model)
+	}
+	return m, nil
+}
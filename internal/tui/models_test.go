@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type fakeModelActions struct {
+	calls []string
+}
+
+func (f *fakeModelActions) SetUnderbossModel(model string) (string, error) {
+	f.calls = append(f.calls, "underboss "+model)
+	return "Underboss now chats with " + model, nil
+}
+
+func (f *fakeModelActions) SetAgentModel(model string) (string, error) {
+	f.calls = append(f.calls, "agents "+model)
+	return "Agents now work with " + model, nil
+}
+
+func TestSidebarShowsModelsAndRates(t *testing.T) {
+	m := NewModel()
+	m.Sidebar.Model, m.Sidebar.AgentModel = "opus", "claude-haiku-4-5"
+
+	view := m.View()
+	for _, want := range []string{"Model: opus ($5/$25 per M tokens)", "Agents: claude-haiku-4-5 ($1/$5 per M tokens)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the sidebar:\n%s", want, view)
+		}
+	}
+}
+
+func TestModelSwitcherAppliesPicks(t *testing.T) {
+	actions := &fakeModelActions{}
+	m := NewModel()
+	m.modelActions = actions
+	m.Sidebar.Model, m.Sidebar.AgentModel = "sonnet", "sonnet"
+
+	var model tea.Model = m
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !model.(Model).switcher.Open {
+		t.Fatal("expected ctrl+o to open the switcher")
+	}
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyRight})
+	if picks := model.(Model).switcher.Picks; picks != [2]string{"opus", "haiku"} {
+		t.Fatalf("expected opus and haiku picked, got %v", picks)
+	}
+
+	model, cmd := press(model, "enter")
+	if model.(Model).switcher.Open || cmd == nil {
+		t.Fatal("expected enter to close the switcher and switch models")
+	}
+	for _, msg := range cmd().(tea.BatchMsg) {
+		model, _ = model.Update(msg())
+	}
+	if got := model.(Model).Sidebar; got.Model != "opus" || got.AgentModel != "haiku" {
+		t.Fatalf("expected the sidebar to show the new models, got %q and %q", got.Model, got.AgentModel)
+	}
+	if len(actions.calls) != 2 {
+		t.Fatalf("expected both models switched, got %v", actions.calls)
+	}
+}
+
+func TestModelSwitcherCancel(t *testing.T) {
+	actions := &fakeModelActions{}
+	m := NewModel()
+	m.modelActions = actions
+
+	var model tea.Model = m
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyRight})
+	model, cmd := press(model, "esc")
+	if model.(Model).switcher.Open || cmd != nil || model.(Model).Sidebar.Model != "" {
+		t.Fatal("expected esc to close the switcher without switching")
+	}
+}
+
+func TestModelCommand(t *testing.T) {
+	actions := &fakeModelActions{}
+	m := NewModel()
+	m.modelActions = actions
+	m.Sidebar.Model = "sonnet"
+
+	var model tea.Model = m
+	model, _ = model.Update(CommandMsg{Line: "/model"})
+	if chat := model.(Model).Chat; len(chat) == 0 || !strings.Contains(chat[len(chat)-1], "Underboss: sonnet ($3/$15 per M tokens)") {
+		t.Fatalf("expected /model to show the models, got %v", chat)
+	}
+
+	model, cmd := model.Update(CommandMsg{Line: "/model agents haiku"})
+	if cmd == nil {
+		t.Fatal("expected /model agents haiku to switch the agents' model")
+	}
+	model, _ = model.Update(cmd())
+	if model.(Model).Sidebar.AgentModel != "haiku" || actions.calls[0] != "agents haiku" {
+		t.Fatalf("expected agents switched to haiku, got %v", actions.calls)
+	}
+
+	model.(Model).Toasts.Pop()
+
+	model, cmd = model.Update(CommandMsg{Line: "/model gpt-4"})
+	if toast, _ := model.(Model).Toasts.Peek(); cmd != nil || !strings.Contains(toast.Message, "Usage: /model") {
+		t.Fatalf("expected an unknown model refused, got %q", toast.Message)
+	}
+}
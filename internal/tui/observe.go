@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/chatsession"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/redact"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// RefreshInterval is how often the dashboard polls status for its tabs
+const RefreshInterval = 2 * time.Second
+
+// Options changes how the dashboard starts
+type Options struct {
+	// Observe makes the dashboard read-only: the chat tab is hidden and slash
+	// commands are refused, so nothing is sent to an LLM and no mob state changes
+	Observe bool
+	// Refresh loads status for the daemon, agents and beads tabs; nil disables polling.
+	// It must only read.
+	Refresh func() RefreshMsg
+	// Redactor masks secrets in transcripts written by /export
+	Redactor *redact.Redactor
+	// LoadBead reads a bead for the detail view opened from a chat reference
+	LoadBead func(id string) (*models.Bead, error)
+	// LoadEpic rolls up an epic's children for the progress panel shown with
+	// an epic's detail view; nil leaves the panel out
+	LoadEpic func(id string) (*models.EpicProgress, error)
+	// Ask sends chat messages and streams the responses; nil leaves chat
+	// unconnected
+	Ask AskFunc
+	// Approver answers the underboss's tool calls held for the user's y/n;
+	// nil when they are not held
+	Approver Approver
+	// Beads diffs and changes beads from the beads tab; its actions are
+	// refused while observing
+	Beads BeadActions
+	// Agents kills, nudges and reassigns agents from the agents tab; its
+	// actions are refused while observing, but transcripts still show
+	Agents AgentActions
+	// Approvals previews, approves and rejects the beads waiting on the user
+	// from the approvals tab; its decisions are refused while observing
+	Approvals ApprovalActions
+	// Commands spawns soldati and lists turfs for the chat's slash commands
+	Commands CommandActions
+	// Sessions saves the chat as it goes so /resume and the startup picker
+	// can bring it back; nil keeps chat in memory only
+	Sessions *chatsession.Store
+	// Resume continues a saved chat's Claude session in the underboss
+	Resume func(sessionID string)
+	// Models switches the underboss's model and the agents' default from the
+	// sidebar and /model, saving the choice to config.toml
+	Models ModelActions
+	// UnderbossModel and AgentModel are the models in use when the dashboard
+	// opens; empty means Claude's default
+	UnderbossModel string
+	AgentModel     string
+	// ThemeDir holds theme files for [tui] theme and /theme beside the
+	// built-in dark and light themes
+	ThemeDir string
+	// MarkRead saves notifications' read state for the notifications tab;
+	// while observing, read state is kept in the dashboard only
+	MarkRead func(read bool, ids ...string) error
+	// WatchUsage registers the callback that feeds the sidebar the tokens of
+	// a response as it streams; nil shows usage once a response finishes
+	WatchUsage func(agent.UsageCallback)
+}
+
+// RefreshMsg carries freshly loaded status for the daemon, agents and beads tabs
+type RefreshMsg struct {
+	Activity []*models.Activity
+	Agents   []*registry.AgentRecord
+	Beads    []*models.Bead // open beads, in the order listed
+	Closed   []*models.Bead // recently closed beads, for the beads board
+	// Notifications are the latest from the feed, oldest first
+	Notifications []notify.FeedEntry
+	// Usage is the usage ledger's last CostLedgerDays days, oldest first
+	Usage []models.UsageEntry
+}
+
+// NewObserverModel returns a read-only model that opens on the daemon tab
+func NewObserverModel() Model {
+	m := NewModel()
+	m.Observe = true
+	m.ActiveTab = TabDaemon
+	return m
+}
+
+// refreshAfter schedules the next status poll
+func (m Model) refreshAfter(d time.Duration) tea.Cmd {
+	if m.refresh == nil {
+		return nil
+	}
+	load := m.refresh
+	return tea.Tick(d, func(time.Time) tea.Msg { return load() })
+}
+
+// handleRefresh shows newly loaded status and schedules the next poll
+func (m Model) handleRefresh(msg RefreshMsg) (tea.Model, tea.Cmd) {
+	m.DaemonTab.Activity = msg.Activity
+	m.polled = msg
+	m.scopeTabs()
+	m.CostsTab.Entries = msg.Usage
+	m.Sidebar.Today = m.CostsTab.since(startOfDay(now(), 0)).USD
+	m.announceApprovals(m.ApprovalsTab.setBeads(msg.Beads))
+	if m.ActiveTab == TabDaemon {
+		m.refreshMatches()
+	}
+	if arrived := m.NotificationsTab.setEntries(msg.Notifications); arrived > 0 && m.bell {
+		ringBell()
+	}
+	next := m.refreshAfter(RefreshInterval)
+	switch m.ActiveTab {
+	case TabApprovals:
+		return m, tea.Batch(next, m.loadApprovalPreview())
+	case TabBeads:
+		return m, tea.Batch(next, m.loadBeadDiff())
+	}
+	return m, next
+}
+
+// refuseInObserve reports a refused command while observing
+func (m Model) refuseInObserve(msg CommandMsg) (tea.Model, tea.Cmd) {
+	m.Toasts.Push(Toast{Message: fmt.Sprintf("Observer mode is read-only: %s is disabled", msg.Line)})
+	return m, nil
+}
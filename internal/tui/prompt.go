@@ -0,0 +1,60 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// textPrompt collects a line of text for an action a tab is about to take
+type textPrompt struct {
+	Action string // key of the action waiting on the text; "" = none
+	Input  string // text typed so far
+}
+
+// Active reports whether an action is waiting on the prompt
+func (p textPrompt) Active() bool {
+	return p.Action != ""
+}
+
+// open starts collecting text for action
+func (p *textPrompt) open(action string) {
+	p.Action, p.Input = action, ""
+}
+
+// close drops the prompt and anything typed
+func (p *textPrompt) close() {
+	p.Action, p.Input = "", ""
+}
+
+// key applies a keypress. It returns true when enter submits the text; esc
+// closes the prompt.
+func (p *textPrompt) key(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyEnter:
+		return true
+	case tea.KeyEsc:
+		p.close()
+	default:
+		p.Input = editLine(p.Input, msg)
+	}
+	return false
+}
+
+// editLine applies a typing keypress (text, space or backspace) to a line
+func editLine(line string, msg tea.KeyMsg) string {
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if r := []rune(line); len(r) > 0 {
+			return string(r[:len(r)-1])
+		}
+	case tea.KeySpace:
+		return line + " "
+	case tea.KeyRunes:
+		return line + string(msg.Runes)
+	}
+	return line
+}
+
+// View renders label, the text typed and what enter does
+func (p textPrompt) View(label, enter string) string {
+	return label + p.Input + "█  (enter to " + enter + ", esc to cancel)"
+}
@@ -9,7 +9,7 @@ import (
 func TestRunUsesStartProgram(t *testing.T) {
 	called := false
 	original := startProgram
-	startProgram = func(model tea.Model) error {
+	startProgram = func(model tea.Model, onStart ...func(send func(tea.Msg))) error {
 		called = true
 		return nil
 	}
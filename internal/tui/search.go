@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Keys for searching the chat, daemon log and agent output, as in less
+const (
+	KeySearch     = "/"      // search the daemon log or agent output
+	KeySearchChat = "ctrl+f" // search any of them; in chat / starts a command
+	KeySearchNext = "n"      // the next match, wrapping around
+	KeySearchPrev = "N"      // the previous match
+	KeySearchDone = "esc"    // stop searching
+)
+
+// searchContext is how many lines are shown above and below the current match
+const searchContext = 10
+
+var searchMatchStyle = lipgloss.NewStyle().Background(lipgloss.Color(NewStyles().Primary)).Foreground(lipgloss.Color(NewStyles().ChipText))
+
+// search is a query over the active tab's lines. While it is typed keys edit
+// it; once entered, n and N move between the lines matching it.
+type search struct {
+	Active  bool
+	Typing  bool
+	Query   string
+	Matches []int // indexes of the lines matching, in order
+	Current int   // index into Matches
+}
+
+// pattern compiles the query. It is literal, and ignores case unless it has
+// an upper-case letter, like less -i.
+func (s search) pattern() *regexp.Regexp {
+	expr := regexp.QuoteMeta(s.Query)
+	if !strings.ContainsFunc(s.Query, unicode.IsUpper) {
+		expr = "(?i)" + expr
+	}
+	return regexp.MustCompile(expr)
+}
+
+// startSearch opens the query prompt for the active tab's lines
+func (m Model) startSearch() (tea.Model, tea.Cmd) {
+	m.search = search{Active: true, Typing: true}
+	return m, nil
+}
+
+// handleSearchKey types the query, then moves between its matches
+func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := &m.search
+	key := msg.String()
+	if s.Typing {
+		switch {
+		case key == KeySearchDone:
+			m.search = search{}
+		case msg.Type == tea.KeyEnter:
+			if s.Query == "" {
+				m.search = search{}
+				return m, nil
+			}
+			s.Typing = false
+			m.findMatches()
+		default:
+			s.Query = editLine(s.Query, msg)
+		}
+		return m, nil
+	}
+
+	switch key {
+	case KeySearch, KeySearchChat:
+		return m.startSearch()
+	case KeySearchNext:
+		if n := len(s.Matches); n > 0 {
+			s.Current = (s.Current + 1) % n
+		}
+	case KeySearchPrev:
+		if n := len(s.Matches); n > 0 {
+			s.Current = (s.Current - 1 + n) % n
+		}
+	case KeySearchDone:
+		m.search = search{}
+	}
+	return m, nil
+}
+
+// findMatches finds the lines matching the query, starting at the last, the
+// most recent
+func (m *Model) findMatches() {
+	lines, _ := m.selectableLines()
+	re := m.search.pattern()
+	m.search.Matches = nil
+	for i, line := range lines {
+		if re.MatchString(line) {
+			m.search.Matches = append(m.search.Matches, i)
+		}
+	}
+	m.search.Current = max(len(m.search.Matches)-1, 0)
+}
+
+// refreshMatches finds the matches again after the lines change, staying on
+// the same line when it still matches
+func (m *Model) refreshMatches() {
+	if !m.search.Active || m.search.Typing {
+		return
+	}
+	line := -1
+	if len(m.search.Matches) > 0 {
+		line = m.search.Matches[m.search.Current]
+	}
+	m.findMatches()
+	for i, match := range m.search.Matches {
+		if match == line {
+			m.search.Current = i
+		}
+	}
+}
+
+// searchView renders the lines around the current match with the matches
+// highlighted, then the query and where it is in the matches
+func (m Model) searchView() string {
+	lines, _ := m.selectableLines()
+	var b strings.Builder
+	switch m.ActiveTab {
+	case TabDaemon:
+		b.WriteString("Daemon\n")
+	case TabAgentOutput:
+		b.WriteString("Agent Output: " + m.AgentOutputTab.Agent + "\n")
+	}
+
+	s := m.search
+	if s.Typing || len(s.Matches) == 0 {
+		first := max(len(lines)-2*searchContext-1, 0)
+		b.WriteString(strings.Join(lines[first:], "\n"))
+	} else {
+		re, current := s.pattern(), s.Matches[s.Current]
+		for i := max(current-searchContext, 0); i <= min(current+searchContext, len(lines)-1); i++ {
+			gutter := "  "
+			if i == current {
+				gutter = "› "
+			}
+			line := re.ReplaceAllStringFunc(lines[i], func(match string) string {
+				return searchMatchStyle.Render(match)
+			})
+			b.WriteString(gutter + line + "\n")
+		}
+	}
+
+	switch {
+	case s.Typing:
+		fmt.Fprintf(&b, "\n/%s█", s.Query)
+	case len(s.Matches) == 0:
+		fmt.Fprintf(&b, "\n/%s  Pattern not found  %s done", s.Query, KeySearchDone)
+	default:
+		fmt.Fprintf(&b, "/%s  %d/%d  %s next  %s previous  %s done", s.Query, s.Current+1, len(s.Matches), KeySearchNext, KeySearchPrev, KeySearchDone)
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+func typeText(model tea.Model, text string) tea.Model {
+	for _, r := range text {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return model
+}
+
+func TestSearchDaemonLog(t *testing.T) {
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	var activity []*models.Activity
+	for _, msg := range []string{"spawned vinnie", "bd-aaaa closed", "Vinnie went idle", "bd-bbbb opened"} {
+		activity = append(activity, &models.Activity{Timestamp: at, Type: "agent", Message: msg})
+	}
+
+	m := NewModel()
+	m.ActiveTab = TabDaemon
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Activity: activity})
+
+	model, _ = typeKeys(model, runeKey(KeySearch))
+	model = typeText(model, "vinnie")
+	if view := model.View(); !strings.Contains(view, "/vinnie█") {
+		t.Fatalf("expected the query prompt:\n%s", view)
+	}
+	model, _ = press(model, "enter")
+
+	// Lower case matches either case, starting at the most recent
+	s := model.(Model).search
+	if len(s.Matches) != 2 || s.Matches[s.Current] != 2 {
+		t.Fatalf("expected two matches with the last selected, got %+v", s)
+	}
+	if view := model.View(); !strings.Contains(view, "2/2") || !strings.Contains(view, "› ") {
+		t.Fatalf("expected the current match marked:\n%s", view)
+	}
+	model, _ = typeKeys(model, runeKey(KeySearchNext))
+	if s := model.(Model).search; s.Matches[s.Current] != 0 {
+		t.Fatalf("expected n to wrap to the first match, got line %d", s.Matches[s.Current])
+	}
+	model, _ = typeKeys(model, runeKey(KeySearchPrev))
+	if s := model.(Model).search; s.Matches[s.Current] != 2 {
+		t.Fatalf("expected N to go back to the last match, got line %d", s.Matches[s.Current])
+	}
+
+	// An upper-case letter makes the search case-sensitive
+	model, _ = typeKeys(model, runeKey(KeySearch))
+	model = typeText(model, "Vinnie")
+	model, _ = press(model, "enter")
+	if s := model.(Model).search; len(s.Matches) != 1 {
+		t.Fatalf("expected one case-sensitive match, got %+v", s)
+	}
+
+	model, _ = press(model, "esc")
+	if model.(Model).search.Active {
+		t.Fatal("expected esc to end the search")
+	}
+}
+
+func TestSearchChatLeavesSlashToCommands(t *testing.T) {
+	var model tea.Model = NewModel()
+	model, _ = model.Update(ChatMsg{Text: "Created bd-aaaa\nnothing else"})
+
+	model = typeText(model, "/")
+	if model.(Model).search.Active || model.(Model).Input != "/" {
+		t.Fatal("expected / to start a command in chat")
+	}
+	model, _ = press(model, "esc")
+
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyCtrlF})
+	model = typeText(model, "nope")
+	model, _ = press(model, "enter")
+	if view := model.View(); !strings.Contains(view, "Pattern not found") {
+		t.Fatalf("expected no match reported:\n%s", view)
+	}
+	model, _ = typeKeys(model, runeKey(KeySearchNext))
+	if model.(Model).Input != "" {
+		t.Fatal("expected n to stay in the search rather than type into the chat")
+	}
+}
+
+func TestSearchAgentOutput(t *testing.T) {
+	m := NewModel()
+	m.ActiveTab = TabAgentOutput
+	var model tea.Model = m
+	if view := model.View(); !strings.Contains(view, "Select an agent on the Agents tab") {
+		t.Fatalf("expected a hint with no agent loaded:\n%s", view)
+	}
+
+	model, _ = model.Update(AgentOutputMsg{Agent: "vinnie", Lines: []string{"## User", "fix the login", "## Assistant", "Fixed the login cookie"}})
+	model, _ = typeKeys(model, runeKey(KeySearch))
+	model = typeText(model, "login")
+	model, _ = press(model, "enter")
+	if s := model.(Model).search; len(s.Matches) != 2 {
+		t.Fatalf("expected two matches in the transcript, got %+v", s)
+	}
+}
@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Keys for selecting and copying lines of the chat, daemon log or agent output
+const (
+	KeySelect     = "ctrl+v" // start selecting lines, capturing the mouse
+	KeyYank       = "y"      // copy the selected lines and stop selecting
+	KeyMark       = "v"      // start the selection again at the cursor
+	KeySelectDone = "esc"    // stop selecting without copying
+)
+
+// selection is a range of lines being picked out of a tab's text. Both ends
+// are line indexes; the cursor moves and the anchor stays.
+type selection struct {
+	Active bool
+	Anchor int
+	Cursor int
+}
+
+// bounds returns the first and last selected lines
+func (s selection) bounds() (int, int) {
+	return min(s.Anchor, s.Cursor), max(s.Anchor, s.Cursor)
+}
+
+// selectableLines returns the active tab's text as plain lines, and the
+// screen row its first line is drawn on. Tabs without text to copy have none.
+func (m Model) selectableLines() ([]string, int) {
+	switch {
+	case m.ActiveTab == TabChat && !m.Observe && m.BeadDetail == nil && len(m.Chat) > 0:
+		return strings.Split(strings.Join(m.Chat, "\n"), "\n"), 1 // below the tab bar
+	case m.ActiveTab == TabDaemon:
+		return m.DaemonTab.lines(), 2 // below the tab bar and the tab's title
+	case m.ActiveTab == TabAgentOutput:
+		return m.AgentOutputTab.Lines, 2
+	}
+	return nil, 0
+}
+
+// startSelection selects the last line of the active tab and captures the
+// mouse so lines can be picked by clicking and dragging
+func (m Model) startSelection() (tea.Model, tea.Cmd) {
+	lines, _ := m.selectableLines()
+	if len(lines) == 0 {
+		return m, nil
+	}
+	last := len(lines) - 1
+	m.selection = selection{Active: true, Anchor: last, Cursor: last}
+	return m, tea.EnableMouseCellMotion
+}
+
+// stopSelection leaves selection and hands the mouse back to the terminal
+func (m Model) stopSelection() (tea.Model, tea.Cmd) {
+	m.selection = selection{}
+	return m, tea.DisableMouse
+}
+
+// handleSelectKey moves the selection and copies it
+func (m Model) handleSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	lines, _ := m.selectableLines()
+	switch msg.String() {
+	case KeyUp, "k":
+		m.selection.Cursor = max(m.selection.Cursor-1, 0)
+	case KeyDown, "j":
+		m.selection.Cursor = min(m.selection.Cursor+1, len(lines)-1)
+	case KeyMark:
+		m.selection.Anchor = m.selection.Cursor
+	case KeyYank:
+		first, last := m.selection.bounds()
+		if last < len(lines) {
+			m.copyText(strings.Join(lines[first:last+1], "\n"), fmt.Sprintf("%d lines", last-first+1))
+		}
+		return m.stopSelection()
+	case KeySelectDone, KeySelect:
+		return m.stopSelection()
+	}
+	return m, nil
+}
+
+// handleMouse picks lines while selecting: a click selects the line under
+// it, dragging extends the selection and the wheel moves the cursor
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if !m.selection.Active {
+		return m, nil
+	}
+	lines, top := m.selectableLines()
+	line := min(max(msg.Y-top, 0), len(lines)-1)
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		m.selection.Cursor = max(m.selection.Cursor-1, 0)
+	case msg.Button == tea.MouseButtonWheelDown:
+		m.selection.Cursor = min(m.selection.Cursor+1, len(lines)-1)
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+		m.selection.Anchor, m.selection.Cursor = line, line
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionMotion:
+		m.selection.Cursor = line
+	}
+	return m, nil
+}
+
+// selectionView renders the active tab's lines with the selection marked
+func (m Model) selectionView() string {
+	lines, _ := m.selectableLines()
+	first, last := m.selection.bounds()
+	var b strings.Builder
+	switch m.ActiveTab {
+	case TabDaemon:
+		b.WriteString("Daemon\n")
+	case TabAgentOutput:
+		b.WriteString("Agent Output: " + m.AgentOutputTab.Agent + "\n")
+	}
+	for i, line := range lines {
+		if i >= first && i <= last {
+			line = rowFocusStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&b, "%s copy  %s mark  ↑/↓ or drag to select  %s cancel", KeyYank, KeyMark, KeySelectDone)
+	return b.String()
+}
+
+// copyText puts text on the clipboard, reporting what was copied
+func (m *Model) copyText(text, what string) {
+	if err := copyToClipboard(text); err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Copy failed: %v", err)})
+		return
+	}
+	m.Toasts.Push(Toast{Message: fmt.Sprintf("Copied %s", what)})
+}
+
+// copyLast copies the underboss's last reply, or with "tool" the output of
+// the last tool it ran
+func (m Model) copyLast(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case len(args) > 0 && args[0] == "tool":
+		if m.lastToolOutput == "" {
+			m.Toasts.Push(Toast{Message: "No tool output yet"})
+			return m, nil
+		}
+		m.copyText(m.lastToolOutput, "the last tool output")
+	case len(args) > 0:
+		m.Toasts.Push(Toast{Message: "Usage: /copy-last [tool]"})
+	case m.lastReply == "":
+		m.Toasts.Push(Toast{Message: "No reply yet"})
+	default:
+		m.copyText(m.lastReply, "the last reply")
+	}
+	return m, nil
+}
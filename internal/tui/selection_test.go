@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+)
+
+func stubClipboard(t *testing.T) *string {
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(text string) error { copied = text; return nil }
+	t.Cleanup(func() { copyToClipboard = orig })
+	return &copied
+}
+
+func TestSelectAndYankChatLines(t *testing.T) {
+	copied := stubClipboard(t)
+
+	var model tea.Model = NewModel()
+	model, _ = model.Update(ChatMsg{Text: "first\nsecond"})
+	model, _ = model.Update(ChatMsg{Text: "third"})
+
+	model, cmd := typeKeys(model, tea.KeyMsg{Type: tea.KeyCtrlV})
+	if !model.(Model).selection.Active || cmd == nil {
+		t.Fatal("expected selection started with the mouse captured")
+	}
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyUp})
+	if view := model.View(); !strings.Contains(view, KeyYank+" copy") {
+		t.Fatalf("expected the selection keys shown:\n%s", view)
+	}
+	model, _ = typeKeys(model, runeKey(KeyYank))
+	if *copied != "second\nthird" {
+		t.Fatalf("expected the last two lines copied, got %q", *copied)
+	}
+	if model.(Model).selection.Active {
+		t.Error("expected yanking to end the selection")
+	}
+
+	// Clicking picks a line and dragging extends from it; rows start below the tab bar
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyCtrlV})
+	model, _ = model.Update(tea.MouseMsg{Y: 1, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	model, _ = model.Update(tea.MouseMsg{Y: 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionMotion})
+	model, _ = typeKeys(model, runeKey(KeyYank))
+	if *copied != "first\nsecond" {
+		t.Fatalf("expected the dragged lines copied, got %q", *copied)
+	}
+}
+
+func TestCopyLast(t *testing.T) {
+	copied := stubClipboard(t)
+
+	m := NewModel()
+	m.ask = func(ctx context.Context, message string, callback agent.StreamCallback) (*agent.ChatResponse, error) {
+		return &agent.ChatResponse{Blocks: []agent.ChatContentBlock{
+			{Type: agent.ContentTypeToolUse, Name: "list_beads"},
+			{Type: agent.ContentTypeToolResult, Text: "bd-a1b2 open"},
+			{Type: agent.ContentTypeText, Text: "One bead is open."},
+		}}, nil
+	}
+
+	var model tea.Model = m
+	model, _ = model.Update(CommandMsg{Line: "/copy-last"})
+	if toast, _ := model.(Model).Toasts.Pop(); toast.Message != "No reply yet" {
+		t.Fatalf("expected nothing to copy yet, got %+v", toast)
+	}
+
+	model = drive(t, model.(Model), SendMsg{Text: "what is open?"})
+	model, _ = model.Update(CommandMsg{Line: "/copy-last"})
+	if *copied != "One bead is open." {
+		t.Errorf("expected the reply copied, got %q", *copied)
+	}
+	model.Update(CommandMsg{Line: "/copy-last tool"})
+	if *copied != "bd-a1b2 open" {
+		t.Errorf("expected the tool output copied, got %q", *copied)
+	}
+}
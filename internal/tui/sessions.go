@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/chatsession"
+)
+
+// Keys for the session picker shown on startup
+const (
+	KeyResumeSession = "enter" // resume the selected session
+	KeyNewSession    = "esc"   // start a new session instead
+)
+
+// pickerLimit is how many recent sessions the startup picker offers
+const pickerLimit = 10
+
+// now is the clock sessions are stamped with; replaced in tests
+var now = time.Now
+
+// record adds a message to the current chat session, starting one if
+// needed, and saves it. Nothing is kept when sessions are not persisted.
+func (m *Model) record(role, text string, blocks []agent.ChatContentBlock) {
+	if m.sessions == nil {
+		return
+	}
+	if m.Session == nil {
+		sess, err := chatsession.New(now())
+		if err != nil {
+			m.Toasts.Push(Toast{Message: fmt.Sprintf("Chat is not being saved: %v", err)})
+			return
+		}
+		m.Session = sess
+	}
+
+	msg := chatsession.Message{Role: role, Text: text, At: now()}
+	for _, b := range blocks {
+		msg.Blocks = append(msg.Blocks, chatsession.Block{Type: string(b.Type), Text: b.Text, Name: b.Name, Input: b.Input, ID: b.ID})
+	}
+	m.Session.Add(msg)
+	m.Session.Underboss = m.SessionID
+	if err := m.sessions.Save(m.Session); err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Could not save the chat: %v", err)})
+	}
+}
+
+// listSessions adds the saved sessions to the chat for /sessions
+func (m Model) listSessions(args []string) (tea.Model, tea.Cmd) {
+	if m.sessions == nil {
+		m.Toasts.Push(Toast{Message: "Chat sessions are not saved"})
+		return m, nil
+	}
+	all, err := m.sessions.List()
+	if err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Could not list sessions: %v", err)})
+		return m, nil
+	}
+	if len(all) == 0 {
+		m.Toasts.Push(Toast{Message: "No saved sessions yet"})
+		return m, nil
+	}
+	lines := []string{"Saved sessions (/resume <id>):"}
+	for _, sess := range all {
+		lines = append(lines, "  "+sessionLine(sess))
+	}
+	m.Chat = append(m.Chat, strings.Join(lines, "\n"))
+	return m, nil
+}
+
+// resumeSession replaces the chat with a saved session and points the
+// underboss at its Claude session
+func (m Model) resumeSession(args []string) (tea.Model, tea.Cmd) {
+	switch {
+	case m.sessions == nil:
+		m.Toasts.Push(Toast{Message: "Chat sessions are not saved"})
+		return m, nil
+	case len(args) == 0:
+		m.Toasts.Push(Toast{Message: "Usage: /resume <id>"})
+		return m, nil
+	case m.stream != nil:
+		m.Toasts.Push(Toast{Message: "Wait for the response to finish, or cancel it, before resuming"})
+		return m, nil
+	}
+	sess, err := m.sessions.Load(args[0])
+	if err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Cannot resume: %v", err)})
+		return m, nil
+	}
+	m.restore(sess)
+	return m, nil
+}
+
+// restore shows a saved session's messages and continues it
+func (m *Model) restore(sess *chatsession.Session) {
+	m.Session = sess
+	m.SessionID = sess.Underboss
+	m.Chat, m.beadRefs, m.selectedRef = nil, nil, -1
+	m.BeadDetail, m.EpicDetail = nil, nil
+	m.lastReply, m.lastToolOutput = "", ""
+	for _, msg := range sess.Messages {
+		if msg.Role == chatsession.RoleUser {
+			m.Chat = append(m.Chat, "> "+msg.Text)
+			continue
+		}
+		m.addChat(msg.Text)
+		m.lastReply = msg.Text
+		for _, b := range msg.Blocks {
+			if b.Type == string(agent.ContentTypeToolResult) && b.Text != "" {
+				m.lastToolOutput = b.Text
+			}
+		}
+	}
+	if m.resume != nil {
+		m.resume(sess.Underboss)
+	}
+	m.Toasts.Push(Toast{Message: fmt.Sprintf("Resumed %s", sess.ID)})
+}
+
+// openPicker offers the most recent sessions to resume on startup
+func (m *Model) openPicker() {
+	if m.sessions == nil || m.Observe {
+		return
+	}
+	all, err := m.sessions.List()
+	if err != nil || len(all) == 0 {
+		return
+	}
+	if len(all) > pickerLimit {
+		all = all[:pickerLimit]
+	}
+	m.Picker, m.pickerSelected = all, 0
+}
+
+// handlePickerKey moves through the startup picker, resuming the chosen
+// session or starting a new one
+func (m Model) handlePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case KeyUp:
+		if m.pickerSelected > 0 {
+			m.pickerSelected--
+		}
+	case KeyDown:
+		if m.pickerSelected < len(m.Picker)-1 {
+			m.pickerSelected++
+		}
+	case KeyResumeSession:
+		sess := m.Picker[m.pickerSelected]
+		m.Picker = nil
+		m.restore(sess)
+	case KeyNewSession:
+		m.Picker = nil
+	}
+	return m, nil
+}
+
+// PickerView lists the sessions offered on startup
+func (m Model) PickerView() string {
+	var b strings.Builder
+	b.WriteString("Resume a chat?")
+	for i, sess := range m.Picker {
+		row := sessionLine(sess)
+		if i == m.pickerSelected {
+			row = rowFocusStyle.Render(row)
+		}
+		b.WriteString("\n" + row)
+	}
+	fmt.Fprintf(&b, "\n\n%s resume  %s start a new chat", KeyResumeSession, KeyNewSession)
+	return b.String()
+}
+
+func sessionLine(sess *chatsession.Session) string {
+	return fmt.Sprintf("%-12s %s  %3d messages  %s", sess.ID, sess.UpdatedAt.Format("Jan 2 15:04"), len(sess.Messages), sess.Title)
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/chatsession"
+)
+
+func TestChatIsSavedAndResumed(t *testing.T) {
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	now = func() time.Time { return at }
+	defer func() { now = time.Now }()
+
+	store := chatsession.NewStore(t.TempDir())
+	m := NewModel()
+	m.ask = echoAsk
+	m.sessions = store
+
+	m = drive(t, m, SendMsg{Text: "status of bd-a1b2"})
+	if m.Session == nil {
+		t.Fatal("expected a session started by the first message")
+	}
+	saved, err := store.Load(m.Session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Messages) != 2 || saved.Title != "status of bd-a1b2" || saved.Underboss != "sess-1" {
+		t.Fatalf("unexpected saved session %+v", saved)
+	}
+
+	// A fresh dashboard lists it and brings it back
+	var resumed []string
+	next := NewModel()
+	next.sessions = store
+	next.resume = func(id string) { resumed = append(resumed, id) }
+
+	var model tea.Model = next
+	model, _ = model.Update(CommandMsg{Line: "/sessions"})
+	if chat := model.(Model).Chat; len(chat) != 1 || !strings.Contains(chat[0], saved.ID) {
+		t.Fatalf("expected the session listed, got %q", chat)
+	}
+
+	model, _ = model.Update(CommandMsg{Line: "/resume " + saved.ID[:8]})
+	got := model.(Model)
+	if len(got.Chat) != 2 || got.Chat[0] != "> status of bd-a1b2" || got.SessionID != "sess-1" {
+		t.Fatalf("expected the chat restored, got %q (session %q)", got.Chat, got.SessionID)
+	}
+	if len(resumed) != 1 || resumed[0] != "sess-1" {
+		t.Errorf("expected the underboss pointed at sess-1, got %q", resumed)
+	}
+	if ref, ok := got.SelectedRef(); ok || len(got.beadRefs) != 1 {
+		t.Errorf("expected bead references rebuilt with none selected, got %q %v", ref, got.beadRefs)
+	}
+
+	model, _ = model.Update(CommandMsg{Line: "/resume chat-zz"})
+	model.(Model).Toasts.Pop() // Resumed chat-...
+	toast, _ := model.(Model).Toasts.Pop()
+	if !strings.Contains(toast.Message, "Cannot resume") {
+		t.Errorf("expected an unknown session refused, got %+v", toast)
+	}
+}
+
+func TestStartupPicker(t *testing.T) {
+	store := chatsession.NewStore(t.TempDir())
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	for i, text := range []string{"older chat", "newer chat"} {
+		sess, _ := chatsession.New(at)
+		sess.Add(chatsession.Message{Role: chatsession.RoleUser, Text: text, At: at.Add(time.Duration(i) * time.Hour)})
+		if err := store.Save(sess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewModel()
+	m.sessions = store
+	m.openPicker()
+	if len(m.Picker) != 2 || !strings.Contains(m.View(), "Resume a chat?") {
+		t.Fatalf("expected the picker shown:\n%s", m.View())
+	}
+
+	var model tea.Model = m
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+	got := model.(Model)
+	if got.Picker != nil || len(got.Chat) != 1 || got.Chat[0] != "> older chat" {
+		t.Fatalf("expected the older chat resumed, got %q", got.Chat)
+	}
+
+	// esc starts afresh, and observers are never offered the picker
+	m.openPicker()
+	model, _ = typeKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if got := model.(Model); got.Picker != nil || got.Session != nil {
+		t.Fatalf("expected a new chat, got %+v", got.Session)
+	}
+	observer := NewObserverModel()
+	observer.sessions = store
+	observer.openPicker()
+	if observer.Picker != nil {
+		t.Error("expected no picker while observing")
+	}
+}
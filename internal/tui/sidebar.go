@@ -1,11 +1,72 @@
 package tui
 
-type Sidebar struct{}
+import "fmt"
+
+// Usage is a token and cost tally
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+	CostUSD      float64
+}
+
+// Add returns the sum of two tallies
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		InputTokens:  u.InputTokens + other.InputTokens,
+		OutputTokens: u.OutputTokens + other.OutputTokens,
+		CostUSD:      u.CostUSD + other.CostUSD,
+	}
+}
+
+type Sidebar struct {
+	Session    Usage   // completed responses
+	Live       Usage   // in-flight response, updated as usage streams in
+	Model      string  // model the underboss chats with; empty = Claude's default
+	AgentModel string  // default model for agents' work; empty = Claude's default
+	Today      float64 // what the whole mob has spent today, from the usage ledger
+}
 
 func NewSidebar() Sidebar {
 	return Sidebar{}
 }
 
-func (Sidebar) View() string {
-	return "Sidebar"
+// SetLive replaces the in-flight response usage
+func (s *Sidebar) SetLive(usage Usage) {
+	s.Live = usage
+}
+
+// CommitLive folds the finished response into the session totals
+func (s *Sidebar) CommitLive(final Usage) {
+	s.Session = s.Session.Add(final)
+	s.Live = Usage{}
+}
+
+// Total returns session usage including the in-flight response
+func (s Sidebar) Total() Usage {
+	return s.Session.Add(s.Live)
+}
+
+func (s Sidebar) View() string {
+	return s.usageView() + fmt.Sprintf("\nModel: %s\nAgents: %s", modelLabel(s.Model), modelLabel(s.AgentModel))
+}
+
+// usageView renders the session's token use and cost
+func (s Sidebar) usageView() string {
+	total := s.Total()
+	view := "Sidebar\n"
+	view += fmt.Sprintf("Tokens: %s in / %s out\n", formatTokens(total.InputTokens), formatTokens(total.OutputTokens))
+	view += fmt.Sprintf("Cost: $%.4f", total.CostUSD)
+	view += fmt.Sprintf("\nMob today: $%.2f", s.Today)
+	if s.Live != (Usage{}) {
+		view += fmt.Sprintf("\nStreaming: %s out", formatTokens(s.Live.OutputTokens))
+	}
+	return view
+}
+
+// formatTokens renders a token count compactly (e.g. 1.2k)
+func formatTokens(n int) string {
+	if n >= 1000 {
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	}
+	return fmt.Sprintf("%d", n)
 }
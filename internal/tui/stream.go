@@ -0,0 +1,237 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/chatsession"
+)
+
+// AskFunc sends a chat message and calls back with each streamed block until
+// the response finishes or ctx is cancelled. Underboss.AskStream fits.
+type AskFunc func(ctx context.Context, message string, callback agent.StreamCallback) (*agent.ChatResponse, error)
+
+// Keys that stop the in-flight response
+const (
+	KeyInterrupt    = "ctrl+c" // on any tab
+	KeyCancelStream = "esc"    // in the chat, once the input is empty
+)
+
+// cancelledMarker ends a response the user stopped, in the chat and its
+// saved session
+const cancelledMarker = "[cancelled]"
+
+// streamBuffer is how many blocks a stream holds before the sender waits
+// for the model to catch up
+const streamBuffer = 64
+
+// SendMsg is a chat message entered by the user
+type SendMsg struct {
+	Text string
+}
+
+// CancelStreamMsg stops the in-flight response and drops queued messages
+type CancelStreamMsg struct{}
+
+// StreamBlockMsg carries one streamed block of a response
+type StreamBlockMsg struct {
+	Stream int
+	Block  agent.ChatContentBlock
+}
+
+// StreamDoneMsg ends a response
+type StreamDoneMsg struct {
+	Stream   int
+	Response *agent.ChatResponse
+	Err      error
+}
+
+// stream is one in-flight chat response, owned by the model. Its goroutine
+// only writes to blocks and result; the model reads one block at a time, so
+// a fast sender waits on the buffer instead of flooding the program, and a
+// cancelled sender never blocks on a reader that has gone.
+type stream struct {
+	id     int
+	blocks chan agent.ChatContentBlock
+	result chan streamResult
+	cancel context.CancelFunc
+	live   map[int]agent.ChatContentBlock // latest snapshot of each block, by index
+}
+
+type streamResult struct {
+	resp *agent.ChatResponse
+	err  error
+}
+
+// newStream sends message with ask in the background
+func newStream(id int, ask AskFunc, message string) *stream {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &stream{
+		id:     id,
+		blocks: make(chan agent.ChatContentBlock, streamBuffer),
+		result: make(chan streamResult, 1),
+		cancel: cancel,
+		live:   make(map[int]agent.ChatContentBlock),
+	}
+	go func() {
+		resp, err := ask(ctx, message, func(block agent.ChatContentBlock) {
+			select {
+			case s.blocks <- block:
+			case <-ctx.Done():
+			}
+		})
+		close(s.blocks)
+		s.result <- streamResult{resp: resp, err: err}
+	}()
+	return s
+}
+
+// next waits for the stream's next block, or for its result once the
+// blocks run out
+func (s *stream) next() tea.Cmd {
+	return func() tea.Msg {
+		if block, ok := <-s.blocks; ok {
+			return StreamBlockMsg{Stream: s.id, Block: block}
+		}
+		r := <-s.result
+		return StreamDoneMsg{Stream: s.id, Response: r.resp, Err: r.err}
+	}
+}
+
+// text is the response's text so far
+func (s *stream) text() string {
+	indexes := make([]int, 0, len(s.live))
+	for i, block := range s.live {
+		if block.Type == agent.ContentTypeText {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+
+	parts := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		parts = append(parts, s.live[i].Text)
+	}
+	return strings.Join(parts, "")
+}
+
+// Streaming reports whether a response is in flight
+func (m Model) Streaming() bool {
+	return m.stream != nil
+}
+
+// handleSend starts a response to the message, or queues it behind the one
+// in flight so overlapping sends go out in order
+func (m Model) handleSend(msg SendMsg) (tea.Model, tea.Cmd) {
+	text := strings.TrimSpace(msg.Text)
+	switch {
+	case text == "":
+		return m, nil
+	case m.Observe:
+		m.Toasts.Push(Toast{Message: "Observer mode is read-only: chat is disabled"})
+		return m, nil
+	case m.ask == nil:
+		m.Toasts.Push(Toast{Message: "Chat is not connected to the underboss"})
+		return m, nil
+	case m.stream != nil:
+		m.queued = append(m.queued, text)
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Queued: sends when the current response finishes (%d waiting)", len(m.queued))})
+		return m, nil
+	}
+	return m.startStream(text)
+}
+
+// startStream echoes the message and sends it
+func (m Model) startStream(text string) (tea.Model, tea.Cmd) {
+	m.streamSeq++
+	m.stream = newStream(m.streamSeq, m.ask, text)
+	m.Chat = append(m.Chat, "> "+text)
+	m.record(chatsession.RoleUser, text, nil)
+	return m, tea.Batch(m.stream.next(), m.pollApprovals(m.stream.id))
+}
+
+// handleStreamBlock shows a block of the current response. Blocks from a
+// cancelled stream are dropped.
+func (m Model) handleStreamBlock(msg StreamBlockMsg) (tea.Model, tea.Cmd) {
+	if m.stream == nil || msg.Stream != m.stream.id {
+		return m, nil
+	}
+	m.stream.live[msg.Block.Index] = msg.Block
+	return m, m.stream.next()
+}
+
+// handleStreamDone moves the finished response into the chat and sends the
+// next queued message
+func (m Model) handleStreamDone(msg StreamDoneMsg) (tea.Model, tea.Cmd) {
+	if m.stream == nil || msg.Stream != m.stream.id {
+		return m, nil
+	}
+	s := m.stream
+	s.cancel()
+	m.stream = nil
+	m.Approvals = nil
+	m.endResponse()
+
+	if msg.Err != nil {
+		text := fmt.Sprintf("Error: %v", msg.Err)
+		m.addChat(text)
+		m.record(chatsession.RoleUnderboss, text, nil)
+	} else {
+		text := s.text()
+		var blocks []agent.ChatContentBlock
+		if msg.Response != nil {
+			if msg.Response.SessionID != "" {
+				m.SessionID = msg.Response.SessionID
+			}
+			if msg.Response.Model != "" {
+				m.UnderbossModel = msg.Response.Model
+			}
+			m.Sidebar.CommitLive(Usage{InputTokens: msg.Response.InputTokens, OutputTokens: msg.Response.OutputTokens, CostUSD: msg.Response.TotalCost})
+			if final := msg.Response.GetText(); final != "" {
+				text = final
+			}
+			blocks = msg.Response.Blocks
+		}
+		m.addChat(text)
+		m.record(chatsession.RoleUnderboss, text, blocks)
+		m.lastReply = text
+		for _, b := range blocks {
+			if b.Type == agent.ContentTypeToolResult && b.Text != "" {
+				m.lastToolOutput = b.Text
+			}
+		}
+	}
+
+	if len(m.queued) == 0 {
+		return m, nil
+	}
+	next := m.queued[0]
+	m.queued = m.queued[1:]
+	return m.startStream(next)
+}
+
+// handleCancelStream stops the in-flight response, which kills the claude
+// process answering it, keeping what had arrived marked as cancelled, and
+// drops queued messages
+func (m Model) handleCancelStream() (tea.Model, tea.Cmd) {
+	if m.stream == nil {
+		return m, nil
+	}
+	m.stream.cancel()
+	text := cancelledMarker
+	if partial := m.stream.text(); partial != "" {
+		text = partial + "\n" + cancelledMarker
+	}
+	m.addChat(text)
+	m.record(chatsession.RoleUnderboss, text, nil)
+	m.stream = nil
+	m.queued = nil
+	m.endResponse()
+	m.declineAllApprovals()
+	m.Toasts.Push(Toast{Message: "Response cancelled"})
+	return m, nil
+}
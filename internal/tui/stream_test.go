@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+)
+
+// drive feeds msg to the model and keeps running the commands it returns
+// until there are none
+func drive(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+	var model tea.Model = m
+	for msg != nil {
+		var cmd tea.Cmd
+		model, cmd = model.Update(msg)
+		msg = nil
+		if cmd != nil {
+			msg = cmd()
+		}
+	}
+	return model.(Model)
+}
+
+// echoAsk streams the message back word by word
+func echoAsk(ctx context.Context, message string, callback agent.StreamCallback) (*agent.ChatResponse, error) {
+	var text string
+	for _, word := range strings.Fields(message) {
+		text += word + " "
+		callback(agent.ChatContentBlock{Type: agent.ContentTypeText, Text: "echo: " + text})
+	}
+	return &agent.ChatResponse{SessionID: "sess-1"}, nil
+}
+
+func TestSendStreamsResponseIntoChat(t *testing.T) {
+	m := NewModel()
+	m.ask = echoAsk
+
+	m = drive(t, m, SendMsg{Text: "hello there"})
+	if m.Streaming() {
+		t.Fatal("expected the stream to finish")
+	}
+	if len(m.Chat) != 2 || m.Chat[0] != "> hello there" || m.Chat[1] != "echo: hello there " {
+		t.Fatalf("unexpected chat: %q", m.Chat)
+	}
+	if m.SessionID != "sess-1" {
+		t.Errorf("expected the session recorded, got %q", m.SessionID)
+	}
+}
+
+func TestOverlappingSendsQueueInOrder(t *testing.T) {
+	m := NewModel()
+	m.ask = echoAsk
+
+	// The second and third sends arrive before the first response is read
+	var model tea.Model = m
+	model, first := model.Update(SendMsg{Text: "one"})
+	model, cmd := model.Update(SendMsg{Text: "two"})
+	if cmd != nil {
+		t.Fatal("expected the second send to queue, not start a stream")
+	}
+	model, _ = model.Update(SendMsg{Text: "three"})
+
+	m = drive(t, model.(Model), first())
+	want := []string{"> one", "echo: one ", "> two", "echo: two ", "> three", "echo: three "}
+	if fmt.Sprint(m.Chat) != fmt.Sprint(want) {
+		t.Fatalf("chat = %q, want %q", m.Chat, want)
+	}
+}
+
+func TestCancelStreamDropsLateBlocks(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	m := NewModel()
+	m.ask = func(ctx context.Context, message string, callback agent.StreamCallback) (*agent.ChatResponse, error) {
+		defer close(finished)
+		callback(agent.ChatContentBlock{Type: agent.ContentTypeText, Text: "partial"})
+		<-release
+		// More blocks than the buffer holds: the sender must not block once cancelled
+		for i := 0; i < streamBuffer*2; i++ {
+			callback(agent.ChatContentBlock{Type: agent.ContentTypeText, Text: "late"})
+		}
+		return nil, ctx.Err()
+	}
+
+	var model tea.Model = m
+	model, next := model.Update(SendMsg{Text: "go"})
+	model, _ = model.Update(next())
+	model, _ = model.Update(SendMsg{Text: "queued"})
+	model, _ = model.Update(CancelStreamMsg{})
+	close(release)
+
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled sender blocked on a full stream")
+	}
+
+	// A read left over from the cancelled stream is ignored
+	model, cmd := model.Update(StreamBlockMsg{Stream: 1, Block: agent.ChatContentBlock{Type: agent.ContentTypeText, Text: "late"}})
+	if cmd != nil {
+		t.Fatal("expected no further reads from a cancelled stream")
+	}
+	model, _ = model.Update(StreamDoneMsg{Stream: 1})
+
+	m = model.(Model)
+	if m.Streaming() || len(m.queued) != 0 {
+		t.Fatalf("expected nothing in flight or queued, streaming=%v queued=%q", m.Streaming(), m.queued)
+	}
+	if fmt.Sprint(m.Chat) != fmt.Sprint([]string{"> go", "partial\n" + cancelledMarker}) {
+		t.Fatalf("expected the partial response kept and marked cancelled, got %q", m.Chat)
+	}
+	m.Toasts.Pop() // the queued notice
+	if toast, ok := m.Toasts.Peek(); !ok || !strings.Contains(toast.Message, "cancelled") {
+		t.Errorf("expected a cancelled toast, got %+v", toast)
+	}
+}
+
+func TestInterruptKeysCancelStream(t *testing.T) {
+	// blockingAsk answers nothing until its call is cancelled, like a claude
+	// process that is killed mid-response
+	blockingAsk := func(ctx context.Context, message string, callback agent.StreamCallback) (*agent.ChatResponse, error) {
+		callback(agent.ChatContentBlock{Type: agent.ContentTypeText, Text: "thinking"})
+		<-ctx.Done()
+		return nil, agent.ErrAborted
+	}
+
+	m := NewModel()
+	m.ask = blockingAsk
+	var model tea.Model = m
+	model, next := model.Update(SendMsg{Text: "go"})
+	model, _ = model.Update(next())
+	if !strings.Contains(model.View(), "esc or ctrl+c to cancel") {
+		t.Fatalf("expected a cancel hint while streaming:\n%s", model.View())
+	}
+
+	// esc clears typed input before it cancels
+	model, _ = typeKeys(model, runeKey("x"), tea.KeyMsg{Type: tea.KeyEsc})
+	if !model.(Model).Streaming() || model.(Model).Input != "" {
+		t.Fatalf("expected esc to clear the input only, streaming=%v input=%q", model.(Model).Streaming(), model.(Model).Input)
+	}
+	s := model.(Model).stream
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(Model).Streaming() {
+		t.Fatal("expected esc to cancel the response")
+	}
+	if got := model.(Model).Chat; fmt.Sprint(got) != fmt.Sprint([]string{"> go", "thinking\n" + cancelledMarker}) {
+		t.Fatalf("expected the partial response marked cancelled, got %q", got)
+	}
+	if done := s.next()(); done.(StreamDoneMsg).Err != agent.ErrAborted {
+		t.Fatalf("expected the call aborted, got %+v", done)
+	}
+
+	// ctrl+c cancels from any tab
+	model, next = model.Update(SendMsg{Text: "again"})
+	model, _ = model.Update(next())
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyCtrlC})
+	if model.(Model).Streaming() {
+		t.Fatal("expected ctrl+c to cancel the response")
+	}
+	if chat := model.(Model).Chat; chat[len(chat)-1] != "thinking\n"+cancelledMarker {
+		t.Fatalf("expected the second response marked cancelled, got %q", chat)
+	}
+}
+
+func TestSendWithoutAsk(t *testing.T) {
+	m := drive(t, NewModel(), SendMsg{Text: "hi"})
+	if len(m.Chat) != 0 {
+		t.Fatalf("expected nothing sent, got %q", m.Chat)
+	}
+	if toast, ok := m.Toasts.Peek(); !ok || !strings.Contains(toast.Message, "not connected") {
+		t.Errorf("expected a not connected toast, got %+v", toast)
+	}
+}
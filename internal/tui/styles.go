@@ -1,11 +1,68 @@
 package tui
 
+// Palette is a theme's colors: hex for true-color and 256-color terminals,
+// or ANSI numbers ("0"-"15") for 16-color ones
+type Palette struct {
+	Primary  string   `toml:"primary"`   // bead references and panel borders
+	Muted    string   `toml:"muted"`     // hints
+	Faint    string   `toml:"faint"`     // the unfilled part of progress bars
+	Success  string   `toml:"success"`   // the filled part of progress bars
+	Error    string   `toml:"error"`     // overdue work
+	ChipText string   `toml:"chip_text"` // text on label chips
+	Labels   []string `toml:"labels"`    // label chip backgrounds
+}
+
+// Styles is a TUI theme. ANSI is used instead of the palette on terminals
+// with only 16 colors, where hex colors degrade poorly.
 type Styles struct {
-	Primary string
+	Palette
+	ANSI Palette `toml:"ansi"`
 }
 
+// NewStyles returns the dark theme, the default
 func NewStyles() Styles {
 	return Styles{
-		Primary: "#fab283",
+		Palette: Palette{
+			Primary:  "#fab283",
+			Muted:    "#808080",
+			Faint:    "#555555",
+			Success:  "#7fd88f",
+			Error:    "#e06c75",
+			ChipText: "#1e1e2e",
+			Labels:   []string{"#5c9cf5", "#7fd88f", "#e5c07b", "#c678dd", "#56b6c2", "#e06c75", "#fab283", "#9d7cd8"},
+		},
+		ANSI: Palette{
+			Primary:  "11",
+			Muted:    "8",
+			Faint:    "8",
+			Success:  "10",
+			Error:    "9",
+			ChipText: "0",
+			Labels:   []string{"12", "10", "11", "13", "14", "9", "3", "5"},
+		},
+	}
+}
+
+// lightStyles returns the theme for terminals with a light background
+func lightStyles() Styles {
+	return Styles{
+		Palette: Palette{
+			Primary:  "#b35900",
+			Muted:    "#6e6e6e",
+			Faint:    "#c8c8c8",
+			Success:  "#2e8b3e",
+			Error:    "#c0392b",
+			ChipText: "#ffffff",
+			Labels:   []string{"#2d6cdf", "#2e8b3e", "#b7791f", "#8e44ad", "#148f8f", "#c0392b", "#d35400", "#6c4fb0"},
+		},
+		ANSI: Palette{
+			Primary:  "3",
+			Muted:    "8",
+			Faint:    "7",
+			Success:  "2",
+			Error:    "1",
+			ChipText: "15",
+			Labels:   []string{"4", "2", "3", "5", "6", "1", "9", "13"},
+		},
 	}
 }
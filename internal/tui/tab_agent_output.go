@@ -1,11 +1,57 @@
 package tui
 
-type AgentOutputTab struct{}
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AgentOutputTab shows the whole session transcript of the agent selected on
+// the agents tab
+type AgentOutputTab struct {
+	Agent string   // label of the agent shown; "" = none loaded
+	Lines []string // its transcript
+}
 
 func NewAgentOutputTab() AgentOutputTab {
 	return AgentOutputTab{}
 }
 
-func (AgentOutputTab) View() string {
-	return "Agent Output"
+// AgentOutputMsg carries the transcript loaded for the agent output tab
+type AgentOutputMsg struct {
+	Agent string
+	Lines []string
+	Err   error
+}
+
+func (t AgentOutputTab) View() string {
+	if t.Agent == "" {
+		return "Agent Output\nSelect an agent on the Agents tab"
+	}
+	return "Agent Output: " + t.Agent + "\n" + strings.Join(t.Lines, "\n")
+}
+
+// loadAgentOutput loads the transcript of the agent selected on the agents tab
+func (m Model) loadAgentOutput() tea.Cmd {
+	a := m.AgentsTab.SelectedAgent()
+	if a == nil {
+		return nil
+	}
+	label, session, redactor := a.Label(), a.SessionID, m.redactor
+	return func() tea.Msg {
+		lines, err := agentTranscript(session, redactor)
+		return AgentOutputMsg{Agent: label, Lines: lines, Err: err}
+	}
+}
+
+// handleAgentOutput shows a loaded transcript on the agent output tab
+func (m Model) handleAgentOutput(msg AgentOutputMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.AgentOutputTab = AgentOutputTab{}
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("No output for %s: %v", msg.Agent, msg.Err)})
+		return m, nil
+	}
+	m.AgentOutputTab = AgentOutputTab{Agent: msg.Agent, Lines: msg.Lines}
+	return m, nil
 }
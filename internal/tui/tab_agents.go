@@ -1,11 +1,272 @@
 package tui
 
-type AgentsTab struct{}
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/redact"
+	"github.com/gabe/mob/internal/registry"
+	"github.com/gabe/mob/internal/transcript"
+)
+
+// Keys on the agents tab
+const (
+	KeyKillAgent     = "x" // kill the selected agent, after confirming
+	KeyNudgeAgent    = "n" // nudge it
+	KeyAgentChat     = "t" // show its session transcript (esc returns)
+	KeyAgentModel    = "m" // change a soldati's model
+	KeyReassignAgent = "r" // hand its current bead to another soldati
+)
+
+// agentTranscriptMax is how many of the most recent transcript lines the
+// agents tab shows
+const agentTranscriptMax = 40
+
+// AgentActions changes agents from the agents tab's keybindings. Agents are
+// named by registry ID, since associates may have no name.
+type AgentActions interface {
+	// Kill stops the agent's work and sends it home
+	Kill(id string) (string, error)
+	Nudge(id string) (string, error)
+	// SetModel changes the model a soldati uses for all of its work
+	SetModel(id, model string) error
+	// Reassign hands the agent's current bead to soldati and returns its ID
+	Reassign(id, soldati string) (string, error)
+}
+
+type AgentsTab struct {
+	Agents     []*registry.AgentRecord
+	Selected   int        // index into Agents
+	Prompt     textPrompt // confirmation or text for a kill, model change or reassign
+	Transcript string     // transcript of the selected agent; "" = list shown
+	Turf       string     // turf the list is scoped to; "" = every turf
+}
 
 func NewAgentsTab() AgentsTab {
 	return AgentsTab{}
 }
 
-func (AgentsTab) View() string {
-	return "Agents"
+// AgentActionMsg reports the outcome of an action taken on an agent
+type AgentActionMsg struct {
+	Agent string // label of the agent acted on
+	Done  string // what happened, shown as a toast
+	Err   error
+}
+
+// AgentTranscriptMsg carries the transcript loaded for an agent's session
+type AgentTranscriptMsg struct {
+	Agent string
+	Text  string
+	Err   error
+}
+
+// SelectedAgent returns the selected agent, or nil when the list is empty
+func (t AgentsTab) SelectedAgent() *registry.AgentRecord {
+	if t.Selected < 0 || t.Selected >= len(t.Agents) {
+		return nil
+	}
+	return t.Agents[t.Selected]
+}
+
+// setAgents replaces the list, keeping the same agent selected when it is
+// still there
+func (t *AgentsTab) setAgents(agents []*registry.AgentRecord) {
+	var selected string
+	if a := t.SelectedAgent(); a != nil {
+		selected = a.ID
+	}
+	t.Agents = agents
+	for i, a := range agents {
+		if a.ID == selected {
+			t.Selected = i
+			return
+		}
+	}
+	t.Selected = min(t.Selected, max(len(agents)-1, 0))
+}
+
+func (t AgentsTab) View() string {
+	if t.Transcript != "" {
+		return t.Transcript + "\n\n" + KeyCloseView + " to return"
+	}
+	title := "Agents"
+	if t.Turf != "" {
+		title += " on " + t.Turf
+	}
+	if len(t.Agents) == 0 {
+		return title
+	}
+
+	var b strings.Builder
+	b.WriteString(title)
+	for i, a := range t.Agents {
+		row := fmt.Sprintf("%-32s %-10s %-10s %-10s %s", a.Label(), a.Type, a.Status, a.Turf, a.Task)
+		if i == t.Selected {
+			row = rowFocusStyle.Render(row)
+		}
+		b.WriteString("\n" + row)
+	}
+
+	b.WriteString("\n\n")
+	switch t.Prompt.Action {
+	case KeyKillAgent:
+		fmt.Fprintf(&b, "Kill %s? (enter to confirm, esc to cancel)", t.SelectedAgent().Label())
+	case KeyAgentModel:
+		b.WriteString(t.Prompt.View("Model: ", "switch"))
+	case KeyReassignAgent:
+		b.WriteString(t.Prompt.View("Reassign current bead to: ", "confirm"))
+	default:
+		fmt.Fprintf(&b, "%s kill  %s nudge  %s transcript  %s model  %s reassign bead", KeyKillAgent, KeyNudgeAgent, KeyAgentChat, KeyAgentModel, KeyReassignAgent)
+	}
+	return b.String()
+}
+
+// handleAgentsKey handles navigation and actions on the agents tab
+func (m Model) handleAgentsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.AgentsTab.Prompt.Active() {
+		return m.handleAgentPrompt(msg)
+	}
+	if m.AgentsTab.Transcript != "" {
+		if msg.String() == KeyCloseView {
+			m.AgentsTab.Transcript = ""
+		}
+		return m, nil
+	}
+
+	key := msg.String()
+	switch key {
+	case KeyUp:
+		if m.AgentsTab.Selected > 0 {
+			m.AgentsTab.Selected--
+		}
+		return m, nil
+	case KeyDown:
+		if m.AgentsTab.Selected < len(m.AgentsTab.Agents)-1 {
+			m.AgentsTab.Selected++
+		}
+		return m, nil
+	case KeyKillAgent, KeyNudgeAgent, KeyAgentChat, KeyAgentModel, KeyReassignAgent:
+	default:
+		return m, nil
+	}
+
+	a := m.AgentsTab.SelectedAgent()
+	if a == nil {
+		return m, nil
+	}
+	switch {
+	case key == KeyAgentChat:
+		return m, m.loadAgentTranscript(a)
+	case m.Observe:
+		m.Toasts.Push(Toast{Message: "Observer mode is read-only: agent actions are disabled"})
+		return m, nil
+	case key == KeyNudgeAgent:
+		return m, m.runAgentAction(a, key, "")
+	case key == KeyAgentModel && a.Type != "soldati":
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Only soldati have a model of their own; %s is a %s", a.Label(), a.Type)})
+		return m, nil
+	}
+	m.AgentsTab.Prompt.open(key)
+	return m, nil
+}
+
+// handleAgentPrompt takes the confirmation or text for a pending kill, model
+// change or reassign
+func (m Model) handleAgentPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.AgentsTab.Prompt
+	if p.Action == KeyKillAgent && msg.Type != tea.KeyEnter && msg.Type != tea.KeyEsc {
+		return m, nil // nothing to type, only confirm or cancel
+	}
+	if !p.key(msg) {
+		return m, nil
+	}
+	action, text := p.Action, strings.TrimSpace(p.Input)
+	if text == "" && action != KeyKillAgent {
+		return m, nil // a model or soldati is required; keep prompting
+	}
+	p.close()
+	if a := m.AgentsTab.SelectedAgent(); a != nil {
+		return m, m.runAgentAction(a, action, text)
+	}
+	return m, nil
+}
+
+// runAgentAction applies an action key to an agent in the background
+func (m Model) runAgentAction(a *registry.AgentRecord, action, text string) tea.Cmd {
+	actions, id, label := m.agentActions, a.ID, a.Label()
+	return func() tea.Msg {
+		if actions == nil {
+			return AgentActionMsg{Agent: label, Err: fmt.Errorf("agent registry unavailable")}
+		}
+		var done string
+		var err error
+		switch action {
+		case KeyKillAgent:
+			done, err = actions.Kill(id)
+		case KeyNudgeAgent:
+			done, err = actions.Nudge(id)
+		case KeyAgentModel:
+			err = actions.SetModel(id, text)
+			done = fmt.Sprintf("%s now uses %s", label, text)
+		case KeyReassignAgent:
+			var beadID string
+			beadID, err = actions.Reassign(id, text)
+			done = fmt.Sprintf("Reassigned %s from %s to %s", beadID, label, text)
+		}
+		return AgentActionMsg{Agent: label, Done: done, Err: err}
+	}
+}
+
+// handleAgentAction reports an action's outcome; the list catches up on the
+// next status poll
+func (m Model) handleAgentAction(msg AgentActionMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("%s: %v", msg.Agent, msg.Err)})
+		return m, nil
+	}
+	m.Toasts.Push(Toast{Message: msg.Done})
+	return m, nil
+}
+
+// loadAgentTranscript loads the last lines of an agent's session transcript
+func (m Model) loadAgentTranscript(a *registry.AgentRecord) tea.Cmd {
+	label, session, redactor := a.Label(), a.SessionID, m.redactor
+	return func() tea.Msg {
+		lines, err := agentTranscript(session, redactor)
+		if err != nil {
+			return AgentTranscriptMsg{Agent: label, Err: err}
+		}
+		if len(lines) > agentTranscriptMax {
+			lines = lines[len(lines)-agentTranscriptMax:]
+		}
+		return AgentTranscriptMsg{Agent: label, Text: strings.Join(lines, "\n")}
+	}
+}
+
+// agentTranscript renders an agent's session transcript as redacted lines
+func agentTranscript(session string, redactor *redact.Redactor) ([]string, error) {
+	if session == "" {
+		return nil, fmt.Errorf("no session yet")
+	}
+	path, err := transcript.Find(session)
+	if err != nil {
+		return nil, err
+	}
+	t, err := transcript.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimRight(redactor.String(transcript.RenderMarkdown(t)), "\n"), "\n"), nil
+}
+
+// handleAgentTranscript shows a loaded transcript, or reports why it could
+// not be loaded
+func (m Model) handleAgentTranscript(msg AgentTranscriptMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("No transcript for %s: %v", msg.Agent, msg.Err)})
+		return m, nil
+	}
+	m.AgentsTab.Transcript = fmt.Sprintf("Transcript of %s\n\n%s", msg.Agent, msg.Text)
+	return m, nil
 }
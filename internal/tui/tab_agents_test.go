@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/registry"
+)
+
+func TestAgentsTabShowsLabels(t *testing.T) {
+	tab := AgentsTab{Agents: []*registry.AgentRecord{
+		{ID: "a1", Type: "associate", Name: "assoc-crimson-fox", Tag: "lint-fix", Status: "working", Turf: "api"},
+		{ID: "b2c3", Type: "associate", Status: "completed"},
+	}}
+
+	view := tab.View()
+	if !strings.Contains(view, "assoc-crimson-fox [lint-fix]") {
+		t.Errorf("view missing tagged name:\n%s", view)
+	}
+	if !strings.Contains(view, "b2c3") {
+		t.Errorf("view should fall back to the ID for unnamed agents:\n%s", view)
+	}
+}
+
+type fakeAgentActions struct {
+	calls []string
+}
+
+func (f *fakeAgentActions) Kill(id string) (string, error) {
+	f.calls = append(f.calls, "kill "+id)
+	return "Killed " + id, nil
+}
+
+func (f *fakeAgentActions) Nudge(id string) (string, error) {
+	f.calls = append(f.calls, "nudge "+id)
+	return "Nudged " + id, nil
+}
+
+func (f *fakeAgentActions) SetModel(id, model string) error {
+	f.calls = append(f.calls, "model "+id+" "+model)
+	return nil
+}
+
+func (f *fakeAgentActions) Reassign(id, soldati string) (string, error) {
+	f.calls = append(f.calls, "reassign "+id+" "+soldati)
+	return "bd-aaaa", nil
+}
+
+func testAgents() []*registry.AgentRecord {
+	return []*registry.AgentRecord{
+		{ID: "s1", Type: "soldati", Name: "vinnie", Status: "active", Turf: "api", Task: "bead:bd-aaaa"},
+		{ID: "a2", Type: "associate", Name: "assoc-crimson-fox", Status: "active"},
+	}
+}
+
+func TestAgentsTabActions(t *testing.T) {
+	actions := &fakeAgentActions{}
+	m := NewModel()
+	m.agentActions = actions
+	m.ActiveTab = TabAgents
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Agents: testAgents()})
+
+	model, cmd := model.Update(runeKey(KeyNudgeAgent))
+	model, _ = model.Update(cmd())
+	if toast, _ := model.(Model).Toasts.Pop(); toast.Message != "Nudged s1" {
+		t.Errorf("expected a nudge toast, got %+v", toast)
+	}
+
+	model, cmd = typeKeys(model, runeKey(KeyAgentModel), runeKey("opus"), tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(cmd())
+	model.(Model).Toasts.Pop()
+	model, cmd = typeKeys(model, runeKey(KeyReassignAgent), runeKey("sal"), tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(cmd())
+	if toast, _ := model.(Model).Toasts.Pop(); !strings.Contains(toast.Message, "Reassigned bd-aaaa from vinnie to sal") {
+		t.Errorf("expected a reassign toast, got %+v", toast)
+	}
+
+	// Kill waits for enter; typed keys are ignored
+	model, cmd = typeKeys(model, tea.KeyMsg{Type: tea.KeyDown}, runeKey(KeyKillAgent), runeKey("n"))
+	if cmd != nil || !strings.Contains(model.View(), "Kill assoc-crimson-fox?") {
+		t.Fatalf("expected a kill confirmation:\n%s", model.View())
+	}
+	model, cmd = typeKeys(model, tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(cmd())
+
+	want := []string{"nudge s1", "model s1 opus", "reassign s1 sal", "kill a2"}
+	if strings.Join(actions.calls, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, actions.calls)
+	}
+}
+
+func TestAgentsTabModelIsForSoldati(t *testing.T) {
+	m := NewModel()
+	m.agentActions = &fakeAgentActions{}
+	m.ActiveTab = TabAgents
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Agents: testAgents()})
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyDown}, runeKey(KeyAgentModel))
+	if model.(Model).AgentsTab.Prompt.Active() {
+		t.Fatal("expected no model prompt for an associate")
+	}
+	if toast, _ := model.(Model).Toasts.Peek(); !strings.Contains(toast.Message, "Only soldati") {
+		t.Errorf("expected a toast, got %+v", toast)
+	}
+}
+
+func TestAgentsTabTranscript(t *testing.T) {
+	session := filepath.Join(t.TempDir(), "sess-7.jsonl")
+	line := `{"type":"user","sessionId":"sess-7","message":{"role":"user","content":"fix the login bug"}}` + "\n"
+	if err := os.WriteFile(session, []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+	agents := testAgents()
+	agents[0].SessionID = session
+
+	// Transcripts only read, so observers may open them
+	m := NewObserverModel()
+	m.ActiveTab = TabAgents
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Agents: agents})
+	model, cmd := model.Update(runeKey(KeyAgentChat))
+	model, _ = model.Update(cmd())
+	if view := model.View(); !strings.Contains(view, "Transcript of vinnie") || !strings.Contains(view, "fix the login bug") {
+		t.Fatalf("expected the transcript:\n%s", view)
+	}
+	model, _ = press(model, KeyCloseView)
+	if model.(Model).AgentsTab.Transcript != "" {
+		t.Fatal("expected esc to return to the list")
+	}
+
+	model, cmd = model.Update(runeKey(KeyNudgeAgent))
+	if cmd != nil {
+		t.Fatal("expected the nudge refused while observing")
+	}
+}
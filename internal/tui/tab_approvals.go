@@ -0,0 +1,257 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gabe/mob/internal/models"
+)
+
+// Keys on the approvals tab
+const (
+	KeyApproveWaiting = "a" // approve the selected bead, or its review
+	KeyRejectWaiting  = "r" // reject it, or send its review back, with a reason
+)
+
+// approvalPreviewLines is how much of a bead's diff the approvals tab shows
+const approvalPreviewLines = 30
+
+// ApprovalActions previews and decides the beads waiting on the user the way
+// mob approve and mob reject do
+type ApprovalActions interface {
+	// Preview returns the patch in the bead's worktree; empty when it has none
+	Preview(id string) (string, error)
+	Approve(id string) (string, error)
+	// Reject closes a pending bead, or sends a review back with the reason
+	// as feedback, and describes what happened
+	Reject(id, reason string) (string, error)
+}
+
+// ApprovalsTab lists the beads waiting on the user: pending beads to let
+// through and reviews to merge
+type ApprovalsTab struct {
+	Beads     []*models.Bead // waiting beads from the status poll, in list order
+	Selected  int            // index into Beads
+	Preview   string         // start of the selected bead's diff
+	Prompt    textPrompt     // reason for a rejection
+	previewID string         // bead Preview was loaded for
+	seen      map[string]bool
+}
+
+func NewApprovalsTab() ApprovalsTab {
+	return ApprovalsTab{}
+}
+
+// ApprovalPreviewMsg carries the diff loaded for a waiting bead
+type ApprovalPreviewMsg struct {
+	ID      string
+	Preview string
+	Err     error
+}
+
+// waitingOnUser reports whether a bead needs the user's approval
+func waitingOnUser(b *models.Bead) bool {
+	return b.Status == models.BeadStatusPendingApproval || b.Status == models.BeadStatusInReview
+}
+
+// SelectedBead returns the selected bead, or nil when nothing is waiting
+func (t ApprovalsTab) SelectedBead() *models.Bead {
+	if t.Selected < 0 || t.Selected >= len(t.Beads) {
+		return nil
+	}
+	return t.Beads[t.Selected]
+}
+
+// setBeads keeps the beads waiting on the user, holding the selection on the
+// same bead. It returns the beads that started waiting since the last call;
+// the first call returns none, as those were waiting before the dashboard
+// opened.
+func (t *ApprovalsTab) setBeads(beads []*models.Bead) []*models.Bead {
+	var selected string
+	if b := t.SelectedBead(); b != nil {
+		selected = b.ID
+	}
+
+	var waiting, arrived []*models.Bead
+	seen := make(map[string]bool)
+	for _, b := range beads {
+		if !waitingOnUser(b) {
+			continue
+		}
+		waiting = append(waiting, b)
+		seen[b.ID] = true
+		if t.seen != nil && !t.seen[b.ID] {
+			arrived = append(arrived, b)
+		}
+	}
+	t.Beads, t.seen = waiting, seen
+
+	t.Selected = min(t.Selected, max(len(waiting)-1, 0))
+	for i, b := range waiting {
+		if b.ID == selected {
+			t.Selected = i
+		}
+	}
+	return arrived
+}
+
+func (t ApprovalsTab) View() string {
+	if len(t.Beads) == 0 {
+		return "Approvals\nNothing waiting on you"
+	}
+
+	var list strings.Builder
+	list.WriteString("Approvals")
+	for i, b := range t.Beads {
+		row := fmt.Sprintf("%-10s %-17s %s", b.ID, b.Status, clipTitle(b.Title, beadListTitleCols))
+		if i == t.Selected {
+			row = rowFocusStyle.Render(row)
+		}
+		list.WriteString("\n" + row)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, list.String(), "   ", t.detail())
+}
+
+// detail renders the selected bead with the start of its diff, then the keys
+// or the reason prompt
+func (t ApprovalsTab) detail() string {
+	bead := t.SelectedBead()
+	var b strings.Builder
+	b.WriteString(beadSummary(bead))
+	if bead.Branch != "" {
+		fmt.Fprintf(&b, "\n\nBranch: %s", bead.Branch)
+	}
+	if t.previewID == bead.ID && t.Preview != "" {
+		lines := strings.Split(t.Preview, "\n")
+		if len(lines) > approvalPreviewLines {
+			lines = append(lines[:approvalPreviewLines], fmt.Sprintf("… %d more lines", len(lines)-approvalPreviewLines))
+		}
+		b.WriteString("\n\n" + strings.Join(lines, "\n"))
+	}
+
+	b.WriteString("\n\n")
+	switch {
+	case t.Prompt.Active() && bead.Status == models.BeadStatusInReview:
+		b.WriteString(t.Prompt.View("Changes needed: ", "send back"))
+	case t.Prompt.Active():
+		b.WriteString(t.Prompt.View("Reject reason: ", "reject"))
+	default:
+		fmt.Fprintf(&b, "%s approve  %s reject", KeyApproveWaiting, KeyRejectWaiting)
+	}
+	return b.String()
+}
+
+// handleApprovalsKey handles navigation and decisions on the approvals tab
+func (m Model) handleApprovalsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.ApprovalsTab.Prompt.Active() {
+		return m.handleRejectPrompt(msg)
+	}
+
+	key := msg.String()
+	switch key {
+	case KeyUp:
+		if m.ApprovalsTab.Selected > 0 {
+			m.ApprovalsTab.Selected--
+		}
+		return m, m.loadApprovalPreview()
+	case KeyDown:
+		if m.ApprovalsTab.Selected < len(m.ApprovalsTab.Beads)-1 {
+			m.ApprovalsTab.Selected++
+		}
+		return m, m.loadApprovalPreview()
+	case KeyApproveWaiting, KeyRejectWaiting:
+	default:
+		return m, nil
+	}
+
+	bead := m.ApprovalsTab.SelectedBead()
+	if bead == nil {
+		return m, nil
+	}
+	if m.Observe {
+		m.Toasts.Push(Toast{Message: "Observer mode is read-only: approvals are disabled"})
+		return m, nil
+	}
+	if key == KeyApproveWaiting {
+		return m, m.decideBead(bead.ID, key, "")
+	}
+	m.ApprovalsTab.Prompt.open(key)
+	return m, nil
+}
+
+// handleRejectPrompt takes the reason for a rejection. A review can only be
+// sent back with feedback; a pending bead's reason is optional.
+func (m Model) handleRejectPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.ApprovalsTab.Prompt
+	if !p.key(msg) {
+		return m, nil
+	}
+	bead := m.ApprovalsTab.SelectedBead()
+	reason := strings.TrimSpace(p.Input)
+	if bead != nil && reason == "" && bead.Status == models.BeadStatusInReview {
+		return m, nil
+	}
+	p.close()
+	if bead == nil {
+		return m, nil
+	}
+	return m, m.decideBead(bead.ID, KeyRejectWaiting, reason)
+}
+
+// decideBead approves or rejects a bead in the background. The outcome comes
+// back as a BeadActionMsg, like the beads tab's actions.
+func (m Model) decideBead(id, action, reason string) tea.Cmd {
+	actions := m.approvalActions
+	return func() tea.Msg {
+		if actions == nil {
+			return BeadActionMsg{ID: id, Err: fmt.Errorf("bead store unavailable")}
+		}
+		var done string
+		var err error
+		if action == KeyApproveWaiting {
+			done, err = actions.Approve(id)
+		} else {
+			done, err = actions.Reject(id, reason)
+		}
+		return BeadActionMsg{ID: id, Done: done, Err: err}
+	}
+}
+
+// loadApprovalPreview loads the diff for the selected bead when it has a
+// worktree and the diff is not already loaded
+func (m Model) loadApprovalPreview() tea.Cmd {
+	bead := m.ApprovalsTab.SelectedBead()
+	if bead == nil || bead.WorktreePath == "" || m.approvalActions == nil || m.ApprovalsTab.previewID == bead.ID {
+		return nil
+	}
+	actions, id := m.approvalActions, bead.ID
+	return func() tea.Msg {
+		preview, err := actions.Preview(id)
+		return ApprovalPreviewMsg{ID: id, Preview: preview, Err: err}
+	}
+}
+
+// handleApprovalPreview shows a loaded diff if its bead is still selected
+func (m Model) handleApprovalPreview(msg ApprovalPreviewMsg) (tea.Model, tea.Cmd) {
+	if bead := m.ApprovalsTab.SelectedBead(); bead == nil || bead.ID != msg.ID {
+		return m, nil
+	}
+	m.ApprovalsTab.previewID, m.ApprovalsTab.Preview = msg.ID, msg.Preview
+	if msg.Err != nil {
+		m.ApprovalsTab.Preview = ""
+	}
+	return m, nil
+}
+
+// announceApprovals toasts beads that have just started waiting on the user
+func (m *Model) announceApprovals(arrived []*models.Bead) {
+	switch len(arrived) {
+	case 0:
+	case 1:
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Waiting on your approval: %s %s", arrived[0].ID, arrived[0].Title)})
+	default:
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("%d beads are waiting on your approval", len(arrived))})
+	}
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+type fakeApprovalActions struct {
+	calls []string
+}
+
+func (f *fakeApprovalActions) Preview(id string) (string, error) {
+	var lines []string
+	for i := 0; i < approvalPreviewLines+5; i++ {
+		lines = append(lines, fmt.Sprintf("+line %d", i))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (f *fakeApprovalActions) Approve(id string) (string, error) {
+	f.calls = append(f.calls, "approve "+id)
+	return "Approved " + id, nil
+}
+
+func (f *fakeApprovalActions) Reject(id, reason string) (string, error) {
+	f.calls = append(f.calls, "reject "+id+" "+reason)
+	return "Rejected " + id, nil
+}
+
+func waitingBeads() []*models.Bead {
+	return []*models.Bead{
+		{ID: "bd-aaaa", Title: "Fix login", Status: models.BeadStatusInReview, WorktreePath: "/repo/.mob-worktrees/bd-aaaa"},
+		{ID: "bd-bbbb", Title: "Ship release", Status: models.BeadStatusOpen},
+		{ID: "bd-cccc", Title: "Drop the users table", Status: models.BeadStatusPendingApproval},
+	}
+}
+
+func TestApprovalsTabListsWaitingBeads(t *testing.T) {
+	m := NewModel()
+	m.approvalActions = &fakeApprovalActions{}
+	m.ActiveTab = TabApprovals
+
+	var model tea.Model = m
+	model, cmd := model.Update(RefreshMsg{Beads: waitingBeads()})
+	if _, ok := model.(Model).Toasts.Peek(); ok {
+		t.Error("expected no toast for beads already waiting when the dashboard opened")
+	}
+	model, _ = model.Update(cmd()) // the selected bead's preview
+
+	view := model.View()
+	for _, want := range []string{"[Approvals (2)]", "bd-aaaa", "bd-cccc", "+line 0", "… 5 more lines"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the approvals tab:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "bd-bbbb") || strings.Contains(view, fmt.Sprintf("+line %d", approvalPreviewLines)) {
+		t.Errorf("expected only waiting beads and a clipped preview:\n%s", view)
+	}
+
+	beads := append(waitingBeads(), &models.Bead{ID: "bd-dddd", Title: "Rotate keys", Status: models.BeadStatusPendingApproval})
+	model, _ = model.Update(RefreshMsg{Beads: beads})
+	if toast, _ := model.(Model).Toasts.Peek(); !strings.Contains(toast.Message, "bd-dddd Rotate keys") {
+		t.Errorf("expected a toast for the new approval, got %+v", toast)
+	}
+}
+
+func TestApprovalsTabDecisions(t *testing.T) {
+	actions := &fakeApprovalActions{}
+	m := NewModel()
+	m.approvalActions = actions
+	m.ActiveTab = TabApprovals
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Beads: waitingBeads()})
+
+	// A review can only be sent back with feedback
+	model, cmd := typeKeys(model, runeKey(KeyRejectWaiting), tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !strings.Contains(model.View(), "Changes needed:") {
+		t.Fatalf("expected the feedback prompt kept open:\n%s", model.View())
+	}
+	model, cmd = typeKeys(model, runeKey("add tests"), tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(cmd())
+
+	// A pending bead's reason is optional
+	model, cmd = typeKeys(model, tea.KeyMsg{Type: tea.KeyDown}, runeKey(KeyRejectWaiting), tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(cmd())
+
+	model, cmd = typeKeys(model, runeKey(KeyApproveWaiting))
+	model.Update(cmd())
+
+	want := []string{"reject bd-aaaa add tests", "reject bd-cccc ", "approve bd-cccc"}
+	if strings.Join(actions.calls, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, actions.calls)
+	}
+
+	observer := NewObserverModel()
+	observer.approvalActions = actions
+	observer.ActiveTab = TabApprovals
+	model, _ = observer.Update(RefreshMsg{Beads: waitingBeads()})
+	if _, cmd = typeKeys(model, runeKey(KeyApproveWaiting)); cmd != nil {
+		t.Error("expected approvals refused while observing")
+	}
+}
@@ -0,0 +1,371 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gabe/mob/internal/models"
+)
+
+// Keys on the beads tab
+const (
+	KeyApproveBead  = "a" // approve the selected bead, or its review
+	KeyReassignBead = "r" // hand it to another soldati
+	KeyCommentBead  = "c" // comment on it
+	KeyCloseBead    = "x" // close it
+)
+
+// Limits on what the detail pane lists
+const (
+	beadHistoryLimit  = 8  // most recent history events
+	beadCommentLimit  = 5  // most recent comments
+	beadListTitleCols = 36 // title width in the list
+)
+
+// BeadActions reads a bead's worktree diff for the beads tab and changes
+// beads from its keybindings
+type BeadActions interface {
+	// Diff summarizes the changes in the bead's worktree; empty when none
+	Diff(id string) (string, error)
+	// Approve approves a bead pending approval, or one in review, and
+	// describes what happened
+	Approve(id string) (string, error)
+	Reassign(id, soldati string) error
+	Comment(id, text string) error
+	Close(id, reason string) error
+	// SetStatus moves a bead to another status from the board
+	SetStatus(id string, status models.BeadStatus) error
+}
+
+// BeadsTab lists the open beads next to a detail pane for the selected one,
+// or lays them out on a board by status
+type BeadsTab struct {
+	Beads    []*models.Bead // open beads from the status poll, in list order
+	Closed   []*models.Bead // recently closed beads, for the board
+	Selected int            // index into Beads
+	Board    beadBoard      // the board view, when Board.Active
+	Turf     string         // turf the beads are scoped to; "" = every turf
+	Diff     string         // diff summary of the selected bead's worktree
+	Prompt   textPrompt     // text for a reassign, comment or close
+	diffID   string         // bead Diff was loaded for
+}
+
+func NewBeadsTab() BeadsTab {
+	return BeadsTab{}
+}
+
+// BeadDiffMsg carries the diff summary loaded for a bead
+type BeadDiffMsg struct {
+	ID   string
+	Diff string
+	Err  error
+}
+
+// BeadActionMsg reports the outcome of an action taken on a bead
+type BeadActionMsg struct {
+	ID   string
+	Done string // what happened, shown as a toast
+	Err  error
+}
+
+var rowFocusStyle = lipgloss.NewStyle().Reverse(true)
+
+// SelectedBead returns the selected bead on the list or the board, or nil
+// when there is none
+func (t BeadsTab) SelectedBead() *models.Bead {
+	if t.Board.Active {
+		return t.boardBead()
+	}
+	if t.Selected < 0 || t.Selected >= len(t.Beads) {
+		return nil
+	}
+	return t.Beads[t.Selected]
+}
+
+// setBeads replaces the open and closed beads, keeping the same bead
+// selected when it is still there; on the board it follows the bead to its
+// new column
+func (t *BeadsTab) setBeads(beads, closed []*models.Bead) {
+	var selected string
+	if b := t.SelectedBead(); b != nil {
+		selected = b.ID
+	}
+	t.Beads, t.Closed = beads, closed
+	if t.Board.Active && !t.selectOnBoard(selected) {
+		t.clampBoardRow()
+	}
+	t.selectInList(selected)
+}
+
+// selectInList selects a bead in the list when it is there
+func (t *BeadsTab) selectInList(id string) {
+	for i, b := range t.Beads {
+		if b.ID == id {
+			t.Selected = i
+			return
+		}
+	}
+	t.Selected = min(t.Selected, max(len(t.Beads)-1, 0))
+}
+
+// toggleBoard switches between the list and the board, keeping the same
+// bead selected
+func (t *BeadsTab) toggleBoard() {
+	var selected string
+	if b := t.SelectedBead(); b != nil {
+		selected = b.ID
+	}
+	t.Board.Active = !t.Board.Active
+	if t.Board.Active {
+		t.selectOnBoard(selected)
+	} else {
+		t.selectInList(selected)
+	}
+}
+
+// blockedBy returns the listed beads that block id. Closed beads block
+// nothing and are not listed.
+func (t BeadsTab) blockedBy(id string) []string {
+	var ids []string
+	for _, b := range t.Beads {
+		for _, blocked := range b.Blocks {
+			if blocked == id {
+				ids = append(ids, b.ID)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+func (t BeadsTab) View() string {
+	if t.Board.Active {
+		return t.boardView()
+	}
+	title := "Beads"
+	if t.Turf != "" {
+		title += " on " + t.Turf
+	}
+	if len(t.Beads) == 0 {
+		return title + "\nNo open beads"
+	}
+
+	var list strings.Builder
+	fmt.Fprintf(&list, "%s (%d)", title, len(t.Beads))
+	for i, b := range t.Beads {
+		row := fmt.Sprintf("%-10s P%d %-17s %s", b.ID, b.Priority, b.Status, clipTitle(b.Title, beadListTitleCols))
+		if i == t.Selected {
+			row = rowFocusStyle.Render(row)
+		}
+		list.WriteString("\n" + row)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, list.String(), "   ", t.detail())
+}
+
+// detail renders the selected bead with its worktree, blockers, history and
+// comments, then the keys or the prompt for a pending action
+func (t BeadsTab) detail() string {
+	bead := t.SelectedBead()
+	var b strings.Builder
+	b.WriteString(beadSummary(bead))
+	if bead.Branch != "" {
+		fmt.Fprintf(&b, "\n\nBranch: %s", bead.Branch)
+		if bead.WorktreePath != "" {
+			fmt.Fprintf(&b, " (%s)", bead.WorktreePath)
+		}
+	}
+	if t.diffID == bead.ID && t.Diff != "" {
+		fmt.Fprintf(&b, "\nDiff: %s", t.Diff)
+	}
+	writeBeadList(&b, "Blocked by", t.blockedBy(bead.ID))
+
+	var history, comments []string
+	for _, e := range bead.History {
+		if e.Type == models.BeadEventTypeComment {
+			comments = append(comments, fmt.Sprintf("  %s %s: %s", e.Timestamp.Format("Jan 2 15:04"), e.Actor, e.Comment))
+		} else {
+			history = append(history, "  "+beadEventLine(e))
+		}
+	}
+	writeRecent(&b, "History", history, beadHistoryLimit)
+	writeRecent(&b, "Comments", comments, beadCommentLimit)
+
+	b.WriteString("\n\n")
+	switch t.Prompt.Action {
+	case KeyReassignBead:
+		b.WriteString(t.Prompt.View("Reassign to: ", "confirm"))
+	case KeyCommentBead:
+		b.WriteString(t.Prompt.View("Comment: ", "post"))
+	case KeyCloseBead:
+		b.WriteString(t.Prompt.View("Close reason: ", "close"))
+	default:
+		fmt.Fprintf(&b, "%s approve  %s reassign  %s comment  %s close  %s board", KeyApproveBead, KeyReassignBead, KeyCommentBead, KeyCloseBead, KeyBoard)
+	}
+	return b.String()
+}
+
+// beadEventLine renders a history event on one line
+func beadEventLine(e models.BeadEvent) string {
+	line := fmt.Sprintf("%s %s %s", e.Timestamp.Format("Jan 2 15:04"), e.Actor, e.Type)
+	switch {
+	case e.From != "" && e.To != "":
+		line += fmt.Sprintf(" %s → %s", e.From, e.To)
+	case e.To != "":
+		line += " " + e.To
+	}
+	if e.Comment != "" {
+		line += ": " + e.Comment
+	}
+	return line
+}
+
+// writeRecent writes a heading and the last n lines under it
+func writeRecent(b *strings.Builder, heading string, lines []string, n int) {
+	if len(lines) == 0 {
+		return
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	fmt.Fprintf(b, "\n\n%s:\n%s", heading, strings.Join(lines, "\n"))
+}
+
+func clipTitle(title string, n int) string {
+	r := []rune(title)
+	if len(r) <= n {
+		return title
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// handleBeadsKey handles navigation and actions on the beads tab
+func (m Model) handleBeadsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.BeadsTab.Prompt.Active() {
+		return m.handleBeadPrompt(msg)
+	}
+
+	key := msg.String()
+	if key == KeyBoard {
+		m.BeadsTab.toggleBoard()
+		return m, m.loadBeadDiff()
+	}
+	if m.BeadsTab.Board.Active {
+		if model, cmd, ok := m.handleBoardKey(key); ok {
+			return model, cmd
+		}
+	}
+	switch key {
+	case KeyUp:
+		if m.BeadsTab.Selected > 0 {
+			m.BeadsTab.Selected--
+		}
+		return m, m.loadBeadDiff()
+	case KeyDown:
+		if m.BeadsTab.Selected < len(m.BeadsTab.Beads)-1 {
+			m.BeadsTab.Selected++
+		}
+		return m, m.loadBeadDiff()
+	case KeyApproveBead, KeyReassignBead, KeyCommentBead, KeyCloseBead:
+	default:
+		return m, nil
+	}
+
+	bead := m.BeadsTab.SelectedBead()
+	if bead == nil {
+		return m, nil
+	}
+	if m.Observe {
+		m.Toasts.Push(Toast{Message: "Observer mode is read-only: bead actions are disabled"})
+		return m, nil
+	}
+	if key == KeyApproveBead {
+		return m, m.runBeadAction(bead.ID, key, "")
+	}
+	m.BeadsTab.Prompt.open(key)
+	return m, nil
+}
+
+// handleBeadPrompt takes the text for a pending reassign, comment or close
+func (m Model) handleBeadPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := &m.BeadsTab.Prompt
+	if !p.key(msg) {
+		return m, nil
+	}
+	action, text := p.Action, strings.TrimSpace(p.Input)
+	if text == "" && action != KeyCloseBead {
+		return m, nil // a soldati or comment is required; keep prompting
+	}
+	p.close()
+	if bead := m.BeadsTab.SelectedBead(); bead != nil {
+		return m, m.runBeadAction(bead.ID, action, text)
+	}
+	return m, nil
+}
+
+// runBeadAction applies an action key to a bead in the background
+func (m Model) runBeadAction(id, action, text string) tea.Cmd {
+	actions := m.beadActions
+	return func() tea.Msg {
+		if actions == nil {
+			return BeadActionMsg{ID: id, Err: fmt.Errorf("bead store unavailable")}
+		}
+		var done string
+		var err error
+		switch action {
+		case KeyApproveBead:
+			done, err = actions.Approve(id)
+		case KeyReassignBead:
+			err = actions.Reassign(id, text)
+			done = fmt.Sprintf("Reassigned %s to %s", id, text)
+		case KeyCommentBead:
+			err = actions.Comment(id, text)
+			done = fmt.Sprintf("Commented on %s", id)
+		case KeyCloseBead:
+			err = actions.Close(id, text)
+			done = fmt.Sprintf("Closed %s", id)
+		}
+		return BeadActionMsg{ID: id, Done: done, Err: err}
+	}
+}
+
+// handleBeadAction reports an action's outcome. The list catches up on the
+// next status poll; the diff is reloaded since the action may have changed it.
+func (m Model) handleBeadAction(msg BeadActionMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("%s: %v", msg.ID, msg.Err)})
+		return m, nil
+	}
+	m.Toasts.Push(Toast{Message: msg.Done})
+	m.BeadsTab.diffID = ""
+	return m, m.loadBeadDiff()
+}
+
+// loadBeadDiff loads the diff summary for the selected bead when it has a
+// worktree and the summary is not already loaded
+func (m Model) loadBeadDiff() tea.Cmd {
+	bead := m.BeadsTab.SelectedBead()
+	if bead == nil || bead.WorktreePath == "" || m.beadActions == nil || m.BeadsTab.diffID == bead.ID {
+		return nil
+	}
+	actions, id := m.beadActions, bead.ID
+	return func() tea.Msg {
+		diff, err := actions.Diff(id)
+		return BeadDiffMsg{ID: id, Diff: diff, Err: err}
+	}
+}
+
+// handleBeadDiff shows a loaded diff summary if its bead is still selected.
+// A worktree that cannot be diffed just leaves the summary out.
+func (m Model) handleBeadDiff(msg BeadDiffMsg) (tea.Model, tea.Cmd) {
+	if bead := m.BeadsTab.SelectedBead(); bead == nil || bead.ID != msg.ID {
+		return m, nil
+	}
+	m.BeadsTab.diffID, m.BeadsTab.Diff = msg.ID, msg.Diff
+	if msg.Err != nil {
+		m.BeadsTab.Diff = ""
+	}
+	return m, nil
+}
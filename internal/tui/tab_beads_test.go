@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+type fakeBeadActions struct {
+	calls []string
+}
+
+func (f *fakeBeadActions) Diff(id string) (string, error) {
+	return "2 files changed, 10 insertions(+)", nil
+}
+
+func (f *fakeBeadActions) Approve(id string) (string, error) {
+	f.calls = append(f.calls, "approve "+id)
+	return "Approved " + id, nil
+}
+
+func (f *fakeBeadActions) Reassign(id, soldati string) error {
+	f.calls = append(f.calls, "reassign "+id+" "+soldati)
+	return nil
+}
+
+func (f *fakeBeadActions) Comment(id, text string) error {
+	f.calls = append(f.calls, "comment "+id+" "+text)
+	return nil
+}
+
+func (f *fakeBeadActions) Close(id, reason string) error {
+	f.calls = append(f.calls, "close "+id+" "+reason)
+	return nil
+}
+
+func (f *fakeBeadActions) SetStatus(id string, status models.BeadStatus) error {
+	f.calls = append(f.calls, "status "+id+" "+string(status))
+	return nil
+}
+
+func typeKeys(model tea.Model, keys ...tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		model, cmd = model.Update(k)
+	}
+	return model, cmd
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func testBeads() []*models.Bead {
+	at := time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)
+	return []*models.Bead{
+		{ID: "bd-aaaa", Title: "Fix login", Status: models.BeadStatusInReview, Priority: 1, Branch: "mob/bd-aaaa", WorktreePath: "/repo/.mob-worktrees/bd-aaaa",
+			History: []models.BeadEvent{
+				{Timestamp: at, Type: models.BeadEventTypeAssigned, Actor: "daemon", To: "vinnie"},
+				{Timestamp: at, Type: models.BeadEventTypeComment, Actor: "vinnie", Comment: "Cookie TTL was wrong"},
+			}},
+		{ID: "bd-bbbb", Title: "Ship release", Status: models.BeadStatusOpen, Priority: 2},
+		{ID: "bd-cccc", Title: "Migrate schema", Status: models.BeadStatusOpen, Priority: 2, Blocks: []string{"bd-bbbb"}},
+	}
+}
+
+func TestBeadsTabDetailPane(t *testing.T) {
+	actions := &fakeBeadActions{}
+	m := NewModel()
+	m.beadActions = actions
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Beads: testBeads()})
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyShiftTab})
+	if model.(Model).ActiveTab != TabBeads {
+		t.Fatalf("expected three shift+tabs from chat to wrap past costs and notifications to beads, got %d", model.(Model).ActiveTab)
+	}
+	model, cmd := typeKeys(model, tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyTab})
+	if model.(Model).ActiveTab != TabChat {
+		t.Fatalf("expected three tabs from beads to pass notifications and costs and wrap back to chat, got %d", model.(Model).ActiveTab)
+	}
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyShiftTab})
+	model, cmd = model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if cmd == nil {
+		t.Fatal("expected shift+tabbing back onto beads to load the selected bead's diff")
+	}
+	model, _ = model.Update(cmd())
+
+	view := model.View()
+	for _, want := range []string{"Fix login", "Branch: mob/bd-aaaa", "Diff: 2 files changed", "assigned vinnie", "vinnie: Cookie TTL was wrong"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the detail pane:\n%s", want, view)
+		}
+	}
+
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyDown})
+	view = model.View()
+	if !strings.Contains(view, "Blocked by: bd-cccc") || strings.Contains(view, "Diff:") {
+		t.Errorf("expected bd-bbbb's blockers and no diff:\n%s", view)
+	}
+
+	// A refresh keeps the selection on the same bead
+	beads := testBeads()
+	model, _ = model.Update(RefreshMsg{Beads: []*models.Bead{beads[2], beads[1]}})
+	if b := model.(Model).BeadsTab.SelectedBead(); b == nil || b.ID != "bd-bbbb" {
+		t.Fatalf("expected bd-bbbb still selected, got %+v", b)
+	}
+}
+
+func TestBeadsTabActions(t *testing.T) {
+	actions := &fakeBeadActions{}
+	m := NewModel()
+	m.beadActions = actions
+	m.ActiveTab = TabBeads
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Beads: testBeads()})
+
+	model, cmd := model.Update(runeKey(KeyApproveBead))
+	model, _ = model.Update(cmd())
+	if toast, _ := model.(Model).Toasts.Peek(); toast.Message != "Approved bd-aaaa" {
+		t.Errorf("expected an approval toast, got %+v", toast)
+	}
+
+	// Enter with nothing typed keeps prompting; esc cancels
+	model, cmd = typeKeys(model, runeKey(KeyReassignBead), tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !strings.Contains(model.View(), "Reassign to:") {
+		t.Fatalf("expected the reassign prompt kept open:\n%s", model.View())
+	}
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyEsc})
+	if strings.Contains(model.View(), "Reassign to:") {
+		t.Fatal("expected esc to cancel the prompt")
+	}
+
+	model, cmd = typeKeys(model, runeKey(KeyReassignBead), runeKey("sak"), tea.KeyMsg{Type: tea.KeyBackspace}, runeKey("l"), tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(cmd())
+
+	// Keys typed into a prompt are text, not actions
+	model, cmd = typeKeys(model, runeKey(KeyCommentBead), runeKey("x"), tea.KeyMsg{Type: tea.KeySpace}, runeKey("ok"), tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = model.Update(cmd())
+
+	// A close reason is optional
+	model, cmd = typeKeys(model, runeKey(KeyCloseBead), tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(cmd())
+
+	want := []string{"approve bd-aaaa", "reassign bd-aaaa sal", "comment bd-aaaa x ok", "close bd-aaaa "}
+	if strings.Join(actions.calls, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, actions.calls)
+	}
+}
+
+func TestBeadsTabReadOnlyWhileObserving(t *testing.T) {
+	actions := &fakeBeadActions{}
+	m := NewObserverModel()
+	m.beadActions = actions
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Beads: testBeads()})
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyShiftTab}, tea.KeyMsg{Type: tea.KeyShiftTab})
+	if model.(Model).ActiveTab != TabBeads {
+		t.Fatalf("expected three shift+tabs from daemon to skip chat and wrap past costs and notifications to beads, got %d", model.(Model).ActiveTab)
+	}
+
+	model, cmd := typeKeys(model, runeKey(KeyCloseBead))
+	if cmd != nil || len(actions.calls) != 0 {
+		t.Fatalf("expected the close refused, got %v", actions.calls)
+	}
+	if toast, _ := model.(Model).Toasts.Peek(); !strings.Contains(toast.Message, "read-only") {
+		t.Errorf("expected a read-only toast, got %+v", toast)
+	}
+}
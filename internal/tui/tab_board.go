@@ -0,0 +1,221 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gabe/mob/internal/models"
+)
+
+// Keys for the board view of the beads tab
+const (
+	KeyBoard       = "b"     // switch between the list and the board
+	KeyBoardTurf   = "f"     // show the next turf, as /turf does
+	KeyBoardLeft   = "left"  // the column to the left
+	KeyBoardRight  = "right" // the column to the right
+	KeyMoveBeadFwd = ">"     // move the selected bead one column right
+	KeyMoveBeadBck = "<"     // move it one column left
+)
+
+// boardColumns are the statuses the board shows, left to right
+var boardColumns = []models.BeadStatus{
+	models.BeadStatusOpen,
+	models.BeadStatusInProgress,
+	models.BeadStatusPendingApproval,
+	models.BeadStatusBlocked,
+	models.BeadStatusClosed,
+}
+
+var boardColumnTitles = map[models.BeadStatus]string{
+	models.BeadStatusOpen:            "Open",
+	models.BeadStatusInProgress:      "In Progress",
+	models.BeadStatusPendingApproval: "Pending Approval",
+	models.BeadStatusBlocked:         "Blocked",
+	models.BeadStatusClosed:          "Closed",
+}
+
+// boardColumnCols is the width of a board column
+const boardColumnCols = 26
+
+var boardHeadingStyle = lipgloss.NewStyle().Bold(true)
+
+// beadBoard is where the board view is: which column and row are selected
+type beadBoard struct {
+	Active bool
+	Column int // index into boardColumns
+	Row    int // index into that column's beads
+}
+
+// boardColumn maps a status to its column. Beads in review are still being
+// worked, so they sit with those in progress.
+func boardColumn(status models.BeadStatus) int {
+	if status == models.BeadStatusInReview {
+		status = models.BeadStatusInProgress
+	}
+	for i, s := range boardColumns {
+		if s == status {
+			return i
+		}
+	}
+	return 0
+}
+
+// column returns the beads in a board column
+func (t BeadsTab) column(i int) []*models.Bead {
+	beads := t.Beads
+	if boardColumns[i] == models.BeadStatusClosed {
+		beads = t.Closed
+	}
+	var col []*models.Bead
+	for _, b := range beads {
+		if boardColumn(b.Status) == i {
+			col = append(col, b)
+		}
+	}
+	return col
+}
+
+// boardBead returns the bead selected on the board, or nil when its column
+// is empty
+func (t BeadsTab) boardBead() *models.Bead {
+	col := t.column(t.Board.Column)
+	if t.Board.Row < 0 || t.Board.Row >= len(col) {
+		return nil
+	}
+	return col[t.Board.Row]
+}
+
+// selectOnBoard selects a bead on the board, wherever it now is. It reports
+// false when the bead is not on the board.
+func (t *BeadsTab) selectOnBoard(id string) bool {
+	for i := range boardColumns {
+		for j, b := range t.column(i) {
+			if b.ID == id {
+				t.Board.Column, t.Board.Row = i, j
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// clampBoardRow keeps the row inside the selected column
+func (t *BeadsTab) clampBoardRow() {
+	t.Board.Row = min(t.Board.Row, max(len(t.column(t.Board.Column))-1, 0))
+}
+
+// boardView renders a column for each status with its count, then the
+// selected bead and the keys or the prompt for a pending action
+func (t BeadsTab) boardView() string {
+	var b strings.Builder
+	b.WriteString("Board")
+	if t.Turf != "" {
+		b.WriteString(" on " + t.Turf)
+	}
+	b.WriteString("\n")
+
+	columns := make([]string, len(boardColumns))
+	for i, status := range boardColumns {
+		beads := t.column(i)
+		rows := []string{boardHeadingStyle.Render(fmt.Sprintf("%s (%d)", boardColumnTitles[status], len(beads)))}
+		for j, bead := range beads {
+			title := bead.Title
+			if bead.Status == models.BeadStatusInReview {
+				title = "[review] " + title
+			}
+			row := fmt.Sprintf("%-8s %s", bead.ID, clipTitle(title, boardColumnCols-10))
+			if i == t.Board.Column && j == t.Board.Row {
+				row = rowFocusStyle.Render(row)
+			}
+			rows = append(rows, row)
+		}
+		columns[i] = lipgloss.NewStyle().Width(boardColumnCols).Render(strings.Join(rows, "\n"))
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+
+	b.WriteString("\n\n")
+	if bead := t.boardBead(); bead != nil {
+		fmt.Fprintf(&b, "%s P%d %s", bead.ID, bead.Priority, bead.Title)
+		if bead.Assignee != "" {
+			b.WriteString(" (" + bead.Assignee + ")")
+		}
+		b.WriteString("\n")
+	}
+	switch t.Prompt.Action {
+	case KeyReassignBead:
+		b.WriteString(t.Prompt.View("Reassign to: ", "confirm"))
+	case KeyCommentBead:
+		b.WriteString(t.Prompt.View("Comment: ", "post"))
+	case KeyCloseBead:
+		b.WriteString(t.Prompt.View("Close reason: ", "close"))
+	default:
+		fmt.Fprintf(&b, "%s/%s move  %s next turf  %s list  %s approve  %s reassign  %s comment  %s close",
+			KeyMoveBeadBck, KeyMoveBeadFwd, KeyBoardTurf, KeyBoard, KeyApproveBead, KeyReassignBead, KeyCommentBead, KeyCloseBead)
+	}
+	return b.String()
+}
+
+// handleBoardKey moves around the board, filters it and moves beads between
+// columns. It reports false for keys it leaves to the beads tab.
+func (m Model) handleBoardKey(key string) (tea.Model, tea.Cmd, bool) {
+	t := &m.BeadsTab
+	switch key {
+	case KeyUp:
+		if t.Board.Row > 0 {
+			t.Board.Row--
+		}
+	case KeyDown:
+		if t.Board.Row < len(t.column(t.Board.Column))-1 {
+			t.Board.Row++
+		}
+	case KeyBoardLeft:
+		if t.Board.Column > 0 {
+			t.Board.Column--
+			t.clampBoardRow()
+		}
+	case KeyBoardRight:
+		if t.Board.Column < len(boardColumns)-1 {
+			t.Board.Column++
+			t.clampBoardRow()
+		}
+	case KeyBoardTurf:
+		model, cmd := m.nextTurf()
+		return model, cmd, true
+	case KeyMoveBeadFwd, KeyMoveBeadBck:
+		model, cmd := m.moveBead(key)
+		return model, cmd, true
+	default:
+		return m, nil, false
+	}
+	return m, m.loadBeadDiff(), true
+}
+
+// moveBead moves the selected bead to the next column in the direction of
+// key, updating its status in the background
+func (m Model) moveBead(key string) (tea.Model, tea.Cmd) {
+	bead := m.BeadsTab.boardBead()
+	if bead == nil {
+		return m, nil
+	}
+	col := boardColumn(bead.Status) + 1
+	if key == KeyMoveBeadBck {
+		col -= 2
+	}
+	if col < 0 || col >= len(boardColumns) {
+		return m, nil
+	}
+	if m.Observe {
+		m.Toasts.Push(Toast{Message: "Observer mode is read-only: bead actions are disabled"})
+		return m, nil
+	}
+	actions, id, status := m.beadActions, bead.ID, boardColumns[col]
+	return m, func() tea.Msg {
+		if actions == nil {
+			return BeadActionMsg{ID: id, Err: fmt.Errorf("bead store unavailable")}
+		}
+		err := actions.SetStatus(id, status)
+		return BeadActionMsg{ID: id, Done: fmt.Sprintf("Moved %s to %s", id, boardColumnTitles[status]), Err: err}
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+func boardBeads() (open, closed []*models.Bead) {
+	closedAt := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	open = []*models.Bead{
+		{ID: "bd-aaaa", Title: "Fix login", Status: models.BeadStatusInReview, Turf: "web"},
+		{ID: "bd-bbbb", Title: "Ship release", Status: models.BeadStatusOpen, Turf: "api"},
+		{ID: "bd-cccc", Title: "Migrate schema", Status: models.BeadStatusOpen, Turf: "web"},
+		{ID: "bd-dddd", Title: "Rotate keys", Status: models.BeadStatusBlocked, Turf: "api"},
+	}
+	closed = []*models.Bead{
+		{ID: "bd-eeee", Title: "Old bug", Status: models.BeadStatusClosed, Turf: "web", ClosedAt: &closedAt},
+	}
+	return open, closed
+}
+
+func TestBeadsBoardColumnsAndTurfFilter(t *testing.T) {
+	m := NewModel()
+	m.ActiveTab = TabBeads
+	open, closed := boardBeads()
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Beads: open, Closed: closed})
+	model, _ = model.Update(runeKey(KeyBoard))
+
+	view := model.View()
+	for _, want := range []string{"Open (2)", "In Progress (1)", "Pending Approval (0)", "Blocked (1)", "Closed (1)", "[review]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q on the board:\n%s", want, view)
+		}
+	}
+	// The list's selection carries over to the board
+	if b := model.(Model).BeadsTab.SelectedBead(); b == nil || b.ID != "bd-aaaa" {
+		t.Fatalf("expected bd-aaaa selected on the board, got %+v", b)
+	}
+
+	model, _ = typeKeys(model, runeKey(KeyBoardTurf))
+	view = model.View()
+	if !strings.Contains(view, "Board on web") || !strings.Contains(view, "Open (1)") || !strings.Contains(view, "Blocked (0)") {
+		t.Fatalf("expected the board filtered to web:\n%s", view)
+	}
+	model, _ = typeKeys(model, runeKey(KeyBoardTurf), runeKey(KeyBoardTurf))
+	if turf := model.(Model).turf; turf != "" {
+		t.Fatalf("expected the filter to cycle back to every turf, got %q", turf)
+	}
+
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyRight}, tea.KeyMsg{Type: tea.KeyRight}, tea.KeyMsg{Type: tea.KeyRight})
+	if b := model.(Model).BeadsTab.SelectedBead(); b == nil || b.ID != "bd-eeee" {
+		t.Fatalf("expected the closed bead selected, got %+v", b)
+	}
+
+	model, _ = model.Update(runeKey(KeyBoard))
+	if b := model.(Model).BeadsTab.SelectedBead(); b == nil || b.ID != "bd-aaaa" {
+		t.Fatalf("expected the list to keep its selection, got %+v", b)
+	}
+}
+
+func TestBeadsBoardMovesBeads(t *testing.T) {
+	actions := &fakeBeadActions{}
+	m := NewModel()
+	m.beadActions = actions
+	m.ActiveTab = TabBeads
+	open, closed := boardBeads()
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Beads: open, Closed: closed})
+	model, _ = typeKeys(model, runeKey(KeyBoard), tea.KeyMsg{Type: tea.KeyLeft})
+
+	model, cmd := model.Update(runeKey(KeyMoveBeadFwd))
+	model, _ = model.Update(cmd())
+	if toast, _ := model.(Model).Toasts.Peek(); toast.Message != "Moved bd-bbbb to In Progress" {
+		t.Errorf("expected a move toast, got %+v", toast)
+	}
+
+	// Beads in review move on from the in progress column
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyRight})
+	model, cmd = model.Update(runeKey(KeyMoveBeadFwd))
+	model.Update(cmd())
+
+	// Nothing is left of open
+	model, _ = typeKeys(model, tea.KeyMsg{Type: tea.KeyLeft})
+	if _, cmd = model.Update(runeKey(KeyMoveBeadBck)); cmd != nil {
+		t.Fatal("expected no move left of the first column")
+	}
+
+	want := []string{"status bd-bbbb in_progress", "status bd-aaaa pending_approval"}
+	if strings.Join(actions.calls, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected %q, got %q", want, actions.calls)
+	}
+
+	// The selection follows the bead to its new column
+	moved := *open[1]
+	moved.Status = models.BeadStatusInProgress
+	model, _ = model.Update(RefreshMsg{Beads: []*models.Bead{open[0], &moved, open[2], open[3]}, Closed: closed})
+	if tab := model.(Model).BeadsTab; tab.Board.Column != 1 || tab.SelectedBead().ID != "bd-bbbb" {
+		t.Fatalf("expected bd-bbbb selected in progress, got column %d %+v", tab.Board.Column, tab.SelectedBead())
+	}
+
+	observer := NewObserverModel()
+	observer.beadActions = actions
+	observer.ActiveTab = TabBeads
+	model = observer
+	model, _ = model.Update(RefreshMsg{Beads: open, Closed: closed})
+	model, cmd = typeKeys(model, runeKey(KeyBoard), runeKey(KeyMoveBeadFwd))
+	if cmd != nil || len(actions.calls) != 2 {
+		t.Fatalf("expected the move refused while observing, got %v", actions.calls)
+	}
+}
@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+// KeyCostWindow cycles the span the per-agent and per-turf breakdowns cover
+const KeyCostWindow = "w"
+
+// costWindows are the spans the breakdowns cycle through, in days
+var costWindows = []int{1, 7, 30}
+
+// Limits on what the costs tab shows
+const (
+	CostLedgerDays = 31 // days of the usage ledger the tab loads, enough for a month
+	costDailyDays  = 30 // days in the daily spend sparkline
+	costBurnHours  = 24 // hours in the token burn sparkline
+	costRateDays   = 7  // days averaged for the projected monthly spend
+	costRows       = 8  // agents or turfs listed in a breakdown
+)
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// CostsTab breaks down what the mob has spent, from the usage ledger, by
+// agent, turf and day, with the recent burn rate and a projection for the
+// month
+type CostsTab struct {
+	Entries []models.UsageEntry // the ledger's last CostLedgerDays days, oldest first
+	Window  int                 // index into costWindows
+}
+
+func NewCostsTab() CostsTab {
+	return CostsTab{Window: 1}
+}
+
+// costTotal is what a set of calls used
+type costTotal struct {
+	Name   string
+	Calls  int
+	Tokens int
+	USD    float64
+}
+
+func (c *costTotal) add(e models.UsageEntry) {
+	c.Calls++
+	c.Tokens += e.Tokens()
+	c.USD += e.CostUSD
+}
+
+// startOfDay is local midnight on t's day, days days later
+func startOfDay(t time.Time, days int) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, time.Local)
+}
+
+// since totals the calls made from the given time on
+func (t CostsTab) since(from time.Time) costTotal {
+	var total costTotal
+	for _, e := range t.Entries {
+		if !e.Timestamp.Before(from) {
+			total.add(e)
+		}
+	}
+	return total
+}
+
+// breakdown totals the calls in the last days by key, most expensive first
+func (t CostsTab) breakdown(at time.Time, days int, key func(models.UsageEntry) string) []costTotal {
+	from := startOfDay(at, 1-days)
+	totals := make(map[string]*costTotal)
+	for _, e := range t.Entries {
+		if e.Timestamp.Before(from) {
+			continue
+		}
+		name := key(e)
+		if name == "" {
+			name = "(none)"
+		}
+		if totals[name] == nil {
+			totals[name] = &costTotal{Name: name}
+		}
+		totals[name].add(e)
+	}
+	rows := make([]costTotal, 0, len(totals))
+	for _, total := range totals {
+		rows = append(rows, *total)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].USD != rows[j].USD {
+			return rows[i].USD > rows[j].USD
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// daily sums the spend on each of the last days, oldest first
+func (t CostsTab) daily(at time.Time, days int) []float64 {
+	first := startOfDay(at, 1-days)
+	spend := make([]float64, days)
+	for _, e := range t.Entries {
+		if e.Timestamp.Before(first) {
+			continue
+		}
+		if i := int(e.Timestamp.Sub(first).Hours() / 24); i < days {
+			spend[i] += e.CostUSD
+		}
+	}
+	return spend
+}
+
+// burn sums the tokens used in each of the last hours, oldest first
+func (t CostsTab) burn(at time.Time, hours int) []float64 {
+	first := at.Truncate(time.Hour).Add(-time.Duration(hours-1) * time.Hour)
+	tokens := make([]float64, hours)
+	for _, e := range t.Entries {
+		if e.Timestamp.Before(first) {
+			continue
+		}
+		if i := int(e.Timestamp.Sub(first) / time.Hour); i < hours {
+			tokens[i] += float64(e.Tokens())
+		}
+	}
+	return tokens
+}
+
+// projection estimates the month's spend: what has been spent so far plus
+// the average daily spend over the last costRateDays (or since the ledger
+// started, when that is sooner) for each day left in the month
+func (t CostsTab) projection(at time.Time) (projected, soFar, perDay float64) {
+	at = at.Local()
+	soFar = t.since(time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.Local)).USD
+	if len(t.Entries) == 0 {
+		return soFar, soFar, 0
+	}
+
+	days := costRateDays
+	if first := startOfDay(t.Entries[0].Timestamp, 0); first.After(startOfDay(at, 1-days)) {
+		days = int(startOfDay(at, 0).Sub(first).Hours()/24+0.5) + 1
+	}
+	perDay = t.since(startOfDay(at, 1-days)).USD / float64(days)
+
+	daysInMonth := time.Date(at.Year(), at.Month()+1, 0, 0, 0, 0, 0, time.Local).Day()
+	return soFar + perDay*float64(daysInMonth-at.Day()), soFar, perDay
+}
+
+// sparkline draws values as a row of bars scaled to the largest
+func sparkline(values []float64) string {
+	peak := 0.0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		tick := 0
+		if peak > 0 {
+			tick = int(v/peak*float64(len(sparkTicks)-1) + 0.5)
+		}
+		b.WriteRune(sparkTicks[tick])
+	}
+	return b.String()
+}
+
+// windowLabel names a breakdown window
+func windowLabel(days int) string {
+	if days == 1 {
+		return "today"
+	}
+	return fmt.Sprintf("last %d days", days)
+}
+
+// View renders the costs dashboard, starting with the chat session's usage
+func (t CostsTab) View(session Usage) string {
+	at := now()
+	var b strings.Builder
+	b.WriteString("Costs\n")
+	fmt.Fprintf(&b, "This chat: %s in / %s out, $%.4f\n", formatTokens(session.InputTokens), formatTokens(session.OutputTokens), session.CostUSD)
+	fmt.Fprintf(&b, "Today: $%.2f   Last 7 days: $%.2f   Last 30 days: $%.2f\n",
+		t.since(startOfDay(at, 0)).USD, t.since(startOfDay(at, -6)).USD, t.since(startOfDay(at, -29)).USD)
+	projected, soFar, perDay := t.projection(at)
+	fmt.Fprintf(&b, "Projected this month: $%.2f ($%.2f so far, $%.2f/day)\n", projected, soFar, perDay)
+
+	daily := t.daily(at, costDailyDays)
+	fmt.Fprintf(&b, "\nDaily spend, last %d days\n%s  $%.2f today\n", costDailyDays, sparkline(daily), daily[len(daily)-1])
+	burn := t.burn(at, costBurnHours)
+	peak := 0.0
+	for _, v := range burn {
+		peak = max(peak, v)
+	}
+	fmt.Fprintf(&b, "Token burn, last %d hours\n%s  %s/h this hour, %s/h peak\n",
+		costBurnHours, sparkline(burn), formatTokens(int(burn[len(burn)-1])), formatTokens(int(peak)))
+
+	days := costWindows[t.Window]
+	writeCostBreakdown(&b, "By agent, "+windowLabel(days), t.breakdown(at, days, func(e models.UsageEntry) string { return e.Agent }))
+	writeCostBreakdown(&b, "By turf, "+windowLabel(days), t.breakdown(at, days, func(e models.UsageEntry) string { return e.Turf }))
+
+	fmt.Fprintf(&b, "\n\n%s window (today, 7 or 30 days)", KeyCostWindow)
+	return b.String()
+}
+
+// writeCostBreakdown writes a heading and the most expensive rows under it
+func writeCostBreakdown(b *strings.Builder, heading string, rows []costTotal) {
+	fmt.Fprintf(b, "\n%s\n", heading)
+	if len(rows) == 0 {
+		b.WriteString("  Nothing spent")
+		return
+	}
+	fmt.Fprintf(b, "  %-20s %6s %8s %9s", "", "calls", "tokens", "cost")
+	for i, r := range rows {
+		if i == costRows {
+			fmt.Fprintf(b, "\n  … %d more", len(rows)-costRows)
+			break
+		}
+		fmt.Fprintf(b, "\n  %-20s %6d %8s %9s", clipTitle(r.Name, 20), r.Calls, formatTokens(r.Tokens), fmt.Sprintf("$%.2f", r.USD))
+	}
+	b.WriteString("\n")
+}
+
+// handleCostsKey switches the window the breakdowns cover
+func (m Model) handleCostsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == KeyCostWindow {
+		m.CostsTab.Window = (m.CostsTab.Window + 1) % len(costWindows)
+	}
+	return m, nil
+}
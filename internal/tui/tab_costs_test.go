@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestCostsTab(t *testing.T) {
+	at := time.Date(2026, 3, 10, 12, 30, 0, 0, time.Local)
+	now = func() time.Time { return at }
+	defer func() { now = time.Now }()
+
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.Local) }
+	usage := []models.UsageEntry{
+		{Timestamp: day(-1, 10), Agent: "vinnie", Turf: "web", CostUSD: 100}, // February
+		{Timestamp: day(1, 10), Agent: "vinnie", Turf: "web", CostUSD: 3},
+		{Timestamp: day(4, 10), Agent: "sal", Turf: "api", InputTokens: 4000, OutputTokens: 1000, CostUSD: 7},
+		{Timestamp: day(10, 9), Agent: "underboss", InputTokens: 2000, CostUSD: 1},
+		{Timestamp: day(10, 12), Agent: "vinnie", Turf: "web", OutputTokens: 6000, CostUSD: 6},
+	}
+
+	tab := CostsTab{Entries: usage}
+	// 17 spent this month, 14 over the last 7 days, so 2 a day for 21 more days
+	if projected, soFar, perDay := tab.projection(at); projected != 59 || soFar != 17 || perDay != 2 {
+		t.Errorf("expected $59 projected from $17 at $2/day, got %v %v %v", projected, soFar, perDay)
+	}
+	if daily := tab.daily(at, 7); daily[0] != 7 || daily[6] != 7 || daily[3] != 0 {
+		t.Errorf("expected 7 days of spend ending today, got %v", daily)
+	}
+	if burn := tab.burn(at, 4); burn[0] != 2000 || burn[3] != 6000 {
+		t.Errorf("expected tokens by hour ending this hour, got %v", burn)
+	}
+	if got := sparkline([]float64{0, 1, 2, 4}); got != "▁▃▅█" {
+		t.Errorf("expected bars scaled to the peak, got %q", got)
+	}
+	if got := sparkline([]float64{0, 0}); got != "▁▁" {
+		t.Errorf("expected flat bars with nothing spent, got %q", got)
+	}
+
+	m := NewModel()
+	m.ActiveTab = TabCosts
+	m.Sidebar.CommitLive(Usage{InputTokens: 1500, OutputTokens: 300, CostUSD: 0.25})
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Usage: usage})
+
+	view := model.View()
+	for _, want := range []string{
+		"This chat: 1.5k in / 300 out, $0.2500",
+		"Today: $7.00   Last 7 days: $14.00   Last 30 days: $117.00",
+		"Projected this month: $59.00 ($17.00 so far, $2.00/day)",
+		"By agent, last 7 days",
+		"By turf, last 7 days",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q on the costs tab:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "sal") > strings.Index(view, "vinnie") {
+		t.Errorf("expected the most expensive agent first:\n%s", view)
+	}
+	if m := model.(Model); m.Sidebar.Today != 7 {
+		t.Errorf("expected the sidebar to show today's mob spend, got %v", m.Sidebar.Today)
+	}
+
+	model, _ = model.Update(runeKey(KeyCostWindow))
+	view = model.View()
+	if !strings.Contains(view, "By agent, last 30 days") || !strings.Contains(view, "(none)") {
+		t.Errorf("expected the breakdowns over 30 days, the underboss's calls under no turf:\n%s", view)
+	}
+	model, _ = model.Update(runeKey(KeyCostWindow))
+	if view = model.View(); !strings.Contains(view, "By agent, today") || strings.Contains(view, "sal") {
+		t.Errorf("expected only today's agents:\n%s", view)
+	}
+}
@@ -1,11 +1,33 @@
 package tui
 
-type DaemonTab struct{}
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+type DaemonTab struct {
+	Activity []*models.Activity // recent activity feed entries, oldest first
+}
 
 func NewDaemonTab() DaemonTab {
 	return DaemonTab{}
 }
 
-func (DaemonTab) View() string {
-	return "Daemon"
+func (t DaemonTab) View() string {
+	if len(t.Activity) == 0 {
+		return "Daemon"
+	}
+
+	return "Daemon\n" + strings.Join(t.lines(), "\n")
+}
+
+// lines renders the activity feed, one entry a line
+func (t DaemonTab) lines() []string {
+	lines := make([]string, 0, len(t.Activity))
+	for _, a := range t.Activity {
+		lines = append(lines, fmt.Sprintf("%s  %-14s %s", a.Timestamp.Format("15:04:05"), a.Type, a.Message))
+	}
+	return lines
 }
@@ -0,0 +1,20 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/models"
+)
+
+func TestDaemonTabShowsActivity(t *testing.T) {
+	tab := DaemonTab{Activity: []*models.Activity{
+		{Timestamp: time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC), Type: models.ActivityMergeLanded, Message: "bd-a1b2 merged"},
+	}}
+
+	view := tab.View()
+	if !strings.Contains(view, "09:30:00") || !strings.Contains(view, "merge_landed") || !strings.Contains(view, "bd-a1b2 merged") {
+		t.Errorf("view missing activity entry:\n%s", view)
+	}
+}
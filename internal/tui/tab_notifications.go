@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// Keys on the notifications tab
+const (
+	KeyOpenNotification = "enter" // jump to the bead or agent it is about
+	KeyToggleRead       = "m"     // mark the selected notification read or unread
+	KeyAllRead          = "M"     // mark every notification read
+)
+
+// notificationMessageCols is how much of a notification's message its row shows
+const notificationMessageCols = 72
+
+// ringBell rings the terminal bell, which most terminals turn into a desktop
+// notification or an urgency hint when they are not focused; replaced in tests
+var ringBell = func() {
+	os.Stderr.WriteString("\a")
+}
+
+// NotificationsTab lists the notifications the daemon and agents sent,
+// newest first, with whether the user has read them
+type NotificationsTab struct {
+	Entries  []notify.FeedEntry // newest first
+	Selected int                // index into Entries
+	read     map[string]bool    // read state changed here, ahead of the feed
+	seen     map[string]bool    // IDs already listed, to spot new arrivals
+}
+
+func NewNotificationsTab() NotificationsTab {
+	return NotificationsTab{read: make(map[string]bool)}
+}
+
+// NotificationsReadMsg reports a failure to save read state
+type NotificationsReadMsg struct {
+	Err error
+}
+
+// SelectedEntry returns the selected notification, or nil when there are none
+func (t NotificationsTab) SelectedEntry() *notify.FeedEntry {
+	if t.Selected < 0 || t.Selected >= len(t.Entries) {
+		return nil
+	}
+	return &t.Entries[t.Selected]
+}
+
+// Unread counts the notifications not yet read
+func (t NotificationsTab) Unread() int {
+	n := 0
+	for _, e := range t.Entries {
+		if !e.Read {
+			n++
+		}
+	}
+	return n
+}
+
+// setEntries replaces the list with the feed, oldest first as it is read,
+// keeping the same notification selected. It returns how many arrived since
+// the last call; the first call counts none.
+func (t *NotificationsTab) setEntries(feed []notify.FeedEntry) int {
+	var selected string
+	if e := t.SelectedEntry(); e != nil {
+		selected = e.ID
+	}
+
+	entries := make([]notify.FeedEntry, len(feed))
+	seen := make(map[string]bool, len(feed))
+	arrived := 0
+	for i, e := range feed {
+		if read, ok := t.read[e.ID]; ok {
+			e.Read = read
+		}
+		entries[len(feed)-1-i] = e
+		seen[e.ID] = true
+		if t.seen != nil && !t.seen[e.ID] && !e.Read {
+			arrived++
+		}
+	}
+	t.Entries, t.seen = entries, seen
+
+	t.Selected = min(t.Selected, max(len(entries)-1, 0))
+	for i, e := range entries {
+		if e.ID == selected {
+			t.Selected = i
+		}
+	}
+	return arrived
+}
+
+// setRead marks notifications read or unread in the list
+func (t *NotificationsTab) setRead(read bool, ids ...string) {
+	if t.read == nil {
+		t.read = make(map[string]bool)
+	}
+	for _, id := range ids {
+		t.read[id] = read
+		for i := range t.Entries {
+			if t.Entries[i].ID == id {
+				t.Entries[i].Read = read
+			}
+		}
+	}
+}
+
+func (t NotificationsTab) View() string {
+	if len(t.Entries) == 0 {
+		return "Notifications\nNothing yet"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Notifications (%d unread)", t.Unread())
+	for i, e := range t.Entries {
+		mark := " "
+		if !e.Read {
+			mark = "●"
+		}
+		row := fmt.Sprintf("%s %s  %-18s %s", mark, e.Timestamp.Local().Format("Jan 2 15:04"), e.Title, clipTitle(e.Message, notificationMessageCols))
+		if i == t.Selected {
+			row = rowFocusStyle.Render(row)
+		}
+		b.WriteString("\n" + row)
+	}
+	fmt.Fprintf(&b, "\n\n%s open  %s read/unread  %s all read", KeyOpenNotification, KeyToggleRead, KeyAllRead)
+	return b.String()
+}
+
+// handleNotificationsKey moves through the notifications, marks them read
+// and jumps to what they are about
+func (m Model) handleNotificationsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t := &m.NotificationsTab
+	switch msg.String() {
+	case KeyUp:
+		if t.Selected > 0 {
+			t.Selected--
+		}
+	case KeyDown:
+		if t.Selected < len(t.Entries)-1 {
+			t.Selected++
+		}
+	case KeyToggleRead:
+		if e := t.SelectedEntry(); e != nil {
+			return m.markRead(!e.Read, e.ID)
+		}
+	case KeyAllRead:
+		var ids []string
+		for _, e := range t.Entries {
+			if !e.Read {
+				ids = append(ids, e.ID)
+			}
+		}
+		return m.markRead(true, ids...)
+	case KeyOpenNotification:
+		if e := t.SelectedEntry(); e != nil {
+			return m.openNotification(*e)
+		}
+	}
+	return m, nil
+}
+
+// markRead changes notifications' read state, saving it in the background.
+// While observing it is kept in the dashboard only.
+func (m Model) markRead(read bool, ids ...string) (tea.Model, tea.Cmd) {
+	if len(ids) == 0 {
+		return m, nil
+	}
+	m.NotificationsTab.setRead(read, ids...)
+	save := m.saveRead
+	if save == nil || m.Observe {
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		return NotificationsReadMsg{Err: save(read, ids...)}
+	}
+}
+
+// openNotification marks a notification read and shows the bead or agent it
+// is about on its tab
+func (m Model) openNotification(e notify.FeedEntry) (tea.Model, tea.Cmd) {
+	model, cmd := m.markRead(true, e.ID)
+	m = model.(Model)
+
+	switch {
+	case e.BeadID != "":
+		revealTurf(&m, m.polled.Beads, func(b *models.Bead) bool { return b.ID == e.BeadID }, beadTurf)
+		for i, b := range m.BeadsTab.Beads {
+			if b.ID == e.BeadID {
+				m.BeadsTab.Selected = i
+				m.ActiveTab = TabBeads
+				return m, tea.Batch(cmd, m.loadBeadDiff())
+			}
+		}
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("%s is no longer open", e.BeadID)})
+	case e.AgentID != "" || e.Agent != "":
+		about := func(a *registry.AgentRecord) bool {
+			return e.AgentID != "" && a.ID == e.AgentID || e.Agent != "" && a.Name == e.Agent
+		}
+		revealTurf(&m, m.polled.Agents, about, agentTurf)
+		for i, a := range m.AgentsTab.Agents {
+			if about(a) {
+				m.AgentsTab.Selected = i
+				m.ActiveTab = TabAgents
+				return m, cmd
+			}
+		}
+		m.Toasts.Push(Toast{Message: "That agent is no longer running"})
+	}
+	return m, cmd
+}
+
+// handleNotificationsRead reports read state that could not be saved
+func (m Model) handleNotificationsRead(msg NotificationsReadMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("Could not save read state: %v", msg.Err)})
+	}
+	return m, nil
+}
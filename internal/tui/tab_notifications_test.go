@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/notify"
+)
+
+func testNotifications() []notify.FeedEntry {
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	return []notify.FeedEntry{
+		{ID: "n1", Type: notify.NotificationTypeApprovalNeeded, Title: "Approval Required", Message: "Bead bd-aaaa needs approval", Timestamp: at, BeadID: "bd-aaaa"},
+		{ID: "n2", Type: notify.NotificationTypeError, Title: "Agent Stuck", Message: "Agent vinnie appears stuck", Timestamp: at.Add(time.Minute), AgentID: "agent-1", Agent: "vinnie"},
+	}
+}
+
+func TestNotificationsTabBellsForNewArrivals(t *testing.T) {
+	rang := 0
+	bell := ringBell
+	ringBell = func() { rang++ }
+	t.Cleanup(func() { ringBell = bell })
+
+	m := NewModel()
+	m.bell = true
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Notifications: testNotifications()[:1]})
+	if rang != 0 {
+		t.Fatal("expected no bell for notifications sent before the dashboard opened")
+	}
+	model, _ = model.Update(RefreshMsg{Notifications: testNotifications()})
+	if rang != 1 {
+		t.Fatalf("expected the bell for a new notification, rang %d times", rang)
+	}
+
+	tab := model.(Model).NotificationsTab
+	if tab.Entries[0].ID != "n2" || tab.Unread() != 2 {
+		t.Fatalf("expected the newest first and both unread, got %+v", tab.Entries)
+	}
+	if view := model.View(); !strings.Contains(view, "[Notifications (2)]") {
+		t.Errorf("expected the unread count in the tab bar:\n%s", view)
+	}
+}
+
+func TestNotificationsTabReadAndJump(t *testing.T) {
+	var saved []string
+	m := NewModel()
+	m.saveRead = func(read bool, ids ...string) error {
+		saved = append(saved, ids...)
+		return nil
+	}
+	m.ActiveTab = TabNotifications
+
+	var model tea.Model = m
+	model, _ = model.Update(RefreshMsg{Agents: testAgents(), Beads: testBeads(), Notifications: testNotifications()})
+
+	// The newest, about an agent, is selected first
+	model, cmd := press(model, "enter")
+	if model.(Model).ActiveTab != TabAgents || model.(Model).AgentsTab.SelectedAgent().Name != "vinnie" {
+		t.Fatalf("expected enter to jump to the agent, got tab %d", model.(Model).ActiveTab)
+	}
+	if cmd == nil {
+		t.Fatal("expected opening a notification to save it as read")
+	}
+	cmd()
+	if len(saved) != 1 || saved[0] != "n2" {
+		t.Fatalf("expected n2 saved as read, got %v", saved)
+	}
+
+	m = model.(Model)
+	m.ActiveTab = TabNotifications
+	model, _ = typeKeys(m, tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = press(model, "enter")
+	if model.(Model).ActiveTab != TabBeads || model.(Model).BeadsTab.SelectedBead().ID != "bd-aaaa" {
+		t.Fatalf("expected enter to jump to the bead, got tab %d", model.(Model).ActiveTab)
+	}
+
+	// Read state survives the next poll, which still has it unread
+	model, _ = model.Update(RefreshMsg{Notifications: testNotifications()})
+	if n := model.(Model).NotificationsTab.Unread(); n != 0 {
+		t.Fatalf("expected both read after opening them, got %d unread", n)
+	}
+	m = model.(Model)
+	m.ActiveTab = TabNotifications
+	model, _ = typeKeys(m, runeKey(KeyToggleRead))
+	if n := model.(Model).NotificationsTab.Unread(); n != 1 {
+		t.Fatalf("expected m to mark one unread again, got %d unread", n)
+	}
+	model, _ = typeKeys(model, runeKey(KeyAllRead))
+	if n := model.(Model).NotificationsTab.Unread(); n != 0 {
+		t.Fatalf("expected M to mark all read, got %d unread", n)
+	}
+}
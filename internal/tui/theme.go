@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// DefaultTheme is the theme used when [tui] theme is unset
+const DefaultTheme = "dark"
+
+// builtinThemes are the themes available without a theme file
+var builtinThemes = map[string]func() Styles{
+	"dark":  NewStyles,
+	"light": lightStyles,
+}
+
+// colorProfile reports the colors the terminal supports; replaced in tests
+var colorProfile = lipgloss.ColorProfile
+
+// ThemeDir returns where theme files live for a mob directory
+func ThemeDir(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "themes")
+}
+
+// LoadTheme reads the theme <name>.toml from dir. Colors the file leaves out
+// come from the built-in theme of that name, or from the dark theme, so a
+// file only needs the colors it changes. Without a file, the built-in themes
+// are used as they are.
+func LoadTheme(dir, name string) (Styles, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return Styles{}, fmt.Errorf("invalid theme name %q", name)
+	}
+	base, builtin := builtinThemes[name]
+	if !builtin {
+		base = NewStyles
+	}
+	styles := base()
+
+	path := filepath.Join(dir, name+".toml")
+	if _, err := os.Stat(path); dir == "" || os.IsNotExist(err) {
+		if !builtin {
+			return Styles{}, fmt.Errorf("unknown theme %q (have %s)", name, strings.Join(ThemeNames(dir), ", "))
+		}
+		return styles, nil
+	}
+	if _, err := toml.DecodeFile(path, &styles); err != nil {
+		return Styles{}, fmt.Errorf("failed to load theme %s: %w", name, err)
+	}
+	if len(styles.Labels) == 0 || len(styles.ANSI.Labels) == 0 {
+		return Styles{}, fmt.Errorf("theme %s has no label colors", name)
+	}
+	return styles, nil
+}
+
+// ThemeNames lists the built-in themes and those in dir, sorted
+func ThemeNames(dir string) []string {
+	seen := make(map[string]bool)
+	for name := range builtinThemes {
+		seen[name] = true
+	}
+	if dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.toml"))
+		for _, file := range files {
+			seen[strings.TrimSuffix(filepath.Base(file), ".toml")] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// palette returns the colors to draw with on this terminal: the theme's ANSI
+// colors when it only has 16
+func (s Styles) palette() Palette {
+	if colorProfile() == termenv.ANSI {
+		return s.ANSI
+	}
+	return s.Palette
+}
+
+// applyTheme restyles the TUI with a theme's colors
+func applyTheme(s Styles) {
+	p := s.palette()
+	beadRefStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.Primary)).Underline(true)
+	beadRefFocusStyle = beadRefStyle.Reverse(true)
+	epicPanelStyle = epicPanelStyle.BorderForeground(lipgloss.Color(p.Primary))
+	epicDoneStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.Success))
+	epicLeftStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.Faint))
+	epicLateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.Error))
+	hintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.Muted))
+	searchMatchStyle = lipgloss.NewStyle().Background(lipgloss.Color(p.Primary)).Foreground(lipgloss.Color(p.ChipText))
+	labelChipStyle = labelChipStyle.Foreground(lipgloss.Color(p.ChipText))
+	labelColors = p.Labels
+}
+
+// setTheme switches to a theme, reporting why it could not
+func (m *Model) setTheme(name string) error {
+	styles, err := LoadTheme(m.themeDir, name)
+	if err != nil {
+		return err
+	}
+	applyTheme(styles)
+	m.Theme = name
+	return nil
+}
+
+// switchTheme lists the themes, or with a name switches to it
+func (m Model) switchTheme(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		names := ThemeNames(m.themeDir)
+		for i, name := range names {
+			if name == m.Theme {
+				names[i] = name + " (current)"
+			}
+		}
+		m.Chat = append(m.Chat, "Themes: "+strings.Join(names, ", "))
+		return m, nil
+	}
+	if err := m.setTheme(args[0]); err != nil {
+		m.Toasts.Push(Toast{Message: err.Error()})
+		return m, nil
+	}
+	m.Toasts.Push(Toast{Message: "Theme: " + args[0]})
+	return m, nil
+}
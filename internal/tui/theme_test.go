@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+func TestLoadThemeBuiltins(t *testing.T) {
+	for name, want := range map[string]Styles{"dark": NewStyles(), "light": lightStyles()} {
+		got, err := LoadTheme("", name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected the built-in theme, got %+v", name, got)
+		}
+	}
+	if _, err := LoadTheme("", "solarized"); err == nil {
+		t.Error("expected an unknown theme refused")
+	}
+	if _, err := LoadTheme(t.TempDir(), "../dark"); err == nil {
+		t.Error("expected a theme name with a path refused")
+	}
+}
+
+func TestLoadThemeFileOverridesColors(t *testing.T) {
+	dir := t.TempDir()
+	file := "primary = \"#ff00ff\"\n\n[ansi]\nprimary = \"5\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "neon.toml"), []byte(file), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	styles, err := LoadTheme(dir, "neon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if styles.Primary != "#ff00ff" || styles.ANSI.Primary != "5" {
+		t.Errorf("expected the file's colors, got %q and %q", styles.Primary, styles.ANSI.Primary)
+	}
+	if styles.Success != NewStyles().Success {
+		t.Errorf("expected colors the file leaves out to come from the dark theme, got %q", styles.Success)
+	}
+	if names := ThemeNames(dir); !reflect.DeepEqual(names, []string{"dark", "light", "neon"}) {
+		t.Errorf("unexpected theme names %v", names)
+	}
+}
+
+func TestThemeUsesANSIColorsWithout256(t *testing.T) {
+	profile := colorProfile
+	t.Cleanup(func() {
+		colorProfile = profile
+		applyTheme(NewStyles())
+	})
+
+	colorProfile = func() termenv.Profile { return termenv.TrueColor }
+	applyTheme(lightStyles())
+	if got := labelColors[0]; got != lightStyles().Labels[0] {
+		t.Errorf("expected hex colors on a true-color terminal, got %q", got)
+	}
+
+	colorProfile = func() termenv.Profile { return termenv.ANSI }
+	applyTheme(lightStyles())
+	if got := labelColors[0]; got != lightStyles().ANSI.Labels[0] {
+		t.Errorf("expected ANSI colors on a 16-color terminal, got %q", got)
+	}
+}
+
+func TestThemeCommand(t *testing.T) {
+	t.Cleanup(func() { applyTheme(NewStyles()) })
+
+	var model tea.Model = NewModel()
+	model, _ = model.Update(CommandMsg{Line: "/theme"})
+	if chat := model.(Model).Chat; len(chat) == 0 || !strings.Contains(chat[0], "dark (current)") {
+		t.Fatalf("expected /theme to list the themes, got %v", chat)
+	}
+
+	model, _ = model.Update(CommandMsg{Line: "/theme light"})
+	if model.(Model).Theme != "light" || labelColors[0] != lightStyles().palette().Labels[0] {
+		t.Fatalf("expected the light theme applied, got %q", model.(Model).Theme)
+	}
+
+	model, _ = model.Update(CommandMsg{Line: "/theme nope"})
+	if model.(Model).Theme != "light" {
+		t.Fatal("expected an unknown theme to leave the current one")
+	}
+}
@@ -2,8 +2,15 @@ package tui
 
 import (
 	"errors"
+	"fmt"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gabe/mob/internal/approval"
+	"github.com/gabe/mob/internal/chatsession"
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/redact"
 )
 
 const (
@@ -11,50 +18,296 @@ const (
 	TabDaemon
 	TabAgentOutput
 	TabAgents
+	TabApprovals
+	TabBeads
+	TabNotifications
+	TabCosts
+)
+
+// Keys for moving between tabs
+const (
+	KeyNextTab = "tab"
+	KeyPrevTab = "shift+tab"
+)
+
+// Keys for moving through a tab's list
+const (
+	KeyUp   = "up"   // select the previous entry
+	KeyDown = "down" // select the next entry
 )
 
 type Model struct {
-	ActiveTab      int
-	InputRows      int
-	Sidebar        Sidebar
-	Toasts         *ToastQueue
-	DaemonTab      DaemonTab
-	AgentOutputTab AgentOutputTab
-	AgentsTab      AgentsTab
+	ActiveTab        int
+	InputRows        int
+	Sidebar          Sidebar
+	Toasts           *ToastQueue
+	DaemonTab        DaemonTab
+	AgentOutputTab   AgentOutputTab
+	AgentsTab        AgentsTab
+	ApprovalsTab     ApprovalsTab
+	BeadsTab         BeadsTab
+	NotificationsTab NotificationsTab
+	CostsTab         CostsTab
+
+	TokenWarnThreshold int      // output tokens in one response before warning; 0 = never
+	Warnings           []string // inline warnings shown under the chat
+	responseWarned     bool
+
+	Chat        []string             // chat output, oldest first
+	Input       string               // chat input being typed
+	completion  int                  // index into the command popup's matches
+	selection   selection            // lines being picked out to copy
+	search      search               // query over the active tab's lines
+	switcher    modelSwitcher        // the sidebar's model switcher
+	BeadDetail  *models.Bead         // bead opened from a chat reference; nil = chat shown
+	EpicDetail  *models.EpicProgress // progress of BeadDetail when it is an epic
+	beadRefs    []string             // bead IDs mentioned in chat, most recent last
+	selectedRef int                  // index into beadRefs; -1 = none selected
+
+	SessionID      string // Claude session backing the chat, used by /export
+	UnderbossModel string // model named in the underboss's last response
+	lastReply      string // the underboss's last reply, for /copy-last
+	lastToolOutput string // output of the last tool it ran, for /copy-last tool
+	ExportDir      string // where /export writes transcripts; empty = current directory
+	Theme          string // name of the theme in use
+	themeDir       string // where /theme finds theme files; empty = built-ins only
+
+	Session        *chatsession.Session   // saved chat being continued; nil = none yet
+	Picker         []*chatsession.Session // sessions offered on startup; nil = picker closed
+	pickerSelected int                    // index into Picker
+	sessions       *chatsession.Store     // where chats are saved; nil = not saved
+	resume         func(sessionID string) // points the underboss at a resumed session; nil = none
+
+	Observe         bool              // read-only observer mode (mob tui --observe)
+	refresh         func() RefreshMsg // polls status for the tabs; nil = no polling
+	polled          RefreshMsg        // the last poll, before scoping to a turf
+	turf            string            // turf the beads and agents tabs show; "" = every turf
+	redactor        *redact.Redactor  // masks secrets in /export output; nil = none
+	loadBead        func(id string) (*models.Bead, error)
+	loadEpic        func(id string) (*models.EpicProgress, error)
+	beadActions     BeadActions                          // acts on beads from the beads tab; nil = unavailable
+	agentActions    AgentActions                         // acts on agents from the agents tab; nil = unavailable
+	commandActions  CommandActions                       // runs /spawn and /turf; nil = unavailable
+	approvalActions ApprovalActions                      // decides beads from the approvals tab; nil = unavailable
+	saveRead        func(read bool, ids ...string) error // saves notifications' read state; nil = kept here only
+	bell            bool                                 // ring the terminal bell for new notifications
+	modelActions    ModelActions                         // switches models from the sidebar and /model; nil = unavailable
+
+	ask       AskFunc  // sends chat messages; nil = chat not connected
+	stream    *stream  // in-flight response; nil = none
+	streamSeq int      // ID of the most recent stream
+	queued    []string // messages sent while a response was in flight, oldest first
+
+	Approvals []*approval.Request // underboss tool calls waiting for y/n, oldest first
+	approver  Approver            // answers them; nil = calls are not held
 }
 
 func NewModel() Model {
 	return Model{
-		ActiveTab:      TabChat,
-		InputRows:      clampHeight(3),
-		Sidebar:        NewSidebar(),
-		Toasts:         NewToastQueue(),
-		DaemonTab:      NewDaemonTab(),
-		AgentOutputTab: NewAgentOutputTab(),
-		AgentsTab:      NewAgentsTab(),
+		ActiveTab:        TabChat,
+		InputRows:        clampHeight(3),
+		Sidebar:          NewSidebar(),
+		Toasts:           NewToastQueue(),
+		DaemonTab:        NewDaemonTab(),
+		AgentOutputTab:   NewAgentOutputTab(),
+		AgentsTab:        NewAgentsTab(),
+		ApprovalsTab:     NewApprovalsTab(),
+		BeadsTab:         NewBeadsTab(),
+		NotificationsTab: NewNotificationsTab(),
+		CostsTab:         NewCostsTab(),
+		selectedRef:      -1,
+		Theme:            DefaultTheme,
+
+		TokenWarnThreshold: DefaultTokenWarnThreshold,
 	}
 }
 
 var ErrNotImplemented = errors.New("tui not implemented")
 
-var startProgram = func(model tea.Model) error {
+var startProgram = func(model tea.Model, onStart ...func(send func(tea.Msg))) error {
 	program := tea.NewProgram(model)
+	for _, fn := range onStart {
+		fn(program.Send)
+	}
 	_, err := program.Run()
 	return err
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	return m.refreshAfter(0)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case UsageMsg:
+		return m.handleUsage(msg)
+	case CommandMsg:
+		if m.Observe {
+			return m.refuseInObserve(msg)
+		}
+		return m.runCommand(msg)
+	case CommandDoneMsg:
+		return m.handleCommandDone(msg)
+	case RefreshMsg:
+		return m.handleRefresh(msg)
+	case ChatMsg:
+		return m.handleChat(msg)
+	case SendMsg:
+		return m.handleSend(msg)
+	case StreamBlockMsg:
+		return m.handleStreamBlock(msg)
+	case StreamDoneMsg:
+		return m.handleStreamDone(msg)
+	case CancelStreamMsg:
+		return m.handleCancelStream()
+	case ApprovalsMsg:
+		return m.handleApprovals(msg)
+	case BeadDetailMsg:
+		return m.handleBeadDetail(msg)
+	case BeadDiffMsg:
+		return m.handleBeadDiff(msg)
+	case BeadActionMsg:
+		return m.handleBeadAction(msg)
+	case ApprovalPreviewMsg:
+		return m.handleApprovalPreview(msg)
+	case AgentActionMsg:
+		return m.handleAgentAction(msg)
+	case AgentTranscriptMsg:
+		return m.handleAgentTranscript(msg)
+	case AgentOutputMsg:
+		return m.handleAgentOutput(msg)
+	case NotificationsReadMsg:
+		return m.handleNotificationsRead(msg)
+	case ModelSwitchedMsg:
+		return m.handleModelSwitched(msg)
+	case TurfScopedMsg:
+		return m.handleTurfScoped(msg)
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+	}
 	return m, nil
 }
 
 func (m Model) View() string {
-	return "[Chat] [Daemon] [Agent Output] [Agents]"
+	approvals := "[Approvals]"
+	if n := len(m.ApprovalsTab.Beads); n > 0 {
+		approvals = fmt.Sprintf("[Approvals (%d)]", n)
+	}
+	notifications := "[Notifications]"
+	if n := m.NotificationsTab.Unread(); n > 0 {
+		notifications = fmt.Sprintf("[Notifications (%d)]", n)
+	}
+	view := "[Chat] [Daemon] [Agent Output] [Agents] " + approvals + " [Beads] " + notifications + " [Costs]" + m.turfLabel()
+	if m.Observe {
+		view = "[Daemon] [Agent Output] [Agents] " + approvals + " [Beads] " + notifications + " [Costs]" + m.turfLabel() + "  (observing, read-only)"
+	}
+	if tab := m.tabView(); tab != "" {
+		view += "\n" + tab
+	}
+	if approvals := m.ApprovalView(); approvals != "" {
+		view += "\n" + approvals
+	}
+	for _, warning := range m.Warnings {
+		view += "\n" + warning
+	}
+	return view
+}
+
+// switchTab moves step tabs along, wrapping around and skipping chat while
+// observing
+func (m Model) switchTab(step int) (tea.Model, tea.Cmd) {
+	first := TabChat
+	if m.Observe {
+		first = TabDaemon
+	}
+	n := TabCosts - first + 1
+	m.ActiveTab = first + ((m.ActiveTab-first+step)%n+n)%n
+	m.search = search{}
+	switch m.ActiveTab {
+	case TabAgentOutput:
+		return m, m.loadAgentOutput()
+	case TabApprovals:
+		return m, m.loadApprovalPreview()
+	case TabBeads:
+		return m, m.loadBeadDiff()
+	}
+	return m, nil
+}
+
+// tabView renders the active tab
+func (m Model) tabView() string {
+	if m.selection.Active {
+		return m.selectionView()
+	}
+	if m.search.Active {
+		return m.searchView()
+	}
+	switch m.ActiveTab {
+	case TabChat:
+		if len(m.Picker) > 0 {
+			return m.PickerView()
+		}
+		if !m.Observe {
+			return lipgloss.JoinHorizontal(lipgloss.Top, m.ChatView(), "   ", m.sidebarView())
+		}
+	case TabDaemon:
+		return m.DaemonTab.View()
+	case TabAgentOutput:
+		return m.AgentOutputTab.View()
+	case TabAgents:
+		return m.AgentsTab.View()
+	case TabApprovals:
+		return m.ApprovalsTab.View()
+	case TabBeads:
+		return m.BeadsTab.View()
+	case TabNotifications:
+		return m.NotificationsTab.View()
+	case TabCosts:
+		return m.CostsTab.View(m.Sidebar.Total())
+	}
+	return ""
 }
 
 func Run() error {
 	return startProgram(NewModel())
 }
+
+// RunWithConfig starts the TUI using dashboard preferences from config
+func RunWithConfig(cfg config.TUIConfig, opts Options) error {
+	model := NewModel()
+	if opts.Observe {
+		model = NewObserverModel()
+	}
+	model.TokenWarnThreshold = cfg.TokenWarnThreshold
+	model.refresh = opts.Refresh
+	model.redactor = opts.Redactor
+	model.loadBead = opts.LoadBead
+	model.loadEpic = opts.LoadEpic
+	model.ask = opts.Ask
+	model.approver = opts.Approver
+	model.beadActions = opts.Beads
+	model.agentActions = opts.Agents
+	model.commandActions = opts.Commands
+	model.approvalActions = opts.Approvals
+	model.sessions = opts.Sessions
+	model.resume = opts.Resume
+	model.modelActions = opts.Models
+	model.Sidebar.Model, model.Sidebar.AgentModel = opts.UnderbossModel, opts.AgentModel
+	model.themeDir = opts.ThemeDir
+	model.saveRead = opts.MarkRead
+	model.bell = cfg.Bell
+	if cfg.Theme != "" {
+		if err := model.setTheme(cfg.Theme); err != nil {
+			model.Toasts.Push(Toast{Message: err.Error()})
+		}
+	}
+	model.openPicker()
+
+	var onStart []func(send func(tea.Msg))
+	if opts.WatchUsage != nil && !opts.Observe {
+		onStart = append(onStart, func(send func(tea.Msg)) { opts.WatchUsage(ForwardUsage(send)) })
+	}
+	return startProgram(model, onStart...)
+}
@@ -1,6 +1,12 @@
 package tui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/registry"
+)
 
 func TestModelInitialTab(t *testing.T) {
 	m := NewModel()
@@ -8,3 +14,45 @@ func TestModelInitialTab(t *testing.T) {
 		t.Fatalf("expected chat tab")
 	}
 }
+
+func TestObserverModelIsReadOnly(t *testing.T) {
+	m := NewObserverModel()
+	if m.ActiveTab != TabDaemon {
+		t.Fatalf("observer should open on the daemon tab, got %d", m.ActiveTab)
+	}
+	if view := m.View(); strings.Contains(view, "[Chat]") || !strings.Contains(view, "read-only") {
+		t.Fatalf("observer view should hide chat and say it is read-only:\n%s", view)
+	}
+
+	var model tea.Model = m
+	model, _ = model.Update(CommandMsg{Line: "/export out.md"})
+	toast, ok := model.(Model).Toasts.Peek()
+	if !ok || !strings.Contains(toast.Message, "read-only") {
+		t.Fatalf("expected refusal toast, got %+v", toast)
+	}
+}
+
+func TestRefreshUpdatesTabs(t *testing.T) {
+	polls := 0
+	m := NewObserverModel()
+	m.refresh = func() RefreshMsg {
+		polls++
+		return RefreshMsg{Agents: []*registry.AgentRecord{{ID: "a1", Name: "vinnie", Status: "active"}}}
+	}
+
+	if m.Init() == nil {
+		t.Fatal("expected Init to schedule a refresh")
+	}
+
+	var model tea.Model = m
+	model, cmd := model.Update(m.refresh())
+	if cmd == nil {
+		t.Fatal("expected the next refresh to be scheduled")
+	}
+	if !strings.Contains(model.(Model).AgentsTab.View(), "vinnie") {
+		t.Fatalf("agents tab not refreshed:\n%s", model.(Model).AgentsTab.View())
+	}
+	if polls != 1 {
+		t.Fatalf("expected one poll, got %d", polls)
+	}
+}
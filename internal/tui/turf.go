@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/registry"
+)
+
+// allTurfs is the /turf argument that shows every turf again
+const allTurfs = "all"
+
+// TurfScopedMsg carries a turf picked with /turf once it is known to exist
+type TurfScopedMsg struct {
+	Turf string
+	Err  error
+}
+
+// onTurf keeps the items on turf; every item when turf is ""
+func onTurf[T any](items []T, turf string, turfOf func(T) string) []T {
+	if turf == "" {
+		return items
+	}
+	var kept []T
+	for _, item := range items {
+		if turfOf(item) == turf {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func beadTurf(b *models.Bead) string           { return b.Turf }
+func agentTurf(a *registry.AgentRecord) string { return a.Turf }
+
+// scopeTabs shows the last status poll's beads and agents on their tabs,
+// only those on the picked turf when there is one
+func (m *Model) scopeTabs() {
+	poll := m.polled
+	m.BeadsTab.Turf, m.AgentsTab.Turf = m.turf, m.turf
+	m.BeadsTab.setBeads(onTurf(poll.Beads, m.turf, beadTurf), onTurf(poll.Closed, m.turf, beadTurf))
+	m.AgentsTab.setAgents(onTurf(poll.Agents, m.turf, agentTurf))
+}
+
+// revealTurf scopes the tabs to the turf of the first polled item matching,
+// when the turf shown hides it
+func revealTurf[T any](m *Model, items []T, match func(T) bool, turfOf func(T) string) {
+	if m.turf == "" {
+		return
+	}
+	for _, item := range items {
+		if match(item) {
+			if turfOf(item) != m.turf {
+				m.turf = turfOf(item)
+				m.scopeTabs()
+			}
+			return
+		}
+	}
+}
+
+// polledTurfs lists the turfs of the polled beads and agents, in the order
+// first seen
+func (m Model) polledTurfs() []string {
+	var turfs []string
+	seen := make(map[string]bool)
+	add := func(turf string) {
+		if turf != "" && !seen[turf] {
+			seen[turf] = true
+			turfs = append(turfs, turf)
+		}
+	}
+	for _, beads := range [][]*models.Bead{m.polled.Beads, m.polled.Closed} {
+		for _, b := range beads {
+			add(b.Turf)
+		}
+	}
+	for _, a := range m.polled.Agents {
+		add(a.Turf)
+	}
+	return turfs
+}
+
+// setTurf scopes the beads and agents tabs to a turf, or "" for every turf
+func (m Model) setTurf(turf string) (tea.Model, tea.Cmd) {
+	m.turf = turf
+	m.scopeTabs()
+	return m, m.loadBeadDiff()
+}
+
+// nextTurf scopes the tabs to the next polled turf, then back to every turf
+func (m Model) nextTurf() (tea.Model, tea.Cmd) {
+	turfs := m.polledTurfs()
+	next := ""
+	for i, turf := range turfs {
+		if turf == m.turf && i+1 < len(turfs) {
+			next = turfs[i+1]
+		}
+	}
+	if m.turf == "" && len(turfs) > 0 {
+		next = turfs[0]
+	}
+	return m.setTurf(next)
+}
+
+// turfCommand lists the turfs, marking the one shown, or with an argument
+// scopes the beads and agents tabs to a turf or back to all of them
+func (m Model) turfCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m.listTurfs()
+	}
+	turf := args[0]
+	if turf == allTurfs {
+		m.Toasts.Push(Toast{Message: "Showing every turf"})
+		return m.setTurf("")
+	}
+	for _, polled := range m.polledTurfs() {
+		if polled == turf {
+			return m.handleTurfScoped(TurfScopedMsg{Turf: turf})
+		}
+	}
+	if m.commandActions == nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("No turf named %s", turf)})
+		return m, nil
+	}
+	actions := m.commandActions
+	return m, func() tea.Msg {
+		turfs, err := actions.Turfs()
+		if err != nil {
+			return TurfScopedMsg{Turf: turf, Err: err}
+		}
+		for _, t := range turfs {
+			if t.Name == turf {
+				return TurfScopedMsg{Turf: turf}
+			}
+		}
+		return TurfScopedMsg{Turf: turf, Err: fmt.Errorf("no turf named %s", turf)}
+	}
+}
+
+// handleTurfScoped shows the turf picked with /turf
+func (m Model) handleTurfScoped(msg TurfScopedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.Toasts.Push(Toast{Message: fmt.Sprintf("/turf failed: %v", msg.Err)})
+		return m, nil
+	}
+	m.Toasts.Push(Toast{Message: fmt.Sprintf("Showing turf %s (/turf %s for every turf)", msg.Turf, allTurfs)})
+	return m.setTurf(msg.Turf)
+}
+
+func (m Model) listTurfs() (tea.Model, tea.Cmd) {
+	if m.commandActions == nil {
+		m.Toasts.Push(Toast{Message: "Turfs are unavailable"})
+		return m, nil
+	}
+	actions, shown := m.commandActions, m.turf
+	return m, runInBackground("/turf", func() (string, error) {
+		turfs, err := actions.Turfs()
+		if err != nil {
+			return "", err
+		}
+		if len(turfs) == 0 {
+			return "No turfs yet (mob turf add <path>)", nil
+		}
+		lines := []string{"Turfs:"}
+		for _, t := range turfs {
+			mark := " "
+			if t.Name == shown {
+				mark = "*"
+			}
+			lines = append(lines, fmt.Sprintf("%s %-16s %s", mark, t.Name, t.Path))
+		}
+		if shown == "" {
+			lines = append(lines, "Showing every turf; /turf <name> shows one")
+		} else {
+			lines = append(lines, fmt.Sprintf("Showing %s; /turf %s shows every turf", shown, allTurfs))
+		}
+		return strings.Join(lines, "\n"), nil
+	})
+}
+
+// turfLabel is the header's note of the turf shown, if one is picked
+func (m Model) turfLabel() string {
+	if m.turf == "" {
+		return ""
+	}
+	return "  turf: " + m.turf
+}
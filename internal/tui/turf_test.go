@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/registry"
+)
+
+type fakeCommandActions struct{}
+
+func (fakeCommandActions) Spawn(turf, name string) (string, error) {
+	return "", nil
+}
+
+func (fakeCommandActions) Turfs() ([]models.Turf, error) {
+	return []models.Turf{{Name: "api", Path: "/src/api"}, {Name: "web", Path: "/src/web"}, {Name: "docs", Path: "/src/docs"}}, nil
+}
+
+func TestTurfScopesBeadsAndAgents(t *testing.T) {
+	m := NewModel()
+	m.commandActions = fakeCommandActions{}
+	poll := RefreshMsg{
+		Beads: []*models.Bead{
+			{ID: "bd-aaaa", Title: "Fix login", Status: models.BeadStatusOpen, Turf: "web"},
+			{ID: "bd-bbbb", Title: "Ship release", Status: models.BeadStatusOpen, Turf: "api"},
+			{ID: "bd-cccc", Title: "Approve me", Status: models.BeadStatusPendingApproval, Turf: "api"},
+		},
+		Agents: []*registry.AgentRecord{
+			{ID: "s1", Name: "vinnie", Turf: "api"},
+			{ID: "s2", Name: "sal", Turf: "web"},
+		},
+	}
+
+	var model tea.Model = m
+	model, _ = model.Update(poll)
+	model, _ = model.Update(CommandMsg{Line: "/turf web"})
+	m = model.(Model)
+	if len(m.BeadsTab.Beads) != 1 || m.BeadsTab.Beads[0].ID != "bd-aaaa" {
+		t.Fatalf("expected only web's beads, got %v", m.BeadsTab.Beads)
+	}
+	if len(m.AgentsTab.Agents) != 1 || m.AgentsTab.Agents[0].Name != "sal" {
+		t.Fatalf("expected only web's agents, got %v", m.AgentsTab.Agents)
+	}
+	if len(m.ApprovalsTab.Beads) != 1 {
+		t.Errorf("expected approvals from every turf still listed, got %v", m.ApprovalsTab.Beads)
+	}
+	if view := m.View(); !strings.Contains(view, "turf: web") {
+		t.Errorf("expected the header to show the turf:\n%s", view)
+	}
+
+	// The scope holds across polls
+	model, _ = model.Update(poll)
+	m = model.(Model)
+	m.ActiveTab = TabBeads
+	if view := m.View(); !strings.Contains(view, "Beads on web (1)") {
+		t.Errorf("expected the beads tab scoped after a poll:\n%s", view)
+	}
+
+	// A registered turf with nothing polled yet is checked in the background
+	model, cmd := model.Update(CommandMsg{Line: "/turf docs"})
+	model, _ = model.Update(cmd())
+	if m = model.(Model); m.turf != "docs" || len(m.BeadsTab.Beads) != 0 {
+		t.Fatalf("expected docs shown with no beads, got %q %v", m.turf, m.BeadsTab.Beads)
+	}
+	model, cmd = model.Update(CommandMsg{Line: "/turf nowhere"})
+	model, _ = model.Update(cmd())
+	if m = model.(Model); m.turf != "docs" {
+		t.Fatalf("expected an unknown turf refused, got %q", m.turf)
+	}
+	refused := false
+	for toast, ok := m.Toasts.Pop(); ok; toast, ok = m.Toasts.Pop() {
+		refused = refused || strings.Contains(toast.Message, "no turf named nowhere")
+	}
+	if !refused {
+		t.Error("expected a toast saying the turf does not exist")
+	}
+
+	// Opening a notification about a hidden bead shows its turf
+	model, _ = model.Update(CommandMsg{Line: "/turf web"})
+	model, _ = model.(Model).openNotification(notify.FeedEntry{ID: "n1", BeadID: "bd-bbbb"})
+	if m = model.(Model); m.turf != "api" || m.ActiveTab != TabBeads {
+		t.Fatalf("expected the api turf shown on the beads tab, got %q tab %d", m.turf, m.ActiveTab)
+	}
+
+	model, _ = model.Update(CommandMsg{Line: "/turf all"})
+	if m = model.(Model); m.turf != "" || len(m.BeadsTab.Beads) != 3 || len(m.AgentsTab.Agents) != 2 {
+		t.Fatalf("expected every turf shown again, got %q %v %v", m.turf, m.BeadsTab.Beads, m.AgentsTab.Agents)
+	}
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+)
+
+// DefaultTokenWarnThreshold is the output size that triggers an inline warning
+const DefaultTokenWarnThreshold = 20000
+
+// UsageMsg carries cumulative usage for the in-flight response. Done marks the
+// final update, after which the usage is added to the session totals.
+type UsageMsg struct {
+	Usage Usage
+	Done  bool
+}
+
+// handleUsage updates the sidebar live and warns once per response when its
+// output crosses the configured threshold
+func (m Model) handleUsage(msg UsageMsg) (tea.Model, tea.Cmd) {
+	if msg.Done {
+		m.Sidebar.CommitLive(msg.Usage)
+		m.responseWarned = false
+		return m, nil
+	}
+
+	m.Sidebar.SetLive(msg.Usage)
+
+	if m.TokenWarnThreshold > 0 && !m.responseWarned && msg.Usage.OutputTokens > m.TokenWarnThreshold {
+		m.responseWarned = true
+		warning := fmt.Sprintf("⚠ Response has used %s output tokens (threshold %s)",
+			formatTokens(msg.Usage.OutputTokens), formatTokens(m.TokenWarnThreshold))
+		m.Warnings = append(m.Warnings, warning)
+		m.Toasts.Push(Toast{Message: warning})
+	}
+
+	return m, nil
+}
+
+// endResponse drops the in-flight usage once a response is over, however it
+// ended, so the sidebar stops showing it streaming and the next response can
+// warn again
+func (m *Model) endResponse() {
+	m.Sidebar.SetLive(Usage{})
+	m.responseWarned = false
+}
+
+// ForwardUsage returns an agent usage callback that streams live updates into
+// the program via send (typically tea.Program.Send)
+func ForwardUsage(send func(tea.Msg)) agent.UsageCallback {
+	return func(usage agent.UsageInfo) {
+		send(UsageMsg{Usage: Usage{InputTokens: usage.InputTokens, OutputTokens: usage.OutputTokens}})
+	}
+}
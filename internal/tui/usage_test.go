@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gabe/mob/internal/agent"
+)
+
+func TestUsageUpdatesSidebarLive(t *testing.T) {
+	var model tea.Model = NewModel()
+	model, _ = model.Update(UsageMsg{Usage: Usage{InputTokens: 100, OutputTokens: 10}})
+
+	m := model.(Model)
+	if m.Sidebar.Live.OutputTokens != 10 {
+		t.Fatalf("expected live output 10, got %d", m.Sidebar.Live.OutputTokens)
+	}
+	if !strings.Contains(m.Sidebar.View(), "Streaming: 10 out") {
+		t.Fatalf("expected streaming usage in sidebar, got %q", m.Sidebar.View())
+	}
+
+	model, _ = model.Update(UsageMsg{Usage: Usage{InputTokens: 100, OutputTokens: 50, CostUSD: 0.01}, Done: true})
+	m = model.(Model)
+	if m.Sidebar.Live != (Usage{}) {
+		t.Fatalf("expected live usage cleared, got %+v", m.Sidebar.Live)
+	}
+	if m.Sidebar.Session.OutputTokens != 50 || m.Sidebar.Session.CostUSD != 0.01 {
+		t.Fatalf("expected session totals updated, got %+v", m.Sidebar.Session)
+	}
+}
+
+func TestUsageWarnsOncePerResponse(t *testing.T) {
+	m := NewModel()
+	m.TokenWarnThreshold = 100
+
+	var model tea.Model = m
+	model, _ = model.Update(UsageMsg{Usage: Usage{OutputTokens: 150}})
+	model, _ = model.Update(UsageMsg{Usage: Usage{OutputTokens: 300}})
+
+	m = model.(Model)
+	if len(m.Warnings) != 1 || m.Toasts.Len() != 1 {
+		t.Fatalf("expected a single warning, got %d warnings and %d toasts", len(m.Warnings), m.Toasts.Len())
+	}
+	if !strings.Contains(m.View(), "150 output tokens") {
+		t.Fatalf("expected inline warning in view, got %q", m.View())
+	}
+
+	// A new response can warn again
+	model, _ = model.Update(UsageMsg{Usage: Usage{OutputTokens: 300}, Done: true})
+	model, _ = model.Update(UsageMsg{Usage: Usage{OutputTokens: 200}})
+	if got := len(model.(Model).Warnings); got != 2 {
+		t.Fatalf("expected second warning for new response, got %d", got)
+	}
+}
+
+// oversizedAsk streams usage over the threshold ahead of a block, the way
+// the underboss's usage callback does, then ends the response with err
+func oversizedAsk(usage chan<- tea.Msg, err error) AskFunc {
+	return func(ctx context.Context, message string, callback agent.StreamCallback) (*agent.ChatResponse, error) {
+		usage <- UsageMsg{Usage: Usage{OutputTokens: 300}}
+		callback(agent.ChatContentBlock{Type: agent.ContentTypeText, Text: "a long answer"})
+		if err != nil {
+			return nil, err
+		}
+		return &agent.ChatResponse{OutputTokens: 300, Blocks: []agent.ChatContentBlock{{Type: agent.ContentTypeText, Text: "a long answer"}}}, nil
+	}
+}
+
+func TestUsageWarnsOncePerStreamedResponse(t *testing.T) {
+	m := NewModel()
+	m.TokenWarnThreshold = 100
+	usage := make(chan tea.Msg, 4)
+
+	var model tea.Model = m
+	send := func(err error) Model {
+		m := model.(Model)
+		m.ask = oversizedAsk(usage, err)
+		model = m
+		var msg tea.Msg = SendMsg{Text: "explain"}
+		for msg != nil {
+			// Usage reaches the program ahead of the block that follows it
+			for drained := false; !drained; {
+				select {
+				case u := <-usage:
+					model, _ = model.Update(u)
+				default:
+					drained = true
+				}
+			}
+			var cmd tea.Cmd
+			model, cmd = model.Update(msg)
+			msg = nil
+			if cmd != nil {
+				msg = cmd()
+			}
+		}
+		return model.(Model)
+	}
+
+	for i, err := range []error{nil, errors.New("claude exited"), nil} {
+		m := send(err)
+		if m.Streaming() {
+			t.Fatalf("response %d: expected the stream to finish", i+1)
+		}
+		if len(m.Warnings) != i+1 {
+			t.Fatalf("response %d: %d warnings, want one per oversized response", i+1, len(m.Warnings))
+		}
+		if m.Sidebar.Live != (Usage{}) || strings.Contains(m.Sidebar.View(), "Streaming:") {
+			t.Fatalf("response %d: live usage %+v left in the sidebar after it ended", i+1, m.Sidebar.Live)
+		}
+	}
+}
+
+func TestForwardUsage(t *testing.T) {
+	var got []tea.Msg
+	forward := ForwardUsage(func(msg tea.Msg) { got = append(got, msg) })
+	forward(agent.UsageInfo{InputTokens: 5, OutputTokens: 7})
+
+	if len(got) != 1 {
+		t.Fatalf("expected one message, got %d", len(got))
+	}
+	usage, ok := got[0].(UsageMsg)
+	if !ok || usage.Usage.InputTokens != 5 || usage.Usage.OutputTokens != 7 || usage.Done {
+		t.Fatalf("unexpected message: %+v", got[0])
+	}
+}
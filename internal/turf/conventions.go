@@ -0,0 +1,58 @@
+package turf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConventionsPath returns the location of a turf's conventions document
+func ConventionsPath(turfPath string) string {
+	return filepath.Join(turfPath, ".mob", "conventions.md")
+}
+
+// LoadConventions reads a turf's conventions document. A missing document
+// is not an error; it simply yields no conventions.
+func LoadConventions(turfPath string) (string, error) {
+	data, err := os.ReadFile(ConventionsPath(turfPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read conventions: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SaveConventions replaces a turf's conventions document
+func SaveConventions(turfPath, content string) error {
+	path := ConventionsPath(turfPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create conventions directory: %w", err)
+	}
+
+	content = strings.TrimSpace(content) + "\n"
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write conventions: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// AppendConventions adds a new entry to the end of a turf's conventions document
+func AppendConventions(turfPath, entry string) error {
+	existing, err := LoadConventions(turfPath)
+	if err != nil {
+		return err
+	}
+
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return fmt.Errorf("nothing to add")
+	}
+	if existing == "" {
+		return SaveConventions(turfPath, entry)
+	}
+	return SaveConventions(turfPath, existing+"\n\n"+entry)
+}
@@ -0,0 +1,45 @@
+package turf
+
+import (
+	"os"
+	"testing"
+)
+
+func TestConventions(t *testing.T) {
+	dir := t.TempDir()
+
+	got, err := LoadConventions(dir)
+	if err != nil || got != "" {
+		t.Fatalf("expected no conventions for new turf, got %q (%v)", got, err)
+	}
+
+	if err := AppendConventions(dir, "- Use table-driven tests"); err != nil {
+		t.Fatalf("failed to append: %v", err)
+	}
+	if err := AppendConventions(dir, "- Wrap errors with %w\n"); err != nil {
+		t.Fatalf("failed to append: %v", err)
+	}
+
+	got, err = LoadConventions(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "- Use table-driven tests\n\n- Wrap errors with %w" {
+		t.Errorf("unexpected conventions %q", got)
+	}
+
+	if err := SaveConventions(dir, "# Conventions\n"); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	data, err := os.ReadFile(ConventionsPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# Conventions\n" {
+		t.Errorf("expected replaced document, got %q", data)
+	}
+
+	if err := AppendConventions(dir, "  "); err == nil {
+		t.Error("expected error appending empty entry")
+	}
+}
@@ -6,7 +6,16 @@ import (
 	"path/filepath"
 
 	"github.com/BurntSushi/toml"
+	"github.com/gabe/mob/internal/errkind"
 	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/vcs"
+)
+
+var (
+	// ErrTurfNotFound is returned when no turf has the requested name
+	ErrTurfNotFound = errkind.New(errkind.NotFound, "turf not found")
+	// ErrTurfExists is returned when adding a turf whose name or path is taken
+	ErrTurfExists = errkind.New(errkind.Conflict, "turf already exists")
 )
 
 // Manager handles turf registration and lookup
@@ -52,10 +61,10 @@ func (m *Manager) Add(path, name, mainBranch string) error {
 	// Check for duplicate
 	for _, t := range m.config.Turfs {
 		if t.Name == name {
-			return fmt.Errorf("turf already exists: %s", name)
+			return fmt.Errorf("%w: %s", ErrTurfExists, name)
 		}
 		if t.Path == absPath {
-			return fmt.Errorf("path already registered as turf: %s", t.Name)
+			return fmt.Errorf("%w: path already registered as turf %s", ErrTurfExists, t.Name)
 		}
 	}
 
@@ -68,6 +77,29 @@ func (m *Manager) Add(path, name, mainBranch string) error {
 	return m.save()
 }
 
+// SetTestCommand sets the command run_tests runs for a turf; empty clears it
+func (m *Manager) SetTestCommand(name, command string) error {
+	t, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+	t.TestCommand = command
+	return m.save()
+}
+
+// SetVCS sets the version control system a turf uses; empty detects it
+func (m *Manager) SetVCS(name, kind string) error {
+	if !vcs.ValidKind(kind) {
+		return errkind.New(errkind.Invalid, fmt.Sprintf("unsupported vcs %q (expected git or jj)", kind))
+	}
+	t, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+	t.VCS = kind
+	return m.save()
+}
+
 // Remove unregisters a turf
 func (m *Manager) Remove(name string) error {
 	for i, t := range m.config.Turfs {
@@ -76,7 +108,7 @@ func (m *Manager) Remove(name string) error {
 			return m.save()
 		}
 	}
-	return fmt.Errorf("turf not found: %s", name)
+	return fmt.Errorf("%w: %s", ErrTurfNotFound, name)
 }
 
 // List returns all registered turfs
@@ -93,7 +125,7 @@ func (m *Manager) Get(name string) (*models.Turf, error) {
 			return &m.config.Turfs[i], nil
 		}
 	}
-	return nil, fmt.Errorf("turf not found: %s", name)
+	return nil, fmt.Errorf("%w: %s", ErrTurfNotFound, name)
 }
 
 func (m *Manager) save() error {
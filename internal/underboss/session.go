@@ -90,6 +90,7 @@ func (s *Session) sendMessage(ctx context.Context, message string) error {
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
+	s.underboss.recordUsage(resp)
 
 	// Display the response
 	fmt.Fprintf(s.output, "\n%s\n", resp.GetText())
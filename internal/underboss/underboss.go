@@ -4,15 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/gabe/mob/internal/agent"
+	"github.com/gabe/mob/internal/config"
 	"github.com/gabe/mob/internal/mcp"
+	"github.com/gabe/mob/internal/models"
 	"github.com/gabe/mob/internal/registry"
 	"github.com/gabe/mob/internal/soldati"
+	"github.com/gabe/mob/internal/storage"
 )
 
 var (
@@ -31,6 +35,10 @@ type Underboss struct {
 	mobDir        string
 	mcpConfigPath string
 	mcpEnabled    bool
+	confirm       bool   // hold mutating tool calls for the user's approval
+	resume        string // Claude session the next agent continues; empty = fresh
+	model         string // model to chat with; empty = Claude's default
+	onUsage       agent.UsageCallback
 	mu            sync.RWMutex
 }
 
@@ -62,6 +70,14 @@ func (u *Underboss) SetMCPEnabled(enabled bool) {
 	u.mcpEnabled = enabled
 }
 
+// SetConfirmMutations makes tool calls that change mob state wait for the
+// user's approval. It applies from the next Start.
+func (u *Underboss) SetConfirmMutations(enabled bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.confirm = enabled
+}
+
 // Start spawns or reconnects to the Underboss agent
 func (u *Underboss) Start(ctx context.Context) error {
 	u.mu.Lock()
@@ -89,14 +105,22 @@ func (u *Underboss) Start(ctx context.Context) error {
 
 	// Generate MCP config if enabled
 	var mcpConfigPath string
+	systemPrompt := DefaultSystemPrompt
 	if u.mcpEnabled {
 		var err error
-		mcpConfigPath, err = mcp.GenerateMCPConfig(workDir)
+		if u.confirm {
+			mcpConfigPath, err = mcp.GenerateConfirmMCPConfig(workDir)
+		} else {
+			mcpConfigPath, err = mcp.GenerateMCPConfig(workDir)
+		}
 		if err != nil {
 			// Log warning but continue without MCP
 			fmt.Fprintf(os.Stderr, "Warning: failed to generate MCP config: %v\n", err)
 		} else {
 			u.mcpConfigPath = mcpConfigPath
+			if u.confirm {
+				systemPrompt += ConfirmPrompt
+			}
 		}
 	}
 
@@ -106,13 +130,16 @@ func (u *Underboss) Start(ctx context.Context) error {
 		Name:         "underboss",
 		Turf:         "",
 		WorkDir:      workDir,
-		SystemPrompt: DefaultSystemPrompt,
+		SystemPrompt: systemPrompt,
 		MCPConfig:    mcpConfigPath,
+		SessionID:    u.resume,
+		Model:        u.model,
 	})
 	if err != nil {
 		return err
 	}
 
+	a.OnUsage = u.onUsage
 	u.agent = a
 	return nil
 }
@@ -141,6 +168,42 @@ func (u *Underboss) ClearSession() {
 		_ = u.agent.Kill()
 		u.agent = nil
 	}
+	u.resume = ""
+}
+
+// SetModel switches the model the underboss chats with, from its next message
+func (u *Underboss) SetModel(model string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.model = model
+	if u.agent != nil {
+		u.agent.Model = model
+	}
+}
+
+// SetOnUsage streams the token usage of each response as it arrives, such as
+// to the dashboard's sidebar
+func (u *Underboss) SetOnUsage(fn agent.UsageCallback) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.onUsage = fn
+	if u.agent != nil {
+		u.agent.OnUsage = fn
+	}
+}
+
+// ResumeSession continues an earlier Claude session, such as a chat saved by
+// the dashboard. An empty ID starts the next message in a fresh session.
+func (u *Underboss) ResumeSession(sessionID string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.resume = sessionID
+	if u.agent != nil {
+		u.agent.SessionID = sessionID
+	}
 }
 
 // IsRunning returns true if Underboss is active
@@ -196,10 +259,15 @@ func (u *Underboss) AskFull(ctx context.Context, question string) (*agent.ChatRe
 		return nil, ErrUnderbossNotRunning
 	}
 
-	return a.Chat(question)
+	resp, err := a.ChatStreamContext(ctx, question, nil)
+	if err == nil {
+		u.recordUsage(resp)
+	}
+	return resp, err
 }
 
 // AskStream sends a question with streaming callback for real-time updates.
+// Cancelling ctx kills the claude process and returns agent.ErrAborted.
 func (u *Underboss) AskStream(ctx context.Context, question string, callback agent.StreamCallback) (*agent.ChatResponse, error) {
 	// Start Underboss if not running
 	if !u.IsRunning() {
@@ -216,7 +284,25 @@ func (u *Underboss) AskStream(ctx context.Context, question string, callback age
 		return nil, ErrUnderbossNotRunning
 	}
 
-	return a.ChatStream(question, callback)
+	resp, err := a.ChatStreamContext(ctx, question, callback)
+	if err == nil {
+		u.recordUsage(resp)
+	}
+	return resp, err
+}
+
+// recordUsage records a chat call in the usage and spend ledgers
+func (u *Underboss) recordUsage(resp *agent.ChatResponse) {
+	entry := models.UsageEntry{
+		Agent:        "underboss",
+		Model:        resp.Model,
+		InputTokens:  resp.InputTokens,
+		OutputTokens: resp.OutputTokens,
+		CostUSD:      resp.TotalCost,
+	}
+	if err := storage.RecordCall(u.mobDir, entry); err != nil {
+		log.Printf("Warning: failed to record usage: %v", err)
+	}
 }
 
 // Tell sends an instruction to the Underboss and returns the acknowledgment.
@@ -288,7 +374,7 @@ func (u *Underboss) SpawnSoldati(name, turf, workDir string) (*agent.Agent, erro
 		Name:         name,
 		Turf:         turf,
 		WorkDir:      workDir,
-		SystemPrompt: agent.SoldatiSystemPrompt,
+		SystemPrompt: u.systemPrompt(agent.PromptSoldati, agent.PromptVars{Name: name, Turf: turf}),
 		Model:        "sonnet", // Default to sonnet for cost efficiency
 	})
 	if err != nil {
@@ -301,7 +387,7 @@ func (u *Underboss) SpawnSoldati(name, turf, workDir string) (*agent.Agent, erro
 		Type:      "soldati",
 		Name:      name,
 		Turf:      turf,
-		Status:    "active",
+		Status:    registry.StatusActive,
 		StartedAt: a.StartedAt,
 	}
 	if err := u.registry.Register(record); err != nil {
@@ -323,13 +409,19 @@ func (u *Underboss) SpawnAssociate(turf, task, workDir string) (*agent.Agent, er
 		}
 	}
 
+	prefix := registry.DefaultAssociatePrefix
+	if cfg, err := config.Load(filepath.Join(u.mobDir, "config.toml")); err == nil {
+		prefix = cfg.Associates.NamePrefix
+	}
+	name := u.registry.NewAssociateName(prefix)
+
 	// Spawn the agent with system prompt
 	a, err := u.spawner.SpawnWithOptions(agent.SpawnOptions{
 		Type:         agent.AgentTypeAssociate,
-		Name:         "", // Associates don't get names
+		Name:         name,
 		Turf:         turf,
 		WorkDir:      workDir,
-		SystemPrompt: agent.AssociateSystemPrompt,
+		SystemPrompt: u.systemPrompt(agent.PromptAssociate, agent.PromptVars{Name: name, Turf: turf}),
 		Model:        "sonnet", // Default to sonnet for cost efficiency
 	})
 	if err != nil {
@@ -340,9 +432,10 @@ func (u *Underboss) SpawnAssociate(turf, task, workDir string) (*agent.Agent, er
 	record := &registry.AgentRecord{
 		ID:        a.ID,
 		Type:      "associate",
+		Name:      name,
 		Turf:      turf,
 		Task:      task,
-		Status:    "active",
+		Status:    registry.StatusActive,
 		StartedAt: a.StartedAt,
 	}
 	if err := u.registry.Register(record); err != nil {
@@ -393,7 +486,7 @@ func (u *Underboss) KillAgent(idOrName string) error {
 }
 
 // UpdateAgentStatus updates an agent's status
-func (u *Underboss) UpdateAgentStatus(idOrName, status string) error {
+func (u *Underboss) UpdateAgentStatus(idOrName string, status registry.Status) error {
 	record, err := u.GetAgent(idOrName)
 	if err != nil {
 		return err
@@ -430,3 +523,13 @@ type AgentInfo struct {
 	StartedAt time.Time
 	LastPing  time.Time
 }
+
+// systemPrompt renders the named agent prompt from ~/mob/prompts, falling
+// back to the compiled-in one if the template is broken
+func (u *Underboss) systemPrompt(name string, vars agent.PromptVars) string {
+	prompt, err := agent.SystemPrompt(u.mobDir, name, vars)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return prompt
+}
@@ -0,0 +1,138 @@
+package vcs
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Limits on what a conflict report keeps per file, so a conflict in a
+// generated file doesn't produce a megabyte attachment
+const (
+	maxHunksPerFile = 20
+	maxHunkLines    = 200
+	maxDiffLines    = 400
+)
+
+// ConflictReport is the state of a conflicted merge, captured before the
+// merge is undone so whoever resolves it can see what clashed. Backends fill
+// in what they can: jj reports conflicted paths and hunks but no diffs.
+type ConflictReport struct {
+	Into   string // branch merged into
+	Branch string // branch being merged
+	Base   string // merge base commit
+	Ours   string // commit of Into
+	Theirs string // commit of Branch
+	Files  []ConflictFile
+}
+
+// ConflictFile is one conflicted path
+type ConflictFile struct {
+	Path       string
+	Hunks      []string // conflict regions in diff3 style: ours, ||||||| base, theirs
+	OursDiff   string   // base..ours for this path
+	TheirsDiff string   // base..theirs for this path
+}
+
+// HunkCount returns the number of conflicting hunks across all files
+func (r *ConflictReport) HunkCount() int {
+	n := 0
+	for _, f := range r.Files {
+		n += len(f.Hunks)
+	}
+	return n
+}
+
+// Summary is a one-paragraph description suitable for a block reason
+func (r *ConflictReport) Summary() string {
+	var parts []string
+	for _, f := range r.Files {
+		parts = append(parts, fmt.Sprintf("%s (%d hunk%s)", f.Path, len(f.Hunks), plural(len(f.Hunks))))
+	}
+	return fmt.Sprintf("%d conflicting hunk%s merging %s into %s at base %s: %s",
+		r.HunkCount(), plural(r.HunkCount()), r.Branch, r.Into, shortSHA(r.Base), strings.Join(parts, ", "))
+}
+
+// Markdown renders the full report: the conflicting hunks of each file and
+// both sides' diffs against the merge base
+func (r *ConflictReport) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Merge conflict: %s into %s\n\n", r.Branch, r.Into)
+	fmt.Fprintf(&b, "- Base:   %s\n- Ours:   %s (%s)\n- Theirs: %s (%s)\n\n", r.Base, r.Ours, r.Into, r.Theirs, r.Branch)
+	b.WriteString("Hunks are shown diff3-style: our side, then `|||||||` the base, then `=======` their side.\n")
+
+	for _, f := range r.Files {
+		fmt.Fprintf(&b, "\n## %s\n", f.Path)
+		for i, hunk := range f.Hunks {
+			fmt.Fprintf(&b, "\n### Hunk %d\n\n```\n%s\n```\n", i+1, hunk)
+		}
+		if f.OursDiff != "" {
+			fmt.Fprintf(&b, "\n### Base → %s\n\n```diff\n%s\n```\n", r.Into, f.OursDiff)
+		}
+		if f.TheirsDiff != "" {
+			fmt.Fprintf(&b, "\n### Base → %s\n\n```diff\n%s\n```\n", r.Branch, f.TheirsDiff)
+		}
+	}
+	return b.String()
+}
+
+// conflictHunks pulls the marker-delimited conflict regions out of a file.
+// Both git's diff3 style and jj's markers start and end with seven angle
+// brackets.
+func conflictHunks(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var hunks []string
+	var current []string
+	inHunk := false
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case !inHunk && strings.HasPrefix(line, "<<<<<<< "):
+			inHunk = true
+			current = []string{line}
+		case inHunk:
+			current = append(current, line)
+			if strings.HasPrefix(line, ">>>>>>> ") {
+				hunks = append(hunks, limitLines(strings.Join(current, "\n"), maxHunkLines))
+				inHunk = false
+				if len(hunks) == maxHunksPerFile {
+					return hunks
+				}
+			}
+		}
+	}
+	return hunks
+}
+
+func limitLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "\n") + fmt.Sprintf("\n... (%d more lines)", len(lines)-n)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	if sha == "" {
+		return "unknown"
+	}
+	return sha
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
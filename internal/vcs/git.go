@@ -0,0 +1,121 @@
+package vcs
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabe/mob/internal/git"
+)
+
+// gitRepo drives git: bead workspaces are git worktrees
+type gitRepo struct {
+	*git.WorktreeManager
+	path string
+}
+
+func (r *gitRepo) Kind() Kind { return KindGit }
+
+func (r *gitRepo) IsClean(dir string) (bool, error) {
+	return git.IsClean(dir)
+}
+
+func (r *gitRepo) Head(dir string) (string, error) {
+	return git.HeadCommit(dir)
+}
+
+func (r *gitRepo) RecentSubjects(n int) ([]string, error) {
+	return git.RecentCommitSubjects(r.path, n)
+}
+
+// Merge checks out the main branch and merges branch into it. A conflict is
+// captured and the merge aborted.
+func (r *gitRepo) Merge(branch string) *MergeResult {
+	mainBranch, err := r.GetMainBranch()
+	if err != nil {
+		return &MergeResult{Message: err.Error()}
+	}
+	result := &MergeResult{Into: mainBranch}
+
+	// Make sure we're on the main branch
+	cmd := exec.Command("git", "checkout", mainBranch)
+	cmd.Dir = r.path
+	if output, err := cmd.CombinedOutput(); err != nil {
+		result.Message = fmt.Sprintf("failed to checkout %s: %s", mainBranch, string(output))
+		return result
+	}
+
+	// Attempt the merge; diff3 markers keep the base in each conflict hunk
+	cmd = exec.Command("git", "-c", "merge.conflictStyle=diff3", "merge", branch, "--no-edit")
+	cmd.Dir = r.path
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		// Check if it's a conflict
+		if strings.Contains(string(output), "CONFLICT") || strings.Contains(string(output), "Merge conflict") {
+			result.Message = "merge conflict detected"
+			result.ConflictFiles = r.conflictFiles()
+			result.Conflict = r.captureConflicts(mainBranch, branch, result.ConflictFiles)
+
+			// Abort the merge to clean up
+			abortCmd := exec.Command("git", "merge", "--abort")
+			abortCmd.Dir = r.path
+			abortCmd.Run()
+
+			return result
+		}
+
+		result.Message = fmt.Sprintf("merge failed: %s", string(output))
+		return result
+	}
+
+	result.Success = true
+	result.Message = fmt.Sprintf("successfully merged %s into %s", branch, mainBranch)
+	return result
+}
+
+// conflictFiles returns the paths left unmerged by a conflicted merge
+func (r *gitRepo) conflictFiles() []string {
+	out := gitText(r.path, "diff", "--name-only", "--diff-filter=U")
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// captureConflicts records a conflicted merge in progress. It must run
+// before the merge is aborted.
+func (r *gitRepo) captureConflicts(into, branch string, files []string) *ConflictReport {
+	report := &ConflictReport{
+		Into:   into,
+		Branch: branch,
+		Ours:   gitText(r.path, "rev-parse", "HEAD"),
+		Theirs: gitText(r.path, "rev-parse", "MERGE_HEAD"),
+	}
+	report.Base = gitText(r.path, "merge-base", "HEAD", "MERGE_HEAD")
+
+	for _, path := range files {
+		f := ConflictFile{Path: path, Hunks: conflictHunks(filepath.Join(r.path, path))}
+		if report.Base != "" {
+			f.OursDiff = limitLines(gitText(r.path, "diff", report.Base, "HEAD", "--", path), maxDiffLines)
+			f.TheirsDiff = limitLines(gitText(r.path, "diff", report.Base, "MERGE_HEAD", "--", path), maxDiffLines)
+		}
+		report.Files = append(report.Files, f)
+	}
+	return report
+}
+
+// gitText runs git in repoPath and returns its trimmed output, "" on error
+func gitText(repoPath string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(out), "\n")
+}
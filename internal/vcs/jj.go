@@ -0,0 +1,338 @@
+package vcs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/git"
+)
+
+// jjRepo drives Jujutsu. Each bead gets a jj workspace named after the bead
+// under .mob-worktrees, with a mob/<bead> bookmark tracking its work.
+type jjRepo struct {
+	path string
+}
+
+// openJJ verifies path is a jj repository
+func openJJ(path string) (*jjRepo, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("path does not exist: %s", path)
+	}
+	if _, err := jjOutput(path, "root"); err != nil {
+		return nil, errkind.New(errkind.Invalid, "not a jj repository")
+	}
+	return &jjRepo{path: path}, nil
+}
+
+func (r *jjRepo) Kind() Kind { return KindJJ }
+
+// GetMainBranch returns the main or master bookmark
+func (r *jjRepo) GetMainBranch() (string, error) {
+	for _, name := range []string{"main", "master"} {
+		if _, err := jjOutput(r.path, "log", "--no-graph", "-r", name, "-T", "commit_id"); err == nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("failed to determine main branch: no main or master bookmark")
+}
+
+func (r *jjRepo) workspacePath(beadID string) string {
+	return filepath.Join(r.path, git.WorktreesDir, beadID)
+}
+
+// CreateWithOptions adds a workspace for the bead on top of the main bookmark
+func (r *jjRepo) CreateWithOptions(beadID string, opts CreateOptions) (*Workspace, error) {
+	if opts.Shallow {
+		return nil, errkind.New(errkind.Invalid, "shallow workspaces are not supported with jj; use sparse directories instead")
+	}
+
+	branch := git.BranchPrefix + beadID
+	wsPath := r.workspacePath(beadID)
+	if _, err := os.Stat(wsPath); !os.IsNotExist(err) {
+		return nil, ErrWorkspaceExists
+	}
+
+	mainBranch, err := r.GetMainBranch()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(wsPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+
+	if _, err := jjOutput(r.path, "workspace", "add", "--name", beadID, "-r", mainBranch, wsPath); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	if len(opts.Sparse) > 0 {
+		args := []string{"sparse", "set", "--clear"}
+		for _, dir := range opts.Sparse {
+			args = append(args, "--add", dir)
+		}
+		if _, err := jjOutput(wsPath, args...); err != nil {
+			r.Remove(beadID, false)
+			return nil, fmt.Errorf("failed to configure sparse checkout: %w", err)
+		}
+	}
+
+	if _, err := jjOutput(wsPath, "bookmark", "create", branch, "-r", "@"); err != nil {
+		r.Remove(beadID, false)
+		return nil, fmt.Errorf("failed to create bookmark: %w", err)
+	}
+
+	return &Workspace{
+		Path:      wsPath,
+		Branch:    branch,
+		BeadID:    beadID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Get returns the bead's workspace
+func (r *jjRepo) Get(beadID string) (*Workspace, error) {
+	workspaces, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces {
+		if ws.BeadID != beadID {
+			continue
+		}
+		if info, err := os.Stat(ws.Path); err == nil {
+			ws.CreatedAt = info.ModTime()
+		}
+		return ws, nil
+	}
+	return nil, ErrWorkspaceNotFound
+}
+
+// List returns the workspaces jj knows about that live under .mob-worktrees
+func (r *jjRepo) List() ([]*Workspace, error) {
+	out, err := jjOutput(r.path, "workspace", "list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var workspaces []*Workspace
+	for _, name := range parseWorkspaceNames(out) {
+		wsPath := r.workspacePath(name)
+		if _, err := os.Stat(wsPath); err != nil {
+			continue // The default workspace, or one created outside mob
+		}
+		workspaces = append(workspaces, &Workspace{
+			Path:   wsPath,
+			Branch: git.BranchPrefix + name,
+			BeadID: name,
+		})
+	}
+	return workspaces, nil
+}
+
+// parseWorkspaceNames reads the names from `jj workspace list`, whose lines
+// look like "name: <change> <commit> <description>"
+func parseWorkspaceNames(out string) []string {
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		name, _, ok := strings.Cut(line, ": ")
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// Remove forgets the bead's workspace, deletes its directory and optionally
+// its bookmark
+func (r *jjRepo) Remove(beadID string, deleteBranch bool) error {
+	wsPath := r.workspacePath(beadID)
+	if _, err := os.Stat(wsPath); os.IsNotExist(err) {
+		return ErrWorkspaceNotFound
+	}
+
+	if _, err := jjOutput(r.path, "workspace", "forget", beadID); err != nil {
+		return fmt.Errorf("failed to forget workspace: %w", err)
+	}
+	if err := os.RemoveAll(wsPath); err != nil {
+		return fmt.Errorf("failed to remove workspace: %w", err)
+	}
+
+	if deleteBranch {
+		if _, err := jjOutput(r.path, "bookmark", "delete", git.BranchPrefix+beadID); err != nil {
+			return fmt.Errorf("failed to delete bookmark: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *jjRepo) Usage() ([]*WorkspaceUsage, error) {
+	workspaces, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	return git.MeasureUsage(workspaces), nil
+}
+
+// ClearStaleLocks has nothing to do: jj's locks are released by the OS when
+// the process holding them dies
+func (r *jjRepo) ClearStaleLocks(olderThan time.Duration) ([]string, error) {
+	return nil, nil
+}
+
+// IsClean reports whether the workspace's working-copy change is empty.
+// jj snapshots edits into that change, so non-empty means unfinished work.
+func (r *jjRepo) IsClean(dir string) (bool, error) {
+	out, err := jjOutput(dir, "diff", "--summary", "-r", "@")
+	if err != nil {
+		return false, fmt.Errorf("failed to check workspace status: %w", err)
+	}
+	return out == "", nil
+}
+
+// Head returns the working-copy commit, which jj rewrites on every snapshot
+func (r *jjRepo) Head(dir string) (string, error) {
+	out, err := jjOutput(dir, "log", "--no-graph", "-r", "@", "-T", "commit_id")
+	if err != nil {
+		return "", fmt.Errorf("failed to read working-copy commit: %w", err)
+	}
+	return out, nil
+}
+
+func (r *jjRepo) RecentSubjects(n int) ([]string, error) {
+	mainBranch, err := r.GetMainBranch()
+	if err != nil {
+		return nil, err
+	}
+	out, err := jjOutput(r.path, "log", "--no-graph", "-r", "::"+mainBranch, "--limit", fmt.Sprint(n),
+		"-T", `description.first_line() ++ "\n"`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+
+	var subjects []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
+}
+
+// Merge creates a merge change of the main bookmark and branch and moves the
+// main bookmark to it. The branch's bookmark is first advanced to its
+// workspace's working copy so uncommitted edits are included. A conflicted
+// merge is captured and undone with `jj op restore`.
+func (r *jjRepo) Merge(branch string) *MergeResult {
+	mainBranch, err := r.GetMainBranch()
+	if err != nil {
+		return &MergeResult{Message: err.Error()}
+	}
+	result := &MergeResult{Into: mainBranch}
+
+	if beadID, ok := strings.CutPrefix(branch, git.BranchPrefix); ok {
+		if wsPath := r.workspacePath(beadID); dirExists(wsPath) {
+			if _, err := jjOutput(wsPath, "bookmark", "set", branch, "-r", "@", "--allow-backwards"); err != nil {
+				result.Message = fmt.Sprintf("failed to update %s: %v", branch, err)
+				return result
+			}
+		}
+	}
+
+	op, err := jjOutput(r.path, "op", "log", "--no-graph", "--limit", "1", "-T", "id")
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to read operation log: %v", err)
+		return result
+	}
+
+	msg := fmt.Sprintf("Merge %s into %s", branch, mainBranch)
+	if _, err := jjOutput(r.path, "new", mainBranch, branch, "-m", msg); err != nil {
+		result.Message = fmt.Sprintf("merge failed: %v", err)
+		return result
+	}
+
+	// `jj resolve --list` fails when there is nothing to resolve
+	if out, err := jjOutput(r.path, "resolve", "--list"); err == nil && out != "" {
+		result.Message = "merge conflict detected"
+		result.ConflictFiles = parseResolveList(out)
+		result.Conflict = r.captureConflicts(mainBranch, branch, result.ConflictFiles)
+
+		jjOutput(r.path, "op", "restore", op)
+		return result
+	}
+
+	if _, err := jjOutput(r.path, "bookmark", "set", mainBranch, "-r", "@"); err != nil {
+		jjOutput(r.path, "op", "restore", op)
+		result.Message = fmt.Sprintf("failed to move %s: %v", mainBranch, err)
+		return result
+	}
+	// Leave an empty change on top so later edits don't amend the merge
+	jjOutput(r.path, "new")
+
+	result.Success = true
+	result.Message = fmt.Sprintf("successfully merged %s into %s", branch, mainBranch)
+	return result
+}
+
+// parseResolveList reads the paths from `jj resolve --list`, whose lines are
+// a path followed by a description of the conflict
+func parseResolveList(out string) []string {
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			files = append(files, fields[0])
+		}
+	}
+	return files
+}
+
+// captureConflicts records the conflicted merge change checked out in the
+// repository. jj materializes conflicts as markers in the working copy, so
+// hunks come from the files; per-side diffs are left out.
+func (r *jjRepo) captureConflicts(into, branch string, files []string) *ConflictReport {
+	report := &ConflictReport{
+		Into:   into,
+		Branch: branch,
+		Ours:   jjCommit(r.path, into),
+		Theirs: jjCommit(r.path, branch),
+		Base:   jjCommit(r.path, fmt.Sprintf("heads(::%s & ::%s)", into, branch)),
+	}
+	for _, path := range files {
+		report.Files = append(report.Files, ConflictFile{Path: path, Hunks: conflictHunks(filepath.Join(r.path, path))})
+	}
+	return report
+}
+
+// jjCommit returns the commit id of a single-revision revset, "" on error
+func jjCommit(repoPath, revset string) string {
+	out, err := jjOutput(repoPath, "log", "--no-graph", "--limit", "1", "-r", revset, "-T", "commit_id")
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// jjOutput runs jj in dir and returns its trimmed standard output. jj reports
+// progress on stderr, which only surfaces in the error.
+func jjOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("jj", append([]string{"--no-pager", "--color", "never"}, args...)...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,82 @@
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseWorkspaceNames(t *testing.T) {
+	out := `default: qpvuntsm 230dd059 (empty) (no description set)
+bd-1: rlvkpnrz 6f1c2a9e Fix the parser
+bd-2: zsuskuln 1b2c3d4e (empty) (no description set)
+`
+	got := parseWorkspaceNames(out)
+	want := []string{"default", "bd-1", "bd-2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseResolveList(t *testing.T) {
+	out := `src/main.go    2-sided conflict
+README.md      2-sided conflict including 1 deletion`
+	got := parseResolveList(out)
+	want := []string{"src/main.go", "README.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// TestJJRepo_WorkspaceAndMerge runs against a real jj when one is installed
+func TestJJRepo_WorkspaceAndMerge(t *testing.T) {
+	if _, err := exec.LookPath("jj"); err != nil {
+		t.Skip("jj not installed")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("JJ_USER", "Test User")
+	t.Setenv("JJ_EMAIL", "test@test.com")
+	for _, args := range [][]string{
+		{"git", "init"},
+		{"describe", "-m", "Initial commit"},
+		{"bookmark", "create", "main", "-r", "@"},
+		{"new"},
+	} {
+		if _, err := jjOutput(dir, args...); err != nil {
+			t.Fatalf("jj %v: %v", args, err)
+		}
+	}
+
+	repo, err := Open(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Kind() != KindJJ {
+		t.Fatalf("expected jj, got %s", repo.Kind())
+	}
+
+	ws, err := repo.CreateWithOptions("bd-1", CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspaces, _ := repo.List(); len(workspaces) != 1 || workspaces[0].BeadID != "bd-1" {
+		t.Fatalf("expected one mob workspace, got %+v", workspaces)
+	}
+
+	if err := os.WriteFile(filepath.Join(ws.Path, "a.txt"), []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if clean, _ := repo.IsClean(ws.Path); clean {
+		t.Error("expected workspace with a new file to be dirty")
+	}
+
+	if result := repo.Merge(ws.Branch); !result.Success {
+		t.Fatalf("expected merge to succeed, got %+v", result)
+	}
+	if err := repo.Remove("bd-1", true); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package vcs puts the version control system behind the operations mob
+// needs — per-bead workspaces, merging finished work into the main line and
+// reading history — so turfs can use git or Jujutsu (jj).
+package vcs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/git"
+)
+
+// Kind names a supported version control system
+type Kind string
+
+const (
+	KindGit Kind = "git"
+	KindJJ  Kind = "jj"
+)
+
+// Workspace types are shared with the git package, whose layout every
+// backend follows: bead workspaces under WorktreesDir on mob/<bead> branches.
+type (
+	Workspace      = git.Worktree
+	WorkspaceUsage = git.WorktreeUsage
+	CreateOptions  = git.CreateOptions
+)
+
+// Errors every backend returns for missing or duplicate workspaces
+var (
+	ErrWorkspaceExists   = git.ErrWorktreeExists
+	ErrWorkspaceNotFound = git.ErrWorktreeNotFound
+)
+
+// Repo is one turf's repository
+type Repo interface {
+	// Kind reports which version control system backs the repository
+	Kind() Kind
+	// GetMainBranch returns the branch (or jj bookmark) work merges into
+	GetMainBranch() (string, error)
+
+	// CreateWithOptions creates a bead's workspace on a new mob/<bead> branch
+	CreateWithOptions(beadID string, opts CreateOptions) (*Workspace, error)
+	// Get returns a bead's workspace, or ErrWorkspaceNotFound
+	Get(beadID string) (*Workspace, error)
+	// List returns every mob workspace
+	List() ([]*Workspace, error)
+	// Remove deletes a bead's workspace and optionally its branch
+	Remove(beadID string, deleteBranch bool) error
+	// Usage returns every mob workspace with its size and last activity
+	Usage() ([]*WorkspaceUsage, error)
+	// ClearStaleLocks removes locks older than olderThan left in mob
+	// workspaces by killed processes and returns what it removed
+	ClearStaleLocks(olderThan time.Duration) ([]string, error)
+
+	// IsClean reports whether a workspace has no uncommitted changes
+	IsClean(dir string) (bool, error)
+	// Head identifies the state checked out in dir, changing whenever its
+	// contents are committed
+	Head(dir string) (string, error)
+	// RecentSubjects returns the subjects of the last n changes on the main line
+	RecentSubjects(n int) ([]string, error)
+
+	// Merge merges branch into the main branch. A conflicted merge is undone
+	// and reported; the repository is left as it was.
+	Merge(branch string) *MergeResult
+}
+
+// MergeResult is the outcome of Repo.Merge
+type MergeResult struct {
+	Success       bool
+	Into          string          // main branch merged into
+	Message       string          // what happened, including the tool's output on failure
+	ConflictFiles []string        // conflicted paths, empty unless the merge conflicted
+	Conflict      *ConflictReport // hunks and diffs of a conflicted merge
+}
+
+// Open returns the repository at path. kind is the turf's configured VCS;
+// empty detects it, preferring jj when the repository has a .jj directory
+// (colocated jj repositories also have .git).
+func Open(path string, kind string) (Repo, error) {
+	switch Resolve(path, kind) {
+	case KindGit:
+		mgr, err := git.NewWorktreeManager(path)
+		if err != nil {
+			return nil, err
+		}
+		return &gitRepo{WorktreeManager: mgr, path: path}, nil
+	case KindJJ:
+		repo, err := openJJ(path)
+		if err != nil {
+			return nil, err
+		}
+		return repo, nil
+	default:
+		return nil, errkind.New(errkind.Invalid, fmt.Sprintf("unsupported vcs %q (expected git or jj)", kind))
+	}
+}
+
+// Resolve returns the configured kind, or the detected one when kind is empty
+func Resolve(path string, kind string) Kind {
+	if kind == "" {
+		return Detect(path)
+	}
+	return Kind(kind)
+}
+
+// Detect guesses the version control system of the repository at path
+func Detect(path string) Kind {
+	if info, err := os.Stat(filepath.Join(path, ".jj")); err == nil && info.IsDir() {
+		return KindJJ
+	}
+	return KindGit
+}
+
+// ValidKind reports whether kind names a supported VCS; empty means auto-detect
+func ValidKind(kind string) bool {
+	switch Kind(kind) {
+	case "", KindGit, KindJJ:
+		return true
+	}
+	return false
+}
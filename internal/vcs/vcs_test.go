@@ -0,0 +1,117 @@
+package vcs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// initGitRepo creates a git repository with one commit on main
+func initGitRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "Initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	return dir
+}
+
+func TestDetectAndResolve(t *testing.T) {
+	dir := t.TempDir()
+	if got := Detect(dir); got != KindGit {
+		t.Errorf("expected git by default, got %s", got)
+	}
+	if err := os.Mkdir(filepath.Join(dir, ".jj"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := Detect(dir); got != KindJJ {
+		t.Errorf("expected jj with a .jj directory, got %s", got)
+	}
+	if got := Resolve(dir, "git"); got != KindGit {
+		t.Errorf("expected configured kind to win, got %s", got)
+	}
+}
+
+func TestValidKind(t *testing.T) {
+	for kind, want := range map[string]bool{"": true, "git": true, "jj": true, "hg": false} {
+		if got := ValidKind(kind); got != want {
+			t.Errorf("ValidKind(%q) = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestOpen_UnsupportedKind(t *testing.T) {
+	_, err := Open(t.TempDir(), "hg")
+	if !errors.Is(err, errkind.Invalid) {
+		t.Fatalf("expected an invalid error, got %v", err)
+	}
+}
+
+func TestGitRepo_WorkspacesAndMerge(t *testing.T) {
+	dir := initGitRepo(t)
+	repo, err := Open(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Kind() != KindGit {
+		t.Fatalf("expected git, got %s", repo.Kind())
+	}
+
+	ws, err := repo.CreateWithOptions("bd-1", CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateWithOptions("bd-1", CreateOptions{}); !errors.Is(err, ErrWorkspaceExists) {
+		t.Fatalf("expected ErrWorkspaceExists, got %v", err)
+	}
+
+	before, err := repo.Head(ws.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ws.Path, "a.txt"), []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if clean, _ := repo.IsClean(ws.Path); clean {
+		t.Error("expected workspace with a new file to be dirty")
+	}
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "Add a"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = ws.Path
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	if after, _ := repo.Head(ws.Path); after == before {
+		t.Error("expected head to change after a commit")
+	}
+
+	result := repo.Merge(ws.Branch)
+	if !result.Success || result.Into != "main" {
+		t.Fatalf("expected merge into main, got %+v", result)
+	}
+	subjects, err := repo.RecentSubjects(5)
+	if err != nil || len(subjects) == 0 || subjects[0] != "Add a" {
+		t.Errorf("expected merged commit in recent subjects, got %v (%v)", subjects, err)
+	}
+
+	if err := repo.Remove("bd-1", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Get("bd-1"); !errors.Is(err, ErrWorkspaceNotFound) {
+		t.Errorf("expected ErrWorkspaceNotFound after removal, got %v", err)
+	}
+}
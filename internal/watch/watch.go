@@ -0,0 +1,217 @@
+// Package watch notifies the humans watching a bead when its status changes
+// or someone comments on it, and anyone @-mentioned in a comment.
+//
+// Bead events are written by many processes (the CLI, MCP servers, the
+// daemon), so rather than hooking every writer the daemon periodically scans
+// bead history for events newer than a saved cursor and sends them out.
+package watch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/plugin"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// CursorPath returns where the dispatcher records how far it has got
+func CursorPath(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "watch.cursor")
+}
+
+// Channels builds each configured human's notification backends, keyed by
+// lowercased name. Humans with a bad channel are reported and skipped.
+func Channels(humans []config.HumanConfig, plugins []*plugin.Plugin) (map[string][]notify.Notifier, []error) {
+	channels := make(map[string][]notify.Notifier)
+	var errs []error
+
+	for _, h := range humans {
+		name := strings.ToLower(strings.TrimSpace(h.Name))
+		if name == "" {
+			errs = append(errs, fmt.Errorf("notifications.humans entry without a name"))
+			continue
+		}
+
+		var notifiers []notify.Notifier
+		var bad error
+		for _, ch := range h.Channels {
+			switch {
+			case ch == "terminal":
+				n, err := notify.NewTerminalNotifier()
+				if err != nil {
+					bad = err
+					break
+				}
+				notifiers = append(notifiers, n)
+			case strings.HasPrefix(ch, "plugin:"):
+				pluginName := strings.TrimPrefix(ch, "plugin:")
+				i := slices.IndexFunc(plugins, func(p *plugin.Plugin) bool { return p.Name == pluginName })
+				if i < 0 {
+					bad = fmt.Errorf("human %s: no plugin named %q", h.Name, pluginName)
+					break
+				}
+				notifiers = append(notifiers, plugin.NewNotifier(plugins[i]))
+			default:
+				bad = fmt.Errorf("human %s: unknown channel %q (expected terminal or plugin:<name>)", h.Name, ch)
+			}
+		}
+		if bad != nil {
+			errs = append(errs, bad)
+			continue
+		}
+		channels[name] = notifiers
+	}
+
+	return channels, errs
+}
+
+// Dispatcher sends watch and mention notifications for new bead events
+type Dispatcher struct {
+	store      *storage.BeadStore
+	channels   map[string][]notify.Notifier
+	cursorPath string
+	now        func() time.Time
+}
+
+// NewDispatcher creates a dispatcher delivering to the given channels
+func NewDispatcher(store *storage.BeadStore, cursorPath string, channels map[string][]notify.Notifier) *Dispatcher {
+	return &Dispatcher{
+		store:      store,
+		channels:   channels,
+		cursorPath: cursorPath,
+		now:        time.Now,
+	}
+}
+
+// pending is a bead event waiting to go out
+type pending struct {
+	bead  *models.Bead
+	event models.BeadEvent
+}
+
+// Dispatch notifies recipients of every status change and comment newer
+// than the cursor, then advances the cursor. The first run only sets the
+// cursor, so existing history is not replayed. Returns how many
+// notifications were sent.
+func (d *Dispatcher) Dispatch() (int, error) {
+	cursor, ok := d.readCursor()
+	if !ok {
+		return 0, d.writeCursor(d.now())
+	}
+
+	beads, err := d.store.List(storage.BeadFilter{})
+	if err != nil {
+		return 0, err
+	}
+
+	var events []pending
+	latest := cursor
+	for _, b := range beads {
+		for _, e := range b.History {
+			if !e.Timestamp.After(cursor) {
+				continue
+			}
+			if e.Timestamp.After(latest) {
+				latest = e.Timestamp
+			}
+			if e.Type == models.BeadEventTypeStatusChange || e.Type == models.BeadEventTypeComment {
+				events = append(events, pending{bead: b, event: e})
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].event.Timestamp.Before(events[j].event.Timestamp) })
+
+	sent := 0
+	var lastErr error
+	for _, p := range events {
+		for _, n := range d.notifications(p.bead, p.event) {
+			recipient := n.Data["recipient"].(string)
+			for _, ch := range d.channels[recipient] {
+				if err := ch.Notify(n); err != nil {
+					lastErr = err
+				}
+			}
+			sent++
+		}
+	}
+
+	if latest.After(cursor) {
+		if err := d.writeCursor(latest); err != nil {
+			return sent, err
+		}
+	}
+	return sent, lastErr
+}
+
+// notifications builds one notification per recipient of an event. The
+// person who caused the event is never notified of it.
+func (d *Dispatcher) notifications(b *models.Bead, e models.BeadEvent) []notify.Notification {
+	var mentioned []string
+	if e.Type == models.BeadEventTypeComment {
+		mentioned = models.Mentions(e.Comment)
+	}
+
+	recipients := append(slices.Clone(b.Watchers), mentioned...)
+	var out []notify.Notification
+	seen := make(map[string]bool)
+	for _, r := range recipients {
+		r = strings.ToLower(r)
+		if seen[r] || strings.EqualFold(r, e.Actor) {
+			continue
+		}
+		seen[r] = true
+		if _, ok := d.channels[r]; !ok {
+			continue
+		}
+
+		n := notify.Notification{
+			Type:      notify.NotificationTypeWatch,
+			Title:     fmt.Sprintf("%s: %s", b.ID, b.Title),
+			Timestamp: e.Timestamp,
+			Data: map[string]interface{}{
+				"bead_id":   b.ID,
+				"recipient": r,
+				"actor":     e.Actor,
+				"event":     string(e.Type),
+			},
+		}
+		switch {
+		case slices.Contains(mentioned, r):
+			n.Type = notify.NotificationTypeMention
+			n.Message = fmt.Sprintf("%s mentioned you: %s", e.Actor, e.Comment)
+		case e.Type == models.BeadEventTypeComment:
+			n.Message = fmt.Sprintf("%s commented: %s", e.Actor, e.Comment)
+		default:
+			n.Message = fmt.Sprintf("%s → %s", e.From, e.To)
+		}
+		out = append(out, n)
+	}
+	return out
+}
+
+func (d *Dispatcher) readCursor() (time.Time, bool) {
+	data, err := os.ReadFile(d.cursorPath)
+	if err != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func (d *Dispatcher) writeCursor(t time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(d.cursorPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(d.cursorPath, []byte(t.Format(time.RFC3339Nano)+"\n"), 0644)
+}
@@ -0,0 +1,98 @@
+package watch
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/notify"
+	"github.com/gabe/mob/internal/storage"
+)
+
+type fakeNotifier struct {
+	sent []notify.Notification
+}
+
+func (f *fakeNotifier) Notify(n notify.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func (f *fakeNotifier) Close() error { return nil }
+
+func TestDispatch(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewBeadStore(filepath.Join(dir, "beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, bob := &fakeNotifier{}, &fakeNotifier{}
+	d := NewDispatcher(store, CursorPath(dir), map[string][]notify.Notifier{
+		"alice": {alice},
+		"bob":   {bob},
+	})
+
+	bead, err := store.Create(&models.Bead{Title: "Fix login", Status: models.BeadStatusOpen, Type: models.BeadTypeBug})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddComment(bead.ID, "soldati-1", "history before the first run"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first run only records where to start from
+	if sent, err := d.Dispatch(); err != nil || sent != 0 {
+		t.Fatalf("first Dispatch = %d, %v; want 0, nil", sent, err)
+	}
+	time.Sleep(time.Millisecond)
+
+	if _, err := store.Watch(bead.ID, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddComment(bead.ID, "soldati-1", "@bob can you check the token refresh?"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddComment(bead.ID, "alice", "on it"); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := d.Dispatch()
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	// alice: watch for the mention comment (not her own). bob: mention, then
+	// watch for alice's comment since the mention made him a watcher.
+	if sent != 3 {
+		t.Errorf("sent %d notifications, want 3", sent)
+	}
+	if len(alice.sent) != 1 || alice.sent[0].Type != notify.NotificationTypeWatch {
+		t.Errorf("unexpected notifications for alice: %+v", alice.sent)
+	}
+	if len(bob.sent) != 2 || bob.sent[0].Type != notify.NotificationTypeMention || bob.sent[1].Type != notify.NotificationTypeWatch {
+		t.Errorf("unexpected notifications for bob: %+v", bob.sent)
+	}
+	if bob.sent[0].Data["bead_id"] != bead.ID {
+		t.Errorf("expected bead_id in data, got %v", bob.sent[0].Data)
+	}
+
+	if sent, err := d.Dispatch(); err != nil || sent != 0 {
+		t.Errorf("repeat Dispatch = %d, %v; want 0, nil", sent, err)
+	}
+}
+
+func TestChannels(t *testing.T) {
+	channels, errs := Channels([]config.HumanConfig{
+		{Name: "Alice"},
+		{Name: "bob", Channels: []string{"plugin:missing"}},
+		{Name: "carol", Channels: []string{"pager"}},
+	}, nil)
+
+	if _, ok := channels["alice"]; !ok {
+		t.Error("expected alice keyed by lowercased name")
+	}
+	if len(channels) != 1 || len(errs) != 2 {
+		t.Errorf("got %d channels and errors %v; want 1 channel, 2 errors", len(channels), errs)
+	}
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// githubPayload holds the fields mob reads from the GitHub events it handles
+type githubPayload struct {
+	Action     string `json:"action"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender githubUser `json:"sender"`
+	Issue  struct {
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+	} `json:"issue"`
+	Comment struct {
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+		Path    string `json:"path"`
+	} `json:"comment"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Head   struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	} `json:"pull_request"`
+	WorkflowRun struct {
+		Name       string `json:"name"`
+		Conclusion string `json:"conclusion"`
+		HeadBranch string `json:"head_branch"`
+		HTMLURL    string `json:"html_url"`
+	} `json:"workflow_run"`
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// VerifyGitHub checks a delivery's X-Hub-Signature-256 against the shared
+// secret. With no secret nothing can be verified, so every delivery fails.
+func VerifyGitHub(secret string, header http.Header, body []byte) error {
+	if secret == "" {
+		return errkind.New(errkind.Invalid, "no github_secret is configured")
+	}
+	sig, ok := strings.CutPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	if !ok {
+		return errkind.New(errkind.Invalid, "missing X-Hub-Signature-256")
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return errkind.New(errkind.Invalid, "malformed X-Hub-Signature-256")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errkind.New(errkind.Invalid, "signature does not match")
+	}
+	return nil
+}
+
+// ParseGitHub reads a GitHub delivery of the given X-GitHub-Event type. It
+// returns nil for events and actions mob does not act on.
+func ParseGitHub(eventType string, body []byte) (*Event, error) {
+	var p githubPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, errkind.Wrap(errkind.Invalid, fmt.Errorf("github %s payload: %w", eventType, err))
+	}
+	ev := &Event{Provider: "github", Repo: p.Repository.FullName, Author: p.Sender.Login}
+
+	switch {
+	case eventType == "issues" && p.Action == "opened":
+		ev.Kind = IssueOpened
+		ev.Title = p.Issue.Title
+		ev.Body = p.Issue.Body
+		ev.URL = p.Issue.HTMLURL
+	case eventType == "pull_request_review_comment" && p.Action == "created":
+		ev.Kind = ReviewComment
+		ev.Branch = p.PullRequest.Head.Ref
+		ev.Title = fmt.Sprintf("Review comment on #%d", p.PullRequest.Number)
+		if p.Comment.Path != "" {
+			ev.Title += " (" + p.Comment.Path + ")"
+		}
+		ev.Body = p.Comment.Body
+		ev.URL = p.Comment.HTMLURL
+	case eventType == "workflow_run" && p.Action == "completed" && p.WorkflowRun.Conclusion == "failure":
+		ev.Kind = CIFailed
+		ev.Branch = p.WorkflowRun.HeadBranch
+		ev.Title = fmt.Sprintf("CI failed: %s on %s", p.WorkflowRun.Name, p.WorkflowRun.HeadBranch)
+		ev.URL = p.WorkflowRun.HTMLURL
+	default:
+		return nil, nil
+	}
+	return ev, nil
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gabe/mob/internal/errkind"
+)
+
+// gitlabPayload holds the fields mob reads from the GitLab events it handles
+type gitlabPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		ID           int    `json:"id"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		Action       string `json:"action"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+		Ref          string `json:"ref"`
+		Status       string `json:"status"`
+		URL          string `json:"url"`
+	} `json:"object_attributes"`
+	MergeRequest struct {
+		IID          int    `json:"iid"`
+		SourceBranch string `json:"source_branch"`
+	} `json:"merge_request"`
+}
+
+// VerifyGitLab checks a delivery's X-Gitlab-Token against the configured
+// token. With no token nothing can be verified, so every delivery fails.
+func VerifyGitLab(token string, header http.Header) error {
+	if token == "" {
+		return errkind.New(errkind.Invalid, "no gitlab_token is configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(token)) != 1 {
+		return errkind.New(errkind.Invalid, "X-Gitlab-Token does not match")
+	}
+	return nil
+}
+
+// ParseGitLab reads a GitLab delivery. It returns nil for events and
+// actions mob does not act on.
+func ParseGitLab(body []byte) (*Event, error) {
+	var p gitlabPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, errkind.Wrap(errkind.Invalid, fmt.Errorf("gitlab payload: %w", err))
+	}
+	attrs := p.ObjectAttributes
+	ev := &Event{Provider: "gitlab", Repo: p.Project.PathWithNamespace, Author: p.User.Username}
+
+	switch {
+	case p.ObjectKind == "issue" && attrs.Action == "open":
+		ev.Kind = IssueOpened
+		ev.Title = attrs.Title
+		ev.Body = attrs.Description
+		ev.URL = attrs.URL
+	case p.ObjectKind == "note" && attrs.NoteableType == "MergeRequest":
+		ev.Kind = ReviewComment
+		ev.Branch = p.MergeRequest.SourceBranch
+		ev.Title = fmt.Sprintf("Review comment on !%d", p.MergeRequest.IID)
+		ev.Body = attrs.Note
+		ev.URL = attrs.URL
+	case p.ObjectKind == "pipeline" && attrs.Status == "failed":
+		ev.Kind = CIFailed
+		ev.Branch = attrs.Ref
+		ev.Title = fmt.Sprintf("CI failed: pipeline #%d on %s", attrs.ID, attrs.Ref)
+		if p.Project.WebURL != "" {
+			ev.URL = fmt.Sprintf("%s/-/pipelines/%d", p.Project.WebURL, attrs.ID)
+		}
+	default:
+		return nil, nil
+	}
+	return ev, nil
+}
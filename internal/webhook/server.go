@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/errkind"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// maxPayload caps the size of a delivery; GitHub sends at most 25MB but the
+// events mob reads are far smaller
+const maxPayload = 5 << 20
+
+// Handler receives webhook deliveries at /webhooks/github and
+// /webhooks/gitlab and applies them to the bead store
+type Handler struct {
+	store *storage.BeadStore
+	cfg   config.WebhooksConfig
+	logf  func(format string, args ...any)
+	mux   *http.ServeMux
+}
+
+// NewHandler returns a handler applying deliveries with cfg's rules. logf
+// reports each delivery's outcome; nil discards them.
+func NewHandler(store *storage.BeadStore, cfg config.WebhooksConfig, logf func(format string, args ...any)) *Handler {
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+	h := &Handler{store: store, cfg: cfg, logf: logf, mux: http.NewServeMux()}
+	h.mux.HandleFunc("POST /webhooks/github", h.github)
+	h.mux.HandleFunc("POST /webhooks/gitlab", h.gitlab)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) github(w http.ResponseWriter, r *http.Request) {
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	if err := h.verify(h.cfg.GitHubSecret, func() error { return VerifyGitHub(h.cfg.GitHubSecret, r.Header, body) }); err != nil {
+		h.logf("Webhook: rejected github delivery: %v", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	eventType := r.Header.Get("X-GitHub-Event")
+	if eventType == "ping" {
+		fmt.Fprintln(w, "pong")
+		return
+	}
+	ev, err := ParseGitHub(eventType, body)
+	h.apply(w, ev, err)
+}
+
+func (h *Handler) gitlab(w http.ResponseWriter, r *http.Request) {
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	if err := h.verify(h.cfg.GitLabToken, func() error { return VerifyGitLab(h.cfg.GitLabToken, r.Header) }); err != nil {
+		h.logf("Webhook: rejected gitlab delivery: %v", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	ev, err := ParseGitLab(body)
+	h.apply(w, ev, err)
+}
+
+// verify checks a delivery against its provider's secret. A provider with
+// no secret is only let through when allow_unsigned opts into it.
+func (h *Handler) verify(secret string, check func() error) error {
+	if secret == "" && h.cfg.AllowUnsigned {
+		return nil
+	}
+	return check()
+}
+
+// apply files a parsed event and reports the outcome to the sender
+func (h *Handler) apply(w http.ResponseWriter, ev *Event, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if ev == nil {
+		fmt.Fprintln(w, "ignored: event not handled")
+		return
+	}
+
+	outcome, err := Apply(h.store, h.cfg.Rules, ev)
+	if err != nil {
+		h.logf("Webhook: %s %s from %s failed: %v", ev.Provider, ev.Kind, ev.Repo, err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, errkind.Transient) {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	h.logf("Webhook: %s %s from %s: %s", ev.Provider, ev.Kind, ev.Repo, outcome)
+	if outcome.Created != nil {
+		w.WriteHeader(http.StatusCreated)
+	}
+	fmt.Fprintln(w, outcome)
+}
+
+// readBody reads a delivery, answering the request itself when it cannot
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayload))
+	if err != nil {
+		http.Error(w, "payload too large or unreadable", http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
+	return body, true
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/storage"
+)
+
+const githubIssue = `{
+  "action": "opened",
+  "repository": {"full_name": "acme/api"},
+  "sender": {"login": "dee"},
+  "issue": {"title": "Login fails", "body": "500 on /login", "html_url": "https://github.com/acme/api/issues/7"}
+}`
+
+const gitlabPipeline = `{
+  "object_kind": "pipeline",
+  "user": {"username": "dee"},
+  "project": {"path_with_namespace": "acme/api", "web_url": "https://gitlab.com/acme/api"},
+  "object_attributes": {"id": 42, "ref": "mob/bd-1234", "status": "failed"}
+}`
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandler_GitHub(t *testing.T) {
+	store := newStore(t)
+	cfg := config.WebhooksConfig{
+		GitHubSecret: "s3cret",
+		Rules:        []config.WebhookRule{{Event: "issue_opened", Turf: "api"}},
+	}
+	srv := httptest.NewServer(NewHandler(store, cfg, nil))
+	defer srv.Close()
+
+	post := func(signature string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/webhooks/github", strings.NewReader(githubIssue))
+		req.Header.Set("X-GitHub-Event", "issues")
+		req.Header.Set("X-Hub-Signature-256", signature)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := post(sign("wrong", githubIssue)); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected a bad signature rejected, got %d", resp.StatusCode)
+	}
+	if resp := post(sign("s3cret", githubIssue)); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected the issue filed, got %d", resp.StatusCode)
+	}
+
+	beads, _ := store.List(storage.BeadFilter{})
+	if len(beads) != 1 || beads[0].Title != "Login fails" || !strings.Contains(beads[0].Description, "issues/7") {
+		t.Fatalf("expected one bead for the issue, got %+v", beads)
+	}
+}
+
+func TestHandler_RejectsProvidersWithoutASecret(t *testing.T) {
+	store := newStore(t)
+	cfg := config.WebhooksConfig{
+		GitLabToken: "tok",
+		Rules:       []config.WebhookRule{{Event: "issue_opened", Turf: "api"}},
+	}
+	post := func(cfg config.WebhooksConfig) int {
+		srv := httptest.NewServer(NewHandler(store, cfg, nil))
+		defer srv.Close()
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/webhooks/github", strings.NewReader(githubIssue))
+		req.Header.Set("X-GitHub-Event", "issues")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Only GitLab is configured, so GitHub deliveries can't be verified
+	if status := post(cfg); status != http.StatusUnauthorized {
+		t.Fatalf("expected an unsigned github delivery rejected, got %d", status)
+	}
+	if beads, _ := store.List(storage.BeadFilter{}); len(beads) != 0 {
+		t.Fatalf("expected nothing filed, got %+v", beads)
+	}
+
+	cfg.AllowUnsigned = true
+	if status := post(cfg); status != http.StatusCreated {
+		t.Fatalf("expected allow_unsigned to accept the delivery, got %d", status)
+	}
+}
+
+func TestParseGitLab_Pipeline(t *testing.T) {
+	ev, err := ParseGitLab([]byte(gitlabPipeline))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev == nil || ev.Kind != CIFailed || ev.Branch != "mob/bd-1234" || ev.URL != "https://gitlab.com/acme/api/-/pipelines/42" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+
+	passed := strings.Replace(gitlabPipeline, `"failed"`, `"success"`, 1)
+	if ev, err := ParseGitLab([]byte(passed)); err != nil || ev != nil {
+		t.Errorf("expected a passing pipeline ignored, got %+v, %v", ev, err)
+	}
+}
+
+func TestVerifyGitLab(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Gitlab-Token", "tok")
+	if err := VerifyGitLab("tok", header); err != nil {
+		t.Errorf("expected the token accepted: %v", err)
+	}
+	if err := VerifyGitLab("other", header); err == nil {
+		t.Error("expected a mismatched token rejected")
+	}
+	if err := VerifyGitLab("", header); err == nil {
+		t.Error("expected no configured token to reject the delivery")
+	}
+}
@@ -0,0 +1,180 @@
+// Package webhook turns GitHub and GitLab webhook deliveries into beads and
+// bead comments, following the [[webhooks.rules]] in config.
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+// Kind is the kind of external event a delivery describes
+type Kind string
+
+const (
+	IssueOpened   Kind = "issue_opened"   // a new issue
+	ReviewComment Kind = "review_comment" // a comment on a pull or merge request
+	CIFailed      Kind = "ci_failed"      // a workflow run or pipeline failed
+)
+
+// Event is a webhook delivery reduced to what mob needs
+type Event struct {
+	Kind     Kind
+	Provider string // github or gitlab
+	Repo     string // owner/name
+	Branch   string // head branch of the pull request or CI run
+	Title    string
+	Body     string
+	URL      string // the issue, comment or run on the hosting site
+	Author   string
+}
+
+// Outcome is what applying an event did
+type Outcome struct {
+	Created   *models.Bead // bead filed for the event
+	Commented *models.Bead // bead the event was added to as a comment
+	Ignored   string       // why nothing happened
+}
+
+func (o Outcome) String() string {
+	switch {
+	case o.Created != nil:
+		return fmt.Sprintf("created %s", o.Created.ID)
+	case o.Commented != nil:
+		return fmt.Sprintf("commented on %s", o.Commented.ID)
+	default:
+		return "ignored: " + o.Ignored
+	}
+}
+
+// Match returns the first rule for the event's kind and repository
+func Match(rules []config.WebhookRule, ev *Event) (config.WebhookRule, bool) {
+	for _, r := range rules {
+		if Kind(r.Event) != ev.Kind {
+			continue
+		}
+		if r.Repo != "" && !strings.EqualFold(r.Repo, ev.Repo) {
+			continue
+		}
+		return r, true
+	}
+	return config.WebhookRule{}, false
+}
+
+// Apply puts an event on the board. New issues become beads. Review comments
+// are added to the open bead working on the pull request's branch. A CI
+// failure is added to the bead for its branch when there is one and filed
+// as a new bead otherwise. A delivery already filed, matched by its URL,
+// is not filed again.
+func Apply(store *storage.BeadStore, rules []config.WebhookRule, ev *Event) (Outcome, error) {
+	rule, ok := Match(rules, ev)
+	if !ok {
+		return Outcome{Ignored: fmt.Sprintf("no rule for %s on %s", ev.Kind, ev.Repo)}, nil
+	}
+
+	beads, err := store.List(storage.BeadFilter{})
+	if err != nil {
+		return Outcome{}, err
+	}
+	for _, b := range beads {
+		if ev.URL != "" && b.Source == ev.URL {
+			return Outcome{Ignored: fmt.Sprintf("already filed as %s", b.ID)}, nil
+		}
+	}
+
+	if ev.Kind != IssueOpened {
+		if b := beadForBranch(beads, ev.Branch, rule.Turf); b != nil {
+			if err := store.AddComment(b.ID, actor(ev), commentText(ev)); err != nil {
+				return Outcome{}, err
+			}
+			return Outcome{Commented: b}, nil
+		}
+		if ev.Kind == ReviewComment {
+			return Outcome{Ignored: fmt.Sprintf("no open bead for branch %q", ev.Branch)}, nil
+		}
+	}
+
+	if rule.Turf == "" {
+		return Outcome{Ignored: fmt.Sprintf("the %s rule for %s has no turf to file beads in", rule.Event, ev.Repo)}, nil
+	}
+	bead, err := store.Create(newBead(rule, ev))
+	if err != nil {
+		return Outcome{}, err
+	}
+	return Outcome{Created: bead}, nil
+}
+
+// beadForBranch returns the unclosed bead working on branch, within turf
+// when one is given
+func beadForBranch(beads []*models.Bead, branch, turf string) *models.Bead {
+	if branch == "" {
+		return nil
+	}
+	for _, b := range beads {
+		if b.Branch != branch || b.Status == models.BeadStatusClosed {
+			continue
+		}
+		if turf != "" && b.Turf != turf {
+			continue
+		}
+		return b
+	}
+	return nil
+}
+
+// newBead builds the bead filed for an event
+func newBead(rule config.WebhookRule, ev *Event) *models.Bead {
+	beadType := models.BeadType(rule.Type)
+	if beadType == "" {
+		beadType = models.BeadTypeTask
+		if ev.Kind == CIFailed {
+			beadType = models.BeadTypeBug
+		}
+	}
+	priority := 2
+	if rule.Priority != nil {
+		priority = *rule.Priority
+	}
+
+	description := ev.Body
+	if ev.URL != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "From " + ev.URL
+	}
+
+	return &models.Bead{
+		Title:       ev.Title,
+		Description: description,
+		Status:      models.BeadStatusOpen,
+		Priority:    priority,
+		Type:        beadType,
+		Turf:        rule.Turf,
+		CreatedBy:   actor(ev),
+		Source:      ev.URL,
+	}
+}
+
+// commentText is the bead comment recording an event
+func commentText(ev *Event) string {
+	text := ev.Title
+	if ev.Body != "" {
+		text += ":\n" + ev.Body
+	}
+	if ev.URL != "" {
+		text += "\n" + ev.URL
+	}
+	return text
+}
+
+// actor names who an event's beads and comments are attributed to
+func actor(ev *Event) string {
+	if ev.Author == "" {
+		return ev.Provider
+	}
+	return ev.Provider + ":" + ev.Author
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/gabe/mob/internal/config"
+	"github.com/gabe/mob/internal/models"
+	"github.com/gabe/mob/internal/storage"
+)
+
+func newStore(t *testing.T) *storage.BeadStore {
+	t.Helper()
+	store, err := storage.NewBeadStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestApply(t *testing.T) {
+	store := newStore(t)
+	one := 1
+	rules := []config.WebhookRule{
+		{Event: "issue_opened", Repo: "acme/api", Turf: "api", Priority: &one},
+		{Event: "review_comment", Turf: "api"},
+		{Event: "ci_failed", Turf: "api"},
+	}
+
+	issue := &Event{Kind: IssueOpened, Provider: "github", Repo: "acme/api", Title: "Login fails", Body: "500 on /login", URL: "https://github.com/acme/api/issues/7", Author: "dee"}
+	out, err := Apply(store, rules, issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Created == nil || out.Created.Turf != "api" || out.Created.Priority != 1 || out.Created.Type != models.BeadTypeTask {
+		t.Fatalf("expected a P1 task in api, got %+v", out)
+	}
+	if out.Created.Source != issue.URL || out.Created.CreatedBy != "github:dee" {
+		t.Errorf("expected source and author recorded, got %q by %q", out.Created.Source, out.Created.CreatedBy)
+	}
+
+	// Redelivery of the same issue files nothing new
+	if out, _ := Apply(store, rules, issue); out.Created != nil || out.Ignored == "" {
+		t.Errorf("expected the redelivery ignored, got %+v", out)
+	}
+
+	// Issues from a repo without a rule are ignored
+	other := &Event{Kind: IssueOpened, Provider: "github", Repo: "acme/web", Title: "x", URL: "https://github.com/acme/web/issues/1"}
+	if out, _ := Apply(store, rules, other); out.Created != nil {
+		t.Errorf("expected no bead for an unmapped repo, got %+v", out)
+	}
+
+	// A review comment lands on the bead working on the PR's branch
+	bead := out.Created
+	comment := &Event{Kind: ReviewComment, Provider: "github", Repo: "acme/api", Branch: bead.Branch, Title: "Review comment on #9", Body: "nit: rename", URL: "https://github.com/acme/api/pull/9#r1"}
+	out, err = Apply(store, rules, comment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Commented == nil || out.Commented.ID != bead.ID {
+		t.Fatalf("expected a comment on %s, got %+v", bead.ID, out)
+	}
+	got, _ := store.Get(bead.ID)
+	if last := got.History[len(got.History)-1]; last.Type != models.BeadEventTypeComment || last.Actor != "github" {
+		t.Errorf("expected a comment event from github, got %+v", last)
+	}
+
+	// A CI failure on a branch no bead owns files a bug
+	ci := &Event{Kind: CIFailed, Provider: "gitlab", Repo: "acme/api", Branch: "main", Title: "CI failed: pipeline #3 on main", URL: "https://gitlab.com/acme/api/-/pipelines/3"}
+	out, err = Apply(store, rules, ci)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Created == nil || out.Created.Type != models.BeadTypeBug || out.Created.Priority != 2 {
+		t.Fatalf("expected a P2 bug for the failure, got %+v", out)
+	}
+}
+
+func TestApply_ReviewCommentWithoutBead(t *testing.T) {
+	store := newStore(t)
+	rules := []config.WebhookRule{{Event: "review_comment", Turf: "api"}}
+	out, err := Apply(store, rules, &Event{Kind: ReviewComment, Repo: "acme/api", Branch: "feature/x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Created != nil || out.Commented != nil {
+		t.Errorf("expected a comment on an unknown branch to be ignored, got %+v", out)
+	}
+}
@@ -0,0 +1,85 @@
+// Package workhours keeps the overrides that let the daemon assign and nudge
+// work outside the configured working hours, set by `mob schedule` and read
+// by the daemon on each patrol.
+package workhours
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AllTurfs keys an override that applies to every turf
+const AllTurfs = "*"
+
+// Overrides maps a turf name, or AllTurfs, to when its override ends
+type Overrides map[string]time.Time
+
+// Path returns where overrides are kept
+func Path(mobDir string) string {
+	return filepath.Join(mobDir, ".mob", "schedule-override.json")
+}
+
+// Active reports whether work in turf may ignore its schedule at now
+func (o Overrides) Active(turf string, now time.Time) bool {
+	return now.Before(o[turf]) || now.Before(o[AllTurfs])
+}
+
+// Any reports whether any override is still running at now
+func (o Overrides) Any(now time.Time) bool {
+	for _, until := range o {
+		if now.Before(until) {
+			return true
+		}
+	}
+	return false
+}
+
+// Prune drops overrides that ended before now
+func (o Overrides) Prune(now time.Time) {
+	for turf, until := range o {
+		if !now.Before(until) {
+			delete(o, turf)
+		}
+	}
+}
+
+// Load reads the overrides. A missing file means there are none.
+func Load(path string) (Overrides, error) {
+	o := Overrides{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return o, nil
+	}
+	if err != nil {
+		return o, fmt.Errorf("failed to read schedule overrides: %w", err)
+	}
+	if err := json.Unmarshal(data, &o); err != nil {
+		return Overrides{}, fmt.Errorf("failed to parse schedule overrides: %w", err)
+	}
+	return o, nil
+}
+
+// Save writes the overrides, removing the file when there are none
+func Save(path string, o Overrides) error {
+	if len(o) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule overrides: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write schedule overrides: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,54 @@
+package workhours
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOverridesActive(t *testing.T) {
+	now := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC)
+	o := Overrides{"batch": now.Add(time.Hour), "web": now.Add(-time.Minute)}
+
+	if !o.Active("batch", now) {
+		t.Error("batch override should be running")
+	}
+	if o.Active("web", now) || o.Active("api", now) {
+		t.Error("expired and missing overrides should not apply")
+	}
+
+	o[AllTurfs] = now.Add(time.Hour)
+	if !o.Active("api", now) {
+		t.Error("an all-turfs override should apply to every turf")
+	}
+
+	o.Prune(now)
+	if _, ok := o["web"]; ok || len(o) != 2 {
+		t.Errorf("after prune = %v", o)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mob", "schedule-override.json")
+	if o, err := Load(path); err != nil || len(o) != 0 {
+		t.Fatalf("missing file = %v, %v", o, err)
+	}
+
+	until := time.Date(2026, 1, 14, 14, 0, 0, 0, time.UTC)
+	if err := Save(path, Overrides{"batch": until}); err != nil {
+		t.Fatal(err)
+	}
+	o, err := Load(path)
+	if err != nil || !o["batch"].Equal(until) {
+		t.Fatalf("round trip = %v, %v", o, err)
+	}
+
+	// Saving none clears the file
+	if err := Save(path, Overrides{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected the file to be removed")
+	}
+}